@@ -0,0 +1,98 @@
+package main
+
+import (
+	"sync"
+
+	"github.com/bio-routing/bio-rd/cmd/bio-rd/config"
+	"github.com/bio-routing/bio-rd/protocols/aggregate"
+	"github.com/bio-routing/bio-rd/routingtable"
+	"github.com/bio-routing/bio-rd/routingtable/vrf"
+)
+
+// aggregateConfigurator manages the aggregate routes of the VRFs
+type aggregateConfigurator struct {
+	mu       sync.Mutex
+	managers map[string]*aggregateManager
+}
+
+type aggregateManager struct {
+	m        *aggregate.Manager
+	vrf      *vrf.VRF
+	localASN uint32
+	routerID uint32
+}
+
+func newAggregateConfigurator() *aggregateConfigurator {
+	return &aggregateConfigurator{
+		managers: make(map[string]*aggregateManager),
+	}
+}
+
+func (ac *aggregateConfigurator) configure(v *vrf.VRF, aggregates []config.Aggregate, localASN uint32, routerID uint32) {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+
+	m := ac.managers[v.Name()]
+	if m != nil && (m.vrf != v || m.localASN != localASN || m.routerID != routerID || len(aggregates) == 0) {
+		ac.remove(m)
+		m = nil
+	}
+
+	if len(aggregates) == 0 {
+		return
+	}
+
+	if m == nil {
+		m = &aggregateManager{
+			m:        aggregate.New(v.IPv4UnicastRIB(), v.IPv6UnicastRIB(), localASN, routerID),
+			vrf:      v,
+			localASN: localASN,
+			routerID: routerID,
+		}
+		m.m.Start()
+		ac.managers[v.Name()] = m
+	}
+
+	aggs := make([]aggregate.Aggregate, len(aggregates))
+	for i, a := range aggregates {
+		aggs[i] = aggregate.Aggregate{
+			Prefix:      a.Pfx,
+			ASSet:       a.ASSet,
+			SummaryOnly: a.SummaryOnly,
+			FilterChain: a.FilterChain,
+		}
+	}
+
+	m.m.Configure(aggs)
+}
+
+// suppressor returns the route suppressor of a VRF or nil if no aggregates are configured
+func (ac *aggregateConfigurator) suppressor(v *vrf.VRF) routingtable.RouteSuppressor {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+
+	m := ac.managers[v.Name()]
+	if m == nil || m.vrf != v {
+		return nil
+	}
+
+	return m.m
+}
+
+// remove withdraws the aggregate routes of a VRF
+func (ac *aggregateConfigurator) remove(m *aggregateManager) {
+	m.m.Stop()
+	delete(ac.managers, m.vrf.Name())
+}
+
+// removeUnconfigured withdraws the aggregate routes of all VRFs but the given ones
+func (ac *aggregateConfigurator) removeUnconfigured(vrfNames map[string]struct{}) {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+
+	for name, m := range ac.managers {
+		if _, found := vrfNames[name]; !found {
+			ac.remove(m)
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/bio-routing/bio-rd/cmd/bio-rd/config"
 	bgpserver "github.com/bio-routing/bio-rd/protocols/bgp/server"
 	"github.com/bio-routing/bio-rd/routingtable"
+	"github.com/bio-routing/bio-rd/routingtable/dampening"
 	"github.com/bio-routing/bio-rd/routingtable/vrf"
 )
 
@@ -27,13 +28,63 @@ func (c *bgpConfigurator) configure(cfg *config.BGP) error {
 				return fmt.Errorf("could not configure session for neighbor %s: %w", bn.PeerAddress, err)
 			}
 		}
+
+		if bg.DynamicNeighbors != nil {
+			err := c.configureDynamicNeighbors(bg.DynamicNeighbors, bg)
+			if err != nil {
+				return fmt.Errorf("could not configure dynamic neighbors %s: %w", bg.DynamicNeighbors.Prefix, err)
+			}
+		}
 	}
 
 	c.deconfigureRemovedSessions(cfg)
+	c.deconfigureRemovedDynamicNeighbors(cfg)
 
 	return nil
 }
 
+func (c *bgpConfigurator) configureDynamicNeighbors(dn *config.BGPDynamicNeighbors, bg *config.BGPGroup) error {
+	v, err := c.determineVRF(dn.Neighbor, bg)
+	if err != nil {
+		return fmt.Errorf("could not determine VRF: %w", err)
+	}
+
+	err = c.srv.ConfigureDynamicNeighbors(bgpserver.DynamicNeighborConfig{
+		Prefix:     dn.PrefixNet,
+		PeerASNs:   dn.PeerASRanges,
+		MaxPeers:   dn.Max,
+		PeerConfig: *c.newPeerConfig(dn.Neighbor, bg, v),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to add BGP dynamic neighbors: %w", err)
+	}
+
+	return nil
+}
+
+func (c *bgpConfigurator) deconfigureRemovedDynamicNeighbors(cfg *config.BGP) {
+	for _, dn := range c.srv.GetDynamicNeighbors() {
+		if !c.dynamicNeighborsExistInConfig(cfg, dn) {
+			c.srv.DisposeDynamicNeighbors(dn.PeerConfig.VRF, dn.Prefix)
+		}
+	}
+}
+
+func (c *bgpConfigurator) dynamicNeighborsExistInConfig(cfg *config.BGP, dn bgpserver.DynamicNeighborConfig) bool {
+	for _, bg := range cfg.Groups {
+		if bg.DynamicNeighbors == nil {
+			continue
+		}
+
+		v, _ := c.determineVRF(bg.DynamicNeighbors.Neighbor, bg)
+		if bg.DynamicNeighbors.PrefixNet == dn.Prefix && dn.PeerConfig.VRF == v {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (c *bgpConfigurator) configureSession(bn *config.BGPNeighbor, bg *config.BGPGroup) error {
 	v, err := c.determineVRF(bn, bg)
 	if err != nil {
@@ -96,6 +147,21 @@ func (c *bgpConfigurator) reconfigureModifiedSession(bn *config.BGPNeighbor, bg
 		return fmt.Errorf("could not replace export filter: %w", err)
 	}
 
+	err = c.srv.ReplacePrefixLimits(newCfg.VRF, bn.PeerAddressIP, newCfg)
+	if err != nil {
+		return fmt.Errorf("could not replace prefix limits: %w", err)
+	}
+
+	err = c.srv.ReplaceNextHopTracker(newCfg.VRF, bn.PeerAddressIP, newCfg.NextHopTracker)
+	if err != nil {
+		return fmt.Errorf("could not replace next hop tracker: %w", err)
+	}
+
+	err = c.srv.ReplaceDampening(newCfg.VRF, bn.PeerAddressIP, newCfg)
+	if err != nil {
+		return fmt.Errorf("could not replace dampening: %w", err)
+	}
+
 	return nil
 }
 
@@ -149,11 +215,38 @@ func (c *bgpConfigurator) newPeerConfig(bn *config.BGPNeighbor, bg *config.BGPGr
 
 	c.configureIPv4(bn, bg, p)
 	c.configureIPv6(bn, bg, p)
+	c.configureVPN(bn, bg, p)
+	c.configureFlowSpec(bn, bg, p)
 
 	if bn.Passive != nil {
 		p.Passive = *bn.Passive
 	}
 
+	if bn.GracefulRestart != nil {
+		p.GracefulRestart = bgpserver.GracefulRestartConfig{
+			Enabled:        bn.GracefulRestart.Enabled,
+			RestartTime:    bn.GracefulRestart.RestartTimeDuration,
+			StalePathsTime: bn.GracefulRestart.StaleRoutesTimeDuration,
+		}
+	}
+
+	if bn.BFD != nil {
+		p.BFD = &bgpserver.BFDConfig{
+			DesiredMinTxInterval:  bn.BFD.MinIntervalDuration,
+			RequiredMinRxInterval: bn.BFD.MinIntervalDuration,
+			DetectMultiplier:      bn.BFD.Multiplier,
+			Multihop:              bn.BFD.Multihop,
+		}
+	}
+
+	p.PeerRole = bn.LocalRoleID
+	if bn.ASPARejectInvalid != nil {
+		p.ASPARejectInvalid = *bn.ASPARejectInvalid
+	}
+
+	p.NextHopTracker = nextHopCfgtr.tracker(vrf)
+	p.RouteSuppressor = aggregateCfgtr.suppressor(vrf)
+
 	if bn.RouteServerClient != nil {
 		p.RouteServerClient = *bn.RouteServerClient
 	}
@@ -193,6 +286,30 @@ func (c *bgpConfigurator) configureIPv6(bn *config.BGPNeighbor, bg *config.BGPGr
 	}
 }
 
+func (c *bgpConfigurator) configureVPN(bn *config.BGPNeighbor, bg *config.BGPGroup, p *bgpserver.PeerConfig) {
+	if bn.VPNv4 != nil {
+		p.VPNv4 = c.newAFIConfig(bn, bg)
+		c.configurePrefixLimit(bn.VPNv4.PrefixLimit, p.VPNv4)
+	}
+
+	if bn.VPNv6 != nil {
+		p.VPNv6 = c.newAFIConfig(bn, bg)
+		c.configurePrefixLimit(bn.VPNv6.PrefixLimit, p.VPNv6)
+	}
+}
+
+func (c *bgpConfigurator) configureFlowSpec(bn *config.BGPNeighbor, bg *config.BGPGroup, p *bgpserver.PeerConfig) {
+	if bn.FlowSpecV4 != nil {
+		p.FlowSpecV4 = c.newAFIConfig(bn, bg)
+		c.configurePrefixLimit(bn.FlowSpecV4.PrefixLimit, p.FlowSpecV4)
+	}
+
+	if bn.FlowSpecV6 != nil {
+		p.FlowSpecV6 = c.newAFIConfig(bn, bg)
+		c.configurePrefixLimit(bn.FlowSpecV6.PrefixLimit, p.FlowSpecV6)
+	}
+}
+
 func (c *bgpConfigurator) newAFIConfig(bn *config.BGPNeighbor, bg *config.BGPGroup) *bgpserver.AddressFamilyConfig {
 	return &bgpserver.AddressFamilyConfig{
 		ImportFilterChain: bn.ImportFilterChain,
@@ -212,6 +329,35 @@ func (c *bgpConfigurator) configureAddressFamily(baf *config.AddressFamilyConfig
 	if baf.NextHopExtended {
 		af.NextHopExtended = true
 	}
+
+	c.configurePrefixLimit(baf.PrefixLimit, af)
+	c.configureDampening(baf.Dampening, af)
+}
+
+func (c *bgpConfigurator) configurePrefixLimit(bpl *config.PrefixLimitConfig, af *bgpserver.AddressFamilyConfig) {
+	if bpl == nil {
+		return
+	}
+
+	af.PrefixLimit = &bgpserver.PrefixLimitConfig{
+		Max:                     bpl.Max,
+		WarningThresholdPercent: bpl.WarningThreshold,
+		LogOnly:                 bpl.LogOnly,
+		RestartTime:             bpl.RestartTimeDuration,
+	}
+}
+
+func (c *bgpConfigurator) configureDampening(bd *config.DampeningConfig, af *bgpserver.AddressFamilyConfig) {
+	if bd == nil {
+		return
+	}
+
+	af.Dampening = &dampening.Config{
+		HalfLife:          bd.HalfLifeDuration,
+		ReuseThreshold:    bd.ReuseThreshold,
+		SuppressThreshold: bd.SuppressThreshold,
+		MaxSuppressTime:   bd.MaxSuppressTimeDuration,
+	}
 }
 
 func (c *bgpConfigurator) configureAddPath(bac *config.AddPathConfig, af *bgpserver.AddressFamilyConfig) {
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"fmt"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/routingtable/filter"
+)
+
+type Aggregate struct {
+	// description: |
+	//   Prefix of the aggregate. It is originated as long as any more specific route exists
+	Prefix string `yaml:"prefix"`
+	// docgen:nodoc
+	Pfx *bnet.Prefix
+	// description: |
+	//   Merge the AS paths of the contributing routes into an AS_SET. Otherwise the aggregate is marked
+	//   ATOMIC_AGGREGATE if the AS path of any contributing route is lost
+	ASSet bool `yaml:"as_set"`
+	// description: |
+	//   Suppress the advertisement of the contributing routes to BGP neighbors
+	SummaryOnly bool `yaml:"summary_only"`
+	// description: |
+	//   List of policy statements applied to the aggregate. The aggregate is not originated if rejected
+	Policies []string `yaml:"policies"`
+	// docgen:nodoc
+	FilterChain filter.Chain
+}
+
+func (a *Aggregate) load(policyOptions *PolicyOptions) error {
+	pfx, err := bnet.PrefixFromString(a.Prefix)
+	if err != nil {
+		return fmt.Errorf("unable to parse prefix: %w", err)
+	}
+
+	if !pfx.Valid() {
+		return fmt.Errorf("prefix %s has host bits set", a.Prefix)
+	}
+	a.Pfx = pfx
+
+	a.FilterChain = make(filter.Chain, 0, len(a.Policies))
+	for i := range a.Policies {
+		f := policyOptions.getPolicyStatementFilter(a.Policies[i])
+		if f == nil {
+			return fmt.Errorf("policy statement %q undefined", a.Policies[i])
+		}
+
+		a.FilterChain = append(a.FilterChain, f)
+	}
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package config
+
+import (
+	"testing"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/routingtable/filter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAggregateLoad(t *testing.T) {
+	policyOptions := &PolicyOptions{}
+	acceptAll := filter.NewFilter("ACCEPT_ALL", nil)
+	policyOptions.PolicyStatementsFilter = []*filter.Filter{
+		acceptAll,
+	}
+
+	tests := []struct {
+		name     string
+		input    *RoutingOptions
+		wantFail bool
+		expected []Aggregate
+	}{
+		{
+			name: "aggregates",
+			input: &RoutingOptions{
+				Aggregates: []Aggregate{
+					{
+						Prefix:      "198.51.100.0/22",
+						ASSet:       true,
+						SummaryOnly: true,
+						Policies:    []string{"ACCEPT_ALL"},
+					},
+					{
+						Prefix: "2001:db8::/32",
+					},
+				},
+			},
+			expected: []Aggregate{
+				{
+					Prefix:      "198.51.100.0/22",
+					Pfx:         bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 100, 0), 22).Ptr(),
+					ASSet:       true,
+					SummaryOnly: true,
+					Policies:    []string{"ACCEPT_ALL"},
+					FilterChain: filter.Chain{acceptAll},
+				},
+				{
+					Prefix:      "2001:db8::/32",
+					Pfx:         bnet.NewPfx(bnet.IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 0), 32).Ptr(),
+					FilterChain: filter.Chain{},
+				},
+			},
+		},
+		{
+			name: "host bits set",
+			input: &RoutingOptions{
+				Aggregates: []Aggregate{
+					{
+						Prefix: "198.51.100.1/22",
+					},
+				},
+			},
+			wantFail: true,
+		},
+		{
+			name: "undefined policy",
+			input: &RoutingOptions{
+				Aggregates: []Aggregate{
+					{
+						Prefix:   "198.51.100.0/22",
+						Policies: []string{"UNDEFINED"},
+					},
+				},
+			},
+			wantFail: true,
+		},
+		{
+			name: "duplicate prefix",
+			input: &RoutingOptions{
+				Aggregates: []Aggregate{
+					{
+						Prefix: "198.51.100.0/22",
+					},
+					{
+						Prefix: "198.51.100.0/22",
+						ASSet:  true,
+					},
+				},
+			},
+			wantFail: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.input.loadAggregates(policyOptions)
+			if test.wantFail {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, test.input.Aggregates)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	DefaultBFDMinIntervalMilliseconds = 300
+	DefaultBFDMultiplier              = 3
+	minBFDMinIntervalMilliseconds     = 10
+)
+
+type BFDConfig struct {
+	// description: |
+	//   Desired minimum transmit and required minimum receive interval in milliseconds (default: 300, minimum: 10)
+	MinInterval uint32 `yaml:"min_interval"`
+	// docgen:nodoc
+	MinIntervalDuration time.Duration
+	// description: |
+	//   Number of missed packets after which the session is declared down (default: 3)
+	Multiplier uint8 `yaml:"multiplier"`
+	// description: |
+	//   Use multihop BFD (RFC5883) instead of single hop BFD (RFC5881). Only supported for BGP neighbors
+	Multihop bool `yaml:"multihop"`
+}
+
+func (b *BFDConfig) load() error {
+	if b.MinInterval == 0 {
+		b.MinInterval = DefaultBFDMinIntervalMilliseconds
+	}
+
+	if b.MinInterval < minBFDMinIntervalMilliseconds {
+		return fmt.Errorf("BFD min interval %dms is below minimum of %dms", b.MinInterval, minBFDMinIntervalMilliseconds)
+	}
+
+	if b.Multiplier == 0 {
+		b.Multiplier = DefaultBFDMultiplier
+	}
+
+	b.MinIntervalDuration = time.Millisecond * time.Duration(b.MinInterval)
+
+	return nil
+}
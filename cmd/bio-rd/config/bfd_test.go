@@ -0,0 +1,55 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBFDConfigLoad(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *BFDConfig
+		wantFail bool
+		expected *BFDConfig
+	}{
+		{
+			name:  "defaults",
+			input: &BFDConfig{},
+			expected: &BFDConfig{
+				MinInterval:         DefaultBFDMinIntervalMilliseconds,
+				MinIntervalDuration: 300 * time.Millisecond,
+				Multiplier:          DefaultBFDMultiplier,
+			},
+		},
+		{
+			name:  "custom",
+			input: &BFDConfig{MinInterval: 50, Multiplier: 4, Multihop: true},
+			expected: &BFDConfig{
+				MinInterval:         50,
+				MinIntervalDuration: 50 * time.Millisecond,
+				Multiplier:          4,
+				Multihop:            true,
+			},
+		},
+		{
+			name:     "interval below minimum",
+			input:    &BFDConfig{MinInterval: 5},
+			wantFail: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.input.load()
+			if test.wantFail {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, test.input)
+		})
+	}
+}
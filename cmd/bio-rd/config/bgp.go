@@ -2,14 +2,24 @@ package config
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	bnet "github.com/bio-routing/bio-rd/net"
+	bgpserver "github.com/bio-routing/bio-rd/protocols/bgp/server"
+	"github.com/bio-routing/bio-rd/routingtable/dampening"
 	"github.com/bio-routing/bio-rd/routingtable/filter"
 )
 
 const (
 	DefaultHoldTimeSeconds = 90
+
+	DefaultGracefulRestartTimeSeconds      = 120
+	DefaultGracefulRestartStaleTimeSeconds = 360
+	maxGracefulRestartTimeSeconds          = 4095
+
+	DefaultPrefixLimitWarningThreshold = 75
 )
 
 type BGP struct {
@@ -20,11 +30,23 @@ type BGP struct {
 }
 
 func (b *BGP) load(localAS uint32, policyOptions *PolicyOptions) error {
+	dynamicNeighbors := make(map[string]struct{})
 	for _, g := range b.Groups {
 		err := g.load(localAS, policyOptions)
 		if err != nil {
 			return err
 		}
+
+		if g.DynamicNeighbors == nil {
+			continue
+		}
+
+		k := g.DynamicNeighbors.Neighbor.RoutingInstance + "/" + g.DynamicNeighbors.PrefixNet.String()
+		if _, exists := dynamicNeighbors[k]; exists {
+			return fmt.Errorf("dynamic neighbors %q are configured in multiple groups", g.DynamicNeighbors.Prefix)
+		}
+
+		dynamicNeighbors[k] = struct{}{}
 	}
 
 	return nil
@@ -85,15 +107,46 @@ type BGPGroup struct {
 	//   Configures the client in passive mode
 	Passive *bool `yaml:"passive"`
 	// description: |
+	//   Our role on the session to the peer (RFC9234)
+	//   Enables the BGP Role capability, the Only to Customer attribute and the ASPA verification of the AS paths
+	//   of received routes. Available options: provider, customer, peer, rs, rs-client
+	LocalRole string `yaml:"local_role"`
+	// docgen:nodoc
+	LocalRoleID uint8
+	// description: |
+	//   Hide received routes failing the ASPA verification. Requires local_role to be set
+	ASPARejectInvalid *bool `yaml:"aspa_reject_invalid"`
+	// description: |
 	//   Neighbors that belong to this group. See bgpneighbors.md for details.
 	Neighbors []*BGPNeighbor `yaml:"neighbors"`
 	// description: |
+	//   Accept sessions from all addresses within a prefix. The sessions inherit the settings of the group
+	DynamicNeighbors *BGPDynamicNeighbors `yaml:"dynamic_neighbors"`
+	// description: |
 	//   Configuration values for the IPv4 AFI family
 	IPv4 *AddressFamilyConfig `yaml:"ipv4"`
 	// description: |
 	//   Configuration values for the IPv6 AFI family
 	IPv6 *AddressFamilyConfig `yaml:"ipv6"`
 	// description: |
+	//   Configuration values for the VPNv4 (BGP/MPLS IP VPN, RFC4364) family
+	VPNv4 *AddressFamilyConfig `yaml:"vpnv4"`
+	// description: |
+	//   Configuration values for the VPNv6 (BGP/MPLS IPv6 VPN, RFC4659) family
+	VPNv6 *AddressFamilyConfig `yaml:"vpnv6"`
+	// description: |
+	//   Configuration values for the IPv4 FlowSpec (RFC8955) family
+	FlowSpecV4 *AddressFamilyConfig `yaml:"flowspec"`
+	// description: |
+	//   Configuration values for the IPv6 FlowSpec (RFC8956) family
+	FlowSpecV6 *AddressFamilyConfig `yaml:"flowspec6"`
+	// description: |
+	//   Graceful Restart (RFC4724) configuration
+	GracefulRestart *GracefulRestartConfig `yaml:"graceful_restart"`
+	// description: |
+	//   Detect failures of the forwarding path to the neighbors with BFD
+	BFD *BFDConfig `yaml:"bfd"`
+	// description: |
 	//   Name of the routing instance this groups belongs to
 	RoutingInstance string `yaml:"routing_instance"`
 }
@@ -144,82 +197,229 @@ func (bg *BGPGroup) load(localAS uint32, policyOptions *PolicyOptions) error {
 	}
 
 	for _, bn := range bg.Neighbors {
-		if bn.RouteServerClient == nil {
-			bn.RouteServerClient = bg.RouteServerClient
-		}
+		bg.inherit(bn)
 
-		if bn.Passive == nil {
-			bn.Passive = bg.Passive
+		if bn.LocalAS == 0 {
+			return fmt.Errorf("local_as 0 is invalid")
 		}
 
-		if bn.LocalAddress == "" {
-			bn.LocalAddressIP = bg.LocalAddressIP
+		if bn.PeerAS == 0 {
+			return fmt.Errorf("peer_as 0 is invalid")
 		}
 
-		if bn.ClusterID == "" {
-			bn.ClusterIDIP = bg.ClusterIDIP
+		err := bn.load(policyOptions)
+		if err != nil {
+			return err
 		}
+	}
 
-		if bn.TTL == 0 {
-			bn.TTL = bg.TTL
+	if bg.DynamicNeighbors != nil {
+		err := bg.DynamicNeighbors.load(bg, policyOptions)
+		if err != nil {
+			return err
 		}
+	}
 
-		if bn.AuthenticationKey == "" {
-			bn.AuthenticationKey = bg.AuthenticationKey
-		}
+	return nil
+}
 
-		if bn.LocalAS == 0 {
-			bn.LocalAS = bg.LocalAS
-		}
+// inherit applies the settings of the group to a neighbor unless they are set on the neighbor itself
+func (bg *BGPGroup) inherit(bn *BGPNeighbor) {
+	if bn.RouteServerClient == nil {
+		bn.RouteServerClient = bg.RouteServerClient
+	}
 
-		if bn.LocalAS == 0 {
-			return fmt.Errorf("local_as 0 is invalid")
-		}
+	if bn.Passive == nil {
+		bn.Passive = bg.Passive
+	}
 
-		if bn.PeerAS == 0 {
-			bn.PeerAS = bg.PeerAS
-		}
+	if bn.LocalRole == "" {
+		bn.LocalRole = bg.LocalRole
+	}
 
-		if bn.PeerAS == 0 {
-			return fmt.Errorf("peer_as 0 is invalid")
-		}
+	if bn.ASPARejectInvalid == nil {
+		bn.ASPARejectInvalid = bg.ASPARejectInvalid
+	}
 
-		if bn.HoldTime == 0 {
-			bn.HoldTime = bg.HoldTime
-		}
+	if bn.LocalAddress == "" {
+		bn.LocalAddressIP = bg.LocalAddressIP
+	}
 
-		if bn.Multipath == nil {
-			bn.Multipath = bg.Multipath
-		}
+	if bn.ClusterID == "" {
+		bn.ClusterIDIP = bg.ClusterIDIP
+	}
 
-		if len(bn.RoutingInstance) == 0 {
-			bn.RoutingInstance = bg.RoutingInstance
-		}
+	if bn.TTL == 0 {
+		bn.TTL = bg.TTL
+	}
 
-		if bn.IPv4 == nil {
-			bn.IPv4 = bg.IPv4
-		}
+	if bn.AuthenticationKey == "" {
+		bn.AuthenticationKey = bg.AuthenticationKey
+	}
+
+	if bn.LocalAS == 0 {
+		bn.LocalAS = bg.LocalAS
+	}
+
+	if bn.PeerAS == 0 {
+		bn.PeerAS = bg.PeerAS
+	}
+
+	if bn.HoldTime == 0 {
+		bn.HoldTime = bg.HoldTime
+	}
 
-		if bn.IPv6 == nil {
-			bn.IPv6 = bg.IPv6
+	if bn.Multipath == nil {
+		bn.Multipath = bg.Multipath
+	}
+
+	if len(bn.RoutingInstance) == 0 {
+		bn.RoutingInstance = bg.RoutingInstance
+	}
+
+	if bn.IPv4 == nil {
+		bn.IPv4 = bg.IPv4
+	}
+
+	if bn.IPv6 == nil {
+		bn.IPv6 = bg.IPv6
+	}
+
+	if bn.VPNv4 == nil {
+		bn.VPNv4 = bg.VPNv4
+	}
+
+	if bn.VPNv6 == nil {
+		bn.VPNv6 = bg.VPNv6
+	}
+
+	if bn.FlowSpecV4 == nil {
+		bn.FlowSpecV4 = bg.FlowSpecV4
+	}
+
+	if bn.FlowSpecV6 == nil {
+		bn.FlowSpecV6 = bg.FlowSpecV6
+	}
+
+	if bn.RouteReflectorClient == nil {
+		bn.RouteReflectorClient = bg.RouteReflectorClient
+	}
+
+	if bn.GracefulRestart == nil {
+		bn.GracefulRestart = bg.GracefulRestart
+	}
+
+	if bn.BFD == nil {
+		bn.BFD = bg.BFD
+	}
+
+	bn.ImportFilterChain = bg.ImportFilterChain
+	bn.ExportFilterChain = bg.ExportFilterChain
+}
+
+type BGPDynamicNeighbors struct {
+	// description: |
+	//   Prefix sessions are accepted from
+	Prefix string `yaml:"prefix"`
+	// docgen:nodoc
+	PrefixNet *bnet.Prefix
+	// description: |
+	//   AS numbers accepted from the dynamic neighbors. Ranges are written as "64512-65534" (default: peer_as of the group)
+	PeerASRange []string `yaml:"peer_as_range"`
+	// docgen:nodoc
+	PeerASRanges []bgpserver.ASNRange
+	// description: |
+	//   Maximum number of sessions accepted from the prefix (default: unlimited)
+	Max uint32 `yaml:"max"`
+	// docgen:nodoc
+	Neighbor *BGPNeighbor
+}
+
+func (dn *BGPDynamicNeighbors) load(bg *BGPGroup, policyOptions *PolicyOptions) error {
+	pfx, err := bnet.PrefixFromString(dn.Prefix)
+	if err != nil {
+		return fmt.Errorf("unable to parse dynamic neighbors prefix %q: %w", dn.Prefix, err)
+	}
+
+	dn.PrefixNet = pfx.Dedup()
+
+	dn.PeerASRanges = make([]bgpserver.ASNRange, 0, len(dn.PeerASRange))
+	for _, x := range dn.PeerASRange {
+		r, err := parseASNRange(x)
+		if err != nil {
+			return fmt.Errorf("dynamic neighbors %q: %w", dn.Prefix, err)
 		}
 
-		if bn.RouteReflectorClient == nil {
-			bn.RouteReflectorClient = bg.RouteReflectorClient
+		dn.PeerASRanges = append(dn.PeerASRanges, r)
+	}
+
+	if len(dn.PeerASRanges) == 0 {
+		if bg.PeerAS == 0 {
+			return fmt.Errorf("dynamic neighbors %q require peer_as or peer_as_range", dn.Prefix)
 		}
 
-		bn.ImportFilterChain = bg.ImportFilterChain
-		bn.ExportFilterChain = bg.ExportFilterChain
+		dn.PeerASRanges = append(dn.PeerASRanges, bgpserver.ASNRange{From: bg.PeerAS, To: bg.PeerAS})
+	}
 
-		err := bn.load(policyOptions)
-		if err != nil {
-			return err
+	if bg.AuthenticationKey != "" {
+		return fmt.Errorf("dynamic neighbors %q: authentication_key is not supported", dn.Prefix)
+	}
+
+	passive := true
+	dn.Neighbor = &BGPNeighbor{
+		PeerAddress:   dn.Prefix,
+		PeerAddressIP: dn.PrefixNet.Addr().Dedup(),
+		Passive:       &passive,
+	}
+	bg.inherit(dn.Neighbor)
+
+	if dn.Neighbor.LocalAS == 0 {
+		return fmt.Errorf("local_as 0 is invalid")
+	}
+
+	dn.Neighbor.ImportFilterChain = bg.ImportFilterChain
+	dn.Neighbor.ExportFilterChain = bg.ExportFilterChain
+
+	err = dn.Neighbor.loadSettings(policyOptions)
+	if err != nil {
+		return err
+	}
+
+	if dn.Neighbor.LocalRoleID != bgpserver.PeerConfigRoleOff {
+		for _, r := range dn.PeerASRanges {
+			if r.Contains(dn.Neighbor.LocalAS) {
+				return fmt.Errorf("dynamic neighbors %q: local_role is not supported for iBGP sessions", dn.Prefix)
+			}
 		}
 	}
 
 	return nil
 }
 
+// parseASNRange parses a single AS number or a range of AS numbers, e.g. "64512-65534"
+func parseASNRange(s string) (bgpserver.ASNRange, error) {
+	from, to, isRange := strings.Cut(s, "-")
+	if !isRange {
+		to = from
+	}
+
+	f, err := strconv.ParseUint(strings.TrimSpace(from), 10, 32)
+	if err != nil {
+		return bgpserver.ASNRange{}, fmt.Errorf("invalid AS number %q", from)
+	}
+
+	t, err := strconv.ParseUint(strings.TrimSpace(to), 10, 32)
+	if err != nil {
+		return bgpserver.ASNRange{}, fmt.Errorf("invalid AS number %q", to)
+	}
+
+	if f == 0 || f > t {
+		return bgpserver.ASNRange{}, fmt.Errorf("invalid AS range %q", s)
+	}
+
+	return bgpserver.ASNRange{From: uint32(f), To: uint32(t)}, nil
+}
+
 type Multipath struct {
 	// description: |
 	//   Enable multipath
@@ -289,6 +489,16 @@ type BGPNeighbor struct {
 	//   Configures the client in passive mode
 	Passive *bool `yaml:"passive"`
 	// description: |
+	//   Our role on the session to the peer (RFC9234)
+	//   Enables the BGP Role capability, the Only to Customer attribute and the ASPA verification of the AS paths
+	//   of received routes. Available options: provider, customer, peer, rs, rs-client
+	LocalRole string `yaml:"local_role"`
+	// docgen:nodoc
+	LocalRoleID uint8
+	// description: |
+	//   Hide received routes failing the ASPA verification. Requires local_role to be set
+	ASPARejectInvalid *bool `yaml:"aspa_reject_invalid"`
+	// description: |
 	//   Cluster ID for route reflection
 	ClusterID string `yaml:"cluster_id"`
 	// docgen:nodoc
@@ -300,9 +510,27 @@ type BGPNeighbor struct {
 	//   Configuration values for the IPv6 AFI family
 	IPv6 *AddressFamilyConfig `yaml:"ipv6"`
 	// description: |
+	//   Configuration values for the VPNv4 (BGP/MPLS IP VPN, RFC4364) family
+	VPNv4 *AddressFamilyConfig `yaml:"vpnv4"`
+	// description: |
+	//   Configuration values for the VPNv6 (BGP/MPLS IPv6 VPN, RFC4659) family
+	VPNv6 *AddressFamilyConfig `yaml:"vpnv6"`
+	// description: |
+	//   Configuration values for the IPv4 FlowSpec (RFC8955) family
+	FlowSpecV4 *AddressFamilyConfig `yaml:"flowspec"`
+	// description: |
+	//   Configuration values for the IPv6 FlowSpec (RFC8956) family
+	FlowSpecV6 *AddressFamilyConfig `yaml:"flowspec6"`
+	// description: |
 	//   Advertise the multiprotocol capability for the IPv4 AFI
 	AdvertiseIPv4MultiProtocol bool `yaml:"advertise_ipv4_multiprotocol"`
 	// description: |
+	//   Graceful Restart (RFC4724) configuration
+	GracefulRestart *GracefulRestartConfig `yaml:"graceful_restart"`
+	// description: |
+	//   Detect failures of the forwarding path to the neighbors with BFD
+	BFD *BFDConfig `yaml:"bfd"`
+	// description: |
 	//   Name of the routing instance this groups belongs to
 	RoutingInstance string `yaml:"routing_instance"`
 }
@@ -316,6 +544,18 @@ func (bn *BGPNeighbor) load(policyOptions *PolicyOptions) error {
 		return fmt.Errorf("mandatory parameter BGP peer address is empty")
 	}
 
+	b, err := bnet.IPFromString(bn.PeerAddress)
+	if err != nil {
+		return fmt.Errorf("unable to parse BGP peer address: %w", err)
+	}
+
+	bn.PeerAddressIP = b.Dedup()
+
+	return bn.loadSettings(policyOptions)
+}
+
+// loadSettings loads everything but the peer address and AS. It is shared with the template of dynamic neighbors.
+func (bn *BGPNeighbor) loadSettings(policyOptions *PolicyOptions) error {
 	if bn.LocalAddress != "" {
 		a, err := bnet.IPFromString(bn.LocalAddress)
 		if err != nil {
@@ -334,13 +574,42 @@ func (bn *BGPNeighbor) load(policyOptions *PolicyOptions) error {
 		bn.ClusterIDIP = a.Dedup()
 	}
 
-	b, err := bnet.IPFromString(bn.PeerAddress)
+	bn.HoldTimeDuration = time.Second * time.Duration(bn.HoldTime)
+
+	var err error
+	bn.LocalRoleID, err = localRoleFromString(bn.LocalRole)
 	if err != nil {
-		return fmt.Errorf("unable to parse BGP peer address: %w", err)
+		return fmt.Errorf("peer %q: %w", bn.PeerAddress, err)
 	}
 
-	bn.PeerAddressIP = b.Dedup()
-	bn.HoldTimeDuration = time.Second * time.Duration(bn.HoldTime)
+	if bn.ASPARejectInvalid != nil && *bn.ASPARejectInvalid && bn.LocalRoleID == bgpserver.PeerConfigRoleOff {
+		return fmt.Errorf("peer %q: aspa_reject_invalid requires local_role", bn.PeerAddress)
+	}
+
+	if bn.GracefulRestart != nil {
+		err := bn.GracefulRestart.load()
+		if err != nil {
+			return fmt.Errorf("peer %q: %w", bn.PeerAddress, err)
+		}
+	}
+
+	if bn.BFD != nil {
+		err := bn.BFD.load()
+		if err != nil {
+			return fmt.Errorf("peer %q: %w", bn.PeerAddress, err)
+		}
+	}
+
+	for _, afc := range []*AddressFamilyConfig{bn.IPv4, bn.IPv6, bn.VPNv4, bn.VPNv6, bn.FlowSpecV4, bn.FlowSpecV6} {
+		if afc == nil {
+			continue
+		}
+
+		err := afc.load()
+		if err != nil {
+			return fmt.Errorf("peer %q: %w", bn.PeerAddress, err)
+		}
+	}
 
 	if len(bn.Import) > 0 {
 		bn.ImportFilterChain = filter.Chain{}
@@ -369,6 +638,60 @@ func (bn *BGPNeighbor) load(policyOptions *PolicyOptions) error {
 	return nil
 }
 
+func localRoleFromString(role string) (uint8, error) {
+	switch role {
+	case "":
+		return bgpserver.PeerConfigRoleOff, nil
+	case "provider":
+		return bgpserver.PeerConfigRoleProvider, nil
+	case "customer":
+		return bgpserver.PeerConfigRoleCustomer, nil
+	case "peer":
+		return bgpserver.PeerConfigRolePeer, nil
+	case "rs":
+		return bgpserver.PeerConfigRoleRS, nil
+	case "rs-client":
+		return bgpserver.PeerConfigRoleRSClient, nil
+	}
+
+	return 0, fmt.Errorf("invalid local role: %q", role)
+}
+
+type GracefulRestartConfig struct {
+	// description: |
+	//   Enable Graceful Restart
+	Enabled bool `yaml:"enabled"`
+	// description: |
+	//   Time in seconds the peer should keep our routes while we restart (default: 120, maximum: 4095)
+	RestartTime uint16 `yaml:"restart_time"`
+	// docgen:nodoc
+	RestartTimeDuration time.Duration
+	// description: |
+	//   Time in seconds routes of a restarted peer are kept until it sent End-of-RIB (default: 360)
+	StaleRoutesTime uint16 `yaml:"stale_routes_time"`
+	// docgen:nodoc
+	StaleRoutesTimeDuration time.Duration
+}
+
+func (gr *GracefulRestartConfig) load() error {
+	if gr.RestartTime == 0 {
+		gr.RestartTime = DefaultGracefulRestartTimeSeconds
+	}
+
+	if gr.RestartTime > maxGracefulRestartTimeSeconds {
+		return fmt.Errorf("graceful restart time %d exceeds maximum of %d seconds", gr.RestartTime, maxGracefulRestartTimeSeconds)
+	}
+
+	if gr.StaleRoutesTime == 0 {
+		gr.StaleRoutesTime = DefaultGracefulRestartStaleTimeSeconds
+	}
+
+	gr.RestartTimeDuration = time.Second * time.Duration(gr.RestartTime)
+	gr.StaleRoutesTimeDuration = time.Second * time.Duration(gr.StaleRoutesTime)
+
+	return nil
+}
+
 type AddressFamilyConfig struct {
 	// description: |
 	//   Enable add_path for send and receive
@@ -376,6 +699,123 @@ type AddressFamilyConfig struct {
 	// description: |
 	//   Enable extended next hop for the address family
 	NextHopExtended bool `yaml:"next_hop_extended"`
+	// description: |
+	//   Limits the number of paths accepted from the peer
+	PrefixLimit *PrefixLimitConfig `yaml:"prefix_limit"`
+	// description: |
+	//   Suppresses flapping routes received from the peer (RFC2439).
+	//   Only applies to IPv4/IPv6 unicast
+	Dampening *DampeningConfig `yaml:"dampening"`
+}
+
+func (afc *AddressFamilyConfig) load() error {
+	if afc.PrefixLimit != nil {
+		err := afc.PrefixLimit.load()
+		if err != nil {
+			return err
+		}
+	}
+
+	if afc.Dampening != nil {
+		err := afc.Dampening.load()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type DampeningConfig struct {
+	// description: |
+	//   Time in seconds after which the figure of merit of a route has decayed by half (default: 900)
+	HalfLife uint32 `yaml:"half_life"`
+	// docgen:nodoc
+	HalfLifeDuration time.Duration
+	// description: |
+	//   Figure of merit below which a suppressed route is reused (default: 750)
+	ReuseThreshold uint32 `yaml:"reuse_threshold"`
+	// description: |
+	//   Figure of merit above which a route is suppressed (default: 6000)
+	SuppressThreshold uint32 `yaml:"suppress_threshold"`
+	// description: |
+	//   Maximum time in seconds a route is suppressed after it stopped flapping (default: 3600)
+	MaxSuppressTime uint32 `yaml:"max_suppress_time"`
+	// docgen:nodoc
+	MaxSuppressTimeDuration time.Duration
+}
+
+func (dc *DampeningConfig) load() error {
+	if dc.HalfLife == 0 {
+		dc.HalfLife = uint32(dampening.DefaultHalfLife / time.Second)
+	}
+
+	if dc.ReuseThreshold == 0 {
+		dc.ReuseThreshold = dampening.DefaultReuseThreshold
+	}
+
+	if dc.SuppressThreshold == 0 {
+		dc.SuppressThreshold = dampening.DefaultSuppressThreshold
+	}
+
+	if dc.MaxSuppressTime == 0 {
+		dc.MaxSuppressTime = uint32(dampening.DefaultMaxSuppressTime / time.Second)
+	}
+
+	dc.HalfLifeDuration = time.Second * time.Duration(dc.HalfLife)
+	dc.MaxSuppressTimeDuration = time.Second * time.Duration(dc.MaxSuppressTime)
+
+	if dc.ReuseThreshold >= dc.SuppressThreshold {
+		return fmt.Errorf("dampening reuse threshold %d must be lower than suppress threshold %d", dc.ReuseThreshold, dc.SuppressThreshold)
+	}
+
+	cfg := dampening.Config{
+		HalfLife:          dc.HalfLifeDuration,
+		ReuseThreshold:    dc.ReuseThreshold,
+		SuppressThreshold: dc.SuppressThreshold,
+		MaxSuppressTime:   dc.MaxSuppressTimeDuration,
+	}
+	if cfg.Ceiling() <= float64(dc.SuppressThreshold) {
+		return fmt.Errorf("dampening max suppress time %ds is too short to ever suppress a route", dc.MaxSuppressTime)
+	}
+
+	return nil
+}
+
+type PrefixLimitConfig struct {
+	// description: |
+	//   Maximum number of paths accepted from the peer
+	Max uint64 `yaml:"max"`
+	// description: |
+	//   Percentage of max at which a warning is logged (default: 75)
+	WarningThreshold uint8 `yaml:"warning_threshold"`
+	// description: |
+	//   Only log exceeding the limit instead of tearing down the session
+	LogOnly bool `yaml:"log_only"`
+	// description: |
+	//   Time in seconds the session is held down after it was torn down.
+	//   If not set the session stays down until the limit is changed
+	RestartTime uint32 `yaml:"restart_time"`
+	// docgen:nodoc
+	RestartTimeDuration time.Duration
+}
+
+func (pl *PrefixLimitConfig) load() error {
+	if pl.Max == 0 {
+		return fmt.Errorf("prefix limit max must be greater than 0")
+	}
+
+	if pl.WarningThreshold == 0 {
+		pl.WarningThreshold = DefaultPrefixLimitWarningThreshold
+	}
+
+	if pl.WarningThreshold > 100 {
+		return fmt.Errorf("prefix limit warning threshold %d%% exceeds 100%%", pl.WarningThreshold)
+	}
+
+	pl.RestartTimeDuration = time.Second * time.Duration(pl.RestartTime)
+
+	return nil
 }
 
 type AddPathConfig struct {
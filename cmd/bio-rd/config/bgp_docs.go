@@ -9,13 +9,17 @@ import (
 )
 
 var (
-	BGPDoc                 encoder.Doc
-	BGPGroupDoc            encoder.Doc
-	MultipathDoc           encoder.Doc
-	BGPNeighborDoc         encoder.Doc
-	AddressFamilyConfigDoc encoder.Doc
-	AddPathConfigDoc       encoder.Doc
-	AddPathSendConfigDoc   encoder.Doc
+	BGPDoc                   encoder.Doc
+	BGPGroupDoc              encoder.Doc
+	BGPDynamicNeighborsDoc   encoder.Doc
+	MultipathDoc             encoder.Doc
+	BGPNeighborDoc           encoder.Doc
+	GracefulRestartConfigDoc encoder.Doc
+	AddressFamilyConfigDoc   encoder.Doc
+	PrefixLimitConfigDoc     encoder.Doc
+	DampeningConfigDoc       encoder.Doc
+	AddPathConfigDoc         encoder.Doc
+	AddPathSendConfigDoc     encoder.Doc
 )
 
 func init() {
@@ -38,7 +42,7 @@ func init() {
 			FieldName: "groups",
 		},
 	}
-	BGPGroupDoc.Fields = make([]encoder.Doc, 18)
+	BGPGroupDoc.Fields = make([]encoder.Doc, 27)
 	BGPGroupDoc.Fields[0].Name = "name"
 	BGPGroupDoc.Fields[0].Type = "string"
 	BGPGroupDoc.Fields[0].Note = ""
@@ -109,26 +113,97 @@ func init() {
 	BGPGroupDoc.Fields[13].Note = ""
 	BGPGroupDoc.Fields[13].Description = "Configures the client in passive mode"
 	BGPGroupDoc.Fields[13].Comments[encoder.LineComment] = "Configures the client in passive mode"
-	BGPGroupDoc.Fields[14].Name = "neighbors"
-	BGPGroupDoc.Fields[14].Type = "[]BGPNeighbor"
+	BGPGroupDoc.Fields[14].Name = "local_role"
+	BGPGroupDoc.Fields[14].Type = "string"
 	BGPGroupDoc.Fields[14].Note = ""
-	BGPGroupDoc.Fields[14].Description = "Neighbors that belong to this group. See bgpneighbors.md for details."
-	BGPGroupDoc.Fields[14].Comments[encoder.LineComment] = "Neighbors that belong to this group. See bgpneighbors.md for details."
-	BGPGroupDoc.Fields[15].Name = "ipv4"
-	BGPGroupDoc.Fields[15].Type = "AddressFamilyConfig"
+	BGPGroupDoc.Fields[14].Description = "Our role on the session to the peer (RFC9234)\nEnables the BGP Role capability, the Only to Customer attribute and the ASPA verification of the AS paths\nof received routes. Available options: provider, customer, peer, rs, rs-client"
+	BGPGroupDoc.Fields[14].Comments[encoder.LineComment] = "Our role on the session to the peer (RFC9234)"
+	BGPGroupDoc.Fields[15].Name = "aspa_reject_invalid"
+	BGPGroupDoc.Fields[15].Type = "bool"
 	BGPGroupDoc.Fields[15].Note = ""
-	BGPGroupDoc.Fields[15].Description = "Configuration values for the IPv4 AFI family"
-	BGPGroupDoc.Fields[15].Comments[encoder.LineComment] = "Configuration values for the IPv4 AFI family"
-	BGPGroupDoc.Fields[16].Name = "ipv6"
-	BGPGroupDoc.Fields[16].Type = "AddressFamilyConfig"
+	BGPGroupDoc.Fields[15].Description = "Hide received routes failing the ASPA verification. Requires local_role to be set"
+	BGPGroupDoc.Fields[15].Comments[encoder.LineComment] = "Hide received routes failing the ASPA verification. Requires local_role to be set"
+	BGPGroupDoc.Fields[16].Name = "neighbors"
+	BGPGroupDoc.Fields[16].Type = "[]BGPNeighbor"
 	BGPGroupDoc.Fields[16].Note = ""
-	BGPGroupDoc.Fields[16].Description = "Configuration values for the IPv6 AFI family"
-	BGPGroupDoc.Fields[16].Comments[encoder.LineComment] = "Configuration values for the IPv6 AFI family"
-	BGPGroupDoc.Fields[17].Name = "routing_instance"
-	BGPGroupDoc.Fields[17].Type = "string"
+	BGPGroupDoc.Fields[16].Description = "Neighbors that belong to this group. See bgpneighbors.md for details."
+	BGPGroupDoc.Fields[16].Comments[encoder.LineComment] = "Neighbors that belong to this group. See bgpneighbors.md for details."
+	BGPGroupDoc.Fields[17].Name = "dynamic_neighbors"
+	BGPGroupDoc.Fields[17].Type = "BGPDynamicNeighbors"
 	BGPGroupDoc.Fields[17].Note = ""
-	BGPGroupDoc.Fields[17].Description = "Name of the routing instance this groups belongs to"
-	BGPGroupDoc.Fields[17].Comments[encoder.LineComment] = "Name of the routing instance this groups belongs to"
+	BGPGroupDoc.Fields[17].Description = "Accept sessions from all addresses within a prefix. The sessions inherit the settings of the group"
+	BGPGroupDoc.Fields[17].Comments[encoder.LineComment] = "Accept sessions from all addresses within a prefix. The sessions inherit the settings of the group"
+	BGPGroupDoc.Fields[18].Name = "ipv4"
+	BGPGroupDoc.Fields[18].Type = "AddressFamilyConfig"
+	BGPGroupDoc.Fields[18].Note = ""
+	BGPGroupDoc.Fields[18].Description = "Configuration values for the IPv4 AFI family"
+	BGPGroupDoc.Fields[18].Comments[encoder.LineComment] = "Configuration values for the IPv4 AFI family"
+	BGPGroupDoc.Fields[19].Name = "ipv6"
+	BGPGroupDoc.Fields[19].Type = "AddressFamilyConfig"
+	BGPGroupDoc.Fields[19].Note = ""
+	BGPGroupDoc.Fields[19].Description = "Configuration values for the IPv6 AFI family"
+	BGPGroupDoc.Fields[19].Comments[encoder.LineComment] = "Configuration values for the IPv6 AFI family"
+	BGPGroupDoc.Fields[20].Name = "vpnv4"
+	BGPGroupDoc.Fields[20].Type = "AddressFamilyConfig"
+	BGPGroupDoc.Fields[20].Note = ""
+	BGPGroupDoc.Fields[20].Description = "Configuration values for the VPNv4 (BGP/MPLS IP VPN, RFC4364) family"
+	BGPGroupDoc.Fields[20].Comments[encoder.LineComment] = "Configuration values for the VPNv4 (BGP/MPLS IP VPN, RFC4364) family"
+	BGPGroupDoc.Fields[21].Name = "vpnv6"
+	BGPGroupDoc.Fields[21].Type = "AddressFamilyConfig"
+	BGPGroupDoc.Fields[21].Note = ""
+	BGPGroupDoc.Fields[21].Description = "Configuration values for the VPNv6 (BGP/MPLS IPv6 VPN, RFC4659) family"
+	BGPGroupDoc.Fields[21].Comments[encoder.LineComment] = "Configuration values for the VPNv6 (BGP/MPLS IPv6 VPN, RFC4659) family"
+	BGPGroupDoc.Fields[22].Name = "flowspec"
+	BGPGroupDoc.Fields[22].Type = "AddressFamilyConfig"
+	BGPGroupDoc.Fields[22].Note = ""
+	BGPGroupDoc.Fields[22].Description = "Configuration values for the IPv4 FlowSpec (RFC8955) family"
+	BGPGroupDoc.Fields[22].Comments[encoder.LineComment] = "Configuration values for the IPv4 FlowSpec (RFC8955) family"
+	BGPGroupDoc.Fields[23].Name = "flowspec6"
+	BGPGroupDoc.Fields[23].Type = "AddressFamilyConfig"
+	BGPGroupDoc.Fields[23].Note = ""
+	BGPGroupDoc.Fields[23].Description = "Configuration values for the IPv6 FlowSpec (RFC8956) family"
+	BGPGroupDoc.Fields[23].Comments[encoder.LineComment] = "Configuration values for the IPv6 FlowSpec (RFC8956) family"
+	BGPGroupDoc.Fields[24].Name = "graceful_restart"
+	BGPGroupDoc.Fields[24].Type = "GracefulRestartConfig"
+	BGPGroupDoc.Fields[24].Note = ""
+	BGPGroupDoc.Fields[24].Description = "Graceful Restart (RFC4724) configuration"
+	BGPGroupDoc.Fields[24].Comments[encoder.LineComment] = "Graceful Restart (RFC4724) configuration"
+	BGPGroupDoc.Fields[25].Name = "bfd"
+	BGPGroupDoc.Fields[25].Type = "BFDConfig"
+	BGPGroupDoc.Fields[25].Note = ""
+	BGPGroupDoc.Fields[25].Description = "Detect failures of the forwarding path to the neighbors with BFD"
+	BGPGroupDoc.Fields[25].Comments[encoder.LineComment] = "Detect failures of the forwarding path to the neighbors with BFD"
+	BGPGroupDoc.Fields[26].Name = "routing_instance"
+	BGPGroupDoc.Fields[26].Type = "string"
+	BGPGroupDoc.Fields[26].Note = ""
+	BGPGroupDoc.Fields[26].Description = "Name of the routing instance this groups belongs to"
+	BGPGroupDoc.Fields[26].Comments[encoder.LineComment] = "Name of the routing instance this groups belongs to"
+
+	BGPDynamicNeighborsDoc.Type = "BGPDynamicNeighbors"
+	BGPDynamicNeighborsDoc.Comments[encoder.LineComment] = ""
+	BGPDynamicNeighborsDoc.Description = ""
+	BGPDynamicNeighborsDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "BGPGroup",
+			FieldName: "dynamic_neighbors",
+		},
+	}
+	BGPDynamicNeighborsDoc.Fields = make([]encoder.Doc, 3)
+	BGPDynamicNeighborsDoc.Fields[0].Name = "prefix"
+	BGPDynamicNeighborsDoc.Fields[0].Type = "string"
+	BGPDynamicNeighborsDoc.Fields[0].Note = ""
+	BGPDynamicNeighborsDoc.Fields[0].Description = "Prefix sessions are accepted from"
+	BGPDynamicNeighborsDoc.Fields[0].Comments[encoder.LineComment] = "Prefix sessions are accepted from"
+	BGPDynamicNeighborsDoc.Fields[1].Name = "peer_as_range"
+	BGPDynamicNeighborsDoc.Fields[1].Type = "[]string"
+	BGPDynamicNeighborsDoc.Fields[1].Note = ""
+	BGPDynamicNeighborsDoc.Fields[1].Description = "AS numbers accepted from the dynamic neighbors. Ranges are written as \"64512-65534\" (default: peer_as of the group)"
+	BGPDynamicNeighborsDoc.Fields[1].Comments[encoder.LineComment] = "AS numbers accepted from the dynamic neighbors. Ranges are written as \"64512-65534\" (default: peer_as of the group)"
+	BGPDynamicNeighborsDoc.Fields[2].Name = "max"
+	BGPDynamicNeighborsDoc.Fields[2].Type = "uint32"
+	BGPDynamicNeighborsDoc.Fields[2].Note = ""
+	BGPDynamicNeighborsDoc.Fields[2].Description = "Maximum number of sessions accepted from the prefix (default: unlimited)"
+	BGPDynamicNeighborsDoc.Fields[2].Comments[encoder.LineComment] = "Maximum number of sessions accepted from the prefix (default: unlimited)"
 
 	MultipathDoc.Type = "Multipath"
 	MultipathDoc.Comments[encoder.LineComment] = ""
@@ -164,7 +239,7 @@ func init() {
 			FieldName: "neighbors",
 		},
 	}
-	BGPNeighborDoc.Fields = make([]encoder.Doc, 19)
+	BGPNeighborDoc.Fields = make([]encoder.Doc, 27)
 	BGPNeighborDoc.Fields[0].Name = "peer_address"
 	BGPNeighborDoc.Fields[0].Type = "string"
 	BGPNeighborDoc.Fields[0].Note = ""
@@ -235,31 +310,101 @@ func init() {
 	BGPNeighborDoc.Fields[13].Note = ""
 	BGPNeighborDoc.Fields[13].Description = "Configures the client in passive mode"
 	BGPNeighborDoc.Fields[13].Comments[encoder.LineComment] = "Configures the client in passive mode"
-	BGPNeighborDoc.Fields[14].Name = "cluster_id"
+	BGPNeighborDoc.Fields[14].Name = "local_role"
 	BGPNeighborDoc.Fields[14].Type = "string"
 	BGPNeighborDoc.Fields[14].Note = ""
-	BGPNeighborDoc.Fields[14].Description = "Cluster ID for route reflection"
-	BGPNeighborDoc.Fields[14].Comments[encoder.LineComment] = "Cluster ID for route reflection"
-	BGPNeighborDoc.Fields[15].Name = "ipv4"
-	BGPNeighborDoc.Fields[15].Type = "AddressFamilyConfig"
+	BGPNeighborDoc.Fields[14].Description = "Our role on the session to the peer (RFC9234)\nEnables the BGP Role capability, the Only to Customer attribute and the ASPA verification of the AS paths\nof received routes. Available options: provider, customer, peer, rs, rs-client"
+	BGPNeighborDoc.Fields[14].Comments[encoder.LineComment] = "Our role on the session to the peer (RFC9234)"
+	BGPNeighborDoc.Fields[15].Name = "aspa_reject_invalid"
+	BGPNeighborDoc.Fields[15].Type = "bool"
 	BGPNeighborDoc.Fields[15].Note = ""
-	BGPNeighborDoc.Fields[15].Description = "Configuration values for the IPv4 AFI family"
-	BGPNeighborDoc.Fields[15].Comments[encoder.LineComment] = "Configuration values for the IPv4 AFI family"
-	BGPNeighborDoc.Fields[16].Name = "ipv6"
-	BGPNeighborDoc.Fields[16].Type = "AddressFamilyConfig"
+	BGPNeighborDoc.Fields[15].Description = "Hide received routes failing the ASPA verification. Requires local_role to be set"
+	BGPNeighborDoc.Fields[15].Comments[encoder.LineComment] = "Hide received routes failing the ASPA verification. Requires local_role to be set"
+	BGPNeighborDoc.Fields[16].Name = "cluster_id"
+	BGPNeighborDoc.Fields[16].Type = "string"
 	BGPNeighborDoc.Fields[16].Note = ""
-	BGPNeighborDoc.Fields[16].Description = "Configuration values for the IPv6 AFI family"
-	BGPNeighborDoc.Fields[16].Comments[encoder.LineComment] = "Configuration values for the IPv6 AFI family"
-	BGPNeighborDoc.Fields[17].Name = "advertise_ipv4_multiprotocol"
-	BGPNeighborDoc.Fields[17].Type = "bool"
+	BGPNeighborDoc.Fields[16].Description = "Cluster ID for route reflection"
+	BGPNeighborDoc.Fields[16].Comments[encoder.LineComment] = "Cluster ID for route reflection"
+	BGPNeighborDoc.Fields[17].Name = "ipv4"
+	BGPNeighborDoc.Fields[17].Type = "AddressFamilyConfig"
 	BGPNeighborDoc.Fields[17].Note = ""
-	BGPNeighborDoc.Fields[17].Description = "Advertise the multiprotocol capability for the IPv4 AFI"
-	BGPNeighborDoc.Fields[17].Comments[encoder.LineComment] = "Advertise the multiprotocol capability for the IPv4 AFI"
-	BGPNeighborDoc.Fields[18].Name = "routing_instance"
-	BGPNeighborDoc.Fields[18].Type = "string"
+	BGPNeighborDoc.Fields[17].Description = "Configuration values for the IPv4 AFI family"
+	BGPNeighborDoc.Fields[17].Comments[encoder.LineComment] = "Configuration values for the IPv4 AFI family"
+	BGPNeighborDoc.Fields[18].Name = "ipv6"
+	BGPNeighborDoc.Fields[18].Type = "AddressFamilyConfig"
 	BGPNeighborDoc.Fields[18].Note = ""
-	BGPNeighborDoc.Fields[18].Description = "Name of the routing instance this groups belongs to"
-	BGPNeighborDoc.Fields[18].Comments[encoder.LineComment] = "Name of the routing instance this groups belongs to"
+	BGPNeighborDoc.Fields[18].Description = "Configuration values for the IPv6 AFI family"
+	BGPNeighborDoc.Fields[18].Comments[encoder.LineComment] = "Configuration values for the IPv6 AFI family"
+	BGPNeighborDoc.Fields[19].Name = "vpnv4"
+	BGPNeighborDoc.Fields[19].Type = "AddressFamilyConfig"
+	BGPNeighborDoc.Fields[19].Note = ""
+	BGPNeighborDoc.Fields[19].Description = "Configuration values for the VPNv4 (BGP/MPLS IP VPN, RFC4364) family"
+	BGPNeighborDoc.Fields[19].Comments[encoder.LineComment] = "Configuration values for the VPNv4 (BGP/MPLS IP VPN, RFC4364) family"
+	BGPNeighborDoc.Fields[20].Name = "vpnv6"
+	BGPNeighborDoc.Fields[20].Type = "AddressFamilyConfig"
+	BGPNeighborDoc.Fields[20].Note = ""
+	BGPNeighborDoc.Fields[20].Description = "Configuration values for the VPNv6 (BGP/MPLS IPv6 VPN, RFC4659) family"
+	BGPNeighborDoc.Fields[20].Comments[encoder.LineComment] = "Configuration values for the VPNv6 (BGP/MPLS IPv6 VPN, RFC4659) family"
+	BGPNeighborDoc.Fields[21].Name = "flowspec"
+	BGPNeighborDoc.Fields[21].Type = "AddressFamilyConfig"
+	BGPNeighborDoc.Fields[21].Note = ""
+	BGPNeighborDoc.Fields[21].Description = "Configuration values for the IPv4 FlowSpec (RFC8955) family"
+	BGPNeighborDoc.Fields[21].Comments[encoder.LineComment] = "Configuration values for the IPv4 FlowSpec (RFC8955) family"
+	BGPNeighborDoc.Fields[22].Name = "flowspec6"
+	BGPNeighborDoc.Fields[22].Type = "AddressFamilyConfig"
+	BGPNeighborDoc.Fields[22].Note = ""
+	BGPNeighborDoc.Fields[22].Description = "Configuration values for the IPv6 FlowSpec (RFC8956) family"
+	BGPNeighborDoc.Fields[22].Comments[encoder.LineComment] = "Configuration values for the IPv6 FlowSpec (RFC8956) family"
+	BGPNeighborDoc.Fields[23].Name = "advertise_ipv4_multiprotocol"
+	BGPNeighborDoc.Fields[23].Type = "bool"
+	BGPNeighborDoc.Fields[23].Note = ""
+	BGPNeighborDoc.Fields[23].Description = "Advertise the multiprotocol capability for the IPv4 AFI"
+	BGPNeighborDoc.Fields[23].Comments[encoder.LineComment] = "Advertise the multiprotocol capability for the IPv4 AFI"
+	BGPNeighborDoc.Fields[24].Name = "graceful_restart"
+	BGPNeighborDoc.Fields[24].Type = "GracefulRestartConfig"
+	BGPNeighborDoc.Fields[24].Note = ""
+	BGPNeighborDoc.Fields[24].Description = "Graceful Restart (RFC4724) configuration"
+	BGPNeighborDoc.Fields[24].Comments[encoder.LineComment] = "Graceful Restart (RFC4724) configuration"
+	BGPNeighborDoc.Fields[25].Name = "bfd"
+	BGPNeighborDoc.Fields[25].Type = "BFDConfig"
+	BGPNeighborDoc.Fields[25].Note = ""
+	BGPNeighborDoc.Fields[25].Description = "Detect failures of the forwarding path to the neighbors with BFD"
+	BGPNeighborDoc.Fields[25].Comments[encoder.LineComment] = "Detect failures of the forwarding path to the neighbors with BFD"
+	BGPNeighborDoc.Fields[26].Name = "routing_instance"
+	BGPNeighborDoc.Fields[26].Type = "string"
+	BGPNeighborDoc.Fields[26].Note = ""
+	BGPNeighborDoc.Fields[26].Description = "Name of the routing instance this groups belongs to"
+	BGPNeighborDoc.Fields[26].Comments[encoder.LineComment] = "Name of the routing instance this groups belongs to"
+
+	GracefulRestartConfigDoc.Type = "GracefulRestartConfig"
+	GracefulRestartConfigDoc.Comments[encoder.LineComment] = ""
+	GracefulRestartConfigDoc.Description = ""
+	GracefulRestartConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "BGPGroup",
+			FieldName: "graceful_restart",
+		},
+		{
+			TypeName:  "BGPNeighbor",
+			FieldName: "graceful_restart",
+		},
+	}
+	GracefulRestartConfigDoc.Fields = make([]encoder.Doc, 3)
+	GracefulRestartConfigDoc.Fields[0].Name = "enabled"
+	GracefulRestartConfigDoc.Fields[0].Type = "bool"
+	GracefulRestartConfigDoc.Fields[0].Note = ""
+	GracefulRestartConfigDoc.Fields[0].Description = "Enable Graceful Restart"
+	GracefulRestartConfigDoc.Fields[0].Comments[encoder.LineComment] = "Enable Graceful Restart"
+	GracefulRestartConfigDoc.Fields[1].Name = "restart_time"
+	GracefulRestartConfigDoc.Fields[1].Type = "uint16"
+	GracefulRestartConfigDoc.Fields[1].Note = ""
+	GracefulRestartConfigDoc.Fields[1].Description = "Time in seconds the peer should keep our routes while we restart (default: 120, maximum: 4095)"
+	GracefulRestartConfigDoc.Fields[1].Comments[encoder.LineComment] = "Time in seconds the peer should keep our routes while we restart (default: 120, maximum: 4095)"
+	GracefulRestartConfigDoc.Fields[2].Name = "stale_routes_time"
+	GracefulRestartConfigDoc.Fields[2].Type = "uint16"
+	GracefulRestartConfigDoc.Fields[2].Note = ""
+	GracefulRestartConfigDoc.Fields[2].Description = "Time in seconds routes of a restarted peer are kept until it sent End-of-RIB (default: 360)"
+	GracefulRestartConfigDoc.Fields[2].Comments[encoder.LineComment] = "Time in seconds routes of a restarted peer are kept until it sent End-of-RIB (default: 360)"
 
 	AddressFamilyConfigDoc.Type = "AddressFamilyConfig"
 	AddressFamilyConfigDoc.Comments[encoder.LineComment] = ""
@@ -273,6 +418,22 @@ func init() {
 			TypeName:  "BGPGroup",
 			FieldName: "ipv6",
 		},
+		{
+			TypeName:  "BGPGroup",
+			FieldName: "vpnv4",
+		},
+		{
+			TypeName:  "BGPGroup",
+			FieldName: "vpnv6",
+		},
+		{
+			TypeName:  "BGPGroup",
+			FieldName: "flowspec",
+		},
+		{
+			TypeName:  "BGPGroup",
+			FieldName: "flowspec6",
+		},
 		{
 			TypeName:  "BGPNeighbor",
 			FieldName: "ipv4",
@@ -281,8 +442,24 @@ func init() {
 			TypeName:  "BGPNeighbor",
 			FieldName: "ipv6",
 		},
+		{
+			TypeName:  "BGPNeighbor",
+			FieldName: "vpnv4",
+		},
+		{
+			TypeName:  "BGPNeighbor",
+			FieldName: "vpnv6",
+		},
+		{
+			TypeName:  "BGPNeighbor",
+			FieldName: "flowspec",
+		},
+		{
+			TypeName:  "BGPNeighbor",
+			FieldName: "flowspec6",
+		},
 	}
-	AddressFamilyConfigDoc.Fields = make([]encoder.Doc, 2)
+	AddressFamilyConfigDoc.Fields = make([]encoder.Doc, 4)
 	AddressFamilyConfigDoc.Fields[0].Name = "add_path"
 	AddressFamilyConfigDoc.Fields[0].Type = "AddPathConfig"
 	AddressFamilyConfigDoc.Fields[0].Note = ""
@@ -293,6 +470,78 @@ func init() {
 	AddressFamilyConfigDoc.Fields[1].Note = ""
 	AddressFamilyConfigDoc.Fields[1].Description = "Enable extended next hop for the address family"
 	AddressFamilyConfigDoc.Fields[1].Comments[encoder.LineComment] = "Enable extended next hop for the address family"
+	AddressFamilyConfigDoc.Fields[2].Name = "prefix_limit"
+	AddressFamilyConfigDoc.Fields[2].Type = "PrefixLimitConfig"
+	AddressFamilyConfigDoc.Fields[2].Note = ""
+	AddressFamilyConfigDoc.Fields[2].Description = "Limits the number of paths accepted from the peer"
+	AddressFamilyConfigDoc.Fields[2].Comments[encoder.LineComment] = "Limits the number of paths accepted from the peer"
+	AddressFamilyConfigDoc.Fields[3].Name = "dampening"
+	AddressFamilyConfigDoc.Fields[3].Type = "DampeningConfig"
+	AddressFamilyConfigDoc.Fields[3].Note = ""
+	AddressFamilyConfigDoc.Fields[3].Description = "Suppresses flapping routes received from the peer (RFC2439).\nOnly applies to IPv4/IPv6 unicast"
+	AddressFamilyConfigDoc.Fields[3].Comments[encoder.LineComment] = "Suppresses flapping routes received from the peer (RFC2439)."
+
+	PrefixLimitConfigDoc.Type = "PrefixLimitConfig"
+	PrefixLimitConfigDoc.Comments[encoder.LineComment] = ""
+	PrefixLimitConfigDoc.Description = ""
+	PrefixLimitConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "AddressFamilyConfig",
+			FieldName: "prefix_limit",
+		},
+	}
+	PrefixLimitConfigDoc.Fields = make([]encoder.Doc, 4)
+	PrefixLimitConfigDoc.Fields[0].Name = "max"
+	PrefixLimitConfigDoc.Fields[0].Type = "uint64"
+	PrefixLimitConfigDoc.Fields[0].Note = ""
+	PrefixLimitConfigDoc.Fields[0].Description = "Maximum number of paths accepted from the peer"
+	PrefixLimitConfigDoc.Fields[0].Comments[encoder.LineComment] = "Maximum number of paths accepted from the peer"
+	PrefixLimitConfigDoc.Fields[1].Name = "warning_threshold"
+	PrefixLimitConfigDoc.Fields[1].Type = "uint8"
+	PrefixLimitConfigDoc.Fields[1].Note = ""
+	PrefixLimitConfigDoc.Fields[1].Description = "Percentage of max at which a warning is logged (default: 75)"
+	PrefixLimitConfigDoc.Fields[1].Comments[encoder.LineComment] = "Percentage of max at which a warning is logged (default: 75)"
+	PrefixLimitConfigDoc.Fields[2].Name = "log_only"
+	PrefixLimitConfigDoc.Fields[2].Type = "bool"
+	PrefixLimitConfigDoc.Fields[2].Note = ""
+	PrefixLimitConfigDoc.Fields[2].Description = "Only log exceeding the limit instead of tearing down the session"
+	PrefixLimitConfigDoc.Fields[2].Comments[encoder.LineComment] = "Only log exceeding the limit instead of tearing down the session"
+	PrefixLimitConfigDoc.Fields[3].Name = "restart_time"
+	PrefixLimitConfigDoc.Fields[3].Type = "uint32"
+	PrefixLimitConfigDoc.Fields[3].Note = ""
+	PrefixLimitConfigDoc.Fields[3].Description = "Time in seconds the session is held down after it was torn down.\nIf not set the session stays down until the limit is changed"
+	PrefixLimitConfigDoc.Fields[3].Comments[encoder.LineComment] = "Time in seconds the session is held down after it was torn down."
+
+	DampeningConfigDoc.Type = "DampeningConfig"
+	DampeningConfigDoc.Comments[encoder.LineComment] = ""
+	DampeningConfigDoc.Description = ""
+	DampeningConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "AddressFamilyConfig",
+			FieldName: "dampening",
+		},
+	}
+	DampeningConfigDoc.Fields = make([]encoder.Doc, 4)
+	DampeningConfigDoc.Fields[0].Name = "half_life"
+	DampeningConfigDoc.Fields[0].Type = "uint32"
+	DampeningConfigDoc.Fields[0].Note = ""
+	DampeningConfigDoc.Fields[0].Description = "Time in seconds after which the figure of merit of a route has decayed by half (default: 900)"
+	DampeningConfigDoc.Fields[0].Comments[encoder.LineComment] = "Time in seconds after which the figure of merit of a route has decayed by half (default: 900)"
+	DampeningConfigDoc.Fields[1].Name = "reuse_threshold"
+	DampeningConfigDoc.Fields[1].Type = "uint32"
+	DampeningConfigDoc.Fields[1].Note = ""
+	DampeningConfigDoc.Fields[1].Description = "Figure of merit below which a suppressed route is reused (default: 750)"
+	DampeningConfigDoc.Fields[1].Comments[encoder.LineComment] = "Figure of merit below which a suppressed route is reused (default: 750)"
+	DampeningConfigDoc.Fields[2].Name = "suppress_threshold"
+	DampeningConfigDoc.Fields[2].Type = "uint32"
+	DampeningConfigDoc.Fields[2].Note = ""
+	DampeningConfigDoc.Fields[2].Description = "Figure of merit above which a route is suppressed (default: 6000)"
+	DampeningConfigDoc.Fields[2].Comments[encoder.LineComment] = "Figure of merit above which a route is suppressed (default: 6000)"
+	DampeningConfigDoc.Fields[3].Name = "max_suppress_time"
+	DampeningConfigDoc.Fields[3].Type = "uint32"
+	DampeningConfigDoc.Fields[3].Note = ""
+	DampeningConfigDoc.Fields[3].Description = "Maximum time in seconds a route is suppressed after it stopped flapping (default: 3600)"
+	DampeningConfigDoc.Fields[3].Comments[encoder.LineComment] = "Maximum time in seconds a route is suppressed after it stopped flapping (default: 3600)"
 
 	AddPathConfigDoc.Type = "AddPathConfig"
 	AddPathConfigDoc.Comments[encoder.LineComment] = ""
@@ -345,6 +594,10 @@ func (_ BGPGroup) Doc() *encoder.Doc {
 	return &BGPGroupDoc
 }
 
+func (_ BGPDynamicNeighbors) Doc() *encoder.Doc {
+	return &BGPDynamicNeighborsDoc
+}
+
 func (_ Multipath) Doc() *encoder.Doc {
 	return &MultipathDoc
 }
@@ -353,10 +606,22 @@ func (_ BGPNeighbor) Doc() *encoder.Doc {
 	return &BGPNeighborDoc
 }
 
+func (_ GracefulRestartConfig) Doc() *encoder.Doc {
+	return &GracefulRestartConfigDoc
+}
+
 func (_ AddressFamilyConfig) Doc() *encoder.Doc {
 	return &AddressFamilyConfigDoc
 }
 
+func (_ PrefixLimitConfig) Doc() *encoder.Doc {
+	return &PrefixLimitConfigDoc
+}
+
+func (_ DampeningConfig) Doc() *encoder.Doc {
+	return &DampeningConfigDoc
+}
+
 func (_ AddPathConfig) Doc() *encoder.Doc {
 	return &AddPathConfigDoc
 }
@@ -373,9 +638,13 @@ func GetbgpDoc() *encoder.FileDoc {
 		Structs: []*encoder.Doc{
 			&BGPDoc,
 			&BGPGroupDoc,
+			&BGPDynamicNeighborsDoc,
 			&MultipathDoc,
 			&BGPNeighborDoc,
+			&GracefulRestartConfigDoc,
 			&AddressFamilyConfigDoc,
+			&PrefixLimitConfigDoc,
+			&DampeningConfigDoc,
 			&AddPathConfigDoc,
 			&AddPathSendConfigDoc,
 		},
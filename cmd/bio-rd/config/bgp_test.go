@@ -5,6 +5,7 @@ import (
 	"time"
 
 	bnet "github.com/bio-routing/bio-rd/net"
+	bgpserver "github.com/bio-routing/bio-rd/protocols/bgp/server"
 	"github.com/bio-routing/bio-rd/routingtable/filter"
 
 	"github.com/stretchr/testify/assert"
@@ -28,6 +29,12 @@ groups:
     import: ["ACCEPT_ALL"]
     export: ["REJECT_ALL"]
     cluster_id: 100.65.1.1
+    local_role: provider
+    aspa_reject_invalid: true
+    graceful_restart:
+      enabled: true
+    bfd:
+      min_interval: 100
     neighbors:
       - peer_address: 100.64.0.2
         cluster_id: 100.64.0.0
@@ -38,6 +45,10 @@ groups:
             send:
               path_count: 5
           next_hop_extended: true
+          prefix_limit:
+            max: 1000
+            warning_threshold: 90
+            restart_time: 300
       - peer_address: 100.64.1.2
         local_address: 100.64.1.1
         local_as: 65400
@@ -51,6 +62,15 @@ groups:
         passive: false
         route_reflector_client: false
         route_server_client: false
+        local_role: rs-client
+        aspa_reject_invalid: false
+        graceful_restart:
+          enabled: true
+          restart_time: 60
+          stale_routes_time: 180
+        bfd:
+          multiplier: 5
+          multihop: true
         ipv6:
           add_path:
             receive: true
@@ -61,6 +81,9 @@ groups:
         receive: false
         send:
           path_count: 10
+      prefix_limit:
+        max: 500
+        log_only: true
   `
 )
 
@@ -111,6 +134,27 @@ func TestBGPLoad(t *testing.T) {
 	assert.Equal(t, uint8(10), n1.IPv6.AddPath.Send.PathCount, "neighbor 1 IPv6 add path send count")
 	assert.True(t, n1.Disabled, "neighbor 1 disabled")
 	assert.True(t, n1.IPv4.NextHopExtended, "neighbor 1 IPv4 extended next hop")
+	assert.Equal(t, &PrefixLimitConfig{
+		Max:                 1000,
+		WarningThreshold:    90,
+		RestartTime:         300,
+		RestartTimeDuration: 300 * time.Second,
+	}, n1.IPv4.PrefixLimit, "neighbor 1 IPv4 prefix limit")
+	assert.Equal(t, &PrefixLimitConfig{
+		Max:              500,
+		WarningThreshold: DefaultPrefixLimitWarningThreshold,
+		LogOnly:          true,
+	}, n1.IPv6.PrefixLimit, "neighbor 1 IPv6 prefix limit")
+	assert.True(t, n1.GracefulRestart.Enabled, "neighbor 1 graceful restart")
+	assert.Equal(t, 120*time.Second, n1.GracefulRestart.RestartTimeDuration, "neighbor 1 graceful restart time")
+	assert.Equal(t, 360*time.Second, n1.GracefulRestart.StaleRoutesTimeDuration, "neighbor 1 graceful restart stale routes time")
+	assert.Equal(t, uint8(bgpserver.PeerConfigRoleProvider), n1.LocalRoleID, "neighbor 1 local role")
+	assert.True(t, *n1.ASPARejectInvalid, "neighbor 1 ASPA reject invalid")
+	assert.Equal(t, &BFDConfig{
+		MinInterval:         100,
+		MinIntervalDuration: 100 * time.Millisecond,
+		Multiplier:          DefaultBFDMultiplier,
+	}, n1.BFD, "neighbor 1 BFD")
 
 	n2 := group.Neighbors[1]
 	assert.Equal(t, bnet.IPv4FromOctets(100, 64, 1, 1).Dedup(), n2.LocalAddressIP, "neighbor 2 local address")
@@ -130,4 +174,316 @@ func TestBGPLoad(t *testing.T) {
 	assert.Nil(t, n2.IPv4, "neighbor 2 IPv4")
 	assert.True(t, n2.IPv6.AddPath.Receive, "neighbor 2 IPv6 add path receive")
 	assert.Equal(t, uint8(2), n2.IPv6.AddPath.Send.PathCount, "neighbor 2 IPv6 add path send count")
+	assert.Nil(t, n2.IPv6.PrefixLimit, "neighbor 2 IPv6 prefix limit")
+	assert.True(t, n2.GracefulRestart.Enabled, "neighbor 2 graceful restart")
+	assert.Equal(t, 60*time.Second, n2.GracefulRestart.RestartTimeDuration, "neighbor 2 graceful restart time")
+	assert.Equal(t, 180*time.Second, n2.GracefulRestart.StaleRoutesTimeDuration, "neighbor 2 graceful restart stale routes time")
+	assert.Equal(t, uint8(bgpserver.PeerConfigRoleRSClient), n2.LocalRoleID, "neighbor 2 local role")
+	assert.False(t, *n2.ASPARejectInvalid, "neighbor 2 ASPA reject invalid")
+	assert.Equal(t, &BFDConfig{
+		MinInterval:         DefaultBFDMinIntervalMilliseconds,
+		MinIntervalDuration: 300 * time.Millisecond,
+		Multiplier:          5,
+		Multihop:            true,
+	}, n2.BFD, "neighbor 2 BFD")
+}
+
+func TestBGPNeighborLoadLocalRole(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *BGPNeighbor
+		wantFail bool
+		expected uint8
+	}{
+		{
+			name: "no role",
+			input: &BGPNeighbor{
+				PeerAddress: "192.0.2.1",
+				PeerAS:      65001,
+			},
+			expected: bgpserver.PeerConfigRoleOff,
+		},
+		{
+			name: "customer",
+			input: &BGPNeighbor{
+				PeerAddress: "192.0.2.1",
+				PeerAS:      65001,
+				LocalRole:   "customer",
+			},
+			expected: bgpserver.PeerConfigRoleCustomer,
+		},
+		{
+			name: "invalid role",
+			input: &BGPNeighbor{
+				PeerAddress: "192.0.2.1",
+				PeerAS:      65001,
+				LocalRole:   "upstream",
+			},
+			wantFail: true,
+		},
+		{
+			name: "ASPA reject invalid without role",
+			input: &BGPNeighbor{
+				PeerAddress:       "192.0.2.1",
+				PeerAS:            65001,
+				ASPARejectInvalid: boolPtr(true),
+			},
+			wantFail: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.input.load(&PolicyOptions{})
+			if test.wantFail {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, test.input.LocalRoleID)
+		})
+	}
+}
+
+func TestPrefixLimitConfigLoad(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *PrefixLimitConfig
+		wantFail bool
+		expected *PrefixLimitConfig
+	}{
+		{
+			name:  "defaults",
+			input: &PrefixLimitConfig{Max: 100},
+			expected: &PrefixLimitConfig{
+				Max:              100,
+				WarningThreshold: DefaultPrefixLimitWarningThreshold,
+			},
+		},
+		{
+			name:  "restart time",
+			input: &PrefixLimitConfig{Max: 100, WarningThreshold: 50, RestartTime: 60},
+			expected: &PrefixLimitConfig{
+				Max:                 100,
+				WarningThreshold:    50,
+				RestartTime:         60,
+				RestartTimeDuration: time.Minute,
+			},
+		},
+		{
+			name:     "no max",
+			input:    &PrefixLimitConfig{LogOnly: true},
+			wantFail: true,
+		},
+		{
+			name:     "warning threshold exceeds 100 percent",
+			input:    &PrefixLimitConfig{Max: 100, WarningThreshold: 101},
+			wantFail: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.input.load()
+			if test.wantFail {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, test.input)
+		})
+	}
+}
+
+func TestDampeningConfigLoad(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *DampeningConfig
+		wantFail bool
+		expected *DampeningConfig
+	}{
+		{
+			name:  "defaults",
+			input: &DampeningConfig{},
+			expected: &DampeningConfig{
+				HalfLife:                900,
+				HalfLifeDuration:        15 * time.Minute,
+				ReuseThreshold:          750,
+				SuppressThreshold:       6000,
+				MaxSuppressTime:         3600,
+				MaxSuppressTimeDuration: time.Hour,
+			},
+		},
+		{
+			name: "custom",
+			input: &DampeningConfig{
+				HalfLife:          300,
+				ReuseThreshold:    1000,
+				SuppressThreshold: 3000,
+				MaxSuppressTime:   1200,
+			},
+			expected: &DampeningConfig{
+				HalfLife:                300,
+				HalfLifeDuration:        5 * time.Minute,
+				ReuseThreshold:          1000,
+				SuppressThreshold:       3000,
+				MaxSuppressTime:         1200,
+				MaxSuppressTimeDuration: 20 * time.Minute,
+			},
+		},
+		{
+			name: "reuse threshold exceeds suppress threshold",
+			input: &DampeningConfig{
+				ReuseThreshold:    2000,
+				SuppressThreshold: 1500,
+			},
+			wantFail: true,
+		},
+		{
+			name: "max suppress time too short",
+			input: &DampeningConfig{
+				MaxSuppressTime: 900,
+			},
+			wantFail: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.input.load()
+			if test.wantFail {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, test.input)
+		})
+	}
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func TestBGPDynamicNeighborsLoad(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		wantFail bool
+		expected *BGPDynamicNeighbors
+	}{
+		{
+			name: "AS ranges",
+			input: `
+name: k8s
+local_as: 65000
+hold_time: 30
+dynamic_neighbors:
+  prefix: 10.0.0.0/16
+  peer_as_range: [65100, "65200-65299"]
+  max: 500
+`,
+			expected: &BGPDynamicNeighbors{
+				Prefix:      "10.0.0.0/16",
+				PrefixNet:   bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 0), 16).Dedup(),
+				PeerASRange: []string{"65100", "65200-65299"},
+				PeerASRanges: []bgpserver.ASNRange{
+					{From: 65100, To: 65100},
+					{From: 65200, To: 65299},
+				},
+				Max: 500,
+			},
+		},
+		{
+			name: "peer AS of the group",
+			input: `
+name: k8s
+local_as: 65000
+peer_as: 65100
+dynamic_neighbors:
+  prefix: 2001:db8::/32
+`,
+			expected: &BGPDynamicNeighbors{
+				Prefix:    "2001:db8::/32",
+				PrefixNet: bnet.NewPfx(bnet.IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 0), 32).Dedup(),
+				PeerASRanges: []bgpserver.ASNRange{
+					{From: 65100, To: 65100},
+				},
+			},
+		},
+		{
+			name: "no peer AS",
+			input: `
+name: k8s
+local_as: 65000
+dynamic_neighbors:
+  prefix: 10.0.0.0/16
+`,
+			wantFail: true,
+		},
+		{
+			name: "invalid AS range",
+			input: `
+name: k8s
+local_as: 65000
+dynamic_neighbors:
+  prefix: 10.0.0.0/16
+  peer_as_range: ["65299-65200"]
+`,
+			wantFail: true,
+		},
+		{
+			name: "authentication",
+			input: `
+name: k8s
+local_as: 65000
+peer_as: 65100
+authentication_key: secret
+dynamic_neighbors:
+  prefix: 10.0.0.0/16
+`,
+			wantFail: true,
+		},
+		{
+			name: "local role with iBGP sessions",
+			input: `
+name: k8s
+local_as: 65000
+local_role: customer
+dynamic_neighbors:
+  prefix: 10.0.0.0/16
+  peer_as_range: ["64512-65534"]
+`,
+			wantFail: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var bg *BGPGroup
+			err := yaml.Unmarshal([]byte(test.input), &bg)
+			if err != nil {
+				t.Fatalf("unexpected error while parsing: %s", err)
+			}
+
+			err = bg.load(0, &PolicyOptions{})
+			if test.wantFail {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+
+			n := bg.DynamicNeighbors.Neighbor
+			bg.DynamicNeighbors.Neighbor = nil
+			assert.Equal(t, test.expected, bg.DynamicNeighbors)
+			assert.True(t, *n.Passive, "template passive")
+			assert.Equal(t, uint32(65000), n.LocalAS, "template local ASN")
+			assert.Equal(t, time.Second*time.Duration(bg.HoldTime), n.HoldTimeDuration, "template hold time")
+			assert.Equal(t, test.expected.PrefixNet.Addr(), *n.PeerAddressIP, "template address")
+		})
+	}
 }
@@ -58,15 +58,15 @@ func (c *Config) load() error {
 		}
 	}
 
-	err := c.RoutingOptions.load()
+	err := c.RoutingOptions.load(c.PolicyOptions)
 	if err != nil {
 		return fmt.Errorf("error in routing_options: %w", err)
 	}
 
 	for _, ri := range c.RoutingInstances {
-		err := ri.load()
-		if ri != nil {
-			return err
+		err := ri.load(c.PolicyOptions)
+		if err != nil {
+			return fmt.Errorf("error in routing_instance %q: %w", ri.Name, err)
 		}
 	}
 
@@ -1,10 +1,29 @@
 package config
 
+import (
+	"fmt"
+	"time"
+
+	isisserver "github.com/bio-routing/bio-rd/protocols/isis/server"
+	"github.com/bio-routing/bio-rd/routingtable/filter"
+)
+
 const (
 	defaultHelloInterval      = 9
 	defaultHoldTime           = 27
+	defaultPriority           = 64
 	lspMinLifetime            = 350
 	lspDefaultLifetimeSeconds = 1200
+	defaultSPFInitialWait     = 50
+	defaultSPFSecondaryWait   = 200
+	defaultSPFMaxWait         = 5000
+	defaultSRGBBase           = 16000
+	defaultSRGBSize           = 8000
+	defaultSRLBBase           = 15000
+	defaultSRLBSize           = 1000
+	defaultOverloadOnStartup  = 600
+	minUnreservedLabel        = 16
+	maxLabel                  = 1048575
 )
 
 // ISIS config
@@ -25,6 +44,70 @@ type ISIS struct {
 	//   Amount of time a link-state PDU should persist in the network
 	//   Expressed in seconds
 	LSPLifetime uint16 `yaml:"lsp_lifetime"`
+	// description: |
+	//   SPF scheduling timers
+	SPF *ISISSPF `yaml:"spf"`
+	// description: |
+	//   Enables multi topology routing (RFC5120) using a separate topology for IPv6 unicast
+	MultiTopology bool `yaml:"multi_topology"`
+	// description: |
+	//   Enables segment routing (RFC8667)
+	SegmentRouting *ISISSegmentRouting `yaml:"segment_routing"`
+	// description: |
+	//   Sets the overload bit so this router is not used for transit traffic
+	Overload *ISISOverload `yaml:"overload"`
+}
+
+// ISISSPF SPF scheduling config
+type ISISSPF struct {
+	// description: |
+	//   Delay of the first SPF run after a quiet period
+	//   Expressed in milliseconds
+	InitialWait uint32 `yaml:"initial_wait"`
+	// description: |
+	//   Delay of the second SPF run. Doubled on every subsequent run up to max_wait
+	//   Expressed in milliseconds
+	SecondaryWait uint32 `yaml:"secondary_wait"`
+	// description: |
+	//   Maximum delay of SPF runs. The delay is reset to initial_wait after no SPF run for this period
+	//   Expressed in milliseconds
+	MaxWait uint32 `yaml:"max_wait"`
+}
+
+// ISISSegmentRouting segment routing config
+type ISISSegmentRouting struct {
+	// description: |
+	//   First label of the SR global block
+	//   Default: 16000
+	SRGBBase uint32 `yaml:"srgb_base"`
+	// description: |
+	//   Number of labels of the SR global block
+	//   Default: 8000
+	SRGBSize uint32 `yaml:"srgb_size"`
+	// description: |
+	//   First label of the SR local block adjacency SIDs are allocated from
+	//   Default: 15000
+	SRLBBase uint32 `yaml:"srlb_base"`
+	// description: |
+	//   Number of labels of the SR local block
+	//   Default: 1000
+	SRLBSize uint32 `yaml:"srlb_size"`
+}
+
+// ISISOverload overload bit config
+type ISISOverload struct {
+	// description: |
+	//   Sets the overload bit permanently
+	Set bool `yaml:"set"`
+	// description: |
+	//   Sets the overload bit after startup for this period
+	//   Expressed in seconds
+	//   Default: 600 if wait_for_bgp is enabled
+	OnStartup uint32 `yaml:"on_startup"`
+	// description: |
+	//   Clears the startup overload bit once all BGP sessions are established and received the End-of-RIB marker
+	//   The overload bit is cleared after on_startup at the latest
+	WaitForBGP bool `yaml:"wait_for_bgp"`
 }
 
 // ISISLevel level config
@@ -33,13 +116,25 @@ type ISISLevel struct {
 	//   Disables this level for the instance
 	Disable bool `yaml:"disable"`
 	// description: |
-	//   Password for authentication
+	//   Key for authentication
 	AuthenticationKey string `yaml:"authentication_key"`
 	// description: |
+	//   Key ID of authentication_key. Sent with HMAC-SHA-256 authenticated PDUs
+	AuthenticationKeyID uint16 `yaml:"authentication_key_id"`
+	// description: |
+	//   Authentication algorithm of authentication_key: cleartext, hmac-md5 or hmac-sha-256
+	//   Default: hmac-md5
+	AuthenticationType string `yaml:"authentication_type"`
+	// description: |
+	//   Keys used in addition to authentication_key. Allows rolling over keys
+	AuthenticationKeyChain []*ISISAuthenticationKey `yaml:"authentication_key_chain"`
+	// docgen:nodoc
+	KeyChain isisserver.KeyChain
+	// description: |
 	//   Disable authentication for the Complete Sequence Number PDUs
 	NoCSNPAuthentication bool `yaml:"no_csnp_authentication"`
 	// description: |
-	//   DIsable authentication for hello messages
+	//   Disable authentication for hello messages
 	NoHelloAuthentication bool `yaml:"no_hello_authentication"`
 	// description: |
 	//   Disable authentication for the Partial Sequence Number PDUs
@@ -47,6 +142,12 @@ type ISISLevel struct {
 	// description: |
 	//   Enable sending and receiving wide metrics only for this level
 	WideMetricsOnly bool `yaml:"wide_metrics_only"`
+	// description: |
+	//   Policies selecting the level 2 routes leaked into level 1 with the up/down bit set (RFC5302)
+	//   Valid for level1 only
+	RouteLeaking []string `yaml:"route_leaking"`
+	// docgen:nodoc
+	RouteLeakingFilterChain filter.Chain
 }
 
 // ISISInterface interface config
@@ -66,6 +167,13 @@ type ISISInterface struct {
 	// description: |
 	//   Level 2 configuration parameters for the interface
 	Level2 *ISISInterfaceLevel `yaml:"level2"`
+	// description: |
+	//   Prefix SID index advertised as node SID with the first /32 address of the interface (usually a loopback)
+	//   Requires segment_routing. Must be smaller than srgb_size
+	PrefixSIDIndex *uint32 `yaml:"prefix_sid_index"`
+	// description: |
+	//   Detect failures of the adjacencies on this interface with BFD
+	BFD *BFDConfig `yaml:"bfd"`
 }
 
 // ISISInterfaceLevel interface level config
@@ -88,9 +196,230 @@ type ISISInterfaceLevel struct {
 	//   Configures interface as passive
 	Passive bool `yaml:"passive"`
 	// description: |
-	//   Configures the device priority to become a designated router for this level
+	//   Configures the device priority to become the designated intermediate system on broadcast interfaces for this level
 	//   Value range: 0-127
-	Priority uint8 `yaml:"priority"`
+	//   Default: 64
+	Priority *uint8 `yaml:"priority"`
+	// description: |
+	//   Keys authenticating hellos on this interface. Overrides the hello authentication of the level
+	HelloAuthenticationKeyChain []*ISISAuthenticationKey `yaml:"hello_authentication_key_chain"`
+	// docgen:nodoc
+	HelloKeyChain isisserver.KeyChain
+}
+
+// ISISAuthenticationKey authentication key config
+type ISISAuthenticationKey struct {
+	// description: |
+	//   Key ID. Sent with HMAC-SHA-256 authenticated PDUs
+	ID uint16 `yaml:"id"`
+	// description: |
+	//   Secret key
+	Key string `yaml:"key"`
+	// description: |
+	//   Authentication algorithm: cleartext, hmac-md5 or hmac-sha-256
+	//   Default: hmac-md5
+	Type string `yaml:"type"`
+	// description: |
+	//   Time the key becomes valid in RFC3339 format. The valid key with the latest start time is used for sending
+	StartTime string `yaml:"start_time"`
+	// description: |
+	//   Time the key expires in RFC3339 format
+	EndTime string `yaml:"end_time"`
+}
+
+func (i *ISIS) load(policyOptions *PolicyOptions) error {
+	i.loadDefaults()
+
+	for _, l := range []*ISISLevel{i.Level1, i.Level2} {
+		if l == nil {
+			continue
+		}
+
+		err := l.loadKeyChain()
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, ifa := range i.Interfaces {
+		for _, l := range []*ISISInterfaceLevel{ifa.Level1, ifa.Level2} {
+			if l == nil || l.HelloAuthenticationKeyChain == nil {
+				continue
+			}
+
+			kc, err := loadKeyChain(l.HelloAuthenticationKeyChain)
+			if err != nil {
+				return fmt.Errorf("interface %q: %w", ifa.Name, err)
+			}
+
+			l.HelloKeyChain = kc
+		}
+
+		if ifa.BFD != nil {
+			err := ifa.BFD.load()
+			if err != nil {
+				return fmt.Errorf("interface %q: %w", ifa.Name, err)
+			}
+
+			if ifa.BFD.Multihop {
+				return fmt.Errorf("interface %q: multihop BFD is not supported for IS-IS", ifa.Name)
+			}
+		}
+	}
+
+	err := i.validateSegmentRouting()
+	if err != nil {
+		return err
+	}
+
+	if i.Level2 != nil && len(i.Level2.RouteLeaking) > 0 {
+		return fmt.Errorf("route leaking is only supported for level1")
+	}
+
+	if i.Level1 == nil {
+		return nil
+	}
+
+	for _, name := range i.Level1.RouteLeaking {
+		f := policyOptions.getPolicyStatementFilter(name)
+		if f == nil {
+			return fmt.Errorf("policy statement %q undefined", name)
+		}
+
+		i.Level1.RouteLeakingFilterChain = append(i.Level1.RouteLeakingFilterChain, f)
+	}
+
+	return nil
+}
+
+func (i *ISIS) validateSegmentRouting() error {
+	indexes := make(map[uint32]string)
+	for _, ifa := range i.Interfaces {
+		if ifa.PrefixSIDIndex == nil {
+			continue
+		}
+
+		if i.SegmentRouting == nil {
+			return fmt.Errorf("interface %q: prefix_sid_index requires segment_routing", ifa.Name)
+		}
+
+		idx := *ifa.PrefixSIDIndex
+		if idx >= i.SegmentRouting.SRGBSize {
+			return fmt.Errorf("interface %q: prefix_sid_index %d exceeds SRGB size %d", ifa.Name, idx, i.SegmentRouting.SRGBSize)
+		}
+
+		if other, found := indexes[idx]; found {
+			return fmt.Errorf("interface %q: prefix_sid_index %d already used by interface %q", ifa.Name, idx, other)
+		}
+
+		indexes[idx] = ifa.Name
+	}
+
+	if i.SegmentRouting == nil {
+		return nil
+	}
+
+	return i.SegmentRouting.validate()
+}
+
+func (s *ISISSegmentRouting) validate() error {
+	err := validateLabelBlock("SRGB", s.SRGBBase, s.SRGBSize)
+	if err != nil {
+		return err
+	}
+
+	err = validateLabelBlock("SRLB", s.SRLBBase, s.SRLBSize)
+	if err != nil {
+		return err
+	}
+
+	if s.SRGBBase < s.SRLBBase+s.SRLBSize && s.SRLBBase < s.SRGBBase+s.SRGBSize {
+		return fmt.Errorf("SRGB and SRLB overlap")
+	}
+
+	return nil
+}
+
+func validateLabelBlock(name string, base uint32, size uint32) error {
+	if base < minUnreservedLabel || uint64(base)+uint64(size)-1 > maxLabel {
+		return fmt.Errorf("%s %d-%d exceeds label range %d-%d", name, base, uint64(base)+uint64(size)-1, minUnreservedLabel, maxLabel)
+	}
+
+	return nil
+}
+
+func (l *ISISLevel) loadKeyChain() error {
+	keys := l.AuthenticationKeyChain
+	if l.AuthenticationKey != "" {
+		keys = append([]*ISISAuthenticationKey{
+			{
+				ID:   l.AuthenticationKeyID,
+				Key:  l.AuthenticationKey,
+				Type: l.AuthenticationType,
+			},
+		}, keys...)
+	}
+
+	kc, err := loadKeyChain(keys)
+	if err != nil {
+		return err
+	}
+
+	l.KeyChain = kc
+	return nil
+}
+
+func loadKeyChain(keys []*ISISAuthenticationKey) (isisserver.KeyChain, error) {
+	ret := make(isisserver.KeyChain, 0, len(keys))
+	for _, k := range keys {
+		key, err := k.load()
+		if err != nil {
+			return nil, fmt.Errorf("invalid authentication key %d: %w", k.ID, err)
+		}
+
+		ret = append(ret, key)
+	}
+
+	return ret, nil
+}
+
+func (k *ISISAuthenticationKey) load() (*isisserver.AuthenticationKey, error) {
+	if k.Key == "" {
+		return nil, fmt.Errorf("key missing")
+	}
+
+	ret := &isisserver.AuthenticationKey{
+		ID:     k.ID,
+		Secret: []byte(k.Key),
+	}
+
+	switch k.Type {
+	case "cleartext":
+		ret.Algorithm = isisserver.AuthenticationCleartext
+	case "", "hmac-md5":
+		ret.Algorithm = isisserver.AuthenticationHMACMD5
+	case "hmac-sha-256":
+		ret.Algorithm = isisserver.AuthenticationHMACSHA256
+	default:
+		return nil, fmt.Errorf("unknown authentication type %q", k.Type)
+	}
+
+	var err error
+	if k.StartTime != "" {
+		ret.Start, err = time.Parse(time.RFC3339, k.StartTime)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse start time: %w", err)
+		}
+	}
+
+	if k.EndTime != "" {
+		ret.End, err = time.Parse(time.RFC3339, k.EndTime)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse end time: %w", err)
+		}
+	}
+
+	return ret, nil
 }
 
 func (i *ISIS) loadDefaults() {
@@ -102,11 +431,57 @@ func (i *ISIS) loadDefaults() {
 		i.LSPLifetime = lspMinLifetime
 	}
 
+	if i.SPF == nil {
+		i.SPF = &ISISSPF{}
+	}
+
+	i.SPF.loadDefaults()
+
+	if i.SegmentRouting != nil {
+		i.SegmentRouting.loadDefaults()
+	}
+
+	if i.Overload != nil && i.Overload.WaitForBGP && i.Overload.OnStartup == 0 {
+		i.Overload.OnStartup = defaultOverloadOnStartup
+	}
+
 	for _, ifa := range i.Interfaces {
 		ifa.loadDefaults()
 	}
 }
 
+func (s *ISISSPF) loadDefaults() {
+	if s.InitialWait == 0 {
+		s.InitialWait = defaultSPFInitialWait
+	}
+
+	if s.SecondaryWait == 0 {
+		s.SecondaryWait = defaultSPFSecondaryWait
+	}
+
+	if s.MaxWait == 0 {
+		s.MaxWait = defaultSPFMaxWait
+	}
+}
+
+func (s *ISISSegmentRouting) loadDefaults() {
+	if s.SRGBBase == 0 {
+		s.SRGBBase = defaultSRGBBase
+	}
+
+	if s.SRGBSize == 0 {
+		s.SRGBSize = defaultSRGBSize
+	}
+
+	if s.SRLBBase == 0 {
+		s.SRLBBase = defaultSRLBBase
+	}
+
+	if s.SRLBSize == 0 {
+		s.SRLBSize = defaultSRLBSize
+	}
+}
+
 func (i *ISISInterface) loadDefaults() {
 	if i.Level1 != nil {
 		i.Level1.loadDefaults()
@@ -125,6 +500,11 @@ func (i *ISISInterfaceLevel) loadDefaults() {
 	if i.HoldTime == 0 {
 		i.HoldTime = defaultHoldTime
 	}
+
+	if i.Priority == nil {
+		p := uint8(defaultPriority)
+		i.Priority = &p
+	}
 }
 
 func (i *ISIS) InterfaceConfigured(name string) bool {
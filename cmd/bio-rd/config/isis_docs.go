@@ -9,17 +9,21 @@ import (
 )
 
 var (
-	ISISDoc               encoder.Doc
-	ISISLevelDoc          encoder.Doc
-	ISISInterfaceDoc      encoder.Doc
-	ISISInterfaceLevelDoc encoder.Doc
+	ISISDoc                  encoder.Doc
+	ISISSPFDoc               encoder.Doc
+	ISISSegmentRoutingDoc    encoder.Doc
+	ISISOverloadDoc          encoder.Doc
+	ISISLevelDoc             encoder.Doc
+	ISISInterfaceDoc         encoder.Doc
+	ISISInterfaceLevelDoc    encoder.Doc
+	ISISAuthenticationKeyDoc encoder.Doc
 )
 
 func init() {
 	ISISDoc.Type = "ISIS"
 	ISISDoc.Comments[encoder.LineComment] = "ISIS config"
 	ISISDoc.Description = "ISIS config"
-	ISISDoc.Fields = make([]encoder.Doc, 5)
+	ISISDoc.Fields = make([]encoder.Doc, 9)
 	ISISDoc.Fields[0].Name = "NETs"
 	ISISDoc.Fields[0].Type = "[]string"
 	ISISDoc.Fields[0].Note = ""
@@ -45,6 +49,109 @@ func init() {
 	ISISDoc.Fields[4].Note = ""
 	ISISDoc.Fields[4].Description = "Amount of time a link-state PDU should persist in the network\nExpressed in seconds"
 	ISISDoc.Fields[4].Comments[encoder.LineComment] = "Amount of time a link-state PDU should persist in the network"
+	ISISDoc.Fields[5].Name = "spf"
+	ISISDoc.Fields[5].Type = "ISISSPF"
+	ISISDoc.Fields[5].Note = ""
+	ISISDoc.Fields[5].Description = "SPF scheduling timers"
+	ISISDoc.Fields[5].Comments[encoder.LineComment] = "SPF scheduling timers"
+	ISISDoc.Fields[6].Name = "multi_topology"
+	ISISDoc.Fields[6].Type = "bool"
+	ISISDoc.Fields[6].Note = ""
+	ISISDoc.Fields[6].Description = "Enables multi topology routing (RFC5120) using a separate topology for IPv6 unicast"
+	ISISDoc.Fields[6].Comments[encoder.LineComment] = "Enables multi topology routing (RFC5120) using a separate topology for IPv6 unicast"
+	ISISDoc.Fields[7].Name = "segment_routing"
+	ISISDoc.Fields[7].Type = "ISISSegmentRouting"
+	ISISDoc.Fields[7].Note = ""
+	ISISDoc.Fields[7].Description = "Enables segment routing (RFC8667)"
+	ISISDoc.Fields[7].Comments[encoder.LineComment] = "Enables segment routing (RFC8667)"
+	ISISDoc.Fields[8].Name = "overload"
+	ISISDoc.Fields[8].Type = "ISISOverload"
+	ISISDoc.Fields[8].Note = ""
+	ISISDoc.Fields[8].Description = "Sets the overload bit so this router is not used for transit traffic"
+	ISISDoc.Fields[8].Comments[encoder.LineComment] = "Sets the overload bit so this router is not used for transit traffic"
+
+	ISISSPFDoc.Type = "ISISSPF"
+	ISISSPFDoc.Comments[encoder.LineComment] = "ISISSPF SPF scheduling config"
+	ISISSPFDoc.Description = "ISISSPF SPF scheduling config"
+	ISISSPFDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "ISIS",
+			FieldName: "spf",
+		},
+	}
+	ISISSPFDoc.Fields = make([]encoder.Doc, 3)
+	ISISSPFDoc.Fields[0].Name = "initial_wait"
+	ISISSPFDoc.Fields[0].Type = "uint32"
+	ISISSPFDoc.Fields[0].Note = ""
+	ISISSPFDoc.Fields[0].Description = "Delay of the first SPF run after a quiet period\nExpressed in milliseconds"
+	ISISSPFDoc.Fields[0].Comments[encoder.LineComment] = "Delay of the first SPF run after a quiet period"
+	ISISSPFDoc.Fields[1].Name = "secondary_wait"
+	ISISSPFDoc.Fields[1].Type = "uint32"
+	ISISSPFDoc.Fields[1].Note = ""
+	ISISSPFDoc.Fields[1].Description = "Delay of the second SPF run. Doubled on every subsequent run up to max_wait\nExpressed in milliseconds"
+	ISISSPFDoc.Fields[1].Comments[encoder.LineComment] = "Delay of the second SPF run. Doubled on every subsequent run up to max_wait"
+	ISISSPFDoc.Fields[2].Name = "max_wait"
+	ISISSPFDoc.Fields[2].Type = "uint32"
+	ISISSPFDoc.Fields[2].Note = ""
+	ISISSPFDoc.Fields[2].Description = "Maximum delay of SPF runs. The delay is reset to initial_wait after no SPF run for this period\nExpressed in milliseconds"
+	ISISSPFDoc.Fields[2].Comments[encoder.LineComment] = "Maximum delay of SPF runs. The delay is reset to initial_wait after no SPF run for this period"
+
+	ISISSegmentRoutingDoc.Type = "ISISSegmentRouting"
+	ISISSegmentRoutingDoc.Comments[encoder.LineComment] = "ISISSegmentRouting segment routing config"
+	ISISSegmentRoutingDoc.Description = "ISISSegmentRouting segment routing config"
+	ISISSegmentRoutingDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "ISIS",
+			FieldName: "segment_routing",
+		},
+	}
+	ISISSegmentRoutingDoc.Fields = make([]encoder.Doc, 4)
+	ISISSegmentRoutingDoc.Fields[0].Name = "srgb_base"
+	ISISSegmentRoutingDoc.Fields[0].Type = "uint32"
+	ISISSegmentRoutingDoc.Fields[0].Note = ""
+	ISISSegmentRoutingDoc.Fields[0].Description = "First label of the SR global block\nDefault: 16000"
+	ISISSegmentRoutingDoc.Fields[0].Comments[encoder.LineComment] = "First label of the SR global block"
+	ISISSegmentRoutingDoc.Fields[1].Name = "srgb_size"
+	ISISSegmentRoutingDoc.Fields[1].Type = "uint32"
+	ISISSegmentRoutingDoc.Fields[1].Note = ""
+	ISISSegmentRoutingDoc.Fields[1].Description = "Number of labels of the SR global block\nDefault: 8000"
+	ISISSegmentRoutingDoc.Fields[1].Comments[encoder.LineComment] = "Number of labels of the SR global block"
+	ISISSegmentRoutingDoc.Fields[2].Name = "srlb_base"
+	ISISSegmentRoutingDoc.Fields[2].Type = "uint32"
+	ISISSegmentRoutingDoc.Fields[2].Note = ""
+	ISISSegmentRoutingDoc.Fields[2].Description = "First label of the SR local block adjacency SIDs are allocated from\nDefault: 15000"
+	ISISSegmentRoutingDoc.Fields[2].Comments[encoder.LineComment] = "First label of the SR local block adjacency SIDs are allocated from"
+	ISISSegmentRoutingDoc.Fields[3].Name = "srlb_size"
+	ISISSegmentRoutingDoc.Fields[3].Type = "uint32"
+	ISISSegmentRoutingDoc.Fields[3].Note = ""
+	ISISSegmentRoutingDoc.Fields[3].Description = "Number of labels of the SR local block\nDefault: 1000"
+	ISISSegmentRoutingDoc.Fields[3].Comments[encoder.LineComment] = "Number of labels of the SR local block"
+
+	ISISOverloadDoc.Type = "ISISOverload"
+	ISISOverloadDoc.Comments[encoder.LineComment] = "ISISOverload overload bit config"
+	ISISOverloadDoc.Description = "ISISOverload overload bit config"
+	ISISOverloadDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "ISIS",
+			FieldName: "overload",
+		},
+	}
+	ISISOverloadDoc.Fields = make([]encoder.Doc, 3)
+	ISISOverloadDoc.Fields[0].Name = "set"
+	ISISOverloadDoc.Fields[0].Type = "bool"
+	ISISOverloadDoc.Fields[0].Note = ""
+	ISISOverloadDoc.Fields[0].Description = "Sets the overload bit permanently"
+	ISISOverloadDoc.Fields[0].Comments[encoder.LineComment] = "Sets the overload bit permanently"
+	ISISOverloadDoc.Fields[1].Name = "on_startup"
+	ISISOverloadDoc.Fields[1].Type = "uint32"
+	ISISOverloadDoc.Fields[1].Note = ""
+	ISISOverloadDoc.Fields[1].Description = "Sets the overload bit after startup for this period\nExpressed in seconds\nDefault: 600 if wait_for_bgp is enabled"
+	ISISOverloadDoc.Fields[1].Comments[encoder.LineComment] = "Sets the overload bit after startup for this period"
+	ISISOverloadDoc.Fields[2].Name = "wait_for_bgp"
+	ISISOverloadDoc.Fields[2].Type = "bool"
+	ISISOverloadDoc.Fields[2].Note = ""
+	ISISOverloadDoc.Fields[2].Description = "Clears the startup overload bit once all BGP sessions are established and received the End-of-RIB marker\nThe overload bit is cleared after on_startup at the latest"
+	ISISOverloadDoc.Fields[2].Comments[encoder.LineComment] = "Clears the startup overload bit once all BGP sessions are established and received the End-of-RIB marker"
 
 	ISISLevelDoc.Type = "ISISLevel"
 	ISISLevelDoc.Comments[encoder.LineComment] = "ISISLevel level config"
@@ -59,7 +166,7 @@ func init() {
 			FieldName: "level2",
 		},
 	}
-	ISISLevelDoc.Fields = make([]encoder.Doc, 6)
+	ISISLevelDoc.Fields = make([]encoder.Doc, 10)
 	ISISLevelDoc.Fields[0].Name = "disable"
 	ISISLevelDoc.Fields[0].Type = "bool"
 	ISISLevelDoc.Fields[0].Note = ""
@@ -68,28 +175,48 @@ func init() {
 	ISISLevelDoc.Fields[1].Name = "authentication_key"
 	ISISLevelDoc.Fields[1].Type = "string"
 	ISISLevelDoc.Fields[1].Note = ""
-	ISISLevelDoc.Fields[1].Description = "Password for authentication"
-	ISISLevelDoc.Fields[1].Comments[encoder.LineComment] = "Password for authentication"
-	ISISLevelDoc.Fields[2].Name = "no_csnp_authentication"
-	ISISLevelDoc.Fields[2].Type = "bool"
+	ISISLevelDoc.Fields[1].Description = "Key for authentication"
+	ISISLevelDoc.Fields[1].Comments[encoder.LineComment] = "Key for authentication"
+	ISISLevelDoc.Fields[2].Name = "authentication_key_id"
+	ISISLevelDoc.Fields[2].Type = "uint16"
 	ISISLevelDoc.Fields[2].Note = ""
-	ISISLevelDoc.Fields[2].Description = "Disable authentication for the Complete Sequence Number PDUs"
-	ISISLevelDoc.Fields[2].Comments[encoder.LineComment] = "Disable authentication for the Complete Sequence Number PDUs"
-	ISISLevelDoc.Fields[3].Name = "no_hello_authentication"
-	ISISLevelDoc.Fields[3].Type = "bool"
+	ISISLevelDoc.Fields[2].Description = "Key ID of authentication_key. Sent with HMAC-SHA-256 authenticated PDUs"
+	ISISLevelDoc.Fields[2].Comments[encoder.LineComment] = "Key ID of authentication_key. Sent with HMAC-SHA-256 authenticated PDUs"
+	ISISLevelDoc.Fields[3].Name = "authentication_type"
+	ISISLevelDoc.Fields[3].Type = "string"
 	ISISLevelDoc.Fields[3].Note = ""
-	ISISLevelDoc.Fields[3].Description = "DIsable authentication for hello messages"
-	ISISLevelDoc.Fields[3].Comments[encoder.LineComment] = "DIsable authentication for hello messages"
-	ISISLevelDoc.Fields[4].Name = "no_psnp_authentication"
-	ISISLevelDoc.Fields[4].Type = "bool"
+	ISISLevelDoc.Fields[3].Description = "Authentication algorithm of authentication_key: cleartext, hmac-md5 or hmac-sha-256\nDefault: hmac-md5"
+	ISISLevelDoc.Fields[3].Comments[encoder.LineComment] = "Authentication algorithm of authentication_key: cleartext, hmac-md5 or hmac-sha-256"
+	ISISLevelDoc.Fields[4].Name = "authentication_key_chain"
+	ISISLevelDoc.Fields[4].Type = "[]ISISAuthenticationKey"
 	ISISLevelDoc.Fields[4].Note = ""
-	ISISLevelDoc.Fields[4].Description = "Disable authentication for the Partial Sequence Number PDUs"
-	ISISLevelDoc.Fields[4].Comments[encoder.LineComment] = "Disable authentication for the Partial Sequence Number PDUs"
-	ISISLevelDoc.Fields[5].Name = "wide_metrics_only"
+	ISISLevelDoc.Fields[4].Description = "Keys used in addition to authentication_key. Allows rolling over keys"
+	ISISLevelDoc.Fields[4].Comments[encoder.LineComment] = "Keys used in addition to authentication_key. Allows rolling over keys"
+	ISISLevelDoc.Fields[5].Name = "no_csnp_authentication"
 	ISISLevelDoc.Fields[5].Type = "bool"
 	ISISLevelDoc.Fields[5].Note = ""
-	ISISLevelDoc.Fields[5].Description = "Enable sending and receiving wide metrics only for this level"
-	ISISLevelDoc.Fields[5].Comments[encoder.LineComment] = "Enable sending and receiving wide metrics only for this level"
+	ISISLevelDoc.Fields[5].Description = "Disable authentication for the Complete Sequence Number PDUs"
+	ISISLevelDoc.Fields[5].Comments[encoder.LineComment] = "Disable authentication for the Complete Sequence Number PDUs"
+	ISISLevelDoc.Fields[6].Name = "no_hello_authentication"
+	ISISLevelDoc.Fields[6].Type = "bool"
+	ISISLevelDoc.Fields[6].Note = ""
+	ISISLevelDoc.Fields[6].Description = "Disable authentication for hello messages"
+	ISISLevelDoc.Fields[6].Comments[encoder.LineComment] = "Disable authentication for hello messages"
+	ISISLevelDoc.Fields[7].Name = "no_psnp_authentication"
+	ISISLevelDoc.Fields[7].Type = "bool"
+	ISISLevelDoc.Fields[7].Note = ""
+	ISISLevelDoc.Fields[7].Description = "Disable authentication for the Partial Sequence Number PDUs"
+	ISISLevelDoc.Fields[7].Comments[encoder.LineComment] = "Disable authentication for the Partial Sequence Number PDUs"
+	ISISLevelDoc.Fields[8].Name = "wide_metrics_only"
+	ISISLevelDoc.Fields[8].Type = "bool"
+	ISISLevelDoc.Fields[8].Note = ""
+	ISISLevelDoc.Fields[8].Description = "Enable sending and receiving wide metrics only for this level"
+	ISISLevelDoc.Fields[8].Comments[encoder.LineComment] = "Enable sending and receiving wide metrics only for this level"
+	ISISLevelDoc.Fields[9].Name = "route_leaking"
+	ISISLevelDoc.Fields[9].Type = "[]string"
+	ISISLevelDoc.Fields[9].Note = ""
+	ISISLevelDoc.Fields[9].Description = "Policies selecting the level 2 routes leaked into level 1 with the up/down bit set (RFC5302)\nValid for level1 only"
+	ISISLevelDoc.Fields[9].Comments[encoder.LineComment] = "Policies selecting the level 2 routes leaked into level 1 with the up/down bit set (RFC5302)"
 
 	ISISInterfaceDoc.Type = "ISISInterface"
 	ISISInterfaceDoc.Comments[encoder.LineComment] = "ISISInterface interface config"
@@ -100,7 +227,7 @@ func init() {
 			FieldName: "interfaces",
 		},
 	}
-	ISISInterfaceDoc.Fields = make([]encoder.Doc, 5)
+	ISISInterfaceDoc.Fields = make([]encoder.Doc, 7)
 	ISISInterfaceDoc.Fields[0].Name = "name"
 	ISISInterfaceDoc.Fields[0].Type = "string"
 	ISISInterfaceDoc.Fields[0].Note = ""
@@ -126,6 +253,16 @@ func init() {
 	ISISInterfaceDoc.Fields[4].Note = ""
 	ISISInterfaceDoc.Fields[4].Description = "Level 2 configuration parameters for the interface"
 	ISISInterfaceDoc.Fields[4].Comments[encoder.LineComment] = "Level 2 configuration parameters for the interface"
+	ISISInterfaceDoc.Fields[5].Name = "prefix_sid_index"
+	ISISInterfaceDoc.Fields[5].Type = "uint32"
+	ISISInterfaceDoc.Fields[5].Note = ""
+	ISISInterfaceDoc.Fields[5].Description = "Prefix SID index advertised as node SID with the first /32 address of the interface (usually a loopback)\nRequires segment_routing. Must be smaller than srgb_size"
+	ISISInterfaceDoc.Fields[5].Comments[encoder.LineComment] = "Prefix SID index advertised as node SID with the first /32 address of the interface (usually a loopback)"
+	ISISInterfaceDoc.Fields[6].Name = "bfd"
+	ISISInterfaceDoc.Fields[6].Type = "BFDConfig"
+	ISISInterfaceDoc.Fields[6].Note = ""
+	ISISInterfaceDoc.Fields[6].Description = "Detect failures of the adjacencies on this interface with BFD"
+	ISISInterfaceDoc.Fields[6].Comments[encoder.LineComment] = "Detect failures of the adjacencies on this interface with BFD"
 
 	ISISInterfaceLevelDoc.Type = "ISISInterfaceLevel"
 	ISISInterfaceLevelDoc.Comments[encoder.LineComment] = "ISISInterfaceLevel interface level config"
@@ -140,7 +277,7 @@ func init() {
 			FieldName: "level2",
 		},
 	}
-	ISISInterfaceLevelDoc.Fields = make([]encoder.Doc, 6)
+	ISISInterfaceLevelDoc.Fields = make([]encoder.Doc, 7)
 	ISISInterfaceLevelDoc.Fields[0].Name = "disable"
 	ISISInterfaceLevelDoc.Fields[0].Type = "bool"
 	ISISInterfaceLevelDoc.Fields[0].Note = ""
@@ -169,14 +306,71 @@ func init() {
 	ISISInterfaceLevelDoc.Fields[5].Name = "priority"
 	ISISInterfaceLevelDoc.Fields[5].Type = "uint8"
 	ISISInterfaceLevelDoc.Fields[5].Note = ""
-	ISISInterfaceLevelDoc.Fields[5].Description = "Configures the device priority to become a designated router for this level\nValue range: 0-127"
-	ISISInterfaceLevelDoc.Fields[5].Comments[encoder.LineComment] = "Configures the device priority to become a designated router for this level"
+	ISISInterfaceLevelDoc.Fields[5].Description = "Configures the device priority to become the designated intermediate system on broadcast interfaces for this level\nValue range: 0-127\nDefault: 64"
+	ISISInterfaceLevelDoc.Fields[5].Comments[encoder.LineComment] = "Configures the device priority to become the designated intermediate system on broadcast interfaces for this level"
+	ISISInterfaceLevelDoc.Fields[6].Name = "hello_authentication_key_chain"
+	ISISInterfaceLevelDoc.Fields[6].Type = "[]ISISAuthenticationKey"
+	ISISInterfaceLevelDoc.Fields[6].Note = ""
+	ISISInterfaceLevelDoc.Fields[6].Description = "Keys authenticating hellos on this interface. Overrides the hello authentication of the level"
+	ISISInterfaceLevelDoc.Fields[6].Comments[encoder.LineComment] = "Keys authenticating hellos on this interface. Overrides the hello authentication of the level"
+
+	ISISAuthenticationKeyDoc.Type = "ISISAuthenticationKey"
+	ISISAuthenticationKeyDoc.Comments[encoder.LineComment] = "ISISAuthenticationKey authentication key config"
+	ISISAuthenticationKeyDoc.Description = "ISISAuthenticationKey authentication key config"
+	ISISAuthenticationKeyDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "ISISLevel",
+			FieldName: "authentication_key_chain",
+		},
+		{
+			TypeName:  "ISISInterfaceLevel",
+			FieldName: "hello_authentication_key_chain",
+		},
+	}
+	ISISAuthenticationKeyDoc.Fields = make([]encoder.Doc, 5)
+	ISISAuthenticationKeyDoc.Fields[0].Name = "id"
+	ISISAuthenticationKeyDoc.Fields[0].Type = "uint16"
+	ISISAuthenticationKeyDoc.Fields[0].Note = ""
+	ISISAuthenticationKeyDoc.Fields[0].Description = "Key ID. Sent with HMAC-SHA-256 authenticated PDUs"
+	ISISAuthenticationKeyDoc.Fields[0].Comments[encoder.LineComment] = "Key ID. Sent with HMAC-SHA-256 authenticated PDUs"
+	ISISAuthenticationKeyDoc.Fields[1].Name = "key"
+	ISISAuthenticationKeyDoc.Fields[1].Type = "string"
+	ISISAuthenticationKeyDoc.Fields[1].Note = ""
+	ISISAuthenticationKeyDoc.Fields[1].Description = "Secret key"
+	ISISAuthenticationKeyDoc.Fields[1].Comments[encoder.LineComment] = "Secret key"
+	ISISAuthenticationKeyDoc.Fields[2].Name = "type"
+	ISISAuthenticationKeyDoc.Fields[2].Type = "string"
+	ISISAuthenticationKeyDoc.Fields[2].Note = ""
+	ISISAuthenticationKeyDoc.Fields[2].Description = "Authentication algorithm: cleartext, hmac-md5 or hmac-sha-256\nDefault: hmac-md5"
+	ISISAuthenticationKeyDoc.Fields[2].Comments[encoder.LineComment] = "Authentication algorithm: cleartext, hmac-md5 or hmac-sha-256"
+	ISISAuthenticationKeyDoc.Fields[3].Name = "start_time"
+	ISISAuthenticationKeyDoc.Fields[3].Type = "string"
+	ISISAuthenticationKeyDoc.Fields[3].Note = ""
+	ISISAuthenticationKeyDoc.Fields[3].Description = "Time the key becomes valid in RFC3339 format. The valid key with the latest start time is used for sending"
+	ISISAuthenticationKeyDoc.Fields[3].Comments[encoder.LineComment] = "Time the key becomes valid in RFC3339 format. The valid key with the latest start time is used for sending"
+	ISISAuthenticationKeyDoc.Fields[4].Name = "end_time"
+	ISISAuthenticationKeyDoc.Fields[4].Type = "string"
+	ISISAuthenticationKeyDoc.Fields[4].Note = ""
+	ISISAuthenticationKeyDoc.Fields[4].Description = "Time the key expires in RFC3339 format"
+	ISISAuthenticationKeyDoc.Fields[4].Comments[encoder.LineComment] = "Time the key expires in RFC3339 format"
 }
 
 func (_ ISIS) Doc() *encoder.Doc {
 	return &ISISDoc
 }
 
+func (_ ISISSPF) Doc() *encoder.Doc {
+	return &ISISSPFDoc
+}
+
+func (_ ISISSegmentRouting) Doc() *encoder.Doc {
+	return &ISISSegmentRoutingDoc
+}
+
+func (_ ISISOverload) Doc() *encoder.Doc {
+	return &ISISOverloadDoc
+}
+
 func (_ ISISLevel) Doc() *encoder.Doc {
 	return &ISISLevelDoc
 }
@@ -189,6 +383,10 @@ func (_ ISISInterfaceLevel) Doc() *encoder.Doc {
 	return &ISISInterfaceLevelDoc
 }
 
+func (_ ISISAuthenticationKey) Doc() *encoder.Doc {
+	return &ISISAuthenticationKeyDoc
+}
+
 // GetisisDoc returns documentation for the file cmd/bio-rd/config/isis_docs.go.
 func GetisisDoc() *encoder.FileDoc {
 	return &encoder.FileDoc{
@@ -196,9 +394,13 @@ func GetisisDoc() *encoder.FileDoc {
 		Description: "",
 		Structs: []*encoder.Doc{
 			&ISISDoc,
+			&ISISSPFDoc,
+			&ISISSegmentRoutingDoc,
+			&ISISOverloadDoc,
 			&ISISLevelDoc,
 			&ISISInterfaceDoc,
 			&ISISInterfaceLevelDoc,
+			&ISISAuthenticationKeyDoc,
 		},
 	}
 }
@@ -0,0 +1,178 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestISISSegmentRoutingLoad(t *testing.T) {
+	idx := func(i uint32) *uint32 {
+		return &i
+	}
+
+	tests := []struct {
+		name     string
+		input    *ISIS
+		wantFail bool
+		expected *ISISSegmentRouting
+	}{
+		{
+			name: "defaults",
+			input: &ISIS{
+				SegmentRouting: &ISISSegmentRouting{},
+				Interfaces: []*ISISInterface{
+					{Name: "lo", PrefixSIDIndex: idx(1)},
+				},
+			},
+			expected: &ISISSegmentRouting{
+				SRGBBase: 16000,
+				SRGBSize: 8000,
+				SRLBBase: 15000,
+				SRLBSize: 1000,
+			},
+		},
+		{
+			name: "prefix SID without segment routing",
+			input: &ISIS{
+				Interfaces: []*ISISInterface{
+					{Name: "lo", PrefixSIDIndex: idx(1)},
+				},
+			},
+			wantFail: true,
+		},
+		{
+			name: "prefix SID index exceeds SRGB",
+			input: &ISIS{
+				SegmentRouting: &ISISSegmentRouting{SRGBSize: 100},
+				Interfaces: []*ISISInterface{
+					{Name: "lo", PrefixSIDIndex: idx(100)},
+				},
+			},
+			wantFail: true,
+		},
+		{
+			name: "duplicate prefix SID index",
+			input: &ISIS{
+				SegmentRouting: &ISISSegmentRouting{},
+				Interfaces: []*ISISInterface{
+					{Name: "lo", PrefixSIDIndex: idx(1)},
+					{Name: "dummy0", PrefixSIDIndex: idx(1)},
+				},
+			},
+			wantFail: true,
+		},
+		{
+			name: "SRGB and SRLB overlap",
+			input: &ISIS{
+				SegmentRouting: &ISISSegmentRouting{SRLBBase: 20000},
+			},
+			wantFail: true,
+		},
+		{
+			name: "reserved label",
+			input: &ISIS{
+				SegmentRouting: &ISISSegmentRouting{SRLBBase: 3},
+			},
+			wantFail: true,
+		},
+		{
+			name: "SRGB exceeds label range",
+			input: &ISIS{
+				SegmentRouting: &ISISSegmentRouting{SRGBBase: 1048000},
+			},
+			wantFail: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.input.load(&PolicyOptions{})
+			if test.wantFail {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, test.input.SegmentRouting)
+		})
+	}
+}
+
+func TestISISOverloadLoad(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *ISISOverload
+		expected *ISISOverload
+	}{
+		{
+			name:     "static",
+			input:    &ISISOverload{Set: true},
+			expected: &ISISOverload{Set: true},
+		},
+		{
+			name:     "wait for BGP default timeout",
+			input:    &ISISOverload{WaitForBGP: true},
+			expected: &ISISOverload{WaitForBGP: true, OnStartup: 600},
+		},
+		{
+			name:     "wait for BGP",
+			input:    &ISISOverload{WaitForBGP: true, OnStartup: 120},
+			expected: &ISISOverload{WaitForBGP: true, OnStartup: 120},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			i := &ISIS{Overload: test.input}
+			assert.NoError(t, i.load(&PolicyOptions{}))
+			assert.Equal(t, test.expected, i.Overload)
+		})
+	}
+}
+
+func TestISISBFDLoad(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *ISIS
+		wantFail bool
+		expected *BFDConfig
+	}{
+		{
+			name: "single hop",
+			input: &ISIS{
+				Interfaces: []*ISISInterface{
+					{Name: "eth0", BFD: &BFDConfig{MinInterval: 100}},
+				},
+			},
+			expected: &BFDConfig{
+				MinInterval:         100,
+				MinIntervalDuration: 100 * time.Millisecond,
+				Multiplier:          DefaultBFDMultiplier,
+			},
+		},
+		{
+			name: "multihop",
+			input: &ISIS{
+				Interfaces: []*ISISInterface{
+					{Name: "eth0", BFD: &BFDConfig{Multihop: true}},
+				},
+			},
+			wantFail: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.input.load(&PolicyOptions{})
+			if test.wantFail {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, test.input.Interfaces[0].BFD)
+		})
+	}
+}
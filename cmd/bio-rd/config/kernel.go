@@ -0,0 +1,95 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/bio-routing/bio-rd/protocols/kernel"
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/bio-routing/bio-rd/routingtable/filter"
+)
+
+type Kernel struct {
+	// description: |
+	//   ID of the Linux routing table routes are installed into. Defaults to the main table (254)
+	Table uint32 `yaml:"table"`
+	// description: |
+	//   Install all equal cost paths of a route as multipath route
+	Multipath bool `yaml:"multipath"`
+	// description: |
+	//   List of policy statements applied to routes before they are installed
+	Export []string `yaml:"export"`
+	// docgen:nodoc
+	ExportFilterChain filter.Chain
+}
+
+func (k *Kernel) load(policyOptions *PolicyOptions) error {
+	if k.Table == 0 {
+		k.Table = kernel.MainRoutingTable
+	}
+
+	k.ExportFilterChain = make(filter.Chain, 0, len(k.Export))
+	for i := range k.Export {
+		f := policyOptions.getPolicyStatementFilter(k.Export[i])
+		if f == nil {
+			return fmt.Errorf("policy statement %q undefined", k.Export[i])
+		}
+
+		k.ExportFilterChain = append(k.ExportFilterChain, f)
+	}
+
+	return nil
+}
+
+type KernelImport struct {
+	// description: |
+	//   ID of the Linux routing table routes are learned from. Defaults to the main table (254)
+	Table uint32 `yaml:"table"`
+	// description: |
+	//   List of protocols of the routes to learn. Routes of all protocols are learned if omitted
+	//   Protocols are given by name (kernel, boot, static, ra, dhcp) or numeric ID
+	//   Routes installed by bio-rd are never learned
+	Protocols []string `yaml:"protocols"`
+	// docgen:nodoc
+	ProtocolIDs []int
+}
+
+func (k *KernelImport) load() error {
+	if k.Table == 0 {
+		k.Table = kernel.MainRoutingTable
+	}
+
+	k.ProtocolIDs = make([]int, 0, len(k.Protocols))
+	for _, x := range k.Protocols {
+		p, err := kernelRouteProtocolFromString(x)
+		if err != nil {
+			return err
+		}
+
+		k.ProtocolIDs = append(k.ProtocolIDs, p)
+	}
+
+	return nil
+}
+
+func kernelRouteProtocolFromString(protocol string) (int, error) {
+	switch protocol {
+	case "kernel":
+		return route.ProtoKernel, nil
+	case "boot":
+		return route.ProtoBoot, nil
+	case "static":
+		return route.ProtoStatic, nil
+	case "ra":
+		return route.ProtoRA, nil
+	case "dhcp":
+		return route.ProtoDHCP, nil
+	}
+
+	p, err := strconv.ParseUint(protocol, 10, 8)
+	if err != nil {
+		return 0, fmt.Errorf("Invalid kernel route protocol: %q", protocol)
+	}
+
+	return int(p), nil
+}
@@ -0,0 +1,71 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+// DO NOT EDIT: this file is automatically generated by docgen
+package config
+
+import (
+	"github.com/projectdiscovery/yamldoc-go/encoder"
+)
+
+var (
+	KernelDoc       encoder.Doc
+	KernelImportDoc encoder.Doc
+)
+
+func init() {
+	KernelDoc.Type = "Kernel"
+	KernelDoc.Comments[encoder.LineComment] = ""
+	KernelDoc.Description = ""
+	KernelDoc.Fields = make([]encoder.Doc, 3)
+	KernelDoc.Fields[0].Name = "table"
+	KernelDoc.Fields[0].Type = "uint32"
+	KernelDoc.Fields[0].Note = ""
+	KernelDoc.Fields[0].Description = "ID of the Linux routing table routes are installed into. Defaults to the main table (254)"
+	KernelDoc.Fields[0].Comments[encoder.LineComment] = "ID of the Linux routing table routes are installed into. Defaults to the main table (254)"
+	KernelDoc.Fields[1].Name = "multipath"
+	KernelDoc.Fields[1].Type = "bool"
+	KernelDoc.Fields[1].Note = ""
+	KernelDoc.Fields[1].Description = "Install all equal cost paths of a route as multipath route"
+	KernelDoc.Fields[1].Comments[encoder.LineComment] = "Install all equal cost paths of a route as multipath route"
+	KernelDoc.Fields[2].Name = "export"
+	KernelDoc.Fields[2].Type = "[]string"
+	KernelDoc.Fields[2].Note = ""
+	KernelDoc.Fields[2].Description = "List of policy statements applied to routes before they are installed"
+	KernelDoc.Fields[2].Comments[encoder.LineComment] = "List of policy statements applied to routes before they are installed"
+
+	KernelImportDoc.Type = "KernelImport"
+	KernelImportDoc.Comments[encoder.LineComment] = ""
+	KernelImportDoc.Description = ""
+	KernelImportDoc.Fields = make([]encoder.Doc, 2)
+	KernelImportDoc.Fields[0].Name = "table"
+	KernelImportDoc.Fields[0].Type = "uint32"
+	KernelImportDoc.Fields[0].Note = ""
+	KernelImportDoc.Fields[0].Description = "ID of the Linux routing table routes are learned from. Defaults to the main table (254)"
+	KernelImportDoc.Fields[0].Comments[encoder.LineComment] = "ID of the Linux routing table routes are learned from. Defaults to the main table (254)"
+	KernelImportDoc.Fields[1].Name = "protocols"
+	KernelImportDoc.Fields[1].Type = "[]string"
+	KernelImportDoc.Fields[1].Note = ""
+	KernelImportDoc.Fields[1].Description = "List of protocols of the routes to learn. Routes of all protocols are learned if omitted\nProtocols are given by name (kernel, boot, static, ra, dhcp) or numeric ID\nRoutes installed by bio-rd are never learned"
+	KernelImportDoc.Fields[1].Comments[encoder.LineComment] = "List of protocols of the routes to learn. Routes of all protocols are learned if omitted"
+}
+
+func (_ Kernel) Doc() *encoder.Doc {
+	return &KernelDoc
+}
+
+func (_ KernelImport) Doc() *encoder.Doc {
+	return &KernelImportDoc
+}
+
+// GetkernelDoc returns documentation for the file cmd/bio-rd/config/kernel_docs.go.
+func GetkernelDoc() *encoder.FileDoc {
+	return &encoder.FileDoc{
+		Name:        "kernel",
+		Description: "",
+		Structs: []*encoder.Doc{
+			&KernelDoc,
+			&KernelImportDoc,
+		},
+	}
+}
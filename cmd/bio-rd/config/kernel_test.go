@@ -0,0 +1,57 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKernelImportLoad(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *KernelImport
+		wantFail bool
+		expected *KernelImport
+	}{
+		{
+			name:  "defaults",
+			input: &KernelImport{},
+			expected: &KernelImport{
+				Table:       254,
+				ProtocolIDs: []int{},
+			},
+		},
+		{
+			name: "protocols by name and ID",
+			input: &KernelImport{
+				Table:     100,
+				Protocols: []string{"static", "dhcp", "42"},
+			},
+			expected: &KernelImport{
+				Table:       100,
+				Protocols:   []string{"static", "dhcp", "42"},
+				ProtocolIDs: []int{4, 16, 42},
+			},
+		},
+		{
+			name: "invalid protocol",
+			input: &KernelImport{
+				Protocols: []string{"foo"},
+			},
+			wantFail: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.input.load()
+			if test.wantFail {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, test.input)
+		})
+	}
+}
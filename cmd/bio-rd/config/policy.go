@@ -4,6 +4,9 @@ import (
 	"fmt"
 
 	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/packet"
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
+	"github.com/bio-routing/bio-rd/route"
 	"github.com/bio-routing/bio-rd/routingtable/filter"
 	"github.com/bio-routing/bio-rd/routingtable/filter/actions"
 )
@@ -29,13 +32,17 @@ type PolicyOptions struct {
 	// description: |
 	//   prefix lists to be used in the policy statements.
 	//   Example:
-	//     prefix-lists:
-	//       prefixes:
-	//         - 2001:db8:0:1::/64
+	//     prefix_lists:
+	//       - name: "CUSTOMER-A"
+	//         prefixes:
+	//           - 2001:db8:0:1::/64
 	PrefixLists []PrefixList `yaml:"prefix_lists"`
 }
 
 type PrefixList struct {
+	// description: |
+	//   Name of the prefix list
+	Name string `yaml:"name"`
 	// description: |
 	//   List of prefixes
 	Prefixes []string `yaml:"prefixes"`
@@ -66,6 +73,10 @@ type PolicyStatementTerm struct {
 	//     - LocalPref sets the local preference to the specified value (max 4294967295)
 	//     - AsPathPrepend: prepends AS numbers to the route. Details bellow
 	//     - NextHop: modify the next-hop to the specified address
+	//     - Community/LargeCommunity: add, remove, replace or delete (by regex) communities
+	//     - Origin: sets the origin
+	//     - RemovePrivateAS: removes private ASNs from the AS path
+	//     - Next: continues with the next term or the next policy
 	Then PolicyStatementTermThen `yaml:"then"`
 }
 
@@ -77,6 +88,68 @@ type PolicyStatementTermFrom struct {
 	//        - prefix: "198.51.100.0/24"
 	//          matcher: "orlonger"
 	RouteFilters []*RouteFilter `yaml:"route_filters"`
+	// description: |
+	//   Names of prefix lists (see prefix_lists) the prefix has to be on exactly
+	PrefixLists []string `yaml:"prefix_lists"`
+	// description: |
+	//   Communities of which at least one has to be attached to the route
+	//   Example:
+	//     communities:
+	//       - "(65000,100)"
+	Communities []string `yaml:"communities"`
+	// description: |
+	//   Large communities of which at least one has to be attached to the route
+	//   Example:
+	//     large_communities:
+	//       - "(65000,1,100)"
+	LargeCommunities []string `yaml:"large_communities"`
+	// description: |
+	//   Extended communities of which at least one has to be attached to the route
+	//   Example:
+	//     extended_communities:
+	//       - "target:65000:100"
+	ExtendedCommunities []string `yaml:"extended_communities"`
+	// description: |
+	//   Regular expressions of which at least one has to match the AS path.
+	//   The AS path is matched in the form "65000 65001 (65002 65003)", underscores match ASN boundaries.
+	//   Example:
+	//     as_path:
+	//       - "^65000_"
+	//       - "_(64496|64511)$"
+	ASPath []string `yaml:"as_path"`
+	// description: |
+	//   ASNs of which one has to be the origin (last ASN of the AS path) of the route
+	OriginAS []uint32 `yaml:"origin_as"`
+	// description: |
+	//   Next hop addresses of which one has to be the next hop of the route
+	NextHop []string `yaml:"next_hop"`
+	// description: |
+	//   Protocols of which one has to have learned the route
+	//   Available options: bgp, static, ospf, isis, kernel (or fib), connected
+	Protocol []string `yaml:"protocol"`
+	// description: |
+	//   MEDs of which one has to be set on the route
+	MED []uint32 `yaml:"med"`
+	// description: |
+	//   Local preferences of which one has to be set on the route
+	LocalPref []uint32 `yaml:"local_pref"`
+	// description: |
+	//   RPKI origin validation states (RFC6811) of which one has to be the state of the route.
+	//   Routes are validated against the VRPs learned from the caches configured in routing_options
+	//   Available options: valid, invalid, not-found, unverified
+	//   Example:
+	//     rpki_validation:
+	//       - "invalid"
+	RPKIValidation []string `yaml:"rpki_validation"`
+	// description: |
+	//   ASPA verification states of which one has to be the state of the AS path of the route.
+	//   AS paths are verified against the ASPAs learned from the sources configured in routing_options
+	//   on sessions with a local_role
+	//   Available options: valid, invalid, unknown, unverified
+	//   Example:
+	//     aspa_verification:
+	//       - "invalid"
+	ASPAVerification []string `yaml:"aspa_verification"`
 }
 
 type RouteFilter struct {
@@ -121,6 +194,53 @@ type PolicyStatementTermThen struct {
 	// description: |
 	//   IP address to be used as a next-hop for the route
 	NextHop *NextHop `yaml:"next_hop"`
+	// description: |
+	//   Modification of the communities of the route
+	//   Example:
+	//     community:
+	//       delete:
+	//         - "^\\(65000,"
+	//       add:
+	//         - "(65000,100)"
+	Community *CommunityModification `yaml:"community"`
+	// description: |
+	//   Modification of the large communities of the route
+	//   Example:
+	//     large_community:
+	//       remove:
+	//         - "(65000,1,100)"
+	LargeCommunity *CommunityModification `yaml:"large_community"`
+	// description: |
+	//   Origin to set
+	//   Available options: igp, egp, incomplete
+	Origin string `yaml:"origin"`
+	// description: |
+	//   Remove all private ASNs (RFC6996) from the AS path
+	RemovePrivateAS bool `yaml:"remove_private_as"`
+	// description: |
+	//   Flow control after executing the actions of this term
+	//   Available options:
+	//     - term: skips the remaining actions of this term and continues with the next term
+	//     - policy: skips the remaining terms of this policy and continues with the next policy
+	Next string `yaml:"next"`
+}
+
+type CommunityModification struct {
+	// description: |
+	//   Communities to add
+	Add []string `yaml:"add"`
+	// description: |
+	//   Communities to remove
+	Remove []string `yaml:"remove"`
+	// description: |
+	//   Communities replacing all existing communities
+	Replace []string `yaml:"replace"`
+	// description: |
+	//   Regular expressions matching the communities to remove
+	//   Example (all communities of AS65000):
+	//     delete:
+	//       - "^\\(65000,"
+	Delete []string `yaml:"delete"`
 }
 
 type ASPathPrepend struct {
@@ -172,9 +292,33 @@ func (po *PolicyOptions) getPolicyStatementFilter(name string) *filter.Filter {
 	return nil
 }
 
+func (po *PolicyOptions) getPrefixList(name string) *PrefixList {
+	for i := range po.PrefixLists {
+		if po.PrefixLists[i].Name == name {
+			return &po.PrefixLists[i]
+		}
+	}
+
+	return nil
+}
+
+func (pl *PrefixList) toFilterPrefixList() (*filter.PrefixList, error) {
+	pfxs := make([]*bnet.Prefix, 0, len(pl.Prefixes))
+	for _, x := range pl.Prefixes {
+		pfx, err := bnet.PrefixFromString(x)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid prefix %q: %w", x, err)
+		}
+
+		pfxs = append(pfxs, pfx.Dedup())
+	}
+
+	return filter.NewPrefixList(pfxs...), nil
+}
+
 func (po *PolicyOptions) load() error {
 	for _, ps := range po.PolicyStatements {
-		f, err := ps.toFilter()
+		f, err := ps.toFilter(po)
 		if err != nil {
 			return fmt.Errorf("Failed to convert policy_statement: %w", err)
 		}
@@ -185,11 +329,11 @@ func (po *PolicyOptions) load() error {
 	return nil
 }
 
-func (ps *PolicyStatement) toFilter() (*filter.Filter, error) {
+func (ps *PolicyStatement) toFilter(po *PolicyOptions) (*filter.Filter, error) {
 	terms := make([]*filter.Term, 0)
 
 	for _, t := range ps.Terms {
-		ft, err := t.toFilterTerm()
+		ft, err := t.toFilterTerm(po)
 		if err != nil {
 			return nil, fmt.Errorf("unable to process filter term: %w", err)
 		}
@@ -200,22 +344,17 @@ func (ps *PolicyStatement) toFilter() (*filter.Filter, error) {
 	return filter.NewFilter(ps.Name, terms), nil
 }
 
-func (pst *PolicyStatementTerm) toFilterTerm() (*filter.Term, error) {
+func (pst *PolicyStatementTerm) toFilterTerm(po *PolicyOptions) (*filter.Term, error) {
 	conditions := make([]*filter.TermCondition, 0)
 	a := make([]actions.Action, 0)
 
-	routeFilters := make([]*filter.RouteFilter, 0)
-	for i := range pst.From.RouteFilters {
-		rf, err := pst.From.RouteFilters[i].toFilterRouteFilter()
-		if err != nil {
-			return nil, fmt.Errorf("unable to parse route filter: %w", err)
-		}
-
-		routeFilters = append(routeFilters, rf)
+	cond, err := pst.From.toFilterTermCondition(po)
+	if err != nil {
+		return nil, err
 	}
 
-	if len(routeFilters) > 0 {
-		conditions = append(conditions, filter.NewTermConditionWithRouteFilters(routeFilters...))
+	if cond != nil {
+		conditions = append(conditions, cond)
 	}
 
 	if pst.Then.Reject {
@@ -243,9 +382,372 @@ func (pst *PolicyStatementTerm) toFilterTerm() (*filter.Term, error) {
 		a = append(a, actions.NewSetNextHopAction(addr.Dedup()))
 	}
 
+	if pst.Then.Origin != "" {
+		origin, err := originFromString(pst.Then.Origin)
+		if err != nil {
+			return nil, err
+		}
+
+		a = append(a, actions.NewSetOriginAction(origin))
+	}
+
+	if pst.Then.RemovePrivateAS {
+		a = append(a, actions.NewRemovePrivateASNsAction())
+	}
+
+	if pst.Then.Community != nil {
+		comActions, err := pst.Then.Community.toCommunityActions()
+		if err != nil {
+			return nil, fmt.Errorf("unable to process community: %w", err)
+		}
+
+		a = append(a, comActions...)
+	}
+
+	if pst.Then.LargeCommunity != nil {
+		comActions, err := pst.Then.LargeCommunity.toLargeCommunityActions()
+		if err != nil {
+			return nil, fmt.Errorf("unable to process large_community: %w", err)
+		}
+
+		a = append(a, comActions...)
+	}
+
+	if pst.Then.Next != "" && (pst.Then.Accept || pst.Then.Reject) {
+		return nil, fmt.Errorf("next can not be combined with accept or reject")
+	}
+
+	switch pst.Then.Next {
+	case "":
+	case "term":
+		a = append(a, actions.NewNextTermAction())
+	case "policy":
+		a = append(a, actions.NewNextPolicyAction())
+	default:
+		return nil, fmt.Errorf("Invalid next: %q", pst.Then.Next)
+	}
+
 	if pst.Then.Accept {
 		a = append(a, actions.NewAcceptAction())
 	}
 
 	return filter.NewTerm(pst.Name, conditions, a), nil
 }
+
+// toFilterTermCondition converts the from block into a single term condition. All given criteria
+// have to match while it is sufficient for one value of each criterion to match.
+func (f *PolicyStatementTermFrom) toFilterTermCondition(po *PolicyOptions) (*filter.TermCondition, error) {
+	if f.empty() {
+		return nil, nil
+	}
+
+	routeFilters := make([]*filter.RouteFilter, 0, len(f.RouteFilters))
+	for i := range f.RouteFilters {
+		rf, err := f.RouteFilters[i].toFilterRouteFilter()
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse route filter: %w", err)
+		}
+
+		routeFilters = append(routeFilters, rf)
+	}
+
+	prefixLists := make([]*filter.PrefixList, 0, len(f.PrefixLists))
+	for _, name := range f.PrefixLists {
+		pl := po.getPrefixList(name)
+		if pl == nil {
+			return nil, fmt.Errorf("prefix list %q not defined", name)
+		}
+
+		fpl, err := pl.toFilterPrefixList()
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse prefix list %q: %w", name, err)
+		}
+
+		prefixLists = append(prefixLists, fpl)
+	}
+
+	communityFilters := make([]*filter.CommunityFilter, 0, len(f.Communities))
+	for _, x := range f.Communities {
+		c, err := types.ParseCommunityString(x)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid community %q: %w", x, err)
+		}
+
+		communityFilters = append(communityFilters, filter.NewCommunityFilter(c))
+	}
+
+	largeCommunityFilters := make([]*filter.LargeCommunityFilter, 0, len(f.LargeCommunities))
+	for _, x := range f.LargeCommunities {
+		c, err := types.ParseLargeCommunityString(x)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid large community %q: %w", x, err)
+		}
+
+		largeCommunityFilters = append(largeCommunityFilters, filter.NewLargeCommunityFilter(c))
+	}
+
+	extendedCommunityFilters := make([]*filter.ExtendedCommunityFilter, 0, len(f.ExtendedCommunities))
+	for _, x := range f.ExtendedCommunities {
+		c, err := types.ParseExtendedCommunityString(x)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid extended community %q: %w", x, err)
+		}
+
+		extendedCommunityFilters = append(extendedCommunityFilters, filter.NewExtendedCommunityFilter(c))
+	}
+
+	asPathFilters := make([]*filter.ASPathFilter, 0, len(f.ASPath))
+	for _, x := range f.ASPath {
+		apf, err := filter.NewASPathFilter(x)
+		if err != nil {
+			return nil, err
+		}
+
+		asPathFilters = append(asPathFilters, apf)
+	}
+
+	nextHops := make([]*bnet.IP, 0, len(f.NextHop))
+	for _, x := range f.NextHop {
+		addr, err := bnet.IPFromString(x)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid next_hop address %q: %w", x, err)
+		}
+
+		nextHops = append(nextHops, addr.Dedup())
+	}
+
+	protocols := make([]uint8, 0, len(f.Protocol))
+	for _, x := range f.Protocol {
+		p, err := protocolToPathType(x)
+		if err != nil {
+			return nil, err
+		}
+
+		protocols = append(protocols, p)
+	}
+
+	rpkiValidationStates := make([]uint8, 0, len(f.RPKIValidation))
+	for _, x := range f.RPKIValidation {
+		state, err := rpkiValidationStateFromString(x)
+		if err != nil {
+			return nil, err
+		}
+
+		rpkiValidationStates = append(rpkiValidationStates, state)
+	}
+
+	aspaVerificationStates := make([]uint8, 0, len(f.ASPAVerification))
+	for _, x := range f.ASPAVerification {
+		state, err := aspaVerificationStateFromString(x)
+		if err != nil {
+			return nil, err
+		}
+
+		aspaVerificationStates = append(aspaVerificationStates, state)
+	}
+
+	return filter.NewTermCondition(prefixLists, routeFilters).
+		WithCommunityFilters(communityFilters...).
+		WithLargeCommunityFilters(largeCommunityFilters...).
+		WithExtendedCommunityFilters(extendedCommunityFilters...).
+		WithASPathFilters(asPathFilters...).
+		WithOriginASNs(f.OriginAS...).
+		WithNextHops(nextHops...).
+		WithProtocols(protocols...).
+		WithMEDs(f.MED...).
+		WithLocalPrefs(f.LocalPref...).
+		WithRPKIValidationStates(rpkiValidationStates...).
+		WithASPAVerificationStates(aspaVerificationStates...), nil
+}
+
+func (f *PolicyStatementTermFrom) empty() bool {
+	return len(f.RouteFilters) == 0 &&
+		len(f.PrefixLists) == 0 &&
+		len(f.Communities) == 0 &&
+		len(f.LargeCommunities) == 0 &&
+		len(f.ExtendedCommunities) == 0 &&
+		len(f.ASPath) == 0 &&
+		len(f.OriginAS) == 0 &&
+		len(f.NextHop) == 0 &&
+		len(f.Protocol) == 0 &&
+		len(f.MED) == 0 &&
+		len(f.LocalPref) == 0 &&
+		len(f.RPKIValidation) == 0 &&
+		len(f.ASPAVerification) == 0
+}
+
+// toCommunityActions converts the modification into actions applied in the order replace, delete, remove, add
+func (cm *CommunityModification) toCommunityActions() ([]actions.Action, error) {
+	res := make([]actions.Action, 0)
+
+	replace, err := parseCommunities(cm.Replace)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(replace) > 0 {
+		res = append(res, actions.NewReplaceCommunityAction(&replace))
+	}
+
+	for _, expr := range cm.Delete {
+		a, err := actions.NewRemoveCommunityByRegexAction(expr)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, a)
+	}
+
+	remove, err := parseCommunities(cm.Remove)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(remove) > 0 {
+		res = append(res, actions.NewRemoveCommunityAction(&remove))
+	}
+
+	add, err := parseCommunities(cm.Add)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(add) > 0 {
+		res = append(res, actions.NewAddCommunityAction(&add))
+	}
+
+	return res, nil
+}
+
+// toLargeCommunityActions converts the modification into actions applied in the order replace, delete, remove, add
+func (cm *CommunityModification) toLargeCommunityActions() ([]actions.Action, error) {
+	res := make([]actions.Action, 0)
+
+	replace, err := parseLargeCommunities(cm.Replace)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(replace) > 0 {
+		res = append(res, actions.NewReplaceLargeCommunityAction(&replace))
+	}
+
+	for _, expr := range cm.Delete {
+		a, err := actions.NewRemoveLargeCommunityByRegexAction(expr)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, a)
+	}
+
+	remove, err := parseLargeCommunities(cm.Remove)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(remove) > 0 {
+		res = append(res, actions.NewRemoveLargeCommunityAction(&remove))
+	}
+
+	add, err := parseLargeCommunities(cm.Add)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(add) > 0 {
+		res = append(res, actions.NewAddLargeCommunityAction(&add))
+	}
+
+	return res, nil
+}
+
+func parseCommunities(coms []string) (types.Communities, error) {
+	res := make(types.Communities, 0, len(coms))
+	for _, x := range coms {
+		c, err := types.ParseCommunityString(x)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid community %q: %w", x, err)
+		}
+
+		res = append(res, c)
+	}
+
+	return res, nil
+}
+
+func parseLargeCommunities(coms []string) (types.LargeCommunities, error) {
+	res := make(types.LargeCommunities, 0, len(coms))
+	for _, x := range coms {
+		c, err := types.ParseLargeCommunityString(x)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid large community %q: %w", x, err)
+		}
+
+		res = append(res, c)
+	}
+
+	return res, nil
+}
+
+func originFromString(origin string) (uint8, error) {
+	switch origin {
+	case "igp":
+		return packet.IGP, nil
+	case "egp":
+		return packet.EGP, nil
+	case "incomplete":
+		return packet.INCOMPLETE, nil
+	}
+
+	return 0, fmt.Errorf("Invalid origin: %q", origin)
+}
+
+func protocolToPathType(protocol string) (uint8, error) {
+	switch protocol {
+	case "bgp":
+		return route.BGPPathType, nil
+	case "static":
+		return route.StaticPathType, nil
+	case "ospf":
+		return route.OSPFPathType, nil
+	case "isis":
+		return route.ISISPathType, nil
+	case "kernel", "fib":
+		return route.FIBPathType, nil
+	case "connected":
+		return route.ConnectedPathType, nil
+	}
+
+	return 0, fmt.Errorf("Invalid protocol: %q", protocol)
+}
+
+func rpkiValidationStateFromString(state string) (uint8, error) {
+	switch state {
+	case "valid":
+		return route.RPKIValidationValid, nil
+	case "invalid":
+		return route.RPKIValidationInvalid, nil
+	case "not-found":
+		return route.RPKIValidationNotFound, nil
+	case "unverified":
+		return route.RPKIValidationUnverified, nil
+	}
+
+	return 0, fmt.Errorf("Invalid RPKI validation state: %q", state)
+}
+
+func aspaVerificationStateFromString(state string) (uint8, error) {
+	switch state {
+	case "valid":
+		return route.ASPAVerificationValid, nil
+	case "invalid":
+		return route.ASPAVerificationInvalid, nil
+	case "unknown":
+		return route.ASPAVerificationUnknown, nil
+	case "unverified":
+		return route.ASPAVerificationUnverified, nil
+	}
+
+	return 0, fmt.Errorf("Invalid ASPA verification state: %q", state)
+}
@@ -16,6 +16,7 @@ var (
 	PolicyStatementTermFromDoc encoder.Doc
 	RouteFilterDoc             encoder.Doc
 	PolicyStatementTermThenDoc encoder.Doc
+	CommunityModificationDoc   encoder.Doc
 	ASPathPrependDoc           encoder.Doc
 	NextHopDoc                 encoder.Doc
 )
@@ -33,7 +34,7 @@ func init() {
 	PolicyOptionsDoc.Fields[1].Name = "prefix_lists"
 	PolicyOptionsDoc.Fields[1].Type = "[]PrefixList"
 	PolicyOptionsDoc.Fields[1].Note = ""
-	PolicyOptionsDoc.Fields[1].Description = "prefix lists to be used in the policy statements.\nExample:\n  prefix-lists:\n    prefixes:\n      - 2001:db8:0:1::/64"
+	PolicyOptionsDoc.Fields[1].Description = "prefix lists to be used in the policy statements.\nExample:\n  prefix_lists:\n    - name: \"CUSTOMER-A\"\n      prefixes:\n        - 2001:db8:0:1::/64"
 	PolicyOptionsDoc.Fields[1].Comments[encoder.LineComment] = "prefix lists to be used in the policy statements."
 
 	PrefixListDoc.Type = "PrefixList"
@@ -45,12 +46,17 @@ func init() {
 			FieldName: "prefix_lists",
 		},
 	}
-	PrefixListDoc.Fields = make([]encoder.Doc, 1)
-	PrefixListDoc.Fields[0].Name = "prefixes"
-	PrefixListDoc.Fields[0].Type = "[]string"
+	PrefixListDoc.Fields = make([]encoder.Doc, 2)
+	PrefixListDoc.Fields[0].Name = "name"
+	PrefixListDoc.Fields[0].Type = "string"
 	PrefixListDoc.Fields[0].Note = ""
-	PrefixListDoc.Fields[0].Description = "List of prefixes"
-	PrefixListDoc.Fields[0].Comments[encoder.LineComment] = "List of prefixes"
+	PrefixListDoc.Fields[0].Description = "Name of the prefix list"
+	PrefixListDoc.Fields[0].Comments[encoder.LineComment] = "Name of the prefix list"
+	PrefixListDoc.Fields[1].Name = "prefixes"
+	PrefixListDoc.Fields[1].Type = "[]string"
+	PrefixListDoc.Fields[1].Note = ""
+	PrefixListDoc.Fields[1].Description = "List of prefixes"
+	PrefixListDoc.Fields[1].Comments[encoder.LineComment] = "List of prefixes"
 
 	PolicyStatementDoc.Type = "PolicyStatement"
 	PolicyStatementDoc.Comments[encoder.LineComment] = ""
@@ -96,7 +102,7 @@ func init() {
 	PolicyStatementTermDoc.Fields[2].Name = "then"
 	PolicyStatementTermDoc.Fields[2].Type = "PolicyStatementTermThen"
 	PolicyStatementTermDoc.Fields[2].Note = ""
-	PolicyStatementTermDoc.Fields[2].Description = "Action to execute if the filter matches\nAvailable actions are:\n  - Accept: accepts the route without modifications\n  - Reject: rejects the route\n  - MED: sets the MED to the specified value (max 4294967295)\n  - LocalPref sets the local preference to the specified value (max 4294967295)\n  - AsPathPrepend: prepends AS numbers to the route. Details bellow\n  - NextHop: modify the next-hop to the specified address"
+	PolicyStatementTermDoc.Fields[2].Description = "Action to execute if the filter matches\nAvailable actions are:\n  - Accept: accepts the route without modifications\n  - Reject: rejects the route\n  - MED: sets the MED to the specified value (max 4294967295)\n  - LocalPref sets the local preference to the specified value (max 4294967295)\n  - AsPathPrepend: prepends AS numbers to the route. Details bellow\n  - NextHop: modify the next-hop to the specified address\n  - Community/LargeCommunity: add, remove, replace or delete (by regex) communities\n  - Origin: sets the origin\n  - RemovePrivateAS: removes private ASNs from the AS path\n  - Next: continues with the next term or the next policy"
 	PolicyStatementTermDoc.Fields[2].Comments[encoder.LineComment] = "Action to execute if the filter matches"
 
 	PolicyStatementTermFromDoc.Type = "PolicyStatementTermFrom"
@@ -108,12 +114,72 @@ func init() {
 			FieldName: "from",
 		},
 	}
-	PolicyStatementTermFromDoc.Fields = make([]encoder.Doc, 1)
+	PolicyStatementTermFromDoc.Fields = make([]encoder.Doc, 13)
 	PolicyStatementTermFromDoc.Fields[0].Name = "route_filters"
 	PolicyStatementTermFromDoc.Fields[0].Type = "[]RouteFilter"
 	PolicyStatementTermFromDoc.Fields[0].Note = ""
 	PolicyStatementTermFromDoc.Fields[0].Description = "List of route filters to match incoming packets\nExample:\n  route_filters:\n     - prefix: \"198.51.100.0/24\"\n       matcher: \"orlonger\""
 	PolicyStatementTermFromDoc.Fields[0].Comments[encoder.LineComment] = "List of route filters to match incoming packets"
+	PolicyStatementTermFromDoc.Fields[1].Name = "prefix_lists"
+	PolicyStatementTermFromDoc.Fields[1].Type = "[]string"
+	PolicyStatementTermFromDoc.Fields[1].Note = ""
+	PolicyStatementTermFromDoc.Fields[1].Description = "Names of prefix lists (see prefix_lists) the prefix has to be on exactly"
+	PolicyStatementTermFromDoc.Fields[1].Comments[encoder.LineComment] = "Names of prefix lists (see prefix_lists) the prefix has to be on exactly"
+	PolicyStatementTermFromDoc.Fields[2].Name = "communities"
+	PolicyStatementTermFromDoc.Fields[2].Type = "[]string"
+	PolicyStatementTermFromDoc.Fields[2].Note = ""
+	PolicyStatementTermFromDoc.Fields[2].Description = "Communities of which at least one has to be attached to the route\nExample:\n  communities:\n    - \"(65000,100)\""
+	PolicyStatementTermFromDoc.Fields[2].Comments[encoder.LineComment] = "Communities of which at least one has to be attached to the route"
+	PolicyStatementTermFromDoc.Fields[3].Name = "large_communities"
+	PolicyStatementTermFromDoc.Fields[3].Type = "[]string"
+	PolicyStatementTermFromDoc.Fields[3].Note = ""
+	PolicyStatementTermFromDoc.Fields[3].Description = "Large communities of which at least one has to be attached to the route\nExample:\n  large_communities:\n    - \"(65000,1,100)\""
+	PolicyStatementTermFromDoc.Fields[3].Comments[encoder.LineComment] = "Large communities of which at least one has to be attached to the route"
+	PolicyStatementTermFromDoc.Fields[4].Name = "extended_communities"
+	PolicyStatementTermFromDoc.Fields[4].Type = "[]string"
+	PolicyStatementTermFromDoc.Fields[4].Note = ""
+	PolicyStatementTermFromDoc.Fields[4].Description = "Extended communities of which at least one has to be attached to the route\nExample:\n  extended_communities:\n    - \"target:65000:100\""
+	PolicyStatementTermFromDoc.Fields[4].Comments[encoder.LineComment] = "Extended communities of which at least one has to be attached to the route"
+	PolicyStatementTermFromDoc.Fields[5].Name = "as_path"
+	PolicyStatementTermFromDoc.Fields[5].Type = "[]string"
+	PolicyStatementTermFromDoc.Fields[5].Note = ""
+	PolicyStatementTermFromDoc.Fields[5].Description = "Regular expressions of which at least one has to match the AS path.\nThe AS path is matched in the form \"65000 65001 (65002 65003)\", underscores match ASN boundaries.\nExample:\n  as_path:\n    - \"^65000_\"\n    - \"_(64496|64511)$\""
+	PolicyStatementTermFromDoc.Fields[5].Comments[encoder.LineComment] = "Regular expressions of which at least one has to match the AS path."
+	PolicyStatementTermFromDoc.Fields[6].Name = "origin_as"
+	PolicyStatementTermFromDoc.Fields[6].Type = "[]uint32"
+	PolicyStatementTermFromDoc.Fields[6].Note = ""
+	PolicyStatementTermFromDoc.Fields[6].Description = "ASNs of which one has to be the origin (last ASN of the AS path) of the route"
+	PolicyStatementTermFromDoc.Fields[6].Comments[encoder.LineComment] = "ASNs of which one has to be the origin (last ASN of the AS path) of the route"
+	PolicyStatementTermFromDoc.Fields[7].Name = "next_hop"
+	PolicyStatementTermFromDoc.Fields[7].Type = "[]string"
+	PolicyStatementTermFromDoc.Fields[7].Note = ""
+	PolicyStatementTermFromDoc.Fields[7].Description = "Next hop addresses of which one has to be the next hop of the route"
+	PolicyStatementTermFromDoc.Fields[7].Comments[encoder.LineComment] = "Next hop addresses of which one has to be the next hop of the route"
+	PolicyStatementTermFromDoc.Fields[8].Name = "protocol"
+	PolicyStatementTermFromDoc.Fields[8].Type = "[]string"
+	PolicyStatementTermFromDoc.Fields[8].Note = ""
+	PolicyStatementTermFromDoc.Fields[8].Description = "Protocols of which one has to have learned the route\nAvailable options: bgp, static, ospf, isis, kernel (or fib), connected"
+	PolicyStatementTermFromDoc.Fields[8].Comments[encoder.LineComment] = "Protocols of which one has to have learned the route"
+	PolicyStatementTermFromDoc.Fields[9].Name = "med"
+	PolicyStatementTermFromDoc.Fields[9].Type = "[]uint32"
+	PolicyStatementTermFromDoc.Fields[9].Note = ""
+	PolicyStatementTermFromDoc.Fields[9].Description = "MEDs of which one has to be set on the route"
+	PolicyStatementTermFromDoc.Fields[9].Comments[encoder.LineComment] = "MEDs of which one has to be set on the route"
+	PolicyStatementTermFromDoc.Fields[10].Name = "local_pref"
+	PolicyStatementTermFromDoc.Fields[10].Type = "[]uint32"
+	PolicyStatementTermFromDoc.Fields[10].Note = ""
+	PolicyStatementTermFromDoc.Fields[10].Description = "Local preferences of which one has to be set on the route"
+	PolicyStatementTermFromDoc.Fields[10].Comments[encoder.LineComment] = "Local preferences of which one has to be set on the route"
+	PolicyStatementTermFromDoc.Fields[11].Name = "rpki_validation"
+	PolicyStatementTermFromDoc.Fields[11].Type = "[]string"
+	PolicyStatementTermFromDoc.Fields[11].Note = ""
+	PolicyStatementTermFromDoc.Fields[11].Description = "RPKI origin validation states (RFC6811) of which one has to be the state of the route.\nRoutes are validated against the VRPs learned from the caches configured in routing_options\nAvailable options: valid, invalid, not-found, unverified\nExample:\n  rpki_validation:\n    - \"invalid\""
+	PolicyStatementTermFromDoc.Fields[11].Comments[encoder.LineComment] = "RPKI origin validation states (RFC6811) of which one has to be the state of the route."
+	PolicyStatementTermFromDoc.Fields[12].Name = "aspa_verification"
+	PolicyStatementTermFromDoc.Fields[12].Type = "[]string"
+	PolicyStatementTermFromDoc.Fields[12].Note = ""
+	PolicyStatementTermFromDoc.Fields[12].Description = "ASPA verification states of which one has to be the state of the AS path of the route.\nAS paths are verified against the ASPAs learned from the sources configured in routing_options\non sessions with a local_role\nAvailable options: valid, invalid, unknown, unverified\nExample:\n  aspa_verification:\n    - \"invalid\""
+	PolicyStatementTermFromDoc.Fields[12].Comments[encoder.LineComment] = "ASPA verification states of which one has to be the state of the AS path of the route."
 
 	RouteFilterDoc.Type = "RouteFilter"
 	RouteFilterDoc.Comments[encoder.LineComment] = ""
@@ -155,7 +221,7 @@ func init() {
 			FieldName: "then",
 		},
 	}
-	PolicyStatementTermThenDoc.Fields = make([]encoder.Doc, 6)
+	PolicyStatementTermThenDoc.Fields = make([]encoder.Doc, 11)
 	PolicyStatementTermThenDoc.Fields[0].Name = "accept"
 	PolicyStatementTermThenDoc.Fields[0].Type = "bool"
 	PolicyStatementTermThenDoc.Fields[0].Note = ""
@@ -186,6 +252,66 @@ func init() {
 	PolicyStatementTermThenDoc.Fields[5].Note = ""
 	PolicyStatementTermThenDoc.Fields[5].Description = "IP address to be used as a next-hop for the route"
 	PolicyStatementTermThenDoc.Fields[5].Comments[encoder.LineComment] = "IP address to be used as a next-hop for the route"
+	PolicyStatementTermThenDoc.Fields[6].Name = "community"
+	PolicyStatementTermThenDoc.Fields[6].Type = "CommunityModification"
+	PolicyStatementTermThenDoc.Fields[6].Note = ""
+	PolicyStatementTermThenDoc.Fields[6].Description = "Modification of the communities of the route\nExample:\n  community:\n    delete:\n      - \"^\\(65000,\"\n    add:\n      - \"(65000,100)\""
+	PolicyStatementTermThenDoc.Fields[6].Comments[encoder.LineComment] = "Modification of the communities of the route"
+	PolicyStatementTermThenDoc.Fields[7].Name = "large_community"
+	PolicyStatementTermThenDoc.Fields[7].Type = "CommunityModification"
+	PolicyStatementTermThenDoc.Fields[7].Note = ""
+	PolicyStatementTermThenDoc.Fields[7].Description = "Modification of the large communities of the route\nExample:\n  large_community:\n    remove:\n      - \"(65000,1,100)\""
+	PolicyStatementTermThenDoc.Fields[7].Comments[encoder.LineComment] = "Modification of the large communities of the route"
+	PolicyStatementTermThenDoc.Fields[8].Name = "origin"
+	PolicyStatementTermThenDoc.Fields[8].Type = "string"
+	PolicyStatementTermThenDoc.Fields[8].Note = ""
+	PolicyStatementTermThenDoc.Fields[8].Description = "Origin to set\nAvailable options: igp, egp, incomplete"
+	PolicyStatementTermThenDoc.Fields[8].Comments[encoder.LineComment] = "Origin to set"
+	PolicyStatementTermThenDoc.Fields[9].Name = "remove_private_as"
+	PolicyStatementTermThenDoc.Fields[9].Type = "bool"
+	PolicyStatementTermThenDoc.Fields[9].Note = ""
+	PolicyStatementTermThenDoc.Fields[9].Description = "Remove all private ASNs (RFC6996) from the AS path"
+	PolicyStatementTermThenDoc.Fields[9].Comments[encoder.LineComment] = "Remove all private ASNs (RFC6996) from the AS path"
+	PolicyStatementTermThenDoc.Fields[10].Name = "next"
+	PolicyStatementTermThenDoc.Fields[10].Type = "string"
+	PolicyStatementTermThenDoc.Fields[10].Note = ""
+	PolicyStatementTermThenDoc.Fields[10].Description = "Flow control after executing the actions of this term\nAvailable options:\n  - term: skips the remaining actions of this term and continues with the next term\n  - policy: skips the remaining terms of this policy and continues with the next policy"
+	PolicyStatementTermThenDoc.Fields[10].Comments[encoder.LineComment] = "Flow control after executing the actions of this term"
+
+	CommunityModificationDoc.Type = "CommunityModification"
+	CommunityModificationDoc.Comments[encoder.LineComment] = ""
+	CommunityModificationDoc.Description = ""
+	CommunityModificationDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "PolicyStatementTermThen",
+			FieldName: "community",
+		},
+		{
+			TypeName:  "PolicyStatementTermThen",
+			FieldName: "large_community",
+		},
+	}
+	CommunityModificationDoc.Fields = make([]encoder.Doc, 4)
+	CommunityModificationDoc.Fields[0].Name = "add"
+	CommunityModificationDoc.Fields[0].Type = "[]string"
+	CommunityModificationDoc.Fields[0].Note = ""
+	CommunityModificationDoc.Fields[0].Description = "Communities to add"
+	CommunityModificationDoc.Fields[0].Comments[encoder.LineComment] = "Communities to add"
+	CommunityModificationDoc.Fields[1].Name = "remove"
+	CommunityModificationDoc.Fields[1].Type = "[]string"
+	CommunityModificationDoc.Fields[1].Note = ""
+	CommunityModificationDoc.Fields[1].Description = "Communities to remove"
+	CommunityModificationDoc.Fields[1].Comments[encoder.LineComment] = "Communities to remove"
+	CommunityModificationDoc.Fields[2].Name = "replace"
+	CommunityModificationDoc.Fields[2].Type = "[]string"
+	CommunityModificationDoc.Fields[2].Note = ""
+	CommunityModificationDoc.Fields[2].Description = "Communities replacing all existing communities"
+	CommunityModificationDoc.Fields[2].Comments[encoder.LineComment] = "Communities replacing all existing communities"
+	CommunityModificationDoc.Fields[3].Name = "delete"
+	CommunityModificationDoc.Fields[3].Type = "[]string"
+	CommunityModificationDoc.Fields[3].Note = ""
+	CommunityModificationDoc.Fields[3].Description = "Regular expressions matching the communities to remove\nExample (all communities of AS65000):\n  delete:\n    - \"^\\(65000,\""
+	CommunityModificationDoc.Fields[3].Comments[encoder.LineComment] = "Regular expressions matching the communities to remove"
 
 	ASPathPrependDoc.Type = "ASPathPrepend"
 	ASPathPrependDoc.Comments[encoder.LineComment] = ""
@@ -253,6 +379,10 @@ func (_ PolicyStatementTermThen) Doc() *encoder.Doc {
 	return &PolicyStatementTermThenDoc
 }
 
+func (_ CommunityModification) Doc() *encoder.Doc {
+	return &CommunityModificationDoc
+}
+
 func (_ ASPathPrepend) Doc() *encoder.Doc {
 	return &ASPathPrependDoc
 }
@@ -274,6 +404,7 @@ func GetpolicyDoc() *encoder.FileDoc {
 			&PolicyStatementTermFromDoc,
 			&RouteFilterDoc,
 			&PolicyStatementTermThenDoc,
+			&CommunityModificationDoc,
 			&ASPathPrependDoc,
 			&NextHopDoc,
 		},
@@ -0,0 +1,265 @@
+package config
+
+import (
+	"testing"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/packet"
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/bio-routing/bio-rd/routingtable/filter"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+const PolicyOptionsTestFile = `
+prefix_lists:
+  - name: "CUSTOMER-A"
+    prefixes:
+      - 198.51.100.0/24
+policy_statements:
+  - name: "CUSTOMER-A-In"
+    terms:
+      - name: "Accept_customer"
+        from:
+          prefix_lists:
+            - "CUSTOMER-A"
+          communities:
+            - "(65000,100)"
+          large_communities:
+            - "(65000,1,100)"
+          as_path:
+            - "^64496_"
+          origin_as:
+            - 64497
+          next_hop:
+            - 192.0.2.1
+          protocol:
+            - bgp
+          med:
+            - 10
+          local_pref:
+            - 100
+          rpki_validation:
+            - valid
+            - not-found
+        then:
+          accept: true
+      - name: "Reject_rest"
+        then:
+          reject: true
+`
+
+func TestPolicyOptionsLoad(t *testing.T) {
+	var po *PolicyOptions
+	err := yaml.Unmarshal([]byte(PolicyOptionsTestFile), &po)
+	if err != nil {
+		t.Fatalf("unexpected error while parsing: %s", err)
+	}
+
+	err = po.load()
+	if err != nil {
+		t.Fatalf("unexpected error while loading policy options: %s", err)
+	}
+
+	f := po.getPolicyStatementFilter("CUSTOMER-A-In")
+	if !assert.NotNil(t, f) {
+		return
+	}
+
+	path := func(originAS uint32, localPref uint32, rpkiValidationState uint8) *route.Path {
+		return &route.Path{
+			Type: route.BGPPathType,
+			BGPPath: &route.BGPPath{
+				RPKIValidationState: rpkiValidationState,
+				BGPPathA: &route.BGPPathA{
+					NextHop:   bnet.IPv4FromOctets(192, 0, 2, 1).Ptr(),
+					MED:       10,
+					LocalPref: localPref,
+				},
+				ASPath: &types.ASPath{
+					{Type: types.ASSequence, ASNs: []uint32{64496, originAS}},
+				},
+				Communities:      &types.Communities{65000<<16 | 100},
+				LargeCommunities: &types.LargeCommunities{{GlobalAdministrator: 65000, DataPart1: 1, DataPart2: 100}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		pfx    *bnet.Prefix
+		path   *route.Path
+		reject bool
+	}{
+		{
+			name: "all conditions match",
+			pfx:  bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 100, 0), 24).Ptr(),
+			path: path(64497, 100, route.RPKIValidationValid),
+		},
+		{
+			name:   "prefix not on prefix list",
+			pfx:    bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 100, 0), 25).Ptr(),
+			path:   path(64497, 100, route.RPKIValidationValid),
+			reject: true,
+		},
+		{
+			name:   "wrong origin AS",
+			pfx:    bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 100, 0), 24).Ptr(),
+			path:   path(64498, 100, route.RPKIValidationValid),
+			reject: true,
+		},
+		{
+			name:   "wrong local pref",
+			pfx:    bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 100, 0), 24).Ptr(),
+			path:   path(64497, 200, route.RPKIValidationValid),
+			reject: true,
+		},
+		{
+			name:   "RPKI invalid",
+			pfx:    bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 100, 0), 24).Ptr(),
+			path:   path(64497, 100, route.RPKIValidationInvalid),
+			reject: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res := f.Process(test.pfx, test.path)
+			assert.Equal(t, test.reject, res.Reject)
+		})
+	}
+}
+
+const PolicyOptionsActionsTestFile = `
+policy_statements:
+  - name: "Transit-Out"
+    terms:
+      - name: "Clean_up"
+        then:
+          origin: incomplete
+          remove_private_as: true
+          community:
+            delete:
+              - "^\\(65000,"
+            add:
+              - "(64496,100)"
+          large_community:
+            replace:
+              - "(64496,1,1)"
+          next: policy
+      - name: "Reject_all"
+        then:
+          reject: true
+  - name: "Accept-All"
+    terms:
+      - name: "Accept"
+        then:
+          accept: true
+`
+
+func TestPolicyOptionsLoadActions(t *testing.T) {
+	var po *PolicyOptions
+	err := yaml.Unmarshal([]byte(PolicyOptionsActionsTestFile), &po)
+	if err != nil {
+		t.Fatalf("unexpected error while parsing: %s", err)
+	}
+
+	err = po.load()
+	if err != nil {
+		t.Fatalf("unexpected error while loading policy options: %s", err)
+	}
+
+	c := filter.Chain{
+		po.getPolicyStatementFilter("Transit-Out"),
+		po.getPolicyStatementFilter("Accept-All"),
+	}
+
+	p := &route.Path{
+		Type: route.BGPPathType,
+		BGPPath: &route.BGPPath{
+			BGPPathA: &route.BGPPathA{
+				NextHop: bnet.IPv4FromOctets(192, 0, 2, 1).Ptr(),
+			},
+			ASPath: &types.ASPath{
+				{Type: types.ASSequence, ASNs: []uint32{65001, 64497}},
+			},
+			Communities:      &types.Communities{65000<<16 | 1, 64497<<16 | 1},
+			LargeCommunities: &types.LargeCommunities{{GlobalAdministrator: 64497, DataPart1: 1, DataPart2: 1}},
+		},
+	}
+
+	res, reject := c.Process(bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 100, 0), 24).Ptr(), p)
+	assert.False(t, reject)
+	assert.Equal(t, uint8(packet.INCOMPLETE), res.BGPPath.BGPPathA.Origin)
+	assert.Equal(t, "64497", res.BGPPath.ASPath.String())
+	assert.Equal(t, "(64497,1) (64496,100)", res.BGPPath.CommunitiesString())
+	assert.Equal(t, "(64496,1,1)", res.BGPPath.LargeCommunities.String())
+}
+
+func TestPolicyOptionsLoadInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		from PolicyStatementTermFrom
+		then PolicyStatementTermThen
+	}{
+		{
+			name: "undefined prefix list",
+			from: PolicyStatementTermFrom{PrefixLists: []string{"UNDEFINED"}},
+		},
+		{
+			name: "invalid AS path expression",
+			from: PolicyStatementTermFrom{ASPath: []string{"(64496"}},
+		},
+		{
+			name: "invalid community",
+			from: PolicyStatementTermFrom{Communities: []string{"65000:100"}},
+		},
+		{
+			name: "invalid protocol",
+			from: PolicyStatementTermFrom{Protocol: []string{"rip"}},
+		},
+		{
+			name: "invalid RPKI validation state",
+			from: PolicyStatementTermFrom{RPKIValidation: []string{"unknown"}},
+		},
+		{
+			name: "invalid ASPA verification state",
+			from: PolicyStatementTermFrom{ASPAVerification: []string{"not-found"}},
+		},
+		{
+			name: "invalid origin",
+			then: PolicyStatementTermThen{Origin: "unknown"},
+		},
+		{
+			name: "invalid community regex",
+			then: PolicyStatementTermThen{Community: &CommunityModification{Delete: []string{"("}}},
+		},
+		{
+			name: "next combined with accept",
+			then: PolicyStatementTermThen{Next: "term", Accept: true},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			po := &PolicyOptions{
+				PolicyStatements: []*PolicyStatement{
+					{
+						Name: "test",
+						Terms: []*PolicyStatementTerm{
+							{
+								Name: "test",
+								From: test.from,
+								Then: test.then,
+							},
+						},
+					},
+				},
+			}
+
+			assert.Error(t, po.load())
+		})
+	}
+}
@@ -4,6 +4,14 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
+)
+
+const (
+	// minVPNLabel is the lowest label not reserved for special purposes (RFC3032 Sect. 2.1)
+	minVPNLabel = 16
+	maxVPNLabel = 1<<20 - 1
 )
 
 type RoutingInstance struct {
@@ -17,6 +25,22 @@ type RoutingInstance struct {
 	// docgen:nodoc
 	InternalRouteDistinguisher uint64
 	// description: |
+	//   Route targets of VPN routes to be imported into this routing instance (RFC4364).
+	//   Example: route_target_import: ["target:65000:100"]
+	RouteTargetImport []string `yaml:"route_target_import"`
+	// docgen:nodoc
+	RouteTargetImportList []types.ExtendedCommunity
+	// description: |
+	//   Route targets attached to routes exported from this routing instance into the VPN tables (RFC4364).
+	//   Example: route_target_export: ["target:65000:100"]
+	RouteTargetExport []string `yaml:"route_target_export"`
+	// docgen:nodoc
+	RouteTargetExportList []types.ExtendedCommunity
+	// description: |
+	//   MPLS label advertised for routes exported from this routing instance (16 - 1048575).
+	//   Mandatory if route_target_export is set
+	VPNLabel uint32 `yaml:"vpn_label"`
+	// description: |
 	//   Routing options for this routing instance. See main config documentation for details
 	RoutingOptions *RoutingOptions `yaml:"routing_options"`
 	// description: |
@@ -24,15 +48,68 @@ type RoutingInstance struct {
 	Protocols *Protocols `yaml:"protocols"`
 }
 
-func (ri *RoutingInstance) load() error {
+func (ri *RoutingInstance) load(policyOptions *PolicyOptions) error {
 	err := ri.loadRD()
 	if err != nil {
 		return fmt.Errorf("unable to load route distinguisher: %w", err)
 	}
 
+	ri.RouteTargetImportList, err = parseRouteTargets(ri.RouteTargetImport)
+	if err != nil {
+		return fmt.Errorf("unable to load import route targets: %w", err)
+	}
+
+	ri.RouteTargetExportList, err = parseRouteTargets(ri.RouteTargetExport)
+	if err != nil {
+		return fmt.Errorf("unable to load export route targets: %w", err)
+	}
+
+	if len(ri.RouteTargetExportList) > 0 && (ri.VPNLabel < minVPNLabel || ri.VPNLabel > maxVPNLabel) {
+		return fmt.Errorf("vpn_label %d of routing instance %q is out of range (%d - %d)", ri.VPNLabel, ri.Name, minVPNLabel, maxVPNLabel)
+	}
+
+	if ri.RoutingOptions != nil {
+		err = ri.RoutingOptions.loadStaticRoutes()
+		if err != nil {
+			return fmt.Errorf("error in routing_options: %w", err)
+		}
+
+		err = ri.RoutingOptions.loadAggregates(policyOptions)
+		if err != nil {
+			return fmt.Errorf("error in routing_options: %w", err)
+		}
+
+		err = ri.RoutingOptions.loadKernel(policyOptions)
+		if err != nil {
+			return fmt.Errorf("error in routing_options: %w", err)
+		}
+
+		if ri.RoutingOptions.RPKI != nil {
+			return fmt.Errorf("rpki is not supported in routing instances")
+		}
+	}
+
 	return nil
 }
 
+func parseRouteTargets(rts []string) ([]types.ExtendedCommunity, error) {
+	ret := make([]types.ExtendedCommunity, 0, len(rts))
+	for _, x := range rts {
+		rt, err := types.ParseExtendedCommunityString(x)
+		if err != nil {
+			return nil, err
+		}
+
+		if !rt.IsRouteTarget() {
+			return nil, fmt.Errorf("%q is not a route target", x)
+		}
+
+		ret = append(ret, rt)
+	}
+
+	return ret, nil
+}
+
 func (ri *RoutingInstance) loadRD() error {
 	parts := strings.Split(ri.RouteDistinguisher, ":")
 	if len(parts) != 2 {
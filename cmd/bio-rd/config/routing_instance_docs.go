@@ -14,7 +14,7 @@ func init() {
 	RoutingInstanceDoc.Type = "RoutingInstance"
 	RoutingInstanceDoc.Comments[encoder.LineComment] = ""
 	RoutingInstanceDoc.Description = ""
-	RoutingInstanceDoc.Fields = make([]encoder.Doc, 4)
+	RoutingInstanceDoc.Fields = make([]encoder.Doc, 7)
 	RoutingInstanceDoc.Fields[0].Name = "name"
 	RoutingInstanceDoc.Fields[0].Type = "string"
 	RoutingInstanceDoc.Fields[0].Note = ""
@@ -25,16 +25,31 @@ func init() {
 	RoutingInstanceDoc.Fields[1].Note = ""
 	RoutingInstanceDoc.Fields[1].Description = "String to be used as a route distinguisher.\nThe format has to be <uint32>:<uint32>. Using IP addresses is *not* allowed"
 	RoutingInstanceDoc.Fields[1].Comments[encoder.LineComment] = "String to be used as a route distinguisher."
-	RoutingInstanceDoc.Fields[2].Name = "routing_options"
-	RoutingInstanceDoc.Fields[2].Type = "RoutingOptions"
+	RoutingInstanceDoc.Fields[2].Name = "route_target_import"
+	RoutingInstanceDoc.Fields[2].Type = "[]string"
 	RoutingInstanceDoc.Fields[2].Note = ""
-	RoutingInstanceDoc.Fields[2].Description = "Routing options for this routing instance. See main config documentation for details"
-	RoutingInstanceDoc.Fields[2].Comments[encoder.LineComment] = "Routing options for this routing instance. See main config documentation for details"
-	RoutingInstanceDoc.Fields[3].Name = "protocols"
-	RoutingInstanceDoc.Fields[3].Type = "Protocols"
+	RoutingInstanceDoc.Fields[2].Description = "Route targets of VPN routes to be imported into this routing instance (RFC4364).\nExample: route_target_import: [\"target:65000:100\"]"
+	RoutingInstanceDoc.Fields[2].Comments[encoder.LineComment] = "Route targets of VPN routes to be imported into this routing instance (RFC4364)."
+	RoutingInstanceDoc.Fields[3].Name = "route_target_export"
+	RoutingInstanceDoc.Fields[3].Type = "[]string"
 	RoutingInstanceDoc.Fields[3].Note = ""
-	RoutingInstanceDoc.Fields[3].Description = "Protocols for this routing instance. See the main protocols documentation for details"
-	RoutingInstanceDoc.Fields[3].Comments[encoder.LineComment] = "Protocols for this routing instance. See the main protocols documentation for details"
+	RoutingInstanceDoc.Fields[3].Description = "Route targets attached to routes exported from this routing instance into the VPN tables (RFC4364).\nExample: route_target_export: [\"target:65000:100\"]"
+	RoutingInstanceDoc.Fields[3].Comments[encoder.LineComment] = "Route targets attached to routes exported from this routing instance into the VPN tables (RFC4364)."
+	RoutingInstanceDoc.Fields[4].Name = "vpn_label"
+	RoutingInstanceDoc.Fields[4].Type = "uint32"
+	RoutingInstanceDoc.Fields[4].Note = ""
+	RoutingInstanceDoc.Fields[4].Description = "MPLS label advertised for routes exported from this routing instance (16 - 1048575).\nMandatory if route_target_export is set"
+	RoutingInstanceDoc.Fields[4].Comments[encoder.LineComment] = "MPLS label advertised for routes exported from this routing instance (16 - 1048575)."
+	RoutingInstanceDoc.Fields[5].Name = "routing_options"
+	RoutingInstanceDoc.Fields[5].Type = "RoutingOptions"
+	RoutingInstanceDoc.Fields[5].Note = ""
+	RoutingInstanceDoc.Fields[5].Description = "Routing options for this routing instance. See main config documentation for details"
+	RoutingInstanceDoc.Fields[5].Comments[encoder.LineComment] = "Routing options for this routing instance. See main config documentation for details"
+	RoutingInstanceDoc.Fields[6].Name = "protocols"
+	RoutingInstanceDoc.Fields[6].Type = "Protocols"
+	RoutingInstanceDoc.Fields[6].Note = ""
+	RoutingInstanceDoc.Fields[6].Description = "Protocols for this routing instance. See the main protocols documentation for details"
+	RoutingInstanceDoc.Fields[6].Comments[encoder.LineComment] = "Protocols for this routing instance. See the main protocols documentation for details"
 }
 
 func (_ RoutingInstance) Doc() *encoder.Doc {
@@ -9,9 +9,18 @@ import (
 type RoutingOptions struct {
 	// description: |
 	//   List of static routes to install in the RIB
-	//   Still not implemented
+	//   <a href="static_route.md">parameter documentation</a>
 	StaticRoutes []StaticRoute `yaml:"static_routes"`
 	// description: |
+	//   List of aggregate routes originated while any more specific route exists
+	//   <a href="aggregate.md">parameter documentation</a>
+	//   Example:
+	//     aggregates:
+	//       - prefix: 198.51.100.0/22
+	//         as_set: true
+	//         summary_only: true
+	Aggregates []Aggregate `yaml:"aggregates"`
+	// description: |
 	//   32-bit number to serve as router id. Must have the format x.x.x.x
 	RouterID string `yaml:"router_id"`
 	// docgen:nodoc
@@ -19,14 +28,132 @@ type RoutingOptions struct {
 	// description: |
 	//   32-bit autonomous system number
 	AutonomousSystem uint32 `yaml:"autonomous_system"`
+	// description: |
+	//   Installation of routes into the Linux routing table. Routes are not installed if omitted
+	//   <a href="kernel.md">parameter documentation</a>
+	//   Example:
+	//     kernel:
+	//       table: 100
+	//       multipath: true
+	//       export:
+	//         - "Kernel-Out"
+	Kernel *Kernel `yaml:"kernel"`
+	// description: |
+	//   Import of routes from the Linux routing table into the RIB. Routes are not imported if omitted
+	//   <a href="kernel.md">parameter documentation</a>
+	//   Example:
+	//     kernel_import:
+	//       table: 254
+	//       protocols:
+	//         - "static"
+	//         - "dhcp"
+	KernelImport *KernelImport `yaml:"kernel_import"`
+	// description: |
+	//   Routes to the networks of the addresses configured on interfaces
+	//   Example:
+	//     connected:
+	//       interfaces:
+	//         - "lo"
+	//         - "eth0"
+	Connected *Connected `yaml:"connected"`
+	// description: |
+	//   RPKI caches and files to validate the origin and verify the AS path of BGP routes against.
+	//   No VRPs and ASPAs are learned if omitted
+	//   <a href="rpki.md">parameter documentation</a>
+	//   Example:
+	//     rpki:
+	//       caches:
+	//         - address: 192.0.2.1
+	//           port: 3323
+	//       files:
+	//         - path: /var/db/rpki-client/json
+	RPKI *RPKI `yaml:"rpki"`
+	// description: |
+	//   Resolve the next hops of routes received via iBGP via the IGP, static and connected routes.
+	//   Routes with unresolvable next hops are ineligible. The IGP metric to the next hop is considered in the best path selection
+	NextHopTracking bool `yaml:"next_hop_tracking"`
 }
 
-func (r *RoutingOptions) load() error {
+type Connected struct {
+	// description: |
+	//   List of interfaces whose networks are added to the RIB
+	Interfaces []string `yaml:"interfaces"`
+}
+
+func (r *RoutingOptions) load(policyOptions *PolicyOptions) error {
 	addr, err := bnet.IPFromString(r.RouterID)
 	if err != nil {
 		return fmt.Errorf("unable to parse router id: %w", err)
 	}
 	r.RouterIDUint32 = uint32(addr.Lower())
 
+	err = r.loadStaticRoutes()
+	if err != nil {
+		return err
+	}
+
+	err = r.loadAggregates(policyOptions)
+	if err != nil {
+		return err
+	}
+
+	err = r.loadKernel(policyOptions)
+	if err != nil {
+		return err
+	}
+
+	if r.RPKI != nil {
+		err = r.RPKI.load()
+		if err != nil {
+			return fmt.Errorf("error in rpki: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *RoutingOptions) loadStaticRoutes() error {
+	for i := range r.StaticRoutes {
+		err := r.StaticRoutes[i].load()
+		if err != nil {
+			return fmt.Errorf("error in static route: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *RoutingOptions) loadAggregates(policyOptions *PolicyOptions) error {
+	prefixes := make(map[bnet.Prefix]struct{}, len(r.Aggregates))
+	for i := range r.Aggregates {
+		err := r.Aggregates[i].load(policyOptions)
+		if err != nil {
+			return fmt.Errorf("error in aggregate: %w", err)
+		}
+
+		if _, found := prefixes[*r.Aggregates[i].Pfx]; found {
+			return fmt.Errorf("duplicate aggregate %s", r.Aggregates[i].Prefix)
+		}
+		prefixes[*r.Aggregates[i].Pfx] = struct{}{}
+	}
+
+	return nil
+}
+
+func (r *RoutingOptions) loadKernel(policyOptions *PolicyOptions) error {
+	if r.Kernel != nil {
+		err := r.Kernel.load(policyOptions)
+		if err != nil {
+			return fmt.Errorf("error in kernel: %w", err)
+		}
+	}
+
+	if r.KernelImport != nil {
+		err := r.KernelImport.load()
+		if err != nil {
+			return fmt.Errorf("error in kernel_import: %w", err)
+		}
+	}
+
 	return nil
 }
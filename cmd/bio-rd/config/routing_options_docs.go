@@ -8,34 +8,87 @@ import (
 	"github.com/projectdiscovery/yamldoc-go/encoder"
 )
 
-var RoutingOptionsDoc encoder.Doc
+var (
+	RoutingOptionsDoc encoder.Doc
+	ConnectedDoc      encoder.Doc
+)
 
 func init() {
 	RoutingOptionsDoc.Type = "RoutingOptions"
 	RoutingOptionsDoc.Comments[encoder.LineComment] = ""
 	RoutingOptionsDoc.Description = ""
-	RoutingOptionsDoc.Fields = make([]encoder.Doc, 3)
+	RoutingOptionsDoc.Fields = make([]encoder.Doc, 9)
 	RoutingOptionsDoc.Fields[0].Name = "static_routes"
 	RoutingOptionsDoc.Fields[0].Type = "[]StaticRoute"
 	RoutingOptionsDoc.Fields[0].Note = ""
-	RoutingOptionsDoc.Fields[0].Description = "List of static routes to install in the RIB\nStill not implemented"
+	RoutingOptionsDoc.Fields[0].Description = "List of static routes to install in the RIB\n<a href=\"static_route.md\">parameter documentation</a>"
 	RoutingOptionsDoc.Fields[0].Comments[encoder.LineComment] = "List of static routes to install in the RIB"
-	RoutingOptionsDoc.Fields[1].Name = "router_id"
-	RoutingOptionsDoc.Fields[1].Type = "string"
+	RoutingOptionsDoc.Fields[1].Name = "aggregates"
+	RoutingOptionsDoc.Fields[1].Type = "[]Aggregate"
 	RoutingOptionsDoc.Fields[1].Note = ""
-	RoutingOptionsDoc.Fields[1].Description = "32-bit number to serve as router id. Must have the format x.x.x.x"
-	RoutingOptionsDoc.Fields[1].Comments[encoder.LineComment] = "32-bit number to serve as router id. Must have the format x.x.x.x"
-	RoutingOptionsDoc.Fields[2].Name = "autonomous_system"
-	RoutingOptionsDoc.Fields[2].Type = "uint32"
+	RoutingOptionsDoc.Fields[1].Description = "List of aggregate routes originated while any more specific route exists\n<a href=\"aggregate.md\">parameter documentation</a>\nExample:\n  aggregates:\n    - prefix: 198.51.100.0/22\n      as_set: true\n      summary_only: true"
+	RoutingOptionsDoc.Fields[1].Comments[encoder.LineComment] = "List of aggregate routes originated while any more specific route exists"
+	RoutingOptionsDoc.Fields[2].Name = "router_id"
+	RoutingOptionsDoc.Fields[2].Type = "string"
 	RoutingOptionsDoc.Fields[2].Note = ""
-	RoutingOptionsDoc.Fields[2].Description = "32-bit autonomous system number"
-	RoutingOptionsDoc.Fields[2].Comments[encoder.LineComment] = "32-bit autonomous system number"
+	RoutingOptionsDoc.Fields[2].Description = "32-bit number to serve as router id. Must have the format x.x.x.x"
+	RoutingOptionsDoc.Fields[2].Comments[encoder.LineComment] = "32-bit number to serve as router id. Must have the format x.x.x.x"
+	RoutingOptionsDoc.Fields[3].Name = "autonomous_system"
+	RoutingOptionsDoc.Fields[3].Type = "uint32"
+	RoutingOptionsDoc.Fields[3].Note = ""
+	RoutingOptionsDoc.Fields[3].Description = "32-bit autonomous system number"
+	RoutingOptionsDoc.Fields[3].Comments[encoder.LineComment] = "32-bit autonomous system number"
+	RoutingOptionsDoc.Fields[4].Name = "kernel"
+	RoutingOptionsDoc.Fields[4].Type = "Kernel"
+	RoutingOptionsDoc.Fields[4].Note = ""
+	RoutingOptionsDoc.Fields[4].Description = "Installation of routes into the Linux routing table. Routes are not installed if omitted\n<a href=\"kernel.md\">parameter documentation</a>\nExample:\n  kernel:\n    table: 100\n    multipath: true\n    export:\n      - \"Kernel-Out\""
+	RoutingOptionsDoc.Fields[4].Comments[encoder.LineComment] = "Installation of routes into the Linux routing table. Routes are not installed if omitted"
+	RoutingOptionsDoc.Fields[5].Name = "kernel_import"
+	RoutingOptionsDoc.Fields[5].Type = "KernelImport"
+	RoutingOptionsDoc.Fields[5].Note = ""
+	RoutingOptionsDoc.Fields[5].Description = "Import of routes from the Linux routing table into the RIB. Routes are not imported if omitted\n<a href=\"kernel.md\">parameter documentation</a>\nExample:\n  kernel_import:\n    table: 254\n    protocols:\n      - \"static\"\n      - \"dhcp\""
+	RoutingOptionsDoc.Fields[5].Comments[encoder.LineComment] = "Import of routes from the Linux routing table into the RIB. Routes are not imported if omitted"
+	RoutingOptionsDoc.Fields[6].Name = "connected"
+	RoutingOptionsDoc.Fields[6].Type = "Connected"
+	RoutingOptionsDoc.Fields[6].Note = ""
+	RoutingOptionsDoc.Fields[6].Description = "Routes to the networks of the addresses configured on interfaces\nExample:\n  connected:\n    interfaces:\n      - \"lo\"\n      - \"eth0\""
+	RoutingOptionsDoc.Fields[6].Comments[encoder.LineComment] = "Routes to the networks of the addresses configured on interfaces"
+	RoutingOptionsDoc.Fields[7].Name = "rpki"
+	RoutingOptionsDoc.Fields[7].Type = "RPKI"
+	RoutingOptionsDoc.Fields[7].Note = ""
+	RoutingOptionsDoc.Fields[7].Description = "RPKI caches and files to validate the origin and verify the AS path of BGP routes against.\nNo VRPs and ASPAs are learned if omitted\n<a href=\"rpki.md\">parameter documentation</a>\nExample:\n  rpki:\n    caches:\n      - address: 192.0.2.1\n        port: 3323\n    files:\n      - path: /var/db/rpki-client/json"
+	RoutingOptionsDoc.Fields[7].Comments[encoder.LineComment] = "RPKI caches and files to validate the origin and verify the AS path of BGP routes against."
+	RoutingOptionsDoc.Fields[8].Name = "next_hop_tracking"
+	RoutingOptionsDoc.Fields[8].Type = "bool"
+	RoutingOptionsDoc.Fields[8].Note = ""
+	RoutingOptionsDoc.Fields[8].Description = "Resolve the next hops of routes received via iBGP via the IGP, static and connected routes.\nRoutes with unresolvable next hops are ineligible. The IGP metric to the next hop is considered in the best path selection"
+	RoutingOptionsDoc.Fields[8].Comments[encoder.LineComment] = "Resolve the next hops of routes received via iBGP via the IGP, static and connected routes."
+
+	ConnectedDoc.Type = "Connected"
+	ConnectedDoc.Comments[encoder.LineComment] = ""
+	ConnectedDoc.Description = ""
+	ConnectedDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "RoutingOptions",
+			FieldName: "connected",
+		},
+	}
+	ConnectedDoc.Fields = make([]encoder.Doc, 1)
+	ConnectedDoc.Fields[0].Name = "interfaces"
+	ConnectedDoc.Fields[0].Type = "[]string"
+	ConnectedDoc.Fields[0].Note = ""
+	ConnectedDoc.Fields[0].Description = "List of interfaces whose networks are added to the RIB"
+	ConnectedDoc.Fields[0].Comments[encoder.LineComment] = "List of interfaces whose networks are added to the RIB"
 }
 
 func (_ RoutingOptions) Doc() *encoder.Doc {
 	return &RoutingOptionsDoc
 }
 
+func (_ Connected) Doc() *encoder.Doc {
+	return &ConnectedDoc
+}
+
 // Getrouting_optionsDoc returns documentation for the file cmd/bio-rd/config/routing_options_docs.go.
 func Getrouting_optionsDoc() *encoder.FileDoc {
 	return &encoder.FileDoc{
@@ -43,6 +96,7 @@ func Getrouting_optionsDoc() *encoder.FileDoc {
 		Description: "",
 		Structs: []*encoder.Doc{
 			&RoutingOptionsDoc,
+			&ConnectedDoc,
 		},
 	}
 }
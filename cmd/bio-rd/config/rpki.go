@@ -0,0 +1,109 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/rpki"
+)
+
+const defaultRTRPort = 323
+
+type RPKI struct {
+	// description: |
+	//   List of RPKI caches (validators) to learn validated ROA payloads from via RTR (RFC8210)
+	//   VRPs of all caches are combined
+	Caches []*RPKICache `yaml:"caches"`
+	// description: |
+	//   List of JSON files in the format written by rpki-client to load VRPs and ASPAs from
+	//   Files are reloaded when they are modified
+	Files []*RPKIFile `yaml:"files"`
+}
+
+type RPKICache struct {
+	// description: |
+	//   IP address of the cache
+	Address string `yaml:"address"`
+	// description: |
+	//   TCP port of the cache. Defaults to 323
+	Port uint16 `yaml:"port"`
+	// description: |
+	//   Interval in seconds to poll the cache for new data until the cache announces its own. Defaults to 3600
+	RefreshInterval uint32 `yaml:"refresh_interval"`
+	// description: |
+	//   Interval in seconds to retry after a failure until the cache announces its own. Defaults to 600
+	RetryInterval uint32 `yaml:"retry_interval"`
+	// description: |
+	//   Interval in seconds after which data of an unreachable cache is discarded until the cache announces its own. Defaults to 7200
+	ExpireInterval uint32 `yaml:"expire_interval"`
+}
+
+type RPKIFile struct {
+	// description: |
+	//   Path of the file
+	Path string `yaml:"path"`
+	// description: |
+	//   Interval in seconds to check the file for modifications. Defaults to 60
+	RefreshInterval uint32 `yaml:"refresh_interval"`
+}
+
+func (r *RPKI) load() error {
+	for _, c := range r.Caches {
+		err := c.load()
+		if err != nil {
+			return fmt.Errorf("error in cache %q: %w", c.Address, err)
+		}
+	}
+
+	for _, f := range r.Files {
+		if f.Path == "" {
+			return fmt.Errorf("file without path")
+		}
+	}
+
+	return nil
+}
+
+func (c *RPKICache) load() error {
+	_, err := bnet.IPFromString(c.Address)
+	if err != nil {
+		return fmt.Errorf("unable to parse address: %w", err)
+	}
+
+	if c.Port == 0 {
+		c.Port = defaultRTRPort
+	}
+
+	return nil
+}
+
+// CacheConfigs returns the configuration of all caches
+func (r *RPKI) CacheConfigs() []rpki.CacheConfig {
+	res := make([]rpki.CacheConfig, 0, len(r.Caches))
+	for _, c := range r.Caches {
+		res = append(res, rpki.CacheConfig{
+			Address:         net.JoinHostPort(c.Address, strconv.Itoa(int(c.Port))),
+			RefreshInterval: time.Duration(c.RefreshInterval) * time.Second,
+			RetryInterval:   time.Duration(c.RetryInterval) * time.Second,
+			ExpireInterval:  time.Duration(c.ExpireInterval) * time.Second,
+		})
+	}
+
+	return res
+}
+
+// FileConfigs returns the configuration of all files
+func (r *RPKI) FileConfigs() []rpki.FileConfig {
+	res := make([]rpki.FileConfig, 0, len(r.Files))
+	for _, f := range r.Files {
+		res = append(res, rpki.FileConfig{
+			Path:            f.Path,
+			RefreshInterval: time.Duration(f.RefreshInterval) * time.Second,
+		})
+	}
+
+	return res
+}
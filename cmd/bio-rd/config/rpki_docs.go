@@ -0,0 +1,114 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+// DO NOT EDIT: this file is automatically generated by docgen
+package config
+
+import (
+	"github.com/projectdiscovery/yamldoc-go/encoder"
+)
+
+var (
+	RPKIDoc      encoder.Doc
+	RPKICacheDoc encoder.Doc
+	RPKIFileDoc  encoder.Doc
+)
+
+func init() {
+	RPKIDoc.Type = "RPKI"
+	RPKIDoc.Comments[encoder.LineComment] = ""
+	RPKIDoc.Description = ""
+	RPKIDoc.Fields = make([]encoder.Doc, 2)
+	RPKIDoc.Fields[0].Name = "caches"
+	RPKIDoc.Fields[0].Type = "[]RPKICache"
+	RPKIDoc.Fields[0].Note = ""
+	RPKIDoc.Fields[0].Description = "List of RPKI caches (validators) to learn validated ROA payloads from via RTR (RFC8210)\nVRPs of all caches are combined"
+	RPKIDoc.Fields[0].Comments[encoder.LineComment] = "List of RPKI caches (validators) to learn validated ROA payloads from via RTR (RFC8210)"
+	RPKIDoc.Fields[1].Name = "files"
+	RPKIDoc.Fields[1].Type = "[]RPKIFile"
+	RPKIDoc.Fields[1].Note = ""
+	RPKIDoc.Fields[1].Description = "List of JSON files in the format written by rpki-client to load VRPs and ASPAs from\nFiles are reloaded when they are modified"
+	RPKIDoc.Fields[1].Comments[encoder.LineComment] = "List of JSON files in the format written by rpki-client to load VRPs and ASPAs from"
+
+	RPKICacheDoc.Type = "RPKICache"
+	RPKICacheDoc.Comments[encoder.LineComment] = ""
+	RPKICacheDoc.Description = ""
+	RPKICacheDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "RPKI",
+			FieldName: "caches",
+		},
+	}
+	RPKICacheDoc.Fields = make([]encoder.Doc, 5)
+	RPKICacheDoc.Fields[0].Name = "address"
+	RPKICacheDoc.Fields[0].Type = "string"
+	RPKICacheDoc.Fields[0].Note = ""
+	RPKICacheDoc.Fields[0].Description = "IP address of the cache"
+	RPKICacheDoc.Fields[0].Comments[encoder.LineComment] = "IP address of the cache"
+	RPKICacheDoc.Fields[1].Name = "port"
+	RPKICacheDoc.Fields[1].Type = "uint16"
+	RPKICacheDoc.Fields[1].Note = ""
+	RPKICacheDoc.Fields[1].Description = "TCP port of the cache. Defaults to 323"
+	RPKICacheDoc.Fields[1].Comments[encoder.LineComment] = "TCP port of the cache. Defaults to 323"
+	RPKICacheDoc.Fields[2].Name = "refresh_interval"
+	RPKICacheDoc.Fields[2].Type = "uint32"
+	RPKICacheDoc.Fields[2].Note = ""
+	RPKICacheDoc.Fields[2].Description = "Interval in seconds to poll the cache for new data until the cache announces its own. Defaults to 3600"
+	RPKICacheDoc.Fields[2].Comments[encoder.LineComment] = "Interval in seconds to poll the cache for new data until the cache announces its own. Defaults to 3600"
+	RPKICacheDoc.Fields[3].Name = "retry_interval"
+	RPKICacheDoc.Fields[3].Type = "uint32"
+	RPKICacheDoc.Fields[3].Note = ""
+	RPKICacheDoc.Fields[3].Description = "Interval in seconds to retry after a failure until the cache announces its own. Defaults to 600"
+	RPKICacheDoc.Fields[3].Comments[encoder.LineComment] = "Interval in seconds to retry after a failure until the cache announces its own. Defaults to 600"
+	RPKICacheDoc.Fields[4].Name = "expire_interval"
+	RPKICacheDoc.Fields[4].Type = "uint32"
+	RPKICacheDoc.Fields[4].Note = ""
+	RPKICacheDoc.Fields[4].Description = "Interval in seconds after which data of an unreachable cache is discarded until the cache announces its own. Defaults to 7200"
+	RPKICacheDoc.Fields[4].Comments[encoder.LineComment] = "Interval in seconds after which data of an unreachable cache is discarded until the cache announces its own. Defaults to 7200"
+
+	RPKIFileDoc.Type = "RPKIFile"
+	RPKIFileDoc.Comments[encoder.LineComment] = ""
+	RPKIFileDoc.Description = ""
+	RPKIFileDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "RPKI",
+			FieldName: "files",
+		},
+	}
+	RPKIFileDoc.Fields = make([]encoder.Doc, 2)
+	RPKIFileDoc.Fields[0].Name = "path"
+	RPKIFileDoc.Fields[0].Type = "string"
+	RPKIFileDoc.Fields[0].Note = ""
+	RPKIFileDoc.Fields[0].Description = "Path of the file"
+	RPKIFileDoc.Fields[0].Comments[encoder.LineComment] = "Path of the file"
+	RPKIFileDoc.Fields[1].Name = "refresh_interval"
+	RPKIFileDoc.Fields[1].Type = "uint32"
+	RPKIFileDoc.Fields[1].Note = ""
+	RPKIFileDoc.Fields[1].Description = "Interval in seconds to check the file for modifications. Defaults to 60"
+	RPKIFileDoc.Fields[1].Comments[encoder.LineComment] = "Interval in seconds to check the file for modifications. Defaults to 60"
+}
+
+func (_ RPKI) Doc() *encoder.Doc {
+	return &RPKIDoc
+}
+
+func (_ RPKICache) Doc() *encoder.Doc {
+	return &RPKICacheDoc
+}
+
+func (_ RPKIFile) Doc() *encoder.Doc {
+	return &RPKIFileDoc
+}
+
+// GetrpkiDoc returns documentation for the file cmd/bio-rd/config/rpki_docs.go.
+func GetrpkiDoc() *encoder.FileDoc {
+	return &encoder.FileDoc{
+		Name:        "rpki",
+		Description: "",
+		Structs: []*encoder.Doc{
+			&RPKIDoc,
+			&RPKICacheDoc,
+			&RPKIFileDoc,
+		},
+	}
+}
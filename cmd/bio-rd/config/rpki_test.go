@@ -0,0 +1,111 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bio-routing/bio-rd/protocols/rpki"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRPKILoad(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         *RPKI
+		wantFail      bool
+		expected      []rpki.CacheConfig
+		expectedFiles []rpki.FileConfig
+	}{
+		{
+			name: "defaults",
+			input: &RPKI{
+				Caches: []*RPKICache{
+					{
+						Address: "192.0.2.1",
+					},
+				},
+			},
+			expected: []rpki.CacheConfig{
+				{
+					Address: "192.0.2.1:323",
+				},
+			},
+			expectedFiles: []rpki.FileConfig{},
+		},
+		{
+			name: "IPv6 with port and intervals",
+			input: &RPKI{
+				Caches: []*RPKICache{
+					{
+						Address:         "2001:db8::1",
+						Port:            3323,
+						RefreshInterval: 300,
+						RetryInterval:   60,
+						ExpireInterval:  3600,
+					},
+				},
+			},
+			expected: []rpki.CacheConfig{
+				{
+					Address:         "[2001:db8::1]:3323",
+					RefreshInterval: 5 * time.Minute,
+					RetryInterval:   time.Minute,
+					ExpireInterval:  time.Hour,
+				},
+			},
+			expectedFiles: []rpki.FileConfig{},
+		},
+		{
+			name: "file",
+			input: &RPKI{
+				Files: []*RPKIFile{
+					{
+						Path:            "/var/db/rpki-client/json",
+						RefreshInterval: 300,
+					},
+				},
+			},
+			expected: []rpki.CacheConfig{},
+			expectedFiles: []rpki.FileConfig{
+				{
+					Path:            "/var/db/rpki-client/json",
+					RefreshInterval: 5 * time.Minute,
+				},
+			},
+		},
+		{
+			name: "file without path",
+			input: &RPKI{
+				Files: []*RPKIFile{
+					{},
+				},
+			},
+			wantFail: true,
+		},
+		{
+			name: "invalid address",
+			input: &RPKI{
+				Caches: []*RPKICache{
+					{
+						Address: "cache.example.com",
+					},
+				},
+			},
+			wantFail: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.input.load()
+			if test.wantFail {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, test.input.CacheConfigs())
+			assert.Equal(t, test.expectedFiles, test.input.FileConfigs())
+		})
+	}
+}
@@ -1,16 +1,63 @@
 package config
 
+import (
+	"fmt"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+)
+
 type StaticRoute struct {
 	// description: |
 	//   Prefix for the route
 	Prefix string `yaml:"prefix"`
+	// docgen:nodoc
+	Pfx *bnet.Prefix
 	// description: |
 	//   Makes this route a blackhole
 	Discard bool `yaml:"discard"`
 	// description: |
 	//   Next hop for the route
 	NextHop string `yaml:"next_hop"`
+	// docgen:nodoc
+	NextHopIP *bnet.IP
 	// description: |
-	//   ??
+	//   Resolve the next hop recursively via the RIB. The route is withdrawn while its next hop is unresolvable
 	Resolve bool `yaml:"resolve"`
 }
+
+func (s *StaticRoute) load() error {
+	pfx, err := bnet.PrefixFromString(s.Prefix)
+	if err != nil {
+		return fmt.Errorf("unable to parse prefix: %w", err)
+	}
+
+	if !pfx.Valid() {
+		return fmt.Errorf("prefix %s has host bits set", s.Prefix)
+	}
+	s.Pfx = pfx
+
+	if s.Discard {
+		if s.NextHop != "" || s.Resolve {
+			return fmt.Errorf("discard route %s must not have a next hop", s.Prefix)
+		}
+
+		return nil
+	}
+
+	if s.NextHop == "" {
+		return fmt.Errorf("route %s has neither a next hop nor is a discard route", s.Prefix)
+	}
+
+	nh, err := bnet.IPFromString(s.NextHop)
+	if err != nil {
+		return fmt.Errorf("unable to parse next hop of route %s: %w", s.Prefix, err)
+	}
+
+	addr := s.Pfx.Addr()
+	if nh.IsIPv4() != addr.IsIPv4() {
+		return fmt.Errorf("address family of next hop %s does not match route %s", s.NextHop, s.Prefix)
+	}
+	s.NextHopIP = nh.Ptr()
+
+	return nil
+}
@@ -33,8 +33,8 @@ func init() {
 	StaticRouteDoc.Fields[3].Name = "resolve"
 	StaticRouteDoc.Fields[3].Type = "bool"
 	StaticRouteDoc.Fields[3].Note = ""
-	StaticRouteDoc.Fields[3].Description = "??"
-	StaticRouteDoc.Fields[3].Comments[encoder.LineComment] = "??"
+	StaticRouteDoc.Fields[3].Description = "Resolve the next hop recursively via the RIB. The route is withdrawn while its next hop is unresolvable"
+	StaticRouteDoc.Fields[3].Comments[encoder.LineComment] = "Resolve the next hop recursively via the RIB. The route is withdrawn while its next hop is unresolvable"
 }
 
 func (_ StaticRoute) Doc() *encoder.Doc {
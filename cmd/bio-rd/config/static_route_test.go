@@ -0,0 +1,90 @@
+package config
+
+import (
+	"testing"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStaticRouteLoad(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *StaticRoute
+		wantFail bool
+		expected *StaticRoute
+	}{
+		{
+			name: "next hop",
+			input: &StaticRoute{
+				Prefix:  "198.51.100.0/24",
+				NextHop: "192.0.2.1",
+				Resolve: true,
+			},
+			expected: &StaticRoute{
+				Prefix:    "198.51.100.0/24",
+				Pfx:       bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 100, 0), 24).Ptr(),
+				NextHop:   "192.0.2.1",
+				NextHopIP: bnet.IPv4FromOctets(192, 0, 2, 1).Ptr(),
+				Resolve:   true,
+			},
+		},
+		{
+			name: "discard",
+			input: &StaticRoute{
+				Prefix:  "2001:db8::/32",
+				Discard: true,
+			},
+			expected: &StaticRoute{
+				Prefix:  "2001:db8::/32",
+				Pfx:     bnet.NewPfx(bnet.IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 0), 32).Ptr(),
+				Discard: true,
+			},
+		},
+		{
+			name: "host bits set",
+			input: &StaticRoute{
+				Prefix:  "198.51.100.1/24",
+				Discard: true,
+			},
+			wantFail: true,
+		},
+		{
+			name: "discard with next hop",
+			input: &StaticRoute{
+				Prefix:  "198.51.100.0/24",
+				NextHop: "192.0.2.1",
+				Discard: true,
+			},
+			wantFail: true,
+		},
+		{
+			name: "no next hop",
+			input: &StaticRoute{
+				Prefix: "198.51.100.0/24",
+			},
+			wantFail: true,
+		},
+		{
+			name: "address family mismatch",
+			input: &StaticRoute{
+				Prefix:  "198.51.100.0/24",
+				NextHop: "2001:db8::1",
+			},
+			wantFail: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.input.load()
+			if test.wantFail {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, test.input)
+		})
+	}
+}
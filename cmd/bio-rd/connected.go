@@ -0,0 +1,82 @@
+package main
+
+import (
+	"sync"
+
+	"github.com/bio-routing/bio-rd/cmd/bio-rd/config"
+	"github.com/bio-routing/bio-rd/protocols/connected"
+	"github.com/bio-routing/bio-rd/protocols/device"
+	"github.com/bio-routing/bio-rd/routingtable/vrf"
+)
+
+// connectedConfigurator manages the routes to the networks connected to interfaces of the VRFs
+type connectedConfigurator struct {
+	ds      device.Updater
+	mu      sync.Mutex
+	sources map[string]*connectedSource
+}
+
+type connectedSource struct {
+	c   *connected.Connected
+	vrf *vrf.VRF
+}
+
+func newConnectedConfigurator(ds device.Updater) *connectedConfigurator {
+	return &connectedConfigurator{
+		ds:      ds,
+		sources: make(map[string]*connectedSource),
+	}
+}
+
+func (cc *connectedConfigurator) configure(v *vrf.VRF, cfg *config.Connected) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	s := cc.sources[v.Name()]
+	if s != nil && (s.vrf != v || cfg == nil) {
+		cc.remove(s)
+		s = nil
+	}
+
+	if cfg == nil {
+		return
+	}
+
+	if s == nil {
+		s = &connectedSource{
+			c:   connected.New(cc.ds, v.IPv4UnicastRIB(), v.IPv6UnicastRIB()),
+			vrf: v,
+		}
+		cc.sources[v.Name()] = s
+	}
+
+	interfaces := make(map[string]struct{}, len(cfg.Interfaces))
+	for _, name := range cfg.Interfaces {
+		interfaces[name] = struct{}{}
+		s.c.AddInterface(name)
+	}
+
+	for _, name := range s.c.Interfaces() {
+		if _, found := interfaces[name]; !found {
+			s.c.RemoveInterface(name)
+		}
+	}
+}
+
+// remove removes the connected routes of a VRF from its RIBs
+func (cc *connectedConfigurator) remove(s *connectedSource) {
+	s.c.Dispose()
+	delete(cc.sources, s.vrf.Name())
+}
+
+// removeUnconfigured removes the connected routes of all VRFs but the given ones
+func (cc *connectedConfigurator) removeUnconfigured(vrfNames map[string]struct{}) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	for name, s := range cc.sources {
+		if _, found := vrfNames[name]; !found {
+			cc.remove(s)
+		}
+	}
+}
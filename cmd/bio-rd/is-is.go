@@ -4,14 +4,18 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/bio-routing/bio-rd/cmd/bio-rd/config"
+	"github.com/bio-routing/bio-rd/protocols/bgp/metrics"
 	"github.com/bio-routing/bio-rd/protocols/isis/server"
 	"github.com/bio-routing/bio-rd/protocols/isis/types"
+	"github.com/bio-routing/bio-rd/routingtable/filter"
+	"github.com/bio-routing/bio-rd/routingtable/vrf"
 	"github.com/bio-routing/bio-rd/util/log"
 )
 
-func configureProtocolsISIS(isis *config.ISIS) error {
+func configureProtocolsISIS(isis *config.ISIS, routerID uint32) error {
 	if len(isis.NETs) == 0 {
 		return fmt.Errorf("no Network Entity Titles (NETs, ISO addresses) given")
 	}
@@ -22,15 +26,63 @@ func configureProtocolsISIS(isis *config.ISIS) error {
 	}
 
 	if isisSrv == nil {
-		var err error
-		isisSrv, err = server.New(nets, ds, isis.LSPLifetime)
+		srv, err := server.New(nets, ds, vrfReg.GetVRFByName(vrf.DefaultVRFName), isis.LSPLifetime)
 		if err != nil {
 			return fmt.Errorf("unable to create ISIS server: %w", err)
 		}
 
+		srv.SetBFD(bfdSrv)
+		if o := isis.Overload; o != nil && o.OnStartup > 0 {
+			var converged func() bool
+			if o.WaitForBGP {
+				converged = bgpConverged
+			}
+
+			srv.SetOverloadOnStartup(time.Duration(o.OnStartup)*time.Second, converged)
+		}
+
+		isisSrv = srv
 		isisSrv.Start()
 	}
 
+	isisSrv.SetOverload(isis.Overload != nil && isis.Overload.Set)
+	isisSrv.SetSPFIntervals(
+		time.Duration(isis.SPF.InitialWait)*time.Millisecond,
+		time.Duration(isis.SPF.SecondaryWait)*time.Millisecond,
+		time.Duration(isis.SPF.MaxWait)*time.Millisecond)
+	isisSrv.SetMultiTopology(isis.MultiTopology)
+
+	var leakFilterChain filter.Chain
+	if isis.Level1 != nil {
+		leakFilterChain = isis.Level1.RouteLeakingFilterChain
+	}
+
+	isisSrv.SetLeakFilterChain(leakFilterChain)
+
+	var srCfg *server.SegmentRoutingConfig
+	if sr := isis.SegmentRouting; sr != nil {
+		srCfg = &server.SegmentRoutingConfig{
+			RouterID: routerID,
+			SRGB:     server.LabelBlock{Base: sr.SRGBBase, Size: sr.SRGBSize},
+			SRLB:     server.LabelBlock{Base: sr.SRLBBase, Size: sr.SRLBSize},
+		}
+	}
+
+	isisSrv.SetSegmentRouting(srCfg)
+
+	for level, l := range map[uint8]*config.ISISLevel{1: isis.Level1, 2: isis.Level2} {
+		if l == nil {
+			continue
+		}
+
+		isisSrv.SetAuthentication(level, &server.AuthenticationConfig{
+			KeyChain:              l.KeyChain,
+			NoHelloAuthentication: l.NoHelloAuthentication,
+			NoCSNPAuthentication:  l.NoCSNPAuthentication,
+			NoPSNPAuthentication:  l.NoPSNPAuthentication,
+		})
+	}
+
 	configuredInterfaces := isisSrv.GetInterfaceNames()
 	for _, ifa := range isis.Interfaces {
 		if strSliceContains(configuredInterfaces, ifa.Name) {
@@ -38,14 +90,27 @@ func configureProtocolsISIS(isis *config.ISIS) error {
 			continue
 		}
 
-		log.Infof("ISIS: Adding interface %s to ISIS server", ifa.Name)
-		err := isisSrv.AddInterface(&server.InterfaceConfig{
+		ifaCfg := &server.InterfaceConfig{
 			Name:         ifa.Name,
 			Passive:      ifa.Passive,
 			PointToPoint: ifa.PointToPoint,
 			Level1:       translateInterfaceLevelConfig(ifa.Level1),
 			Level2:       translateInterfaceLevelConfig(ifa.Level2),
-		})
+
+			PrefixSIDIndex: ifa.PrefixSIDIndex,
+			BFD:            translateInterfaceBFDConfig(ifa.BFD),
+		}
+
+		if isis.Level1 != nil && isis.Level1.Disable {
+			ifaCfg.Level1 = nil
+		}
+
+		if isis.Level2 != nil && isis.Level2.Disable {
+			ifaCfg.Level2 = nil
+		}
+
+		log.Infof("ISIS: Adding interface %s to ISIS server", ifa.Name)
+		err := isisSrv.AddInterface(ifaCfg)
 		if err != nil {
 			return fmt.Errorf("unable to add interface: %s: %w", ifa.Name, err)
 		}
@@ -63,8 +128,42 @@ func configureProtocolsISIS(isis *config.ISIS) error {
 	return nil
 }
 
+// bgpConverged checks if all BGP sessions are established and received the End-of-RIB marker for all address families
+func bgpConverged() bool {
+	m, err := bgpSrv.Metrics()
+	if err != nil {
+		return false
+	}
+
+	for _, p := range m.Peers {
+		if p.State != metrics.StateEstablished {
+			return false
+		}
+
+		for _, f := range p.AddressFamilies {
+			if !f.EndOfRIBMarkerReceived {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func translateInterfaceBFDConfig(cfg *config.BFDConfig) *server.BFDConfig {
+	if cfg == nil {
+		return nil
+	}
+
+	return &server.BFDConfig{
+		DesiredMinTxInterval:  cfg.MinIntervalDuration,
+		RequiredMinRxInterval: cfg.MinIntervalDuration,
+		DetectMultiplier:      cfg.Multiplier,
+	}
+}
+
 func translateInterfaceLevelConfig(c *config.ISISInterfaceLevel) *server.InterfaceLevelConfig {
-	if c == nil {
+	if c == nil || c.Disable {
 		return nil
 	}
 
@@ -73,7 +172,9 @@ func translateInterfaceLevelConfig(c *config.ISISInterfaceLevel) *server.Interfa
 		HoldingTimer:  c.HoldTime,
 		Metric:        c.Metric,
 		Passive:       c.Passive,
-		Priority:      c.Priority,
+		Priority:      *c.Priority,
+
+		HelloAuthentication: c.HelloKeyChain,
 	}
 }
 
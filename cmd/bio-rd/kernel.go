@@ -0,0 +1,179 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/bio-routing/bio-rd/cmd/bio-rd/config"
+	"github.com/bio-routing/bio-rd/protocols/kernel"
+	"github.com/bio-routing/bio-rd/routingtable"
+	"github.com/bio-routing/bio-rd/routingtable/vrf"
+)
+
+// kernelConfigurator manages the kernel clients installing the routes of the VRFs into Linux routing tables
+// and the importers learning routes from Linux routing tables
+type kernelConfigurator struct {
+	mu        sync.Mutex
+	clients   map[string]*kernelClient
+	importers map[string]*kernelImporter
+}
+
+type kernelClient struct {
+	k         *kernel.Kernel
+	vrf       *vrf.VRF
+	multipath bool
+}
+
+type kernelImporter struct {
+	i         *kernel.Importer
+	vrf       *vrf.VRF
+	protocols []int
+}
+
+func newKernelConfigurator() *kernelConfigurator {
+	return &kernelConfigurator{
+		clients:   make(map[string]*kernelClient),
+		importers: make(map[string]*kernelImporter),
+	}
+}
+
+func (kc *kernelConfigurator) configure(v *vrf.VRF, cfg *config.Kernel) error {
+	kc.mu.Lock()
+	defer kc.mu.Unlock()
+
+	c := kc.clients[v.Name()]
+	if c != nil && c.vrf == v && cfg != nil && c.k.RoutingTable() == cfg.Table && c.multipath == cfg.Multipath {
+		c.k.ReplaceFilterChain(cfg.ExportFilterChain)
+		return nil
+	}
+
+	if c != nil {
+		kc.remove(c)
+	}
+
+	if cfg == nil {
+		return nil
+	}
+
+	k, err := kernel.NewWithConfig(kernel.Config{
+		RoutingTable:      cfg.Table,
+		ExportFilterChain: cfg.ExportFilterChain,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to create kernel client: %w", err)
+	}
+
+	opts := routingtable.ClientOptions{
+		BestOnly: !cfg.Multipath,
+		EcmpOnly: cfg.Multipath,
+	}
+	v.IPv4UnicastRIB().RegisterWithOptions(k, opts)
+	v.IPv6UnicastRIB().RegisterWithOptions(k, opts)
+
+	kc.clients[v.Name()] = &kernelClient{
+		k:         k,
+		vrf:       v,
+		multipath: cfg.Multipath,
+	}
+
+	return nil
+}
+
+func (kc *kernelConfigurator) configureImport(v *vrf.VRF, cfg *config.KernelImport) error {
+	kc.mu.Lock()
+	defer kc.mu.Unlock()
+
+	imp := kc.importers[v.Name()]
+	if imp != nil && imp.vrf == v && cfg != nil && imp.i.RoutingTable() == cfg.Table && intSlicesEqual(imp.protocols, cfg.ProtocolIDs) {
+		return nil
+	}
+
+	if imp != nil {
+		kc.removeImporter(imp)
+	}
+
+	if cfg == nil {
+		return nil
+	}
+
+	i, err := kernel.NewImporter(kernel.ImportConfig{
+		RoutingTable: cfg.Table,
+		Protocols:    cfg.ProtocolIDs,
+	}, v.IPv4UnicastRIB(), v.IPv6UnicastRIB())
+	if err != nil {
+		return fmt.Errorf("unable to create kernel importer: %w", err)
+	}
+
+	err = i.Start()
+	if err != nil {
+		return fmt.Errorf("unable to start kernel importer: %w", err)
+	}
+
+	kc.importers[v.Name()] = &kernelImporter{
+		i:         i,
+		vrf:       v,
+		protocols: cfg.ProtocolIDs,
+	}
+
+	return nil
+}
+
+func intSlicesEqual(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// removeImporter stops an importer and removes the routes it learned from the RIBs
+func (kc *kernelConfigurator) removeImporter(imp *kernelImporter) {
+	imp.i.Stop()
+	delete(kc.importers, imp.vrf.Name())
+}
+
+// remove unregisters a client and removes its routes from the kernel
+func (kc *kernelConfigurator) remove(c *kernelClient) {
+	c.vrf.IPv4UnicastRIB().Unregister(c.k)
+	c.vrf.IPv6UnicastRIB().Unregister(c.k)
+	c.k.Dispose()
+	delete(kc.clients, c.vrf.Name())
+}
+
+// removeUnconfigured removes the clients and importers of all VRFs but the given ones
+func (kc *kernelConfigurator) removeUnconfigured(vrfNames map[string]struct{}) {
+	kc.mu.Lock()
+	defer kc.mu.Unlock()
+
+	for name, c := range kc.clients {
+		if _, found := vrfNames[name]; !found {
+			kc.remove(c)
+		}
+	}
+
+	for name, imp := range kc.importers {
+		if _, found := vrfNames[name]; !found {
+			kc.removeImporter(imp)
+		}
+	}
+}
+
+// removeAll removes all routes installed by bio-rd from the kernel
+func (kc *kernelConfigurator) removeAll() {
+	kc.mu.Lock()
+	defer kc.mu.Unlock()
+
+	for _, c := range kc.clients {
+		kc.remove(c)
+	}
+
+	for _, imp := range kc.importers {
+		kc.removeImporter(imp)
+	}
+}
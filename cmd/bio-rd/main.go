@@ -9,11 +9,14 @@ import (
 	"time"
 
 	"github.com/bio-routing/bio-rd/cmd/bio-rd/config"
+	bfdapi "github.com/bio-routing/bio-rd/protocols/bfd/api"
+	bfdserver "github.com/bio-routing/bio-rd/protocols/bfd/server"
 	bgpapi "github.com/bio-routing/bio-rd/protocols/bgp/api"
 	bgpserver "github.com/bio-routing/bio-rd/protocols/bgp/server"
 	"github.com/bio-routing/bio-rd/protocols/device"
 	isisapi "github.com/bio-routing/bio-rd/protocols/isis/api"
 	isisserver "github.com/bio-routing/bio-rd/protocols/isis/server"
+	"github.com/bio-routing/bio-rd/protocols/rpki"
 	"github.com/bio-routing/bio-rd/routingtable/vrf"
 	"github.com/bio-routing/bio-rd/util/log"
 	"github.com/bio-routing/bio-rd/util/servicewrapper"
@@ -34,8 +37,17 @@ var (
 	metricsPort          = flag.Uint("metrics_port", 55667, "Metrics HTTP server port")
 	bgpListenAddrIPv4    = flag.String("bgp.listen-addr-ipv4", DefaultBGPListenAddrIPv4, "BGP listen address for IPv4 AFI")
 	bgpListenAddrIPv6    = flag.String("bgp.listen-addr-ipv6", DefaultBGPListenAddrIPv6, "BGP listen address for IPv6 AFI")
-	sigHUP               = make(chan os.Signal)
+	bgpRestarted         = flag.Bool("bgp.restarted", false, "Signal BGP peers with graceful restart enabled that we restarted with our forwarding state preserved")
+	sigHUP               = make(chan os.Signal, 1)
+	sigTerm              = make(chan os.Signal, 1)
 	vrfReg               = vrf.NewVRFRegistry()
+	kernelCfgtr          = newKernelConfigurator()
+	connectedCfgtr       *connectedConfigurator
+	staticCfgtr          = newStaticConfigurator()
+	aggregateCfgtr       = newAggregateConfigurator()
+	nextHopCfgtr         = newNextHopConfigurator()
+	rpkiValidator        = rpki.New()
+	bfdSrv               *bfdserver.Server
 	bgpSrv               bgpserver.BGPServer
 	isisSrv              isisserver.ISISServer
 	ds                   device.Updater
@@ -66,6 +78,14 @@ func main() {
 		log.Errorf("Unable to start device server: %v", err)
 		os.Exit(1)
 	}
+	connectedCfgtr = newConnectedConfigurator(ds)
+
+	bfdSrv = bfdserver.New(bfdserver.ServerConfig{})
+	err = bfdSrv.Start()
+	if err != nil {
+		log.Errorf("Unable to start BFD server: %v", err)
+		os.Exit(1)
+	}
 
 	listenAddrsByVRF := map[string][]string{
 		vrf.DefaultVRFName: {
@@ -74,10 +94,20 @@ func main() {
 		},
 	}
 
+	defaultVRF := vrfReg.CreateVRFIfNotExists(vrf.DefaultVRFName, 0)
+	err = createVPNRIBs(defaultVRF)
+	if err != nil {
+		log.Errorf("Unable to create VPN RIBs: %v", err)
+		os.Exit(1)
+	}
+
 	bgpSrvCfg := bgpserver.BGPServerConfig{
 		RouterID:         startCfg.RoutingOptions.RouterIDUint32,
-		DefaultVRF:       vrfReg.CreateVRFIfNotExists(vrf.DefaultVRFName, 0),
+		DefaultVRF:       defaultVRF,
 		ListenAddrsByVRF: listenAddrsByVRF,
+		RPKIValidator:    rpkiValidator,
+		BFD:              bfdSrv,
+		Restarted:        *bgpRestarted,
 	}
 	bgpSrv = bgpserver.NewBGPServer(bgpSrvCfg)
 	bgpSrv.Start()
@@ -85,9 +115,11 @@ func main() {
 	go configReloader()
 	sigHUP <- syscall.SIGHUP
 	installSignalHandler()
+	go shutdownHandler()
 
 	s := bgpserver.NewBGPAPIServer(bgpSrv, vrfReg)
 	isisAPISrv := isisserver.NewISISAPIServer(isisSrv)
+	bfdAPISrv := bfdserver.NewBFDAPIServer(bfdSrv)
 	unaryInterceptors := []grpc.UnaryServerInterceptor{}
 	streamInterceptors := []grpc.StreamServerInterceptor{}
 	srv, err := servicewrapper.New(
@@ -107,6 +139,7 @@ func main() {
 
 	bgpapi.RegisterBgpServiceServer(srv.GRPC(), s)
 	isisapi.RegisterIsisServiceServer(srv.GRPC(), isisAPISrv)
+	bfdapi.RegisterBfdServiceServer(srv.GRPC(), bfdAPISrv)
 	if err := srv.Serve(); err != nil {
 		log.Errorf("failed to start server: %v", err)
 		os.Exit(1)
@@ -117,6 +150,16 @@ func main() {
 
 func installSignalHandler() {
 	signal.Notify(sigHUP, syscall.SIGHUP)
+	signal.Notify(sigTerm, syscall.SIGINT, syscall.SIGTERM)
+}
+
+// shutdownHandler removes all routes installed into the kernel before exiting
+func shutdownHandler() {
+	<-sigTerm
+	log.Infof("Shutting down")
+	kernelCfgtr.removeAll()
+	rpkiValidator.Stop()
+	os.Exit(0)
 }
 
 func configReloader() {
@@ -140,11 +183,40 @@ func configReloader() {
 }
 
 func loadConfig(cfg *config.Config) error {
+	defaultVRF := vrfReg.GetVRFByName(vrf.DefaultVRFName)
+	err := kernelCfgtr.configure(defaultVRF, cfg.RoutingOptions.Kernel)
+	if err != nil {
+		return fmt.Errorf("unable to configure kernel: %w", err)
+	}
+
+	err = kernelCfgtr.configureImport(defaultVRF, cfg.RoutingOptions.KernelImport)
+	if err != nil {
+		return fmt.Errorf("unable to configure kernel import: %w", err)
+	}
+
+	connectedCfgtr.configure(defaultVRF, cfg.RoutingOptions.Connected)
+	staticCfgtr.configure(defaultVRF, cfg.RoutingOptions.StaticRoutes)
+	aggregateCfgtr.configure(defaultVRF, cfg.RoutingOptions.Aggregates, cfg.RoutingOptions.AutonomousSystem, cfg.RoutingOptions.RouterIDUint32)
+	nextHopCfgtr.configure(defaultVRF, cfg.RoutingOptions.NextHopTracking)
+
+	configureRPKI(cfg.RoutingOptions.RPKI)
+
+	vrfNames := map[string]struct{}{
+		vrf.DefaultVRFName: {},
+	}
 	for _, ri := range cfg.RoutingInstances {
-		err := configureRoutingInstance(ri)
-		_ = err
+		err := configureRoutingInstance(ri, cfg.RoutingOptions.AutonomousSystem, cfg.RoutingOptions.RouterIDUint32)
+		if err != nil {
+			log.Errorf("unable to configure routing instance %q: %v", ri.Name, err)
+		}
 
+		vrfNames[ri.Name] = struct{}{}
 	}
+	kernelCfgtr.removeUnconfigured(vrfNames)
+	connectedCfgtr.removeUnconfigured(vrfNames)
+	staticCfgtr.removeUnconfigured(vrfNames)
+	aggregateCfgtr.removeUnconfigured(vrfNames)
+	nextHopCfgtr.removeUnconfigured(vrfNames)
 
 	if cfg.Protocols != nil {
 		if cfg.Protocols.BGP != nil {
@@ -159,7 +231,7 @@ func loadConfig(cfg *config.Config) error {
 		}
 
 		if cfg.Protocols.ISIS != nil {
-			err := configureProtocolsISIS(cfg.Protocols.ISIS)
+			err := configureProtocolsISIS(cfg.Protocols.ISIS, cfg.RoutingOptions.RouterIDUint32)
 			if err != nil {
 				return fmt.Errorf("unable to configure ISIS: %w", err)
 			}
@@ -169,18 +241,73 @@ func loadConfig(cfg *config.Config) error {
 	return nil
 }
 
-func configureRoutingInstance(ri *config.RoutingInstance) error {
-	vrf := vrfReg.GetVRFByName(ri.Name)
+// configureRPKI sets the sources of VRPs and ASPAs. Files failing to load are retried periodically.
+func configureRPKI(cfg *config.RPKI) {
+	if cfg == nil {
+		cfg = &config.RPKI{}
+	}
+
+	rpkiValidator.Configure(cfg.CacheConfigs())
+	err := rpkiValidator.ConfigureFiles(cfg.FileConfigs())
+	if err != nil {
+		log.Errorf("unable to configure RPKI files: %v", err)
+	}
+}
+
+// createVPNRIBs creates the RIBs holding the routes of all BGP/MPLS IP VPNs (RFC4364)
+func createVPNRIBs(v *vrf.VRF) error {
+	_, err := v.CreateIPv4VPNRIB("bgp.l3vpn.0")
+	if err != nil {
+		return err
+	}
+
+	_, err = v.CreateIPv6VPNRIB("bgp.l3vpn-inet6.0")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// configureRoutingInstance configures a VRF. Aggregates are originated with the global ASN and router ID.
+func configureRoutingInstance(ri *config.RoutingInstance, localASN uint32, routerID uint32) error {
+	v := vrfReg.GetVRFByName(ri.Name)
+	if v == nil {
+		v = vrfReg.CreateVRFIfNotExists(ri.Name, ri.InternalRouteDistinguisher)
+	}
 
 	// RD Change
-	if vrf.RD() != ri.InternalRouteDistinguisher {
+	if v.RD() != ri.InternalRouteDistinguisher {
 		// TODO: Drop all routing adjacencies
-		vrf.Dispose()
-		vrfReg.UnregisterVRF(vrf)
+		v.DisconnectVPN()
+		v.Dispose()
+		vrfReg.UnregisterVRF(v)
 
-		vrf = vrfReg.CreateVRFIfNotExists(ri.Name, ri.InternalRouteDistinguisher)
+		v = vrfReg.CreateVRFIfNotExists(ri.Name, ri.InternalRouteDistinguisher)
 		// TODO: Add all routing adjacencies
 	}
 
+	v.ConnectVPN(vrfReg.GetVRFByName(vrf.DefaultVRFName), ri.RouteTargetImportList, ri.RouteTargetExportList, ri.VPNLabel)
+
+	routingOptions := ri.RoutingOptions
+	if routingOptions == nil {
+		routingOptions = &config.RoutingOptions{}
+	}
+
+	err := kernelCfgtr.configure(v, routingOptions.Kernel)
+	if err != nil {
+		return fmt.Errorf("unable to configure kernel: %w", err)
+	}
+
+	err = kernelCfgtr.configureImport(v, routingOptions.KernelImport)
+	if err != nil {
+		return fmt.Errorf("unable to configure kernel import: %w", err)
+	}
+
+	connectedCfgtr.configure(v, routingOptions.Connected)
+	staticCfgtr.configure(v, routingOptions.StaticRoutes)
+	aggregateCfgtr.configure(v, routingOptions.Aggregates, localASN, routerID)
+	nextHopCfgtr.configure(v, routingOptions.NextHopTracking)
+
 	return nil
 }
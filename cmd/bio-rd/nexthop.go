@@ -0,0 +1,78 @@
+package main
+
+import (
+	"sync"
+
+	"github.com/bio-routing/bio-rd/routingtable"
+	"github.com/bio-routing/bio-rd/routingtable/nexthop"
+	"github.com/bio-routing/bio-rd/routingtable/vrf"
+)
+
+// nextHopConfigurator manages the next hop trackers of the VRFs
+type nextHopConfigurator struct {
+	mu       sync.Mutex
+	trackers map[string]*nextHopTracker
+}
+
+type nextHopTracker struct {
+	t   *nexthop.Tracker
+	vrf *vrf.VRF
+}
+
+func newNextHopConfigurator() *nextHopConfigurator {
+	return &nextHopConfigurator{
+		trackers: make(map[string]*nextHopTracker),
+	}
+}
+
+func (nc *nextHopConfigurator) configure(v *vrf.VRF, enabled bool) {
+	nc.mu.Lock()
+	defer nc.mu.Unlock()
+
+	t := nc.trackers[v.Name()]
+	if t != nil && (t.vrf != v || !enabled) {
+		nc.remove(t)
+		t = nil
+	}
+
+	if !enabled || t != nil {
+		return
+	}
+
+	t = &nextHopTracker{
+		t:   nexthop.New(v.IPv4UnicastRIB(), v.IPv6UnicastRIB()),
+		vrf: v,
+	}
+	t.t.Start()
+	nc.trackers[v.Name()] = t
+}
+
+// tracker returns the next hop tracker of a VRF or nil if next hop tracking is disabled
+func (nc *nextHopConfigurator) tracker(v *vrf.VRF) routingtable.NextHopTracker {
+	nc.mu.Lock()
+	defer nc.mu.Unlock()
+
+	t := nc.trackers[v.Name()]
+	if t == nil || t.vrf != v {
+		return nil
+	}
+
+	return t.t
+}
+
+func (nc *nextHopConfigurator) remove(t *nextHopTracker) {
+	t.t.Stop()
+	delete(nc.trackers, t.vrf.Name())
+}
+
+// removeUnconfigured stops the next hop trackers of all VRFs but the given ones
+func (nc *nextHopConfigurator) removeUnconfigured(vrfNames map[string]struct{}) {
+	nc.mu.Lock()
+	defer nc.mu.Unlock()
+
+	for name, t := range nc.trackers {
+		if _, found := vrfNames[name]; !found {
+			nc.remove(t)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"sync"
+
+	"github.com/bio-routing/bio-rd/cmd/bio-rd/config"
+	"github.com/bio-routing/bio-rd/protocols/static"
+	"github.com/bio-routing/bio-rd/routingtable/vrf"
+)
+
+// staticConfigurator manages the static routes of the VRFs
+type staticConfigurator struct {
+	mu       sync.Mutex
+	managers map[string]*staticManager
+}
+
+type staticManager struct {
+	m   *static.Manager
+	vrf *vrf.VRF
+}
+
+func newStaticConfigurator() *staticConfigurator {
+	return &staticConfigurator{
+		managers: make(map[string]*staticManager),
+	}
+}
+
+func (sc *staticConfigurator) configure(v *vrf.VRF, routes []config.StaticRoute) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	m := sc.managers[v.Name()]
+	if m != nil && (m.vrf != v || len(routes) == 0) {
+		sc.remove(m)
+		m = nil
+	}
+
+	if len(routes) == 0 {
+		return
+	}
+
+	if m == nil {
+		m = &staticManager{
+			m:   static.New(v.IPv4UnicastRIB(), v.IPv6UnicastRIB()),
+			vrf: v,
+		}
+		m.m.Start()
+		sc.managers[v.Name()] = m
+	}
+
+	staticRoutes := make([]static.Route, len(routes))
+	for i, r := range routes {
+		staticRoutes[i] = static.Route{
+			Prefix:  r.Pfx,
+			NextHop: r.NextHopIP,
+			Discard: r.Discard,
+			Resolve: r.Resolve,
+		}
+	}
+
+	m.m.Configure(staticRoutes)
+}
+
+// remove withdraws the static routes of a VRF
+func (sc *staticConfigurator) remove(m *staticManager) {
+	m.m.Stop()
+	delete(sc.managers, m.vrf.Name())
+}
+
+// removeUnconfigured withdraws the static routes of all VRFs but the given ones
+func (sc *staticConfigurator) removeUnconfigured(vrfNames map[string]struct{}) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	for name, m := range sc.managers {
+		if _, found := vrfNames[name]; !found {
+			sc.remove(m)
+		}
+	}
+}
@@ -15,8 +15,12 @@ func main() {
 		config.Getrouting_optionsDoc(),
 		config.GetprotocolsDoc(),
 		config.Getstatic_routeDoc(),
+		config.GetaggregateDoc(),
+		config.GetkernelDoc(),
+		config.GetrpkiDoc(),
 		config.GetbgpDoc(),
 		config.GetisisDoc(),
+		config.GetbfdDoc(),
 	}
 
 	for _, fd := range FileDocs {
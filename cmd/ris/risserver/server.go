@@ -7,6 +7,7 @@ import (
 	"github.com/bio-routing/bio-rd/protocols/bgp/server"
 	"github.com/bio-routing/bio-rd/route"
 	"github.com/bio-routing/bio-rd/routingtable"
+	"github.com/bio-routing/bio-rd/routingtable/flowspecRIB"
 	"github.com/bio-routing/bio-rd/routingtable/locRIB"
 	"github.com/bio-routing/bio-rd/routingtable/vrf"
 	"google.golang.org/grpc/codes"
@@ -71,7 +72,12 @@ func ipVersionFromProto(v netapi.IP_Version) uint16 {
 	return 0
 }
 
-func (s Server) getRIB(rtr string, vrfID uint64, ipVersion netapi.IP_Version) (*locRIB.LocRIB, error) {
+// ribDumper is implemented by all RIBs which can be dumped
+type ribDumper interface {
+	Dump() []*route.Route
+}
+
+func (s Server) getVRF(rtr string, vrfID uint64) (*vrf.VRF, error) {
 	r := s.bmp.GetRouter(rtr)
 	if r == nil {
 		return nil, fmt.Errorf("unable to get router")
@@ -82,6 +88,15 @@ func (s Server) getRIB(rtr string, vrfID uint64, ipVersion netapi.IP_Version) (*
 		return nil, fmt.Errorf("unable to get VRF")
 	}
 
+	return v, nil
+}
+
+func (s Server) getRIB(rtr string, vrfID uint64, ipVersion netapi.IP_Version) (*locRIB.LocRIB, error) {
+	v, err := s.getVRF(rtr, vrfID)
+	if err != nil {
+		return nil, err
+	}
+
 	var rib *locRIB.LocRIB
 	switch ipVersion {
 	case netapi.IP_IPv4:
@@ -99,6 +114,29 @@ func (s Server) getRIB(rtr string, vrfID uint64, ipVersion netapi.IP_Version) (*
 	return rib, nil
 }
 
+func (s Server) getFlowSpecRIB(rtr string, vrfID uint64, ipVersion netapi.IP_Version) (*flowspecRIB.FlowSpecRIB, error) {
+	v, err := s.getVRF(rtr, vrfID)
+	if err != nil {
+		return nil, err
+	}
+
+	var rib *flowspecRIB.FlowSpecRIB
+	switch ipVersion {
+	case netapi.IP_IPv4:
+		rib = v.IPv4FlowSpecRIB()
+	case netapi.IP_IPv6:
+		rib = v.IPv6FlowSpecRIB()
+	default:
+		return nil, fmt.Errorf("unknown afi")
+	}
+
+	if rib == nil {
+		return nil, fmt.Errorf("unable to get FlowSpec RIB")
+	}
+
+	return rib, nil
+}
+
 // LPM provides a longest prefix match service
 func (s *Server) LPM(ctx context.Context, req *pb.LPMRequest) (*pb.LPMResponse, error) {
 	vrfID, err := getVRFID(req)
@@ -245,16 +283,29 @@ func (s *Server) DumpRIB(req *pb.DumpRIBRequest, stream pb.RoutingInformationSer
 	}
 
 	ipVersion := netapi.IP_IPv4
+	flowSpec := false
 	switch req.Afisafi {
 	case pb.DumpRIBRequest_IPv4Unicast:
 		ipVersion = netapi.IP_IPv4
 	case pb.DumpRIBRequest_IPv6Unicast:
 		ipVersion = netapi.IP_IPv6
+	case pb.DumpRIBRequest_IPv4FlowSpec:
+		ipVersion = netapi.IP_IPv4
+		flowSpec = true
+	case pb.DumpRIBRequest_IPv6FlowSpec:
+		ipVersion = netapi.IP_IPv6
+		flowSpec = true
 	default:
 		return fmt.Errorf("uknown AFI/SAFI")
 	}
 
-	rib, err := s.getRIB(req.Router, vrfID, ipVersion)
+	var rib ribDumper
+	if flowSpec {
+		rib, err = s.getFlowSpecRIB(req.Router, vrfID, ipVersion)
+	} else {
+		rib, err = s.getRIB(req.Router, vrfID, ipVersion)
+	}
+
 	if err != nil {
 		return wrapGetRIBErr(err, req.Router, vrfID, ipVersion)
 	}
@@ -19,6 +19,7 @@ func NewDumpLocRIBCommand() cli.Command {
 		Flags: []cli.Flag{
 			&cli.BoolFlag{Name: "4", Usage: "print IPv4 routes"},
 			&cli.BoolFlag{Name: "6", Usage: "print IPv6 routes"},
+			&cli.BoolFlag{Name: "flowspec", Usage: "print FlowSpec rules instead of unicast routes"},
 			&cli.Uint64Flag{Name: "origin", Usage: "print routes originated by ASN"},
 			&cli.Uint64Flag{Name: "min", Usage: "print routes having at least this prefix length"},
 			&cli.Uint64Flag{Name: "max", Usage: "print routes having at most this prefix length"},
@@ -35,11 +36,15 @@ func NewDumpLocRIBCommand() cli.Command {
 		if !req_ipv4 && !req_ipv6 {
 			req_ipv4, req_ipv6 = true, true
 		}
+		ipv4, ipv6 := pb.DumpRIBRequest_IPv4Unicast, pb.DumpRIBRequest_IPv6Unicast
+		if c.Bool("flowspec") {
+			ipv4, ipv6 = pb.DumpRIBRequest_IPv4FlowSpec, pb.DumpRIBRequest_IPv6FlowSpec
+		}
 		if req_ipv4 {
-			afisafis = append(afisafis, pb.DumpRIBRequest_IPv4Unicast)
+			afisafis = append(afisafis, ipv4)
 		}
 		if req_ipv6 {
-			afisafis = append(afisafis, pb.DumpRIBRequest_IPv6Unicast)
+			afisafis = append(afisafis, ipv6)
 		}
 
 		filter := &pb.RIBFilter{
@@ -19,6 +19,7 @@ var (
 	uptimeDesc                *prometheus.Desc
 	updatesReceivedDesc       *prometheus.Desc
 	updatesSentDesc           *prometheus.Desc
+	prefixLimitHeldDownDesc   *prometheus.Desc
 	stateDescRouter           *prometheus.Desc
 	uptimeDescRouter          *prometheus.Desc
 	updatesReceivedDescRouter *prometheus.Desc
@@ -28,6 +29,10 @@ var (
 	routesRejectedDesc        *prometheus.Desc
 	routesAcceptedDesc        *prometheus.Desc
 	endOfRIBMarkerDesc        *prometheus.Desc
+	pathsReceivedDesc         *prometheus.Desc
+	prefixLimitDesc           *prometheus.Desc
+	prefixLimitWarningDesc    *prometheus.Desc
+	prefixLimitExceededDesc   *prometheus.Desc
 	routesReceivedDescRouter  *prometheus.Desc
 	routesSentDescRouter      *prometheus.Desc
 	routesRejectedDescRouter  *prometheus.Desc
@@ -41,6 +46,7 @@ func init() {
 	uptimeDesc = prometheus.NewDesc(prefix+"uptime_second", "Time since the session was established in seconds", labels, nil)
 	updatesReceivedDesc = prometheus.NewDesc(prefix+"update_received_count", "Number of updates received", labels, nil)
 	updatesSentDesc = prometheus.NewDesc(prefix+"update_sent_count", "Number of updates sent", labels, nil)
+	prefixLimitHeldDownDesc = prometheus.NewDesc(prefix+"prefix_limit_held_down", "Session is held down after the peer exceeded a prefix limit", labels, nil)
 
 	labelsRouter := append(labels, "sys_name", "agent_address")
 	stateDescRouter = prometheus.NewDesc(prefix+"state", "State of the BGP session (Down = 0, Idle = 1, Connect = 2, Active = 3, OpenSent = 4, OpenConfirm = 5, Established = 6)", labelsRouter, nil)
@@ -54,6 +60,10 @@ func init() {
 	routesRejectedDesc = prometheus.NewDesc(prefix+"route_rejected_count", "Number of routes rejected", labels, nil)
 	routesAcceptedDesc = prometheus.NewDesc(prefix+"route_accepted_count", "Number of routes accepted", labels, nil)
 	endOfRIBMarkerDesc = prometheus.NewDesc(prefix+"end_of_rib_marker_received", "End of RIB marker received", labels, nil)
+	pathsReceivedDesc = prometheus.NewDesc(prefix+"path_received_count", "Number of paths received", labels, nil)
+	prefixLimitDesc = prometheus.NewDesc(prefix+"prefix_limit", "Maximum number of paths accepted from the peer", labels, nil)
+	prefixLimitWarningDesc = prometheus.NewDesc(prefix+"prefix_limit_warning", "Number of paths received reached the warning threshold of the prefix limit", labels, nil)
+	prefixLimitExceededDesc = prometheus.NewDesc(prefix+"prefix_limit_exceeded", "Number of paths received exceeds the prefix limit", labels, nil)
 
 	labelsRouter = append(labelsRouter, "afi", "safi")
 	routesReceivedDescRouter = prometheus.NewDesc(prefix+"route_received_count", "Number of routes received", labelsRouter, nil)
@@ -84,6 +94,11 @@ func (c *bgpCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- routesRejectedDesc
 	ch <- routesAcceptedDesc
 	ch <- endOfRIBMarkerDesc
+	ch <- prefixLimitHeldDownDesc
+	ch <- pathsReceivedDesc
+	ch <- prefixLimitDesc
+	ch <- prefixLimitWarningDesc
+	ch <- prefixLimitExceededDesc
 }
 
 func DescribeRouter(ch chan<- *prometheus.Desc) {
@@ -128,6 +143,7 @@ func collectForPeer(ch chan<- prometheus.Metric, peer *metrics.BGPPeerMetrics) {
 
 	ch <- prometheus.MustNewConstMetric(updatesReceivedDesc, prometheus.CounterValue, float64(peer.UpdatesReceived), l...)
 	ch <- prometheus.MustNewConstMetric(updatesSentDesc, prometheus.CounterValue, float64(peer.UpdatesSent), l...)
+	ch <- prometheus.MustNewConstMetric(prefixLimitHeldDownDesc, prometheus.GaugeValue, boolToFloat(peer.PrefixLimitHeldDown), l...)
 
 	for _, family := range peer.AddressFamilies {
 		collectForFamily(ch, family, l)
@@ -170,6 +186,13 @@ func collectForFamily(ch chan<- prometheus.Metric, family *metrics.BGPAddressFam
 		eor = 1
 	}
 	ch <- prometheus.MustNewConstMetric(endOfRIBMarkerDesc, prometheus.GaugeValue, float64(eor), l...)
+
+	ch <- prometheus.MustNewConstMetric(pathsReceivedDesc, prometheus.GaugeValue, float64(family.PathsReceived), l...)
+	if family.PrefixLimit != 0 {
+		ch <- prometheus.MustNewConstMetric(prefixLimitDesc, prometheus.GaugeValue, float64(family.PrefixLimit), l...)
+		ch <- prometheus.MustNewConstMetric(prefixLimitWarningDesc, prometheus.GaugeValue, boolToFloat(family.PrefixLimitWarning), l...)
+		ch <- prometheus.MustNewConstMetric(prefixLimitExceededDesc, prometheus.GaugeValue, boolToFloat(family.PrefixLimitExceeded), l...)
+	}
 }
 
 func collectForFamilyRouter(ch chan<- prometheus.Metric, family *metrics.BGPAddressFamilyMetrics, l []string) {
@@ -184,3 +207,11 @@ func collectForFamilyRouter(ch chan<- prometheus.Metric, family *metrics.BGPAddr
 	}
 	ch <- prometheus.MustNewConstMetric(endOfRIBMarkerDescRouter, prometheus.GaugeValue, float64(eor), l...)
 }
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+
+	return 0
+}
@@ -28,6 +28,7 @@ type EthernetInterface struct {
 	socket  int
 	devName string
 	ifIndex uint32
+	mac     MACAddr
 	llc     LLC
 }
 
@@ -36,6 +37,7 @@ type EthernetInterfaceI interface {
 	SendPacket(dst MACAddr, pkt []byte) error
 	MCastJoin(addr MACAddr) error
 	GetMTU() int
+	GetMAC() MACAddr
 	Close()
 }
 
@@ -50,6 +52,7 @@ func NewEthernetInterface(devName string, bpf *BPF, llc LLC) (*EthernetInterface
 		ifIndex: uint32(ifa.Index),
 		llc:     llc,
 	}
+	copy(h.mac[:], ifa.HardwareAddr)
 
 	err = h.init(bpf)
 	if err != nil {
@@ -141,6 +144,11 @@ func ethertype802dot3(len int) uint16 {
 	return uint16(len)
 }
 
+// GetMAC gets the interfaces MAC address
+func (e *EthernetInterface) GetMAC() MACAddr {
+	return e.mac
+}
+
 // GetMTU gets the interfaces MTU
 func (e *EthernetInterface) GetMTU() int {
 	netIfa, err := net.InterfaceByIndex(int(e.ifIndex))
@@ -7,6 +7,7 @@ import (
 const MockEthernetInterfaceBufferSize = 1024
 
 type MockEthernetInterface struct {
+	mac               MACAddr
 	joinedMCastGroups []MACAddr
 	sendCh            chan mockPkt
 	recvCh            chan mockPkt
@@ -58,6 +59,15 @@ func (mei *MockEthernetInterface) GetMTU() int {
 	return 1500
 }
 
+func (mei *MockEthernetInterface) GetMAC() MACAddr {
+	return mei.mac
+}
+
+// SetMAC sets the MAC address of the mock interface
+func (mei *MockEthernetInterface) SetMAC(mac MACAddr) {
+	mei.mac = mac
+}
+
 func (mei *MockEthernetInterface) Close() {
 	close(mei.closedCh)
 }
@@ -15,7 +15,8 @@ const (
 )
 
 var (
-	v4Loopback = NewPfx(IPv4FromOctets(127, 0, 0, 0), 8).Ptr()
+	v4Loopback  = NewPfx(IPv4FromOctets(127, 0, 0, 0), 8).Ptr()
+	v4LinkLocal = NewPfx(IPv4FromOctets(169, 254, 0, 0), 16).Ptr()
 )
 
 // IP represents an IPv4 or IPv6 address
@@ -45,6 +46,16 @@ func (ip IP) isLoopbackV6() bool {
 	}
 }
 
+// IsLinkLocalUnicast checks if ip is a link local unicast address
+func (ip IP) IsLinkLocalUnicast() bool {
+	if ip.isLegacy {
+		return v4LinkLocal.containsIPv4(NewPfx(ip, 32).Ptr())
+	}
+
+	// fe80::/10
+	return ip.higher>>54 == 0xfe80>>6
+}
+
 // Dedup gets a copy of IP from the cache
 func (ip IP) Dedup() *IP {
 	return ipc.get(ip)
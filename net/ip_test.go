@@ -69,6 +69,49 @@ func TestIPVersion(t *testing.T) {
 		assert.Equal(t, test.expected, test.ip.isLegacy, test.name)
 	}
 }
+func TestIsLinkLocalUnicast(t *testing.T) {
+	tests := []struct {
+		name     string
+		ip       IP
+		expected bool
+	}{
+		{
+			name:     "IPv4 link local",
+			ip:       IPv4FromOctets(169, 254, 1, 1),
+			expected: true,
+		},
+		{
+			name:     "IPv4 global",
+			ip:       IPv4FromOctets(192, 0, 2, 1),
+			expected: false,
+		},
+		{
+			name:     "IPv6 link local",
+			ip:       IPv6FromBlocks(0xfe80, 0, 0, 0, 0, 0, 0, 1),
+			expected: true,
+		},
+		{
+			name:     "IPv6 link local upper end",
+			ip:       IPv6FromBlocks(0xfebf, 0xffff, 0, 0, 0, 0, 0, 1),
+			expected: true,
+		},
+		{
+			name:     "IPv6 site local",
+			ip:       IPv6FromBlocks(0xfec0, 0, 0, 0, 0, 0, 0, 1),
+			expected: false,
+		},
+		{
+			name:     "IPv6 global",
+			ip:       IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 1),
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, test.ip.IsLinkLocalUnicast(), test.name)
+	}
+}
+
 func TestIPToProto(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -0,0 +1,399 @@
+package aggregate
+
+import (
+	"sort"
+	"sync"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/bio-routing/bio-rd/routingtable"
+	"github.com/bio-routing/bio-rd/routingtable/filter"
+	"github.com/bio-routing/bio-rd/routingtable/locRIB"
+	"github.com/bio-routing/bio-rd/util/log"
+)
+
+// defaultLocalPref is the LOCAL_PREF of originated aggregates unless changed by a policy
+const defaultLocalPref = 100
+
+// Aggregate is the configuration of an aggregate route
+type Aggregate struct {
+	Prefix *bnet.Prefix
+
+	// ASSet makes the AS paths of the contributing routes to be merged into an AS_SET
+	// instead of marking the aggregate ATOMIC_AGGREGATE
+	ASSet bool
+
+	// SummaryOnly suppresses the advertisement of the contributing routes to BGP neighbors
+	SummaryOnly bool
+
+	// FilterChain is applied to the aggregate before it is originated. The aggregate is not originated if rejected.
+	FilterChain filter.Chain
+}
+
+// Manager originates the aggregates of a VRF into its RIBs while they have any contributing route
+type Manager struct {
+	rib4        *locRIB.LocRIB
+	rib6        *locRIB.LocRIB
+	localASN    uint32
+	routerID    uint32
+	mu          sync.Mutex
+	aggregates  map[bnet.Prefix]*aggregate
+	observer    *routingtable.RIBObserver
+	suppressMu  sync.RWMutex
+	suppressing map[bnet.Prefix]struct{}
+	clients     map[routingtable.RouteSuppressorClient]struct{}
+}
+
+type aggregate struct {
+	cfg  Aggregate
+	path *route.Path // Path added to the RIB. Nil if there are no contributing routes.
+}
+
+// New creates a new aggregate manager adding IPv4 aggregates to rib4 and IPv6 aggregates to rib6.
+// localASN and routerID are announced in the AGGREGATOR attribute.
+func New(rib4 *locRIB.LocRIB, rib6 *locRIB.LocRIB, localASN uint32, routerID uint32) *Manager {
+	m := &Manager{
+		rib4:        rib4,
+		rib6:        rib6,
+		localASN:    localASN,
+		routerID:    routerID,
+		aggregates:  make(map[bnet.Prefix]*aggregate),
+		suppressing: make(map[bnet.Prefix]struct{}),
+		clients:     make(map[routingtable.RouteSuppressorClient]struct{}),
+	}
+	m.observer = routingtable.NewRIBObserver(func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		m.update()
+	})
+
+	return m
+}
+
+// Start starts tracking changes of the RIBs to keep the aggregates up to date
+func (m *Manager) Start() {
+	m.observer.Start()
+
+	m.rib4.Register(m.observer)
+	m.rib6.Register(m.observer)
+}
+
+// Stop stops the manager and removes all aggregates from the RIBs
+func (m *Manager) Stop() {
+	m.rib4.Unregister(m.observer)
+	m.rib6.Unregister(m.observer)
+	m.observer.Stop()
+
+	m.Configure(nil)
+}
+
+// Configure replaces the configured aggregates. Aggregates not configured anymore are withdrawn.
+func (m *Manager) Configure(aggregates []Aggregate) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	configured := make(map[bnet.Prefix]Aggregate, len(aggregates))
+	for _, a := range aggregates {
+		configured[*a.Prefix] = a
+	}
+
+	for pfx, a := range m.aggregates {
+		if _, found := configured[pfx]; found {
+			continue
+		}
+
+		m.withdraw(a)
+		delete(m.aggregates, pfx)
+	}
+
+	for pfx, cfg := range configured {
+		a, found := m.aggregates[pfx]
+		if !found {
+			a = &aggregate{}
+			m.aggregates[pfx] = a
+		}
+
+		a.cfg = cfg
+	}
+
+	m.update()
+}
+
+// Register registers a client to be notified about changes of the suppressed routes
+func (m *Manager) Register(client routingtable.RouteSuppressorClient) {
+	m.suppressMu.Lock()
+	defer m.suppressMu.Unlock()
+
+	m.clients[client] = struct{}{}
+}
+
+// Unregister unregisters a client
+func (m *Manager) Unregister(client routingtable.RouteSuppressorClient) {
+	m.suppressMu.Lock()
+	defer m.suppressMu.Unlock()
+
+	delete(m.clients, client)
+}
+
+// Suppressed checks if pfx is a more specific of an originated summary only aggregate
+func (m *Manager) Suppressed(pfx *bnet.Prefix) bool {
+	m.suppressMu.RLock()
+	defer m.suppressMu.RUnlock()
+
+	for aggPfx := range m.suppressing {
+		if covers(&aggPfx, pfx) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// update adds, replaces or withdraws the paths of all aggregates according to their contributing routes
+func (m *Manager) update() {
+	for _, a := range m.aggregates {
+		p := m.computePath(&a.cfg)
+		if p == nil && a.path == nil {
+			continue
+		}
+
+		if p != nil && a.path != nil && p.Compare(a.path) {
+			continue
+		}
+
+		m.withdraw(a)
+		if p == nil {
+			continue
+		}
+
+		err := m.ribForPrefix(a.cfg.Prefix).AddPath(a.cfg.Prefix, p)
+		if err != nil {
+			log.Errorf("unable to add aggregate %s: %v", a.cfg.Prefix.String(), err)
+			continue
+		}
+
+		a.path = p
+	}
+
+	m.updateSuppression()
+}
+
+// updateSuppression suppresses the more specifics of all originated summary only aggregates
+// and notifies the clients about changes
+func (m *Manager) updateSuppression() {
+	suppressing := make(map[bnet.Prefix]struct{})
+	for pfx, a := range m.aggregates {
+		if a.cfg.SummaryOnly && a.path != nil {
+			suppressing[pfx] = struct{}{}
+		}
+	}
+
+	m.suppressMu.Lock()
+	changed := len(suppressing) != len(m.suppressing)
+	for pfx := range suppressing {
+		if _, found := m.suppressing[pfx]; !found {
+			changed = true
+		}
+	}
+	m.suppressing = suppressing
+
+	clients := make([]routingtable.RouteSuppressorClient, 0, len(m.clients))
+	for c := range m.clients {
+		clients = append(clients, c)
+	}
+	m.suppressMu.Unlock()
+
+	if !changed {
+		return
+	}
+
+	for _, c := range clients {
+		c.SuppressionUpdate()
+	}
+}
+
+func (m *Manager) withdraw(a *aggregate) {
+	if a.path == nil {
+		return
+	}
+
+	m.ribForPrefix(a.cfg.Prefix).RemovePath(a.cfg.Prefix, a.path)
+	a.path = nil
+}
+
+// computePath returns the path of an aggregate or nil if it has no contributing routes
+func (m *Manager) computePath(a *Aggregate) *route.Path {
+	contributors := m.contributors(a.Prefix)
+	if len(contributors) == 0 {
+		return nil
+	}
+
+	p := &route.Path{
+		Type:    route.BGPPathType,
+		BGPPath: route.NewBGPPath(),
+	}
+	p.BGPPath.LocalAggregate = true
+	p.BGPPath.BGPPathA.LocalPref = defaultLocalPref
+	p.BGPPath.BGPPathA.Aggregator = &types.Aggregator{
+		ASN:     m.localASN,
+		Address: m.routerID,
+	}
+
+	for _, c := range contributors {
+		if c.Type != route.BGPPathType {
+			continue
+		}
+
+		// RFC4271 Sect. 9.2.2.2: The aggregate must carry ATOMIC_AGGREGATE if any contributor does
+		if c.BGPPath.BGPPathA.AtomicAggregate {
+			p.BGPPath.BGPPathA.AtomicAggregate = true
+		}
+
+		// RFC4271 Sect. 9.2.2.2: INCOMPLETE takes precedence over EGP which takes precedence over IGP
+		if c.BGPPath.BGPPathA.Origin > p.BGPPath.BGPPathA.Origin {
+			p.BGPPath.BGPPathA.Origin = c.BGPPath.BGPPathA.Origin
+		}
+
+		// The AS path of the contributor is lost (RFC4271 Sect. 9.1.4)
+		if !a.ASSet && c.BGPPath.ASPath != nil && c.BGPPath.ASPath.Length() > 0 {
+			p.BGPPath.BGPPathA.AtomicAggregate = true
+		}
+	}
+
+	if a.ASSet {
+		p.BGPPath.ASPath = aggregateASPath(contributors)
+	}
+	p.BGPPath.ASPathLen = p.BGPPath.ASPath.Length()
+
+	p, reject := a.FilterChain.Process(a.Prefix, p)
+	if reject {
+		return nil
+	}
+
+	p.BGPPath = p.BGPPath.Dedup()
+	return p
+}
+
+// contributors returns the best paths of all more specific routes of pfx
+func (m *Manager) contributors(pfx *bnet.Prefix) []*route.Path {
+	ret := make([]*route.Path, 0)
+	for _, r := range m.ribForPrefix(pfx).GetLonger(pfx) {
+		if r.Pfxlen() <= pfx.Len() {
+			continue
+		}
+
+		p := r.BestPath()
+		if p == nil {
+			continue
+		}
+
+		ret = append(ret, p)
+	}
+
+	return ret
+}
+
+// aggregateASPath merges the AS paths of the contributors into their common leading AS_SEQUENCE
+// followed by an AS_SET of all other ASNs (RFC4271 Appendix F.6)
+func aggregateASPath(contributors []*route.Path) *types.ASPath {
+	seq := leadingSequence(contributors[0])
+	for _, c := range contributors[1:] {
+		seq = commonPrefix(seq, leadingSequence(c))
+	}
+
+	inSeq := make(map[uint32]struct{}, len(seq))
+	for _, asn := range seq {
+		inSeq[asn] = struct{}{}
+	}
+
+	set := make(map[uint32]struct{})
+	for _, c := range contributors {
+		if c.Type != route.BGPPathType || c.BGPPath.ASPath == nil {
+			continue
+		}
+
+		for _, seg := range *c.BGPPath.ASPath {
+			for _, asn := range seg.ASNs {
+				if _, found := inSeq[asn]; !found {
+					set[asn] = struct{}{}
+				}
+			}
+		}
+	}
+
+	asns := make([]uint32, 0, len(set))
+	for asn := range set {
+		asns = append(asns, asn)
+	}
+	sort.Slice(asns, func(i, j int) bool {
+		return asns[i] < asns[j]
+	})
+
+	if len(asns) == 0 {
+		return types.NewASPath(seq)
+	}
+
+	ret := make(types.ASPath, 0)
+	if len(seq) > 0 {
+		ret = append(ret, (*types.NewASPath(seq))[0])
+	}
+
+	for len(asns) > 0 {
+		n := len(asns)
+		if n > types.MaxASNsSegment {
+			n = types.MaxASNsSegment
+		}
+
+		ret = append(ret, types.ASPathSegment{
+			Type: types.ASSet,
+			ASNs: asns[:n],
+		})
+		asns = asns[n:]
+	}
+
+	return &ret
+}
+
+// leadingSequence returns the ASNs of the first segment of the AS path of p if it is an AS_SEQUENCE
+func leadingSequence(p *route.Path) []uint32 {
+	if p.Type != route.BGPPathType || p.BGPPath.ASPath == nil || len(*p.BGPPath.ASPath) == 0 {
+		return nil
+	}
+
+	first := (*p.BGPPath.ASPath)[0]
+	if first.Type != types.ASSequence {
+		return nil
+	}
+
+	return first.ASNs
+}
+
+func commonPrefix(a []uint32, b []uint32) []uint32 {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+
+	return a[:i]
+}
+
+// covers checks if pfx is a more specific of agg
+func covers(agg *bnet.Prefix, pfx *bnet.Prefix) bool {
+	aggAddr, addr := agg.Addr(), pfx.Addr()
+	if aggAddr.IsIPv4() != addr.IsIPv4() || pfx.Len() <= agg.Len() {
+		return false
+	}
+
+	return agg.GetIPNet().Contains(addr.ToNetIP())
+}
+
+func (m *Manager) ribForPrefix(pfx *bnet.Prefix) *locRIB.LocRIB {
+	addr := pfx.Addr()
+	if addr.IsIPv4() {
+		return m.rib4
+	}
+
+	return m.rib6
+}
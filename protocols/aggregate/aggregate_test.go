@@ -0,0 +1,324 @@
+package aggregate
+
+import (
+	"testing"
+	"time"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/packet"
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/bio-routing/bio-rd/routingtable/filter"
+	"github.com/bio-routing/bio-rd/routingtable/filter/actions"
+	"github.com/bio-routing/bio-rd/routingtable/locRIB"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testASN      = 65000
+	testRouterID = 0xc0000201
+)
+
+func bgpPath(asPath *types.ASPath, origin uint8, atomicAggregate bool) *route.Path {
+	p := route.NewBGPPath()
+	p.ASPath = asPath
+	p.ASPathLen = asPath.Length()
+	p.BGPPathA.Origin = origin
+	p.BGPPathA.AtomicAggregate = atomicAggregate
+
+	return &route.Path{
+		Type:    route.BGPPathType,
+		BGPPath: p,
+	}
+}
+
+func staticPath(nh *bnet.IP) *route.Path {
+	return &route.Path{
+		Type: route.StaticPathType,
+		StaticPath: &route.StaticPath{
+			NextHop: nh,
+		},
+	}
+}
+
+func aggregatePath(asPath *types.ASPath, origin uint8, atomicAggregate bool, localPref uint32) *route.Path {
+	p := bgpPath(asPath, origin, atomicAggregate)
+	p.BGPPath.LocalAggregate = true
+	p.BGPPath.BGPPathA.LocalPref = localPref
+	p.BGPPath.BGPPathA.Aggregator = &types.Aggregator{
+		ASN:     testASN,
+		Address: testRouterID,
+	}
+	p.BGPPath.Dedup()
+
+	return p
+}
+
+func TestManager(t *testing.T) {
+	agg := bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 100, 0), 22).Ptr()
+	pfxA := bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 100, 0), 24).Ptr()
+	pfxB := bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 101, 0), 24).Ptr()
+	outside := bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 104, 0), 24).Ptr()
+	agg6 := bnet.NewPfx(bnet.IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 0), 32).Ptr()
+	gw := bnet.IPv4FromOctets(192, 0, 2, 1).Ptr()
+
+	rejectAll := filter.Chain{filter.NewDrainFilter()}
+	setLocalPref := filter.Chain{
+		filter.NewFilter("set-local-pref", []*filter.Term{
+			filter.NewTerm("all", []*filter.TermCondition{}, []actions.Action{
+				actions.NewSetLocalPrefAction(200),
+				actions.NewAcceptAction(),
+			}),
+		}),
+	}
+
+	tests := []struct {
+		name       string
+		rib4       map[bnet.Prefix]*route.Path
+		aggregates []Aggregate
+		expected4  map[bnet.Prefix][]*route.Path
+		expected6  map[bnet.Prefix][]*route.Path
+	}{
+		{
+			name: "no contributors",
+			rib4: map[bnet.Prefix]*route.Path{
+				*outside: staticPath(gw),
+			},
+			aggregates: []Aggregate{
+				{Prefix: agg},
+				{Prefix: agg6},
+			},
+			expected4: map[bnet.Prefix][]*route.Path{
+				*outside: {staticPath(gw)},
+			},
+			expected6: map[bnet.Prefix][]*route.Path{},
+		},
+		{
+			name: "non BGP contributor",
+			rib4: map[bnet.Prefix]*route.Path{
+				*pfxA: staticPath(gw),
+			},
+			aggregates: []Aggregate{
+				{Prefix: agg},
+			},
+			expected4: map[bnet.Prefix][]*route.Path{
+				*agg:  {aggregatePath(types.NewASPath([]uint32{}), packet.IGP, false, defaultLocalPref)},
+				*pfxA: {staticPath(gw)},
+			},
+			expected6: map[bnet.Prefix][]*route.Path{},
+		},
+		{
+			name: "AS paths of contributors are lost",
+			rib4: map[bnet.Prefix]*route.Path{
+				*pfxA: bgpPath(types.NewASPath([]uint32{65100, 65200}), packet.INCOMPLETE, false),
+				*pfxB: staticPath(gw),
+			},
+			aggregates: []Aggregate{
+				{Prefix: agg},
+			},
+			expected4: map[bnet.Prefix][]*route.Path{
+				*agg:  {aggregatePath(types.NewASPath([]uint32{}), packet.INCOMPLETE, true, defaultLocalPref)},
+				*pfxA: {bgpPath(types.NewASPath([]uint32{65100, 65200}), packet.INCOMPLETE, false)},
+				*pfxB: {staticPath(gw)},
+			},
+			expected6: map[bnet.Prefix][]*route.Path{},
+		},
+		{
+			name: "AS set",
+			rib4: map[bnet.Prefix]*route.Path{
+				*pfxA: bgpPath(types.NewASPath([]uint32{65100, 65200, 65300}), packet.IGP, false),
+				*pfxB: bgpPath(types.NewASPath([]uint32{65100, 65400, 65200}), packet.EGP, false),
+			},
+			aggregates: []Aggregate{
+				{
+					Prefix: agg,
+					ASSet:  true,
+				},
+			},
+			expected4: map[bnet.Prefix][]*route.Path{
+				*agg: {aggregatePath(&types.ASPath{
+					{
+						Type: types.ASSequence,
+						ASNs: []uint32{65100},
+					},
+					{
+						Type: types.ASSet,
+						ASNs: []uint32{65200, 65300, 65400},
+					},
+				}, packet.EGP, false, defaultLocalPref)},
+				*pfxA: {bgpPath(types.NewASPath([]uint32{65100, 65200, 65300}), packet.IGP, false)},
+				*pfxB: {bgpPath(types.NewASPath([]uint32{65100, 65400, 65200}), packet.EGP, false)},
+			},
+			expected6: map[bnet.Prefix][]*route.Path{},
+		},
+		{
+			name: "AS set keeps atomic aggregate of contributor",
+			rib4: map[bnet.Prefix]*route.Path{
+				*pfxA: bgpPath(types.NewASPath([]uint32{65100}), packet.IGP, true),
+				*pfxB: staticPath(gw),
+			},
+			aggregates: []Aggregate{
+				{
+					Prefix: agg,
+					ASSet:  true,
+				},
+			},
+			expected4: map[bnet.Prefix][]*route.Path{
+				*agg: {aggregatePath(&types.ASPath{
+					{
+						Type: types.ASSet,
+						ASNs: []uint32{65100},
+					},
+				}, packet.IGP, true, defaultLocalPref)},
+				*pfxA: {bgpPath(types.NewASPath([]uint32{65100}), packet.IGP, true)},
+				*pfxB: {staticPath(gw)},
+			},
+			expected6: map[bnet.Prefix][]*route.Path{},
+		},
+		{
+			name: "policy changes attributes",
+			rib4: map[bnet.Prefix]*route.Path{
+				*pfxA: staticPath(gw),
+			},
+			aggregates: []Aggregate{
+				{
+					Prefix:      agg,
+					FilterChain: setLocalPref,
+				},
+			},
+			expected4: map[bnet.Prefix][]*route.Path{
+				*agg:  {aggregatePath(types.NewASPath([]uint32{}), packet.IGP, false, 200)},
+				*pfxA: {staticPath(gw)},
+			},
+			expected6: map[bnet.Prefix][]*route.Path{},
+		},
+		{
+			name: "policy rejects aggregate",
+			rib4: map[bnet.Prefix]*route.Path{
+				*pfxA: staticPath(gw),
+			},
+			aggregates: []Aggregate{
+				{
+					Prefix:      agg,
+					FilterChain: rejectAll,
+				},
+			},
+			expected4: map[bnet.Prefix][]*route.Path{
+				*pfxA: {staticPath(gw)},
+			},
+			expected6: map[bnet.Prefix][]*route.Path{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := New(locRIB.New("inet.0"), locRIB.New("inet6.0"), testASN, testRouterID)
+			for pfx, p := range test.rib4 {
+				pfx := pfx
+				m.rib4.AddPath(&pfx, p)
+			}
+
+			m.Configure(test.aggregates)
+
+			assert.Equal(t, test.expected4, dump(m.rib4))
+			assert.Equal(t, test.expected6, dump(m.rib6))
+		})
+	}
+}
+
+type mockSuppressorClient struct {
+	updates int
+}
+
+func (m *mockSuppressorClient) SuppressionUpdate() {
+	m.updates++
+}
+
+func TestSuppression(t *testing.T) {
+	agg := bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 100, 0), 22).Ptr()
+	pfxA := bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 100, 0), 24).Ptr()
+	outside := bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 104, 0), 24).Ptr()
+	pfx6 := bnet.NewPfx(bnet.IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 0), 48).Ptr()
+	gw := bnet.IPv4FromOctets(192, 0, 2, 1).Ptr()
+
+	m := New(locRIB.New("inet.0"), locRIB.New("inet6.0"), testASN, testRouterID)
+	c := &mockSuppressorClient{}
+	m.Register(c)
+
+	m.Configure([]Aggregate{
+		{
+			Prefix:      agg,
+			SummaryOnly: true,
+		},
+	})
+	assert.False(t, m.Suppressed(pfxA), "no contributors")
+	assert.Equal(t, 0, c.updates)
+
+	m.rib4.AddPath(pfxA, staticPath(gw))
+	m.Configure([]Aggregate{
+		{
+			Prefix:      agg,
+			SummaryOnly: true,
+		},
+	})
+	assert.True(t, m.Suppressed(pfxA))
+	assert.False(t, m.Suppressed(agg), "aggregate itself")
+	assert.False(t, m.Suppressed(outside))
+	assert.False(t, m.Suppressed(pfx6))
+	assert.Equal(t, 1, c.updates)
+
+	m.Configure([]Aggregate{
+		{
+			Prefix: agg,
+		},
+	})
+	assert.False(t, m.Suppressed(pfxA), "not summary only")
+	assert.Equal(t, 2, c.updates)
+
+	m.Unregister(c)
+	m.Configure(nil)
+	assert.Equal(t, 2, c.updates)
+}
+
+func TestManagerTracksRIB(t *testing.T) {
+	agg := bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 100, 0), 22).Ptr()
+	pfxA := bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 100, 0), 24).Ptr()
+	gw := bnet.IPv4FromOctets(192, 0, 2, 1).Ptr()
+	expected := aggregatePath(types.NewASPath([]uint32{}), packet.IGP, false, defaultLocalPref)
+
+	m := New(locRIB.New("inet.0"), locRIB.New("inet6.0"), testASN, testRouterID)
+	m.Start()
+	m.Configure([]Aggregate{
+		{
+			Prefix: agg,
+		},
+	})
+	assert.Nil(t, m.rib4.Get(agg))
+
+	m.rib4.AddPath(pfxA, staticPath(gw))
+	assert.Eventually(t, func() bool {
+		return m.rib4.ContainsPfxPath(agg, expected)
+	}, time.Second, time.Millisecond)
+
+	m.rib4.RemovePath(pfxA, staticPath(gw))
+	assert.Eventually(t, func() bool {
+		return m.rib4.Get(agg) == nil
+	}, time.Second, time.Millisecond)
+
+	m.rib4.AddPath(pfxA, staticPath(gw))
+	assert.Eventually(t, func() bool {
+		return m.rib4.ContainsPfxPath(agg, expected)
+	}, time.Second, time.Millisecond)
+
+	m.Stop()
+	assert.False(t, m.rib4.ContainsPfxPath(agg, expected))
+}
+
+func dump(rib *locRIB.LocRIB) map[bnet.Prefix][]*route.Path {
+	res := make(map[bnet.Prefix][]*route.Path)
+	for _, r := range rib.Dump() {
+		res[*r.Prefix()] = r.Paths()
+	}
+
+	return res
+}
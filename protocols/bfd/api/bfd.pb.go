@@ -0,0 +1,499 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        v3.17.3
+// source: protocols/bfd/api/bfd.proto
+
+package api
+
+import (
+	api "github.com/bio-routing/bio-rd/net/api"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Session_State int32
+
+const (
+	Session_AdminDown Session_State = 0
+	Session_Down      Session_State = 1
+	Session_Init      Session_State = 2
+	Session_Up        Session_State = 3
+)
+
+// Enum value maps for Session_State.
+var (
+	Session_State_name = map[int32]string{
+		0: "AdminDown",
+		1: "Down",
+		2: "Init",
+		3: "Up",
+	}
+	Session_State_value = map[string]int32{
+		"AdminDown": 0,
+		"Down":      1,
+		"Init":      2,
+		"Up":        3,
+	}
+)
+
+func (x Session_State) Enum() *Session_State {
+	p := new(Session_State)
+	*p = x
+	return p
+}
+
+func (x Session_State) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Session_State) Descriptor() protoreflect.EnumDescriptor {
+	return file_protocols_bfd_api_bfd_proto_enumTypes[0].Descriptor()
+}
+
+func (Session_State) Type() protoreflect.EnumType {
+	return &file_protocols_bfd_api_bfd_proto_enumTypes[0]
+}
+
+func (x Session_State) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Session_State.Descriptor instead.
+func (Session_State) EnumDescriptor() ([]byte, []int) {
+	return file_protocols_bfd_api_bfd_proto_rawDescGZIP(), []int{2, 0}
+}
+
+type ListSessionsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListSessionsRequest) Reset() {
+	*x = ListSessionsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocols_bfd_api_bfd_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSessionsRequest) ProtoMessage() {}
+
+func (x *ListSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_bfd_api_bfd_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSessionsRequest.ProtoReflect.Descriptor instead.
+func (*ListSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_protocols_bfd_api_bfd_proto_rawDescGZIP(), []int{0}
+}
+
+type ListSessionsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sessions []*Session `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+}
+
+func (x *ListSessionsResponse) Reset() {
+	*x = ListSessionsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocols_bfd_api_bfd_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSessionsResponse) ProtoMessage() {}
+
+func (x *ListSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_bfd_api_bfd_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSessionsResponse.ProtoReflect.Descriptor instead.
+func (*ListSessionsResponse) Descriptor() ([]byte, []int) {
+	return file_protocols_bfd_api_bfd_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListSessionsResponse) GetSessions() []*Session {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+type Session struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Peer                    *api.IP       `protobuf:"bytes,1,opt,name=peer,proto3" json:"peer,omitempty"`
+	Local                   *api.IP       `protobuf:"bytes,2,opt,name=local,proto3" json:"local,omitempty"`
+	InterfaceName           string        `protobuf:"bytes,3,opt,name=interface_name,json=interfaceName,proto3" json:"interface_name,omitempty"`
+	Multihop                bool          `protobuf:"varint,4,opt,name=multihop,proto3" json:"multihop,omitempty"`
+	State                   Session_State `protobuf:"varint,5,opt,name=state,proto3,enum=bio.bfd.Session_State" json:"state,omitempty"`
+	RemoteState             Session_State `protobuf:"varint,6,opt,name=remote_state,json=remoteState,proto3,enum=bio.bfd.Session_State" json:"remote_state,omitempty"`
+	Diagnostic              uint32        `protobuf:"varint,7,opt,name=diagnostic,proto3" json:"diagnostic,omitempty"`
+	LocalDiscriminator      uint32        `protobuf:"varint,8,opt,name=local_discriminator,json=localDiscriminator,proto3" json:"local_discriminator,omitempty"`
+	RemoteDiscriminator     uint32        `protobuf:"varint,9,opt,name=remote_discriminator,json=remoteDiscriminator,proto3" json:"remote_discriminator,omitempty"`
+	DesiredMinTxIntervalUs  uint32        `protobuf:"varint,10,opt,name=desired_min_tx_interval_us,json=desiredMinTxIntervalUs,proto3" json:"desired_min_tx_interval_us,omitempty"`
+	RequiredMinRxIntervalUs uint32        `protobuf:"varint,11,opt,name=required_min_rx_interval_us,json=requiredMinRxIntervalUs,proto3" json:"required_min_rx_interval_us,omitempty"`
+	RemoteMinRxIntervalUs   uint32        `protobuf:"varint,12,opt,name=remote_min_rx_interval_us,json=remoteMinRxIntervalUs,proto3" json:"remote_min_rx_interval_us,omitempty"`
+	DetectMultiplier        uint32        `protobuf:"varint,13,opt,name=detect_multiplier,json=detectMultiplier,proto3" json:"detect_multiplier,omitempty"`
+	DetectionTimeUs         uint32        `protobuf:"varint,14,opt,name=detection_time_us,json=detectionTimeUs,proto3" json:"detection_time_us,omitempty"`
+	LastTransitionUnix      int64         `protobuf:"varint,15,opt,name=last_transition_unix,json=lastTransitionUnix,proto3" json:"last_transition_unix,omitempty"`
+	Clients                 uint32        `protobuf:"varint,16,opt,name=clients,proto3" json:"clients,omitempty"`
+}
+
+func (x *Session) Reset() {
+	*x = Session{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocols_bfd_api_bfd_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Session) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Session) ProtoMessage() {}
+
+func (x *Session) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_bfd_api_bfd_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Session.ProtoReflect.Descriptor instead.
+func (*Session) Descriptor() ([]byte, []int) {
+	return file_protocols_bfd_api_bfd_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Session) GetPeer() *api.IP {
+	if x != nil {
+		return x.Peer
+	}
+	return nil
+}
+
+func (x *Session) GetLocal() *api.IP {
+	if x != nil {
+		return x.Local
+	}
+	return nil
+}
+
+func (x *Session) GetInterfaceName() string {
+	if x != nil {
+		return x.InterfaceName
+	}
+	return ""
+}
+
+func (x *Session) GetMultihop() bool {
+	if x != nil {
+		return x.Multihop
+	}
+	return false
+}
+
+func (x *Session) GetState() Session_State {
+	if x != nil {
+		return x.State
+	}
+	return Session_AdminDown
+}
+
+func (x *Session) GetRemoteState() Session_State {
+	if x != nil {
+		return x.RemoteState
+	}
+	return Session_AdminDown
+}
+
+func (x *Session) GetDiagnostic() uint32 {
+	if x != nil {
+		return x.Diagnostic
+	}
+	return 0
+}
+
+func (x *Session) GetLocalDiscriminator() uint32 {
+	if x != nil {
+		return x.LocalDiscriminator
+	}
+	return 0
+}
+
+func (x *Session) GetRemoteDiscriminator() uint32 {
+	if x != nil {
+		return x.RemoteDiscriminator
+	}
+	return 0
+}
+
+func (x *Session) GetDesiredMinTxIntervalUs() uint32 {
+	if x != nil {
+		return x.DesiredMinTxIntervalUs
+	}
+	return 0
+}
+
+func (x *Session) GetRequiredMinRxIntervalUs() uint32 {
+	if x != nil {
+		return x.RequiredMinRxIntervalUs
+	}
+	return 0
+}
+
+func (x *Session) GetRemoteMinRxIntervalUs() uint32 {
+	if x != nil {
+		return x.RemoteMinRxIntervalUs
+	}
+	return 0
+}
+
+func (x *Session) GetDetectMultiplier() uint32 {
+	if x != nil {
+		return x.DetectMultiplier
+	}
+	return 0
+}
+
+func (x *Session) GetDetectionTimeUs() uint32 {
+	if x != nil {
+		return x.DetectionTimeUs
+	}
+	return 0
+}
+
+func (x *Session) GetLastTransitionUnix() int64 {
+	if x != nil {
+		return x.LastTransitionUnix
+	}
+	return 0
+}
+
+func (x *Session) GetClients() uint32 {
+	if x != nil {
+		return x.Clients
+	}
+	return 0
+}
+
+var File_protocols_bfd_api_bfd_proto protoreflect.FileDescriptor
+
+var file_protocols_bfd_api_bfd_proto_rawDesc = []byte{
+	0x0a, 0x1b, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x73, 0x2f, 0x62, 0x66, 0x64, 0x2f,
+	0x61, 0x70, 0x69, 0x2f, 0x62, 0x66, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x07, 0x62,
+	0x69, 0x6f, 0x2e, 0x62, 0x66, 0x64, 0x1a, 0x11, 0x6e, 0x65, 0x74, 0x2f, 0x61, 0x70, 0x69, 0x2f,
+	0x6e, 0x65, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x15, 0x0a, 0x13, 0x4c, 0x69, 0x73,
+	0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x22, 0x44, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x08, 0x73, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x62, 0x69, 0x6f,
+	0x2e, 0x62, 0x66, 0x64, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x73, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x8a, 0x06, 0x0a, 0x07, 0x53, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x04, 0x70, 0x65, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0b, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x49, 0x50, 0x52, 0x04, 0x70,
+	0x65, 0x65, 0x72, 0x12, 0x21, 0x0a, 0x05, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x49, 0x50, 0x52,
+	0x05, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x12, 0x25, 0x0a, 0x0e, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66,
+	0x61, 0x63, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a,
+	0x08, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x68, 0x6f, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x08, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x68, 0x6f, 0x70, 0x12, 0x2c, 0x0a, 0x05, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x62,
+	0x66, 0x64, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x39, 0x0a, 0x0c, 0x72, 0x65, 0x6d, 0x6f, 0x74,
+	0x65, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e,
+	0x62, 0x69, 0x6f, 0x2e, 0x62, 0x66, 0x64, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x2e,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x0b, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74,
+	0x69, 0x63, 0x12, 0x2f, 0x0a, 0x13, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x5f, 0x64, 0x69, 0x73, 0x63,
+	0x72, 0x69, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x12, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x44, 0x69, 0x73, 0x63, 0x72, 0x69, 0x6d, 0x69, 0x6e, 0x61,
+	0x74, 0x6f, 0x72, 0x12, 0x31, 0x0a, 0x14, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x64, 0x69,
+	0x73, 0x63, 0x72, 0x69, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x13, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x44, 0x69, 0x73, 0x63, 0x72, 0x69, 0x6d,
+	0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x3a, 0x0a, 0x1a, 0x64, 0x65, 0x73, 0x69, 0x72, 0x65,
+	0x64, 0x5f, 0x6d, 0x69, 0x6e, 0x5f, 0x74, 0x78, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61,
+	0x6c, 0x5f, 0x75, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x16, 0x64, 0x65, 0x73, 0x69,
+	0x72, 0x65, 0x64, 0x4d, 0x69, 0x6e, 0x54, 0x78, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c,
+	0x55, 0x73, 0x12, 0x3c, 0x0a, 0x1b, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x5f, 0x6d,
+	0x69, 0x6e, 0x5f, 0x72, 0x78, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x5f, 0x75,
+	0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x17, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65,
+	0x64, 0x4d, 0x69, 0x6e, 0x52, 0x78, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x55, 0x73,
+	0x12, 0x38, 0x0a, 0x19, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x6d, 0x69, 0x6e, 0x5f, 0x72,
+	0x78, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x5f, 0x75, 0x73, 0x18, 0x0c, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x15, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x4d, 0x69, 0x6e, 0x52, 0x78,
+	0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x55, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x64, 0x65,
+	0x74, 0x65, 0x63, 0x74, 0x5f, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x69, 0x65, 0x72, 0x18,
+	0x0d, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x10, 0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x4d, 0x75, 0x6c,
+	0x74, 0x69, 0x70, 0x6c, 0x69, 0x65, 0x72, 0x12, 0x2a, 0x0a, 0x11, 0x64, 0x65, 0x74, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x75, 0x73, 0x18, 0x0e, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x0f, 0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x6d,
+	0x65, 0x55, 0x73, 0x12, 0x30, 0x0a, 0x14, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x74, 0x72, 0x61, 0x6e,
+	0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x75, 0x6e, 0x69, 0x78, 0x18, 0x0f, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x12, 0x6c, 0x61, 0x73, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x55, 0x6e, 0x69, 0x78, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73,
+	0x18, 0x10, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x22,
+	0x32, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x0d, 0x0a, 0x09, 0x41, 0x64, 0x6d, 0x69,
+	0x6e, 0x44, 0x6f, 0x77, 0x6e, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x44, 0x6f, 0x77, 0x6e, 0x10,
+	0x01, 0x12, 0x08, 0x0a, 0x04, 0x49, 0x6e, 0x69, 0x74, 0x10, 0x02, 0x12, 0x06, 0x0a, 0x02, 0x55,
+	0x70, 0x10, 0x03, 0x32, 0x5b, 0x0a, 0x0a, 0x42, 0x66, 0x64, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x12, 0x4d, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x1c, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x62, 0x66, 0x64, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1d, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x62, 0x66, 0x64, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x42, 0x31, 0x5a, 0x2f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x62,
+	0x69, 0x6f, 0x2d, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2f, 0x62, 0x69, 0x6f, 0x2d, 0x72,
+	0x64, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x73, 0x2f, 0x62, 0x66, 0x64, 0x2f,
+	0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_protocols_bfd_api_bfd_proto_rawDescOnce sync.Once
+	file_protocols_bfd_api_bfd_proto_rawDescData = file_protocols_bfd_api_bfd_proto_rawDesc
+)
+
+func file_protocols_bfd_api_bfd_proto_rawDescGZIP() []byte {
+	file_protocols_bfd_api_bfd_proto_rawDescOnce.Do(func() {
+		file_protocols_bfd_api_bfd_proto_rawDescData = protoimpl.X.CompressGZIP(file_protocols_bfd_api_bfd_proto_rawDescData)
+	})
+	return file_protocols_bfd_api_bfd_proto_rawDescData
+}
+
+var file_protocols_bfd_api_bfd_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_protocols_bfd_api_bfd_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_protocols_bfd_api_bfd_proto_goTypes = []interface{}{
+	(Session_State)(0),           // 0: bio.bfd.Session.State
+	(*ListSessionsRequest)(nil),  // 1: bio.bfd.ListSessionsRequest
+	(*ListSessionsResponse)(nil), // 2: bio.bfd.ListSessionsResponse
+	(*Session)(nil),              // 3: bio.bfd.Session
+	(*api.IP)(nil),               // 4: bio.net.IP
+}
+var file_protocols_bfd_api_bfd_proto_depIdxs = []int32{
+	3, // 0: bio.bfd.ListSessionsResponse.sessions:type_name -> bio.bfd.Session
+	4, // 1: bio.bfd.Session.peer:type_name -> bio.net.IP
+	4, // 2: bio.bfd.Session.local:type_name -> bio.net.IP
+	0, // 3: bio.bfd.Session.state:type_name -> bio.bfd.Session.State
+	0, // 4: bio.bfd.Session.remote_state:type_name -> bio.bfd.Session.State
+	1, // 5: bio.bfd.BfdService.ListSessions:input_type -> bio.bfd.ListSessionsRequest
+	2, // 6: bio.bfd.BfdService.ListSessions:output_type -> bio.bfd.ListSessionsResponse
+	6, // [6:7] is the sub-list for method output_type
+	5, // [5:6] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_protocols_bfd_api_bfd_proto_init() }
+func file_protocols_bfd_api_bfd_proto_init() {
+	if File_protocols_bfd_api_bfd_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_protocols_bfd_api_bfd_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListSessionsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocols_bfd_api_bfd_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListSessionsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocols_bfd_api_bfd_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Session); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_protocols_bfd_api_bfd_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_protocols_bfd_api_bfd_proto_goTypes,
+		DependencyIndexes: file_protocols_bfd_api_bfd_proto_depIdxs,
+		EnumInfos:         file_protocols_bfd_api_bfd_proto_enumTypes,
+		MessageInfos:      file_protocols_bfd_api_bfd_proto_msgTypes,
+	}.Build()
+	File_protocols_bfd_api_bfd_proto = out.File
+	file_protocols_bfd_api_bfd_proto_rawDesc = nil
+	file_protocols_bfd_api_bfd_proto_goTypes = nil
+	file_protocols_bfd_api_bfd_proto_depIdxs = nil
+}
@@ -0,0 +1,101 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package api
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// BfdServiceClient is the client API for BfdService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type BfdServiceClient interface {
+	ListSessions(ctx context.Context, in *ListSessionsRequest, opts ...grpc.CallOption) (*ListSessionsResponse, error)
+}
+
+type bfdServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBfdServiceClient(cc grpc.ClientConnInterface) BfdServiceClient {
+	return &bfdServiceClient{cc}
+}
+
+func (c *bfdServiceClient) ListSessions(ctx context.Context, in *ListSessionsRequest, opts ...grpc.CallOption) (*ListSessionsResponse, error) {
+	out := new(ListSessionsResponse)
+	err := c.cc.Invoke(ctx, "/bio.bfd.BfdService/ListSessions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BfdServiceServer is the server API for BfdService service.
+// All implementations must embed UnimplementedBfdServiceServer
+// for forward compatibility
+type BfdServiceServer interface {
+	ListSessions(context.Context, *ListSessionsRequest) (*ListSessionsResponse, error)
+	mustEmbedUnimplementedBfdServiceServer()
+}
+
+// UnimplementedBfdServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedBfdServiceServer struct {
+}
+
+func (UnimplementedBfdServiceServer) ListSessions(context.Context, *ListSessionsRequest) (*ListSessionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSessions not implemented")
+}
+func (UnimplementedBfdServiceServer) mustEmbedUnimplementedBfdServiceServer() {}
+
+// UnsafeBfdServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BfdServiceServer will
+// result in compilation errors.
+type UnsafeBfdServiceServer interface {
+	mustEmbedUnimplementedBfdServiceServer()
+}
+
+func RegisterBfdServiceServer(s grpc.ServiceRegistrar, srv BfdServiceServer) {
+	s.RegisterService(&BfdService_ServiceDesc, srv)
+}
+
+func _BfdService_ListSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSessionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BfdServiceServer).ListSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bio.bfd.BfdService/ListSessions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BfdServiceServer).ListSessions(ctx, req.(*ListSessionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// BfdService_ServiceDesc is the grpc.ServiceDesc for BfdService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BfdService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "bio.bfd.BfdService",
+	HandlerType: (*BfdServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListSessions",
+			Handler:    _BfdService_ListSessions_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "protocols/bfd/api/bfd.proto",
+}
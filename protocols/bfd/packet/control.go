@@ -0,0 +1,210 @@
+package packet
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/bio-routing/bio-rd/util/decode"
+	"github.com/bio-routing/tflow2/convert"
+)
+
+const (
+	// Version is the BFD protocol version defined in RFC5880
+	Version = 1
+
+	// ControlPacketLen is the length of a control packet without authentication section
+	ControlPacketLen = 24
+
+	// SingleHopPort is the UDP destination port of single hop control packets (RFC5881)
+	SingleHopPort = 3784
+
+	// MultiHopPort is the UDP destination port of multihop control packets (RFC5883)
+	MultiHopPort = 4784
+
+	// SingleHopTTL is the TTL single hop control packets are sent and expected with (RFC5881 Sect. 5)
+	SingleHopTTL = 255
+
+	// Session states (RFC5880 Sect. 4.1)
+	StateAdminDown = 0
+	StateDown      = 1
+	StateInit      = 2
+	StateUp        = 3
+
+	// Diagnostic codes (RFC5880 Sect. 4.1)
+	DiagNone                        = 0
+	DiagControlDetectionExpired     = 1
+	DiagEchoFunctionFailed          = 2
+	DiagNeighborSignaledDown        = 3
+	DiagForwardingPlaneReset        = 4
+	DiagPathDown                    = 5
+	DiagConcatenatedPathDown        = 6
+	DiagAdministrativelyDown        = 7
+	DiagReverseConcatenatedPathDown = 8
+
+	versionShift = 5
+	diagMask     = 0x1f
+	stateShift   = 6
+
+	flagPoll                  = 0x20
+	flagFinal                 = 0x10
+	flagControlPlaneIndep     = 0x08
+	flagAuthenticationPresent = 0x04
+	flagDemand                = 0x02
+	flagMultipoint            = 0x01
+)
+
+// ControlPacket represents a BFD control packet (RFC5880 Sect. 4.1)
+type ControlPacket struct {
+	Diagnostic                uint8
+	State                     uint8
+	Poll                      bool
+	Final                     bool
+	ControlPlaneIndependent   bool
+	AuthenticationPresent     bool
+	Demand                    bool
+	Multipoint                bool
+	DetectMultiplier          uint8
+	MyDiscriminator           uint32
+	YourDiscriminator         uint32
+	DesiredMinTxInterval      uint32 // microseconds
+	RequiredMinRxInterval     uint32 // microseconds
+	RequiredMinEchoRxInterval uint32 // microseconds
+}
+
+// DecodeControlPacket decodes and validates a BFD control packet (RFC5880 Sect. 6.8.6)
+func DecodeControlPacket(buf *bytes.Buffer) (*ControlPacket, error) {
+	payloadLen := buf.Len()
+
+	versionDiag := uint8(0)
+	stateFlags := uint8(0)
+	length := uint8(0)
+	p := &ControlPacket{}
+
+	fields := []interface{}{
+		&versionDiag,
+		&stateFlags,
+		&p.DetectMultiplier,
+		&length,
+		&p.MyDiscriminator,
+		&p.YourDiscriminator,
+		&p.DesiredMinTxInterval,
+		&p.RequiredMinRxInterval,
+		&p.RequiredMinEchoRxInterval,
+	}
+
+	err := decode.Decode(buf, fields)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode fields: %w", err)
+	}
+
+	p.Diagnostic = versionDiag & diagMask
+	p.State = stateFlags >> stateShift
+	p.Poll = stateFlags&flagPoll != 0
+	p.Final = stateFlags&flagFinal != 0
+	p.ControlPlaneIndependent = stateFlags&flagControlPlaneIndep != 0
+	p.AuthenticationPresent = stateFlags&flagAuthenticationPresent != 0
+	p.Demand = stateFlags&flagDemand != 0
+	p.Multipoint = stateFlags&flagMultipoint != 0
+
+	if versionDiag>>versionShift != Version {
+		return nil, fmt.Errorf("unsupported version %d", versionDiag>>versionShift)
+	}
+
+	if length < ControlPacketLen || int(length) > payloadLen {
+		return nil, fmt.Errorf("invalid length %d", length)
+	}
+
+	if p.AuthenticationPresent {
+		return nil, fmt.Errorf("authentication is not supported")
+	}
+
+	if p.DetectMultiplier == 0 {
+		return nil, fmt.Errorf("detect multiplier must not be 0")
+	}
+
+	if p.Multipoint {
+		return nil, fmt.Errorf("multipoint bit must not be set")
+	}
+
+	if p.MyDiscriminator == 0 {
+		return nil, fmt.Errorf("my discriminator must not be 0")
+	}
+
+	if p.YourDiscriminator == 0 && p.State != StateDown && p.State != StateAdminDown {
+		return nil, fmt.Errorf("your discriminator must not be 0 in state %s", StateName(p.State))
+	}
+
+	return p, nil
+}
+
+// Serialize serializes a BFD control packet
+func (p *ControlPacket) Serialize(buf *bytes.Buffer) {
+	stateFlags := p.State << stateShift
+	for _, f := range []struct {
+		set  bool
+		flag uint8
+	}{
+		{p.Poll, flagPoll},
+		{p.Final, flagFinal},
+		{p.ControlPlaneIndependent, flagControlPlaneIndep},
+		{p.AuthenticationPresent, flagAuthenticationPresent},
+		{p.Demand, flagDemand},
+		{p.Multipoint, flagMultipoint},
+	} {
+		if f.set {
+			stateFlags |= f.flag
+		}
+	}
+
+	buf.WriteByte(Version<<versionShift | p.Diagnostic&diagMask)
+	buf.WriteByte(stateFlags)
+	buf.WriteByte(p.DetectMultiplier)
+	buf.WriteByte(ControlPacketLen)
+	buf.Write(convert.Uint32Byte(p.MyDiscriminator))
+	buf.Write(convert.Uint32Byte(p.YourDiscriminator))
+	buf.Write(convert.Uint32Byte(p.DesiredMinTxInterval))
+	buf.Write(convert.Uint32Byte(p.RequiredMinRxInterval))
+	buf.Write(convert.Uint32Byte(p.RequiredMinEchoRxInterval))
+}
+
+// StateName returns the name of a session state
+func StateName(state uint8) string {
+	switch state {
+	case StateAdminDown:
+		return "AdminDown"
+	case StateDown:
+		return "Down"
+	case StateInit:
+		return "Init"
+	case StateUp:
+		return "Up"
+	default:
+		return "Unknown"
+	}
+}
+
+// DiagnosticName returns the name of a diagnostic code
+func DiagnosticName(diag uint8) string {
+	switch diag {
+	case DiagNone:
+		return "No Diagnostic"
+	case DiagControlDetectionExpired:
+		return "Control Detection Time Expired"
+	case DiagEchoFunctionFailed:
+		return "Echo Function Failed"
+	case DiagNeighborSignaledDown:
+		return "Neighbor Signaled Session Down"
+	case DiagForwardingPlaneReset:
+		return "Forwarding Plane Reset"
+	case DiagPathDown:
+		return "Path Down"
+	case DiagConcatenatedPathDown:
+		return "Concatenated Path Down"
+	case DiagAdministrativelyDown:
+		return "Administratively Down"
+	case DiagReverseConcatenatedPathDown:
+		return "Reverse Concatenated Path Down"
+	default:
+		return "Unknown"
+	}
+}
@@ -0,0 +1,212 @@
+package packet
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeControlPacket(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []byte
+		wantFail bool
+		expected *ControlPacket
+	}{
+		{
+			name: "Down without remote discriminator",
+			input: []byte{
+				0x20,       // Version 1, no diagnostic
+				0x40,       // State Down
+				3,          // Detect Multiplier
+				24,         // Length
+				0, 0, 0, 1, // My Discriminator
+				0, 0, 0, 0, // Your Discriminator
+				0, 0x0f, 0x42, 0x40, // Desired Min TX
+				0, 0x04, 0x93, 0xe0, // Required Min RX
+				0, 0, 0, 0, // Required Min Echo RX
+			},
+			expected: &ControlPacket{
+				State:                 StateDown,
+				DetectMultiplier:      3,
+				MyDiscriminator:       1,
+				DesiredMinTxInterval:  1000000,
+				RequiredMinRxInterval: 300000,
+			},
+		},
+		{
+			name: "Up with poll and diagnostic",
+			input: []byte{
+				0x21,
+				0xe0, // State Up, Poll
+				5,
+				24,
+				0, 0, 0, 1,
+				0, 0, 0, 2,
+				0, 0x04, 0x93, 0xe0,
+				0, 0x04, 0x93, 0xe0,
+				0, 0, 0, 0,
+			},
+			expected: &ControlPacket{
+				Diagnostic:            DiagControlDetectionExpired,
+				State:                 StateUp,
+				Poll:                  true,
+				DetectMultiplier:      5,
+				MyDiscriminator:       1,
+				YourDiscriminator:     2,
+				DesiredMinTxInterval:  300000,
+				RequiredMinRxInterval: 300000,
+			},
+		},
+		{
+			name: "Incomplete",
+			input: []byte{
+				0x20, 0x40, 3, 24,
+				0, 0, 0, 1,
+			},
+			wantFail: true,
+		},
+		{
+			name: "Wrong version",
+			input: []byte{
+				0x40, 0x40, 3, 24,
+				0, 0, 0, 1,
+				0, 0, 0, 0,
+				0, 0, 0, 0,
+				0, 0, 0, 0,
+				0, 0, 0, 0,
+			},
+			wantFail: true,
+		},
+		{
+			name: "Length exceeds payload",
+			input: []byte{
+				0x20, 0x40, 3, 26,
+				0, 0, 0, 1,
+				0, 0, 0, 0,
+				0, 0, 0, 0,
+				0, 0, 0, 0,
+				0, 0, 0, 0,
+			},
+			wantFail: true,
+		},
+		{
+			name: "Detect multiplier 0",
+			input: []byte{
+				0x20, 0x40, 0, 24,
+				0, 0, 0, 1,
+				0, 0, 0, 0,
+				0, 0, 0, 0,
+				0, 0, 0, 0,
+				0, 0, 0, 0,
+			},
+			wantFail: true,
+		},
+		{
+			name: "Multipoint",
+			input: []byte{
+				0x20, 0x41, 3, 24,
+				0, 0, 0, 1,
+				0, 0, 0, 0,
+				0, 0, 0, 0,
+				0, 0, 0, 0,
+				0, 0, 0, 0,
+			},
+			wantFail: true,
+		},
+		{
+			name: "Authentication present",
+			input: []byte{
+				0x20, 0x44, 3, 24,
+				0, 0, 0, 1,
+				0, 0, 0, 0,
+				0, 0, 0, 0,
+				0, 0, 0, 0,
+				0, 0, 0, 0,
+			},
+			wantFail: true,
+		},
+		{
+			name: "My discriminator 0",
+			input: []byte{
+				0x20, 0x40, 3, 24,
+				0, 0, 0, 0,
+				0, 0, 0, 0,
+				0, 0, 0, 0,
+				0, 0, 0, 0,
+				0, 0, 0, 0,
+			},
+			wantFail: true,
+		},
+		{
+			name: "Your discriminator 0 in state Up",
+			input: []byte{
+				0x20, 0xc0, 3, 24,
+				0, 0, 0, 1,
+				0, 0, 0, 0,
+				0, 0, 0, 0,
+				0, 0, 0, 0,
+				0, 0, 0, 0,
+			},
+			wantFail: true,
+		},
+	}
+
+	for _, test := range tests {
+		p, err := DecodeControlPacket(bytes.NewBuffer(test.input))
+		if test.wantFail {
+			assert.Error(t, err, test.name)
+			continue
+		}
+
+		if !assert.NoError(t, err, test.name) {
+			continue
+		}
+
+		assert.Equal(t, test.expected, p, test.name)
+	}
+}
+
+func TestControlPacketSerialize(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *ControlPacket
+		expected []byte
+	}{
+		{
+			name: "Init with final",
+			input: &ControlPacket{
+				Diagnostic:            DiagNeighborSignaledDown,
+				State:                 StateInit,
+				Final:                 true,
+				DetectMultiplier:      3,
+				MyDiscriminator:       0x01020304,
+				YourDiscriminator:     0x05060708,
+				DesiredMinTxInterval:  300000,
+				RequiredMinRxInterval: 300000,
+			},
+			expected: []byte{
+				0x23,
+				0x90,
+				3,
+				24,
+				1, 2, 3, 4,
+				5, 6, 7, 8,
+				0, 0x04, 0x93, 0xe0,
+				0, 0x04, 0x93, 0xe0,
+				0, 0, 0, 0,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		buf := bytes.NewBuffer(nil)
+		test.input.Serialize(buf)
+		assert.Equal(t, test.expected, buf.Bytes(), test.name)
+
+		p, err := DecodeControlPacket(buf)
+		assert.NoError(t, err, test.name)
+		assert.Equal(t, test.input, p, test.name)
+	}
+}
@@ -0,0 +1,54 @@
+package server
+
+import (
+	"context"
+
+	"github.com/bio-routing/bio-rd/protocols/bfd/api"
+)
+
+type BFDAPIServer struct {
+	api.UnimplementedBfdServiceServer
+	srv SessionManager
+}
+
+// NewBFDAPIServer creates a new BFD API Server
+func NewBFDAPIServer(s SessionManager) *BFDAPIServer {
+	return &BFDAPIServer{
+		srv: s,
+	}
+}
+
+// ListSessions lists all BFD sessions
+func (s *BFDAPIServer) ListSessions(context.Context, *api.ListSessionsRequest) (*api.ListSessionsResponse, error) {
+	res := &api.ListSessionsResponse{
+		Sessions: make([]*api.Session, 0),
+	}
+
+	for _, x := range s.srv.GetSessions() {
+		sess := &api.Session{
+			Peer:                    x.Peer.ToProto(),
+			InterfaceName:           x.Interface,
+			Multihop:                x.Multihop,
+			State:                   api.Session_State(x.State),
+			RemoteState:             api.Session_State(x.RemoteState),
+			Diagnostic:              uint32(x.Diagnostic),
+			LocalDiscriminator:      x.LocalDiscriminator,
+			RemoteDiscriminator:     x.RemoteDiscriminator,
+			DesiredMinTxIntervalUs:  microseconds(x.DesiredMinTxInterval),
+			RequiredMinRxIntervalUs: microseconds(x.RequiredMinRxInterval),
+			RemoteMinRxIntervalUs:   microseconds(x.RemoteMinRxInterval),
+			DetectMultiplier:        uint32(x.DetectMultiplier),
+			DetectionTimeUs:         microseconds(x.DetectionTime),
+			LastTransitionUnix:      x.LastStateChange.Unix(),
+			Clients:                 uint32(x.Clients),
+		}
+
+		if x.Local != nil {
+			sess.Local = x.Local.ToProto()
+		}
+
+		res.Sessions = append(res.Sessions, sess)
+	}
+
+	return res, nil
+}
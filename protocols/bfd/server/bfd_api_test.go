@@ -0,0 +1,95 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bfd/api"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+type mockSessionManager struct {
+	sessions []*SessionInfo
+}
+
+func (m *mockSessionManager) Subscribe(Client, SessionConfig) error {
+	return nil
+}
+
+func (m *mockSessionManager) Unsubscribe(Client, SessionConfig) {}
+
+func (m *mockSessionManager) GetSessions() []*SessionInfo {
+	return m.sessions
+}
+
+func TestListSessions(t *testing.T) {
+	m := &mockSessionManager{
+		sessions: []*SessionInfo{
+			{
+				Peer:                  bnet.IPv4FromOctets(192, 0, 2, 1),
+				Local:                 bnet.IPv4FromOctets(192, 0, 2, 0).Ptr(),
+				Interface:             "eth0",
+				State:                 3,
+				RemoteState:           3,
+				LocalDiscriminator:    100,
+				RemoteDiscriminator:   200,
+				DesiredMinTxInterval:  300 * time.Millisecond,
+				RequiredMinRxInterval: 300 * time.Millisecond,
+				RemoteMinRxInterval:   100 * time.Millisecond,
+				DetectMultiplier:      3,
+				DetectionTime:         900 * time.Millisecond,
+				LastStateChange:       time.Unix(1700000000, 0),
+				Clients:               2,
+			},
+			{
+				Peer:             bnet.IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 1),
+				Multihop:         true,
+				State:            1,
+				RemoteState:      1,
+				Diagnostic:       1,
+				DetectMultiplier: 5,
+				LastStateChange:  time.Unix(1700000000, 0),
+				Clients:          1,
+			},
+		},
+	}
+
+	res, err := NewBFDAPIServer(m).ListSessions(context.Background(), &api.ListSessionsRequest{})
+	assert.NoError(t, err)
+
+	expected := &api.ListSessionsResponse{
+		Sessions: []*api.Session{
+			{
+				Peer:                    bnet.IPv4FromOctets(192, 0, 2, 1).ToProto(),
+				Local:                   bnet.IPv4FromOctets(192, 0, 2, 0).ToProto(),
+				InterfaceName:           "eth0",
+				State:                   api.Session_Up,
+				RemoteState:             api.Session_Up,
+				LocalDiscriminator:      100,
+				RemoteDiscriminator:     200,
+				DesiredMinTxIntervalUs:  300000,
+				RequiredMinRxIntervalUs: 300000,
+				RemoteMinRxIntervalUs:   100000,
+				DetectMultiplier:        3,
+				DetectionTimeUs:         900000,
+				LastTransitionUnix:      1700000000,
+				Clients:                 2,
+			},
+			{
+				Peer:               bnet.IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 1).ToProto(),
+				Multihop:           true,
+				State:              api.Session_Down,
+				RemoteState:        api.Session_Down,
+				Diagnostic:         1,
+				DetectMultiplier:   5,
+				LastTransitionUnix: 1700000000,
+				Clients:            1,
+			},
+		},
+	}
+
+	assert.True(t, proto.Equal(expected, res))
+}
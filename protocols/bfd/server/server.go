@@ -0,0 +1,368 @@
+package server
+
+import (
+	"fmt"
+	"math/rand"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bfd/packet"
+	"github.com/bio-routing/bio-rd/util/log"
+)
+
+const (
+	// DefaultMinInterval is the default desired min TX and required min RX interval
+	DefaultMinInterval = 300 * time.Millisecond
+
+	// DefaultDetectMultiplier is the default detection time multiplier
+	DefaultDetectMultiplier = 3
+)
+
+// SessionManager is the interface of the BFD session manager protocols subscribe to
+type SessionManager interface {
+	Subscribe(Client, SessionConfig) error
+	Unsubscribe(Client, SessionConfig)
+	GetSessions() []*SessionInfo
+}
+
+// Client is notified about state changes of the BFD sessions it subscribed to
+type Client interface {
+	// BFDStateChange is called when a session goes up or fails. A session taken down administratively by the peer is not reported as failed (RFC5882 Sect. 3.2).
+	BFDStateChange(peer bnet.IP, up bool)
+}
+
+// SessionConfig describes a BFD session
+type SessionConfig struct {
+	// Peer is the address of the remote system
+	Peer bnet.IP
+
+	// Local is the source address of control packets. If nil the kernel picks the source address.
+	Local *bnet.IP
+
+	// Interface binds single hop sessions to an interface
+	Interface string
+
+	// Multihop selects multihop (RFC5883) instead of single hop (RFC5881) encapsulation
+	Multihop bool
+
+	// DesiredMinTxInterval is the minimum interval we want to send control packets at
+	DesiredMinTxInterval time.Duration
+
+	// RequiredMinRxInterval is the minimum interval between received control packets we support
+	RequiredMinRxInterval time.Duration
+
+	// DetectMultiplier is the number of missed control packets after which the session is declared down
+	DetectMultiplier uint8
+}
+
+type sessionKey struct {
+	peer     bnet.IP
+	iface    string
+	multihop bool
+}
+
+func (c *SessionConfig) key() sessionKey {
+	return sessionKey{
+		peer:     c.Peer,
+		iface:    c.Interface,
+		multihop: c.Multihop,
+	}
+}
+
+func (c *SessionConfig) applyDefaults() {
+	if c.DesiredMinTxInterval == 0 {
+		c.DesiredMinTxInterval = DefaultMinInterval
+	}
+
+	if c.RequiredMinRxInterval == 0 {
+		c.RequiredMinRxInterval = DefaultMinInterval
+	}
+
+	if c.DetectMultiplier == 0 {
+		c.DetectMultiplier = DefaultDetectMultiplier
+	}
+}
+
+// ServerConfig is the configuration of the BFD server
+type ServerConfig struct {
+	// ListenAddrs are the addresses control packets are received on. Defaults to 0.0.0.0 and ::.
+	ListenAddrs []bnet.IP
+
+	// SingleHopPort overrides the single hop UDP port (for testing)
+	SingleHopPort uint16
+
+	// MultiHopPort overrides the multihop UDP port (for testing)
+	MultiHopPort uint16
+}
+
+// Server is a BFD session manager
+type Server struct {
+	cfg         ServerConfig
+	listeners   []*listener
+	sessions    map[sessionKey]*session
+	byDiscr     map[uint32]*session
+	sessionsMu  sync.RWMutex
+	nextSrcPort uint16
+	wg          sync.WaitGroup
+}
+
+// New creates a new BFD server
+func New(cfg ServerConfig) *Server {
+	if len(cfg.ListenAddrs) == 0 {
+		cfg.ListenAddrs = []bnet.IP{
+			bnet.IPv4(0),
+			bnet.IPv6(0, 0),
+		}
+	}
+
+	if cfg.SingleHopPort == 0 {
+		cfg.SingleHopPort = packet.SingleHopPort
+	}
+
+	if cfg.MultiHopPort == 0 {
+		cfg.MultiHopPort = packet.MultiHopPort
+	}
+
+	return &Server{
+		cfg:         cfg,
+		sessions:    make(map[sessionKey]*session),
+		byDiscr:     make(map[uint32]*session),
+		nextSrcPort: minSourcePort,
+	}
+}
+
+// Start starts receiving control packets
+func (s *Server) Start() error {
+	for _, addr := range s.cfg.ListenAddrs {
+		for _, multihop := range []bool{false, true} {
+			port := s.cfg.SingleHopPort
+			if multihop {
+				port = s.cfg.MultiHopPort
+			}
+
+			l, err := listen(s, addr, port, multihop)
+			if err != nil {
+				s.Stop()
+				return fmt.Errorf("unable to listen on %s port %d: %w", addr.String(), port, err)
+			}
+
+			s.listeners = append(s.listeners, l)
+			s.wg.Add(1)
+			go l.serve()
+		}
+	}
+
+	return nil
+}
+
+// Stop stops all listeners and sessions
+func (s *Server) Stop() {
+	for _, l := range s.listeners {
+		l.close()
+	}
+
+	s.sessionsMu.Lock()
+	for k, sess := range s.sessions {
+		s.removeSession(k, sess)
+	}
+	s.sessionsMu.Unlock()
+
+	s.wg.Wait()
+}
+
+// Subscribe subscribes a client to the session described by cfg. The session is created if it does not exist yet.
+// Timers of an existing session are not changed by further subscriptions.
+func (s *Server) Subscribe(c Client, cfg SessionConfig) error {
+	cfg.applyDefaults()
+	k := cfg.key()
+
+	s.sessionsMu.Lock()
+	defer s.sessionsMu.Unlock()
+
+	if sess, found := s.sessions[k]; found {
+		sess.clients = append(sess.clients, c)
+		return nil
+	}
+
+	sess, err := s.newSession(cfg)
+	if err != nil {
+		return fmt.Errorf("unable to create session to %s: %w", cfg.Peer.String(), err)
+	}
+
+	sess.clients = append(sess.clients, c)
+	s.sessions[k] = sess
+	s.byDiscr[sess.localDiscr] = sess
+
+	s.wg.Add(1)
+	go sess.run()
+
+	return nil
+}
+
+// Unsubscribe removes a client from a session. The session is torn down when its last client unsubscribed.
+func (s *Server) Unsubscribe(c Client, cfg SessionConfig) {
+	k := cfg.key()
+
+	s.sessionsMu.Lock()
+	defer s.sessionsMu.Unlock()
+
+	sess, found := s.sessions[k]
+	if !found {
+		return
+	}
+
+	for i, x := range sess.clients {
+		if x == c {
+			sess.clients = append(sess.clients[:i], sess.clients[i+1:]...)
+			break
+		}
+	}
+
+	if len(sess.clients) == 0 {
+		s.removeSession(k, sess)
+	}
+}
+
+// removeSession must be called with sessionsMu held
+func (s *Server) removeSession(k sessionKey, sess *session) {
+	delete(s.sessions, k)
+	delete(s.byDiscr, sess.localDiscr)
+	close(sess.done)
+}
+
+// newSession must be called with sessionsMu held
+func (s *Server) newSession(cfg SessionConfig) (*session, error) {
+	conn, err := s.dial(&cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return newSession(s, cfg, conn, s.newDiscriminator()), nil
+}
+
+// dial binds the socket a session sends its control packets from. Source ports are taken from the range required by RFC5881 Sect. 4.
+func (s *Server) dial(cfg *SessionConfig) (*net.UDPConn, error) {
+	var err error
+	for i := 0; i <= maxSourcePort-minSourcePort; i++ {
+		port := s.nextSrcPort
+		s.nextSrcPort++
+		if s.nextSrcPort == 0 || s.nextSrcPort > maxSourcePort {
+			s.nextSrcPort = minSourcePort
+		}
+
+		var conn *net.UDPConn
+		conn, err = dial(cfg, port)
+		if err == nil {
+			return conn, nil
+		}
+	}
+
+	return nil, err
+}
+
+// newDiscriminator must be called with sessionsMu held
+func (s *Server) newDiscriminator() uint32 {
+	for {
+		d := rand.Uint32()
+		if d == 0 {
+			continue
+		}
+
+		if _, found := s.byDiscr[d]; !found {
+			return d
+		}
+	}
+}
+
+// receive demultiplexes a received control packet to its session (RFC5880 Sect. 6.3)
+func (s *Server) receive(p *packet.ControlPacket, src bnet.IP, ttl int, multihop bool) {
+	if !multihop && ttl != packet.SingleHopTTL {
+		log.WithFields(log.Fields{
+			"protocol": "BFD",
+			"peer":     src.String(),
+			"ttl":      ttl,
+		}).Debug("Dropping single hop control packet with TTL != 255")
+		return
+	}
+
+	sess := s.lookupSession(p, src, multihop)
+	if sess == nil {
+		return
+	}
+
+	select {
+	case sess.rxCh <- p:
+	default:
+	}
+}
+
+func (s *Server) lookupSession(p *packet.ControlPacket, src bnet.IP, multihop bool) *session {
+	s.sessionsMu.RLock()
+	defer s.sessionsMu.RUnlock()
+
+	if p.YourDiscriminator != 0 {
+		sess := s.byDiscr[p.YourDiscriminator]
+		if sess == nil || sess.cfg.Multihop != multihop || !sess.cfg.Peer.Equal(src) {
+			return nil
+		}
+
+		return sess
+	}
+
+	for k, sess := range s.sessions {
+		if k.multihop == multihop && k.peer.Equal(src) {
+			return sess
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) clients(sess *session) []Client {
+	s.sessionsMu.RLock()
+	defer s.sessionsMu.RUnlock()
+
+	return append([]Client(nil), sess.clients...)
+}
+
+// SessionInfo describes the state of a session
+type SessionInfo struct {
+	Peer                  bnet.IP
+	Local                 *bnet.IP
+	Interface             string
+	Multihop              bool
+	State                 uint8
+	RemoteState           uint8
+	Diagnostic            uint8
+	LocalDiscriminator    uint32
+	RemoteDiscriminator   uint32
+	DesiredMinTxInterval  time.Duration
+	RequiredMinRxInterval time.Duration
+	RemoteMinRxInterval   time.Duration
+	DetectMultiplier      uint8
+	DetectionTime         time.Duration
+	LastStateChange       time.Time
+	Clients               int
+}
+
+// GetSessions returns all sessions
+func (s *Server) GetSessions() []*SessionInfo {
+	s.sessionsMu.RLock()
+	defer s.sessionsMu.RUnlock()
+
+	ret := make([]*SessionInfo, 0, len(s.sessions))
+	for _, sess := range s.sessions {
+		info := sess.info()
+		info.Clients = len(sess.clients)
+		ret = append(ret, info)
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Peer.Compare(&ret[j].Peer) < 0
+	})
+
+	return ret
+}
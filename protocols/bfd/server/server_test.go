@@ -0,0 +1,327 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bfd/packet"
+	"github.com/stretchr/testify/assert"
+)
+
+const testPort = 13784
+
+type testClient struct {
+	updates chan bool
+}
+
+func newTestClient() *testClient {
+	return &testClient{
+		updates: make(chan bool, 16),
+	}
+}
+
+func (c *testClient) BFDStateChange(peer bnet.IP, up bool) {
+	c.updates <- up
+}
+
+func (c *testClient) expect(t *testing.T, up bool) {
+	select {
+	case x := <-c.updates:
+		assert.Equal(t, up, x)
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Timeout waiting for session to change state (up=%v)", up)
+	}
+}
+
+func startTestServer(t *testing.T, addr bnet.IP) *Server {
+	s := New(ServerConfig{
+		ListenAddrs:   []bnet.IP{addr},
+		SingleHopPort: testPort,
+		MultiHopPort:  testPort + 1000,
+	})
+
+	err := s.Start()
+	if err != nil {
+		t.Skipf("Unable to listen on loopback: %v", err)
+	}
+
+	return s
+}
+
+func testSessionConfig(local, peer bnet.IP, multihop bool) SessionConfig {
+	return SessionConfig{
+		Peer:                  peer,
+		Local:                 local.Ptr(),
+		Multihop:              multihop,
+		DesiredMinTxInterval:  20 * time.Millisecond,
+		RequiredMinRxInterval: 20 * time.Millisecond,
+		DetectMultiplier:      3,
+	}
+}
+
+func TestSessionLoopback(t *testing.T) {
+	addrA := bnet.IPv4FromOctets(127, 0, 0, 1)
+	addrB := bnet.IPv4FromOctets(127, 0, 0, 2)
+
+	for _, multihop := range []bool{false, true} {
+		a := startTestServer(t, addrA)
+		b := startTestServer(t, addrB)
+
+		clientA := newTestClient()
+		clientB := newTestClient()
+		cfgA := testSessionConfig(addrA, addrB, multihop)
+		cfgB := testSessionConfig(addrB, addrA, multihop)
+
+		assert.NoError(t, a.Subscribe(clientA, cfgA))
+		assert.NoError(t, b.Subscribe(clientB, cfgB))
+
+		clientA.expect(t, true)
+		clientB.expect(t, true)
+
+		// Timers are switched to the configured intervals by a poll sequence after the session came up
+		assert.Eventually(t, func() bool {
+			s := a.GetSessions()[0]
+			return s.RemoteState == packet.StateUp && s.DetectionTime == 60*time.Millisecond
+		}, 5*time.Second, 10*time.Millisecond)
+
+		sessions := a.GetSessions()
+		if assert.Len(t, sessions, 1) {
+			assert.Equal(t, uint8(packet.StateUp), sessions[0].State)
+			assert.Equal(t, multihop, sessions[0].Multihop)
+			assert.Equal(t, 1, sessions[0].Clients)
+		}
+
+		// Peer stops receiving: both sides detect the failure
+		for _, l := range b.listeners {
+			l.close()
+		}
+
+		clientA.expect(t, false)
+		clientB.expect(t, false)
+
+		a.Stop()
+		b.Stop()
+	}
+}
+
+func TestSessionAdminDown(t *testing.T) {
+	addrA := bnet.IPv4FromOctets(127, 0, 0, 1)
+	addrB := bnet.IPv4FromOctets(127, 0, 0, 2)
+
+	a := startTestServer(t, addrA)
+	defer a.Stop()
+	b := startTestServer(t, addrB)
+	defer b.Stop()
+
+	clientA := newTestClient()
+	clientB := newTestClient()
+	cfgA := testSessionConfig(addrA, addrB, false)
+	cfgB := testSessionConfig(addrB, addrA, false)
+
+	assert.NoError(t, a.Subscribe(clientA, cfgA))
+	assert.NoError(t, b.Subscribe(clientB, cfgB))
+
+	clientA.expect(t, true)
+	clientB.expect(t, true)
+
+	// Removing the last client takes the session down administratively which must not be reported as a failure
+	b.Unsubscribe(clientB, cfgB)
+	assert.Len(t, b.GetSessions(), 0)
+
+	assert.Eventually(t, func() bool {
+		return a.GetSessions()[0].State == packet.StateDown
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, uint8(packet.DiagNeighborSignaledDown), a.GetSessions()[0].Diagnostic)
+	assert.Len(t, clientA.updates, 0)
+}
+
+func TestSubscribeRefcount(t *testing.T) {
+	s := New(ServerConfig{})
+	defer s.Stop()
+
+	cfg := SessionConfig{
+		Peer:  bnet.IPv4FromOctets(127, 0, 0, 2),
+		Local: bnet.IPv4FromOctets(127, 0, 0, 1).Ptr(),
+	}
+
+	c1 := newTestClient()
+	c2 := newTestClient()
+	assert.NoError(t, s.Subscribe(c1, cfg))
+	assert.NoError(t, s.Subscribe(c2, cfg))
+
+	sessions := s.GetSessions()
+	if assert.Len(t, sessions, 1) {
+		assert.Equal(t, 2, sessions[0].Clients)
+		assert.Equal(t, DefaultMinInterval, sessions[0].DesiredMinTxInterval)
+		assert.Equal(t, uint8(DefaultDetectMultiplier), sessions[0].DetectMultiplier)
+	}
+
+	s.Unsubscribe(c1, cfg)
+	assert.Len(t, s.GetSessions(), 1)
+
+	s.Unsubscribe(c2, cfg)
+	assert.Len(t, s.GetSessions(), 0)
+}
+
+func TestSessionReceive(t *testing.T) {
+	tests := []struct {
+		name          string
+		state         uint8
+		remoteState   uint8
+		expectedState uint8
+		expectedDiag  uint8
+		expectedUp    *bool
+	}{
+		{
+			name:          "Down -> Init",
+			state:         packet.StateDown,
+			remoteState:   packet.StateDown,
+			expectedState: packet.StateInit,
+		},
+		{
+			name:          "Down -> Up",
+			state:         packet.StateDown,
+			remoteState:   packet.StateInit,
+			expectedState: packet.StateUp,
+			expectedUp:    boolPtr(true),
+		},
+		{
+			name:          "Down ignores Up",
+			state:         packet.StateDown,
+			remoteState:   packet.StateUp,
+			expectedState: packet.StateDown,
+		},
+		{
+			name:          "Init -> Up",
+			state:         packet.StateInit,
+			remoteState:   packet.StateUp,
+			expectedState: packet.StateUp,
+			expectedUp:    boolPtr(true),
+		},
+		{
+			name:          "Up -> Down",
+			state:         packet.StateUp,
+			remoteState:   packet.StateDown,
+			expectedState: packet.StateDown,
+			expectedDiag:  packet.DiagNeighborSignaledDown,
+			expectedUp:    boolPtr(false),
+		},
+		{
+			name:          "Up -> Down by remote AdminDown",
+			state:         packet.StateUp,
+			remoteState:   packet.StateAdminDown,
+			expectedState: packet.StateDown,
+			expectedDiag:  packet.DiagNeighborSignaledDown,
+		},
+		{
+			name:          "AdminDown ignores packets",
+			state:         packet.StateAdminDown,
+			remoteState:   packet.StateInit,
+			expectedState: packet.StateAdminDown,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			srv := New(ServerConfig{})
+			cfg := SessionConfig{
+				Peer:  bnet.IPv4FromOctets(127, 0, 0, 2),
+				Local: bnet.IPv4FromOctets(127, 0, 0, 1).Ptr(),
+			}
+			cfg.applyDefaults()
+
+			conn, err := srv.dial(&cfg)
+			if err != nil {
+				t.Skipf("Unable to bind to loopback: %v", err)
+			}
+			defer conn.Close()
+
+			c := newTestClient()
+			sess := newSession(srv, cfg, conn, 100)
+			sess.clients = []Client{c}
+			sess.state = test.state
+
+			sess.receive(&packet.ControlPacket{
+				State:                 test.remoteState,
+				DetectMultiplier:      3,
+				MyDiscriminator:       200,
+				DesiredMinTxInterval:  1000000,
+				RequiredMinRxInterval: 1000000,
+			})
+
+			assert.Equal(t, test.expectedState, sess.state)
+			assert.Equal(t, test.expectedDiag, sess.localDiag)
+			assert.Equal(t, uint32(200), sess.remoteDiscr)
+			assert.Equal(t, 3*time.Second, sess.detectionTime())
+
+			if test.expectedUp == nil {
+				assert.Len(t, c.updates, 0)
+				return
+			}
+
+			c.expect(t, *test.expectedUp)
+		})
+	}
+}
+
+func TestTxInterval(t *testing.T) {
+	tests := []struct {
+		name        string
+		state       uint8
+		desiredTx   time.Duration
+		remoteMinRx uint32
+		detectMult  uint8
+		min         time.Duration
+		max         time.Duration
+	}{
+		{
+			name:        "Slow while down",
+			state:       packet.StateDown,
+			desiredTx:   50 * time.Millisecond,
+			remoteMinRx: 1,
+			detectMult:  3,
+			min:         750 * time.Millisecond,
+			max:         time.Second,
+		},
+		{
+			name:        "Desired interval when up",
+			state:       packet.StateUp,
+			desiredTx:   100 * time.Millisecond,
+			remoteMinRx: 50000,
+			detectMult:  3,
+			min:         75 * time.Millisecond,
+			max:         100 * time.Millisecond,
+		},
+		{
+			name:        "Remote required min RX when up",
+			state:       packet.StateUp,
+			desiredTx:   100 * time.Millisecond,
+			remoteMinRx: 200000,
+			detectMult:  1,
+			min:         150 * time.Millisecond,
+			max:         180 * time.Millisecond,
+		},
+	}
+
+	for _, test := range tests {
+		s := &session{
+			cfg: SessionConfig{
+				DesiredMinTxInterval: test.desiredTx,
+				DetectMultiplier:     test.detectMult,
+			},
+			state:               test.state,
+			remoteMinRxInterval: test.remoteMinRx,
+		}
+
+		for i := 0; i < 100; i++ {
+			d := s.txInterval()
+			assert.GreaterOrEqual(t, d, test.min, test.name)
+			assert.LessOrEqual(t, d, test.max, test.name)
+		}
+	}
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
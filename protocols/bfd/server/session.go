@@ -0,0 +1,300 @@
+package server
+
+import (
+	"bytes"
+	"math/rand"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/bio-routing/bio-rd/protocols/bfd/packet"
+	"github.com/bio-routing/bio-rd/util/log"
+)
+
+const (
+	// slowTxInterval is the minimum TX interval while a session is not up (RFC5880 Sect. 6.8.3)
+	slowTxInterval = time.Second
+
+	rxQueueLen = 16
+)
+
+// session implements the asynchronous mode state machine of RFC5880 Sect. 6.8
+type session struct {
+	srv     *Server
+	cfg     SessionConfig
+	conn    *net.UDPConn
+	dst     *net.UDPAddr
+	clients []Client // protected by srv.sessionsMu
+	rxCh    chan *packet.ControlPacket
+	done    chan struct{}
+
+	mu                         sync.RWMutex
+	state                      uint8
+	remoteState                uint8
+	localDiscr                 uint32
+	remoteDiscr                uint32
+	localDiag                  uint8
+	remoteMinRxInterval        uint32 // microseconds
+	remoteDesiredMinTxInterval uint32 // microseconds
+	remoteDetectMult           uint8
+	poll                       bool
+	lastStateChange            time.Time
+}
+
+func newSession(srv *Server, cfg SessionConfig, conn *net.UDPConn, localDiscr uint32) *session {
+	return &session{
+		srv:                 srv,
+		cfg:                 cfg,
+		conn:                conn,
+		dst:                 srv.remoteAddr(&cfg),
+		rxCh:                make(chan *packet.ControlPacket, rxQueueLen),
+		done:                make(chan struct{}),
+		state:               packet.StateDown,
+		remoteState:         packet.StateDown,
+		localDiscr:          localDiscr,
+		remoteMinRxInterval: 1,
+		lastStateChange:     time.Now(),
+	}
+}
+
+func (s *session) fields() log.Fields {
+	return log.Fields{
+		"protocol":  "BFD",
+		"peer":      s.cfg.Peer.String(),
+		"interface": s.cfg.Interface,
+		"multihop":  s.cfg.Multihop,
+	}
+}
+
+func (s *session) run() {
+	defer s.srv.wg.Done()
+	defer s.conn.Close()
+
+	txTimer := time.NewTimer(s.txInterval())
+	defer txTimer.Stop()
+
+	detectTimer := time.NewTimer(time.Hour)
+	detectTimer.Stop()
+	defer detectTimer.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			s.adminDown()
+			return
+		case p := <-s.rxCh:
+			s.receive(p)
+			if d := s.detectionTime(); d != 0 {
+				detectTimer.Reset(d)
+			}
+		case <-txTimer.C:
+			s.transmit(false)
+			txTimer.Reset(s.txInterval())
+		case <-detectTimer.C:
+			s.detectionTimeExpired()
+		}
+	}
+}
+
+// receive processes a control packet (RFC5880 Sect. 6.8.6)
+func (s *session) receive(p *packet.ControlPacket) {
+	s.mu.Lock()
+	s.remoteDiscr = p.MyDiscriminator
+	s.remoteState = p.State
+	s.remoteMinRxInterval = p.RequiredMinRxInterval
+	s.remoteDesiredMinTxInterval = p.DesiredMinTxInterval
+	s.remoteDetectMult = p.DetectMultiplier
+	if p.Final {
+		s.poll = false
+	}
+
+	if s.state == packet.StateAdminDown {
+		s.mu.Unlock()
+		return
+	}
+
+	oldState := s.state
+	switch {
+	case p.State == packet.StateAdminDown:
+		if s.state != packet.StateDown {
+			s.setState(packet.StateDown, packet.DiagNeighborSignaledDown)
+		}
+	case s.state == packet.StateDown:
+		if p.State == packet.StateDown {
+			s.setState(packet.StateInit, packet.DiagNone)
+		} else if p.State == packet.StateInit {
+			s.setState(packet.StateUp, packet.DiagNone)
+		}
+	case s.state == packet.StateInit:
+		if p.State == packet.StateInit || p.State == packet.StateUp {
+			s.setState(packet.StateUp, packet.DiagNone)
+		}
+	default:
+		if p.State == packet.StateDown {
+			s.setState(packet.StateDown, packet.DiagNeighborSignaledDown)
+		}
+	}
+	newState := s.state
+	s.mu.Unlock()
+
+	if p.Poll {
+		s.transmit(true)
+	}
+
+	s.stateChanged(oldState, newState, p.State == packet.StateAdminDown)
+}
+
+func (s *session) detectionTimeExpired() {
+	s.mu.Lock()
+	oldState := s.state
+	if oldState == packet.StateInit || oldState == packet.StateUp {
+		s.setState(packet.StateDown, packet.DiagControlDetectionExpired)
+		s.remoteDiscr = 0
+		s.remoteState = packet.StateDown
+		s.remoteMinRxInterval = 1
+	}
+	newState := s.state
+	s.mu.Unlock()
+
+	s.stateChanged(oldState, newState, false)
+}
+
+// setState must be called with mu held
+func (s *session) setState(state uint8, diag uint8) {
+	if s.state == packet.StateUp || state == packet.StateUp {
+		// The TX interval changes when entering or leaving Up which has to be signaled with a poll sequence
+		s.poll = true
+	}
+
+	s.state = state
+	s.localDiag = diag
+	s.lastStateChange = time.Now()
+}
+
+func (s *session) stateChanged(oldState, newState uint8, remoteAdminDown bool) {
+	if oldState == newState {
+		return
+	}
+
+	log.WithFields(s.fields()).Infof("Session changed state from %s to %s", packet.StateName(oldState), packet.StateName(newState))
+	s.transmit(false)
+
+	up := newState == packet.StateUp
+	if !up && (oldState != packet.StateUp || remoteAdminDown) {
+		return
+	}
+
+	for _, c := range s.srv.clients(s) {
+		c.BFDStateChange(s.cfg.Peer, up)
+	}
+}
+
+func (s *session) adminDown() {
+	s.mu.Lock()
+	s.setState(packet.StateAdminDown, packet.DiagAdministrativelyDown)
+	s.mu.Unlock()
+
+	s.transmit(false)
+}
+
+func (s *session) transmit(final bool) {
+	s.mu.RLock()
+	if !final && s.remoteMinRxInterval == 0 {
+		s.mu.RUnlock()
+		return
+	}
+
+	p := &packet.ControlPacket{
+		Diagnostic:            s.localDiag,
+		State:                 s.state,
+		Poll:                  s.poll && !final,
+		Final:                 final,
+		DetectMultiplier:      s.cfg.DetectMultiplier,
+		MyDiscriminator:       s.localDiscr,
+		YourDiscriminator:     s.remoteDiscr,
+		DesiredMinTxInterval:  microseconds(s.desiredMinTxInterval()),
+		RequiredMinRxInterval: microseconds(s.cfg.RequiredMinRxInterval),
+	}
+	s.mu.RUnlock()
+
+	buf := bytes.NewBuffer(nil)
+	p.Serialize(buf)
+
+	_, err := s.conn.WriteToUDP(buf.Bytes(), s.dst)
+	if err != nil {
+		log.WithFields(s.fields()).WithError(err).Debug("Unable to send control packet")
+	}
+}
+
+// desiredMinTxInterval must be called with mu held
+func (s *session) desiredMinTxInterval() time.Duration {
+	if s.state != packet.StateUp && s.cfg.DesiredMinTxInterval < slowTxInterval {
+		return slowTxInterval
+	}
+
+	return s.cfg.DesiredMinTxInterval
+}
+
+// txInterval returns the jittered interval until the next control packet is sent (RFC5880 Sect. 6.8.7)
+func (s *session) txInterval() time.Duration {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	interval := s.desiredMinTxInterval()
+	remote := time.Duration(s.remoteMinRxInterval) * time.Microsecond
+	if remote > interval {
+		interval = remote
+	}
+
+	maxPercent := 100
+	if s.cfg.DetectMultiplier == 1 {
+		maxPercent = 90
+	}
+
+	return interval * time.Duration(75+rand.Intn(maxPercent-75+1)) / 100
+}
+
+// detectionTime returns the time after which the session is declared down if no control packet was received (RFC5880 Sect. 6.8.4)
+func (s *session) detectionTime() time.Duration {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.detectionTimeLocked()
+}
+
+func (s *session) detectionTimeLocked() time.Duration {
+	interval := s.cfg.RequiredMinRxInterval
+	remote := time.Duration(s.remoteDesiredMinTxInterval) * time.Microsecond
+	if remote > interval {
+		interval = remote
+	}
+
+	return time.Duration(s.remoteDetectMult) * interval
+}
+
+func (s *session) info() *SessionInfo {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return &SessionInfo{
+		Peer:                  s.cfg.Peer,
+		Local:                 s.cfg.Local,
+		Interface:             s.cfg.Interface,
+		Multihop:              s.cfg.Multihop,
+		State:                 s.state,
+		RemoteState:           s.remoteState,
+		Diagnostic:            s.localDiag,
+		LocalDiscriminator:    s.localDiscr,
+		RemoteDiscriminator:   s.remoteDiscr,
+		DesiredMinTxInterval:  s.cfg.DesiredMinTxInterval,
+		RequiredMinRxInterval: s.cfg.RequiredMinRxInterval,
+		RemoteMinRxInterval:   time.Duration(s.remoteMinRxInterval) * time.Microsecond,
+		DetectMultiplier:      s.cfg.DetectMultiplier,
+		DetectionTime:         s.detectionTimeLocked(),
+		LastStateChange:       s.lastStateChange,
+	}
+}
+
+func microseconds(d time.Duration) uint32 {
+	return uint32(d / time.Microsecond)
+}
@@ -0,0 +1,215 @@
+package server
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bfd/packet"
+	"github.com/bio-routing/bio-rd/util/log"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	minSourcePort = 49152
+	maxSourcePort = 65535
+
+	maxPacketLen = 1500
+	oobLen       = 64
+)
+
+type listener struct {
+	srv      *Server
+	conn     *net.UDPConn
+	multihop bool
+}
+
+func listen(srv *Server, addr bnet.IP, port uint16, multihop bool) (*listener, error) {
+	network := "udp6"
+	if addr.IsIPv4() {
+		network = "udp4"
+	}
+
+	conn, err := net.ListenUDP(network, &net.UDPAddr{
+		IP:   addr.ToNetIP(),
+		Port: int(port),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = setSockopts(conn, func(fd int) error {
+		if addr.IsIPv4() {
+			return unix.SetsockoptInt(fd, unix.IPPROTO_IP, unix.IP_RECVTTL, 1)
+		}
+
+		return unix.SetsockoptInt(fd, unix.IPPROTO_IPV6, unix.IPV6_RECVHOPLIMIT, 1)
+	})
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("unable to enable TTL reception: %w", err)
+	}
+
+	return &listener{
+		srv:      srv,
+		conn:     conn,
+		multihop: multihop,
+	}, nil
+}
+
+func (l *listener) close() {
+	l.conn.Close()
+}
+
+func (l *listener) serve() {
+	defer l.srv.wg.Done()
+
+	buf := make([]byte, maxPacketLen)
+	oob := make([]byte, oobLen)
+	for {
+		n, oobn, _, src, err := l.conn.ReadMsgUDP(buf, oob)
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+
+			log.WithError(err).Error("BFD: Unable to read from socket")
+			continue
+		}
+
+		srcIP, err := bnet.IPFromBytes(ipBytes(src.IP))
+		if err != nil {
+			continue
+		}
+
+		p, err := packet.DecodeControlPacket(bytes.NewBuffer(buf[:n]))
+		if err != nil {
+			log.WithError(err).WithFields(log.Fields{
+				"protocol": "BFD",
+				"peer":     srcIP.String(),
+			}).Debug("Dropping invalid control packet")
+			continue
+		}
+
+		l.srv.receive(p, srcIP, receivedTTL(oob[:oobn]), l.multihop)
+	}
+}
+
+// receivedTTL extracts the TTL or hop limit from the control messages of a received packet. Returns -1 if not found.
+func receivedTTL(oob []byte) int {
+	msgs, err := unix.ParseSocketControlMessage(oob)
+	if err != nil {
+		return -1
+	}
+
+	for _, m := range msgs {
+		if len(m.Data) < 4 {
+			continue
+		}
+
+		if (m.Header.Level == unix.IPPROTO_IP && m.Header.Type == unix.IP_TTL) ||
+			(m.Header.Level == unix.IPPROTO_IPV6 && m.Header.Type == unix.IPV6_HOPLIMIT) {
+			return int(binary.NativeEndian.Uint32(m.Data))
+		}
+	}
+
+	return -1
+}
+
+func ipBytes(ip net.IP) []byte {
+	if ip4 := ip.To4(); ip4 != nil {
+		return ip4
+	}
+
+	return ip
+}
+
+// dial creates the socket a session sends its control packets from
+func dial(cfg *SessionConfig, port uint16) (*net.UDPConn, error) {
+	network := "udp6"
+	local := &net.UDPAddr{
+		IP:   net.IPv6unspecified,
+		Port: int(port),
+	}
+
+	if cfg.Peer.IsIPv4() {
+		network = "udp4"
+		local.IP = net.IPv4zero
+	}
+
+	if cfg.Local != nil {
+		local.IP = cfg.Local.ToNetIP()
+	}
+
+	conn, err := net.ListenUDP(network, local)
+	if err != nil {
+		return nil, err
+	}
+
+	err = setSockopts(conn, func(fd int) error {
+		if cfg.Peer.IsIPv4() {
+			err := unix.SetsockoptInt(fd, unix.IPPROTO_IP, unix.IP_TTL, packet.SingleHopTTL)
+			if err != nil {
+				return fmt.Errorf("unable to set TTL: %w", err)
+			}
+		} else {
+			err := unix.SetsockoptInt(fd, unix.IPPROTO_IPV6, unix.IPV6_UNICAST_HOPS, packet.SingleHopTTL)
+			if err != nil {
+				return fmt.Errorf("unable to set hop limit: %w", err)
+			}
+		}
+
+		if cfg.Interface != "" && !cfg.Multihop {
+			err := bindToDev(fd, cfg.Interface)
+			if err != nil {
+				return fmt.Errorf("unable to bind to interface %s: %w", cfg.Interface, err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+// remoteAddr returns the address control packets of a session are sent to
+func (s *Server) remoteAddr(cfg *SessionConfig) *net.UDPAddr {
+	addr := &net.UDPAddr{
+		IP:   cfg.Peer.ToNetIP(),
+		Port: int(s.cfg.SingleHopPort),
+	}
+
+	if cfg.Multihop {
+		addr.Port = int(s.cfg.MultiHopPort)
+	}
+
+	if cfg.Peer.IsLinkLocalUnicast() {
+		addr.Zone = cfg.Interface
+	}
+
+	return addr
+}
+
+func setSockopts(conn *net.UDPConn, f func(fd int) error) error {
+	rawConn, err := conn.SyscallConn()
+	if err != nil {
+		return err
+	}
+
+	var sockoptErr error
+	err = rawConn.Control(func(fd uintptr) {
+		sockoptErr = f(int(fd))
+	})
+	if err != nil {
+		return err
+	}
+
+	return sockoptErr
+}
@@ -0,0 +1,10 @@
+//go:build linux
+
+package server
+
+import "golang.org/x/sys/unix"
+
+// bindToDev sets the SO_BINDTODEVICE option
+func bindToDev(fd int, devName string) error {
+	return unix.SetsockoptString(fd, unix.SOL_SOCKET, unix.SO_BINDTODEVICE, devName)
+}
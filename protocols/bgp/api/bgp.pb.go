@@ -242,6 +242,178 @@ func (x *DumpRIBRequest) GetVrfName() string {
 	return ""
 }
 
+type ClearDampeningRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Peer    *api.IP     `protobuf:"bytes,1,opt,name=peer,proto3" json:"peer,omitempty"`
+	Afi     uint32      `protobuf:"varint,2,opt,name=afi,proto3" json:"afi,omitempty"`
+	Safi    uint32      `protobuf:"varint,3,opt,name=safi,proto3" json:"safi,omitempty"`
+	VrfName string      `protobuf:"bytes,4,opt,name=vrf_name,json=vrfName,proto3" json:"vrf_name,omitempty"`
+	Pfx     *api.Prefix `protobuf:"bytes,5,opt,name=pfx,proto3" json:"pfx,omitempty"`
+}
+
+func (x *ClearDampeningRequest) Reset() {
+	*x = ClearDampeningRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocols_bgp_api_bgp_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClearDampeningRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearDampeningRequest) ProtoMessage() {}
+
+func (x *ClearDampeningRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_bgp_api_bgp_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearDampeningRequest.ProtoReflect.Descriptor instead.
+func (*ClearDampeningRequest) Descriptor() ([]byte, []int) {
+	return file_protocols_bgp_api_bgp_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ClearDampeningRequest) GetPeer() *api.IP {
+	if x != nil {
+		return x.Peer
+	}
+	return nil
+}
+
+func (x *ClearDampeningRequest) GetAfi() uint32 {
+	if x != nil {
+		return x.Afi
+	}
+	return 0
+}
+
+func (x *ClearDampeningRequest) GetSafi() uint32 {
+	if x != nil {
+		return x.Safi
+	}
+	return 0
+}
+
+func (x *ClearDampeningRequest) GetVrfName() string {
+	if x != nil {
+		return x.VrfName
+	}
+	return ""
+}
+
+func (x *ClearDampeningRequest) GetPfx() *api.Prefix {
+	if x != nil {
+		return x.Pfx
+	}
+	return nil
+}
+
+type ClearDampeningResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ClearDampeningResponse) Reset() {
+	*x = ClearDampeningResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocols_bgp_api_bgp_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClearDampeningResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearDampeningResponse) ProtoMessage() {}
+
+func (x *ClearDampeningResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_bgp_api_bgp_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearDampeningResponse.ProtoReflect.Descriptor instead.
+func (*ClearDampeningResponse) Descriptor() ([]byte, []int) {
+	return file_protocols_bgp_api_bgp_proto_rawDescGZIP(), []int{5}
+}
+
+type DumpFlowSpecRIBRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Afi     uint32 `protobuf:"varint,1,opt,name=afi,proto3" json:"afi,omitempty"`
+	VrfName string `protobuf:"bytes,2,opt,name=vrf_name,json=vrfName,proto3" json:"vrf_name,omitempty"`
+}
+
+func (x *DumpFlowSpecRIBRequest) Reset() {
+	*x = DumpFlowSpecRIBRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocols_bgp_api_bgp_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DumpFlowSpecRIBRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DumpFlowSpecRIBRequest) ProtoMessage() {}
+
+func (x *DumpFlowSpecRIBRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_bgp_api_bgp_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DumpFlowSpecRIBRequest.ProtoReflect.Descriptor instead.
+func (*DumpFlowSpecRIBRequest) Descriptor() ([]byte, []int) {
+	return file_protocols_bgp_api_bgp_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DumpFlowSpecRIBRequest) GetAfi() uint32 {
+	if x != nil {
+		return x.Afi
+	}
+	return 0
+}
+
+func (x *DumpFlowSpecRIBRequest) GetVrfName() string {
+	if x != nil {
+		return x.VrfName
+	}
+	return ""
+}
+
 var File_protocols_bgp_api_bgp_proto protoreflect.FileDescriptor
 
 var file_protocols_bgp_api_bgp_proto_rawDesc = []byte{
@@ -273,7 +445,23 @@ var file_protocols_bgp_api_bgp_proto_rawDesc = []byte{
 	0x66, 0x69, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x61, 0x66, 0x69, 0x12, 0x12, 0x0a,
 	0x04, 0x73, 0x61, 0x66, 0x69, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x73, 0x61, 0x66,
 	0x69, 0x12, 0x19, 0x0a, 0x08, 0x76, 0x72, 0x66, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x72, 0x66, 0x4e, 0x61, 0x6d, 0x65, 0x32, 0xd4, 0x01, 0x0a,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x72, 0x66, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x9c, 0x01, 0x0a,
+	0x15, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x44, 0x61, 0x6d, 0x70, 0x65, 0x6e, 0x69, 0x6e, 0x67, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x04, 0x70, 0x65, 0x65, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x49,
+	0x50, 0x52, 0x04, 0x70, 0x65, 0x65, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x66, 0x69, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x61, 0x66, 0x69, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x61, 0x66,
+	0x69, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x73, 0x61, 0x66, 0x69, 0x12, 0x19, 0x0a,
+	0x08, 0x76, 0x72, 0x66, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x76, 0x72, 0x66, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x03, 0x70, 0x66, 0x78, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x6e, 0x65, 0x74, 0x2e,
+	0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x52, 0x03, 0x70, 0x66, 0x78, 0x22, 0x18, 0x0a, 0x16, 0x43,
+	0x6c, 0x65, 0x61, 0x72, 0x44, 0x61, 0x6d, 0x70, 0x65, 0x6e, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x45, 0x0a, 0x16, 0x44, 0x75, 0x6d, 0x70, 0x46, 0x6c, 0x6f,
+	0x77, 0x53, 0x70, 0x65, 0x63, 0x52, 0x49, 0x42, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x10, 0x0a, 0x03, 0x61, 0x66, 0x69, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x61, 0x66,
+	0x69, 0x12, 0x19, 0x0a, 0x08, 0x76, 0x72, 0x66, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x72, 0x66, 0x4e, 0x61, 0x6d, 0x65, 0x32, 0xf3, 0x02, 0x0a,
 	0x0a, 0x42, 0x67, 0x70, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x4d, 0x0a, 0x0c, 0x4c,
 	0x69, 0x73, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1c, 0x2e, 0x62, 0x69,
 	0x6f, 0x2e, 0x62, 0x67, 0x70, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f,
@@ -287,10 +475,20 @@ var file_protocols_bgp_api_bgp_proto_rawDesc = []byte{
 	0x42, 0x4f, 0x75, 0x74, 0x12, 0x17, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x62, 0x67, 0x70, 0x2e, 0x44,
 	0x75, 0x6d, 0x70, 0x52, 0x49, 0x42, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e,
 	0x62, 0x69, 0x6f, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x22,
-	0x00, 0x30, 0x01, 0x42, 0x31, 0x5a, 0x2f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
-	0x6d, 0x2f, 0x62, 0x69, 0x6f, 0x2d, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2f, 0x62, 0x69,
-	0x6f, 0x2d, 0x72, 0x64, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x73, 0x2f, 0x62,
-	0x67, 0x70, 0x2f, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x00, 0x30, 0x01, 0x12, 0x48, 0x0a, 0x0f, 0x44, 0x75, 0x6d, 0x70, 0x46, 0x6c, 0x6f, 0x77, 0x53,
+	0x70, 0x65, 0x63, 0x52, 0x49, 0x42, 0x12, 0x1f, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x62, 0x67, 0x70,
+	0x2e, 0x44, 0x75, 0x6d, 0x70, 0x46, 0x6c, 0x6f, 0x77, 0x53, 0x70, 0x65, 0x63, 0x52, 0x49, 0x42,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x72, 0x6f,
+	0x75, 0x74, 0x65, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x53, 0x0a,
+	0x0e, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x44, 0x61, 0x6d, 0x70, 0x65, 0x6e, 0x69, 0x6e, 0x67, 0x12,
+	0x1e, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x62, 0x67, 0x70, 0x2e, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x44,
+	0x61, 0x6d, 0x70, 0x65, 0x6e, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1f, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x62, 0x67, 0x70, 0x2e, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x44,
+	0x61, 0x6d, 0x70, 0x65, 0x6e, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x42, 0x31, 0x5a, 0x2f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x62, 0x69, 0x6f, 0x2d, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2f, 0x62, 0x69, 0x6f,
+	0x2d, 0x72, 0x64, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x73, 0x2f, 0x62, 0x67,
+	0x70, 0x2f, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -305,32 +503,42 @@ func file_protocols_bgp_api_bgp_proto_rawDescGZIP() []byte {
 	return file_protocols_bgp_api_bgp_proto_rawDescData
 }
 
-var file_protocols_bgp_api_bgp_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_protocols_bgp_api_bgp_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_protocols_bgp_api_bgp_proto_goTypes = []interface{}{
-	(*ListSessionsRequest)(nil),  // 0: bio.bgp.ListSessionsRequest
-	(*SessionFilter)(nil),        // 1: bio.bgp.SessionFilter
-	(*ListSessionsResponse)(nil), // 2: bio.bgp.ListSessionsResponse
-	(*DumpRIBRequest)(nil),       // 3: bio.bgp.DumpRIBRequest
-	(*api.IP)(nil),               // 4: bio.net.IP
-	(*Session)(nil),              // 5: bio.bgp.Session
-	(*api1.Route)(nil),           // 6: bio.route.Route
+	(*ListSessionsRequest)(nil),    // 0: bio.bgp.ListSessionsRequest
+	(*SessionFilter)(nil),          // 1: bio.bgp.SessionFilter
+	(*ListSessionsResponse)(nil),   // 2: bio.bgp.ListSessionsResponse
+	(*DumpRIBRequest)(nil),         // 3: bio.bgp.DumpRIBRequest
+	(*ClearDampeningRequest)(nil),  // 4: bio.bgp.ClearDampeningRequest
+	(*ClearDampeningResponse)(nil), // 5: bio.bgp.ClearDampeningResponse
+	(*DumpFlowSpecRIBRequest)(nil), // 6: bio.bgp.DumpFlowSpecRIBRequest
+	(*api.IP)(nil),                 // 7: bio.net.IP
+	(*Session)(nil),                // 8: bio.bgp.Session
+	(*api.Prefix)(nil),             // 9: bio.net.Prefix
+	(*api1.Route)(nil),             // 10: bio.route.Route
 }
 var file_protocols_bgp_api_bgp_proto_depIdxs = []int32{
-	1, // 0: bio.bgp.ListSessionsRequest.filter:type_name -> bio.bgp.SessionFilter
-	4, // 1: bio.bgp.SessionFilter.neighbor_ip:type_name -> bio.net.IP
-	5, // 2: bio.bgp.ListSessionsResponse.sessions:type_name -> bio.bgp.Session
-	4, // 3: bio.bgp.DumpRIBRequest.peer:type_name -> bio.net.IP
-	0, // 4: bio.bgp.BgpService.ListSessions:input_type -> bio.bgp.ListSessionsRequest
-	3, // 5: bio.bgp.BgpService.DumpRIBIn:input_type -> bio.bgp.DumpRIBRequest
-	3, // 6: bio.bgp.BgpService.DumpRIBOut:input_type -> bio.bgp.DumpRIBRequest
-	2, // 7: bio.bgp.BgpService.ListSessions:output_type -> bio.bgp.ListSessionsResponse
-	6, // 8: bio.bgp.BgpService.DumpRIBIn:output_type -> bio.route.Route
-	6, // 9: bio.bgp.BgpService.DumpRIBOut:output_type -> bio.route.Route
-	7, // [7:10] is the sub-list for method output_type
-	4, // [4:7] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	1,  // 0: bio.bgp.ListSessionsRequest.filter:type_name -> bio.bgp.SessionFilter
+	7,  // 1: bio.bgp.SessionFilter.neighbor_ip:type_name -> bio.net.IP
+	8,  // 2: bio.bgp.ListSessionsResponse.sessions:type_name -> bio.bgp.Session
+	7,  // 3: bio.bgp.DumpRIBRequest.peer:type_name -> bio.net.IP
+	7,  // 4: bio.bgp.ClearDampeningRequest.peer:type_name -> bio.net.IP
+	9,  // 5: bio.bgp.ClearDampeningRequest.pfx:type_name -> bio.net.Prefix
+	0,  // 6: bio.bgp.BgpService.ListSessions:input_type -> bio.bgp.ListSessionsRequest
+	3,  // 7: bio.bgp.BgpService.DumpRIBIn:input_type -> bio.bgp.DumpRIBRequest
+	3,  // 8: bio.bgp.BgpService.DumpRIBOut:input_type -> bio.bgp.DumpRIBRequest
+	6,  // 9: bio.bgp.BgpService.DumpFlowSpecRIB:input_type -> bio.bgp.DumpFlowSpecRIBRequest
+	4,  // 10: bio.bgp.BgpService.ClearDampening:input_type -> bio.bgp.ClearDampeningRequest
+	2,  // 11: bio.bgp.BgpService.ListSessions:output_type -> bio.bgp.ListSessionsResponse
+	10, // 12: bio.bgp.BgpService.DumpRIBIn:output_type -> bio.route.Route
+	10, // 13: bio.bgp.BgpService.DumpRIBOut:output_type -> bio.route.Route
+	10, // 14: bio.bgp.BgpService.DumpFlowSpecRIB:output_type -> bio.route.Route
+	5,  // 15: bio.bgp.BgpService.ClearDampening:output_type -> bio.bgp.ClearDampeningResponse
+	11, // [11:16] is the sub-list for method output_type
+	6,  // [6:11] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_protocols_bgp_api_bgp_proto_init() }
@@ -388,6 +596,42 @@ func file_protocols_bgp_api_bgp_proto_init() {
 				return nil
 			}
 		}
+		file_protocols_bgp_api_bgp_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClearDampeningRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocols_bgp_api_bgp_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClearDampeningResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocols_bgp_api_bgp_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DumpFlowSpecRIBRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -395,7 +639,7 @@ func file_protocols_bgp_api_bgp_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_protocols_bgp_api_bgp_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -22,6 +22,8 @@ type BgpServiceClient interface {
 	ListSessions(ctx context.Context, in *ListSessionsRequest, opts ...grpc.CallOption) (*ListSessionsResponse, error)
 	DumpRIBIn(ctx context.Context, in *DumpRIBRequest, opts ...grpc.CallOption) (BgpService_DumpRIBInClient, error)
 	DumpRIBOut(ctx context.Context, in *DumpRIBRequest, opts ...grpc.CallOption) (BgpService_DumpRIBOutClient, error)
+	DumpFlowSpecRIB(ctx context.Context, in *DumpFlowSpecRIBRequest, opts ...grpc.CallOption) (BgpService_DumpFlowSpecRIBClient, error)
+	ClearDampening(ctx context.Context, in *ClearDampeningRequest, opts ...grpc.CallOption) (*ClearDampeningResponse, error)
 }
 
 type bgpServiceClient struct {
@@ -105,6 +107,47 @@ func (x *bgpServiceDumpRIBOutClient) Recv() (*api.Route, error) {
 	return m, nil
 }
 
+func (c *bgpServiceClient) DumpFlowSpecRIB(ctx context.Context, in *DumpFlowSpecRIBRequest, opts ...grpc.CallOption) (BgpService_DumpFlowSpecRIBClient, error) {
+	stream, err := c.cc.NewStream(ctx, &BgpService_ServiceDesc.Streams[2], "/bio.bgp.BgpService/DumpFlowSpecRIB", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &bgpServiceDumpFlowSpecRIBClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BgpService_DumpFlowSpecRIBClient interface {
+	Recv() (*api.Route, error)
+	grpc.ClientStream
+}
+
+type bgpServiceDumpFlowSpecRIBClient struct {
+	grpc.ClientStream
+}
+
+func (x *bgpServiceDumpFlowSpecRIBClient) Recv() (*api.Route, error) {
+	m := new(api.Route)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *bgpServiceClient) ClearDampening(ctx context.Context, in *ClearDampeningRequest, opts ...grpc.CallOption) (*ClearDampeningResponse, error) {
+	out := new(ClearDampeningResponse)
+	err := c.cc.Invoke(ctx, "/bio.bgp.BgpService/ClearDampening", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BgpServiceServer is the server API for BgpService service.
 // All implementations must embed UnimplementedBgpServiceServer
 // for forward compatibility
@@ -112,6 +155,8 @@ type BgpServiceServer interface {
 	ListSessions(context.Context, *ListSessionsRequest) (*ListSessionsResponse, error)
 	DumpRIBIn(*DumpRIBRequest, BgpService_DumpRIBInServer) error
 	DumpRIBOut(*DumpRIBRequest, BgpService_DumpRIBOutServer) error
+	DumpFlowSpecRIB(*DumpFlowSpecRIBRequest, BgpService_DumpFlowSpecRIBServer) error
+	ClearDampening(context.Context, *ClearDampeningRequest) (*ClearDampeningResponse, error)
 	mustEmbedUnimplementedBgpServiceServer()
 }
 
@@ -128,6 +173,12 @@ func (UnimplementedBgpServiceServer) DumpRIBIn(*DumpRIBRequest, BgpService_DumpR
 func (UnimplementedBgpServiceServer) DumpRIBOut(*DumpRIBRequest, BgpService_DumpRIBOutServer) error {
 	return status.Errorf(codes.Unimplemented, "method DumpRIBOut not implemented")
 }
+func (UnimplementedBgpServiceServer) DumpFlowSpecRIB(*DumpFlowSpecRIBRequest, BgpService_DumpFlowSpecRIBServer) error {
+	return status.Errorf(codes.Unimplemented, "method DumpFlowSpecRIB not implemented")
+}
+func (UnimplementedBgpServiceServer) ClearDampening(context.Context, *ClearDampeningRequest) (*ClearDampeningResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClearDampening not implemented")
+}
 func (UnimplementedBgpServiceServer) mustEmbedUnimplementedBgpServiceServer() {}
 
 // UnsafeBgpServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -201,6 +252,45 @@ func (x *bgpServiceDumpRIBOutServer) Send(m *api.Route) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _BgpService_DumpFlowSpecRIB_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DumpFlowSpecRIBRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BgpServiceServer).DumpFlowSpecRIB(m, &bgpServiceDumpFlowSpecRIBServer{stream})
+}
+
+type BgpService_DumpFlowSpecRIBServer interface {
+	Send(*api.Route) error
+	grpc.ServerStream
+}
+
+type bgpServiceDumpFlowSpecRIBServer struct {
+	grpc.ServerStream
+}
+
+func (x *bgpServiceDumpFlowSpecRIBServer) Send(m *api.Route) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _BgpService_ClearDampening_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClearDampeningRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BgpServiceServer).ClearDampening(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bio.bgp.BgpService/ClearDampening",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BgpServiceServer).ClearDampening(ctx, req.(*ClearDampeningRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // BgpService_ServiceDesc is the grpc.ServiceDesc for BgpService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -212,6 +302,10 @@ var BgpService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListSessions",
 			Handler:    _BgpService_ListSessions_Handler,
 		},
+		{
+			MethodName: "ClearDampening",
+			Handler:    _BgpService_ClearDampening_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -224,6 +318,11 @@ var BgpService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _BgpService_DumpRIBOut_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "DumpFlowSpecRIB",
+			Handler:       _BgpService_DumpFlowSpecRIB_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "protocols/bgp/api/bgp.proto",
 }
@@ -16,4 +16,16 @@ type BGPAddressFamilyMetrics struct {
 
 	// EndOfRIBMarkerReceived indicates if a BGP End of RIB marker was received for this AFI/SAFI from the peer
 	EndOfRIBMarkerReceived bool
+
+	// PathsReceived is the number of paths we received (counted against the prefix limit)
+	PathsReceived uint64
+
+	// PrefixLimit is the maximum number of paths accepted from the peer (0 = unlimited)
+	PrefixLimit uint64
+
+	// PrefixLimitWarning indicates if the number of paths received reached the warning threshold of the prefix limit
+	PrefixLimitWarning bool
+
+	// PrefixLimitExceeded indicates if the number of paths received exceeds the prefix limit
+	PrefixLimitExceeded bool
 }
@@ -42,6 +42,12 @@ type BGPPeerMetrics struct {
 	// UpdatesReceived is the number of update messages we sent on this session
 	UpdatesSent uint64
 
+	// Dynamic indicates if the peer was created for an incoming connection covered by dynamic neighbors
+	Dynamic bool
+
+	// PrefixLimitHeldDown indicates if the session is held down after the peer exceeded a prefix limit
+	PrefixLimitHeldDown bool
+
 	// AddressFamilies provides metrics on AFI/SAFI level
 	AddressFamilies []*BGPAddressFamilyMetrics
 }
@@ -6,33 +6,39 @@ const (
 	BGP4Version    = 4
 	MinOpenLen     = 29
 
-	MarkerLen         = 16
-	HeaderLen         = 19
-	MinLen            = 19
-	MaxLen            = 4096
-	MinUpdateLen      = 4
-	NLRIMaxLen        = 5
-	AFILen            = 2
-	SAFILen           = 1
-	CommunityLen      = 4
-	LargeCommunityLen = 12
-	IPv4Len           = 4
-	IPv6Len           = 16
-	ClusterIDLen      = 4
+	MarkerLen                = 16
+	HeaderLen                = 19
+	MinLen                   = 19
+	MaxLen                   = 4096
+	MinUpdateLen             = 4
+	NLRIMaxLen               = 5
+	AFILen                   = 2
+	SAFILen                  = 1
+	CommunityLen             = 4
+	LargeCommunityLen        = 12
+	ExtendedCommunityLen     = 8
+	IPv6ExtendedCommunityLen = 20
+	IPv4Len                  = 4
+	IPv6Len                  = 16
+	ClusterIDLen             = 4
+	RouteRefreshLen          = 4
+	RouteDistinguisherLen    = 8
 
 	// BGP message types
 	OpenMsg         = 1
 	UpdateMsg       = 2
 	NotificationMsg = 3
 	KeepaliveMsg    = 4
+	RouteRefreshMsg = 5
 
 	// BGP errors
-	MessageHeaderError      = 1
-	OpenMessageError        = 2
-	UpdateMessageError      = 3
-	HoldTimeExpired         = 4
-	FiniteStateMachineError = 5
-	Cease                   = 6
+	MessageHeaderError       = 1
+	OpenMessageError         = 2
+	UpdateMessageError       = 3
+	HoldTimeExpired          = 4
+	FiniteStateMachineError  = 5
+	Cease                    = 6
+	RouteRefreshMessageError = 7
 
 	// Msg Header Errors
 	ConnectionNotSync = 1
@@ -61,6 +67,14 @@ const (
 	InvalidNetworkField       = 10
 	MalformedASPath           = 11
 
+	// Route Refresh Msg Errors (RFC7313)
+	InvalidMessageLength = 1
+
+	// Route Refresh Msg Subtypes (RFC7313)
+	RouteRefreshRequest     = 0
+	BeginningOfRouteRefresh = 1
+	EndOfRouteRefresh       = 2
+
 	// Notification Msg Subcodes
 	AdministrativeShutdown = 2
 	AdministrativeReset    = 4
@@ -76,10 +90,12 @@ const (
 	CommunitiesAttr              = 8
 	OriginatorIDAttr             = 9
 	ClusterListAttr              = 10
+	ExtendedCommunitiesAttr      = 16
 	MultiProtocolReachNLRIAttr   = 14
 	MultiProtocolUnreachNLRIAttr = 15
 	AS4PathAttr                  = 17
 	AS4AggregatorAttr            = 18
+	IPv6ExtendedCommunitiesAttr  = 25
 	LargeCommunitiesAttr         = 32
 	OnlyToCustomerAttr           = 35
 
@@ -105,20 +121,31 @@ const (
 	// Sub-Address Familiy Identifiers
 	SAFIUnicast        = 1
 	SAFILabeledUnicast = 4
+	SAFIMPLSVPN        = 128
+	SAFIFlowSpec       = 133
 
 	// Capabilities
 	MultiProtocolCapabilityCode           = 1
 	CapabilitiesParamType                 = 2
+	RouteRefreshCapabilityCode            = 2
 	ExtendedNextHopEncodingCapabilityCode = 5
 	PeerRoleCapabilityCode                = 9
+	GracefulRestartCapabilityCode         = 64
 	ASN4CapabilityCode                    = 65
 	AddPathCapabilityCode                 = 69
+	EnhancedRouteRefreshCapabilityCode    = 70
 
 	// AddPath capability
 	AddPathReceive     = 1
 	AddPathSend        = 2
 	AddPathSendReceive = 3
 
+	// Graceful Restart capability (RFC4724)
+	GracefulRestartRestartStateFlag    = 0x8000
+	GracefulRestartTimeMask            = 0x0fff
+	GracefulRestartForwardingStateFlag = 0x80
+	GracefulRestartMaxRestartTime      = 4095
+
 	// BGP Role capability
 	PeerRoleRoleProvider = 0
 	PeerRoleRoleRS       = 1
@@ -170,6 +197,13 @@ type BGPNotification struct {
 	ErrorSubcode uint8
 }
 
+// BGPRouteRefresh represents a ROUTE-REFRESH message (RFC2918, RFC7313)
+type BGPRouteRefresh struct {
+	AFI     uint16
+	SubType uint8
+	SAFI    uint8
+}
+
 type PathAttribute struct {
 	Length         uint16
 	Optional       bool
@@ -193,6 +227,22 @@ func AFIName(afi uint16) string {
 	}
 }
 
+// SAFIName returns the name of a subsequent address family
+func SAFIName(safi uint8) string {
+	switch safi {
+	case SAFIUnicast:
+		return "Unicast"
+	case SAFILabeledUnicast:
+		return "Labeled Unicast"
+	case SAFIMPLSVPN:
+		return "MPLS VPN"
+	case SAFIFlowSpec:
+		return "FlowSpec"
+	default:
+		return "Unknown SAFI"
+	}
+}
+
 func PeerRoleName(pr uint8) string {
 	switch pr {
 	case PeerRoleRoleProvider:
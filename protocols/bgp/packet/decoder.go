@@ -10,6 +10,7 @@ import (
 const (
 	addPathTupleSize         = 4
 	extendedNextHopTupleSize = 6
+	gracefulRestartTupleSize = 4
 )
 
 // Decode decodes a BGP message
@@ -40,6 +41,8 @@ func decodeMsgBody(buf *bytes.Buffer, msgType uint8, l uint16, opt *DecodeOption
 		return nil, nil // Nothing to decode in Keepalive message
 	case NotificationMsg:
 		return decodeNotificationMsg(buf)
+	case RouteRefreshMsg:
+		return decodeRouteRefreshMsg(buf, l)
 	}
 	return nil, fmt.Errorf("unknown message type: %d", msgType)
 }
@@ -78,6 +81,43 @@ func decodeUpdateMsg(buf *bytes.Buffer, l uint16, opt *DecodeOptions) (*BGPUpdat
 	return msg, nil
 }
 
+func decodeRouteRefreshMsg(buf *bytes.Buffer, l uint16) (*BGPRouteRefresh, error) {
+	if l < RouteRefreshLen {
+		return nil, BGPError{
+			ErrorCode:    MessageHeaderError,
+			ErrorSubCode: BadMessageLength,
+			ErrorStr:     fmt.Sprintf("invalid ROUTE-REFRESH message length: %d", l+MinLen),
+		}
+	}
+
+	msg := &BGPRouteRefresh{}
+	fields := []interface{}{
+		&msg.AFI,
+		&msg.SubType,
+		&msg.SAFI,
+	}
+
+	err := decode.Decode(buf, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	// RFC7313 Sect. 5: A BoRR or EoRR message with a length other than 23 is malformed. Only a route refresh
+	// request may carry ORF entries (RFC5291).
+	if msg.SubType != RouteRefreshRequest && l != RouteRefreshLen {
+		return nil, BGPError{
+			ErrorCode:    RouteRefreshMessageError,
+			ErrorSubCode: InvalidMessageLength,
+			ErrorStr:     fmt.Sprintf("invalid ROUTE-REFRESH message length: %d", l+MinLen),
+		}
+	}
+
+	// ORF entries are not supported and thus ignored
+	buf.Next(int(l - RouteRefreshLen))
+
+	return msg, nil
+}
+
 func decodeNotificationMsg(buf *bytes.Buffer) (*BGPNotification, error) {
 	msg := &BGPNotification{}
 
@@ -91,7 +131,7 @@ func decodeNotificationMsg(buf *bytes.Buffer) (*BGPNotification, error) {
 		return msg, err
 	}
 
-	if msg.ErrorCode > Cease {
+	if msg.ErrorCode > RouteRefreshMessageError {
 		return msg, fmt.Errorf("invalid error code: %d", msg.ErrorSubcode)
 	}
 
@@ -121,6 +161,10 @@ func decodeNotificationMsg(buf *bytes.Buffer) (*BGPNotification, error) {
 		if msg.ErrorSubcode > OutOfResources {
 			return invalidErrCode(msg)
 		}
+	case RouteRefreshMessageError:
+		if msg.ErrorSubcode != InvalidMessageLength {
+			return invalidErrCode(msg)
+		}
 	default:
 		return invalidErrCode(msg)
 	}
@@ -267,18 +311,45 @@ func decodeCapability(buf *bytes.Buffer) (Capability, error) {
 			return cap, fmt.Errorf("unable to decode Extended Next Hop capability: %w", err)
 		}
 		cap.Value = extendedNextHopCap
+	case GracefulRestartCapabilityCode:
+		gracefulRestartCap, err := decodeGracefulRestartCapability(buf, cap.Length)
+		if err != nil {
+			return cap, fmt.Errorf("unable to decode graceful restart capability: %w", err)
+		}
+		cap.Value = gracefulRestartCap
+	case RouteRefreshCapabilityCode:
+		err := skipCapabilityValue(buf, cap.Length)
+		if err != nil {
+			return cap, fmt.Errorf("unable to decode route refresh capability: %w", err)
+		}
+		cap.Value = RouteRefreshCapability{}
+	case EnhancedRouteRefreshCapabilityCode:
+		err := skipCapabilityValue(buf, cap.Length)
+		if err != nil {
+			return cap, fmt.Errorf("unable to decode enhanced route refresh capability: %w", err)
+		}
+		cap.Value = EnhancedRouteRefreshCapability{}
 	default:
-		for i := uint8(0); i < cap.Length; i++ {
-			_, err := buf.ReadByte()
-			if err != nil {
-				return cap, fmt.Errorf("read failed: %w", err)
-			}
+		err := skipCapabilityValue(buf, cap.Length)
+		if err != nil {
+			return cap, err
 		}
 	}
 
 	return cap, nil
 }
 
+func skipCapabilityValue(buf *bytes.Buffer, length uint8) error {
+	for i := uint8(0); i < length; i++ {
+		_, err := buf.ReadByte()
+		if err != nil {
+			return fmt.Errorf("read failed: %w", err)
+		}
+	}
+
+	return nil
+}
+
 func decodeMultiProtocolCapability(buf *bytes.Buffer) (MultiProtocolCapability, error) {
 	mpCap := MultiProtocolCapability{}
 	reserved := uint8(0)
@@ -347,6 +418,44 @@ func decodePeerRoleCapability(buf *bytes.Buffer) (PeerRoleCapability, error) {
 	return peerRoleCap, nil
 }
 
+func decodeGracefulRestartCapability(buf *bytes.Buffer, capLength uint8) (GracefulRestartCapability, error) {
+	grCap := GracefulRestartCapability{}
+
+	if capLength < 2 || (capLength-2)%gracefulRestartTupleSize != 0 {
+		return grCap, fmt.Errorf("invalid caplength %d", capLength)
+	}
+
+	flagsAndTime := uint16(0)
+	err := decode.DecodeUint16(buf, &flagsAndTime)
+	if err != nil {
+		return grCap, err
+	}
+
+	grCap.RestartState = flagsAndTime&GracefulRestartRestartStateFlag != 0
+	grCap.RestartTime = flagsAndTime & GracefulRestartTimeMask
+
+	grCap.AddressFamilies = make([]GracefulRestartCapabilityTuple, 0)
+	for capLength -= 2; capLength >= gracefulRestartTupleSize; capLength -= gracefulRestartTupleSize {
+		af := GracefulRestartCapabilityTuple{}
+		flags := uint8(0)
+		fields := []interface{}{
+			&af.AFI,
+			&af.SAFI,
+			&flags,
+		}
+
+		err := decode.Decode(buf, fields)
+		if err != nil {
+			return grCap, err
+		}
+
+		af.ForwardingState = flags&GracefulRestartForwardingStateFlag != 0
+		grCap.AddressFamilies = append(grCap.AddressFamilies, af)
+	}
+
+	return grCap, nil
+}
+
 func validateOpen(msg *BGPOpen) error {
 	if msg.Version != BGP4Version {
 		return BGPError{
@@ -440,7 +549,7 @@ func decodeHeader(buf *bytes.Buffer) (*BGPHeader, error) {
 		}
 	}
 
-	if hdr.Type > KeepaliveMsg || hdr.Type == 0 {
+	if hdr.Type > RouteRefreshMsg || hdr.Type == 0 {
 		return hdr, BGPError{
 			ErrorCode:    MessageHeaderError,
 			ErrorSubCode: BadMessageType,
@@ -389,6 +389,15 @@ func TestDecodeNotificationMsg(t *testing.T) {
 			input:    []byte{6, 9},
 			wantFail: true,
 		},
+		{
+			name:     "Route Refresh Message Error",
+			input:    []byte{7, 1},
+			wantFail: false,
+			expected: &BGPNotification{
+				ErrorCode:    7,
+				ErrorSubcode: 1,
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -411,6 +420,82 @@ func TestDecodeNotificationMsg(t *testing.T) {
 	}
 }
 
+func TestDecodeRouteRefreshMsg(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []byte
+		length   uint16
+		wantFail bool
+		expected *BGPRouteRefresh
+	}{
+		{
+			name:   "Normal route refresh request",
+			input:  []byte{0, 2, 0, 1},
+			length: 4,
+			expected: &BGPRouteRefresh{
+				AFI:     AFIIPv6,
+				SubType: RouteRefreshRequest,
+				SAFI:    SAFIUnicast,
+			},
+		},
+		{
+			name:   "Beginning of route refresh",
+			input:  []byte{0, 1, 1, 1},
+			length: 4,
+			expected: &BGPRouteRefresh{
+				AFI:     AFIIPv4,
+				SubType: BeginningOfRouteRefresh,
+				SAFI:    SAFIUnicast,
+			},
+		},
+		{
+			name:   "Route refresh request with ORF entries",
+			input:  []byte{0, 1, 0, 1, 1, 64, 0, 0, 0},
+			length: 9,
+			expected: &BGPRouteRefresh{
+				AFI:     AFIIPv4,
+				SubType: RouteRefreshRequest,
+				SAFI:    SAFIUnicast,
+			},
+		},
+		{
+			name:     "Invalid length of end of route refresh",
+			input:    []byte{0, 1, 2, 1, 0},
+			length:   5,
+			wantFail: true,
+		},
+		{
+			name:     "Invalid length of beginning of route refresh",
+			input:    []byte{0, 1, 1, 1, 0},
+			length:   5,
+			wantFail: true,
+		},
+		{
+			name:     "Too short",
+			input:    []byte{0, 1, 0},
+			length:   3,
+			wantFail: true,
+		},
+		{
+			name:     "Incomplete message",
+			input:    []byte{0, 1, 2},
+			length:   4,
+			wantFail: true,
+		},
+	}
+
+	for _, test := range tests {
+		res, err := decodeRouteRefreshMsg(bytes.NewBuffer(test.input), test.length)
+		if test.wantFail {
+			assert.Error(t, err, test.name)
+			continue
+		}
+
+		assert.NoError(t, err, test.name)
+		assert.Equal(t, test.expected, res, test.name)
+	}
+}
+
 func TestDecodeUpdateMsg(t *testing.T) {
 	tests := []struct {
 		testNum        int
@@ -1087,7 +1172,7 @@ func TestDecodeUpdateMsg(t *testing.T) {
 											Length:         6,
 											TypeCode:       7,
 											Value: types.Aggregator{
-												ASN:     uint16(258),
+												ASN:     uint32(258),
 												Address: bnet.IPv4FromOctets(10, 11, 12, 13).Ptr().ToUint32(),
 											},
 										},
@@ -1237,7 +1322,7 @@ func TestDecodeMsgBody(t *testing.T) {
 	}{
 		{
 			name:     "Unknown msgType",
-			msgType:  5,
+			msgType:  6,
 			wantFail: true,
 		},
 	}
@@ -1316,9 +1401,9 @@ func TestDecodeHeader(t *testing.T) {
 			},
 		},
 		{
-			// Invalid message type 5
+			// Invalid message type 6
 			testNum:  4,
-			input:    []byte{255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 0, 19, 5},
+			input:    []byte{255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 0, 19, 6},
 			wantFail: true,
 			expected: &BGPHeader{
 				Length: 19,
@@ -1632,6 +1717,55 @@ func TestDecodeCapability(t *testing.T) {
 			input:    []byte{69, 4, 0, 1},
 			wantFail: true,
 		},
+		{
+			name: "Graceful Restart Capability",
+			input: []byte{
+				64, 10, // Type, Length
+				0x80, 0x78, // Restart Flags, Restart Time
+				0, 1, 1, 0x80, // IPv4 unicast, forwarding state preserved
+				0, 2, 1, 0, // IPv6 unicast
+			},
+			expected: Capability{
+				Code:   GracefulRestartCapabilityCode,
+				Length: 10,
+				Value: GracefulRestartCapability{
+					RestartState: true,
+					RestartTime:  120,
+					AddressFamilies: []GracefulRestartCapabilityTuple{
+						{
+							AFI:             AFIIPv4,
+							SAFI:            SAFIUnicast,
+							ForwardingState: true,
+						},
+						{
+							AFI:  AFIIPv6,
+							SAFI: SAFIUnicast,
+						},
+					},
+				},
+			},
+		},
+		{
+			name:     "Graceful Restart Capability with invalid length",
+			input:    []byte{64, 3, 0, 120, 0},
+			wantFail: true,
+		},
+		{
+			name:  "Route Refresh Capability",
+			input: []byte{2, 0},
+			expected: Capability{
+				Code:  RouteRefreshCapabilityCode,
+				Value: RouteRefreshCapability{},
+			},
+		},
+		{
+			name:  "Enhanced Route Refresh Capability",
+			input: []byte{70, 0},
+			expected: Capability{
+				Code:  EnhancedRouteRefreshCapabilityCode,
+				Value: EnhancedRouteRefreshCapability{},
+			},
+		},
 		{
 			name: "Extended Next Hop",
 			input: []byte{
@@ -24,6 +24,18 @@ func SerializeNotificationMsg(msg *BGPNotification) []byte {
 	return buf.Bytes()
 }
 
+// SerializeRouteRefreshMsg serializes a ROUTE-REFRESH message
+func SerializeRouteRefreshMsg(msg *BGPRouteRefresh) []byte {
+	routeRefreshLen := uint16(HeaderLen + RouteRefreshLen)
+	buf := bytes.NewBuffer(make([]byte, 0, routeRefreshLen))
+	serializeHeader(buf, routeRefreshLen, RouteRefreshMsg)
+	buf.Write(convert.Uint16Byte(msg.AFI))
+	buf.WriteByte(msg.SubType)
+	buf.WriteByte(msg.SAFI)
+
+	return buf.Bytes()
+}
+
 func SerializeOpenMsg(msg *BGPOpen) []byte {
 	optParamsBuf := bytes.NewBuffer(make([]byte, 0))
 	serializeOptParams(optParamsBuf, msg.OptParams)
@@ -60,6 +60,36 @@ func TestSerializeNotificationMsg(t *testing.T) {
 	}
 }
 
+func TestSerializeRouteRefreshMsg(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *BGPRouteRefresh
+		expected []byte
+	}{
+		{
+			name: "IPv6 unicast EoRR",
+			input: &BGPRouteRefresh{
+				AFI:     AFIIPv6,
+				SubType: EndOfRouteRefresh,
+				SAFI:    SAFIUnicast,
+			},
+			expected: []byte{
+				0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+				0x00, 0x17, // Length
+				0x05,       // Type
+				0x00, 0x02, // AFI
+				0x02, // Subtype
+				0x01, // SAFI
+			},
+		},
+	}
+
+	for _, test := range tests {
+		res := SerializeRouteRefreshMsg(test.input)
+		assert.Equal(t, test.expected, res, test.name)
+	}
+}
+
 func TestSerializeOpenMsg(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -155,6 +185,31 @@ func TestSerializeOptParams(t *testing.T) {
 			},
 			expected: []byte{2, 12, 1, 4, 0, 2, 0, 1, 65, 4, 0x00, 0x03, 0x17, 0xf3},
 		},
+		{
+			name: "Graceful Restart",
+			optParams: []OptParam{
+				{
+					Type: CapabilitiesParamType,
+					Value: Capabilities{
+						Capability{
+							Code: GracefulRestartCapabilityCode,
+							Value: GracefulRestartCapability{
+								RestartState: true,
+								RestartTime:  120,
+								AddressFamilies: []GracefulRestartCapabilityTuple{
+									{
+										AFI:             AFIIPv6,
+										SAFI:            SAFIUnicast,
+										ForwardingState: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expected: []byte{2, 8, 64, 6, 0x80, 0x78, 0, 2, 1, 0x80},
+		},
 		{
 			name: "PeerRole",
 			optParams: []OptParam{
@@ -0,0 +1,344 @@
+package packet
+
+import (
+	"bytes"
+	"fmt"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
+	"github.com/bio-routing/tflow2/convert"
+)
+
+const (
+	// FlowSpec NLRIs of at least this length use a two octet length field (RFC8955 Sect. 4.1)
+	flowSpecExtendedLengthThreshold = 0xf0
+
+	// FlowSpec operator bits (RFC8955 Sect. 4.2.1)
+	flowSpecOpEndOfList   = 0x80
+	flowSpecOpAnd         = 0x40
+	flowSpecOpLenMask     = 0x30
+	flowSpecOpLessThan    = 0x04
+	flowSpecOpGreaterThan = 0x02
+	flowSpecOpEqual       = 0x01
+	flowSpecOpNot         = 0x02
+	flowSpecOpMatch       = 0x01
+
+	flowSpecNumericReservedMask = 0x08
+	flowSpecBitmaskReservedMask = 0x0c
+)
+
+func decodeFlowSpecNLRI(buf *bytes.Buffer, afi uint16) (*NLRI, uint16, error) {
+	length, consumed, err := decodeFlowSpecLength(buf)
+	if err != nil {
+		return nil, consumed, err
+	}
+
+	if buf.Len() < int(length) {
+		return nil, consumed, fmt.Errorf("expected %d bytes for FlowSpec NLRI, only %d remaining", length, buf.Len())
+	}
+
+	rule, err := decodeFlowSpecRule(bytes.NewBuffer(buf.Next(int(length))), afi)
+	if err != nil {
+		return nil, consumed + length, err
+	}
+
+	return &NLRI{
+		Prefix:   rule.RoutePrefix(afi == AFIIPv6),
+		FlowSpec: rule,
+	}, consumed + length, nil
+}
+
+func decodeFlowSpecLength(buf *bytes.Buffer) (uint16, uint16, error) {
+	b, err := buf.ReadByte()
+	if err != nil {
+		return 0, 0, fmt.Errorf("unable to read FlowSpec NLRI length: %w", err)
+	}
+
+	if b < flowSpecExtendedLengthThreshold {
+		return uint16(b), 1, nil
+	}
+
+	lower, err := buf.ReadByte()
+	if err != nil {
+		return 0, 1, fmt.Errorf("unable to read FlowSpec NLRI length: %w", err)
+	}
+
+	return uint16(b&0x0f)<<8 | uint16(lower), 2, nil
+}
+
+func decodeFlowSpecRule(buf *bytes.Buffer, afi uint16) (*types.FlowSpecRule, error) {
+	rule := &types.FlowSpecRule{
+		Components: make([]types.FlowSpecComponent, 0),
+	}
+
+	for buf.Len() > 0 {
+		t, _ := buf.ReadByte()
+
+		// RFC8955 Sect. 4.2: Components must follow strict type ordering by increasing numerical order
+		if len(rule.Components) > 0 && t <= rule.Components[len(rule.Components)-1].Type {
+			return nil, fmt.Errorf("FlowSpec component type %d out of order", t)
+		}
+
+		c, err := decodeFlowSpecComponent(buf, afi, t)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode FlowSpec component type %d: %w", t, err)
+		}
+
+		rule.Components = append(rule.Components, c)
+	}
+
+	if len(rule.Components) == 0 {
+		return nil, fmt.Errorf("FlowSpec NLRI without components")
+	}
+
+	return rule, nil
+}
+
+func decodeFlowSpecComponent(buf *bytes.Buffer, afi uint16, t uint8) (types.FlowSpecComponent, error) {
+	if t < types.FlowSpecComponentDestinationPrefix || t > types.FlowSpecComponentFlowLabel ||
+		(t == types.FlowSpecComponentFlowLabel && afi != AFIIPv6) {
+		return types.FlowSpecComponent{}, fmt.Errorf("unknown component type")
+	}
+
+	if types.IsPrefixComponent(t) {
+		return decodeFlowSpecPrefixComponent(buf, afi, t)
+	}
+
+	c := types.FlowSpecComponent{
+		Type:       t,
+		Operations: make([]types.FlowSpecOperation, 0, 1),
+	}
+
+	for {
+		op, err := buf.ReadByte()
+		if err != nil {
+			return c, fmt.Errorf("unable to read operator: %w", err)
+		}
+
+		o, err := decodeFlowSpecOperation(buf, op, types.IsBitmaskComponent(t))
+		if err != nil {
+			return c, err
+		}
+
+		c.Operations = append(c.Operations, o)
+
+		if op&flowSpecOpEndOfList != 0 {
+			return c, nil
+		}
+	}
+}
+
+func decodeFlowSpecOperation(buf *bytes.Buffer, op uint8, bitmask bool) (types.FlowSpecOperation, error) {
+	o := types.FlowSpecOperation{
+		And: op&flowSpecOpAnd != 0,
+	}
+
+	if bitmask {
+		if op&flowSpecBitmaskReservedMask != 0 {
+			return o, fmt.Errorf("reserved bits set in bitmask operator 0x%02x", op)
+		}
+
+		o.Not = op&flowSpecOpNot != 0
+		o.Match = op&flowSpecOpMatch != 0
+	} else {
+		if op&flowSpecNumericReservedMask != 0 {
+			return o, fmt.Errorf("reserved bit set in numeric operator 0x%02x", op)
+		}
+
+		o.LessThan = op&flowSpecOpLessThan != 0
+		o.GreaterThan = op&flowSpecOpGreaterThan != 0
+		o.Equal = op&flowSpecOpEqual != 0
+	}
+
+	valueLen := 1 << ((op & flowSpecOpLenMask) >> 4)
+	if buf.Len() < valueLen {
+		return o, fmt.Errorf("expected %d bytes for operator value, only %d remaining", valueLen, buf.Len())
+	}
+
+	for _, b := range buf.Next(valueLen) {
+		o.Value = o.Value<<8 | uint64(b)
+	}
+
+	return o, nil
+}
+
+func decodeFlowSpecPrefixComponent(buf *bytes.Buffer, afi uint16, t uint8) (types.FlowSpecComponent, error) {
+	c := types.FlowSpecComponent{
+		Type: t,
+	}
+
+	pfxLen, err := buf.ReadByte()
+	if err != nil {
+		return c, fmt.Errorf("unable to read prefix length: %w", err)
+	}
+
+	if afi == AFIIPv4 {
+		if pfxLen > 32 {
+			return c, fmt.Errorf("invalid IPv4 prefix length %d", pfxLen)
+		}
+
+		numBytes := int(bnet.BytesInAddr(pfxLen))
+		if buf.Len() < numBytes {
+			return c, fmt.Errorf("expected %d bytes for prefix, only %d remaining", numBytes, buf.Len())
+		}
+
+		c.Prefix, err = deserializePrefix(buf.Next(numBytes), pfxLen, afi)
+		return c, err
+	}
+
+	// RFC8956 Sect. 3.1: IPv6 prefix components carry an offset and the bits of the prefix from offset to length
+	offset, err := buf.ReadByte()
+	if err != nil {
+		return c, fmt.Errorf("unable to read prefix offset: %w", err)
+	}
+
+	if pfxLen > 128 || offset > pfxLen {
+		return c, fmt.Errorf("invalid IPv6 prefix length %d with offset %d", pfxLen, offset)
+	}
+
+	numBytes := int(bnet.BytesInAddr(pfxLen - offset))
+	if buf.Len() < numBytes {
+		return c, fmt.Errorf("expected %d bytes for prefix pattern, only %d remaining", numBytes, buf.Len())
+	}
+
+	pattern := buf.Next(numBytes)
+	addr := make([]byte, IPv6Len)
+	for i := 0; i < int(pfxLen-offset); i++ {
+		if pattern[i/8]&(0x80>>(i%8)) == 0 {
+			continue
+		}
+
+		bit := int(offset) + i
+		addr[bit/8] |= 0x80 >> (bit % 8)
+	}
+
+	ip, err := bnet.IPFromBytes(addr)
+	if err != nil {
+		return c, err
+	}
+
+	c.Prefix = bnet.NewPfx(ip, pfxLen).Dedup()
+	c.Offset = offset
+	return c, nil
+}
+
+func (n *NLRI) serializeFlowSpec(buf *bytes.Buffer) uint16 {
+	tempBuf := bytes.NewBuffer(nil)
+	for _, c := range n.FlowSpec.Components {
+		serializeFlowSpecComponent(tempBuf, &c)
+	}
+
+	// Rules received from peers never exceed the maximum length as we encode values with the minimal length
+	length := uint16(tempBuf.Len())
+	numBytes := uint16(1)
+	if length < flowSpecExtendedLengthThreshold {
+		buf.WriteByte(uint8(length))
+	} else {
+		buf.Write(convert.Uint16Byte(0xf000 | length))
+		numBytes++
+	}
+
+	buf.Write(tempBuf.Bytes())
+	return numBytes + length
+}
+
+func serializeFlowSpecComponent(buf *bytes.Buffer, c *types.FlowSpecComponent) {
+	buf.WriteByte(c.Type)
+
+	if types.IsPrefixComponent(c.Type) {
+		serializeFlowSpecPrefix(buf, c)
+		return
+	}
+
+	for i, o := range c.Operations {
+		serializeFlowSpecOperation(buf, &o, types.IsBitmaskComponent(c.Type), i == len(c.Operations)-1)
+	}
+}
+
+func serializeFlowSpecPrefix(buf *bytes.Buffer, c *types.FlowSpecComponent) {
+	buf.WriteByte(c.Prefix.Len())
+
+	if c.Prefix.Addr().IsIPv4() {
+		buf.Write(c.Prefix.Addr().Bytes()[:c.Prefix.BytesInPrefix()])
+		return
+	}
+
+	buf.WriteByte(c.Offset)
+
+	addr := c.Prefix.Addr().Bytes()
+	patternLen := int(c.Prefix.Len() - c.Offset)
+	pattern := make([]byte, bnet.BytesInAddr(uint8(patternLen)))
+	for i := 0; i < patternLen; i++ {
+		bit := int(c.Offset) + i
+		if addr[bit/8]&(0x80>>(bit%8)) == 0 {
+			continue
+		}
+
+		pattern[i/8] |= 0x80 >> (i % 8)
+	}
+
+	buf.Write(pattern)
+}
+
+func serializeFlowSpecOperation(buf *bytes.Buffer, o *types.FlowSpecOperation, bitmask bool, last bool) {
+	op := uint8(0)
+	if last {
+		op |= flowSpecOpEndOfList
+	}
+
+	if o.And {
+		op |= flowSpecOpAnd
+	}
+
+	if bitmask {
+		if o.Not {
+			op |= flowSpecOpNot
+		}
+
+		if o.Match {
+			op |= flowSpecOpMatch
+		}
+	} else {
+		if o.LessThan {
+			op |= flowSpecOpLessThan
+		}
+
+		if o.GreaterThan {
+			op |= flowSpecOpGreaterThan
+		}
+
+		if o.Equal {
+			op |= flowSpecOpEqual
+		}
+	}
+
+	valueLen, lenBits := flowSpecValueLength(o.Value)
+	buf.WriteByte(op | lenBits<<4)
+
+	for i := valueLen - 1; i >= 0; i-- {
+		buf.WriteByte(uint8(o.Value >> (8 * i)))
+	}
+}
+
+// flowSpecValueLength returns the smallest possible length of an operator value in bytes and its encoding
+func flowSpecValueLength(v uint64) (int, uint8) {
+	switch {
+	case v <= 0xff:
+		return 1, 0
+	case v <= 0xffff:
+		return 2, 1
+	case v <= 0xffffffff:
+		return 4, 2
+	}
+
+	return 8, 3
+}
+
+// FlowSpecNLRILength returns the number of bytes needed to serialize the FlowSpec NLRI of rule
+func FlowSpecNLRILength(rule *types.FlowSpecRule) int {
+	n := &NLRI{
+		FlowSpec: rule,
+	}
+
+	return int(n.serializeFlowSpec(bytes.NewBuffer(nil)))
+}
@@ -0,0 +1,209 @@
+package packet
+
+import (
+	"bytes"
+	"testing"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlowSpecNLRI(t *testing.T) {
+	longRule := &types.FlowSpecRule{
+		Components: []types.FlowSpecComponent{
+			{
+				Type:       types.FlowSpecComponentPacketLength,
+				Operations: make([]types.FlowSpecOperation, 0),
+			},
+		},
+	}
+	longRuleBytes := []byte{0xf0, 0xf1, 0x0a}
+	for i := 0; i < 120; i++ {
+		longRule.Components[0].Operations = append(longRule.Components[0].Operations, types.FlowSpecOperation{
+			Equal: true,
+			Value: uint64(i),
+		})
+
+		op := uint8(0x01)
+		if i == 119 {
+			op |= 0x80
+		}
+		longRuleBytes = append(longRuleBytes, op, uint8(i))
+	}
+
+	tests := []struct {
+		name     string
+		afi      uint16
+		input    []byte
+		expected *NLRI
+		wantFail bool
+	}{
+		{
+			name: "IPv4 rule with numeric and bitmask components",
+			afi:  AFIIPv4,
+			input: []byte{
+				0x14,                         // Length
+				0x01, 0x18, 0xc0, 0x00, 0x02, // Destination prefix
+				0x03, 0x81, 0x06, // IP protocol =6
+				0x05, 0x01, 0x50, 0x13, 0x04, 0x00, 0xd5, 0x08, 0x00, // Destination port =80,>=1024&<=2048
+				0x09, 0x81, 0x02, // TCP flags =SYN
+			},
+			expected: &NLRI{
+				Prefix: bnet.NewPfx(bnet.IPv4FromOctets(192, 0, 2, 0), 24).Ptr(),
+				FlowSpec: &types.FlowSpecRule{
+					Components: []types.FlowSpecComponent{
+						{
+							Type:   types.FlowSpecComponentDestinationPrefix,
+							Prefix: bnet.NewPfx(bnet.IPv4FromOctets(192, 0, 2, 0), 24).Ptr(),
+						},
+						{
+							Type:       types.FlowSpecComponentIPProtocol,
+							Operations: []types.FlowSpecOperation{{Equal: true, Value: 6}},
+						},
+						{
+							Type: types.FlowSpecComponentDestinationPort,
+							Operations: []types.FlowSpecOperation{
+								{Equal: true, Value: 80},
+								{GreaterThan: true, Equal: true, Value: 1024},
+								{And: true, LessThan: true, Equal: true, Value: 2048},
+							},
+						},
+						{
+							Type:       types.FlowSpecComponentTCPFlags,
+							Operations: []types.FlowSpecOperation{{Match: true, Value: 0x02}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "IPv6 rule with prefix offset and flow label",
+			afi:  AFIIPv6,
+			input: []byte{
+				0x12,                                     // Length
+				0x01, 0x20, 0x00, 0x20, 0x01, 0x0d, 0xb8, // Destination prefix
+				0x02, 0x40, 0x20, 0x12, 0x34, 0x56, 0x78, // Source prefix with offset
+				0x0d, 0x91, 0x03, 0xe8, // Flow label =1000
+			},
+			expected: &NLRI{
+				Prefix: bnet.NewPfx(bnet.IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 0), 32).Ptr(),
+				FlowSpec: &types.FlowSpecRule{
+					Components: []types.FlowSpecComponent{
+						{
+							Type:   types.FlowSpecComponentDestinationPrefix,
+							Prefix: bnet.NewPfx(bnet.IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 0), 32).Ptr(),
+						},
+						{
+							Type:   types.FlowSpecComponentSourcePrefix,
+							Prefix: bnet.NewPfx(bnet.IPv6FromBlocks(0, 0, 0x1234, 0x5678, 0, 0, 0, 0), 64).Ptr(),
+							Offset: 32,
+						},
+						{
+							Type:       types.FlowSpecComponentFlowLabel,
+							Operations: []types.FlowSpecOperation{{Equal: true, Value: 1000}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:  "Rule without destination prefix and two octet length",
+			afi:   AFIIPv4,
+			input: longRuleBytes,
+			expected: &NLRI{
+				Prefix:   bnet.NewPfx(bnet.IPv4(0), 0).Ptr(),
+				FlowSpec: longRule,
+			},
+		},
+		{
+			name: "Components out of order",
+			afi:  AFIIPv4,
+			input: []byte{
+				0x06,
+				0x05, 0x81, 0x50,
+				0x03, 0x81, 0x06,
+			},
+			wantFail: true,
+		},
+		{
+			name: "Flow label in IPv4 rule",
+			afi:  AFIIPv4,
+			input: []byte{
+				0x04,
+				0x0d, 0x91, 0x03, 0xe8,
+			},
+			wantFail: true,
+		},
+		{
+			name: "Reserved bit set in numeric operator",
+			afi:  AFIIPv4,
+			input: []byte{
+				0x03,
+				0x03, 0x89, 0x06,
+			},
+			wantFail: true,
+		},
+		{
+			name: "Truncated operator value",
+			afi:  AFIIPv4,
+			input: []byte{
+				0x03,
+				0x05, 0x91, 0x50,
+			},
+			wantFail: true,
+		},
+		{
+			name: "Invalid IPv4 prefix length",
+			afi:  AFIIPv4,
+			input: []byte{
+				0x07,
+				0x01, 0x21, 0xc0, 0x00, 0x02, 0x00, 0x00,
+			},
+			wantFail: true,
+		},
+		{
+			name: "IPv6 prefix offset larger than length",
+			afi:  AFIIPv6,
+			input: []byte{
+				0x03,
+				0x01, 0x20, 0x40,
+			},
+			wantFail: true,
+		},
+		{
+			name:     "Rule without components",
+			afi:      AFIIPv4,
+			input:    []byte{0x00},
+			wantFail: true,
+		},
+		{
+			name:     "Length exceeding the buffer",
+			afi:      AFIIPv4,
+			input:    []byte{0x05, 0x03, 0x81, 0x06},
+			wantFail: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			nlri, consumed, err := decodeFlowSpecNLRI(bytes.NewBuffer(test.input), test.afi)
+			if test.wantFail {
+				assert.Error(t, err)
+				return
+			}
+
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			assert.Equal(t, test.expected, nlri)
+			assert.Equal(t, uint16(len(test.input)), consumed)
+
+			buf := bytes.NewBuffer(nil)
+			assert.Equal(t, uint16(len(test.input)), nlri.serializeFlowSpec(buf))
+			assert.Equal(t, test.input, buf.Bytes())
+			assert.Equal(t, len(test.input), FlowSpecNLRILength(nlri.FlowSpec))
+		})
+	}
+}
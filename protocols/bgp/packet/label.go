@@ -12,6 +12,9 @@ const lengthEXPAndBottomOfStack = 0x04
 
 type LabelStackEntry uint32
 
+// WithdrawLabelStackEntry is the compatibility label used in withdrawals (RFC8277 Sect. 2.4)
+const WithdrawLabelStackEntry = LabelStackEntry(0x800000)
+
 // NewLabelStackEntry creates a new label stack entry
 func NewLabelStackEntry(labelValue uint32) LabelStackEntry {
 	return LabelStackEntry(labelValue << lengthEXPAndBottomOfStack)
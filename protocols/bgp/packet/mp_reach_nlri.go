@@ -18,18 +18,27 @@ type MultiProtocolReachNLRI struct {
 }
 
 func (n *MultiProtocolReachNLRI) serialize(buf *bytes.Buffer, opt *EncodeOptions) uint16 {
-	nextHop := n.NextHop.Bytes()
+	// FlowSpec NLRIs are advertised without a next hop (RFC8955 Sect. 4)
+	var nextHop []byte
+	if n.NextHop != nil {
+		nextHop = n.NextHop.Bytes()
+	}
 
 	tempBuf := bytes.NewBuffer(nil)
 	tempBuf.Write(convert.Uint16Byte(n.AFI))
 	tempBuf.WriteByte(n.SAFI)
-	tempBuf.WriteByte(uint8(len(nextHop)))
+
+	if n.SAFI == SAFIMPLSVPN {
+		// RFC4364 Sect. 4.3.2: The next hop is encoded as VPN-IPv4/VPN-IPv6 address with an RD of 0
+		tempBuf.WriteByte(uint8(RouteDistinguisherLen + len(nextHop)))
+		tempBuf.Write(make([]byte, RouteDistinguisherLen))
+	} else {
+		tempBuf.WriteByte(uint8(len(nextHop)))
+	}
 	tempBuf.Write(nextHop)
 	tempBuf.WriteByte(0) // RESERVED
 
-	for cur := n.NLRI; cur != nil; cur = cur.Next {
-		cur.serialize(tempBuf, opt.UseAddPath, n.SAFI)
-	}
+	serializeNLRIs(tempBuf, n.NLRI, opt.UseAddPath, n.SAFI)
 
 	buf.Write(tempBuf.Bytes())
 
@@ -63,12 +72,22 @@ func deserializeMultiProtocolReachNLRI(b []byte, opt *DecodeOptions) (MultiProto
 			fmt.Errorf("failed to decode next hop IP: expected %d bytes for NLRI, only %d remaining", nextHopLength, budget)
 	}
 
+	if n.SAFI == SAFIFlowSpec && nextHopLength == 0 {
+		return n.decodeNLRIs(variable[1:], opt) // 1 <- RESERVED field
+	}
+
+	nextHopOffset := uint8(0)
 	firstNextHopLength := nextHopLength
-	if nextHopLength == 32 {
+	if n.SAFI == SAFIMPLSVPN {
+		nextHopOffset, firstNextHopLength, err = vpnNextHopOffsetAndLength(nextHopLength)
+		if err != nil {
+			return MultiProtocolReachNLRI{}, err
+		}
+	} else if nextHopLength == 32 {
 		// second next-hop is lladdr (see rfc2545 sec 3 par 2)
 		firstNextHopLength = 16
 	}
-	nh, err := bnet.IPFromBytes(variable[:firstNextHopLength])
+	nh, err := bnet.IPFromBytes(variable[nextHopOffset : nextHopOffset+firstNextHopLength])
 	if err != nil {
 		return MultiProtocolReachNLRI{}, fmt.Errorf("failed to decode next hop IP: %w", err)
 	}
@@ -79,9 +98,11 @@ func deserializeMultiProtocolReachNLRI(b []byte, opt *DecodeOptions) (MultiProto
 		return n, nil
 	}
 
-	variable = variable[1+nextHopLength:] // 1 <- RESERVED field
+	return n.decodeNLRIs(variable[1+nextHopLength:], opt) // 1 <- RESERVED field
+}
 
-	buf := bytes.NewBuffer(variable)
+func (n MultiProtocolReachNLRI) decodeNLRIs(b []byte, opt *DecodeOptions) (MultiProtocolReachNLRI, error) {
+	buf := bytes.NewBuffer(b)
 	nlri, err := decodeNLRIs(buf, uint16(buf.Len()), n.AFI, n.SAFI, opt.addPath(n.AFI, n.SAFI))
 	if err != nil {
 		return MultiProtocolReachNLRI{}, err
@@ -90,3 +111,16 @@ func deserializeMultiProtocolReachNLRI(b []byte, opt *DecodeOptions) (MultiProto
 
 	return n, nil
 }
+
+// vpnNextHopOffsetAndLength gets the position of the IP address within a VPN next hop (RFC4364, RFC4659)
+func vpnNextHopOffsetAndLength(nextHopLength uint8) (offset uint8, length uint8, err error) {
+	switch nextHopLength {
+	case RouteDistinguisherLen + IPv4Len, RouteDistinguisherLen + IPv6Len:
+		return RouteDistinguisherLen, nextHopLength - RouteDistinguisherLen, nil
+	case 2 * (RouteDistinguisherLen + IPv6Len):
+		// second next-hop is lladdr (see rfc4659 sec 3.2.1.1)
+		return RouteDistinguisherLen, IPv6Len, nil
+	}
+
+	return 0, 0, fmt.Errorf("invalid VPN next hop length: %d", nextHopLength)
+}
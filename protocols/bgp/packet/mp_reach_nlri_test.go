@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -78,6 +79,64 @@ func TestSerializeMultiProtocolReachNLRI(t *testing.T) {
 				192, 0, 2, // Prefix
 			},
 		},
+		{
+			name: "VPNv4",
+			nlri: MultiProtocolReachNLRI{
+				AFI:     AFIIPv4,
+				SAFI:    SAFIMPLSVPN,
+				NextHop: bnet.IPv4FromOctets(192, 0, 2, 1).Dedup(),
+				NLRI: &NLRI{
+					Prefix: bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 0), 24).Dedup(),
+					LabelStack: []LabelStackEntry{
+						NewLabelStackEntry(299824),
+					},
+					RouteDistinguisher: 65000<<32 + 100,
+				},
+			},
+			expected: []byte{
+				0x00, 0x01, // AFI
+				0x80,                                           // SAFI
+				0x0c,                                           // NextHop length
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // NextHop RD
+				192, 0, 2, 1, // NextHop
+				0x00,             // Reserved
+				112,              // Prefix Length + Label Stack + RD
+				0x49, 0x33, 0x01, // Label (bottom of stack)
+				0x00, 0x00, 0xfd, 0xe8, 0x00, 0x00, 0x00, 0x64, // RD
+				10, 0, 0, // Prefix
+			},
+		},
+		{
+			name: "IPv4 FlowSpec without next hop",
+			nlri: MultiProtocolReachNLRI{
+				AFI:  AFIIPv4,
+				SAFI: SAFIFlowSpec,
+				NLRI: &NLRI{
+					Prefix: bnet.NewPfx(bnet.IPv4FromOctets(192, 0, 2, 0), 24).Dedup(),
+					FlowSpec: &types.FlowSpecRule{
+						Components: []types.FlowSpecComponent{
+							{
+								Type:   types.FlowSpecComponentDestinationPrefix,
+								Prefix: bnet.NewPfx(bnet.IPv4FromOctets(192, 0, 2, 0), 24).Dedup(),
+							},
+							{
+								Type:       types.FlowSpecComponentIPProtocol,
+								Operations: []types.FlowSpecOperation{{Equal: true, Value: 17}},
+							},
+						},
+					},
+				},
+			},
+			expected: []byte{
+				0x00, 0x01, // AFI
+				0x85,                         // SAFI
+				0x00,                         // NextHop length
+				0x00,                         // Reserved
+				0x08,                         // NLRI length
+				0x01, 0x18, 0xc0, 0x00, 0x02, // Destination prefix
+				0x03, 0x81, 0x11, // IP protocol
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -20,9 +20,7 @@ func (n *MultiProtocolUnreachNLRI) serialize(buf *bytes.Buffer, opt *EncodeOptio
 	tempBuf.Write(convert.Uint16Byte(n.AFI))
 	tempBuf.WriteByte(n.SAFI)
 
-	for cur := n.NLRI; cur != nil; cur = cur.Next {
-		cur.serialize(tempBuf, opt.UseAddPath, n.SAFI)
-	}
+	serializeNLRIs(tempBuf, n.NLRI, opt.UseAddPath, n.SAFI)
 
 	buf.Write(tempBuf.Bytes())
 
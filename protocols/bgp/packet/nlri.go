@@ -6,6 +6,7 @@ import (
 
 	"github.com/bio-routing/bio-rd/net"
 	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
 	"github.com/bio-routing/bio-rd/util/decode"
 	"github.com/bio-routing/tflow2/convert"
 )
@@ -18,10 +19,15 @@ const (
 
 // NLRI represents a Network Layer Reachability Information
 type NLRI struct {
-	PathIdentifier uint32
-	LabelStack     []LabelStackEntry
-	Prefix         *bnet.Prefix
-	Next           *NLRI
+	PathIdentifier     uint32
+	LabelStack         []LabelStackEntry
+	RouteDistinguisher uint64
+	Prefix             *bnet.Prefix
+
+	// FlowSpec holds the rule of FlowSpec NLRIs. Prefix is set to the destination prefix of the rule
+	// or the default route if the rule does not match on the destination.
+	FlowSpec *types.FlowSpecRule
+	Next     *NLRI
 }
 
 func decodeNLRIs(buf *bytes.Buffer, length uint16, afi uint16, safi uint8, addPath bool) (*NLRI, error) {
@@ -29,15 +35,15 @@ func decodeNLRIs(buf *bytes.Buffer, length uint16, afi uint16, safi uint8, addPa
 	var eol *NLRI
 	var nlri *NLRI
 	var err error
-	var consumed uint8
+	var consumed uint16
 	p := uint16(0)
 
 	for p < length {
-		nlri, consumed, err = decodeNLRI(buf, afi, safi, addPath)
+		nlri, consumed, err = decodeNLRIOfSAFI(buf, afi, safi, addPath)
 		if err != nil {
 			return nil, fmt.Errorf("unable to decode NLRI: %w", err)
 		}
-		p += uint16(consumed)
+		p += consumed
 
 		if ret == nil {
 			ret = nlri
@@ -52,6 +58,15 @@ func decodeNLRIs(buf *bytes.Buffer, length uint16, afi uint16, safi uint8, addPa
 	return ret, nil
 }
 
+func decodeNLRIOfSAFI(buf *bytes.Buffer, afi uint16, safi uint8, addPath bool) (*NLRI, uint16, error) {
+	if safi == SAFIFlowSpec {
+		return decodeFlowSpecNLRI(buf, afi)
+	}
+
+	nlri, consumed, err := decodeNLRI(buf, afi, safi, addPath)
+	return nlri, uint16(consumed), err
+}
+
 func decodeNLRI(buf *bytes.Buffer, afi uint16, safi uint8, addPath bool) (*NLRI, uint8, error) {
 	nlri := &NLRI{}
 
@@ -74,9 +89,13 @@ func decodeNLRI(buf *bytes.Buffer, afi uint16, safi uint8, addPath bool) (*NLRI,
 	}
 	consumed++
 
-	if safi == SAFILabeledUnicast {
+	if hasLabelStack(safi) {
 		nlri.LabelStack = make([]LabelStackEntry, 0, 1)
 		for {
+			if pfxLen < BitsPerLabel {
+				return nil, consumed, fmt.Errorf("prefix length %d too short for label stack", pfxLen)
+			}
+
 			lse, err := decodeLabelStackEntry(buf)
 			if err != nil {
 				return nil, consumed, fmt.Errorf("decode label stack entry failed: %w", err)
@@ -86,12 +105,29 @@ func decodeNLRI(buf *bytes.Buffer, afi uint16, safi uint8, addPath bool) (*NLRI,
 			pfxLen -= BitsPerLabel
 			nlri.LabelStack = append(nlri.LabelStack, lse)
 
-			if lse.isBottomOfStack() {
+			// RFC8277 Sect. 2.4: Withdrawals may carry the compatibility label instead of a label stack
+			if lse.isBottomOfStack() || lse == WithdrawLabelStackEntry {
 				break
 			}
 		}
 	}
 
+	if safi == SAFIMPLSVPN {
+		if pfxLen < RouteDistinguisherLen*OctetLen {
+			return nil, consumed, fmt.Errorf("prefix length %d too short for route distinguisher", pfxLen)
+		}
+
+		err := decode.Decode(buf, []interface{}{
+			&nlri.RouteDistinguisher,
+		})
+		if err != nil {
+			return nil, consumed, fmt.Errorf("unable to decode route distinguisher: %w", err)
+		}
+
+		consumed += RouteDistinguisherLen
+		pfxLen -= RouteDistinguisherLen * OctetLen
+	}
+
 	numBytes := uint8(net.BytesInAddr(pfxLen))
 	bytes := make([]byte, numBytes)
 
@@ -113,6 +149,17 @@ func decodeNLRI(buf *bytes.Buffer, afi uint16, safi uint8, addPath bool) (*NLRI,
 	return nlri, consumed, nil
 }
 
+func serializeNLRIs(buf *bytes.Buffer, nlri *NLRI, addPath bool, safi uint8) {
+	for cur := nlri; cur != nil; cur = cur.Next {
+		if safi == SAFIFlowSpec {
+			cur.serializeFlowSpec(buf)
+			continue
+		}
+
+		cur.serialize(buf, addPath, safi)
+	}
+}
+
 func (n *NLRI) serialize(buf *bytes.Buffer, addPath bool, safi uint8) uint8 {
 	numBytes := uint8(0)
 
@@ -121,25 +168,44 @@ func (n *NLRI) serialize(buf *bytes.Buffer, addPath bool, safi uint8) uint8 {
 		numBytes += 4
 	}
 
+	labelStack := n.LabelStack
+	if safi == SAFIMPLSVPN && len(labelStack) == 0 {
+		// RFC8277 Sect. 2.4: Withdrawals without a label carry the compatibility label
+		labelStack = []LabelStackEntry{WithdrawLabelStackEntry}
+	}
+
 	pfxLen := n.Prefix.Len()
-	if safi == SAFILabeledUnicast {
-		pfxLen += uint8(len(n.LabelStack) * BitsPerLabel)
+	if hasLabelStack(safi) {
+		pfxLen += uint8(len(labelStack) * BitsPerLabel)
+	}
+
+	if safi == SAFIMPLSVPN {
+		pfxLen += RouteDistinguisherLen * OctetLen
 	}
 
 	buf.WriteByte(pfxLen)
 	numBytes++
 
-	if safi == SAFILabeledUnicast {
-		labelCount := len(n.LabelStack)
-		for i, l := range n.LabelStack {
-			l.serialize(buf, i == labelCount-1)
+	if hasLabelStack(safi) {
+		labelCount := len(labelStack)
+		for i, l := range labelStack {
+			l.serialize(buf, i == labelCount-1 && l != WithdrawLabelStackEntry)
 			numBytes += BytesPerLabel
 		}
 	}
 
+	if safi == SAFIMPLSVPN {
+		buf.Write(convert.Uint64Byte(n.RouteDistinguisher))
+		numBytes += RouteDistinguisherLen
+	}
+
 	pfxNumBytes := n.Prefix.BytesInPrefix()
 	buf.Write(n.Prefix.Addr().Bytes()[:pfxNumBytes])
 	numBytes += pfxNumBytes
 
 	return numBytes
 }
+
+func hasLabelStack(safi uint8) bool {
+	return safi == SAFILabeledUnicast || safi == SAFIMPLSVPN
+}
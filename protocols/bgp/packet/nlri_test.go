@@ -139,6 +139,52 @@ func TestDecodeNLRI(t *testing.T) {
 				Prefix: bnet.NewPfx(bnet.IPv4FromOctets(5, 193, 0, 0), 18).Dedup(),
 			},
 		},
+		{
+			name: "VPNv4 NLRI",
+			safi: SAFIMPLSVPN,
+			input: []byte{
+				112,              // prefix + label stack + route distinguisher length
+				0x49, 0x33, 0x01, // MPLS label
+				0x00, 0x00, 0xfd, 0xe8, 0x00, 0x00, 0x00, 0x64, // RD 65000:100
+				10, 0, 0, // 10.0.0.0/24 (112 - 24 - 64 = 24)
+			},
+			wantFail: false,
+			expected: &NLRI{
+				LabelStack: []LabelStackEntry{
+					0x00493301,
+				},
+				RouteDistinguisher: 65000<<32 + 100,
+				Prefix:             bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 0), 24).Dedup(),
+			},
+		},
+		{
+			name: "VPNv4 NLRI withdraw with compatibility label",
+			safi: SAFIMPLSVPN,
+			input: []byte{
+				112,              // prefix + label stack + route distinguisher length
+				0x80, 0x00, 0x00, // compatibility label
+				0x00, 0x00, 0xfd, 0xe8, 0x00, 0x00, 0x00, 0x64, // RD 65000:100
+				10, 0, 0, // 10.0.0.0/24
+			},
+			wantFail: false,
+			expected: &NLRI{
+				LabelStack: []LabelStackEntry{
+					WithdrawLabelStackEntry,
+				},
+				RouteDistinguisher: 65000<<32 + 100,
+				Prefix:             bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 0), 24).Dedup(),
+			},
+		},
+		{
+			name: "VPNv4 NLRI too short for route distinguisher",
+			safi: SAFIMPLSVPN,
+			input: []byte{
+				48,               // prefix + label stack length
+				0x49, 0x33, 0x01, // MPLS label
+				0x00, 0x00, 0xfd, 0xe8, 0x00, 0x00, 0x00, 0x64,
+			},
+			wantFail: true,
+		},
 		{
 			name: "Valid NRLI #1",
 			input: []byte{
@@ -330,6 +376,37 @@ func TestNLRISerialize(t *testing.T) {
 			safi:     SAFILabeledUnicast,
 			expected: []byte{17 + 24 + 24, 0x49, 0x33, 0x00, 0x49, 0x33, 0x11, 100, 200, 128},
 		},
+		{
+			name: "VPNv4",
+			nlri: &NLRI{
+				Prefix: bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 0), 24).Dedup(),
+				LabelStack: []LabelStackEntry{
+					NewLabelStackEntry(299824),
+				},
+				RouteDistinguisher: 65000<<32 + 100,
+			},
+			safi: SAFIMPLSVPN,
+			expected: []byte{
+				24 + 24 + 64,
+				0x49, 0x33, 0x01,
+				0x00, 0x00, 0xfd, 0xe8, 0x00, 0x00, 0x00, 0x64,
+				10, 0, 0,
+			},
+		},
+		{
+			name: "VPNv4 withdraw without label",
+			nlri: &NLRI{
+				Prefix:             bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 0), 24).Dedup(),
+				RouteDistinguisher: 65000<<32 + 100,
+			},
+			safi: SAFIMPLSVPN,
+			expected: []byte{
+				24 + 24 + 64,
+				0x80, 0x00, 0x00,
+				0x00, 0x00, 0xfd, 0xe8, 0x00, 0x00, 0x00, 0x64,
+				10, 0, 0,
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -109,3 +109,61 @@ func (e ExtendedNextHopCapability) serialize(buf *bytes.Buffer) {
 }
 
 type ExtendedNextHopCapability []ExtendedNextHopCapabilityTuple
+
+// RouteRefreshCapability signals support for the ROUTE-REFRESH message (RFC2918)
+type RouteRefreshCapability struct{}
+
+func (r RouteRefreshCapability) serialize(buf *bytes.Buffer) {}
+
+// EnhancedRouteRefreshCapability signals support for BoRR/EoRR demarcation (RFC7313)
+type EnhancedRouteRefreshCapability struct{}
+
+func (e EnhancedRouteRefreshCapability) serialize(buf *bytes.Buffer) {}
+
+// GracefulRestartCapability represents the Graceful Restart capability (RFC4724)
+type GracefulRestartCapability struct {
+	RestartState    bool
+	RestartTime     uint16
+	AddressFamilies []GracefulRestartCapabilityTuple
+}
+
+// GracefulRestartCapabilityTuple represents an address family for which Graceful Restart is supported
+type GracefulRestartCapabilityTuple struct {
+	AFI             uint16
+	SAFI            uint8
+	ForwardingState bool
+}
+
+func (g GracefulRestartCapability) serialize(buf *bytes.Buffer) {
+	flagsAndTime := g.RestartTime & GracefulRestartTimeMask
+	if g.RestartState {
+		flagsAndTime |= GracefulRestartRestartStateFlag
+	}
+
+	buf.Write(convert.Uint16Byte(flagsAndTime))
+	for _, af := range g.AddressFamilies {
+		af.serialize(buf)
+	}
+}
+
+func (g GracefulRestartCapabilityTuple) serialize(buf *bytes.Buffer) {
+	flags := uint8(0)
+	if g.ForwardingState {
+		flags |= GracefulRestartForwardingStateFlag
+	}
+
+	buf.Write(convert.Uint16Byte(g.AFI))
+	buf.WriteByte(g.SAFI)
+	buf.WriteByte(flags)
+}
+
+// AddressFamily returns the tuple for the given AFI/SAFI or nil if the family is not present
+func (g *GracefulRestartCapability) AddressFamily(afi uint16, safi uint8) *GracefulRestartCapabilityTuple {
+	for i := range g.AddressFamilies {
+		if g.AddressFamilies[i].AFI == afi && g.AddressFamilies[i].SAFI == safi {
+			return &g.AddressFamilies[i]
+		}
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package packet
 
 import (
 	"bytes"
+	"encoding/binary"
 	"fmt"
 	"math"
 
@@ -125,7 +126,12 @@ func decodePathAttr(buf *bytes.Buffer, opt *DecodeOptions) (pa *PathAttribute, c
 			return nil, consumed, fmt.Errorf("failed to decode local pref: %w", err)
 		}
 	case AggregatorAttr:
-		if err := pa.decodeAggregator(buf); err != nil {
+		asnLength := uint8(2)
+		if opt.Use32BitASN {
+			asnLength = 4
+		}
+
+		if err := pa.decodeAggregator(buf, asnLength); err != nil {
 			return nil, consumed, fmt.Errorf("failed to decode Aggregator: %w", err)
 		}
 	case AtomicAggrAttr:
@@ -134,6 +140,14 @@ func decodePathAttr(buf *bytes.Buffer, opt *DecodeOptions) (pa *PathAttribute, c
 		if err := pa.decodeCommunities(buf); err != nil {
 			return nil, consumed, fmt.Errorf("failed to decode Community: %w", err)
 		}
+	case ExtendedCommunitiesAttr:
+		if err := pa.decodeExtendedCommunities(buf); err != nil {
+			return nil, consumed, fmt.Errorf("failed to decode extended communities: %w", err)
+		}
+	case IPv6ExtendedCommunitiesAttr:
+		if err := pa.decodeIPv6ExtendedCommunities(buf); err != nil {
+			return nil, consumed, fmt.Errorf("failed to decode IPv6 address specific extended communities: %w", err)
+		}
 	case OriginatorIDAttr:
 		if err := pa.decodeOriginatorID(buf); err != nil {
 			return nil, consumed, fmt.Errorf("failed to decode OriginatorID: %w", err)
@@ -151,8 +165,8 @@ func decodePathAttr(buf *bytes.Buffer, opt *DecodeOptions) (pa *PathAttribute, c
 			return nil, consumed, fmt.Errorf("failed to multi protocol unreachable NLRI: %w", err)
 		}
 	case AS4AggregatorAttr:
-		if err := pa.decodeAS4Aggregator(buf); err != nil {
-			return nil, consumed, fmt.Errorf("failed to skip not supported AS4Aggregator: %w", err)
+		if err := pa.decodeAggregator(buf, 4); err != nil {
+			return nil, consumed, fmt.Errorf("failed to decode AS4Aggregator: %w", err)
 		}
 	case LargeCommunitiesAttr:
 		if err := pa.decodeLargeCommunities(buf); err != nil {
@@ -339,15 +353,24 @@ func (pa *PathAttribute) decodeLocalPref(buf *bytes.Buffer) error {
 	return nil
 }
 
-func (pa *PathAttribute) decodeAggregator(buf *bytes.Buffer) error {
+func (pa *PathAttribute) decodeAggregator(buf *bytes.Buffer, asnLength uint8) error {
 	aggr := types.Aggregator{}
-	p := uint16(0)
+	p := uint16(asnLength) + 4
 
-	err := decode.Decode(buf, []interface{}{&aggr.ASN, &aggr.Address})
-	if err != nil {
-		return err
+	if asnLength == 4 {
+		err := decode.Decode(buf, []interface{}{&aggr.ASN, &aggr.Address})
+		if err != nil {
+			return err
+		}
+	} else {
+		asn := uint16(0)
+		err := decode.Decode(buf, []interface{}{&asn, &aggr.Address})
+		if err != nil {
+			return err
+		}
+		aggr.ASN = uint32(asn)
 	}
-	p += 6
+
 	pa.Value = aggr
 	return dumpNBytes(buf, pa.Length-p)
 }
@@ -408,8 +431,60 @@ func (pa *PathAttribute) decodeLargeCommunities(buf *bytes.Buffer) error {
 	return nil
 }
 
-func (pa *PathAttribute) decodeAS4Aggregator(buf *bytes.Buffer) error {
-	return pa.decodeUint32(buf, "AS4Aggregator")
+func (pa *PathAttribute) decodeExtendedCommunities(buf *bytes.Buffer) error {
+	if pa.Length%ExtendedCommunityLen != 0 {
+		return fmt.Errorf("unable to read extended community path attribute. Length %d is not divisible by 8", pa.Length)
+	}
+
+	count := pa.Length / ExtendedCommunityLen
+	coms := make(types.ExtendedCommunities, count)
+
+	for i := uint16(0); i < count; i++ {
+		v, err := read8BytesAsUint64(buf)
+		if err != nil {
+			return err
+		}
+
+		coms[i] = types.ExtendedCommunityFromUint64(v)
+	}
+
+	pa.Value = &coms
+	return nil
+}
+
+func (pa *PathAttribute) decodeIPv6ExtendedCommunities(buf *bytes.Buffer) error {
+	if pa.Length%IPv6ExtendedCommunityLen != 0 {
+		return fmt.Errorf("unable to read IPv6 address specific extended community path attribute. Length %d is not divisible by 20", pa.Length)
+	}
+
+	count := pa.Length / IPv6ExtendedCommunityLen
+	coms := make(types.IPv6ExtendedCommunities, count)
+
+	for i := uint16(0); i < count; i++ {
+		b := [IPv6ExtendedCommunityLen]byte{}
+		n, err := buf.Read(b[:])
+		if err != nil {
+			return err
+		}
+		if n != IPv6ExtendedCommunityLen {
+			return fmt.Errorf("unable to read IPv6 address specific extended community. Expected %d bytes but got only %d", IPv6ExtendedCommunityLen, n)
+		}
+
+		addr, err := bnet.IPFromBytes(b[2:18])
+		if err != nil {
+			return err
+		}
+
+		coms[i] = types.IPv6ExtendedCommunity{
+			Type:                b[0],
+			SubType:             b[1],
+			GlobalAdministrator: addr,
+			LocalAdministrator:  uint16(b[18])<<8 | uint16(b[19]),
+		}
+	}
+
+	pa.Value = &coms
+	return nil
 }
 
 func (pa *PathAttribute) decodeUint32(buf *bytes.Buffer, attrName string) error {
@@ -523,11 +598,15 @@ func (pa *PathAttribute) Serialize(buf *bytes.Buffer, opt *EncodeOptions) uint16
 	case AtomicAggrAttr:
 		pathAttrLen = uint16(pa.serializeAtomicAggregate(buf))
 	case AggregatorAttr:
-		pathAttrLen = uint16(pa.serializeAggregator(buf))
+		pathAttrLen = pa.serializeAggregator(buf, opt)
 	case CommunitiesAttr:
 		pathAttrLen = uint16(pa.serializeCommunities(buf))
 	case LargeCommunitiesAttr:
 		pathAttrLen = uint16(pa.serializeLargeCommunities(buf))
+	case ExtendedCommunitiesAttr:
+		pathAttrLen = pa.serializeExtendedCommunities(buf)
+	case IPv6ExtendedCommunitiesAttr:
+		pathAttrLen = pa.serializeIPv6ExtendedCommunities(buf)
 	case MultiProtocolReachNLRIAttr:
 		pathAttrLen = pa.serializeMultiProtocolReachNLRI(buf, opt)
 	case MultiProtocolUnreachNLRIAttr:
@@ -640,20 +719,47 @@ func (pa *PathAttribute) serializeAtomicAggregate(buf *bytes.Buffer) uint8 {
 	return 3
 }
 
-func (pa *PathAttribute) serializeAggregator(buf *bytes.Buffer) uint8 {
+func (pa *PathAttribute) serializeAggregator(buf *bytes.Buffer, opt *EncodeOptions) uint16 {
 	attrFlags := uint8(0)
 	attrFlags = setOptional(attrFlags)
 	attrFlags = setTransitive(attrFlags)
+	aggregator := pa.Value.(types.Aggregator)
+
+	if opt.Use32BitASN {
+		buf.WriteByte(attrFlags)
+		buf.WriteByte(AggregatorAttr)
+		length := uint16(8)
+		buf.WriteByte(uint8(length))
+		buf.Write(convert.Uint32Byte(aggregator.ASN))
+		buf.Write(convert.Uint32Byte(aggregator.Address))
+
+		return length + 3
+	}
+
+	// RFC6793 Sect. 4.2.2: A 4 octet ASN is replaced by AS_TRANS and sent in an additional AS4_AGGREGATOR
+	asn := uint16(aggregator.ASN)
+	if aggregator.ASN > 0xffff {
+		asn = ASTransASN
+	}
+
 	buf.WriteByte(attrFlags)
 	buf.WriteByte(AggregatorAttr)
-	length := uint8(6)
-	buf.WriteByte(length)
+	length := uint16(6)
+	buf.WriteByte(uint8(length))
+	buf.Write(convert.Uint16Byte(asn))
+	buf.Write(convert.Uint32Byte(aggregator.Address))
 
-	aggregator := pa.Value.(types.Aggregator)
-	buf.Write(convert.Uint16Byte(aggregator.ASN))
+	if aggregator.ASN <= 0xffff {
+		return length + 3
+	}
+
+	buf.WriteByte(attrFlags)
+	buf.WriteByte(AS4AggregatorAttr)
+	buf.WriteByte(8)
+	buf.Write(convert.Uint32Byte(aggregator.ASN))
 	buf.Write(convert.Uint32Byte(aggregator.Address))
 
-	return length + 3
+	return length + 3 + 11
 }
 
 func (pa *PathAttribute) serializeCommunities(buf *bytes.Buffer) uint16 {
@@ -730,6 +836,76 @@ func (pa *PathAttribute) serializeLargeCommunities(buf *bytes.Buffer) uint16 {
 	return length + 3
 }
 
+func (pa *PathAttribute) serializeExtendedCommunities(buf *bytes.Buffer) uint16 {
+	if pa.Value == nil {
+		return 0
+	}
+
+	coms := pa.Value.(*types.ExtendedCommunities)
+	if len(*coms) == 0 {
+		return 0
+	}
+
+	length := uint16(ExtendedCommunityLen * len(*coms))
+	pa.serializeCommunitiesHeader(buf, ExtendedCommunitiesAttr, length)
+
+	for _, com := range *coms {
+		buf.Write(convert.Uint64Byte(com.ToUint64()))
+	}
+
+	if length > 255 {
+		return length + 4
+	}
+
+	return length + 3
+}
+
+func (pa *PathAttribute) serializeIPv6ExtendedCommunities(buf *bytes.Buffer) uint16 {
+	if pa.Value == nil {
+		return 0
+	}
+
+	coms := pa.Value.(*types.IPv6ExtendedCommunities)
+	if len(*coms) == 0 {
+		return 0
+	}
+
+	length := uint16(IPv6ExtendedCommunityLen * len(*coms))
+	pa.serializeCommunitiesHeader(buf, IPv6ExtendedCommunitiesAttr, length)
+
+	for _, com := range *coms {
+		buf.WriteByte(com.Type)
+		buf.WriteByte(com.SubType)
+		addr := com.GlobalAdministrator.To16BytesArray()
+		buf.Write(addr[:])
+		buf.Write(convert.Uint16Byte(com.LocalAdministrator))
+	}
+
+	if length > 255 {
+		return length + 4
+	}
+
+	return length + 3
+}
+
+func (pa *PathAttribute) serializeCommunitiesHeader(buf *bytes.Buffer, typeCode uint8, length uint16) {
+	attrFlags := uint8(0)
+	attrFlags = setOptional(attrFlags)
+	attrFlags = setTransitive(attrFlags)
+	attrFlags = setPartial(attrFlags)
+	if length > 255 {
+		attrFlags = setExtendedLength(attrFlags)
+	}
+	buf.WriteByte(attrFlags)
+	buf.WriteByte(typeCode)
+
+	if length < 256 {
+		buf.WriteByte(uint8(length))
+	} else {
+		buf.Write(convert.Uint16Byte(length))
+	}
+}
+
 func (pa *PathAttribute) serializeOriginatorID(buf *bytes.Buffer) uint8 {
 	attrFlags := uint8(0)
 	attrFlags = setOptional(attrFlags)
@@ -853,6 +1029,19 @@ func fourBytesToUint32(address [4]byte) uint32 {
 	return uint32(address[0])<<24 + uint32(address[1])<<16 + uint32(address[2])<<8 + uint32(address[3])
 }
 
+func read8BytesAsUint64(buf *bytes.Buffer) (uint64, error) {
+	b := [8]byte{}
+	n, err := buf.Read(b[:])
+	if err != nil {
+		return 0, err
+	}
+	if n != 8 {
+		return 0, fmt.Errorf("unable to read as uint64. Expected 8 bytes but got only %d", n)
+	}
+
+	return binary.BigEndian.Uint64(b[:]), nil
+}
+
 func read4BytesAsUint32(buf *bytes.Buffer) (uint32, error) {
 	b := [4]byte{}
 	n, err := buf.Read(b[:])
@@ -888,6 +1077,24 @@ func (pa *PathAttribute) AddOptionalPathAttributes(p *route.Path) *PathAttribute
 		current = largeCommunities
 	}
 
+	if p.BGPPath.ExtendedCommunities != nil && len(*p.BGPPath.ExtendedCommunities) > 0 {
+		extendedCommunities := &PathAttribute{
+			TypeCode: ExtendedCommunitiesAttr,
+			Value:    p.BGPPath.ExtendedCommunities,
+		}
+		current.Next = extendedCommunities
+		current = extendedCommunities
+	}
+
+	if p.BGPPath.IPv6ExtendedCommunities != nil && len(*p.BGPPath.IPv6ExtendedCommunities) > 0 {
+		ipv6ExtendedCommunities := &PathAttribute{
+			TypeCode: IPv6ExtendedCommunitiesAttr,
+			Value:    p.BGPPath.IPv6ExtendedCommunities,
+		}
+		current.Next = ipv6ExtendedCommunities
+		current = ipv6ExtendedCommunities
+	}
+
 	return current
 }
 
@@ -643,6 +643,7 @@ func TestDecodeAggregator(t *testing.T) {
 	tests := []struct {
 		name           string
 		input          []byte
+		asnLength      uint8
 		wantFail       bool
 		explicitLength uint16
 		expected       *PathAttribute
@@ -653,7 +654,8 @@ func TestDecodeAggregator(t *testing.T) {
 				0, 222, // ASN
 				10, 20, 30, 40, // Aggregator IP
 			},
-			wantFail: false,
+			asnLength: 2,
+			wantFail:  false,
 			expected: &PathAttribute{
 				Length: 6,
 				Value: types.Aggregator{
@@ -662,25 +664,53 @@ func TestDecodeAggregator(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Valid 4 octet ASN aggregator",
+			input: []byte{
+				250, 86, 234, 0, // ASN
+				10, 20, 30, 40, // Aggregator IP
+			},
+			asnLength: 4,
+			wantFail:  false,
+			expected: &PathAttribute{
+				Length: 8,
+				Value: types.Aggregator{
+					ASN:     4200000000,
+					Address: bnet.IPv4FromOctets(10, 20, 30, 40).Ptr().ToUint32(),
+				},
+			},
+		},
 		{
 			name: "Incomplete Address",
 			input: []byte{
 				0, 222, // ASN
 				10, 20, // Aggregator IP
 			},
-			wantFail: true,
+			asnLength: 2,
+			wantFail:  true,
+		},
+		{
+			name: "Incomplete 4 octet ASN aggregator",
+			input: []byte{
+				0, 0, 0, 222, // ASN
+				10, 20, // Aggregator IP
+			},
+			asnLength: 4,
+			wantFail:  true,
 		},
 		{
 			name: "Missing Address",
 			input: []byte{
 				0, 222, // ASN
 			},
-			wantFail: true,
+			asnLength: 2,
+			wantFail:  true,
 		},
 		{
-			name:     "Empty input",
-			input:    []byte{},
-			wantFail: true,
+			name:      "Empty input",
+			input:     []byte{},
+			asnLength: 2,
+			wantFail:  true,
 		},
 	}
 
@@ -692,7 +722,7 @@ func TestDecodeAggregator(t *testing.T) {
 		pa := &PathAttribute{
 			Length: l,
 		}
-		err := pa.decodeAggregator(bytes.NewBuffer(test.input))
+		err := pa.decodeAggregator(bytes.NewBuffer(test.input), test.asnLength)
 
 		if test.wantFail {
 			if err != nil {
@@ -779,6 +809,101 @@ func TestDecodeLargeCommunity(t *testing.T) {
 	}
 }
 
+func TestDecodeExtendedCommunities(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []byte
+		wantFail bool
+		expected *PathAttribute
+	}{
+		{
+			name: "route target and route origin",
+			input: []byte{
+				0x00, 0x02, 0xfd, 0xe8, 0, 0, 0, 100, // target:65000:100
+				0x01, 0x03, 192, 0, 2, 1, 0, 100, // origin:192.0.2.1:100
+			},
+			expected: &PathAttribute{
+				Length: 16,
+				Value: &types.ExtendedCommunities{
+					types.NewRouteTarget(65000, false, 100),
+					types.NewRouteOrigin(bnet.IPv4FromOctets(192, 0, 2, 1).ToUint32(), true, 100),
+				},
+			},
+		},
+		{
+			name:     "invalid length",
+			input:    []byte{0x00, 0x02, 0xfd, 0xe8, 0, 0, 0},
+			wantFail: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pa := &PathAttribute{
+				Length: uint16(len(test.input)),
+			}
+			err := pa.decodeExtendedCommunities(bytes.NewBuffer(test.input))
+			if test.wantFail {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, pa)
+		})
+	}
+}
+
+func TestDecodeIPv6ExtendedCommunities(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []byte
+		wantFail bool
+		expected *PathAttribute
+	}{
+		{
+			name: "route target",
+			input: []byte{
+				0x00, 0x02, // Type, Sub Type
+				0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, // 2001:db8::1
+				0, 100, // Local Administrator
+			},
+			expected: &PathAttribute{
+				Length: 20,
+				Value: &types.IPv6ExtendedCommunities{
+					{
+						Type:                types.IPv6ExtendedCommunityTypeIPv6Address,
+						SubType:             types.ExtendedCommunitySubTypeRouteTarget,
+						GlobalAdministrator: bnet.IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 1),
+						LocalAdministrator:  100,
+					},
+				},
+			},
+		},
+		{
+			name:     "invalid length",
+			input:    []byte{0x00, 0x02, 0x20, 0x01},
+			wantFail: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pa := &PathAttribute{
+				Length: uint16(len(test.input)),
+			}
+			err := pa.decodeIPv6ExtendedCommunities(bytes.NewBuffer(test.input))
+			if test.wantFail {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, pa)
+		})
+	}
+}
+
 func TestDecodeCommunity(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -978,6 +1103,89 @@ func TestDecodeMultiProtocolReachNLRI(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "valid VPNv6 MP_REACH_NLRI",
+			input: []byte{
+				0x00, 0x02, // AFI
+				0x80,                                           // SAFI
+				0x18,                                           // NextHop length
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // NextHop RD
+				0x20, 0x01, 0x06, 0x78, 0x01, 0xe0, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, // NextHop
+				0x00,             // RESERVED
+				0x88,             // Prefix Length + Label Stack + RD
+				0x49, 0x33, 0x01, // Label
+				0x00, 0x00, 0xfd, 0xe8, 0x00, 0x00, 0x00, 0x64, // RD
+				0x26, 0x00, 0x00, 0x06, 0xff, 0x05, // Prefix
+			},
+			opt: &DecodeOptions{},
+			expected: &PathAttribute{
+				Length: 47,
+				Value: MultiProtocolReachNLRI{
+					AFI:     AFIIPv6,
+					SAFI:    SAFIMPLSVPN,
+					NextHop: bnet.IPv6FromBlocks(0x2001, 0x678, 0x1e0, 0, 0, 0, 0, 0x2).Ptr(),
+					NLRI: &NLRI{
+						LabelStack: []LabelStackEntry{
+							0x00493301,
+						},
+						RouteDistinguisher: 65000<<32 + 100,
+						Prefix:             bnet.NewPfx(bnet.IPv6FromBlocks(0x2600, 0x6, 0xff05, 0, 0, 0, 0, 0), 48).Ptr(),
+					},
+				},
+			},
+		},
+		{
+			name: "valid IPv4 FlowSpec MP_REACH_NLRI",
+			input: []byte{
+				0x00, 0x01, // AFI
+				0x85,                         // SAFI
+				0x00,                         // NextHop length
+				0x00,                         // RESERVED
+				0x0b,                         // NLRI length
+				0x01, 0x18, 0xc0, 0x00, 0x02, // Destination prefix
+				0x03, 0x81, 0x06, // IP protocol
+				0x05, 0x81, 0x50, // Destination port
+			},
+			opt: &DecodeOptions{},
+			expected: &PathAttribute{
+				Length: 17,
+				Value: MultiProtocolReachNLRI{
+					AFI:  AFIIPv4,
+					SAFI: SAFIFlowSpec,
+					NLRI: &NLRI{
+						Prefix: bnet.NewPfx(bnet.IPv4FromOctets(192, 0, 2, 0), 24).Ptr(),
+						FlowSpec: &types.FlowSpecRule{
+							Components: []types.FlowSpecComponent{
+								{
+									Type:   types.FlowSpecComponentDestinationPrefix,
+									Prefix: bnet.NewPfx(bnet.IPv4FromOctets(192, 0, 2, 0), 24).Ptr(),
+								},
+								{
+									Type:       types.FlowSpecComponentIPProtocol,
+									Operations: []types.FlowSpecOperation{{Equal: true, Value: 6}},
+								},
+								{
+									Type:       types.FlowSpecComponentDestinationPort,
+									Operations: []types.FlowSpecOperation{{Equal: true, Value: 80}},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "VPNv6 MP_REACH_NLRI with invalid next hop length",
+			input: []byte{
+				0x00, 0x02, // AFI
+				0x80,                                                                                           // SAFI
+				0x10,                                                                                           // NextHop length
+				0x20, 0x01, 0x06, 0x78, 0x01, 0xe0, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, // NextHop
+				0x00, // RESERVED
+			},
+			opt:      &DecodeOptions{},
+			wantFail: true,
+		},
 		{
 			name: "MP_REACH_NLRI with invalid length",
 			input: []byte{
@@ -1549,8 +1757,9 @@ func TestSerializeAggregator(t *testing.T) {
 	tests := []struct {
 		name        string
 		input       *PathAttribute
+		use32BitASN bool
 		expected    []byte
-		expectedLen uint8
+		expectedLen uint16
 	}{
 		{
 			name: "Test #1",
@@ -1570,11 +1779,72 @@ func TestSerializeAggregator(t *testing.T) {
 			},
 			expectedLen: 9,
 		},
+		{
+			name: "4 octet ASN session",
+			input: &PathAttribute{
+				TypeCode: AggregatorAttr,
+				Value: types.Aggregator{
+					ASN:     174,
+					Address: bnet.IPv4FromOctets(10, 20, 30, 40).Ptr().ToUint32(),
+				},
+			},
+			use32BitASN: true,
+			expected: []byte{
+				192,          // Attribute flags
+				7,            // Type
+				8,            // Length
+				0, 0, 0, 174, // Value = 174
+				10, 20, 30, 40,
+			},
+			expectedLen: 11,
+		},
+		{
+			name: "4 octet ASN on 4 octet ASN session",
+			input: &PathAttribute{
+				TypeCode: AggregatorAttr,
+				Value: types.Aggregator{
+					ASN:     4200000000,
+					Address: bnet.IPv4FromOctets(10, 20, 30, 40).Ptr().ToUint32(),
+				},
+			},
+			use32BitASN: true,
+			expected: []byte{
+				192,             // Attribute flags
+				7,               // Type
+				8,               // Length
+				250, 86, 234, 0, // Value = 4200000000
+				10, 20, 30, 40,
+			},
+			expectedLen: 11,
+		},
+		{
+			name: "4 octet ASN on 2 octet ASN session",
+			input: &PathAttribute{
+				TypeCode: AggregatorAttr,
+				Value: types.Aggregator{
+					ASN:     4200000000,
+					Address: bnet.IPv4FromOctets(10, 20, 30, 40).Ptr().ToUint32(),
+				},
+			},
+			expected: []byte{
+				192,        // Attribute flags
+				7,          // Type
+				6,          // Length
+				0x5b, 0xa0, // Value = AS_TRANS
+				10, 20, 30, 40,
+				192,             // Attribute flags
+				18,              // Type AS4_AGGREGATOR
+				8,               // Length
+				250, 86, 234, 0, // Value = 4200000000
+				10, 20, 30, 40,
+			},
+			expectedLen: 20,
+		},
 	}
 
 	for _, test := range tests {
 		buf := bytes.NewBuffer(nil)
-		n := test.input.serializeAggregator(buf)
+		n := test.input.serializeAggregator(buf, &EncodeOptions{Use32BitASN: test.use32BitASN})
 		if n != test.expectedLen {
 			t.Errorf("Unexpected length for test %q: %d", test.name, n)
 			continue
@@ -1719,6 +1989,84 @@ func TestSerializeLargeCommunities(t *testing.T) {
 	}
 }
 
+func TestSerializeExtendedCommunities(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       *PathAttribute
+		expected    []byte
+		expectedLen uint16
+	}{
+		{
+			name: "route target",
+			input: &PathAttribute{
+				TypeCode: ExtendedCommunitiesAttr,
+				Value: &types.ExtendedCommunities{
+					types.NewRouteTarget(65000, false, 100),
+				},
+			},
+			expected: []byte{
+				0xe0,                                 // Attribute flags
+				16,                                   // Type
+				8,                                    // Length
+				0x00, 0x02, 0xfd, 0xe8, 0, 0, 0, 100, // target:65000:100
+			},
+			expectedLen: 11,
+		},
+		{
+			name: "IPv6 address specific route target",
+			input: &PathAttribute{
+				TypeCode: IPv6ExtendedCommunitiesAttr,
+				Value: &types.IPv6ExtendedCommunities{
+					{
+						Type:                types.IPv6ExtendedCommunityTypeIPv6Address,
+						SubType:             types.ExtendedCommunitySubTypeRouteTarget,
+						GlobalAdministrator: bnet.IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 1),
+						LocalAdministrator:  100,
+					},
+				},
+			},
+			expected: []byte{
+				0xe0,       // Attribute flags
+				25,         // Type
+				20,         // Length
+				0x00, 0x02, // Type, Sub Type
+				0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, // 2001:db8::1
+				0, 100, // Local Administrator
+			},
+			expectedLen: 23,
+		},
+		{
+			name: "empty list of communities",
+			input: &PathAttribute{
+				TypeCode: ExtendedCommunitiesAttr,
+				Value:    &types.ExtendedCommunities{},
+			},
+			expected:    []byte{},
+			expectedLen: 0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			buf := bytes.NewBuffer([]byte{})
+			n := test.input.Serialize(buf, &EncodeOptions{})
+			assert.Equal(t, test.expectedLen, n)
+			assert.Equal(t, test.expected, buf.Bytes())
+
+			if test.expectedLen == 0 {
+				return
+			}
+
+			pa, _, err := decodePathAttr(buf, &DecodeOptions{})
+			if err != nil {
+				t.Fatalf("unable to decode serialized attribute: %v", err)
+			}
+
+			assert.Equal(t, test.input.Value, pa.Value)
+		})
+	}
+}
+
 func TestSerializeCommunities(t *testing.T) {
 	tests := []struct {
 		name        string
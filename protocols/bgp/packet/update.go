@@ -74,6 +74,31 @@ func (b *BGPUpdate) SerializeUpdate(opt *EncodeOptions) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+// IsEndOfRIBMarker checks if the update is an End-of-RIB marker for any address family
 func (b *BGPUpdate) IsEndOfRIBMarker() bool {
-	return b.WithdrawnRoutesLen == 0 && b.NLRI == nil
+	_, _, isEndOfRIB := b.EndOfRIBMarkerFamily()
+	return isEndOfRIB
+}
+
+// EndOfRIBMarkerFamily checks if the update is an End-of-RIB marker (RFC4724 Sect. 2)
+// and returns the address family it was sent for
+func (b *BGPUpdate) EndOfRIBMarkerFamily() (afi uint16, safi uint8, isEndOfRIB bool) {
+	if b.WithdrawnRoutes != nil || b.NLRI != nil {
+		return 0, 0, false
+	}
+
+	if b.PathAttributes == nil {
+		return AFIIPv4, SAFIUnicast, true
+	}
+
+	if b.PathAttributes.Next != nil || b.PathAttributes.TypeCode != MultiProtocolUnreachNLRIAttr {
+		return 0, 0, false
+	}
+
+	mpUnreach := b.PathAttributes.Value.(MultiProtocolUnreachNLRI)
+	if mpUnreach.NLRI != nil {
+		return 0, 0, false
+	}
+
+	return mpUnreach.AFI, mpUnreach.SAFI, true
 }
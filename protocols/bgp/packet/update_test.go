@@ -0,0 +1,80 @@
+package packet
+
+import (
+	"testing"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEndOfRIBMarkerFamily(t *testing.T) {
+	tests := []struct {
+		name         string
+		update       *BGPUpdate
+		expectedAFI  uint16
+		expectedSAFI uint8
+		expectedEoR  bool
+	}{
+		{
+			name:         "IPv4 unicast",
+			update:       &BGPUpdate{},
+			expectedAFI:  AFIIPv4,
+			expectedSAFI: SAFIUnicast,
+			expectedEoR:  true,
+		},
+		{
+			name: "IPv6 unicast",
+			update: &BGPUpdate{
+				PathAttributes: &PathAttribute{
+					TypeCode: MultiProtocolUnreachNLRIAttr,
+					Value: MultiProtocolUnreachNLRI{
+						AFI:  AFIIPv6,
+						SAFI: SAFIUnicast,
+					},
+				},
+			},
+			expectedAFI:  AFIIPv6,
+			expectedSAFI: SAFIUnicast,
+			expectedEoR:  true,
+		},
+		{
+			name: "IPv6 withdraw",
+			update: &BGPUpdate{
+				PathAttributes: &PathAttribute{
+					TypeCode: MultiProtocolUnreachNLRIAttr,
+					Value: MultiProtocolUnreachNLRI{
+						AFI:  AFIIPv6,
+						SAFI: SAFIUnicast,
+						NLRI: &NLRI{
+							Prefix: bnet.NewPfx(bnet.IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 0), 32).Ptr(),
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "IPv4 withdraw",
+			update: &BGPUpdate{
+				WithdrawnRoutes: &NLRI{
+					Prefix: bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 0), 8).Ptr(),
+				},
+			},
+		},
+		{
+			name: "Path attributes only",
+			update: &BGPUpdate{
+				PathAttributes: &PathAttribute{
+					TypeCode: OriginAttr,
+					Value:    uint8(IGP),
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		afi, safi, eor := test.update.EndOfRIBMarkerFamily()
+		assert.Equal(t, test.expectedEoR, eor, test.name)
+		assert.Equal(t, test.expectedAFI, afi, test.name)
+		assert.Equal(t, test.expectedSAFI, safi, test.name)
+	}
+}
@@ -0,0 +1,117 @@
+package server
+
+import (
+	"time"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	bfdserver "github.com/bio-routing/bio-rd/protocols/bfd/server"
+	"github.com/bio-routing/bio-rd/util/log"
+)
+
+// BFDConfig enables BFD (RFC5882) for a peer to detect forwarding failures faster than the hold timer does
+type BFDConfig struct {
+	DesiredMinTxInterval  time.Duration
+	RequiredMinRxInterval time.Duration
+	DetectMultiplier      uint8
+	Multihop              bool
+}
+
+// Equal compares two BFD configs
+func (c *BFDConfig) Equal(x *BFDConfig) bool {
+	if c == nil || x == nil {
+		return c == x
+	}
+
+	return *c == *x
+}
+
+func (pc *PeerConfig) bfdSessionConfig() bfdserver.SessionConfig {
+	return bfdserver.SessionConfig{
+		Peer:                  *pc.PeerAddress,
+		Local:                 pc.LocalAddress,
+		Multihop:              pc.BFD.Multihop,
+		DesiredMinTxInterval:  pc.BFD.DesiredMinTxInterval,
+		RequiredMinRxInterval: pc.BFD.RequiredMinRxInterval,
+		DetectMultiplier:      pc.BFD.DetectMultiplier,
+	}
+}
+
+func (b *bgpServer) subscribeBFD(p *peer) error {
+	if p.config.BFD == nil || b.config.BFD == nil {
+		return nil
+	}
+
+	return b.config.BFD.Subscribe(p, p.config.bfdSessionConfig())
+}
+
+func (b *bgpServer) unsubscribeBFD(p *peer) {
+	if p.config.BFD == nil || b.config.BFD == nil {
+		return
+	}
+
+	b.config.BFD.Unsubscribe(p, p.config.bfdSessionConfig())
+}
+
+// BFDStateChange tears down the sessions of a peer when its BFD session failed and holds them down until the BFD
+// session is up again
+func (p *peer) BFDStateChange(addr bnet.IP, up bool) {
+	if up {
+		if !p.bfdDown.Swap(false) {
+			return
+		}
+
+		log.WithFields(log.Fields{
+			"peer": p.addr.String(),
+		}).Info("BFD session is up again")
+
+		p.fsmsMu.Lock()
+		defer p.fsmsMu.Unlock()
+
+		for _, fsm := range p.fsms {
+			fsm.bfdUp()
+		}
+
+		return
+	}
+
+	p.bfdDown.Store(true)
+	log.WithFields(log.Fields{
+		"peer": p.addr.String(),
+	}).Info("BFD session went down")
+
+	p.fsmsMu.Lock()
+	defer p.fsmsMu.Unlock()
+
+	for _, fsm := range p.fsms {
+		fsm.bfdDown()
+	}
+}
+
+// bfdDown signals a BFD failure to the FSM without blocking the BFD session
+func (fsm *FSM) bfdDown() {
+	select {
+	case fsm.bfdDownCh <- struct{}{}:
+	default:
+	}
+}
+
+// bfdUp signals the recovery of the BFD session to the FSM without blocking the BFD session
+func (fsm *FSM) bfdUp() {
+	select {
+	case fsm.bfdUpCh <- struct{}{}:
+	default:
+	}
+}
+
+// drainBFD discards BFD state changes signaled before the session was established
+func (fsm *FSM) drainBFD() {
+	select {
+	case <-fsm.bfdDownCh:
+	default:
+	}
+
+	select {
+	case <-fsm.bfdUpCh:
+	default:
+	}
+}
@@ -0,0 +1,155 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	bfdserver "github.com/bio-routing/bio-rd/protocols/bfd/server"
+	"github.com/bio-routing/bio-rd/routingtable/vrf"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockBFD struct {
+	subscribed map[bnet.IP]bfdserver.SessionConfig
+}
+
+func (m *mockBFD) Subscribe(c bfdserver.Client, cfg bfdserver.SessionConfig) error {
+	m.subscribed[cfg.Peer] = cfg
+	return nil
+}
+
+func (m *mockBFD) Unsubscribe(c bfdserver.Client, cfg bfdserver.SessionConfig) {
+	delete(m.subscribed, cfg.Peer)
+}
+
+func (m *mockBFD) GetSessions() []*bfdserver.SessionInfo {
+	return nil
+}
+
+func TestSubscribeBFD(t *testing.T) {
+	m := &mockBFD{
+		subscribed: make(map[bnet.IP]bfdserver.SessionConfig),
+	}
+	b := newBGPServer(BGPServerConfig{BFD: m})
+
+	peerAddr := bnet.IPv4FromOctets(169, 254, 100, 100)
+	localAddr := bnet.IPv4FromOctets(169, 254, 100, 1)
+	withBFD := &peer{
+		config: &PeerConfig{
+			PeerAddress:  peerAddr.Ptr(),
+			LocalAddress: localAddr.Ptr(),
+			BFD: &BFDConfig{
+				DesiredMinTxInterval:  100 * time.Millisecond,
+				RequiredMinRxInterval: 200 * time.Millisecond,
+				DetectMultiplier:      5,
+				Multihop:              true,
+			},
+		},
+	}
+	withoutBFD := &peer{
+		config: &PeerConfig{
+			PeerAddress: bnet.IPv4FromOctets(169, 254, 200, 200).Ptr(),
+		},
+	}
+
+	assert.NoError(t, b.subscribeBFD(withBFD))
+	assert.NoError(t, b.subscribeBFD(withoutBFD))
+	assert.Equal(t, map[bnet.IP]bfdserver.SessionConfig{
+		peerAddr: {
+			Peer:                  peerAddr,
+			Local:                 localAddr.Ptr(),
+			Multihop:              true,
+			DesiredMinTxInterval:  100 * time.Millisecond,
+			RequiredMinRxInterval: 200 * time.Millisecond,
+			DetectMultiplier:      5,
+		},
+	}, m.subscribed)
+
+	b.unsubscribeBFD(withBFD)
+	assert.Len(t, m.subscribed, 0)
+}
+
+func TestBFDDown(t *testing.T) {
+	con := &recordingConn{}
+	p := &peer{
+		addr: bnet.IPv4FromOctets(169, 254, 100, 100).Ptr(),
+		ipv4: &peerAddressFamily{},
+		vrf:  vrf.NewUntrackedVRF("vrf0", 0),
+	}
+
+	fsm := newFSM(p)
+	fsm.con = con
+	fsm.ribsInitialized = true
+	p.fsms = []*FSM{fsm}
+
+	// Session coming up is ignored
+	p.BFDStateChange(*p.addr, true)
+	assert.Len(t, fsm.bfdDownCh, 0)
+
+	// Signaling a failure twice must not block
+	p.BFDStateChange(*p.addr, false)
+	p.BFDStateChange(*p.addr, false)
+
+	next, reason := newEstablishedState(fsm).run()
+	assert.Equal(t, stateNameIdle, stateName(next))
+	assert.Equal(t, "BFD session down", reason)
+	assert.Len(t, con.written, 0)
+	assert.False(t, fsm.ribsInitialized)
+}
+
+func TestBFDDownBeforeEstablished(t *testing.T) {
+	p := &peer{
+		addr: bnet.IPv4FromOctets(169, 254, 100, 100).Ptr(),
+		vrf:  vrf.NewUntrackedVRF("vrf0", 0),
+	}
+
+	fsm := newFSM(p)
+	fsm.bfdDown()
+
+	assert.NoError(t, newEstablishedState(fsm).init())
+	assert.Len(t, fsm.bfdDownCh, 0)
+}
+
+func TestBFDHoldDown(t *testing.T) {
+	p := &peer{
+		addr:              bnet.IPv4FromOctets(169, 254, 100, 100).Ptr(),
+		reconnectInterval: time.Second,
+		vrf:               vrf.NewUntrackedVRF("vrf0", 0),
+	}
+
+	fsm := newFSM(p)
+	p.fsms = []*FSM{fsm}
+
+	p.BFDStateChange(*p.addr, false)
+	assert.True(t, p.bfdDown.Load())
+
+	type result struct {
+		next   state
+		reason string
+	}
+	done := make(chan result)
+	go func() {
+		next, reason := newIdleState(fsm).run()
+		done <- result{next: next, reason: reason}
+	}()
+
+	// The session is not started while the BFD session is down
+	fsm.eventCh <- AutomaticStart
+	select {
+	case <-done:
+		t.Fatal("session started while BFD session is down")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	p.BFDStateChange(*p.addr, true)
+	assert.False(t, p.bfdDown.Load())
+
+	select {
+	case r := <-done:
+		assert.Equal(t, stateNameConnect, stateName(r.next))
+		assert.Equal(t, "BFD session up", r.reason)
+	case <-time.After(time.Second):
+		t.Fatal("session was not started after the BFD session came up")
+	}
+}
@@ -5,6 +5,9 @@ import (
 	"fmt"
 
 	"github.com/bio-routing/bio-rd/protocols/bgp/api"
+	"github.com/bio-routing/bio-rd/protocols/bgp/metrics"
+	"github.com/bio-routing/bio-rd/protocols/bgp/packet"
+	"github.com/bio-routing/bio-rd/routingtable/flowspecRIB"
 	"github.com/bio-routing/bio-rd/routingtable/vrf"
 
 	bnet "github.com/bio-routing/bio-rd/net"
@@ -24,8 +27,94 @@ func NewBGPAPIServer(s BGPServer, vrfReg *vrf.VRFRegistry) *BGPAPIServer {
 	}
 }
 
+// ListSessions lists all BGP sessions matching the filter
 func (s *BGPAPIServer) ListSessions(ctx context.Context, in *api.ListSessionsRequest) (*api.ListSessionsResponse, error) {
-	return nil, fmt.Errorf("not implemented yet")
+	m, err := s.srv.Metrics()
+	if err != nil {
+		return nil, err
+	}
+
+	res := &api.ListSessionsResponse{
+		Sessions: make([]*api.Session, 0, len(m.Peers)),
+	}
+
+	for _, peer := range m.Peers {
+		if !sessionFilterMatches(in.Filter, peer) {
+			continue
+		}
+
+		res.Sessions = append(res.Sessions, s.session(peer))
+	}
+
+	return res, nil
+}
+
+func sessionFilterMatches(f *api.SessionFilter, peer *metrics.BGPPeerMetrics) bool {
+	if f == nil {
+		return true
+	}
+
+	if f.VrfName != "" && f.VrfName != peer.VRF {
+		return false
+	}
+
+	if f.NeighborIp != nil && bnet.IPFromProtoIP(f.NeighborIp) != *peer.IP {
+		return false
+	}
+
+	return true
+}
+
+func (s *BGPAPIServer) session(peer *metrics.BGPPeerMetrics) *api.Session {
+	sess := &api.Session{
+		NeighborAddress: peer.IP.ToProto(),
+		LocalAsn:        peer.LocalASN,
+		PeerAsn:         peer.ASN,
+		Status:          api.Session_State(peer.State),
+		Dynamic:         peer.Dynamic,
+		Stats: &api.SessionStats{
+			PrefixLimitHeldDown: peer.PrefixLimitHeldDown,
+		},
+	}
+
+	if peer.State == metrics.StateEstablished {
+		sess.EstablishedSince = uint64(peer.Since.Unix())
+	}
+
+	for _, f := range peer.AddressFamilies {
+		sess.Stats.RoutesReceived += f.RoutesReceived
+		sess.Stats.RoutesExported += f.RoutesSent
+
+		if f.PrefixLimit == 0 {
+			continue
+		}
+
+		sess.Stats.PrefixLimits = append(sess.Stats.PrefixLimits, &api.PrefixLimitStats{
+			Afi:           uint32(f.AFI),
+			Safi:          uint32(f.SAFI),
+			Max:           f.PrefixLimit,
+			PathsReceived: f.PathsReceived,
+			Warning:       f.PrefixLimitWarning,
+			Exceeded:      f.PrefixLimitExceeded,
+		})
+	}
+
+	v := s.vrfReg.GetVRFByName(peer.VRF)
+	if v == nil {
+		return sess
+	}
+
+	cfg := s.srv.GetPeerConfig(v, peer.IP)
+	if cfg == nil {
+		return sess
+	}
+
+	if cfg.LocalAddress != nil {
+		sess.LocalAddress = cfg.LocalAddress.ToProto()
+	}
+	sess.Description = cfg.Description
+
+	return sess
 }
 
 // DumpRIBIn dumps the RIB in of a peer for a given AFI/SAFI
@@ -35,13 +124,17 @@ func (s *BGPAPIServer) DumpRIBIn(in *api.DumpRIBRequest, stream api.BgpService_D
 		return fmt.Errorf("unable to find vrf %q", in.VrfName)
 	}
 
-	r := s.srv.GetRIBIn(v, bnet.IPFromProtoIP(in.Peer).Ptr(), uint16(in.Afi), uint8(in.Safi))
-	if r == nil {
+	ribIn := s.srv.GetRIBIn(v, bnet.IPFromProtoIP(in.Peer).Ptr(), uint16(in.Afi), uint8(in.Safi))
+	if ribIn == nil {
 		return fmt.Errorf("unable to get AdjRIBIn")
 	}
 
-	for _, r := range r.Dump() {
+	for _, r := range ribIn.Dump() {
 		x := r.ToProto()
+		for i, p := range r.Paths() {
+			x.Paths[i].Dampening = ribIn.DampeningState(r.Prefix(), p).ToProto()
+		}
+
 		err := stream.Send(x)
 		if err != nil {
 			return err
@@ -74,6 +167,65 @@ func (s *BGPAPIServer) DumpRIBOut(in *api.DumpRIBRequest, stream api.BgpService_
 	return nil
 }
 
+// ClearDampening drops the dampening state of the routes of a peer for a given AFI/SAFI. Suppressed routes are reused.
+// If a prefix is given only the state of the routes covered by it is dropped.
+func (s *BGPAPIServer) ClearDampening(ctx context.Context, in *api.ClearDampeningRequest) (*api.ClearDampeningResponse, error) {
+	if in.VrfName == "" {
+		in.VrfName = vrf.DefaultVRFName
+	}
+
+	v := s.vrfReg.GetVRFByName(in.VrfName)
+	if v == nil {
+		return nil, fmt.Errorf("unable to find vrf %q", in.VrfName)
+	}
+
+	r := s.srv.GetRIBIn(v, bnet.IPFromProtoIP(in.Peer).Ptr(), uint16(in.Afi), uint8(in.Safi))
+	if r == nil {
+		return nil, fmt.Errorf("unable to get AdjRIBIn")
+	}
+
+	var pfx *bnet.Prefix
+	if in.Pfx != nil {
+		pfx = bnet.NewPrefixFromProtoPrefix(in.Pfx)
+	}
+
+	r.ClearDampening(pfx)
+	return &api.ClearDampeningResponse{}, nil
+}
+
+// DumpFlowSpecRIB dumps the FlowSpec RIB of a VRF for a given AFI
+func (s *BGPAPIServer) DumpFlowSpecRIB(in *api.DumpFlowSpecRIBRequest, stream api.BgpService_DumpFlowSpecRIBServer) error {
+	if in.VrfName == "" {
+		in.VrfName = vrf.DefaultVRFName
+	}
+
+	v := s.vrfReg.GetVRFByName(in.VrfName)
+	if v == nil {
+		return fmt.Errorf("unable to find vrf %q", in.VrfName)
+	}
+
+	var rib *flowspecRIB.FlowSpecRIB
+	switch in.Afi {
+	case packet.AFIIPv4:
+		rib = v.IPv4FlowSpecRIB()
+	case packet.AFIIPv6:
+		rib = v.IPv6FlowSpecRIB()
+	}
+
+	if rib == nil {
+		return fmt.Errorf("unable to get FlowSpec RIB for AFI %d", in.Afi)
+	}
+
+	for _, r := range rib.Dump() {
+		err := stream.Send(r.ToProto())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (s *BGPAPIServer) getVRF(in *api.DumpRIBRequest) *vrf.VRF {
 	if in.VrfName == "" {
 		in.VrfName = vrf.DefaultVRFName
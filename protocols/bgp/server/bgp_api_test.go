@@ -15,12 +15,15 @@ import (
 	"github.com/bio-routing/bio-rd/routingtable"
 	"github.com/bio-routing/bio-rd/routingtable/adjRIBIn"
 	"github.com/bio-routing/bio-rd/routingtable/adjRIBOut"
+	"github.com/bio-routing/bio-rd/routingtable/dampening"
 	"github.com/bio-routing/bio-rd/routingtable/filter"
+	"github.com/bio-routing/bio-rd/routingtable/locRIB"
 	"github.com/bio-routing/bio-rd/routingtable/vrf"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/test/bufconn"
 
+	bbclock "github.com/benbjohnson/clock"
 	bnet "github.com/bio-routing/bio-rd/net"
 )
 
@@ -32,6 +35,7 @@ func TestDumpRIBInOut(t *testing.T) {
 		ClusterID: 0,
 		LocalASN:  42,
 		PeerASN:   42,
+		IBGP:      true,
 		AddPathRX: true,
 		AddPathTX: true,
 	}
@@ -398,3 +402,281 @@ func TestDumpRIBInOut(t *testing.T) {
 		assert.Equal(t, expected, results, test.name)
 	}
 }
+
+func TestDumpFlowSpecRIB(t *testing.T) {
+	vrfReg := vrf.NewVRFRegistry()
+	v := vrfReg.CreateVRFIfNotExists("flowspec", 0)
+
+	rule := &types.FlowSpecRule{
+		Components: []types.FlowSpecComponent{
+			{
+				Type:   types.FlowSpecComponentDestinationPrefix,
+				Prefix: bnet.NewPfx(bnet.IPv4FromOctets(192, 0, 2, 0), 24).Ptr(),
+			},
+			{
+				Type:       types.FlowSpecComponentIPProtocol,
+				Operations: []types.FlowSpecOperation{{Equal: true, Value: 17}},
+			},
+		},
+	}
+
+	p := &route.Path{
+		Type: route.BGPPathType,
+		BGPPath: &route.BGPPath{
+			FlowSpecRule: rule,
+			ASPath:       route.NewBGPPath().ASPath,
+			BGPPathA: &route.BGPPathA{
+				NextHop: bnet.IPv4(0).Ptr(),
+				Source:  bnet.IPv4FromOctets(10, 0, 0, 1).Ptr(),
+			},
+		},
+	}
+	v.IPv4FlowSpecRIB().AddPath(rule.RoutePrefix(false), p)
+
+	tests := []struct {
+		name     string
+		req      *api.DumpFlowSpecRIBRequest
+		expected []*routeapi.Route
+		wantFail bool
+	}{
+		{
+			name: "IPv4 FlowSpec RIB",
+			req: &api.DumpFlowSpecRIBRequest{
+				Afi:     packet.AFIIPv4,
+				VrfName: "flowspec",
+			},
+			expected: []*routeapi.Route{
+				route.NewRoute(rule.RoutePrefix(false), p).ToProto(),
+			},
+		},
+		{
+			name: "Invalid AFI",
+			req: &api.DumpFlowSpecRIBRequest{
+				Afi:     3,
+				VrfName: "flowspec",
+			},
+			wantFail: true,
+		},
+		{
+			name: "Non existent VRF",
+			req: &api.DumpFlowSpecRIBRequest{
+				Afi:     packet.AFIIPv4,
+				VrfName: "foo",
+			},
+			wantFail: true,
+		},
+	}
+
+	lis := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer()
+	api.RegisterBgpServiceServer(s, NewBGPAPIServer(nil, vrfReg))
+	go func() {
+		if err := s.Serve(lis); err != nil {
+			t.Logf("Server exited with error: %v", err)
+		}
+	}()
+	defer s.Stop()
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithDialer(func(string, time.Duration) (net.Conn, error) {
+		return lis.Dial()
+	}), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+
+	client := api.NewBgpServiceClient(conn)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			streamClient, err := client.DumpFlowSpecRIB(ctx, test.req)
+			if err != nil {
+				t.Fatalf("DumpFlowSpecRIB client call failed: %v", err)
+			}
+
+			results := make([]string, 0)
+			for {
+				r, err := streamClient.Recv()
+				if err != nil {
+					if err != io.EOF {
+						assert.True(t, test.wantFail, "unexpected error: %v", err)
+					} else {
+						assert.False(t, test.wantFail, "expected error")
+					}
+
+					break
+				}
+
+				results = append(results, r.String())
+			}
+
+			expected := make([]string, 0)
+			for _, exp := range test.expected {
+				expected = append(expected, exp.String())
+			}
+
+			assert.Equal(t, expected, results)
+		})
+	}
+}
+
+func TestListSessions(t *testing.T) {
+	v := vrf.GetGlobalRegistry().CreateVRFIfNotExists(vrf.DefaultVRFName, 0)
+	establishedTime := time.Unix(1700000000, 0)
+
+	p := &peer{
+		config: &PeerConfig{
+			LocalAddress: bnet.IPv4FromOctets(192, 0, 2, 1).Ptr(),
+			Description:  "customer",
+		},
+		peerASN:  65001,
+		localASN: 65000,
+		addr:     bnet.IPv4FromOctets(192, 0, 2, 2).Dedup(),
+		ipv4: &peerAddressFamily{
+			prefixLimit: &PrefixLimitConfig{Max: 100, WarningThresholdPercent: 80},
+		},
+		ipv6: &peerAddressFamily{},
+		vrf:  v,
+	}
+
+	fsm := newFSM(p)
+	fsm.state = &establishedState{}
+	fsm.ribsInitialized = true
+	fsm.establishedTime = establishedTime
+	fsm.ipv4Unicast.adjRIBIn = &routingtable.RTMockClient{FakeRouteCount: 80, FakePathCount: 90}
+	fsm.ipv4Unicast.adjRIBOut = &routingtable.RTMockClient{FakeRouteCount: 3}
+	fsm.ipv4Unicast.prefixLimitWarning.Store(true)
+	fsm.ipv6Unicast.adjRIBIn = &routingtable.RTMockClient{FakeRouteCount: 5, FakePathCount: 5}
+	fsm.ipv6Unicast.adjRIBOut = &routingtable.RTMockClient{FakeRouteCount: 4}
+	p.fsms = append(p.fsms, fsm)
+
+	s := newBGPServer(BGPServerConfig{DefaultVRF: v})
+	s.peers.add(p)
+
+	apisrv := NewBGPAPIServer(s, vrf.GetGlobalRegistry())
+
+	expected := &api.ListSessionsResponse{
+		Sessions: []*api.Session{
+			{
+				LocalAddress:     bnet.IPv4FromOctets(192, 0, 2, 1).ToProto(),
+				NeighborAddress:  bnet.IPv4FromOctets(192, 0, 2, 2).ToProto(),
+				LocalAsn:         65000,
+				PeerAsn:          65001,
+				Status:           api.Session_Established,
+				EstablishedSince: uint64(establishedTime.Unix()),
+				Description:      "customer",
+				Stats: &api.SessionStats{
+					RoutesReceived: 85,
+					RoutesExported: 7,
+					PrefixLimits: []*api.PrefixLimitStats{
+						{
+							Afi:           packet.AFIIPv4,
+							Safi:          packet.SAFIUnicast,
+							Max:           100,
+							PathsReceived: 90,
+							Warning:       true,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	res, err := apisrv.ListSessions(context.Background(), &api.ListSessionsRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, expected, res)
+
+	res, err = apisrv.ListSessions(context.Background(), &api.ListSessionsRequest{
+		Filter: &api.SessionFilter{
+			NeighborIp: bnet.IPv4FromOctets(192, 0, 2, 3).ToProto(),
+		},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, res.Sessions, 0)
+}
+
+func TestClearDampening(t *testing.T) {
+	dampening.SetClock(bbclock.NewMock())
+
+	v := vrf.GetGlobalRegistry().CreateVRFIfNotExists(vrf.DefaultVRFName, 0)
+	pfx1 := bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 100, 0), 24).Ptr()
+	pfx2 := bnet.NewPfx(bnet.IPv4FromOctets(203, 0, 113, 0), 24).Ptr()
+	bgpPath := func() *route.Path {
+		return &route.Path{
+			Type: route.BGPPathType,
+			BGPPath: &route.BGPPath{
+				ASPath: types.NewASPath([]uint32{65001}),
+				BGPPathA: &route.BGPPathA{
+					NextHop: bnet.IPv4FromOctets(192, 0, 2, 2).Ptr(),
+					Source:  bnet.IPv4FromOctets(192, 0, 2, 2).Ptr(),
+				},
+			},
+		}
+	}
+
+	d := dampening.New(dampening.Config{
+		HalfLife:          dampening.DefaultHalfLife,
+		ReuseThreshold:    dampening.DefaultReuseThreshold,
+		SuppressThreshold: dampening.DefaultSuppressThreshold,
+		MaxSuppressTime:   dampening.DefaultMaxSuppressTime,
+	})
+	defer d.Stop()
+
+	p := &peer{
+		peerASN:  65001,
+		localASN: 65000,
+		addr:     bnet.IPv4FromOctets(192, 0, 2, 2).Dedup(),
+		ipv4:     &peerAddressFamily{},
+		ipv6:     &peerAddressFamily{},
+		vrf:      v,
+	}
+
+	fsm := newFSM(p)
+	rib := adjRIBIn.New(filter.NewAcceptAllFilterChain(), v, routingtable.SessionAttrs{
+		RouterID:      bnet.IPv4FromOctets(192, 0, 2, 1).ToUint32(),
+		LocalASN:      65000,
+		PeerASN:       65001,
+		RouteDampener: d,
+	})
+	fsm.ipv4Unicast.adjRIBIn = rib
+	p.fsms = append(p.fsms, fsm)
+
+	locRIB := locRIB.New("inet.0")
+	rib.Register(locRIB)
+
+	for _, pfx := range []*bnet.Prefix{pfx1, pfx2} {
+		for i := 0; i < 7; i++ {
+			rib.AddPath(pfx, bgpPath())
+			rib.RemovePath(pfx, bgpPath())
+		}
+
+		rib.AddPath(pfx, bgpPath())
+		assert.Equal(t, uint8(route.HiddenReasonDampened), rib.Get(pfx).Paths()[0].HiddenReason)
+	}
+
+	assert.Equal(t, int64(0), locRIB.RouteCount())
+
+	s := newBGPServer(BGPServerConfig{DefaultVRF: v})
+	s.peers.add(p)
+	apisrv := NewBGPAPIServer(s, vrf.GetGlobalRegistry())
+
+	_, err := apisrv.ClearDampening(context.Background(), &api.ClearDampeningRequest{
+		Peer: bnet.IPv4FromOctets(192, 0, 2, 3).ToProto(),
+		Afi:  packet.AFIIPv4,
+		Safi: packet.SAFIUnicast,
+	})
+	assert.Error(t, err)
+
+	_, err = apisrv.ClearDampening(context.Background(), &api.ClearDampeningRequest{
+		Peer: bnet.IPv4FromOctets(192, 0, 2, 2).ToProto(),
+		Afi:  packet.AFIIPv4,
+		Safi: packet.SAFIUnicast,
+		Pfx:  pfx1.ToProto(),
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, int64(1), locRIB.RouteCount())
+	assert.NotNil(t, locRIB.Get(pfx1))
+	assert.Nil(t, rib.DampeningState(pfx1, rib.Get(pfx1).Paths()[0]))
+	assert.True(t, rib.DampeningState(pfx2, rib.Get(pfx2).Paths()[0]).Suppressed)
+}
@@ -56,12 +56,8 @@ func (nm *neighborManager) _neighborDown(vrfID uint64, addr [16]byte) error {
 			continue
 		}
 
-		if nm.neighbors[i].fsm.ipv4Unicast != nil {
-			nm.neighbors[i].fsm.ipv4Unicast.bmpDispose()
-		}
-
-		if nm.neighbors[i].fsm.ipv6Unicast != nil {
-			nm.neighbors[i].fsm.ipv6Unicast.bmpDispose()
+		for _, f := range nm.neighbors[i].fsm.addressFamilies() {
+			f.bmpDispose()
 		}
 
 		nm.neighbors = append(nm.neighbors[:i], nm.neighbors[i+1:]...)
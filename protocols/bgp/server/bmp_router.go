@@ -408,6 +408,8 @@ func (r *Router) processPeerUpNotification(msg *bmppkt.PeerUpNotification) error
 			localASN:        uint32(sentOpen.ASN),
 			ipv4:            &peerAddressFamily{},
 			ipv6:            &peerAddressFamily{},
+			flowSpecV4:      &peerAddressFamily{},
+			flowSpecV6:      &peerAddressFamily{},
 			vrf:             r.vrfRegistry.CreateVRFIfNotExists(vrf.RouteDistinguisherHumanReadable(msg.PerPeerHeader.PeerDistinguisher), msg.PerPeerHeader.PeerDistinguisher),
 			adjRIBInFactory: r.adjRIBInFactory,
 		},
@@ -440,6 +442,28 @@ func (r *Router) processPeerUpNotification(msg *bmppkt.PeerUpNotification) error
 	}, fsm)
 	fsm.ipv6Unicast.bmpInit()
 
+	flowRIB4 := fsm.peer.vrf.IPv4FlowSpecRIB()
+	if flowRIB4 == nil {
+		return fmt.Errorf("unable to get inetflow RIB")
+	}
+
+	fsm.ipv4FlowSpec = newFSMAddressFamily(packet.AFIIPv4, packet.SAFIFlowSpec, &peerAddressFamily{
+		rib:               flowRIB4,
+		importFilterChain: filter.NewAcceptAllFilterChain(),
+	}, fsm)
+	fsm.ipv4FlowSpec.bmpInit()
+
+	flowRIB6 := fsm.peer.vrf.IPv6FlowSpecRIB()
+	if flowRIB6 == nil {
+		return fmt.Errorf("unable to get inet6flow RIB")
+	}
+
+	fsm.ipv6FlowSpec = newFSMAddressFamily(packet.AFIIPv6, packet.SAFIFlowSpec, &peerAddressFamily{
+		rib:               flowRIB6,
+		importFilterChain: filter.NewAcceptAllFilterChain(),
+	}, fsm)
+	fsm.ipv6FlowSpec.bmpInit()
+
 	fsm.state = newOpenSentState(fsm)
 	openSent := fsm.state.(*openSentState)
 	openSent.openMsgReceived(recvOpen)
@@ -0,0 +1,434 @@
+package server
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/routingtable/filter"
+	"github.com/bio-routing/bio-rd/routingtable/vrf"
+	"github.com/bio-routing/bio-rd/util/log"
+)
+
+// DynamicNeighborConfig accepts BGP sessions from all addresses within a prefix. A passive peer is created for
+// every incoming connection and removed again once its session went down.
+type DynamicNeighborConfig struct {
+	// Prefix sessions are accepted from
+	Prefix *bnet.Prefix
+
+	// PeerASNs are the AS numbers accepted in the OPEN message of a dynamic peer
+	PeerASNs []ASNRange
+
+	// MaxPeers limits the number of concurrent dynamic peers (0 = unlimited)
+	MaxPeers uint32
+
+	// PeerConfig is the template for the dynamic peers. PeerAddress and PeerAS are set per peer.
+	PeerConfig PeerConfig
+}
+
+// ASNRange is an inclusive range of AS numbers
+type ASNRange struct {
+	From uint32
+	To   uint32
+}
+
+// Contains checks if asn is within the range
+func (r ASNRange) Contains(asn uint32) bool {
+	return asn >= r.From && asn <= r.To
+}
+
+func (r ASNRange) String() string {
+	if r.From == r.To {
+		return fmt.Sprintf("%d", r.From)
+	}
+
+	return fmt.Sprintf("%d-%d", r.From, r.To)
+}
+
+func (c *DynamicNeighborConfig) acceptsPeerASN(asn uint32) bool {
+	for _, r := range c.PeerASNs {
+		if r.Contains(asn) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NeedsRestart determines if the dynamic peers have to be torn down on cfg change
+func (c *DynamicNeighborConfig) NeedsRestart(x *DynamicNeighborConfig) bool {
+	if *c.Prefix != *x.Prefix {
+		return true
+	}
+
+	if len(c.PeerASNs) != len(x.PeerASNs) {
+		return true
+	}
+
+	for i := range c.PeerASNs {
+		if c.PeerASNs[i] != x.PeerASNs[i] {
+			return true
+		}
+	}
+
+	return c.PeerConfig.NeedsRestart(&x.PeerConfig)
+}
+
+// filterChains returns the import and export filter chain of the template. All address families share the same chains.
+func (c *DynamicNeighborConfig) filterChains() (filter.Chain, filter.Chain) {
+	for _, afc := range []*AddressFamilyConfig{c.PeerConfig.IPv4, c.PeerConfig.IPv6, c.PeerConfig.VPNv4, c.PeerConfig.VPNv6, c.PeerConfig.FlowSpecV4, c.PeerConfig.FlowSpecV6} {
+		if afc != nil {
+			return afc.ImportFilterChain, afc.ExportFilterChain
+		}
+	}
+
+	return nil, nil
+}
+
+type dynamicNeighborsKey struct {
+	vrf    *vrf.VRF
+	prefix bnet.Prefix
+}
+
+// dynamicNeighbors tracks the peers created for one prefix
+type dynamicNeighbors struct {
+	// guarded by mu
+	config   DynamicNeighborConfig
+	peers    map[*peer]struct{}
+	disposed bool
+	mu       sync.RWMutex
+}
+
+func newDynamicNeighbors(c DynamicNeighborConfig) *dynamicNeighbors {
+	return &dynamicNeighbors{
+		config: c,
+		peers:  make(map[*peer]struct{}),
+	}
+}
+
+func (d *dynamicNeighbors) getConfig() DynamicNeighborConfig {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	return d.config
+}
+
+func (d *dynamicNeighbors) acceptsPeerASN(asn uint32) bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	return d.config.acceptsPeerASN(asn)
+}
+
+func (d *dynamicNeighbors) peerASNs() []ASNRange {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	return d.config.PeerASNs
+}
+
+// update replaces the config of the dynamic peers and applies changed filters, prefix limits and dampening parameters
+// to the established sessions
+func (d *dynamicNeighbors) update(c DynamicNeighborConfig) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.config = c
+	importChain, exportChain := c.filterChains()
+	for p := range d.peers {
+		p.replaceImportFilterChain(importChain)
+		p.replaceExportFilterChain(exportChain)
+		p.replacePrefixLimits(&c.PeerConfig)
+		p.replaceNextHopTracker(c.PeerConfig.NextHopTracker)
+		p.replaceDampening(&c.PeerConfig)
+	}
+}
+
+// addPeer creates a peer for addr and registers it with the server
+func (d *dynamicNeighbors) addPeer(b *bgpServer, addr *bnet.IP) (*peer, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.disposed {
+		return nil, fmt.Errorf("dynamic neighbors for %s have been removed", d.config.Prefix)
+	}
+
+	if d.config.MaxPeers != 0 && d.activePeers() >= d.config.MaxPeers {
+		return nil, fmt.Errorf("limit of %d dynamic neighbors for %s reached", d.config.MaxPeers, d.config.Prefix)
+	}
+
+	c := d.config.PeerConfig
+	c.PeerAddress = addr
+	c.PeerAS = 0
+	c.Passive = true
+
+	p, err := newPeer(c, b)
+	if err != nil {
+		return nil, err
+	}
+
+	p.routerID = c.RouterID
+	p.dynamic = d
+
+	err = b.subscribeBFD(p)
+	if err != nil {
+		return nil, fmt.Errorf("unable to subscribe to BFD session: %w", err)
+	}
+
+	d.peers[p] = struct{}{}
+	b.peers.add(p)
+
+	return p, nil
+}
+
+// activePeers returns the number of peers not held down after exceeding a prefix limit. Held down peers are only kept
+// to enforce the hold down on reconnect and thus don't count against MaxPeers. The caller has to hold d.mu.
+func (d *dynamicNeighbors) activePeers() uint32 {
+	n := uint32(0)
+	for p := range d.peers {
+		if !p.prefixLimitHoldDown.heldDown() {
+			n++
+		}
+	}
+
+	return n
+}
+
+// removePeer unregisters p. It returns false if p has already been removed.
+func (d *dynamicNeighbors) removePeer(p *peer) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, exists := d.peers[p]; !exists {
+		return false
+	}
+
+	delete(d.peers, p)
+	return true
+}
+
+// dispose prevents new peers from being created and returns the existing ones
+func (d *dynamicNeighbors) dispose() []*peer {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.disposed = true
+	ret := make([]*peer, 0, len(d.peers))
+	for p := range d.peers {
+		ret = append(ret, p)
+	}
+
+	return ret
+}
+
+type dynamicNeighborManager struct {
+	ranges   map[dynamicNeighborsKey]*dynamicNeighbors
+	rangesMu sync.RWMutex
+}
+
+func newDynamicNeighborManager() *dynamicNeighborManager {
+	return &dynamicNeighborManager{
+		ranges: make(map[dynamicNeighborsKey]*dynamicNeighbors),
+	}
+}
+
+func (m *dynamicNeighborManager) add(v *vrf.VRF, d *dynamicNeighbors) {
+	m.rangesMu.Lock()
+	defer m.rangesMu.Unlock()
+
+	m.ranges[dynamicNeighborsKey{vrf: v, prefix: *d.config.Prefix}] = d
+}
+
+func (m *dynamicNeighborManager) remove(v *vrf.VRF, pfx *bnet.Prefix) *dynamicNeighbors {
+	m.rangesMu.Lock()
+	defer m.rangesMu.Unlock()
+
+	k := dynamicNeighborsKey{vrf: v, prefix: *pfx}
+	d := m.ranges[k]
+	delete(m.ranges, k)
+	return d
+}
+
+func (m *dynamicNeighborManager) get(v *vrf.VRF, pfx *bnet.Prefix) *dynamicNeighbors {
+	m.rangesMu.RLock()
+	defer m.rangesMu.RUnlock()
+
+	return m.ranges[dynamicNeighborsKey{vrf: v, prefix: *pfx}]
+}
+
+// match finds the most specific prefix in VRF v containing addr
+func (m *dynamicNeighborManager) match(v *vrf.VRF, addr *bnet.IP) *dynamicNeighbors {
+	m.rangesMu.RLock()
+	defer m.rangesMu.RUnlock()
+
+	var ret *dynamicNeighbors
+	for k, d := range m.ranges {
+		if k.vrf != v || !k.prefix.GetIPNet().Contains(addr.ToNetIP()) {
+			continue
+		}
+
+		if ret == nil || k.prefix.Len() > ret.config.Prefix.Len() {
+			ret = d
+		}
+	}
+
+	return ret
+}
+
+func (m *dynamicNeighborManager) list() []*dynamicNeighbors {
+	m.rangesMu.RLock()
+	defer m.rangesMu.RUnlock()
+
+	ret := make([]*dynamicNeighbors, 0, len(m.ranges))
+	for _, d := range m.ranges {
+		ret = append(ret, d)
+	}
+
+	return ret
+}
+
+// ConfigureDynamicNeighbors adds dynamic neighbors for a prefix or updates them if they exist already
+func (b *bgpServer) ConfigureDynamicNeighbors(c DynamicNeighborConfig) error {
+	if c.PeerConfig.AuthenticationKey != "" {
+		return fmt.Errorf("TCP MD5 authentication is not supported for dynamic neighbors")
+	}
+
+	// The Peer Role capability is sent before the AS of a dynamic peer is known but must not be used for iBGP (RFC9234)
+	if peerRoleEnabled(c.PeerConfig.PeerRole) && c.acceptsPeerASN(c.PeerConfig.LocalAS) {
+		return fmt.Errorf("peer role is not supported for dynamic neighbors accepting iBGP sessions")
+	}
+
+	c.Prefix = c.Prefix.Dedup()
+	v := c.PeerConfig.VRF
+
+	existing := b.dynamicNeighbors.get(v, c.Prefix)
+	if existing != nil {
+		old := existing.getConfig()
+		if !old.NeedsRestart(&c) {
+			existing.update(c)
+			return nil
+		}
+
+		b.DisposeDynamicNeighbors(v, c.Prefix)
+	}
+
+	err := b.listenerManager.CreateListenersIfNotExists(v)
+	if err != nil {
+		return err
+	}
+
+	b.dynamicNeighbors.add(v, newDynamicNeighbors(c))
+
+	log.WithFields(log.Fields{
+		"prefix":   c.Prefix,
+		"vrf":      v.Name(),
+		"local_as": c.PeerConfig.LocalAS,
+	}).Infof("Added BGP dynamic neighbors")
+
+	return nil
+}
+
+// GetDynamicNeighbors gets the configs of all dynamic neighbor prefixes
+func (b *bgpServer) GetDynamicNeighbors() []DynamicNeighborConfig {
+	ranges := b.dynamicNeighbors.list()
+	ret := make([]DynamicNeighborConfig, 0, len(ranges))
+	for _, d := range ranges {
+		ret = append(ret, d.getConfig())
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Prefix.String() < ret[j].Prefix.String()
+	})
+
+	return ret
+}
+
+// DisposeDynamicNeighbors stops accepting sessions from a prefix and disposes all peers created for it
+func (b *bgpServer) DisposeDynamicNeighbors(v *vrf.VRF, pfx *bnet.Prefix) {
+	d := b.dynamicNeighbors.remove(v, pfx)
+	if d == nil {
+		return
+	}
+
+	log.Infof("disposing BGP dynamic neighbors %s", pfx.String())
+	for _, p := range d.dispose() {
+		b.DisposePeer(p.vrf, p.addr)
+	}
+}
+
+// addDynamicPeer creates a peer for an incoming connection if addr is covered by dynamic neighbors
+func (b *bgpServer) addDynamicPeer(v *vrf.VRF, addr *bnet.IP) *peer {
+	d := b.dynamicNeighbors.match(v, addr)
+	if d == nil {
+		return nil
+	}
+
+	p, err := d.addPeer(b, addr)
+	if err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"source": addr,
+		}).Error("Unable to add dynamic BGP peer")
+		return nil
+	}
+
+	log.WithFields(log.Fields{
+		"peer_address": addr,
+		"prefix":       d.getConfig().Prefix,
+	}).Infof("Added dynamic BGP peer")
+
+	return p
+}
+
+// dynamicPeerDown removes a dynamic peer once the session handled by fsm went down. Peers held down after
+// exceeding a prefix limit are kept to enforce the hold down on reconnect and removed once it expired.
+// A hold down without restart time would only be lifted by reconfiguring the peer, so such peers are removed right away.
+func (b *bgpServer) dynamicPeerDown(p *peer, fsm *FSM) {
+	p.fsmsMu.Lock()
+	for i, f := range p.fsms {
+		if f == fsm {
+			p.fsms = append(p.fsms[:i], p.fsms[i+1:]...)
+			break
+		}
+	}
+	remaining := len(p.fsms)
+	p.fsmsMu.Unlock()
+
+	fsm.cease()
+
+	if remaining > 0 {
+		return
+	}
+
+	if d, held := p.prefixLimitHoldDown.remaining(); held && d != 0 {
+		time.AfterFunc(d, func() {
+			if p.prefixLimitHoldDown.heldDown() {
+				return
+			}
+
+			b.removeIdleDynamicPeer(p)
+		})
+
+		return
+	}
+
+	b.removeIdleDynamicPeer(p)
+}
+
+// removeIdleDynamicPeer removes a dynamic peer unless a new session has been started meanwhile
+func (b *bgpServer) removeIdleDynamicPeer(p *peer) {
+	p.fsmsMu.Lock()
+	idle := len(p.fsms) == 0
+	p.fsmsMu.Unlock()
+
+	if !idle {
+		return
+	}
+
+	if b.peers.get(p.vrf, p.addr) != p {
+		return
+	}
+
+	b.DisposePeer(p.vrf, p.addr)
+}
@@ -0,0 +1,359 @@
+package server
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/net/tcp"
+	"github.com/bio-routing/bio-rd/protocols/bgp/packet"
+	"github.com/bio-routing/bio-rd/routingtable/filter"
+	"github.com/bio-routing/bio-rd/routingtable/vrf"
+	"github.com/stretchr/testify/assert"
+)
+
+func newDynamicNeighborsTestServer() (*bgpServer, *vrf.VRF) {
+	b := newBGPServer(BGPServerConfig{
+		RouterID: bnet.IPv4FromOctets(192, 0, 2, 1).ToUint32(),
+	})
+
+	lm := tcp.NewListenerManager(map[string][]string{
+		vrf.DefaultVRFName: {
+			"0.0.0.0:179",
+		},
+	}, false)
+	lm.SetListenerFactory(tcp.NewMockListenerFactory())
+	b.SetListenerManager(lm)
+
+	v := vrf.NewUntrackedVRF(vrf.DefaultVRFName, 0)
+	v.CreateIPv4UnicastLocRIB("inet.0")
+
+	return b, v
+}
+
+func dynamicNeighborsTestConfig(v *vrf.VRF, pfx bnet.Prefix, max uint32) DynamicNeighborConfig {
+	return DynamicNeighborConfig{
+		Prefix: pfx.Ptr(),
+		PeerASNs: []ASNRange{
+			{From: 65100, To: 65199},
+		},
+		MaxPeers: max,
+		PeerConfig: PeerConfig{
+			LocalAS:  65000,
+			HoldTime: 90 * time.Second,
+			VRF:      v,
+			IPv4: &AddressFamilyConfig{
+				ImportFilterChain: filter.NewAcceptAllFilterChain(),
+				ExportFilterChain: filter.NewAcceptAllFilterChain(),
+			},
+		},
+	}
+}
+
+func TestDynamicNeighborManagerMatch(t *testing.T) {
+	v1 := vrf.NewUntrackedVRF("vrf1", 0)
+	v2 := vrf.NewUntrackedVRF("vrf2", 0)
+
+	wide := newDynamicNeighbors(DynamicNeighborConfig{Prefix: bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 0), 8).Ptr()})
+	narrow := newDynamicNeighbors(DynamicNeighborConfig{Prefix: bnet.NewPfx(bnet.IPv4FromOctets(10, 1, 0, 0), 16).Ptr()})
+	other := newDynamicNeighbors(DynamicNeighborConfig{Prefix: bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 0), 8).Ptr()})
+	v6 := newDynamicNeighbors(DynamicNeighborConfig{Prefix: bnet.NewPfx(bnet.IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 0), 32).Ptr()})
+
+	m := newDynamicNeighborManager()
+	m.add(v1, wide)
+	m.add(v1, narrow)
+	m.add(v1, v6)
+	m.add(v2, other)
+
+	tests := []struct {
+		name     string
+		vrf      *vrf.VRF
+		addr     bnet.IP
+		expected *dynamicNeighbors
+	}{
+		{
+			name:     "less specific",
+			vrf:      v1,
+			addr:     bnet.IPv4FromOctets(10, 2, 0, 1),
+			expected: wide,
+		},
+		{
+			name:     "most specific",
+			vrf:      v1,
+			addr:     bnet.IPv4FromOctets(10, 1, 0, 1),
+			expected: narrow,
+		},
+		{
+			name:     "other VRF",
+			vrf:      v2,
+			addr:     bnet.IPv4FromOctets(10, 1, 0, 1),
+			expected: other,
+		},
+		{
+			name:     "IPv6",
+			vrf:      v1,
+			addr:     bnet.IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 1),
+			expected: v6,
+		},
+		{
+			name: "no match",
+			vrf:  v1,
+			addr: bnet.IPv4FromOctets(192, 0, 2, 1),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Same(t, test.expected, m.match(test.vrf, &test.addr))
+		})
+	}
+}
+
+func TestDynamicPeers(t *testing.T) {
+	b, v := newDynamicNeighborsTestServer()
+	pfx := bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 0), 16)
+
+	assert.NoError(t, b.ConfigureDynamicNeighbors(dynamicNeighborsTestConfig(v, pfx, 2)))
+	assert.Len(t, b.GetDynamicNeighbors(), 1)
+
+	addrA := bnet.IPv4FromOctets(10, 0, 0, 1).Dedup()
+	addrB := bnet.IPv4FromOctets(10, 0, 1, 1).Dedup()
+	addrC := bnet.IPv4FromOctets(10, 0, 2, 1).Dedup()
+
+	assert.Nil(t, b.addDynamicPeer(v, bnet.IPv4FromOctets(10, 1, 0, 1).Dedup()), "address outside of prefix")
+
+	pA := b.addDynamicPeer(v, addrA)
+	if !assert.NotNil(t, pA) {
+		return
+	}
+	assert.True(t, pA.passive)
+	assert.Equal(t, uint32(0), pA.getPeerASN())
+	assert.Same(t, pA, b.peers.get(v, addrA))
+
+	assert.NotNil(t, b.addDynamicPeer(v, addrB))
+	assert.Nil(t, b.addDynamicPeer(v, addrC), "limit exceeded")
+
+	// Dynamic peers are listed in the metrics but not as configured peers
+	assert.Len(t, b.GetPeers(), 0)
+	m, err := b.Metrics()
+	assert.NoError(t, err)
+	assert.Len(t, m.Peers, 2)
+	for _, p := range m.Peers {
+		assert.True(t, p.Dynamic)
+	}
+
+	// Changing the limit keeps the peers
+	assert.NoError(t, b.ConfigureDynamicNeighbors(dynamicNeighborsTestConfig(v, pfx, 3)))
+	assert.Same(t, pA, b.peers.get(v, addrA))
+	assert.NotNil(t, b.addDynamicPeer(v, addrC))
+
+	// A configured peer replaces the dynamic one
+	assert.NoError(t, b.AddPeer(PeerConfig{
+		LocalAS:      65000,
+		PeerAS:       65100,
+		LocalAddress: bnet.IPv4FromOctets(10, 0, 0, 254).Ptr(),
+		PeerAddress:  addrA,
+		Passive:      true,
+		VRF:          v,
+	}))
+	assert.NotSame(t, pA, b.peers.get(v, addrA))
+	assert.Len(t, b.GetPeers(), 1)
+
+	b.DisposeDynamicNeighbors(v, pfx.Ptr())
+	assert.Len(t, b.GetDynamicNeighbors(), 0)
+	assert.Nil(t, b.peers.get(v, addrB))
+	assert.Nil(t, b.peers.get(v, addrC))
+	assert.NotNil(t, b.peers.get(v, addrA))
+}
+
+func TestDynamicNeighborsNeedsRestart(t *testing.T) {
+	b, v := newDynamicNeighborsTestServer()
+	pfx := bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 0), 16)
+
+	assert.NoError(t, b.ConfigureDynamicNeighbors(dynamicNeighborsTestConfig(v, pfx, 0)))
+	p := b.addDynamicPeer(v, bnet.IPv4FromOctets(10, 0, 0, 1).Dedup())
+	assert.NotNil(t, p)
+
+	c := dynamicNeighborsTestConfig(v, pfx, 0)
+	c.PeerASNs = []ASNRange{{From: 65100, To: 65100}}
+	assert.NoError(t, b.ConfigureDynamicNeighbors(c))
+	assert.Nil(t, b.peers.get(v, p.addr))
+	assert.Equal(t, []DynamicNeighborConfig{c}, b.GetDynamicNeighbors())
+
+	c.PeerConfig.AuthenticationKey = "secret"
+	assert.Error(t, b.ConfigureDynamicNeighbors(c))
+}
+
+func TestDynamicNeighborsPeerRole(t *testing.T) {
+	tests := []struct {
+		name     string
+		peerASNs []ASNRange
+		wantFail bool
+	}{
+		{
+			name:     "eBGP",
+			peerASNs: []ASNRange{{From: 65100, To: 65199}},
+		},
+		{
+			name:     "iBGP",
+			peerASNs: []ASNRange{{From: 65100, To: 65199}, {From: 65000, To: 65000}},
+			wantFail: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b, v := newDynamicNeighborsTestServer()
+			c := dynamicNeighborsTestConfig(v, bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 0), 16), 0)
+			c.PeerASNs = test.peerASNs
+			c.PeerConfig.PeerRole = PeerConfigRoleCustomer
+
+			err := b.ConfigureDynamicNeighbors(c)
+			if test.wantFail {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestDynamicPeerDown(t *testing.T) {
+	b, v := newDynamicNeighborsTestServer()
+	pfx := bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 0), 16)
+	assert.NoError(t, b.ConfigureDynamicNeighbors(dynamicNeighborsTestConfig(v, pfx, 0)))
+
+	p := b.addDynamicPeer(v, bnet.IPv4FromOctets(10, 0, 0, 1).Dedup())
+	fsm := NewActiveFSM(p)
+	p.fsms = append(p.fsms, fsm)
+
+	done := make(chan struct{})
+	go func() {
+		fsm.run()
+		close(done)
+	}()
+
+	b.dynamicPeerDown(p, fsm)
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("FSM of dynamic peer did not terminate")
+	}
+
+	assert.Nil(t, b.peers.get(v, p.addr))
+	assert.Len(t, p.fsms, 0)
+	assert.Len(t, b.dynamicNeighbors.get(v, pfx.Ptr()).peers, 0)
+}
+
+func TestDynamicPeerDownHeldDown(t *testing.T) {
+	tests := []struct {
+		name        string
+		restartTime time.Duration
+	}{
+		{
+			name:        "hold down expires",
+			restartTime: 50 * time.Millisecond,
+		},
+		{
+			name: "no restart time",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b, v := newDynamicNeighborsTestServer()
+			pfx := bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 0), 16)
+			assert.NoError(t, b.ConfigureDynamicNeighbors(dynamicNeighborsTestConfig(v, pfx, 1)))
+
+			p := b.addDynamicPeer(v, bnet.IPv4FromOctets(10, 0, 0, 1).Dedup())
+			fsm := NewActiveFSM(p)
+			p.fsms = append(p.fsms, fsm)
+			p.prefixLimitHoldDown.start(test.restartTime)
+			go fsm.run()
+
+			b.dynamicPeerDown(p, fsm)
+			if test.restartTime == 0 {
+				// The hold down would never expire as dynamic peers are not reconfigured individually
+				assert.Nil(t, b.peers.get(v, p.addr))
+				assert.Empty(t, b.dynamicNeighbors.get(v, pfx.Ptr()).peers)
+				return
+			}
+
+			assert.Same(t, p, b.peers.get(v, p.addr), "held down peer is kept")
+
+			// Held down peers don't count against the limit of dynamic peers
+			q := b.addDynamicPeer(v, bnet.IPv4FromOctets(10, 0, 0, 2).Dedup())
+			assert.NotNil(t, q)
+
+			assert.Eventually(t, func() bool {
+				return b.peers.get(v, p.addr) == nil
+			}, time.Second, 10*time.Millisecond)
+			assert.Len(t, b.dynamicNeighbors.get(v, pfx.Ptr()).peers, 1)
+		})
+	}
+}
+
+func TestDynamicPeerOpenASN(t *testing.T) {
+	tests := []struct {
+		name     string
+		asn      uint16
+		wantIdle bool
+	}{
+		{
+			name: "ASN within range",
+			asn:  65150,
+		},
+		{
+			name:     "ASN outside of range",
+			asn:      65200,
+			wantIdle: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := &peer{
+				dynamic: newDynamicNeighbors(DynamicNeighborConfig{
+					PeerASNs: []ASNRange{{From: 65100, To: 65199}},
+				}),
+			}
+			fsm := newFSM(p)
+
+			conA, conB := net.Pipe()
+			fsm.con = conB
+			go func() {
+				buf := make([]byte, 1)
+				for {
+					_, err := conA.Read(buf)
+					if err != nil {
+						return
+					}
+				}
+			}()
+			defer conA.Close()
+
+			s := &openSentState{
+				fsm: fsm,
+			}
+
+			state, _ := s.handleOpenMessage(&packet.BGPOpen{
+				HoldTime:      90,
+				BGPIdentifier: 1,
+				Version:       4,
+				ASN:           test.asn,
+			})
+
+			if test.wantIdle {
+				assert.IsType(t, &idleState{}, state, "state")
+				assert.Equal(t, uint32(0), p.getPeerASN())
+				return
+			}
+
+			assert.IsType(t, &openConfirmState{}, state, "state")
+			assert.Equal(t, uint32(test.asn), p.getPeerASN())
+		})
+	}
+}
@@ -10,6 +10,8 @@ import (
 
 	"github.com/bio-routing/bio-rd/net/tcp"
 	"github.com/bio-routing/bio-rd/protocols/bgp/packet"
+	"github.com/bio-routing/bio-rd/routingtable"
+	"github.com/bio-routing/bio-rd/routingtable/dampening"
 	"github.com/bio-routing/bio-rd/routingtable/filter"
 	"github.com/bio-routing/bio-rd/util/log"
 )
@@ -65,17 +67,33 @@ type FSM struct {
 	keepaliveTimer *time.Timer
 
 	msgRecvCh     chan []byte
-	msgRecvFailCh chan error
+	msgRecvFailCh chan msgRecvFailure
 	stopMsgRecvCh chan struct{}
 
+	// peerRestartCh receives requests to hand over the session to a new connection of a restarting peer
+	peerRestartCh chan chan bool
+
+	bfdDownCh chan struct{}
+	bfdUpCh   chan struct{}
+
 	local net.IP
 
 	ribsInitialized bool
 	ipv4Unicast     *fsmAddressFamily
 	ipv6Unicast     *fsmAddressFamily
+	ipv4VPN         *fsmAddressFamily
+	ipv6VPN         *fsmAddressFamily
+	ipv4FlowSpec    *fsmAddressFamily
+	ipv6FlowSpec    *fsmAddressFamily
 
 	supports4OctetASN bool
 
+	supportsRouteRefresh         bool
+	supportsEnhancedRouteRefresh bool
+
+	// peerGracefulRestart is the Graceful Restart capability advertised by the peer (if any)
+	peerGracefulRestart *packet.GracefulRestartCapability
+
 	neighborID uint32
 	state      state
 	stateMu    sync.RWMutex
@@ -114,8 +132,11 @@ func newFSM(peer *peer) *FSM {
 		conErrCh:         make(chan error),
 		initiateCon:      make(chan struct{}),
 		msgRecvCh:        make(chan []byte),
-		msgRecvFailCh:    make(chan error),
+		msgRecvFailCh:    make(chan msgRecvFailure),
 		stopMsgRecvCh:    make(chan struct{}),
+		peerRestartCh:    make(chan chan bool),
+		bfdDownCh:        make(chan struct{}, 1),
+		bfdUpCh:          make(chan struct{}, 1),
 		counters:         fsmCounters{},
 	}
 
@@ -127,26 +148,61 @@ func newFSM(peer *peer) *FSM {
 		f.ipv6Unicast = newFSMAddressFamily(packet.AFIIPv6, packet.SAFIUnicast, peer.ipv6, f)
 	}
 
+	if peer.vpnv4 != nil {
+		f.ipv4VPN = newFSMAddressFamily(packet.AFIIPv4, packet.SAFIMPLSVPN, peer.vpnv4, f)
+	}
+
+	if peer.vpnv6 != nil {
+		f.ipv6VPN = newFSMAddressFamily(packet.AFIIPv6, packet.SAFIMPLSVPN, peer.vpnv6, f)
+	}
+
+	if peer.flowSpecV4 != nil {
+		f.ipv4FlowSpec = newFSMAddressFamily(packet.AFIIPv4, packet.SAFIFlowSpec, peer.flowSpecV4, f)
+	}
+
+	if peer.flowSpecV6 != nil {
+		f.ipv6FlowSpec = newFSMAddressFamily(packet.AFIIPv6, packet.SAFIFlowSpec, peer.flowSpecV6, f)
+	}
+
 	return f
 }
 
+// addressFamilies returns all address families configured for the FSM
+func (fsm *FSM) addressFamilies() []*fsmAddressFamily {
+	ret := make([]*fsmAddressFamily, 0, 6)
+	for _, f := range []*fsmAddressFamily{fsm.ipv4Unicast, fsm.ipv6Unicast, fsm.ipv4VPN, fsm.ipv6VPN, fsm.ipv4FlowSpec, fsm.ipv6FlowSpec} {
+		if f != nil {
+			ret = append(ret, f)
+		}
+	}
+
+	return ret
+}
+
 func (fsm *FSM) replaceImportFilterChain(c filter.Chain) {
-	if fsm.ipv4Unicast != nil {
-		fsm.ipv4Unicast.replaceImportFilterChain(c)
+	for _, f := range fsm.addressFamilies() {
+		f.replaceImportFilterChain(c)
 	}
+}
 
-	if fsm.ipv6Unicast != nil {
-		fsm.ipv6Unicast.replaceImportFilterChain(c)
+func (fsm *FSM) replaceNextHopTracker(t routingtable.NextHopTracker) {
+	for _, f := range fsm.addressFamilies() {
+		f.replaceNextHopTracker(t)
 	}
 }
 
-func (fsm *FSM) replaceExportFilterChain(c filter.Chain) {
-	if fsm.ipv4Unicast != nil {
-		fsm.ipv4Unicast.replaceExportFilterChain(c)
+func (fsm *FSM) replaceRouteDampener(afi uint16, d *dampening.Dampener) {
+	f := fsm.addressFamily(afi, packet.SAFIUnicast)
+	if f == nil {
+		return
 	}
 
-	if fsm.ipv6Unicast != nil {
-		fsm.ipv6Unicast.replaceExportFilterChain(c)
+	f.replaceRouteDampener(d)
+}
+
+func (fsm *FSM) replaceExportFilterChain(c filter.Chain) {
+	for _, f := range fsm.addressFamilies() {
+		f.replaceExportFilterChain(c)
 	}
 }
 
@@ -155,18 +211,31 @@ func (fsm *FSM) updateLastUpdateOrKeepalive() {
 }
 
 func (fsm *FSM) addressFamily(afi uint16, safi uint8) *fsmAddressFamily {
-	if safi != packet.SAFIUnicast {
-		return nil
+	switch safi {
+	case packet.SAFIUnicast:
+		switch afi {
+		case packet.AFIIPv4:
+			return fsm.ipv4Unicast
+		case packet.AFIIPv6:
+			return fsm.ipv6Unicast
+		}
+	case packet.SAFIMPLSVPN:
+		switch afi {
+		case packet.AFIIPv4:
+			return fsm.ipv4VPN
+		case packet.AFIIPv6:
+			return fsm.ipv6VPN
+		}
+	case packet.SAFIFlowSpec:
+		switch afi {
+		case packet.AFIIPv4:
+			return fsm.ipv4FlowSpec
+		case packet.AFIIPv6:
+			return fsm.ipv6FlowSpec
+		}
 	}
 
-	switch afi {
-	case packet.AFIIPv4:
-		return fsm.ipv4Unicast
-	case packet.AFIIPv6:
-		return fsm.ipv6Unicast
-	default:
-		return nil
-	}
+	return nil
 }
 
 func (fsm *FSM) start() {
@@ -210,6 +279,10 @@ func (fsm *FSM) run() {
 		fsm.state = next
 		fsm.stateMu.Unlock()
 
+		if oldState != newState && newState == stateNameIdle && fsm.peer.dynamic != nil {
+			go fsm.peer.server.dynamicPeerDown(fsm.peer, fsm)
+		}
+
 		next, reason = fsm.state.run()
 	}
 }
@@ -241,6 +314,13 @@ func stateName(s state) string {
 	}
 }
 
+func (fsm *FSM) established() bool {
+	fsm.stateMu.RLock()
+	defer fsm.stateMu.RUnlock()
+
+	return isEstablishedState(fsm.state)
+}
+
 func (fsm *FSM) cease() {
 	fsm.eventCh <- Cease
 }
@@ -311,17 +391,31 @@ func (fsm *FSM) tcpConnect() {
 	fsm.initiateCon <- struct{}{}
 }
 
-func (fsm *FSM) msgReceiver() error {
+// msgRecvFailure reports a failed read on the connection of a session
+type msgRecvFailure struct {
+	con net.Conn
+	err error
+}
+
+func (fsm *FSM) msgReceiver(c net.Conn) error {
 	for {
-		msg, err := recvMsg(fsm.con)
+		msg, err := recvMsg(c)
 		if err != nil {
-			fsm.msgRecvFailCh <- err
+			fsm.msgRecvFailCh <- msgRecvFailure{
+				con: c,
+				err: err,
+			}
 			return nil
 		}
 		fsm.msgRecvCh <- msg
 	}
 }
 
+// connectionFailed checks if a read failure belongs to the current connection and not to one of a previous session
+func (fsm *FSM) connectionFailed(f msgRecvFailure) bool {
+	return f.con == fsm.con
+}
+
 func (fsm *FSM) decodeOptions() *packet.DecodeOptions {
 	ret := &packet.DecodeOptions{
 		Use32BitASN:     fsm.supports4OctetASN,
@@ -371,7 +465,7 @@ func (fsm *FSM) openMessage() *packet.BGPOpen {
 		ASN:           fsm.local16BitASN(),
 		HoldTime:      uint16(fsm.peer.holdTime / time.Second),
 		BGPIdentifier: fsm.peer.routerID,
-		OptParams:     fsm.peer.optOpenParams,
+		OptParams:     fsm.peer.openParams(),
 	}
 }
 
@@ -397,6 +491,21 @@ func (fsm *FSM) sendNotification(errorCode uint8, errorSubCode uint8) error {
 	return nil
 }
 
+func (fsm *FSM) sendRouteRefresh(afi uint16, safi uint8, subType uint8) error {
+	msg := packet.SerializeRouteRefreshMsg(&packet.BGPRouteRefresh{
+		AFI:     afi,
+		SubType: subType,
+		SAFI:    safi,
+	})
+
+	_, err := fsm.con.Write(msg)
+	if err != nil {
+		return fmt.Errorf("unable to send ROUTE-REFRESH message: %w", err)
+	}
+
+	return nil
+}
+
 func (fsm *FSM) sendKeepalive() error {
 	msg := packet.SerializeKeepaliveMsg()
 
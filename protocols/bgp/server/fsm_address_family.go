@@ -11,11 +11,22 @@ import (
 	"github.com/bio-routing/bio-rd/routingtable"
 	"github.com/bio-routing/bio-rd/routingtable/adjRIBIn"
 	"github.com/bio-routing/bio-rd/routingtable/adjRIBOut"
+	"github.com/bio-routing/bio-rd/routingtable/dampening"
 	"github.com/bio-routing/bio-rd/routingtable/filter"
-	"github.com/bio-routing/bio-rd/routingtable/locRIB"
 	"github.com/bio-routing/bio-rd/routingtable/vrf"
+	"github.com/bio-routing/bio-rd/util/log"
 )
 
+// ribI is the interface of the RIBs an address family of a peer is connected to
+type ribI interface {
+	routingtable.RouteTableClient
+	RegisterWithOptions(client routingtable.RouteTableClient, opt routingtable.ClientOptions)
+	Unregister(client routingtable.RouteTableClient)
+	RefreshClient(client routingtable.RouteTableClient)
+	ClientCount() uint64
+	RouteCount() int64
+}
+
 // fsmAddressFamily holds RIBs and the UpdateSender of an peer for an AFI/SAFI combination
 type fsmAddressFamily struct {
 	afi  uint16
@@ -24,7 +35,7 @@ type fsmAddressFamily struct {
 
 	adjRIBIn  routingtable.AdjRIBIn
 	adjRIBOut routingtable.AdjRIBOut
-	rib       *locRIB.LocRIB
+	rib       ribI
 
 	importFilterChain filter.Chain
 	exportFilterChain filter.Chain
@@ -38,6 +49,36 @@ type fsmAddressFamily struct {
 
 	initialized            bool
 	endOfRIBMarkerReceived atomic.Bool
+
+	prefixLimit         *PrefixLimitConfig
+	prefixLimitWarning  atomic.Bool
+	prefixLimitExceeded atomic.Bool
+
+	// stalePaths holds all paths which have not been re-advertised since the peer signaled
+	// the beginning of an enhanced route refresh (RFC7313) or since it restarted (RFC4724)
+	stalePaths map[stalePathKey]*route.Path
+
+	// staleDeadline is set while waiting for the End-of-RIB marker of a restarted peer
+	staleDeadline time.Time
+
+	// ribOutDeferredUntil is set while we defer sending our routes after our own restart
+	ribOutDeferredUntil time.Time
+}
+
+type stalePathKey struct {
+	pfx    bnet.Prefix
+	pathID uint32
+	rd     uint64
+	rule   string
+}
+
+func newStalePathKey(pfx *bnet.Prefix, p *route.BGPPath) stalePathKey {
+	return stalePathKey{
+		pfx:    *pfx,
+		pathID: p.PathIdentifier,
+		rd:     p.RouteDistinguisher,
+		rule:   p.FlowSpecRule.String(),
+	}
 }
 
 func newFSMAddressFamily(afi uint16, safi uint8, family *peerAddressFamily, fsm *FSM) *fsmAddressFamily {
@@ -48,12 +89,37 @@ func newFSMAddressFamily(afi uint16, safi uint8, family *peerAddressFamily, fsm
 		rib:               family.rib,
 		importFilterChain: family.importFilterChain,
 		exportFilterChain: family.exportFilterChain,
+		prefixLimit:       family.prefixLimit,
 		addPathTX: routingtable.ClientOptions{
 			BestOnly: true,
 		},
 	}
 }
 
+// replaceNextHopTracker replaces the tracker of an established session. Sessions established later pick it up from the peer.
+func (f *fsmAddressFamily) replaceNextHopTracker(t routingtable.NextHopTracker) {
+	if !f.initialized || f.fsm.isBMP || f.safi == packet.SAFIFlowSpec {
+		return
+	}
+
+	f.adjRIBIn.ReplaceNextHopTracker(t)
+}
+
+// replaceRouteDampener replaces the dampener of an established session. Sessions established later pick it up from the peer.
+func (f *fsmAddressFamily) replaceRouteDampener(d *dampening.Dampener) {
+	if !f.initialized || f.fsm.isBMP {
+		return
+	}
+
+	// A nil dampener must not end up in a non-nil interface
+	if d == nil {
+		f.adjRIBIn.ReplaceRouteDampener(nil)
+		return
+	}
+
+	f.adjRIBIn.ReplaceRouteDampener(d)
+}
+
 func (f *fsmAddressFamily) replaceImportFilterChain(c filter.Chain) {
 	if c.Equal(f.importFilterChain) {
 		return
@@ -61,6 +127,62 @@ func (f *fsmAddressFamily) replaceImportFilterChain(c filter.Chain) {
 
 	f.importFilterChain = c
 	f.adjRIBIn.ReplaceFilterChain(c)
+
+	// Ask the peer to re-send its Adj-RIB-Out so paths we haven't kept are evaluated, too
+	if f.initialized && f.fsm.supportsRouteRefresh {
+		f.requestRouteRefresh()
+	}
+}
+
+func (f *fsmAddressFamily) requestRouteRefresh() {
+	err := f.fsm.sendRouteRefresh(f.afi, f.safi, packet.RouteRefreshRequest)
+	if err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"peer": f.fsm.peer.addr.String(),
+			"afi":  packet.AFIName(f.afi),
+		}).Error("Unable to request route refresh")
+	}
+}
+
+// refreshRIBOut re-advertises our Adj-RIB-Out to the peer (RFC2918)
+func (f *fsmAddressFamily) refreshRIBOut() {
+	f.updateSender.RouteRefresh(f.adjRIBOut.Dump(), f.fsm.supportsEnhancedRouteRefresh)
+}
+
+// beginRouteRefresh marks all paths received from the peer as stale (RFC7313 Sect. 4)
+func (f *fsmAddressFamily) beginRouteRefresh() {
+	f.markPathsStale()
+}
+
+// endRouteRefresh removes all paths not re-advertised by the peer since the beginning of the route refresh
+func (f *fsmAddressFamily) endRouteRefresh() {
+	f.purgeStalePaths()
+}
+
+func (f *fsmAddressFamily) markPathsStale() {
+	f.stalePaths = make(map[stalePathKey]*route.Path)
+	for _, r := range f.adjRIBIn.Dump() {
+		for _, p := range r.Paths() {
+			f.stalePaths[newStalePathKey(r.Prefix(), p.BGPPath)] = p
+		}
+	}
+}
+
+func (f *fsmAddressFamily) purgeStalePaths() {
+	for k, p := range f.stalePaths {
+		pfx := k.pfx
+		f.adjRIBIn.RemovePath(&pfx, p)
+	}
+
+	f.stalePaths = nil
+}
+
+func (f *fsmAddressFamily) markPathFresh(pfx *bnet.Prefix, p *route.BGPPath) {
+	if f.stalePaths == nil {
+		return
+	}
+
+	delete(f.stalePaths, newStalePathKey(pfx, p))
 }
 
 func (f *fsmAddressFamily) replaceExportFilterChain(c filter.Chain) {
@@ -93,13 +215,15 @@ func (a adjRIBInFactory) New(exportFilterChain filter.Chain, vrf *vrf.VRF, sessi
 func (f *fsmAddressFamily) init() {
 	sessionAttrs := f.getSessionAttrs()
 
-	f.adjRIBIn = f.fsm.peer.adjRIBInFactory.New(f.importFilterChain, f.fsm.peer.vrf, sessionAttrs)
 	f.fsm.peer.vrf.AddContributingASN(f.fsm.peer.localASN)
 	if f.fsm.peer.routeReflectorClient {
 		f.fsm.peer.vrf.AddContributingClusterID(f.fsm.peer.clusterID)
 	}
 
-	f.adjRIBIn.Register(f.rib)
+	if !f.restoreRetained() {
+		f.adjRIBIn = f.fsm.peer.adjRIBInFactory.New(f.importFilterChain, f.fsm.peer.vrf, sessionAttrs)
+		f.adjRIBIn.Register(f.rib)
+	}
 
 	f.adjRIBOut = adjRIBOut.New(f.rib, sessionAttrs, f.exportFilterChain)
 
@@ -108,7 +232,12 @@ func (f *fsmAddressFamily) init() {
 
 	f.adjRIBOut.Register(f.updateSender)
 
-	f.rib.RegisterWithOptions(f.adjRIBOut, f.addPathTX)
+	if f.deferRIBOut() {
+		f.ribOutDeferredUntil = time.Now().Add(f.fsm.peer.gracefulRestart.RestartTime)
+	} else {
+		f.rib.RegisterWithOptions(f.adjRIBOut, f.addPathTX)
+	}
+
 	f.initialized = true
 }
 
@@ -120,9 +249,9 @@ func (f *fsmAddressFamily) getSessionAttrs() routingtable.SessionAttrs {
 		PeerIP:               f.fsm.peer.addr,
 		LocalIP:              f.fsm.peer.localAddr,
 		Type:                 route.BGPPathType,
-		IBGP:                 f.fsm.peer.localASN == f.fsm.peer.peerASN,
+		IBGP:                 f.fsm.peer.localASN == f.fsm.peer.getPeerASN(),
 		LocalASN:             f.fsm.peer.localASN,
-		PeerASN:              f.fsm.peer.peerASN,
+		PeerASN:              f.fsm.peer.getPeerASN(),
 		RouteServerClient:    f.fsm.peer.routeServerClient,
 		RouteReflectorClient: f.fsm.peer.routeReflectorClient,
 		ClusterID:            f.fsm.peer.clusterID,
@@ -143,6 +272,23 @@ func (f *fsmAddressFamily) getSessionAttrs() routingtable.SessionAttrs {
 	// Worry not, if Local Preference is 0, AdjRIBIn, will default it to 100.
 	if !f.fsm.isBMP && f.fsm.peer.server != nil {
 		sa.DefaultLocalPreference = *f.fsm.peer.server.config.DefaultLocalPreference
+		sa.RPKIValidator = f.fsm.peer.server.config.RPKIValidator
+		sa.ASPARejectInvalid = f.fsm.peer.aspaRejectInvalid
+
+		// FlowSpec routes are not forwarded to their next hop
+		if f.safi != packet.SAFIFlowSpec {
+			sa.NextHopTracker = f.fsm.peer.nextHopTracker
+		}
+
+		// Aggregates are only originated into the unicast RIBs
+		if f.safi == packet.SAFIUnicast {
+			sa.RouteSuppressor = f.fsm.peer.routeSuppressor
+		}
+
+		af := f.fsm.peer.addressFamily(f.afi, f.safi)
+		if f.safi == packet.SAFIUnicast && af != nil && af.dampener != nil {
+			sa.RouteDampener = af.dampener
+		}
 	}
 
 	return sa
@@ -164,6 +310,7 @@ func (f *fsmAddressFamily) bmpDispose() {
 	f.adjRIBIn.Flush()
 
 	f.adjRIBIn.Unregister(f.rib)
+	f.adjRIBIn.Dispose()
 
 	f.adjRIBIn = nil
 }
@@ -173,52 +320,66 @@ func (f *fsmAddressFamily) dispose() {
 		return
 	}
 
+	f.disposeRIBOut()
+	f.adjRIBIn.Unregister(f.rib)
+	f.adjRIBIn.Dispose()
+
+	f.adjRIBIn = nil
+	f.stalePaths = nil
+	f.staleDeadline = time.Time{}
+	f.ribOutDeferredUntil = time.Time{}
+	f.resetPrefixLimit()
+
+	f.initialized = false
+}
+
+func (f *fsmAddressFamily) disposeRIBOut() {
 	f.fsm.peer.vrf.RemoveContributingASN(f.fsm.peer.localASN)
 	if f.fsm.peer.routeReflectorClient {
 		f.fsm.peer.vrf.RemoveContributingClusterID(f.fsm.peer.clusterID)
 	}
 
-	f.adjRIBIn.Unregister(f.rib)
 	f.rib.Unregister(f.adjRIBOut)
+	f.adjRIBOut.Dispose()
 	f.adjRIBOut.Unregister(f.updateSender)
 	f.updateSender.Destroy()
 
-	f.adjRIBIn = nil
 	f.adjRIBOut = nil
-
-	f.initialized = false
 }
 
 func (f *fsmAddressFamily) processUpdate(u *packet.BGPUpdate, bmpPostPolicy bool, timestamp uint32) {
-	if f.safi != packet.SAFIUnicast {
+	if f.safi != packet.SAFIUnicast && f.safi != packet.SAFIMPLSVPN && f.safi != packet.SAFIFlowSpec {
 		return
 	}
 
-	f.multiProtocolUpdates(u, bmpPostPolicy, timestamp)
-	if f.afi == packet.AFIIPv4 {
-		if u.IsEndOfRIBMarker() {
-			f.endOfRIBMarkerReceived.Store(true)
+	afi, safi, isEndOfRIB := u.EndOfRIBMarkerFamily()
+	if isEndOfRIB {
+		if afi == f.afi && safi == f.safi {
+			f.endOfRIBReceived()
 		}
 
+		return
+	}
+
+	f.multiProtocolUpdates(u, bmpPostPolicy, timestamp)
+	if f.afi == packet.AFIIPv4 && f.safi == packet.SAFIUnicast {
 		f.withdraws(u, bmpPostPolicy, timestamp)
 		f.updates(u, bmpPostPolicy, timestamp)
 	}
-	if f.afi == packet.AFIIPv6 {
-		if u.IsEndOfRIBMarker() {
-			f.endOfRIBMarkerReceived.Store(true)
-		}
-	}
 }
 
 func (f *fsmAddressFamily) withdraws(u *packet.BGPUpdate, bmpPostPolicy bool, timestamp uint32) {
 	for r := u.WithdrawnRoutes; r != nil; r = r.Next {
-		f.adjRIBIn.RemovePath(r.Prefix, &route.Path{
+		p := &route.Path{
 			LTime: timestamp,
 			BGPPath: &route.BGPPath{
 				BMPPostPolicy:  bmpPostPolicy,
 				PathIdentifier: r.PathIdentifier,
 			},
-		})
+		}
+
+		f.markPathFresh(r.Prefix, p.BGPPath)
+		f.adjRIBIn.RemovePath(r.Prefix, p)
 	}
 }
 
@@ -226,8 +387,9 @@ func (f *fsmAddressFamily) updates(u *packet.BGPUpdate, bmpPostPolicy bool, time
 	for r := u.NLRI; r != nil; r = r.Next {
 		path := f.newRoutePath(bmpPostPolicy, timestamp)
 		f.processAttributes(u.PathAttributes, path)
-		path.BGPPath.PathIdentifier = u.NLRI.PathIdentifier
+		path.BGPPath.PathIdentifier = r.PathIdentifier
 
+		f.markPathFresh(r.Prefix, path.BGPPath)
 		f.adjRIBIn.AddPath(r.Prefix, path)
 	}
 }
@@ -245,12 +407,6 @@ func (f *fsmAddressFamily) multiProtocolUpdates(u *packet.BGPUpdate, bmpPostPoli
 	if mpUnreachNLRI != nil {
 		f.multiProtocolWithdraw(path, *mpUnreachNLRI)
 	}
-
-	if mpReachNLRI != nil && mpUnreachNLRI != nil {
-		if mpReachNLRI.NLRI == nil && mpUnreachNLRI.NLRI == nil {
-			f.endOfRIBMarkerReceived.Store(true)
-		}
-	}
 }
 
 func getMPReachAndUnreachNLRIs(u *packet.BGPUpdate) (reach *packet.MultiProtocolReachNLRI, unreach *packet.MultiProtocolUnreachNLRI) {
@@ -277,7 +433,7 @@ func (f *fsmAddressFamily) newRoutePath(bmpPostPolicy bool, timestamp uint32) *r
 			BMPPostPolicy: bmpPostPolicy,
 			BGPPathA: &route.BGPPathA{
 				Source: f.fsm.peer.addr,
-				EBGP:   f.fsm.peer.localASN != f.fsm.peer.peerASN,
+				EBGP:   f.fsm.peer.localASN != f.fsm.peer.getPeerASN(),
 			},
 		},
 	}
@@ -292,8 +448,42 @@ func (f *fsmAddressFamily) multiProtocolUpdate(path *route.Path, nlri packet.Mul
 	path.BGPPath.BGPPathA.NextHop = nlri.NextHop
 
 	for n := nlri.NLRI; n != nil; n = n.Next {
-		f.adjRIBIn.AddPath(n.Prefix, path)
+		p := f.pathForNLRI(path, n)
+		f.markPathFresh(n.Prefix, p.BGPPath)
+		f.adjRIBIn.AddPath(n.Prefix, p)
+	}
+}
+
+// pathForNLRI returns the path for a single NLRI. VPN NLRIs carry their own route distinguisher and labels (RFC4364 Sect. 4.3.4),
+// FlowSpec NLRIs carry a rule instead of a reachable prefix (RFC8955 Sect. 4).
+func (f *fsmAddressFamily) pathForNLRI(path *route.Path, n *packet.NLRI) *route.Path {
+	if f.safi == packet.SAFIFlowSpec {
+		p := path.Copy()
+		p.BGPPath.FlowSpecRule = n.FlowSpec
+
+		// FlowSpec NLRIs do not carry a next hop (RFC8955 Sect. 4), we use the unspecified address to keep path selection working
+		if p.BGPPath.BGPPathA.NextHop == nil {
+			p.BGPPath.BGPPathA.NextHop = bnet.IPv4(0).Ptr()
+			if f.afi == packet.AFIIPv6 {
+				p.BGPPath.BGPPathA.NextHop = bnet.IPv6(0, 0).Ptr()
+			}
+		}
+
+		return p
+	}
+
+	if f.safi != packet.SAFIMPLSVPN {
+		return path
 	}
+
+	p := path.Copy()
+	p.BGPPath.RouteDistinguisher = n.RouteDistinguisher
+	p.BGPPath.LabelStack = make([]uint32, 0, len(n.LabelStack))
+	for _, l := range n.LabelStack {
+		p.BGPPath.LabelStack = append(p.BGPPath.LabelStack, l.GetLabel())
+	}
+
+	return p
 }
 
 func (f *fsmAddressFamily) multiProtocolWithdraw(path *route.Path, nlri packet.MultiProtocolUnreachNLRI) {
@@ -306,11 +496,14 @@ func (f *fsmAddressFamily) multiProtocolWithdraw(path *route.Path, nlri packet.M
 	}
 
 	for cur := nlri.NLRI; cur != nil; cur = cur.Next {
-		f.adjRIBIn.RemovePath(cur.Prefix, path)
+		p := f.pathForNLRI(path, cur)
+		f.markPathFresh(cur.Prefix, p.BGPPath)
+		f.adjRIBIn.RemovePath(cur.Prefix, p)
 	}
 }
 
 func (f *fsmAddressFamily) processAttributes(attrs *packet.PathAttribute, path *route.Path) {
+	var as4Aggregator *types.Aggregator
 	for pa := attrs; pa != nil; pa = pa.Next {
 		switch pa.TypeCode {
 		case packet.OriginAttr:
@@ -327,12 +520,19 @@ func (f *fsmAddressFamily) processAttributes(attrs *packet.PathAttribute, path *
 		case packet.AggregatorAttr:
 			aggr := pa.Value.(types.Aggregator)
 			path.BGPPath.BGPPathA.Aggregator = &aggr
+		case packet.AS4AggregatorAttr:
+			aggr := pa.Value.(types.Aggregator)
+			as4Aggregator = &aggr
 		case packet.AtomicAggrAttr:
 			path.BGPPath.BGPPathA.AtomicAggregate = true
 		case packet.CommunitiesAttr:
 			path.BGPPath.Communities = pa.Value.(*types.Communities)
 		case packet.LargeCommunitiesAttr:
 			path.BGPPath.LargeCommunities = pa.Value.(*types.LargeCommunities)
+		case packet.ExtendedCommunitiesAttr:
+			path.BGPPath.ExtendedCommunities = pa.Value.(*types.ExtendedCommunities)
+		case packet.IPv6ExtendedCommunitiesAttr:
+			path.BGPPath.IPv6ExtendedCommunities = pa.Value.(*types.IPv6ExtendedCommunities)
 		case packet.OriginatorIDAttr:
 			path.BGPPath.BGPPathA.OriginatorID = pa.Value.(uint32)
 		case packet.ClusterListAttr:
@@ -346,6 +546,16 @@ func (f *fsmAddressFamily) processAttributes(attrs *packet.PathAttribute, path *
 			}
 		}
 	}
+
+	// RFC6793 Sect. 4.2.3: AS4_AGGREGATOR is only considered if received from a 2 octet speaker with AGGREGATOR carrying AS_TRANS
+	if as4Aggregator == nil || f.fsm.supports4OctetASN {
+		return
+	}
+
+	aggr := path.BGPPath.BGPPathA.Aggregator
+	if aggr != nil && aggr.ASN == packet.ASTransASN {
+		path.BGPPath.BGPPathA.Aggregator = as4Aggregator
+	}
 }
 
 func (f *fsmAddressFamily) processUnknownAttribute(attr *packet.PathAttribute) *types.UnknownPathAttribute {
@@ -4,10 +4,12 @@ import (
 	"bytes"
 	"testing"
 
+	bnet "github.com/bio-routing/bio-rd/net"
 	"github.com/bio-routing/bio-rd/protocols/bgp/packet"
 	"github.com/bio-routing/bio-rd/protocols/bgp/types"
 	"github.com/bio-routing/bio-rd/route"
 	"github.com/bio-routing/bio-rd/routingtable"
+	"github.com/bio-routing/bio-rd/routingtable/adjRIBIn"
 	"github.com/bio-routing/bio-rd/routingtable/filter"
 	"github.com/bio-routing/bio-rd/routingtable/locRIB"
 	"github.com/bio-routing/bio-rd/routingtable/vrf"
@@ -119,3 +121,137 @@ func TestProcessAttributes(t *testing.T) {
 
 	assert.Equal(t, 2, i, "Count")
 }
+
+func TestProcessAttributesAS4Aggregator(t *testing.T) {
+	as4Aggregator := types.Aggregator{
+		ASN:     4200000000,
+		Address: 100,
+	}
+
+	tests := []struct {
+		name              string
+		supports4OctetASN bool
+		aggregatorASN     uint32
+		expected          types.Aggregator
+	}{
+		{
+			name:          "AS_TRANS from 2 octet speaker",
+			aggregatorASN: packet.ASTransASN,
+			expected:      as4Aggregator,
+		},
+		{
+			name:          "2 octet ASN from 2 octet speaker",
+			aggregatorASN: 65000,
+			expected: types.Aggregator{
+				ASN:     65000,
+				Address: 200,
+			},
+		},
+		{
+			name:              "AS_TRANS from 4 octet speaker",
+			supports4OctetASN: true,
+			aggregatorASN:     packet.ASTransASN,
+			expected: types.Aggregator{
+				ASN:     packet.ASTransASN,
+				Address: 200,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			attrs := &packet.PathAttribute{
+				TypeCode: packet.AggregatorAttr,
+				Value: types.Aggregator{
+					ASN:     test.aggregatorASN,
+					Address: 200,
+				},
+				Next: &packet.PathAttribute{
+					TypeCode:   packet.AS4AggregatorAttr,
+					Optional:   true,
+					Transitive: true,
+					Value:      as4Aggregator,
+				},
+			}
+
+			f := &fsmAddressFamily{
+				fsm: &FSM{
+					supports4OctetASN: test.supports4OctetASN,
+				},
+			}
+
+			p := &route.Path{
+				BGPPath: route.NewBGPPath(),
+			}
+			f.processAttributes(attrs, p)
+
+			assert.Equal(t, test.expected, *p.BGPPath.BGPPathA.Aggregator)
+			assert.Empty(t, p.BGPPath.UnknownAttributes)
+		})
+	}
+}
+
+func TestEnhancedRouteRefreshStalePaths(t *testing.T) {
+	f := &fsmAddressFamily{
+		afi:               packet.AFIIPv4,
+		safi:              packet.SAFIUnicast,
+		rib:               locRIB.New("inet.0"),
+		importFilterChain: filter.NewAcceptAllFilterChain(),
+		exportFilterChain: filter.NewAcceptAllFilterChain(),
+		fsm: &FSM{
+			peer: &peer{
+				addr:            bnet.IPv4FromOctets(10, 0, 0, 1).Ptr(),
+				routerID:        100,
+				localASN:        15169,
+				peerASN:         15169,
+				adjRIBInFactory: adjRIBInFactory{},
+				vrf:             vrf.NewUntrackedVRF("vrf0", 0),
+			},
+			con: &biotesting.MockConn{
+				Buf: bytes.NewBuffer(nil),
+			},
+		},
+		addPathTX: routingtable.ClientOptions{
+			BestOnly: true,
+		},
+	}
+
+	f.init()
+	defer f.dispose()
+
+	pfxA := bnet.NewPfx(bnet.IPv4FromOctets(192, 0, 2, 0), 24).Ptr()
+	pfxB := bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 100, 0), 24).Ptr()
+
+	update := func(pfxs ...*bnet.Prefix) *packet.BGPUpdate {
+		u := &packet.BGPUpdate{
+			PathAttributes: &packet.PathAttribute{
+				TypeCode: packet.NextHopAttr,
+				Value:    bnet.IPv4FromOctets(10, 0, 0, 1).Ptr(),
+			},
+		}
+
+		for _, pfx := range pfxs {
+			u.NLRI = &packet.NLRI{
+				Prefix: pfx,
+				Next:   u.NLRI,
+			}
+		}
+
+		return u
+	}
+
+	f.processUpdate(update(pfxA, pfxB), false, 0)
+	assert.Equal(t, int64(2), f.adjRIBIn.RouteCount())
+
+	f.beginRouteRefresh()
+	assert.Equal(t, 2, len(f.stalePaths))
+
+	f.processUpdate(update(pfxA), false, 0)
+	assert.Equal(t, 1, len(f.stalePaths))
+
+	f.endRouteRefresh()
+	assert.Nil(t, f.stalePaths)
+	assert.Equal(t, int64(1), f.adjRIBIn.RouteCount())
+	assert.NotNil(t, f.adjRIBIn.(*adjRIBIn.AdjRIBIn).Get(pfxA))
+	assert.Nil(t, f.adjRIBIn.(*adjRIBIn.AdjRIBIn).Get(pfxB))
+}
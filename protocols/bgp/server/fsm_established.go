@@ -28,6 +28,8 @@ func (s establishedState) run() (state, string) {
 		}
 	}
 
+	s.fsm.checkGracefulRestartTimers()
+
 	keepaliveTimerC := make(<-chan time.Time)
 	if s.fsm.keepaliveTimer != nil {
 		keepaliveTimerC = s.fsm.keepaliveTimer.C
@@ -49,10 +51,18 @@ func (s establishedState) run() (state, string) {
 			}
 		case <-keepaliveTimerC:
 			return s.keepaliveTimerExpired()
+		case <-s.fsm.bfdDownCh:
+			return s.bfdDown()
 		case <-time.After(time.Second):
 			return s.checkHoldtimer()
 		case recvMsg := <-s.fsm.msgRecvCh:
 			return s.msgReceived(recvMsg, opt, false, uint32(time.Now().Unix()))
+		case f := <-s.fsm.msgRecvFailCh:
+			if s.fsm.connectionFailed(f) {
+				return s.tcpFailure(f.err)
+			}
+		case accepted := <-s.fsm.peerRestartCh:
+			return s.peerRestarted(accepted)
 		}
 	}
 }
@@ -66,25 +76,38 @@ func (s *establishedState) checkHoldtimer() (state, string) {
 }
 
 func (s *establishedState) init() error {
-	if s.fsm.ipv4Unicast != nil {
-		s.fsm.ipv4Unicast.init()
-	}
+	s.fsm.drainBFD()
 
-	if s.fsm.ipv6Unicast != nil {
-		s.fsm.ipv6Unicast.init()
+	for _, f := range s.fsm.addressFamilies() {
+		f.init()
 	}
 
+	// Once the session is back up, our restart is over from the peer's point of view
+	s.fsm.peer.gracefulRestartRecovery.Store(false)
+
 	s.fsm.ribsInitialized = true
 	return nil
 }
 
 func (s *establishedState) uninit() {
-	if s.fsm.ipv4Unicast != nil {
-		s.fsm.ipv4Unicast.dispose()
+	for _, f := range s.fsm.addressFamilies() {
+		f.dispose()
 	}
 
-	if s.fsm.ipv6Unicast != nil {
-		s.fsm.ipv6Unicast.dispose()
+	s.fsm.counters.reset()
+
+	s.fsm.ribsInitialized = false
+}
+
+// uninitGracefully keeps the paths of address families the peer can restart gracefully (RFC4724 Sect. 4.2)
+func (s *establishedState) uninitGracefully() {
+	for _, f := range s.fsm.addressFamilies() {
+		if s.fsm.gracefulRestartHelper(f.afi, f.safi) {
+			f.retain()
+			continue
+		}
+
+		f.dispose()
 	}
 
 	s.fsm.counters.reset()
@@ -117,6 +140,8 @@ func (s *establishedState) cease() (state, string) {
 	return newCeaseState(), "Cease"
 }
 
+// holdTimerExpired tears down the session. As we don't support RFC8538, the peer drops our paths once it received
+// the notification and the paths received from the peer are not retained either.
 func (s *establishedState) holdTimerExpired() (state, string) {
 	s.fsm.sendNotification(packet.HoldTimeExpired, 0)
 	s.uninit()
@@ -126,10 +151,44 @@ func (s *establishedState) holdTimerExpired() (state, string) {
 	return newIdleState(s.fsm), "Holdtimer expired"
 }
 
+// tcpFailure tears down the session after the connection to the peer was lost (RFC4724 Sect. 4.2)
+func (s *establishedState) tcpFailure(err error) (state, string) {
+	s.uninitGracefully()
+	stopTimer(s.fsm.connectRetryTimer)
+	s.fsm.con.Close()
+	s.fsm.connectRetryCounter++
+	return newIdleState(s.fsm), fmt.Sprintf("TCP connection failure: %v", err)
+}
+
+// peerRestarted closes the session if the peer established a new connection while it was still up and its paths can
+// be retained (RFC4724 Sect. 4.2). The new session is then started on the new connection. Otherwise the session is kept.
+func (s *establishedState) peerRestarted(accepted chan<- bool) (state, string) {
+	if !s.fsm.gracefulRestartHelperForAny() {
+		accepted <- false
+		return newEstablishedState(s.fsm), s.fsm.reason
+	}
+
+	s.uninitGracefully()
+	s.fsm.con.Close()
+	stopTimer(s.fsm.connectRetryTimer)
+	s.fsm.startConnectRetryTimer()
+	accepted <- true
+	return newActiveState(s.fsm), "Peer restarted"
+}
+
+// bfdDown tears down the session without sending a notification as the path to the peer is considered broken (RFC5882)
+func (s *establishedState) bfdDown() (state, string) {
+	s.uninit()
+	stopTimer(s.fsm.connectRetryTimer)
+	s.fsm.con.Close()
+	s.fsm.connectRetryCounter++
+	return newIdleState(s.fsm), "BFD session down"
+}
+
 func (s *establishedState) keepaliveTimerExpired() (state, string) {
 	err := s.fsm.sendKeepalive()
 	if err != nil {
-		s.uninit()
+		s.uninitGracefully()
 		stopTimer(s.fsm.connectRetryTimer)
 		s.fsm.con.Close()
 		s.fsm.connectRetryCounter++
@@ -162,6 +221,8 @@ func (s *establishedState) msgReceived(data []byte, opt *packet.DecodeOptions, b
 		return s.update(msg.Body.(*packet.BGPUpdate), bmpPostPolicy, timestamp)
 	case packet.KeepaliveMsg:
 		return s.keepaliveReceived()
+	case packet.RouteRefreshMsg:
+		return s.routeRefreshReceived(msg.Body.(*packet.BGPRouteRefresh))
 	default:
 		return s.unexpectedMessage()
 	}
@@ -182,37 +243,41 @@ func (s *establishedState) update(u *packet.BGPUpdate, bmpPostPolicy bool, times
 		s.fsm.updateLastUpdateOrKeepalive()
 	}
 
-	if s.fsm.ipv4Unicast != nil {
-		s.fsm.ipv4Unicast.processUpdate(u, bmpPostPolicy, timestemp)
+	for _, f := range s.fsm.addressFamilies() {
+		f.processUpdate(u, bmpPostPolicy, timestemp)
 	}
 
-	if s.fsm.ipv6Unicast != nil {
-		s.fsm.ipv6Unicast.processUpdate(u, bmpPostPolicy, timestemp)
+	for _, f := range s.fsm.addressFamilies() {
+		if f.checkPrefixLimit() {
+			return s.prefixLimitExceeded(f)
+		}
 	}
 
 	afi, safi := s.updateAddressFamily(u)
 
-	if safi != packet.SAFIUnicast {
-		// only unicast support, so other SAFIs are ignored
+	if safi != packet.SAFIUnicast && safi != packet.SAFIMPLSVPN && safi != packet.SAFIFlowSpec {
+		// only unicast, VPN and FlowSpec support, so other SAFIs are ignored
 		return newEstablishedState(s.fsm), s.fsm.reason
 	}
 
-	switch afi {
-	case packet.AFIIPv4:
-		if s.fsm.ipv4Unicast == nil {
-			log.Info("Received update for family IPv4 unicast, but this family is not configured.")
-		}
-
-	case packet.AFIIPv6:
-		if s.fsm.ipv6Unicast == nil {
-			log.Info("Received update for family IPv6 unicast, but this family is not configured.")
-		}
-
+	if s.fsm.addressFamily(afi, safi) == nil {
+		log.Infof("Received update for family %s %s, but this family is not configured.", packet.AFIName(afi), packet.SAFIName(safi))
 	}
 
 	return newEstablishedState(s.fsm), s.fsm.reason
 }
 
+// prefixLimitExceeded tears down the session and holds it down for the configured restart time (RFC4486 Sect. 4)
+func (s *establishedState) prefixLimitExceeded(f *fsmAddressFamily) (state, string) {
+	s.fsm.sendNotification(packet.Cease, packet.MaxPrefReached)
+	s.fsm.peer.prefixLimitHoldDown.start(f.prefixLimit.RestartTime)
+	s.uninit()
+	stopTimer(s.fsm.connectRetryTimer)
+	s.fsm.con.Close()
+	s.fsm.connectRetryCounter++
+	return newIdleState(s.fsm), fmt.Sprintf("Prefix limit exceeded for %s %s", packet.AFIName(f.afi), packet.SAFIName(f.safi))
+}
+
 func (s *establishedState) updateAddressFamily(u *packet.BGPUpdate) (afi uint16, safi uint8) {
 	if u.WithdrawnRoutes != nil || u.NLRI != nil {
 		return packet.AFIIPv4, packet.SAFIUnicast
@@ -240,6 +305,37 @@ func (s *establishedState) keepaliveReceived() (state, string) {
 	return newEstablishedState(s.fsm), s.fsm.reason
 }
 
+func (s *establishedState) routeRefreshReceived(rr *packet.BGPRouteRefresh) (state, string) {
+	if !s.fsm.supportsRouteRefresh {
+		log.WithFields(log.Fields{
+			"peer": s.fsm.peer.addr.String(),
+		}).Info("Received ROUTE-REFRESH from peer that didn't advertise the capability. Ignoring.")
+		return newEstablishedState(s.fsm), s.fsm.reason
+	}
+
+	// RFC2918 Sect. 4: Ignore requests for address families we did not advertise
+	f := s.fsm.addressFamily(rr.AFI, rr.SAFI)
+	if f == nil || !f.initialized {
+		return newEstablishedState(s.fsm), s.fsm.reason
+	}
+
+	switch rr.SubType {
+	case packet.RouteRefreshRequest:
+		f.refreshRIBOut()
+	case packet.BeginningOfRouteRefresh:
+		if s.fsm.supportsEnhancedRouteRefresh {
+			f.beginRouteRefresh()
+		}
+	case packet.EndOfRouteRefresh:
+		if s.fsm.supportsEnhancedRouteRefresh {
+			f.endRouteRefresh()
+		}
+	}
+
+	// RFC7313 Sect. 5: Messages with an unknown subtype must be ignored
+	return newEstablishedState(s.fsm), s.fsm.reason
+}
+
 func (s *establishedState) unexpectedMessage() (state, string) {
 	s.fsm.sendNotification(packet.FiniteStateMachineError, 0)
 	s.uninit()
@@ -16,21 +16,42 @@ func newIdleState(fsm *FSM) *idleState {
 }
 
 func (s idleState) run() (state, string) {
-	if !s.fsm.peer.passive && s.fsm.peer.reconnectInterval != 0 {
+	restartC := make(<-chan time.Time)
+	if d, held := s.fsm.peer.prefixLimitHoldDown.remaining(); held {
+		if d != 0 {
+			restartC = time.After(d)
+		}
+	} else if !s.fsm.peer.passive && s.fsm.peer.reconnectInterval != 0 && !s.fsm.peer.bfdDown.Load() {
 		time.Sleep(s.fsm.peer.reconnectInterval)
 		go s.fsm.activate()
 	}
+
 	for {
-		event := <-s.fsm.eventCh
-		switch event {
-		case ManualStart:
-			return s.manualStart()
-		case AutomaticStart:
-			return s.automaticStart()
-		case Cease:
-			return newCeaseState(), "Cease"
-		default:
-			continue
+		select {
+		case <-restartC:
+			if !s.fsm.peer.passive {
+				s.newStateReason = "Prefix limit restart timer expired"
+				return s.start()
+			}
+		case <-s.fsm.bfdUpCh:
+			if !s.fsm.peer.passive && s.fsm.peer.reconnectInterval != 0 && !s.fsm.peer.prefixLimitHoldDown.heldDown() {
+				s.newStateReason = "BFD session up"
+				return s.start()
+			}
+		case event := <-s.fsm.eventCh:
+			switch event {
+			case ManualStart:
+				return s.manualStart()
+			case AutomaticStart:
+				if s.fsm.peer.prefixLimitHoldDown.heldDown() || s.fsm.peer.bfdDown.Load() {
+					continue
+				}
+				return s.automaticStart()
+			case Cease:
+				return newCeaseState(), "Cease"
+			default:
+				continue
+			}
 		}
 	}
 }
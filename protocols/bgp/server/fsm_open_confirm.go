@@ -43,6 +43,10 @@ func (s openConfirmState) run() (state, string) {
 			return s.keepaliveTimerExpired()
 		case recvMsg := <-s.fsm.msgRecvCh:
 			return s.msgReceived(recvMsg, opt)
+		case f := <-s.fsm.msgRecvFailCh:
+			if s.fsm.connectionFailed(f) {
+				return s.tcpFailure(f.err)
+			}
 		}
 	}
 }
@@ -77,6 +81,13 @@ func (s *openConfirmState) holdTimerExpired() (state, string) {
 	return newIdleState(s.fsm), "Holdtimer expired"
 }
 
+func (s *openConfirmState) tcpFailure(err error) (state, string) {
+	stopTimer(s.fsm.connectRetryTimer)
+	s.fsm.con.Close()
+	s.fsm.connectRetryCounter++
+	return newIdleState(s.fsm), fmt.Sprintf("TCP connection failure: %v", err)
+}
+
 func (s *openConfirmState) keepaliveTimerExpired() (state, string) {
 	err := s.fsm.sendKeepalive()
 	if err != nil {
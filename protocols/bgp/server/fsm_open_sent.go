@@ -22,7 +22,7 @@ func newOpenSentState(fsm *FSM) *openSentState {
 }
 
 func (s openSentState) run() (state, string) {
-	go s.fsm.msgReceiver()
+	go s.fsm.msgReceiver(s.fsm.con)
 
 	opt := s.fsm.decodeOptions()
 
@@ -43,6 +43,10 @@ func (s openSentState) run() (state, string) {
 			return s.checkHoldtimer()
 		case recvMsg := <-s.fsm.msgRecvCh:
 			return s.msgReceived(recvMsg, opt)
+		case f := <-s.fsm.msgRecvFailCh:
+			if s.fsm.connectionFailed(f) {
+				return s.tcpFailure()
+			}
 		}
 	}
 }
@@ -128,7 +132,7 @@ func (s *openSentState) openMsgReceived(openMsg *packet.BGPOpen) (state, string)
 	// or if it is the same as the BGP Identifier of the local BGP speaker and the
 	// message is from an internal peer, then the Error Subcode is set to "Bad BGP Identifier".
 	// A zero BGP identifier is already checked when decoding the OPEN message.
-	if s.fsm.peer.localASN == s.fsm.peer.peerASN && s.fsm.peer.routerID == openMsg.BGPIdentifier {
+	if s.fsm.peer.localASN == s.fsm.peer.getPeerASN() && s.fsm.peer.routerID == openMsg.BGPIdentifier {
 		s.fsm.sendNotification(packet.OpenMessageError, packet.BadBGPIdentifier)
 		return newIdleState(s.fsm), fmt.Sprintf("Bad BGP Identifier %d", openMsg.BGPIdentifier)
 	}
@@ -154,15 +158,28 @@ func (s *openSentState) handleOpenMessage(openMsg *packet.BGPOpen) (state, strin
 	}
 
 	s.peerASNRcvd = uint32(openMsg.ASN)
+	s.fsm.supportsRouteRefresh = false
+	s.fsm.supportsEnhancedRouteRefresh = false
+	s.fsm.peerGracefulRestart = nil
 	s.processOpenOptions(openMsg.OptParams)
 
-	if s.peerASNRcvd != s.fsm.peer.peerASN {
+	if d := s.fsm.peer.dynamic; d != nil {
+		if !d.acceptsPeerASN(s.peerASNRcvd) {
+			s.fsm.sendNotification(packet.OpenMessageError, packet.BadPeerAS)
+			return newIdleState(s.fsm), fmt.Sprintf("Bad Peer AS %d, expected: %v", s.peerASNRcvd, d.peerASNs())
+		}
+
+		// The peer AS of a dynamic peer is only known once it sent its OPEN message
+		s.fsm.peer.setPeerASN(s.peerASNRcvd)
+	}
+
+	if s.peerASNRcvd != s.fsm.peer.getPeerASN() {
 		s.fsm.sendNotification(packet.OpenMessageError, packet.BadPeerAS)
-		return newIdleState(s.fsm), fmt.Sprintf("Bad Peer AS %d, expected: %d", s.peerASNRcvd, s.fsm.peer.peerASN)
+		return newIdleState(s.fsm), fmt.Sprintf("Bad Peer AS %d, expected: %d", s.peerASNRcvd, s.fsm.peer.getPeerASN())
 	}
 
 	// Validate Peer Role relationship for eBGP peers
-	if !s.fsm.isBMP && s.fsm.peer.localASN != s.fsm.peer.peerASN {
+	if !s.fsm.isBMP && s.fsm.peer.localASN != s.fsm.peer.getPeerASN() {
 		err := s.validatePeerRole()
 		if err != nil {
 			s.fsm.sendNotification(packet.OpenMessageError, packet.RoleMismatchError)
@@ -205,15 +222,22 @@ func (s *openSentState) processCapability(cap packet.Capability) {
 		s.processMultiProtocolCapability(cap.Value.(packet.MultiProtocolCapability))
 	case packet.PeerRoleCapabilityCode:
 		s.processPeerRoleCapability(cap.Value.(packet.PeerRoleCapability))
+	case packet.RouteRefreshCapabilityCode:
+		s.fsm.supportsRouteRefresh = true
+	case packet.EnhancedRouteRefreshCapabilityCode:
+		s.fsm.supportsEnhancedRouteRefresh = true
+	case packet.GracefulRestartCapabilityCode:
+		grCap := cap.Value.(packet.GracefulRestartCapability)
+		s.fsm.peerGracefulRestart = &grCap
 	}
 }
 
 func (s *openSentState) processMultiProtocolCapability(cap packet.MultiProtocolCapability) {
-	if cap.SAFI != packet.SAFIUnicast {
+	if cap.SAFI != packet.SAFIUnicast && cap.SAFI != packet.SAFIMPLSVPN && cap.SAFI != packet.SAFIFlowSpec {
 		return
 	}
 
-	if cap.AFI == packet.AFIIPv4 && !s.fsm.peer.ipv4MultiProtocolAdvertised {
+	if cap.AFI == packet.AFIIPv4 && cap.SAFI == packet.SAFIUnicast && !s.fsm.peer.ipv4MultiProtocolAdvertised {
 		return
 	}
 
@@ -0,0 +1,319 @@
+package server
+
+import (
+	"net"
+	"time"
+
+	"github.com/bio-routing/bio-rd/protocols/bgp/packet"
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/bio-routing/bio-rd/routingtable"
+	"github.com/bio-routing/bio-rd/util/log"
+)
+
+// peerRestartTimeout limits how long the incoming connection handling waits for an established session to take over
+// the connection of a restarting peer
+const peerRestartTimeout = time.Second
+
+// retainedAdjRIBIn holds the Adj-RIB-In of a peer while it is restarting (RFC4724 Sect. 4.2)
+type retainedAdjRIBIn struct {
+	adjRIBIn   routingtable.AdjRIBIn
+	stalePaths map[stalePathKey]*route.Path
+	timer      *time.Timer
+}
+
+func (p *peer) gracefulRestartCapability() packet.Capability {
+	restartTime := p.gracefulRestart.RestartTime / time.Second
+	if restartTime > packet.GracefulRestartMaxRestartTime {
+		restartTime = packet.GracefulRestartMaxRestartTime
+	}
+
+	grCap := packet.GracefulRestartCapability{
+		RestartState:    p.gracefulRestartRecovery.Load(),
+		RestartTime:     uint16(restartTime),
+		AddressFamilies: make([]packet.GracefulRestartCapabilityTuple, 0, 4),
+	}
+
+	// We don't preserve our forwarding state, so the F bit is never set
+	if p.ipv4 != nil {
+		grCap.AddressFamilies = append(grCap.AddressFamilies, packet.GracefulRestartCapabilityTuple{
+			AFI:  packet.AFIIPv4,
+			SAFI: packet.SAFIUnicast,
+		})
+	}
+
+	if p.ipv6 != nil {
+		grCap.AddressFamilies = append(grCap.AddressFamilies, packet.GracefulRestartCapabilityTuple{
+			AFI:  packet.AFIIPv6,
+			SAFI: packet.SAFIUnicast,
+		})
+	}
+
+	if p.vpnv4 != nil {
+		grCap.AddressFamilies = append(grCap.AddressFamilies, packet.GracefulRestartCapabilityTuple{
+			AFI:  packet.AFIIPv4,
+			SAFI: packet.SAFIMPLSVPN,
+		})
+	}
+
+	if p.vpnv6 != nil {
+		grCap.AddressFamilies = append(grCap.AddressFamilies, packet.GracefulRestartCapabilityTuple{
+			AFI:  packet.AFIIPv6,
+			SAFI: packet.SAFIMPLSVPN,
+		})
+	}
+
+	if p.flowSpecV4 != nil {
+		grCap.AddressFamilies = append(grCap.AddressFamilies, packet.GracefulRestartCapabilityTuple{
+			AFI:  packet.AFIIPv4,
+			SAFI: packet.SAFIFlowSpec,
+		})
+	}
+
+	if p.flowSpecV6 != nil {
+		grCap.AddressFamilies = append(grCap.AddressFamilies, packet.GracefulRestartCapabilityTuple{
+			AFI:  packet.AFIIPv6,
+			SAFI: packet.SAFIFlowSpec,
+		})
+	}
+
+	return packet.Capability{
+		Code:  packet.GracefulRestartCapabilityCode,
+		Value: grCap,
+	}
+}
+
+// flushRetainedRIBs drops all paths retained for a restarting peer
+func (p *peer) flushRetainedRIBs() {
+	if p.ipv4 != nil {
+		p.ipv4.flushRetained()
+	}
+
+	if p.ipv6 != nil {
+		p.ipv6.flushRetained()
+	}
+
+	if p.vpnv4 != nil {
+		p.vpnv4.flushRetained()
+	}
+
+	if p.vpnv6 != nil {
+		p.vpnv6.flushRetained()
+	}
+
+	if p.flowSpecV4 != nil {
+		p.flowSpecV4.flushRetained()
+	}
+
+	if p.flowSpecV6 != nil {
+		p.flowSpecV6.flushRetained()
+	}
+}
+
+// retain keeps r alive for restartTime. If the peer doesn't come back in time all retained paths are removed.
+func (p *peerAddressFamily) retain(r *retainedAdjRIBIn, restartTime time.Duration) {
+	p.retainedMu.Lock()
+	defer p.retainedMu.Unlock()
+
+	r.timer = time.AfterFunc(restartTime, p.flushRetained)
+	p.retained = r
+}
+
+// takeRetained hands over the retained Adj-RIB-In (if any) to a re-established session
+func (p *peerAddressFamily) takeRetained() *retainedAdjRIBIn {
+	p.retainedMu.Lock()
+	defer p.retainedMu.Unlock()
+
+	r := p.retained
+	if r == nil {
+		return nil
+	}
+
+	r.timer.Stop()
+	p.retained = nil
+	return r
+}
+
+func (p *peerAddressFamily) flushRetained() {
+	p.retainedMu.Lock()
+	defer p.retainedMu.Unlock()
+
+	if p.retained == nil {
+		return
+	}
+
+	p.retained.timer.Stop()
+	p.retained.adjRIBIn.Unregister(p.rib)
+	p.retained.adjRIBIn.Dispose()
+	p.retained = nil
+}
+
+// gracefulRestartHelper determines if we act as a Graceful Restart helper for an address family
+func (fsm *FSM) gracefulRestartHelper(afi uint16, safi uint8) bool {
+	if !fsm.peer.gracefulRestart.Enabled || fsm.peerGracefulRestart == nil {
+		return false
+	}
+
+	return fsm.peerGracefulRestart.AddressFamily(afi, safi) != nil
+}
+
+// gracefulRestartHelperForAny determines if we act as a Graceful Restart helper for any address family of the session
+func (fsm *FSM) gracefulRestartHelperForAny() bool {
+	for _, f := range fsm.addressFamilies() {
+		if fsm.gracefulRestartHelper(f.afi, f.safi) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// peerRestarted hands a new connection of the peer to the established session of the FSM. If the peer can restart
+// gracefully, the session retains the paths of the peer and continues on the new connection (RFC4724 Sect. 4.2).
+// It returns false if the session has been kept or did not respond within peerRestartTimeout.
+func (fsm *FSM) peerRestarted(c net.Conn) bool {
+	accepted := make(chan bool, 1)
+	select {
+	case fsm.peerRestartCh <- accepted:
+	case <-time.After(peerRestartTimeout):
+		return false
+	}
+
+	if !<-accepted {
+		return false
+	}
+
+	select {
+	case fsm.conCh <- c:
+	case <-time.After(peerRestartTimeout):
+		// The paths of the peer are retained already, so the peer just has to connect again
+		c.Close()
+	}
+
+	return true
+}
+
+// peerRestarted hands a new connection of the peer to its established session if the peer can restart gracefully.
+// It returns false if the connection has to be handled by a new FSM.
+func (p *peer) peerRestarted(c net.Conn) bool {
+	p.fsmsMu.Lock()
+	fsms := make([]*FSM, len(p.fsms))
+	copy(fsms, p.fsms)
+	p.fsmsMu.Unlock()
+
+	for _, fsm := range fsms {
+		if fsm.established() && fsm.peerRestarted(c) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (fsm *FSM) checkGracefulRestartTimers() {
+	now := time.Now()
+
+	for _, f := range fsm.addressFamilies() {
+		f.checkGracefulRestartTimers(now)
+	}
+}
+
+// retain tears down the outbound side of the address family but keeps all paths received from
+// the peer as stale for the restart time the peer advertised (RFC4724 Sect. 4.2)
+func (f *fsmAddressFamily) retain() {
+	if !f.initialized {
+		return
+	}
+
+	f.disposeRIBOut()
+	f.markPathsStale()
+
+	restartTime := time.Duration(f.fsm.peerGracefulRestart.RestartTime) * time.Second
+	f.fsm.peer.addressFamily(f.afi, f.safi).retain(&retainedAdjRIBIn{
+		adjRIBIn:   f.adjRIBIn,
+		stalePaths: f.stalePaths,
+	}, restartTime)
+
+	log.WithFields(log.Fields{
+		"peer":         f.fsm.peer.addr.String(),
+		"afi":          packet.AFIName(f.afi),
+		"stale_paths":  len(f.stalePaths),
+		"restart_time": restartTime,
+	}).Info("Peer is restarting gracefully, retaining its paths")
+
+	f.adjRIBIn = nil
+	f.stalePaths = nil
+	f.staleDeadline = time.Time{}
+	f.ribOutDeferredUntil = time.Time{}
+	f.initialized = false
+}
+
+// restoreRetained takes over the paths retained from a previous session. The stale paths are kept until the
+// peer sends an End-of-RIB marker if it preserved its forwarding state, otherwise they are removed right away.
+func (f *fsmAddressFamily) restoreRetained() bool {
+	pf := f.fsm.peer.addressFamily(f.afi, f.safi)
+	if pf == nil {
+		return false
+	}
+
+	r := pf.takeRetained()
+	if r == nil {
+		return false
+	}
+
+	f.adjRIBIn = r.adjRIBIn
+	f.stalePaths = r.stalePaths
+
+	if !f.peerPreservedForwardingState() {
+		f.purgeStalePaths()
+		return true
+	}
+
+	f.staleDeadline = time.Now().Add(f.fsm.peer.gracefulRestart.StalePathsTime)
+	return true
+}
+
+func (f *fsmAddressFamily) peerPreservedForwardingState() bool {
+	if !f.fsm.gracefulRestartHelper(f.afi, f.safi) {
+		return false
+	}
+
+	return f.fsm.peerGracefulRestart.AddressFamily(f.afi, f.safi).ForwardingState
+}
+
+// deferRIBOut determines if we have to wait for the peers End-of-RIB marker before sending our routes (RFC4724 Sect. 4.1)
+func (f *fsmAddressFamily) deferRIBOut() bool {
+	return f.fsm.peer.gracefulRestartRecovery.Load() && f.fsm.gracefulRestartHelper(f.afi, f.safi)
+}
+
+func (f *fsmAddressFamily) startRIBOut() {
+	f.ribOutDeferredUntil = time.Time{}
+	f.rib.RegisterWithOptions(f.adjRIBOut, f.addPathTX)
+}
+
+func (f *fsmAddressFamily) endOfRIBReceived() {
+	f.endOfRIBMarkerReceived.Store(true)
+
+	if !f.staleDeadline.IsZero() {
+		f.staleDeadline = time.Time{}
+		f.purgeStalePaths()
+	}
+
+	if !f.ribOutDeferredUntil.IsZero() {
+		f.startRIBOut()
+	}
+}
+
+func (f *fsmAddressFamily) checkGracefulRestartTimers(now time.Time) {
+	if !f.initialized {
+		return
+	}
+
+	if !f.staleDeadline.IsZero() && now.After(f.staleDeadline) {
+		f.staleDeadline = time.Time{}
+		f.purgeStalePaths()
+	}
+
+	if !f.ribOutDeferredUntil.IsZero() && now.After(f.ribOutDeferredUntil) {
+		f.startRIBOut()
+	}
+}
@@ -0,0 +1,296 @@
+package server
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/packet"
+	"github.com/bio-routing/bio-rd/routingtable"
+	"github.com/bio-routing/bio-rd/routingtable/filter"
+	"github.com/bio-routing/bio-rd/routingtable/locRIB"
+	"github.com/bio-routing/bio-rd/routingtable/vrf"
+	"github.com/stretchr/testify/assert"
+
+	biotesting "github.com/bio-routing/bio-rd/testing"
+)
+
+func newGracefulRestartTestAddressFamily(rib *locRIB.LocRIB, p *peer, peerCap *packet.GracefulRestartCapability) *fsmAddressFamily {
+	return &fsmAddressFamily{
+		afi:               packet.AFIIPv4,
+		safi:              packet.SAFIUnicast,
+		rib:               rib,
+		importFilterChain: filter.NewAcceptAllFilterChain(),
+		exportFilterChain: filter.NewAcceptAllFilterChain(),
+		fsm: &FSM{
+			peer:                p,
+			peerGracefulRestart: peerCap,
+			con: &biotesting.MockConn{
+				Buf: bytes.NewBuffer(nil),
+			},
+		},
+		addPathTX: routingtable.ClientOptions{
+			BestOnly: true,
+		},
+	}
+}
+
+func gracefulRestartTestUpdate(pfxs ...*bnet.Prefix) *packet.BGPUpdate {
+	u := &packet.BGPUpdate{
+		PathAttributes: &packet.PathAttribute{
+			TypeCode: packet.NextHopAttr,
+			Value:    bnet.IPv4FromOctets(10, 0, 0, 1).Ptr(),
+		},
+	}
+
+	for _, pfx := range pfxs {
+		u.NLRI = &packet.NLRI{
+			Prefix: pfx,
+			Next:   u.NLRI,
+		}
+	}
+
+	return u
+}
+
+func TestGracefulRestartHelper(t *testing.T) {
+	pfxA := bnet.NewPfx(bnet.IPv4FromOctets(192, 0, 2, 0), 24).Ptr()
+	pfxB := bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 100, 0), 24).Ptr()
+
+	tests := []struct {
+		name            string
+		forwardingState bool
+		expectedStale   int
+		expectedRoutes  uint64
+	}{
+		{
+			name:            "Forwarding state preserved",
+			forwardingState: true,
+			expectedStale:   2,
+			expectedRoutes:  2,
+		},
+		{
+			name:            "Forwarding state not preserved",
+			forwardingState: false,
+			expectedStale:   0,
+			expectedRoutes:  0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rib := locRIB.New("inet.0")
+			p := &peer{
+				addr:            bnet.IPv4FromOctets(10, 0, 0, 1).Ptr(),
+				routerID:        100,
+				localASN:        15169,
+				peerASN:         15169,
+				adjRIBInFactory: adjRIBInFactory{},
+				vrf:             vrf.NewUntrackedVRF("vrf0", 0),
+				gracefulRestart: GracefulRestartConfig{
+					Enabled:        true,
+					RestartTime:    time.Minute,
+					StalePathsTime: time.Minute,
+				},
+				ipv4: &peerAddressFamily{
+					rib: rib,
+				},
+			}
+
+			peerCap := &packet.GracefulRestartCapability{
+				RestartTime: 120,
+				AddressFamilies: []packet.GracefulRestartCapabilityTuple{
+					{
+						AFI:  packet.AFIIPv4,
+						SAFI: packet.SAFIUnicast,
+					},
+				},
+			}
+
+			f := newGracefulRestartTestAddressFamily(rib, p, peerCap)
+			f.init()
+			f.processUpdate(gracefulRestartTestUpdate(pfxA, pfxB), false, 0)
+			assert.Equal(t, uint64(2), rib.Count())
+
+			f.retain()
+			assert.False(t, f.initialized)
+			assert.NotNil(t, p.ipv4.retained)
+			assert.Equal(t, uint64(2), rib.Count(), "paths must be kept while the peer restarts")
+
+			peerCap = &packet.GracefulRestartCapability{
+				RestartTime: 120,
+				AddressFamilies: []packet.GracefulRestartCapabilityTuple{
+					{
+						AFI:             packet.AFIIPv4,
+						SAFI:            packet.SAFIUnicast,
+						ForwardingState: test.forwardingState,
+					},
+				},
+			}
+
+			f = newGracefulRestartTestAddressFamily(rib, p, peerCap)
+			f.init()
+			defer f.dispose()
+
+			assert.Nil(t, p.ipv4.retained)
+			assert.Equal(t, test.expectedStale, len(f.stalePaths))
+			assert.Equal(t, test.expectedRoutes, rib.Count())
+
+			f.processUpdate(gracefulRestartTestUpdate(pfxA), false, 0)
+			f.processUpdate(&packet.BGPUpdate{}, false, 0)
+			assert.True(t, f.endOfRIBMarkerReceived.Load())
+			assert.Equal(t, 0, len(f.stalePaths))
+			assert.Equal(t, uint64(1), rib.Count())
+			assert.NotNil(t, rib.Get(pfxA))
+			assert.Nil(t, rib.Get(pfxB))
+		})
+	}
+}
+
+func TestGracefulRestartRetainedFlush(t *testing.T) {
+	rib := locRIB.New("inet.0")
+	p := &peer{
+		addr:            bnet.IPv4FromOctets(10, 0, 0, 1).Ptr(),
+		routerID:        100,
+		localASN:        15169,
+		peerASN:         15169,
+		adjRIBInFactory: adjRIBInFactory{},
+		vrf:             vrf.NewUntrackedVRF("vrf0", 0),
+		gracefulRestart: GracefulRestartConfig{
+			Enabled: true,
+		},
+		ipv4: &peerAddressFamily{
+			rib: rib,
+		},
+	}
+
+	f := newGracefulRestartTestAddressFamily(rib, p, &packet.GracefulRestartCapability{
+		RestartTime: 0,
+		AddressFamilies: []packet.GracefulRestartCapabilityTuple{
+			{
+				AFI:  packet.AFIIPv4,
+				SAFI: packet.SAFIUnicast,
+			},
+		},
+	})
+	f.init()
+	f.processUpdate(gracefulRestartTestUpdate(bnet.NewPfx(bnet.IPv4FromOctets(192, 0, 2, 0), 24).Ptr()), false, 0)
+	assert.Equal(t, uint64(1), rib.Count())
+
+	// A restart time of 0 makes the peer's paths expire right away
+	f.retain()
+	assert.Eventually(t, func() bool {
+		return rib.Count() == 0
+	}, time.Second, time.Millisecond*10)
+
+	p.ipv4.retainedMu.Lock()
+	defer p.ipv4.retainedMu.Unlock()
+	assert.Nil(t, p.ipv4.retained)
+}
+
+func TestGracefulRestartCapability(t *testing.T) {
+	tests := []struct {
+		name     string
+		peer     *peer
+		recovery bool
+		expected packet.GracefulRestartCapability
+	}{
+		{
+			name: "IPv4 and IPv6, recovering",
+			peer: &peer{
+				gracefulRestart: GracefulRestartConfig{
+					Enabled:     true,
+					RestartTime: time.Second * 120,
+				},
+				ipv4: &peerAddressFamily{},
+				ipv6: &peerAddressFamily{},
+			},
+			recovery: true,
+			expected: packet.GracefulRestartCapability{
+				RestartState: true,
+				RestartTime:  120,
+				AddressFamilies: []packet.GracefulRestartCapabilityTuple{
+					{
+						AFI:  packet.AFIIPv4,
+						SAFI: packet.SAFIUnicast,
+					},
+					{
+						AFI:  packet.AFIIPv6,
+						SAFI: packet.SAFIUnicast,
+					},
+				},
+			},
+		},
+		{
+			name: "IPv6 only, restart time capped",
+			peer: &peer{
+				gracefulRestart: GracefulRestartConfig{
+					Enabled:     true,
+					RestartTime: time.Hour * 2,
+				},
+				ipv6: &peerAddressFamily{},
+			},
+			expected: packet.GracefulRestartCapability{
+				RestartTime: packet.GracefulRestartMaxRestartTime,
+				AddressFamilies: []packet.GracefulRestartCapabilityTuple{
+					{
+						AFI:  packet.AFIIPv6,
+						SAFI: packet.SAFIUnicast,
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.peer.gracefulRestartRecovery.Store(test.recovery)
+			c := test.peer.gracefulRestartCapability()
+			assert.Equal(t, uint8(packet.GracefulRestartCapabilityCode), c.Code)
+			assert.Equal(t, test.expected, c.Value)
+		})
+	}
+}
+
+func TestNewPeerGracefulRestartRecovery(t *testing.T) {
+	tests := []struct {
+		name      string
+		restarted bool
+		expected  bool
+	}{
+		{
+			name:      "restarted",
+			restarted: true,
+			expected:  true,
+		},
+		{
+			name: "cold start",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b := newBGPServer(BGPServerConfig{
+				Restarted: test.restarted,
+			})
+
+			p, err := newPeer(PeerConfig{
+				LocalAS:     65000,
+				PeerAS:      65100,
+				PeerAddress: bnet.IPv4FromOctets(10, 0, 0, 1).Ptr(),
+				Passive:     true,
+				VRF:         vrf.NewUntrackedVRF("test", 0),
+				GracefulRestart: GracefulRestartConfig{
+					Enabled:     true,
+					RestartTime: 120 * time.Second,
+				},
+			}, b)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			assert.Equal(t, test.expected, p.gracefulRestartRecovery.Load())
+		})
+	}
+}
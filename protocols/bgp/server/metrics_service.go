@@ -27,13 +27,16 @@ func (b *metricsService) peerMetrics() []*metrics.BGPPeerMetrics {
 
 func metricsForPeer(peer *peer) *metrics.BGPPeerMetrics {
 	m := &metrics.BGPPeerMetrics{
-		ASN:             peer.peerASN,
+		ASN:             peer.getPeerASN(),
 		LocalASN:        peer.localASN,
 		IP:              peer.addr,
 		AddressFamilies: make([]*metrics.BGPAddressFamilyMetrics, 0),
 		VRF:             peer.vrf.Name(),
+		Dynamic:         peer.dynamic != nil,
 	}
 
+	m.PrefixLimitHeldDown = peer.prefixLimitHoldDown.heldDown()
+
 	var fsms = peer.fsms
 	if len(fsms) == 0 {
 		return m
@@ -53,12 +56,8 @@ func metricsForPeer(peer *peer) *metrics.BGPPeerMetrics {
 	defer fsm.stateMu.RUnlock()
 
 	if fsm.ribsInitialized {
-		if peer.ipv4 != nil {
-			m.AddressFamilies = append(m.AddressFamilies, metricsForFamily(fsm.ipv4Unicast))
-		}
-
-		if peer.ipv6 != nil {
-			m.AddressFamilies = append(m.AddressFamilies, metricsForFamily(fsm.ipv6Unicast))
+		for _, f := range fsm.addressFamilies() {
+			m.AddressFamilies = append(m.AddressFamilies, metricsForFamily(f))
 		}
 	}
 
@@ -71,6 +70,13 @@ func metricsForFamily(family *fsmAddressFamily) *metrics.BGPAddressFamilyMetrics
 		SAFI:                   family.safi,
 		RoutesReceived:         uint64(family.adjRIBIn.RouteCount()),
 		EndOfRIBMarkerReceived: family.endOfRIBMarkerReceived.Load(),
+		PathsReceived:          uint64(family.adjRIBIn.PathCount()),
+		PrefixLimitWarning:     family.prefixLimitWarning.Load(),
+		PrefixLimitExceeded:    family.prefixLimitExceeded.Load(),
+	}
+
+	if family.prefixLimit != nil {
+		m.PrefixLimit = family.prefixLimit.Max
 	}
 
 	if family.adjRIBOut != nil {
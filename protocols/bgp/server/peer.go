@@ -3,14 +3,15 @@ package server
 import (
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	bnet "github.com/bio-routing/bio-rd/net"
 	"github.com/bio-routing/bio-rd/protocols/bgp/packet"
 	"github.com/bio-routing/bio-rd/route"
 	"github.com/bio-routing/bio-rd/routingtable"
+	"github.com/bio-routing/bio-rd/routingtable/dampening"
 	"github.com/bio-routing/bio-rd/routingtable/filter"
-	"github.com/bio-routing/bio-rd/routingtable/locRIB"
 	"github.com/bio-routing/bio-rd/routingtable/vrf"
 )
 
@@ -21,9 +22,12 @@ type peer struct {
 	localAddr *bnet.IP
 	ttl       uint8
 	passive   bool
-	peerASN   uint32
 	localASN  uint32
 
+	// guarded by peerASNMu
+	peerASN   uint32
+	peerASNMu sync.RWMutex
+
 	// guarded by fsmsMu
 	fsms   []*FSM
 	fsmsMu sync.Mutex
@@ -42,10 +46,31 @@ type peer struct {
 	peerRoleLocal               uint8
 	peerRoleAdvByPeer           bool
 	peerRoleRemote              uint8
+	aspaRejectInvalid           bool
+	nextHopTracker              routingtable.NextHopTracker
+	routeSuppressor             routingtable.RouteSuppressor
+	gracefulRestart             GracefulRestartConfig
+
+	// gracefulRestartRecovery is set while we are recovering from our own restart (RFC4724 Sect. 4.1)
+	gracefulRestartRecovery atomic.Bool
+
+	prefixLimitHoldDown prefixLimitHoldDown
+
+	// bfdDown is set while the BFD session of the peer is down after it had been up. The session is held down until
+	// the BFD session is up again (RFC5882 Sect. 4.3).
+	bfdDown atomic.Bool
+
+	// dynamic is set if the peer was created for an incoming connection covered by dynamic neighbors
+	dynamic *dynamicNeighbors
+
+	vrf   *vrf.VRF
+	ipv4  *peerAddressFamily
+	ipv6  *peerAddressFamily
+	vpnv4 *peerAddressFamily
+	vpnv6 *peerAddressFamily
 
-	vrf  *vrf.VRF
-	ipv4 *peerAddressFamily
-	ipv6 *peerAddressFamily
+	flowSpecV4 *peerAddressFamily
+	flowSpecV6 *peerAddressFamily
 
 	adjRIBInFactory adjRIBInFactoryI
 
@@ -72,8 +97,17 @@ type PeerConfig struct {
 	AdvertiseIPv4MultiProtocol bool
 	PeerRole                   uint8
 	PeerRoleStrictMode         bool
+	ASPARejectInvalid          bool
+	NextHopTracker             routingtable.NextHopTracker  // NextHopTracker resolves the next hops of routes received via iBGP if set
+	RouteSuppressor            routingtable.RouteSuppressor // RouteSuppressor suppresses the advertisement of unicast routes, e.g. more specifics of aggregates, if set
 	IPv4                       *AddressFamilyConfig
 	IPv6                       *AddressFamilyConfig
+	VPNv4                      *AddressFamilyConfig
+	VPNv6                      *AddressFamilyConfig
+	FlowSpecV4                 *AddressFamilyConfig
+	FlowSpecV6                 *AddressFamilyConfig
+	GracefulRestart            GracefulRestartConfig
+	BFD                        *BFDConfig
 	VRF                        *vrf.VRF
 	Description                string
 }
@@ -85,6 +119,19 @@ type AddressFamilyConfig struct {
 	AddPathSend       routingtable.ClientOptions
 	AddPathRecv       bool
 	NextHopExtended   bool
+	PrefixLimit       *PrefixLimitConfig
+	Dampening         *dampening.Config // Dampening suppresses flapping routes (RFC2439). Only applies to unicast address families.
+}
+
+// GracefulRestartConfig represents the Graceful Restart (RFC4724) configuration of a peer
+type GracefulRestartConfig struct {
+	Enabled bool
+
+	// RestartTime is advertised to the peer and also limits how long we defer our updates after our own restart
+	RestartTime time.Duration
+
+	// StalePathsTime limits how long stale paths are kept after the peer re-established the session
+	StalePathsTime time.Duration
 }
 
 // NeedsRestart determines if the peer needs a restart on cfg change
@@ -127,6 +174,14 @@ func (pc *PeerConfig) NeedsRestart(x *PeerConfig) bool {
 		}
 	}
 
+	if pc.ASPARejectInvalid != x.ASPARejectInvalid {
+		return true
+	}
+
+	if pc.RouteSuppressor != x.RouteSuppressor {
+		return true
+	}
+
 	if pc.VRF != x.VRF {
 		return true
 	}
@@ -139,9 +194,42 @@ func (pc *PeerConfig) NeedsRestart(x *PeerConfig) bool {
 		return true
 	}
 
+	if pc.GracefulRestart != x.GracefulRestart {
+		return true
+	}
+
+	if !pc.BFD.Equal(x.BFD) {
+		return true
+	}
+
+	// VPN and FlowSpec address families are negotiated by capabilities, so (de)activating them requires a new session
+	if (pc.VPNv4 == nil) != (x.VPNv4 == nil) || (pc.VPNv6 == nil) != (x.VPNv6 == nil) {
+		return true
+	}
+
+	if (pc.FlowSpecV4 == nil) != (x.FlowSpecV4 == nil) || (pc.FlowSpecV6 == nil) != (x.FlowSpecV6 == nil) {
+		return true
+	}
+
 	return false
 }
 
+func (c *AddressFamilyConfig) prefixLimit() *PrefixLimitConfig {
+	if c == nil {
+		return nil
+	}
+
+	return c.PrefixLimit
+}
+
+func (c *AddressFamilyConfig) dampening() *dampening.Config {
+	if c == nil {
+		return nil
+	}
+
+	return c.Dampening
+}
+
 // replaceImportFilterChain replaces a peers import filter chain
 func (p *peer) replaceImportFilterChain(c filter.Chain) {
 	p.fsmsMu.Lock()
@@ -152,6 +240,17 @@ func (p *peer) replaceImportFilterChain(c filter.Chain) {
 	}
 }
 
+// replaceNextHopTracker replaces the tracker resolving the next hops of routes received from the peer
+func (p *peer) replaceNextHopTracker(t routingtable.NextHopTracker) {
+	p.fsmsMu.Lock()
+	defer p.fsmsMu.Unlock()
+
+	p.nextHopTracker = t
+	for _, fsm := range p.fsms {
+		fsm.replaceNextHopTracker(t)
+	}
+}
+
 // replaceExportFilterChain replaces a peers import filter chain
 func (p *peer) replaceExportFilterChain(c filter.Chain) {
 	p.fsmsMu.Lock()
@@ -191,28 +290,50 @@ func (p *peer) dumpRIBOut(afi uint16, safi uint8) []*route.Route {
 }
 
 type peerAddressFamily struct {
-	rib *locRIB.LocRIB
+	rib ribI
 
 	importFilterChain filter.Chain
 	exportFilterChain filter.Chain
 
 	addPathSend    routingtable.ClientOptions
 	addPathReceive bool
+
+	prefixLimit *PrefixLimitConfig
+
+	// dampener keeps the figures of merit of the received routes across sessions
+	dampener *dampening.Dampener
+
+	// retained holds the Adj-RIB-In of a gracefully restarting peer
+	retained   *retainedAdjRIBIn
+	retainedMu sync.Mutex
 }
 
 func (p *peer) addressFamily(afi uint16, safi uint8) *peerAddressFamily {
-	if safi != packet.SAFIUnicast {
-		return nil
+	switch safi {
+	case packet.SAFIUnicast:
+		switch afi {
+		case packet.AFIIPv4:
+			return p.ipv4
+		case packet.AFIIPv6:
+			return p.ipv6
+		}
+	case packet.SAFIMPLSVPN:
+		switch afi {
+		case packet.AFIIPv4:
+			return p.vpnv4
+		case packet.AFIIPv6:
+			return p.vpnv6
+		}
+	case packet.SAFIFlowSpec:
+		switch afi {
+		case packet.AFIIPv4:
+			return p.flowSpecV4
+		case packet.AFIIPv6:
+			return p.flowSpecV6
+		}
 	}
 
-	switch afi {
-	case packet.AFIIPv4:
-		return p.ipv4
-	case packet.AFIIPv6:
-		return p.ipv6
-	default:
-		return nil
-	}
+	return nil
 }
 
 func (p *peer) collisionHandling(callingFSM *FSM) bool {
@@ -258,7 +379,7 @@ func (p *peer) shouldCeaseOnCollision(callingFSM *FSM) bool {
 	//    collision are identical, then the connection initiated by the BGP
 	//    speaker with the larger AS number is preserved.
 	if p.routerID == callingFSM.neighborID {
-		return p.localASN < callingFSM.peer.peerASN
+		return p.localASN < callingFSM.peer.getPeerASN()
 	}
 
 	// RFC4271 collision handling
@@ -267,7 +388,7 @@ func (p *peer) shouldCeaseOnCollision(callingFSM *FSM) bool {
 
 func isOpenConfirmState(s state) bool {
 	switch s.(type) {
-	case openConfirmState:
+	case *openConfirmState:
 		return true
 	}
 
@@ -276,7 +397,7 @@ func isOpenConfirmState(s state) bool {
 
 func isEstablishedState(s state) bool {
 	switch s.(type) {
-	case establishedState:
+	case *establishedState:
 		return true
 	}
 
@@ -306,21 +427,28 @@ func newPeer(c PeerConfig, server *bgpServer) (*peer, error) {
 		peerRoleEnabled:      peerRoleEnabled(c.PeerRole),
 		peerRoleStrictMode:   c.PeerRoleStrictMode,
 		peerRoleLocal:        translatePeerRole(c.PeerRole),
+		aspaRejectInvalid:    c.ASPARejectInvalid,
+		nextHopTracker:       c.NextHopTracker,
+		routeSuppressor:      c.RouteSuppressor,
+		gracefulRestart:      c.GracefulRestart,
 		vrf:                  c.VRF,
 		adjRIBInFactory:      adjRIBInFactory{},
 	}
 
 	if c.IPv4 != nil {
+		rib := c.VRF.IPv4UnicastRIB()
+		if rib == nil {
+			return nil, fmt.Errorf("no RIB for IPv4 unicast configured")
+		}
+
 		p.ipv4 = &peerAddressFamily{
-			rib:               c.VRF.IPv4UnicastRIB(),
+			rib:               rib,
 			importFilterChain: filterOrDefault(c.IPv4.ImportFilterChain),
 			exportFilterChain: filterOrDefault(c.IPv4.ExportFilterChain),
 			addPathReceive:    c.IPv4.AddPathRecv,
 			addPathSend:       c.IPv4.AddPathSend,
-		}
-
-		if p.ipv4.rib == nil {
-			return nil, fmt.Errorf("no RIB for IPv4 unicast configured")
+			prefixLimit:       c.IPv4.PrefixLimit,
+			dampener:          newDampener(c.IPv4.Dampening),
 		}
 	}
 
@@ -335,38 +463,84 @@ func newPeer(c PeerConfig, server *bgpServer) (*peer, error) {
 
 	caps = append(caps, asn4Capability(c))
 
+	caps = append(caps, routeRefreshCapabilities()...)
+
 	if c.IPv4 != nil {
 		if c.IPv4.NextHopExtended {
 			caps = append(caps, nextHopExtendedCapability(c)...)
 			p.nextHopExtendedAdvertised = true
 			// If no IPv4 session with the peer is established, we must signalize that we
 			// can also speak IPv4 to send or accept IPv4 addresses with IPv6 NextHop.
-			caps = append(caps, multiProtocolCapability(packet.AFIIPv4))
+			caps = append(caps, multiProtocolCapability(packet.AFIIPv4, packet.SAFIUnicast))
 			p.ipv4MultiProtocolAdvertised = true
 		}
 
 		if c.AdvertiseIPv4MultiProtocol {
-			caps = append(caps, multiProtocolCapability(packet.AFIIPv4))
+			caps = append(caps, multiProtocolCapability(packet.AFIIPv4, packet.SAFIUnicast))
 			p.ipv4MultiProtocolAdvertised = true
 		}
 	}
 
 	if c.IPv6 != nil {
+		rib := c.VRF.IPv6UnicastRIB()
+		if rib == nil {
+			return nil, fmt.Errorf("no RIB for IPv6 unicast configured")
+		}
+
 		p.ipv6 = &peerAddressFamily{
-			rib:               c.VRF.IPv6UnicastRIB(),
+			rib:               rib,
 			importFilterChain: filterOrDefault(c.IPv6.ImportFilterChain),
 			exportFilterChain: filterOrDefault(c.IPv6.ExportFilterChain),
 			addPathReceive:    c.IPv6.AddPathRecv,
 			addPathSend:       c.IPv6.AddPathSend,
+			prefixLimit:       c.IPv6.PrefixLimit,
+			dampener:          newDampener(c.IPv6.Dampening),
 		}
-		caps = append(caps, multiProtocolCapability(packet.AFIIPv6))
+		caps = append(caps, multiProtocolCapability(packet.AFIIPv6, packet.SAFIUnicast))
+	}
 
-		if p.ipv6.rib == nil {
-			return nil, fmt.Errorf("no RIB for IPv6 unicast configured")
+	if c.VPNv4 != nil {
+		rib := c.VRF.IPv4VPNRIB()
+		if rib == nil {
+			return nil, fmt.Errorf("no RIB for VPNv4 configured")
+		}
+
+		p.vpnv4 = newBestOnlyPeerAddressFamily(rib, c.VPNv4)
+		caps = append(caps, multiProtocolCapability(packet.AFIIPv4, packet.SAFIMPLSVPN))
+	}
+
+	if c.VPNv6 != nil {
+		rib := c.VRF.IPv6VPNRIB()
+		if rib == nil {
+			return nil, fmt.Errorf("no RIB for VPNv6 configured")
+		}
+
+		p.vpnv6 = newBestOnlyPeerAddressFamily(rib, c.VPNv6)
+		caps = append(caps, multiProtocolCapability(packet.AFIIPv6, packet.SAFIMPLSVPN))
+	}
+
+	if c.FlowSpecV4 != nil {
+		rib := c.VRF.IPv4FlowSpecRIB()
+		if rib == nil {
+			return nil, fmt.Errorf("no RIB for IPv4 FlowSpec configured")
+		}
+
+		p.flowSpecV4 = newBestOnlyPeerAddressFamily(rib, c.FlowSpecV4)
+		caps = append(caps, multiProtocolCapability(packet.AFIIPv4, packet.SAFIFlowSpec))
+	}
+
+	if c.FlowSpecV6 != nil {
+		rib := c.VRF.IPv6FlowSpecRIB()
+		if rib == nil {
+			return nil, fmt.Errorf("no RIB for IPv6 FlowSpec configured")
 		}
+
+		p.flowSpecV6 = newBestOnlyPeerAddressFamily(rib, c.FlowSpecV6)
+		caps = append(caps, multiProtocolCapability(packet.AFIIPv6, packet.SAFIFlowSpec))
 	}
 
-	// Activate Peer Role capability for eBGP neighbors if configured
+	// Activate Peer Role capability for eBGP neighbors if configured. The peer AS of dynamic neighbors is unknown
+	// here, but they are only allowed to use a peer role if they don't accept iBGP sessions.
 	if p.localASN != p.peerASN && peerRoleEnabled(c.PeerRole) {
 		caps = append(caps, peerRoleCapability(c))
 	}
@@ -376,6 +550,10 @@ func newPeer(c PeerConfig, server *bgpServer) (*peer, error) {
 		Value: caps,
 	})
 
+	if p.gracefulRestart.Enabled && server != nil && server.config.Restarted && time.Since(server.startTime) < p.gracefulRestart.RestartTime {
+		p.gracefulRestartRecovery.Store(true)
+	}
+
 	if !p.passive {
 		p.fsms = append(p.fsms, NewActiveFSM(p))
 	}
@@ -383,6 +561,21 @@ func newPeer(c PeerConfig, server *bgpServer) (*peer, error) {
 	return p, nil
 }
 
+// openParams returns the optional parameters for our OPEN message
+func (p *peer) openParams() []packet.OptParam {
+	if !p.gracefulRestart.Enabled {
+		return p.optOpenParams
+	}
+
+	// The Graceful Restart capability carries our restart state and thus can not be precomputed
+	params := make([]packet.OptParam, 0, len(p.optOpenParams)+1)
+	params = append(params, p.optOpenParams...)
+	return append(params, packet.OptParam{
+		Type:  packet.CapabilitiesParamType,
+		Value: packet.Capabilities{p.gracefulRestartCapability()},
+	})
+}
+
 func asn4Capability(c PeerConfig) packet.Capability {
 	return packet.Capability{
 		Code: packet.ASN4CapabilityCode,
@@ -392,13 +585,39 @@ func asn4Capability(c PeerConfig) packet.Capability {
 	}
 }
 
-func multiProtocolCapability(afi uint16) packet.Capability {
+func routeRefreshCapabilities() []packet.Capability {
+	return []packet.Capability{
+		{
+			Code:  packet.RouteRefreshCapabilityCode,
+			Value: packet.RouteRefreshCapability{},
+		},
+		{
+			Code:  packet.EnhancedRouteRefreshCapabilityCode,
+			Value: packet.EnhancedRouteRefreshCapability{},
+		},
+	}
+}
+
+func multiProtocolCapability(afi uint16, safi uint8) packet.Capability {
 	return packet.Capability{
 		Code: packet.MultiProtocolCapabilityCode,
 		Value: packet.MultiProtocolCapability{
 			AFI:  afi,
-			SAFI: packet.SAFIUnicast,
+			SAFI: safi,
+		},
+	}
+}
+
+// newBestOnlyPeerAddressFamily creates an address family without ADD-PATH support as used for VPN and FlowSpec
+func newBestOnlyPeerAddressFamily(rib ribI, c *AddressFamilyConfig) *peerAddressFamily {
+	return &peerAddressFamily{
+		rib:               rib,
+		importFilterChain: filterOrDefault(c.ImportFilterChain),
+		exportFilterChain: filterOrDefault(c.ExportFilterChain),
+		addPathSend: routingtable.ClientOptions{
+			BestOnly: true,
 		},
+		prefixLimit: c.PrefixLimit,
 	}
 }
 
@@ -502,8 +721,69 @@ func (p *peer) stop() {
 	}
 }
 
+// stopDampening stops the reuse of suppressed routes
+func (p *peer) stopDampening() {
+	for _, f := range []*peerAddressFamily{p.ipv4, p.ipv6} {
+		if f != nil && f.dampener != nil {
+			f.dampener.Stop()
+		}
+	}
+}
+
+// replaceDampening applies the dampening parameters of c to the unicast address families. The figures of merit
+// of the received routes are kept unless dampening is disabled.
+func (p *peer) replaceDampening(c *PeerConfig) {
+	p.fsmsMu.Lock()
+	defer p.fsmsMu.Unlock()
+
+	p.replaceDampener(packet.AFIIPv4, p.ipv4, c.IPv4.dampening())
+	p.replaceDampener(packet.AFIIPv6, p.ipv6, c.IPv6.dampening())
+}
+
+func (p *peer) replaceDampener(afi uint16, f *peerAddressFamily, c *dampening.Config) {
+	if f == nil || (f.dampener == nil && c == nil) {
+		return
+	}
+
+	if f.dampener != nil && c != nil {
+		f.dampener.SetConfig(*c)
+		return
+	}
+
+	if f.dampener != nil {
+		f.dampener.Stop()
+	}
+
+	f.dampener = newDampener(c)
+	for _, fsm := range p.fsms {
+		fsm.replaceRouteDampener(afi, f.dampener)
+	}
+}
+
+func newDampener(c *dampening.Config) *dampening.Dampener {
+	if c == nil {
+		return nil
+	}
+
+	return dampening.New(*c)
+}
+
 func (p *peer) isEBGP() bool {
-	return p.localASN != p.peerASN
+	return p.localASN != p.getPeerASN()
+}
+
+func (p *peer) getPeerASN() uint32 {
+	p.peerASNMu.RLock()
+	defer p.peerASNMu.RUnlock()
+
+	return p.peerASN
+}
+
+func (p *peer) setPeerASN(asn uint32) {
+	p.peerASNMu.Lock()
+	defer p.peerASNMu.Unlock()
+
+	p.peerASN = asn
 }
 
 func (p *peer) getBindDev() string {
@@ -0,0 +1,188 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	bbclock "github.com/benbjohnson/clock"
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/bio-routing/bio-rd/routingtable"
+	"github.com/bio-routing/bio-rd/routingtable/adjRIBIn"
+	"github.com/bio-routing/bio-rd/routingtable/dampening"
+	"github.com/bio-routing/bio-rd/routingtable/filter"
+	"github.com/bio-routing/bio-rd/routingtable/vrf"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollisionHandling(t *testing.T) {
+	tests := []struct {
+		name          string
+		otherState    func(fsm *FSM) state
+		routerID      uint32
+		neighborID    uint32
+		expected      bool
+		expectedCease bool
+	}{
+		{
+			name: "other session idle",
+			otherState: func(fsm *FSM) state {
+				return newIdleState(fsm)
+			},
+			expected: false,
+		},
+		{
+			name: "other session established",
+			otherState: func(fsm *FSM) state {
+				return newEstablishedState(fsm)
+			},
+			expected: true,
+		},
+		{
+			name: "other session in OpenConfirm with higher local router ID",
+			otherState: func(fsm *FSM) state {
+				return newOpenConfirmState(fsm)
+			},
+			routerID:   200,
+			neighborID: 100,
+			expected:   true,
+		},
+		{
+			name: "other session in OpenConfirm with lower local router ID",
+			otherState: func(fsm *FSM) state {
+				return newOpenConfirmState(fsm)
+			},
+			routerID:      100,
+			neighborID:    200,
+			expected:      false,
+			expectedCease: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := &peer{
+				routerID: test.routerID,
+			}
+
+			other := newFSM(p)
+			other.state = test.otherState(other)
+
+			calling := newFSM(p)
+			calling.neighborID = test.neighborID
+			p.fsms = []*FSM{other, calling}
+
+			ceased := make(chan struct{})
+			go func() {
+				if <-other.eventCh == Cease {
+					close(ceased)
+				}
+			}()
+
+			assert.Equal(t, test.expected, p.collisionHandling(calling))
+
+			select {
+			case <-ceased:
+				assert.True(t, test.expectedCease, "unexpected cease")
+			case <-time.After(100 * time.Millisecond):
+				assert.False(t, test.expectedCease, "other session was not ceased")
+			}
+		})
+	}
+}
+
+func TestReplaceDampening(t *testing.T) {
+	dampeningConfig := func(reuseThreshold uint32, suppressThreshold uint32) *dampening.Config {
+		return &dampening.Config{
+			HalfLife:          dampening.DefaultHalfLife,
+			ReuseThreshold:    reuseThreshold,
+			SuppressThreshold: suppressThreshold,
+			MaxSuppressTime:   dampening.DefaultMaxSuppressTime,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		old      *dampening.Config
+		new      *dampening.Config
+		expected *routingtable.DampeningState
+	}{
+		{
+			name: "Dampening stays disabled",
+		},
+		{
+			name: "Dampening enabled",
+			new:  dampeningConfig(dampening.DefaultReuseThreshold, dampening.DefaultSuppressThreshold),
+			expected: &routingtable.DampeningState{
+				FigureOfMerit: 1000,
+			},
+		},
+		{
+			name: "Parameters changed",
+			old:  dampeningConfig(dampening.DefaultReuseThreshold, dampening.DefaultSuppressThreshold),
+			new:  dampeningConfig(7500, 9000),
+			expected: &routingtable.DampeningState{
+				FigureOfMerit: 8000,
+			},
+		},
+		{
+			name: "Dampening disabled",
+			old:  dampeningConfig(dampening.DefaultReuseThreshold, dampening.DefaultSuppressThreshold),
+		},
+	}
+
+	pfx := bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 100, 0), 24).Ptr()
+	path := &route.Path{
+		Type:    route.BGPPathType,
+		BGPPath: &route.BGPPath{},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dampening.SetClock(bbclock.NewMock())
+
+			p := &peer{
+				addr: bnet.IPv4FromOctets(169, 254, 100, 100).Ptr(),
+				ipv4: &peerAddressFamily{
+					dampener: newDampener(test.old),
+				},
+				vrf: vrf.NewUntrackedVRF("vrf0", 0),
+			}
+			defer p.stopDampening()
+
+			sa := routingtable.SessionAttrs{}
+			old := p.ipv4.dampener
+			if old != nil {
+				sa.RouteDampener = old
+				for i := 0; i < 7; i++ {
+					old.Withdraw(pfx, 0)
+				}
+			}
+
+			fsm := newFSM(p)
+			fsm.ipv4Unicast.adjRIBIn = adjRIBIn.New(filter.NewAcceptAllFilterChain(), p.vrf, sa)
+			fsm.ipv4Unicast.initialized = true
+			p.fsms = []*FSM{fsm}
+
+			p.replaceDampening(&PeerConfig{
+				IPv4: &AddressFamilyConfig{
+					Dampening: test.new,
+				},
+			})
+
+			if test.new == nil {
+				assert.Nil(t, p.ipv4.dampener)
+				assert.Nil(t, fsm.ipv4Unicast.adjRIBIn.(*adjRIBIn.AdjRIBIn).DampeningState(pfx, path))
+				return
+			}
+
+			// The figures of merit are kept if dampening stays enabled
+			if old != nil {
+				assert.Same(t, old, p.ipv4.dampener)
+			}
+
+			p.ipv4.dampener.Withdraw(pfx, 0)
+			assert.Equal(t, test.expected, fsm.ipv4Unicast.adjRIBIn.(*adjRIBIn.AdjRIBIn).DampeningState(pfx, path))
+		})
+	}
+}
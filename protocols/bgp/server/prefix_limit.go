@@ -0,0 +1,176 @@
+package server
+
+import (
+	"sync"
+	"time"
+
+	"github.com/bio-routing/bio-rd/protocols/bgp/packet"
+	"github.com/bio-routing/bio-rd/util/log"
+)
+
+// PrefixLimitConfig limits the number of paths a peer may announce for an address family
+type PrefixLimitConfig struct {
+	// Max is the maximum number of paths accepted from the peer
+	Max uint64
+
+	// WarningThresholdPercent is the share of Max at which a warning is logged (0 disables the warning)
+	WarningThresholdPercent uint8
+
+	// LogOnly only logs exceeding the limit instead of tearing down the session
+	LogOnly bool
+
+	// RestartTime is the time the session is held down after it was torn down. If 0 the session stays down until it is reconfigured.
+	// Dynamic peers are not held down without restart time but removed right away.
+	RestartTime time.Duration
+}
+
+// Equal compares two prefix limits
+func (c *PrefixLimitConfig) Equal(x *PrefixLimitConfig) bool {
+	if c == nil || x == nil {
+		return c == x
+	}
+
+	return *c == *x
+}
+
+// prefixLimitHoldDown keeps a peer from re-establishing the session after it exceeded a prefix limit (RFC4486 Sect. 4)
+type prefixLimitHoldDown struct {
+	mu     sync.Mutex
+	active bool
+	until  time.Time
+}
+
+func (h *prefixLimitHoldDown) start(restartTime time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.active = true
+	h.until = time.Time{}
+	if restartTime != 0 {
+		h.until = time.Now().Add(restartTime)
+	}
+}
+
+// remaining returns if the hold down is in effect and for how long. A duration of 0 means until the peer is reconfigured.
+func (h *prefixLimitHoldDown) remaining() (time.Duration, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !h.active {
+		return 0, false
+	}
+
+	if h.until.IsZero() {
+		return 0, true
+	}
+
+	d := time.Until(h.until)
+	if d <= 0 {
+		h.active = false
+		return 0, false
+	}
+
+	return d, true
+}
+
+func (h *prefixLimitHoldDown) heldDown() bool {
+	_, held := h.remaining()
+	return held
+}
+
+// stop lifts the hold down. It returns true if the hold down was in effect.
+func (h *prefixLimitHoldDown) stop() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	held := h.active && (h.until.IsZero() || time.Now().Before(h.until))
+	h.active = false
+
+	return held
+}
+
+// replacePrefixLimits applies the prefix limits of c to the peer and its sessions. Established sessions are kept,
+// a session held down after exceeding a prefix limit is started again.
+func (p *peer) replacePrefixLimits(c *PeerConfig) {
+	changed := p.replacePrefixLimit(packet.AFIIPv4, packet.SAFIUnicast, c.IPv4.prefixLimit())
+	changed = p.replacePrefixLimit(packet.AFIIPv6, packet.SAFIUnicast, c.IPv6.prefixLimit()) || changed
+	changed = p.replacePrefixLimit(packet.AFIIPv4, packet.SAFIMPLSVPN, c.VPNv4.prefixLimit()) || changed
+	changed = p.replacePrefixLimit(packet.AFIIPv6, packet.SAFIMPLSVPN, c.VPNv6.prefixLimit()) || changed
+	changed = p.replacePrefixLimit(packet.AFIIPv4, packet.SAFIFlowSpec, c.FlowSpecV4.prefixLimit()) || changed
+	changed = p.replacePrefixLimit(packet.AFIIPv6, packet.SAFIFlowSpec, c.FlowSpecV6.prefixLimit()) || changed
+
+	if !changed || !p.prefixLimitHoldDown.stop() || p.passive {
+		return
+	}
+
+	log.WithFields(log.Fields{
+		"peer": p.addr.String(),
+	}).Info("Prefix limit changed, lifting hold down")
+
+	p.fsmsMu.Lock()
+	defer p.fsmsMu.Unlock()
+
+	for _, fsm := range p.fsms {
+		go fsm.activate()
+	}
+}
+
+// replacePrefixLimit replaces the prefix limit of an address family. It returns true if the limit changed.
+func (p *peer) replacePrefixLimit(afi uint16, safi uint8, l *PrefixLimitConfig) bool {
+	family := p.addressFamily(afi, safi)
+	if family == nil || family.prefixLimit.Equal(l) {
+		return false
+	}
+
+	family.prefixLimit = l
+
+	p.fsmsMu.Lock()
+	defer p.fsmsMu.Unlock()
+
+	for _, fsm := range p.fsms {
+		if f := fsm.addressFamily(afi, safi); f != nil {
+			f.prefixLimit = l
+			f.resetPrefixLimit()
+		}
+	}
+
+	return true
+}
+
+// checkPrefixLimit compares the number of paths received from the peer against the configured limit.
+// It returns true if the limit was exceeded and the session has to be torn down.
+func (f *fsmAddressFamily) checkPrefixLimit() bool {
+	l := f.prefixLimit
+	if l == nil || l.Max == 0 || f.adjRIBIn == nil {
+		return false
+	}
+
+	n := uint64(f.adjRIBIn.PathCount())
+	warning := l.WarningThresholdPercent != 0 && n*100 >= l.Max*uint64(l.WarningThresholdPercent)
+	exceeded := n > l.Max
+
+	if f.prefixLimitWarning.Swap(warning) != warning && warning && !exceeded {
+		f.prefixLimitLogger(n).Infof("Number of paths reached %d%% of the prefix limit", l.WarningThresholdPercent)
+	}
+
+	if f.prefixLimitExceeded.Swap(exceeded) != exceeded && exceeded {
+		f.prefixLimitLogger(n).Error("Prefix limit exceeded")
+	}
+
+	return exceeded && !l.LogOnly
+}
+
+func (f *fsmAddressFamily) prefixLimitLogger(paths uint64) log.LoggerInterface {
+	return log.WithFields(log.Fields{
+		"peer":  f.fsm.peer.addr.String(),
+		"afi":   packet.AFIName(f.afi),
+		"safi":  packet.SAFIName(f.safi),
+		"paths": paths,
+		"limit": f.prefixLimit.Max,
+	})
+}
+
+func (f *fsmAddressFamily) resetPrefixLimit() {
+	f.prefixLimitWarning.Store(false)
+	f.prefixLimitExceeded.Store(false)
+}
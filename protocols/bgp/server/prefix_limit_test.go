@@ -0,0 +1,198 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/packet"
+	"github.com/bio-routing/bio-rd/routingtable"
+	"github.com/bio-routing/bio-rd/routingtable/vrf"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingConn struct {
+	fakeConn
+	written [][]byte
+}
+
+func (c *recordingConn) Write(b []byte) (int, error) {
+	c.written = append(c.written, b)
+	return len(b), nil
+}
+
+func TestCheckPrefixLimit(t *testing.T) {
+	tests := []struct {
+		name             string
+		limit            *PrefixLimitConfig
+		paths            int64
+		expectedTeardown bool
+		expectedWarning  bool
+		expectedExceeded bool
+	}{
+		{
+			name:  "No limit",
+			paths: 1000,
+		},
+		{
+			name:  "Below warning threshold",
+			limit: &PrefixLimitConfig{Max: 100, WarningThresholdPercent: 80},
+			paths: 79,
+		},
+		{
+			name:            "Warning threshold reached",
+			limit:           &PrefixLimitConfig{Max: 100, WarningThresholdPercent: 80},
+			paths:           80,
+			expectedWarning: true,
+		},
+		{
+			name:  "Limit reached",
+			limit: &PrefixLimitConfig{Max: 100},
+			paths: 100,
+		},
+		{
+			name:             "Limit exceeded",
+			limit:            &PrefixLimitConfig{Max: 100, WarningThresholdPercent: 80},
+			paths:            101,
+			expectedTeardown: true,
+			expectedWarning:  true,
+			expectedExceeded: true,
+		},
+		{
+			name:             "Limit exceeded, log only",
+			limit:            &PrefixLimitConfig{Max: 100, LogOnly: true},
+			paths:            101,
+			expectedExceeded: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f := &fsmAddressFamily{
+				afi:         packet.AFIIPv4,
+				safi:        packet.SAFIUnicast,
+				fsm:         &FSM{peer: &peer{addr: bnet.IPv4FromOctets(169, 254, 100, 100).Ptr()}},
+				adjRIBIn:    &routingtable.RTMockClient{FakePathCount: test.paths},
+				prefixLimit: test.limit,
+			}
+
+			assert.Equal(t, test.expectedTeardown, f.checkPrefixLimit())
+			assert.Equal(t, test.expectedWarning, f.prefixLimitWarning.Load())
+			assert.Equal(t, test.expectedExceeded, f.prefixLimitExceeded.Load())
+		})
+	}
+}
+
+func TestPrefixLimitHoldDown(t *testing.T) {
+	h := &prefixLimitHoldDown{}
+	assert.False(t, h.heldDown())
+
+	h.start(time.Hour)
+	d, held := h.remaining()
+	assert.True(t, held)
+	assert.InDelta(t, time.Hour, d, float64(time.Second))
+
+	h.start(0)
+	d, held = h.remaining()
+	assert.True(t, held)
+	assert.Equal(t, time.Duration(0), d)
+
+	h.start(time.Nanosecond)
+	time.Sleep(time.Millisecond)
+	assert.False(t, h.heldDown())
+}
+
+func TestPrefixLimitExceeded(t *testing.T) {
+	con := &recordingConn{}
+	p := &peer{
+		addr: bnet.IPv4FromOctets(169, 254, 100, 100).Ptr(),
+		ipv4: &peerAddressFamily{
+			prefixLimit: &PrefixLimitConfig{Max: 10, RestartTime: time.Hour},
+		},
+		vrf: vrf.NewUntrackedVRF("vrf0", 0),
+	}
+
+	fsm := newFSM(p)
+	fsm.con = con
+	fsm.ipv4Unicast.adjRIBIn = &routingtable.RTMockClient{FakePathCount: 11}
+
+	s := newEstablishedState(fsm)
+	next, reason := s.update(&packet.BGPUpdate{
+		WithdrawnRoutes: &packet.NLRI{
+			Prefix: bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 0), 8).Ptr(),
+		},
+	}, false, 0)
+
+	assert.Equal(t, stateNameIdle, stateName(next))
+	assert.Equal(t, "Prefix limit exceeded for IPv4 Unicast", reason)
+	assert.Equal(t, [][]byte{packet.SerializeNotificationMsg(&packet.BGPNotification{
+		ErrorCode:    packet.Cease,
+		ErrorSubcode: packet.MaxPrefReached,
+	})}, con.written)
+	assert.True(t, p.prefixLimitHoldDown.heldDown())
+}
+
+func TestReplacePrefixLimits(t *testing.T) {
+	tests := []struct {
+		name             string
+		limit            *PrefixLimitConfig
+		expectedLimit    *PrefixLimitConfig
+		expectedHeldDown bool
+	}{
+		{
+			name:             "Unchanged limit",
+			limit:            &PrefixLimitConfig{Max: 10},
+			expectedLimit:    &PrefixLimitConfig{Max: 10},
+			expectedHeldDown: true,
+		},
+		{
+			name:             "Changed limit",
+			limit:            &PrefixLimitConfig{Max: 20},
+			expectedLimit:    &PrefixLimitConfig{Max: 20},
+			expectedHeldDown: false,
+		},
+		{
+			name:             "Removed limit",
+			expectedHeldDown: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := &peer{
+				addr: bnet.IPv4FromOctets(169, 254, 100, 100).Ptr(),
+				ipv4: &peerAddressFamily{
+					prefixLimit: &PrefixLimitConfig{Max: 10},
+				},
+				vrf: vrf.NewUntrackedVRF("vrf0", 0),
+			}
+
+			fsm := newFSM(p)
+			fsm.ipv4Unicast.prefixLimitExceeded.Store(true)
+			p.fsms = []*FSM{fsm}
+			p.prefixLimitHoldDown.start(0)
+
+			p.replacePrefixLimits(&PeerConfig{
+				IPv4: &AddressFamilyConfig{
+					PrefixLimit: test.limit,
+				},
+			})
+
+			assert.Equal(t, test.expectedLimit, p.ipv4.prefixLimit)
+			assert.Equal(t, test.expectedLimit, fsm.ipv4Unicast.prefixLimit)
+			assert.Equal(t, test.expectedHeldDown, p.prefixLimitHoldDown.heldDown())
+			assert.Equal(t, test.expectedHeldDown, fsm.ipv4Unicast.prefixLimitExceeded.Load())
+
+			if test.expectedHeldDown {
+				return
+			}
+
+			select {
+			case e := <-fsm.eventCh:
+				assert.Equal(t, AutomaticStart, e)
+			case <-time.After(time.Second):
+				t.Fatal("session was not started again")
+			}
+		})
+	}
+}
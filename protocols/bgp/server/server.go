@@ -2,8 +2,10 @@ package server
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/bio-routing/bio-rd/net/tcp"
+	"github.com/bio-routing/bio-rd/routingtable"
 	"github.com/bio-routing/bio-rd/routingtable/adjRIBOut"
 	"github.com/bio-routing/bio-rd/routingtable/filter"
 	"github.com/bio-routing/bio-rd/routingtable/vrf"
@@ -11,6 +13,7 @@ import (
 	"github.com/bio-routing/bio-rd/routingtable/adjRIBIn"
 
 	bnet "github.com/bio-routing/bio-rd/net"
+	bfdserver "github.com/bio-routing/bio-rd/protocols/bfd/server"
 	"github.com/bio-routing/bio-rd/protocols/bgp/metrics"
 	"github.com/bio-routing/bio-rd/util/log"
 	bnetutils "github.com/bio-routing/bio-rd/util/net"
@@ -30,13 +33,22 @@ type BGPServerConfig struct {
 	// Optional attributes
 	DefaultLocalPreference *uint32
 	ReusePort              bool
+	// RPKIValidator validates the origin of all received routes if set
+	RPKIValidator routingtable.RPKIValidator
+	// BFD is the session manager peers with BFD enabled subscribe to
+	BFD bfdserver.SessionManager
+	// Restarted indicates that we have been restarted with our forwarding state preserved. Sessions established within
+	// the restart time announce the Restart State bit and defer their updates until End-of-RIB (RFC4724 Sect. 4.1).
+	Restarted bool
 }
 
 type bgpServer struct {
-	config          BGPServerConfig
-	listenerManager tcp.ListenerManagerI
-	peers           *peerManager
-	metrics         *metricsService
+	config           BGPServerConfig
+	listenerManager  tcp.ListenerManagerI
+	peers            *peerManager
+	dynamicNeighbors *dynamicNeighborManager
+	metrics          *metricsService
+	startTime        time.Time
 }
 
 type BGPServer interface {
@@ -46,11 +58,17 @@ type BGPServer interface {
 	GetPeerConfig(*vrf.VRF, *bnet.IP) *PeerConfig
 	DisposePeer(*vrf.VRF, *bnet.IP)
 	GetPeers() []PeerKey
+	ConfigureDynamicNeighbors(DynamicNeighborConfig) error
+	GetDynamicNeighbors() []DynamicNeighborConfig
+	DisposeDynamicNeighbors(*vrf.VRF, *bnet.Prefix)
 	Metrics() (*metrics.BGPMetrics, error)
 	GetRIBIn(vrf *vrf.VRF, peerIP *bnet.IP, afi uint16, safi uint8) *adjRIBIn.AdjRIBIn
 	GetRIBOut(vrf *vrf.VRF, peerIP *bnet.IP, afi uint16, safi uint8) *adjRIBOut.AdjRIBOut
 	ReplaceImportFilterChain(vrf *vrf.VRF, peer *bnet.IP, c filter.Chain) error
 	ReplaceExportFilterChain(vrf *vrf.VRF, peer *bnet.IP, c filter.Chain) error
+	ReplacePrefixLimits(vrf *vrf.VRF, peer *bnet.IP, c *PeerConfig) error
+	ReplaceNextHopTracker(vrf *vrf.VRF, peer *bnet.IP, t routingtable.NextHopTracker) error
+	ReplaceDampening(vrf *vrf.VRF, peer *bnet.IP, c *PeerConfig) error
 	GetDefaultVRF() *vrf.VRF
 	SetListenerManager(lm tcp.ListenerManagerI)
 }
@@ -67,9 +85,11 @@ func NewBGPServer(config BGPServerConfig) BGPServer {
 
 func newBGPServer(config BGPServerConfig) *bgpServer {
 	server := &bgpServer{
-		config:          config,
-		peers:           newPeerManager(),
-		listenerManager: tcp.NewListenerManager(config.ListenAddrsByVRF, config.ReusePort),
+		config:           config,
+		peers:            newPeerManager(),
+		dynamicNeighbors: newDynamicNeighborManager(),
+		listenerManager:  tcp.NewListenerManager(config.ListenAddrsByVRF, config.ReusePort),
+		startTime:        time.Now(),
 	}
 
 	server.metrics = &metricsService{server}
@@ -88,11 +108,15 @@ func (b *bgpServer) RouterID() uint32 {
 	return b.config.RouterID
 }
 
-// GetPeers gets a list of all peers
+// GetPeers gets a list of all configured peers. Peers created for dynamic neighbors are not included.
 func (b *bgpServer) GetPeers() []PeerKey {
 	ret := make([]PeerKey, 0)
 
 	for _, p := range b.peers.list() {
+		if p.dynamic != nil {
+			continue
+		}
+
 		ret = append(ret, p.peerKey())
 	}
 
@@ -121,6 +145,39 @@ func (b *bgpServer) ReplaceExportFilterChain(vrf *vrf.VRF, peerIP *bnet.IP, c fi
 	return nil
 }
 
+// ReplacePrefixLimits applies the prefix limits of c to a peer without restarting its sessions
+func (b *bgpServer) ReplacePrefixLimits(vrf *vrf.VRF, peerIP *bnet.IP, c *PeerConfig) error {
+	p := b.peers.get(vrf, peerIP)
+	if p == nil {
+		return fmt.Errorf("peer %q not found in VRF %q", peerIP.String(), vrf.Name())
+	}
+
+	p.replacePrefixLimits(c)
+	return nil
+}
+
+// ReplaceNextHopTracker replaces the tracker resolving the next hops of routes received from a peer
+func (b *bgpServer) ReplaceNextHopTracker(vrf *vrf.VRF, peerIP *bnet.IP, t routingtable.NextHopTracker) error {
+	p := b.peers.get(vrf, peerIP)
+	if p == nil {
+		return fmt.Errorf("peer %q not found in VRF %q", peerIP.String(), vrf.Name())
+	}
+
+	p.replaceNextHopTracker(t)
+	return nil
+}
+
+// ReplaceDampening applies the dampening parameters of c to a peer without restarting its sessions
+func (b *bgpServer) ReplaceDampening(vrf *vrf.VRF, peerIP *bnet.IP, c *PeerConfig) error {
+	p := b.peers.get(vrf, peerIP)
+	if p == nil {
+		return fmt.Errorf("peer %q not found in VRF %q", peerIP.String(), vrf.Name())
+	}
+
+	p.replaceDampening(c)
+	return nil
+}
+
 func (b *bgpServer) GetRIBIn(vrf *vrf.VRF, peerIP *bnet.IP, afi uint16, safi uint8) *adjRIBIn.AdjRIBIn {
 	p := b.peers.get(vrf, peerIP)
 	if p == nil {
@@ -165,6 +222,10 @@ func (b *bgpServer) incomingConnectionWorker() {
 
 		peerAddr, _ := bnetutils.BIONetIPFromAddr(c.Conn.RemoteAddr().String())
 		peer := b.peers.get(c.VRF, peerAddr.Dedup())
+		if peer == nil {
+			peer = b.addDynamicPeer(c.VRF, peerAddr.Dedup())
+		}
+
 		if peer == nil {
 			c.Conn.Close()
 			log.WithFields(log.Fields{
@@ -173,6 +234,30 @@ func (b *bgpServer) incomingConnectionWorker() {
 			continue
 		}
 
+		if peer.prefixLimitHoldDown.heldDown() {
+			c.Conn.Close()
+			log.WithFields(log.Fields{
+				"source": c.Conn.RemoteAddr(),
+			}).Info("TCP connection from peer held down after exceeding a prefix limit")
+			continue
+		}
+
+		if peer.bfdDown.Load() {
+			c.Conn.Close()
+			log.WithFields(log.Fields{
+				"source": c.Conn.RemoteAddr(),
+			}).Info("TCP connection from peer held down until its BFD session is up again")
+			continue
+		}
+
+		// A restarting peer might reconnect before we noticed its previous session went down
+		if peer.peerRestarted(c.Conn) {
+			log.WithFields(log.Fields{
+				"source": c.Conn.RemoteAddr(),
+			}).Info("Incoming TCP connection from restarting peer, replacing its established session")
+			continue
+		}
+
 		log.WithFields(log.Fields{
 			"source": c.Conn.RemoteAddr(),
 		}).Info("Incoming TCP connection")
@@ -206,6 +291,11 @@ func (b *bgpServer) AddPeer(c PeerConfig) error {
 		return err
 	}
 
+	// A configured peer takes precedence over a dynamic one with the same address
+	if p := b.peers.get(c.VRF, c.PeerAddress); p != nil && p.dynamic != nil {
+		b.DisposePeer(c.VRF, c.PeerAddress)
+	}
+
 	err = b.listenerManager.CreateListenersIfNotExists(c.VRF)
 	if err != nil {
 		return err
@@ -220,6 +310,11 @@ func (b *bgpServer) AddPeer(c PeerConfig) error {
 		}
 	}
 
+	err = b.subscribeBFD(peer)
+	if err != nil {
+		return fmt.Errorf("unable to subscribe to BFD session: %w", err)
+	}
+
 	peer.routerID = c.RouterID
 	b.peers.add(peer)
 	if !c.Passive {
@@ -252,8 +347,16 @@ func (b *bgpServer) DisposePeer(vrf *vrf.VRF, addr *bnet.IP) {
 		return
 	}
 
+	// The peer of a dynamic neighbor might be disposed concurrently by its session going down
+	if p.dynamic != nil && !p.dynamic.removePeer(p) {
+		return
+	}
+
 	log.Infof("disposing BGP session with %s", addr.String())
 	p.stop()
+	b.unsubscribeBFD(p)
+	p.flushRetainedRIBs()
+	p.stopDampening()
 	b.peers.remove(PeerKey{
 		vrf:        vrf,
 		neighborIP: addr,
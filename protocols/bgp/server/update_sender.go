@@ -39,7 +39,7 @@ func newUpdateSender(f *fsmAddressFamily) *UpdateSender {
 	u := &UpdateSender{
 		fsm:           f.fsm,
 		addressFamily: f,
-		iBGP:          f.fsm.peer.localASN == f.fsm.peer.peerASN,
+		iBGP:          f.fsm.peer.localASN == f.fsm.peer.getPeerASN(),
 		rrClient:      f.fsm.peer.routeReflectorClient,
 		destroyCh:     make(chan struct{}),
 		toSend:        make(map[string]*pathPfxs),
@@ -78,6 +78,10 @@ func (u *UpdateSender) AddPath(pfx *bnet.Prefix, p *route.Path) error {
 	u.toSendMu.Lock()
 	defer u.toSendMu.Unlock()
 
+	return u._addPath(pfx, p)
+}
+
+func (u *UpdateSender) _addPath(pfx *bnet.Prefix, p *route.Path) error {
 	hash := p.BGPPath.ComputeHashWithPathID()
 	if _, exists := u.toSend[hash]; exists {
 		u.toSend[hash].pfxs = append(u.toSend[hash].pfxs, pfx)
@@ -111,12 +115,55 @@ func (u *UpdateSender) sendEndOfRIB() {
 	update := &packet.BGPUpdate{
 		SAFI: u.addressFamily.safi,
 	}
+
+	// End-of-RIB for families other than IPv4 unicast is an empty MP_UNREACH_NLRI (RFC4724 Sect. 2)
+	if u.addressFamily.afi != packet.AFIIPv4 || u.addressFamily.multiProtocol {
+		update.PathAttributes = &packet.PathAttribute{
+			TypeCode: packet.MultiProtocolUnreachNLRIAttr,
+			Value: packet.MultiProtocolUnreachNLRI{
+				AFI:  u.addressFamily.afi,
+				SAFI: u.addressFamily.safi,
+			},
+		}
+	}
+
 	err := serializeAndSendUpdate(u.fsm.con, update, u.options)
 	if err != nil {
 		log.Errorf("Failed to serialize and send end of RIB marker: %v", err)
 	}
 }
 
+// RouteRefresh re-sends all paths of routes to the peer (RFC2918). If enhanced is set
+// the updates are enclosed in BoRR and EoRR messages (RFC7313).
+func (u *UpdateSender) RouteRefresh(routes []*route.Route, enhanced bool) {
+	u.toSendMu.Lock()
+	defer u.toSendMu.Unlock()
+
+	u._flush()
+
+	if enhanced {
+		u.sendRouteRefreshDemarcation(packet.BeginningOfRouteRefresh)
+	}
+
+	for _, r := range routes {
+		for _, p := range r.Paths() {
+			u._addPath(r.Prefix(), p)
+		}
+	}
+	u._flush()
+
+	if enhanced {
+		u.sendRouteRefreshDemarcation(packet.EndOfRouteRefresh)
+	}
+}
+
+func (u *UpdateSender) sendRouteRefreshDemarcation(subType uint8) {
+	err := u.fsm.sendRouteRefresh(u.addressFamily.afi, u.addressFamily.safi, subType)
+	if err != nil {
+		log.Errorf("Failed to send route refresh demarcation: %v", err)
+	}
+}
+
 // sender serializes BGP update messages
 func (u *UpdateSender) sender(aggrTime time.Duration) {
 	ticker := time.NewTicker(aggrTime)
@@ -131,31 +178,31 @@ func (u *UpdateSender) sender(aggrTime time.Duration) {
 
 		u.toSendMu.Lock()
 		for key, pathNLRIs := range u.toSend {
-			pathAttrs, updatesPrefixes, pathID := u._getUpdateInformation(pathNLRIs)
+			pathAttrs, updatesPrefixes, bgpPath := u._getUpdateInformation(pathNLRIs)
 
 			delete(u.toSend, key)
 			u.toSendMu.Unlock()
 
-			u.sendUpdates(pathAttrs, updatesPrefixes, pathID)
+			u.sendUpdates(pathAttrs, updatesPrefixes, bgpPath)
 			u.toSendMu.Lock()
 		}
 		u.toSendMu.Unlock()
 	}
 }
 
-func (u *UpdateSender) _getUpdateInformation(pathNLRIs *pathPfxs) (*packet.PathAttribute, [][]*bnet.Prefix, uint32) {
+func (u *UpdateSender) _getUpdateInformation(pathNLRIs *pathPfxs) (*packet.PathAttribute, [][]*bnet.Prefix, *route.BGPPath) {
 	budget := u.getBudget(pathNLRIs)
 
 	pathAttrs, err := packet.PathAttributes(pathNLRIs.path, u.iBGP, u.rrClient)
 	if err != nil {
 		log.Errorf("unable to get path attributes: %v", err)
-		return nil, nil, 0 // FIXME
+		return nil, nil, nil // FIXME
 	}
 
 	updatesPrefixes := make([][]*bnet.Prefix, 0, 1)
 	prefixes := make([]*bnet.Prefix, 0, 1)
 	for _, pfx := range pathNLRIs.pfxs {
-		budget -= int(pfx.BytesInPrefix()) + 1
+		budget -= u.nlriLength(pfx, pathNLRIs.path.BGPPath)
 
 		if u.options.UseAddPath {
 			budget -= packet.PathIdentifierLen
@@ -173,15 +220,15 @@ func (u *UpdateSender) _getUpdateInformation(pathNLRIs *pathPfxs) (*packet.PathA
 		updatesPrefixes = append(updatesPrefixes, prefixes)
 	}
 
-	return pathAttrs, updatesPrefixes, pathNLRIs.path.BGPPath.PathIdentifier
+	return pathAttrs, updatesPrefixes, pathNLRIs.path.BGPPath
 }
 
 func (u *UpdateSender) _flush() {
 	for key, pathNLRIs := range u.toSend {
-		pathAttrs, updatesPrefixes, pathID := u._getUpdateInformation(pathNLRIs)
+		pathAttrs, updatesPrefixes, bgpPath := u._getUpdateInformation(pathNLRIs)
 		delete(u.toSend, key)
 
-		u.sendUpdates(pathAttrs, updatesPrefixes, pathID)
+		u.sendUpdates(pathAttrs, updatesPrefixes, bgpPath)
 	}
 }
 
@@ -199,15 +246,48 @@ func (u *UpdateSender) updateOverhead() int {
 		addrLen = packet.IPv6Len
 	}
 
+	// VPN next hops are prefixed with an all zero route distinguisher (RFC4364 Sect. 4.3.2)
+	if u.addressFamily.safi == packet.SAFIMPLSVPN {
+		addrLen += packet.RouteDistinguisherLen
+	}
+
+	// FlowSpec NLRIs are sent without next hop (RFC8955 Sect. 4)
+	if u.addressFamily.safi == packet.SAFIFlowSpec {
+		addrLen = 0
+	}
+
 	// since we are replacing the next hop attribute IPv4Len has to be subtracted, we also add another byte for extended length
 	return packet.AFILen + packet.SAFILen + 1 + addrLen - packet.IPv4Len + 1
 }
 
-func (u *UpdateSender) sendUpdates(pathAttrs *packet.PathAttribute, updatePrefixes [][]*bnet.Prefix, pathID uint32) {
+// nlriLength returns the number of bytes needed for the NLRI of a prefix
+func (u *UpdateSender) nlriLength(pfx *bnet.Prefix, bgpPath *route.BGPPath) int {
+	if u.addressFamily.safi == packet.SAFIFlowSpec {
+		return packet.FlowSpecNLRILength(bgpPath.FlowSpecRule)
+	}
+
+	return int(pfx.BytesInPrefix()) + 1 + u.nlriOverhead(bgpPath)
+}
+
+// nlriOverhead returns the number of bytes the NLRI of a prefix carries in addition to the prefix itself
+func (u *UpdateSender) nlriOverhead(bgpPath *route.BGPPath) int {
+	if u.addressFamily.safi != packet.SAFIMPLSVPN {
+		return 0
+	}
+
+	labels := len(bgpPath.LabelStack)
+	if labels == 0 {
+		labels = 1
+	}
+
+	return packet.RouteDistinguisherLen + labels*packet.BytesPerLabel
+}
+
+func (u *UpdateSender) sendUpdates(pathAttrs *packet.PathAttribute, updatePrefixes [][]*bnet.Prefix, bgpPath *route.BGPPath) {
 	var err error
 
 	for _, prefixes := range updatePrefixes {
-		update := u.updateMessageForPrefixes(prefixes, pathAttrs, pathID)
+		update := u.updateMessageForPrefixes(prefixes, pathAttrs, bgpPath)
 		if update == nil {
 			log.Errorf("Failed to create update: Neighbor does not support multi protocol.")
 			return
@@ -221,13 +301,13 @@ func (u *UpdateSender) sendUpdates(pathAttrs *packet.PathAttribute, updatePrefix
 	}
 }
 
-func (u *UpdateSender) updateMessageForPrefixes(pfxs []*bnet.Prefix, pa *packet.PathAttribute, pathID uint32) *packet.BGPUpdate {
+func (u *UpdateSender) updateMessageForPrefixes(pfxs []*bnet.Prefix, pa *packet.PathAttribute, bgpPath *route.BGPPath) *packet.BGPUpdate {
 	if u.addressFamily.afi == packet.AFIIPv4 && !u.addressFamily.multiProtocol {
-		return u.bgpUpdate(pfxs, pa, pathID)
+		return u.bgpUpdate(pfxs, pa, bgpPath.PathIdentifier)
 	}
 
 	if u.addressFamily.multiProtocol {
-		return u.bgpUpdateMultiProtocol(pfxs, pa, pathID)
+		return u.bgpUpdateMultiProtocol(pfxs, pa, bgpPath)
 	}
 
 	return nil
@@ -252,8 +332,11 @@ func (u *UpdateSender) bgpUpdate(pfxs []*bnet.Prefix, pa *packet.PathAttribute,
 	return update
 }
 
-func (u *UpdateSender) bgpUpdateMultiProtocol(pfxs []*bnet.Prefix, pa *packet.PathAttribute, pathID uint32) *packet.BGPUpdate {
+func (u *UpdateSender) bgpUpdateMultiProtocol(pfxs []*bnet.Prefix, pa *packet.PathAttribute, bgpPath *route.BGPPath) *packet.BGPUpdate {
 	pa, nextHop := u.copyAttributesWithoutNextHop(pa)
+	if u.addressFamily.safi == packet.SAFIFlowSpec {
+		nextHop = nil
+	}
 
 	attrs := &packet.PathAttribute{
 		TypeCode: packet.MultiProtocolReachNLRIAttr,
@@ -261,7 +344,7 @@ func (u *UpdateSender) bgpUpdateMultiProtocol(pfxs []*bnet.Prefix, pa *packet.Pa
 			AFI:     u.addressFamily.afi,
 			SAFI:    u.addressFamily.safi,
 			NextHop: nextHop,
-			NLRI:    u.nlriForPrefixes(pfxs, pathID),
+			NLRI:    u.nlriForPrefixes(pfxs, bgpPath),
 		},
 	}
 	attrs.Next = pa
@@ -272,13 +355,10 @@ func (u *UpdateSender) bgpUpdateMultiProtocol(pfxs []*bnet.Prefix, pa *packet.Pa
 	}
 }
 
-func (u *UpdateSender) nlriForPrefixes(pfxs []*bnet.Prefix, pathID uint32) *packet.NLRI {
+func (u *UpdateSender) nlriForPrefixes(pfxs []*bnet.Prefix, bgpPath *route.BGPPath) *packet.NLRI {
 	var prev, res *packet.NLRI
 	for _, pfx := range pfxs {
-		cur := &packet.NLRI{
-			Prefix:         pfx,
-			PathIdentifier: pathID,
-		}
+		cur := u.nlri(pfx, bgpPath)
 
 		if res == nil {
 			res = cur
@@ -293,6 +373,31 @@ func (u *UpdateSender) nlriForPrefixes(pfxs []*bnet.Prefix, pathID uint32) *pack
 	return res
 }
 
+// nlri creates the NLRI of a prefix. VPN NLRIs carry the route distinguisher and labels of the path (RFC4364 Sect. 4.3.4),
+// FlowSpec NLRIs carry the rule of the path.
+func (u *UpdateSender) nlri(pfx *bnet.Prefix, bgpPath *route.BGPPath) *packet.NLRI {
+	n := &packet.NLRI{
+		Prefix:         pfx,
+		PathIdentifier: bgpPath.PathIdentifier,
+	}
+
+	if u.addressFamily.safi == packet.SAFIFlowSpec {
+		n.FlowSpec = bgpPath.FlowSpecRule
+		return n
+	}
+
+	if u.addressFamily.safi != packet.SAFIMPLSVPN {
+		return n
+	}
+
+	n.RouteDistinguisher = bgpPath.RouteDistinguisher
+	for _, l := range bgpPath.LabelStack {
+		n.LabelStack = append(n.LabelStack, packet.NewLabelStackEntry(l))
+	}
+
+	return n
+}
+
 func (u *UpdateSender) copyAttributesWithoutNextHop(pa *packet.PathAttribute) (attrs *packet.PathAttribute, nextHop *bnet.IP) {
 	var curCopy, lastCopy *packet.PathAttribute
 	for cur := pa; cur != nil; cur = cur.Next {
@@ -357,9 +462,21 @@ func (u *UpdateSender) withdrawPrefixIPv4(out io.Writer, pfx *bnet.Prefix, p *ro
 }
 
 func (u *UpdateSender) withdrawPrefixMultiProtocol(out io.Writer, pfx *bnet.Prefix, p *route.Path) error {
-	pathID := uint32(0)
+	nlri := &packet.NLRI{
+		Prefix: pfx,
+	}
+
 	if p.BGPPath != nil {
-		pathID = p.BGPPath.PathIdentifier
+		nlri.PathIdentifier = p.BGPPath.PathIdentifier
+
+		// Labels are meaningless in withdrawals (RFC8277 Sect. 2.4)
+		if u.addressFamily.safi == packet.SAFIMPLSVPN {
+			nlri.RouteDistinguisher = p.BGPPath.RouteDistinguisher
+		}
+
+		if u.addressFamily.safi == packet.SAFIFlowSpec {
+			nlri.FlowSpec = p.BGPPath.FlowSpecRule
+		}
 	}
 
 	update := &packet.BGPUpdate{
@@ -368,10 +485,7 @@ func (u *UpdateSender) withdrawPrefixMultiProtocol(out io.Writer, pfx *bnet.Pref
 			Value: packet.MultiProtocolUnreachNLRI{
 				AFI:  u.addressFamily.afi,
 				SAFI: u.addressFamily.safi,
-				NLRI: &packet.NLRI{
-					PathIdentifier: pathID,
-					Prefix:         pfx,
-				},
+				NLRI: nlri,
 			},
 		},
 	}
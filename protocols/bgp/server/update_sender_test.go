@@ -1064,6 +1064,7 @@ func TestWithdrawPrefix(t *testing.T) {
 		name          string
 		addPathTX     routingtable.ClientOptions
 		afi           uint16
+		safi          uint8
 		multiProtocol bool
 		prefix        *bnet.Prefix
 		path          *route.Path
@@ -1207,6 +1208,41 @@ func TestWithdrawPrefix(t *testing.T) {
 			expected:      []byte{},
 			expectedError: errors.New("IPv6 was not negotiated"),
 		},
+		{
+			name:          "IPv4 FlowSpec MP_UNREACH_NLRI",
+			afi:           packet.AFIIPv4,
+			safi:          packet.SAFIFlowSpec,
+			multiProtocol: true,
+			addPathTX:     routingtable.ClientOptions{BestOnly: true},
+			prefix:        bnet.NewPfx(bnet.IPv4FromOctets(192, 0, 2, 0), 24).Ptr(),
+			path: &route.Path{
+				Type: route.BGPPathType,
+				BGPPath: &route.BGPPath{
+					FlowSpecRule: &types.FlowSpecRule{
+						Components: []types.FlowSpecComponent{
+							{
+								Type:   types.FlowSpecComponentDestinationPrefix,
+								Prefix: bnet.NewPfx(bnet.IPv4FromOctets(192, 0, 2, 0), 24).Ptr(),
+							},
+						},
+					},
+				},
+			},
+			expected: []byte{
+				0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, // BGP Marker
+				0x00, 0x23, // BGP Message Length
+				0x02,       // BGP Message Type == Update
+				0x00, 0x00, // WithDraw Octet length
+				0x00, 0x0c, // Length
+				0x80,       // Flags
+				0x0f,       // Attribute Code
+				0x09,       // Attribute length
+				0x00, 0x01, // AFI
+				0x85,                         // SAFI
+				0x05,                         // FlowSpec NLRI length
+				0x01, 0x18, 0xc0, 0x00, 0x02, // Destination prefix
+			},
+		},
 	}
 
 	t.Parallel()
@@ -1214,6 +1250,9 @@ func TestWithdrawPrefix(t *testing.T) {
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
 			buf := bytes.NewBuffer([]byte{})
+			if tc.safi == 0 {
+				tc.safi = packet.SAFIUnicast
+			}
 
 			u := &UpdateSender{
 				fsm: &FSM{},
@@ -1221,7 +1260,7 @@ func TestWithdrawPrefix(t *testing.T) {
 					addPathTX:     tc.addPathTX,
 					multiProtocol: tc.multiProtocol,
 					afi:           tc.afi,
-					safi:          packet.SAFIUnicast,
+					safi:          tc.safi,
 				},
 				options: &packet.EncodeOptions{
 					UseAddPath: !tc.addPathTX.BestOnly,
@@ -1234,3 +1273,111 @@ func TestWithdrawPrefix(t *testing.T) {
 		})
 	}
 }
+
+func TestSenderRouteRefresh(t *testing.T) {
+	con := btest.NewMockConn()
+	fsmA := &FSM{
+		peer: &peer{
+			localASN: 15169,
+			peerASN:  15169,
+		},
+		con: con,
+	}
+	fsmA.ipv4Unicast = &fsmAddressFamily{
+		afi:  packet.AFIIPv4,
+		safi: packet.SAFIUnicast,
+		fsm:  fsmA,
+		addPathTX: routingtable.ClientOptions{
+			BestOnly: true,
+		},
+	}
+
+	u := newUpdateSender(fsmA.ipv4Unicast)
+	u.RouteRefresh([]*route.Route{
+		route.NewRoute(bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 0), 8).Ptr(), &route.Path{
+			Type: route.BGPPathType,
+			BGPPath: &route.BGPPath{
+				BGPPathA: &route.BGPPathA{
+					LocalPref: 100,
+					NextHop:   bnet.IPv4(0).Ptr(),
+					Source:    bnet.IPv4(0).Ptr(),
+				},
+				ASPath: &types.ASPath{},
+			},
+		}),
+	}, true)
+
+	expectedTypes := []uint8{packet.RouteRefreshMsg, packet.UpdateMsg, packet.RouteRefreshMsg}
+	expectedSubTypes := []uint8{packet.BeginningOfRouteRefresh, 0, packet.EndOfRouteRefresh}
+	for i := range expectedTypes {
+		msg, err := packet.Decode(con.Buf, &packet.DecodeOptions{})
+		if err != nil {
+			t.Fatalf("unable to decode message %d: %v", i, err)
+		}
+
+		assert.Equal(t, expectedTypes[i], msg.Header.Type, "message %d", i)
+		if msg.Header.Type == packet.RouteRefreshMsg {
+			assert.Equal(t, expectedSubTypes[i], msg.Body.(*packet.BGPRouteRefresh).SubType, "message %d", i)
+		}
+	}
+
+	assert.Equal(t, 0, con.Buf.Len())
+}
+
+func TestSenderEndOfRIB(t *testing.T) {
+	tests := []struct {
+		name          string
+		afi           uint16
+		multiProtocol bool
+	}{
+		{
+			name: "IPv4 unicast",
+			afi:  packet.AFIIPv4,
+		},
+		{
+			name:          "IPv4 unicast multi protocol",
+			afi:           packet.AFIIPv4,
+			multiProtocol: true,
+		},
+		{
+			name:          "IPv6 unicast",
+			afi:           packet.AFIIPv6,
+			multiProtocol: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			con := btest.NewMockConn()
+			fsmA := &FSM{
+				peer: &peer{
+					localASN: 15169,
+					peerASN:  15169,
+				},
+				con: con,
+			}
+			f := &fsmAddressFamily{
+				afi:           test.afi,
+				safi:          packet.SAFIUnicast,
+				multiProtocol: test.multiProtocol,
+				fsm:           fsmA,
+				addPathTX: routingtable.ClientOptions{
+					BestOnly: true,
+				},
+			}
+
+			u := newUpdateSender(f)
+			u.EndOfRIB()
+
+			msg, err := packet.Decode(con.Buf, &packet.DecodeOptions{})
+			if err != nil {
+				t.Fatalf("unable to decode message: %v", err)
+			}
+
+			afi, safi, isEndOfRIB := msg.Body.(*packet.BGPUpdate).EndOfRIBMarkerFamily()
+			assert.True(t, isEndOfRIB)
+			assert.Equal(t, test.afi, afi)
+			assert.Equal(t, uint8(packet.SAFIUnicast), safi)
+		})
+	}
+}
@@ -0,0 +1,439 @@
+package types
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/route/api"
+)
+
+const (
+	// ExtendedCommunityTypeTwoOctetAS is the transitive two-octet AS specific extended community type (RFC4360)
+	ExtendedCommunityTypeTwoOctetAS = 0x00
+	// ExtendedCommunityTypeIPv4Address is the transitive IPv4 address specific extended community type (RFC4360)
+	ExtendedCommunityTypeIPv4Address = 0x01
+	// ExtendedCommunityTypeFourOctetAS is the transitive four-octet AS specific extended community type (RFC5668)
+	ExtendedCommunityTypeFourOctetAS = 0x02
+	// ExtendedCommunityTypeOpaque is the transitive opaque extended community type (RFC4360)
+	ExtendedCommunityTypeOpaque = 0x03
+
+	// ExtendedCommunityTypeNonTransitive is set in the type of all non-transitive extended communities (RFC4360)
+	ExtendedCommunityTypeNonTransitive = 0x40
+
+	// ExtendedCommunityTypeGenericTransitive is the generic transitive extended community type used for FlowSpec actions (RFC8955 Sect. 7)
+	ExtendedCommunityTypeGenericTransitive = 0x80
+
+	// ExtendedCommunitySubTypeRouteTarget is the sub type of the route target extended community (RFC4360)
+	ExtendedCommunitySubTypeRouteTarget = 0x02
+	// ExtendedCommunitySubTypeRouteOrigin is the sub type of the route origin extended community (RFC4360)
+	ExtendedCommunitySubTypeRouteOrigin = 0x03
+
+	// FlowSpec action sub types of generic transitive extended communities (RFC8955 Sect. 7)
+	ExtendedCommunitySubTypeFlowSpecTrafficRateBytes   = 0x06
+	ExtendedCommunitySubTypeFlowSpecTrafficAction      = 0x07
+	ExtendedCommunitySubTypeFlowSpecRedirect           = 0x08
+	ExtendedCommunitySubTypeFlowSpecTrafficMarking     = 0x09
+	ExtendedCommunitySubTypeFlowSpecTrafficRatePackets = 0x0c
+
+	// IPv6ExtendedCommunityTypeIPv6Address is the transitive IPv6 address specific extended community type (RFC5701)
+	IPv6ExtendedCommunityTypeIPv6Address = 0x00
+
+	extendedCommunityValueMask = 0x0000ffffffffffff
+	routeTargetPrefix          = "target"
+	routeOriginPrefix          = "origin"
+	redirectPrefix             = "redirect"
+	trafficRatePrefix          = "traffic-rate"
+)
+
+type ExtendedCommunities []ExtendedCommunity
+
+func (ec *ExtendedCommunities) String() string {
+	if ec == nil {
+		return ""
+	}
+
+	ecStrings := make([]string, len(*ec))
+	for i, x := range *ec {
+		ecStrings[i] = x.String()
+	}
+
+	return strings.Join(ecStrings, " ")
+}
+
+// Transitive returns all extended communities that may be propagated to other ASes
+func (ec *ExtendedCommunities) Transitive() *ExtendedCommunities {
+	if ec == nil {
+		return nil
+	}
+
+	ret := make(ExtendedCommunities, 0, len(*ec))
+	for _, x := range *ec {
+		if x.Transitive() {
+			ret = append(ret, x)
+		}
+	}
+
+	if len(ret) == len(*ec) {
+		return ec
+	}
+
+	return &ret
+}
+
+// ExtendedCommunity represents an extended community (RFC4360)
+type ExtendedCommunity struct {
+	Type    uint8
+	SubType uint8
+
+	// Value holds the 6 octets following type and sub type
+	Value uint64
+}
+
+// NewRouteTarget creates a route target extended community for AS or IPv4 address specific global administrators
+func NewRouteTarget(globalAdministrator uint32, ipv4 bool, localAdministrator uint32) ExtendedCommunity {
+	return newASOrIPv4Specific(ExtendedCommunitySubTypeRouteTarget, globalAdministrator, ipv4, localAdministrator)
+}
+
+// NewRouteOrigin creates a route origin extended community for AS or IPv4 address specific global administrators
+func NewRouteOrigin(globalAdministrator uint32, ipv4 bool, localAdministrator uint32) ExtendedCommunity {
+	return newASOrIPv4Specific(ExtendedCommunitySubTypeRouteOrigin, globalAdministrator, ipv4, localAdministrator)
+}
+
+// NewFlowSpecRedirect creates a FlowSpec redirect action to the VRF with the given route target (RFC8955 Sect. 7.4)
+func NewFlowSpecRedirect(globalAdministrator uint32, ipv4 bool, localAdministrator uint32) ExtendedCommunity {
+	c := newASOrIPv4Specific(ExtendedCommunitySubTypeFlowSpecRedirect, globalAdministrator, ipv4, localAdministrator)
+	c.Type |= ExtendedCommunityTypeGenericTransitive
+
+	return c
+}
+
+// NewFlowSpecTrafficRate creates a FlowSpec traffic rate action limiting matching traffic to bytesPerSecond.
+// A rate of 0 discards all matching traffic (RFC8955 Sect. 7.3).
+func NewFlowSpecTrafficRate(asn uint16, bytesPerSecond float32) ExtendedCommunity {
+	return ExtendedCommunity{
+		Type:    ExtendedCommunityTypeGenericTransitive,
+		SubType: ExtendedCommunitySubTypeFlowSpecTrafficRateBytes,
+		Value:   uint64(asn)<<32 | uint64(math.Float32bits(bytesPerSecond)),
+	}
+}
+
+func newASOrIPv4Specific(subType uint8, globalAdministrator uint32, ipv4 bool, localAdministrator uint32) ExtendedCommunity {
+	if ipv4 {
+		return ExtendedCommunity{
+			Type:    ExtendedCommunityTypeIPv4Address,
+			SubType: subType,
+			Value:   uint64(globalAdministrator)<<16 | uint64(localAdministrator&0xffff),
+		}
+	}
+
+	if globalAdministrator > 0xffff {
+		return ExtendedCommunity{
+			Type:    ExtendedCommunityTypeFourOctetAS,
+			SubType: subType,
+			Value:   uint64(globalAdministrator)<<16 | uint64(localAdministrator&0xffff),
+		}
+	}
+
+	return ExtendedCommunity{
+		Type:    ExtendedCommunityTypeTwoOctetAS,
+		SubType: subType,
+		Value:   uint64(globalAdministrator)<<32 | uint64(localAdministrator),
+	}
+}
+
+// Transitive checks if the extended community may be propagated to other ASes
+func (c *ExtendedCommunity) Transitive() bool {
+	return c.Type&ExtendedCommunityTypeNonTransitive == 0
+}
+
+// IsRouteTarget checks if c is a route target
+func (c *ExtendedCommunity) IsRouteTarget() bool {
+	return c.hasGlobalAdministrator() && c.SubType == ExtendedCommunitySubTypeRouteTarget
+}
+
+// IsRouteOrigin checks if c is a route origin
+func (c *ExtendedCommunity) IsRouteOrigin() bool {
+	return c.hasGlobalAdministrator() && c.SubType == ExtendedCommunitySubTypeRouteOrigin
+}
+
+// IsFlowSpecRedirect checks if c is a FlowSpec redirect action
+func (c *ExtendedCommunity) IsFlowSpecRedirect() bool {
+	switch c.Type {
+	case ExtendedCommunityTypeGenericTransitive | ExtendedCommunityTypeTwoOctetAS,
+		ExtendedCommunityTypeGenericTransitive | ExtendedCommunityTypeIPv4Address,
+		ExtendedCommunityTypeGenericTransitive | ExtendedCommunityTypeFourOctetAS:
+		return c.SubType == ExtendedCommunitySubTypeFlowSpecRedirect
+	}
+
+	return false
+}
+
+// IsFlowSpecTrafficRate checks if c is a FlowSpec traffic rate action
+func (c *ExtendedCommunity) IsFlowSpecTrafficRate() bool {
+	return c.Type == ExtendedCommunityTypeGenericTransitive && c.SubType == ExtendedCommunitySubTypeFlowSpecTrafficRateBytes
+}
+
+// FlowSpecTrafficRate returns the rate in bytes per second of a FlowSpec traffic rate action
+func (c *ExtendedCommunity) FlowSpecTrafficRate() float32 {
+	return math.Float32frombits(uint32(c.Value))
+}
+
+func (c *ExtendedCommunity) hasGlobalAdministrator() bool {
+	switch c.Type &^ ExtendedCommunityTypeNonTransitive {
+	case ExtendedCommunityTypeTwoOctetAS, ExtendedCommunityTypeIPv4Address, ExtendedCommunityTypeFourOctetAS:
+		return true
+	}
+
+	return false
+}
+
+// administratorType returns the type of AS or IPv4 address specific extended communities without flags
+func (c *ExtendedCommunity) administratorType() uint8 {
+	if c.IsFlowSpecRedirect() {
+		return c.Type &^ ExtendedCommunityTypeGenericTransitive
+	}
+
+	return c.Type &^ ExtendedCommunityTypeNonTransitive
+}
+
+// GlobalAdministrator returns the global administrator of AS or IPv4 address specific extended communities
+func (c *ExtendedCommunity) GlobalAdministrator() uint32 {
+	if c.administratorType() == ExtendedCommunityTypeTwoOctetAS {
+		return uint32(c.Value >> 32)
+	}
+
+	return uint32(c.Value >> 16)
+}
+
+// LocalAdministrator returns the local administrator of AS or IPv4 address specific extended communities
+func (c *ExtendedCommunity) LocalAdministrator() uint32 {
+	if c.administratorType() == ExtendedCommunityTypeTwoOctetAS {
+		return uint32(c.Value)
+	}
+
+	return uint32(c.Value & 0xffff)
+}
+
+// ToUint64 returns the wire representation of the extended community
+func (c *ExtendedCommunity) ToUint64() uint64 {
+	return uint64(c.Type)<<56 | uint64(c.SubType)<<48 | c.Value&extendedCommunityValueMask
+}
+
+// ExtendedCommunityFromUint64 creates an extended community from its wire representation
+func ExtendedCommunityFromUint64(v uint64) ExtendedCommunity {
+	return ExtendedCommunity{
+		Type:    uint8(v >> 56),
+		SubType: uint8(v >> 48),
+		Value:   v & extendedCommunityValueMask,
+	}
+}
+
+// ToProto converts ExtendedCommunity to proto ExtendedCommunity
+func (c *ExtendedCommunity) ToProto() *api.ExtendedCommunity {
+	return &api.ExtendedCommunity{
+		Type:    uint32(c.Type),
+		SubType: uint32(c.SubType),
+		Value:   c.Value,
+	}
+}
+
+// ExtendedCommunityFromProtoExtendedCommunity converts a proto ExtendedCommunity to ExtendedCommunity
+func ExtendedCommunityFromProtoExtendedCommunity(aec *api.ExtendedCommunity) ExtendedCommunity {
+	return ExtendedCommunity{
+		Type:    uint8(aec.Type),
+		SubType: uint8(aec.SubType),
+		Value:   aec.Value & extendedCommunityValueMask,
+	}
+}
+
+// String transitions an extended community to it's human readable representation
+func (c *ExtendedCommunity) String() string {
+	if c == nil {
+		return ""
+	}
+
+	prefix := ""
+	switch {
+	case c.IsRouteTarget():
+		prefix = routeTargetPrefix
+	case c.IsRouteOrigin():
+		prefix = routeOriginPrefix
+	case c.IsFlowSpecRedirect():
+		prefix = redirectPrefix
+	case c.IsFlowSpecTrafficRate():
+		return fmt.Sprintf("%s:%d:%s", trafficRatePrefix, c.Value>>32, strconv.FormatFloat(float64(c.FlowSpecTrafficRate()), 'f', -1, 32))
+	default:
+		return fmt.Sprintf("0x%02x:0x%02x:0x%012x", c.Type, c.SubType, c.Value)
+	}
+
+	if c.administratorType() == ExtendedCommunityTypeIPv4Address {
+		return fmt.Sprintf("%s:%s:%d", prefix, bnet.IPv4(c.GlobalAdministrator()).String(), c.LocalAdministrator())
+	}
+
+	return fmt.Sprintf("%s:%d:%d", prefix, c.GlobalAdministrator(), c.LocalAdministrator())
+}
+
+// ParseExtendedCommunityString parses a human readable route target, route origin or FlowSpec action,
+// e.g. target:65000:100, target:4200000000:100, origin:192.0.2.1:100, redirect:65000:100 or traffic-rate:65000:0
+func ParseExtendedCommunityString(s string) (com ExtendedCommunity, err error) {
+	t := strings.Split(s, ":")
+	if len(t) != 3 {
+		return com, fmt.Errorf("can not parse extended community %s", s)
+	}
+
+	var newFunc func(uint32, bool, uint32) ExtendedCommunity
+	switch t[0] {
+	case routeTargetPrefix:
+		newFunc = NewRouteTarget
+	case routeOriginPrefix:
+		newFunc = NewRouteOrigin
+	case redirectPrefix:
+		newFunc = NewFlowSpecRedirect
+	case trafficRatePrefix:
+		return parseTrafficRate(t[1], t[2])
+	default:
+		return com, fmt.Errorf("unknown extended community type %q", t[0])
+	}
+
+	if strings.Contains(t[1], ".") {
+		addr, err := bnet.IPFromString(t[1])
+		if err != nil {
+			return com, err
+		}
+
+		if !addr.IsIPv4() {
+			return com, fmt.Errorf("%s is not an IPv4 address", t[1])
+		}
+
+		la, err := strconv.ParseUint(t[2], 10, 16)
+		if err != nil {
+			return com, err
+		}
+
+		return newFunc(addr.ToUint32(), true, uint32(la)), nil
+	}
+
+	ga, err := strconv.ParseUint(t[1], 10, 32)
+	if err != nil {
+		return com, err
+	}
+
+	laBits := 32
+	if ga > 0xffff {
+		laBits = 16
+	}
+
+	la, err := strconv.ParseUint(t[2], 10, laBits)
+	if err != nil {
+		return com, err
+	}
+
+	return newFunc(uint32(ga), false, uint32(la)), nil
+}
+
+type IPv6ExtendedCommunities []IPv6ExtendedCommunity
+
+func (ec *IPv6ExtendedCommunities) String() string {
+	if ec == nil {
+		return ""
+	}
+
+	ecStrings := make([]string, len(*ec))
+	for i, x := range *ec {
+		ecStrings[i] = x.String()
+	}
+
+	return strings.Join(ecStrings, " ")
+}
+
+// Transitive returns all IPv6 address specific extended communities that may be propagated to other ASes
+func (ec *IPv6ExtendedCommunities) Transitive() *IPv6ExtendedCommunities {
+	if ec == nil {
+		return nil
+	}
+
+	ret := make(IPv6ExtendedCommunities, 0, len(*ec))
+	for _, x := range *ec {
+		if x.Transitive() {
+			ret = append(ret, x)
+		}
+	}
+
+	if len(ret) == len(*ec) {
+		return ec
+	}
+
+	return &ret
+}
+
+// IPv6ExtendedCommunity represents an IPv6 address specific extended community (RFC5701)
+type IPv6ExtendedCommunity struct {
+	Type                uint8
+	SubType             uint8
+	GlobalAdministrator bnet.IP
+	LocalAdministrator  uint16
+}
+
+// Transitive checks if the extended community may be propagated to other ASes
+func (c *IPv6ExtendedCommunity) Transitive() bool {
+	return c.Type&ExtendedCommunityTypeNonTransitive == 0
+}
+
+// ToProto converts IPv6ExtendedCommunity to proto IPv6ExtendedCommunity
+func (c *IPv6ExtendedCommunity) ToProto() *api.IPv6ExtendedCommunity {
+	return &api.IPv6ExtendedCommunity{
+		Type:                uint32(c.Type),
+		SubType:             uint32(c.SubType),
+		GlobalAdministrator: c.GlobalAdministrator.ToProto(),
+		LocalAdministrator:  uint32(c.LocalAdministrator),
+	}
+}
+
+// IPv6ExtendedCommunityFromProtoIPv6ExtendedCommunity converts a proto IPv6ExtendedCommunity to IPv6ExtendedCommunity
+func IPv6ExtendedCommunityFromProtoIPv6ExtendedCommunity(aec *api.IPv6ExtendedCommunity) IPv6ExtendedCommunity {
+	return IPv6ExtendedCommunity{
+		Type:                uint8(aec.Type),
+		SubType:             uint8(aec.SubType),
+		GlobalAdministrator: bnet.IPFromProtoIP(aec.GlobalAdministrator),
+		LocalAdministrator:  uint16(aec.LocalAdministrator),
+	}
+}
+
+// String transitions an IPv6 address specific extended community to it's human readable representation
+func (c *IPv6ExtendedCommunity) String() string {
+	if c == nil {
+		return ""
+	}
+
+	if c.Type&^ExtendedCommunityTypeNonTransitive == IPv6ExtendedCommunityTypeIPv6Address {
+		switch c.SubType {
+		case ExtendedCommunitySubTypeRouteTarget:
+			return fmt.Sprintf("%s:[%s]:%d", routeTargetPrefix, c.GlobalAdministrator.String(), c.LocalAdministrator)
+		case ExtendedCommunitySubTypeRouteOrigin:
+			return fmt.Sprintf("%s:[%s]:%d", routeOriginPrefix, c.GlobalAdministrator.String(), c.LocalAdministrator)
+		}
+	}
+
+	return fmt.Sprintf("0x%02x:0x%02x:[%s]:%d", c.Type, c.SubType, c.GlobalAdministrator.String(), c.LocalAdministrator)
+}
+
+func parseTrafficRate(asn string, rate string) (com ExtendedCommunity, err error) {
+	a, err := strconv.ParseUint(asn, 10, 16)
+	if err != nil {
+		return com, err
+	}
+
+	r, err := strconv.ParseFloat(rate, 32)
+	if err != nil {
+		return com, err
+	}
+
+	if r < 0 {
+		return com, fmt.Errorf("traffic rate must not be negative")
+	}
+
+	return NewFlowSpecTrafficRate(uint16(a), float32(r)), nil
+}
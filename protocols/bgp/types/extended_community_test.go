@@ -0,0 +1,218 @@
+package types
+
+import (
+	"testing"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/route/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseExtendedCommunityString(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       string
+		expected ExtendedCommunity
+		wantFail bool
+	}{
+		{
+			name: "two octet AS route target",
+			in:   "target:65000:100",
+			expected: ExtendedCommunity{
+				Type:    ExtendedCommunityTypeTwoOctetAS,
+				SubType: ExtendedCommunitySubTypeRouteTarget,
+				Value:   0xfde800000064,
+			},
+		},
+		{
+			name: "four octet AS route target",
+			in:   "target:4200000000:100",
+			expected: ExtendedCommunity{
+				Type:    ExtendedCommunityTypeFourOctetAS,
+				SubType: ExtendedCommunitySubTypeRouteTarget,
+				Value:   0xfa56ea000064,
+			},
+		},
+		{
+			name: "IPv4 address route origin",
+			in:   "origin:192.0.2.1:100",
+			expected: ExtendedCommunity{
+				Type:    ExtendedCommunityTypeIPv4Address,
+				SubType: ExtendedCommunitySubTypeRouteOrigin,
+				Value:   0xc00002010064,
+			},
+		},
+		{
+			name: "FlowSpec redirect",
+			in:   "redirect:65000:100",
+			expected: ExtendedCommunity{
+				Type:    ExtendedCommunityTypeGenericTransitive,
+				SubType: ExtendedCommunitySubTypeFlowSpecRedirect,
+				Value:   0xfde800000064,
+			},
+		},
+		{
+			name: "FlowSpec redirect with IPv4 address",
+			in:   "redirect:192.0.2.1:100",
+			expected: ExtendedCommunity{
+				Type:    ExtendedCommunityTypeGenericTransitive | ExtendedCommunityTypeIPv4Address,
+				SubType: ExtendedCommunitySubTypeFlowSpecRedirect,
+				Value:   0xc00002010064,
+			},
+		},
+		{
+			name: "FlowSpec traffic rate",
+			in:   "traffic-rate:65000:1250000",
+			expected: ExtendedCommunity{
+				Type:    ExtendedCommunityTypeGenericTransitive,
+				SubType: ExtendedCommunitySubTypeFlowSpecTrafficRateBytes,
+				Value:   0xfde849989680,
+			},
+		},
+		{
+			name: "FlowSpec discard",
+			in:   "traffic-rate:65000:0",
+			expected: ExtendedCommunity{
+				Type:    ExtendedCommunityTypeGenericTransitive,
+				SubType: ExtendedCommunitySubTypeFlowSpecTrafficRateBytes,
+				Value:   0xfde800000000,
+			},
+		},
+		{
+			name:     "negative traffic rate",
+			in:       "traffic-rate:65000:-1",
+			wantFail: true,
+		},
+		{
+			name:     "local administrator too large for four octet AS",
+			in:       "target:4200000000:65536",
+			wantFail: true,
+		},
+		{
+			name:     "IPv6 address",
+			in:       "target:2001:db8::1:100",
+			wantFail: true,
+		},
+		{
+			name:     "unknown type",
+			in:       "foo:65000:100",
+			wantFail: true,
+		},
+		{
+			name:     "missing local administrator",
+			in:       "target:65000",
+			wantFail: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, err := ParseExtendedCommunityString(test.in)
+			if test.wantFail {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, c)
+			assert.Equal(t, test.in, c.String())
+		})
+	}
+}
+
+func TestExtendedCommunity(t *testing.T) {
+	tests := []struct {
+		name                string
+		c                   ExtendedCommunity
+		transitive          bool
+		routeTarget         bool
+		routeOrigin         bool
+		globalAdministrator uint32
+		localAdministrator  uint32
+		str                 string
+	}{
+		{
+			name:                "route target",
+			c:                   NewRouteTarget(65000, false, 100000),
+			transitive:          true,
+			routeTarget:         true,
+			globalAdministrator: 65000,
+			localAdministrator:  100000,
+			str:                 "target:65000:100000",
+		},
+		{
+			name:                "non-transitive route origin",
+			c:                   ExtendedCommunity{Type: ExtendedCommunityTypeIPv4Address | ExtendedCommunityTypeNonTransitive, SubType: ExtendedCommunitySubTypeRouteOrigin, Value: 0xc00002010064},
+			routeOrigin:         true,
+			globalAdministrator: 0xc0000201,
+			localAdministrator:  100,
+			str:                 "origin:192.0.2.1:100",
+		},
+		{
+			name:                "FlowSpec redirect",
+			c:                   NewFlowSpecRedirect(4200000000, false, 100),
+			transitive:          true,
+			globalAdministrator: 4200000000,
+			localAdministrator:  100,
+			str:                 "redirect:4200000000:100",
+		},
+		{
+			name:                "opaque",
+			c:                   ExtendedCommunity{Type: ExtendedCommunityTypeOpaque, SubType: 0x0c, Value: 0x7},
+			transitive:          true,
+			globalAdministrator: 0,
+			localAdministrator:  7,
+			str:                 "0x03:0x0c:0x000000000007",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.transitive, test.c.Transitive())
+			assert.Equal(t, test.routeTarget, test.c.IsRouteTarget())
+			assert.Equal(t, test.routeOrigin, test.c.IsRouteOrigin())
+			assert.Equal(t, test.globalAdministrator, test.c.GlobalAdministrator())
+			assert.Equal(t, test.localAdministrator, test.c.LocalAdministrator())
+			assert.Equal(t, test.str, test.c.String())
+			assert.Equal(t, test.c, ExtendedCommunityFromUint64(test.c.ToUint64()))
+			assert.Equal(t, test.c, ExtendedCommunityFromProtoExtendedCommunity(test.c.ToProto()))
+		})
+	}
+}
+
+func TestExtendedCommunitiesTransitive(t *testing.T) {
+	transitive := NewRouteTarget(65000, false, 100)
+	nonTransitive := ExtendedCommunity{
+		Type:    ExtendedCommunityTypeTwoOctetAS | ExtendedCommunityTypeNonTransitive,
+		SubType: ExtendedCommunitySubTypeRouteTarget,
+	}
+
+	var nilComs *ExtendedCommunities
+	assert.Nil(t, nilComs.Transitive())
+
+	onlyTransitive := &ExtendedCommunities{transitive}
+	assert.True(t, onlyTransitive == onlyTransitive.Transitive(), "unmodified list must not be copied")
+
+	mixed := &ExtendedCommunities{nonTransitive, transitive}
+	assert.Equal(t, &ExtendedCommunities{transitive}, mixed.Transitive())
+	assert.Equal(t, 2, len(*mixed))
+}
+
+func TestIPv6ExtendedCommunity(t *testing.T) {
+	c := IPv6ExtendedCommunity{
+		Type:                IPv6ExtendedCommunityTypeIPv6Address,
+		SubType:             ExtendedCommunitySubTypeRouteTarget,
+		GlobalAdministrator: bnet.IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 1),
+		LocalAdministrator:  100,
+	}
+
+	assert.True(t, c.Transitive())
+	assert.Equal(t, "target:[2001:db8::1]:100", c.String())
+	assert.Equal(t, &api.IPv6ExtendedCommunity{
+		Type:                0,
+		SubType:             2,
+		GlobalAdministrator: c.GlobalAdministrator.ToProto(),
+		LocalAdministrator:  100,
+	}, c.ToProto())
+	assert.Equal(t, c, IPv6ExtendedCommunityFromProtoIPv6ExtendedCommunity(c.ToProto()))
+}
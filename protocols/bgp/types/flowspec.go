@@ -0,0 +1,347 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/route/api"
+)
+
+const (
+	// FlowSpec component types (RFC8955 Sect. 4.2.2, RFC8956 Sect. 3)
+	FlowSpecComponentDestinationPrefix = 1
+	FlowSpecComponentSourcePrefix      = 2
+	FlowSpecComponentIPProtocol        = 3
+	FlowSpecComponentPort              = 4
+	FlowSpecComponentDestinationPort   = 5
+	FlowSpecComponentSourcePort        = 6
+	FlowSpecComponentICMPType          = 7
+	FlowSpecComponentICMPCode          = 8
+	FlowSpecComponentTCPFlags          = 9
+	FlowSpecComponentPacketLength      = 10
+	FlowSpecComponentDSCP              = 11
+	FlowSpecComponentFragment          = 12
+	FlowSpecComponentFlowLabel         = 13
+)
+
+var flowSpecComponentNames = map[uint8]string{
+	FlowSpecComponentDestinationPrefix: "dst",
+	FlowSpecComponentSourcePrefix:      "src",
+	FlowSpecComponentIPProtocol:        "proto",
+	FlowSpecComponentPort:              "port",
+	FlowSpecComponentDestinationPort:   "dport",
+	FlowSpecComponentSourcePort:        "sport",
+	FlowSpecComponentICMPType:          "icmp-type",
+	FlowSpecComponentICMPCode:          "icmp-code",
+	FlowSpecComponentTCPFlags:          "tcp-flags",
+	FlowSpecComponentPacketLength:      "length",
+	FlowSpecComponentDSCP:              "dscp",
+	FlowSpecComponentFragment:          "fragment",
+	FlowSpecComponentFlowLabel:         "flow-label",
+}
+
+// FlowSpecRule represents a flow specification (RFC8955, RFC8956). A packet matches the rule if it matches all components.
+type FlowSpecRule struct {
+	Components []FlowSpecComponent
+}
+
+// FlowSpecComponent represents a single component of a flow specification
+type FlowSpecComponent struct {
+	Type uint8
+
+	// Prefix is only set for prefix components
+	Prefix *bnet.Prefix
+
+	// Offset is the number of leading bits of Prefix to skip. Only used for IPv6 (RFC8956 Sect. 3.1)
+	Offset uint8
+
+	// Operations holds the (operator, value) pairs of numeric and bitmask components
+	Operations []FlowSpecOperation
+}
+
+// FlowSpecOperation is an (operator, value) pair of a numeric or bitmask component (RFC8955 Sect. 4.2.1)
+type FlowSpecOperation struct {
+	// And binds the operation to the previous one with a logical AND instead of OR
+	And bool
+
+	// LessThan, GreaterThan and Equal are only used by numeric components
+	LessThan    bool
+	GreaterThan bool
+	Equal       bool
+
+	// Not and Match are only used by bitmask components
+	Not   bool
+	Match bool
+
+	Value uint64
+}
+
+// IsPrefixComponent checks if the component type carries a prefix
+func IsPrefixComponent(t uint8) bool {
+	return t == FlowSpecComponentDestinationPrefix || t == FlowSpecComponentSourcePrefix
+}
+
+// IsBitmaskComponent checks if the component type uses bitmask operators
+func IsBitmaskComponent(t uint8) bool {
+	return t == FlowSpecComponentTCPFlags || t == FlowSpecComponentFragment
+}
+
+// DestinationPrefix returns the destination prefix of the rule or nil if the rule does not match on it
+func (r *FlowSpecRule) DestinationPrefix() *bnet.Prefix {
+	for _, c := range r.Components {
+		if c.Type == FlowSpecComponentDestinationPrefix {
+			return c.Prefix
+		}
+	}
+
+	return nil
+}
+
+// RoutePrefix returns the prefix the rule is stored under in routing tables. This is the destination
+// prefix of the rule or the default route if the rule does not match on the destination.
+func (r *FlowSpecRule) RoutePrefix(ipv6 bool) *bnet.Prefix {
+	pfx := r.DestinationPrefix()
+	if pfx != nil {
+		return pfx
+	}
+
+	if ipv6 {
+		return bnet.NewPfx(bnet.IPv6(0, 0), 0).Dedup()
+	}
+
+	return bnet.NewPfx(bnet.IPv4(0), 0).Dedup()
+}
+
+// Equal checks if two rules are equal
+func (r *FlowSpecRule) Equal(x *FlowSpecRule) bool {
+	if r == nil || x == nil {
+		return r == x
+	}
+
+	if len(r.Components) != len(x.Components) {
+		return false
+	}
+
+	for i := range r.Components {
+		if !r.Components[i].equal(&x.Components[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (c *FlowSpecComponent) equal(x *FlowSpecComponent) bool {
+	if c.Type != x.Type || c.Offset != x.Offset || len(c.Operations) != len(x.Operations) {
+		return false
+	}
+
+	if (c.Prefix == nil) != (x.Prefix == nil) {
+		return false
+	}
+
+	if c.Prefix != nil && !c.Prefix.Equal(x.Prefix) {
+		return false
+	}
+
+	for i := range c.Operations {
+		if c.Operations[i] != x.Operations[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Copy creates a deep copy of the rule
+func (r *FlowSpecRule) Copy() *FlowSpecRule {
+	if r == nil {
+		return nil
+	}
+
+	cp := &FlowSpecRule{
+		Components: make([]FlowSpecComponent, len(r.Components)),
+	}
+
+	for i, c := range r.Components {
+		cp.Components[i] = c
+		if c.Operations != nil {
+			cp.Components[i].Operations = make([]FlowSpecOperation, len(c.Operations))
+			copy(cp.Components[i].Operations, c.Operations)
+		}
+	}
+
+	return cp
+}
+
+// String returns the human readable representation of the rule, e.g. "dst 192.0.2.0/24, proto =17, dport =53 or >=1024"
+func (r *FlowSpecRule) String() string {
+	if r == nil {
+		return ""
+	}
+
+	parts := make([]string, 0, len(r.Components))
+	for _, c := range r.Components {
+		parts = append(parts, c.String())
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// String returns the human readable representation of the component
+func (c *FlowSpecComponent) String() string {
+	name, found := flowSpecComponentNames[c.Type]
+	if !found {
+		name = fmt.Sprintf("type-%d", c.Type)
+	}
+
+	if IsPrefixComponent(c.Type) {
+		if c.Offset > 0 {
+			return fmt.Sprintf("%s %s offset %d", name, c.Prefix.String(), c.Offset)
+		}
+
+		return fmt.Sprintf("%s %s", name, c.Prefix.String())
+	}
+
+	buf := &strings.Builder{}
+	buf.WriteString(name)
+	for i, op := range c.Operations {
+		switch {
+		case i == 0:
+			buf.WriteString(" ")
+		case op.And:
+			buf.WriteString(" and ")
+		default:
+			buf.WriteString(" or ")
+		}
+
+		if IsBitmaskComponent(c.Type) {
+			buf.WriteString(op.bitmaskString())
+			continue
+		}
+
+		buf.WriteString(op.numericString())
+	}
+
+	return buf.String()
+}
+
+func (o *FlowSpecOperation) numericString() string {
+	op := ""
+	switch {
+	case o.LessThan && o.GreaterThan && o.Equal:
+		op = "true"
+	case o.LessThan && o.GreaterThan:
+		op = "!="
+	case o.LessThan && o.Equal:
+		op = "<="
+	case o.GreaterThan && o.Equal:
+		op = ">="
+	case o.LessThan:
+		op = "<"
+	case o.GreaterThan:
+		op = ">"
+	case o.Equal:
+		op = "="
+	default:
+		op = "false"
+	}
+
+	return fmt.Sprintf("%s%d", op, o.Value)
+}
+
+func (o *FlowSpecOperation) bitmaskString() string {
+	op := "&"
+	if o.Match {
+		op = "="
+	}
+
+	if o.Not {
+		op = "!" + op
+	}
+
+	return fmt.Sprintf("%s0x%x", op, o.Value)
+}
+
+// ToProto converts a FlowSpecRule to its proto representation
+func (r *FlowSpecRule) ToProto() *api.FlowSpecRule {
+	if r == nil {
+		return nil
+	}
+
+	a := &api.FlowSpecRule{
+		Components: make([]*api.FlowSpecComponent, len(r.Components)),
+	}
+
+	for i, c := range r.Components {
+		ac := &api.FlowSpecComponent{
+			Type:   uint32(c.Type),
+			Offset: uint32(c.Offset),
+		}
+
+		if c.Prefix != nil {
+			ac.Prefix = c.Prefix.ToProto()
+		}
+
+		if len(c.Operations) > 0 {
+			ac.Operations = make([]*api.FlowSpecOperation, len(c.Operations))
+			for j, op := range c.Operations {
+				ac.Operations[j] = &api.FlowSpecOperation{
+					And:         op.And,
+					LessThan:    op.LessThan,
+					GreaterThan: op.GreaterThan,
+					Equal:       op.Equal,
+					Not:         op.Not,
+					Match:       op.Match,
+					Value:       op.Value,
+				}
+			}
+		}
+
+		a.Components[i] = ac
+	}
+
+	return a
+}
+
+// FlowSpecRuleFromProtoFlowSpecRule converts a proto FlowSpecRule to FlowSpecRule
+func FlowSpecRuleFromProtoFlowSpecRule(a *api.FlowSpecRule) *FlowSpecRule {
+	if a == nil {
+		return nil
+	}
+
+	r := &FlowSpecRule{
+		Components: make([]FlowSpecComponent, len(a.Components)),
+	}
+
+	for i, ac := range a.Components {
+		c := FlowSpecComponent{
+			Type:   uint8(ac.Type),
+			Offset: uint8(ac.Offset),
+		}
+
+		if ac.Prefix != nil {
+			c.Prefix = bnet.NewPrefixFromProtoPrefix(ac.Prefix)
+		}
+
+		if len(ac.Operations) > 0 {
+			c.Operations = make([]FlowSpecOperation, len(ac.Operations))
+			for j, op := range ac.Operations {
+				c.Operations[j] = FlowSpecOperation{
+					And:         op.And,
+					LessThan:    op.LessThan,
+					GreaterThan: op.GreaterThan,
+					Equal:       op.Equal,
+					Not:         op.Not,
+					Match:       op.Match,
+					Value:       op.Value,
+				}
+			}
+		}
+
+		r.Components[i] = c
+	}
+
+	return r
+}
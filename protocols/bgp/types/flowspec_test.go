@@ -0,0 +1,82 @@
+package types
+
+import (
+	"testing"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlowSpecRule(t *testing.T) {
+	tests := []struct {
+		name        string
+		rule        *FlowSpecRule
+		str         string
+		routePrefix *bnet.Prefix
+	}{
+		{
+			name: "IPv4 rule",
+			rule: &FlowSpecRule{
+				Components: []FlowSpecComponent{
+					{
+						Type:   FlowSpecComponentDestinationPrefix,
+						Prefix: bnet.NewPfx(bnet.IPv4FromOctets(192, 0, 2, 0), 24).Ptr(),
+					},
+					{
+						Type:       FlowSpecComponentIPProtocol,
+						Operations: []FlowSpecOperation{{Equal: true, Value: 17}},
+					},
+					{
+						Type: FlowSpecComponentDestinationPort,
+						Operations: []FlowSpecOperation{
+							{Equal: true, Value: 53},
+							{GreaterThan: true, Equal: true, Value: 1024},
+							{And: true, LessThan: true, Value: 2048},
+						},
+					},
+					{
+						Type: FlowSpecComponentTCPFlags,
+						Operations: []FlowSpecOperation{
+							{Match: true, Value: 0x02},
+							{And: true, Not: true, Value: 0x10},
+						},
+					},
+				},
+			},
+			str:         "dst 192.0.2.0/24, proto =17, dport =53 or >=1024 and <2048, tcp-flags =0x2 and !&0x10",
+			routePrefix: bnet.NewPfx(bnet.IPv4FromOctets(192, 0, 2, 0), 24).Ptr(),
+		},
+		{
+			name: "IPv6 rule without destination prefix",
+			rule: &FlowSpecRule{
+				Components: []FlowSpecComponent{
+					{
+						Type:   FlowSpecComponentSourcePrefix,
+						Prefix: bnet.NewPfx(bnet.IPv6FromBlocks(0, 0, 0x1234, 0, 0, 0, 0, 0), 48).Ptr(),
+						Offset: 32,
+					},
+					{
+						Type:       FlowSpecComponentFlowLabel,
+						Operations: []FlowSpecOperation{{LessThan: true, GreaterThan: true, Value: 0}},
+					},
+				},
+			},
+			str:         "src 0:0:1234::/48 offset 32, flow-label !=0",
+			routePrefix: bnet.NewPfx(bnet.IPv6(0, 0), 0).Ptr(),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.str, test.rule.String())
+			assert.Equal(t, test.routePrefix, test.rule.RoutePrefix(!test.routePrefix.Addr().IsIPv4()))
+
+			cp := test.rule.Copy()
+			assert.True(t, test.rule.Equal(cp))
+			cp.Components[len(cp.Components)-1].Operations[0].Value++
+			assert.False(t, test.rule.Equal(cp))
+
+			assert.Equal(t, test.rule, FlowSpecRuleFromProtoFlowSpecRule(test.rule.ToProto()))
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package connected
+
+import (
+	"sync"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/device"
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/bio-routing/bio-rd/routingtable"
+	"github.com/bio-routing/bio-rd/util/log"
+)
+
+// Connected adds paths to the networks of the addresses configured on interfaces to RIBs
+type Connected struct {
+	ds         device.Updater
+	rib4       routingtable.RouteTableClient
+	rib6       routingtable.RouteTableClient
+	mu         sync.Mutex
+	interfaces map[string]*netIfa
+}
+
+// New creates a new connected route source adding IPv4 paths to rib4 and IPv6 paths to rib6
+func New(ds device.Updater, rib4 routingtable.RouteTableClient, rib6 routingtable.RouteTableClient) *Connected {
+	return &Connected{
+		ds:         ds,
+		rib4:       rib4,
+		rib6:       rib6,
+		interfaces: make(map[string]*netIfa),
+	}
+}
+
+// AddInterface starts adding the connected networks of an interface
+func (c *Connected) AddInterface(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, found := c.interfaces[name]; found {
+		return
+	}
+
+	n := newNetIfa(c, name)
+	c.interfaces[name] = n
+	c.ds.Subscribe(n, name)
+}
+
+// RemoveInterface removes the connected networks of an interface
+func (c *Connected) RemoveInterface(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.removeInterface(name)
+}
+
+func (c *Connected) removeInterface(name string) {
+	n, found := c.interfaces[name]
+	if !found {
+		return
+	}
+
+	c.ds.Unsubscribe(n, name)
+	n.withdrawAll()
+	delete(c.interfaces, name)
+}
+
+// Interfaces returns the names of all interfaces connected networks are added for
+func (c *Connected) Interfaces() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	res := make([]string, 0, len(c.interfaces))
+	for name := range c.interfaces {
+		res = append(res, name)
+	}
+
+	return res
+}
+
+// Dispose removes the connected networks of all interfaces
+func (c *Connected) Dispose() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for name := range c.interfaces {
+		c.removeInterface(name)
+	}
+}
+
+func (c *Connected) ribForPrefix(pfx *bnet.Prefix) routingtable.RouteTableClient {
+	addr := pfx.Addr()
+	if addr.IsIPv4() {
+		return c.rib4
+	}
+
+	return c.rib6
+}
+
+// netIfa tracks the connected networks of a single interface
+type netIfa struct {
+	c     *Connected
+	name  string
+	mu    sync.Mutex
+	paths map[bnet.Prefix]*route.Path
+}
+
+func newNetIfa(c *Connected, name string) *netIfa {
+	return &netIfa{
+		c:     c,
+		name:  name,
+		paths: make(map[bnet.Prefix]*route.Path),
+	}
+}
+
+// DeviceUpdate receives device state and address changes
+func (n *netIfa) DeviceUpdate(dev device.DeviceInterface) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	paths := make(map[bnet.Prefix]*route.Path)
+	if isUp(dev.GetOperState()) {
+		for _, addr := range dev.GetAddrs() {
+			if isLinkLocal(addr) {
+				continue
+			}
+
+			pfx := bnet.NewPfx(addr.BaseAddr(), addr.Len())
+			if _, found := paths[pfx]; found {
+				continue
+			}
+
+			paths[pfx] = &route.Path{
+				Type: route.ConnectedPathType,
+				ConnectedPath: &route.ConnectedPath{
+					Interface: n.name,
+					Address:   addr.Addr().Ptr(),
+				},
+			}
+		}
+	}
+
+	for pfx, p := range n.paths {
+		if newPath, found := paths[pfx]; found && newPath.Equal(p) {
+			continue
+		}
+
+		pfx := pfx
+		n.c.ribForPrefix(&pfx).RemovePath(&pfx, p)
+		delete(n.paths, pfx)
+	}
+
+	for pfx, p := range paths {
+		if _, found := n.paths[pfx]; found {
+			continue
+		}
+
+		pfx := pfx
+		err := n.c.ribForPrefix(&pfx).AddPath(&pfx, p)
+		if err != nil {
+			log.Errorf("unable to add connected route %s of interface %s: %v", pfx.String(), n.name, err)
+			continue
+		}
+
+		n.paths[pfx] = p
+	}
+}
+
+func (n *netIfa) withdrawAll() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for pfx, p := range n.paths {
+		pfx := pfx
+		n.c.ribForPrefix(&pfx).RemovePath(&pfx, p)
+	}
+
+	n.paths = make(map[bnet.Prefix]*route.Path)
+}
+
+// isUp checks if an interface is operational. Linux reports the state of the loopback interface as unknown.
+func isUp(operState uint8) bool {
+	return operState == device.IfOperUp || operState == device.IfOperUnknown
+}
+
+// isLinkLocal checks if addr is an IPv6 link local address (fe80::/10)
+func isLinkLocal(addr *bnet.Prefix) bool {
+	ip := addr.Addr()
+	if ip.IsIPv4() {
+		return false
+	}
+
+	return ip.Higher()>>54 == 0xfe80>>6
+}
@@ -0,0 +1,108 @@
+package connected
+
+import (
+	"testing"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/device"
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/bio-routing/bio-rd/routingtable/locRIB"
+	"github.com/stretchr/testify/assert"
+)
+
+func connectedPath(ifName string, addr bnet.IP) *route.Path {
+	return &route.Path{
+		Type: route.ConnectedPathType,
+		ConnectedPath: &route.ConnectedPath{
+			Interface: ifName,
+			Address:   addr.Ptr(),
+		},
+	}
+}
+
+func TestConnected(t *testing.T) {
+	addr4 := bnet.NewPfx(bnet.IPv4FromOctets(192, 0, 2, 1), 24).Ptr()
+	addr6 := bnet.NewPfx(bnet.IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 1), 64).Ptr()
+	linkLocal := bnet.NewPfx(bnet.IPv6FromBlocks(0xfe80, 0, 0, 0, 0, 0, 0, 1), 64).Ptr()
+
+	tests := []struct {
+		name       string
+		run        func(ds *device.MockServer, c *Connected)
+		expected4  map[bnet.Prefix][]*route.Path
+		expected6  map[bnet.Prefix][]*route.Path
+		interfaces []string
+	}{
+		{
+			name: "interface up",
+			run: func(ds *device.MockServer, c *Connected) {
+				ds.DeviceUpEvent("eth0", []*bnet.Prefix{addr4, addr6, linkLocal})
+			},
+			expected4: map[bnet.Prefix][]*route.Path{
+				bnet.NewPfx(bnet.IPv4FromOctets(192, 0, 2, 0), 24): {connectedPath("eth0", addr4.Addr())},
+			},
+			expected6: map[bnet.Prefix][]*route.Path{
+				bnet.NewPfx(bnet.IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 0), 64): {connectedPath("eth0", addr6.Addr())},
+			},
+			interfaces: []string{"eth0"},
+		},
+		{
+			name: "address removed",
+			run: func(ds *device.MockServer, c *Connected) {
+				ds.DeviceUpEvent("eth0", []*bnet.Prefix{addr4, addr6})
+				ds.DeviceUpEvent("eth0", []*bnet.Prefix{addr6})
+			},
+			expected4: map[bnet.Prefix][]*route.Path{},
+			expected6: map[bnet.Prefix][]*route.Path{
+				bnet.NewPfx(bnet.IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 0), 64): {connectedPath("eth0", addr6.Addr())},
+			},
+			interfaces: []string{"eth0"},
+		},
+		{
+			name: "interface down",
+			run: func(ds *device.MockServer, c *Connected) {
+				ds.DeviceUpEvent("eth0", []*bnet.Prefix{addr4, addr6})
+				ds.DeviceDownEvent("eth0", []*bnet.Prefix{addr4, addr6})
+			},
+			expected4:  map[bnet.Prefix][]*route.Path{},
+			expected6:  map[bnet.Prefix][]*route.Path{},
+			interfaces: []string{"eth0"},
+		},
+		{
+			name: "interface removed",
+			run: func(ds *device.MockServer, c *Connected) {
+				ds.DeviceUpEvent("eth0", []*bnet.Prefix{addr4, addr6})
+				c.RemoveInterface("eth0")
+			},
+			expected4:  map[bnet.Prefix][]*route.Path{},
+			expected6:  map[bnet.Prefix][]*route.Path{},
+			interfaces: []string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ds := &device.MockServer{}
+			rib4 := locRIB.New("inet.0")
+			rib6 := locRIB.New("inet6.0")
+			c := New(ds, rib4, rib6)
+			c.AddInterface("eth0")
+			assert.True(t, ds.Called)
+			assert.Equal(t, "eth0", ds.Name)
+
+			test.run(ds, c)
+
+			assert.Equal(t, test.expected4, dump(rib4))
+			assert.Equal(t, test.expected6, dump(rib6))
+			assert.Equal(t, test.interfaces, c.Interfaces())
+		})
+	}
+}
+
+func dump(rib *locRIB.LocRIB) map[bnet.Prefix][]*route.Path {
+	res := make(map[bnet.Prefix][]*route.Path)
+	for _, r := range rib.Dump() {
+		res[*r.Prefix()] = r.Paths()
+	}
+
+	return res
+}
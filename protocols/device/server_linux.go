@@ -131,10 +131,11 @@ func (o *osAdapterLinux) processAddrUpdate(au *netlink.AddrUpdate) {
 	d := o.srv.devices[uint64(au.LinkIndex)]
 	if au.NewAddr {
 		d.addAddr(bnet.NewPfxFromIPNet(&au.LinkAddress))
-		return
+	} else {
+		d.delAddr(bnet.NewPfxFromIPNet(&au.LinkAddress))
 	}
 
-	d.delAddr(bnet.NewPfxFromIPNet(&au.LinkAddress))
+	o.srv.notify(uint64(au.LinkIndex))
 }
 
 func (o *osAdapterLinux) processLinkUpdate(lu *netlink.LinkUpdate) {
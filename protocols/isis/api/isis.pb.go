@@ -497,6 +497,7 @@ type LSPDU struct {
 	ExtendedIpReachabilities []*ExtendedIPReachability `protobuf:"bytes,12,rep,name=extended_ip_reachabilities,json=extendedIpReachabilities,proto3" json:"extended_ip_reachabilities,omitempty"`
 	Ipv4TeRouterId           uint32                    `protobuf:"varint,13,opt,name=ipv4_te_router_id,json=ipv4TeRouterId,proto3" json:"ipv4_te_router_id,omitempty"`
 	Hostname                 string                    `protobuf:"bytes,14,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	RouterCapability         *RouterCapability         `protobuf:"bytes,15,opt,name=router_capability,json=routerCapability,proto3" json:"router_capability,omitempty"`
 }
 
 func (x *LSPDU) Reset() {
@@ -629,33 +630,873 @@ func (x *LSPDU) GetHostname() string {
 	return ""
 }
 
+func (x *LSPDU) GetRouterCapability() *RouterCapability {
+	if x != nil {
+		return x.RouterCapability
+	}
+	return nil
+}
+
 type LSPID struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SystemId     []byte `protobuf:"bytes,1,opt,name=system_id,json=systemId,proto3" json:"system_id,omitempty"`
-	PseudonodeId uint32 `protobuf:"varint,2,opt,name=pseudonode_id,json=pseudonodeId,proto3" json:"pseudonode_id,omitempty"`
-	LspNumber    uint32 `protobuf:"varint,3,opt,name=lsp_number,json=lspNumber,proto3" json:"lsp_number,omitempty"`
+	SystemId     []byte `protobuf:"bytes,1,opt,name=system_id,json=systemId,proto3" json:"system_id,omitempty"`
+	PseudonodeId uint32 `protobuf:"varint,2,opt,name=pseudonode_id,json=pseudonodeId,proto3" json:"pseudonode_id,omitempty"`
+	LspNumber    uint32 `protobuf:"varint,3,opt,name=lsp_number,json=lspNumber,proto3" json:"lsp_number,omitempty"`
+}
+
+func (x *LSPID) Reset() {
+	*x = LSPID{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocols_isis_api_isis_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LSPID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LSPID) ProtoMessage() {}
+
+func (x *LSPID) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_isis_api_isis_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LSPID.ProtoReflect.Descriptor instead.
+func (*LSPID) Descriptor() ([]byte, []int) {
+	return file_protocols_isis_api_isis_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *LSPID) GetSystemId() []byte {
+	if x != nil {
+		return x.SystemId
+	}
+	return nil
+}
+
+func (x *LSPID) GetPseudonodeId() uint32 {
+	if x != nil {
+		return x.PseudonodeId
+	}
+	return 0
+}
+
+func (x *LSPID) GetLspNumber() uint32 {
+	if x != nil {
+		return x.LspNumber
+	}
+	return 0
+}
+
+type ExtendedIPReachability struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Metric       uint32     `protobuf:"varint,1,opt,name=metric,proto3" json:"metric,omitempty"`
+	PrefixLength uint32     `protobuf:"varint,2,opt,name=prefix_length,json=prefixLength,proto3" json:"prefix_length,omitempty"`
+	IpAddress    uint32     `protobuf:"varint,3,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
+	PrefixSid    *PrefixSID `protobuf:"bytes,4,opt,name=prefix_sid,json=prefixSid,proto3" json:"prefix_sid,omitempty"`
+}
+
+func (x *ExtendedIPReachability) Reset() {
+	*x = ExtendedIPReachability{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocols_isis_api_isis_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExtendedIPReachability) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtendedIPReachability) ProtoMessage() {}
+
+func (x *ExtendedIPReachability) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_isis_api_isis_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtendedIPReachability.ProtoReflect.Descriptor instead.
+func (*ExtendedIPReachability) Descriptor() ([]byte, []int) {
+	return file_protocols_isis_api_isis_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ExtendedIPReachability) GetMetric() uint32 {
+	if x != nil {
+		return x.Metric
+	}
+	return 0
+}
+
+func (x *ExtendedIPReachability) GetPrefixLength() uint32 {
+	if x != nil {
+		return x.PrefixLength
+	}
+	return 0
+}
+
+func (x *ExtendedIPReachability) GetIpAddress() uint32 {
+	if x != nil {
+		return x.IpAddress
+	}
+	return 0
+}
+
+func (x *ExtendedIPReachability) GetPrefixSid() *PrefixSID {
+	if x != nil {
+		return x.PrefixSid
+	}
+	return nil
+}
+
+type IPv4NLRI struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	IpAddress  uint32 `protobuf:"varint,1,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
+	Subnetmask uint32 `protobuf:"varint,2,opt,name=subnetmask,proto3" json:"subnetmask,omitempty"`
+}
+
+func (x *IPv4NLRI) Reset() {
+	*x = IPv4NLRI{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocols_isis_api_isis_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IPv4NLRI) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IPv4NLRI) ProtoMessage() {}
+
+func (x *IPv4NLRI) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_isis_api_isis_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IPv4NLRI.ProtoReflect.Descriptor instead.
+func (*IPv4NLRI) Descriptor() ([]byte, []int) {
+	return file_protocols_isis_api_isis_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *IPv4NLRI) GetIpAddress() uint32 {
+	if x != nil {
+		return x.IpAddress
+	}
+	return 0
+}
+
+func (x *IPv4NLRI) GetSubnetmask() uint32 {
+	if x != nil {
+		return x.Subnetmask
+	}
+	return 0
+}
+
+type ExtendedISReachability struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DefaultMetric uint32          `protobuf:"varint,1,opt,name=default_metric,json=defaultMetric,proto3" json:"default_metric,omitempty"`
+	NeighborId    []byte          `protobuf:"bytes,2,opt,name=neighbor_id,json=neighborId,proto3" json:"neighbor_id,omitempty"`
+	AdjacencySids []*AdjacencySID `protobuf:"bytes,3,rep,name=adjacency_sids,json=adjacencySids,proto3" json:"adjacency_sids,omitempty"`
+}
+
+func (x *ExtendedISReachability) Reset() {
+	*x = ExtendedISReachability{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocols_isis_api_isis_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExtendedISReachability) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtendedISReachability) ProtoMessage() {}
+
+func (x *ExtendedISReachability) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_isis_api_isis_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtendedISReachability.ProtoReflect.Descriptor instead.
+func (*ExtendedISReachability) Descriptor() ([]byte, []int) {
+	return file_protocols_isis_api_isis_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ExtendedISReachability) GetDefaultMetric() uint32 {
+	if x != nil {
+		return x.DefaultMetric
+	}
+	return 0
+}
+
+func (x *ExtendedISReachability) GetNeighborId() []byte {
+	if x != nil {
+		return x.NeighborId
+	}
+	return nil
+}
+
+func (x *ExtendedISReachability) GetAdjacencySids() []*AdjacencySID {
+	if x != nil {
+		return x.AdjacencySids
+	}
+	return nil
+}
+
+type GetSPFTreeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Level uint32 `protobuf:"varint,1,opt,name=level,proto3" json:"level,omitempty"`
+}
+
+func (x *GetSPFTreeRequest) Reset() {
+	*x = GetSPFTreeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocols_isis_api_isis_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSPFTreeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSPFTreeRequest) ProtoMessage() {}
+
+func (x *GetSPFTreeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_isis_api_isis_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSPFTreeRequest.ProtoReflect.Descriptor instead.
+func (*GetSPFTreeRequest) Descriptor() ([]byte, []int) {
+	return file_protocols_isis_api_isis_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetSPFTreeRequest) GetLevel() uint32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+type GetSPFTreeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Entries []*SPFTreeEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+}
+
+func (x *GetSPFTreeResponse) Reset() {
+	*x = GetSPFTreeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocols_isis_api_isis_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSPFTreeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSPFTreeResponse) ProtoMessage() {}
+
+func (x *GetSPFTreeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_isis_api_isis_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSPFTreeResponse.ProtoReflect.Descriptor instead.
+func (*GetSPFTreeResponse) Descriptor() ([]byte, []int) {
+	return file_protocols_isis_api_isis_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetSPFTreeResponse) GetEntries() []*SPFTreeEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type SPFTreeEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SystemId     []byte        `protobuf:"bytes,1,opt,name=system_id,json=systemId,proto3" json:"system_id,omitempty"`
+	PseudonodeId uint32        `protobuf:"varint,2,opt,name=pseudonode_id,json=pseudonodeId,proto3" json:"pseudonode_id,omitempty"`
+	Metric       uint32        `protobuf:"varint,3,opt,name=metric,proto3" json:"metric,omitempty"`
+	Parents      [][]byte      `protobuf:"bytes,4,rep,name=parents,proto3" json:"parents,omitempty"`
+	NextHops     []*NextHop    `protobuf:"bytes,5,rep,name=next_hops,json=nextHops,proto3" json:"next_hops,omitempty"`
+	Srgb         []*LabelBlock `protobuf:"bytes,6,rep,name=srgb,proto3" json:"srgb,omitempty"`
+}
+
+func (x *SPFTreeEntry) Reset() {
+	*x = SPFTreeEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocols_isis_api_isis_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SPFTreeEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SPFTreeEntry) ProtoMessage() {}
+
+func (x *SPFTreeEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_isis_api_isis_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SPFTreeEntry.ProtoReflect.Descriptor instead.
+func (*SPFTreeEntry) Descriptor() ([]byte, []int) {
+	return file_protocols_isis_api_isis_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SPFTreeEntry) GetSystemId() []byte {
+	if x != nil {
+		return x.SystemId
+	}
+	return nil
+}
+
+func (x *SPFTreeEntry) GetPseudonodeId() uint32 {
+	if x != nil {
+		return x.PseudonodeId
+	}
+	return 0
+}
+
+func (x *SPFTreeEntry) GetMetric() uint32 {
+	if x != nil {
+		return x.Metric
+	}
+	return 0
+}
+
+func (x *SPFTreeEntry) GetParents() [][]byte {
+	if x != nil {
+		return x.Parents
+	}
+	return nil
+}
+
+func (x *SPFTreeEntry) GetNextHops() []*NextHop {
+	if x != nil {
+		return x.NextHops
+	}
+	return nil
+}
+
+func (x *SPFTreeEntry) GetSrgb() []*LabelBlock {
+	if x != nil {
+		return x.Srgb
+	}
+	return nil
+}
+
+type NextHop struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	InterfaceName string  `protobuf:"bytes,1,opt,name=interface_name,json=interfaceName,proto3" json:"interface_name,omitempty"`
+	SystemId      []byte  `protobuf:"bytes,2,opt,name=system_id,json=systemId,proto3" json:"system_id,omitempty"`
+	Address       *api.IP `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+}
+
+func (x *NextHop) Reset() {
+	*x = NextHop{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocols_isis_api_isis_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NextHop) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NextHop) ProtoMessage() {}
+
+func (x *NextHop) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_isis_api_isis_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NextHop.ProtoReflect.Descriptor instead.
+func (*NextHop) Descriptor() ([]byte, []int) {
+	return file_protocols_isis_api_isis_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *NextHop) GetInterfaceName() string {
+	if x != nil {
+		return x.InterfaceName
+	}
+	return ""
+}
+
+func (x *NextHop) GetSystemId() []byte {
+	if x != nil {
+		return x.SystemId
+	}
+	return nil
+}
+
+func (x *NextHop) GetAddress() *api.IP {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+type GetRoutesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Level uint32 `protobuf:"varint,1,opt,name=level,proto3" json:"level,omitempty"`
+}
+
+func (x *GetRoutesRequest) Reset() {
+	*x = GetRoutesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocols_isis_api_isis_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetRoutesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRoutesRequest) ProtoMessage() {}
+
+func (x *GetRoutesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_isis_api_isis_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRoutesRequest.ProtoReflect.Descriptor instead.
+func (*GetRoutesRequest) Descriptor() ([]byte, []int) {
+	return file_protocols_isis_api_isis_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetRoutesRequest) GetLevel() uint32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+type GetRoutesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Routes []*Route `protobuf:"bytes,1,rep,name=routes,proto3" json:"routes,omitempty"`
+}
+
+func (x *GetRoutesResponse) Reset() {
+	*x = GetRoutesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocols_isis_api_isis_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetRoutesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRoutesResponse) ProtoMessage() {}
+
+func (x *GetRoutesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_isis_api_isis_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRoutesResponse.ProtoReflect.Descriptor instead.
+func (*GetRoutesResponse) Descriptor() ([]byte, []int) {
+	return file_protocols_isis_api_isis_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetRoutesResponse) GetRoutes() []*Route {
+	if x != nil {
+		return x.Routes
+	}
+	return nil
+}
+
+type Route struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Prefix    *api.Prefix `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Metric    uint32      `protobuf:"varint,2,opt,name=metric,proto3" json:"metric,omitempty"`
+	NextHops  []*NextHop  `protobuf:"bytes,3,rep,name=next_hops,json=nextHops,proto3" json:"next_hops,omitempty"`
+	PrefixSid *PrefixSID  `protobuf:"bytes,4,opt,name=prefix_sid,json=prefixSid,proto3" json:"prefix_sid,omitempty"`
+}
+
+func (x *Route) Reset() {
+	*x = Route{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocols_isis_api_isis_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Route) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Route) ProtoMessage() {}
+
+func (x *Route) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_isis_api_isis_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Route.ProtoReflect.Descriptor instead.
+func (*Route) Descriptor() ([]byte, []int) {
+	return file_protocols_isis_api_isis_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Route) GetPrefix() *api.Prefix {
+	if x != nil {
+		return x.Prefix
+	}
+	return nil
+}
+
+func (x *Route) GetMetric() uint32 {
+	if x != nil {
+		return x.Metric
+	}
+	return 0
+}
+
+func (x *Route) GetNextHops() []*NextHop {
+	if x != nil {
+		return x.NextHops
+	}
+	return nil
+}
+
+func (x *Route) GetPrefixSid() *PrefixSID {
+	if x != nil {
+		return x.PrefixSid
+	}
+	return nil
+}
+
+type GetAuthenticationFailuresRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetAuthenticationFailuresRequest) Reset() {
+	*x = GetAuthenticationFailuresRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocols_isis_api_isis_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetAuthenticationFailuresRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAuthenticationFailuresRequest) ProtoMessage() {}
+
+func (x *GetAuthenticationFailuresRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_isis_api_isis_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAuthenticationFailuresRequest.ProtoReflect.Descriptor instead.
+func (*GetAuthenticationFailuresRequest) Descriptor() ([]byte, []int) {
+	return file_protocols_isis_api_isis_proto_rawDescGZIP(), []int{18}
+}
+
+type GetAuthenticationFailuresResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AuthenticationFailures []*AuthenticationFailures `protobuf:"bytes,1,rep,name=authentication_failures,json=authenticationFailures,proto3" json:"authentication_failures,omitempty"`
+}
+
+func (x *GetAuthenticationFailuresResponse) Reset() {
+	*x = GetAuthenticationFailuresResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocols_isis_api_isis_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetAuthenticationFailuresResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAuthenticationFailuresResponse) ProtoMessage() {}
+
+func (x *GetAuthenticationFailuresResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_isis_api_isis_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAuthenticationFailuresResponse.ProtoReflect.Descriptor instead.
+func (*GetAuthenticationFailuresResponse) Descriptor() ([]byte, []int) {
+	return file_protocols_isis_api_isis_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetAuthenticationFailuresResponse) GetAuthenticationFailures() []*AuthenticationFailures {
+	if x != nil {
+		return x.AuthenticationFailures
+	}
+	return nil
+}
+
+type AuthenticationFailures struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	InterfaceName string `protobuf:"bytes,1,opt,name=interface_name,json=interfaceName,proto3" json:"interface_name,omitempty"`
+	Level         uint32 `protobuf:"varint,2,opt,name=level,proto3" json:"level,omitempty"`
+	Hello         uint64 `protobuf:"varint,3,opt,name=hello,proto3" json:"hello,omitempty"`
+	Lsp           uint64 `protobuf:"varint,4,opt,name=lsp,proto3" json:"lsp,omitempty"`
+	Csnp          uint64 `protobuf:"varint,5,opt,name=csnp,proto3" json:"csnp,omitempty"`
+	Psnp          uint64 `protobuf:"varint,6,opt,name=psnp,proto3" json:"psnp,omitempty"`
+}
+
+func (x *AuthenticationFailures) Reset() {
+	*x = AuthenticationFailures{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocols_isis_api_isis_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AuthenticationFailures) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthenticationFailures) ProtoMessage() {}
+
+func (x *AuthenticationFailures) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_isis_api_isis_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthenticationFailures.ProtoReflect.Descriptor instead.
+func (*AuthenticationFailures) Descriptor() ([]byte, []int) {
+	return file_protocols_isis_api_isis_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *AuthenticationFailures) GetInterfaceName() string {
+	if x != nil {
+		return x.InterfaceName
+	}
+	return ""
+}
+
+func (x *AuthenticationFailures) GetLevel() uint32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *AuthenticationFailures) GetHello() uint64 {
+	if x != nil {
+		return x.Hello
+	}
+	return 0
+}
+
+func (x *AuthenticationFailures) GetLsp() uint64 {
+	if x != nil {
+		return x.Lsp
+	}
+	return 0
+}
+
+func (x *AuthenticationFailures) GetCsnp() uint64 {
+	if x != nil {
+		return x.Csnp
+	}
+	return 0
+}
+
+func (x *AuthenticationFailures) GetPsnp() uint64 {
+	if x != nil {
+		return x.Psnp
+	}
+	return 0
+}
+
+type PrefixSID struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Flags     uint32 `protobuf:"varint,1,opt,name=flags,proto3" json:"flags,omitempty"`
+	Algorithm uint32 `protobuf:"varint,2,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	Sid       uint32 `protobuf:"varint,3,opt,name=sid,proto3" json:"sid,omitempty"`
 }
 
-func (x *LSPID) Reset() {
-	*x = LSPID{}
+func (x *PrefixSID) Reset() {
+	*x = PrefixSID{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protocols_isis_api_isis_proto_msgTypes[7]
+		mi := &file_protocols_isis_api_isis_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *LSPID) String() string {
+func (x *PrefixSID) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LSPID) ProtoMessage() {}
+func (*PrefixSID) ProtoMessage() {}
 
-func (x *LSPID) ProtoReflect() protoreflect.Message {
-	mi := &file_protocols_isis_api_isis_proto_msgTypes[7]
+func (x *PrefixSID) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_isis_api_isis_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -666,59 +1507,60 @@ func (x *LSPID) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LSPID.ProtoReflect.Descriptor instead.
-func (*LSPID) Descriptor() ([]byte, []int) {
-	return file_protocols_isis_api_isis_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use PrefixSID.ProtoReflect.Descriptor instead.
+func (*PrefixSID) Descriptor() ([]byte, []int) {
+	return file_protocols_isis_api_isis_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *LSPID) GetSystemId() []byte {
+func (x *PrefixSID) GetFlags() uint32 {
 	if x != nil {
-		return x.SystemId
+		return x.Flags
 	}
-	return nil
+	return 0
 }
 
-func (x *LSPID) GetPseudonodeId() uint32 {
+func (x *PrefixSID) GetAlgorithm() uint32 {
 	if x != nil {
-		return x.PseudonodeId
+		return x.Algorithm
 	}
 	return 0
 }
 
-func (x *LSPID) GetLspNumber() uint32 {
+func (x *PrefixSID) GetSid() uint32 {
 	if x != nil {
-		return x.LspNumber
+		return x.Sid
 	}
 	return 0
 }
 
-type ExtendedIPReachability struct {
+type AdjacencySID struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Metric       uint32 `protobuf:"varint,1,opt,name=metric,proto3" json:"metric,omitempty"`
-	PrefixLength uint32 `protobuf:"varint,2,opt,name=prefix_length,json=prefixLength,proto3" json:"prefix_length,omitempty"`
-	IpAddress    uint32 `protobuf:"varint,3,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"` // To be extended with sub-TLVs
+	Flags            uint32 `protobuf:"varint,1,opt,name=flags,proto3" json:"flags,omitempty"`
+	Weight           uint32 `protobuf:"varint,2,opt,name=weight,proto3" json:"weight,omitempty"`
+	NeighborSystemId []byte `protobuf:"bytes,3,opt,name=neighbor_system_id,json=neighborSystemId,proto3" json:"neighbor_system_id,omitempty"`
+	Sid              uint32 `protobuf:"varint,4,opt,name=sid,proto3" json:"sid,omitempty"`
 }
 
-func (x *ExtendedIPReachability) Reset() {
-	*x = ExtendedIPReachability{}
+func (x *AdjacencySID) Reset() {
+	*x = AdjacencySID{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protocols_isis_api_isis_proto_msgTypes[8]
+		mi := &file_protocols_isis_api_isis_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ExtendedIPReachability) String() string {
+func (x *AdjacencySID) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ExtendedIPReachability) ProtoMessage() {}
+func (*AdjacencySID) ProtoMessage() {}
 
-func (x *ExtendedIPReachability) ProtoReflect() protoreflect.Message {
-	mi := &file_protocols_isis_api_isis_proto_msgTypes[8]
+func (x *AdjacencySID) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_isis_api_isis_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -729,58 +1571,69 @@ func (x *ExtendedIPReachability) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ExtendedIPReachability.ProtoReflect.Descriptor instead.
-func (*ExtendedIPReachability) Descriptor() ([]byte, []int) {
-	return file_protocols_isis_api_isis_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use AdjacencySID.ProtoReflect.Descriptor instead.
+func (*AdjacencySID) Descriptor() ([]byte, []int) {
+	return file_protocols_isis_api_isis_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *ExtendedIPReachability) GetMetric() uint32 {
+func (x *AdjacencySID) GetFlags() uint32 {
 	if x != nil {
-		return x.Metric
+		return x.Flags
 	}
 	return 0
 }
 
-func (x *ExtendedIPReachability) GetPrefixLength() uint32 {
+func (x *AdjacencySID) GetWeight() uint32 {
 	if x != nil {
-		return x.PrefixLength
+		return x.Weight
 	}
 	return 0
 }
 
-func (x *ExtendedIPReachability) GetIpAddress() uint32 {
+func (x *AdjacencySID) GetNeighborSystemId() []byte {
 	if x != nil {
-		return x.IpAddress
+		return x.NeighborSystemId
+	}
+	return nil
+}
+
+func (x *AdjacencySID) GetSid() uint32 {
+	if x != nil {
+		return x.Sid
 	}
 	return 0
 }
 
-type IPv4NLRI struct {
+type RouterCapability struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	IpAddress  uint32 `protobuf:"varint,1,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
-	Subnetmask uint32 `protobuf:"varint,2,opt,name=subnetmask,proto3" json:"subnetmask,omitempty"`
+	RouterId            uint32        `protobuf:"varint,1,opt,name=router_id,json=routerId,proto3" json:"router_id,omitempty"`
+	Flags               uint32        `protobuf:"varint,2,opt,name=flags,proto3" json:"flags,omitempty"`
+	SrCapabilitiesFlags uint32        `protobuf:"varint,3,opt,name=sr_capabilities_flags,json=srCapabilitiesFlags,proto3" json:"sr_capabilities_flags,omitempty"`
+	Srgb                []*LabelBlock `protobuf:"bytes,4,rep,name=srgb,proto3" json:"srgb,omitempty"`
+	SrAlgorithms        []uint32      `protobuf:"varint,5,rep,packed,name=sr_algorithms,json=srAlgorithms,proto3" json:"sr_algorithms,omitempty"`
+	Srlb                []*LabelBlock `protobuf:"bytes,6,rep,name=srlb,proto3" json:"srlb,omitempty"`
 }
 
-func (x *IPv4NLRI) Reset() {
-	*x = IPv4NLRI{}
+func (x *RouterCapability) Reset() {
+	*x = RouterCapability{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protocols_isis_api_isis_proto_msgTypes[9]
+		mi := &file_protocols_isis_api_isis_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *IPv4NLRI) String() string {
+func (x *RouterCapability) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IPv4NLRI) ProtoMessage() {}
+func (*RouterCapability) ProtoMessage() {}
 
-func (x *IPv4NLRI) ProtoReflect() protoreflect.Message {
-	mi := &file_protocols_isis_api_isis_proto_msgTypes[9]
+func (x *RouterCapability) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_isis_api_isis_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -791,51 +1644,79 @@ func (x *IPv4NLRI) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IPv4NLRI.ProtoReflect.Descriptor instead.
-func (*IPv4NLRI) Descriptor() ([]byte, []int) {
-	return file_protocols_isis_api_isis_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use RouterCapability.ProtoReflect.Descriptor instead.
+func (*RouterCapability) Descriptor() ([]byte, []int) {
+	return file_protocols_isis_api_isis_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *IPv4NLRI) GetIpAddress() uint32 {
+func (x *RouterCapability) GetRouterId() uint32 {
 	if x != nil {
-		return x.IpAddress
+		return x.RouterId
 	}
 	return 0
 }
 
-func (x *IPv4NLRI) GetSubnetmask() uint32 {
+func (x *RouterCapability) GetFlags() uint32 {
 	if x != nil {
-		return x.Subnetmask
+		return x.Flags
 	}
 	return 0
 }
 
-type ExtendedISReachability struct {
+func (x *RouterCapability) GetSrCapabilitiesFlags() uint32 {
+	if x != nil {
+		return x.SrCapabilitiesFlags
+	}
+	return 0
+}
+
+func (x *RouterCapability) GetSrgb() []*LabelBlock {
+	if x != nil {
+		return x.Srgb
+	}
+	return nil
+}
+
+func (x *RouterCapability) GetSrAlgorithms() []uint32 {
+	if x != nil {
+		return x.SrAlgorithms
+	}
+	return nil
+}
+
+func (x *RouterCapability) GetSrlb() []*LabelBlock {
+	if x != nil {
+		return x.Srlb
+	}
+	return nil
+}
+
+type LabelBlock struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	DefaultMetric uint32 `protobuf:"varint,1,opt,name=default_metric,json=defaultMetric,proto3" json:"default_metric,omitempty"`
-	NeighborId    []byte `protobuf:"bytes,2,opt,name=neighbor_id,json=neighborId,proto3" json:"neighbor_id,omitempty"` // To be extended with sub-TLVs
+	Base uint32 `protobuf:"varint,1,opt,name=base,proto3" json:"base,omitempty"`
+	Size uint32 `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
 }
 
-func (x *ExtendedISReachability) Reset() {
-	*x = ExtendedISReachability{}
+func (x *LabelBlock) Reset() {
+	*x = LabelBlock{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protocols_isis_api_isis_proto_msgTypes[10]
+		mi := &file_protocols_isis_api_isis_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ExtendedISReachability) String() string {
+func (x *LabelBlock) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ExtendedISReachability) ProtoMessage() {}
+func (*LabelBlock) ProtoMessage() {}
 
-func (x *ExtendedISReachability) ProtoReflect() protoreflect.Message {
-	mi := &file_protocols_isis_api_isis_proto_msgTypes[10]
+func (x *LabelBlock) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_isis_api_isis_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -846,23 +1727,23 @@ func (x *ExtendedISReachability) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ExtendedISReachability.ProtoReflect.Descriptor instead.
-func (*ExtendedISReachability) Descriptor() ([]byte, []int) {
-	return file_protocols_isis_api_isis_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use LabelBlock.ProtoReflect.Descriptor instead.
+func (*LabelBlock) Descriptor() ([]byte, []int) {
+	return file_protocols_isis_api_isis_proto_rawDescGZIP(), []int{24}
 }
 
-func (x *ExtendedISReachability) GetDefaultMetric() uint32 {
+func (x *LabelBlock) GetBase() uint32 {
 	if x != nil {
-		return x.DefaultMetric
+		return x.Base
 	}
 	return 0
 }
 
-func (x *ExtendedISReachability) GetNeighborId() []byte {
+func (x *LabelBlock) GetSize() uint32 {
 	if x != nil {
-		return x.NeighborId
+		return x.Size
 	}
-	return nil
+	return 0
 }
 
 var File_protocols_isis_api_isis_proto protoreflect.FileDescriptor
@@ -921,7 +1802,7 @@ var file_protocols_isis_api_isis_proto_rawDesc = []byte{
 	0x0a, 0x18, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x5f, 0x77, 0x69, 0x74,
 	0x68, 0x5f, 0x73, 0x72, 0x6d, 0x5f, 0x66, 0x6c, 0x61, 0x67, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09,
 	0x52, 0x15, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x57, 0x69, 0x74, 0x68,
-	0x53, 0x72, 0x6d, 0x46, 0x6c, 0x61, 0x67, 0x22, 0xc7, 0x05, 0x0a, 0x05, 0x4c, 0x53, 0x50, 0x44,
+	0x53, 0x72, 0x6d, 0x46, 0x6c, 0x61, 0x67, 0x22, 0x90, 0x06, 0x0a, 0x05, 0x4c, 0x53, 0x50, 0x44,
 	0x55, 0x12, 0x26, 0x0a, 0x06, 0x6c, 0x73, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
 	0x0b, 0x32, 0x0f, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x69, 0x73, 0x69, 0x73, 0x2e, 0x4c, 0x53, 0x50,
 	0x49, 0x44, 0x52, 0x05, 0x6c, 0x73, 0x70, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x65, 0x6e,
@@ -964,43 +1845,170 @@ var file_protocols_isis_api_isis_proto_rawDesc = []byte{
 	0x0d, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x69, 0x70, 0x76, 0x34, 0x54, 0x65, 0x52, 0x6f, 0x75,
 	0x74, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d,
 	0x65, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d,
-	0x65, 0x22, 0x1e, 0x0a, 0x08, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x08, 0x0a,
-	0x04, 0x49, 0x50, 0x76, 0x34, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x49, 0x50, 0x76, 0x36, 0x10,
-	0x01, 0x22, 0x68, 0x0a, 0x05, 0x4c, 0x53, 0x50, 0x49, 0x44, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x79,
-	0x73, 0x74, 0x65, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x73,
-	0x79, 0x73, 0x74, 0x65, 0x6d, 0x49, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x73, 0x65, 0x75, 0x64,
-	0x6f, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c,
-	0x70, 0x73, 0x65, 0x75, 0x64, 0x6f, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a,
-	0x6c, 0x73, 0x70, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d,
-	0x52, 0x09, 0x6c, 0x73, 0x70, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x74, 0x0a, 0x16, 0x45,
-	0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x49, 0x50, 0x52, 0x65, 0x61, 0x63, 0x68, 0x61, 0x62,
-	0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x12, 0x23, 0x0a,
-	0x0d, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x4c, 0x65, 0x6e, 0x67,
-	0x74, 0x68, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x70, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x69, 0x70, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73,
-	0x73, 0x22, 0x49, 0x0a, 0x08, 0x49, 0x50, 0x76, 0x34, 0x4e, 0x4c, 0x52, 0x49, 0x12, 0x1d, 0x0a,
-	0x0a, 0x69, 0x70, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0d, 0x52, 0x09, 0x69, 0x70, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1e, 0x0a, 0x0a,
-	0x73, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x6d, 0x61, 0x73, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d,
-	0x52, 0x0a, 0x73, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x6d, 0x61, 0x73, 0x6b, 0x22, 0x60, 0x0a, 0x16,
-	0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x49, 0x53, 0x52, 0x65, 0x61, 0x63, 0x68, 0x61,
-	0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x25, 0x0a, 0x0e, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c,
-	0x74, 0x5f, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d,
-	0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x12, 0x1f, 0x0a,
-	0x0b, 0x6e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x0a, 0x6e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x49, 0x64, 0x32, 0xa9,
-	0x01, 0x0a, 0x0b, 0x49, 0x73, 0x69, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x58,
-	0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x64, 0x6a, 0x61, 0x63, 0x65, 0x6e, 0x63, 0x69, 0x65,
-	0x73, 0x12, 0x20, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x69, 0x73, 0x69, 0x73, 0x2e, 0x4c, 0x69, 0x73,
-	0x74, 0x41, 0x64, 0x6a, 0x61, 0x63, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x69, 0x73, 0x69, 0x73, 0x2e, 0x4c,
-	0x69, 0x73, 0x74, 0x41, 0x64, 0x6a, 0x61, 0x63, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x40, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x4c,
-	0x53, 0x44, 0x42, 0x12, 0x18, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x69, 0x73, 0x69, 0x73, 0x2e, 0x47,
-	0x65, 0x74, 0x4c, 0x53, 0x44, 0x42, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e,
-	0x62, 0x69, 0x6f, 0x2e, 0x69, 0x73, 0x69, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x53, 0x44, 0x42,
+	0x65, 0x12, 0x47, 0x0a, 0x11, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x5f, 0x63, 0x61, 0x70, 0x61,
+	0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x62,
+	0x69, 0x6f, 0x2e, 0x69, 0x73, 0x69, 0x73, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x43, 0x61,
+	0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x10, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72,
+	0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x22, 0x1e, 0x0a, 0x08, 0x50, 0x72,
+	0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x08, 0x0a, 0x04, 0x49, 0x50, 0x76, 0x34, 0x10, 0x00,
+	0x12, 0x08, 0x0a, 0x04, 0x49, 0x50, 0x76, 0x36, 0x10, 0x01, 0x22, 0x68, 0x0a, 0x05, 0x4c, 0x53,
+	0x50, 0x49, 0x44, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x49, 0x64,
+	0x12, 0x23, 0x0a, 0x0d, 0x70, 0x73, 0x65, 0x75, 0x64, 0x6f, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x70, 0x73, 0x65, 0x75, 0x64, 0x6f, 0x6e,
+	0x6f, 0x64, 0x65, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x73, 0x70, 0x5f, 0x6e, 0x75, 0x6d,
+	0x62, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x6c, 0x73, 0x70, 0x4e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x22, 0xa8, 0x01, 0x0a, 0x16, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65,
+	0x64, 0x49, 0x50, 0x52, 0x65, 0x61, 0x63, 0x68, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12,
+	0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x06, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x72, 0x65, 0x66, 0x69,
+	0x78, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c,
+	0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x1d, 0x0a, 0x0a,
+	0x69, 0x70, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x09, 0x69, 0x70, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x32, 0x0a, 0x0a, 0x70,
+	0x72, 0x65, 0x66, 0x69, 0x78, 0x5f, 0x73, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x13, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x69, 0x73, 0x69, 0x73, 0x2e, 0x50, 0x72, 0x65, 0x66, 0x69,
+	0x78, 0x53, 0x49, 0x44, 0x52, 0x09, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x53, 0x69, 0x64, 0x22,
+	0x49, 0x0a, 0x08, 0x49, 0x50, 0x76, 0x34, 0x4e, 0x4c, 0x52, 0x49, 0x12, 0x1d, 0x0a, 0x0a, 0x69,
+	0x70, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x09, 0x69, 0x70, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x75,
+	0x62, 0x6e, 0x65, 0x74, 0x6d, 0x61, 0x73, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a,
+	0x73, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x6d, 0x61, 0x73, 0x6b, 0x22, 0x9f, 0x01, 0x0a, 0x16, 0x45,
+	0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x49, 0x53, 0x52, 0x65, 0x61, 0x63, 0x68, 0x61, 0x62,
+	0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x25, 0x0a, 0x0e, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74,
+	0x5f, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x64,
+	0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x12, 0x1f, 0x0a, 0x0b,
+	0x6e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x0a, 0x6e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x49, 0x64, 0x12, 0x3d, 0x0a,
+	0x0e, 0x61, 0x64, 0x6a, 0x61, 0x63, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x73, 0x69, 0x64, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x69, 0x73, 0x69, 0x73,
+	0x2e, 0x41, 0x64, 0x6a, 0x61, 0x63, 0x65, 0x6e, 0x63, 0x79, 0x53, 0x49, 0x44, 0x52, 0x0d, 0x61,
+	0x64, 0x6a, 0x61, 0x63, 0x65, 0x6e, 0x63, 0x79, 0x53, 0x69, 0x64, 0x73, 0x22, 0x29, 0x0a, 0x11,
+	0x47, 0x65, 0x74, 0x53, 0x50, 0x46, 0x54, 0x72, 0x65, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x22, 0x46, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x53, 0x50,
+	0x46, 0x54, 0x72, 0x65, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x30, 0x0a,
+	0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16,
+	0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x69, 0x73, 0x69, 0x73, 0x2e, 0x53, 0x50, 0x46, 0x54, 0x72, 0x65,
+	0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x22,
+	0xdc, 0x01, 0x0a, 0x0c, 0x53, 0x50, 0x46, 0x54, 0x72, 0x65, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x1b, 0x0a, 0x09, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x08, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x49, 0x64, 0x12, 0x23, 0x0a,
+	0x0d, 0x70, 0x73, 0x65, 0x75, 0x64, 0x6f, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x70, 0x73, 0x65, 0x75, 0x64, 0x6f, 0x6e, 0x6f, 0x64, 0x65,
+	0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61,
+	0x72, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x07, 0x70, 0x61, 0x72,
+	0x65, 0x6e, 0x74, 0x73, 0x12, 0x2e, 0x0a, 0x09, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x68, 0x6f, 0x70,
+	0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x69, 0x73,
+	0x69, 0x73, 0x2e, 0x4e, 0x65, 0x78, 0x74, 0x48, 0x6f, 0x70, 0x52, 0x08, 0x6e, 0x65, 0x78, 0x74,
+	0x48, 0x6f, 0x70, 0x73, 0x12, 0x28, 0x0a, 0x04, 0x73, 0x72, 0x67, 0x62, 0x18, 0x06, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x69, 0x73, 0x69, 0x73, 0x2e, 0x4c, 0x61,
+	0x62, 0x65, 0x6c, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x04, 0x73, 0x72, 0x67, 0x62, 0x22, 0x74,
+	0x0a, 0x07, 0x4e, 0x65, 0x78, 0x74, 0x48, 0x6f, 0x70, 0x12, 0x25, 0x0a, 0x0e, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0d, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65,
+	0x12, 0x1b, 0x0a, 0x09, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x08, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x49, 0x64, 0x12, 0x25, 0x0a,
+	0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b,
+	0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x49, 0x50, 0x52, 0x07, 0x61, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x22, 0x28, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x75, 0x74, 0x65,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65,
+	0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x22, 0x3c,
+	0x0a, 0x11, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x27, 0x0a, 0x06, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x69, 0x73, 0x69, 0x73, 0x2e, 0x52,
+	0x6f, 0x75, 0x74, 0x65, 0x52, 0x06, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x22, 0xac, 0x01, 0x0a,
+	0x05, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x12, 0x27, 0x0a, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x6e, 0x65, 0x74,
+	0x2e, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x52, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12,
+	0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x06, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x12, 0x2e, 0x0a, 0x09, 0x6e, 0x65, 0x78, 0x74, 0x5f,
+	0x68, 0x6f, 0x70, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x62, 0x69, 0x6f,
+	0x2e, 0x69, 0x73, 0x69, 0x73, 0x2e, 0x4e, 0x65, 0x78, 0x74, 0x48, 0x6f, 0x70, 0x52, 0x08, 0x6e,
+	0x65, 0x78, 0x74, 0x48, 0x6f, 0x70, 0x73, 0x12, 0x32, 0x0a, 0x0a, 0x70, 0x72, 0x65, 0x66, 0x69,
+	0x78, 0x5f, 0x73, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x62, 0x69,
+	0x6f, 0x2e, 0x69, 0x73, 0x69, 0x73, 0x2e, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x53, 0x49, 0x44,
+	0x52, 0x09, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x53, 0x69, 0x64, 0x22, 0x22, 0x0a, 0x20, 0x47,
+	0x65, 0x74, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22,
+	0x7e, 0x0a, 0x21, 0x47, 0x65, 0x74, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x59, 0x0a, 0x17, 0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x69, 0x73, 0x69, 0x73,
+	0x2e, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x46,
+	0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x52, 0x16, 0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74,
+	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x22,
+	0xa5, 0x01, 0x0a, 0x16, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x69, 0x6e,
+	0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0d, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x4e, 0x61, 0x6d,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x68, 0x65, 0x6c, 0x6c, 0x6f,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x68, 0x65, 0x6c, 0x6c, 0x6f, 0x12, 0x10, 0x0a,
+	0x03, 0x6c, 0x73, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x6c, 0x73, 0x70, 0x12,
+	0x12, 0x0a, 0x04, 0x63, 0x73, 0x6e, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x63,
+	0x73, 0x6e, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x73, 0x6e, 0x70, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x04, 0x70, 0x73, 0x6e, 0x70, 0x22, 0x51, 0x0a, 0x09, 0x50, 0x72, 0x65, 0x66, 0x69,
+	0x78, 0x53, 0x49, 0x44, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x6c,
+	0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x61,
+	0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x69, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x73, 0x69, 0x64, 0x22, 0x7c, 0x0a, 0x0c, 0x41, 0x64,
+	0x6a, 0x61, 0x63, 0x65, 0x6e, 0x63, 0x79, 0x53, 0x49, 0x44, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6c,
+	0x61, 0x67, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73,
+	0x12, 0x16, 0x0a, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x2c, 0x0a, 0x12, 0x6e, 0x65, 0x69, 0x67,
+	0x68, 0x62, 0x6f, 0x72, 0x5f, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x10, 0x6e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x53, 0x79,
+	0x73, 0x74, 0x65, 0x6d, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x69, 0x64, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x03, 0x73, 0x69, 0x64, 0x22, 0xf2, 0x01, 0x0a, 0x10, 0x52, 0x6f, 0x75,
+	0x74, 0x65, 0x72, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x1b, 0x0a,
+	0x09, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x08, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6c,
+	0x61, 0x67, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73,
+	0x12, 0x32, 0x0a, 0x15, 0x73, 0x72, 0x5f, 0x63, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74,
+	0x69, 0x65, 0x73, 0x5f, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x13, 0x73, 0x72, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x46,
+	0x6c, 0x61, 0x67, 0x73, 0x12, 0x28, 0x0a, 0x04, 0x73, 0x72, 0x67, 0x62, 0x18, 0x04, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x69, 0x73, 0x69, 0x73, 0x2e, 0x4c, 0x61,
+	0x62, 0x65, 0x6c, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x04, 0x73, 0x72, 0x67, 0x62, 0x12, 0x23,
+	0x0a, 0x0d, 0x73, 0x72, 0x5f, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x73, 0x18,
+	0x05, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x0c, 0x73, 0x72, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74,
+	0x68, 0x6d, 0x73, 0x12, 0x28, 0x0a, 0x04, 0x73, 0x72, 0x6c, 0x62, 0x18, 0x06, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x14, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x69, 0x73, 0x69, 0x73, 0x2e, 0x4c, 0x61, 0x62,
+	0x65, 0x6c, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x04, 0x73, 0x72, 0x6c, 0x62, 0x22, 0x34, 0x0a,
+	0x0a, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x62,
+	0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x73,
+	0x69, 0x7a, 0x65, 0x32, 0xb4, 0x03, 0x0a, 0x0b, 0x49, 0x73, 0x69, 0x73, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x12, 0x58, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x64, 0x6a, 0x61, 0x63,
+	0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x12, 0x20, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x69, 0x73, 0x69,
+	0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x64, 0x6a, 0x61, 0x63, 0x65, 0x6e, 0x63, 0x69, 0x65,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x69,
+	0x73, 0x69, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x64, 0x6a, 0x61, 0x63, 0x65, 0x6e, 0x63,
+	0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x40, 0x0a,
+	0x07, 0x47, 0x65, 0x74, 0x4c, 0x53, 0x44, 0x42, 0x12, 0x18, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x69,
+	0x73, 0x69, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x53, 0x44, 0x42, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x19, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x69, 0x73, 0x69, 0x73, 0x2e, 0x47, 0x65,
+	0x74, 0x4c, 0x53, 0x44, 0x42, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x49, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x53, 0x50, 0x46, 0x54, 0x72, 0x65, 0x65, 0x12, 0x1b, 0x2e,
+	0x62, 0x69, 0x6f, 0x2e, 0x69, 0x73, 0x69, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x50, 0x46, 0x54,
+	0x72, 0x65, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x62, 0x69, 0x6f,
+	0x2e, 0x69, 0x73, 0x69, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x50, 0x46, 0x54, 0x72, 0x65, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x46, 0x0a, 0x09, 0x47, 0x65,
+	0x74, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x12, 0x1a, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x69, 0x73,
+	0x69, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x69, 0x73, 0x69, 0x73, 0x2e, 0x47,
+	0x65, 0x74, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x76, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74,
+	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x12,
+	0x2a, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x69, 0x73, 0x69, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x75,
+	0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x61, 0x69, 0x6c,
+	0x75, 0x72, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x62, 0x69,
+	0x6f, 0x2e, 0x69, 0x73, 0x69, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e,
+	0x74, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73,
 	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x32, 0x5a, 0x30, 0x67, 0x69,
 	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x62, 0x69, 0x6f, 0x2d, 0x72, 0x6f, 0x75,
 	0x74, 0x69, 0x6e, 0x67, 0x2f, 0x62, 0x69, 0x6f, 0x2d, 0x72, 0x64, 0x2f, 0x70, 0x72, 0x6f, 0x74,
@@ -1021,26 +2029,41 @@ func file_protocols_isis_api_isis_proto_rawDescGZIP() []byte {
 }
 
 var file_protocols_isis_api_isis_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_protocols_isis_api_isis_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_protocols_isis_api_isis_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
 var file_protocols_isis_api_isis_proto_goTypes = []interface{}{
-	(Adjacency_State)(0),            // 0: bio.isis.Adjacency.State
-	(LSPDU_Protocol)(0),             // 1: bio.isis.LSPDU.Protocol
-	(*ListAdjacenciesRequest)(nil),  // 2: bio.isis.ListAdjacenciesRequest
-	(*ListAdjacenciesResponse)(nil), // 3: bio.isis.ListAdjacenciesResponse
-	(*Adjacency)(nil),               // 4: bio.isis.Adjacency
-	(*GetLSDBRequest)(nil),          // 5: bio.isis.GetLSDBRequest
-	(*GetLSDBResponse)(nil),         // 6: bio.isis.GetLSDBResponse
-	(*LSDBEntry)(nil),               // 7: bio.isis.LSDBEntry
-	(*LSPDU)(nil),                   // 8: bio.isis.LSPDU
-	(*LSPID)(nil),                   // 9: bio.isis.LSPID
-	(*ExtendedIPReachability)(nil),  // 10: bio.isis.ExtendedIPReachability
-	(*IPv4NLRI)(nil),                // 11: bio.isis.IPv4NLRI
-	(*ExtendedISReachability)(nil),  // 12: bio.isis.ExtendedISReachability
-	(*api.IP)(nil),                  // 13: bio.net.IP
+	(Adjacency_State)(0),                      // 0: bio.isis.Adjacency.State
+	(LSPDU_Protocol)(0),                       // 1: bio.isis.LSPDU.Protocol
+	(*ListAdjacenciesRequest)(nil),            // 2: bio.isis.ListAdjacenciesRequest
+	(*ListAdjacenciesResponse)(nil),           // 3: bio.isis.ListAdjacenciesResponse
+	(*Adjacency)(nil),                         // 4: bio.isis.Adjacency
+	(*GetLSDBRequest)(nil),                    // 5: bio.isis.GetLSDBRequest
+	(*GetLSDBResponse)(nil),                   // 6: bio.isis.GetLSDBResponse
+	(*LSDBEntry)(nil),                         // 7: bio.isis.LSDBEntry
+	(*LSPDU)(nil),                             // 8: bio.isis.LSPDU
+	(*LSPID)(nil),                             // 9: bio.isis.LSPID
+	(*ExtendedIPReachability)(nil),            // 10: bio.isis.ExtendedIPReachability
+	(*IPv4NLRI)(nil),                          // 11: bio.isis.IPv4NLRI
+	(*ExtendedISReachability)(nil),            // 12: bio.isis.ExtendedISReachability
+	(*GetSPFTreeRequest)(nil),                 // 13: bio.isis.GetSPFTreeRequest
+	(*GetSPFTreeResponse)(nil),                // 14: bio.isis.GetSPFTreeResponse
+	(*SPFTreeEntry)(nil),                      // 15: bio.isis.SPFTreeEntry
+	(*NextHop)(nil),                           // 16: bio.isis.NextHop
+	(*GetRoutesRequest)(nil),                  // 17: bio.isis.GetRoutesRequest
+	(*GetRoutesResponse)(nil),                 // 18: bio.isis.GetRoutesResponse
+	(*Route)(nil),                             // 19: bio.isis.Route
+	(*GetAuthenticationFailuresRequest)(nil),  // 20: bio.isis.GetAuthenticationFailuresRequest
+	(*GetAuthenticationFailuresResponse)(nil), // 21: bio.isis.GetAuthenticationFailuresResponse
+	(*AuthenticationFailures)(nil),            // 22: bio.isis.AuthenticationFailures
+	(*PrefixSID)(nil),                         // 23: bio.isis.PrefixSID
+	(*AdjacencySID)(nil),                      // 24: bio.isis.AdjacencySID
+	(*RouterCapability)(nil),                  // 25: bio.isis.RouterCapability
+	(*LabelBlock)(nil),                        // 26: bio.isis.LabelBlock
+	(*api.IP)(nil),                            // 27: bio.net.IP
+	(*api.Prefix)(nil),                        // 28: bio.net.Prefix
 }
 var file_protocols_isis_api_isis_proto_depIdxs = []int32{
 	4,  // 0: bio.isis.ListAdjacenciesResponse.adjacencies:type_name -> bio.isis.Adjacency
-	13, // 1: bio.isis.Adjacency.ip_addresses:type_name -> bio.net.IP
+	27, // 1: bio.isis.Adjacency.ip_addresses:type_name -> bio.net.IP
 	0,  // 2: bio.isis.Adjacency.status:type_name -> bio.isis.Adjacency.State
 	7,  // 3: bio.isis.GetLSDBResponse.lsdb_entries:type_name -> bio.isis.LSDBEntry
 	8,  // 4: bio.isis.LSDBEntry.lsp:type_name -> bio.isis.LSPDU
@@ -1048,15 +2071,35 @@ var file_protocols_isis_api_isis_proto_depIdxs = []int32{
 	1,  // 6: bio.isis.LSPDU.protocols_supported:type_name -> bio.isis.LSPDU.Protocol
 	12, // 7: bio.isis.LSPDU.extended_is_reachabilities:type_name -> bio.isis.ExtendedISReachability
 	10, // 8: bio.isis.LSPDU.extended_ip_reachabilities:type_name -> bio.isis.ExtendedIPReachability
-	2,  // 9: bio.isis.IsisService.ListAdjacencies:input_type -> bio.isis.ListAdjacenciesRequest
-	5,  // 10: bio.isis.IsisService.GetLSDB:input_type -> bio.isis.GetLSDBRequest
-	3,  // 11: bio.isis.IsisService.ListAdjacencies:output_type -> bio.isis.ListAdjacenciesResponse
-	6,  // 12: bio.isis.IsisService.GetLSDB:output_type -> bio.isis.GetLSDBResponse
-	11, // [11:13] is the sub-list for method output_type
-	9,  // [9:11] is the sub-list for method input_type
-	9,  // [9:9] is the sub-list for extension type_name
-	9,  // [9:9] is the sub-list for extension extendee
-	0,  // [0:9] is the sub-list for field type_name
+	25, // 9: bio.isis.LSPDU.router_capability:type_name -> bio.isis.RouterCapability
+	23, // 10: bio.isis.ExtendedIPReachability.prefix_sid:type_name -> bio.isis.PrefixSID
+	24, // 11: bio.isis.ExtendedISReachability.adjacency_sids:type_name -> bio.isis.AdjacencySID
+	15, // 12: bio.isis.GetSPFTreeResponse.entries:type_name -> bio.isis.SPFTreeEntry
+	16, // 13: bio.isis.SPFTreeEntry.next_hops:type_name -> bio.isis.NextHop
+	26, // 14: bio.isis.SPFTreeEntry.srgb:type_name -> bio.isis.LabelBlock
+	27, // 15: bio.isis.NextHop.address:type_name -> bio.net.IP
+	19, // 16: bio.isis.GetRoutesResponse.routes:type_name -> bio.isis.Route
+	28, // 17: bio.isis.Route.prefix:type_name -> bio.net.Prefix
+	16, // 18: bio.isis.Route.next_hops:type_name -> bio.isis.NextHop
+	23, // 19: bio.isis.Route.prefix_sid:type_name -> bio.isis.PrefixSID
+	22, // 20: bio.isis.GetAuthenticationFailuresResponse.authentication_failures:type_name -> bio.isis.AuthenticationFailures
+	26, // 21: bio.isis.RouterCapability.srgb:type_name -> bio.isis.LabelBlock
+	26, // 22: bio.isis.RouterCapability.srlb:type_name -> bio.isis.LabelBlock
+	2,  // 23: bio.isis.IsisService.ListAdjacencies:input_type -> bio.isis.ListAdjacenciesRequest
+	5,  // 24: bio.isis.IsisService.GetLSDB:input_type -> bio.isis.GetLSDBRequest
+	13, // 25: bio.isis.IsisService.GetSPFTree:input_type -> bio.isis.GetSPFTreeRequest
+	17, // 26: bio.isis.IsisService.GetRoutes:input_type -> bio.isis.GetRoutesRequest
+	20, // 27: bio.isis.IsisService.GetAuthenticationFailures:input_type -> bio.isis.GetAuthenticationFailuresRequest
+	3,  // 28: bio.isis.IsisService.ListAdjacencies:output_type -> bio.isis.ListAdjacenciesResponse
+	6,  // 29: bio.isis.IsisService.GetLSDB:output_type -> bio.isis.GetLSDBResponse
+	14, // 30: bio.isis.IsisService.GetSPFTree:output_type -> bio.isis.GetSPFTreeResponse
+	18, // 31: bio.isis.IsisService.GetRoutes:output_type -> bio.isis.GetRoutesResponse
+	21, // 32: bio.isis.IsisService.GetAuthenticationFailures:output_type -> bio.isis.GetAuthenticationFailuresResponse
+	28, // [28:33] is the sub-list for method output_type
+	23, // [23:28] is the sub-list for method input_type
+	23, // [23:23] is the sub-list for extension type_name
+	23, // [23:23] is the sub-list for extension extendee
+	0,  // [0:23] is the sub-list for field type_name
 }
 
 func init() { file_protocols_isis_api_isis_proto_init() }
@@ -1197,6 +2240,174 @@ func file_protocols_isis_api_isis_proto_init() {
 				return nil
 			}
 		}
+		file_protocols_isis_api_isis_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSPFTreeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocols_isis_api_isis_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSPFTreeResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocols_isis_api_isis_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SPFTreeEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocols_isis_api_isis_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NextHop); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocols_isis_api_isis_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetRoutesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocols_isis_api_isis_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetRoutesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocols_isis_api_isis_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Route); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocols_isis_api_isis_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetAuthenticationFailuresRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocols_isis_api_isis_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetAuthenticationFailuresResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocols_isis_api_isis_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AuthenticationFailures); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocols_isis_api_isis_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PrefixSID); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocols_isis_api_isis_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AdjacencySID); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocols_isis_api_isis_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RouterCapability); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocols_isis_api_isis_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LabelBlock); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1204,7 +2415,7 @@ func file_protocols_isis_api_isis_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_protocols_isis_api_isis_proto_rawDesc,
 			NumEnums:      2,
-			NumMessages:   11,
+			NumMessages:   25,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -20,6 +20,9 @@ const _ = grpc.SupportPackageIsVersion7
 type IsisServiceClient interface {
 	ListAdjacencies(ctx context.Context, in *ListAdjacenciesRequest, opts ...grpc.CallOption) (*ListAdjacenciesResponse, error)
 	GetLSDB(ctx context.Context, in *GetLSDBRequest, opts ...grpc.CallOption) (*GetLSDBResponse, error)
+	GetSPFTree(ctx context.Context, in *GetSPFTreeRequest, opts ...grpc.CallOption) (*GetSPFTreeResponse, error)
+	GetRoutes(ctx context.Context, in *GetRoutesRequest, opts ...grpc.CallOption) (*GetRoutesResponse, error)
+	GetAuthenticationFailures(ctx context.Context, in *GetAuthenticationFailuresRequest, opts ...grpc.CallOption) (*GetAuthenticationFailuresResponse, error)
 }
 
 type isisServiceClient struct {
@@ -48,12 +51,42 @@ func (c *isisServiceClient) GetLSDB(ctx context.Context, in *GetLSDBRequest, opt
 	return out, nil
 }
 
+func (c *isisServiceClient) GetSPFTree(ctx context.Context, in *GetSPFTreeRequest, opts ...grpc.CallOption) (*GetSPFTreeResponse, error) {
+	out := new(GetSPFTreeResponse)
+	err := c.cc.Invoke(ctx, "/bio.isis.IsisService/GetSPFTree", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *isisServiceClient) GetRoutes(ctx context.Context, in *GetRoutesRequest, opts ...grpc.CallOption) (*GetRoutesResponse, error) {
+	out := new(GetRoutesResponse)
+	err := c.cc.Invoke(ctx, "/bio.isis.IsisService/GetRoutes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *isisServiceClient) GetAuthenticationFailures(ctx context.Context, in *GetAuthenticationFailuresRequest, opts ...grpc.CallOption) (*GetAuthenticationFailuresResponse, error) {
+	out := new(GetAuthenticationFailuresResponse)
+	err := c.cc.Invoke(ctx, "/bio.isis.IsisService/GetAuthenticationFailures", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // IsisServiceServer is the server API for IsisService service.
 // All implementations must embed UnimplementedIsisServiceServer
 // for forward compatibility
 type IsisServiceServer interface {
 	ListAdjacencies(context.Context, *ListAdjacenciesRequest) (*ListAdjacenciesResponse, error)
 	GetLSDB(context.Context, *GetLSDBRequest) (*GetLSDBResponse, error)
+	GetSPFTree(context.Context, *GetSPFTreeRequest) (*GetSPFTreeResponse, error)
+	GetRoutes(context.Context, *GetRoutesRequest) (*GetRoutesResponse, error)
+	GetAuthenticationFailures(context.Context, *GetAuthenticationFailuresRequest) (*GetAuthenticationFailuresResponse, error)
 	mustEmbedUnimplementedIsisServiceServer()
 }
 
@@ -67,6 +100,15 @@ func (UnimplementedIsisServiceServer) ListAdjacencies(context.Context, *ListAdja
 func (UnimplementedIsisServiceServer) GetLSDB(context.Context, *GetLSDBRequest) (*GetLSDBResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetLSDB not implemented")
 }
+func (UnimplementedIsisServiceServer) GetSPFTree(context.Context, *GetSPFTreeRequest) (*GetSPFTreeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSPFTree not implemented")
+}
+func (UnimplementedIsisServiceServer) GetRoutes(context.Context, *GetRoutesRequest) (*GetRoutesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRoutes not implemented")
+}
+func (UnimplementedIsisServiceServer) GetAuthenticationFailures(context.Context, *GetAuthenticationFailuresRequest) (*GetAuthenticationFailuresResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAuthenticationFailures not implemented")
+}
 func (UnimplementedIsisServiceServer) mustEmbedUnimplementedIsisServiceServer() {}
 
 // UnsafeIsisServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -116,6 +158,60 @@ func _IsisService_GetLSDB_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _IsisService_GetSPFTree_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSPFTreeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IsisServiceServer).GetSPFTree(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bio.isis.IsisService/GetSPFTree",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IsisServiceServer).GetSPFTree(ctx, req.(*GetSPFTreeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IsisService_GetRoutes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRoutesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IsisServiceServer).GetRoutes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bio.isis.IsisService/GetRoutes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IsisServiceServer).GetRoutes(ctx, req.(*GetRoutesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IsisService_GetAuthenticationFailures_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAuthenticationFailuresRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IsisServiceServer).GetAuthenticationFailures(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bio.isis.IsisService/GetAuthenticationFailures",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IsisServiceServer).GetAuthenticationFailures(ctx, req.(*GetAuthenticationFailuresRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // IsisService_ServiceDesc is the grpc.ServiceDesc for IsisService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -131,6 +227,18 @@ var IsisService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetLSDB",
 			Handler:    _IsisService_GetLSDB_Handler,
 		},
+		{
+			MethodName: "GetSPFTree",
+			Handler:    _IsisService_GetSPFTree_Handler,
+		},
+		{
+			MethodName: "GetRoutes",
+			Handler:    _IsisService_GetRoutes_Handler,
+		},
+		{
+			MethodName: "GetAuthenticationFailures",
+			Handler:    _IsisService_GetAuthenticationFailures_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "protocols/isis/api/isis.proto",
@@ -0,0 +1,153 @@
+package packet
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"fmt"
+	"hash"
+
+	"github.com/bio-routing/tflow2/convert"
+)
+
+const (
+	md5DigestLen = 16
+
+	helloPDULengthOffset = HeaderLen + 9 // Circuit Type + Source ID + Holding Time
+	lspLifetimeOffset    = HeaderLen + 2
+	lspChecksumOffset    = HeaderLen + 16
+)
+
+// apad is the value the digest is set to while computing generic cryptographic authentication digests (RFC5310)
+var apad = []byte{0x87, 0x8f, 0xe1, 0xf3}
+
+// pduLength gets the length of a serialized PDU (starting with the common header) from its PDU length field
+func pduLength(pdu []byte) (int, error) {
+	if len(pdu) < HeaderLen {
+		return 0, fmt.Errorf("PDU too short")
+	}
+
+	offset := HeaderLen
+	switch pdu[4] {
+	case P2P_HELLO, L1_LAN_HELLO_TYPE, L2_LAN_HELLO_TYPE:
+		offset = helloPDULengthOffset
+	case L1_LS_PDU_TYPE, L2_LS_PDU_TYPE, L1_CSNP_TYPE, L2_CSNP_TYPE, L1_PSNP_TYPE, L2_PSNP_TYPE:
+	default:
+		return 0, fmt.Errorf("unknown PDU type %d", pdu[4])
+	}
+
+	if len(pdu) < offset+2 {
+		return 0, fmt.Errorf("PDU too short")
+	}
+
+	l := int(convert.Uint16b(pdu[offset : offset+2]))
+	if l > len(pdu) {
+		return 0, fmt.Errorf("PDU length %d exceeds received length %d", l, len(pdu))
+	}
+
+	return l, nil
+}
+
+// findAuthenticationTLV gets the offset and the contents of the first authentication TLV of a serialized PDU
+func findAuthenticationTLV(pdu []byte) (int, *AuthenticationTLV, error) {
+	l, err := pduLength(pdu)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	pdu = pdu[:l]
+	for offset := int(pdu[1]); offset+tlvBaseLen <= len(pdu); offset += tlvBaseLen + int(pdu[offset+1]) {
+		if pdu[offset] != AuthenticationTLVType {
+			continue
+		}
+
+		tlvLen := pdu[offset+1]
+		if offset+tlvBaseLen+int(tlvLen) > len(pdu) {
+			return 0, nil, fmt.Errorf("authentication TLV exceeds PDU")
+		}
+
+		tlv, err := readAuthenticationTLV(bytes.NewBuffer(pdu[offset+tlvBaseLen:offset+tlvBaseLen+int(tlvLen)]), AuthenticationTLVType, tlvLen)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		return offset, tlv, nil
+	}
+
+	return 0, nil, nil
+}
+
+// GetAuthenticationTLV gets the authentication TLV of a serialized PDU (starting with the common header).
+// Returns nil if the PDU carries no authentication TLV.
+func GetAuthenticationTLV(pdu []byte) (*AuthenticationTLV, error) {
+	_, tlv, err := findAuthenticationTLV(pdu)
+	return tlv, err
+}
+
+// HMACDigest computes the digest of a serialized PDU (starting with the common header) carrying an HMAC-MD5 (RFC5304)
+// or a generic cryptographic (RFC5310) authentication TLV. Remaining lifetime and checksum of LSPs are not covered.
+func HMACDigest(pdu []byte, h func() hash.Hash, key []byte) ([]byte, error) {
+	offset, tlv, err := findAuthenticationTLV(pdu)
+	if err != nil {
+		return nil, err
+	}
+
+	if tlv == nil {
+		return nil, fmt.Errorf("PDU has no authentication TLV")
+	}
+
+	if len(tlv.AuthenticationData) != h().Size() {
+		return nil, fmt.Errorf("digest length %d does not match hash size %d", len(tlv.AuthenticationData), h().Size())
+	}
+
+	l, _ := pduLength(pdu)
+	p := make([]byte, l)
+	copy(p, pdu)
+
+	if p[4] == L1_LS_PDU_TYPE || p[4] == L2_LS_PDU_TYPE {
+		copy(p[lspLifetimeOffset:], []byte{0, 0})
+		copy(p[lspChecksumOffset:], []byte{0, 0})
+	}
+
+	valueOffset := offset + tlvBaseLen + 1
+	switch tlv.AuthenticationType {
+	case AuthenticationTypeHMACMD5:
+		for i := range tlv.AuthenticationData {
+			p[valueOffset+i] = 0
+		}
+	case AuthenticationTypeGenericCrypto:
+		valueOffset += keyIDLen
+		for i := range tlv.AuthenticationData {
+			p[valueOffset+i] = apad[i%len(apad)]
+		}
+
+		// Keys longer than the hash size are hashed first (RFC5310 section 3.3)
+		if len(key) > h().Size() {
+			kh := h()
+			kh.Write(key)
+			key = kh.Sum(nil)
+		}
+	default:
+		return nil, fmt.Errorf("authentication type %d is not HMAC based", tlv.AuthenticationType)
+	}
+
+	mac := hmac.New(h, key)
+	mac.Write(p)
+	return mac.Sum(nil), nil
+}
+
+// SetHMACDigest computes the digest of a serialized PDU (starting with the common header) and writes it into its authentication TLV
+func SetHMACDigest(pdu []byte, h func() hash.Hash, key []byte) error {
+	digest, err := HMACDigest(pdu, h, key)
+	if err != nil {
+		return err
+	}
+
+	offset, tlv, _ := findAuthenticationTLV(pdu)
+	valueOffset := offset + tlvBaseLen + 1
+	if tlv.AuthenticationType == AuthenticationTypeGenericCrypto {
+		valueOffset += keyIDLen
+	}
+
+	copy(pdu[valueOffset:], digest)
+	return nil
+}
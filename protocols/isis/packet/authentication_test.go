@@ -0,0 +1,139 @@
+package packet
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/bio-routing/bio-rd/protocols/isis/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func testSerializePDU(pduType uint8, lengthIndicator uint8, pdu Serializable) []byte {
+	buf := bytes.NewBuffer(nil)
+	hdr := ISISHeader{
+		ProtoDiscriminator:  0x83,
+		LengthIndicator:     lengthIndicator,
+		ProtocolIDExtension: 1,
+		PDUType:             pduType,
+		Version:             1,
+	}
+	hdr.Serialize(buf)
+	pdu.Serialize(buf)
+
+	return buf.Bytes()
+}
+
+func testPSNP(authTLV *AuthenticationTLV) []byte {
+	psnp := newPSNP(types.SourceID{SystemID: types.SystemID{1, 2, 3, 4, 5, 6}}, []*LSPEntry{
+		{
+			SequenceNumber:    10,
+			RemainingLifetime: 1200,
+			LSPChecksum:       0x1234,
+			LSPID: LSPID{
+				SystemID: types.SystemID{10, 20, 30, 40, 50, 60},
+			},
+		},
+	})
+	psnp.AddTLV(authTLV)
+
+	return testSerializePDU(L2_PSNP_TYPE, PSNPMinLen, psnp)
+}
+
+func testLSP(authTLV *AuthenticationTLV, remainingLifetime uint16) []byte {
+	lsp := &LSPDU{
+		RemainingLifetime: remainingLifetime,
+		LSPID: LSPID{
+			SystemID: types.SystemID{10, 20, 30, 40, 50, 60},
+		},
+		SequenceNumber: 5,
+		TypeBlock:      TypeBlockISTypeL1L2,
+		TLVs: []TLV{
+			NewDynamicHostnameTLV([]byte("foo")),
+			authTLV,
+		},
+	}
+	lsp.UpdateLength()
+	lsp.SetChecksum()
+
+	return testSerializePDU(L2_LS_PDU_TYPE, LSPDUMinLen, lsp)
+}
+
+func TestHMACDigest(t *testing.T) {
+	key := []byte("secret")
+
+	// RFC5304: digest is computed with the authentication value set to zero
+	pdu := testPSNP(NewHMACMD5AuthenticationTLV())
+	mac := hmac.New(md5.New, key)
+	mac.Write(pdu)
+	expectedMD5 := mac.Sum(nil)
+
+	err := SetHMACDigest(pdu, md5.New, key)
+	assert.NoError(t, err)
+
+	tlv, err := GetAuthenticationTLV(pdu)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedMD5, tlv.AuthenticationData)
+
+	digest, err := HMACDigest(pdu, md5.New, key)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedMD5, digest, "digest must not depend on the contained digest")
+
+	digest, err = HMACDigest(pdu, md5.New, []byte("wrong"))
+	assert.NoError(t, err)
+	assert.NotEqual(t, expectedMD5, digest)
+
+	// Ethernet padding must not be covered
+	digest, err = HMACDigest(append(pdu, 0, 0, 0, 0), md5.New, key)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedMD5, digest)
+
+	// RFC5310: digest is computed with the authentication value set to Apad
+	pdu = testPSNP(NewGenericCryptoAuthenticationTLV(7, sha256.Size))
+	apadPDU := make([]byte, len(pdu))
+	copy(apadPDU, pdu)
+	for i := 0; i < sha256.Size; i++ {
+		apadPDU[len(apadPDU)-sha256.Size+i] = apad[i%len(apad)]
+	}
+
+	mac = hmac.New(sha256.New, key)
+	mac.Write(apadPDU)
+	expectedSHA := mac.Sum(nil)
+
+	err = SetHMACDigest(pdu, sha256.New, key)
+	assert.NoError(t, err)
+
+	tlv, err = GetAuthenticationTLV(pdu)
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(7), tlv.KeyID)
+	assert.Equal(t, expectedSHA, tlv.AuthenticationData)
+
+	_, err = HMACDigest(pdu, md5.New, key)
+	assert.Error(t, err, "digest length mismatch")
+
+	_, err = HMACDigest(testPSNP(NewCleartextAuthenticationTLV(key)), md5.New, key)
+	assert.Error(t, err, "cleartext")
+}
+
+func TestHMACDigestLSP(t *testing.T) {
+	key := []byte("secret")
+
+	a, err := HMACDigest(testLSP(NewHMACMD5AuthenticationTLV(), 1200), md5.New, key)
+	assert.NoError(t, err)
+
+	b, err := HMACDigest(testLSP(NewHMACMD5AuthenticationTLV(), 100), md5.New, key)
+	assert.NoError(t, err)
+
+	assert.Equal(t, a, b, "remaining lifetime and checksum must not be covered")
+}
+
+func TestGetAuthenticationTLV(t *testing.T) {
+	tlv, err := GetAuthenticationTLV(testSerializePDU(L2_PSNP_TYPE, PSNPMinLen, newPSNP(types.SourceID{}, []*LSPEntry{{}})))
+	assert.NoError(t, err)
+	assert.Nil(t, tlv)
+
+	_, err = GetAuthenticationTLV([]byte{0x83, 17, 1, 0, L2_PSNP_TYPE, 1, 0, 0, 0xff, 0xff})
+	assert.Error(t, err, "PDU length exceeding the received data")
+}
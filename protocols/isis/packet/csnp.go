@@ -106,6 +106,12 @@ func newCSNP(sourceID types.SourceID, startLSPID LSPID, endLSPID LSPID, tlvs []T
 	return &csnp
 }
 
+// AddTLV adds a TLV to the CSNP and updates its PDU length
+func (c *CSNP) AddTLV(tlv TLV) {
+	c.TLVs = append(c.TLVs, tlv)
+	c.PDULength += uint16(tlv.Length()) + tlvBaseLen
+}
+
 // GetLSPEntries returns LSP Entries from the LSP Entries TLV
 func (c *CSNP) GetLSPEntries() []*LSPEntry {
 	return getLSPEntries(c.TLVs)
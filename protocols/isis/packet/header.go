@@ -9,6 +9,9 @@ import (
 
 const (
 	HeaderLen = 8
+
+	// LLCHeaderLen is the length of the 802.2 LLC header preceding received PDUs
+	LLCHeaderLen = 3
 )
 
 // ISISHeader represents an ISIS header
@@ -9,14 +9,14 @@ import (
 	"github.com/bio-routing/tflow2/convert"
 )
 
-// L2Hello represents a broadcast L2 hello
-type L2Hello struct {
+// LANHello represents a broadcast (LAN) L1 or L2 hello
+type LANHello struct {
 	CircuitType  uint8
-	SystemID     [6]byte
+	SystemID     types.SystemID
 	HoldingTimer uint16
 	PDULength    uint16
 	Priority     uint8
-	DesignatedIS [6]byte
+	DesignatedIS types.SourceID // LAN ID
 	TLVs         []TLV
 }
 
@@ -32,8 +32,13 @@ type P2PHello struct {
 
 const (
 	P2PHelloMinLen = 20
+	LANHelloMinLen = 27
 	ISISHeaderLen  = 8
 	L2CircuitType  = 2
+
+	// MaxLANPriority is the highest priority for DIS election
+	MaxLANPriority       = 127
+	lanHelloPriorityMask = 0x7f
 )
 
 // GetProtocolsSupportedTLV gets the protocols supported TLV
@@ -87,6 +92,32 @@ func (h *P2PHello) GetIPInterfaceAddressesesTLV() *IPInterfaceAddressesTLV {
 	return nil
 }
 
+// GetIPv6InterfaceAddressesTLV gets the IPv6 Interface Addresses TLV
+func (h *P2PHello) GetIPv6InterfaceAddressesTLV() *IPv6InterfaceAddressesTLV {
+	for _, tlv := range h.TLVs {
+		if tlv.Type() != IPv6InterfaceAddressesTLVType {
+			continue
+		}
+
+		return tlv.(*IPv6InterfaceAddressesTLV)
+	}
+
+	return nil
+}
+
+// GetMultiTopologyTLV gets the Multi-Topology TLV
+func (h *P2PHello) GetMultiTopologyTLV() *MultiTopologyTLV {
+	for _, tlv := range h.TLVs {
+		if tlv.Type() != MultiTopologyTLVType {
+			continue
+		}
+
+		return tlv.(*MultiTopologyTLV)
+	}
+
+	return nil
+}
+
 // Serialize serializes a P2P Hello
 func (h *P2PHello) Serialize(buf *bytes.Buffer) {
 	tlvsLen := uint16(0)
@@ -132,18 +163,115 @@ func DecodeP2PHello(buf *bytes.Buffer) (*P2PHello, error) {
 	return pdu, nil
 }
 
-// DecodeL2Hello decodes an ISIS broadcast L2 hello
-func DecodeL2Hello(buf *bytes.Buffer) (*L2Hello, error) {
-	pdu := &L2Hello{}
-	reserved := uint8(0)
+// GetISNeighborsTLV gets the IS Neighbors TLV
+func (h *LANHello) GetISNeighborsTLV() *ISNeighborsTLV {
+	for _, tlv := range h.TLVs {
+		if tlv.Type() != ISNeighborsTLVType {
+			continue
+		}
+
+		return tlv.(*ISNeighborsTLV)
+	}
+
+	return nil
+}
+
+// GetProtocolsSupportedTLV gets the protocols supported TLV
+func (h *LANHello) GetProtocolsSupportedTLV() *ProtocolsSupportedTLV {
+	for _, tlv := range h.TLVs {
+		if tlv.Type() != ProtocolsSupportedTLVType {
+			continue
+		}
+
+		return tlv.(*ProtocolsSupportedTLV)
+	}
+
+	return nil
+}
+
+// GetAreaAddressesTLV gets the area addresses TLV
+func (h *LANHello) GetAreaAddressesTLV() *AreaAddressesTLV {
+	for _, tlv := range h.TLVs {
+		if tlv.Type() != AreaAddressesTLVType {
+			continue
+		}
+
+		return tlv.(*AreaAddressesTLV)
+	}
+
+	return nil
+}
+
+// GetIPInterfaceAddressesesTLV gets the IP Interface Addresses TLV
+func (h *LANHello) GetIPInterfaceAddressesesTLV() *IPInterfaceAddressesTLV {
+	for _, tlv := range h.TLVs {
+		if tlv.Type() != IPInterfaceAddressesTLVType {
+			continue
+		}
+
+		return tlv.(*IPInterfaceAddressesTLV)
+	}
+
+	return nil
+}
+
+// GetIPv6InterfaceAddressesTLV gets the IPv6 Interface Addresses TLV
+func (h *LANHello) GetIPv6InterfaceAddressesTLV() *IPv6InterfaceAddressesTLV {
+	for _, tlv := range h.TLVs {
+		if tlv.Type() != IPv6InterfaceAddressesTLVType {
+			continue
+		}
+
+		return tlv.(*IPv6InterfaceAddressesTLV)
+	}
+
+	return nil
+}
+
+// GetMultiTopologyTLV gets the Multi-Topology TLV
+func (h *LANHello) GetMultiTopologyTLV() *MultiTopologyTLV {
+	for _, tlv := range h.TLVs {
+		if tlv.Type() != MultiTopologyTLVType {
+			continue
+		}
+
+		return tlv.(*MultiTopologyTLV)
+	}
+
+	return nil
+}
+
+// Serialize serializes a LAN Hello
+func (h *LANHello) Serialize(buf *bytes.Buffer) {
+	tlvsLen := uint16(0)
+	for _, TLV := range h.TLVs {
+		tlvsLen += uint16(TLV.Length()) + tlvBaseLen
+	}
+	h.PDULength = LANHelloMinLen + tlvsLen
+
+	buf.WriteByte(h.CircuitType)
+	buf.Write(h.SystemID[:])
+	buf.Write(convert.Uint16Byte(h.HoldingTimer))
+	buf.Write(convert.Uint16Byte(h.PDULength))
+	buf.WriteByte(h.Priority & lanHelloPriorityMask)
+	buf.Write(h.DesignatedIS.Serialize())
+
+	for _, TLV := range h.TLVs {
+		TLV.Serialize(buf)
+	}
+}
+
+// DecodeLANHello decodes an ISIS broadcast (LAN) hello
+func DecodeLANHello(buf *bytes.Buffer) (*LANHello, error) {
+	pdu := &LANHello{}
 	fields := []interface{}{
 		&pdu.CircuitType,
 		&pdu.SystemID,
 		&pdu.HoldingTimer,
 		&pdu.PDULength,
 		&pdu.Priority,
-		&reserved,
-		&pdu.DesignatedIS,
+		&pdu.DesignatedIS.SystemID,
+		&pdu.DesignatedIS.CircuitID,
 	}
 
 	err := decode.Decode(buf, fields)
@@ -151,6 +279,8 @@ func DecodeL2Hello(buf *bytes.Buffer) (*L2Hello, error) {
 		return nil, fmt.Errorf("unable to decode fields: %v", err)
 	}
 
+	pdu.Priority &= lanHelloPriorityMask
+
 	TLVs, err := readTLVs(buf)
 	if err != nil {
 		return nil, fmt.Errorf("unable to read TLVs: %v", err)
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"testing"
 
+	"github.com/bio-routing/bio-rd/net/ethernet"
 	"github.com/bio-routing/bio-rd/protocols/isis/types"
 	"github.com/stretchr/testify/assert"
 
@@ -78,6 +79,44 @@ func TestGetP2PAdjTLV(t *testing.T) {
 	}
 }
 
+func TestGetMultiTopologyTLV(t *testing.T) {
+	tests := []struct {
+		name     string
+		hello    *P2PHello
+		expected *MultiTopologyTLV
+	}{
+		{
+			name: "Test #1",
+			hello: &P2PHello{
+				TLVs: []TLV{
+					NewIPv6InterfaceAddressesTLV([]bnet.IP{bnet.IPv6FromBlocks(0xfe80, 0, 0, 0, 0, 0, 0, 1)}),
+					NewMultiTopologyTLV([]MultiTopology{{MTID: MTIDStandard}, {MTID: MTIDIPv6Unicast}}),
+				},
+			},
+			expected: &MultiTopologyTLV{
+				TLVType:   MultiTopologyTLVType,
+				TLVLength: 4,
+				Topologies: []MultiTopology{
+					{MTID: MTIDStandard},
+					{MTID: MTIDIPv6Unicast},
+				},
+			},
+		},
+		{
+			name: "Test #2",
+			hello: &P2PHello{
+				TLVs: []TLV{},
+			},
+			expected: nil,
+		},
+	}
+
+	for _, test := range tests {
+		ret := test.hello.GetMultiTopologyTLV()
+		assert.Equalf(t, test.expected, ret, "Test %q", test.name)
+	}
+}
+
 func TestP2PHelloSerialize(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -216,7 +255,7 @@ func TestDecodeISISHello(t *testing.T) {
 		name     string
 		input    []byte
 		wantFail bool
-		expected *L2Hello
+		expected *LANHello
 	}{
 		{
 			name: "No TLVs",
@@ -224,18 +263,17 @@ func TestDecodeISISHello(t *testing.T) {
 				2,
 				1, 2, 3, 4, 5, 6,
 				0, 200,
-				0, 18,
+				0, 27,
 				150,
-				0,
-				1, 1, 1, 2, 2, 2,
+				1, 1, 1, 2, 2, 2, 1,
 			},
-			expected: &L2Hello{
+			expected: &LANHello{
 				CircuitType:  2,
-				SystemID:     [6]byte{1, 2, 3, 4, 5, 6},
+				SystemID:     types.SystemID{1, 2, 3, 4, 5, 6},
 				HoldingTimer: 200,
-				PDULength:    18,
-				Priority:     150,
-				DesignatedIS: [6]byte{1, 1, 1, 2, 2, 2},
+				PDULength:    27,
+				Priority:     22,
+				DesignatedIS: types.NewSourceID(types.SystemID{1, 1, 1, 2, 2, 2}, 1),
 				TLVs:         []TLV{},
 			},
 		},
@@ -245,26 +283,25 @@ func TestDecodeISISHello(t *testing.T) {
 				2,
 				1, 2, 3, 4, 5, 6,
 				0, 200,
-				0, 26,
-				150,
-				0,
-				1, 1, 1, 2, 2, 2,
+				0, 35,
+				100,
+				1, 1, 1, 2, 2, 2, 1,
 				6,
 				6,
 				2, 2, 2, 3, 3, 3,
 			},
-			expected: &L2Hello{
+			expected: &LANHello{
 				CircuitType:  2,
-				SystemID:     [6]byte{1, 2, 3, 4, 5, 6},
+				SystemID:     types.SystemID{1, 2, 3, 4, 5, 6},
 				HoldingTimer: 200,
-				PDULength:    26,
-				Priority:     150,
-				DesignatedIS: [6]byte{1, 1, 1, 2, 2, 2},
+				PDULength:    35,
+				Priority:     100,
+				DesignatedIS: types.NewSourceID(types.SystemID{1, 1, 1, 2, 2, 2}, 1),
 				TLVs: []TLV{
 					&ISNeighborsTLV{
-						TLVType:      6,
-						TLVLength:    6,
-						NeighborSNPA: [6]byte{2, 2, 2, 3, 3, 3},
+						TLVType:       6,
+						TLVLength:     6,
+						NeighborSNPAs: []ethernet.MACAddr{{2, 2, 2, 3, 3, 3}},
 					},
 				},
 			},
@@ -275,10 +312,9 @@ func TestDecodeISISHello(t *testing.T) {
 				2,                // CircuitType
 				1, 2, 3, 4, 5, 6, // SystemID
 				0, 200, // Holding Timer
-				0, 41, // PDU Length
-				150,              // Prio
-				0,                // Reserved
-				1, 1, 1, 2, 2, 2, // Designated IS
+				0, 50, // PDU Length
+				64,                  // Prio
+				1, 1, 1, 2, 2, 2, 1, // LAN ID
 				6,                // Type = ISNeighborsTLV
 				6,                // Length
 				2, 2, 2, 3, 3, 3, // Neighbor
@@ -292,18 +328,18 @@ func TestDecodeISISHello(t *testing.T) {
 				7,
 				6, 49, 10, 0, 0, 20, 30,
 			},
-			expected: &L2Hello{
+			expected: &LANHello{
 				CircuitType:  2,
-				SystemID:     [6]byte{1, 2, 3, 4, 5, 6},
+				SystemID:     types.SystemID{1, 2, 3, 4, 5, 6},
 				HoldingTimer: 200,
-				PDULength:    41,
-				Priority:     150,
-				DesignatedIS: [6]byte{1, 1, 1, 2, 2, 2},
+				PDULength:    50,
+				Priority:     64,
+				DesignatedIS: types.NewSourceID(types.SystemID{1, 1, 1, 2, 2, 2}, 1),
 				TLVs: []TLV{
 					&ISNeighborsTLV{
-						TLVType:      6,
-						TLVLength:    6,
-						NeighborSNPA: [6]byte{2, 2, 2, 3, 3, 3},
+						TLVType:       6,
+						TLVLength:     6,
+						NeighborSNPAs: []ethernet.MACAddr{{2, 2, 2, 3, 3, 3}},
 					},
 					&ProtocolsSupportedTLV{
 						TLVType:                 129,
@@ -327,11 +363,23 @@ func TestDecodeISISHello(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Incomplete LAN ID",
+			input: []byte{
+				2,
+				1, 2, 3, 4, 5, 6,
+				0, 200,
+				0, 27,
+				150,
+				1, 1, 1, 2,
+			},
+			wantFail: true,
+		},
 	}
 
 	for _, test := range tests {
 		buffer := bytes.NewBuffer(test.input)
-		pdu, err := DecodeL2Hello(buffer)
+		pdu, err := DecodeLANHello(buffer)
 
 		if err != nil {
 			if test.wantFail {
@@ -350,3 +398,45 @@ func TestDecodeISISHello(t *testing.T) {
 		assert.Equalf(t, test.expected, pdu, "Test: %q", test.name)
 	}
 }
+
+func TestLANHelloSerialize(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *LANHello
+		expected []byte
+	}{
+		{
+			name: "Test #1",
+			input: &LANHello{
+				CircuitType:  2,
+				SystemID:     types.SystemID{1, 2, 3, 4, 5, 6},
+				HoldingTimer: 27,
+				Priority:     64,
+				DesignatedIS: types.NewSourceID(types.SystemID{1, 2, 3, 4, 5, 6}, 3),
+				TLVs: []TLV{
+					NewISNeighborsTLV([]ethernet.MACAddr{{2, 2, 2, 3, 3, 3}}),
+				},
+			},
+			expected: []byte{
+				2,
+				1, 2, 3, 4, 5, 6,
+				0, 27,
+				0, 35,
+				64,
+				1, 2, 3, 4, 5, 6, 3,
+				6, 6, 2, 2, 2, 3, 3, 3,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		buf := bytes.NewBuffer(nil)
+		test.input.Serialize(buf)
+		assert.Equalf(t, test.expected, buf.Bytes(), "Test %q", test.name)
+
+		h, err := DecodeLANHello(buf)
+		if assert.NoError(t, err, test.name) {
+			assert.Equalf(t, test.input, h, "Test %q", test.name)
+		}
+	}
+}
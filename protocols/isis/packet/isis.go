@@ -57,19 +57,25 @@ func Decode(buf *bytes.Buffer) (*ISISPacket, error) {
 			return nil, fmt.Errorf("unable to decode P2P hello: %v", err)
 		}
 		pkt.Body = p2pHello
-	case L2_LS_PDU_TYPE:
+	case L1_LAN_HELLO_TYPE, L2_LAN_HELLO_TYPE:
+		lanHello, err := DecodeLANHello(buf)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode LAN hello: %v", err)
+		}
+		pkt.Body = lanHello
+	case L1_LS_PDU_TYPE, L2_LS_PDU_TYPE:
 		lspdu, err := DecodeLSPDU(buf)
 		if err != nil {
 			return nil, fmt.Errorf("unable to decode LSPDU: %v", err)
 		}
 		pkt.Body = lspdu
-	case L2_CSNP_TYPE:
+	case L1_CSNP_TYPE, L2_CSNP_TYPE:
 		csnp, err := DecodeCSNP(buf)
 		if err != nil {
 			return nil, fmt.Errorf("unable to decode CSNP: %v", err)
 		}
 		pkt.Body = csnp
-	case L2_PSNP_TYPE:
+	case L1_PSNP_TYPE, L2_PSNP_TYPE:
 		psnp, err := DecodePSNP(buf)
 		if err != nil {
 			return nil, fmt.Errorf("unable to decode PSNP: %v", err)
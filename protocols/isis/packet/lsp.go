@@ -14,6 +14,18 @@ const (
 	LSPIDLen    = 8
 	LSPDUMinLen = 27
 	MODX        = 5802
+
+	// TypeBlockAttached is the ATT bit (default metric) indicating that an L1/L2 router is attached to other areas
+	TypeBlockAttached = 0x08
+
+	// TypeBlockOverload is the LSP database overload bit indicating that a router must not be used for transit traffic
+	TypeBlockOverload = 0x04
+
+	// TypeBlockISTypeL1 is the IS type of level 1 only routers
+	TypeBlockISTypeL1 = 0x01
+
+	// TypeBlockISTypeL1L2 is the IS type of level 2 and level 1/2 routers
+	TypeBlockISTypeL1L2 = 0x03
 )
 
 // LSPID represents a Link State Packet ID
@@ -78,6 +90,16 @@ func (l *LSPDU) Copy() *LSPDU {
 	return &ret
 }
 
+// Attached checks if the ATT bit is set
+func (l *LSPDU) Attached() bool {
+	return l.TypeBlock&TypeBlockAttached != 0
+}
+
+// Overload checks if the LSP database overload bit is set
+func (l *LSPDU) Overload() bool {
+	return l.TypeBlock&TypeBlockOverload != 0
+}
+
 // UpdateLength updates the length of the LSPDU
 func (l *LSPDU) UpdateLength() {
 	l.Length = LSPDUMinLen
@@ -62,6 +62,12 @@ func newPSNP(sourceID types.SourceID, lspEntries []*LSPEntry) *PSNP {
 	return &psnp
 }
 
+// AddTLV adds a TLV to the PSNP and updates its PDU length
+func (p *PSNP) AddTLV(tlv TLV) {
+	p.TLVs = append(p.TLVs, tlv)
+	p.PDULength += uint16(tlv.Length()) + tlvBaseLen
+}
+
 // GetLSPEntries returns LSP Entries from the LSP Entries TLV
 func (p *PSNP) GetLSPEntries() []*LSPEntry {
 	return getLSPEntries(p.TLVs)
@@ -9,6 +9,9 @@ import (
 
 const (
 	tlvBaseLen = 2 // Type + Length field
+
+	// MaxTLVLength is the maximum length of the value of a TLV
+	MaxTLVLength = 255
 )
 
 // TLV is an interface that all TLVs must fulfill
@@ -61,6 +64,8 @@ func readTLV(buf *bytes.Buffer) (TLV, error) {
 
 	var tlv TLV
 	switch tlvType {
+	case AuthenticationTLVType:
+		tlv, err = readAuthenticationTLV(buf, tlvType, tlvLength)
 	case DynamicHostNameTLVType:
 		tlv, err = readDynamicHostnameTLV(buf, tlvType, tlvLength)
 	case ChecksumTLVType:
@@ -77,6 +82,22 @@ func readTLV(buf *bytes.Buffer) (TLV, error) {
 		tlv, err = readISNeighborsTLV(buf, tlvType, tlvLength)
 	case LSPEntriesTLVType:
 		tlv, err = readLSPEntriesTLV(buf, tlvType, tlvLength)
+	case ExtendedISReachabilityType:
+		tlv, err = readExtendedISReachabilityTLV(buf, tlvType, tlvLength)
+	case ExtendedIPReachabilityTLVType:
+		tlv, err = readExtendedIPReachabilityTLV(buf, tlvType, tlvLength)
+	case IPv6InterfaceAddressesTLVType:
+		tlv, err = readIPv6InterfaceAddressesTLV(buf, tlvType, tlvLength)
+	case IPv6ReachabilityTLVType:
+		tlv, err = readIPv6ReachabilityTLV(buf, tlvType, tlvLength)
+	case MultiTopologyTLVType:
+		tlv, err = readMultiTopologyTLV(buf, tlvType, tlvLength)
+	case MTIntermediateSystemsTLVType:
+		tlv, err = readMTIntermediateSystemsTLV(buf, tlvType, tlvLength)
+	case MTIPv6ReachabilityTLVType:
+		tlv, err = readMTIPv6ReachabilityTLV(buf, tlvType, tlvLength)
+	case RouterCapabilityTLVType:
+		tlv, err = readRouterCapabilityTLV(buf, tlvType, tlvLength)
 	default:
 		tlv, err = readUnknownTLV(buf, tlvType, tlvLength)
 	}
@@ -1,12 +1,136 @@
 package packet
 
-// AuthenticationType is the type value of an authentication TLV
-const AuthenticationType = 10
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/bio-routing/bio-rd/util/decode"
+	"github.com/bio-routing/tflow2/convert"
+)
+
+const (
+	// AuthenticationTLVType is the type value of an authentication TLV
+	AuthenticationTLVType = 10
+
+	// AuthenticationTypeCleartext is the authentication type of cleartext passwords (ISO 10589)
+	AuthenticationTypeCleartext = 1
+
+	// AuthenticationTypeGenericCrypto is the authentication type of generic cryptographic authentication (RFC5310)
+	AuthenticationTypeGenericCrypto = 3
+
+	// AuthenticationTypeHMACMD5 is the authentication type of HMAC-MD5 authentication (RFC5304)
+	AuthenticationTypeHMACMD5 = 54
+
+	keyIDLen = 2
+)
 
 // AuthenticationTLV represents an authentication TLV
 type AuthenticationTLV struct {
 	TLVType            uint8
 	TLVLength          uint8
 	AuthenticationType uint8
-	Password           []byte
+	KeyID              uint16 // only present for generic cryptographic authentication
+	AuthenticationData []byte // password or digest
+}
+
+// NewCleartextAuthenticationTLV creates a new authentication TLV carrying a cleartext password
+func NewCleartextAuthenticationTLV(password []byte) *AuthenticationTLV {
+	return &AuthenticationTLV{
+		TLVType:            AuthenticationTLVType,
+		TLVLength:          uint8(1 + len(password)),
+		AuthenticationType: AuthenticationTypeCleartext,
+		AuthenticationData: password,
+	}
+}
+
+// NewHMACMD5AuthenticationTLV creates a new HMAC-MD5 authentication TLV with an all zero digest
+func NewHMACMD5AuthenticationTLV() *AuthenticationTLV {
+	return &AuthenticationTLV{
+		TLVType:            AuthenticationTLVType,
+		TLVLength:          1 + md5DigestLen,
+		AuthenticationType: AuthenticationTypeHMACMD5,
+		AuthenticationData: make([]byte, md5DigestLen),
+	}
+}
+
+// NewGenericCryptoAuthenticationTLV creates a new generic cryptographic authentication TLV with an all zero digest of digestLen bytes
+func NewGenericCryptoAuthenticationTLV(keyID uint16, digestLen int) *AuthenticationTLV {
+	return &AuthenticationTLV{
+		TLVType:            AuthenticationTLVType,
+		TLVLength:          uint8(1 + keyIDLen + digestLen),
+		AuthenticationType: AuthenticationTypeGenericCrypto,
+		KeyID:              keyID,
+		AuthenticationData: make([]byte, digestLen),
+	}
+}
+
+func (a *AuthenticationTLV) Copy() TLV {
+	ret := *a
+	ret.AuthenticationData = make([]byte, len(a.AuthenticationData))
+	copy(ret.AuthenticationData, a.AuthenticationData)
+	return &ret
+}
+
+// Type gets the type of the TLV
+func (a *AuthenticationTLV) Type() uint8 {
+	return a.TLVType
+}
+
+// Length gets the length of the TLV
+func (a *AuthenticationTLV) Length() uint8 {
+	return a.TLVLength
+}
+
+// Value returns the TLV itself
+func (a *AuthenticationTLV) Value() interface{} {
+	return a
+}
+
+func readAuthenticationTLV(buf *bytes.Buffer, tlvType uint8, tlvLength uint8) (*AuthenticationTLV, error) {
+	if tlvLength < 1 {
+		return nil, fmt.Errorf("invalid authentication TLV length %d", tlvLength)
+	}
+
+	pdu := &AuthenticationTLV{
+		TLVType:   tlvType,
+		TLVLength: tlvLength,
+	}
+
+	err := decode.Decode(buf, []interface{}{&pdu.AuthenticationType})
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode fields: %v", err)
+	}
+
+	valueLen := int(tlvLength) - 1
+	fields := make([]interface{}, 0, 2)
+	if pdu.AuthenticationType == AuthenticationTypeGenericCrypto {
+		if valueLen < keyIDLen {
+			return nil, fmt.Errorf("invalid generic cryptographic authentication TLV length %d", tlvLength)
+		}
+
+		valueLen -= keyIDLen
+		fields = append(fields, &pdu.KeyID)
+	}
+
+	pdu.AuthenticationData = make([]byte, valueLen)
+	fields = append(fields, &pdu.AuthenticationData)
+
+	err = decode.Decode(buf, fields)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode fields: %v", err)
+	}
+
+	return pdu, nil
+}
+
+// Serialize serializes an authentication TLV
+func (a *AuthenticationTLV) Serialize(buf *bytes.Buffer) {
+	buf.WriteByte(a.TLVType)
+	buf.WriteByte(a.TLVLength)
+	buf.WriteByte(a.AuthenticationType)
+	if a.AuthenticationType == AuthenticationTypeGenericCrypto {
+		buf.Write(convert.Uint16Byte(a.KeyID))
+	}
+
+	buf.Write(a.AuthenticationData)
 }
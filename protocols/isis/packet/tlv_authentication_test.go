@@ -0,0 +1,106 @@
+package packet
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthenticationTLVSerialize(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *AuthenticationTLV
+		expected []byte
+	}{
+		{
+			name:     "Cleartext",
+			input:    NewCleartextAuthenticationTLV([]byte("abc")),
+			expected: []byte{10, 4, 1, 'a', 'b', 'c'},
+		},
+		{
+			name: "Generic crypto",
+			input: &AuthenticationTLV{
+				TLVType:            AuthenticationTLVType,
+				TLVLength:          7,
+				AuthenticationType: AuthenticationTypeGenericCrypto,
+				KeyID:              0x0102,
+				AuthenticationData: []byte{1, 2, 3, 4},
+			},
+			expected: []byte{10, 7, 3, 1, 2, 1, 2, 3, 4},
+		},
+	}
+
+	for _, test := range tests {
+		buf := bytes.NewBuffer(nil)
+		test.input.Serialize(buf)
+
+		assert.Equalf(t, test.expected, buf.Bytes(), "Test %q", test.name)
+	}
+}
+
+func TestReadAuthenticationTLV(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []byte
+		tlvLen   uint8
+		wantFail bool
+		expected *AuthenticationTLV
+	}{
+		{
+			name:   "HMAC-MD5",
+			input:  []byte{54, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+			tlvLen: 17,
+			expected: &AuthenticationTLV{
+				TLVType:            AuthenticationTLVType,
+				TLVLength:          17,
+				AuthenticationType: AuthenticationTypeHMACMD5,
+				AuthenticationData: []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+			},
+		},
+		{
+			name:   "Generic crypto",
+			input:  []byte{3, 0, 5, 1, 2, 3, 4},
+			tlvLen: 7,
+			expected: &AuthenticationTLV{
+				TLVType:            AuthenticationTLVType,
+				TLVLength:          7,
+				AuthenticationType: AuthenticationTypeGenericCrypto,
+				KeyID:              5,
+				AuthenticationData: []byte{1, 2, 3, 4},
+			},
+		},
+		{
+			name:     "Generic crypto without key ID",
+			input:    []byte{3, 0},
+			tlvLen:   2,
+			wantFail: true,
+		},
+		{
+			name:     "Incomplete",
+			input:    []byte{54, 1, 2, 3},
+			tlvLen:   17,
+			wantFail: true,
+		},
+	}
+
+	for _, test := range tests {
+		buf := bytes.NewBuffer(test.input)
+		tlv, err := readAuthenticationTLV(buf, AuthenticationTLVType, test.tlvLen)
+
+		if err != nil {
+			if test.wantFail {
+				continue
+			}
+			t.Errorf("Unexpected failure for test %q: %v", test.name, err)
+			continue
+		}
+
+		if test.wantFail {
+			t.Errorf("Unexpected success for test %q", test.name)
+			continue
+		}
+
+		assert.Equalf(t, test.expected, tlv, "Test %q", test.name)
+	}
+}
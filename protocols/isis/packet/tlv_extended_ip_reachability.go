@@ -15,6 +15,9 @@ const (
 
 	// ExtendedIPReachabilityMinLength is the minimum length of an Extended IP Reachability excluding Sub TLVs
 	ExtendedIPReachabilityMinLength = 5
+
+	upDownBit  = 0x80
+	subTLVsBit = 0x40
 )
 
 // ExtendedIPReachabilityTLV is an Extended IP Reachability TLV
@@ -73,18 +76,18 @@ func readExtendedIPReachabilityTLV(buf *bytes.Buffer, tlvType uint8, tlvLength u
 	pdu := NewExtendedIPReachabilityTLV()
 	pdu.TLVLength = tlvLength
 
-	toRead := tlvLength
-	for toRead > 0 {
-		extIPReach, bytesRead, err := readExtendedIPReachability(buf)
+	data := buf.Next(int(tlvLength))
+	if len(data) != int(tlvLength) {
+		return nil, fmt.Errorf("TLV too short")
+	}
+
+	tlvBuf := bytes.NewBuffer(data)
+	for tlvBuf.Len() > 0 {
+		extIPReach, err := readExtendedIPReachability(tlvBuf)
 		if err != nil {
 			return nil, fmt.Errorf("unable to reach extended IP reachability: %w", err)
 		}
 
-		toRead -= bytesRead
-		for i := range extIPReach.SubTLVs {
-			toRead -= extIPReach.SubTLVs[i].Length()
-		}
-
 		pdu.ExtendedIPReachabilities = append(pdu.ExtendedIPReachabilities, extIPReach)
 	}
 
@@ -122,9 +125,32 @@ func (e *ExtendedIPReachability) Copy() *ExtendedIPReachability {
 // AddExtendedIPReachability adds an extended IP reachability
 func (e *ExtendedIPReachabilityTLV) AddExtendedIPReachability(eipr *ExtendedIPReachability) {
 	e.ExtendedIPReachabilities = append(e.ExtendedIPReachabilities, eipr)
-	e.TLVLength += ExtendedIPReachabilityMinLength + net.BytesInAddr(eipr.PfxLen())
+	e.TLVLength += eipr.Length()
+}
+
+// AddSubTLV adds a sub TLV to the ExtendedIPReachability
+func (e *ExtendedIPReachability) AddSubTLV(tlv TLV) {
+	e.UDSubBitPfxLen |= subTLVsBit
+	e.SubTLVs = append(e.SubTLVs, tlv)
+}
+
+func (e *ExtendedIPReachability) subTLVLength() uint8 {
+	l := uint8(0)
+	for _, stlv := range e.SubTLVs {
+		l += tlvBaseLen + stlv.Length()
+	}
+
+	return l
+}
+
+// Length gets the length of the ExtendedIPReachability including its sub TLVs
+func (e *ExtendedIPReachability) Length() uint8 {
+	l := ExtendedIPReachabilityMinLength + net.BytesInAddr(e.PfxLen())
+	if e.hasSubTLVs() {
+		l += 1 + e.subTLVLength()
+	}
 
-	// TODO: Add length of sub TLVs. They will be added as soon as we support for TE
+	return l
 }
 
 // Serialize serializes an ExtendedIPReachability
@@ -136,13 +162,28 @@ func (e *ExtendedIPReachability) Serialize(buf *bytes.Buffer) {
 	addrBytes := convert.Uint32Byte(e.Address)
 	buf.Write(addrBytes[:n])
 
+	if !e.hasSubTLVs() {
+		return
+	}
+
+	buf.WriteByte(e.subTLVLength())
 	for i := range e.SubTLVs {
 		e.SubTLVs[i].Serialize(buf)
 	}
 }
 
 func (e *ExtendedIPReachability) hasSubTLVs() bool {
-	return e.UDSubBitPfxLen&(uint8(1)<<6) == 64
+	return e.UDSubBitPfxLen&subTLVsBit != 0
+}
+
+// UpDown checks if the up/down bit is set. It marks prefixes leaked from level 2 into level 1 (RFC5302)
+func (e *ExtendedIPReachability) UpDown() bool {
+	return e.UDSubBitPfxLen&upDownBit != 0
+}
+
+// SetUpDown sets the up/down bit
+func (e *ExtendedIPReachability) SetUpDown() {
+	e.UDSubBitPfxLen |= upDownBit
 }
 
 // PfxLen returns the prefix length
@@ -150,7 +191,7 @@ func (e *ExtendedIPReachability) PfxLen() uint8 {
 	return (e.UDSubBitPfxLen << 2) >> 2
 }
 
-func readExtendedIPReachability(buf *bytes.Buffer) (*ExtendedIPReachability, uint8, error) {
+func readExtendedIPReachability(buf *bytes.Buffer) (*ExtendedIPReachability, error) {
 	e := &ExtendedIPReachability{}
 
 	fields := []interface{}{
@@ -160,43 +201,33 @@ func readExtendedIPReachability(buf *bytes.Buffer) (*ExtendedIPReachability, uin
 
 	err := decode.Decode(buf, fields)
 	if err != nil {
-		return nil, 0, fmt.Errorf("unable to decode fields: %v", err)
+		return nil, fmt.Errorf("unable to decode fields: %v", err)
 	}
 
-	nBytes := net.BytesInAddr(e.PfxLen())
-	bytesRead := ExtendedIPReachabilityMinLength + nBytes
-	addr := make([]byte, nBytes)
-	for i := 0; i < int(nBytes); i++ {
-		buf.Read(addr)
+	if e.PfxLen() > 32 {
+		return nil, fmt.Errorf("invalid prefix length %d", e.PfxLen())
 	}
 
-	for i := len(addr); i < net.IPv4AddrBytes; i++ {
-		addr = append(addr, 0)
-	}
-
-	fields = []interface{}{
-		&e.Address,
-	}
-
-	err = decode.Decode(bytes.NewBuffer(addr), fields)
-	if err != nil {
-		return nil, bytesRead, fmt.Errorf("unable to decode fields: %v", err)
+	nBytes := net.BytesInAddr(e.PfxLen())
+	addr := make([]byte, net.IPv4AddrBytes)
+	if n, _ := buf.Read(addr[:nBytes]); n != int(nBytes) {
+		return nil, fmt.Errorf("prefix too short")
 	}
 
+	e.Address = convert.Uint32b(addr)
 	if !e.hasSubTLVs() {
-		return e, bytesRead, nil
+		return e, nil
 	}
 
-	subTLVsLen := uint8(0)
-	err = decode.Decode(buf, []interface{}{&subTLVsLen})
+	subTLVsLen, err := buf.ReadByte()
 	if err != nil {
-		return nil, bytesRead, fmt.Errorf("unable to decode fields: %v", err)
+		return nil, fmt.Errorf("unable to decode sub TLVs length: %v", err)
 	}
 
-	toRead := subTLVsLen
-	for toRead > 0 {
-		// TODO: Read Sub TLVs
+	e.SubTLVs, err = readSubTLVs(buf, subTLVsLen, readPrefixSubTLV)
+	if err != nil {
+		return nil, err
 	}
 
-	return e, bytesRead, nil
+	return e, nil
 }
@@ -77,6 +77,40 @@ func TestHasSubTLVs(t *testing.T) {
 	}
 }
 
+func TestUpDown(t *testing.T) {
+	tests := []struct {
+		name     string
+		e        *ExtendedIPReachability
+		expected bool
+	}{
+		{
+			name:     "Not set",
+			e:        NewExtendedIPReachability(10, 24, 0x0a000000),
+			expected: false,
+		},
+		{
+			name: "Set",
+			e: &ExtendedIPReachability{
+				UDSubBitPfxLen: 152, // /24 with up/down bit (+128)
+			},
+			expected: true,
+		},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, test.e.UpDown(), test.name)
+	}
+}
+
+func TestSetUpDown(t *testing.T) {
+	e := NewExtendedIPReachability(10, 24, 0x0a000000)
+	e.SetUpDown()
+
+	assert.True(t, e.UpDown())
+	assert.Equal(t, uint8(24), e.PfxLen())
+	assert.False(t, e.hasSubTLVs())
+}
+
 func TestReadExtendedIPReachabilityTLV(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -104,6 +138,62 @@ func TestReadExtendedIPReachabilityTLV(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Two entries. Sub TLVs.",
+			input: []byte{
+				0, 0, 0, 10, // Metric
+				64 + 32,        // UDSubBitPfxLen (sub TLVs)
+				192, 168, 0, 1, // Address
+				4,    // Sub TLVs length
+				1, 2, // Sub TLV
+				0, 1,
+				0, 0, 0, 20, // Metric
+				16,      // UDSubBitPfxLen (no sub TLVs)
+				172, 16, // Address
+			},
+			expected: &ExtendedIPReachabilityTLV{
+				TLVType:   135,
+				TLVLength: 21,
+				ExtendedIPReachabilities: []*ExtendedIPReachability{
+					{
+						Metric:         10,
+						UDSubBitPfxLen: 96,
+						Address:        3232235521,
+						SubTLVs: []TLV{
+							&UnknownTLV{
+								TLVType:   1,
+								TLVLength: 2,
+								TLVValue:  []byte{0, 1},
+							},
+						},
+					},
+					{
+						Metric:         20,
+						UDSubBitPfxLen: 16,
+						Address:        2886729728,
+					},
+				},
+			},
+		},
+		{
+			name: "Prefix too short",
+			input: []byte{
+				0, 0, 0, 100, // Metric
+				24,     // UDSubBitPfxLen (no sub TLVs)
+				10, 20, // Address
+			},
+			wantFail: true,
+		},
+		{
+			name: "Invalid prefix length",
+			input: []byte{
+				0, 0, 0, 100, // Metric
+				33,             // UDSubBitPfxLen (no sub TLVs)
+				10, 20, 30, 40, // Address
+				0,
+			},
+			wantFail: true,
+		},
 	}
 
 	for _, test := range tests {
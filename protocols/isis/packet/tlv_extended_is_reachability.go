@@ -2,6 +2,7 @@ package packet
 
 import (
 	"bytes"
+	"fmt"
 
 	"github.com/bio-routing/bio-rd/protocols/isis/types"
 	"github.com/bio-routing/tflow2/convert"
@@ -58,7 +59,7 @@ func (e *ExtendedISReachabilityTLV) Value() interface{} {
 
 // AddNeighbor adds a neighbor to the extended IS Reach. TLV
 func (e *ExtendedISReachabilityTLV) AddNeighbor(n *ExtendedISReachabilityNeighbor) {
-	e.TLVLength += ExtendedISReachabilityNeighborMinLen + n.SubTLVLength
+	e.TLVLength += n.Length()
 	e.Neighbors = append(e.Neighbors, n)
 }
 
@@ -71,6 +72,115 @@ func (e *ExtendedISReachabilityTLV) Serialize(buf *bytes.Buffer) {
 	}
 }
 
+func readExtendedISReachabilityTLV(buf *bytes.Buffer, tlvType uint8, tlvLength uint8) (*ExtendedISReachabilityTLV, error) {
+	pdu := NewExtendedISReachabilityTLV()
+	pdu.TLVLength = tlvLength
+
+	data := buf.Next(int(tlvLength))
+	if len(data) != int(tlvLength) {
+		return nil, fmt.Errorf("TLV too short")
+	}
+
+	tlvBuf := bytes.NewBuffer(data)
+	for tlvBuf.Len() > 0 {
+		n, err := readExtendedISReachabilityNeighbor(tlvBuf)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read extended IS reachability neighbor: %w", err)
+		}
+
+		pdu.Neighbors = append(pdu.Neighbors, n)
+	}
+
+	return pdu, nil
+}
+
+func readExtendedISReachabilityNeighbor(buf *bytes.Buffer) (*ExtendedISReachabilityNeighbor, error) {
+	if buf.Len() < ExtendedISReachabilityNeighborMinLen {
+		return nil, fmt.Errorf("neighbor too short")
+	}
+
+	n := &ExtendedISReachabilityNeighbor{
+		SubTLVs: make([]TLV, 0),
+	}
+
+	copy(n.NeighborID.SystemID[:], buf.Next(len(n.NeighborID.SystemID)))
+	n.NeighborID.CircuitID, _ = buf.ReadByte()
+
+	metric := buf.Next(3)
+	n.Metric = uint32(metric[0])<<16 | uint32(metric[1])<<8 | uint32(metric[2])
+	n.SubTLVLength, _ = buf.ReadByte()
+
+	subTLVs, err := readSubTLVs(buf, n.SubTLVLength, readISReachabilitySubTLV)
+	if err != nil {
+		return nil, err
+	}
+
+	n.SubTLVs = subTLVs
+	return n, nil
+}
+
+// readSubTLVs reads length bytes of sub TLVs of an Extended IS or IP Reachability. readSubTLV decodes
+// the sub TLVs known in the context of the reachability.
+func readSubTLVs(buf *bytes.Buffer, length uint8, readSubTLV func(*bytes.Buffer, uint8, uint8) (TLV, error)) ([]TLV, error) {
+	data := buf.Next(int(length))
+	if len(data) != int(length) {
+		return nil, fmt.Errorf("sub TLVs too short")
+	}
+
+	subTLVs := make([]TLV, 0)
+	subTLVBuf := bytes.NewBuffer(data)
+	for subTLVBuf.Len() > 0 {
+		if subTLVBuf.Len() < tlvBaseLen {
+			return nil, fmt.Errorf("sub TLV too short")
+		}
+
+		tlvType, _ := subTLVBuf.ReadByte()
+		tlvLength, _ := subTLVBuf.ReadByte()
+		if subTLVBuf.Len() < int(tlvLength) {
+			return nil, fmt.Errorf("sub TLV %d too short", tlvType)
+		}
+
+		tlv, err := readSubTLV(subTLVBuf, tlvType, tlvLength)
+		if err != nil {
+			return nil, err
+		}
+
+		subTLVs = append(subTLVs, tlv)
+	}
+
+	return subTLVs, nil
+}
+
+// readISReachabilitySubTLV reads a sub TLV of an Extended IS Reachability
+func readISReachabilitySubTLV(buf *bytes.Buffer, tlvType uint8, tlvLength uint8) (TLV, error) {
+	switch {
+	case tlvType == LinkLocalRemoteIdentifiersSubTLVType && tlvLength == 8:
+		return &LinkLocalRemoteIdentifiersSubTLV{
+			TLVType:   tlvType,
+			TLVLength: tlvLength,
+			Local:     convert.Uint32b(buf.Next(4)),
+			Remote:    convert.Uint32b(buf.Next(4)),
+		}, nil
+	case (tlvType == IPv4InterfaceAddressSubTLVType || tlvType == IPv4NeighborAddressSubTLVType) && tlvLength == 4:
+		return newIPv4AddressSubTLV(tlvType, convert.Uint32b(buf.Next(4))), nil
+	case tlvType == AdjacencySIDSubTLVType && (tlvLength == 2+sidLabelLen || tlvLength == 2+sidIndexLen):
+		return readAdjacencySIDSubTLV(buf, tlvType, tlvLength)
+	case tlvType == LANAdjacencySIDSubTLVType && (tlvLength == 8+sidLabelLen || tlvLength == 8+sidIndexLen):
+		return readAdjacencySIDSubTLV(buf, tlvType, tlvLength)
+	}
+
+	return readUnknownTLV(buf, tlvType, tlvLength)
+}
+
+// readPrefixSubTLV reads a sub TLV of an Extended IP Reachability or IPv6 Reachability
+func readPrefixSubTLV(buf *bytes.Buffer, tlvType uint8, tlvLength uint8) (TLV, error) {
+	if tlvType == PrefixSIDSubTLVType && (tlvLength == 2+sidLabelLen || tlvLength == 2+sidIndexLen) {
+		return readPrefixSIDSubTLV(buf, tlvType, tlvLength)
+	}
+
+	return readUnknownTLV(buf, tlvType, tlvLength)
+}
+
 // ExtendedISReachabilityNeighbor is an extended IS Reachability Neighbor
 type ExtendedISReachabilityNeighbor struct {
 	NeighborID   types.SourceID
@@ -88,6 +198,11 @@ func (e *ExtendedISReachabilityNeighbor) Copy() *ExtendedISReachabilityNeighbor
 	return &ret
 }
 
+// Length gets the length of the ExtendedISReachabilityNeighbor including its sub TLVs
+func (e *ExtendedISReachabilityNeighbor) Length() uint8 {
+	return ExtendedISReachabilityNeighborMinLen + e.SubTLVLength
+}
+
 // Serialize serializes an ExtendedISReachabilityNeighbor
 func (e *ExtendedISReachabilityNeighbor) Serialize(buf *bytes.Buffer) {
 	buf.Write(e.NeighborID.Serialize())
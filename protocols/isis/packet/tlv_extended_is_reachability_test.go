@@ -142,3 +142,97 @@ func TestNewIPv4NeighborAddressSubTLV(t *testing.T) {
 
 	assert.Equal(t, expectred, tlv)
 }
+
+func TestReadExtendedISReachabilityTLV(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []byte
+		wantFail bool
+		expected *ExtendedISReachabilityTLV
+	}{
+		{
+			name: "Two neighbors",
+			input: []byte{
+				10, 20, 30, 40, 50, 60, // System ID
+				0,         // Circuit ID
+				0, 0, 123, // Metric
+				16,   // Sub TLVs length
+				4, 8, // Link Local/Remote Identifiers
+				0, 0, 0x3, 0xe8,
+				0, 0, 0x7, 0xd0,
+				6, 4, // IPv4 Interface Address
+				192, 168, 0, 1,
+				11, 12, 13, 14, 15, 16, // System ID
+				1,       // Circuit ID
+				1, 0, 0, // Metric
+				0, // Sub TLVs length
+			},
+			expected: &ExtendedISReachabilityTLV{
+				TLVType:   22,
+				TLVLength: 38,
+				Neighbors: []*ExtendedISReachabilityNeighbor{
+					{
+						NeighborID: types.SourceID{
+							SystemID: types.SystemID{10, 20, 30, 40, 50, 60},
+						},
+						Metric:       123,
+						SubTLVLength: 16,
+						SubTLVs: []TLV{
+							NewLinkLocalRemoteIdentifiersSubTLV(1000, 2000),
+							NewIPv4InterfaceAddressSubTLV(3232235521),
+						},
+					},
+					{
+						NeighborID: types.SourceID{
+							SystemID:  types.SystemID{11, 12, 13, 14, 15, 16},
+							CircuitID: 1,
+						},
+						Metric:  65536,
+						SubTLVs: []TLV{},
+					},
+				},
+			},
+		},
+		{
+			name: "Neighbor too short",
+			input: []byte{
+				10, 20, 30, 40, 50, 60, // System ID
+				0,    // Circuit ID
+				0, 0, // Metric
+			},
+			wantFail: true,
+		},
+		{
+			name: "Sub TLVs too short",
+			input: []byte{
+				10, 20, 30, 40, 50, 60, // System ID
+				0,         // Circuit ID
+				0, 0, 123, // Metric
+				8,    // Sub TLVs length
+				6, 4, // IPv4 Interface Address
+				192, 168, 0, 1,
+			},
+			wantFail: true,
+		},
+	}
+
+	for _, test := range tests {
+		buf := bytes.NewBuffer(test.input)
+		tlv, err := readExtendedISReachabilityTLV(buf, 22, uint8(len(test.input)))
+		if err != nil {
+			if test.wantFail {
+				continue
+			}
+
+			t.Errorf("Unexpected failure for test %q: %v", test.name, err)
+			continue
+		}
+
+		if test.wantFail {
+			t.Errorf("Unexpected success for test %q", test.name)
+			continue
+		}
+
+		assert.Equal(t, test.expected, tlv, test.name)
+	}
+}
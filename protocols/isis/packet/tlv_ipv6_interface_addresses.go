@@ -0,0 +1,89 @@
+package packet
+
+import (
+	"bytes"
+	"fmt"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+)
+
+// IPv6InterfaceAddressesTLVType is the type value of an IPv6 interface address TLV (RFC5308)
+const IPv6InterfaceAddressesTLVType = 232
+
+// IPv6InterfaceAddressesTLV represents an IPv6 interface address TLV
+type IPv6InterfaceAddressesTLV struct {
+	TLVType       uint8
+	TLVLength     uint8
+	IPv6Addresses []bnet.IP
+}
+
+// NewIPv6InterfaceAddressesTLV creates a new IPv6 interface address TLV
+func NewIPv6InterfaceAddressesTLV(addrs []bnet.IP) *IPv6InterfaceAddressesTLV {
+	t := &IPv6InterfaceAddressesTLV{
+		TLVType:       IPv6InterfaceAddressesTLVType,
+		TLVLength:     uint8(len(addrs) * 16),
+		IPv6Addresses: make([]bnet.IP, len(addrs)),
+	}
+
+	copy(t.IPv6Addresses, addrs)
+	return t
+}
+
+func readIPv6InterfaceAddressesTLV(buf *bytes.Buffer, tlvType uint8, tlvLength uint8) (*IPv6InterfaceAddressesTLV, error) {
+	if tlvLength%16 != 0 {
+		return nil, fmt.Errorf("invalid length %d", tlvLength)
+	}
+
+	data := buf.Next(int(tlvLength))
+	if len(data) != int(tlvLength) {
+		return nil, fmt.Errorf("TLV too short")
+	}
+
+	pdu := &IPv6InterfaceAddressesTLV{
+		TLVType:       tlvType,
+		TLVLength:     tlvLength,
+		IPv6Addresses: make([]bnet.IP, 0, tlvLength/16),
+	}
+
+	for i := 0; i < len(data); i += 16 {
+		addr, err := bnet.IPFromBytes(data[i : i+16])
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode address: %w", err)
+		}
+
+		pdu.IPv6Addresses = append(pdu.IPv6Addresses, addr)
+	}
+
+	return pdu, nil
+}
+
+func (i *IPv6InterfaceAddressesTLV) Copy() TLV {
+	ret := *i
+	ret.IPv6Addresses = make([]bnet.IP, len(i.IPv6Addresses))
+	copy(ret.IPv6Addresses, i.IPv6Addresses)
+	return &ret
+}
+
+// Type returns the type of the TLV
+func (i *IPv6InterfaceAddressesTLV) Type() uint8 {
+	return i.TLVType
+}
+
+// Length returns the length of the TLV
+func (i *IPv6InterfaceAddressesTLV) Length() uint8 {
+	return i.TLVLength
+}
+
+// Value gets the TLV itself
+func (i *IPv6InterfaceAddressesTLV) Value() interface{} {
+	return i
+}
+
+// Serialize serializes an IPv6 interfaces address TLV
+func (i *IPv6InterfaceAddressesTLV) Serialize(buf *bytes.Buffer) {
+	buf.WriteByte(i.TLVType)
+	buf.WriteByte(i.TLVLength)
+	for j := range i.IPv6Addresses {
+		buf.Write(i.IPv6Addresses[j].Bytes())
+	}
+}
@@ -0,0 +1,81 @@
+package packet
+
+import (
+	"bytes"
+	"testing"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIPv6InterfaceAddressesTLV(t *testing.T) {
+	tlv := NewIPv6InterfaceAddressesTLV([]bnet.IP{
+		bnet.IPv6FromBlocks(0xfe80, 0, 0, 0, 0, 0, 0, 1),
+	})
+
+	buf := bytes.NewBuffer(nil)
+	tlv.Serialize(buf)
+	assert.Equal(t, []byte{
+		232, 16,
+		0xfe, 0x80, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1,
+	}, buf.Bytes())
+
+	res, err := readTLV(buf)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, tlv, res)
+}
+
+func TestReadIPv6InterfaceAddressesTLV(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []byte
+		wantFail bool
+		expected *IPv6InterfaceAddressesTLV
+	}{
+		{
+			name: "Two addresses",
+			input: []byte{
+				0xfe, 0x80, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1,
+				0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1,
+			},
+			expected: &IPv6InterfaceAddressesTLV{
+				TLVType:   232,
+				TLVLength: 32,
+				IPv6Addresses: []bnet.IP{
+					bnet.IPv6FromBlocks(0xfe80, 0, 0, 0, 0, 0, 0, 1),
+					bnet.IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 1),
+				},
+			},
+		},
+		{
+			name: "Invalid length",
+			input: []byte{
+				0xfe, 0x80, 0, 0,
+			},
+			wantFail: true,
+		},
+	}
+
+	for _, test := range tests {
+		buf := bytes.NewBuffer(test.input)
+		tlv, err := readIPv6InterfaceAddressesTLV(buf, 232, uint8(len(test.input)))
+		if err != nil {
+			if test.wantFail {
+				continue
+			}
+
+			t.Errorf("Unexpected failure for test %q: %v", test.name, err)
+			continue
+		}
+
+		if test.wantFail {
+			t.Errorf("Unexpected success for test %q", test.name)
+			continue
+		}
+
+		assert.Equal(t, test.expected, tlv, test.name)
+	}
+}
@@ -0,0 +1,238 @@
+package packet
+
+import (
+	"bytes"
+	"fmt"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/util/decode"
+	"github.com/bio-routing/tflow2/convert"
+)
+
+const (
+	// IPv6ReachabilityTLVType is the type value of an IPv6 Reachability TLV (RFC5308)
+	IPv6ReachabilityTLVType = 236
+
+	// IPv6ReachabilityMinLength is the minimum length of an IPv6 Reachability excluding the prefix and Sub TLVs
+	IPv6ReachabilityMinLength = 6
+
+	// IPv6ReachabilityFlagUp is the Up/Down bit indicating a prefix has been leaked from level 2 to level 1
+	IPv6ReachabilityFlagUp = 0x80
+
+	// IPv6ReachabilityFlagExternal indicates a prefix has been redistributed from another protocol
+	IPv6ReachabilityFlagExternal = 0x40
+
+	// IPv6ReachabilityFlagSubTLVs indicates the presence of Sub TLVs
+	IPv6ReachabilityFlagSubTLVs = 0x20
+)
+
+// IPv6ReachabilityTLV is an IPv6 Reachability TLV
+type IPv6ReachabilityTLV struct {
+	TLVType            uint8
+	TLVLength          uint8
+	IPv6Reachabilities []*IPv6Reachability
+}
+
+// NewIPv6ReachabilityTLV creates a new IPv6ReachabilityTLV
+func NewIPv6ReachabilityTLV() *IPv6ReachabilityTLV {
+	return &IPv6ReachabilityTLV{
+		TLVType:            IPv6ReachabilityTLVType,
+		IPv6Reachabilities: make([]*IPv6Reachability, 0),
+	}
+}
+
+func (i *IPv6ReachabilityTLV) Copy() TLV {
+	ret := *i
+	ret.IPv6Reachabilities = copyIPv6Reachabilities(i.IPv6Reachabilities)
+	return &ret
+}
+
+// Type gets the type of the TLV
+func (i *IPv6ReachabilityTLV) Type() uint8 {
+	return i.TLVType
+}
+
+// Length gets the length of the TLV
+func (i *IPv6ReachabilityTLV) Length() uint8 {
+	return i.TLVLength
+}
+
+// Value returns the TLV itself
+func (i *IPv6ReachabilityTLV) Value() interface{} {
+	return i
+}
+
+// AddIPv6Reachability adds an IPv6 reachability
+func (i *IPv6ReachabilityTLV) AddIPv6Reachability(r *IPv6Reachability) {
+	i.IPv6Reachabilities = append(i.IPv6Reachabilities, r)
+	i.TLVLength += r.Length()
+}
+
+// Serialize serializes an IPv6ReachabilityTLV
+func (i *IPv6ReachabilityTLV) Serialize(buf *bytes.Buffer) {
+	buf.WriteByte(i.TLVType)
+	buf.WriteByte(i.TLVLength)
+
+	for _, r := range i.IPv6Reachabilities {
+		r.Serialize(buf)
+	}
+}
+
+func readIPv6ReachabilityTLV(buf *bytes.Buffer, tlvType uint8, tlvLength uint8) (*IPv6ReachabilityTLV, error) {
+	data := buf.Next(int(tlvLength))
+	if len(data) != int(tlvLength) {
+		return nil, fmt.Errorf("TLV too short")
+	}
+
+	reachabilities, err := readIPv6Reachabilities(bytes.NewBuffer(data))
+	if err != nil {
+		return nil, err
+	}
+
+	return &IPv6ReachabilityTLV{
+		TLVType:            tlvType,
+		TLVLength:          tlvLength,
+		IPv6Reachabilities: reachabilities,
+	}, nil
+}
+
+// IPv6Reachability is a prefix of an IPv6 Reachability TLV
+type IPv6Reachability struct {
+	Metric       uint32
+	Flags        uint8
+	Prefix       bnet.Prefix
+	SubTLVLength uint8
+	SubTLVs      []TLV
+}
+
+// NewIPv6Reachability creates a new IPv6Reachability
+func NewIPv6Reachability(metric uint32, pfx bnet.Prefix) *IPv6Reachability {
+	return &IPv6Reachability{
+		Metric: metric,
+		Prefix: pfx,
+	}
+}
+
+func (r *IPv6Reachability) Copy() *IPv6Reachability {
+	x := *r
+	x.SubTLVs = make([]TLV, 0, len(r.SubTLVs))
+	for _, stlv := range r.SubTLVs {
+		x.SubTLVs = append(x.SubTLVs, stlv.Copy())
+	}
+
+	return &x
+}
+
+func copyIPv6Reachabilities(reachabilities []*IPv6Reachability) []*IPv6Reachability {
+	ret := make([]*IPv6Reachability, 0, len(reachabilities))
+	for _, r := range reachabilities {
+		ret = append(ret, r.Copy())
+	}
+
+	return ret
+}
+
+// Up returns if the Up/Down bit is set
+func (r *IPv6Reachability) Up() bool {
+	return r.Flags&IPv6ReachabilityFlagUp != 0
+}
+
+// External returns if the prefix has been redistributed from another protocol
+func (r *IPv6Reachability) External() bool {
+	return r.Flags&IPv6ReachabilityFlagExternal != 0
+}
+
+func (r *IPv6Reachability) hasSubTLVs() bool {
+	return r.Flags&IPv6ReachabilityFlagSubTLVs != 0
+}
+
+// Length gets the length of the IPv6Reachability including its sub TLVs
+func (r *IPv6Reachability) Length() uint8 {
+	l := IPv6ReachabilityMinLength + r.Prefix.BytesInPrefix()
+	if r.hasSubTLVs() {
+		l += 1 + r.SubTLVLength
+	}
+
+	return l
+}
+
+// Serialize serializes an IPv6Reachability
+func (r *IPv6Reachability) Serialize(buf *bytes.Buffer) {
+	buf.Write(convert.Uint32Byte(r.Metric))
+	buf.WriteByte(r.Flags)
+	buf.WriteByte(r.Prefix.Len())
+
+	addr := r.Prefix.Addr()
+	buf.Write(addr.Bytes()[:r.Prefix.BytesInPrefix()])
+
+	if !r.hasSubTLVs() {
+		return
+	}
+
+	buf.WriteByte(r.SubTLVLength)
+	for _, stlv := range r.SubTLVs {
+		stlv.Serialize(buf)
+	}
+}
+
+func readIPv6Reachabilities(buf *bytes.Buffer) ([]*IPv6Reachability, error) {
+	ret := make([]*IPv6Reachability, 0)
+	for buf.Len() > 0 {
+		r, err := readIPv6Reachability(buf)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read IPv6 reachability: %w", err)
+		}
+
+		ret = append(ret, r)
+	}
+
+	return ret, nil
+}
+
+func readIPv6Reachability(buf *bytes.Buffer) (*IPv6Reachability, error) {
+	r := &IPv6Reachability{}
+	pfxLen := uint8(0)
+
+	fields := []interface{}{
+		&r.Metric,
+		&r.Flags,
+		&pfxLen,
+	}
+
+	err := decode.Decode(buf, fields)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode fields: %v", err)
+	}
+
+	if pfxLen > 128 {
+		return nil, fmt.Errorf("invalid prefix length %d", pfxLen)
+	}
+
+	addr := make([]byte, 16)
+	nBytes := bnet.BytesInAddr(pfxLen)
+	if n, _ := buf.Read(addr[:nBytes]); n != int(nBytes) {
+		return nil, fmt.Errorf("prefix too short")
+	}
+
+	ip, err := bnet.IPFromBytes(addr)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode prefix: %w", err)
+	}
+
+	r.Prefix = bnet.NewPfx(ip, pfxLen)
+	if !r.hasSubTLVs() {
+		return r, nil
+	}
+
+	r.SubTLVLength, err = buf.ReadByte()
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode sub TLVs length: %v", err)
+	}
+
+	r.SubTLVs, err = readSubTLVs(buf, r.SubTLVLength, readPrefixSubTLV)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
@@ -0,0 +1,118 @@
+package packet
+
+import (
+	"bytes"
+	"testing"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIPv6ReachabilityTLV(t *testing.T) {
+	tlv := NewIPv6ReachabilityTLV()
+	tlv.AddIPv6Reachability(NewIPv6Reachability(10, bnet.NewPfx(bnet.IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 1), 128)))
+	tlv.AddIPv6Reachability(NewIPv6Reachability(20, bnet.NewPfx(bnet.IPv6FromBlocks(0x2001, 0xdb8, 0x100, 0, 0, 0, 0, 0), 40)))
+
+	buf := bytes.NewBuffer(nil)
+	tlv.Serialize(buf)
+	assert.Equal(t, []byte{
+		236, 33,
+		0, 0, 0, 10, // Metric
+		0,   // Flags
+		128, // Prefix length
+		0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1,
+		0, 0, 0, 20, // Metric
+		0,  // Flags
+		40, // Prefix length
+		0x20, 0x01, 0x0d, 0xb8, 0x01,
+	}, buf.Bytes())
+
+	res, err := readTLV(buf)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, tlv, res)
+}
+
+func TestReadIPv6ReachabilityTLV(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []byte
+		wantFail bool
+		expected *IPv6ReachabilityTLV
+	}{
+		{
+			name: "External prefix with sub TLVs",
+			input: []byte{
+				0, 0, 0, 10, // Metric
+				0x60, // Flags (external, sub TLVs)
+				32,   // Prefix length
+				0x20, 0x01, 0x0d, 0xb8,
+				4,    // Sub TLVs length
+				1, 2, // Sub TLV
+				0, 1,
+			},
+			expected: &IPv6ReachabilityTLV{
+				TLVType:   236,
+				TLVLength: 15,
+				IPv6Reachabilities: []*IPv6Reachability{
+					{
+						Metric:       10,
+						Flags:        0x60,
+						Prefix:       bnet.NewPfx(bnet.IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 0), 32),
+						SubTLVLength: 4,
+						SubTLVs: []TLV{
+							&UnknownTLV{
+								TLVType:   1,
+								TLVLength: 2,
+								TLVValue:  []byte{0, 1},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Prefix too short",
+			input: []byte{
+				0, 0, 0, 10, // Metric
+				0,  // Flags
+				32, // Prefix length
+				0x20, 0x01,
+			},
+			wantFail: true,
+		},
+		{
+			name: "Invalid prefix length",
+			input: []byte{
+				0, 0, 0, 10, // Metric
+				0,   // Flags
+				129, // Prefix length
+			},
+			wantFail: true,
+		},
+	}
+
+	for _, test := range tests {
+		buf := bytes.NewBuffer(test.input)
+		tlv, err := readIPv6ReachabilityTLV(buf, 236, uint8(len(test.input)))
+		if err != nil {
+			if test.wantFail {
+				continue
+			}
+
+			t.Errorf("Unexpected failure for test %q: %v", test.name, err)
+			continue
+		}
+
+		if test.wantFail {
+			t.Errorf("Unexpected success for test %q", test.name)
+			continue
+		}
+
+		assert.Equal(t, test.expected, tlv, test.name)
+		assert.True(t, tlv.IPv6Reachabilities[0].External(), test.name)
+		assert.False(t, tlv.IPv6Reachabilities[0].Up(), test.name)
+	}
+}
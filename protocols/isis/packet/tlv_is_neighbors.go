@@ -4,63 +4,98 @@ import (
 	"bytes"
 	"fmt"
 
-	"github.com/bio-routing/bio-rd/protocols/isis/types"
-	"github.com/bio-routing/bio-rd/util/decode"
+	"github.com/bio-routing/bio-rd/net/ethernet"
 )
 
 // ISNeighborsTLVType is the type value of an IS Neighbor TLV
 const ISNeighborsTLVType = 6
 
-// ISNeighborsTLV represents an IS Neighbor TLV
+// ISNeighborsTLV represents an IS Neighbor TLV. It carries the SNPAs (MAC addresses) of all
+// neighbors a LAN hello has been received from.
 type ISNeighborsTLV struct {
-	TLVType      uint8
-	TLVLength    uint8
-	NeighborSNPA types.SystemID
+	TLVType       uint8
+	TLVLength     uint8
+	NeighborSNPAs []ethernet.MACAddr
 }
 
-// ISNeighborsTLVLength is the length of an IS Neighbor TLV
-const ISNeighborsTLVLength = 8
+// ISNeighborsSNPALength is the length of an SNPA in an IS Neighbor TLV
+const ISNeighborsSNPALength = 6
+
+// NewISNeighborsTLV creates a new IS Neighbors TLV
+func NewISNeighborsTLV(snpas []ethernet.MACAddr) *ISNeighborsTLV {
+	t := &ISNeighborsTLV{
+		TLVType:       ISNeighborsTLVType,
+		TLVLength:     uint8(len(snpas) * ISNeighborsSNPALength),
+		NeighborSNPAs: make([]ethernet.MACAddr, len(snpas)),
+	}
+
+	copy(t.NeighborSNPAs, snpas)
+	return t
+}
 
 func readISNeighborsTLV(buf *bytes.Buffer, tlvType uint8, tlvLength uint8) (*ISNeighborsTLV, error) {
-	pdu := &ISNeighborsTLV{
-		TLVType:   tlvType,
-		TLVLength: tlvLength,
+	if tlvLength%ISNeighborsSNPALength != 0 {
+		return nil, fmt.Errorf("invalid length %d", tlvLength)
+	}
+
+	data := buf.Next(int(tlvLength))
+	if len(data) != int(tlvLength) {
+		return nil, fmt.Errorf("TLV too short")
 	}
-	fields := []interface{}{
-		&pdu.NeighborSNPA,
+
+	pdu := &ISNeighborsTLV{
+		TLVType:       tlvType,
+		TLVLength:     tlvLength,
+		NeighborSNPAs: make([]ethernet.MACAddr, 0, tlvLength/ISNeighborsSNPALength),
 	}
 
-	err := decode.Decode(buf, fields)
-	if err != nil {
-		return nil, fmt.Errorf("unable to decode fields: %v", err)
+	for i := 0; i < len(data); i += ISNeighborsSNPALength {
+		var snpa ethernet.MACAddr
+		copy(snpa[:], data[i:i+ISNeighborsSNPALength])
+		pdu.NeighborSNPAs = append(pdu.NeighborSNPAs, snpa)
 	}
 
 	return pdu, nil
 }
 
-func (i ISNeighborsTLV) Copy() TLV {
-	ret := i
+// ContainsSNPA checks if snpa is listed in the TLV
+func (i *ISNeighborsTLV) ContainsSNPA(snpa ethernet.MACAddr) bool {
+	for _, n := range i.NeighborSNPAs {
+		if n == snpa {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (i *ISNeighborsTLV) Copy() TLV {
+	ret := *i
+	ret.NeighborSNPAs = make([]ethernet.MACAddr, len(i.NeighborSNPAs))
+	copy(ret.NeighborSNPAs, i.NeighborSNPAs)
 	return &ret
 }
 
 // Type returns the type of the TLV
-func (i ISNeighborsTLV) Type() uint8 {
+func (i *ISNeighborsTLV) Type() uint8 {
 	return i.TLVType
 }
 
 // Length returns the length of the TLV
-func (i ISNeighborsTLV) Length() uint8 {
+func (i *ISNeighborsTLV) Length() uint8 {
 	return i.TLVLength
 }
 
 // Value returns the TLV itself
-func (i ISNeighborsTLV) Value() interface{} {
+func (i *ISNeighborsTLV) Value() interface{} {
 	return i
 }
 
-// Serialize serializes an WriteByte into a buffer
-func (i ISNeighborsTLV) Serialize(buf *bytes.Buffer) {
+// Serialize serializes an IS Neighbors TLV into a buffer
+func (i *ISNeighborsTLV) Serialize(buf *bytes.Buffer) {
 	buf.WriteByte(i.TLVType)
 	buf.WriteByte(i.TLVLength)
-	buf.Write(i.NeighborSNPA[:])
+	for _, snpa := range i.NeighborSNPAs {
+		buf.Write(snpa[:])
+	}
 }
@@ -4,24 +4,26 @@ import (
 	"bytes"
 	"testing"
 
-	"github.com/bio-routing/bio-rd/protocols/isis/types"
+	"github.com/bio-routing/bio-rd/net/ethernet"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestIsNeighborsTLV(t *testing.T) {
-	tlv := &ISNeighborsTLV{
-		TLVType:      6,
-		TLVLength:    6,
-		NeighborSNPA: types.SystemID{1, 2, 3, 4, 5, 6},
-	}
+	tlv := NewISNeighborsTLV([]ethernet.MACAddr{
+		{1, 2, 3, 4, 5, 6},
+	})
 
 	assert.Equal(t, uint8(6), tlv.Type())
 	assert.Equal(t, uint8(6), tlv.Length())
-	assert.Equal(t, ISNeighborsTLV{
-		TLVType:      6,
-		TLVLength:    6,
-		NeighborSNPA: types.SystemID{1, 2, 3, 4, 5, 6},
+	assert.Equal(t, &ISNeighborsTLV{
+		TLVType:   6,
+		TLVLength: 6,
+		NeighborSNPAs: []ethernet.MACAddr{
+			{1, 2, 3, 4, 5, 6},
+		},
 	}, tlv.Value())
+	assert.True(t, tlv.ContainsSNPA(ethernet.MACAddr{1, 2, 3, 4, 5, 6}))
+	assert.False(t, tlv.ContainsSNPA(ethernet.MACAddr{1, 2, 3, 4, 5, 7}))
 }
 
 func TestReadISNeighborsTLV(t *testing.T) {
@@ -38,11 +40,39 @@ func TestReadISNeighborsTLV(t *testing.T) {
 			tlvLength: 6,
 			wantFail:  false,
 			expected: &ISNeighborsTLV{
-				TLVType:      6,
-				TLVLength:    6,
-				NeighborSNPA: types.SystemID{1, 2, 3, 4, 5, 6},
+				TLVType:   6,
+				TLVLength: 6,
+				NeighborSNPAs: []ethernet.MACAddr{
+					{1, 2, 3, 4, 5, 6},
+				},
+			},
+		},
+		{
+			name:      "Multiple neighbors",
+			input:     []byte{1, 2, 3, 4, 5, 6, 6, 5, 4, 3, 2, 1},
+			tlvLength: 12,
+			wantFail:  false,
+			expected: &ISNeighborsTLV{
+				TLVType:   6,
+				TLVLength: 12,
+				NeighborSNPAs: []ethernet.MACAddr{
+					{1, 2, 3, 4, 5, 6},
+					{6, 5, 4, 3, 2, 1},
+				},
 			},
 		},
+		{
+			name:      "Invalid length",
+			input:     []byte{1, 2, 3, 4, 5, 6, 7},
+			tlvLength: 7,
+			wantFail:  true,
+		},
+		{
+			name:      "Incomplete",
+			input:     []byte{1, 2, 3, 4, 5},
+			tlvLength: 6,
+			wantFail:  true,
+		},
 	}
 
 	for _, test := range tests {
@@ -74,13 +104,19 @@ func TestISNeighborsTLVSerialize(t *testing.T) {
 	}{
 		{
 			name: "Test #1",
-			input: &ISNeighborsTLV{
-				TLVType:      6,
-				TLVLength:    6,
-				NeighborSNPA: types.SystemID{1, 2, 3, 4, 5, 6},
-			},
+			input: NewISNeighborsTLV([]ethernet.MACAddr{
+				{1, 2, 3, 4, 5, 6},
+			}),
 			expected: []byte{6, 6, 1, 2, 3, 4, 5, 6},
 		},
+		{
+			name: "Test #2",
+			input: NewISNeighborsTLV([]ethernet.MACAddr{
+				{1, 2, 3, 4, 5, 6},
+				{6, 5, 4, 3, 2, 1},
+			}),
+			expected: []byte{6, 12, 1, 2, 3, 4, 5, 6, 6, 5, 4, 3, 2, 1},
+		},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,100 @@
+package packet
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/bio-routing/tflow2/convert"
+)
+
+// MTIntermediateSystemsTLVType is the type value of an MT Intermediate Systems TLV (RFC5120)
+const MTIntermediateSystemsTLVType = 222
+
+// MTIntermediateSystemsTLV is an MT Intermediate Systems TLV. It carries Extended IS Reachability neighbors of a topology.
+type MTIntermediateSystemsTLV struct {
+	TLVType   uint8
+	TLVLength uint8
+	MTID      uint16
+	Neighbors []*ExtendedISReachabilityNeighbor
+}
+
+// NewMTIntermediateSystemsTLV creates a new MT Intermediate Systems TLV
+func NewMTIntermediateSystemsTLV(mtID uint16) *MTIntermediateSystemsTLV {
+	return &MTIntermediateSystemsTLV{
+		TLVType:   MTIntermediateSystemsTLVType,
+		TLVLength: multiTopologySize,
+		MTID:      mtID,
+		Neighbors: make([]*ExtendedISReachabilityNeighbor, 0),
+	}
+}
+
+// AddNeighbor adds a neighbor to the MT Intermediate Systems TLV
+func (m *MTIntermediateSystemsTLV) AddNeighbor(n *ExtendedISReachabilityNeighbor) {
+	m.TLVLength += n.Length()
+	m.Neighbors = append(m.Neighbors, n)
+}
+
+func readMTIntermediateSystemsTLV(buf *bytes.Buffer, tlvType uint8, tlvLength uint8) (*MTIntermediateSystemsTLV, error) {
+	data := buf.Next(int(tlvLength))
+	if len(data) != int(tlvLength) {
+		return nil, fmt.Errorf("TLV too short")
+	}
+
+	if len(data) < multiTopologySize {
+		return nil, fmt.Errorf("MT ID missing")
+	}
+
+	pdu := &MTIntermediateSystemsTLV{
+		TLVType:   tlvType,
+		TLVLength: tlvLength,
+		MTID:      convert.Uint16b(data[:multiTopologySize]) & mtIDMask,
+		Neighbors: make([]*ExtendedISReachabilityNeighbor, 0),
+	}
+
+	tlvBuf := bytes.NewBuffer(data[multiTopologySize:])
+	for tlvBuf.Len() > 0 {
+		n, err := readExtendedISReachabilityNeighbor(tlvBuf)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read extended IS reachability neighbor: %w", err)
+		}
+
+		pdu.Neighbors = append(pdu.Neighbors, n)
+	}
+
+	return pdu, nil
+}
+
+func (m *MTIntermediateSystemsTLV) Copy() TLV {
+	ret := *m
+	ret.Neighbors = make([]*ExtendedISReachabilityNeighbor, 0, len(m.Neighbors))
+	for _, n := range m.Neighbors {
+		ret.Neighbors = append(ret.Neighbors, n.Copy())
+	}
+
+	return &ret
+}
+
+// Type gets the type of the TLV
+func (m *MTIntermediateSystemsTLV) Type() uint8 {
+	return m.TLVType
+}
+
+// Length gets the length of the TLV
+func (m *MTIntermediateSystemsTLV) Length() uint8 {
+	return m.TLVLength
+}
+
+// Value returns the TLV itself
+func (m *MTIntermediateSystemsTLV) Value() interface{} {
+	return m
+}
+
+// Serialize serializes an MT Intermediate Systems TLV
+func (m *MTIntermediateSystemsTLV) Serialize(buf *bytes.Buffer) {
+	buf.WriteByte(m.TLVType)
+	buf.WriteByte(m.TLVLength)
+	buf.Write(convert.Uint16Byte(m.MTID & mtIDMask))
+	for _, n := range m.Neighbors {
+		n.Serialize(buf)
+	}
+}
@@ -0,0 +1,42 @@
+package packet
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/bio-routing/bio-rd/protocols/isis/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMTIntermediateSystemsTLV(t *testing.T) {
+	n := NewExtendedISReachabilityNeighbor(types.NewSourceID(types.SystemID{1, 2, 3, 4, 5, 6}, 0), 10)
+	n.AddSubTLV(NewIPv4InterfaceAddressSubTLV(0xc0000201))
+
+	tlv := NewMTIntermediateSystemsTLV(MTIDIPv6Unicast)
+	tlv.AddNeighbor(n)
+
+	buf := bytes.NewBuffer(nil)
+	tlv.Serialize(buf)
+	assert.Equal(t, []byte{
+		222, 19,
+		0, 2, // MT ID
+		1, 2, 3, 4, 5, 6, // System ID
+		0,        // Pseudonode ID
+		0, 0, 10, // Metric
+		6,    // Sub TLVs length
+		6, 4, // IPv4 Interface Address
+		192, 0, 2, 1,
+	}, buf.Bytes())
+
+	res, err := readTLV(buf)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, tlv, res)
+}
+
+func TestReadMTIntermediateSystemsTLVMissingMTID(t *testing.T) {
+	_, err := readMTIntermediateSystemsTLV(bytes.NewBuffer([]byte{0}), 222, 1)
+	assert.Error(t, err)
+}
@@ -0,0 +1,89 @@
+package packet
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/bio-routing/tflow2/convert"
+)
+
+// MTIPv6ReachabilityTLVType is the type value of an MT IPv6 Reachability TLV (RFC5120)
+const MTIPv6ReachabilityTLVType = 237
+
+// MTIPv6ReachabilityTLV is an MT IPv6 Reachability TLV. It carries IPv6 Reachabilities of a topology.
+type MTIPv6ReachabilityTLV struct {
+	TLVType            uint8
+	TLVLength          uint8
+	MTID               uint16
+	IPv6Reachabilities []*IPv6Reachability
+}
+
+// NewMTIPv6ReachabilityTLV creates a new MT IPv6 Reachability TLV
+func NewMTIPv6ReachabilityTLV(mtID uint16) *MTIPv6ReachabilityTLV {
+	return &MTIPv6ReachabilityTLV{
+		TLVType:            MTIPv6ReachabilityTLVType,
+		TLVLength:          multiTopologySize,
+		MTID:               mtID,
+		IPv6Reachabilities: make([]*IPv6Reachability, 0),
+	}
+}
+
+// AddIPv6Reachability adds an IPv6 reachability
+func (m *MTIPv6ReachabilityTLV) AddIPv6Reachability(r *IPv6Reachability) {
+	m.IPv6Reachabilities = append(m.IPv6Reachabilities, r)
+	m.TLVLength += r.Length()
+}
+
+func readMTIPv6ReachabilityTLV(buf *bytes.Buffer, tlvType uint8, tlvLength uint8) (*MTIPv6ReachabilityTLV, error) {
+	data := buf.Next(int(tlvLength))
+	if len(data) != int(tlvLength) {
+		return nil, fmt.Errorf("TLV too short")
+	}
+
+	if len(data) < multiTopologySize {
+		return nil, fmt.Errorf("MT ID missing")
+	}
+
+	reachabilities, err := readIPv6Reachabilities(bytes.NewBuffer(data[multiTopologySize:]))
+	if err != nil {
+		return nil, err
+	}
+
+	return &MTIPv6ReachabilityTLV{
+		TLVType:            tlvType,
+		TLVLength:          tlvLength,
+		MTID:               convert.Uint16b(data[:multiTopologySize]) & mtIDMask,
+		IPv6Reachabilities: reachabilities,
+	}, nil
+}
+
+func (m *MTIPv6ReachabilityTLV) Copy() TLV {
+	ret := *m
+	ret.IPv6Reachabilities = copyIPv6Reachabilities(m.IPv6Reachabilities)
+	return &ret
+}
+
+// Type gets the type of the TLV
+func (m *MTIPv6ReachabilityTLV) Type() uint8 {
+	return m.TLVType
+}
+
+// Length gets the length of the TLV
+func (m *MTIPv6ReachabilityTLV) Length() uint8 {
+	return m.TLVLength
+}
+
+// Value returns the TLV itself
+func (m *MTIPv6ReachabilityTLV) Value() interface{} {
+	return m
+}
+
+// Serialize serializes an MT IPv6 Reachability TLV
+func (m *MTIPv6ReachabilityTLV) Serialize(buf *bytes.Buffer) {
+	buf.WriteByte(m.TLVType)
+	buf.WriteByte(m.TLVLength)
+	buf.Write(convert.Uint16Byte(m.MTID & mtIDMask))
+	for _, r := range m.IPv6Reachabilities {
+		r.Serialize(buf)
+	}
+}
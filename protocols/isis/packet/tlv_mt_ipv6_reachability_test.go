@@ -0,0 +1,37 @@
+package packet
+
+import (
+	"bytes"
+	"testing"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMTIPv6ReachabilityTLV(t *testing.T) {
+	tlv := NewMTIPv6ReachabilityTLV(MTIDIPv6Unicast)
+	tlv.AddIPv6Reachability(NewIPv6Reachability(10, bnet.NewPfx(bnet.IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 0), 32)))
+
+	buf := bytes.NewBuffer(nil)
+	tlv.Serialize(buf)
+	assert.Equal(t, []byte{
+		237, 12,
+		0, 2, // MT ID
+		0, 0, 0, 10, // Metric
+		0,  // Flags
+		32, // Prefix length
+		0x20, 0x01, 0x0d, 0xb8,
+	}, buf.Bytes())
+
+	res, err := readTLV(buf)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, tlv, res)
+}
+
+func TestReadMTIPv6ReachabilityTLVMissingMTID(t *testing.T) {
+	_, err := readMTIPv6ReachabilityTLV(bytes.NewBuffer([]byte{0}), 237, 1)
+	assert.Error(t, err)
+}
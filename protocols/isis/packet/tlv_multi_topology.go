@@ -0,0 +1,136 @@
+package packet
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/bio-routing/tflow2/convert"
+)
+
+const (
+	// MultiTopologyTLVType is the type value of a Multi-Topology TLV (RFC5120)
+	MultiTopologyTLVType = 229
+
+	// MTIDStandard is the ID of the standard topology
+	MTIDStandard = 0
+
+	// MTIDIPv6Unicast is the ID of the IPv6 unicast topology
+	MTIDIPv6Unicast = 2
+
+	mtIDMask          = 0x0fff
+	mtOverloadFlag    = 0x8000
+	mtAttachedFlag    = 0x4000
+	multiTopologySize = 2
+)
+
+// MultiTopology is a topology an IS participates in
+type MultiTopology struct {
+	MTID     uint16
+	Overload bool
+	Attached bool
+}
+
+func (m MultiTopology) serialize() uint16 {
+	x := m.MTID & mtIDMask
+	if m.Overload {
+		x |= mtOverloadFlag
+	}
+
+	if m.Attached {
+		x |= mtAttachedFlag
+	}
+
+	return x
+}
+
+func multiTopologyFromUint16(x uint16) MultiTopology {
+	return MultiTopology{
+		MTID:     x & mtIDMask,
+		Overload: x&mtOverloadFlag != 0,
+		Attached: x&mtAttachedFlag != 0,
+	}
+}
+
+// MultiTopologyTLV is a Multi-Topology TLV
+type MultiTopologyTLV struct {
+	TLVType    uint8
+	TLVLength  uint8
+	Topologies []MultiTopology
+}
+
+// NewMultiTopologyTLV creates a new Multi-Topology TLV
+func NewMultiTopologyTLV(topologies []MultiTopology) *MultiTopologyTLV {
+	t := &MultiTopologyTLV{
+		TLVType:    MultiTopologyTLVType,
+		TLVLength:  uint8(len(topologies) * multiTopologySize),
+		Topologies: make([]MultiTopology, len(topologies)),
+	}
+
+	copy(t.Topologies, topologies)
+	return t
+}
+
+func readMultiTopologyTLV(buf *bytes.Buffer, tlvType uint8, tlvLength uint8) (*MultiTopologyTLV, error) {
+	if tlvLength%multiTopologySize != 0 {
+		return nil, fmt.Errorf("invalid length %d", tlvLength)
+	}
+
+	data := buf.Next(int(tlvLength))
+	if len(data) != int(tlvLength) {
+		return nil, fmt.Errorf("TLV too short")
+	}
+
+	pdu := &MultiTopologyTLV{
+		TLVType:    tlvType,
+		TLVLength:  tlvLength,
+		Topologies: make([]MultiTopology, 0, tlvLength/multiTopologySize),
+	}
+
+	for i := 0; i < len(data); i += multiTopologySize {
+		pdu.Topologies = append(pdu.Topologies, multiTopologyFromUint16(convert.Uint16b(data[i:i+multiTopologySize])))
+	}
+
+	return pdu, nil
+}
+
+// HasTopology checks if the topology with ID mtID is contained
+func (m *MultiTopologyTLV) HasTopology(mtID uint16) bool {
+	for _, t := range m.Topologies {
+		if t.MTID == mtID {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *MultiTopologyTLV) Copy() TLV {
+	ret := *m
+	ret.Topologies = make([]MultiTopology, len(m.Topologies))
+	copy(ret.Topologies, m.Topologies)
+	return &ret
+}
+
+// Type gets the type of the TLV
+func (m *MultiTopologyTLV) Type() uint8 {
+	return m.TLVType
+}
+
+// Length gets the length of the TLV
+func (m *MultiTopologyTLV) Length() uint8 {
+	return m.TLVLength
+}
+
+// Value returns the TLV itself
+func (m *MultiTopologyTLV) Value() interface{} {
+	return m
+}
+
+// Serialize serializes a Multi-Topology TLV
+func (m *MultiTopologyTLV) Serialize(buf *bytes.Buffer) {
+	buf.WriteByte(m.TLVType)
+	buf.WriteByte(m.TLVLength)
+	for _, t := range m.Topologies {
+		buf.Write(convert.Uint16Byte(t.serialize()))
+	}
+}
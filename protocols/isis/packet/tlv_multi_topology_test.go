@@ -0,0 +1,47 @@
+package packet
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiTopologyTLV(t *testing.T) {
+	tlv := NewMultiTopologyTLV([]MultiTopology{
+		{
+			MTID: MTIDStandard,
+		},
+		{
+			MTID:     MTIDIPv6Unicast,
+			Overload: true,
+		},
+		{
+			MTID:     4095,
+			Attached: true,
+		},
+	})
+
+	buf := bytes.NewBuffer(nil)
+	tlv.Serialize(buf)
+	assert.Equal(t, []byte{
+		229, 6,
+		0, 0,
+		0x80, 2,
+		0x4f, 0xff,
+	}, buf.Bytes())
+
+	res, err := readTLV(buf)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, tlv, res)
+	assert.True(t, tlv.HasTopology(MTIDIPv6Unicast))
+	assert.False(t, tlv.HasTopology(1))
+}
+
+func TestReadMultiTopologyTLVInvalidLength(t *testing.T) {
+	_, err := readMultiTopologyTLV(bytes.NewBuffer([]byte{0, 0, 2}), 229, 3)
+	assert.Error(t, err)
+}
@@ -0,0 +1,315 @@
+package packet
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/bio-routing/tflow2/convert"
+)
+
+const (
+	// RouterCapabilityTLVType is the type value of a Router Capability TLV (RFC7981)
+	RouterCapabilityTLVType = 242
+
+	// RouterCapabilityMinLength is the length of a Router Capability TLV excluding sub TLVs
+	RouterCapabilityMinLength = 5
+
+	// RouterCapabilityFlagS indicates the TLV is flooded across the entire routing domain
+	RouterCapabilityFlagS = 0x01
+
+	// RouterCapabilityFlagD indicates the TLV has been leaked from level 2 into level 1
+	RouterCapabilityFlagD = 0x02
+
+	// SRCapabilitiesSubTLVType is the type value of an SR-Capabilities sub TLV (RFC8667)
+	SRCapabilitiesSubTLVType = 2
+
+	// SRAlgorithmSubTLVType is the type value of an SR-Algorithm sub TLV (RFC8667)
+	SRAlgorithmSubTLVType = 19
+
+	// SRLocalBlockSubTLVType is the type value of an SR Local Block sub TLV (RFC8667)
+	SRLocalBlockSubTLVType = 22
+
+	// SIDLabelSubTLVType is the type value of a SID/Label sub TLV (RFC8667)
+	SIDLabelSubTLVType = 1
+
+	// SRCapabilitiesFlagMPLSIPv4 indicates the router processes SR-MPLS encapsulated IPv4 packets
+	SRCapabilitiesFlagMPLSIPv4 = 0x80
+
+	// SRCapabilitiesFlagMPLSIPv6 indicates the router processes SR-MPLS encapsulated IPv6 packets
+	SRCapabilitiesFlagMPLSIPv6 = 0x40
+
+	// SRAlgorithmSPF is the shortest path first algorithm based on link metric
+	SRAlgorithmSPF = 0
+
+	// SRAlgorithmStrictSPF is the shortest path first algorithm not altered by local policy
+	SRAlgorithmStrictSPF = 1
+
+	sidRangeLen = 3
+)
+
+// RouterCapabilityTLV is a Router Capability TLV
+type RouterCapabilityTLV struct {
+	TLVType   uint8
+	TLVLength uint8
+	RouterID  uint32
+	Flags     uint8
+	SubTLVs   []TLV
+}
+
+// NewRouterCapabilityTLV creates a new Router Capability TLV
+func NewRouterCapabilityTLV(routerID uint32, flags uint8) *RouterCapabilityTLV {
+	return &RouterCapabilityTLV{
+		TLVType:   RouterCapabilityTLVType,
+		TLVLength: RouterCapabilityMinLength,
+		RouterID:  routerID,
+		Flags:     flags,
+		SubTLVs:   make([]TLV, 0),
+	}
+}
+
+// AddSubTLV adds a sub TLV to the Router Capability TLV
+func (r *RouterCapabilityTLV) AddSubTLV(tlv TLV) {
+	r.TLVLength += tlvBaseLen + tlv.Length()
+	r.SubTLVs = append(r.SubTLVs, tlv)
+}
+
+func readRouterCapabilityTLV(buf *bytes.Buffer, tlvType uint8, tlvLength uint8) (*RouterCapabilityTLV, error) {
+	data := buf.Next(int(tlvLength))
+	if len(data) != int(tlvLength) {
+		return nil, fmt.Errorf("TLV too short")
+	}
+
+	if len(data) < RouterCapabilityMinLength {
+		return nil, fmt.Errorf("router ID or flags missing")
+	}
+
+	subTLVs, err := readSubTLVs(bytes.NewBuffer(data[RouterCapabilityMinLength:]), tlvLength-RouterCapabilityMinLength, readRouterCapabilitySubTLV)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RouterCapabilityTLV{
+		TLVType:   tlvType,
+		TLVLength: tlvLength,
+		RouterID:  convert.Uint32b(data[:4]),
+		Flags:     data[4],
+		SubTLVs:   subTLVs,
+	}, nil
+}
+
+// readRouterCapabilitySubTLV reads a sub TLV of a Router Capability TLV
+func readRouterCapabilitySubTLV(buf *bytes.Buffer, tlvType uint8, tlvLength uint8) (TLV, error) {
+	switch tlvType {
+	case SRCapabilitiesSubTLVType, SRLocalBlockSubTLVType:
+		return readSRCapabilitiesSubTLV(buf, tlvType, tlvLength)
+	case SRAlgorithmSubTLVType:
+		return &SRAlgorithmSubTLV{
+			TLVType:    tlvType,
+			TLVLength:  tlvLength,
+			Algorithms: append([]uint8(nil), buf.Next(int(tlvLength))...),
+		}, nil
+	}
+
+	return readUnknownTLV(buf, tlvType, tlvLength)
+}
+
+func (r *RouterCapabilityTLV) Copy() TLV {
+	ret := *r
+	ret.SubTLVs = make([]TLV, 0, len(r.SubTLVs))
+	for _, stlv := range r.SubTLVs {
+		ret.SubTLVs = append(ret.SubTLVs, stlv.Copy())
+	}
+
+	return &ret
+}
+
+// Type gets the type of the TLV
+func (r *RouterCapabilityTLV) Type() uint8 {
+	return r.TLVType
+}
+
+// Length gets the length of the TLV
+func (r *RouterCapabilityTLV) Length() uint8 {
+	return r.TLVLength
+}
+
+// Value returns the TLV itself
+func (r *RouterCapabilityTLV) Value() interface{} {
+	return r
+}
+
+// Serialize serializes a Router Capability TLV
+func (r *RouterCapabilityTLV) Serialize(buf *bytes.Buffer) {
+	buf.WriteByte(r.TLVType)
+	buf.WriteByte(r.TLVLength)
+	buf.Write(convert.Uint32Byte(r.RouterID))
+	buf.WriteByte(r.Flags)
+	for _, stlv := range r.SubTLVs {
+		stlv.Serialize(buf)
+	}
+}
+
+// SIDLabelRange is a range of SIDs or labels starting at First. First is a label if Label is set, an index otherwise.
+type SIDLabelRange struct {
+	Range uint32
+	First uint32
+	Label bool
+}
+
+func (s SIDLabelRange) length() uint8 {
+	return sidRangeLen + tlvBaseLen + sidLen(s.Label)
+}
+
+func (s SIDLabelRange) serialize(buf *bytes.Buffer) {
+	buf.Write(convert.Uint32Byte(s.Range)[1:])
+	buf.WriteByte(SIDLabelSubTLVType)
+	buf.WriteByte(sidLen(s.Label))
+	serializeSID(buf, s.First, s.Label)
+}
+
+// SRCapabilitiesSubTLV is an SR-Capabilities or SR Local Block sub TLV. Both share the same format
+// apart from the SR Local Block not defining any flags.
+type SRCapabilitiesSubTLV struct {
+	TLVType   uint8
+	TLVLength uint8
+	Flags     uint8
+	Ranges    []SIDLabelRange
+}
+
+// NewSRCapabilitiesSubTLV creates a new SR-Capabilities sub TLV advertising the SR global block
+func NewSRCapabilitiesSubTLV(flags uint8, ranges []SIDLabelRange) *SRCapabilitiesSubTLV {
+	return newSRCapabilitiesSubTLV(SRCapabilitiesSubTLVType, flags, ranges)
+}
+
+// NewSRLocalBlockSubTLV creates a new SR Local Block sub TLV
+func NewSRLocalBlockSubTLV(ranges []SIDLabelRange) *SRCapabilitiesSubTLV {
+	return newSRCapabilitiesSubTLV(SRLocalBlockSubTLVType, 0, ranges)
+}
+
+func newSRCapabilitiesSubTLV(tlvType uint8, flags uint8, ranges []SIDLabelRange) *SRCapabilitiesSubTLV {
+	s := &SRCapabilitiesSubTLV{
+		TLVType:   tlvType,
+		TLVLength: 1,
+		Flags:     flags,
+		Ranges:    ranges,
+	}
+
+	for _, r := range ranges {
+		s.TLVLength += r.length()
+	}
+
+	return s
+}
+
+func readSRCapabilitiesSubTLV(buf *bytes.Buffer, tlvType uint8, tlvLength uint8) (*SRCapabilitiesSubTLV, error) {
+	data := buf.Next(int(tlvLength))
+	if len(data) < 1 {
+		return nil, fmt.Errorf("flags missing")
+	}
+
+	s := &SRCapabilitiesSubTLV{
+		TLVType:   tlvType,
+		TLVLength: tlvLength,
+		Flags:     data[0],
+		Ranges:    make([]SIDLabelRange, 0),
+	}
+
+	data = data[1:]
+	for len(data) > 0 {
+		if len(data) < sidRangeLen+tlvBaseLen {
+			return nil, fmt.Errorf("SID range too short")
+		}
+
+		r := SIDLabelRange{
+			Range: uint32(data[0])<<16 | uint32(data[1])<<8 | uint32(data[2]),
+		}
+
+		sidType, sidLength := data[3], data[4]
+		data = data[sidRangeLen+tlvBaseLen:]
+		if sidType != SIDLabelSubTLVType || (sidLength != sidLabelLen && sidLength != sidIndexLen) || len(data) < int(sidLength) {
+			return nil, fmt.Errorf("invalid SID/Label sub TLV (type %d, length %d)", sidType, sidLength)
+		}
+
+		r.First = decodeSID(data[:sidLength])
+		r.Label = sidLength == sidLabelLen
+		s.Ranges = append(s.Ranges, r)
+		data = data[sidLength:]
+	}
+
+	return s, nil
+}
+
+func (s *SRCapabilitiesSubTLV) Copy() TLV {
+	ret := *s
+	ret.Ranges = append([]SIDLabelRange(nil), s.Ranges...)
+	return &ret
+}
+
+// Type gets the type of the TLV
+func (s *SRCapabilitiesSubTLV) Type() uint8 {
+	return s.TLVType
+}
+
+// Length gets the length of the TLV
+func (s *SRCapabilitiesSubTLV) Length() uint8 {
+	return s.TLVLength
+}
+
+// Value returns the TLV itself
+func (s *SRCapabilitiesSubTLV) Value() interface{} {
+	return s
+}
+
+// Serialize serializes an SR-Capabilities sub TLV
+func (s *SRCapabilitiesSubTLV) Serialize(buf *bytes.Buffer) {
+	buf.WriteByte(s.TLVType)
+	buf.WriteByte(s.TLVLength)
+	buf.WriteByte(s.Flags)
+	for _, r := range s.Ranges {
+		r.serialize(buf)
+	}
+}
+
+// SRAlgorithmSubTLV is an SR-Algorithm sub TLV
+type SRAlgorithmSubTLV struct {
+	TLVType    uint8
+	TLVLength  uint8
+	Algorithms []uint8
+}
+
+// NewSRAlgorithmSubTLV creates a new SR-Algorithm sub TLV
+func NewSRAlgorithmSubTLV(algorithms []uint8) *SRAlgorithmSubTLV {
+	return &SRAlgorithmSubTLV{
+		TLVType:    SRAlgorithmSubTLVType,
+		TLVLength:  uint8(len(algorithms)),
+		Algorithms: algorithms,
+	}
+}
+
+func (s *SRAlgorithmSubTLV) Copy() TLV {
+	ret := *s
+	ret.Algorithms = append([]uint8(nil), s.Algorithms...)
+	return &ret
+}
+
+// Type gets the type of the TLV
+func (s *SRAlgorithmSubTLV) Type() uint8 {
+	return s.TLVType
+}
+
+// Length gets the length of the TLV
+func (s *SRAlgorithmSubTLV) Length() uint8 {
+	return s.TLVLength
+}
+
+// Value returns the TLV itself
+func (s *SRAlgorithmSubTLV) Value() interface{} {
+	return s
+}
+
+// Serialize serializes an SR-Algorithm sub TLV
+func (s *SRAlgorithmSubTLV) Serialize(buf *bytes.Buffer) {
+	buf.WriteByte(s.TLVType)
+	buf.WriteByte(s.TLVLength)
+	buf.Write(s.Algorithms)
+}
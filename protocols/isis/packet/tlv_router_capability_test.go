@@ -0,0 +1,131 @@
+package packet
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouterCapabilityTLV(t *testing.T) {
+	tlv := NewRouterCapabilityTLV(0x0a000001, 0)
+	tlv.AddSubTLV(NewSRCapabilitiesSubTLV(SRCapabilitiesFlagMPLSIPv4, []SIDLabelRange{
+		{
+			Range: 8000,
+			First: 16000,
+			Label: true,
+		},
+	}))
+	tlv.AddSubTLV(NewSRAlgorithmSubTLV([]uint8{SRAlgorithmSPF}))
+	tlv.AddSubTLV(NewSRLocalBlockSubTLV([]SIDLabelRange{
+		{
+			Range: 1000,
+			First: 15000,
+			Label: true,
+		},
+	}))
+
+	buf := bytes.NewBuffer(nil)
+	tlv.Serialize(buf)
+	assert.Equal(t, []byte{
+		242, 30,
+		10, 0, 0, 1, // Router ID
+		0,    // Flags
+		2, 9, // SR-Capabilities
+		0x80,             // Flags
+		0x00, 0x1f, 0x40, // Range
+		1, 3, 0x00, 0x3e, 0x80, // SID/Label
+		19, 1, // SR-Algorithm
+		0,
+		22, 9, // SR Local Block
+		0,                // Flags
+		0x00, 0x03, 0xe8, // Range
+		1, 3, 0x00, 0x3a, 0x98, // SID/Label
+	}, buf.Bytes())
+
+	res, err := readTLV(buf)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, tlv, res)
+}
+
+func TestReadRouterCapabilityTLV(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []byte
+		wantFail bool
+		expected *RouterCapabilityTLV
+	}{
+		{
+			name: "SID index and unknown sub TLV",
+			input: []byte{
+				10, 0, 0, 1, // Router ID
+				RouterCapabilityFlagS,
+				2, 10, // SR-Capabilities
+				0xc0,
+				0x00, 0x00, 0x10,
+				1, 4, 0, 0, 0, 100,
+				99, 1, 0, // Unknown
+			},
+			expected: &RouterCapabilityTLV{
+				TLVType:   RouterCapabilityTLVType,
+				TLVLength: 20,
+				RouterID:  0x0a000001,
+				Flags:     RouterCapabilityFlagS,
+				SubTLVs: []TLV{
+					&SRCapabilitiesSubTLV{
+						TLVType:   SRCapabilitiesSubTLVType,
+						TLVLength: 10,
+						Flags:     SRCapabilitiesFlagMPLSIPv4 | SRCapabilitiesFlagMPLSIPv6,
+						Ranges: []SIDLabelRange{
+							{
+								Range: 16,
+								First: 100,
+							},
+						},
+					},
+					&UnknownTLV{
+						TLVType:   99,
+						TLVLength: 1,
+						TLVValue:  []byte{0},
+					},
+				},
+			},
+		},
+		{
+			name: "Router ID too short",
+			input: []byte{
+				10, 0, 0, 1,
+			},
+			wantFail: true,
+		},
+		{
+			name: "Invalid SID/Label sub TLV",
+			input: []byte{
+				10, 0, 0, 1, // Router ID
+				0,
+				2, 8, // SR-Capabilities
+				0x80,
+				0x00, 0x00, 0x10,
+				1, 2, 0, 0,
+			},
+			wantFail: true,
+		},
+	}
+
+	for _, test := range tests {
+		tlv, err := readRouterCapabilityTLV(bytes.NewBuffer(test.input), RouterCapabilityTLVType, uint8(len(test.input)))
+		if test.wantFail {
+			assert.Errorf(t, err, "Test %q", test.name)
+			continue
+		}
+
+		if !assert.NoErrorf(t, err, "Test %q", test.name) {
+			continue
+		}
+
+		assert.Equalf(t, test.expected, tlv, "Test %q", test.name)
+	}
+}
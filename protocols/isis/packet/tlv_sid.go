@@ -0,0 +1,260 @@
+package packet
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/bio-routing/bio-rd/protocols/isis/types"
+	"github.com/bio-routing/tflow2/convert"
+)
+
+const (
+	// PrefixSIDSubTLVType is the type value of a Prefix-SID sub TLV (RFC8667)
+	PrefixSIDSubTLVType = 3
+
+	// AdjacencySIDSubTLVType is the type value of an Adjacency-SID sub TLV (RFC8667)
+	AdjacencySIDSubTLVType = 31
+
+	// LANAdjacencySIDSubTLVType is the type value of a LAN-Adjacency-SID sub TLV (RFC8667)
+	LANAdjacencySIDSubTLVType = 32
+
+	// PrefixSIDFlagReadvertisement indicates the prefix has been propagated from another level or redistributed
+	PrefixSIDFlagReadvertisement = 0x80
+
+	// PrefixSIDFlagNode indicates the SID identifies the node advertising the prefix
+	PrefixSIDFlagNode = 0x40
+
+	// PrefixSIDFlagNoPHP requests the penultimate hop not to pop the label
+	PrefixSIDFlagNoPHP = 0x20
+
+	// PrefixSIDFlagExplicitNull requests the penultimate hop to replace the label by the explicit null label
+	PrefixSIDFlagExplicitNull = 0x10
+
+	// PrefixSIDFlagValue indicates the SID carries a label instead of an index
+	PrefixSIDFlagValue = 0x08
+
+	// PrefixSIDFlagLocal indicates the SID has local significance
+	PrefixSIDFlagLocal = 0x04
+
+	// AdjacencySIDFlagAddressFamily indicates the adjacency is used for IPv6
+	AdjacencySIDFlagAddressFamily = 0x80
+
+	// AdjacencySIDFlagBackup indicates the SID is eligible for protection
+	AdjacencySIDFlagBackup = 0x40
+
+	// AdjacencySIDFlagValue indicates the SID carries a label instead of an index
+	AdjacencySIDFlagValue = 0x20
+
+	// AdjacencySIDFlagLocal indicates the SID has local significance
+	AdjacencySIDFlagLocal = 0x10
+
+	// AdjacencySIDFlagSet indicates the SID refers to a set of adjacencies
+	AdjacencySIDFlagSet = 0x08
+
+	// AdjacencySIDFlagPersistent indicates the SID is persistently allocated
+	AdjacencySIDFlagPersistent = 0x04
+
+	sidLabelLen = 3
+	sidIndexLen = 4
+	labelMask   = 0x000fffff
+)
+
+func sidLen(label bool) uint8 {
+	if label {
+		return sidLabelLen
+	}
+
+	return sidIndexLen
+}
+
+// serializeSID serializes a SID. Labels are encoded in 3 octets, indexes in 4 octets.
+func serializeSID(buf *bytes.Buffer, sid uint32, label bool) {
+	if label {
+		buf.Write(convert.Uint32Byte(sid & labelMask)[1:])
+		return
+	}
+
+	buf.Write(convert.Uint32Byte(sid))
+}
+
+func decodeSID(data []byte) uint32 {
+	if len(data) == sidLabelLen {
+		return (uint32(data[0])<<16 | uint32(data[1])<<8 | uint32(data[2])) & labelMask
+	}
+
+	return convert.Uint32b(data)
+}
+
+// PrefixSIDSubTLV is a Prefix-SID sub TLV of an Extended IP Reachability
+type PrefixSIDSubTLV struct {
+	TLVType   uint8
+	TLVLength uint8
+	Flags     uint8
+	Algorithm uint8
+	SID       uint32
+}
+
+// NewPrefixSIDSubTLV creates a new Prefix-SID sub TLV. The SID is a label if the V and L flags are set, an index otherwise.
+func NewPrefixSIDSubTLV(flags uint8, algorithm uint8, sid uint32) *PrefixSIDSubTLV {
+	p := &PrefixSIDSubTLV{
+		TLVType:   PrefixSIDSubTLVType,
+		Flags:     flags,
+		Algorithm: algorithm,
+		SID:       sid,
+	}
+
+	p.TLVLength = 2 + sidLen(p.IsLabel())
+	return p
+}
+
+func readPrefixSIDSubTLV(buf *bytes.Buffer, tlvType uint8, tlvLength uint8) (*PrefixSIDSubTLV, error) {
+	data := buf.Next(int(tlvLength))
+	if len(data) != int(tlvLength) {
+		return nil, fmt.Errorf("prefix SID sub TLV too short")
+	}
+
+	return &PrefixSIDSubTLV{
+		TLVType:   tlvType,
+		TLVLength: tlvLength,
+		Flags:     data[0],
+		Algorithm: data[1],
+		SID:       decodeSID(data[2:]),
+	}, nil
+}
+
+// IsLabel checks if the SID is a label
+func (p *PrefixSIDSubTLV) IsLabel() bool {
+	return p.Flags&PrefixSIDFlagValue != 0 && p.Flags&PrefixSIDFlagLocal != 0
+}
+
+// IsNodeSID checks if the SID identifies the advertising node
+func (p *PrefixSIDSubTLV) IsNodeSID() bool {
+	return p.Flags&PrefixSIDFlagNode != 0
+}
+
+func (p *PrefixSIDSubTLV) Copy() TLV {
+	ret := *p
+	return &ret
+}
+
+// Type gets the type of the TLV
+func (p *PrefixSIDSubTLV) Type() uint8 {
+	return p.TLVType
+}
+
+// Length gets the length of the TLV
+func (p *PrefixSIDSubTLV) Length() uint8 {
+	return p.TLVLength
+}
+
+// Value returns the TLV itself
+func (p *PrefixSIDSubTLV) Value() interface{} {
+	return p
+}
+
+// Serialize serializes a Prefix-SID sub TLV
+func (p *PrefixSIDSubTLV) Serialize(buf *bytes.Buffer) {
+	buf.WriteByte(p.TLVType)
+	buf.WriteByte(p.TLVLength)
+	buf.WriteByte(p.Flags)
+	buf.WriteByte(p.Algorithm)
+	serializeSID(buf, p.SID, p.TLVLength == 2+sidLabelLen)
+}
+
+// AdjacencySIDSubTLV is an Adjacency-SID or LAN-Adjacency-SID sub TLV of an Extended IS Reachability.
+// NeighborID is only present in LAN-Adjacency-SIDs.
+type AdjacencySIDSubTLV struct {
+	TLVType    uint8
+	TLVLength  uint8
+	Flags      uint8
+	Weight     uint8
+	NeighborID types.SystemID
+	SID        uint32
+}
+
+// NewAdjacencySIDSubTLV creates a new Adjacency-SID sub TLV. The SID is a label if the V and L flags are set, an index otherwise.
+func NewAdjacencySIDSubTLV(flags uint8, weight uint8, sid uint32) *AdjacencySIDSubTLV {
+	a := &AdjacencySIDSubTLV{
+		TLVType: AdjacencySIDSubTLVType,
+		Flags:   flags,
+		Weight:  weight,
+		SID:     sid,
+	}
+
+	a.TLVLength = 2 + sidLen(a.IsLabel())
+	return a
+}
+
+// NewLANAdjacencySIDSubTLV creates a new LAN-Adjacency-SID sub TLV for the adjacency to neighborID
+func NewLANAdjacencySIDSubTLV(flags uint8, weight uint8, neighborID types.SystemID, sid uint32) *AdjacencySIDSubTLV {
+	a := NewAdjacencySIDSubTLV(flags, weight, sid)
+	a.TLVType = LANAdjacencySIDSubTLVType
+	a.NeighborID = neighborID
+	a.TLVLength += uint8(len(neighborID))
+
+	return a
+}
+
+func readAdjacencySIDSubTLV(buf *bytes.Buffer, tlvType uint8, tlvLength uint8) (*AdjacencySIDSubTLV, error) {
+	data := buf.Next(int(tlvLength))
+	if len(data) != int(tlvLength) {
+		return nil, fmt.Errorf("adjacency SID sub TLV too short")
+	}
+
+	a := &AdjacencySIDSubTLV{
+		TLVType:   tlvType,
+		TLVLength: tlvLength,
+		Flags:     data[0],
+		Weight:    data[1],
+	}
+
+	data = data[2:]
+	if tlvType == LANAdjacencySIDSubTLVType {
+		copy(a.NeighborID[:], data)
+		data = data[len(a.NeighborID):]
+	}
+
+	a.SID = decodeSID(data)
+	return a, nil
+}
+
+// IsLabel checks if the SID is a label
+func (a *AdjacencySIDSubTLV) IsLabel() bool {
+	return a.Flags&AdjacencySIDFlagValue != 0 && a.Flags&AdjacencySIDFlagLocal != 0
+}
+
+func (a *AdjacencySIDSubTLV) Copy() TLV {
+	ret := *a
+	return &ret
+}
+
+// Type gets the type of the TLV
+func (a *AdjacencySIDSubTLV) Type() uint8 {
+	return a.TLVType
+}
+
+// Length gets the length of the TLV
+func (a *AdjacencySIDSubTLV) Length() uint8 {
+	return a.TLVLength
+}
+
+// Value returns the TLV itself
+func (a *AdjacencySIDSubTLV) Value() interface{} {
+	return a
+}
+
+// Serialize serializes an Adjacency-SID sub TLV
+func (a *AdjacencySIDSubTLV) Serialize(buf *bytes.Buffer) {
+	buf.WriteByte(a.TLVType)
+	buf.WriteByte(a.TLVLength)
+	buf.WriteByte(a.Flags)
+	buf.WriteByte(a.Weight)
+
+	sidLength := a.TLVLength - 2
+	if a.TLVType == LANAdjacencySIDSubTLVType {
+		buf.Write(a.NeighborID[:])
+		sidLength -= uint8(len(a.NeighborID))
+	}
+
+	serializeSID(buf, a.SID, sidLength == sidLabelLen)
+}
@@ -0,0 +1,92 @@
+package packet
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/bio-routing/bio-rd/protocols/isis/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtendedIPReachabilityPrefixSID(t *testing.T) {
+	tlv := NewExtendedIPReachabilityTLV()
+	eipr := NewExtendedIPReachability(10, 32, 0x0a000001)
+	eipr.AddSubTLV(NewPrefixSIDSubTLV(PrefixSIDFlagNode, SRAlgorithmSPF, 100))
+	tlv.AddExtendedIPReachability(eipr)
+
+	buf := bytes.NewBuffer(nil)
+	tlv.Serialize(buf)
+	assert.Equal(t, []byte{
+		135, 18,
+		0, 0, 0, 10, // Metric
+		0x40 | 32,   // UDSubBitPfxLen
+		10, 0, 0, 1, // Address
+		8,    // Sub TLVs length
+		3, 6, // Prefix-SID
+		0x40, 0, // Flags, Algorithm
+		0, 0, 0, 100, // Index
+	}, buf.Bytes())
+
+	res, err := readTLV(buf)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, tlv, res)
+	assert.True(t, res.(*ExtendedIPReachabilityTLV).ExtendedIPReachabilities[0].SubTLVs[0].(*PrefixSIDSubTLV).IsNodeSID())
+}
+
+func TestPrefixSIDSubTLVLabel(t *testing.T) {
+	tlv := NewPrefixSIDSubTLV(PrefixSIDFlagValue|PrefixSIDFlagLocal, SRAlgorithmSPF, 0xabcde)
+
+	buf := bytes.NewBuffer(nil)
+	tlv.Serialize(buf)
+	assert.Equal(t, []byte{3, 5, 0x0c, 0, 0x0a, 0xbc, 0xde}, buf.Bytes())
+	assert.True(t, tlv.IsLabel())
+}
+
+func TestExtendedISReachabilityAdjacencySIDs(t *testing.T) {
+	n := NewExtendedISReachabilityNeighbor(types.NewSourceID(types.SystemID{1, 2, 3, 4, 5, 6}, 1), 10)
+	n.AddSubTLV(NewAdjacencySIDSubTLV(AdjacencySIDFlagValue|AdjacencySIDFlagLocal, 0, 24000))
+	n.AddSubTLV(NewLANAdjacencySIDSubTLV(AdjacencySIDFlagValue|AdjacencySIDFlagLocal, 0, types.SystemID{10, 20, 30, 40, 50, 60}, 24001))
+	tlv := NewExtendedISReachabilityTLV()
+	tlv.AddNeighbor(n)
+
+	buf := bytes.NewBuffer(nil)
+	tlv.Serialize(buf)
+	assert.Equal(t, []byte{
+		22, 31,
+		1, 2, 3, 4, 5, 6, 1, // Neighbor ID
+		0, 0, 10, // Metric
+		20,    // Sub TLVs length
+		31, 5, // Adjacency-SID
+		0x30, 0, // Flags, Weight
+		0x00, 0x5d, 0xc0, // Label
+		32, 11, // LAN-Adjacency-SID
+		0x30, 0, // Flags, Weight
+		10, 20, 30, 40, 50, 60, // Neighbor ID
+		0x00, 0x5d, 0xc1, // Label
+	}, buf.Bytes())
+
+	res, err := readTLV(buf)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, tlv, res)
+}
+
+func TestReadPrefixSIDSubTLVInvalidLength(t *testing.T) {
+	tlvs, err := readSubTLVs(bytes.NewBuffer([]byte{3, 3, 0, 0, 1}), 5, readPrefixSubTLV)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, []TLV{
+		&UnknownTLV{
+			TLVType:   3,
+			TLVLength: 3,
+			TLVValue:  []byte{0, 0, 1},
+		},
+	}, tlvs)
+}
@@ -0,0 +1,355 @@
+package server
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/sha256"
+	"fmt"
+	"hash"
+	"sync/atomic"
+	"time"
+
+	"github.com/bio-routing/bio-rd/protocols/isis/packet"
+	"github.com/bio-routing/bio-rd/util/log"
+)
+
+// AuthenticationAlgorithm is an algorithm used to authenticate PDUs
+type AuthenticationAlgorithm uint8
+
+const (
+	// AuthenticationCleartext sends the key as cleartext password (ISO 10589)
+	AuthenticationCleartext AuthenticationAlgorithm = iota
+
+	// AuthenticationHMACMD5 authenticates PDUs using HMAC-MD5 (RFC5304)
+	AuthenticationHMACMD5
+
+	// AuthenticationHMACSHA256 authenticates PDUs using HMAC-SHA-256 (RFC5310)
+	AuthenticationHMACSHA256
+)
+
+// AuthenticationKey is a key of a key chain
+type AuthenticationKey struct {
+	ID        uint16
+	Algorithm AuthenticationAlgorithm
+	Secret    []byte
+	Start     time.Time // Zero means valid since ever
+	End       time.Time // Zero means valid forever
+}
+
+// KeyChain is a set of authentication keys. PDUs are sent using the valid key with the latest start time
+// and accepted if they are authenticated by any valid key. This allows rolling over keys without flaps.
+type KeyChain []*AuthenticationKey
+
+// AuthenticationConfig is the authentication config of a level
+type AuthenticationConfig struct {
+	KeyChain              KeyChain
+	NoHelloAuthentication bool
+	NoCSNPAuthentication  bool
+	NoPSNPAuthentication  bool
+}
+
+// AuthenticationFailures are the authentication failure counters of an interface and level
+type AuthenticationFailures struct {
+	InterfaceName string
+	Level         uint8
+	Hello         uint64
+	LSP           uint64
+	CSNP          uint64
+	PSNP          uint64
+}
+
+type authenticationFailureCounters struct {
+	hello atomic.Uint64
+	lsp   atomic.Uint64
+	csnp  atomic.Uint64
+	psnp  atomic.Uint64
+}
+
+func (k *AuthenticationKey) valid(t time.Time) bool {
+	if !k.Start.IsZero() && t.Before(k.Start) {
+		return false
+	}
+
+	if !k.End.IsZero() && !t.Before(k.End) {
+		return false
+	}
+
+	return true
+}
+
+func (k *AuthenticationKey) hash() func() hash.Hash {
+	if k.Algorithm == AuthenticationHMACMD5 {
+		return md5.New
+	}
+
+	return sha256.New
+}
+
+// tlv creates the authentication TLV of k. Digests are filled in by sign().
+func (k *AuthenticationKey) tlv() *packet.AuthenticationTLV {
+	switch k.Algorithm {
+	case AuthenticationHMACMD5:
+		return packet.NewHMACMD5AuthenticationTLV()
+	case AuthenticationHMACSHA256:
+		return packet.NewGenericCryptoAuthenticationTLV(k.ID, sha256.Size)
+	}
+
+	return packet.NewCleartextAuthenticationTLV(k.Secret)
+}
+
+// sign fills in the digest of a serialized PDU carrying the authentication TLV of k
+func (k *AuthenticationKey) sign(pdu []byte) error {
+	if k.Algorithm == AuthenticationCleartext {
+		return nil
+	}
+
+	return packet.SetHMACDigest(pdu, k.hash(), k.Secret)
+}
+
+// authenticates checks if a serialized PDU carrying tlv is authenticated by k
+func (k *AuthenticationKey) authenticates(pdu []byte, tlv *packet.AuthenticationTLV) bool {
+	switch k.Algorithm {
+	case AuthenticationCleartext:
+		return tlv.AuthenticationType == packet.AuthenticationTypeCleartext && bytes.Equal(tlv.AuthenticationData, k.Secret)
+	case AuthenticationHMACMD5:
+		if tlv.AuthenticationType != packet.AuthenticationTypeHMACMD5 {
+			return false
+		}
+	case AuthenticationHMACSHA256:
+		if tlv.AuthenticationType != packet.AuthenticationTypeGenericCrypto || tlv.KeyID != k.ID {
+			return false
+		}
+	}
+
+	digest, err := packet.HMACDigest(pdu, k.hash(), k.Secret)
+	if err != nil {
+		return false
+	}
+
+	return bytes.Equal(digest, tlv.AuthenticationData)
+}
+
+// sendKey gets the key used to authenticate PDUs sent at t. Returns nil if there is no valid key.
+func (kc KeyChain) sendKey(t time.Time) *AuthenticationKey {
+	var ret *AuthenticationKey
+	for _, k := range kc {
+		if !k.valid(t) {
+			continue
+		}
+
+		if ret == nil || k.Start.After(ret.Start) {
+			ret = k
+		}
+	}
+
+	return ret
+}
+
+// verify checks if a serialized PDU received at t is authenticated by any key of the key chain valid at t.
+// PDUs are not checked if the key chain is empty.
+func (kc KeyChain) verify(pdu []byte, t time.Time) error {
+	if len(kc) == 0 {
+		return nil
+	}
+
+	tlv, err := packet.GetAuthenticationTLV(pdu)
+	if err != nil {
+		return fmt.Errorf("unable to get authentication TLV: %w", err)
+	}
+
+	if tlv == nil {
+		return fmt.Errorf("authentication TLV missing")
+	}
+
+	for _, k := range kc {
+		if k.valid(t) && k.authenticates(pdu, tlv) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("no valid key authenticates the PDU (authentication type %d)", tlv.AuthenticationType)
+}
+
+// SetAuthentication sets the authentication config of a level
+func (s *Server) SetAuthentication(level uint8, cfg *AuthenticationConfig) {
+	s.authenticationMu.Lock()
+	defer s.authenticationMu.Unlock()
+
+	if level == 1 {
+		s.authenticationL1 = cfg
+		return
+	}
+
+	s.authenticationL2 = cfg
+}
+
+func (s *Server) authentication(level uint8) *AuthenticationConfig {
+	s.authenticationMu.RLock()
+	defer s.authenticationMu.RUnlock()
+
+	if level == 1 {
+		return s.authenticationL1
+	}
+
+	return s.authenticationL2
+}
+
+// lspKeyChain gets the key chain authenticating LSPs of a level
+func (s *Server) lspKeyChain(level uint8) KeyChain {
+	a := s.authentication(level)
+	if a == nil {
+		return nil
+	}
+
+	return a.KeyChain
+}
+
+// snpKeyChain gets the key chain authenticating CSNPs or PSNPs of a level
+func (s *Server) snpKeyChain(level uint8, complete bool) KeyChain {
+	a := s.authentication(level)
+	if a == nil {
+		return nil
+	}
+
+	if complete && a.NoCSNPAuthentication || !complete && a.NoPSNPAuthentication {
+		return nil
+	}
+
+	return a.KeyChain
+}
+
+// authenticateLSP adds an authentication TLV to an LSP originated by us and computes its digest.
+// This must be done before the LSPs checksum is calculated.
+func (s *Server) authenticateLSP(lsp *packet.LSPDU, level uint8) {
+	key := s.lspKeyChain(level).sendKey(clock.Now())
+	if key == nil {
+		return
+	}
+
+	tlv := key.tlv()
+	lsp.TLVs = append(lsp.TLVs, tlv)
+	lsp.UpdateLength()
+
+	if key.Algorithm == AuthenticationCleartext {
+		return
+	}
+
+	digest, err := packet.HMACDigest(serializePDU(lsp, lspPDUType(level)), key.hash(), key.Secret)
+	if err != nil {
+		log.WithError(err).Errorf("Unable to sign LSP %v", lsp.LSPID)
+		return
+	}
+
+	tlv.AuthenticationData = digest
+}
+
+// helloKeyChain gets the key chain authenticating hellos of a level on the interface. A key chain
+// configured for the interface takes precedence over the key chain of the level.
+func (nifa *netIfa) helloKeyChain(level uint8) KeyChain {
+	cfg := nifa.levelConfig(level)
+	if cfg != nil && cfg.HelloAuthentication != nil {
+		return cfg.HelloAuthentication
+	}
+
+	a := nifa.srv.authentication(level)
+	if a == nil || a.NoHelloAuthentication {
+		return nil
+	}
+
+	return a.KeyChain
+}
+
+// p2pHelloLevel gets the level whose authentication is used for p2p hellos. Those are shared by both levels,
+// so level 1 is used if enabled.
+func (nifa *netIfa) p2pHelloLevel() uint8 {
+	if nifa.cfg.Level1 != nil {
+		return 1
+	}
+
+	return 2
+}
+
+// verifyAuthentication checks the authentication of a received PDU (starting with the common header)
+func (nifa *netIfa) verifyAuthentication(pduType uint8, pdu []byte) error {
+	now := clock.Now()
+
+	switch pduType {
+	case packet.P2P_HELLO:
+		return nifa.helloKeyChain(nifa.p2pHelloLevel()).verify(pdu, now)
+	case packet.L1_LAN_HELLO_TYPE:
+		return nifa.helloKeyChain(1).verify(pdu, now)
+	case packet.L2_LAN_HELLO_TYPE:
+		return nifa.helloKeyChain(2).verify(pdu, now)
+	case packet.L1_LS_PDU_TYPE:
+		return nifa.srv.lspKeyChain(1).verify(pdu, now)
+	case packet.L2_LS_PDU_TYPE:
+		return nifa.srv.lspKeyChain(2).verify(pdu, now)
+	case packet.L1_CSNP_TYPE:
+		return nifa.srv.snpKeyChain(1, true).verify(pdu, now)
+	case packet.L2_CSNP_TYPE:
+		return nifa.srv.snpKeyChain(2, true).verify(pdu, now)
+	case packet.L1_PSNP_TYPE:
+		return nifa.srv.snpKeyChain(1, false).verify(pdu, now)
+	case packet.L2_PSNP_TYPE:
+		return nifa.srv.snpKeyChain(2, false).verify(pdu, now)
+	}
+
+	return nil
+}
+
+func (nifa *netIfa) authenticationFailureCounters(level uint8) *authenticationFailureCounters {
+	if level == 1 {
+		return &nifa.authFailuresL1
+	}
+
+	return &nifa.authFailuresL2
+}
+
+// countAuthenticationFailure increments the authentication failure counter of a PDU type
+func (nifa *netIfa) countAuthenticationFailure(pduType uint8) {
+	switch pduType {
+	case packet.P2P_HELLO:
+		nifa.authenticationFailureCounters(nifa.p2pHelloLevel()).hello.Add(1)
+	case packet.L1_LAN_HELLO_TYPE:
+		nifa.authFailuresL1.hello.Add(1)
+	case packet.L2_LAN_HELLO_TYPE:
+		nifa.authFailuresL2.hello.Add(1)
+	case packet.L1_LS_PDU_TYPE:
+		nifa.authFailuresL1.lsp.Add(1)
+	case packet.L2_LS_PDU_TYPE:
+		nifa.authFailuresL2.lsp.Add(1)
+	case packet.L1_CSNP_TYPE:
+		nifa.authFailuresL1.csnp.Add(1)
+	case packet.L2_CSNP_TYPE:
+		nifa.authFailuresL2.csnp.Add(1)
+	case packet.L1_PSNP_TYPE:
+		nifa.authFailuresL1.psnp.Add(1)
+	case packet.L2_PSNP_TYPE:
+		nifa.authFailuresL2.psnp.Add(1)
+	}
+}
+
+// GetAuthenticationFailures gets the authentication failure counters of all interfaces and enabled levels
+func (s *Server) GetAuthenticationFailures() []*AuthenticationFailures {
+	ret := make([]*AuthenticationFailures, 0)
+
+	for _, ifa := range s.netIfaManager.getAllInterfaces() {
+		for _, level := range []uint8{1, 2} {
+			if ifa.levelConfig(level) == nil {
+				continue
+			}
+
+			c := ifa.authenticationFailureCounters(level)
+			ret = append(ret, &AuthenticationFailures{
+				InterfaceName: ifa.getName(),
+				Level:         level,
+				Hello:         c.hello.Load(),
+				LSP:           c.lsp.Load(),
+				CSNP:          c.csnp.Load(),
+				PSNP:          c.psnp.Load(),
+			})
+		}
+	}
+
+	return ret
+}
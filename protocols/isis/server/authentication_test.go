@@ -0,0 +1,186 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bio-routing/bio-rd/protocols/isis/packet"
+	"github.com/bio-routing/bio-rd/protocols/isis/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func testAuthenticatedPSNP(key *AuthenticationKey) []byte {
+	psnp := packet.NewPSNPs(types.SourceID{SystemID: types.SystemID{1, 2, 3, 4, 5, 6}}, []*packet.LSPEntry{
+		{
+			SequenceNumber:    1,
+			RemainingLifetime: 1200,
+			LSPID: packet.LSPID{
+				SystemID: types.SystemID{10, 20, 30, 40, 50, 60},
+			},
+		},
+	}, 1492)[0]
+
+	if key != nil {
+		psnp.AddTLV(key.tlv())
+	}
+
+	pdu := serializePDU(&psnp, packet.L2_PSNP_TYPE)
+	if key != nil {
+		key.sign(pdu)
+	}
+
+	return pdu
+}
+
+func TestKeyChainSendKey(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	oldKey := &AuthenticationKey{ID: 1, Algorithm: AuthenticationHMACSHA256, Secret: []byte("old"), End: t0.Add(2 * time.Hour)}
+	newKey := &AuthenticationKey{ID: 2, Algorithm: AuthenticationHMACSHA256, Secret: []byte("new"), Start: t0.Add(time.Hour)}
+	kc := KeyChain{oldKey, newKey}
+
+	assert.Equal(t, oldKey, kc.sendKey(t0))
+	assert.Equal(t, newKey, kc.sendKey(t0.Add(time.Hour)))
+	assert.Equal(t, newKey, kc.sendKey(t0.Add(3*time.Hour)))
+	assert.Nil(t, KeyChain{oldKey}.sendKey(t0.Add(3*time.Hour)))
+	assert.Nil(t, KeyChain(nil).sendKey(t0))
+}
+
+func TestKeyChainVerify(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cleartext := &AuthenticationKey{Algorithm: AuthenticationCleartext, Secret: []byte("password")}
+	md5Key := &AuthenticationKey{Algorithm: AuthenticationHMACMD5, Secret: []byte("md5")}
+	shaOld := &AuthenticationKey{ID: 1, Algorithm: AuthenticationHMACSHA256, Secret: []byte("old"), End: t0.Add(2 * time.Hour)}
+	shaNew := &AuthenticationKey{ID: 2, Algorithm: AuthenticationHMACSHA256, Secret: []byte("new"), Start: t0.Add(time.Hour)}
+	shaWrongSecret := &AuthenticationKey{ID: 2, Algorithm: AuthenticationHMACSHA256, Secret: []byte("wrong")}
+
+	tests := []struct {
+		name     string
+		keyChain KeyChain
+		pdu      []byte
+		t        time.Time
+		wantFail bool
+	}{
+		{
+			name:     "No authentication configured",
+			keyChain: nil,
+			pdu:      testAuthenticatedPSNP(nil),
+			t:        t0,
+		},
+		{
+			name:     "Authentication TLV missing",
+			keyChain: KeyChain{md5Key},
+			pdu:      testAuthenticatedPSNP(nil),
+			t:        t0,
+			wantFail: true,
+		},
+		{
+			name:     "Cleartext",
+			keyChain: KeyChain{cleartext},
+			pdu:      testAuthenticatedPSNP(cleartext),
+			t:        t0,
+		},
+		{
+			name:     "Cleartext wrong password",
+			keyChain: KeyChain{cleartext},
+			pdu:      testAuthenticatedPSNP(&AuthenticationKey{Algorithm: AuthenticationCleartext, Secret: []byte("wrong")}),
+			t:        t0,
+			wantFail: true,
+		},
+		{
+			name:     "HMAC-MD5",
+			keyChain: KeyChain{md5Key},
+			pdu:      testAuthenticatedPSNP(md5Key),
+			t:        t0,
+		},
+		{
+			name:     "HMAC-MD5 received, HMAC-SHA-256 expected",
+			keyChain: KeyChain{shaOld},
+			pdu:      testAuthenticatedPSNP(md5Key),
+			t:        t0,
+			wantFail: true,
+		},
+		{
+			name:     "HMAC-SHA-256 old key during rollover",
+			keyChain: KeyChain{shaOld, shaNew},
+			pdu:      testAuthenticatedPSNP(shaOld),
+			t:        t0.Add(90 * time.Minute),
+		},
+		{
+			name:     "HMAC-SHA-256 new key during rollover",
+			keyChain: KeyChain{shaOld, shaNew},
+			pdu:      testAuthenticatedPSNP(shaNew),
+			t:        t0.Add(90 * time.Minute),
+		},
+		{
+			name:     "HMAC-SHA-256 expired key",
+			keyChain: KeyChain{shaOld, shaNew},
+			pdu:      testAuthenticatedPSNP(shaOld),
+			t:        t0.Add(3 * time.Hour),
+			wantFail: true,
+		},
+		{
+			name:     "HMAC-SHA-256 key not yet valid",
+			keyChain: KeyChain{shaOld, shaNew},
+			pdu:      testAuthenticatedPSNP(shaNew),
+			t:        t0,
+			wantFail: true,
+		},
+		{
+			name:     "HMAC-SHA-256 wrong secret",
+			keyChain: KeyChain{shaNew},
+			pdu:      testAuthenticatedPSNP(shaWrongSecret),
+			t:        t0.Add(3 * time.Hour),
+			wantFail: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.keyChain.verify(test.pdu, test.t)
+			if test.wantFail {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestAuthenticateLSP(t *testing.T) {
+	key := &AuthenticationKey{ID: 5, Algorithm: AuthenticationHMACSHA256, Secret: []byte("secret")}
+	s := &Server{}
+	s.SetAuthentication(1, &AuthenticationConfig{
+		KeyChain: KeyChain{key},
+	})
+
+	lsp := &packet.LSPDU{
+		RemainingLifetime: 1200,
+		LSPID: packet.LSPID{
+			SystemID: types.SystemID{1, 2, 3, 4, 5, 6},
+		},
+		SequenceNumber: 1,
+		TypeBlock:      packet.TypeBlockISTypeL1,
+		TLVs: []packet.TLV{
+			packet.NewDynamicHostnameTLV([]byte("foo")),
+		},
+	}
+	lsp.UpdateLength()
+	s.authenticateLSP(lsp, 1)
+	lsp.SetChecksum()
+
+	assert.Len(t, lsp.TLVs, 2)
+	assert.NoError(t, s.lspKeyChain(1).verify(serializePDU(lsp, packet.L1_LS_PDU_TYPE), clock.Now()))
+
+	// Lifetime is decremented while the LSP is flooded
+	lsp.RemainingLifetime = 100
+	assert.NoError(t, s.lspKeyChain(1).verify(serializePDU(lsp, packet.L1_LS_PDU_TYPE), clock.Now()))
+
+	lsp.SequenceNumber++
+	assert.Error(t, s.lspKeyChain(1).verify(serializePDU(lsp, packet.L1_LS_PDU_TYPE), clock.Now()))
+
+	// Level 2 has no authentication configured
+	lsp = &packet.LSPDU{}
+	s.authenticateLSP(lsp, 2)
+	assert.Len(t, lsp.TLVs, 0)
+}
@@ -0,0 +1,101 @@
+package server
+
+import (
+	"time"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	bfdserver "github.com/bio-routing/bio-rd/protocols/bfd/server"
+	"github.com/bio-routing/bio-rd/protocols/isis/packet"
+	"github.com/bio-routing/bio-rd/util/log"
+)
+
+// BFDConfig enables BFD (RFC5882) for the adjacencies of an interface
+type BFDConfig struct {
+	DesiredMinTxInterval  time.Duration
+	RequiredMinRxInterval time.Duration
+	DetectMultiplier      uint8
+}
+
+// SetBFD sets the session manager adjacencies on interfaces with BFD enabled subscribe to. Must be called before Start.
+func (s *Server) SetBFD(m bfdserver.SessionManager) {
+	s.bfd = m
+}
+
+// subscribeBFD subscribes to a BFD session to the first IPv4 address of the neighbor once the adjacency is up
+func (n *neighbor) subscribeBFD() {
+	cfg := n.nm.netIfa.cfg.BFD
+	if cfg == nil || n.nm.server.bfd == nil || n.getState() != packet.P2PAdjStateUp {
+		return
+	}
+
+	n.bfdMu.Lock()
+	defer n.bfdMu.Unlock()
+
+	if n.bfdSession != nil {
+		return
+	}
+
+	for _, a := range n.ipAddresses {
+		if !a.IsIPv4() {
+			continue
+		}
+
+		sessionCfg := &bfdserver.SessionConfig{
+			Peer:                  a,
+			Interface:             n.nm.netIfa.name,
+			DesiredMinTxInterval:  cfg.DesiredMinTxInterval,
+			RequiredMinRxInterval: cfg.RequiredMinRxInterval,
+			DetectMultiplier:      cfg.DetectMultiplier,
+		}
+
+		err := n.nm.server.bfd.Subscribe(n, *sessionCfg)
+		if err != nil {
+			log.WithFields(n.fields()).WithError(err).Error("Unable to subscribe to BFD session")
+			return
+		}
+
+		n.bfdSession = sessionCfg
+		return
+	}
+}
+
+func (n *neighbor) unsubscribeBFD() {
+	n.bfdMu.Lock()
+	defer n.bfdMu.Unlock()
+
+	if n.bfdSession == nil {
+		return
+	}
+
+	n.nm.server.bfd.Unsubscribe(n, *n.bfdSession)
+	n.bfdSession = nil
+}
+
+// heldDownByBFD returns true if the BFD session of the adjacency went down and did not come up again yet
+func (n *neighbor) heldDownByBFD() bool {
+	n.bfdMu.Lock()
+	defer n.bfdMu.Unlock()
+
+	return n.bfdDown
+}
+
+// BFDStateChange takes the adjacency down when its BFD session failed. The adjacency is held down
+// until the BFD session is up again. Therefore the subscription is kept while the adjacency is down.
+func (n *neighbor) BFDStateChange(addr bnet.IP, up bool) {
+	n.bfdMu.Lock()
+	n.bfdDown = !up
+	n.bfdMu.Unlock()
+
+	if up {
+		log.WithFields(n.fields()).Infof("BFD session to %s is up", addr.String())
+		return
+	}
+
+	if n.getState() != packet.P2PAdjStateUp {
+		return
+	}
+
+	log.WithFields(n.fields()).Infof("BFD session to %s went down", addr.String())
+	n.down()
+	n.nm.neighborDown(n)
+}
@@ -0,0 +1,147 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/net/ethernet"
+	bfdserver "github.com/bio-routing/bio-rd/protocols/bfd/server"
+	"github.com/bio-routing/bio-rd/protocols/isis/packet"
+	"github.com/bio-routing/bio-rd/protocols/isis/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockBFD struct {
+	sessions map[bnet.IP]bfdserver.SessionConfig
+}
+
+func (m *mockBFD) Subscribe(c bfdserver.Client, cfg bfdserver.SessionConfig) error {
+	m.sessions[cfg.Peer] = cfg
+	return nil
+}
+
+func (m *mockBFD) Unsubscribe(c bfdserver.Client, cfg bfdserver.SessionConfig) {
+	delete(m.sessions, cfg.Peer)
+}
+
+func (m *mockBFD) GetSessions() []*bfdserver.SessionInfo {
+	return nil
+}
+
+func TestNeighborBFD(t *testing.T) {
+	tests := []struct {
+		name             string
+		bfd              *BFDConfig
+		state            uint8
+		expectedSessions map[bnet.IP]bfdserver.SessionConfig
+	}{
+		{
+			name:             "BFD disabled",
+			state:            packet.P2PAdjStateUp,
+			expectedSessions: map[bnet.IP]bfdserver.SessionConfig{},
+		},
+		{
+			name: "Adjacency not up",
+			bfd: &BFDConfig{
+				DetectMultiplier: 3,
+			},
+			state:            packet.P2PAdjStateInit,
+			expectedSessions: map[bnet.IP]bfdserver.SessionConfig{},
+		},
+		{
+			name: "Adjacency up",
+			bfd: &BFDConfig{
+				DesiredMinTxInterval:  100 * time.Millisecond,
+				RequiredMinRxInterval: 100 * time.Millisecond,
+				DetectMultiplier:      3,
+			},
+			state: packet.P2PAdjStateUp,
+			expectedSessions: map[bnet.IP]bfdserver.SessionConfig{
+				bnet.IPv4FromOctets(10, 0, 0, 2): {
+					Peer:                  bnet.IPv4FromOctets(10, 0, 0, 2),
+					Interface:             "eth0",
+					DesiredMinTxInterval:  100 * time.Millisecond,
+					RequiredMinRxInterval: 100 * time.Millisecond,
+					DetectMultiplier:      3,
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := &mockBFD{
+				sessions: make(map[bnet.IP]bfdserver.SessionConfig),
+			}
+			srv := &Server{
+				bfd: m,
+				nets: []*types.NET{
+					{
+						SystemID: types.SystemID{0, 0, 0, 0, 0, 1},
+					},
+				},
+			}
+			srv.lsdbL2 = newLSDB(srv)
+
+			nifa := &netIfa{
+				name:      "eth0",
+				srv:       srv,
+				devStatus: &mockDevice{},
+				cfg: &InterfaceConfig{
+					Name:         "eth0",
+					PointToPoint: true,
+					BFD:          test.bfd,
+				},
+			}
+			nm := newNeighborManager(srv, nifa, 2)
+
+			mac := ethernet.MACAddr{0, 0, 0, 0, 0, 2}
+			n := nm.newNeighbor(types.SystemID{0, 0, 0, 0, 0, 2}, 30, mac)
+			n.ipAddresses = []bnet.IP{
+				bnet.IPv6FromBlocks(0xfe80, 0, 0, 0, 0, 0, 0, 2),
+				bnet.IPv4FromOctets(10, 0, 0, 2),
+			}
+			n.setState(test.state)
+			nm.neighbors[mac] = n
+
+			n.subscribeBFD()
+			n.subscribeBFD()
+			assert.Equal(t, test.expectedSessions, m.sessions)
+
+			if test.state == packet.P2PAdjStateUp {
+				n.BFDStateChange(bnet.IPv4FromOctets(10, 0, 0, 2), true)
+				assert.Equal(t, uint8(packet.P2PAdjStateUp), n.getState())
+
+				n.BFDStateChange(bnet.IPv4FromOctets(10, 0, 0, 2), false)
+				assert.Equal(t, uint8(packet.P2PAdjStateDown), n.getState())
+				assert.Len(t, srv.lsdbL2.refreshCh, 1)
+				<-srv.lsdbL2.refreshCh
+
+				hello := &packet.P2PHello{
+					HoldingTimer: 30,
+					TLVs: []packet.TLV{
+						&packet.P2PAdjacencyStateTLV{
+							TLVType:                        packet.P2PAdjacencyStateTLVType,
+							AdjacencyState:                 packet.P2PAdjStateUp,
+							NeighborSystemID:               types.SystemID{0, 0, 0, 0, 0, 1},
+							NeighborExtendedLocalCircuitID: 1337,
+						},
+					},
+				}
+
+				// The adjacency is held down until the BFD session is up again
+				assert.NoError(t, n.processP2PHello(hello))
+				assert.Equal(t, uint8(packet.P2PAdjStateDown), n.getState())
+				assert.Equal(t, test.expectedSessions, m.sessions)
+
+				n.BFDStateChange(bnet.IPv4FromOctets(10, 0, 0, 2), true)
+				assert.NoError(t, n.processP2PHello(hello))
+				assert.Equal(t, uint8(packet.P2PAdjStateUp), n.getState())
+			}
+
+			nm.dropNeighbour(n)
+			assert.Len(t, m.sessions, 0)
+		})
+	}
+}
@@ -1,8 +1,6 @@
 package server
 
 import (
-	"bytes"
-
 	"github.com/bio-routing/bio-rd/protocols/isis/packet"
 	"github.com/bio-routing/bio-rd/protocols/isis/types"
 	"github.com/bio-routing/bio-rd/util/log"
@@ -20,16 +18,14 @@ func (nifa *netIfa) p2pHelloSender() {
 			nifa.helloTicker.Stop()
 			return
 		case <-nifa.helloTicker.C:
+			level := nifa.p2pHelloLevel()
+			key := nifa.helloKeyChain(level).sendKey(clock.Now())
 			hello := nifa.p2pHello()
-			helloBuf := bytes.NewBuffer(nil)
-			hello.Serialize(helloBuf)
-
-			hdr := getHeader(packet.P2P_HELLO)
-			hdrBuf := bytes.NewBuffer(nil)
-			hdr.Serialize(hdrBuf)
-			hdrBuf.Write(helloBuf.Bytes())
+			if key != nil {
+				hello.TLVs = append(hello.TLVs, key.tlv())
+			}
 
-			err := nifa.ethernetInterface.SendPacket(allISNetworkEntitiesAddr, hdrBuf.Bytes())
+			err := nifa.sendPDU(hello, packet.P2P_HELLO, level, key)
 			if err != nil {
 				log.WithFields(nifa.fields()).WithError(err).Error("Unable to send hello packet")
 			}
@@ -50,7 +46,35 @@ func (nifa *netIfa) ipv4Addrs() []*bnet.Prefix {
 	return ipv4Addrs
 }
 
-func (nifa *netIfa) p2pHello() *packet.P2PHello {
+// ipv6Prefixes gets all IPv6 addresses of the interface that are neither link local nor loopback
+func (nifa *netIfa) ipv6Prefixes() []*bnet.Prefix {
+	ret := make([]*bnet.Prefix, 0)
+	for _, a := range nifa.devStatus.GetAddrs() {
+		if a.Addr().IsIPv4() || a.Addr().IsLinkLocalUnicast() || a.Addr().IsLoopback() {
+			continue
+		}
+
+		ret = append(ret, a)
+	}
+
+	return ret
+}
+
+// ipv6LinkLocalAddrs gets all IPv6 link local addresses of the interface
+func (nifa *netIfa) ipv6LinkLocalAddrs() []bnet.IP {
+	ret := make([]bnet.IP, 0)
+	for _, a := range nifa.devStatus.GetAddrs() {
+		if a.Addr().IsIPv4() || !a.Addr().IsLinkLocalUnicast() {
+			continue
+		}
+
+		ret = append(ret, a.Addr())
+	}
+
+	return ret
+}
+
+func (nifa *netIfa) circuitType() uint8 {
 	circuitType := uint8(0)
 	if nifa.cfg.Level1 != nil {
 		circuitType += types.CircuitTypeL1
@@ -59,8 +83,12 @@ func (nifa *netIfa) p2pHello() *packet.P2PHello {
 		circuitType += types.CircuitTypeL2
 	}
 
+	return circuitType
+}
+
+func (nifa *netIfa) p2pHello() *packet.P2PHello {
 	h := &packet.P2PHello{
-		CircuitType:    circuitType,
+		CircuitType:    nifa.circuitType(),
 		SystemID:       nifa.srv.nets[0].SystemID,
 		HoldingTimer:   nifa.cfg.holdingTimer(),
 		PDULength:      packet.P2PHelloMinLen,
@@ -80,18 +108,30 @@ func (nifa *netIfa) p2pHello() *packet.P2PHello {
 		h.TLVs = append(h.TLVs, p2pAdjTLV)
 	}
 
-	h.TLVs = append(h.TLVs, nifa.srv.getProtocolsSupportedTLV())
-	h.TLVs = append(h.TLVs, packet.NewIPInterfaceAddressesTLV(nifa.ipv4Addrs()))
+	h.TLVs = append(h.TLVs, nifa.helloTLVs()...)
 
-	// TODO: Add IPv6 Interface Addresses TLV
+	return h
+}
 
-	areas := make([]types.AreaID, 0)
-	for _, net := range nifa.srv.nets {
-		areas = append(areas, net.AreaID)
+// helloTLVs gets the TLVs common to p2p and LAN hellos
+func (nifa *netIfa) helloTLVs() []packet.TLV {
+	tlvs := []packet.TLV{
+		nifa.srv.getProtocolsSupportedTLV(),
+		packet.NewIPInterfaceAddressesTLV(nifa.ipv4Addrs()),
 	}
-	h.TLVs = append(h.TLVs, packet.NewAreaAddressesTLV(areas))
 
-	return h
+	linkLocalAddrs := nifa.ipv6LinkLocalAddrs()
+	if len(linkLocalAddrs) > 0 {
+		tlvs = append(tlvs, packet.NewIPv6InterfaceAddressesTLV(linkLocalAddrs))
+	}
+
+	if nifa.srv.multiTopology.Load() {
+		tlvs = append(tlvs, packet.NewMultiTopologyTLV(nifa.srv.topologies()))
+	}
+
+	tlvs = append(tlvs, packet.NewAreaAddressesTLV(nifa.srv.areaIDs()))
+
+	return tlvs
 }
 
 func (nifa *netIfa) getP2PNeighbor() *neighbor {
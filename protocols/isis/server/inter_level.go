@@ -0,0 +1,76 @@
+package server
+
+import (
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/route"
+)
+
+// attached checks if we are an L1/L2 router reaching other areas via level 2. Level 1 only routers of
+// our area use us as default gateway then.
+func (s *Server) attached() bool {
+	if !s.levelEnabled(2) {
+		return false
+	}
+
+	s.spfMu.RLock()
+	defer s.spfMu.RUnlock()
+
+	return s.spfL2.attached
+}
+
+// propagatedL1Routes gets the level 1 routes advertised in our level 2 LSP. Prefixes leaked from level 2
+// must not be propagated back into level 2 (RFC5302 Sect. 3.3).
+func (s *Server) propagatedL1Routes() []*Route {
+	s.spfMu.RLock()
+	defer s.spfMu.RUnlock()
+
+	routes := make(map[bnet.Prefix]*spfRoute)
+	for pfx, r := range s.spfL1.routes {
+		if r.upDown {
+			continue
+		}
+
+		routes[pfx] = r
+	}
+
+	return routesSorted(routes)
+}
+
+// leakedL2Routes gets the level 2 routes accepted by the leak filter chain. They are advertised in our
+// level 1 LSP with the up/down bit set. Prefixes reachable within the area are not leaked.
+func (s *Server) leakedL2Routes() []*Route {
+	chain := s.getLeakFilterChain()
+	if len(chain) == 0 {
+		return nil
+	}
+
+	s.spfMu.RLock()
+	defer s.spfMu.RUnlock()
+
+	routes := make(map[bnet.Prefix]*spfRoute)
+	for pfx, r := range s.spfL2.routes {
+		if _, found := s.spfL1.routes[pfx]; found {
+			continue
+		}
+
+		pfx := pfx
+		p, reject := chain.Process(&pfx, &route.Path{
+			Type: route.ISISPathType,
+			ISISPath: &route.ISISPath{
+				Metric: r.metric,
+				Level:  2,
+			},
+		})
+		if reject {
+			continue
+		}
+
+		routes[pfx] = &spfRoute{
+			metric:    p.ISISPath.Metric,
+			nextHops:  r.nextHops,
+			prefixSID: r.prefixSID,
+		}
+	}
+
+	return routesSorted(routes)
+}
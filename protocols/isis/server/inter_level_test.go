@@ -0,0 +1,101 @@
+package server
+
+import (
+	"testing"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/routingtable/filter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLeakedL2Routes(t *testing.T) {
+	pfxA := bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 1, 0), 24)
+	pfxB := bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 2, 0), 24)
+	pfxC := bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 3, 0), 24)
+
+	l1 := &spfResult{
+		routes: map[bnet.Prefix]*spfRoute{
+			pfxA: {metric: 10},
+		},
+	}
+
+	l2 := &spfResult{
+		routes: map[bnet.Prefix]*spfRoute{
+			pfxA: {metric: 30},
+			pfxB: {metric: 20},
+			pfxC: {metric: 40},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		chain    filter.Chain
+		expected []*Route
+	}{
+		{
+			name:     "no leaking configured",
+			chain:    nil,
+			expected: nil,
+		},
+		{
+			name:  "accept all",
+			chain: filter.NewAcceptAllFilterChain(),
+			expected: []*Route{
+				{Prefix: pfxB, Metric: 20},
+				{Prefix: pfxC, Metric: 40},
+			},
+		},
+		{
+			name:     "reject all",
+			chain:    filter.NewDrainFilterChain(),
+			expected: []*Route{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := &Server{
+				spfL1: l1,
+				spfL2: l2,
+			}
+			s.SetLeakFilterChain(test.chain)
+
+			assert.Equal(t, test.expected, s.leakedL2Routes())
+		})
+	}
+}
+
+func TestSetLeakFilterChainUpdatesL1LSP(t *testing.T) {
+	s := &Server{
+		running: true,
+	}
+	s.lsdbL1 = newLSDB(s)
+
+	s.SetLeakFilterChain(filter.NewAcceptAllFilterChain())
+	assert.Len(t, s.lsdbL1.refreshCh, 1)
+	<-s.lsdbL1.refreshCh
+
+	s.SetLeakFilterChain(filter.NewAcceptAllFilterChain())
+	assert.Len(t, s.lsdbL1.refreshCh, 0)
+
+	s.SetLeakFilterChain(nil)
+	assert.Len(t, s.lsdbL1.refreshCh, 1)
+}
+
+func TestPropagatedL1Routes(t *testing.T) {
+	pfxA := bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 1, 0), 24)
+	pfxB := bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 2, 0), 24)
+
+	s := &Server{
+		spfL1: &spfResult{
+			routes: map[bnet.Prefix]*spfRoute{
+				pfxA: {metric: 10},
+				pfxB: {metric: 20, upDown: true},
+			},
+		},
+	}
+
+	assert.Equal(t, []*Route{
+		{Prefix: pfxA, Metric: 10},
+	}, s.propagatedL1Routes())
+}
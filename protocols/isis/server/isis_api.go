@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"fmt"
 
 	netapi "github.com/bio-routing/bio-rd/net/api"
 	"github.com/bio-routing/bio-rd/protocols/isis/api"
@@ -50,18 +51,145 @@ func (s *ISISAPIServer) ListAdjacencies(context.Context, *api.ListAdjacenciesReq
 	return res, nil
 }
 
-func (s *ISISAPIServer) GetLSDB(context.Context, *api.GetLSDBRequest) (*api.GetLSDBResponse, error) {
+// levelFromRequest gets the level requested. Level 2 is used if no level is given.
+func levelFromRequest(level uint32) (uint8, error) {
+	switch level {
+	case 0, 2:
+		return 2, nil
+	case 1:
+		return 1, nil
+	}
+
+	return 0, fmt.Errorf("invalid level %d", level)
+}
+
+func (s *ISISAPIServer) GetLSDB(ctx context.Context, req *api.GetLSDBRequest) (*api.GetLSDBResponse, error) {
+	level, err := levelFromRequest(req.Level)
+	if err != nil {
+		return nil, err
+	}
+
 	resp := &api.GetLSDBResponse{
 		LsdbEntries: make([]*api.LSDBEntry, 0),
 	}
 
-	for _, e := range s.srv.GetLSDB() {
+	for _, e := range s.srv.GetLSDB(level) {
 		resp.LsdbEntries = append(resp.LsdbEntries, lsdbEntryToProto(e))
 	}
 
 	return resp, nil
 }
 
+func (s *ISISAPIServer) GetSPFTree(ctx context.Context, req *api.GetSPFTreeRequest) (*api.GetSPFTreeResponse, error) {
+	level, err := levelFromRequest(req.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &api.GetSPFTreeResponse{
+		Entries: make([]*api.SPFTreeEntry, 0),
+	}
+
+	for _, e := range s.srv.GetSPFTree(level) {
+		entry := &api.SPFTreeEntry{
+			SystemId:     e.SourceID.SystemID[:],
+			PseudonodeId: uint32(e.SourceID.CircuitID),
+			Metric:       e.Metric,
+			Parents:      make([][]byte, 0, len(e.Parents)),
+			NextHops:     nextHopsToProto(e.NextHops),
+			Srgb:         labelBlocksToProto(e.SRGB),
+		}
+
+		for _, p := range e.Parents {
+			entry.Parents = append(entry.Parents, p.Serialize())
+		}
+
+		resp.Entries = append(resp.Entries, entry)
+	}
+
+	return resp, nil
+}
+
+func (s *ISISAPIServer) GetRoutes(ctx context.Context, req *api.GetRoutesRequest) (*api.GetRoutesResponse, error) {
+	level, err := levelFromRequest(req.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &api.GetRoutesResponse{
+		Routes: make([]*api.Route, 0),
+	}
+
+	for _, r := range s.srv.GetRoutes(level) {
+		route := &api.Route{
+			Prefix:   r.Prefix.ToProto(),
+			Metric:   r.Metric,
+			NextHops: nextHopsToProto(r.NextHops),
+		}
+
+		if r.PrefixSID != nil {
+			route.PrefixSid = &api.PrefixSID{
+				Flags:     uint32(r.PrefixSID.Flags),
+				Algorithm: packet.SRAlgorithmSPF,
+				Sid:       r.PrefixSID.SID,
+			}
+		}
+
+		resp.Routes = append(resp.Routes, route)
+	}
+
+	return resp, nil
+}
+
+func (s *ISISAPIServer) GetAuthenticationFailures(context.Context, *api.GetAuthenticationFailuresRequest) (*api.GetAuthenticationFailuresResponse, error) {
+	resp := &api.GetAuthenticationFailuresResponse{
+		AuthenticationFailures: make([]*api.AuthenticationFailures, 0),
+	}
+
+	for _, f := range s.srv.GetAuthenticationFailures() {
+		resp.AuthenticationFailures = append(resp.AuthenticationFailures, &api.AuthenticationFailures{
+			InterfaceName: f.InterfaceName,
+			Level:         uint32(f.Level),
+			Hello:         f.Hello,
+			Lsp:           f.LSP,
+			Csnp:          f.CSNP,
+			Psnp:          f.PSNP,
+		})
+	}
+
+	return resp, nil
+}
+
+func nextHopsToProto(nextHops []NextHop) []*api.NextHop {
+	ret := make([]*api.NextHop, 0, len(nextHops))
+	for _, nh := range nextHops {
+		n := &api.NextHop{
+			InterfaceName: nh.InterfaceName,
+			SystemId:      append([]byte(nil), nh.SystemID[:]...),
+		}
+
+		if nh.Address != nil {
+			n.Address = nh.Address.ToProto()
+		}
+
+		ret = append(ret, n)
+	}
+
+	return ret
+}
+
+func labelBlocksToProto(blocks []LabelBlock) []*api.LabelBlock {
+	ret := make([]*api.LabelBlock, 0, len(blocks))
+	for _, b := range blocks {
+		ret = append(ret, &api.LabelBlock{
+			Base: b.Base,
+			Size: b.Size,
+		})
+	}
+
+	return ret
+}
+
 func lsdbEntryToProto(e *LSDBEntry) *api.LSDBEntry {
 	l := &api.LSDBEntry{
 		Lsp:                   lspduToProto(e.lspdu),
@@ -117,6 +245,7 @@ func lspduToProto(e *packet.LSPDU) *api.LSPDU {
 				ret.ExtendedIsReachabilities = append(ret.ExtendedIsReachabilities, &api.ExtendedISReachability{
 					NeighborId:    n.NeighborID.Serialize(),
 					DefaultMetric: n.Metric,
+					AdjacencySids: adjacencySIDsToProto(n.SubTLVs),
 				})
 			}
 		case packet.ExtendedIPReachabilityTLVType:
@@ -125,13 +254,93 @@ func lspduToProto(e *packet.LSPDU) *api.LSPDU {
 					Metric:       eipr.Metric,
 					IpAddress:    eipr.Address,
 					PrefixLength: uint32(eipr.PfxLen()),
+					PrefixSid:    prefixSIDToProto(eipr.SubTLVs),
 				})
 			}
 		case packet.DynamicHostNameTLVType:
 			ret.Hostname = string(tlv.(*packet.DynamicHostNameTLV).Hostname)
 		case packet.TrafficEngineeringRouterIDTLVType:
 			ret.Ipv4TeRouterId = tlv.(*packet.TrafficEngineeringRouterIDTLV).Address
+		case packet.RouterCapabilityTLVType:
+			ret.RouterCapability = routerCapabilityToProto(tlv.(*packet.RouterCapabilityTLV))
+		}
+	}
+
+	return ret
+}
+
+func prefixSIDToProto(subTLVs []packet.TLV) *api.PrefixSID {
+	for _, stlv := range subTLVs {
+		if sid, ok := stlv.(*packet.PrefixSIDSubTLV); ok {
+			return &api.PrefixSID{
+				Flags:     uint32(sid.Flags),
+				Algorithm: uint32(sid.Algorithm),
+				Sid:       sid.SID,
+			}
+		}
+	}
+
+	return nil
+}
+
+func adjacencySIDsToProto(subTLVs []packet.TLV) []*api.AdjacencySID {
+	ret := make([]*api.AdjacencySID, 0)
+	for _, stlv := range subTLVs {
+		sid, ok := stlv.(*packet.AdjacencySIDSubTLV)
+		if !ok {
+			continue
+		}
+
+		a := &api.AdjacencySID{
+			Flags:  uint32(sid.Flags),
+			Weight: uint32(sid.Weight),
+			Sid:    sid.SID,
+		}
+
+		if sid.TLVType == packet.LANAdjacencySIDSubTLVType {
+			a.NeighborSystemId = append([]byte(nil), sid.NeighborID[:]...)
 		}
+
+		ret = append(ret, a)
+	}
+
+	return ret
+}
+
+func routerCapabilityToProto(rc *packet.RouterCapabilityTLV) *api.RouterCapability {
+	ret := &api.RouterCapability{
+		RouterId:     rc.RouterID,
+		Flags:        uint32(rc.Flags),
+		Srgb:         make([]*api.LabelBlock, 0),
+		SrAlgorithms: make([]uint32, 0),
+		Srlb:         make([]*api.LabelBlock, 0),
+	}
+
+	for _, stlv := range rc.SubTLVs {
+		switch stlv.Type() {
+		case packet.SRCapabilitiesSubTLVType:
+			c := stlv.(*packet.SRCapabilitiesSubTLV)
+			ret.SrCapabilitiesFlags = uint32(c.Flags)
+			ret.Srgb = append(ret.Srgb, sidLabelRangesToProto(c.Ranges)...)
+		case packet.SRLocalBlockSubTLVType:
+			ret.Srlb = append(ret.Srlb, sidLabelRangesToProto(stlv.(*packet.SRCapabilitiesSubTLV).Ranges)...)
+		case packet.SRAlgorithmSubTLVType:
+			for _, a := range stlv.(*packet.SRAlgorithmSubTLV).Algorithms {
+				ret.SrAlgorithms = append(ret.SrAlgorithms, uint32(a))
+			}
+		}
+	}
+
+	return ret
+}
+
+func sidLabelRangesToProto(ranges []packet.SIDLabelRange) []*api.LabelBlock {
+	ret := make([]*api.LabelBlock, 0, len(ranges))
+	for _, r := range ranges {
+		ret = append(ret, &api.LabelBlock{
+			Base: r.First,
+			Size: r.Range,
+		})
 	}
 
 	return ret
@@ -0,0 +1,346 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/bio-routing/bio-rd/net/ethernet"
+	"github.com/bio-routing/bio-rd/protocols/isis/packet"
+	"github.com/bio-routing/bio-rd/protocols/isis/types"
+	"github.com/bio-routing/bio-rd/util/log"
+)
+
+var (
+	allL1ISs = ethernet.MACAddr(packet.AllL1ISS)
+	allL2ISs = ethernet.MACAddr(packet.AllL2ISS)
+)
+
+// lanState is the state of a broadcast circuit for one level
+type lanState struct {
+	lanID types.SourceID // LAN ID of the elected DIS. Zero if no DIS has been elected.
+	isDIS bool
+}
+
+func (nifa *netIfa) isLAN() bool {
+	return !nifa.cfg.PointToPoint
+}
+
+func (nifa *netIfa) levelConfig(level uint8) *InterfaceLevelConfig {
+	if level == 1 {
+		return nifa.cfg.Level1
+	}
+
+	return nifa.cfg.Level2
+}
+
+func (nifa *netIfa) neighborManager(level uint8) *neighborManager {
+	if level == 1 {
+		return nifa.neighborManagerL1
+	}
+
+	return nifa.neighborManagerL2
+}
+
+// ownLANID is the LAN ID used if we are DIS of the circuit
+func (nifa *netIfa) ownLANID() types.SourceID {
+	return types.NewSourceID(nifa.srv.systemID(), nifa.circuitID)
+}
+
+func (nifa *netIfa) lanHelloSender() {
+	defer nifa.wg.Done()
+	log.WithFields(nifa.fields()).Debug("Starting LAN hello sender")
+
+	for {
+		select {
+		case <-nifa.done:
+			nifa.helloTicker.Stop()
+			return
+		case <-nifa.helloTicker.C:
+			for _, level := range []uint8{1, 2} {
+				nm := nifa.neighborManager(level)
+				if nm == nil {
+					continue
+				}
+
+				err := nifa.sendLANHello(nm)
+				if err != nil {
+					log.WithFields(nifa.fields()).WithError(err).Errorf("Unable to send L%d LAN hello packet", level)
+				}
+			}
+		}
+	}
+}
+
+func (nifa *netIfa) sendLANHello(nm *neighborManager) error {
+	pduType := uint8(packet.L2_LAN_HELLO_TYPE)
+	if nm.level == 1 {
+		pduType = packet.L1_LAN_HELLO_TYPE
+	}
+
+	key := nifa.helloKeyChain(nm.level).sendKey(clock.Now())
+	hello := nifa.lanHello(nm)
+	if key != nil {
+		hello.TLVs = append(hello.TLVs, key.tlv())
+	}
+
+	return nifa.sendPDU(hello, pduType, nm.level, key)
+}
+
+func (nifa *netIfa) lanHello(nm *neighborManager) *packet.LANHello {
+	lanID := nm.getLANID()
+	if lanID.SystemID == (types.SystemID{}) {
+		lanID = nifa.ownLANID()
+	}
+
+	h := &packet.LANHello{
+		CircuitType:  nifa.circuitType(),
+		SystemID:     nifa.srv.systemID(),
+		HoldingTimer: nifa.cfg.holdingTimer(),
+		Priority:     nifa.levelConfig(nm.level).Priority,
+		DesignatedIS: lanID,
+		TLVs:         make([]packet.TLV, 0, 6),
+	}
+
+	snpas := make([]ethernet.MACAddr, 0)
+	for _, n := range nm.getNeighbors() {
+		if n.getState() == packet.P2PAdjStateDown {
+			continue
+		}
+
+		snpas = append(snpas, n.addr)
+	}
+
+	if len(snpas) > 0 {
+		h.TLVs = append(h.TLVs, packet.NewISNeighborsTLV(snpas))
+	}
+
+	h.TLVs = append(h.TLVs, nifa.helloTLVs()...)
+
+	return h
+}
+
+func (nifa *netIfa) processLANHello(src ethernet.MACAddr, level uint8, hello *packet.LANHello) error {
+	if !nifa.isLAN() {
+		return fmt.Errorf("received LAN hello on p2p interface")
+	}
+
+	nm := nifa.neighborManager(level)
+	if nm == nil {
+		return nil
+	}
+
+	if hello.CircuitType&level == 0 {
+		return fmt.Errorf("received L%d LAN hello with circuit type %d", level, hello.CircuitType)
+	}
+
+	err := nm.processLANHello(src, hello)
+	if err != nil {
+		return fmt.Errorf("neighbor manager L%d failed processing the LAN hello: %w", level, err)
+	}
+
+	return nil
+}
+
+func (nm *neighborManager) processLANHello(src ethernet.MACAddr, hello *packet.LANHello) error {
+	if hello.SystemID == nm.server.systemID() {
+		return fmt.Errorf("LAN hello with own system ID from %s", src.String())
+	}
+
+	err := nm.validateHello(hello)
+	if err != nil {
+		return fmt.Errorf("Invalid LAN hello msg from %s: %w", src.String(), err)
+	}
+
+	if nm.level == 1 && !nm.netIfa.validateAreasL1(hello.GetAreaAddressesTLV().AreaIDs) {
+		log.WithFields(nm.fields()).Infof("Rejecting L1 adjacency from %s due to area mismatch", src.String())
+		return nil
+	}
+
+	nm.neighborsMu.Lock()
+	n, found := nm.neighbors[src]
+	if !found {
+		n = nm.neighborFromLANHello(hello, src)
+		nm.neighbors[src] = n
+
+		n.wg.Add(1)
+		go nm.adjChecker(n)
+
+		log.WithFields(nm.fields()).Infof("Adding new neighbor %q", hello.SystemID.String())
+	}
+	nm.neighborsMu.Unlock()
+
+	if n.processLANHello(hello, nm.netIfa.ethernetInterface.GetMAC()) {
+		n.subscribeBFD()
+		nm.electDIS()
+		nm.server.updateLSP(nm.level)
+	}
+
+	return nil
+}
+
+// processLANHello processes a LAN hello of an existing neighbor. The adjacency is up once the
+// neighbor lists our SNPA in its IS Neighbors TLV (3-way handshake). Returns true if the adjacency
+// state or a parameter relevant to the DIS election changed.
+func (n *neighbor) processLANHello(hello *packet.LANHello, ownSNPA ethernet.MACAddr) bool {
+	n.updateTimeout(clock.Now().Add(time.Second * time.Duration(hello.HoldingTimer)))
+
+	seesUs := false
+	if isNeighbors := hello.GetISNeighborsTLV(); isNeighbors != nil {
+		seesUs = isNeighbors.ContainsSNPA(ownSNPA)
+	}
+
+	heldDown := n.heldDownByBFD()
+
+	n.stateMu.Lock()
+	defer n.stateMu.Unlock()
+
+	changed := n.priority != hello.Priority || n.lanID != hello.DesignatedIS
+	n.priority = hello.Priority
+	n.lanID = hello.DesignatedIS
+
+	if seesUs && n.state != packet.P2PAdjStateUp && !heldDown {
+		log.WithFields(n.fields()).Infof("Adjacency reaches up state")
+		n.lastStateChange = clock.Now()
+		n.state = packet.P2PAdjStateUp
+		return true
+	}
+
+	if !seesUs && n.state == packet.P2PAdjStateUp {
+		log.WithFields(n.fields()).Infof("Adjacency reaches init state")
+		n.lastStateChange = clock.Now()
+		n.state = packet.P2PAdjStateInit
+		return true
+	}
+
+	return changed
+}
+
+func (n *neighbor) getPriorityAndLANID() (uint8, types.SourceID) {
+	n.stateMu.RLock()
+	defer n.stateMu.RUnlock()
+
+	return n.priority, n.lanID
+}
+
+func (nm *neighborManager) getLANID() types.SourceID {
+	nm.lanMu.RLock()
+	defer nm.lanMu.RUnlock()
+
+	return nm.lan.lanID
+}
+
+func (nm *neighborManager) isDIS() bool {
+	nm.lanMu.RLock()
+	defer nm.lanMu.RUnlock()
+
+	return nm.lan.isDIS
+}
+
+// electDIS elects the designated intermediate system of the LAN. The IS with the highest priority
+// wins. Ties are broken by the highest SNPA. A DIS is elected only if at least one adjacency is up.
+// The caller is responsible to update the LSPs.
+func (nm *neighborManager) electDIS() {
+	if !nm.netIfa.isLAN() {
+		return
+	}
+
+	candidates := make([]disCandidate, 0)
+	for _, n := range nm.getNeighborsUp() {
+		priority, lanID := n.getPriorityAndLANID()
+		candidates = append(candidates, disCandidate{
+			snpa:     n.addr,
+			priority: priority,
+			lanID:    lanID,
+		})
+	}
+
+	s := lanState{}
+	if len(candidates) > 0 {
+		candidates = append(candidates, disCandidate{
+			snpa:     nm.netIfa.ethernetInterface.GetMAC(),
+			priority: nm.netIfa.levelConfig(nm.level).Priority,
+			lanID:    nm.netIfa.ownLANID(),
+			local:    true,
+		})
+
+		dis := electDIS(candidates)
+		s.lanID = dis.lanID
+		s.isDIS = dis.local
+	}
+
+	nm.lanMu.Lock()
+	defer nm.lanMu.Unlock()
+
+	if nm.lan != s {
+		log.WithFields(nm.fields()).Infof("DIS changed: LAN ID %s-%02x (local: %v)", s.lanID.SystemID.String(), s.lanID.CircuitID, s.isDIS)
+	}
+
+	nm.lan = s
+}
+
+type disCandidate struct {
+	snpa     ethernet.MACAddr
+	priority uint8
+	lanID    types.SourceID
+	local    bool
+}
+
+func electDIS(candidates []disCandidate) disCandidate {
+	dis := candidates[0]
+	for _, c := range candidates[1:] {
+		if c.priority > dis.priority || (c.priority == dis.priority && bytes.Compare(c.snpa[:], dis.snpa[:]) > 0) {
+			dis = c
+		}
+	}
+
+	return dis
+}
+
+// lanISReachabilityNeighbor gets the reachability of the pseudonode of a LAN interface.
+// Returns nil if the interface has no adjacency up or no DIS has been elected.
+func (nifa *netIfa) lanISReachabilityNeighbor(nm *neighborManager) *packet.ExtendedISReachabilityNeighbor {
+	lanID := nm.getLANID()
+	if lanID.CircuitID == 0 {
+		return nil
+	}
+
+	eirn := packet.NewExtendedISReachabilityNeighbor(lanID, nifa.levelConfig(nm.level).Metric)
+	for _, pfx := range nifa.ipv4Addrs() {
+		eirn.AddSubTLV(packet.NewIPv4InterfaceAddressSubTLV(pfx.Addr().ToUint32()))
+	}
+
+	return eirn
+}
+
+// generatePseudonodeLSPs generates the fragments of the LSP of the pseudonode of a LAN we are DIS on. It lists
+// all ISs we have an adjacency with including ourselves with metric 0.
+func (s *Server) generatePseudonodeLSPs(nifa *netIfa, level uint8, maxLen int) []*packet.LSPDU {
+	members := []*packet.ExtendedISReachabilityNeighbor{
+		packet.NewExtendedISReachabilityNeighbor(types.NewSourceID(s.systemID(), 0), 0),
+	}
+
+	for _, n := range nifa.neighborManager(level).getNeighborsUp() {
+		members = append(members, packet.NewExtendedISReachabilityNeighbor(types.NewSourceID(n.sysID, 0), 0))
+	}
+
+	tlvs := make([]packet.TLV, 0)
+	var eisr *packet.ExtendedISReachabilityTLV
+	for _, m := range members {
+		if eisr == nil || int(eisr.Length())+int(m.Length()) > packet.MaxTLVLength {
+			eisr = packet.NewExtendedISReachabilityTLV()
+			tlvs = append(tlvs, eisr)
+		}
+
+		eisr.AddNeighbor(m)
+	}
+
+	return fragmentLSP(&packet.LSPDU{
+		RemainingLifetime: defaultLifetimeSeconds,
+		LSPID: packet.LSPID{
+			SystemID:     s.systemID(),
+			PseudonodeID: nifa.circuitID,
+		},
+		TypeBlock: s.isType(),
+	}, tlvs, maxLen)
+}
@@ -0,0 +1,54 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/bio-routing/bio-rd/net/ethernet"
+	"github.com/bio-routing/bio-rd/protocols/isis/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestElectDIS(t *testing.T) {
+	lanA := types.NewSourceID(types.SystemID{0, 0, 0, 0, 0, 1}, 1)
+	lanB := types.NewSourceID(types.SystemID{0, 0, 0, 0, 0, 2}, 3)
+	lanC := types.NewSourceID(types.SystemID{0, 0, 0, 0, 0, 3}, 2)
+
+	tests := []struct {
+		name       string
+		candidates []disCandidate
+		expected   types.SourceID
+	}{
+		{
+			name: "highest priority wins",
+			candidates: []disCandidate{
+				{snpa: ethernet.MACAddr{0, 0, 0, 0, 0, 3}, priority: 64, lanID: lanA, local: true},
+				{snpa: ethernet.MACAddr{0, 0, 0, 0, 0, 1}, priority: 100, lanID: lanB},
+				{snpa: ethernet.MACAddr{0, 0, 0, 0, 0, 2}, priority: 64, lanID: lanC},
+			},
+			expected: lanB,
+		},
+		{
+			name: "tie broken by highest SNPA",
+			candidates: []disCandidate{
+				{snpa: ethernet.MACAddr{0, 0, 0, 0, 0, 1}, priority: 64, lanID: lanA, local: true},
+				{snpa: ethernet.MACAddr{0, 0, 0, 0, 1, 0}, priority: 64, lanID: lanB},
+				{snpa: ethernet.MACAddr{0, 0, 0, 0, 0, 2}, priority: 64, lanID: lanC},
+			},
+			expected: lanB,
+		},
+		{
+			name: "local system wins",
+			candidates: []disCandidate{
+				{snpa: ethernet.MACAddr{0, 0, 0, 0, 0, 1}, priority: 127, lanID: lanA, local: true},
+				{snpa: ethernet.MACAddr{0, 0, 0, 0, 0, 2}, priority: 0, lanID: lanB},
+			},
+			expected: lanA,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, electDIS(test.candidates).lanID)
+		})
+	}
+}
@@ -9,7 +9,12 @@ import (
 	"github.com/bio-routing/bio-rd/util/log"
 )
 
-const lspRefreshThresholdSeconds = 300
+const (
+	lspRefreshThresholdSeconds = 300
+
+	// zeroAgeLifetimeSeconds is the time purges are retained in the LSDB (ISO 10589 ZeroAgeLifetime)
+	zeroAgeLifetimeSeconds = 60
+)
 
 type lsdb struct {
 	srv       *Server
@@ -18,6 +23,7 @@ type lsdb struct {
 	done      chan struct{}
 	wg        sync.WaitGroup
 	refreshCh chan struct{}
+	lspKey    *AuthenticationKey // key our LSPs were authenticated with
 }
 
 func newLSDB(s *Server) *lsdb {
@@ -29,7 +35,7 @@ func newLSDB(s *Server) *lsdb {
 	}
 }
 
-func (l *lsdb) level() int {
+func (l *lsdb) level() uint8 {
 	if l.srv.lsdbL1 == l {
 		return 1
 	}
@@ -62,7 +68,7 @@ func (l *lsdb) start(decrementTicker *bbclock.Ticker, minLSPTransTicker *bbclock
 	go l.sendCSNPsRoutine(csnpTransTicker)
 
 	l.wg.Add(1)
-	go l.l2LSPUpdater()
+	go l.lspUpdater()
 }
 
 func (l *lsdb) stop() {
@@ -84,20 +90,44 @@ func (l *lsdb) decrementRemainingLifetimesRoutine(t *bbclock.Ticker) {
 }
 
 func (l *lsdb) decrementRemainingLifetimes() {
+	interfaces := l.srv.netIfaManager.getAllInterfaces()
+
 	l.lspsMu.Lock()
 	defer l.lspsMu.Unlock()
 
+	// Re-originate our LSPs on key rollover
+	if l.srv.lspKeyChain(l.level()).sendKey(clock.Now()) != l.lspKey {
+		l.requestLSPUpdate()
+	}
+
 	for lspid, lspdbEntry := range l.lsps {
+		if lspdbEntry.lspdu.RemainingLifetime == 0 {
+			if lspdbEntry.zeroAgeLifetime <= 1 {
+				delete(l.lsps, lspid)
+				continue
+			}
+
+			lspdbEntry.zeroAgeLifetime--
+			continue
+		}
+
 		if lspid.SystemID == l.srv.systemID() && lspdbEntry.lspdu.RemainingLifetime < lspRefreshThresholdSeconds {
-			l.requestL2LSPUpdate()
+			l.requestLSPUpdate()
+		}
+
+		lspdbEntry.lspdu.RemainingLifetime--
+		if lspdbEntry.lspdu.RemainingLifetime > 0 {
+			continue
 		}
 
-		if lspdbEntry.lspdu.RemainingLifetime <= 1 {
+		if lspdbEntry.lspdu.SequenceNumber == 0 {
 			delete(l.lsps, lspid)
 			continue
 		}
 
-		lspdbEntry.lspdu.RemainingLifetime--
+		// Expired LSPs are purged (ISO 10589 7.3.16.3)
+		l._installPurge(l.newPurge(lspid, lspdbEntry.lspdu.SequenceNumber), interfaces)
+		l.srv.triggerSPF()
 	}
 }
 
@@ -125,6 +155,11 @@ func (l *lsdb) sendLSPDUs() {
 			}
 
 			ifa.sendLSPDU(entry.lspdu, l.level())
+
+			// LSPs are not acknowledged on broadcast circuits. Missing LSPs are detected via the CSNPs of the DIS.
+			if ifa.isLAN() {
+				entry.clearSRMFlag(ifa)
+			}
 		}
 	}
 }
@@ -182,7 +217,7 @@ func (l *lsdb) processCSNPLSPEntry(lspEntry *packet.LSPEntry, from *netIfa) {
 }
 
 func (l *lsdb) processCSNPLSPEntryUnknown(lspEntry *packet.LSPEntry, from *netIfa) {
-	l.lsps[lspEntry.LSPID] = newEmptyLSDBEntry(lspEntry)
+	l.lsps[lspEntry.LSPID] = newEmptyLSDBEntry(lspEntry, l.level())
 	l.lsps[lspEntry.LSPID].setSSN(from)
 }
 
@@ -227,7 +262,13 @@ func (l *lsdb) sendCSNPsRoutine(t *bbclock.Ticker) {
 
 func (l *lsdb) sendCSNPss() {
 	for _, ifa := range l.srv.netIfaManager.getAllInterfaces() {
-		if len(ifa.neighborManagerL2.getNeighborsUp()) < 1 {
+		nm := ifa.neighborManager(l.level())
+		if nm == nil || len(nm.getNeighborsUp()) < 1 {
+			continue
+		}
+
+		// Only the DIS sends CSNPs on broadcast circuits
+		if ifa.isLAN() && !nm.isDIS() {
 			continue
 		}
 
@@ -257,12 +298,12 @@ func (l *lsdb) sendPSNPss() {
 	}
 
 	for _, ifa := range l.srv.netIfaManager.getAllInterfaces() {
-		if ifa.cfg.Passive {
+		if ifa.cfg.Passive || ifa.neighborManager(l.level()) == nil {
 			continue
 		}
 
 		lspdus := l._getLSPWithSSNSet(ifa)
-		for _, psnp := range packet.NewPSNPs(srcID, lspdus, ifa.ethernetInterface.GetMTU()) {
+		for _, psnp := range packet.NewPSNPs(srcID, lspdus, l.maxSNPLen(ifa, false)) {
 			ifa.sendPSNP(&psnp, l.level())
 		}
 	}
@@ -306,7 +347,18 @@ func (l *lsdb) getCSNPs(ifa *netIfa) []packet.CSNP {
 		SystemID: l.srv.nets[0].SystemID,
 	}
 
-	return packet.NewCSNPs(srcID, l.getLSPEntries(), ifa.ethernetInterface.GetMTU())
+	return packet.NewCSNPs(srcID, l.getLSPEntries(), l.maxSNPLen(ifa, true))
+}
+
+// maxSNPLen gets the maximum length of sequence number PDUs sent on an interface leaving room for the authentication TLV
+func (l *lsdb) maxSNPLen(ifa *netIfa, complete bool) int {
+	mtu := ifa.ethernetInterface.GetMTU()
+	key := l.srv.snpKeyChain(l.level(), complete).sendKey(clock.Now())
+	if key == nil {
+		return mtu
+	}
+
+	return mtu - 2 - int(key.tlv().Length())
 }
 
 func (l *lsdb) sendCSNPs(ifa *netIfa) {
@@ -321,13 +373,23 @@ func (l *lsdb) processLSP(ifa *netIfa, lspdu *packet.LSPDU) {
 	defer l.lspsMu.Unlock()
 
 	existingLSDBEntry, exists := l.lsps[lspdu.LSPID]
-	if !exists || lspdu.SequenceNumber > existingLSDBEntry.lspdu.SequenceNumber {
+	if !exists || lspNewer(lspdu, existingLSDBEntry.lspdu) {
 		log.Debugf("ISIS: Received newer LSPDU %v sequence number %d", lspdu.LSPID, lspdu.SequenceNumber)
+		if lspdu.LSPID.SystemID == l.srv.systemID() {
+			l.processNewerOwnLSPDU(ifa, lspdu, existingLSDBEntry)
+			return
+		}
+
+		if !exists && lspdu.RemainingLifetime == 0 {
+			l.processUnknownPurge(ifa, lspdu)
+			return
+		}
+
 		l.processNewerLSPDU(ifa, lspdu)
 		return
 	}
 
-	if lspdu.SequenceNumber == existingLSDBEntry.lspdu.SequenceNumber {
+	if !lspNewer(existingLSDBEntry.lspdu, lspdu) {
 		log.Debugf("ISIS: Received same sequence LSPDU %v sequence number %d", lspdu.LSPID, lspdu.SequenceNumber)
 		existingLSDBEntry.processSameLSPDU(ifa)
 		return
@@ -337,22 +399,67 @@ func (l *lsdb) processLSP(ifa *netIfa, lspdu *packet.LSPDU) {
 	existingLSDBEntry.newerLocalLSPDU(ifa)
 }
 
+// lspNewer checks if LSP a is newer than LSP b. Of two LSPs with equal sequence numbers a purge is
+// considered newer (ISO 10589 7.3.16.4).
+func lspNewer(a *packet.LSPDU, b *packet.LSPDU) bool {
+	if a.SequenceNumber != b.SequenceNumber {
+		return a.SequenceNumber > b.SequenceNumber
+	}
+
+	return a.RemainingLifetime == 0 && b.RemainingLifetime != 0
+}
+
 func (l *lsdb) processNewerLSPDU(ifa *netIfa, lspdu *packet.LSPDU) {
-	lsdbEntry := newLSDBEntry(lspdu)
+	lsdbEntry := newLSDBEntry(lspdu, l.level())
 
 	for _, i := range l.srv.netIfaManager.getAllInterfacesExcept(ifa) {
 		lsdbEntry.setSRM(i)
 	}
 
 	lsdbEntry.clearSRMFlag(ifa)
-	lsdbEntry.setSSN(ifa)
+	if !ifa.isLAN() {
+		lsdbEntry.setSSN(ifa)
+	}
+
+	l.lsps[lspdu.LSPID] = lsdbEntry
+	l.srv.triggerSPF()
+}
+
+// processUnknownPurge acknowledges the purge of an LSP not in the database without flooding it
+// (ISO 10589 7.3.16.4). It is retained for ZeroAgeLifetime only.
+func (l *lsdb) processUnknownPurge(ifa *netIfa, lspdu *packet.LSPDU) {
+	lsdbEntry := newLSDBEntry(lspdu, l.level())
+	if !ifa.isLAN() {
+		lsdbEntry.setSSN(ifa)
+	}
+
+	l.lsps[lspdu.LSPID] = lsdbEntry
+}
+
+// processNewerOwnLSPDU processes an instance of a self originated LSP newer than ours, e.g. one
+// originated before a restart (ISO 10589 7.3.16.1). It is stored so our next instance gets a higher
+// sequence number. LSPs we still originate are re-originated, all others are purged.
+func (l *lsdb) processNewerOwnLSPDU(ifa *netIfa, lspdu *packet.LSPDU, existing *lsdbEntry) {
+	originated := existing != nil && existing.lspdu.SequenceNumber != 0 && existing.lspdu.RemainingLifetime != 0
+
+	lsdbEntry := newLSDBEntry(lspdu, l.level())
+	if !ifa.isLAN() {
+		lsdbEntry.setSSN(ifa)
+	}
 
 	l.lsps[lspdu.LSPID] = lsdbEntry
-	return
+	if originated {
+		l.requestLSPUpdate()
+		return
+	}
+
+	if lspdu.RemainingLifetime != 0 {
+		l._purgeLSP(lsdbEntry, l.srv.netIfaManager.getAllInterfaces())
+	}
 }
 
-// requestL2LSPUpdate queues an update request if none is pending
-func (l *lsdb) requestL2LSPUpdate() {
+// requestLSPUpdate queues an update request if none is pending
+func (l *lsdb) requestLSPUpdate() {
 	select {
 	case l.refreshCh <- struct{}{}:
 		return
@@ -361,7 +468,7 @@ func (l *lsdb) requestL2LSPUpdate() {
 	}
 }
 
-func (l *lsdb) l2LSPUpdater() {
+func (l *lsdb) lspUpdater() {
 	defer l.wg.Done()
 
 	for {
@@ -369,21 +476,152 @@ func (l *lsdb) l2LSPUpdater() {
 		case <-l.done:
 			return
 		case <-l.refreshCh:
-			l.updateL2LSP()
+			l.updateLSP()
 		}
 	}
 }
 
-func (l *lsdb) updateL2LSP() {
-	lspdu := l.srv.generateLocalLSP()
-	lsdbEntry := newLSDBEntry(lspdu)
+// updateLSP originates the LSPs of the local system. Nothing is originated if no interface participates in the level.
+func (l *lsdb) updateLSP() {
+	key := l.srv.lspKeyChain(l.level()).sendKey(clock.Now())
+	l.lspsMu.Lock()
+	l.lspKey = key
+	l.lspsMu.Unlock()
 
-	for _, ifa := range l.srv.netIfaManager.getAllInterfaces() {
-		lsdbEntry.setSRM(ifa)
+	if !l.srv.levelEnabled(l.level()) {
+		return
 	}
 
+	interfaces := l.srv.netIfaManager.getAllInterfaces()
+	maxLen := l.maxLSPLen(interfaces)
+	lsps := l.srv.generateLocalLSPs(l.level(), maxLen)
+
 	l.lspsMu.Lock()
 	defer l.lspsMu.Unlock()
 
+	l._installLocalLSPs(lsps, interfaces)
+	l._updatePseudonodeLSPs(interfaces, maxLen)
+	l.srv.triggerSPF()
+}
+
+// maxLSPLen gets the maximum length of the LSPs we originate. LSPs must fit into the MTU of all
+// interfaces participating in the level and leave room for the authentication TLV.
+func (l *lsdb) maxLSPLen(interfaces []*netIfa) int {
+	ret := originatingLSPBufferSize
+	for _, ifa := range interfaces {
+		if ifa.ethernetInterface == nil || ifa.neighborManager(l.level()) == nil {
+			continue
+		}
+
+		mtu := ifa.ethernetInterface.GetMTU() - llcHeaderLen
+		if mtu > 0 && mtu < ret {
+			ret = mtu
+		}
+	}
+
+	key := l.srv.lspKeyChain(l.level()).sendKey(clock.Now())
+	if key == nil {
+		return ret
+	}
+
+	return ret - 2 - int(key.tlv().Length())
+}
+
+// _installLocalLSPs installs the fragments of a self originated LSP and purges the fragments of it
+// not originated anymore. l.lspsMu must be held.
+func (l *lsdb) _installLocalLSPs(lsps []*packet.LSPDU, interfaces []*netIfa) {
+	for _, lspdu := range lsps {
+		l._installLocalLSP(lspdu, interfaces)
+	}
+
+	id := lsps[0].LSPID
+	for lspID, e := range l.lsps {
+		if lspID.SystemID != id.SystemID || lspID.PseudonodeID != id.PseudonodeID || int(lspID.LSPNumber) < len(lsps) {
+			continue
+		}
+
+		if e.lspdu.SequenceNumber == 0 || e.lspdu.RemainingLifetime == 0 {
+			continue
+		}
+
+		l._purgeLSP(e, interfaces)
+	}
+}
+
+// _installLocalLSP installs a self originated LSP and floods it on all interfaces. Its sequence number
+// follows the one of the instance in the database. l.lspsMu must be held.
+func (l *lsdb) _installLocalLSP(lspdu *packet.LSPDU, interfaces []*netIfa) {
+	lspdu.SequenceNumber = 1
+	if e, found := l.lsps[lspdu.LSPID]; found {
+		lspdu.SequenceNumber = e.lspdu.SequenceNumber + 1
+	}
+
+	l.srv.sealLSP(lspdu, l.level())
+
+	lsdbEntry := newLSDBEntry(lspdu, l.level())
+	for _, ifa := range interfaces {
+		lsdbEntry.setSRM(ifa)
+	}
+
 	l.lsps[lspdu.LSPID] = lsdbEntry
 }
+
+// _updatePseudonodeLSPs originates the pseudonode LSPs of all LANs we are DIS on and purges
+// pseudonode LSPs of LANs we are not DIS on anymore. l.lspsMu must be held.
+func (l *lsdb) _updatePseudonodeLSPs(interfaces []*netIfa, maxLen int) {
+	dis := make(map[uint8]*netIfa)
+	for _, ifa := range interfaces {
+		if nm := ifa.neighborManager(l.level()); ifa.isLAN() && nm != nil && nm.isDIS() {
+			dis[ifa.circuitID] = ifa
+		}
+	}
+
+	for lspID, e := range l.lsps {
+		if lspID.SystemID != l.srv.systemID() || lspID.PseudonodeID == 0 {
+			continue
+		}
+
+		if _, found := dis[lspID.PseudonodeID]; found || e.lspdu.SequenceNumber == 0 || e.lspdu.RemainingLifetime == 0 {
+			continue
+		}
+
+		l._purgeLSP(e, interfaces)
+	}
+
+	for _, ifa := range dis {
+		l._installLocalLSPs(l.srv.generatePseudonodeLSPs(ifa, l.level(), maxLen), interfaces)
+	}
+}
+
+// _purgeLSP purges an LSP by flooding it with a remaining lifetime of zero and an incremented
+// sequence number so it gets removed by all other ISs. l.lspsMu must be held.
+func (l *lsdb) _purgeLSP(e *lsdbEntry, interfaces []*netIfa) {
+	log.WithFields(l.fields()).Infof("Purging LSP %v", e.lspdu.LSPID)
+
+	l._installPurge(l.newPurge(e.lspdu.LSPID, e.lspdu.SequenceNumber+1), interfaces)
+}
+
+// newPurge creates a purge of an LSP. Purges only consist of the LSP header and the authentication TLV.
+func (l *lsdb) newPurge(lspID packet.LSPID, sequenceNumber uint32) *packet.LSPDU {
+	purge := &packet.LSPDU{
+		RemainingLifetime: 0,
+		LSPID:             lspID,
+		SequenceNumber:    sequenceNumber,
+		TLVs:              make([]packet.TLV, 0),
+	}
+	purge.UpdateLength()
+	l.srv.authenticateLSP(purge, l.level())
+
+	return purge
+}
+
+// _installPurge replaces an LSP by a purge and floods it on all interfaces. The purge is retained
+// for ZeroAgeLifetime. l.lspsMu must be held.
+func (l *lsdb) _installPurge(purge *packet.LSPDU, interfaces []*netIfa) {
+	lsdbEntry := newLSDBEntry(purge, l.level())
+	for _, ifa := range interfaces {
+		lsdbEntry.setSRM(ifa)
+	}
+
+	l.lsps[purge.LSPID] = lsdbEntry
+}
@@ -7,10 +7,12 @@ import (
 )
 
 type lsdbEntry struct {
-	lspdu    *packet.LSPDU
-	srmFlags map[*netIfa]struct{}
-	ssnFlags map[*netIfa]struct{}
-	mutex    sync.RWMutex
+	level           uint8
+	lspdu           *packet.LSPDU
+	zeroAgeLifetime uint16 // seconds a purge is retained for
+	srmFlags        map[*netIfa]struct{}
+	ssnFlags        map[*netIfa]struct{}
+	mutex           sync.RWMutex
 }
 
 type LSDBEntry struct {
@@ -23,16 +25,24 @@ func (l *LSDBEntry) GetLSPDU() *packet.LSPDU {
 	return l.lspdu
 }
 
-func newLSDBEntry(lspdu *packet.LSPDU) *lsdbEntry {
-	return &lsdbEntry{
+func newLSDBEntry(lspdu *packet.LSPDU, level uint8) *lsdbEntry {
+	e := &lsdbEntry{
+		level:    level,
 		lspdu:    lspdu,
 		srmFlags: make(map[*netIfa]struct{}),
 		ssnFlags: make(map[*netIfa]struct{}),
 	}
+
+	if lspdu.RemainingLifetime == 0 {
+		e.zeroAgeLifetime = zeroAgeLifetimeSeconds
+	}
+
+	return e
 }
 
-func newEmptyLSDBEntry(lspEntry *packet.LSPEntry) *lsdbEntry {
+func newEmptyLSDBEntry(lspEntry *packet.LSPEntry, level uint8) *lsdbEntry {
 	return &lsdbEntry{
+		level: level,
 		lspdu: &packet.LSPDU{
 			RemainingLifetime: lspEntry.RemainingLifetime,
 			LSPID:             lspEntry.LSPID,
@@ -75,7 +85,8 @@ func (l *lsdbEntry) setSRM(ifa *netIfa) {
 		return
 	}
 
-	if len(ifa.neighborManagerL2.getNeighbors()) == 0 {
+	nm := ifa.neighborManager(l.level)
+	if nm == nil || len(nm.getNeighbors()) == 0 {
 		return
 	}
 
@@ -164,7 +175,9 @@ func (l *lsdbEntry) olderInDatabase(x *packet.LSPEntry) bool {
 
 func (l *lsdbEntry) processSameLSPDU(ifa *netIfa) {
 	l.clearSRMFlag(ifa)
-	l.setSSN(ifa)
+	if !ifa.isLAN() {
+		l.setSSN(ifa)
+	}
 }
 
 func (l *lsdbEntry) newerLocalLSPDU(ifa *netIfa) {
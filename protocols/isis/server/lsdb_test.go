@@ -0,0 +1,142 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/bio-routing/bio-rd/protocols/isis/packet"
+	"github.com/bio-routing/bio-rd/protocols/isis/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func testLSDB() *lsdb {
+	s := &Server{
+		nets: []*types.NET{
+			{SystemID: sysA},
+		},
+	}
+	s.netIfaManager = newNetIfaManager(s)
+	s.lsdbL1 = newLSDB(s)
+	s.lsdbL2 = newLSDB(s)
+
+	return s.lsdbL1
+}
+
+func testPurge(sysID types.SystemID, sequenceNumber uint32) *packet.LSPDU {
+	return &packet.LSPDU{
+		LSPID: packet.LSPID{
+			SystemID: sysID,
+		},
+		SequenceNumber: sequenceNumber,
+		TLVs:           make([]packet.TLV, 0),
+	}
+}
+
+func TestLSPNewer(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        *packet.LSPDU
+		b        *packet.LSPDU
+		expected bool
+	}{
+		{
+			name:     "higher sequence number",
+			a:        &packet.LSPDU{SequenceNumber: 2, RemainingLifetime: 1200},
+			b:        &packet.LSPDU{SequenceNumber: 1, RemainingLifetime: 1200},
+			expected: true,
+		},
+		{
+			name:     "lower sequence number purge",
+			a:        &packet.LSPDU{SequenceNumber: 1},
+			b:        &packet.LSPDU{SequenceNumber: 2, RemainingLifetime: 1200},
+			expected: false,
+		},
+		{
+			name:     "same sequence number purge",
+			a:        &packet.LSPDU{SequenceNumber: 2},
+			b:        &packet.LSPDU{SequenceNumber: 2, RemainingLifetime: 1200},
+			expected: true,
+		},
+		{
+			name:     "same sequence number",
+			a:        &packet.LSPDU{SequenceNumber: 2, RemainingLifetime: 1000},
+			b:        &packet.LSPDU{SequenceNumber: 2, RemainingLifetime: 1200},
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, lspNewer(test.a, test.b))
+		})
+	}
+}
+
+func TestProcessLSPPurge(t *testing.T) {
+	l := testLSDB()
+	ifa := &netIfa{cfg: &InterfaceConfig{PointToPoint: true}}
+
+	lsp := testLSP(sysB, nil, nil)
+	l.processLSP(ifa, lsp)
+	assert.Equal(t, lsp, l.lsps[lsp.LSPID].lspdu)
+
+	// Purges with the same sequence number supersede the LSP
+	purge := testPurge(sysB, 1)
+	l.processLSP(ifa, purge)
+	assert.Equal(t, purge, l.lsps[lsp.LSPID].lspdu)
+	assert.Equal(t, uint16(zeroAgeLifetimeSeconds), l.lsps[lsp.LSPID].zeroAgeLifetime)
+
+	// Purges of unknown LSPs are acknowledged
+	purge = testPurge(sysC, 5)
+	l.processLSP(ifa, purge)
+	assert.True(t, l.lsps[purge.LSPID].getSSN(ifa))
+
+	// Purges are removed after ZeroAgeLifetime
+	for i := 0; i < zeroAgeLifetimeSeconds; i++ {
+		l.decrementRemainingLifetimes()
+	}
+
+	assert.Empty(t, l.lsps)
+}
+
+func TestProcessNewerOwnLSP(t *testing.T) {
+	l := testLSDB()
+	ifa := &netIfa{cfg: &InterfaceConfig{PointToPoint: true}}
+
+	// LSPs we do not originate are purged
+	stale := testLSP(sysA, nil, nil)
+	stale.LSPID.LSPNumber = 3
+	stale.SequenceNumber = 10
+	l.processLSP(ifa, stale)
+
+	e := l.lsps[stale.LSPID]
+	assert.Equal(t, uint16(0), e.lspdu.RemainingLifetime)
+	assert.Equal(t, uint32(11), e.lspdu.SequenceNumber)
+
+	// LSPs we originate are re-originated with a higher sequence number
+	own := testLSP(sysA, nil, nil)
+	l._installLocalLSP(own, nil)
+	assert.Equal(t, uint32(1), own.SequenceNumber)
+
+	old := testLSP(sysA, nil, nil)
+	old.SequenceNumber = 20
+	l.processLSP(ifa, old)
+	assert.Len(t, l.refreshCh, 1)
+
+	l._installLocalLSP(testLSP(sysA, nil, nil), nil)
+	assert.Equal(t, uint32(21), l.lsps[own.LSPID].lspdu.SequenceNumber)
+}
+
+func TestLSPExpiry(t *testing.T) {
+	l := testLSDB()
+	ifa := &netIfa{cfg: &InterfaceConfig{PointToPoint: true}}
+
+	lsp := testLSP(sysB, nil, nil)
+	lsp.RemainingLifetime = 1
+	l.processLSP(ifa, lsp)
+	l.decrementRemainingLifetimes()
+
+	e := l.lsps[lsp.LSPID]
+	assert.Equal(t, uint16(0), e.lspdu.RemainingLifetime)
+	assert.Equal(t, uint32(1), e.lspdu.SequenceNumber)
+	assert.Empty(t, e.lspdu.TLVs)
+}
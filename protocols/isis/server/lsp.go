@@ -1,11 +1,23 @@
 package server
 
 import (
+	bnet "github.com/bio-routing/bio-rd/net"
 	"github.com/bio-routing/bio-rd/protocols/device"
 	"github.com/bio-routing/bio-rd/protocols/isis/packet"
+	"github.com/bio-routing/bio-rd/util/log"
 )
 
-const defaultLifetimeSeconds = 1800
+const (
+	defaultLifetimeSeconds = 1800
+
+	// originatingLSPBufferSize is the default maximum size of LSPs we originate (ISO 10589)
+	originatingLSPBufferSize = 1492
+
+	// llcHeaderLen is the length of the 802.2 LLC header preceding IS-IS PDUs
+	llcHeaderLen = 3
+
+	maxLSPNumber = 255
+)
 
 func (s *Server) getProtocolsSupportedTLV() packet.ProtocolsSupportedTLV {
 	return packet.NewProtocolsSupportedTLV([]uint8{
@@ -14,74 +26,314 @@ func (s *Server) getProtocolsSupportedTLV() packet.ProtocolsSupportedTLV {
 	})
 }
 
-func (s *Server) nextL2SequencenNumber() uint32 {
-	s.sequenceNumberL2Mu.Lock()
-	defer s.sequenceNumberL2Mu.Unlock()
+// generateLocalLSPs generates the fragments of our LSP of a level. TLVs are split across LSP numbers
+// so no fragment exceeds maxLen. Sequence numbers, authentication and checksums are set when the
+// fragments are installed into the LSDB.
+func (s *Server) generateLocalLSPs(level uint8, maxLen int) []*packet.LSPDU {
+	// TLVs required in LSP number 0 go first
+	tlvs := []packet.TLV{
+		packet.NewAreaAddressesTLV(s.areaIDs()),
+		s.getProtocolsSupportedTLV(),
+		packet.NewIPInterfaceAddressesTLV(s.netIfaManager.getAddressesIPv4()),
+	}
 
-	s.sequenceNumberL2++
-	if s.sequenceNumberL2 == 0 {
-		s.sequenceNumberL2++
+	if s.multiTopology.Load() {
+		tlvs = append(tlvs, packet.NewMultiTopologyTLV(s.topologies()))
 	}
 
-	return s.sequenceNumberL2
-}
+	tlvs = append(tlvs, s.extendedIPReachabilityTLVs(level)...)
+	tlvs = append(tlvs, s.isReachabilityTLVs(level, packet.MTIDStandard)...)
+
+	ipv6Addrs := s.netIfaManager.getAddressesIPv6()
+	if len(ipv6Addrs) > 0 {
+		tlvs = append(tlvs, packet.NewIPv6InterfaceAddressesTLV(ipv6Addrs))
+	}
+
+	tlvs = append(tlvs, s.ipv6TLVs(level)...)
+
+	if rc := s.routerCapabilityTLV(); rc != nil {
+		tlvs = append(tlvs, rc)
+	}
+
+	hostname, err := s.hostname()
+	if err == nil {
+		tlvs = append(tlvs, packet.NewDynamicHostnameTLV([]byte(hostname)))
+	}
 
-func (s *Server) generateLocalLSP() *packet.LSPDU {
-	l := &packet.LSPDU{
+	lsps := fragmentLSP(&packet.LSPDU{
 		RemainingLifetime: defaultLifetimeSeconds,
 		LSPID: packet.LSPID{
-			SystemID:     s.systemID(),
-			PseudonodeID: 0,
-			LSPNumber:    0, // FIXME: We may need to use multiple LSPDUs
-		},
-		SequenceNumber: s.nextL2SequencenNumber(),
-		TLVs: []packet.TLV{
-			packet.NewAreaAddressesTLV(s.areaIDs()),
-			s.getProtocolsSupportedTLV(),
-			packet.NewIPInterfaceAddressesTLV(s.netIfaManager.getAddressesIPv4()),
-			s.extendedIPReachabilityTLV(),
-			s.extendedISReachabilityTLV(),
+			SystemID: s.systemID(),
 		},
+		TypeBlock: s.isType(),
+	}, tlvs, maxLen)
+
+	if level == 1 && s.attached() {
+		lsps[0].TypeBlock |= packet.TypeBlockAttached
 	}
 
-	hostname, err := s.hostname()
-	if err == nil {
-		l.TLVs = append(l.TLVs, packet.NewDynamicHostnameTLV([]byte(hostname)))
+	if s.overloaded() {
+		lsps[0].TypeBlock |= packet.TypeBlockOverload
+	}
+
+	return lsps
+}
+
+// fragmentLSP packs TLVs into LSPs with the header of template and consecutive LSP numbers. No LSP
+// exceeds maxLen unless a single TLV does. TLVs exceeding the last LSP number are dropped.
+func fragmentLSP(template *packet.LSPDU, tlvs []packet.TLV, maxLen int) []*packet.LSPDU {
+	newFragment := func(lspNumber uint8) *packet.LSPDU {
+		l := *template
+		l.LSPID.LSPNumber = lspNumber
+		l.TLVs = make([]packet.TLV, 0)
+		return &l
+	}
+
+	ret := []*packet.LSPDU{
+		newFragment(0),
+	}
+
+	length := packet.LSPDUMinLen
+	for i, tlv := range tlvs {
+		l := ret[len(ret)-1]
+		tlvLen := 2 + int(tlv.Length())
+		if length+tlvLen > maxLen && len(l.TLVs) > 0 {
+			if len(ret) > maxLSPNumber {
+				log.Errorf("ISIS: LSP %v exceeds %d fragments. Dropping %d TLVs", template.LSPID, maxLSPNumber+1, len(tlvs)-i)
+				break
+			}
+
+			l = newFragment(uint8(len(ret)))
+			ret = append(ret, l)
+			length = packet.LSPDUMinLen
+		}
+
+		l.TLVs = append(l.TLVs, tlv)
+		length += tlvLen
 	}
 
+	return ret
+}
+
+// sealLSP sets length, authentication and checksum of a self originated LSP
+func (s *Server) sealLSP(l *packet.LSPDU, level uint8) {
 	l.UpdateLength()
+	s.authenticateLSP(l, level)
 	l.SetChecksum()
+}
 
-	return l
+// extendedIPReachabilityTLVs gets the TLVs advertising our IPv4 prefixes. Prefixes are split across
+// multiple TLVs if they exceed the maximum TLV length.
+func (s *Server) extendedIPReachabilityTLVs(level uint8) []packet.TLV {
+	ret := make([]packet.TLV, 0)
+	var eipr *packet.ExtendedIPReachabilityTLV
+	for _, e := range s.extendedIPReachabilities(level) {
+		if eipr == nil || int(eipr.Length())+int(e.Length()) > packet.MaxTLVLength {
+			eipr = packet.NewExtendedIPReachabilityTLV()
+			ret = append(ret, eipr)
+		}
+
+		eipr.AddExtendedIPReachability(e)
+	}
+
+	return ret
 }
 
-func (s *Server) extendedIPReachabilityTLV() *packet.ExtendedIPReachabilityTLV {
-	eipr := packet.NewExtendedIPReachabilityTLV()
+func (s *Server) extendedIPReachabilities(level uint8) []*packet.ExtendedIPReachability {
+	ret := make([]*packet.ExtendedIPReachability, 0)
 	for _, ifa := range s.netIfaManager.getAllInterfaces() {
-		if ifa.devStatus.GetOperState() != device.IfOperUp {
+		metric, ok := ifa.reachabilityMetric(level)
+		if !ok || ifa.devStatus.GetOperState() != device.IfOperUp {
 			continue
 		}
 
 		for _, addr := range ifa.ipv4Addrs() {
-			eipr.AddExtendedIPReachability(
-				packet.NewExtendedIPReachability(
-					ifa.cfg.Level2.Metric,
-					addr.Len(),
-					addr.BaseAddr().ToUint32()),
-			)
+			e := packet.NewExtendedIPReachability(
+				metric,
+				addr.Len(),
+				addr.BaseAddr().ToUint32())
+			if sid := s.prefixSIDSubTLV(ifa, addr); sid != nil {
+				e.AddSubTLV(sid)
+			}
+
+			ret = append(ret, e)
+		}
+	}
+
+	if level == 2 {
+		for _, r := range s.propagatedL1Routes() {
+			ret = append(ret, r.extendedIPReachability())
+		}
+
+		return ret
+	}
+
+	for _, r := range s.leakedL2Routes() {
+		e := r.extendedIPReachability()
+		e.SetUpDown()
+		ret = append(ret, e)
+	}
+
+	return ret
+}
+
+// extendedIPReachability gets the Extended IP Reachability advertising a route propagated between levels
+func (r *Route) extendedIPReachability() *packet.ExtendedIPReachability {
+	e := packet.NewExtendedIPReachability(r.Metric, r.Prefix.Len(), r.Prefix.Addr().ToUint32())
+	if r.PrefixSID != nil {
+		e.AddSubTLV(r.PrefixSID.subTLV())
+	}
+
+	return e
+}
+
+// reachabilityMetric gets the metric the prefixes of an interface are advertised with in the LSP of a level.
+// Prefixes of level 1 only interfaces are propagated into level 2 as well.
+func (nifa *netIfa) reachabilityMetric(level uint8) (uint32, bool) {
+	if c := nifa.levelConfig(level); c != nil {
+		return c.Metric, true
+	}
+
+	if level == 2 && nifa.cfg.Level1 != nil {
+		return nifa.cfg.Level1.Metric, true
+	}
+
+	return 0, false
+}
+
+// ipv6ReachabilityTLV is a TLV advertising IPv6 prefixes
+type ipv6ReachabilityTLV interface {
+	packet.TLV
+	AddIPv6Reachability(*packet.IPv6Reachability)
+}
+
+// ipv6TLVs gets the TLVs advertising our IPv6 prefixes. In multi topology mode prefixes are advertised
+// in the IPv6 unicast topology together with the neighbors participating in it. Prefixes are split across
+// multiple TLVs if they exceed the maximum TLV length.
+func (s *Server) ipv6TLVs(level uint8) []packet.TLV {
+	newTLV := func() ipv6ReachabilityTLV {
+		return packet.NewIPv6ReachabilityTLV()
+	}
+
+	ret := make([]packet.TLV, 0)
+	if s.multiTopology.Load() {
+		newTLV = func() ipv6ReachabilityTLV {
+			return packet.NewMTIPv6ReachabilityTLV(packet.MTIDIPv6Unicast)
+		}
+
+		ret = append(ret, s.isReachabilityTLVs(level, packet.MTIDIPv6Unicast)...)
+	}
+
+	var tlv ipv6ReachabilityTLV
+	for _, r := range s.ipv6Reachabilities(level) {
+		if tlv == nil || int(tlv.Length())+int(r.Length()) > packet.MaxTLVLength {
+			tlv = newTLV()
+			ret = append(ret, tlv)
 		}
+
+		tlv.AddIPv6Reachability(r)
 	}
 
-	return eipr
+	return ret
+}
+
+func (s *Server) ipv6Reachabilities(level uint8) []*packet.IPv6Reachability {
+	ret := make([]*packet.IPv6Reachability, 0)
+	for _, ifa := range s.netIfaManager.getAllInterfaces() {
+		metric, ok := ifa.reachabilityMetric(level)
+		if !ok || ifa.devStatus.GetOperState() != device.IfOperUp {
+			continue
+		}
+
+		for _, pfx := range ifa.ipv6Prefixes() {
+			ret = append(ret, packet.NewIPv6Reachability(metric, bnet.NewPfx(pfx.BaseAddr(), pfx.Len())))
+		}
+	}
+
+	return ret
+}
+
+// isReachabilityTLV is a TLV advertising IS reachabilities
+type isReachabilityTLV interface {
+	packet.TLV
+	AddNeighbor(*packet.ExtendedISReachabilityNeighbor)
 }
 
-func (s *Server) extendedISReachabilityTLV() *packet.ExtendedISReachabilityTLV {
-	eir := packet.NewExtendedISReachabilityTLV()
+// isReachabilityTLVs gets the TLVs advertising our neighbors in topology mtID. Neighbors are split across
+// multiple TLVs if they exceed the maximum TLV length.
+func (s *Server) isReachabilityTLVs(level uint8, mtID uint16) []packet.TLV {
+	newTLV := func() isReachabilityTLV {
+		if mtID == packet.MTIDStandard {
+			return packet.NewExtendedISReachabilityTLV()
+		}
+
+		return packet.NewMTIntermediateSystemsTLV(mtID)
+	}
+
+	ret := make([]packet.TLV, 0)
+	var tlv isReachabilityTLV
 	for _, ifa := range s.netIfaManager.getAllInterfaces() {
-		for _, n := range ifa.neighborManagerL2.getNeighborsUp() {
-			eir.AddNeighbor(n.extendedISReachabilityNeighbor())
+		for _, n := range ifa.isReachabilityNeighbors(level, mtID) {
+			if tlv == nil || int(tlv.Length())+int(n.Length()) > packet.MaxTLVLength {
+				tlv = newTLV()
+				ret = append(ret, tlv)
+			}
+
+			tlv.AddNeighbor(n)
+		}
+	}
+
+	return ret
+}
+
+// isReachabilityNeighbors gets the IS reachabilities of an interface in a level and topology mtID.
+// On LANs only the pseudonode is advertised.
+func (nifa *netIfa) isReachabilityNeighbors(level uint8, mtID uint16) []*packet.ExtendedISReachabilityNeighbor {
+	ret := make([]*packet.ExtendedISReachabilityNeighbor, 0)
+	nm := nifa.neighborManager(level)
+	if nm == nil {
+		return ret
+	}
+
+	supported := false
+	lanAdjSIDs := make([]*packet.AdjacencySIDSubTLV, 0)
+	for _, n := range nm.getNeighborsUp() {
+		if !n.supportsTopology(mtID) {
+			continue
+		}
+
+		supported = true
+		sid := n.adjacencySIDSubTLV(mtID)
+		if nifa.isLAN() {
+			if sid != nil {
+				lanAdjSIDs = append(lanAdjSIDs, sid)
+			}
+
+			continue
+		}
+
+		eirn := n.extendedISReachabilityNeighbor()
+		if sid != nil {
+			eirn.AddSubTLV(sid)
+		}
+
+		ret = append(ret, eirn)
+	}
+
+	if supported && nifa.isLAN() {
+		if eirn := nifa.lanISReachabilityNeighbor(nm); eirn != nil {
+			for _, sid := range lanAdjSIDs {
+				// LAN-Adjacency-SIDs not fitting into the TLV are not advertised
+				if int(eirn.SubTLVLength)+2+int(sid.Length()) > maxSubTLVLength {
+					break
+				}
+
+				eirn.AddSubTLV(sid)
+			}
+
+			ret = append(ret, eirn)
 		}
 	}
 
-	return eir
+	return ret
 }
@@ -0,0 +1,72 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/bio-routing/bio-rd/protocols/isis/packet"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFragmentLSP(t *testing.T) {
+	hostname := packet.NewDynamicHostnameTLV([]byte("foo"))
+	template := &packet.LSPDU{
+		RemainingLifetime: defaultLifetimeSeconds,
+		LSPID: packet.LSPID{
+			SystemID: sysA,
+		},
+		TypeBlock: packet.TypeBlockISTypeL1L2,
+	}
+
+	tests := []struct {
+		name     string
+		tlvs     []packet.TLV
+		maxLen   int
+		expected [][]packet.TLV
+	}{
+		{
+			name:   "no TLVs",
+			maxLen: 1492,
+			expected: [][]packet.TLV{
+				{},
+			},
+		},
+		{
+			name:   "single fragment",
+			tlvs:   []packet.TLV{hostname, hostname},
+			maxLen: 1492,
+			expected: [][]packet.TLV{
+				{hostname, hostname},
+			},
+		},
+		{
+			name:   "multiple fragments",
+			tlvs:   []packet.TLV{hostname, hostname, hostname},
+			maxLen: packet.LSPDUMinLen + 10,
+			expected: [][]packet.TLV{
+				{hostname, hostname},
+				{hostname},
+			},
+		},
+		{
+			name:   "TLV exceeding max length",
+			tlvs:   []packet.TLV{hostname, hostname},
+			maxLen: packet.LSPDUMinLen,
+			expected: [][]packet.TLV{
+				{hostname},
+				{hostname},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			lsps := fragmentLSP(template, test.tlvs, test.maxLen)
+			assert.Len(t, lsps, len(test.expected))
+			for i, lsp := range lsps {
+				assert.Equal(t, uint8(i), lsp.LSPID.LSPNumber)
+				assert.Equal(t, template.TypeBlock, lsp.TypeBlock)
+				assert.Equal(t, test.expected[i], lsp.TLVs)
+			}
+		})
+	}
+}
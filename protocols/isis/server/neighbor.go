@@ -7,6 +7,7 @@ import (
 	bbclock "github.com/benbjohnson/clock"
 	bnet "github.com/bio-routing/bio-rd/net"
 	"github.com/bio-routing/bio-rd/net/ethernet"
+	bfdserver "github.com/bio-routing/bio-rd/protocols/bfd/server"
 	"github.com/bio-routing/bio-rd/protocols/isis/packet"
 	"github.com/bio-routing/bio-rd/protocols/isis/types"
 	"github.com/bio-routing/bio-rd/util/log"
@@ -33,12 +34,20 @@ type neighbor struct {
 	timeout                time.Time
 	timeoutMu              sync.Mutex
 	priority               uint8
+	lanID                  types.SourceID
 	ipAddresses            []bnet.IP
 	protocols              []uint8
 	areas                  []types.AreaID
+	topologies             []uint16
 	adjCheckTicker         *bbclock.Ticker
 	wg                     sync.WaitGroup
 	done                   chan struct{}
+	adjSID                 uint32
+	adjSIDAllocator        *labelAllocator
+	adjSIDMu               sync.Mutex
+	bfdSession             *bfdserver.SessionConfig
+	bfdDown                bool
+	bfdMu                  sync.Mutex
 }
 
 func (n *neighbor) getAdjacency() *Adjacency {
@@ -63,20 +72,39 @@ func (n *neighbor) getAdjacency() *Adjacency {
 }
 
 func (nm *neighborManager) neighborFromP2PHello(hello *packet.P2PHello, addr ethernet.MACAddr) *neighbor {
-	n := &neighbor{
+	n := nm.newNeighbor(hello.SystemID, hello.HoldingTimer, addr)
+	n.processHelloTLVs(hello.TLVs)
+
+	return n
+}
+
+func (nm *neighborManager) neighborFromLANHello(hello *packet.LANHello, addr ethernet.MACAddr) *neighbor {
+	n := nm.newNeighbor(hello.SystemID, hello.HoldingTimer, addr)
+	n.priority = hello.Priority
+	n.lanID = hello.DesignatedIS
+	n.processHelloTLVs(hello.TLVs)
+
+	return n
+}
+
+func (nm *neighborManager) newNeighbor(sysID types.SystemID, holdingTimer uint16, addr ethernet.MACAddr) *neighbor {
+	return &neighbor{
 		addr:            addr,
-		sysID:           hello.SystemID,
+		sysID:           sysID,
 		nm:              nm,
 		lastStateChange: clock.Now(),
 		state:           packet.P2PAdjStateInit,
-		timeout:         clock.Now().Add(time.Duration(hello.HoldingTimer) * time.Second),
+		timeout:         clock.Now().Add(time.Duration(holdingTimer) * time.Second),
 		ipAddresses:     make([]bnet.IP, 0),
 		protocols:       make([]uint8, 0),
 		areas:           make([]types.AreaID, 0),
+		topologies:      []uint16{packet.MTIDStandard},
 		done:            make(chan struct{}),
 	}
+}
 
-	for _, tlv := range hello.TLVs {
+func (n *neighbor) processHelloTLVs(tlvs []packet.TLV) {
+	for _, tlv := range tlvs {
 		switch tlv.Type() {
 		case packet.ProtocolsSupportedTLVType:
 			x := tlv.Value().(packet.ProtocolsSupportedTLV)
@@ -88,6 +116,15 @@ func (nm *neighborManager) neighborFromP2PHello(hello *packet.P2PHello, addr eth
 			for _, a := range ipIntAddrs.IPv4Addresses {
 				n.ipAddresses = append(n.ipAddresses, bnet.IPv4(a))
 			}
+		case packet.IPv6InterfaceAddressesTLVType:
+			x := tlv.Value().(*packet.IPv6InterfaceAddressesTLV)
+			n.ipAddresses = append(n.ipAddresses, x.IPv6Addresses...)
+		case packet.MultiTopologyTLVType:
+			x := tlv.Value().(*packet.MultiTopologyTLV)
+			n.topologies = make([]uint16, 0, len(x.Topologies))
+			for _, t := range x.Topologies {
+				n.topologies = append(n.topologies, t.MTID)
+			}
 		case packet.AreaAddressesTLVType:
 			x := tlv.Value().(*packet.AreaAddressesTLV)
 			for _, a := range x.AreaIDs {
@@ -98,8 +135,6 @@ func (nm *neighborManager) neighborFromP2PHello(hello *packet.P2PHello, addr eth
 			n.extendedLocalCircuitID = x.ExtendedLocalCircuitID
 		}
 	}
-
-	return n
 }
 
 func (n *neighbor) down() {
@@ -140,6 +175,7 @@ func (n *neighbor) adjChecker() {
 			if state == packet.P2PAdjStateUp {
 				if n.timedOut() {
 					n.down()
+					n.nm.neighborDown(n)
 					state, change = n.getStateAndTime()
 				}
 			}
@@ -200,16 +236,21 @@ func (n *neighbor) processP2PHello(hello *packet.P2PHello) error {
 	}
 
 	if n.getState() != packet.P2PAdjStateUp && n.p2pAdjTLVContainsSelf(p2pAdjState) {
+		if n.heldDownByBFD() {
+			return nil
+		}
+
 		log.WithFields(n.fields()).Infof("Adjacency reaches up state")
 		n.setState(packet.P2PAdjStateUp)
-		n.nm.server.updateL2LSP()
+		n.subscribeBFD()
+		n.nm.server.updateLSP(n.nm.level)
 		return nil
 	}
 
 	if n.getState() == packet.P2PAdjStateUp && !n.p2pAdjTLVContainsSelf(p2pAdjState) {
 		log.WithFields(n.fields()).Infof("Adjacency reaches down state")
 		n.setState(packet.P2PAdjStateDown)
-		n.nm.server.updateL2LSP()
+		n.nm.server.updateLSP(n.nm.level)
 		return nil
 	}
 
@@ -231,13 +272,24 @@ func (n *neighbor) p2pAdjTLVContainsSelf(t *packet.P2PAdjacencyStateTLV) bool {
 	return t.NeighborSystemID == n.nm.netIfa.srv.nets[0].SystemID && t.NeighborExtendedLocalCircuitID == uint32(n.nm.netIfa.devStatus.GetIndex())
 }
 
+// supportsTopology checks if the neighbor participates in the topology with ID mtID
+func (n *neighbor) supportsTopology(mtID uint16) bool {
+	for _, t := range n.topologies {
+		if t == mtID {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (n *neighbor) extendedISReachabilityNeighbor() *packet.ExtendedISReachabilityNeighbor {
 	srcID := types.SourceID{
 		SystemID:  n.sysID,
 		CircuitID: 0, // TODO: Check if this needs to be adjusted
 	}
 
-	eirn := packet.NewExtendedISReachabilityNeighbor(srcID, n.nm.netIfa.cfg.Level2.Metric)
+	eirn := packet.NewExtendedISReachabilityNeighbor(srcID, n.nm.netIfa.levelConfig(n.nm.level).Metric)
 
 	for _, pfx := range n.nm.netIfa.ipv4Addrs() {
 		eirn.AddSubTLV(packet.NewIPv4InterfaceAddressSubTLV(pfx.Addr().ToUint32()))
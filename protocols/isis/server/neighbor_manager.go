@@ -18,6 +18,8 @@ type neighborManager struct {
 	level       uint8
 	neighbors   map[ethernet.MACAddr]*neighbor
 	neighborsMu sync.RWMutex
+	lan         lanState
+	lanMu       sync.RWMutex
 }
 
 func newNeighborManager(server *Server, netIfa *netIfa, level uint8) *neighborManager {
@@ -138,15 +140,45 @@ func (nm *neighborManager) adjChecker(n *neighbor) {
 	log.WithFields(nm.fields()).Debug("Removing neighbor from neighborManager")
 }
 
+// neighborDown is called when the adjacency to a neighbor timed out
+func (nm *neighborManager) neighborDown(n *neighbor) {
+	if nm.netIfa.isLAN() {
+		nm.electDIS()
+	}
+
+	nm.server.updateLSP(nm.level)
+}
+
 func (nm *neighborManager) dropNeighbour(n *neighbor) {
 	nm.neighborsMu.Lock()
 	defer nm.neighborsMu.Unlock()
 
 	delete(nm.neighbors, n.addr)
+	n.releaseAdjacencySID()
+	n.unsubscribeBFD()
+}
+
+// helloPDU is implemented by p2p and LAN hellos
+type helloPDU interface {
+	GetAreaAddressesTLV() *packet.AreaAddressesTLV
+	GetProtocolsSupportedTLV() *packet.ProtocolsSupportedTLV
+	GetIPInterfaceAddressesesTLV() *packet.IPInterfaceAddressesTLV
+	GetIPv6InterfaceAddressesTLV() *packet.IPv6InterfaceAddressesTLV
+	GetMultiTopologyTLV() *packet.MultiTopologyTLV
 }
 
 // validateP2PHello validates p2p hello messages
 func (nm *neighborManager) validateP2PHello(hello *packet.P2PHello) error {
+	p2pAdjTLV := hello.GetP2PAdjTLV()
+	if p2pAdjTLV == nil {
+		return fmt.Errorf("P2P Adjacency TLV missing")
+	}
+
+	return nm.validateHello(hello)
+}
+
+// validateHello validates the TLVs common to p2p and LAN hellos
+func (nm *neighborManager) validateHello(hello helloPDU) error {
 	areaAddrsTLV := hello.GetAreaAddressesTLV()
 	if areaAddrsTLV == nil {
 		return fmt.Errorf("Area Addresses TLV missing")
@@ -156,11 +188,6 @@ func (nm *neighborManager) validateP2PHello(hello *packet.P2PHello) error {
 		return fmt.Errorf("No area(s) given in Area Addresses TLV")
 	}
 
-	p2pAdjTLV := hello.GetP2PAdjTLV()
-	if p2pAdjTLV == nil {
-		return fmt.Errorf("P2P Adjacency TLV missing")
-	}
-
 	protoSupportTLV := hello.GetProtocolsSupportedTLV()
 	if protoSupportTLV == nil {
 		return fmt.Errorf("Protocol Supported TLV missing")
@@ -179,6 +206,15 @@ func (nm *neighborManager) validateP2PHello(hello *packet.P2PHello) error {
 		return fmt.Errorf("IPv4 addressing mismatch")
 	}
 
+	err := nm.netIfa.validateIPv6Addresses(hello.GetIPv6InterfaceAddressesTLV())
+	if err != nil {
+		return err
+	}
+
+	if !nm.netIfa.srv.validateTopologies(hello.GetMultiTopologyTLV()) {
+		return fmt.Errorf("No common topology")
+	}
+
 	return nil
 }
 
@@ -223,6 +259,48 @@ func validateProtocolsSupported(protocols []uint8) bool {
 	return true
 }
 
+// validateIPv6Addresses checks the IPv6 Interface Addresses TLV of a hello. It must only contain link local
+// addresses (RFC5308) and must be present if we have link local addresses on the interface.
+func (nifa *netIfa) validateIPv6Addresses(t *packet.IPv6InterfaceAddressesTLV) error {
+	if t == nil {
+		if len(nifa.ipv6LinkLocalAddrs()) > 0 {
+			return fmt.Errorf("IPv6 Interface Addresses TLV missing")
+		}
+
+		return nil
+	}
+
+	if len(t.IPv6Addresses) == 0 {
+		return fmt.Errorf("No address given in IPv6 Interface Addresses TLV")
+	}
+
+	for _, a := range t.IPv6Addresses {
+		if !a.IsLinkLocalUnicast() {
+			return fmt.Errorf("Non link local address %s in IPv6 Interface Addresses TLV", a.String())
+		}
+	}
+
+	return nil
+}
+
+// validateTopologies checks if the neighbor participates in at least one of our topologies.
+// A neighbor not sending a Multi-Topology TLV participates in the standard topology only.
+func (s *Server) validateTopologies(t *packet.MultiTopologyTLV) bool {
+	if t == nil {
+		t = packet.NewMultiTopologyTLV([]packet.MultiTopology{
+			{MTID: packet.MTIDStandard},
+		})
+	}
+
+	for _, local := range s.topologies() {
+		if t.HasTopology(local.MTID) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (nifa *netIfa) validateIPv4Addresses(addrs []uint32) bool {
 	localAddrs := nifa.devStatus.GetAddrs()
 
@@ -182,3 +182,129 @@ func TestValidateIPv4Addresses(t *testing.T) {
 		assert.Equal(t, test.expected, res, test.name)
 	}
 }
+
+func TestValidateIPv6Addresses(t *testing.T) {
+	linkLocal := bnet.IPv6FromBlocks(0xfe80, 0, 0, 0, 0, 0, 0, 1)
+	global := bnet.IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 1)
+
+	tests := []struct {
+		name     string
+		nifa     *netIfa
+		tlv      *packet.IPv6InterfaceAddressesTLV
+		wantFail bool
+	}{
+		{
+			name: "No TLV and no local link local address",
+			nifa: &netIfa{
+				devStatus: &mockDevice{
+					addrs: []*bnet.Prefix{
+						bnet.NewPfx(bnet.IPv4(110), 31).Ptr(),
+					},
+				},
+			},
+		},
+		{
+			name: "No TLV but local link local address",
+			nifa: &netIfa{
+				devStatus: &mockDevice{
+					addrs: []*bnet.Prefix{
+						bnet.NewPfx(linkLocal, 64).Ptr(),
+					},
+				},
+			},
+			wantFail: true,
+		},
+		{
+			name: "Link local address",
+			nifa: &netIfa{
+				devStatus: &mockDevice{
+					addrs: []*bnet.Prefix{
+						bnet.NewPfx(linkLocal, 64).Ptr(),
+					},
+				},
+			},
+			tlv: packet.NewIPv6InterfaceAddressesTLV([]bnet.IP{
+				bnet.IPv6FromBlocks(0xfe80, 0, 0, 0, 0, 0, 0, 2),
+			}),
+		},
+		{
+			name: "Global address",
+			nifa: &netIfa{
+				devStatus: &mockDevice{},
+			},
+			tlv: packet.NewIPv6InterfaceAddressesTLV([]bnet.IP{
+				linkLocal,
+				global,
+			}),
+			wantFail: true,
+		},
+		{
+			name: "Empty TLV",
+			nifa: &netIfa{
+				devStatus: &mockDevice{},
+			},
+			tlv:      packet.NewIPv6InterfaceAddressesTLV(nil),
+			wantFail: true,
+		},
+	}
+
+	for _, test := range tests {
+		err := test.nifa.validateIPv6Addresses(test.tlv)
+		if test.wantFail {
+			assert.Error(t, err, test.name)
+			continue
+		}
+
+		assert.NoError(t, err, test.name)
+	}
+}
+
+func TestValidateTopologies(t *testing.T) {
+	tests := []struct {
+		name          string
+		multiTopology bool
+		tlv           *packet.MultiTopologyTLV
+		expected      bool
+	}{
+		{
+			name:     "Single topology without TLV",
+			expected: true,
+		},
+		{
+			name:          "Multi topology without TLV",
+			multiTopology: true,
+			expected:      true,
+		},
+		{
+			name:          "Multi topology with common topologies",
+			multiTopology: true,
+			tlv: packet.NewMultiTopologyTLV([]packet.MultiTopology{
+				{MTID: packet.MTIDStandard},
+				{MTID: packet.MTIDIPv6Unicast},
+			}),
+			expected: true,
+		},
+		{
+			name: "Single topology with IPv6 unicast topology only",
+			tlv: packet.NewMultiTopologyTLV([]packet.MultiTopology{
+				{MTID: packet.MTIDIPv6Unicast},
+			}),
+			expected: false,
+		},
+		{
+			name:          "Multi topology with IPv6 unicast topology only",
+			multiTopology: true,
+			tlv: packet.NewMultiTopologyTLV([]packet.MultiTopology{
+				{MTID: packet.MTIDIPv6Unicast},
+			}),
+			expected: true,
+		},
+	}
+
+	for _, test := range tests {
+		s := &Server{}
+		s.multiTopology.Store(test.multiTopology)
+
+		assert.Equal(t, test.expected, s.validateTopologies(test.tlv), test.name)
+	}
+}
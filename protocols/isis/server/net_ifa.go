@@ -23,6 +23,12 @@ type InterfaceConfig struct {
 	PointToPoint bool
 	Level1       *InterfaceLevelConfig
 	Level2       *InterfaceLevelConfig
+
+	// PrefixSIDIndex is advertised as node SID with the first IPv4 host address of the interface if segment routing is enabled
+	PrefixSIDIndex *uint32
+
+	// BFD enables BFD for the adjacencies of the interface if set
+	BFD *BFDConfig
 }
 
 // holdingTimer() picks the maximum holding timer from Level1 and Level2 config
@@ -73,6 +79,9 @@ type InterfaceLevelConfig struct {
 	Metric        uint32
 	Passive       bool
 	Priority      uint8
+
+	// HelloAuthentication overrides the hello authentication of the level for this interface if set
+	HelloAuthentication KeyChain
 }
 
 type netIfaInterface interface {
@@ -92,14 +101,18 @@ type netIfa struct {
 	initialized       bool
 	devStatus         device.DeviceInterface
 	ethernetInterface ethernet.EthernetInterfaceI
+	circuitID         uint8 // local circuit ID of LAN interfaces. Used as pseudonode ID if we are DIS.
+	authFailuresL1    authenticationFailureCounters
+	authFailuresL2    authenticationFailureCounters
 }
 
-func newNetIfa(srv *Server, cfg *InterfaceConfig) *netIfa {
+func newNetIfa(srv *Server, cfg *InterfaceConfig, circuitID uint8) *netIfa {
 	ret := &netIfa{
-		name: cfg.Name,
-		srv:  srv,
-		cfg:  cfg,
-		done: make(chan struct{}),
+		name:      cfg.Name,
+		srv:       srv,
+		cfg:       cfg,
+		done:      make(chan struct{}),
+		circuitID: circuitID,
 	}
 
 	if cfg.Level1 != nil {
@@ -182,13 +195,17 @@ func (nifa *netIfa) _start() error {
 		}
 		nifa.ethernetInterface = ethIfa
 
-		nifa.wg.Add(1)
-		go nifa.p2pHelloSender()
-
-		err = nifa.ethernetInterface.MCastJoin(allISNetworkEntitiesAddr)
+		err = nifa.joinMCastGroups()
 		if err != nil {
 			nifa._stop()
-			return fmt.Errorf("unable to join IS p2p hello multicast group: %w", err)
+			return err
+		}
+
+		nifa.wg.Add(1)
+		if nifa.isLAN() {
+			go nifa.lanHelloSender()
+		} else {
+			go nifa.p2pHelloSender()
 		}
 
 		nifa.wg.Add(1)
@@ -197,7 +214,34 @@ func (nifa *netIfa) _start() error {
 		nifa.initialized = true
 	}
 
-	nifa.srv.updateL2LSP()
+	nifa.srv.updateLSPs()
+
+	return nil
+}
+
+func (nifa *netIfa) joinMCastGroups() error {
+	if !nifa.isLAN() {
+		err := nifa.ethernetInterface.MCastJoin(allISNetworkEntitiesAddr)
+		if err != nil {
+			return fmt.Errorf("unable to join IS p2p hello multicast group: %w", err)
+		}
+
+		return nil
+	}
+
+	if nifa.cfg.Level1 != nil {
+		err := nifa.ethernetInterface.MCastJoin(allL1ISs)
+		if err != nil {
+			return fmt.Errorf("unable to join all L1 ISs multicast group: %w", err)
+		}
+	}
+
+	if nifa.cfg.Level2 != nil {
+		err := nifa.ethernetInterface.MCastJoin(allL2ISs)
+		if err != nil {
+			return fmt.Errorf("unable to join all L2 ISs multicast group: %w", err)
+		}
+	}
 
 	return nil
 }
@@ -220,7 +264,7 @@ func (nifa *netIfa) _stop() {
 
 	close(nifa.done)
 	nifa.ethernetInterface.Close()
-	nifa.srv.updateL2LSP()
+	nifa.srv.updateLSPs()
 	nifa.wg.Wait()
 }
 
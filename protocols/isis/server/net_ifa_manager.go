@@ -54,12 +54,38 @@ func (nima *netIfaManager) addInterface(cfg *InterfaceConfig) error {
 		return fmt.Errorf("ISIS is enabled on that interface already. Updating config is not supported yet")
 	}
 
-	ifa := newNetIfa(nima.srv, cfg)
+	circuitID := uint8(0)
+	if !cfg.PointToPoint {
+		id, err := nima.freeCircuitID()
+		if err != nil {
+			return err
+		}
+
+		circuitID = id
+	}
+
+	ifa := newNetIfa(nima.srv, cfg, circuitID)
 	nima.netIfas[cfg.Name] = ifa
 
 	return nil
 }
 
+// freeCircuitID gets the lowest circuit ID not used by any LAN interface. netIfasMu must be held.
+func (nima *netIfaManager) freeCircuitID() (uint8, error) {
+	used := make(map[uint8]struct{})
+	for _, ifa := range nima.netIfas {
+		used[ifa.circuitID] = struct{}{}
+	}
+
+	for id := 1; id <= 255; id++ {
+		if _, found := used[uint8(id)]; !found {
+			return uint8(id), nil
+		}
+	}
+
+	return 0, fmt.Errorf("no free circuit ID. At most 255 LAN interfaces are supported")
+}
+
 func (nima *netIfaManager) getInterface(name string) *netIfa {
 	nima.netIfasMu.Lock()
 	defer nima.netIfasMu.Unlock()
@@ -112,3 +138,18 @@ func (nima *netIfaManager) getAddressesIPv4() []*bnet.Prefix {
 
 	return res
 }
+
+// getAddressesIPv6 gets all global IPv6 addresses of all interfaces
+func (nima *netIfaManager) getAddressesIPv6() []bnet.IP {
+	nima.netIfasMu.Lock()
+	defer nima.netIfasMu.Unlock()
+
+	res := make([]bnet.IP, 0)
+	for _, ifa := range nima.netIfas {
+		for _, pfx := range ifa.ipv6Prefixes() {
+			res = append(res, pfx.Addr())
+		}
+	}
+
+	return res
+}
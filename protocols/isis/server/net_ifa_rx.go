@@ -42,6 +42,13 @@ func (nifa *netIfa) processPkt(src ethernet.MACAddr, rawPkt []byte) error {
 		return fmt.Errorf("Decode failed: %w", err)
 	}
 
+	err = nifa.verifyAuthentication(pkt.Header.PDUType, rawPkt[packet.LLCHeaderLen:])
+	if err != nil {
+		nifa.countAuthenticationFailure(pkt.Header.PDUType)
+		log.WithFields(nifa.fields()).WithError(err).Debug("Packet authentication failed")
+		return nil
+	}
+
 	err = nifa.validatePkt(src, pkt)
 	if err != nil {
 		log.WithFields(nifa.fields()).WithError(err).Debug("Packet validation failed")
@@ -51,6 +58,19 @@ func (nifa *netIfa) processPkt(src ethernet.MACAddr, rawPkt []byte) error {
 	switch pkt.Header.PDUType {
 	case packet.P2P_HELLO:
 		return nifa.processP2PHello(src, pkt.Body.(*packet.P2PHello))
+	case packet.L1_LAN_HELLO_TYPE:
+		return nifa.processLANHello(src, 1, pkt.Body.(*packet.LANHello))
+	case packet.L2_LAN_HELLO_TYPE:
+		return nifa.processLANHello(src, 2, pkt.Body.(*packet.LANHello))
+	case packet.L1_LS_PDU_TYPE:
+		nifa.srv.lsdbL1.processLSP(nifa, pkt.Body.(*packet.LSPDU))
+		return nil
+	case packet.L1_CSNP_TYPE:
+		nifa.srv.lsdbL1.processCSNP(nifa, pkt.Body.(*packet.CSNP))
+		return nil
+	case packet.L1_PSNP_TYPE:
+		nifa.srv.lsdbL1.processPSNP(nifa, pkt.Body.(*packet.PSNP))
+		return nil
 	case packet.L2_LS_PDU_TYPE:
 		nifa.srv.lsdbL2.processLSP(nifa, pkt.Body.(*packet.LSPDU))
 		return nil
@@ -90,6 +110,10 @@ func (nifa *netIfa) validatePkt(src ethernet.MACAddr, pkt *packet.ISISPacket) er
 }
 
 func (nifa *netIfa) processP2PHello(src ethernet.MACAddr, hello *packet.P2PHello) error {
+	if nifa.isLAN() {
+		return fmt.Errorf("received p2p hello on LAN interface")
+	}
+
 	if hello.CircuitType == types.CircuitTypeL1 || hello.CircuitType == types.CircuitTypeL1L2 {
 		if nifa.neighborManagerL1 != nil {
 			err := nifa.neighborManagerL1.processP2PHello(src, hello)
@@ -2,48 +2,85 @@ package server
 
 import (
 	"bytes"
+	"fmt"
 
+	"github.com/bio-routing/bio-rd/net/ethernet"
 	"github.com/bio-routing/bio-rd/protocols/isis/packet"
 )
 
-func (nifa *netIfa) sendLSPDU(lsp *packet.LSPDU, level int) error {
+func (nifa *netIfa) sendLSPDU(lsp *packet.LSPDU, level uint8) error {
+	// LSPs are authenticated by their originator
+	return nifa.sendPDU(lsp, lspPDUType(level), level, nil)
+}
+
+func (nifa *netIfa) sendPSNP(psnp *packet.PSNP, level uint8) error {
+	key := nifa.srv.snpKeyChain(level, false).sendKey(clock.Now())
+	if key != nil {
+		psnp.AddTLV(key.tlv())
+	}
+
 	if level == 1 {
-		panic("L1 is not supported yet")
-		return nil
+		return nifa.sendPDU(psnp, packet.L1_PSNP_TYPE, level, key)
 	}
 
-	return nifa.sendPDU(lsp, packet.L2_LS_PDU_TYPE)
+	return nifa.sendPDU(psnp, packet.L2_PSNP_TYPE, level, key)
 }
 
-func (nifa *netIfa) sendPSNP(psnp *packet.PSNP, level int) error {
+func (nifa *netIfa) sendCSNP(csnp *packet.CSNP, level uint8) error {
+	key := nifa.srv.snpKeyChain(level, true).sendKey(clock.Now())
+	if key != nil {
+		csnp.AddTLV(key.tlv())
+	}
+
 	if level == 1 {
-		panic("L1 is not supported yet")
-		return nil
+		return nifa.sendPDU(csnp, packet.L1_CSNP_TYPE, level, key)
 	}
 
-	return nifa.sendPDU(psnp, packet.L2_PSNP_TYPE)
+	return nifa.sendPDU(csnp, packet.L2_CSNP_TYPE, level, key)
 }
 
-func (nifa *netIfa) sendCSNP(csnp *packet.CSNP, level int) error {
-	if level == 1 {
-		panic("L1 is not supported yet")
-		return nil
+// sendPDU sends a PDU. If key is set the PDU must carry the authentication TLV of key which is signed before sending.
+func (nifa *netIfa) sendPDU(pkt packet.Serializable, pduType uint8, level uint8, key *AuthenticationKey) error {
+	pdu := serializePDU(pkt, pduType)
+	if key != nil {
+		err := key.sign(pdu)
+		if err != nil {
+			return fmt.Errorf("unable to sign PDU: %w", err)
+		}
 	}
 
-	return nifa.sendPDU(csnp, packet.L2_CSNP_TYPE)
+	return nifa.ethernetInterface.SendPacket(nifa.pduDestination(level), pdu)
 }
 
-func (nifa *netIfa) sendPDU(pkt packet.Serializable, pduType uint8) error {
+// serializePDU serializes a PDU including the common header
+func serializePDU(pkt packet.Serializable, pduType uint8) []byte {
 	buf := bytes.NewBuffer(nil)
+	hdr := getHeader(pduType)
+	hdr.Serialize(buf)
 	pkt.Serialize(buf)
 
-	hdr := getHeader(pduType)
-	hdrBuf := bytes.NewBuffer(nil)
-	hdr.Serialize(hdrBuf)
-	hdrBuf.Write(buf.Bytes())
+	return buf.Bytes()
+}
+
+func lspPDUType(level uint8) uint8 {
+	if level == 1 {
+		return packet.L1_LS_PDU_TYPE
+	}
+
+	return packet.L2_LS_PDU_TYPE
+}
+
+// pduDestination gets the destination MAC address of PDUs of a level
+func (nifa *netIfa) pduDestination(level uint8) ethernet.MACAddr {
+	if !nifa.isLAN() {
+		return allISNetworkEntitiesAddr
+	}
+
+	if level == 1 {
+		return allL1ISs
+	}
 
-	err := nifa.ethernetInterface.SendPacket(allISNetworkEntitiesAddr, hdrBuf.Bytes())
-	return err
+	return allL2ISs
 }
 
 func getHeader(pduType uint8) packet.ISISHeader {
@@ -72,6 +109,10 @@ func lengthIndicatorByPDUType(pduType uint8) uint8 {
 		return packet.PSNPMinLen
 	case packet.P2P_HELLO:
 		return packet.P2PHelloMinLen
+	case packet.L1_LAN_HELLO_TYPE:
+		return packet.LANHelloMinLen
+	case packet.L2_LAN_HELLO_TYPE:
+		return packet.LANHelloMinLen
 	case packet.L2_LS_PDU_TYPE:
 		return packet.LSPDUMinLen
 	case packet.L1_LS_PDU_TYPE:
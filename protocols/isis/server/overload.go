@@ -0,0 +1,69 @@
+package server
+
+import (
+	"time"
+
+	"github.com/bio-routing/bio-rd/util/log"
+)
+
+// startupOverloadCheckInterval is the interval the end of the startup overload period is checked in
+const startupOverloadCheckInterval = time.Second
+
+// startupOverload sets the overload bit after startup until a timeout expires or an external condition,
+// e.g. BGP convergence, is met
+type startupOverload struct {
+	timeout   time.Duration
+	converged func() bool
+	active    bool
+}
+
+// SetOverload sets or clears the overload bit in our LSPs. Overloaded ISs are not used for transit traffic.
+func (s *Server) SetOverload(overload bool) {
+	s.overloadMu.Lock()
+	changed := s.overload != overload
+	s.overload = overload
+	s.overloadMu.Unlock()
+
+	if changed {
+		s.updateLSPs()
+	}
+}
+
+// SetOverloadOnStartup sets the overload bit after startup until timeout expires or converged returns true.
+// converged may be nil. Must be called before Start.
+func (s *Server) SetOverloadOnStartup(timeout time.Duration, converged func() bool) {
+	s.startupOverload = &startupOverload{
+		timeout:   timeout,
+		converged: converged,
+		active:    true,
+	}
+}
+
+// overloaded checks if the overload bit is to be set in our LSPs
+func (s *Server) overloaded() bool {
+	s.overloadMu.RLock()
+	defer s.overloadMu.RUnlock()
+
+	return s.overload || (s.startupOverload != nil && s.startupOverload.active)
+}
+
+func (o *startupOverload) run(s *Server) {
+	deadline := clock.Now().Add(o.timeout)
+	t := clock.Ticker(startupOverloadCheckInterval)
+	defer t.Stop()
+
+	for range t.C {
+		if clock.Now().Before(deadline) && (o.converged == nil || !o.converged()) {
+			continue
+		}
+
+		log.Info("ISIS: Startup overload period over. Clearing overload bit")
+
+		s.overloadMu.Lock()
+		o.active = false
+		s.overloadMu.Unlock()
+
+		s.updateLSPs()
+		return
+	}
+}
@@ -0,0 +1,83 @@
+package server
+
+import (
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/bio-routing/bio-rd/routingtable/locRIB"
+)
+
+// ribInstaller installs the routes computed by SPF into a RIB
+type ribInstaller struct {
+	rib       *locRIB.LocRIB
+	level     uint8
+	installed map[bnet.Prefix][]*route.Path
+}
+
+func newRIBInstaller(rib *locRIB.LocRIB, level uint8) *ribInstaller {
+	return &ribInstaller{
+		rib:       rib,
+		level:     level,
+		installed: make(map[bnet.Prefix][]*route.Path),
+	}
+}
+
+// install updates the RIB to contain exactly the given routes. Paths are added before
+// outdated paths are removed so changing routes are never withdrawn temporarily.
+func (r *ribInstaller) install(routes map[bnet.Prefix]*spfRoute) {
+	if r == nil {
+		return
+	}
+
+	paths := make(map[bnet.Prefix][]*route.Path)
+	for pfx, rt := range routes {
+		for _, nh := range rt.nextHops {
+			// Next hops without an address can not be installed
+			if nh.Address == nil {
+				continue
+			}
+
+			p := &route.Path{
+				Type: route.ISISPathType,
+				ISISPath: &route.ISISPath{
+					NextHop: nh.Address,
+					Metric:  rt.metric,
+					Level:   r.level,
+				},
+			}
+
+			if !containsPath(paths[pfx], p) {
+				paths[pfx] = append(paths[pfx], p)
+			}
+		}
+	}
+
+	for pfx, ps := range paths {
+		pfx := pfx
+		for _, p := range ps {
+			if !containsPath(r.installed[pfx], p) {
+				r.rib.AddPath(&pfx, p)
+			}
+		}
+	}
+
+	for pfx, ps := range r.installed {
+		pfx := pfx
+		for _, p := range ps {
+			if !containsPath(paths[pfx], p) {
+				r.rib.RemovePath(&pfx, p)
+			}
+		}
+	}
+
+	r.installed = paths
+}
+
+func containsPath(haystack []*route.Path, needle *route.Path) bool {
+	for _, p := range haystack {
+		if p.Equal(needle) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,270 @@
+package server
+
+import (
+	"fmt"
+	"sync"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/isis/packet"
+	"github.com/bio-routing/bio-rd/util/log"
+)
+
+// maxSubTLVLength is the maximum length of the sub TLVs of an Extended IS Reachability neighbor
+const maxSubTLVLength = packet.MaxTLVLength - packet.ExtendedISReachabilityNeighborMinLen
+
+// LabelBlock is a block of consecutive MPLS labels
+type LabelBlock struct {
+	Base uint32
+	Size uint32
+}
+
+func (b LabelBlock) sidLabelRange() packet.SIDLabelRange {
+	return packet.SIDLabelRange{
+		Range: b.Size,
+		First: b.Base,
+		Label: true,
+	}
+}
+
+// SegmentRoutingConfig is the segment routing (RFC8667) config
+type SegmentRoutingConfig struct {
+	RouterID uint32     // Router ID advertised in the Router Capability TLV
+	SRGB     LabelBlock // SR global block. Prefix SID indexes are relative to its base.
+	SRLB     LabelBlock // SR local block adjacency SIDs are allocated from
+}
+
+// PrefixSID is the prefix segment of a route
+type PrefixSID struct {
+	Flags uint8 // Flags of the Prefix-SID sub TLV
+	SID   uint32
+}
+
+// SetSegmentRouting configures segment routing. Segment routing is disabled if cfg is nil.
+func (s *Server) SetSegmentRouting(cfg *SegmentRoutingConfig) {
+	s.segmentRoutingMu.Lock()
+	prev := s.segmentRouting
+	if (prev == nil && cfg == nil) || (prev != nil && cfg != nil && *prev == *cfg) {
+		s.segmentRoutingMu.Unlock()
+		return
+	}
+
+	if cfg == nil {
+		s.segmentRouting = nil
+		s.adjSIDAllocator = nil
+	} else {
+		c := *cfg
+		s.segmentRouting = &c
+
+		// Adjacency SIDs are re-allocated from the new local block when our LSPs are regenerated
+		if prev == nil || prev.SRLB != cfg.SRLB {
+			s.adjSIDAllocator = newLabelAllocator(cfg.SRLB)
+		}
+	}
+	s.segmentRoutingMu.Unlock()
+
+	if s.isRunning() {
+		s.updateLSPs()
+	}
+}
+
+func (s *Server) getSegmentRouting() (*SegmentRoutingConfig, *labelAllocator) {
+	s.segmentRoutingMu.RLock()
+	defer s.segmentRoutingMu.RUnlock()
+
+	return s.segmentRouting, s.adjSIDAllocator
+}
+
+// routerCapabilityTLV gets the Router Capability TLV advertising our segment routing capabilities.
+// Returns nil if segment routing is disabled.
+func (s *Server) routerCapabilityTLV() *packet.RouterCapabilityTLV {
+	sr, _ := s.getSegmentRouting()
+	if sr == nil {
+		return nil
+	}
+
+	rc := packet.NewRouterCapabilityTLV(sr.RouterID, 0)
+	rc.AddSubTLV(packet.NewSRCapabilitiesSubTLV(packet.SRCapabilitiesFlagMPLSIPv4, []packet.SIDLabelRange{sr.SRGB.sidLabelRange()}))
+	rc.AddSubTLV(packet.NewSRAlgorithmSubTLV([]uint8{packet.SRAlgorithmSPF}))
+	rc.AddSubTLV(packet.NewSRLocalBlockSubTLV([]packet.SIDLabelRange{sr.SRLB.sidLabelRange()}))
+
+	return rc
+}
+
+// prefixSIDSubTLV gets the Prefix-SID sub TLV of a prefix of an interface. The SID index configured for an
+// interface is advertised as node SID with its first host address.
+func (s *Server) prefixSIDSubTLV(nifa *netIfa, pfx *bnet.Prefix) *packet.PrefixSIDSubTLV {
+	if sr, _ := s.getSegmentRouting(); sr == nil || nifa.cfg.PrefixSIDIndex == nil {
+		return nil
+	}
+
+	addr := nifa.prefixSIDAddress()
+	if addr == nil || !addr.Equal(pfx) {
+		return nil
+	}
+
+	return packet.NewPrefixSIDSubTLV(packet.PrefixSIDFlagNode, packet.SRAlgorithmSPF, *nifa.cfg.PrefixSIDIndex)
+}
+
+// prefixSIDAddress gets the first IPv4 host address of the interface
+func (nifa *netIfa) prefixSIDAddress() *bnet.Prefix {
+	for _, pfx := range nifa.ipv4Addrs() {
+		if pfx.Len() == 32 {
+			return pfx
+		}
+	}
+
+	return nil
+}
+
+// subTLV gets the Prefix-SID sub TLV advertising a prefix SID learned from another level with the R flag set
+func (p *PrefixSID) subTLV() *packet.PrefixSIDSubTLV {
+	return packet.NewPrefixSIDSubTLV(p.Flags|packet.PrefixSIDFlagReadvertisement, packet.SRAlgorithmSPF, p.SID)
+}
+
+// prefixSIDFromSubTLVs gets the prefix SID of the SPF algorithm from the sub TLVs of a reachability
+func prefixSIDFromSubTLVs(subTLVs []packet.TLV) *PrefixSID {
+	for _, stlv := range subTLVs {
+		sid, ok := stlv.(*packet.PrefixSIDSubTLV)
+		if !ok || sid.Algorithm != packet.SRAlgorithmSPF {
+			continue
+		}
+
+		return &PrefixSID{
+			Flags: sid.Flags,
+			SID:   sid.SID,
+		}
+	}
+
+	return nil
+}
+
+// srgbFromRouterCapability gets the SR global block advertised in a Router Capability TLV
+func srgbFromRouterCapability(rc *packet.RouterCapabilityTLV) []LabelBlock {
+	ret := make([]LabelBlock, 0)
+	for _, stlv := range rc.SubTLVs {
+		if stlv.Type() != packet.SRCapabilitiesSubTLVType {
+			continue
+		}
+
+		for _, r := range stlv.(*packet.SRCapabilitiesSubTLV).Ranges {
+			ret = append(ret, LabelBlock{
+				Base: r.First,
+				Size: r.Range,
+			})
+		}
+	}
+
+	return ret
+}
+
+// adjacencySIDSubTLV gets the sub TLV advertising the adjacency SID of the neighbor. Adjacency SIDs are
+// only advertised in the standard topology. Returns nil if segment routing is disabled.
+func (n *neighbor) adjacencySIDSubTLV(mtID uint16) *packet.AdjacencySIDSubTLV {
+	if mtID != packet.MTIDStandard {
+		return nil
+	}
+
+	label, ok := n.adjacencySID()
+	if !ok {
+		return nil
+	}
+
+	flags := uint8(packet.AdjacencySIDFlagValue | packet.AdjacencySIDFlagLocal)
+	if n.nm.netIfa.isLAN() {
+		return packet.NewLANAdjacencySIDSubTLV(flags, 0, n.sysID, label)
+	}
+
+	return packet.NewAdjacencySIDSubTLV(flags, 0, label)
+}
+
+// adjacencySID gets the label of the adjacency. It is allocated from the SR local block on first use and
+// kept until the neighbor is dropped or the local block changes.
+func (n *neighbor) adjacencySID() (uint32, bool) {
+	_, a := n.nm.server.getSegmentRouting()
+
+	n.adjSIDMu.Lock()
+	defer n.adjSIDMu.Unlock()
+
+	if n.adjSID != 0 && n.adjSIDAllocator == a {
+		return n.adjSID, true
+	}
+
+	n.releaseAdjacencySIDLocked()
+	if a == nil {
+		return 0, false
+	}
+
+	label, err := a.allocate()
+	if err != nil {
+		log.WithFields(n.fields()).WithError(err).Error("Unable to allocate adjacency SID")
+		return 0, false
+	}
+
+	n.adjSID = label
+	n.adjSIDAllocator = a
+	return label, true
+}
+
+func (n *neighbor) releaseAdjacencySID() {
+	n.adjSIDMu.Lock()
+	defer n.adjSIDMu.Unlock()
+
+	n.releaseAdjacencySIDLocked()
+}
+
+// releaseAdjacencySIDLocked returns the adjacency SID to the allocator it was allocated from. adjSIDMu must be held.
+func (n *neighbor) releaseAdjacencySIDLocked() {
+	if n.adjSID == 0 {
+		return
+	}
+
+	n.adjSIDAllocator.release(n.adjSID)
+	n.adjSID = 0
+	n.adjSIDAllocator = nil
+}
+
+// labelAllocator allocates labels of a label block. Labels are handed out round robin so released labels
+// are not reused immediately.
+type labelAllocator struct {
+	block LabelBlock
+	next  uint32
+	used  map[uint32]struct{}
+	mu    sync.Mutex
+}
+
+func newLabelAllocator(block LabelBlock) *labelAllocator {
+	return &labelAllocator{
+		block: block,
+		next:  block.Base,
+		used:  make(map[uint32]struct{}),
+	}
+}
+
+func (a *labelAllocator) allocate() (uint32, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for i := uint32(0); i < a.block.Size; i++ {
+		label := a.next
+		a.next++
+		if a.next >= a.block.Base+a.block.Size {
+			a.next = a.block.Base
+		}
+
+		if _, found := a.used[label]; found {
+			continue
+		}
+
+		a.used[label] = struct{}{}
+		return label, nil
+	}
+
+	return 0, fmt.Errorf("label block %d-%d exhausted", a.block.Base, a.block.Base+a.block.Size-1)
+}
+
+func (a *labelAllocator) release(label uint32) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	delete(a.used, label)
+}
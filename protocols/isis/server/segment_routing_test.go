@@ -0,0 +1,123 @@
+package server
+
+import (
+	"testing"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/isis/packet"
+	"github.com/bio-routing/bio-rd/protocols/isis/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLabelAllocator(t *testing.T) {
+	a := newLabelAllocator(LabelBlock{Base: 15000, Size: 2})
+
+	l, err := a.allocate()
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(15000), l)
+
+	l, err = a.allocate()
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(15001), l)
+
+	_, err = a.allocate()
+	assert.Error(t, err)
+
+	a.release(15000)
+	l, err = a.allocate()
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(15000), l)
+}
+
+func TestSetSegmentRoutingAdjacencySID(t *testing.T) {
+	srv := &Server{}
+	n := &neighbor{
+		nm: &neighborManager{
+			server: srv,
+		},
+	}
+
+	_, ok := n.adjacencySID()
+	assert.False(t, ok)
+
+	cfg := SegmentRoutingConfig{
+		SRGB: LabelBlock{Base: 16000, Size: 8000},
+		SRLB: LabelBlock{Base: 15000, Size: 8},
+	}
+	srv.SetSegmentRouting(&cfg)
+	label, ok := n.adjacencySID()
+	assert.True(t, ok)
+	assert.Equal(t, uint32(15000), label)
+
+	// The SID is kept as long as the local block is unchanged
+	cfgCopy := cfg
+	srv.SetSegmentRouting(&cfgCopy)
+	cfg.SRGB = LabelBlock{Base: 17000, Size: 8000}
+	srv.SetSegmentRouting(&cfg)
+	label, ok = n.adjacencySID()
+	assert.True(t, ok)
+	assert.Equal(t, uint32(15000), label)
+
+	// The SID is re-allocated from a new local block
+	cfg.SRLB = LabelBlock{Base: 14000, Size: 8}
+	srv.SetSegmentRouting(&cfg)
+	label, ok = n.adjacencySID()
+	assert.True(t, ok)
+	assert.Equal(t, uint32(14000), label)
+
+	srv.SetSegmentRouting(nil)
+	_, ok = n.adjacencySID()
+	assert.False(t, ok)
+	assert.Equal(t, uint32(0), n.adjSID)
+}
+
+func TestComputeRoutesPrefixSID(t *testing.T) {
+	pfx := bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 2), 32)
+	nhB := bnet.IPv4FromOctets(192, 0, 2, 2)
+
+	lspB := testLSP(sysB, map[types.SystemID]uint32{sysA: 10}, map[bnet.Prefix]uint32{pfx: 0})
+	lspB.TLVs[1].(*packet.ExtendedIPReachabilityTLV).ExtendedIPReachabilities[0].AddSubTLV(
+		packet.NewPrefixSIDSubTLV(packet.PrefixSIDFlagNode, packet.SRAlgorithmSPF, 2))
+
+	rc := packet.NewRouterCapabilityTLV(0x0a000002, 0)
+	rc.AddSubTLV(packet.NewSRCapabilitiesSubTLV(packet.SRCapabilitiesFlagMPLSIPv4, []packet.SIDLabelRange{
+		{Range: 8000, First: 16000, Label: true},
+	}))
+	lspB.TLVs = append(lspB.TLVs, rc)
+
+	nodes := spfNodesFromLSPs([]*packet.LSPDU{lspB})
+	assert.Equal(t, []LabelBlock{{Base: 16000, Size: 8000}}, nodes[types.NewSourceID(sysB, 0)].srgb)
+
+	spt := computeSPT(sysA, nodes, []spfAdjacency{
+		testAdjacency(sysB, "eth0", nhB, 10),
+	})
+
+	assert.Equal(t, map[bnet.Prefix]*spfRoute{
+		pfx: {
+			metric: 10,
+			nextHops: []NextHop{
+				{InterfaceName: "eth0", SystemID: sysB, Address: nhB.Ptr()},
+			},
+			prefixSID: &PrefixSID{
+				Flags: packet.PrefixSIDFlagNode,
+				SID:   2,
+			},
+		},
+	}, computeRoutes(sysA, nodes, spt))
+}
+
+func TestRouteExtendedIPReachabilityPrefixSID(t *testing.T) {
+	r := &Route{
+		Prefix: bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 2), 32),
+		Metric: 10,
+		PrefixSID: &PrefixSID{
+			Flags: packet.PrefixSIDFlagNode,
+			SID:   2,
+		},
+	}
+
+	e := r.extendedIPReachability()
+	assert.Equal(t, []packet.TLV{
+		packet.NewPrefixSIDSubTLV(packet.PrefixSIDFlagReadvertisement|packet.PrefixSIDFlagNode, packet.SRAlgorithmSPF, 2),
+	}, e.SubTLVs)
+}
@@ -4,13 +4,18 @@ import (
 	"fmt"
 	"os"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	bbclock "github.com/benbjohnson/clock"
 	bnet "github.com/bio-routing/bio-rd/net"
 	"github.com/bio-routing/bio-rd/net/ethernet"
+	bfdserver "github.com/bio-routing/bio-rd/protocols/bfd/server"
 	"github.com/bio-routing/bio-rd/protocols/device"
+	"github.com/bio-routing/bio-rd/protocols/isis/packet"
 	"github.com/bio-routing/bio-rd/protocols/isis/types"
+	"github.com/bio-routing/bio-rd/routingtable/filter"
+	"github.com/bio-routing/bio-rd/routingtable/vrf"
 )
 
 var (
@@ -33,7 +38,16 @@ type ISISServer interface {
 	GetInterfaceNames() []string
 	Start()
 	GetAdjacencies() []*Adjacency
-	GetLSDB() []*LSDBEntry
+	GetLSDB(level uint8) []*LSDBEntry
+	GetSPFTree(level uint8) []*SPFTreeEntry
+	GetRoutes(level uint8) []*Route
+	GetAuthenticationFailures() []*AuthenticationFailures
+	SetAuthentication(level uint8, cfg *AuthenticationConfig)
+	SetOverload(overload bool)
+	SetSPFIntervals(initialWait time.Duration, secondaryWait time.Duration, maxWait time.Duration)
+	SetMultiTopology(enabled bool)
+	SetLeakFilterChain(c filter.Chain)
+	SetSegmentRouting(cfg *SegmentRoutingConfig)
 }
 
 // Server represents an ISIS server
@@ -42,10 +56,6 @@ type Server struct {
 	runningMu                sync.Mutex
 	nets                     []*types.NET
 	lspLifetime              uint16
-	sequenceNumberL1         uint32
-	sequenceNumberL1Mu       sync.Mutex
-	sequenceNumberL2         uint32
-	sequenceNumberL2Mu       sync.Mutex
 	netIfaManager            *netIfaManager
 	lsdbL1                   *lsdb
 	lsdbL2                   *lsdb
@@ -53,6 +63,27 @@ type Server struct {
 	ds                       device.Updater
 	ethernetInterfaceFactory ethernet.EthernetInterfaceFactoryI
 	hostname                 func() (string, error)
+	spfScheduler             *spfScheduler
+	ribInstallerL1           *ribInstaller
+	ribInstallerL2           *ribInstaller
+	ribInstallerIPv6L1       *ribInstaller
+	ribInstallerIPv6L2       *ribInstaller
+	spfMu                    sync.RWMutex
+	spfL1                    *spfResult
+	spfL2                    *spfResult
+	multiTopology            atomic.Bool
+	leakFilterChain          filter.Chain
+	leakFilterChainMu        sync.RWMutex
+	authenticationL1         *AuthenticationConfig
+	authenticationL2         *AuthenticationConfig
+	authenticationMu         sync.RWMutex
+	segmentRouting           *SegmentRoutingConfig
+	adjSIDAllocator          *labelAllocator
+	segmentRoutingMu         sync.RWMutex
+	overload                 bool
+	startupOverload          *startupOverload
+	overloadMu               sync.RWMutex
+	bfd                      bfdserver.SessionManager
 }
 
 // Start starts the ISIS server
@@ -64,13 +95,28 @@ func (s *Server) Start() {
 		s.running = true
 	}
 
-	s.lsdbL2.updateL2LSP()
+	for _, l := range []*lsdb{s.lsdbL1, s.lsdbL2} {
+		l.updateLSP()
 
-	decrementTicker := clock.Ticker(time.Second)
-	minLSPTransTicker := clock.Ticker(minimumLSPTransmissionInterval)
-	psnpTransTicker := clock.Ticker(time.Second * 5)
-	csnpTransTicker := clock.Ticker(csnpTransmissionInterval)
-	s.lsdbL2.start(decrementTicker, minLSPTransTicker, psnpTransTicker, csnpTransTicker)
+		decrementTicker := clock.Ticker(time.Second)
+		minLSPTransTicker := clock.Ticker(minimumLSPTransmissionInterval)
+		psnpTransTicker := clock.Ticker(time.Second * 5)
+		csnpTransTicker := clock.Ticker(csnpTransmissionInterval)
+		l.start(decrementTicker, minLSPTransTicker, psnpTransTicker, csnpTransTicker)
+	}
+
+	s.spfScheduler.start()
+
+	if s.startupOverload != nil {
+		go s.startupOverload.run(s)
+	}
+}
+
+func (s *Server) isRunning() bool {
+	s.runningMu.Lock()
+	defer s.runningMu.Unlock()
+
+	return s.running
 }
 
 type Adjacency struct {
@@ -90,28 +136,45 @@ func (s *Server) GetAdjacencies() []*Adjacency {
 	ret := make([]*Adjacency, 0)
 
 	for _, ifa := range s.netIfaManager.getAllInterfaces() {
-		for _, n := range ifa.neighborManagerL2.getNeighbors() {
-			ret = append(ret, n.getAdjacency())
+		for _, level := range []uint8{1, 2} {
+			nm := ifa.neighborManager(level)
+			if nm == nil {
+				continue
+			}
+
+			for _, n := range nm.getNeighbors() {
+				ret = append(ret, n.getAdjacency())
+			}
 		}
 	}
 
 	return ret
 }
 
-func (s *Server) GetLSDB() []*LSDBEntry {
-	s.lsdbL2.lspsMu.RLock()
-	defer s.lsdbL2.lspsMu.RUnlock()
+// GetLSDB gets the link state database of a level
+func (s *Server) GetLSDB(level uint8) []*LSDBEntry {
+	l := s.lsdb(level)
+	l.lspsMu.RLock()
+	defer l.lspsMu.RUnlock()
 
 	ret := make([]*LSDBEntry, 0)
-	for _, lspEntry := range s.lsdbL2.lsps {
+	for _, lspEntry := range l.lsps {
 		ret = append(ret, lspEntry.Export())
 	}
 
 	return ret
 }
 
-// New creates a new ISIS server
-func New(nets []*types.NET, ds device.Updater, lspLifetime uint16) (*Server, error) {
+func (s *Server) lsdb(level uint8) *lsdb {
+	if level == 1 {
+		return s.lsdbL1
+	}
+
+	return s.lsdbL2
+}
+
+// New creates a new ISIS server installing the computed routes into VRF v
+func New(nets []*types.NET, ds device.Updater, v *vrf.VRF, lspLifetime uint16) (*Server, error) {
 	if len(nets) == 0 {
 		return nil, fmt.Errorf("No NETs given. One is minimum")
 	}
@@ -130,7 +193,15 @@ func New(nets []*types.NET, ds device.Updater, lspLifetime uint16) (*Server, err
 	}
 
 	s.netIfaManager = newNetIfaManager(s)
+	s.lsdbL1 = newLSDB(s)
 	s.lsdbL2 = newLSDB(s)
+	s.spfScheduler = newSPFScheduler(s.runSPF)
+	s.ribInstallerL1 = newRIBInstaller(v.IPv4UnicastRIB(), 1)
+	s.ribInstallerL2 = newRIBInstaller(v.IPv4UnicastRIB(), 2)
+	s.ribInstallerIPv6L1 = newRIBInstaller(v.IPv6UnicastRIB(), 1)
+	s.ribInstallerIPv6L2 = newRIBInstaller(v.IPv6UnicastRIB(), 2)
+	s.spfL1 = &spfResult{}
+	s.spfL2 = &spfResult{}
 
 	return s, nil
 }
@@ -143,6 +214,56 @@ func (s *Server) SetHostnameFunc(f func() (string, error)) {
 	s.hostname = f
 }
 
+// SetSPFIntervals sets the delays of SPF runs after topology changes
+func (s *Server) SetSPFIntervals(initialWait time.Duration, secondaryWait time.Duration, maxWait time.Duration) {
+	s.spfScheduler.setIntervals(initialWait, secondaryWait, maxWait)
+}
+
+// SetMultiTopology enables multi topology routing (RFC5120) using a separate IPv6 unicast topology
+func (s *Server) SetMultiTopology(enabled bool) {
+	if s.multiTopology.Swap(enabled) == enabled || !s.isRunning() {
+		return
+	}
+
+	s.updateLSPs()
+	s.spfScheduler.schedule()
+}
+
+// SetLeakFilterChain sets the filter chain selecting the level 2 routes leaked into level 1 (RFC5302).
+// No routes are leaked if the chain is empty.
+func (s *Server) SetLeakFilterChain(c filter.Chain) {
+	s.leakFilterChainMu.Lock()
+	changed := !c.Equal(s.leakFilterChain)
+	s.leakFilterChain = c
+	s.leakFilterChainMu.Unlock()
+
+	// The leaked routes are advertised in our level 1 LSP
+	if changed && s.isRunning() {
+		s.updateLSP(1)
+	}
+}
+
+func (s *Server) getLeakFilterChain() filter.Chain {
+	s.leakFilterChainMu.RLock()
+	defer s.leakFilterChainMu.RUnlock()
+
+	return s.leakFilterChain
+}
+
+// topologies returns the topologies we participate in
+func (s *Server) topologies() []packet.MultiTopology {
+	if !s.multiTopology.Load() {
+		return []packet.MultiTopology{
+			{MTID: packet.MTIDStandard},
+		}
+	}
+
+	return []packet.MultiTopology{
+		{MTID: packet.MTIDStandard},
+		{MTID: packet.MTIDIPv6Unicast},
+	}
+}
+
 func (s *Server) GetEthernetInterface(name string) ethernet.EthernetInterfaceI {
 	ifa := s.netIfaManager.getInterface(name)
 	if ifa == nil {
@@ -187,7 +308,33 @@ func (s *Server) GetInterfaceNames() []string {
 	return ret
 }
 
-// updateL2LSP updates the systems L2 LSP. This is triggered when:
+// levelEnabled checks if any interface participates in a level
+func (s *Server) levelEnabled(level uint8) bool {
+	for _, ifa := range s.netIfaManager.getAllInterfaces() {
+		if ifa.levelConfig(level) != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isType gets the IS type advertised in our LSPs
+func (s *Server) isType() uint8 {
+	if s.levelEnabled(2) {
+		return packet.TypeBlockISTypeL1L2
+	}
+
+	return packet.TypeBlockISTypeL1
+}
+
+// updateLSPs updates the systems LSPs of both levels
+func (s *Server) updateLSPs() {
+	s.updateLSP(1)
+	s.updateLSP(2)
+}
+
+// updateLSP updates the systems LSP of a level. This is triggered when:
 // 1. Router starts up (done)
 // 2. Periodic refresh timer expired (done)
 // 3. A new adjacency is formed (done)
@@ -195,9 +342,10 @@ func (s *Server) GetInterfaceNames() []string {
 // 5. A link goes down (done)
 // 6. Metric associated with a link or reachable address changes (todo)
 // 7. The routers sysID changes  (todo)
-// 8. The router is elected or superseded as the DIS (todo)
+// 8. The router is elected or superseded as the DIS (done)
 // 9. An area address associated with the router is added or removed (todo)
-// 10. The overload status of the database changes (todo)
-func (s *Server) updateL2LSP() {
-	s.lsdbL2.requestL2LSPUpdate()
+// 10. The overload status of the database changes (done)
+// 11. The routes propagated between the levels or the attachment to other areas change (done)
+func (s *Server) updateLSP(level uint8) {
+	s.lsdb(level).requestLSPUpdate()
 }
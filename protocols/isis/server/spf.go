@@ -0,0 +1,865 @@
+package server
+
+import (
+	"bytes"
+	"container/heap"
+	"sort"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/isis/packet"
+	"github.com/bio-routing/bio-rd/protocols/isis/types"
+)
+
+const (
+	// maxLinkMetric is the metric of links that must not be used for SPF (RFC5305 Sect. 3)
+	maxLinkMetric = 0xffffff
+
+	// maxPathMetric is the highest metric of a path. Nodes and prefixes beyond are unreachable (RFC5305 Sect. 4)
+	maxPathMetric = 0xfe000000
+)
+
+// NextHop is the first hop towards a node of the shortest path tree
+type NextHop struct {
+	InterfaceName string
+	SystemID      types.SystemID
+	Address       *bnet.IP // Address of the neighbor in the address family of the route. Nil if unknown.
+}
+
+func (n NextHop) equal(m NextHop) bool {
+	if n.InterfaceName != m.InterfaceName || n.SystemID != m.SystemID {
+		return false
+	}
+
+	return compareOptionalIPs(n.Address, m.Address) == 0
+}
+
+func (n NextHop) less(m NextHop) bool {
+	if n.InterfaceName != m.InterfaceName {
+		return n.InterfaceName < m.InterfaceName
+	}
+
+	if n.SystemID != m.SystemID {
+		return bytes.Compare(n.SystemID[:], m.SystemID[:]) < 0
+	}
+
+	return compareOptionalIPs(n.Address, m.Address) < 0
+}
+
+func compareOptionalIPs(a, b *bnet.IP) int8 {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+
+	return a.Compare(b)
+}
+
+// mergeNextHops returns the union of a and b sorted
+func mergeNextHops(a []NextHop, b []NextHop) []NextHop {
+	ret := make([]NextHop, 0, len(a)+len(b))
+	ret = append(ret, a...)
+	for _, nh := range b {
+		if !containsNextHop(ret, nh) {
+			ret = append(ret, nh)
+		}
+	}
+
+	for i := 1; i < len(ret); i++ {
+		for j := i; j > 0 && ret[j].less(ret[j-1]); j-- {
+			ret[j], ret[j-1] = ret[j-1], ret[j]
+		}
+	}
+
+	return ret
+}
+
+func containsNextHop(haystack []NextHop, needle NextHop) bool {
+	for _, nh := range haystack {
+		if nh.equal(needle) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// spfNode is a node of the topology as described by its LSPs
+type spfNode struct {
+	neighbors    map[types.SourceID]uint32
+	prefixes     []spfPrefix
+	ipv6Prefixes []spfPrefix
+	areas        []types.AreaID
+	attached     bool
+	overload     bool
+	srgb         []LabelBlock
+
+	// mt holds the neighbors and IPv6 prefixes of the other topologies the node participates in (RFC5120)
+	mt map[uint16]*spfNode
+}
+
+// topology gets the view of a topology other than the standard topology
+func (n *spfNode) topology(mtID uint16) *spfNode {
+	t, found := n.mt[mtID]
+	if !found {
+		t = &spfNode{
+			neighbors: make(map[types.SourceID]uint32),
+			prefixes:  make([]spfPrefix, 0),
+		}
+		n.mt[mtID] = t
+	}
+
+	return t
+}
+
+type spfPrefix struct {
+	pfx    bnet.Prefix
+	metric uint32
+	upDown bool
+	sid    *PrefixSID
+}
+
+// spfAdjacency is an adjacency of the local system. Adjacencies on LANs are reached via the
+// pseudonode identified by lanID.
+type spfAdjacency struct {
+	neighbor types.SourceID
+	lanID    types.SourceID
+	metric   uint32
+	nextHop  NextHop
+}
+
+func (a spfAdjacency) viaLAN() bool {
+	return a.lanID.CircuitID != 0
+}
+
+// spfVertex is a node of the shortest path tree
+type spfVertex struct {
+	id       types.SourceID
+	distance uint32
+	parents  []types.SourceID
+	nextHops []NextHop
+	index    int
+}
+
+// spfNodes builds the topology from the LSPs. Nodes without a valid first fragment are ignored.
+func (l *lsdb) spfNodes() map[types.SourceID]*spfNode {
+	l.lspsMu.RLock()
+	defer l.lspsMu.RUnlock()
+
+	return spfNodesFromLSPs(l.validLSPs())
+}
+
+// validLSPs returns all LSPs eligible for SPF. l.lspsMu must be held.
+func (l *lsdb) validLSPs() []*packet.LSPDU {
+	ret := make([]*packet.LSPDU, 0, len(l.lsps))
+	for _, e := range l.lsps {
+		if e.lspdu.SequenceNumber == 0 || e.lspdu.RemainingLifetime == 0 {
+			continue
+		}
+
+		ret = append(ret, e.lspdu)
+	}
+
+	return ret
+}
+
+func spfNodesFromLSPs(lsps []*packet.LSPDU) map[types.SourceID]*spfNode {
+	nodes := make(map[types.SourceID]*spfNode)
+	for _, lsp := range lsps {
+		if lsp.LSPID.LSPNumber != 0 {
+			continue
+		}
+
+		nodes[types.NewSourceID(lsp.LSPID.SystemID, lsp.LSPID.PseudonodeID)] = &spfNode{
+			neighbors:    make(map[types.SourceID]uint32),
+			prefixes:     make([]spfPrefix, 0),
+			ipv6Prefixes: make([]spfPrefix, 0),
+			areas:        make([]types.AreaID, 0),
+			attached:     lsp.Attached(),
+			overload:     lsp.Overload(),
+			mt:           make(map[uint16]*spfNode),
+		}
+	}
+
+	for _, lsp := range lsps {
+		n, found := nodes[types.NewSourceID(lsp.LSPID.SystemID, lsp.LSPID.PseudonodeID)]
+		if !found {
+			continue
+		}
+
+		for _, tlv := range lsp.TLVs {
+			switch tlv.Type() {
+			case packet.ExtendedISReachabilityType:
+				addSPFNeighbors(n.neighbors, tlv.(*packet.ExtendedISReachabilityTLV).Neighbors)
+			case packet.MTIntermediateSystemsTLVType:
+				mtis := tlv.(*packet.MTIntermediateSystemsTLV)
+				if mtis.MTID == packet.MTIDStandard {
+					addSPFNeighbors(n.neighbors, mtis.Neighbors)
+					continue
+				}
+
+				addSPFNeighbors(n.topology(mtis.MTID).neighbors, mtis.Neighbors)
+			case packet.ExtendedIPReachabilityTLVType:
+				for _, eipr := range tlv.(*packet.ExtendedIPReachabilityTLV).ExtendedIPReachabilities {
+					n.prefixes = append(n.prefixes, spfPrefix{
+						pfx:    bnet.NewPfx(bnet.IPv4(eipr.Address), eipr.PfxLen()),
+						metric: eipr.Metric,
+						upDown: eipr.UpDown(),
+						sid:    prefixSIDFromSubTLVs(eipr.SubTLVs),
+					})
+				}
+			case packet.IPv6ReachabilityTLVType:
+				n.ipv6Prefixes = append(n.ipv6Prefixes, ipv6SPFPrefixes(tlv.(*packet.IPv6ReachabilityTLV).IPv6Reachabilities)...)
+			case packet.MTIPv6ReachabilityTLVType:
+				mtr := tlv.(*packet.MTIPv6ReachabilityTLV)
+				if mtr.MTID == packet.MTIDStandard {
+					n.ipv6Prefixes = append(n.ipv6Prefixes, ipv6SPFPrefixes(mtr.IPv6Reachabilities)...)
+					continue
+				}
+
+				t := n.topology(mtr.MTID)
+				t.prefixes = append(t.prefixes, ipv6SPFPrefixes(mtr.IPv6Reachabilities)...)
+			case packet.MultiTopologyTLVType:
+				// The flags of the standard topology are taken from the LSP header
+				for _, mt := range tlv.(*packet.MultiTopologyTLV).Topologies {
+					if mt.MTID == packet.MTIDStandard {
+						continue
+					}
+
+					t := n.topology(mt.MTID)
+					t.attached = mt.Attached
+					t.overload = mt.Overload
+				}
+			case packet.AreaAddressesTLVType:
+				n.areas = append(n.areas, tlv.(*packet.AreaAddressesTLV).AreaIDs...)
+			case packet.RouterCapabilityTLVType:
+				n.srgb = append(n.srgb, srgbFromRouterCapability(tlv.(*packet.RouterCapabilityTLV))...)
+			}
+		}
+	}
+
+	return nodes
+}
+
+// addSPFNeighbors adds the neighbors of an IS reachability TLV to the neighbors of a node
+func addSPFNeighbors(nodeNeighbors map[types.SourceID]uint32, neighbors []*packet.ExtendedISReachabilityNeighbor) {
+	for _, neighbor := range neighbors {
+		if neighbor.Metric >= maxLinkMetric {
+			continue
+		}
+
+		// Parallel links are represented by the lowest metric
+		if m, exists := nodeNeighbors[neighbor.NeighborID]; exists && m <= neighbor.Metric {
+			continue
+		}
+
+		nodeNeighbors[neighbor.NeighborID] = neighbor.Metric
+	}
+}
+
+func ipv6SPFPrefixes(reachabilities []*packet.IPv6Reachability) []spfPrefix {
+	ret := make([]spfPrefix, 0, len(reachabilities))
+	for _, r := range reachabilities {
+		ret = append(ret, spfPrefix{
+			pfx:    bnet.NewPfx(r.Prefix.Addr(), r.Prefix.Len()),
+			metric: r.Metric,
+			upDown: r.Up(),
+			sid:    prefixSIDFromSubTLVs(r.SubTLVs),
+		})
+	}
+
+	return ret
+}
+
+// ipv6Topology gets the topology IPv6 routes are computed in. Without multi topology IPv6 prefixes are reached via
+// the standard topology. Pseudonodes do not advertise topology specific TLVs, their neighbors belong to all topologies.
+func ipv6Topology(nodes map[types.SourceID]*spfNode, mtID uint16) map[types.SourceID]*spfNode {
+	ret := make(map[types.SourceID]*spfNode, len(nodes))
+	for id, n := range nodes {
+		v := &spfNode{
+			neighbors: n.neighbors,
+			prefixes:  n.ipv6Prefixes,
+			areas:     n.areas,
+			attached:  n.attached,
+			overload:  n.overload,
+			srgb:      n.srgb,
+		}
+
+		if mtID != packet.MTIDStandard && id.CircuitID == 0 {
+			v.neighbors = make(map[types.SourceID]uint32)
+			v.prefixes = nil
+			v.attached = false
+			v.overload = false
+
+			if t, found := n.mt[mtID]; found {
+				v.neighbors = t.neighbors
+				v.prefixes = t.prefixes
+				v.attached = t.attached
+				v.overload = t.overload
+			}
+		}
+
+		ret[id] = v
+	}
+
+	return ret
+}
+
+// computeSPT computes the shortest path tree rooted at the local system. Links are only considered if
+// both ends report the adjacency (two-way check). Equal cost paths are combined into multiple next hops.
+// Paths via overloaded ISs are not considered.
+func computeSPT(root types.SystemID, nodes map[types.SourceID]*spfNode, adjacencies []spfAdjacency) map[types.SourceID]*spfVertex {
+	rootID := types.NewSourceID(root, 0)
+	spt := make(map[types.SourceID]*spfVertex)
+	spt[rootID] = &spfVertex{
+		id:       rootID,
+		parents:  make([]types.SourceID, 0),
+		nextHops: make([]NextHop, 0),
+	}
+
+	candidates := make(map[types.SourceID]*spfVertex)
+	q := make(spfQueue, 0)
+
+	relax := func(from types.SourceID, to types.SourceID, distance uint64, nextHops []NextHop) {
+		if distance > maxPathMetric {
+			return
+		}
+
+		if _, done := spt[to]; done {
+			return
+		}
+
+		c, found := candidates[to]
+		if !found {
+			c = &spfVertex{
+				id:       to,
+				distance: uint32(distance),
+				parents:  []types.SourceID{from},
+				nextHops: mergeNextHops(nil, nextHops),
+			}
+			candidates[to] = c
+			heap.Push(&q, c)
+			return
+		}
+
+		if uint32(distance) > c.distance {
+			return
+		}
+
+		if uint32(distance) < c.distance {
+			c.distance = uint32(distance)
+			c.parents = []types.SourceID{from}
+			c.nextHops = mergeNextHops(nil, nextHops)
+			heap.Fix(&q, c.index)
+			return
+		}
+
+		c.parents = append(c.parents, from)
+		c.nextHops = mergeNextHops(c.nextHops, nextHops)
+	}
+
+	// Next hops towards the members of directly connected LANs
+	lanNextHops := make(map[types.SourceID]map[types.SourceID]NextHop)
+	lanMetrics := make(map[types.SourceID]uint32)
+	for _, adj := range adjacencies {
+		if adj.viaLAN() {
+			if _, found := lanNextHops[adj.lanID]; !found {
+				lanNextHops[adj.lanID] = make(map[types.SourceID]NextHop)
+			}
+
+			lanNextHops[adj.lanID][adj.neighbor] = adj.nextHop
+			if m, found := lanMetrics[adj.lanID]; !found || adj.metric < m {
+				lanMetrics[adj.lanID] = adj.metric
+			}
+
+			continue
+		}
+
+		if !twoWay(nodes, rootID, adj.neighbor) {
+			continue
+		}
+
+		relax(rootID, adj.neighbor, uint64(adj.metric), []NextHop{adj.nextHop})
+	}
+
+	for lanID, metric := range lanMetrics {
+		if !twoWay(nodes, rootID, lanID) {
+			continue
+		}
+
+		relax(rootID, lanID, uint64(metric), nil)
+	}
+
+	for q.Len() > 0 {
+		v := heap.Pop(&q).(*spfVertex)
+		delete(candidates, v.id)
+		spt[v.id] = v
+
+		// Overloaded ISs are not used for transit. Their own prefixes are still reachable.
+		if nodes[v.id].overload {
+			continue
+		}
+
+		for neighbor, metric := range nodes[v.id].neighbors {
+			if neighbor == rootID || !twoWay(nodes, v.id, neighbor) {
+				continue
+			}
+
+			nextHops := v.nextHops
+			if members, found := lanNextHops[v.id]; found && v.hasParent(rootID) {
+				// Members of a directly connected LAN are reached via our adjacency to them
+				nh, found := members[neighbor]
+				if found {
+					nextHops = mergeNextHops(v.nextHops, []NextHop{nh})
+				}
+
+				if len(nextHops) == 0 {
+					continue
+				}
+			}
+
+			relax(v.id, neighbor, uint64(v.distance)+uint64(metric), nextHops)
+		}
+	}
+
+	return spt
+}
+
+func (v *spfVertex) hasParent(id types.SourceID) bool {
+	for _, p := range v.parents {
+		if p == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+// twoWay checks if node b reports an adjacency to node a. The adjacency from a to b is known
+// from the LSPs of a or the adjacencies of the local system.
+func twoWay(nodes map[types.SourceID]*spfNode, a types.SourceID, b types.SourceID) bool {
+	n, found := nodes[b]
+	if !found {
+		return false
+	}
+
+	_, found = n.neighbors[a]
+	return found
+}
+
+// spfRoute is a route computed from the shortest path tree. upDown marks routes to prefixes leaked
+// from level 2 into level 1.
+type spfRoute struct {
+	metric    uint32
+	nextHops  []NextHop
+	upDown    bool
+	prefixSID *PrefixSID
+}
+
+// computeRoutes computes the best paths to all prefixes advertised by nodes of the shortest path tree.
+// Prefixes advertised by the local system are directly connected and thus ignored. Prefixes leaked from
+// level 2 are only used if the prefix is not reachable within the area (RFC5302 Sect. 3.3).
+func computeRoutes(root types.SystemID, nodes map[types.SourceID]*spfNode, spt map[types.SourceID]*spfVertex) map[bnet.Prefix]*spfRoute {
+	rootID := types.NewSourceID(root, 0)
+	local := make(map[bnet.Prefix]struct{})
+	if n, found := nodes[rootID]; found {
+		for _, p := range n.prefixes {
+			local[p.pfx] = struct{}{}
+		}
+	}
+
+	routes := make(map[bnet.Prefix]*spfRoute)
+	for id, v := range spt {
+		if id == rootID || len(v.nextHops) == 0 {
+			continue
+		}
+
+		for _, p := range nodes[id].prefixes {
+			if _, found := local[p.pfx]; found {
+				continue
+			}
+
+			metric := uint64(v.distance) + uint64(p.metric)
+			if metric > maxPathMetric {
+				continue
+			}
+
+			r, found := routes[p.pfx]
+			if found && p.upDown && !r.upDown {
+				continue
+			}
+
+			if !found || uint32(metric) < r.metric || (r.upDown && !p.upDown) {
+				routes[p.pfx] = &spfRoute{
+					metric:    uint32(metric),
+					nextHops:  mergeNextHops(nil, v.nextHops),
+					upDown:    p.upDown,
+					prefixSID: p.sid,
+				}
+				continue
+			}
+
+			if uint32(metric) == r.metric {
+				r.nextHops = mergeNextHops(r.nextHops, v.nextHops)
+				if r.prefixSID == nil {
+					r.prefixSID = p.sid
+				}
+			}
+		}
+	}
+
+	return routes
+}
+
+// spfQueue is a priority queue of SPF candidates ordered by distance
+type spfQueue []*spfVertex
+
+func (q spfQueue) Len() int {
+	return len(q)
+}
+
+func (q spfQueue) Less(i, j int) bool {
+	if q[i].distance != q[j].distance {
+		return q[i].distance < q[j].distance
+	}
+
+	// Pseudonodes are processed first so their members are reached with all equal cost paths
+	return q[i].id.CircuitID > q[j].id.CircuitID
+}
+
+func (q spfQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *spfQueue) Push(x interface{}) {
+	v := x.(*spfVertex)
+	v.index = len(*q)
+	*q = append(*q, v)
+}
+
+func (q *spfQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	v := old[n-1]
+	*q = old[:n-1]
+	return v
+}
+
+// spfAdjacencies returns the adjacencies of the local system in a level that are up and participate in topology mtID.
+// Next hops are addressed by the neighbors IPv6 address if ipv6 is set and by its IPv4 address otherwise.
+func (s *Server) spfAdjacencies(level uint8, mtID uint16, ipv6 bool) []spfAdjacency {
+	ret := make([]spfAdjacency, 0)
+	for _, ifa := range s.netIfaManager.getAllInterfaces() {
+		nm := ifa.neighborManager(level)
+		if nm == nil {
+			continue
+		}
+
+		for _, n := range nm.getNeighborsUp() {
+			if !n.supportsTopology(mtID) {
+				continue
+			}
+
+			nh := NextHop{
+				InterfaceName: ifa.name,
+				SystemID:      n.sysID,
+			}
+
+			for _, addr := range n.ipAddresses {
+				if addr.IsIPv4() != ipv6 {
+					nh.Address = addr.Dedup()
+					break
+				}
+			}
+
+			adj := spfAdjacency{
+				neighbor: types.NewSourceID(n.sysID, 0),
+				metric:   ifa.levelConfig(level).Metric,
+				nextHop:  nh,
+			}
+
+			if ifa.isLAN() {
+				adj.lanID = nm.getLANID()
+				if !adj.viaLAN() {
+					continue
+				}
+			}
+
+			ret = append(ret, adj)
+		}
+	}
+
+	return ret
+}
+
+// spfResult is the result of the SPF computation of a level
+type spfResult struct {
+	nodes      map[types.SourceID]*spfNode
+	spt        map[types.SourceID]*spfVertex
+	routes     map[bnet.Prefix]*spfRoute
+	ipv6Routes map[bnet.Prefix]*spfRoute
+
+	// attached is set if other areas are reachable via level 2
+	attached bool
+}
+
+// runSPF computes the shortest path trees of both levels and installs the resulting routes into the RIB
+func (s *Server) runSPF() {
+	l1 := s.computeLevel(1)
+	l2 := s.computeLevel(2)
+
+	s.spfMu.Lock()
+	prevL1, prevL2 := s.spfL1, s.spfL2
+	s.spfL1 = l1
+	s.spfL2 = l2
+	s.spfMu.Unlock()
+
+	s.ribInstallerL1.install(l1.routes)
+	s.ribInstallerL2.install(l2.routes)
+	s.ribInstallerIPv6L1.install(l1.ipv6Routes)
+	s.ribInstallerIPv6L2.install(l2.ipv6Routes)
+
+	// Level 1 routes are propagated into level 2
+	if !routeMetricsEqual(prevL1.routes, l1.routes) {
+		s.updateLSP(2)
+	}
+
+	// Level 2 routes may be leaked into level 1
+	if prevL2.attached != l2.attached || (len(s.getLeakFilterChain()) > 0 && !routeMetricsEqual(prevL2.routes, l2.routes)) {
+		s.updateLSP(1)
+	}
+}
+
+// computeLevel computes the shortest path tree and routes of a level. IPv6 routes are computed in the IPv6
+// unicast topology in multi topology mode. L1/L2 routers ignore prefixes leaked from level 2 into level 1
+// as they know the level 2 topology. Level 1 only routers use the closest attached L1/L2 router as default
+// gateway (ISO10589 Sect. 7.2.9.2).
+func (s *Server) computeLevel(level uint8) *spfResult {
+	nodes := s.lsdb(level).spfNodes()
+	spt := computeSPT(s.systemID(), nodes, s.spfAdjacencies(level, packet.MTIDStandard, false))
+
+	ipv6MTID := uint16(packet.MTIDStandard)
+	if s.multiTopology.Load() {
+		ipv6MTID = packet.MTIDIPv6Unicast
+	}
+
+	ipv6Nodes := ipv6Topology(nodes, ipv6MTID)
+	ipv6SPT := computeSPT(s.systemID(), ipv6Nodes, s.spfAdjacencies(level, ipv6MTID, true))
+
+	res := &spfResult{
+		nodes:      nodes,
+		spt:        spt,
+		routes:     computeRoutes(s.systemID(), nodes, spt),
+		ipv6Routes: computeRoutes(s.systemID(), ipv6Nodes, ipv6SPT),
+	}
+
+	if level == 2 {
+		res.attached = attachedToOtherAreas(s.systemID(), s.areaIDs(), nodes, spt)
+		return res
+	}
+
+	if s.levelEnabled(2) {
+		removeUpDownRoutes(res.routes)
+		removeUpDownRoutes(res.ipv6Routes)
+		return res
+	}
+
+	addDefaultRoute(s.systemID(), nodes, spt, res.routes, bnet.NewPfx(bnet.IPv4(0), 0))
+	addDefaultRoute(s.systemID(), ipv6Nodes, ipv6SPT, res.ipv6Routes, bnet.NewPfx(bnet.IPv6(0, 0), 0))
+	return res
+}
+
+// removeUpDownRoutes removes the routes to prefixes leaked from level 2 into level 1
+func removeUpDownRoutes(routes map[bnet.Prefix]*spfRoute) {
+	for pfx, r := range routes {
+		if r.upDown {
+			delete(routes, pfx)
+		}
+	}
+}
+
+// attachedToOtherAreas checks if any node of the level 2 shortest path tree is located in another area
+func attachedToOtherAreas(root types.SystemID, areas []types.AreaID, nodes map[types.SourceID]*spfNode, spt map[types.SourceID]*spfVertex) bool {
+	for id := range spt {
+		if id.SystemID == root || id.CircuitID != 0 {
+			continue
+		}
+
+		if !areasOverlap(areas, nodes[id].areas) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func areasOverlap(a []types.AreaID, b []types.AreaID) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x.Equal(y) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// addDefaultRoute adds the default route defaultRoute towards the closest L1/L2 routers setting the ATT bit
+// unless a default route is advertised explicitly
+func addDefaultRoute(root types.SystemID, nodes map[types.SourceID]*spfNode, spt map[types.SourceID]*spfVertex, routes map[bnet.Prefix]*spfRoute, defaultRoute bnet.Prefix) {
+	if _, found := routes[defaultRoute]; found {
+		return
+	}
+
+	var r *spfRoute
+	for id, v := range spt {
+		if id.SystemID == root || id.CircuitID != 0 || !nodes[id].attached || len(v.nextHops) == 0 {
+			continue
+		}
+
+		if r == nil || v.distance < r.metric {
+			r = &spfRoute{
+				metric:   v.distance,
+				nextHops: mergeNextHops(nil, v.nextHops),
+			}
+			continue
+		}
+
+		if v.distance == r.metric {
+			r.nextHops = mergeNextHops(r.nextHops, v.nextHops)
+		}
+	}
+
+	if r != nil {
+		routes[defaultRoute] = r
+	}
+}
+
+// routeMetricsEqual checks if a and b contain the same prefixes with equal metrics and prefix SIDs
+func routeMetricsEqual(a map[bnet.Prefix]*spfRoute, b map[bnet.Prefix]*spfRoute) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for pfx, r := range a {
+		x, found := b[pfx]
+		if !found || x.metric != r.metric || x.upDown != r.upDown || !prefixSIDsEqual(x.prefixSID, r.prefixSID) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func prefixSIDsEqual(a *PrefixSID, b *PrefixSID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
+
+// triggerSPF schedules an SPF run
+func (s *Server) triggerSPF() {
+	if s.spfScheduler == nil {
+		return
+	}
+
+	s.spfScheduler.schedule()
+}
+
+// SPFTreeEntry is a node of the shortest path tree
+type SPFTreeEntry struct {
+	SourceID types.SourceID
+	Metric   uint32
+	Parents  []types.SourceID
+	NextHops []NextHop
+	SRGB     []LabelBlock // SR global block advertised by the node
+}
+
+// Route is a route computed by SPF
+type Route struct {
+	Prefix    bnet.Prefix
+	Metric    uint32
+	NextHops  []NextHop
+	PrefixSID *PrefixSID // Nil if no prefix SID is advertised
+}
+
+func (s *Server) spfResult(level uint8) *spfResult {
+	if level == 1 {
+		return s.spfL1
+	}
+
+	return s.spfL2
+}
+
+// GetSPFTree gets the shortest path tree of the last SPF run of a level
+func (s *Server) GetSPFTree(level uint8) []*SPFTreeEntry {
+	s.spfMu.RLock()
+	defer s.spfMu.RUnlock()
+
+	res := s.spfResult(level)
+	ret := make([]*SPFTreeEntry, 0, len(res.spt))
+	for _, v := range res.spt {
+		e := &SPFTreeEntry{
+			SourceID: v.id,
+			Metric:   v.distance,
+			Parents:  v.parents,
+			NextHops: v.nextHops,
+		}
+
+		if n, found := res.nodes[v.id]; found {
+			e.SRGB = n.srgb
+		}
+
+		ret = append(ret, e)
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Metric != ret[j].Metric {
+			return ret[i].Metric < ret[j].Metric
+		}
+
+		return bytes.Compare(ret[i].SourceID.Serialize(), ret[j].SourceID.Serialize()) < 0
+	})
+
+	return ret
+}
+
+// GetRoutes gets the IPv4 and IPv6 routes computed by the last SPF run of a level
+func (s *Server) GetRoutes(level uint8) []*Route {
+	s.spfMu.RLock()
+	defer s.spfMu.RUnlock()
+
+	res := s.spfResult(level)
+	return append(routesSorted(res.routes), routesSorted(res.ipv6Routes)...)
+}
+
+func routesSorted(routes map[bnet.Prefix]*spfRoute) []*Route {
+	ret := make([]*Route, 0, len(routes))
+	for pfx, r := range routes {
+		ret = append(ret, &Route{
+			Prefix:    pfx,
+			Metric:    r.metric,
+			NextHops:  r.nextHops,
+			PrefixSID: r.prefixSID,
+		})
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		a, b := ret[i].Prefix.Addr(), ret[j].Prefix.Addr()
+		if c := a.Compare(&b); c != 0 {
+			return c < 0
+		}
+
+		return ret[i].Prefix.Len() < ret[j].Prefix.Len()
+	})
+
+	return ret
+}
@@ -0,0 +1,118 @@
+package server
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	defaultSPFInitialWait   = 50 * time.Millisecond
+	defaultSPFSecondaryWait = 200 * time.Millisecond
+	defaultSPFMaxWait       = 5 * time.Second
+)
+
+// spfScheduler delays SPF runs after changes of the topology. The first run after a quiet period
+// is delayed by initialWait. Subsequent runs are delayed by secondaryWait which is doubled on every
+// run up to maxWait. The delay falls back to initialWait once no run happened for maxWait (holddown).
+type spfScheduler struct {
+	initialWait   time.Duration
+	secondaryWait time.Duration
+	maxWait       time.Duration
+	intervalsMu   sync.Mutex
+	run           func()
+	wait          time.Duration
+	lastRun       time.Time
+	trigger       chan struct{}
+	done          chan struct{}
+	wg            sync.WaitGroup
+}
+
+func newSPFScheduler(run func()) *spfScheduler {
+	return &spfScheduler{
+		initialWait:   defaultSPFInitialWait,
+		secondaryWait: defaultSPFSecondaryWait,
+		maxWait:       defaultSPFMaxWait,
+		run:           run,
+		trigger:       make(chan struct{}, 1),
+		done:          make(chan struct{}),
+	}
+}
+
+func (s *spfScheduler) setIntervals(initialWait time.Duration, secondaryWait time.Duration, maxWait time.Duration) {
+	s.intervalsMu.Lock()
+	defer s.intervalsMu.Unlock()
+
+	s.initialWait = initialWait
+	s.secondaryWait = secondaryWait
+	s.maxWait = maxWait
+}
+
+func (s *spfScheduler) start() {
+	s.wg.Add(1)
+	go s.scheduler()
+}
+
+func (s *spfScheduler) stop() {
+	close(s.done)
+	s.wg.Wait()
+}
+
+// schedule requests an SPF run if none is pending
+func (s *spfScheduler) schedule() {
+	select {
+	case s.trigger <- struct{}{}:
+	default:
+	}
+}
+
+func (s *spfScheduler) scheduler() {
+	defer s.wg.Done()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-s.trigger:
+		}
+
+		t := clock.Timer(s.nextWait(clock.Now()))
+		select {
+		case <-s.done:
+			t.Stop()
+			return
+		case <-t.C:
+		}
+
+		// Changes requested while waiting are covered by this run
+		select {
+		case <-s.trigger:
+		default:
+		}
+
+		s.run()
+		s.lastRun = clock.Now()
+	}
+}
+
+// nextWait returns the delay of the next SPF run requested at now
+func (s *spfScheduler) nextWait(now time.Time) time.Duration {
+	s.intervalsMu.Lock()
+	defer s.intervalsMu.Unlock()
+
+	if s.lastRun.IsZero() || now.Sub(s.lastRun) > s.maxWait {
+		s.wait = s.initialWait
+		return s.wait
+	}
+
+	if s.wait < s.secondaryWait {
+		s.wait = s.secondaryWait
+		return s.wait
+	}
+
+	s.wait *= 2
+	if s.wait > s.maxWait {
+		s.wait = s.maxWait
+	}
+
+	return s.wait
+}
@@ -0,0 +1,627 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/isis/packet"
+	"github.com/bio-routing/bio-rd/protocols/isis/types"
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/bio-routing/bio-rd/routingtable/locRIB"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	sysA = types.SystemID{0, 0, 0, 0, 0, 1}
+	sysB = types.SystemID{0, 0, 0, 0, 0, 2}
+	sysC = types.SystemID{0, 0, 0, 0, 0, 3}
+	sysD = types.SystemID{0, 0, 0, 0, 0, 4}
+)
+
+func testLSP(sysID types.SystemID, neighbors map[types.SystemID]uint32, prefixes map[bnet.Prefix]uint32) *packet.LSPDU {
+	eisr := packet.NewExtendedISReachabilityTLV()
+	for n, metric := range neighbors {
+		eisr.AddNeighbor(packet.NewExtendedISReachabilityNeighbor(types.NewSourceID(n, 0), metric))
+	}
+
+	eipr := packet.NewExtendedIPReachabilityTLV()
+	for pfx, metric := range prefixes {
+		eipr.AddExtendedIPReachability(packet.NewExtendedIPReachability(metric, pfx.Len(), pfx.Addr().ToUint32()))
+	}
+
+	return &packet.LSPDU{
+		RemainingLifetime: 1200,
+		LSPID: packet.LSPID{
+			SystemID: sysID,
+		},
+		SequenceNumber: 1,
+		TLVs: []packet.TLV{
+			eisr,
+			eipr,
+		},
+	}
+}
+
+func testPseudonodeLSP(lanID types.SourceID, members []types.SystemID, neighbors map[types.SourceID]uint32) *packet.LSPDU {
+	eisr := packet.NewExtendedISReachabilityTLV()
+	for _, m := range members {
+		eisr.AddNeighbor(packet.NewExtendedISReachabilityNeighbor(types.NewSourceID(m, 0), 0))
+	}
+
+	for n, metric := range neighbors {
+		eisr.AddNeighbor(packet.NewExtendedISReachabilityNeighbor(n, metric))
+	}
+
+	return &packet.LSPDU{
+		RemainingLifetime: 1200,
+		LSPID: packet.LSPID{
+			SystemID:     lanID.SystemID,
+			PseudonodeID: lanID.CircuitID,
+		},
+		SequenceNumber: 1,
+		TLVs: []packet.TLV{
+			eisr,
+		},
+	}
+}
+
+func testAdjacency(sysID types.SystemID, ifa string, addr bnet.IP, metric uint32) spfAdjacency {
+	return spfAdjacency{
+		neighbor: types.NewSourceID(sysID, 0),
+		metric:   metric,
+		nextHop: NextHop{
+			InterfaceName: ifa,
+			SystemID:      sysID,
+			Address:       addr.Ptr(),
+		},
+	}
+}
+
+func testLSPWithPseudonode(sysID types.SystemID, lanID types.SourceID, metric uint32, prefixes map[bnet.Prefix]uint32) *packet.LSPDU {
+	l := testLSP(sysID, nil, prefixes)
+	l.TLVs[0].(*packet.ExtendedISReachabilityTLV).AddNeighbor(packet.NewExtendedISReachabilityNeighbor(lanID, metric))
+
+	return l
+}
+
+func testLANAdjacency(sysID types.SystemID, lanID types.SourceID, ifa string, addr bnet.IP, metric uint32) spfAdjacency {
+	adj := testAdjacency(sysID, ifa, addr, metric)
+	adj.lanID = lanID
+
+	return adj
+}
+
+func TestComputeRoutes(t *testing.T) {
+	pfxB := bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 2, 0), 24)
+	pfxC := bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 3, 0), 24)
+	pfxD := bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 4, 0), 24)
+	pfxLocal := bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 1, 0), 24)
+	nhB := bnet.IPv4FromOctets(192, 0, 2, 2)
+	nhC := bnet.IPv4FromOctets(192, 0, 2, 3)
+	lanB := types.NewSourceID(sysB, 1)
+
+	tests := []struct {
+		name           string
+		lsps           []*packet.LSPDU
+		adjacencies    []spfAdjacency
+		expectedTree   map[types.SourceID]uint32
+		expectedRoutes map[bnet.Prefix]*spfRoute
+	}{
+		{
+			name: "ECMP via B and C",
+			lsps: []*packet.LSPDU{
+				testLSP(sysA, map[types.SystemID]uint32{sysB: 10, sysC: 10}, map[bnet.Prefix]uint32{pfxLocal: 10}),
+				testLSP(sysB, map[types.SystemID]uint32{sysA: 10, sysD: 10}, map[bnet.Prefix]uint32{pfxB: 10, pfxLocal: 10}),
+				testLSP(sysC, map[types.SystemID]uint32{sysA: 10, sysD: 10}, map[bnet.Prefix]uint32{pfxC: 10}),
+				testLSP(sysD, map[types.SystemID]uint32{sysB: 10, sysC: 10}, map[bnet.Prefix]uint32{pfxD: 5}),
+			},
+			adjacencies: []spfAdjacency{
+				testAdjacency(sysB, "eth0", nhB, 10),
+				testAdjacency(sysC, "eth1", nhC, 10),
+			},
+			expectedTree: map[types.SourceID]uint32{
+				types.NewSourceID(sysA, 0): 0,
+				types.NewSourceID(sysB, 0): 10,
+				types.NewSourceID(sysC, 0): 10,
+				types.NewSourceID(sysD, 0): 20,
+			},
+			expectedRoutes: map[bnet.Prefix]*spfRoute{
+				pfxB: {
+					metric: 20,
+					nextHops: []NextHop{
+						{InterfaceName: "eth0", SystemID: sysB, Address: nhB.Ptr()},
+					},
+				},
+				pfxC: {
+					metric: 20,
+					nextHops: []NextHop{
+						{InterfaceName: "eth1", SystemID: sysC, Address: nhC.Ptr()},
+					},
+				},
+				pfxD: {
+					metric: 25,
+					nextHops: []NextHop{
+						{InterfaceName: "eth0", SystemID: sysB, Address: nhB.Ptr()},
+						{InterfaceName: "eth1", SystemID: sysC, Address: nhC.Ptr()},
+					},
+				},
+			},
+		},
+		{
+			name: "shortest path via C",
+			lsps: []*packet.LSPDU{
+				testLSP(sysB, map[types.SystemID]uint32{sysA: 10, sysD: 100}, nil),
+				testLSP(sysC, map[types.SystemID]uint32{sysA: 10, sysD: 10}, nil),
+				testLSP(sysD, map[types.SystemID]uint32{sysB: 100, sysC: 10}, map[bnet.Prefix]uint32{pfxD: 5}),
+			},
+			adjacencies: []spfAdjacency{
+				testAdjacency(sysB, "eth0", nhB, 10),
+				testAdjacency(sysC, "eth1", nhC, 20),
+			},
+			expectedTree: map[types.SourceID]uint32{
+				types.NewSourceID(sysA, 0): 0,
+				types.NewSourceID(sysB, 0): 10,
+				types.NewSourceID(sysC, 0): 20,
+				types.NewSourceID(sysD, 0): 30,
+			},
+			expectedRoutes: map[bnet.Prefix]*spfRoute{
+				pfxD: {
+					metric: 35,
+					nextHops: []NextHop{
+						{InterfaceName: "eth1", SystemID: sysC, Address: nhC.Ptr()},
+					},
+				},
+			},
+		},
+		{
+			name: "two-way check fails",
+			lsps: []*packet.LSPDU{
+				testLSP(sysB, map[types.SystemID]uint32{sysA: 10, sysD: 10}, map[bnet.Prefix]uint32{pfxB: 10}),
+				testLSP(sysC, map[types.SystemID]uint32{sysD: 10}, map[bnet.Prefix]uint32{pfxC: 10}),
+				testLSP(sysD, map[types.SystemID]uint32{sysC: 10}, map[bnet.Prefix]uint32{pfxD: 5}),
+			},
+			adjacencies: []spfAdjacency{
+				testAdjacency(sysB, "eth0", nhB, 10),
+				testAdjacency(sysC, "eth1", nhC, 10),
+			},
+			expectedTree: map[types.SourceID]uint32{
+				types.NewSourceID(sysA, 0): 0,
+				types.NewSourceID(sysB, 0): 10,
+			},
+			expectedRoutes: map[bnet.Prefix]*spfRoute{
+				pfxB: {
+					metric: 20,
+					nextHops: []NextHop{
+						{InterfaceName: "eth0", SystemID: sysB, Address: nhB.Ptr()},
+					},
+				},
+			},
+		},
+		{
+			name: "LAN via pseudonode of B",
+			lsps: []*packet.LSPDU{
+				testLSPWithPseudonode(sysB, lanB, 10, map[bnet.Prefix]uint32{pfxB: 10}),
+				testLSPWithPseudonode(sysC, lanB, 10, map[bnet.Prefix]uint32{pfxC: 10}),
+				testPseudonodeLSP(lanB, []types.SystemID{sysA, sysB, sysC}, nil),
+			},
+			adjacencies: []spfAdjacency{
+				testLANAdjacency(sysB, lanB, "eth0", nhB, 10),
+				testLANAdjacency(sysC, lanB, "eth0", nhC, 10),
+			},
+			expectedTree: map[types.SourceID]uint32{
+				types.NewSourceID(sysA, 0): 0,
+				lanB:                       10,
+				types.NewSourceID(sysB, 0): 10,
+				types.NewSourceID(sysC, 0): 10,
+			},
+			expectedRoutes: map[bnet.Prefix]*spfRoute{
+				pfxB: {
+					metric: 20,
+					nextHops: []NextHop{
+						{InterfaceName: "eth0", SystemID: sysB, Address: nhB.Ptr()},
+					},
+				},
+				pfxC: {
+					metric: 20,
+					nextHops: []NextHop{
+						{InterfaceName: "eth0", SystemID: sysC, Address: nhC.Ptr()},
+					},
+				},
+			},
+		},
+		{
+			name: "links with maximum metric are not used",
+			lsps: []*packet.LSPDU{
+				testLSP(sysB, map[types.SystemID]uint32{sysA: 10, sysD: maxLinkMetric}, nil),
+				testLSP(sysD, map[types.SystemID]uint32{sysB: maxLinkMetric}, map[bnet.Prefix]uint32{pfxD: 5}),
+			},
+			adjacencies: []spfAdjacency{
+				testAdjacency(sysB, "eth0", nhB, 10),
+			},
+			expectedTree: map[types.SourceID]uint32{
+				types.NewSourceID(sysA, 0): 0,
+				types.NewSourceID(sysB, 0): 10,
+			},
+			expectedRoutes: map[bnet.Prefix]*spfRoute{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			nodes := spfNodesFromLSPs(test.lsps)
+			spt := computeSPT(sysA, nodes, test.adjacencies)
+
+			tree := make(map[types.SourceID]uint32)
+			for id, v := range spt {
+				tree[id] = v.distance
+			}
+
+			assert.Equal(t, test.expectedTree, tree)
+			assert.Equal(t, test.expectedRoutes, computeRoutes(sysA, nodes, spt))
+		})
+	}
+}
+
+func TestSPFSchedulerNextWait(t *testing.T) {
+	s := newSPFScheduler(func() {})
+	now := time.Unix(1000, 0)
+
+	assert.Equal(t, defaultSPFInitialWait, s.nextWait(now))
+
+	s.lastRun = now
+	assert.Equal(t, defaultSPFSecondaryWait, s.nextWait(now.Add(time.Second)))
+	assert.Equal(t, 2*defaultSPFSecondaryWait, s.nextWait(now.Add(time.Second)))
+
+	for i := 0; i < 10; i++ {
+		s.nextWait(now.Add(time.Second))
+	}
+	assert.Equal(t, defaultSPFMaxWait, s.wait)
+
+	// Holddown expired
+	assert.Equal(t, defaultSPFInitialWait, s.nextWait(now.Add(defaultSPFMaxWait+time.Second)))
+}
+
+func TestRIBInstaller(t *testing.T) {
+	pfx := bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 0), 8)
+	nhA := bnet.IPv4FromOctets(192, 0, 2, 1)
+	nhB := bnet.IPv4FromOctets(192, 0, 2, 2)
+
+	rib := locRIB.New("inet.0")
+	r := newRIBInstaller(rib, 2)
+
+	r.install(map[bnet.Prefix]*spfRoute{
+		pfx: {
+			metric: 10,
+			nextHops: []NextHop{
+				{InterfaceName: "eth0", Address: nhA.Ptr()},
+				{InterfaceName: "eth1", Address: nhB.Ptr()},
+				{InterfaceName: "eth2"},
+			},
+		},
+	})
+
+	rt := rib.Get(pfx.Ptr())
+	if assert.NotNil(t, rt) {
+		assert.Len(t, rt.Paths(), 2)
+		assert.Equal(t, uint(2), rt.ECMPPathCount())
+	}
+
+	r.install(map[bnet.Prefix]*spfRoute{
+		pfx: {
+			metric: 20,
+			nextHops: []NextHop{
+				{InterfaceName: "eth1", Address: nhB.Ptr()},
+			},
+		},
+	})
+
+	rt = rib.Get(pfx.Ptr())
+	if assert.NotNil(t, rt) {
+		assert.Len(t, rt.Paths(), 1)
+		assert.Equal(t, &route.ISISPath{NextHop: nhB.Ptr(), Metric: 20, Level: 2}, rt.Paths()[0].ISISPath)
+	}
+
+	r.install(map[bnet.Prefix]*spfRoute{})
+	assert.Nil(t, rib.Get(pfx.Ptr()))
+}
+
+func TestComputeRoutesUpDown(t *testing.T) {
+	pfx := bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 2, 0), 24)
+	nhB := bnet.IPv4FromOctets(192, 0, 2, 2)
+	nhC := bnet.IPv4FromOctets(192, 0, 2, 3)
+
+	lspB := testLSP(sysB, map[types.SystemID]uint32{sysA: 10}, map[bnet.Prefix]uint32{pfx: 100})
+	lspC := testLSP(sysC, map[types.SystemID]uint32{sysA: 10}, map[bnet.Prefix]uint32{pfx: 5})
+	lspC.TLVs[1].(*packet.ExtendedIPReachabilityTLV).ExtendedIPReachabilities[0].SetUpDown()
+
+	nodes := spfNodesFromLSPs([]*packet.LSPDU{lspB, lspC})
+	spt := computeSPT(sysA, nodes, []spfAdjacency{
+		testAdjacency(sysB, "eth0", nhB, 10),
+		testAdjacency(sysC, "eth1", nhC, 10),
+	})
+
+	assert.Equal(t, map[bnet.Prefix]*spfRoute{
+		pfx: {
+			metric: 110,
+			nextHops: []NextHop{
+				{InterfaceName: "eth0", SystemID: sysB, Address: nhB.Ptr()},
+			},
+		},
+	}, computeRoutes(sysA, nodes, spt))
+}
+
+// withIPv6 adds IPv6 prefixes to an LSP. Prefixes of topologies other than the standard topology are advertised
+// together with the neighbors in that topology.
+func withIPv6(l *packet.LSPDU, mt packet.MultiTopology, neighbors map[types.SystemID]uint32, prefixes map[bnet.Prefix]uint32) *packet.LSPDU {
+	if mt.MTID == packet.MTIDStandard {
+		ipr := packet.NewIPv6ReachabilityTLV()
+		for pfx, metric := range prefixes {
+			ipr.AddIPv6Reachability(packet.NewIPv6Reachability(metric, pfx))
+		}
+
+		l.TLVs = append(l.TLVs, ipr)
+		return l
+	}
+
+	mtis := packet.NewMTIntermediateSystemsTLV(mt.MTID)
+	for n, metric := range neighbors {
+		mtis.AddNeighbor(packet.NewExtendedISReachabilityNeighbor(types.NewSourceID(n, 0), metric))
+	}
+
+	mtr := packet.NewMTIPv6ReachabilityTLV(mt.MTID)
+	for pfx, metric := range prefixes {
+		mtr.AddIPv6Reachability(packet.NewIPv6Reachability(metric, pfx))
+	}
+
+	l.TLVs = append(l.TLVs, packet.NewMultiTopologyTLV([]packet.MultiTopology{{MTID: packet.MTIDStandard}, mt}), mtis, mtr)
+	return l
+}
+
+func TestComputeRoutesIPv6(t *testing.T) {
+	pfxB := bnet.NewPfx(bnet.IPv6FromBlocks(0x2001, 0xdb8, 2, 0, 0, 0, 0, 0), 64)
+	pfxC := bnet.NewPfx(bnet.IPv6FromBlocks(0x2001, 0xdb8, 3, 0, 0, 0, 0, 0), 64)
+	pfxD := bnet.NewPfx(bnet.IPv6FromBlocks(0x2001, 0xdb8, 4, 0, 0, 0, 0, 0), 64)
+	nhB := bnet.IPv6FromBlocks(0xfe80, 0, 0, 0, 0, 0, 0, 2)
+	nhC := bnet.IPv6FromBlocks(0xfe80, 0, 0, 0, 0, 0, 0, 3)
+	mt := packet.MultiTopology{MTID: packet.MTIDIPv6Unicast}
+	st := packet.MultiTopology{MTID: packet.MTIDStandard}
+
+	tests := []struct {
+		name           string
+		mtID           uint16
+		lsps           []*packet.LSPDU
+		adjacencies    []spfAdjacency
+		expectedRoutes map[bnet.Prefix]*spfRoute
+	}{
+		{
+			name: "Single topology",
+			mtID: packet.MTIDStandard,
+			lsps: []*packet.LSPDU{
+				withIPv6(testLSP(sysB, map[types.SystemID]uint32{sysA: 10, sysD: 10}, nil), st, nil, map[bnet.Prefix]uint32{pfxB: 5}),
+				withIPv6(testLSP(sysC, map[types.SystemID]uint32{sysA: 10, sysD: 10}, nil), st, nil, map[bnet.Prefix]uint32{pfxC: 5}),
+				withIPv6(testLSP(sysD, map[types.SystemID]uint32{sysB: 10, sysC: 10}, nil), st, nil, map[bnet.Prefix]uint32{pfxD: 5}),
+			},
+			adjacencies: []spfAdjacency{
+				testAdjacency(sysB, "eth0", nhB, 10),
+				testAdjacency(sysC, "eth1", nhC, 10),
+			},
+			expectedRoutes: map[bnet.Prefix]*spfRoute{
+				pfxB: {
+					metric: 15,
+					nextHops: []NextHop{
+						{InterfaceName: "eth0", SystemID: sysB, Address: nhB.Ptr()},
+					},
+				},
+				pfxC: {
+					metric: 15,
+					nextHops: []NextHop{
+						{InterfaceName: "eth1", SystemID: sysC, Address: nhC.Ptr()},
+					},
+				},
+				pfxD: {
+					metric: 25,
+					nextHops: []NextHop{
+						{InterfaceName: "eth0", SystemID: sysB, Address: nhB.Ptr()},
+						{InterfaceName: "eth1", SystemID: sysC, Address: nhC.Ptr()},
+					},
+				},
+			},
+		},
+		{
+			name: "Multi topology",
+			mtID: packet.MTIDIPv6Unicast,
+			lsps: []*packet.LSPDU{
+				withIPv6(
+					withIPv6(testLSP(sysB, map[types.SystemID]uint32{sysA: 10, sysD: 10}, nil), mt, map[types.SystemID]uint32{sysA: 10, sysD: 10}, map[bnet.Prefix]uint32{pfxB: 5}),
+					st, nil, map[bnet.Prefix]uint32{pfxC: 5}),
+				withIPv6(testLSP(sysC, map[types.SystemID]uint32{sysA: 10, sysD: 10}, nil), st, nil, map[bnet.Prefix]uint32{pfxC: 5}),
+				withIPv6(testLSP(sysD, map[types.SystemID]uint32{sysB: 10, sysC: 10}, nil), mt, map[types.SystemID]uint32{sysB: 10}, map[bnet.Prefix]uint32{pfxD: 5}),
+			},
+			adjacencies: []spfAdjacency{
+				testAdjacency(sysB, "eth0", nhB, 10),
+			},
+			expectedRoutes: map[bnet.Prefix]*spfRoute{
+				pfxB: {
+					metric: 15,
+					nextHops: []NextHop{
+						{InterfaceName: "eth0", SystemID: sysB, Address: nhB.Ptr()},
+					},
+				},
+				pfxD: {
+					metric: 25,
+					nextHops: []NextHop{
+						{InterfaceName: "eth0", SystemID: sysB, Address: nhB.Ptr()},
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			nodes := ipv6Topology(spfNodesFromLSPs(test.lsps), test.mtID)
+			spt := computeSPT(sysA, nodes, test.adjacencies)
+			assert.Equal(t, test.expectedRoutes, computeRoutes(sysA, nodes, spt))
+		})
+	}
+}
+
+func TestIPv6TopologyFlags(t *testing.T) {
+	lsp := withIPv6(testLSP(sysB, nil, nil), packet.MultiTopology{
+		MTID:     packet.MTIDIPv6Unicast,
+		Attached: true,
+		Overload: true,
+	}, nil, nil)
+	id := types.NewSourceID(sysB, 0)
+
+	nodes := spfNodesFromLSPs([]*packet.LSPDU{lsp})
+	assert.False(t, ipv6Topology(nodes, packet.MTIDStandard)[id].attached)
+	assert.False(t, ipv6Topology(nodes, packet.MTIDStandard)[id].overload)
+	assert.True(t, ipv6Topology(nodes, packet.MTIDIPv6Unicast)[id].attached)
+	assert.True(t, ipv6Topology(nodes, packet.MTIDIPv6Unicast)[id].overload)
+}
+
+func TestComputeRoutesOverload(t *testing.T) {
+	pfxB := bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 2, 0), 24)
+	pfxC := bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 3, 0), 24)
+	nhB := bnet.IPv4FromOctets(192, 0, 2, 2)
+
+	lspB := testLSP(sysB, map[types.SystemID]uint32{sysA: 10, sysC: 10}, map[bnet.Prefix]uint32{pfxB: 0})
+	lspB.TypeBlock |= packet.TypeBlockOverload
+	lspC := testLSP(sysC, map[types.SystemID]uint32{sysB: 10}, map[bnet.Prefix]uint32{pfxC: 0})
+
+	nodes := spfNodesFromLSPs([]*packet.LSPDU{lspB, lspC})
+	spt := computeSPT(sysA, nodes, []spfAdjacency{
+		testAdjacency(sysB, "eth0", nhB, 10),
+	})
+
+	// C is only reachable via the overloaded B
+	assert.Equal(t, map[bnet.Prefix]*spfRoute{
+		pfxB: {
+			metric: 10,
+			nextHops: []NextHop{
+				{InterfaceName: "eth0", SystemID: sysB, Address: nhB.Ptr()},
+			},
+		},
+	}, computeRoutes(sysA, nodes, spt))
+}
+
+func TestAddDefaultRoute(t *testing.T) {
+	nhB := bnet.IPv4FromOctets(192, 0, 2, 2)
+	nhC := bnet.IPv4FromOctets(192, 0, 2, 3)
+	defaultRoute := bnet.NewPfx(bnet.IPv4(0), 0)
+
+	attachedLSP := func(l *packet.LSPDU) *packet.LSPDU {
+		l.TypeBlock |= packet.TypeBlockAttached
+		return l
+	}
+
+	tests := []struct {
+		name     string
+		lsps     []*packet.LSPDU
+		expected *spfRoute
+	}{
+		{
+			name: "closest attached router",
+			lsps: []*packet.LSPDU{
+				attachedLSP(testLSP(sysB, map[types.SystemID]uint32{sysA: 10}, nil)),
+				testLSP(sysC, map[types.SystemID]uint32{sysA: 10, sysD: 10}, nil),
+				attachedLSP(testLSP(sysD, map[types.SystemID]uint32{sysC: 10}, nil)),
+			},
+			expected: &spfRoute{
+				metric: 10,
+				nextHops: []NextHop{
+					{InterfaceName: "eth0", SystemID: sysB, Address: nhB.Ptr()},
+				},
+			},
+		},
+		{
+			name: "ECMP",
+			lsps: []*packet.LSPDU{
+				attachedLSP(testLSP(sysB, map[types.SystemID]uint32{sysA: 10}, nil)),
+				attachedLSP(testLSP(sysC, map[types.SystemID]uint32{sysA: 10}, nil)),
+			},
+			expected: &spfRoute{
+				metric: 10,
+				nextHops: []NextHop{
+					{InterfaceName: "eth0", SystemID: sysB, Address: nhB.Ptr()},
+					{InterfaceName: "eth1", SystemID: sysC, Address: nhC.Ptr()},
+				},
+			},
+		},
+		{
+			name: "no attached router",
+			lsps: []*packet.LSPDU{
+				testLSP(sysB, map[types.SystemID]uint32{sysA: 10}, nil),
+				testLSP(sysC, map[types.SystemID]uint32{sysA: 10}, nil),
+			},
+			expected: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			nodes := spfNodesFromLSPs(test.lsps)
+			spt := computeSPT(sysA, nodes, []spfAdjacency{
+				testAdjacency(sysB, "eth0", nhB, 10),
+				testAdjacency(sysC, "eth1", nhC, 10),
+			})
+
+			routes := make(map[bnet.Prefix]*spfRoute)
+			addDefaultRoute(sysA, nodes, spt, routes, defaultRoute)
+			assert.Equal(t, test.expected, routes[defaultRoute])
+		})
+	}
+}
+
+func TestAttachedToOtherAreas(t *testing.T) {
+	areaA := types.AreaID{0x49, 0x00, 0x01}
+	areaB := types.AreaID{0x49, 0x00, 0x02}
+
+	withAreas := func(l *packet.LSPDU, areas ...types.AreaID) *packet.LSPDU {
+		l.TLVs = append(l.TLVs, packet.NewAreaAddressesTLV(areas))
+		return l
+	}
+
+	tests := []struct {
+		name     string
+		lsps     []*packet.LSPDU
+		expected bool
+	}{
+		{
+			name: "same area only",
+			lsps: []*packet.LSPDU{
+				withAreas(testLSP(sysB, map[types.SystemID]uint32{sysA: 10}, nil), areaA),
+			},
+			expected: false,
+		},
+		{
+			name: "other area reachable",
+			lsps: []*packet.LSPDU{
+				withAreas(testLSP(sysB, map[types.SystemID]uint32{sysA: 10, sysC: 10}, nil), areaA),
+				withAreas(testLSP(sysC, map[types.SystemID]uint32{sysB: 10}, nil), areaB),
+			},
+			expected: true,
+		},
+		{
+			name: "other area not reachable",
+			lsps: []*packet.LSPDU{
+				withAreas(testLSP(sysB, map[types.SystemID]uint32{sysA: 10}, nil), areaA),
+				withAreas(testLSP(sysC, map[types.SystemID]uint32{sysD: 10}, nil), areaB),
+			},
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			nodes := spfNodesFromLSPs(test.lsps)
+			spt := computeSPT(sysA, nodes, []spfAdjacency{
+				testAdjacency(sysB, "eth0", bnet.IPv4FromOctets(192, 0, 2, 2), 10),
+			})
+
+			assert.Equal(t, test.expected, attachedToOtherAreas(sysA, []types.AreaID{areaA}, nodes, spt))
+		})
+	}
+}
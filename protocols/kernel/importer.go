@@ -0,0 +1,186 @@
+package kernel
+
+import (
+	"sync"
+
+	"github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/bio-routing/bio-rd/routingtable"
+	"github.com/bio-routing/bio-rd/util/log"
+)
+
+// ImportConfig is the configuration of a kernel route importer
+type ImportConfig struct {
+	// RoutingTable is the ID of the OS routing table routes are learned from. Defaults to MainRoutingTable.
+	RoutingTable uint32
+
+	// Protocols are the IDs of the route protocols (e.g. 4 for static routes) of the routes to learn.
+	// Routes of all protocols are learned if empty. Routes installed by bio-rd are never learned.
+	Protocols []int
+}
+
+// Importer learns routes from a routing table of the OS and adds them as FIB paths to RIBs
+type Importer struct {
+	cfg      ImportConfig
+	rib4     routingtable.RouteTableClient
+	rib6     routingtable.RouteTableClient
+	osReader osRouteReader
+	mu       sync.Mutex
+	paths    map[net.Prefix][]*route.Path
+}
+
+type osRouteReader interface {
+	start() error
+	stop()
+}
+
+// NewImporter creates a new kernel route importer adding IPv4 paths to rib4 and IPv6 paths to rib6
+func NewImporter(cfg ImportConfig, rib4 routingtable.RouteTableClient, rib6 routingtable.RouteTableClient) (*Importer, error) {
+	i := newImporter(cfg, rib4, rib6)
+	err := i.initReader()
+	if err != nil {
+		return nil, err
+	}
+
+	return i, nil
+}
+
+func newImporter(cfg ImportConfig, rib4 routingtable.RouteTableClient, rib6 routingtable.RouteTableClient) *Importer {
+	if cfg.RoutingTable == 0 {
+		cfg.RoutingTable = MainRoutingTable
+	}
+
+	return &Importer{
+		cfg:   cfg,
+		rib4:  rib4,
+		rib6:  rib6,
+		paths: make(map[net.Prefix][]*route.Path),
+	}
+}
+
+// Start starts learning routes
+func (i *Importer) Start() error {
+	return i.osReader.start()
+}
+
+// Stop stops learning routes and removes all learned paths from the RIBs
+func (i *Importer) Stop() {
+	i.osReader.stop()
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	for pfx, paths := range i.paths {
+		pfx := pfx
+		for _, p := range paths {
+			i.ribForPrefix(&pfx).RemovePath(&pfx, p)
+		}
+	}
+
+	i.paths = make(map[net.Prefix][]*route.Path)
+}
+
+// RoutingTable returns the ID of the OS routing table routes are learned from
+func (i *Importer) RoutingTable() uint32 {
+	return i.cfg.RoutingTable
+}
+
+// accepts checks if routes of a protocol in a table are to be learned
+func (i *Importer) accepts(protocol int, table int) bool {
+	if protocol == route.ProtoBio || table != int(i.cfg.RoutingTable) {
+		return false
+	}
+
+	if len(i.cfg.Protocols) == 0 {
+		return true
+	}
+
+	for _, p := range i.cfg.Protocols {
+		if p == protocol {
+			return true
+		}
+	}
+
+	return false
+}
+
+// addRoute adds the paths of an OS route. As the OS identifies a route by its prefix and priority,
+// previously learned paths of the same prefix and priority are replaced.
+func (i *Importer) addRoute(pfx *net.Prefix, paths []*route.Path) {
+	if len(paths) == 0 {
+		return
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	rib := i.ribForPrefix(pfx)
+	remaining := make([]*route.Path, 0, len(i.paths[*pfx])+len(paths))
+	for _, p := range i.paths[*pfx] {
+		if p.FIBPath.Priority == paths[0].FIBPath.Priority && !containsPath(paths, p) {
+			rib.RemovePath(pfx, p)
+			continue
+		}
+
+		remaining = append(remaining, p)
+	}
+
+	for _, p := range paths {
+		if containsPath(remaining, p) {
+			continue
+		}
+
+		err := rib.AddPath(pfx, p)
+		if err != nil {
+			log.Errorf("unable to add kernel route %s: %v", pfx.String(), err)
+			continue
+		}
+
+		remaining = append(remaining, p)
+	}
+
+	i.paths[*pfx] = remaining
+}
+
+// removeRoute removes the paths of the OS route of a prefix with the given priority
+func (i *Importer) removeRoute(pfx *net.Prefix, priority int) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	rib := i.ribForPrefix(pfx)
+	remaining := make([]*route.Path, 0, len(i.paths[*pfx]))
+	for _, p := range i.paths[*pfx] {
+		if p.FIBPath.Priority == priority {
+			rib.RemovePath(pfx, p)
+			continue
+		}
+
+		remaining = append(remaining, p)
+	}
+
+	if len(remaining) == 0 {
+		delete(i.paths, *pfx)
+		return
+	}
+
+	i.paths[*pfx] = remaining
+}
+
+func (i *Importer) ribForPrefix(pfx *net.Prefix) routingtable.RouteTableClient {
+	addr := pfx.Addr()
+	if addr.IsIPv4() {
+		return i.rib4
+	}
+
+	return i.rib6
+}
+
+func containsPath(paths []*route.Path, p *route.Path) bool {
+	for _, x := range paths {
+		if x.Equal(p) {
+			return true
+		}
+	}
+
+	return false
+}
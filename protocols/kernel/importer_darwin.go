@@ -0,0 +1,7 @@
+package kernel
+
+import "errors"
+
+func (i *Importer) initReader() error {
+	return errors.New("Not implemented for Darwin")
+}
@@ -0,0 +1,156 @@
+package kernel
+
+import (
+	"fmt"
+
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/bio-routing/bio-rd/util/log"
+)
+
+func (i *Importer) initReader() error {
+	i.osReader = newLinuxRouteReader(i)
+	return nil
+}
+
+type linuxRouteReader struct {
+	i    *Importer
+	done chan struct{}
+}
+
+func newLinuxRouteReader(i *Importer) *linuxRouteReader {
+	return &linuxRouteReader{
+		i:    i,
+		done: make(chan struct{}),
+	}
+}
+
+func (r *linuxRouteReader) start() error {
+	ch := make(chan netlink.RouteUpdate)
+	err := netlink.RouteSubscribeWithOptions(ch, r.done, netlink.RouteSubscribeOptions{
+		ListExisting: true,
+		ErrorCallback: func(err error) {
+			log.Errorf("kernel route subscription failed: %v", err)
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("unable to subscribe for route updates: %w", err)
+	}
+
+	go r.monitorRoutes(ch)
+	return nil
+}
+
+func (r *linuxRouteReader) stop() {
+	close(r.done)
+}
+
+func (r *linuxRouteReader) monitorRoutes(ch chan netlink.RouteUpdate) {
+	for {
+		select {
+		case <-r.done:
+			return
+		case ru, ok := <-ch:
+			if !ok {
+				return
+			}
+
+			r.processRouteUpdate(&ru)
+		}
+	}
+}
+
+func (r *linuxRouteReader) processRouteUpdate(ru *netlink.RouteUpdate) {
+	// Local and broadcast routes of the addresses of the host are maintained by the kernel
+	if ru.Route.Type != unix.RTN_UNICAST || !r.i.accepts(ru.Protocol, ru.Table) {
+		return
+	}
+
+	pfx := routeDestination(&ru.Route)
+	if pfx == nil {
+		return
+	}
+
+	switch ru.Type {
+	case unix.RTM_NEWROUTE:
+		r.i.addRoute(pfx, routeToPaths(&ru.Route))
+	case unix.RTM_DELROUTE:
+		r.i.removeRoute(pfx, ru.Priority)
+	}
+}
+
+// routeDestination returns the prefix of a route. Netlink omits the destination of default routes.
+func routeDestination(r *netlink.Route) *bnet.Prefix {
+	if r.Dst != nil {
+		return bnet.NewPfxFromIPNet(r.Dst)
+	}
+
+	gw := r.Gw
+	if gw == nil && len(r.MultiPath) > 0 {
+		gw = r.MultiPath[0].Gw
+	}
+
+	if gw == nil {
+		return nil
+	}
+
+	if gw.To4() != nil {
+		return bnet.NewPfx(bnet.IPv4(0), 0).Ptr()
+	}
+
+	return bnet.NewPfx(bnet.IPv6(0, 0), 0).Ptr()
+}
+
+// routeToPaths creates a FIB path for each next hop of a route
+func routeToPaths(r *netlink.Route) []*route.Path {
+	var src *bnet.IP
+	if r.Src != nil {
+		addr, err := bnet.IPFromBytes(r.Src)
+		if err == nil {
+			src = addr.Dedup()
+		}
+	}
+
+	gateways := []netlink.NexthopInfo{
+		{
+			Gw: r.Gw,
+		},
+	}
+	if len(r.MultiPath) > 0 {
+		gateways = make([]netlink.NexthopInfo, len(r.MultiPath))
+		for i := range r.MultiPath {
+			gateways[i] = *r.MultiPath[i]
+		}
+	}
+
+	res := make([]*route.Path, 0, len(gateways))
+	for _, nh := range gateways {
+		var gw *bnet.IP
+		if nh.Gw != nil {
+			addr, err := bnet.IPFromBytes(nh.Gw)
+			if err != nil {
+				continue
+			}
+
+			gw = addr.Dedup()
+		}
+
+		res = append(res, &route.Path{
+			Type: route.FIBPathType,
+			FIBPath: &route.FIBPath{
+				Src:      src,
+				NextHop:  gw,
+				Priority: r.Priority,
+				Protocol: r.Protocol,
+				Type:     r.Type,
+				Table:    r.Table,
+				Kernel:   true,
+			},
+		})
+	}
+
+	return res
+}
@@ -0,0 +1,65 @@
+package kernel
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vishvananda/netlink"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/route"
+)
+
+func TestRouteToPaths(t *testing.T) {
+	tests := []struct {
+		name        string
+		route       *netlink.Route
+		expectedPfx *bnet.Prefix
+		expected    []*route.Path
+	}{
+		{
+			name: "device route",
+			route: &netlink.Route{
+				Dst: &net.IPNet{
+					IP:   net.IP{198, 51, 100, 0},
+					Mask: net.CIDRMask(24, 32),
+				},
+				Protocol: 4,
+				Table:    MainRoutingTable,
+			},
+			expectedPfx: bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 100, 0), 24).Ptr(),
+			expected: []*route.Path{
+				fibPath(nil, 0),
+			},
+		},
+		{
+			name: "default route with multiple next hops",
+			route: &netlink.Route{
+				MultiPath: []*netlink.NexthopInfo{
+					{
+						Gw: net.IP{192, 0, 2, 1},
+					},
+					{
+						Gw: net.IP{192, 0, 2, 2},
+					},
+				},
+				Priority: 100,
+				Protocol: 4,
+				Table:    MainRoutingTable,
+			},
+			expectedPfx: bnet.NewPfx(bnet.IPv4(0), 0).Ptr(),
+			expected: []*route.Path{
+				fibPath(bnet.IPv4FromOctets(192, 0, 2, 1).Ptr(), 100),
+				fibPath(bnet.IPv4FromOctets(192, 0, 2, 2).Ptr(), 100),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expectedPfx, routeDestination(test.route))
+			assert.Equal(t, test.expected, routeToPaths(test.route))
+		})
+	}
+}
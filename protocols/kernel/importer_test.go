@@ -0,0 +1,185 @@
+package kernel
+
+import (
+	"testing"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/bio-routing/bio-rd/routingtable/locRIB"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockRouteReader struct {
+	started bool
+	stopped bool
+}
+
+func (m *mockRouteReader) start() error {
+	m.started = true
+	return nil
+}
+
+func (m *mockRouteReader) stop() {
+	m.stopped = true
+}
+
+func fibPath(nh *bnet.IP, priority int) *route.Path {
+	return &route.Path{
+		Type: route.FIBPathType,
+		FIBPath: &route.FIBPath{
+			NextHop:  nh,
+			Priority: priority,
+			Protocol: 4,
+			Table:    MainRoutingTable,
+			Kernel:   true,
+		},
+	}
+}
+
+func TestImporterAccepts(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      ImportConfig
+		protocol int
+		table    int
+		expected bool
+	}{
+		{
+			name:     "all protocols",
+			protocol: 4,
+			table:    MainRoutingTable,
+			expected: true,
+		},
+		{
+			name:     "own route",
+			protocol: route.ProtoBio,
+			table:    MainRoutingTable,
+			expected: false,
+		},
+		{
+			name:     "other table",
+			protocol: 4,
+			table:    100,
+			expected: false,
+		},
+		{
+			name: "configured table and protocol",
+			cfg: ImportConfig{
+				RoutingTable: 100,
+				Protocols:    []int{3, 4},
+			},
+			protocol: 4,
+			table:    100,
+			expected: true,
+		},
+		{
+			name: "protocol not configured",
+			cfg: ImportConfig{
+				Protocols: []int{3},
+			},
+			protocol: 4,
+			table:    MainRoutingTable,
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			i := newImporter(test.cfg, nil, nil)
+			assert.Equal(t, test.expected, i.accepts(test.protocol, test.table))
+		})
+	}
+}
+
+func TestImporter(t *testing.T) {
+	pfx4 := bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 100, 0), 24).Ptr()
+	pfx6 := bnet.NewPfx(bnet.IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 0), 48).Ptr()
+	nh1 := bnet.IPv4FromOctets(192, 0, 2, 1).Ptr()
+	nh2 := bnet.IPv4FromOctets(192, 0, 2, 2).Ptr()
+
+	tests := []struct {
+		name      string
+		run       func(i *Importer)
+		expected4 map[bnet.Prefix][]*route.Path
+		expected6 map[bnet.Prefix][]*route.Path
+	}{
+		{
+			name: "add routes",
+			run: func(i *Importer) {
+				i.addRoute(pfx4, []*route.Path{fibPath(nh1, 0), fibPath(nh2, 0)})
+				i.addRoute(pfx6, []*route.Path{fibPath(nil, 0)})
+			},
+			expected4: map[bnet.Prefix][]*route.Path{
+				*pfx4: {fibPath(nh2, 0), fibPath(nh1, 0)},
+			},
+			expected6: map[bnet.Prefix][]*route.Path{
+				*pfx6: {fibPath(nil, 0)},
+			},
+		},
+		{
+			name: "replace route",
+			run: func(i *Importer) {
+				i.addRoute(pfx4, []*route.Path{fibPath(nh1, 0), fibPath(nh2, 0)})
+				i.addRoute(pfx4, []*route.Path{fibPath(nh2, 0)})
+			},
+			expected4: map[bnet.Prefix][]*route.Path{
+				*pfx4: {fibPath(nh2, 0)},
+			},
+			expected6: map[bnet.Prefix][]*route.Path{},
+		},
+		{
+			name: "routes with different priorities",
+			run: func(i *Importer) {
+				i.addRoute(pfx4, []*route.Path{fibPath(nh1, 0)})
+				i.addRoute(pfx4, []*route.Path{fibPath(nh2, 100)})
+				i.removeRoute(pfx4, 0)
+			},
+			expected4: map[bnet.Prefix][]*route.Path{
+				*pfx4: {fibPath(nh2, 100)},
+			},
+			expected6: map[bnet.Prefix][]*route.Path{},
+		},
+		{
+			name: "remove route",
+			run: func(i *Importer) {
+				i.addRoute(pfx4, []*route.Path{fibPath(nh1, 0), fibPath(nh2, 0)})
+				i.removeRoute(pfx4, 0)
+			},
+			expected4: map[bnet.Prefix][]*route.Path{},
+			expected6: map[bnet.Prefix][]*route.Path{},
+		},
+		{
+			name: "stop",
+			run: func(i *Importer) {
+				i.addRoute(pfx4, []*route.Path{fibPath(nh1, 0)})
+				i.addRoute(pfx6, []*route.Path{fibPath(nil, 0)})
+				i.Stop()
+			},
+			expected4: map[bnet.Prefix][]*route.Path{},
+			expected6: map[bnet.Prefix][]*route.Path{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rib4 := locRIB.New("inet.0")
+			rib6 := locRIB.New("inet6.0")
+			i := newImporter(ImportConfig{}, rib4, rib6)
+			i.osReader = &mockRouteReader{}
+
+			test.run(i)
+
+			assert.Equal(t, test.expected4, dumpRIB(rib4))
+			assert.Equal(t, test.expected6, dumpRIB(rib6))
+		})
+	}
+}
+
+func dumpRIB(rib *locRIB.LocRIB) map[bnet.Prefix][]*route.Path {
+	res := make(map[bnet.Prefix][]*route.Path)
+	for _, r := range rib.Dump() {
+		res[*r.Prefix()] = r.Paths()
+	}
+
+	return res
+}
@@ -1,24 +1,59 @@
 package kernel
 
 import (
+	"sync"
+
 	"github.com/bio-routing/bio-rd/net"
 	"github.com/bio-routing/bio-rd/route"
 	"github.com/bio-routing/bio-rd/routingtable"
 	"github.com/bio-routing/bio-rd/routingtable/filter"
+	"github.com/bio-routing/bio-rd/util/log"
+)
+
+const (
+	// MainRoutingTable is the ID of the main routing table on Linux
+	MainRoutingTable = 254
 )
 
+// Config is the configuration of a kernel routing table client
+type Config struct {
+	// RoutingTable is the ID of the OS routing table routes are installed into. Defaults to MainRoutingTable.
+	RoutingTable uint32
+
+	// ExportFilterChain is applied to all paths before they are installed
+	ExportFilterChain filter.Chain
+}
+
+// Kernel is a route table client installing routes into the routing table of the OS.
+// All paths propagated for a prefix are installed as one (multipath) route.
 type Kernel struct {
-	osKernel osKernel
+	cfg         Config
+	osKernel    osKernel
+	mu          sync.Mutex
+	filterChain filter.Chain
+	paths       map[net.Prefix][]*route.Path
+	installed   map[net.Prefix]struct{}
 }
 
 type osKernel interface {
-	AddPath(pfx *net.Prefix, path *route.Path) error
-	RemovePath(pfx *net.Prefix, path *route.Path) bool
+	replaceRoute(pfx *net.Prefix, nextHops []*net.IP) error
+	replaceBlackholeRoute(pfx *net.Prefix) error
+	removeRoute(pfx *net.Prefix) error
 	uninit() error
 }
 
+// New creates a new kernel client installing routes into the main routing table
 func New() (*Kernel, error) {
-	k := &Kernel{}
+	return NewWithConfig(Config{})
+}
+
+// NewWithConfig creates a new kernel client
+func NewWithConfig(cfg Config) (*Kernel, error) {
+	if cfg.RoutingTable == 0 {
+		cfg.RoutingTable = MainRoutingTable
+	}
+
+	k := newKernel(cfg)
 	err := k.init()
 	if err != nil {
 		return nil, err
@@ -27,18 +62,139 @@ func New() (*Kernel, error) {
 	return k, nil
 }
 
+func newKernel(cfg Config) *Kernel {
+	return &Kernel{
+		cfg:         cfg,
+		filterChain: cfg.ExportFilterChain,
+		paths:       make(map[net.Prefix][]*route.Path),
+		installed:   make(map[net.Prefix]struct{}),
+	}
+}
+
+// RoutingTable returns the ID of the OS routing table the client installs routes into
+func (k *Kernel) RoutingTable() uint32 {
+	return k.cfg.RoutingTable
+}
+
 func (k *Kernel) AddPathInitialDump(pfx *net.Prefix, path *route.Path) error {
 	return k.AddPath(pfx, path)
 }
 
 func (k *Kernel) AddPath(pfx *net.Prefix, path *route.Path) error {
-	return k.osKernel.AddPath(pfx, path)
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	k.paths[*pfx] = append(removePath(k.paths[*pfx], path), path)
+	return k.sync(pfx)
 }
 
 func (k *Kernel) EndOfRIB() {}
 
 func (k *Kernel) RemovePath(pfx *net.Prefix, path *route.Path) bool {
-	return k.osKernel.RemovePath(pfx, path)
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	paths, found := k.paths[*pfx]
+	if !found {
+		return false
+	}
+
+	k.paths[*pfx] = removePath(paths, path)
+	err := k.sync(pfx)
+	if err != nil {
+		log.Errorf("unable to remove path of %s from kernel: %v", pfx.String(), err)
+		return false
+	}
+
+	return true
+}
+
+func removePath(paths []*route.Path, path *route.Path) []*route.Path {
+	for i := range paths {
+		if paths[i].Equal(path) {
+			return append(paths[:i:i], paths[i+1:]...)
+		}
+	}
+
+	return paths
+}
+
+// sync installs, replaces or removes the OS route of pfx according to the paths currently known for it
+func (k *Kernel) sync(pfx *net.Prefix) error {
+	nextHops, discard := k.nextHops(pfx, k.paths[*pfx])
+	if len(k.paths[*pfx]) == 0 {
+		delete(k.paths, *pfx)
+	}
+
+	_, installed := k.installed[*pfx]
+	if len(nextHops) == 0 && !discard {
+		if !installed {
+			return nil
+		}
+
+		delete(k.installed, *pfx)
+		return k.osKernel.removeRoute(pfx)
+	}
+
+	var err error
+	if len(nextHops) == 0 {
+		err = k.osKernel.replaceBlackholeRoute(pfx)
+	} else {
+		err = k.osKernel.replaceRoute(pfx, nextHops)
+	}
+	if err != nil {
+		return err
+	}
+
+	k.installed[*pfx] = struct{}{}
+	return nil
+}
+
+// nextHops returns the distinct next hops of all paths accepted by the export filter chain
+// and if any of them is a discard path
+func (k *Kernel) nextHops(pfx *net.Prefix, paths []*route.Path) (res []*net.IP, discard bool) {
+	res = make([]*net.IP, 0, len(paths))
+	for _, p := range paths {
+		// Paths learned from the kernel are installed already
+		if p.Type == route.FIBPathType {
+			continue
+		}
+
+		p, reject := k.filterChain.Process(pfx, p)
+		if reject {
+			continue
+		}
+
+		if p.Type == route.StaticPathType && p.StaticPath.Discard {
+			discard = true
+			continue
+		}
+
+		// Traffic to our own aggregates not covered by any more specific route is dropped
+		if p.Type == route.BGPPathType && p.BGPPath.LocalAggregate {
+			discard = true
+			continue
+		}
+
+		nh := p.NextHop()
+		if nh == nil || containsIP(res, nh) {
+			continue
+		}
+
+		res = append(res, nh)
+	}
+
+	return res, discard
+}
+
+func containsIP(addrs []*net.IP, addr *net.IP) bool {
+	for _, x := range addrs {
+		if x.Equal(*addr) {
+			return true
+		}
+	}
+
+	return false
 }
 
 func (k *Kernel) UpdateNewClient(routingtable.RouteTableClient) error {
@@ -55,7 +211,10 @@ func (k *Kernel) Unregister(routingtable.RouteTableClient) {
 }
 
 func (k *Kernel) RouteCount() int64 {
-	return -1
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	return int64(len(k.installed))
 }
 
 func (k *Kernel) ClientCount() uint64 {
@@ -66,21 +225,64 @@ func (k *Kernel) Dump() []*route.Route {
 	return nil
 }
 
+// Dispose removes the routes installed by the client from the OS routing table. Routes installed by
+// others, e.g. other clients sharing the routing table, are kept.
 func (k *Kernel) Dispose() {
-	k.osKernel.uninit()
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	for pfx := range k.installed {
+		pfx := pfx
+		err := k.osKernel.removeRoute(&pfx)
+		if err != nil {
+			log.Errorf("unable to remove route %s from kernel: %v", pfx.String(), err)
+		}
+	}
+
+	k.paths = make(map[net.Prefix][]*route.Path)
+	k.installed = make(map[net.Prefix]struct{})
+
+	err := k.osKernel.uninit()
+	if err != nil {
+		log.Errorf("unable to release kernel: %v", err)
+	}
 }
 
-// ReplaceFilterChain is here to fulfill an interface
+// ReplaceFilterChain replaces the export filter chain and updates all installed routes accordingly
 func (k *Kernel) ReplaceFilterChain(c filter.Chain) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
 
+	k.filterChain = c
+	for pfx := range k.paths {
+		pfx := pfx
+		err := k.sync(&pfx)
+		if err != nil {
+			log.Errorf("unable to update route %s in kernel: %v", pfx.String(), err)
+		}
+	}
 }
 
-// ReplacePath is here to fulfill an interface
-func (k *Kernel) ReplacePath(*net.Prefix, *route.Path, *route.Path) {
+// ReplacePath replaces a path of a prefix
+func (k *Kernel) ReplacePath(pfx *net.Prefix, old *route.Path, new *route.Path) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
 
+	k.paths[*pfx] = append(removePath(k.paths[*pfx], old), new)
+	err := k.sync(pfx)
+	if err != nil {
+		log.Errorf("unable to replace path of %s in kernel: %v", pfx.String(), err)
+	}
 }
 
-// RefreshRoute is here to fultill an interface
-func (k *Kernel) RefreshRoute(*net.Prefix, []*route.Path) {
+// RefreshRoute replaces all paths of a prefix
+func (k *Kernel) RefreshRoute(pfx *net.Prefix, paths []*route.Path) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
 
+	k.paths[*pfx] = append([]*route.Path(nil), paths...)
+	err := k.sync(pfx)
+	if err != nil {
+		log.Errorf("unable to refresh route %s in kernel: %v", pfx.String(), err)
+	}
 }
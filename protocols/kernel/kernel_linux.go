@@ -2,9 +2,10 @@ package kernel
 
 import (
 	"fmt"
+	"sync"
 
-	"github.com/bio-routing/bio-rd/route"
 	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
 
 	bnet "github.com/bio-routing/bio-rd/net"
 )
@@ -13,8 +14,14 @@ const (
 	protoBio = 45
 )
 
+var (
+	// cleanedTables holds the routing tables routes left over by previous runs have been removed from
+	cleanedTables   = make(map[int]struct{})
+	cleanedTablesMu sync.Mutex
+)
+
 func (k *Kernel) init() error {
-	lk, err := newLinuxKernel()
+	lk, err := newLinuxKernel(k.cfg.RoutingTable)
 	if err != nil {
 		return fmt.Errorf("unable to initialize linux kernel: %w", err)
 	}
@@ -28,41 +35,53 @@ func (k *Kernel) init() error {
 }
 
 type linuxKernel struct {
-	h      *netlink.Handle
-	routes map[*bnet.Prefix]struct{}
+	h     *netlink.Handle
+	table int
 }
 
-func newLinuxKernel() (*linuxKernel, error) {
+func newLinuxKernel(table uint32) (*linuxKernel, error) {
 	h, err := netlink.NewHandle()
 	if err != nil {
 		return nil, fmt.Errorf("unable to get Netlink handle: %w", err)
 	}
 
 	return &linuxKernel{
-		h:      h,
-		routes: make(map[*bnet.Prefix]struct{}),
+		h:     h,
+		table: int(table),
 	}, nil
 }
 
 func (lk *linuxKernel) init() error {
+	cleanedTablesMu.Lock()
+	defer cleanedTablesMu.Unlock()
+
+	// Clients created later must not remove the routes of clients sharing the table
+	if _, found := cleanedTables[lk.table]; found {
+		return nil
+	}
+
 	err := lk.cleanup()
 	if err != nil {
 		return fmt.Errorf("cleanup failed: %w", err)
 	}
 
+	cleanedTables[lk.table] = struct{}{}
 	return nil
 }
 
 func (lk *linuxKernel) uninit() error {
-	return lk.cleanup()
+	lk.h.Delete()
+	return nil
 }
 
+// cleanup removes all routes installed by bio-rd from the routing table
 func (lk *linuxKernel) cleanup() error {
 	filter := &netlink.Route{
 		Protocol: protoBio,
+		Table:    lk.table,
 	}
 
-	routes, err := lk.h.RouteListFiltered(0, filter, netlink.RT_FILTER_PROTOCOL)
+	routes, err := lk.h.RouteListFiltered(0, filter, netlink.RT_FILTER_PROTOCOL|netlink.RT_FILTER_TABLE)
 	if err != nil {
 		return fmt.Errorf("unable to get routes: %w", err)
 	}
@@ -77,21 +96,22 @@ func (lk *linuxKernel) cleanup() error {
 	return nil
 }
 
-func (lk *linuxKernel) AddPath(pfx *bnet.Prefix, path *route.Path) error {
+func (lk *linuxKernel) replaceRoute(pfx *bnet.Prefix, nextHops []*bnet.IP) error {
 	r := &netlink.Route{
 		Protocol: protoBio,
+		Table:    lk.table,
 		Dst:      pfx.GetIPNet(),
-		Gw:       path.NextHop().ToNetIP(),
 	}
 
-	if _, found := lk.routes[pfx]; !found {
-		err := lk.h.RouteAdd(r)
-		if err != nil {
-			return fmt.Errorf("unable to add route: %w", err)
+	if len(nextHops) == 1 {
+		r.Gw = nextHops[0].ToNetIP()
+	} else {
+		r.MultiPath = make([]*netlink.NexthopInfo, len(nextHops))
+		for i, nh := range nextHops {
+			r.MultiPath[i] = &netlink.NexthopInfo{
+				Gw: nh.ToNetIP(),
+			}
 		}
-
-		lk.routes[pfx] = struct{}{}
-		return nil
 	}
 
 	err := lk.h.RouteReplace(r)
@@ -102,22 +122,33 @@ func (lk *linuxKernel) AddPath(pfx *bnet.Prefix, path *route.Path) error {
 	return nil
 }
 
-func (lk *linuxKernel) RemovePath(pfx *bnet.Prefix, path *route.Path) bool {
-	if _, found := lk.routes[pfx]; !found {
-		return false
+func (lk *linuxKernel) replaceBlackholeRoute(pfx *bnet.Prefix) error {
+	r := &netlink.Route{
+		Protocol: protoBio,
+		Table:    lk.table,
+		Dst:      pfx.GetIPNet(),
+		Type:     unix.RTN_BLACKHOLE,
+	}
+
+	err := lk.h.RouteReplace(r)
+	if err != nil {
+		return fmt.Errorf("unable to replace route: %w", err)
 	}
 
+	return nil
+}
+
+func (lk *linuxKernel) removeRoute(pfx *bnet.Prefix) error {
 	r := &netlink.Route{
 		Protocol: protoBio,
+		Table:    lk.table,
 		Dst:      pfx.GetIPNet(),
-		Gw:       path.NextHop().ToNetIP(),
 	}
 
 	err := lk.h.RouteDel(r)
 	if err != nil {
-		return false
+		return fmt.Errorf("unable to remove route: %w", err)
 	}
 
-	delete(lk.routes, pfx)
-	return true
+	return nil
 }
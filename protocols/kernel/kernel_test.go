@@ -0,0 +1,226 @@
+package kernel
+
+import (
+	"testing"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/bio-routing/bio-rd/routingtable/filter"
+	"github.com/bio-routing/bio-rd/routingtable/filter/actions"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockOSKernel struct {
+	routes   map[bnet.Prefix][]*bnet.IP
+	released bool
+}
+
+func newMockOSKernel() *mockOSKernel {
+	return &mockOSKernel{
+		routes: make(map[bnet.Prefix][]*bnet.IP),
+	}
+}
+
+func (m *mockOSKernel) replaceRoute(pfx *bnet.Prefix, nextHops []*bnet.IP) error {
+	m.routes[*pfx] = nextHops
+	return nil
+}
+
+func (m *mockOSKernel) replaceBlackholeRoute(pfx *bnet.Prefix) error {
+	m.routes[*pfx] = []*bnet.IP{}
+	return nil
+}
+
+func (m *mockOSKernel) removeRoute(pfx *bnet.Prefix) error {
+	delete(m.routes, *pfx)
+	return nil
+}
+
+func (m *mockOSKernel) uninit() error {
+	m.released = true
+	return nil
+}
+
+func staticPath(nh *bnet.IP) *route.Path {
+	return &route.Path{
+		Type: route.StaticPathType,
+		StaticPath: &route.StaticPath{
+			NextHop: nh,
+		},
+	}
+}
+
+func TestKernel(t *testing.T) {
+	pfx := bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 100, 0), 24).Ptr()
+	nh1 := bnet.IPv4FromOctets(192, 0, 2, 1).Ptr()
+	nh2 := bnet.IPv4FromOctets(192, 0, 2, 2).Ptr()
+	rejectNH2 := filter.NewFilter("reject-nh2", []*filter.Term{
+		filter.NewTerm("nh2", []*filter.TermCondition{
+			filter.NewTermCondition(nil, nil).WithNextHops(nh2),
+		}, []actions.Action{
+			actions.NewRejectAction(),
+		}),
+	})
+
+	tests := []struct {
+		name     string
+		chain    filter.Chain
+		run      func(k *Kernel)
+		expected map[bnet.Prefix][]*bnet.IP
+	}{
+		{
+			name: "add single path",
+			run: func(k *Kernel) {
+				k.AddPath(pfx, staticPath(nh1))
+			},
+			expected: map[bnet.Prefix][]*bnet.IP{
+				*pfx: {nh1},
+			},
+		},
+		{
+			name: "add ECMP paths",
+			run: func(k *Kernel) {
+				k.AddPath(pfx, staticPath(nh1))
+				k.AddPath(pfx, staticPath(nh2))
+				k.AddPath(pfx, staticPath(nh2))
+			},
+			expected: map[bnet.Prefix][]*bnet.IP{
+				*pfx: {nh1, nh2},
+			},
+		},
+		{
+			name: "remove one of two paths",
+			run: func(k *Kernel) {
+				k.AddPath(pfx, staticPath(nh1))
+				k.AddPath(pfx, staticPath(nh2))
+				k.RemovePath(pfx, staticPath(nh1))
+			},
+			expected: map[bnet.Prefix][]*bnet.IP{
+				*pfx: {nh2},
+			},
+		},
+		{
+			name: "remove last path",
+			run: func(k *Kernel) {
+				k.AddPath(pfx, staticPath(nh1))
+				k.RemovePath(pfx, staticPath(nh1))
+			},
+			expected: map[bnet.Prefix][]*bnet.IP{},
+		},
+		{
+			name: "replace path",
+			run: func(k *Kernel) {
+				k.AddPath(pfx, staticPath(nh1))
+				k.ReplacePath(pfx, staticPath(nh1), staticPath(nh2))
+			},
+			expected: map[bnet.Prefix][]*bnet.IP{
+				*pfx: {nh2},
+			},
+		},
+		{
+			name:  "export filter rejects path",
+			chain: filter.Chain{rejectNH2},
+			run: func(k *Kernel) {
+				k.AddPath(pfx, staticPath(nh1))
+				k.AddPath(pfx, staticPath(nh2))
+			},
+			expected: map[bnet.Prefix][]*bnet.IP{
+				*pfx: {nh1},
+			},
+		},
+		{
+			name: "replaced export filter removes route",
+			run: func(k *Kernel) {
+				k.AddPath(pfx, staticPath(nh2))
+				k.ReplaceFilterChain(filter.Chain{rejectNH2})
+			},
+			expected: map[bnet.Prefix][]*bnet.IP{},
+		},
+		{
+			name: "FIB paths are not installed",
+			run: func(k *Kernel) {
+				k.AddPath(pfx, &route.Path{
+					Type: route.FIBPathType,
+					FIBPath: &route.FIBPath{
+						NextHop: nh1,
+					},
+				})
+			},
+			expected: map[bnet.Prefix][]*bnet.IP{},
+		},
+		{
+			name: "discard path installs blackhole route",
+			run: func(k *Kernel) {
+				k.AddPath(pfx, &route.Path{
+					Type: route.StaticPathType,
+					StaticPath: &route.StaticPath{
+						Discard: true,
+					},
+				})
+			},
+			expected: map[bnet.Prefix][]*bnet.IP{
+				*pfx: {},
+			},
+		},
+		{
+			name: "local aggregate installs blackhole route",
+			run: func(k *Kernel) {
+				p := route.NewBGPPath()
+				p.LocalAggregate = true
+				k.AddPath(pfx, &route.Path{
+					Type:    route.BGPPathType,
+					BGPPath: p,
+				})
+			},
+			expected: map[bnet.Prefix][]*bnet.IP{
+				*pfx: {},
+			},
+		},
+		{
+			name: "dispose",
+			run: func(k *Kernel) {
+				k.AddPath(pfx, staticPath(nh1))
+				k.Dispose()
+			},
+			expected: map[bnet.Prefix][]*bnet.IP{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := newMockOSKernel()
+			k := newKernel(Config{
+				RoutingTable:      MainRoutingTable,
+				ExportFilterChain: test.chain,
+			})
+			k.osKernel = m
+
+			test.run(k)
+			assert.Equal(t, test.expected, m.routes)
+			assert.Equal(t, int64(len(test.expected)), k.RouteCount())
+		})
+	}
+}
+
+func TestDisposeKeepsForeignRoutes(t *testing.T) {
+	pfx := bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 100, 0), 24).Ptr()
+	foreign := bnet.NewPfx(bnet.IPv4FromOctets(203, 0, 113, 0), 24)
+	nh := bnet.IPv4FromOctets(192, 0, 2, 1).Ptr()
+
+	m := newMockOSKernel()
+	m.routes[foreign] = []*bnet.IP{nh}
+
+	k := newKernel(Config{
+		RoutingTable: MainRoutingTable,
+	})
+	k.osKernel = m
+
+	k.AddPath(pfx, staticPath(nh))
+	k.Dispose()
+
+	assert.Equal(t, map[bnet.Prefix][]*bnet.IP{
+		foreign: {nh},
+	}, m.routes)
+	assert.True(t, m.released)
+	assert.Equal(t, int64(0), k.RouteCount())
+}
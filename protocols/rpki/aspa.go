@@ -0,0 +1,282 @@
+package rpki
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/bio-routing/bio-rd/protocols/bgp/packet"
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
+	"github.com/bio-routing/bio-rd/route"
+)
+
+// ASPA is a validated Autonomous System Provider Authorization: the set of ASes a customer AS
+// attests to be its providers (draft-ietf-sidrops-aspa-profile). AS 0 as only provider attests
+// that the customer has no providers.
+type ASPA struct {
+	CustomerASN  uint32
+	ProviderASNs []uint32
+}
+
+// String returns a human readable representation of the ASPA
+func (a ASPA) String() string {
+	return fmt.Sprintf("AS%d providers %v", a.CustomerASN, a.ProviderASNs)
+}
+
+func newASPA(customerASN uint32, providerASNs []uint32) ASPA {
+	providers := make([]uint32, len(providerASNs))
+	copy(providers, providerASNs)
+	sort.Slice(providers, func(i, j int) bool {
+		return providers[i] < providers[j]
+	})
+
+	return ASPA{
+		CustomerASN:  customerASN,
+		ProviderASNs: providers,
+	}
+}
+
+func (a ASPA) equal(b ASPA) bool {
+	if a.CustomerASN != b.CustomerASN || len(a.ProviderASNs) != len(b.ProviderASNs) {
+		return false
+	}
+
+	for i := range a.ProviderASNs {
+		if a.ProviderASNs[i] != b.ProviderASNs[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// aspaEntry holds the providers of a customer AS learned from all sources
+type aspaEntry struct {
+	// count is the number of sources providing an ASPA for the customer
+	count     uint
+	providers map[uint32]uint
+}
+
+// aspaTable holds the ASPAs learned from all sources. The provider sets of ASPAs for the same
+// customer learned from multiple sources are combined.
+type aspaTable struct {
+	mu        sync.RWMutex
+	customers map[uint32]*aspaEntry
+}
+
+func newASPATable() *aspaTable {
+	return &aspaTable{
+		customers: make(map[uint32]*aspaEntry),
+	}
+}
+
+// update adds and removes ASPAs and returns true if the table has changed
+func (t *aspaTable) update(added []ASPA, removed []ASPA) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	changed := false
+	for _, a := range removed {
+		if t.remove(a) {
+			changed = true
+		}
+	}
+
+	for _, a := range added {
+		if t.add(a) {
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+func (t *aspaTable) add(a ASPA) bool {
+	e, found := t.customers[a.CustomerASN]
+	if !found {
+		e = &aspaEntry{
+			providers: make(map[uint32]uint),
+		}
+		t.customers[a.CustomerASN] = e
+	}
+
+	changed := e.count == 0
+	e.count++
+	for _, p := range a.ProviderASNs {
+		e.providers[p]++
+		if e.providers[p] == 1 {
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+func (t *aspaTable) remove(a ASPA) bool {
+	e, found := t.customers[a.CustomerASN]
+	if !found {
+		return false
+	}
+
+	changed := false
+	for _, p := range a.ProviderASNs {
+		if e.providers[p] == 0 {
+			continue
+		}
+
+		e.providers[p]--
+		if e.providers[p] == 0 {
+			delete(e.providers, p)
+			changed = true
+		}
+	}
+
+	e.count--
+	if e.count == 0 {
+		delete(t.customers, a.CustomerASN)
+		changed = true
+	}
+
+	return changed
+}
+
+// len returns the number of customer ASes having an ASPA
+func (t *aspaTable) len() uint {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return uint(len(t.customers))
+}
+
+// Outcomes of the check of a single hop of an AS path (draft-ietf-sidrops-aspa-verification Sect. 5)
+const (
+	hopNoAttestation = iota
+	hopProvider
+	hopNotProvider
+)
+
+// hop checks if provider is attested to be a provider of customer. t.mu must be held.
+func (t *aspaTable) hop(customer uint32, provider uint32) int {
+	e, found := t.customers[customer]
+	if !found {
+		return hopNoAttestation
+	}
+
+	if e.providers[provider] > 0 {
+		return hopProvider
+	}
+
+	return hopNotProvider
+}
+
+// verify verifies an AS path received from a neighbor against the ASPAs (draft-ietf-sidrops-aspa-verification Sect. 6).
+// localRole is our role (see packet.PeerRoleRole*) on the session the path was received on.
+// It determines if the path is verified as received from a customer, lateral peer or route server
+// (upstream verification) or as received from a provider (downstream verification).
+func (t *aspaTable) verify(asPath *types.ASPath, neighborASN uint32, localRole uint8) uint8 {
+	if asPath == nil || len(*asPath) == 0 {
+		return route.ASPAVerificationInvalid
+	}
+
+	// asns holds the AS path with prepends collapsed from the origin (asns[0]) to the neighbor
+	asns := make([]uint32, 0)
+	for i := len(*asPath) - 1; i >= 0; i-- {
+		seg := (*asPath)[i]
+		if seg.Type != types.ASSequence {
+			return route.ASPAVerificationInvalid
+		}
+
+		for j := len(seg.ASNs) - 1; j >= 0; j-- {
+			if len(asns) > 0 && asns[len(asns)-1] == seg.ASNs[j] {
+				continue
+			}
+
+			asns = append(asns, seg.ASNs[j])
+		}
+	}
+
+	if len(asns) == 0 {
+		return route.ASPAVerificationInvalid
+	}
+
+	// Route servers are not required to add their AS to the path (RFC7947 Sect. 2.2.2.1)
+	if localRole != packet.PeerRoleRoleRSClient && asns[len(asns)-1] != neighborASN {
+		return route.ASPAVerificationInvalid
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if localRole == packet.PeerRoleRoleCustomer {
+		return t.verifyDownstream(asns)
+	}
+
+	return t.verifyUpstream(asns)
+}
+
+// verifyUpstream implements the upstream path verification (draft-ietf-sidrops-aspa-verification). t.mu must be held.
+func (t *aspaTable) verifyUpstream(asns []uint32) uint8 {
+	unknown := false
+	for i := 1; i < len(asns); i++ {
+		switch t.hop(asns[i-1], asns[i]) {
+		case hopNotProvider:
+			return route.ASPAVerificationInvalid
+		case hopNoAttestation:
+			unknown = true
+		}
+	}
+
+	if unknown {
+		return route.ASPAVerificationUnknown
+	}
+
+	return route.ASPAVerificationValid
+}
+
+// verifyDownstream implements the downstream path verification (draft-ietf-sidrops-aspa-verification). t.mu must be held.
+// Indices are 0-based in contrast to the draft.
+func (t *aspaTable) verifyDownstream(asns []uint32) uint8 {
+	n := len(asns)
+	if n <= 2 {
+		return route.ASPAVerificationValid
+	}
+
+	// The path is invalid if a customer-to-provider hop that is not attested precedes one on the down-ramp
+	uMin := n
+	for u := 1; u < n; u++ {
+		if t.hop(asns[u-1], asns[u]) == hopNotProvider {
+			uMin = u
+			break
+		}
+	}
+
+	vMax := -1
+	for v := n - 2; v >= 0; v-- {
+		if t.hop(asns[v+1], asns[v]) == hopNotProvider {
+			vMax = v
+			break
+		}
+	}
+
+	if uMin <= vMax {
+		return route.ASPAVerificationInvalid
+	}
+
+	// The path is valid if the up-ramp and the down-ramp of attested hops cover it, allowing for a
+	// single lateral peering between them
+	r := 0
+	for r+1 < n && t.hop(asns[r], asns[r+1]) == hopProvider {
+		r++
+	}
+
+	l := n - 1
+	for l > 0 && t.hop(asns[l], asns[l-1]) == hopProvider {
+		l--
+	}
+
+	if r >= l-1 {
+		return route.ASPAVerificationValid
+	}
+
+	return route.ASPAVerificationUnknown
+}
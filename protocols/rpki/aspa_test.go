@@ -0,0 +1,165 @@
+package rpki
+
+import (
+	"testing"
+
+	"github.com/bio-routing/bio-rd/protocols/bgp/packet"
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestASPATableVerify(t *testing.T) {
+	aspas := []ASPA{
+		newASPA(65001, []uint32{65002}),
+		newASPA(65002, []uint32{65003}),
+		newASPA(65004, []uint32{0}),
+		newASPA(65005, []uint32{65001}),
+		newASPA(65006, []uint32{65003}),
+	}
+
+	tests := []struct {
+		name        string
+		asPath      *types.ASPath
+		neighborASN uint32
+		localRole   uint8
+		expected    uint8
+	}{
+		{
+			name:        "upstream single AS",
+			asPath:      types.NewASPath([]uint32{65001}),
+			neighborASN: 65001,
+			localRole:   packet.PeerRoleRoleProvider,
+			expected:    route.ASPAVerificationValid,
+		},
+		{
+			name:        "upstream with prepends",
+			asPath:      types.NewASPath([]uint32{65001, 65005, 65005, 65005}),
+			neighborASN: 65001,
+			localRole:   packet.PeerRoleRoleProvider,
+			expected:    route.ASPAVerificationValid,
+		},
+		{
+			name:        "upstream from lateral peer",
+			asPath:      types.NewASPath([]uint32{65001, 65005}),
+			neighborASN: 65001,
+			localRole:   packet.PeerRoleRolePeer,
+			expected:    route.ASPAVerificationValid,
+		},
+		{
+			name:        "upstream leak",
+			asPath:      types.NewASPath([]uint32{65001, 65004}),
+			neighborASN: 65001,
+			localRole:   packet.PeerRoleRoleProvider,
+			expected:    route.ASPAVerificationInvalid,
+		},
+		{
+			name:        "upstream without attestation",
+			asPath:      types.NewASPath([]uint32{65001, 65007}),
+			neighborASN: 65001,
+			localRole:   packet.PeerRoleRoleProvider,
+			expected:    route.ASPAVerificationUnknown,
+		},
+		{
+			name:        "neighbor AS missing",
+			asPath:      types.NewASPath([]uint32{65005}),
+			neighborASN: 65001,
+			localRole:   packet.PeerRoleRoleProvider,
+			expected:    route.ASPAVerificationInvalid,
+		},
+		{
+			name:        "transparent route server",
+			asPath:      types.NewASPath([]uint32{65001, 65005}),
+			neighborASN: 64500,
+			localRole:   packet.PeerRoleRoleRSClient,
+			expected:    route.ASPAVerificationValid,
+		},
+		{
+			name: "AS set",
+			asPath: &types.ASPath{
+				{
+					Type: types.ASSequence,
+					ASNs: []uint32{65001},
+				},
+				{
+					Type: types.ASSet,
+					ASNs: []uint32{65005},
+				},
+			},
+			neighborASN: 65001,
+			localRole:   packet.PeerRoleRoleProvider,
+			expected:    route.ASPAVerificationInvalid,
+		},
+		{
+			name:        "empty AS path",
+			asPath:      &types.ASPath{},
+			neighborASN: 65001,
+			localRole:   packet.PeerRoleRoleProvider,
+			expected:    route.ASPAVerificationInvalid,
+		},
+		{
+			name:        "downstream two ASes",
+			asPath:      types.NewASPath([]uint32{65001, 65004}),
+			neighborASN: 65001,
+			localRole:   packet.PeerRoleRoleCustomer,
+			expected:    route.ASPAVerificationValid,
+		},
+		{
+			name:        "downstream up-ramp",
+			asPath:      types.NewASPath([]uint32{65003, 65002, 65001}),
+			neighborASN: 65003,
+			localRole:   packet.PeerRoleRoleCustomer,
+			expected:    route.ASPAVerificationValid,
+		},
+		{
+			name:        "downstream lateral peering at apex",
+			asPath:      types.NewASPath([]uint32{65004, 65001, 65005}),
+			neighborASN: 65004,
+			localRole:   packet.PeerRoleRoleCustomer,
+			expected:    route.ASPAVerificationValid,
+		},
+		{
+			name:        "downstream valley",
+			asPath:      types.NewASPath([]uint32{65006, 65005, 65001}),
+			neighborASN: 65006,
+			localRole:   packet.PeerRoleRoleCustomer,
+			expected:    route.ASPAVerificationInvalid,
+		},
+		{
+			name:        "downstream without attestation",
+			asPath:      types.NewASPath([]uint32{65009, 65008, 65007}),
+			neighborASN: 65009,
+			localRole:   packet.PeerRoleRoleCustomer,
+			expected:    route.ASPAVerificationUnknown,
+		},
+	}
+
+	table := newASPATable()
+	table.update(aspas, nil)
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, table.verify(test.asPath, test.neighborASN, test.localRole))
+		})
+	}
+}
+
+func TestASPATableUpdate(t *testing.T) {
+	table := newASPATable()
+	assert.True(t, table.update([]ASPA{newASPA(65001, []uint32{65002})}, nil))
+
+	// Providers of ASPAs learned from multiple sources are combined
+	assert.True(t, table.update([]ASPA{newASPA(65001, []uint32{65003, 65002})}, nil))
+	assert.False(t, table.update([]ASPA{newASPA(65001, []uint32{65002})}, nil))
+	assert.Equal(t, uint(1), table.len())
+	assert.Equal(t, hopProvider, table.hop(65001, 65003))
+
+	assert.True(t, table.update(nil, []ASPA{newASPA(65001, []uint32{65003, 65002})}))
+	assert.Equal(t, hopNotProvider, table.hop(65001, 65003))
+	assert.Equal(t, hopProvider, table.hop(65001, 65002))
+
+	assert.False(t, table.update(nil, []ASPA{newASPA(65001, []uint32{65002})}))
+	assert.True(t, table.update(nil, []ASPA{newASPA(65001, []uint32{65002})}))
+	assert.Equal(t, hopNoAttestation, table.hop(65001, 65002))
+	assert.Equal(t, uint(0), table.len())
+}
@@ -0,0 +1,581 @@
+package rpki
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/rpki/packet"
+	"github.com/bio-routing/bio-rd/util/log"
+)
+
+const (
+	// DefaultRefreshInterval is the default interval to poll a cache for new data (RFC8210 Sect. 6)
+	DefaultRefreshInterval = time.Hour
+
+	// DefaultRetryInterval is the default interval to retry connecting to a cache or polling it after a failure (RFC8210 Sect. 6)
+	DefaultRetryInterval = 10 * time.Minute
+
+	// DefaultExpireInterval is the default interval after which data of an unreachable cache is discarded (RFC8210 Sect. 6)
+	DefaultExpireInterval = 2 * time.Hour
+
+	dialTimeout = 10 * time.Second
+)
+
+// CacheConfig is the configuration of an RPKI cache
+type CacheConfig struct {
+	// Address is the address of the cache in the form host:port
+	Address string
+
+	// RefreshInterval, RetryInterval and ExpireInterval are used until the cache announces its own
+	// intervals. They default to DefaultRefreshInterval, DefaultRetryInterval and DefaultExpireInterval.
+	RefreshInterval time.Duration
+	RetryInterval   time.Duration
+	ExpireInterval  time.Duration
+}
+
+func (c CacheConfig) withDefaults() CacheConfig {
+	if c.RefreshInterval == 0 {
+		c.RefreshInterval = DefaultRefreshInterval
+	}
+
+	if c.RetryInterval == 0 {
+		c.RetryInterval = DefaultRetryInterval
+	}
+
+	if c.ExpireInterval == 0 {
+		c.ExpireInterval = DefaultExpireInterval
+	}
+
+	return c
+}
+
+// cache is an RTR client keeping the VRPs of a cache in sync (RFC8210)
+type cache struct {
+	cfg     CacheConfig
+	updates updater
+	done    chan struct{}
+	stopped sync.WaitGroup
+
+	mu              sync.Mutex
+	conn            net.Conn
+	version         uint8
+	sessionID       uint16
+	serial          uint32
+	hasSession      bool
+	vrps            map[VRP]struct{}
+	aspas           map[uint32]ASPA
+	refreshInterval time.Duration
+	retryInterval   time.Duration
+	expireInterval  time.Duration
+	expireTimer     *time.Timer
+
+	// State of the response currently received
+	inResponse bool
+	reset      bool
+	announced  []VRP
+	withdrawn  []VRP
+	// aspaChanges holds the ASPAs received by customer. Withdrawn ASPAs have no providers.
+	aspaChanges map[uint32]ASPA
+}
+
+type updater interface {
+	updateVRPs(added []VRP, removed []VRP)
+	updateASPAs(added []ASPA, removed []ASPA)
+}
+
+func newCache(cfg CacheConfig, updates updater) *cache {
+	cfg = cfg.withDefaults()
+
+	return &cache{
+		cfg:             cfg,
+		updates:         updates,
+		done:            make(chan struct{}),
+		version:         packet.Version2,
+		vrps:            make(map[VRP]struct{}),
+		aspas:           make(map[uint32]ASPA),
+		refreshInterval: cfg.RefreshInterval,
+		retryInterval:   cfg.RetryInterval,
+		expireInterval:  cfg.ExpireInterval,
+	}
+}
+
+func (c *cache) start() {
+	c.stopped.Add(1)
+	go c.run()
+}
+
+// stop closes the session to the cache and withdraws all VRPs and ASPAs learned from it
+func (c *cache) stop() {
+	close(c.done)
+
+	c.mu.Lock()
+	if c.conn != nil {
+		c.conn.Close()
+	}
+	c.mu.Unlock()
+
+	c.stopped.Wait()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.expire()
+}
+
+func (c *cache) run() {
+	defer c.stopped.Done()
+
+	for {
+		err := c.session()
+		if c.isStopped() {
+			return
+		}
+
+		log.WithError(err).WithFields(log.Fields{
+			"cache": c.cfg.Address,
+		}).Error("RPKI cache session failed")
+		c.scheduleExpiry()
+
+		select {
+		case <-c.done:
+			return
+		case <-time.After(c.getRetryInterval(err)):
+		}
+	}
+}
+
+func (c *cache) isStopped() bool {
+	select {
+	case <-c.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// getRetryInterval returns the time to wait before reconnecting. Sessions failing due to a protocol
+// version downgrade are reestablished immediately.
+func (c *cache) getRetryInterval(err error) time.Duration {
+	if err == errVersionDowngrade {
+		return 0
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.retryInterval
+}
+
+var errVersionDowngrade = fmt.Errorf("cache requires a lower protocol version")
+
+// session connects to the cache and processes PDUs until the connection fails
+func (c *cache) session() error {
+	conn, err := net.DialTimeout("tcp", c.cfg.Address, dialTimeout)
+	if err != nil {
+		return fmt.Errorf("unable to connect: %w", err)
+	}
+	defer conn.Close()
+
+	c.mu.Lock()
+	if c.isStopped() {
+		c.mu.Unlock()
+		return nil
+	}
+
+	c.conn = conn
+	c.inResponse = false
+	if c.expireTimer != nil {
+		c.expireTimer.Stop()
+		c.expireTimer = nil
+	}
+
+	err = c.sendQuery()
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	pdus := make(chan packet.PDU)
+	readErr := make(chan error, 1)
+	sessionDone := make(chan struct{})
+	defer close(sessionDone)
+	go func() {
+		for {
+			pdu, err := packet.Read(conn)
+			if err != nil {
+				readErr <- err
+				return
+			}
+
+			select {
+			case pdus <- pdu:
+			case <-sessionDone:
+				return
+			}
+		}
+	}()
+
+	refresh := time.NewTimer(c.getRefreshInterval())
+	defer refresh.Stop()
+
+	for {
+		select {
+		case <-c.done:
+			return nil
+		case err := <-readErr:
+			return fmt.Errorf("unable to read PDU: %w", err)
+		case <-refresh.C:
+			c.mu.Lock()
+			err := c.sendQuery()
+			c.mu.Unlock()
+			if err != nil {
+				return err
+			}
+
+			refresh.Reset(c.getRefreshInterval())
+		case pdu := <-pdus:
+			c.mu.Lock()
+			endOfData, err := c.processPDU(pdu)
+			c.mu.Unlock()
+			if err != nil {
+				return err
+			}
+
+			if endOfData {
+				refresh.Reset(c.getRefreshInterval())
+			}
+		}
+	}
+}
+
+func (c *cache) getRefreshInterval() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.refreshInterval
+}
+
+// sendQuery asks the cache for the changes since our serial or for all data if we have no session yet.
+// c.mu must be held.
+func (c *cache) sendQuery() error {
+	if c.inResponse {
+		return nil
+	}
+
+	var pdu packet.PDU = &packet.ResetQuery{
+		Version: c.version,
+	}
+	if c.hasSession {
+		pdu = &packet.SerialQuery{
+			Version:      c.version,
+			SessionID:    c.sessionID,
+			SerialNumber: c.serial,
+		}
+	}
+
+	return c.send(pdu)
+}
+
+// send sends a PDU to the cache. c.mu must be held.
+func (c *cache) send(pdu packet.PDU) error {
+	buf := bytes.NewBuffer(nil)
+	pdu.Serialize(buf)
+
+	_, err := c.conn.Write(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("unable to send PDU: %w", err)
+	}
+
+	return nil
+}
+
+// sendError reports a protocol error to the cache and returns it. c.mu must be held.
+func (c *cache) sendError(code uint16, pdu packet.PDU, text string) error {
+	erroneous := bytes.NewBuffer(nil)
+	if pdu != nil {
+		pdu.Serialize(erroneous)
+	}
+
+	c.send(&packet.ErrorReport{
+		Version:      c.version,
+		ErrorCode:    code,
+		ErroneousPDU: erroneous.Bytes(),
+		Text:         text,
+	})
+
+	return fmt.Errorf("protocol error %d: %s", code, text)
+}
+
+// processPDU processes a PDU received from the cache. It returns true if the PDU completed a response.
+// c.mu must be held.
+func (c *cache) processPDU(pdu packet.PDU) (bool, error) {
+	if e, ok := pdu.(*packet.ErrorReport); ok {
+		return false, c.processErrorReport(e)
+	}
+
+	if pdu.ProtocolVersion() != c.version {
+		return false, c.sendError(packet.UnexpectedProtocolVersion, pdu, "unexpected protocol version")
+	}
+
+	switch p := pdu.(type) {
+	case *packet.SerialNotify:
+		return false, c.sendQuery()
+	case *packet.CacheResponse:
+		if c.inResponse {
+			return false, c.sendError(packet.CorruptData, pdu, "unexpected cache response")
+		}
+
+		c.inResponse = true
+		c.reset = !c.hasSession
+		if c.hasSession && p.SessionID != c.sessionID {
+			// The cache has been restarted and its data has to be reloaded entirely (RFC8210 Sect. 5.5)
+			c.hasSession = false
+			c.inResponse = false
+			return false, c.sendQuery()
+		}
+
+		c.sessionID = p.SessionID
+		c.announced = nil
+		c.withdrawn = nil
+		c.aspaChanges = make(map[uint32]ASPA)
+	case *packet.IPPrefix:
+		if !c.inResponse {
+			return false, c.sendError(packet.CorruptData, pdu, "prefix outside of cache response")
+		}
+
+		v, err := prefixPDUToVRP(p)
+		if err != nil {
+			return false, c.sendError(packet.CorruptData, pdu, err.Error())
+		}
+
+		if p.Announcement() {
+			c.announced = append(c.announced, v)
+		} else {
+			c.withdrawn = append(c.withdrawn, v)
+		}
+	case *packet.ASPA:
+		// ASPA PDUs have been introduced in version 2 (draft-ietf-sidrops-8210bis Sect. 5.12)
+		if p.Version < packet.Version2 {
+			return false, c.sendError(packet.UnsupportedPDUType, pdu, "unsupported PDU type")
+		}
+
+		if !c.inResponse {
+			return false, c.sendError(packet.CorruptData, pdu, "ASPA outside of cache response")
+		}
+
+		if p.Announcement() && len(p.ProviderASNs) == 0 {
+			return false, c.sendError(packet.CorruptData, pdu, "ASPA announcement without providers")
+		}
+
+		// An announcement replaces the ASPA of the customer received before
+		c.aspaChanges[p.CustomerASN] = newASPA(p.CustomerASN, p.ProviderASNs)
+	case *packet.RouterKey:
+		// BGPsec router keys are not supported
+	case *packet.EndOfData:
+		if !c.inResponse {
+			return false, c.sendError(packet.CorruptData, pdu, "unexpected end of data")
+		}
+
+		c.processEndOfData(p)
+		return true, nil
+	case *packet.CacheReset:
+		c.hasSession = false
+		return false, c.sendQuery()
+	default:
+		return false, c.sendError(packet.UnsupportedPDUType, pdu, "unsupported PDU type")
+	}
+
+	return false, nil
+}
+
+func (c *cache) processErrorReport(e *packet.ErrorReport) error {
+	// A cache supporting an older protocol version only reports the version it supports (RFC8210 Sect. 7)
+	if e.ErrorCode == packet.UnsupportedProtocolVersion && !c.hasSession && e.Version < c.version {
+		c.version = e.Version
+		return errVersionDowngrade
+	}
+
+	// No data available is a transient condition that is handled by retrying later (RFC8210 Sect. 12)
+	return fmt.Errorf("cache reported error %d: %s", e.ErrorCode, e.Text)
+}
+
+// processEndOfData applies all changes of the completed response. c.mu must be held.
+func (c *cache) processEndOfData(p *packet.EndOfData) {
+	var added, removed []VRP
+	if c.reset {
+		current := make(map[VRP]struct{}, len(c.announced))
+		for _, v := range c.announced {
+			current[v] = struct{}{}
+		}
+
+		added, removed = diffVRPs(c.vrps, current)
+		c.vrps = current
+
+		// Customers not included in a reset response do not have an ASPA anymore
+		for customer := range c.aspas {
+			if _, found := c.aspaChanges[customer]; !found {
+				c.aspaChanges[customer] = ASPA{CustomerASN: customer}
+			}
+		}
+	} else {
+		for _, v := range c.withdrawn {
+			if _, found := c.vrps[v]; found {
+				delete(c.vrps, v)
+				removed = append(removed, v)
+			}
+		}
+
+		for _, v := range c.announced {
+			if _, found := c.vrps[v]; !found {
+				c.vrps[v] = struct{}{}
+				added = append(added, v)
+			}
+		}
+	}
+
+	addedASPAs, removedASPAs := c.applyASPAChanges()
+
+	c.inResponse = false
+	c.reset = false
+	c.announced = nil
+	c.withdrawn = nil
+	c.aspaChanges = nil
+	c.hasSession = true
+	c.serial = p.SerialNumber
+
+	if p.Version >= packet.Version1 {
+		c.refreshInterval = time.Duration(p.RefreshInterval) * time.Second
+		c.retryInterval = time.Duration(p.RetryInterval) * time.Second
+		c.expireInterval = time.Duration(p.ExpireInterval) * time.Second
+	}
+
+	if len(added) > 0 || len(removed) > 0 {
+		c.updates.updateVRPs(added, removed)
+	}
+
+	if len(addedASPAs) > 0 || len(removedASPAs) > 0 {
+		c.updates.updateASPAs(addedASPAs, removedASPAs)
+	}
+}
+
+// applyASPAChanges applies the ASPAs of the completed response and returns the ASPAs to add and to remove. c.mu must be held.
+func (c *cache) applyASPAChanges() (added []ASPA, removed []ASPA) {
+	for customer, a := range c.aspaChanges {
+		old, found := c.aspas[customer]
+		if found && old.equal(a) {
+			continue
+		}
+
+		if found {
+			removed = append(removed, old)
+			delete(c.aspas, customer)
+		}
+
+		if len(a.ProviderASNs) > 0 {
+			added = append(added, a)
+			c.aspas[customer] = a
+		}
+	}
+
+	return added, removed
+}
+
+// scheduleExpiry discards the data of the cache if no session can be established within the expire interval
+func (c *cache) scheduleExpiry() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.conn = nil
+	if c.expireTimer != nil || (len(c.vrps) == 0 && len(c.aspas) == 0) {
+		return
+	}
+
+	c.expireTimer = time.AfterFunc(c.expireInterval, func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		// The session has been reestablished in the meantime
+		if c.expireTimer == nil {
+			return
+		}
+
+		c.expire()
+	})
+}
+
+// expire withdraws all VRPs and ASPAs learned from the cache. c.mu must be held.
+func (c *cache) expire() {
+	removed := make([]VRP, 0, len(c.vrps))
+	for v := range c.vrps {
+		removed = append(removed, v)
+	}
+
+	removedASPAs := make([]ASPA, 0, len(c.aspas))
+	for _, a := range c.aspas {
+		removedASPAs = append(removedASPAs, a)
+	}
+
+	if c.expireTimer != nil {
+		c.expireTimer.Stop()
+		c.expireTimer = nil
+	}
+
+	c.vrps = make(map[VRP]struct{})
+	c.aspas = make(map[uint32]ASPA)
+	c.hasSession = false
+	if len(removed) > 0 {
+		c.updates.updateVRPs(nil, removed)
+	}
+
+	if len(removedASPAs) > 0 {
+		c.updates.updateASPAs(nil, removedASPAs)
+	}
+}
+
+func (c *cache) vrpCount() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.vrps)
+}
+
+func (c *cache) aspaCount() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.aspas)
+}
+
+// diffVRPs returns the VRPs to add and to remove to get from the old to the new set of VRPs
+func diffVRPs(old map[VRP]struct{}, new map[VRP]struct{}) (added []VRP, removed []VRP) {
+	for v := range old {
+		if _, found := new[v]; !found {
+			removed = append(removed, v)
+		}
+	}
+
+	for v := range new {
+		if _, found := old[v]; !found {
+			added = append(added, v)
+		}
+	}
+
+	return added, removed
+}
+
+func prefixPDUToVRP(p *packet.IPPrefix) (VRP, error) {
+	addr, err := bnet.IPFromBytes(p.Prefix)
+	if err != nil {
+		return VRP{}, err
+	}
+
+	pfx := bnet.NewPfx(addr, p.PrefixLen)
+	return VRP{
+		Prefix:    bnet.NewPfx(pfx.BaseAddr(), p.PrefixLen),
+		MaxLength: p.MaxLen,
+		ASN:       p.ASN,
+	}, nil
+}
@@ -0,0 +1,225 @@
+package rpki
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/util/log"
+)
+
+// DefaultFileRefreshInterval is the default interval to check a file for changes
+const DefaultFileRefreshInterval = time.Minute
+
+// FileConfig is the configuration of a JSON file in the format written by rpki-client to load VRPs and ASPAs from
+type FileConfig struct {
+	Path string
+
+	// RefreshInterval is the interval to check the file for changes. Defaults to DefaultFileRefreshInterval.
+	RefreshInterval time.Duration
+}
+
+// fileData is the content of a JSON file written by rpki-client (or any other validator using the same format)
+type fileData struct {
+	ROAs  []fileROA  `json:"roas"`
+	ASPAs []fileASPA `json:"aspas"`
+}
+
+type fileROA struct {
+	ASN       fileASN `json:"asn"`
+	Prefix    string  `json:"prefix"`
+	MaxLength uint8   `json:"maxLength"`
+}
+
+type fileASPA struct {
+	CustomerASN  fileASN   `json:"customer_asid"`
+	ProviderASNs []fileASN `json:"providers"`
+}
+
+// fileASN is an ASN given as number or as string in the form AS123
+type fileASN uint32
+
+// UnmarshalJSON decodes an ASN
+func (a *fileASN) UnmarshalJSON(b []byte) error {
+	s := strings.TrimPrefix(strings.Trim(string(b), `"`), "AS")
+	asn, err := strconv.ParseUint(s, 10, 32)
+	if err != nil {
+		return fmt.Errorf("invalid ASN %s", string(b))
+	}
+
+	*a = fileASN(asn)
+	return nil
+}
+
+// file keeps the VRPs and ASPAs of a JSON file in sync
+type file struct {
+	cfg     FileConfig
+	updates updater
+	done    chan struct{}
+	stopped sync.WaitGroup
+
+	mu      sync.Mutex
+	modTime time.Time
+	vrps    map[VRP]struct{}
+	aspas   map[uint32]ASPA
+}
+
+func newFile(cfg FileConfig, updates updater) *file {
+	if cfg.RefreshInterval == 0 {
+		cfg.RefreshInterval = DefaultFileRefreshInterval
+	}
+
+	return &file{
+		cfg:     cfg,
+		updates: updates,
+		done:    make(chan struct{}),
+		vrps:    make(map[VRP]struct{}),
+		aspas:   make(map[uint32]ASPA),
+	}
+}
+
+// start loads the file and keeps checking it for changes
+func (f *file) start() error {
+	err := f.reload()
+
+	f.stopped.Add(1)
+	go f.run()
+
+	return err
+}
+
+// stop stops checking the file for changes and withdraws all VRPs and ASPAs loaded from it
+func (f *file) stop() {
+	close(f.done)
+	f.stopped.Wait()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.replace(make(map[VRP]struct{}), make(map[uint32]ASPA))
+}
+
+func (f *file) run() {
+	defer f.stopped.Done()
+
+	t := time.NewTicker(f.cfg.RefreshInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-f.done:
+			return
+		case <-t.C:
+		}
+
+		err := f.reload()
+		if err != nil {
+			log.WithError(err).WithFields(log.Fields{
+				"file": f.cfg.Path,
+			}).Error("Unable to load RPKI file")
+		}
+	}
+}
+
+// reload loads the file if it has been modified since it has been loaded last. The data loaded
+// before is kept if the file can not be loaded.
+func (f *file) reload() error {
+	info, err := os.Stat(f.cfg.Path)
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if info.ModTime().Equal(f.modTime) {
+		return nil
+	}
+
+	content, err := os.ReadFile(f.cfg.Path)
+	if err != nil {
+		return err
+	}
+
+	vrps, aspas, err := decodeFile(content)
+	if err != nil {
+		return err
+	}
+
+	f.modTime = info.ModTime()
+	f.replace(vrps, aspas)
+	return nil
+}
+
+// replace replaces the VRPs and ASPAs of the file. f.mu must be held.
+func (f *file) replace(vrps map[VRP]struct{}, aspas map[uint32]ASPA) {
+	added, removed := diffVRPs(f.vrps, vrps)
+	f.vrps = vrps
+	if len(added) > 0 || len(removed) > 0 {
+		f.updates.updateVRPs(added, removed)
+	}
+
+	var addedASPAs, removedASPAs []ASPA
+	for customer, a := range f.aspas {
+		if n, found := aspas[customer]; !found || !n.equal(a) {
+			removedASPAs = append(removedASPAs, a)
+		}
+	}
+
+	for customer, a := range aspas {
+		if o, found := f.aspas[customer]; !found || !o.equal(a) {
+			addedASPAs = append(addedASPAs, a)
+		}
+	}
+
+	f.aspas = aspas
+	if len(addedASPAs) > 0 || len(removedASPAs) > 0 {
+		f.updates.updateASPAs(addedASPAs, removedASPAs)
+	}
+}
+
+func decodeFile(content []byte) (map[VRP]struct{}, map[uint32]ASPA, error) {
+	data := fileData{}
+	err := json.Unmarshal(content, &data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to decode JSON: %w", err)
+	}
+
+	vrps := make(map[VRP]struct{}, len(data.ROAs))
+	for _, r := range data.ROAs {
+		pfx, err := bnet.PrefixFromString(r.Prefix)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid prefix %q: %w", r.Prefix, err)
+		}
+
+		if r.MaxLength < pfx.Len() || (pfx.Addr().IsIPv4() && r.MaxLength > 32) || r.MaxLength > 128 {
+			return nil, nil, fmt.Errorf("invalid max length %d of prefix %q", r.MaxLength, r.Prefix)
+		}
+
+		vrps[VRP{
+			Prefix:    bnet.NewPfx(pfx.BaseAddr(), pfx.Len()),
+			MaxLength: r.MaxLength,
+			ASN:       uint32(r.ASN),
+		}] = struct{}{}
+	}
+
+	aspas := make(map[uint32]ASPA, len(data.ASPAs))
+	for _, a := range data.ASPAs {
+		if len(a.ProviderASNs) == 0 {
+			return nil, nil, fmt.Errorf("ASPA of AS%d without providers", a.CustomerASN)
+		}
+
+		providers := make([]uint32, 0, len(a.ProviderASNs))
+		for _, p := range a.ProviderASNs {
+			providers = append(providers, uint32(p))
+		}
+
+		aspas[uint32(a.CustomerASN)] = newASPA(uint32(a.CustomerASN), providers)
+	}
+
+	return vrps, aspas, nil
+}
@@ -0,0 +1,124 @@
+package rpki
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/packet"
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/stretchr/testify/assert"
+)
+
+const testRPKIClientJSON = `{
+	"metadata": {
+		"buildmachine": "validator.example.com",
+		"roas": 2,
+		"aspas": 1
+	},
+	"roas": [
+		{ "asn": 65001, "prefix": "198.51.100.0/24", "maxLength": 24, "ta": "ripe", "expires": 1700000000 },
+		{ "asn": "AS65002", "prefix": "2001:db8::/32", "maxLength": 48, "ta": "ripe", "expires": 1700000000 }
+	],
+	"aspas": [
+		{ "customer_asid": 65001, "expires": 1700000000, "providers": [ 65003, 65002 ] }
+	]
+}`
+
+func TestDecodeFile(t *testing.T) {
+	vrps, aspas, err := decodeFile([]byte(testRPKIClientJSON))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assert.Equal(t, map[VRP]struct{}{
+		{
+			Prefix:    bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 100, 0), 24),
+			MaxLength: 24,
+			ASN:       65001,
+		}: {},
+		{
+			Prefix:    bnet.NewPfx(bnet.IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 0), 32),
+			MaxLength: 48,
+			ASN:       65002,
+		}: {},
+	}, vrps)
+	assert.Equal(t, map[uint32]ASPA{
+		65001: {
+			CustomerASN:  65001,
+			ProviderASNs: []uint32{65002, 65003},
+		},
+	}, aspas)
+}
+
+func TestDecodeFileErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{
+			name:  "invalid JSON",
+			input: `{"roas": [`,
+		},
+		{
+			name:  "invalid ASN",
+			input: `{"roas": [{"asn": "ASfoo", "prefix": "198.51.100.0/24", "maxLength": 24}]}`,
+		},
+		{
+			name:  "max length shorter than prefix",
+			input: `{"roas": [{"asn": 65001, "prefix": "198.51.100.0/24", "maxLength": 16}]}`,
+		},
+		{
+			name:  "ASPA without providers",
+			input: `{"aspas": [{"customer_asid": 65001, "providers": []}]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, _, err := decodeFile([]byte(test.input))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestRPKIFile(t *testing.T) {
+	pfx := bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 100, 0), 24).Ptr()
+	asPath := types.NewASPath([]uint32{65002, 65001})
+
+	path := filepath.Join(t.TempDir(), "rpki.json")
+	err := os.WriteFile(path, []byte(testRPKIClientJSON), 0600)
+	if err != nil {
+		t.Fatalf("unable to write file: %v", err)
+	}
+
+	cfg := FileConfig{
+		Path:            path,
+		RefreshInterval: time.Millisecond,
+	}
+
+	r := New()
+	defer r.Stop()
+	assert.NoError(t, r.ConfigureFiles([]FileConfig{cfg}))
+	assert.Equal(t, route.RPKIValidationValid, r.Validate(pfx, 65001))
+	assert.Equal(t, route.ASPAVerificationValid, r.VerifyASPath(asPath, 65002, packet.PeerRoleRoleProvider))
+
+	// Modifications of the file are picked up
+	err = os.WriteFile(path, []byte(`{"roas": [], "aspas": [{"customer_asid": 65001, "providers": [65003]}]}`), 0600)
+	if err != nil {
+		t.Fatalf("unable to write file: %v", err)
+	}
+	os.Chtimes(path, time.Now(), time.Now().Add(time.Hour))
+
+	assert.Eventually(t, func() bool {
+		return r.VerifyASPath(asPath, 65002, packet.PeerRoleRoleProvider) == route.ASPAVerificationInvalid
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, route.RPKIValidationNotFound, r.Validate(pfx, 65001))
+
+	assert.NoError(t, r.ConfigureFiles(nil))
+	assert.Equal(t, uint(0), r.ASPACount())
+	assert.Error(t, r.ConfigureFiles([]FileConfig{{Path: filepath.Join(t.TempDir(), "missing.json")}}))
+}
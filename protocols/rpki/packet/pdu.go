@@ -0,0 +1,625 @@
+package packet
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/bio-routing/bio-rd/util/decoder"
+	"github.com/bio-routing/tflow2/convert"
+)
+
+const (
+	// Version0 is the version of the RTR protocol defined in RFC6810
+	Version0 = 0
+
+	// Version1 is the version of the RTR protocol defined in RFC8210
+	Version1 = 1
+
+	// Version2 is the version of the RTR protocol defined in draft-ietf-sidrops-8210bis adding ASPA
+	Version2 = 2
+
+	// HeaderLen is the length of the header every PDU starts with
+	HeaderLen = 8
+
+	// MaxPDULen is the maximum length of a PDU we accept
+	MaxPDULen = 65536
+
+	SerialNotifyType  = 0
+	SerialQueryType   = 1
+	ResetQueryType    = 2
+	CacheResponseType = 3
+	IPv4PrefixType    = 4
+	IPv6PrefixType    = 6
+	EndOfDataType     = 7
+	CacheResetType    = 8
+	RouterKeyType     = 9
+	ErrorReportType   = 10
+	ASPAType          = 11
+
+	CorruptData                = 0
+	InternalError              = 1
+	NoDataAvailable            = 2
+	InvalidRequest             = 3
+	UnsupportedProtocolVersion = 4
+	UnsupportedPDUType         = 5
+	WithdrawalOfUnknownRecord  = 6
+	DuplicateAnnouncement      = 7
+	UnexpectedProtocolVersion  = 8
+
+	// FlagAnnouncement is set in prefix and ASPA PDUs announcing a record and cleared in PDUs withdrawing a record
+	FlagAnnouncement = 1
+
+	serialNotifyLen   = 12
+	serialQueryLen    = 12
+	resetQueryLen     = 8
+	cacheResponseLen  = 8
+	endOfDataV0Len    = 12
+	endOfDataV1Len    = 24
+	cacheResetLen     = 8
+	errorReportMinLen = 16
+	aspaMinLen        = 12
+)
+
+// PDU is an interface that every RTR PDU must fulfill
+type PDU interface {
+	PDUType() uint8
+	ProtocolVersion() uint8
+	Serialize(buf *bytes.Buffer)
+}
+
+// Header is the header every RTR PDU starts with (RFC8210 Sect. 5.1)
+type Header struct {
+	Version uint8
+	Type    uint8
+	// Field is the Session ID, the Error Code or zero depending on the PDU type
+	Field  uint16
+	Length uint32
+}
+
+func (h *Header) serialize(buf *bytes.Buffer) {
+	buf.WriteByte(h.Version)
+	buf.WriteByte(h.Type)
+	buf.Write(convert.Uint16Byte(h.Field))
+	buf.Write(convert.Uint32Byte(h.Length))
+}
+
+func decodeHeader(buf *bytes.Buffer) (*Header, error) {
+	h := &Header{}
+	fields := []interface{}{
+		&h.Version,
+		&h.Type,
+		&h.Field,
+		&h.Length,
+	}
+
+	err := decoder.Decode(buf, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	return h, nil
+}
+
+// SerialNotify informs the router that the cache has new data (RFC8210 Sect. 5.2)
+type SerialNotify struct {
+	Version      uint8
+	SessionID    uint16
+	SerialNumber uint32
+}
+
+// PDUType returns the type of the PDU
+func (p *SerialNotify) PDUType() uint8 {
+	return SerialNotifyType
+}
+
+// ProtocolVersion returns the protocol version of the PDU
+func (p *SerialNotify) ProtocolVersion() uint8 {
+	return p.Version
+}
+
+// Serialize serializes the PDU
+func (p *SerialNotify) Serialize(buf *bytes.Buffer) {
+	h := Header{Version: p.Version, Type: SerialNotifyType, Field: p.SessionID, Length: serialNotifyLen}
+	h.serialize(buf)
+	buf.Write(convert.Uint32Byte(p.SerialNumber))
+}
+
+// SerialQuery requests the changes since the given serial number (RFC8210 Sect. 5.3)
+type SerialQuery struct {
+	Version      uint8
+	SessionID    uint16
+	SerialNumber uint32
+}
+
+// PDUType returns the type of the PDU
+func (p *SerialQuery) PDUType() uint8 {
+	return SerialQueryType
+}
+
+// ProtocolVersion returns the protocol version of the PDU
+func (p *SerialQuery) ProtocolVersion() uint8 {
+	return p.Version
+}
+
+// Serialize serializes the PDU
+func (p *SerialQuery) Serialize(buf *bytes.Buffer) {
+	h := Header{Version: p.Version, Type: SerialQueryType, Field: p.SessionID, Length: serialQueryLen}
+	h.serialize(buf)
+	buf.Write(convert.Uint32Byte(p.SerialNumber))
+}
+
+// ResetQuery requests the full data set of the cache (RFC8210 Sect. 5.4)
+type ResetQuery struct {
+	Version uint8
+}
+
+// PDUType returns the type of the PDU
+func (p *ResetQuery) PDUType() uint8 {
+	return ResetQueryType
+}
+
+// ProtocolVersion returns the protocol version of the PDU
+func (p *ResetQuery) ProtocolVersion() uint8 {
+	return p.Version
+}
+
+// Serialize serializes the PDU
+func (p *ResetQuery) Serialize(buf *bytes.Buffer) {
+	h := Header{Version: p.Version, Type: ResetQueryType, Length: resetQueryLen}
+	h.serialize(buf)
+}
+
+// CacheResponse starts the response of the cache to a query (RFC8210 Sect. 5.5)
+type CacheResponse struct {
+	Version   uint8
+	SessionID uint16
+}
+
+// PDUType returns the type of the PDU
+func (p *CacheResponse) PDUType() uint8 {
+	return CacheResponseType
+}
+
+// ProtocolVersion returns the protocol version of the PDU
+func (p *CacheResponse) ProtocolVersion() uint8 {
+	return p.Version
+}
+
+// Serialize serializes the PDU
+func (p *CacheResponse) Serialize(buf *bytes.Buffer) {
+	h := Header{Version: p.Version, Type: CacheResponseType, Field: p.SessionID, Length: cacheResponseLen}
+	h.serialize(buf)
+}
+
+// IPPrefix announces or withdraws a VRP (RFC8210 Sect. 5.6 and 5.7)
+type IPPrefix struct {
+	Version   uint8
+	Flags     uint8
+	PrefixLen uint8
+	MaxLen    uint8
+	// Prefix holds 4 bytes for IPv4 Prefix PDUs and 16 bytes for IPv6 Prefix PDUs
+	Prefix []byte
+	ASN    uint32
+}
+
+// PDUType returns the type of the PDU
+func (p *IPPrefix) PDUType() uint8 {
+	if p.IPv6() {
+		return IPv6PrefixType
+	}
+
+	return IPv4PrefixType
+}
+
+// ProtocolVersion returns the protocol version of the PDU
+func (p *IPPrefix) ProtocolVersion() uint8 {
+	return p.Version
+}
+
+// IPv6 checks if the PDU is an IPv6 Prefix PDU
+func (p *IPPrefix) IPv6() bool {
+	return len(p.Prefix) == 16
+}
+
+// Announcement checks if the PDU announces a VRP
+func (p *IPPrefix) Announcement() bool {
+	return p.Flags&FlagAnnouncement != 0
+}
+
+// Serialize serializes the PDU
+func (p *IPPrefix) Serialize(buf *bytes.Buffer) {
+	h := Header{Version: p.Version, Type: p.PDUType(), Length: uint32(HeaderLen + 8 + len(p.Prefix))}
+	h.serialize(buf)
+	buf.WriteByte(p.Flags)
+	buf.WriteByte(p.PrefixLen)
+	buf.WriteByte(p.MaxLen)
+	buf.WriteByte(0)
+	buf.Write(p.Prefix)
+	buf.Write(convert.Uint32Byte(p.ASN))
+}
+
+// EndOfData ends the response of the cache to a query (RFC8210 Sect. 5.8).
+// The timing parameters are only present in version 1.
+type EndOfData struct {
+	Version         uint8
+	SessionID       uint16
+	SerialNumber    uint32
+	RefreshInterval uint32
+	RetryInterval   uint32
+	ExpireInterval  uint32
+}
+
+// PDUType returns the type of the PDU
+func (p *EndOfData) PDUType() uint8 {
+	return EndOfDataType
+}
+
+// ProtocolVersion returns the protocol version of the PDU
+func (p *EndOfData) ProtocolVersion() uint8 {
+	return p.Version
+}
+
+// Serialize serializes the PDU
+func (p *EndOfData) Serialize(buf *bytes.Buffer) {
+	h := Header{Version: p.Version, Type: EndOfDataType, Field: p.SessionID, Length: endOfDataV0Len}
+	if p.Version >= Version1 {
+		h.Length = endOfDataV1Len
+	}
+
+	h.serialize(buf)
+	buf.Write(convert.Uint32Byte(p.SerialNumber))
+	if p.Version >= Version1 {
+		buf.Write(convert.Uint32Byte(p.RefreshInterval))
+		buf.Write(convert.Uint32Byte(p.RetryInterval))
+		buf.Write(convert.Uint32Byte(p.ExpireInterval))
+	}
+}
+
+// CacheReset informs the router that the cache can not answer a serial query (RFC8210 Sect. 5.9)
+type CacheReset struct {
+	Version uint8
+}
+
+// PDUType returns the type of the PDU
+func (p *CacheReset) PDUType() uint8 {
+	return CacheResetType
+}
+
+// ProtocolVersion returns the protocol version of the PDU
+func (p *CacheReset) ProtocolVersion() uint8 {
+	return p.Version
+}
+
+// Serialize serializes the PDU
+func (p *CacheReset) Serialize(buf *bytes.Buffer) {
+	h := Header{Version: p.Version, Type: CacheResetType, Length: cacheResetLen}
+	h.serialize(buf)
+}
+
+// RouterKey carries a BGPsec router key (RFC8210 Sect. 5.10). Its payload is not decoded.
+type RouterKey struct {
+	Version uint8
+	Flags   uint16
+	Payload []byte
+}
+
+// PDUType returns the type of the PDU
+func (p *RouterKey) PDUType() uint8 {
+	return RouterKeyType
+}
+
+// ProtocolVersion returns the protocol version of the PDU
+func (p *RouterKey) ProtocolVersion() uint8 {
+	return p.Version
+}
+
+// Serialize serializes the PDU
+func (p *RouterKey) Serialize(buf *bytes.Buffer) {
+	h := Header{Version: p.Version, Type: RouterKeyType, Field: p.Flags, Length: uint32(HeaderLen + len(p.Payload))}
+	h.serialize(buf)
+	buf.Write(p.Payload)
+}
+
+// ASPA announces or withdraws the set of provider ASes of a customer AS (draft-ietf-sidrops-8210bis Sect. 5.12).
+// Withdrawals carry no providers.
+type ASPA struct {
+	Version      uint8
+	Flags        uint8
+	CustomerASN  uint32
+	ProviderASNs []uint32
+}
+
+// PDUType returns the type of the PDU
+func (p *ASPA) PDUType() uint8 {
+	return ASPAType
+}
+
+// ProtocolVersion returns the protocol version of the PDU
+func (p *ASPA) ProtocolVersion() uint8 {
+	return p.Version
+}
+
+// Announcement checks if the PDU announces an ASPA
+func (p *ASPA) Announcement() bool {
+	return p.Flags&FlagAnnouncement != 0
+}
+
+// Serialize serializes the PDU
+func (p *ASPA) Serialize(buf *bytes.Buffer) {
+	h := Header{Version: p.Version, Type: ASPAType, Field: uint16(p.Flags) << 8, Length: uint32(aspaMinLen + 4*len(p.ProviderASNs))}
+	h.serialize(buf)
+	buf.Write(convert.Uint32Byte(p.CustomerASN))
+	for _, asn := range p.ProviderASNs {
+		buf.Write(convert.Uint32Byte(asn))
+	}
+}
+
+// ErrorReport reports an error to the other side (RFC8210 Sect. 5.11)
+type ErrorReport struct {
+	Version      uint8
+	ErrorCode    uint16
+	ErroneousPDU []byte
+	Text         string
+}
+
+// PDUType returns the type of the PDU
+func (p *ErrorReport) PDUType() uint8 {
+	return ErrorReportType
+}
+
+// ProtocolVersion returns the protocol version of the PDU
+func (p *ErrorReport) ProtocolVersion() uint8 {
+	return p.Version
+}
+
+// Serialize serializes the PDU
+func (p *ErrorReport) Serialize(buf *bytes.Buffer) {
+	h := Header{
+		Version: p.Version,
+		Type:    ErrorReportType,
+		Field:   p.ErrorCode,
+		Length:  uint32(errorReportMinLen + len(p.ErroneousPDU) + len(p.Text)),
+	}
+	h.serialize(buf)
+	buf.Write(convert.Uint32Byte(uint32(len(p.ErroneousPDU))))
+	buf.Write(p.ErroneousPDU)
+	buf.Write(convert.Uint32Byte(uint32(len(p.Text))))
+	buf.WriteString(p.Text)
+}
+
+// Read reads and decodes a PDU from r
+func Read(r io.Reader) (PDU, error) {
+	hdr := make([]byte, HeaderLen)
+	_, err := io.ReadFull(r, hdr)
+	if err != nil {
+		return nil, err
+	}
+
+	h, err := decodeHeader(bytes.NewBuffer(hdr))
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode header: %w", err)
+	}
+
+	if h.Length < HeaderLen || h.Length > MaxPDULen {
+		return nil, fmt.Errorf("invalid PDU length: %d", h.Length)
+	}
+
+	msg := make([]byte, h.Length)
+	copy(msg, hdr)
+	_, err = io.ReadFull(r, msg[HeaderLen:])
+	if err != nil {
+		return nil, err
+	}
+
+	return Decode(msg)
+}
+
+// Decode decodes a PDU
+func Decode(msg []byte) (PDU, error) {
+	buf := bytes.NewBuffer(msg)
+	h, err := decodeHeader(buf)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode header: %w", err)
+	}
+
+	if int(h.Length) != len(msg) {
+		return nil, fmt.Errorf("PDU length %d does not match message length %d", h.Length, len(msg))
+	}
+
+	switch h.Type {
+	case SerialNotifyType:
+		return decodeSerialNotify(buf, h)
+	case SerialQueryType:
+		return decodeSerialQuery(buf, h)
+	case ResetQueryType:
+		return &ResetQuery{Version: h.Version}, checkLength(h, resetQueryLen)
+	case CacheResponseType:
+		return &CacheResponse{Version: h.Version, SessionID: h.Field}, checkLength(h, cacheResponseLen)
+	case IPv4PrefixType:
+		return decodeIPPrefix(buf, h, 4)
+	case IPv6PrefixType:
+		return decodeIPPrefix(buf, h, 16)
+	case EndOfDataType:
+		return decodeEndOfData(buf, h)
+	case CacheResetType:
+		return &CacheReset{Version: h.Version}, checkLength(h, cacheResetLen)
+	case RouterKeyType:
+		return &RouterKey{Version: h.Version, Flags: h.Field, Payload: buf.Bytes()}, nil
+	case ErrorReportType:
+		return decodeErrorReport(buf, h)
+	case ASPAType:
+		return decodeASPA(buf, h)
+	}
+
+	return nil, fmt.Errorf("unsupported PDU type: %d", h.Type)
+}
+
+func checkLength(h *Header, expected uint32) error {
+	if h.Length != expected {
+		return fmt.Errorf("invalid length %d of PDU type %d", h.Length, h.Type)
+	}
+
+	return nil
+}
+
+func decodeSerialNotify(buf *bytes.Buffer, h *Header) (*SerialNotify, error) {
+	err := checkLength(h, serialNotifyLen)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &SerialNotify{
+		Version:   h.Version,
+		SessionID: h.Field,
+	}
+
+	err = decoder.Decode(buf, []interface{}{&p.SerialNumber})
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func decodeSerialQuery(buf *bytes.Buffer, h *Header) (*SerialQuery, error) {
+	err := checkLength(h, serialQueryLen)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &SerialQuery{
+		Version:   h.Version,
+		SessionID: h.Field,
+	}
+
+	err = decoder.Decode(buf, []interface{}{&p.SerialNumber})
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func decodeIPPrefix(buf *bytes.Buffer, h *Header, addrLen int) (*IPPrefix, error) {
+	err := checkLength(h, uint32(HeaderLen+8+addrLen))
+	if err != nil {
+		return nil, err
+	}
+
+	p := &IPPrefix{
+		Version: h.Version,
+		Prefix:  make([]byte, addrLen),
+	}
+
+	zero := uint8(0)
+	fields := []interface{}{
+		&p.Flags,
+		&p.PrefixLen,
+		&p.MaxLen,
+		&zero,
+		p.Prefix,
+		&p.ASN,
+	}
+
+	err = decoder.Decode(buf, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.PrefixLen > uint8(addrLen*8) || p.MaxLen > uint8(addrLen*8) || p.PrefixLen > p.MaxLen {
+		return nil, fmt.Errorf("invalid prefix length %d or max length %d", p.PrefixLen, p.MaxLen)
+	}
+
+	return p, nil
+}
+
+func decodeEndOfData(buf *bytes.Buffer, h *Header) (*EndOfData, error) {
+	p := &EndOfData{
+		Version:   h.Version,
+		SessionID: h.Field,
+	}
+
+	fields := []interface{}{
+		&p.SerialNumber,
+	}
+
+	expectedLen := uint32(endOfDataV0Len)
+	if h.Version >= Version1 {
+		expectedLen = endOfDataV1Len
+		fields = append(fields, &p.RefreshInterval, &p.RetryInterval, &p.ExpireInterval)
+	}
+
+	err := checkLength(h, expectedLen)
+	if err != nil {
+		return nil, err
+	}
+
+	err = decoder.Decode(buf, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func decodeASPA(buf *bytes.Buffer, h *Header) (*ASPA, error) {
+	if h.Length < aspaMinLen || (h.Length-aspaMinLen)%4 != 0 {
+		return nil, fmt.Errorf("invalid length %d of ASPA PDU", h.Length)
+	}
+
+	p := &ASPA{
+		Version:      h.Version,
+		Flags:        uint8(h.Field >> 8),
+		ProviderASNs: make([]uint32, (h.Length-aspaMinLen)/4),
+	}
+
+	fields := []interface{}{
+		&p.CustomerASN,
+	}
+	for i := range p.ProviderASNs {
+		fields = append(fields, &p.ProviderASNs[i])
+	}
+
+	err := decoder.Decode(buf, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func decodeErrorReport(buf *bytes.Buffer, h *Header) (*ErrorReport, error) {
+	if h.Length < errorReportMinLen {
+		return nil, fmt.Errorf("invalid length %d of error report", h.Length)
+	}
+
+	p := &ErrorReport{
+		Version:   h.Version,
+		ErrorCode: h.Field,
+	}
+
+	pduLen := uint32(0)
+	err := decoder.Decode(buf, []interface{}{&pduLen})
+	if err != nil {
+		return nil, err
+	}
+
+	if pduLen > uint32(buf.Len()) {
+		return nil, fmt.Errorf("invalid length %d of erroneous PDU", pduLen)
+	}
+	p.ErroneousPDU = buf.Next(int(pduLen))
+
+	textLen := uint32(0)
+	err = decoder.Decode(buf, []interface{}{&textLen})
+	if err != nil {
+		return nil, err
+	}
+
+	if textLen != uint32(buf.Len()) {
+		return nil, fmt.Errorf("invalid length %d of error text", textLen)
+	}
+	p.Text = string(buf.Next(int(textLen)))
+
+	return p, nil
+}
@@ -0,0 +1,206 @@
+package packet
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSerializeDecode(t *testing.T) {
+	tests := []struct {
+		name     string
+		pdu      PDU
+		expected []byte
+	}{
+		{
+			name: "serial notify",
+			pdu: &SerialNotify{
+				Version:      Version1,
+				SessionID:    0x1234,
+				SerialNumber: 42,
+			},
+			expected: []byte{1, 0, 0x12, 0x34, 0, 0, 0, 12, 0, 0, 0, 42},
+		},
+		{
+			name: "serial query",
+			pdu: &SerialQuery{
+				Version:      Version1,
+				SessionID:    0x1234,
+				SerialNumber: 42,
+			},
+			expected: []byte{1, 1, 0x12, 0x34, 0, 0, 0, 12, 0, 0, 0, 42},
+		},
+		{
+			name: "reset query",
+			pdu: &ResetQuery{
+				Version: Version0,
+			},
+			expected: []byte{0, 2, 0, 0, 0, 0, 0, 8},
+		},
+		{
+			name: "cache response",
+			pdu: &CacheResponse{
+				Version:   Version1,
+				SessionID: 0x1234,
+			},
+			expected: []byte{1, 3, 0x12, 0x34, 0, 0, 0, 8},
+		},
+		{
+			name: "IPv4 prefix",
+			pdu: &IPPrefix{
+				Version:   Version1,
+				Flags:     FlagAnnouncement,
+				PrefixLen: 24,
+				MaxLen:    24,
+				Prefix:    []byte{198, 51, 100, 0},
+				ASN:       65536,
+			},
+			expected: []byte{1, 4, 0, 0, 0, 0, 0, 20, 1, 24, 24, 0, 198, 51, 100, 0, 0, 1, 0, 0},
+		},
+		{
+			name: "IPv6 prefix",
+			pdu: &IPPrefix{
+				Version:   Version1,
+				PrefixLen: 32,
+				MaxLen:    48,
+				Prefix:    []byte{0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+				ASN:       64496,
+			},
+			expected: []byte{
+				1, 6, 0, 0, 0, 0, 0, 32, 0, 32, 48, 0,
+				0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+				0, 0, 0xfb, 0xf0,
+			},
+		},
+		{
+			name: "end of data version 0",
+			pdu: &EndOfData{
+				Version:      Version0,
+				SessionID:    0x1234,
+				SerialNumber: 42,
+			},
+			expected: []byte{0, 7, 0x12, 0x34, 0, 0, 0, 12, 0, 0, 0, 42},
+		},
+		{
+			name: "end of data version 1",
+			pdu: &EndOfData{
+				Version:         Version1,
+				SessionID:       0x1234,
+				SerialNumber:    42,
+				RefreshInterval: 3600,
+				RetryInterval:   600,
+				ExpireInterval:  7200,
+			},
+			expected: []byte{
+				1, 7, 0x12, 0x34, 0, 0, 0, 24, 0, 0, 0, 42,
+				0, 0, 0x0e, 0x10, 0, 0, 0x02, 0x58, 0, 0, 0x1c, 0x20,
+			},
+		},
+		{
+			name: "cache reset",
+			pdu: &CacheReset{
+				Version: Version1,
+			},
+			expected: []byte{1, 8, 0, 0, 0, 0, 0, 8},
+		},
+		{
+			name: "ASPA",
+			pdu: &ASPA{
+				Version:      Version2,
+				Flags:        FlagAnnouncement,
+				CustomerASN:  65001,
+				ProviderASNs: []uint32{65002, 65003},
+			},
+			expected: []byte{
+				2, 11, 1, 0, 0, 0, 0, 20,
+				0, 0, 0xfd, 0xe9, 0, 0, 0xfd, 0xea, 0, 0, 0xfd, 0xeb,
+			},
+		},
+		{
+			name: "ASPA withdrawal",
+			pdu: &ASPA{
+				Version:      Version2,
+				CustomerASN:  65001,
+				ProviderASNs: []uint32{},
+			},
+			expected: []byte{2, 11, 0, 0, 0, 0, 0, 12, 0, 0, 0xfd, 0xe9},
+		},
+		{
+			name: "error report",
+			pdu: &ErrorReport{
+				Version:      Version0,
+				ErrorCode:    UnsupportedProtocolVersion,
+				ErroneousPDU: []byte{1, 2, 0, 0, 0, 0, 0, 8},
+				Text:         "v0",
+			},
+			expected: []byte{
+				0, 10, 0, 4, 0, 0, 0, 26,
+				0, 0, 0, 8, 1, 2, 0, 0, 0, 0, 0, 8,
+				0, 0, 0, 2, 'v', '0',
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			buf := bytes.NewBuffer(nil)
+			test.pdu.Serialize(buf)
+			assert.Equal(t, test.expected, buf.Bytes())
+
+			pdu, err := Read(bytes.NewBuffer(test.expected))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			assert.Equal(t, test.pdu, pdu)
+		})
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []byte
+	}{
+		{
+			name:  "incomplete header",
+			input: []byte{1, 2, 0, 0, 0, 0, 0},
+		},
+		{
+			name:  "length mismatch",
+			input: []byte{1, 2, 0, 0, 0, 0, 0, 12, 0, 0, 0, 0},
+		},
+		{
+			name:  "unsupported PDU type",
+			input: []byte{1, 42, 0, 0, 0, 0, 0, 8},
+		},
+		{
+			name:  "prefix length exceeding max length",
+			input: []byte{1, 4, 0, 0, 0, 0, 0, 20, 1, 24, 16, 0, 198, 51, 100, 0, 0, 1, 0, 0},
+		},
+		{
+			name:  "max length exceeding address length",
+			input: []byte{1, 4, 0, 0, 0, 0, 0, 20, 1, 24, 33, 0, 198, 51, 100, 0, 0, 1, 0, 0},
+		},
+		{
+			name:  "version 0 end of data with timing parameters",
+			input: []byte{0, 7, 0, 0, 0, 0, 0, 24, 0, 0, 0, 42, 0, 0, 0, 1, 0, 0, 0, 1, 0, 0, 0, 1},
+		},
+		{
+			name:  "ASPA with truncated provider",
+			input: []byte{2, 11, 1, 0, 0, 0, 0, 14, 0, 0, 0xfd, 0xe9, 0, 0},
+		},
+		{
+			name:  "error text length exceeding PDU",
+			input: []byte{0, 10, 0, 4, 0, 0, 0, 16, 0, 0, 0, 0, 0, 0, 0, 2},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Decode(test.input)
+			assert.Error(t, err)
+		})
+	}
+}
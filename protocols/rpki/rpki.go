@@ -0,0 +1,179 @@
+package rpki
+
+import (
+	"fmt"
+	"sync"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
+	"github.com/bio-routing/bio-rd/routingtable"
+)
+
+// RPKI validates the origin of routes against the VRPs and verifies AS paths against the ASPAs
+// learned from RPKI caches via the RTR protocol (RFC8210, draft-ietf-sidrops-8210bis) or loaded from files.
+// The data of all configured sources is combined.
+type RPKI struct {
+	table     *vrpTable
+	aspas     *aspaTable
+	sourcesMu sync.Mutex
+	caches    map[CacheConfig]*cache
+	files     map[FileConfig]*file
+	clientsMu sync.RWMutex
+	clients   map[routingtable.RPKIValidatorClient]struct{}
+}
+
+// New creates a new RPKI validator without sources
+func New() *RPKI {
+	return &RPKI{
+		table:   newVRPTable(),
+		aspas:   newASPATable(),
+		caches:  make(map[CacheConfig]*cache),
+		files:   make(map[FileConfig]*file),
+		clients: make(map[routingtable.RPKIValidatorClient]struct{}),
+	}
+}
+
+// Configure sets the caches to learn VRPs and ASPAs from. Sessions to caches not configured anymore are closed
+// and their data is withdrawn.
+func (r *RPKI) Configure(caches []CacheConfig) {
+	r.sourcesMu.Lock()
+	defer r.sourcesMu.Unlock()
+
+	configured := make(map[CacheConfig]struct{}, len(caches))
+	for _, cfg := range caches {
+		configured[cfg] = struct{}{}
+	}
+
+	for cfg, c := range r.caches {
+		if _, found := configured[cfg]; found {
+			continue
+		}
+
+		c.stop()
+		delete(r.caches, cfg)
+	}
+
+	for cfg := range configured {
+		if _, found := r.caches[cfg]; found {
+			continue
+		}
+
+		c := newCache(cfg, r)
+		r.caches[cfg] = c
+		c.start()
+	}
+}
+
+// ConfigureFiles sets the files to load VRPs and ASPAs from. Files are reloaded when they are modified.
+// The data of files not configured anymore is withdrawn. An error is returned if a newly configured
+// file can not be loaded, it is retried to load it on every refresh nevertheless.
+func (r *RPKI) ConfigureFiles(files []FileConfig) error {
+	r.sourcesMu.Lock()
+	defer r.sourcesMu.Unlock()
+
+	configured := make(map[FileConfig]struct{}, len(files))
+	for _, cfg := range files {
+		configured[cfg] = struct{}{}
+	}
+
+	for cfg, f := range r.files {
+		if _, found := configured[cfg]; found {
+			continue
+		}
+
+		f.stop()
+		delete(r.files, cfg)
+	}
+
+	var res error
+	for cfg := range configured {
+		if _, found := r.files[cfg]; found {
+			continue
+		}
+
+		f := newFile(cfg, r)
+		r.files[cfg] = f
+		err := f.start()
+		if err != nil {
+			res = fmt.Errorf("unable to load %q: %w", cfg.Path, err)
+		}
+	}
+
+	return res
+}
+
+// Stop closes all cache sessions and withdraws all VRPs and ASPAs
+func (r *RPKI) Stop() {
+	r.Configure(nil)
+	r.ConfigureFiles(nil)
+}
+
+// Validate returns the validation state (see route.RPKIValidation*) of a route
+func (r *RPKI) Validate(pfx *bnet.Prefix, originASN uint32) uint8 {
+	return r.table.validate(pfx, originASN)
+}
+
+// VRPCount returns the number of distinct VRPs learned from all sources
+func (r *RPKI) VRPCount() uint {
+	return r.table.len()
+}
+
+// VerifyASPath returns the ASPA verification state (see route.ASPAVerification*) of an AS path received from a neighbor.
+// localRole is our role (see packet.PeerRoleRole*) on the session to the neighbor.
+func (r *RPKI) VerifyASPath(asPath *types.ASPath, neighborASN uint32, localRole uint8) uint8 {
+	return r.aspas.verify(asPath, neighborASN, localRole)
+}
+
+// ASPACount returns the number of customer ASes having an ASPA learned from any source
+func (r *RPKI) ASPACount() uint {
+	return r.aspas.len()
+}
+
+// Register registers a client to be notified about changed VRPs
+func (r *RPKI) Register(client routingtable.RPKIValidatorClient) {
+	r.clientsMu.Lock()
+	defer r.clientsMu.Unlock()
+
+	r.clients[client] = struct{}{}
+}
+
+// Unregister unregisters a client
+func (r *RPKI) Unregister(client routingtable.RPKIValidatorClient) {
+	r.clientsMu.Lock()
+	defer r.clientsMu.Unlock()
+
+	delete(r.clients, client)
+}
+
+func (r *RPKI) updateVRPs(added []VRP, removed []VRP) {
+	changed := r.table.update(added, removed)
+	if len(changed) == 0 {
+		return
+	}
+
+	for _, c := range r.getClients() {
+		c.RPKIUpdate(changed)
+	}
+}
+
+func (r *RPKI) updateASPAs(added []ASPA, removed []ASPA) {
+	if !r.aspas.update(added, removed) {
+		return
+	}
+
+	for _, c := range r.getClients() {
+		c.ASPAUpdate()
+	}
+}
+
+func (r *RPKI) getClients() []routingtable.RPKIValidatorClient {
+	r.clientsMu.RLock()
+	defer r.clientsMu.RUnlock()
+
+	clients := make([]routingtable.RPKIValidatorClient, 0, len(r.clients))
+	for c := range r.clients {
+		clients = append(clients, c)
+	}
+
+	return clients
+}
@@ -0,0 +1,461 @@
+package rpki
+
+import (
+	"bytes"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	bgppacket "github.com/bio-routing/bio-rd/protocols/bgp/packet"
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
+	"github.com/bio-routing/bio-rd/protocols/rpki/packet"
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeCache is a stand-in for an RPKI cache serving VRPs via RTR
+type fakeCache struct {
+	ln         net.Listener
+	maxVersion uint8
+	sessionID  uint16
+
+	mu      sync.Mutex
+	serial  uint32
+	changes []fakeChange
+	conns   []net.Conn
+	queries []packet.PDU
+}
+
+type fakeChange struct {
+	serial uint32
+	pdu    packet.PDU
+}
+
+func newFakeCache(t *testing.T, maxVersion uint8) *fakeCache {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unable to listen: %v", err)
+	}
+
+	f := &fakeCache{
+		ln:         ln,
+		maxVersion: maxVersion,
+		sessionID:  42,
+	}
+
+	go f.serve()
+	return f
+}
+
+func (f *fakeCache) addr() string {
+	return f.ln.Addr().String()
+}
+
+func (f *fakeCache) close() {
+	f.ln.Close()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, c := range f.conns {
+		c.Close()
+	}
+}
+
+func (f *fakeCache) serve() {
+	for {
+		conn, err := f.ln.Accept()
+		if err != nil {
+			return
+		}
+
+		f.mu.Lock()
+		f.conns = append(f.conns, conn)
+		f.mu.Unlock()
+
+		go f.handle(conn)
+	}
+}
+
+func (f *fakeCache) handle(conn net.Conn) {
+	defer conn.Close()
+
+	for {
+		pdu, err := packet.Read(conn)
+		if err != nil {
+			return
+		}
+
+		f.mu.Lock()
+		f.queries = append(f.queries, pdu)
+		f.mu.Unlock()
+
+		if pdu.ProtocolVersion() > f.maxVersion {
+			f.send(conn, &packet.ErrorReport{
+				Version:   f.maxVersion,
+				ErrorCode: packet.UnsupportedProtocolVersion,
+			})
+			return
+		}
+
+		switch q := pdu.(type) {
+		case *packet.ResetQuery:
+			f.respond(conn, q.Version, 0, true)
+		case *packet.SerialQuery:
+			f.respond(conn, q.Version, q.SerialNumber, false)
+		}
+	}
+}
+
+func (f *fakeCache) respond(conn net.Conn, version uint8, serial uint32, reset bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	pdus := []packet.PDU{
+		&packet.CacheResponse{
+			Version:   version,
+			SessionID: f.sessionID,
+		},
+	}
+
+	for _, pfx := range f.prefixes(serial, reset) {
+		pfx.Version = version
+		pdus = append(pdus, pfx)
+	}
+
+	if version >= packet.Version2 {
+		for _, a := range f.aspas(serial, reset) {
+			a.Version = version
+			pdus = append(pdus, a)
+		}
+	}
+
+	pdus = append(pdus, &packet.EndOfData{
+		Version:         version,
+		SessionID:       f.sessionID,
+		SerialNumber:    f.serial,
+		RefreshInterval: 3600,
+		RetryInterval:   1,
+		ExpireInterval:  7200,
+	})
+
+	for _, pdu := range pdus {
+		f.send(conn, pdu)
+	}
+}
+
+// prefixes returns the prefix PDUs of all changes after serial, or all currently announced prefixes on reset
+func (f *fakeCache) prefixes(serial uint32, reset bool) []*packet.IPPrefix {
+	res := make([]*packet.IPPrefix, 0)
+	for _, c := range f.changes {
+		if !reset && c.serial <= serial {
+			continue
+		}
+
+		p, ok := c.pdu.(*packet.IPPrefix)
+		if !ok {
+			continue
+		}
+
+		pdu := *p
+		if !reset {
+			res = append(res, &pdu)
+			continue
+		}
+
+		if pdu.Announcement() {
+			res = append(res, &pdu)
+			continue
+		}
+
+		for i := range res {
+			if res[i].PrefixLen == pdu.PrefixLen && res[i].MaxLen == pdu.MaxLen && res[i].ASN == pdu.ASN &&
+				bytes.Equal(res[i].Prefix, pdu.Prefix) {
+				res = append(res[:i], res[i+1:]...)
+				break
+			}
+		}
+	}
+
+	return res
+}
+
+// aspas returns the ASPA PDUs of all changes after serial, or all currently announced ASPAs on reset
+func (f *fakeCache) aspas(serial uint32, reset bool) []*packet.ASPA {
+	res := make([]*packet.ASPA, 0)
+	for _, c := range f.changes {
+		if !reset && c.serial <= serial {
+			continue
+		}
+
+		p, ok := c.pdu.(*packet.ASPA)
+		if !ok {
+			continue
+		}
+
+		pdu := *p
+		if reset {
+			for i := range res {
+				if res[i].CustomerASN == pdu.CustomerASN {
+					res = append(res[:i], res[i+1:]...)
+					break
+				}
+			}
+
+			if !pdu.Announcement() {
+				continue
+			}
+		}
+
+		res = append(res, &pdu)
+	}
+
+	return res
+}
+
+func (f *fakeCache) send(conn net.Conn, pdu packet.PDU) {
+	buf := bytes.NewBuffer(nil)
+	pdu.Serialize(buf)
+	conn.Write(buf.Bytes())
+}
+
+// update adds a new serial with the given changes and notifies all connected routers
+func (f *fakeCache) update(pdus ...packet.PDU) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.serial++
+	for _, pdu := range pdus {
+		f.changes = append(f.changes, fakeChange{
+			serial: f.serial,
+			pdu:    pdu,
+		})
+	}
+
+	for _, c := range f.conns {
+		f.send(c, &packet.SerialNotify{
+			Version:      f.maxVersion,
+			SessionID:    f.sessionID,
+			SerialNumber: f.serial,
+		})
+	}
+}
+
+func (f *fakeCache) queryTypes() []uint8 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	res := make([]uint8, 0, len(f.queries))
+	for _, q := range f.queries {
+		res = append(res, q.PDUType())
+	}
+
+	return res
+}
+
+func ipv4PrefixPDU(announce bool, a, b, c, d byte, pfxLen, maxLen uint8, asn uint32) *packet.IPPrefix {
+	pdu := &packet.IPPrefix{
+		PrefixLen: pfxLen,
+		MaxLen:    maxLen,
+		Prefix:    []byte{a, b, c, d},
+		ASN:       asn,
+	}
+
+	if announce {
+		pdu.Flags = packet.FlagAnnouncement
+	}
+
+	return pdu
+}
+
+func aspaPDU(announce bool, customerASN uint32, providerASNs ...uint32) *packet.ASPA {
+	pdu := &packet.ASPA{
+		CustomerASN:  customerASN,
+		ProviderASNs: providerASNs,
+	}
+
+	if announce {
+		pdu.Flags = packet.FlagAnnouncement
+	}
+
+	return pdu
+}
+
+type mockClient struct {
+	mu          sync.Mutex
+	updated     []*bnet.Prefix
+	aspaUpdates int
+}
+
+func (m *mockClient) RPKIUpdate(pfxs []*bnet.Prefix) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.updated = append(m.updated, pfxs...)
+}
+
+func (m *mockClient) ASPAUpdate() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.aspaUpdates++
+}
+
+func (m *mockClient) getASPAUpdates() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.aspaUpdates
+}
+
+func (m *mockClient) updates() []*bnet.Prefix {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.updated
+}
+
+func TestRPKI(t *testing.T) {
+	pfx1 := bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 100, 0), 24).Ptr()
+	pfx2 := bnet.NewPfx(bnet.IPv4FromOctets(203, 0, 113, 0), 24).Ptr()
+
+	f := newFakeCache(t, packet.Version2)
+	defer f.close()
+	f.update(ipv4PrefixPDU(true, 198, 51, 100, 0, 24, 24, 65001))
+
+	client := &mockClient{}
+	r := New()
+	r.Register(client)
+	r.Configure([]CacheConfig{
+		{
+			Address: f.addr(),
+		},
+	})
+
+	assert.Eventually(t, func() bool {
+		return r.Validate(pfx1, 65001) == route.RPKIValidationValid
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, route.RPKIValidationInvalid, r.Validate(pfx1, 65002))
+	assert.Equal(t, route.RPKIValidationNotFound, r.Validate(pfx2, 65002))
+	assert.Eventually(t, func() bool {
+		return len(client.updates()) == 1
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, []*bnet.Prefix{pfx1}, client.updates())
+
+	// Incremental update after a serial notify
+	f.update(
+		ipv4PrefixPDU(false, 198, 51, 100, 0, 24, 24, 65001),
+		ipv4PrefixPDU(true, 203, 0, 113, 0, 24, 24, 65002),
+	)
+
+	assert.Eventually(t, func() bool {
+		return r.Validate(pfx2, 65002) == route.RPKIValidationValid
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, route.RPKIValidationNotFound, r.Validate(pfx1, 65001))
+	assert.Equal(t, uint(1), r.VRPCount())
+	assert.Eventually(t, func() bool {
+		return len(client.updates()) == 3
+	}, time.Second, time.Millisecond)
+	assert.ElementsMatch(t, []*bnet.Prefix{pfx1, pfx1, pfx2}, client.updates())
+	assert.Equal(t, []uint8{packet.ResetQueryType, packet.SerialQueryType}, f.queryTypes())
+
+	r.Stop()
+	assert.Equal(t, uint(0), r.VRPCount())
+	assert.Equal(t, route.RPKIValidationNotFound, r.Validate(pfx2, 65002))
+}
+
+func TestRPKIVersionDowngrade(t *testing.T) {
+	pfx := bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 100, 0), 24).Ptr()
+
+	f := newFakeCache(t, packet.Version0)
+	defer f.close()
+	f.update(ipv4PrefixPDU(true, 198, 51, 100, 0, 24, 24, 65001))
+
+	r := New()
+	defer r.Stop()
+	r.Configure([]CacheConfig{
+		{
+			Address: f.addr(),
+		},
+	})
+
+	assert.Eventually(t, func() bool {
+		return r.Validate(pfx, 65001) == route.RPKIValidationValid
+	}, time.Second, time.Millisecond)
+}
+
+func TestRPKIASPA(t *testing.T) {
+	asPath := types.NewASPath([]uint32{65002, 65001})
+
+	f := newFakeCache(t, packet.Version2)
+	defer f.close()
+	f.update(aspaPDU(true, 65001, 65002, 65003))
+
+	client := &mockClient{}
+	r := New()
+	r.Register(client)
+	r.Configure([]CacheConfig{
+		{
+			Address: f.addr(),
+		},
+	})
+
+	assert.Eventually(t, func() bool {
+		return r.VerifyASPath(asPath, 65002, bgppacket.PeerRoleRoleProvider) == route.ASPAVerificationValid
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, uint(1), r.ASPACount())
+
+	// An announcement replaces the ASPA of the customer
+	f.update(aspaPDU(true, 65001, 65003))
+	assert.Eventually(t, func() bool {
+		return r.VerifyASPath(asPath, 65002, bgppacket.PeerRoleRoleProvider) == route.ASPAVerificationInvalid
+	}, time.Second, time.Millisecond)
+
+	f.update(aspaPDU(false, 65001))
+	assert.Eventually(t, func() bool {
+		return r.VerifyASPath(asPath, 65002, bgppacket.PeerRoleRoleProvider) == route.ASPAVerificationUnknown
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, uint(0), r.ASPACount())
+	assert.Eventually(t, func() bool {
+		return client.getASPAUpdates() == 3
+	}, time.Second, time.Millisecond)
+
+	r.Stop()
+}
+
+func TestRPKIMultipleCaches(t *testing.T) {
+	pfx := bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 100, 0), 24).Ptr()
+
+	f1 := newFakeCache(t, packet.Version1)
+	defer f1.close()
+	f1.update(ipv4PrefixPDU(true, 198, 51, 100, 0, 24, 24, 65001))
+
+	f2 := newFakeCache(t, packet.Version1)
+	defer f2.close()
+	f2.update(ipv4PrefixPDU(true, 198, 51, 100, 0, 24, 24, 65001))
+
+	cfg1 := CacheConfig{
+		Address: f1.addr(),
+	}
+	cfg2 := CacheConfig{
+		Address: f2.addr(),
+	}
+
+	r := New()
+	defer r.Stop()
+	r.Configure([]CacheConfig{cfg1, cfg2})
+
+	assert.Eventually(t, func() bool {
+		return r.caches[cfg1].vrpCount() == 1 && r.caches[cfg2].vrpCount() == 1
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, route.RPKIValidationValid, r.Validate(pfx, 65001))
+
+	// The VRP is kept as long as one of the caches provides it
+	r.Configure([]CacheConfig{cfg2})
+	assert.Equal(t, route.RPKIValidationValid, r.Validate(pfx, 65001))
+
+	r.Configure(nil)
+	assert.Equal(t, route.RPKIValidationNotFound, r.Validate(pfx, 65001))
+}
@@ -0,0 +1,162 @@
+package rpki
+
+import (
+	"fmt"
+	"sync"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/route"
+)
+
+// VRP is a validated ROA payload (RFC6811)
+type VRP struct {
+	Prefix    bnet.Prefix
+	MaxLength uint8
+	ASN       uint32
+}
+
+// String returns a human readable representation of the VRP
+func (v VRP) String() string {
+	return fmt.Sprintf("%s-%d AS%d", v.Prefix.String(), v.MaxLength, v.ASN)
+}
+
+type vrpValue struct {
+	maxLength uint8
+	asn       uint32
+}
+
+// vrpTable holds the VRPs learned from all caches. VRPs learned from multiple caches are counted.
+type vrpTable struct {
+	mu   sync.RWMutex
+	vrps map[bnet.Prefix]map[vrpValue]uint
+	// pfxLens counts the VRP prefixes by prefix length per address family to limit lookups to lengths in use
+	pfxLens4 [33]uint
+	pfxLens6 [129]uint
+	count    uint
+}
+
+func newVRPTable() *vrpTable {
+	return &vrpTable{
+		vrps: make(map[bnet.Prefix]map[vrpValue]uint),
+	}
+}
+
+// update adds and removes VRPs and returns the prefixes of VRPs that have been added or removed entirely
+func (t *vrpTable) update(added []VRP, removed []VRP) []*bnet.Prefix {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	changed := make(map[bnet.Prefix]struct{})
+	for _, v := range added {
+		if t.add(v) {
+			changed[v.Prefix] = struct{}{}
+		}
+	}
+
+	for _, v := range removed {
+		if t.remove(v) {
+			changed[v.Prefix] = struct{}{}
+		}
+	}
+
+	res := make([]*bnet.Prefix, 0, len(changed))
+	for pfx := range changed {
+		res = append(res, pfx.Dedup())
+	}
+
+	return res
+}
+
+func (t *vrpTable) add(v VRP) bool {
+	values, found := t.vrps[v.Prefix]
+	if !found {
+		values = make(map[vrpValue]uint)
+		t.vrps[v.Prefix] = values
+		t.pfxLens(&v.Prefix)[v.Prefix.Len()]++
+	}
+
+	val := vrpValue{maxLength: v.MaxLength, asn: v.ASN}
+	values[val]++
+	if values[val] > 1 {
+		return false
+	}
+
+	t.count++
+	return true
+}
+
+func (t *vrpTable) remove(v VRP) bool {
+	values, found := t.vrps[v.Prefix]
+	if !found {
+		return false
+	}
+
+	val := vrpValue{maxLength: v.MaxLength, asn: v.ASN}
+	if values[val] == 0 {
+		return false
+	}
+
+	values[val]--
+	if values[val] > 0 {
+		return false
+	}
+
+	delete(values, val)
+	t.count--
+	if len(values) == 0 {
+		delete(t.vrps, v.Prefix)
+		t.pfxLens(&v.Prefix)[v.Prefix.Len()]--
+	}
+
+	return true
+}
+
+func (t *vrpTable) pfxLens(pfx *bnet.Prefix) []uint {
+	if pfx.Addr().IsIPv4() {
+		return t.pfxLens4[:]
+	}
+
+	return t.pfxLens6[:]
+}
+
+// validate determines the validation state of a route (RFC6811 Sect. 2)
+func (t *vrpTable) validate(pfx *bnet.Prefix, originASN uint32) uint8 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	covered := false
+	pfxLens := t.pfxLens(pfx)
+	for l := uint8(0); l <= pfx.Len(); l++ {
+		if pfxLens[l] == 0 {
+			continue
+		}
+
+		candidate := bnet.NewPfx(pfx.Addr(), l)
+		values, found := t.vrps[bnet.NewPfx(candidate.BaseAddr(), l)]
+		if !found {
+			continue
+		}
+
+		covered = true
+		for v := range values {
+			// VRPs for AS 0 never match a route (RFC6483 Sect. 4)
+			if originASN != 0 && v.asn == originASN && pfx.Len() <= v.maxLength {
+				return route.RPKIValidationValid
+			}
+		}
+	}
+
+	if covered {
+		return route.RPKIValidationInvalid
+	}
+
+	return route.RPKIValidationNotFound
+}
+
+// len returns the number of distinct VRPs
+func (t *vrpTable) len() uint {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.count
+}
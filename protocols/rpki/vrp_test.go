@@ -0,0 +1,134 @@
+package rpki
+
+import (
+	"testing"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVRPTableValidate(t *testing.T) {
+	vrps := []VRP{
+		{
+			Prefix:    bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 100, 0), 22),
+			MaxLength: 24,
+			ASN:       65001,
+		},
+		{
+			Prefix:    bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 100, 0), 24),
+			MaxLength: 24,
+			ASN:       65002,
+		},
+		{
+			Prefix:    bnet.NewPfx(bnet.IPv4FromOctets(203, 0, 113, 0), 24),
+			MaxLength: 24,
+			ASN:       0,
+		},
+		{
+			Prefix:    bnet.NewPfx(bnet.IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 0), 32),
+			MaxLength: 48,
+			ASN:       65003,
+		},
+	}
+
+	tests := []struct {
+		name     string
+		pfx      *bnet.Prefix
+		origin   uint32
+		expected uint8
+	}{
+		{
+			name:     "valid",
+			pfx:      bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 101, 0), 24).Ptr(),
+			origin:   65001,
+			expected: route.RPKIValidationValid,
+		},
+		{
+			name:     "valid by second VRP",
+			pfx:      bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 100, 0), 24).Ptr(),
+			origin:   65002,
+			expected: route.RPKIValidationValid,
+		},
+		{
+			name:     "wrong origin",
+			pfx:      bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 101, 0), 24).Ptr(),
+			origin:   65002,
+			expected: route.RPKIValidationInvalid,
+		},
+		{
+			name:     "exceeding max length",
+			pfx:      bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 101, 0), 25).Ptr(),
+			origin:   65001,
+			expected: route.RPKIValidationInvalid,
+		},
+		{
+			name:     "unknown origin",
+			pfx:      bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 100, 0), 22).Ptr(),
+			origin:   0,
+			expected: route.RPKIValidationInvalid,
+		},
+		{
+			name:     "AS 0 VRP",
+			pfx:      bnet.NewPfx(bnet.IPv4FromOctets(203, 0, 113, 0), 24).Ptr(),
+			origin:   65001,
+			expected: route.RPKIValidationInvalid,
+		},
+		{
+			name:     "less specific than VRP",
+			pfx:      bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 0, 0), 16).Ptr(),
+			origin:   65001,
+			expected: route.RPKIValidationNotFound,
+		},
+		{
+			name:     "not covered",
+			pfx:      bnet.NewPfx(bnet.IPv4FromOctets(192, 0, 2, 0), 24).Ptr(),
+			origin:   65001,
+			expected: route.RPKIValidationNotFound,
+		},
+		{
+			name:     "valid IPv6",
+			pfx:      bnet.NewPfx(bnet.IPv6FromBlocks(0x2001, 0xdb8, 0x100, 0, 0, 0, 0, 0), 48).Ptr(),
+			origin:   65003,
+			expected: route.RPKIValidationValid,
+		},
+		{
+			name:     "invalid IPv6",
+			pfx:      bnet.NewPfx(bnet.IPv6FromBlocks(0x2001, 0xdb8, 0x100, 0, 0, 0, 0, 0), 56).Ptr(),
+			origin:   65003,
+			expected: route.RPKIValidationInvalid,
+		},
+	}
+
+	table := newVRPTable()
+	table.update(vrps, nil)
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, table.validate(test.pfx, test.origin))
+		})
+	}
+}
+
+func TestVRPTableUpdate(t *testing.T) {
+	pfx := bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 100, 0), 24)
+	v := VRP{
+		Prefix:    pfx,
+		MaxLength: 24,
+		ASN:       65001,
+	}
+
+	table := newVRPTable()
+	assert.Equal(t, []*bnet.Prefix{pfx.Ptr()}, table.update([]VRP{v}, nil))
+
+	// VRPs learned from a second cache do not change the table
+	assert.Equal(t, []*bnet.Prefix{}, table.update([]VRP{v}, nil))
+	assert.Equal(t, uint(1), table.len())
+
+	assert.Equal(t, []*bnet.Prefix{}, table.update(nil, []VRP{v}))
+	assert.Equal(t, route.RPKIValidationValid, table.validate(pfx.Ptr(), 65001))
+
+	assert.Equal(t, []*bnet.Prefix{pfx.Ptr()}, table.update(nil, []VRP{v}))
+	assert.Equal(t, route.RPKIValidationNotFound, table.validate(pfx.Ptr(), 65001))
+	assert.Equal(t, uint(0), table.len())
+}
@@ -0,0 +1,196 @@
+package static
+
+import (
+	"sync"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/bio-routing/bio-rd/routingtable"
+	"github.com/bio-routing/bio-rd/routingtable/locRIB"
+	"github.com/bio-routing/bio-rd/routingtable/nexthop"
+	"github.com/bio-routing/bio-rd/util/log"
+)
+
+// Route is the configuration of a static route
+type Route struct {
+	Prefix  *bnet.Prefix
+	NextHop *bnet.IP
+	Discard bool
+
+	// Resolve makes the next hop to be resolved recursively via the RIB. The route is
+	// withdrawn as long as its next hop is unresolvable.
+	Resolve bool
+}
+
+func (r *Route) equal(x *Route) bool {
+	if r.Discard != x.Discard || r.Resolve != x.Resolve || !r.Prefix.Equal(x.Prefix) {
+		return false
+	}
+
+	if r.NextHop == nil || x.NextHop == nil {
+		return r.NextHop == x.NextHop
+	}
+
+	return r.NextHop.Equal(*x.NextHop)
+}
+
+// Manager adds the static routes of a VRF to its RIBs
+type Manager struct {
+	rib4     *locRIB.LocRIB
+	rib6     *locRIB.LocRIB
+	mu       sync.Mutex
+	routes   map[bnet.Prefix]*staticRoute
+	observer *routingtable.RIBObserver
+}
+
+type staticRoute struct {
+	cfg  Route
+	path *route.Path // Path added to the RIB. Nil if the next hop is unresolved.
+}
+
+// New creates a new static route manager adding IPv4 routes to rib4 and IPv6 routes to rib6
+func New(rib4 *locRIB.LocRIB, rib6 *locRIB.LocRIB) *Manager {
+	m := &Manager{
+		rib4:   rib4,
+		rib6:   rib6,
+		routes: make(map[bnet.Prefix]*staticRoute),
+	}
+	m.observer = routingtable.NewRIBObserver(func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		m.update()
+	})
+
+	return m
+}
+
+// Start starts tracking changes of the RIBs to keep the next hops of routes to be resolved up to date
+func (m *Manager) Start() {
+	m.observer.Start()
+
+	m.rib4.Register(m.observer)
+	m.rib6.Register(m.observer)
+}
+
+// Stop stops the manager and removes all static routes from the RIBs
+func (m *Manager) Stop() {
+	m.rib4.Unregister(m.observer)
+	m.rib6.Unregister(m.observer)
+	m.observer.Stop()
+
+	m.Configure(nil)
+}
+
+// Configure replaces the configured static routes. Routes not configured anymore are withdrawn.
+func (m *Manager) Configure(routes []Route) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	configured := make(map[bnet.Prefix]Route, len(routes))
+	for _, r := range routes {
+		configured[*r.Prefix] = r
+	}
+
+	for pfx, r := range m.routes {
+		if cfg, found := configured[pfx]; found && cfg.equal(&r.cfg) {
+			continue
+		}
+
+		m.withdraw(r)
+		delete(m.routes, pfx)
+	}
+
+	for pfx, cfg := range configured {
+		if _, found := m.routes[pfx]; found {
+			continue
+		}
+
+		m.routes[pfx] = &staticRoute{
+			cfg: cfg,
+		}
+	}
+
+	m.update()
+}
+
+// update adds, replaces or withdraws the paths of all routes according to their next hop resolution
+func (m *Manager) update() {
+	for _, r := range m.routes {
+		p := m.computePath(&r.cfg)
+		if p == nil && r.path == nil {
+			continue
+		}
+
+		if p != nil && r.path != nil && p.Equal(r.path) {
+			continue
+		}
+
+		m.withdraw(r)
+		if p == nil {
+			continue
+		}
+
+		err := m.ribForPrefix(r.cfg.Prefix).AddPath(r.cfg.Prefix, p)
+		if err != nil {
+			log.Errorf("unable to add static route %s: %v", r.cfg.Prefix.String(), err)
+			continue
+		}
+
+		r.path = p
+	}
+}
+
+func (m *Manager) withdraw(r *staticRoute) {
+	if r.path == nil {
+		return
+	}
+
+	m.ribForPrefix(r.cfg.Prefix).RemovePath(r.cfg.Prefix, r.path)
+	r.path = nil
+}
+
+// computePath returns the path of a route or nil if its next hop is unresolvable
+func (m *Manager) computePath(r *Route) *route.Path {
+	if r.Discard {
+		return &route.Path{
+			Type: route.StaticPathType,
+			StaticPath: &route.StaticPath{
+				Discard: true,
+			},
+		}
+	}
+
+	nh := r.NextHop
+	if r.Resolve {
+		// Routes for the prefix itself are never used to resolve its next hop
+		nh, _ = nexthop.Resolve(m.ribForAddr(r.NextHop), r.NextHop, nexthop.ResolveOptions{
+			Recursive:    true,
+			DefaultRoute: true,
+			Exclude:      r.Prefix,
+		})
+		if nh == nil {
+			return nil
+		}
+	}
+
+	return &route.Path{
+		Type: route.StaticPathType,
+		StaticPath: &route.StaticPath{
+			NextHop: nh,
+		},
+	}
+}
+
+func (m *Manager) ribForPrefix(pfx *bnet.Prefix) *locRIB.LocRIB {
+	addr := pfx.Addr()
+	return m.ribForAddr(&addr)
+}
+
+func (m *Manager) ribForAddr(addr *bnet.IP) *locRIB.LocRIB {
+	if addr.IsIPv4() {
+		return m.rib4
+	}
+
+	return m.rib6
+}
@@ -0,0 +1,249 @@
+package static
+
+import (
+	"testing"
+	"time"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/bio-routing/bio-rd/routingtable/locRIB"
+	"github.com/stretchr/testify/assert"
+)
+
+func staticPath(nh *bnet.IP) *route.Path {
+	return &route.Path{
+		Type: route.StaticPathType,
+		StaticPath: &route.StaticPath{
+			NextHop: nh,
+		},
+	}
+}
+
+func connectedPath(addr *bnet.IP) *route.Path {
+	return &route.Path{
+		Type: route.ConnectedPathType,
+		ConnectedPath: &route.ConnectedPath{
+			Interface: "eth0",
+			Address:   addr,
+		},
+	}
+}
+
+func bgpPath(nh *bnet.IP) *route.Path {
+	p := route.NewBGPPath()
+	p.BGPPathA.NextHop = nh
+
+	return &route.Path{
+		Type:    route.BGPPathType,
+		BGPPath: p,
+	}
+}
+
+func TestManager(t *testing.T) {
+	aggregate := bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 100, 0), 24).Ptr()
+	connected := bnet.NewPfx(bnet.IPv4FromOctets(192, 0, 2, 0), 24).Ptr()
+	loopback := bnet.NewPfx(bnet.IPv4FromOctets(203, 0, 113, 1), 32).Ptr()
+	pfx6 := bnet.NewPfx(bnet.IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 0), 32).Ptr()
+	gw := bnet.IPv4FromOctets(192, 0, 2, 1).Ptr()
+	gw6 := bnet.IPv6FromBlocks(0x2001, 0xdb8, 0xffff, 0, 0, 0, 0, 1).Ptr()
+	localAddr := bnet.IPv4FromOctets(192, 0, 2, 100).Ptr()
+
+	tests := []struct {
+		name      string
+		rib4      map[bnet.Prefix]*route.Path
+		routes    []Route
+		run       func(m *Manager)
+		expected4 map[bnet.Prefix][]*route.Path
+		expected6 map[bnet.Prefix][]*route.Path
+	}{
+		{
+			name: "discard and next hop routes",
+			routes: []Route{
+				{
+					Prefix:  aggregate,
+					Discard: true,
+				},
+				{
+					Prefix:  pfx6,
+					NextHop: gw6,
+				},
+			},
+			expected4: map[bnet.Prefix][]*route.Path{
+				*aggregate: {
+					{
+						Type: route.StaticPathType,
+						StaticPath: &route.StaticPath{
+							Discard: true,
+						},
+					},
+				},
+			},
+			expected6: map[bnet.Prefix][]*route.Path{
+				*pfx6: {staticPath(gw6)},
+			},
+		},
+		{
+			name: "unresolvable next hop",
+			routes: []Route{
+				{
+					Prefix:  aggregate,
+					NextHop: gw,
+					Resolve: true,
+				},
+			},
+			expected4: map[bnet.Prefix][]*route.Path{},
+			expected6: map[bnet.Prefix][]*route.Path{},
+		},
+		{
+			name: "next hop resolved via connected route",
+			rib4: map[bnet.Prefix]*route.Path{
+				*connected: connectedPath(localAddr),
+			},
+			routes: []Route{
+				{
+					Prefix:  aggregate,
+					NextHop: gw,
+					Resolve: true,
+				},
+			},
+			expected4: map[bnet.Prefix][]*route.Path{
+				*connected: {connectedPath(localAddr)},
+				*aggregate: {staticPath(gw)},
+			},
+			expected6: map[bnet.Prefix][]*route.Path{},
+		},
+		{
+			name: "next hop resolved recursively via BGP route",
+			rib4: map[bnet.Prefix]*route.Path{
+				*connected: connectedPath(localAddr),
+				*loopback:  bgpPath(gw),
+			},
+			routes: []Route{
+				{
+					Prefix:  aggregate,
+					NextHop: loopback.Addr().Ptr(),
+					Resolve: true,
+				},
+			},
+			expected4: map[bnet.Prefix][]*route.Path{
+				*connected: {connectedPath(localAddr)},
+				*loopback:  {bgpPath(gw)},
+				*aggregate: {staticPath(gw)},
+			},
+			expected6: map[bnet.Prefix][]*route.Path{},
+		},
+		{
+			name: "next hop is not resolved via the route itself",
+			rib4: map[bnet.Prefix]*route.Path{
+				*aggregate: bgpPath(gw),
+			},
+			routes: []Route{
+				{
+					Prefix:  aggregate,
+					NextHop: bnet.IPv4FromOctets(198, 51, 100, 1).Ptr(),
+					Resolve: true,
+				},
+			},
+			expected4: map[bnet.Prefix][]*route.Path{
+				*aggregate: {bgpPath(gw)},
+			},
+			expected6: map[bnet.Prefix][]*route.Path{},
+		},
+		{
+			name: "route is withdrawn when its next hop becomes unresolvable",
+			rib4: map[bnet.Prefix]*route.Path{
+				*connected: connectedPath(localAddr),
+			},
+			routes: []Route{
+				{
+					Prefix:  aggregate,
+					NextHop: gw,
+					Resolve: true,
+				},
+			},
+			run: func(m *Manager) {
+				m.rib4.RemovePath(connected, connectedPath(localAddr))
+				m.update()
+			},
+			expected4: map[bnet.Prefix][]*route.Path{},
+			expected6: map[bnet.Prefix][]*route.Path{},
+		},
+		{
+			name: "reconfiguration",
+			routes: []Route{
+				{
+					Prefix:  aggregate,
+					Discard: true,
+				},
+				{
+					Prefix:  pfx6,
+					NextHop: gw6,
+				},
+			},
+			run: func(m *Manager) {
+				m.Configure([]Route{
+					{
+						Prefix:  aggregate,
+						NextHop: gw,
+					},
+				})
+			},
+			expected4: map[bnet.Prefix][]*route.Path{
+				*aggregate: {staticPath(gw)},
+			},
+			expected6: map[bnet.Prefix][]*route.Path{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := New(locRIB.New("inet.0"), locRIB.New("inet6.0"))
+			for pfx, p := range test.rib4 {
+				pfx := pfx
+				m.rib4.AddPath(&pfx, p)
+			}
+
+			m.Configure(test.routes)
+			if test.run != nil {
+				test.run(m)
+			}
+
+			assert.Equal(t, test.expected4, dump(m.rib4))
+			assert.Equal(t, test.expected6, dump(m.rib6))
+		})
+	}
+}
+
+func TestManagerTracksRIB(t *testing.T) {
+	aggregate := bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 100, 0), 24).Ptr()
+	connected := bnet.NewPfx(bnet.IPv4FromOctets(192, 0, 2, 0), 24).Ptr()
+	gw := bnet.IPv4FromOctets(192, 0, 2, 1).Ptr()
+
+	m := New(locRIB.New("inet.0"), locRIB.New("inet6.0"))
+	m.Start()
+	m.Configure([]Route{
+		{
+			Prefix:  aggregate,
+			NextHop: gw,
+			Resolve: true,
+		},
+	})
+	assert.Nil(t, m.rib4.Get(aggregate))
+
+	m.rib4.AddPath(connected, connectedPath(bnet.IPv4FromOctets(192, 0, 2, 100).Ptr()))
+	assert.Eventually(t, func() bool {
+		return m.rib4.ContainsPfxPath(aggregate, staticPath(gw))
+	}, time.Second, time.Millisecond)
+
+	m.Stop()
+	assert.False(t, m.rib4.ContainsPfxPath(aggregate, staticPath(gw)))
+}
+
+func dump(rib *locRIB.LocRIB) map[bnet.Prefix][]*route.Path {
+	res := make(map[bnet.Prefix][]*route.Path)
+	for _, r := range rib.Dump() {
+		res[*r.Prefix()] = r.Paths()
+	}
+
+	return res
+}
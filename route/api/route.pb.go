@@ -77,6 +77,9 @@ const (
 	Path_HiddenReasonOurOriginatorID    Path_HiddenReason = 4
 	Path_HiddenReasonClusterLoop        Path_HiddenReason = 5
 	Path_HiddenReasonOTCMismatch        Path_HiddenReason = 6
+	Path_HiddenReasonEmptyASPath        Path_HiddenReason = 7
+	Path_HiddenReasonASPAInvalid        Path_HiddenReason = 8
+	Path_HiddenReasonDampened           Path_HiddenReason = 9
 )
 
 // Enum value maps for Path_HiddenReason.
@@ -89,6 +92,9 @@ var (
 		4: "HiddenReasonOurOriginatorID",
 		5: "HiddenReasonClusterLoop",
 		6: "HiddenReasonOTCMismatch",
+		7: "HiddenReasonEmptyASPath",
+		8: "HiddenReasonASPAInvalid",
+		9: "HiddenReasonDampened",
 	}
 	Path_HiddenReason_value = map[string]int32{
 		"HiddenReasonNone":               0,
@@ -98,6 +104,9 @@ var (
 		"HiddenReasonOurOriginatorID":    4,
 		"HiddenReasonClusterLoop":        5,
 		"HiddenReasonOTCMismatch":        6,
+		"HiddenReasonEmptyASPath":        7,
+		"HiddenReasonASPAInvalid":        8,
+		"HiddenReasonDampened":           9,
 	}
 )
 
@@ -194,6 +203,7 @@ type Path struct {
 	HiddenReason Path_HiddenReason `protobuf:"varint,4,opt,name=hidden_reason,json=hiddenReason,proto3,enum=bio.route.Path_HiddenReason" json:"hidden_reason,omitempty"`
 	TimeLearned  uint32            `protobuf:"varint,5,opt,name=time_learned,json=timeLearned,proto3" json:"time_learned,omitempty"`
 	GrpPath      *GRPPath          `protobuf:"bytes,6,opt,name=grp_path,json=grpPath,proto3" json:"grp_path,omitempty"`
+	Dampening    *DampeningState   `protobuf:"bytes,7,opt,name=dampening,proto3" json:"dampening,omitempty"`
 }
 
 func (x *Path) Reset() {
@@ -270,18 +280,89 @@ func (x *Path) GetGrpPath() *GRPPath {
 	return nil
 }
 
+func (x *Path) GetDampening() *DampeningState {
+	if x != nil {
+		return x.Dampening
+	}
+	return nil
+}
+
+type DampeningState struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FigureOfMerit uint32 `protobuf:"varint,1,opt,name=figure_of_merit,json=figureOfMerit,proto3" json:"figure_of_merit,omitempty"`
+	Suppressed    bool   `protobuf:"varint,2,opt,name=suppressed,proto3" json:"suppressed,omitempty"`
+	ReuseTime     uint64 `protobuf:"varint,3,opt,name=reuse_time,json=reuseTime,proto3" json:"reuse_time,omitempty"`
+}
+
+func (x *DampeningState) Reset() {
+	*x = DampeningState{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_route_api_route_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DampeningState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DampeningState) ProtoMessage() {}
+
+func (x *DampeningState) ProtoReflect() protoreflect.Message {
+	mi := &file_route_api_route_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DampeningState.ProtoReflect.Descriptor instead.
+func (*DampeningState) Descriptor() ([]byte, []int) {
+	return file_route_api_route_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DampeningState) GetFigureOfMerit() uint32 {
+	if x != nil {
+		return x.FigureOfMerit
+	}
+	return 0
+}
+
+func (x *DampeningState) GetSuppressed() bool {
+	if x != nil {
+		return x.Suppressed
+	}
+	return false
+}
+
+func (x *DampeningState) GetReuseTime() uint64 {
+	if x != nil {
+		return x.ReuseTime
+	}
+	return 0
+}
+
 type StaticPath struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	NextHop *api.IP `protobuf:"bytes,1,opt,name=next_hop,json=nextHop,proto3" json:"next_hop,omitempty"`
+	Discard bool    `protobuf:"varint,2,opt,name=discard,proto3" json:"discard,omitempty"`
 }
 
 func (x *StaticPath) Reset() {
 	*x = StaticPath{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_route_api_route_proto_msgTypes[2]
+		mi := &file_route_api_route_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -294,7 +375,7 @@ func (x *StaticPath) String() string {
 func (*StaticPath) ProtoMessage() {}
 
 func (x *StaticPath) ProtoReflect() protoreflect.Message {
-	mi := &file_route_api_route_proto_msgTypes[2]
+	mi := &file_route_api_route_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -307,7 +388,7 @@ func (x *StaticPath) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StaticPath.ProtoReflect.Descriptor instead.
 func (*StaticPath) Descriptor() ([]byte, []int) {
-	return file_route_api_route_proto_rawDescGZIP(), []int{2}
+	return file_route_api_route_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *StaticPath) GetNextHop() *api.IP {
@@ -317,6 +398,13 @@ func (x *StaticPath) GetNextHop() *api.IP {
 	return nil
 }
 
+func (x *StaticPath) GetDiscard() bool {
+	if x != nil {
+		return x.Discard
+	}
+	return false
+}
+
 type GRPPath struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -329,7 +417,7 @@ type GRPPath struct {
 func (x *GRPPath) Reset() {
 	*x = GRPPath{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_route_api_route_proto_msgTypes[3]
+		mi := &file_route_api_route_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -342,7 +430,7 @@ func (x *GRPPath) String() string {
 func (*GRPPath) ProtoMessage() {}
 
 func (x *GRPPath) ProtoReflect() protoreflect.Message {
-	mi := &file_route_api_route_proto_msgTypes[3]
+	mi := &file_route_api_route_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -355,7 +443,7 @@ func (x *GRPPath) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GRPPath.ProtoReflect.Descriptor instead.
 func (*GRPPath) Descriptor() ([]byte, []int) {
-	return file_route_api_route_proto_rawDescGZIP(), []int{3}
+	return file_route_api_route_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *GRPPath) GetNextHop() *api.IP {
@@ -377,28 +465,33 @@ type BGPPath struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	PathIdentifier    uint32                  `protobuf:"varint,1,opt,name=path_identifier,json=pathIdentifier,proto3" json:"path_identifier,omitempty"`
-	NextHop           *api.IP                 `protobuf:"bytes,2,opt,name=next_hop,json=nextHop,proto3" json:"next_hop,omitempty"`
-	LocalPref         uint32                  `protobuf:"varint,3,opt,name=local_pref,json=localPref,proto3" json:"local_pref,omitempty"`
-	AsPath            []*ASPathSegment        `protobuf:"bytes,4,rep,name=as_path,json=asPath,proto3" json:"as_path,omitempty"`
-	Origin            uint32                  `protobuf:"varint,5,opt,name=origin,proto3" json:"origin,omitempty"`
-	Med               uint32                  `protobuf:"varint,6,opt,name=med,proto3" json:"med,omitempty"`
-	Ebgp              bool                    `protobuf:"varint,7,opt,name=ebgp,proto3" json:"ebgp,omitempty"`
-	BgpIdentifier     uint32                  `protobuf:"varint,8,opt,name=bgp_identifier,json=bgpIdentifier,proto3" json:"bgp_identifier,omitempty"`
-	Source            *api.IP                 `protobuf:"bytes,9,opt,name=source,proto3" json:"source,omitempty"`
-	Communities       []uint32                `protobuf:"varint,10,rep,packed,name=communities,proto3" json:"communities,omitempty"`
-	LargeCommunities  []*LargeCommunity       `protobuf:"bytes,11,rep,name=large_communities,json=largeCommunities,proto3" json:"large_communities,omitempty"`
-	OriginatorId      uint32                  `protobuf:"varint,12,opt,name=originator_id,json=originatorId,proto3" json:"originator_id,omitempty"`
-	ClusterList       []uint32                `protobuf:"varint,13,rep,packed,name=cluster_list,json=clusterList,proto3" json:"cluster_list,omitempty"`
-	UnknownAttributes []*UnknownPathAttribute `protobuf:"bytes,14,rep,name=unknown_attributes,json=unknownAttributes,proto3" json:"unknown_attributes,omitempty"`
-	BmpPostPolicy     bool                    `protobuf:"varint,15,opt,name=bmp_post_policy,json=bmpPostPolicy,proto3" json:"bmp_post_policy,omitempty"`
-	OnlyToCustomer    uint32                  `protobuf:"varint,16,opt,name=only_to_customer,json=onlyToCustomer,proto3" json:"only_to_customer,omitempty"`
+	PathIdentifier          uint32                   `protobuf:"varint,1,opt,name=path_identifier,json=pathIdentifier,proto3" json:"path_identifier,omitempty"`
+	NextHop                 *api.IP                  `protobuf:"bytes,2,opt,name=next_hop,json=nextHop,proto3" json:"next_hop,omitempty"`
+	LocalPref               uint32                   `protobuf:"varint,3,opt,name=local_pref,json=localPref,proto3" json:"local_pref,omitempty"`
+	AsPath                  []*ASPathSegment         `protobuf:"bytes,4,rep,name=as_path,json=asPath,proto3" json:"as_path,omitempty"`
+	Origin                  uint32                   `protobuf:"varint,5,opt,name=origin,proto3" json:"origin,omitempty"`
+	Med                     uint32                   `protobuf:"varint,6,opt,name=med,proto3" json:"med,omitempty"`
+	Ebgp                    bool                     `protobuf:"varint,7,opt,name=ebgp,proto3" json:"ebgp,omitempty"`
+	BgpIdentifier           uint32                   `protobuf:"varint,8,opt,name=bgp_identifier,json=bgpIdentifier,proto3" json:"bgp_identifier,omitempty"`
+	Source                  *api.IP                  `protobuf:"bytes,9,opt,name=source,proto3" json:"source,omitempty"`
+	Communities             []uint32                 `protobuf:"varint,10,rep,packed,name=communities,proto3" json:"communities,omitempty"`
+	LargeCommunities        []*LargeCommunity        `protobuf:"bytes,11,rep,name=large_communities,json=largeCommunities,proto3" json:"large_communities,omitempty"`
+	OriginatorId            uint32                   `protobuf:"varint,12,opt,name=originator_id,json=originatorId,proto3" json:"originator_id,omitempty"`
+	ClusterList             []uint32                 `protobuf:"varint,13,rep,packed,name=cluster_list,json=clusterList,proto3" json:"cluster_list,omitempty"`
+	UnknownAttributes       []*UnknownPathAttribute  `protobuf:"bytes,14,rep,name=unknown_attributes,json=unknownAttributes,proto3" json:"unknown_attributes,omitempty"`
+	BmpPostPolicy           bool                     `protobuf:"varint,15,opt,name=bmp_post_policy,json=bmpPostPolicy,proto3" json:"bmp_post_policy,omitempty"`
+	OnlyToCustomer          uint32                   `protobuf:"varint,16,opt,name=only_to_customer,json=onlyToCustomer,proto3" json:"only_to_customer,omitempty"`
+	ExtendedCommunities     []*ExtendedCommunity     `protobuf:"bytes,17,rep,name=extended_communities,json=extendedCommunities,proto3" json:"extended_communities,omitempty"`
+	Ipv6ExtendedCommunities []*IPv6ExtendedCommunity `protobuf:"bytes,18,rep,name=ipv6_extended_communities,json=ipv6ExtendedCommunities,proto3" json:"ipv6_extended_communities,omitempty"`
+	RouteDistinguisher      uint64                   `protobuf:"varint,19,opt,name=route_distinguisher,json=routeDistinguisher,proto3" json:"route_distinguisher,omitempty"`
+	LabelStack              []uint32                 `protobuf:"varint,20,rep,packed,name=label_stack,json=labelStack,proto3" json:"label_stack,omitempty"`
+	FlowspecRule            *FlowSpecRule            `protobuf:"bytes,21,opt,name=flowspec_rule,json=flowspecRule,proto3" json:"flowspec_rule,omitempty"`
 }
 
 func (x *BGPPath) Reset() {
 	*x = BGPPath{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_route_api_route_proto_msgTypes[4]
+		mi := &file_route_api_route_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -411,7 +504,7 @@ func (x *BGPPath) String() string {
 func (*BGPPath) ProtoMessage() {}
 
 func (x *BGPPath) ProtoReflect() protoreflect.Message {
-	mi := &file_route_api_route_proto_msgTypes[4]
+	mi := &file_route_api_route_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -424,7 +517,7 @@ func (x *BGPPath) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BGPPath.ProtoReflect.Descriptor instead.
 func (*BGPPath) Descriptor() ([]byte, []int) {
-	return file_route_api_route_proto_rawDescGZIP(), []int{4}
+	return file_route_api_route_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *BGPPath) GetPathIdentifier() uint32 {
@@ -539,6 +632,41 @@ func (x *BGPPath) GetOnlyToCustomer() uint32 {
 	return 0
 }
 
+func (x *BGPPath) GetExtendedCommunities() []*ExtendedCommunity {
+	if x != nil {
+		return x.ExtendedCommunities
+	}
+	return nil
+}
+
+func (x *BGPPath) GetIpv6ExtendedCommunities() []*IPv6ExtendedCommunity {
+	if x != nil {
+		return x.Ipv6ExtendedCommunities
+	}
+	return nil
+}
+
+func (x *BGPPath) GetRouteDistinguisher() uint64 {
+	if x != nil {
+		return x.RouteDistinguisher
+	}
+	return 0
+}
+
+func (x *BGPPath) GetLabelStack() []uint32 {
+	if x != nil {
+		return x.LabelStack
+	}
+	return nil
+}
+
+func (x *BGPPath) GetFlowspecRule() *FlowSpecRule {
+	if x != nil {
+		return x.FlowspecRule
+	}
+	return nil
+}
+
 type ASPathSegment struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -551,7 +679,7 @@ type ASPathSegment struct {
 func (x *ASPathSegment) Reset() {
 	*x = ASPathSegment{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_route_api_route_proto_msgTypes[5]
+		mi := &file_route_api_route_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -564,7 +692,7 @@ func (x *ASPathSegment) String() string {
 func (*ASPathSegment) ProtoMessage() {}
 
 func (x *ASPathSegment) ProtoReflect() protoreflect.Message {
-	mi := &file_route_api_route_proto_msgTypes[5]
+	mi := &file_route_api_route_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -577,7 +705,7 @@ func (x *ASPathSegment) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ASPathSegment.ProtoReflect.Descriptor instead.
 func (*ASPathSegment) Descriptor() ([]byte, []int) {
-	return file_route_api_route_proto_rawDescGZIP(), []int{5}
+	return file_route_api_route_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ASPathSegment) GetAsSequence() bool {
@@ -607,7 +735,7 @@ type LargeCommunity struct {
 func (x *LargeCommunity) Reset() {
 	*x = LargeCommunity{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_route_api_route_proto_msgTypes[6]
+		mi := &file_route_api_route_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -620,7 +748,7 @@ func (x *LargeCommunity) String() string {
 func (*LargeCommunity) ProtoMessage() {}
 
 func (x *LargeCommunity) ProtoReflect() protoreflect.Message {
-	mi := &file_route_api_route_proto_msgTypes[6]
+	mi := &file_route_api_route_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -633,7 +761,7 @@ func (x *LargeCommunity) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LargeCommunity.ProtoReflect.Descriptor instead.
 func (*LargeCommunity) Descriptor() ([]byte, []int) {
-	return file_route_api_route_proto_rawDescGZIP(), []int{6}
+	return file_route_api_route_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *LargeCommunity) GetGlobalAdministrator() uint32 {
@@ -657,6 +785,353 @@ func (x *LargeCommunity) GetDataPart2() uint32 {
 	return 0
 }
 
+type ExtendedCommunity struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type    uint32 `protobuf:"varint,1,opt,name=type,proto3" json:"type,omitempty"`
+	SubType uint32 `protobuf:"varint,2,opt,name=sub_type,json=subType,proto3" json:"sub_type,omitempty"`
+	Value   uint64 `protobuf:"varint,3,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *ExtendedCommunity) Reset() {
+	*x = ExtendedCommunity{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_route_api_route_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExtendedCommunity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtendedCommunity) ProtoMessage() {}
+
+func (x *ExtendedCommunity) ProtoReflect() protoreflect.Message {
+	mi := &file_route_api_route_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtendedCommunity.ProtoReflect.Descriptor instead.
+func (*ExtendedCommunity) Descriptor() ([]byte, []int) {
+	return file_route_api_route_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ExtendedCommunity) GetType() uint32 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+func (x *ExtendedCommunity) GetSubType() uint32 {
+	if x != nil {
+		return x.SubType
+	}
+	return 0
+}
+
+func (x *ExtendedCommunity) GetValue() uint64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+type IPv6ExtendedCommunity struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type                uint32  `protobuf:"varint,1,opt,name=type,proto3" json:"type,omitempty"`
+	SubType             uint32  `protobuf:"varint,2,opt,name=sub_type,json=subType,proto3" json:"sub_type,omitempty"`
+	GlobalAdministrator *api.IP `protobuf:"bytes,3,opt,name=global_administrator,json=globalAdministrator,proto3" json:"global_administrator,omitempty"`
+	LocalAdministrator  uint32  `protobuf:"varint,4,opt,name=local_administrator,json=localAdministrator,proto3" json:"local_administrator,omitempty"`
+}
+
+func (x *IPv6ExtendedCommunity) Reset() {
+	*x = IPv6ExtendedCommunity{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_route_api_route_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IPv6ExtendedCommunity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IPv6ExtendedCommunity) ProtoMessage() {}
+
+func (x *IPv6ExtendedCommunity) ProtoReflect() protoreflect.Message {
+	mi := &file_route_api_route_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IPv6ExtendedCommunity.ProtoReflect.Descriptor instead.
+func (*IPv6ExtendedCommunity) Descriptor() ([]byte, []int) {
+	return file_route_api_route_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *IPv6ExtendedCommunity) GetType() uint32 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+func (x *IPv6ExtendedCommunity) GetSubType() uint32 {
+	if x != nil {
+		return x.SubType
+	}
+	return 0
+}
+
+func (x *IPv6ExtendedCommunity) GetGlobalAdministrator() *api.IP {
+	if x != nil {
+		return x.GlobalAdministrator
+	}
+	return nil
+}
+
+func (x *IPv6ExtendedCommunity) GetLocalAdministrator() uint32 {
+	if x != nil {
+		return x.LocalAdministrator
+	}
+	return 0
+}
+
+type FlowSpecRule struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Components []*FlowSpecComponent `protobuf:"bytes,1,rep,name=components,proto3" json:"components,omitempty"`
+}
+
+func (x *FlowSpecRule) Reset() {
+	*x = FlowSpecRule{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_route_api_route_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FlowSpecRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlowSpecRule) ProtoMessage() {}
+
+func (x *FlowSpecRule) ProtoReflect() protoreflect.Message {
+	mi := &file_route_api_route_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlowSpecRule.ProtoReflect.Descriptor instead.
+func (*FlowSpecRule) Descriptor() ([]byte, []int) {
+	return file_route_api_route_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *FlowSpecRule) GetComponents() []*FlowSpecComponent {
+	if x != nil {
+		return x.Components
+	}
+	return nil
+}
+
+type FlowSpecComponent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type       uint32               `protobuf:"varint,1,opt,name=type,proto3" json:"type,omitempty"`
+	Prefix     *api.Prefix          `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Offset     uint32               `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	Operations []*FlowSpecOperation `protobuf:"bytes,4,rep,name=operations,proto3" json:"operations,omitempty"`
+}
+
+func (x *FlowSpecComponent) Reset() {
+	*x = FlowSpecComponent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_route_api_route_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FlowSpecComponent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlowSpecComponent) ProtoMessage() {}
+
+func (x *FlowSpecComponent) ProtoReflect() protoreflect.Message {
+	mi := &file_route_api_route_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlowSpecComponent.ProtoReflect.Descriptor instead.
+func (*FlowSpecComponent) Descriptor() ([]byte, []int) {
+	return file_route_api_route_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *FlowSpecComponent) GetType() uint32 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+func (x *FlowSpecComponent) GetPrefix() *api.Prefix {
+	if x != nil {
+		return x.Prefix
+	}
+	return nil
+}
+
+func (x *FlowSpecComponent) GetOffset() uint32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *FlowSpecComponent) GetOperations() []*FlowSpecOperation {
+	if x != nil {
+		return x.Operations
+	}
+	return nil
+}
+
+type FlowSpecOperation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	And         bool   `protobuf:"varint,1,opt,name=and,proto3" json:"and,omitempty"`
+	LessThan    bool   `protobuf:"varint,2,opt,name=less_than,json=lessThan,proto3" json:"less_than,omitempty"`
+	GreaterThan bool   `protobuf:"varint,3,opt,name=greater_than,json=greaterThan,proto3" json:"greater_than,omitempty"`
+	Equal       bool   `protobuf:"varint,4,opt,name=equal,proto3" json:"equal,omitempty"`
+	Not         bool   `protobuf:"varint,5,opt,name=not,proto3" json:"not,omitempty"`
+	Match       bool   `protobuf:"varint,6,opt,name=match,proto3" json:"match,omitempty"`
+	Value       uint64 `protobuf:"varint,7,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *FlowSpecOperation) Reset() {
+	*x = FlowSpecOperation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_route_api_route_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FlowSpecOperation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlowSpecOperation) ProtoMessage() {}
+
+func (x *FlowSpecOperation) ProtoReflect() protoreflect.Message {
+	mi := &file_route_api_route_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlowSpecOperation.ProtoReflect.Descriptor instead.
+func (*FlowSpecOperation) Descriptor() ([]byte, []int) {
+	return file_route_api_route_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *FlowSpecOperation) GetAnd() bool {
+	if x != nil {
+		return x.And
+	}
+	return false
+}
+
+func (x *FlowSpecOperation) GetLessThan() bool {
+	if x != nil {
+		return x.LessThan
+	}
+	return false
+}
+
+func (x *FlowSpecOperation) GetGreaterThan() bool {
+	if x != nil {
+		return x.GreaterThan
+	}
+	return false
+}
+
+func (x *FlowSpecOperation) GetEqual() bool {
+	if x != nil {
+		return x.Equal
+	}
+	return false
+}
+
+func (x *FlowSpecOperation) GetNot() bool {
+	if x != nil {
+		return x.Not
+	}
+	return false
+}
+
+func (x *FlowSpecOperation) GetMatch() bool {
+	if x != nil {
+		return x.Match
+	}
+	return false
+}
+
+func (x *FlowSpecOperation) GetValue() uint64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
 type UnknownPathAttribute struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -672,7 +1147,7 @@ type UnknownPathAttribute struct {
 func (x *UnknownPathAttribute) Reset() {
 	*x = UnknownPathAttribute{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_route_api_route_proto_msgTypes[7]
+		mi := &file_route_api_route_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -685,7 +1160,7 @@ func (x *UnknownPathAttribute) String() string {
 func (*UnknownPathAttribute) ProtoMessage() {}
 
 func (x *UnknownPathAttribute) ProtoReflect() protoreflect.Message {
-	mi := &file_route_api_route_proto_msgTypes[7]
+	mi := &file_route_api_route_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -698,7 +1173,7 @@ func (x *UnknownPathAttribute) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UnknownPathAttribute.ProtoReflect.Descriptor instead.
 func (*UnknownPathAttribute) Descriptor() ([]byte, []int) {
-	return file_route_api_route_proto_rawDescGZIP(), []int{7}
+	return file_route_api_route_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *UnknownPathAttribute) GetOptional() bool {
@@ -747,7 +1222,7 @@ var file_route_api_route_proto_rawDesc = []byte{
 	0x6f, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x52, 0x03, 0x70, 0x66,
 	0x78, 0x12, 0x25, 0x0a, 0x05, 0x70, 0x61, 0x74, 0x68, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
 	0x32, 0x0f, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x2e, 0x50, 0x61, 0x74,
-	0x68, 0x52, 0x05, 0x70, 0x61, 0x74, 0x68, 0x73, 0x22, 0xa9, 0x04, 0x0a, 0x04, 0x50, 0x61, 0x74,
+	0x68, 0x52, 0x05, 0x70, 0x61, 0x74, 0x68, 0x73, 0x22, 0xb6, 0x05, 0x0a, 0x04, 0x50, 0x61, 0x74,
 	0x68, 0x12, 0x28, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32,
 	0x14, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x2e, 0x50, 0x61, 0x74, 0x68,
 	0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x36, 0x0a, 0x0b, 0x73,
@@ -766,26 +1241,44 @@ var file_route_api_route_proto_rawDesc = []byte{
 	0x65, 0x4c, 0x65, 0x61, 0x72, 0x6e, 0x65, 0x64, 0x12, 0x2d, 0x0a, 0x08, 0x67, 0x72, 0x70, 0x5f,
 	0x70, 0x61, 0x74, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x62, 0x69, 0x6f,
 	0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x2e, 0x47, 0x52, 0x50, 0x50, 0x61, 0x74, 0x68, 0x52, 0x07,
-	0x67, 0x72, 0x70, 0x50, 0x61, 0x74, 0x68, 0x22, 0x1b, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12,
-	0x0a, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x69, 0x63, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x42,
-	0x47, 0x50, 0x10, 0x01, 0x22, 0xdd, 0x01, 0x0a, 0x0c, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x52,
-	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x10, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x52,
-	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x4e, 0x6f, 0x6e, 0x65, 0x10, 0x00, 0x12, 0x22, 0x0a, 0x1e, 0x48,
-	0x69, 0x64, 0x64, 0x65, 0x6e, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x4e, 0x65, 0x78, 0x74, 0x48,
-	0x6f, 0x70, 0x55, 0x6e, 0x72, 0x65, 0x61, 0x63, 0x68, 0x61, 0x62, 0x6c, 0x65, 0x10, 0x01, 0x12,
-	0x20, 0x0a, 0x1c, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x46,
-	0x69, 0x6c, 0x74, 0x65, 0x72, 0x65, 0x64, 0x42, 0x79, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x10,
-	0x02, 0x12, 0x16, 0x0a, 0x12, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x52, 0x65, 0x61, 0x73, 0x6f,
-	0x6e, 0x41, 0x53, 0x4c, 0x6f, 0x6f, 0x70, 0x10, 0x03, 0x12, 0x1f, 0x0a, 0x1b, 0x48, 0x69, 0x64,
-	0x64, 0x65, 0x6e, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x4f, 0x75, 0x72, 0x4f, 0x72, 0x69, 0x67,
-	0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x49, 0x44, 0x10, 0x04, 0x12, 0x1b, 0x0a, 0x17, 0x48, 0x69,
-	0x64, 0x64, 0x65, 0x6e, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65,
-	0x72, 0x4c, 0x6f, 0x6f, 0x70, 0x10, 0x05, 0x12, 0x1b, 0x0a, 0x17, 0x48, 0x69, 0x64, 0x64, 0x65,
-	0x6e, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x4f, 0x54, 0x43, 0x4d, 0x69, 0x73, 0x6d, 0x61, 0x74,
-	0x63, 0x68, 0x10, 0x06, 0x22, 0x34, 0x0a, 0x0a, 0x53, 0x74, 0x61, 0x74, 0x69, 0x63, 0x50, 0x61,
-	0x74, 0x68, 0x12, 0x26, 0x0a, 0x08, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x68, 0x6f, 0x70, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x49,
-	0x50, 0x52, 0x07, 0x6e, 0x65, 0x78, 0x74, 0x48, 0x6f, 0x70, 0x22, 0xad, 0x01, 0x0a, 0x07, 0x47,
+	0x67, 0x72, 0x70, 0x50, 0x61, 0x74, 0x68, 0x12, 0x37, 0x0a, 0x09, 0x64, 0x61, 0x6d, 0x70, 0x65,
+	0x6e, 0x69, 0x6e, 0x67, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x62, 0x69, 0x6f,
+	0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x2e, 0x44, 0x61, 0x6d, 0x70, 0x65, 0x6e, 0x69, 0x6e, 0x67,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x09, 0x64, 0x61, 0x6d, 0x70, 0x65, 0x6e, 0x69, 0x6e, 0x67,
+	0x22, 0x1b, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74,
+	0x69, 0x63, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x42, 0x47, 0x50, 0x10, 0x01, 0x22, 0xb1, 0x02,
+	0x0a, 0x0c, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x14,
+	0x0a, 0x10, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x4e, 0x6f,
+	0x6e, 0x65, 0x10, 0x00, 0x12, 0x22, 0x0a, 0x1e, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x52, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x4e, 0x65, 0x78, 0x74, 0x48, 0x6f, 0x70, 0x55, 0x6e, 0x72, 0x65, 0x61,
+	0x63, 0x68, 0x61, 0x62, 0x6c, 0x65, 0x10, 0x01, 0x12, 0x20, 0x0a, 0x1c, 0x48, 0x69, 0x64, 0x64,
+	0x65, 0x6e, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x65, 0x64,
+	0x42, 0x79, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x10, 0x02, 0x12, 0x16, 0x0a, 0x12, 0x48, 0x69,
+	0x64, 0x64, 0x65, 0x6e, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x41, 0x53, 0x4c, 0x6f, 0x6f, 0x70,
+	0x10, 0x03, 0x12, 0x1f, 0x0a, 0x1b, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x52, 0x65, 0x61, 0x73,
+	0x6f, 0x6e, 0x4f, 0x75, 0x72, 0x4f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x49,
+	0x44, 0x10, 0x04, 0x12, 0x1b, 0x0a, 0x17, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x52, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x4c, 0x6f, 0x6f, 0x70, 0x10, 0x05,
+	0x12, 0x1b, 0x0a, 0x17, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x4f, 0x54, 0x43, 0x4d, 0x69, 0x73, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x10, 0x06, 0x12, 0x1b, 0x0a,
+	0x17, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x41, 0x53, 0x50, 0x61, 0x74, 0x68, 0x10, 0x07, 0x12, 0x1b, 0x0a, 0x17, 0x48, 0x69,
+	0x64, 0x64, 0x65, 0x6e, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x41, 0x53, 0x50, 0x41, 0x49, 0x6e,
+	0x76, 0x61, 0x6c, 0x69, 0x64, 0x10, 0x08, 0x12, 0x18, 0x0a, 0x14, 0x48, 0x69, 0x64, 0x64, 0x65,
+	0x6e, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x44, 0x61, 0x6d, 0x70, 0x65, 0x6e, 0x65, 0x64, 0x10,
+	0x09, 0x22, 0x77, 0x0a, 0x0e, 0x44, 0x61, 0x6d, 0x70, 0x65, 0x6e, 0x69, 0x6e, 0x67, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x12, 0x26, 0x0a, 0x0f, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x5f, 0x6f, 0x66,
+	0x5f, 0x6d, 0x65, 0x72, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x66, 0x69,
+	0x67, 0x75, 0x72, 0x65, 0x4f, 0x66, 0x4d, 0x65, 0x72, 0x69, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x73,
+	0x75, 0x70, 0x70, 0x72, 0x65, 0x73, 0x73, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0a, 0x73, 0x75, 0x70, 0x70, 0x72, 0x65, 0x73, 0x73, 0x65, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x72,
+	0x65, 0x75, 0x73, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x09, 0x72, 0x65, 0x75, 0x73, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x4e, 0x0a, 0x0a, 0x53, 0x74,
+	0x61, 0x74, 0x69, 0x63, 0x50, 0x61, 0x74, 0x68, 0x12, 0x26, 0x0a, 0x08, 0x6e, 0x65, 0x78, 0x74,
+	0x5f, 0x68, 0x6f, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x62, 0x69, 0x6f,
+	0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x49, 0x50, 0x52, 0x07, 0x6e, 0x65, 0x78, 0x74, 0x48, 0x6f, 0x70,
+	0x12, 0x18, 0x0a, 0x07, 0x64, 0x69, 0x73, 0x63, 0x61, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x07, 0x64, 0x69, 0x73, 0x63, 0x61, 0x72, 0x64, 0x22, 0xad, 0x01, 0x0a, 0x07, 0x47,
 	0x52, 0x50, 0x50, 0x61, 0x74, 0x68, 0x12, 0x26, 0x0a, 0x08, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x68,
 	0x6f, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x6e,
 	0x65, 0x74, 0x2e, 0x49, 0x50, 0x52, 0x07, 0x6e, 0x65, 0x78, 0x74, 0x48, 0x6f, 0x70, 0x12, 0x3d,
@@ -796,7 +1289,7 @@ var file_route_api_route_proto_rawDesc = []byte{
 	0x0d, 0x4d, 0x65, 0x74, 0x61, 0x44, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
 	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
 	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x8a, 0x05, 0x0a, 0x07, 0x42,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xc9, 0x07, 0x0a, 0x07, 0x42,
 	0x47, 0x50, 0x50, 0x61, 0x74, 0x68, 0x12, 0x27, 0x0a, 0x0f, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x69,
 	0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52,
 	0x0e, 0x70, 0x61, 0x74, 0x68, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x12,
@@ -837,33 +1330,97 @@ var file_route_api_route_proto_rawDesc = []byte{
 	0x62, 0x6d, 0x70, 0x50, 0x6f, 0x73, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x28, 0x0a,
 	0x10, 0x6f, 0x6e, 0x6c, 0x79, 0x5f, 0x74, 0x6f, 0x5f, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65,
 	0x72, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x6f, 0x6e, 0x6c, 0x79, 0x54, 0x6f, 0x43,
-	0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x22, 0x44, 0x0a, 0x0d, 0x41, 0x53, 0x50, 0x61, 0x74,
-	0x68, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x73, 0x5f, 0x73,
-	0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x61,
-	0x73, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x73, 0x6e,
-	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x04, 0x61, 0x73, 0x6e, 0x73, 0x22, 0x81, 0x01,
-	0x0a, 0x0e, 0x4c, 0x61, 0x72, 0x67, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x75, 0x6e, 0x69, 0x74, 0x79,
-	0x12, 0x31, 0x0a, 0x14, 0x67, 0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x5f, 0x61, 0x64, 0x6d, 0x69, 0x6e,
-	0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x13,
+	0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x12, 0x4f, 0x0a, 0x14, 0x65, 0x78, 0x74, 0x65, 0x6e,
+	0x64, 0x65, 0x64, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x75, 0x6e, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18,
+	0x11, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x72, 0x6f, 0x75, 0x74,
+	0x65, 0x2e, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x43, 0x6f, 0x6d, 0x6d, 0x75, 0x6e,
+	0x69, 0x74, 0x79, 0x52, 0x13, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x43, 0x6f, 0x6d,
+	0x6d, 0x75, 0x6e, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x5c, 0x0a, 0x19, 0x69, 0x70, 0x76, 0x36,
+	0x5f, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x75, 0x6e,
+	0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x12, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x62, 0x69,
+	0x6f, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x2e, 0x49, 0x50, 0x76, 0x36, 0x45, 0x78, 0x74, 0x65,
+	0x6e, 0x64, 0x65, 0x64, 0x43, 0x6f, 0x6d, 0x6d, 0x75, 0x6e, 0x69, 0x74, 0x79, 0x52, 0x17, 0x69,
+	0x70, 0x76, 0x36, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x43, 0x6f, 0x6d, 0x6d, 0x75,
+	0x6e, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x2f, 0x0a, 0x13, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x5f,
+	0x64, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x67, 0x75, 0x69, 0x73, 0x68, 0x65, 0x72, 0x18, 0x13, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x12, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x44, 0x69, 0x73, 0x74, 0x69, 0x6e,
+	0x67, 0x75, 0x69, 0x73, 0x68, 0x65, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x6c, 0x61, 0x62, 0x65, 0x6c,
+	0x5f, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x18, 0x14, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x0a, 0x6c, 0x61,
+	0x62, 0x65, 0x6c, 0x53, 0x74, 0x61, 0x63, 0x6b, 0x12, 0x3c, 0x0a, 0x0d, 0x66, 0x6c, 0x6f, 0x77,
+	0x73, 0x70, 0x65, 0x63, 0x5f, 0x72, 0x75, 0x6c, 0x65, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x17, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x2e, 0x46, 0x6c, 0x6f, 0x77,
+	0x53, 0x70, 0x65, 0x63, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x0c, 0x66, 0x6c, 0x6f, 0x77, 0x73, 0x70,
+	0x65, 0x63, 0x52, 0x75, 0x6c, 0x65, 0x22, 0x44, 0x0a, 0x0d, 0x41, 0x53, 0x50, 0x61, 0x74, 0x68,
+	0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x73, 0x5f, 0x73, 0x65,
+	0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x61, 0x73,
+	0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x73, 0x6e, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x04, 0x61, 0x73, 0x6e, 0x73, 0x22, 0x81, 0x01, 0x0a,
+	0x0e, 0x4c, 0x61, 0x72, 0x67, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x75, 0x6e, 0x69, 0x74, 0x79, 0x12,
+	0x31, 0x0a, 0x14, 0x67, 0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x5f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x69,
+	0x73, 0x74, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x13, 0x67,
+	0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74,
+	0x6f, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x70, 0x61, 0x72, 0x74, 0x31,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x64, 0x61, 0x74, 0x61, 0x50, 0x61, 0x72, 0x74,
+	0x31, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x70, 0x61, 0x72, 0x74, 0x32, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x64, 0x61, 0x74, 0x61, 0x50, 0x61, 0x72, 0x74, 0x32,
+	0x22, 0x58, 0x0a, 0x11, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x43, 0x6f, 0x6d, 0x6d,
+	0x75, 0x6e, 0x69, 0x74, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x75, 0x62,
+	0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x73, 0x75, 0x62,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0xb7, 0x01, 0x0a, 0x15, 0x49,
+	0x50, 0x76, 0x36, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x43, 0x6f, 0x6d, 0x6d, 0x75,
+	0x6e, 0x69, 0x74, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x75, 0x62, 0x5f,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x73, 0x75, 0x62, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x3e, 0x0a, 0x14, 0x67, 0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x5f, 0x61, 0x64,
+	0x6d, 0x69, 0x6e, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0b, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x49, 0x50, 0x52, 0x13,
 	0x67, 0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x69, 0x73, 0x74, 0x72, 0x61,
-	0x74, 0x6f, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x70, 0x61, 0x72, 0x74,
-	0x31, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x64, 0x61, 0x74, 0x61, 0x50, 0x61, 0x72,
-	0x74, 0x31, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x70, 0x61, 0x72, 0x74, 0x32,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x64, 0x61, 0x74, 0x61, 0x50, 0x61, 0x72, 0x74,
-	0x32, 0x22, 0x9f, 0x01, 0x0a, 0x14, 0x55, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x50, 0x61, 0x74,
-	0x68, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x70,
-	0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x6f, 0x70,
-	0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x69,
-	0x74, 0x69, 0x76, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x74, 0x72, 0x61, 0x6e,
-	0x73, 0x69, 0x74, 0x69, 0x76, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x72, 0x74, 0x69, 0x61,
-	0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x70, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c,
-	0x12, 0x1b, 0x0a, 0x09, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x0d, 0x52, 0x08, 0x74, 0x79, 0x70, 0x65, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x14, 0x0a,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x42, 0x29, 0x5a, 0x27, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
-	0x6d, 0x2f, 0x62, 0x69, 0x6f, 0x2d, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2f, 0x62, 0x69,
-	0x6f, 0x2d, 0x72, 0x64, 0x2f, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x74, 0x6f, 0x72, 0x12, 0x2f, 0x0a, 0x13, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x5f, 0x61, 0x64, 0x6d,
+	0x69, 0x6e, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x12, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x69, 0x73, 0x74, 0x72,
+	0x61, 0x74, 0x6f, 0x72, 0x22, 0x4c, 0x0a, 0x0c, 0x46, 0x6c, 0x6f, 0x77, 0x53, 0x70, 0x65, 0x63,
+	0x52, 0x75, 0x6c, 0x65, 0x12, 0x3c, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e,
+	0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x72,
+	0x6f, 0x75, 0x74, 0x65, 0x2e, 0x46, 0x6c, 0x6f, 0x77, 0x53, 0x70, 0x65, 0x63, 0x43, 0x6f, 0x6d,
+	0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e,
+	0x74, 0x73, 0x22, 0xa6, 0x01, 0x0a, 0x11, 0x46, 0x6c, 0x6f, 0x77, 0x53, 0x70, 0x65, 0x63, 0x43,
+	0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x27, 0x0a, 0x06,
+	0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x62,
+	0x69, 0x6f, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x52, 0x06, 0x70,
+	0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x3c, 0x0a,
+	0x0a, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x1c, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x2e, 0x46, 0x6c,
+	0x6f, 0x77, 0x53, 0x70, 0x65, 0x63, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x0a, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xb9, 0x01, 0x0a, 0x11,
+	0x46, 0x6c, 0x6f, 0x77, 0x53, 0x70, 0x65, 0x63, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03,
+	0x61, 0x6e, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x65, 0x73, 0x73, 0x5f, 0x74, 0x68, 0x61, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x6c, 0x65, 0x73, 0x73, 0x54, 0x68, 0x61, 0x6e,
+	0x12, 0x21, 0x0a, 0x0c, 0x67, 0x72, 0x65, 0x61, 0x74, 0x65, 0x72, 0x5f, 0x74, 0x68, 0x61, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x67, 0x72, 0x65, 0x61, 0x74, 0x65, 0x72, 0x54,
+	0x68, 0x61, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x71, 0x75, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x05, 0x65, 0x71, 0x75, 0x61, 0x6c, 0x12, 0x10, 0x0a, 0x03, 0x6e, 0x6f, 0x74,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x6e, 0x6f, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6d,
+	0x61, 0x74, 0x63, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x6d, 0x61, 0x74, 0x63,
+	0x68, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x9f, 0x01, 0x0a, 0x14, 0x55, 0x6e, 0x6b, 0x6e,
+	0x6f, 0x77, 0x6e, 0x50, 0x61, 0x74, 0x68, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65,
+	0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x08, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x12, 0x1e, 0x0a, 0x0a,
+	0x74, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x76, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0a, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x76, 0x65, 0x12, 0x18, 0x0a, 0x07,
+	0x70, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x70,
+	0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x63,
+	0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x74, 0x79, 0x70, 0x65, 0x43,
+	0x6f, 0x64, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x29, 0x5a, 0x27, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x62, 0x69, 0x6f, 0x2d, 0x72, 0x6f, 0x75, 0x74,
+	0x69, 0x6e, 0x67, 0x2f, 0x62, 0x69, 0x6f, 0x2d, 0x72, 0x64, 0x2f, 0x72, 0x6f, 0x75, 0x74, 0x65,
+	0x2f, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -879,43 +1436,57 @@ func file_route_api_route_proto_rawDescGZIP() []byte {
 }
 
 var file_route_api_route_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_route_api_route_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_route_api_route_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_route_api_route_proto_goTypes = []interface{}{
-	(Path_Type)(0),               // 0: bio.route.Path.Type
-	(Path_HiddenReason)(0),       // 1: bio.route.Path.HiddenReason
-	(*Route)(nil),                // 2: bio.route.Route
-	(*Path)(nil),                 // 3: bio.route.Path
-	(*StaticPath)(nil),           // 4: bio.route.StaticPath
-	(*GRPPath)(nil),              // 5: bio.route.GRPPath
-	(*BGPPath)(nil),              // 6: bio.route.BGPPath
-	(*ASPathSegment)(nil),        // 7: bio.route.ASPathSegment
-	(*LargeCommunity)(nil),       // 8: bio.route.LargeCommunity
-	(*UnknownPathAttribute)(nil), // 9: bio.route.UnknownPathAttribute
-	nil,                          // 10: bio.route.GRPPath.MetaDataEntry
-	(*api.Prefix)(nil),           // 11: bio.net.Prefix
-	(*api.IP)(nil),               // 12: bio.net.IP
+	(Path_Type)(0),                // 0: bio.route.Path.Type
+	(Path_HiddenReason)(0),        // 1: bio.route.Path.HiddenReason
+	(*Route)(nil),                 // 2: bio.route.Route
+	(*Path)(nil),                  // 3: bio.route.Path
+	(*DampeningState)(nil),        // 4: bio.route.DampeningState
+	(*StaticPath)(nil),            // 5: bio.route.StaticPath
+	(*GRPPath)(nil),               // 6: bio.route.GRPPath
+	(*BGPPath)(nil),               // 7: bio.route.BGPPath
+	(*ASPathSegment)(nil),         // 8: bio.route.ASPathSegment
+	(*LargeCommunity)(nil),        // 9: bio.route.LargeCommunity
+	(*ExtendedCommunity)(nil),     // 10: bio.route.ExtendedCommunity
+	(*IPv6ExtendedCommunity)(nil), // 11: bio.route.IPv6ExtendedCommunity
+	(*FlowSpecRule)(nil),          // 12: bio.route.FlowSpecRule
+	(*FlowSpecComponent)(nil),     // 13: bio.route.FlowSpecComponent
+	(*FlowSpecOperation)(nil),     // 14: bio.route.FlowSpecOperation
+	(*UnknownPathAttribute)(nil),  // 15: bio.route.UnknownPathAttribute
+	nil,                           // 16: bio.route.GRPPath.MetaDataEntry
+	(*api.Prefix)(nil),            // 17: bio.net.Prefix
+	(*api.IP)(nil),                // 18: bio.net.IP
 }
 var file_route_api_route_proto_depIdxs = []int32{
-	11, // 0: bio.route.Route.pfx:type_name -> bio.net.Prefix
+	17, // 0: bio.route.Route.pfx:type_name -> bio.net.Prefix
 	3,  // 1: bio.route.Route.paths:type_name -> bio.route.Path
 	0,  // 2: bio.route.Path.type:type_name -> bio.route.Path.Type
-	4,  // 3: bio.route.Path.static_path:type_name -> bio.route.StaticPath
-	6,  // 4: bio.route.Path.bgp_path:type_name -> bio.route.BGPPath
+	5,  // 3: bio.route.Path.static_path:type_name -> bio.route.StaticPath
+	7,  // 4: bio.route.Path.bgp_path:type_name -> bio.route.BGPPath
 	1,  // 5: bio.route.Path.hidden_reason:type_name -> bio.route.Path.HiddenReason
-	5,  // 6: bio.route.Path.grp_path:type_name -> bio.route.GRPPath
-	12, // 7: bio.route.StaticPath.next_hop:type_name -> bio.net.IP
-	12, // 8: bio.route.GRPPath.next_hop:type_name -> bio.net.IP
-	10, // 9: bio.route.GRPPath.meta_data:type_name -> bio.route.GRPPath.MetaDataEntry
-	12, // 10: bio.route.BGPPath.next_hop:type_name -> bio.net.IP
-	7,  // 11: bio.route.BGPPath.as_path:type_name -> bio.route.ASPathSegment
-	12, // 12: bio.route.BGPPath.source:type_name -> bio.net.IP
-	8,  // 13: bio.route.BGPPath.large_communities:type_name -> bio.route.LargeCommunity
-	9,  // 14: bio.route.BGPPath.unknown_attributes:type_name -> bio.route.UnknownPathAttribute
-	15, // [15:15] is the sub-list for method output_type
-	15, // [15:15] is the sub-list for method input_type
-	15, // [15:15] is the sub-list for extension type_name
-	15, // [15:15] is the sub-list for extension extendee
-	0,  // [0:15] is the sub-list for field type_name
+	6,  // 6: bio.route.Path.grp_path:type_name -> bio.route.GRPPath
+	4,  // 7: bio.route.Path.dampening:type_name -> bio.route.DampeningState
+	18, // 8: bio.route.StaticPath.next_hop:type_name -> bio.net.IP
+	18, // 9: bio.route.GRPPath.next_hop:type_name -> bio.net.IP
+	16, // 10: bio.route.GRPPath.meta_data:type_name -> bio.route.GRPPath.MetaDataEntry
+	18, // 11: bio.route.BGPPath.next_hop:type_name -> bio.net.IP
+	8,  // 12: bio.route.BGPPath.as_path:type_name -> bio.route.ASPathSegment
+	18, // 13: bio.route.BGPPath.source:type_name -> bio.net.IP
+	9,  // 14: bio.route.BGPPath.large_communities:type_name -> bio.route.LargeCommunity
+	15, // 15: bio.route.BGPPath.unknown_attributes:type_name -> bio.route.UnknownPathAttribute
+	10, // 16: bio.route.BGPPath.extended_communities:type_name -> bio.route.ExtendedCommunity
+	11, // 17: bio.route.BGPPath.ipv6_extended_communities:type_name -> bio.route.IPv6ExtendedCommunity
+	12, // 18: bio.route.BGPPath.flowspec_rule:type_name -> bio.route.FlowSpecRule
+	18, // 19: bio.route.IPv6ExtendedCommunity.global_administrator:type_name -> bio.net.IP
+	13, // 20: bio.route.FlowSpecRule.components:type_name -> bio.route.FlowSpecComponent
+	17, // 21: bio.route.FlowSpecComponent.prefix:type_name -> bio.net.Prefix
+	14, // 22: bio.route.FlowSpecComponent.operations:type_name -> bio.route.FlowSpecOperation
+	23, // [23:23] is the sub-list for method output_type
+	23, // [23:23] is the sub-list for method input_type
+	23, // [23:23] is the sub-list for extension type_name
+	23, // [23:23] is the sub-list for extension extendee
+	0,  // [0:23] is the sub-list for field type_name
 }
 
 func init() { file_route_api_route_proto_init() }
@@ -949,7 +1520,7 @@ func file_route_api_route_proto_init() {
 			}
 		}
 		file_route_api_route_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StaticPath); i {
+			switch v := v.(*DampeningState); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -961,7 +1532,7 @@ func file_route_api_route_proto_init() {
 			}
 		}
 		file_route_api_route_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GRPPath); i {
+			switch v := v.(*StaticPath); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -973,7 +1544,7 @@ func file_route_api_route_proto_init() {
 			}
 		}
 		file_route_api_route_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BGPPath); i {
+			switch v := v.(*GRPPath); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -985,7 +1556,7 @@ func file_route_api_route_proto_init() {
 			}
 		}
 		file_route_api_route_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ASPathSegment); i {
+			switch v := v.(*BGPPath); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -997,7 +1568,7 @@ func file_route_api_route_proto_init() {
 			}
 		}
 		file_route_api_route_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LargeCommunity); i {
+			switch v := v.(*ASPathSegment); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1009,6 +1580,78 @@ func file_route_api_route_proto_init() {
 			}
 		}
 		file_route_api_route_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LargeCommunity); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_route_api_route_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExtendedCommunity); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_route_api_route_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IPv6ExtendedCommunity); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_route_api_route_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FlowSpecRule); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_route_api_route_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FlowSpecComponent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_route_api_route_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FlowSpecOperation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_route_api_route_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*UnknownPathAttribute); i {
 			case 0:
 				return &v.state
@@ -1027,7 +1670,7 @@ func file_route_api_route_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_route_api_route_proto_rawDesc,
 			NumEnums:      2,
-			NumMessages:   9,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -14,15 +14,25 @@ import (
 
 // BGPPath represents a set of BGP path attributes
 type BGPPath struct {
-	BGPPathA          *BGPPathA
-	ASPath            *types.ASPath
-	ClusterList       *types.ClusterList
-	Communities       *types.Communities
-	LargeCommunities  *types.LargeCommunities
-	UnknownAttributes []types.UnknownPathAttribute
-	PathIdentifier    uint32
-	ASPathLen         uint16
-	BMPPostPolicy     bool // BMPPostPolicy fields is a hack used in BMP to differentiate between pre/post policy routes (L flag of the per peer header)
+	BGPPathA                *BGPPathA
+	ASPath                  *types.ASPath
+	ClusterList             *types.ClusterList
+	Communities             *types.Communities
+	LargeCommunities        *types.LargeCommunities
+	ExtendedCommunities     *types.ExtendedCommunities
+	IPv6ExtendedCommunities *types.IPv6ExtendedCommunities
+	UnknownAttributes       []types.UnknownPathAttribute
+	PathIdentifier          uint32
+	RouteDistinguisher      uint64              // RouteDistinguisher is only set for VPN paths (RFC4364)
+	LabelStack              []uint32            // LabelStack holds the MPLS labels of labeled paths (RFC8277)
+	FlowSpecRule            *types.FlowSpecRule // FlowSpecRule is only set for FlowSpec paths (RFC8955)
+	ASPathLen               uint16
+	RPKIValidationState     uint8  // RPKIValidationState is the result of the origin validation of the path (RFC6811)
+	ASPAVerificationState   uint8  // ASPAVerificationState is the result of the ASPA based verification of the AS path
+	IGPMetric               uint32 // IGPMetric is the interior cost to reach the next hop (RFC4271 Sect. 9.1.2.2 e)
+	BMPPostPolicy           bool   // BMPPostPolicy fields is a hack used in BMP to differentiate between pre/post policy routes (L flag of the per peer header)
+	LocalAggregate          bool   // LocalAggregate is set for aggregates originated by this router
+	LocalVPNExport          bool   // LocalVPNExport is set for paths exported into a VPN RIB from one of our VRFs
 }
 
 // BGPPathA represents cachable BGP path attributes
@@ -72,9 +82,19 @@ func (b *BGPPath) ToProto() *api.BGPPath {
 	}
 
 	a := &api.BGPPath{
-		PathIdentifier:    b.PathIdentifier,
-		UnknownAttributes: make([]*api.UnknownPathAttribute, len(b.UnknownAttributes)),
-		BmpPostPolicy:     b.BMPPostPolicy,
+		PathIdentifier:     b.PathIdentifier,
+		UnknownAttributes:  make([]*api.UnknownPathAttribute, len(b.UnknownAttributes)),
+		BmpPostPolicy:      b.BMPPostPolicy,
+		RouteDistinguisher: b.RouteDistinguisher,
+	}
+
+	if len(b.LabelStack) > 0 {
+		a.LabelStack = make([]uint32, len(b.LabelStack))
+		copy(a.LabelStack, b.LabelStack)
+	}
+
+	if b.FlowSpecRule != nil {
+		a.FlowspecRule = b.FlowSpecRule.ToProto()
 	}
 
 	if b.BGPPathA != nil {
@@ -116,6 +136,20 @@ func (b *BGPPath) ToProto() *api.BGPPath {
 		}
 	}
 
+	if b.ExtendedCommunities != nil {
+		a.ExtendedCommunities = make([]*api.ExtendedCommunity, len(*b.ExtendedCommunities))
+		for i := range *b.ExtendedCommunities {
+			a.ExtendedCommunities[i] = (*b.ExtendedCommunities)[i].ToProto()
+		}
+	}
+
+	if b.IPv6ExtendedCommunities != nil {
+		a.Ipv6ExtendedCommunities = make([]*api.IPv6ExtendedCommunity, len(*b.IPv6ExtendedCommunities))
+		for i := range *b.IPv6ExtendedCommunities {
+			a.Ipv6ExtendedCommunities[i] = (*b.IPv6ExtendedCommunities)[i].ToProto()
+		}
+	}
+
 	for i := range b.UnknownAttributes {
 		a.UnknownAttributes[i] = b.UnknownAttributes[i].ToProto()
 	}
@@ -138,10 +172,20 @@ func BGPPathFromProtoBGPPath(pb *api.BGPPath, dedup bool) *BGPPath {
 			Source:         bnet.IPFromProtoIP(pb.Source).Ptr(),
 			OnlyToCustomer: pb.OnlyToCustomer,
 		},
-		PathIdentifier: pb.PathIdentifier,
-		ASPath:         asPath,
-		ASPathLen:      asPath.Length(),
-		BMPPostPolicy:  pb.BmpPostPolicy,
+		PathIdentifier:     pb.PathIdentifier,
+		ASPath:             asPath,
+		ASPathLen:          asPath.Length(),
+		BMPPostPolicy:      pb.BmpPostPolicy,
+		RouteDistinguisher: pb.RouteDistinguisher,
+	}
+
+	if len(pb.LabelStack) > 0 {
+		p.LabelStack = make([]uint32, len(pb.LabelStack))
+		copy(p.LabelStack, pb.LabelStack)
+	}
+
+	if pb.FlowspecRule != nil {
+		p.FlowSpecRule = types.FlowSpecRuleFromProtoFlowSpecRule(pb.FlowspecRule)
 	}
 
 	if dedup {
@@ -163,6 +207,24 @@ func BGPPathFromProtoBGPPath(pb *api.BGPPath, dedup bool) *BGPPath {
 		}
 	}
 
+	if len(pb.ExtendedCommunities) > 0 {
+		extendedCommunities := make(types.ExtendedCommunities, len(pb.ExtendedCommunities))
+		p.ExtendedCommunities = &extendedCommunities
+
+		for i := range pb.ExtendedCommunities {
+			(*p.ExtendedCommunities)[i] = types.ExtendedCommunityFromProtoExtendedCommunity(pb.ExtendedCommunities[i])
+		}
+	}
+
+	if len(pb.Ipv6ExtendedCommunities) > 0 {
+		ipv6ExtendedCommunities := make(types.IPv6ExtendedCommunities, len(pb.Ipv6ExtendedCommunities))
+		p.IPv6ExtendedCommunities = &ipv6ExtendedCommunities
+
+		for i := range pb.Ipv6ExtendedCommunities {
+			(*p.IPv6ExtendedCommunities)[i] = types.IPv6ExtendedCommunityFromProtoIPv6ExtendedCommunity(pb.Ipv6ExtendedCommunities[i])
+		}
+	}
+
 	if len(pb.UnknownAttributes) > 0 {
 		unknownAttr := make([]types.UnknownPathAttribute, len(pb.UnknownAttributes))
 		p.UnknownAttributes = unknownAttr
@@ -199,6 +261,16 @@ func (b *BGPPath) Length() uint16 {
 		largeCommunitiesLen += 3 + uint16(len(*b.LargeCommunities)*12)
 	}
 
+	extendedCommunitiesLen := uint16(0)
+	if b.ExtendedCommunities != nil && len(*b.ExtendedCommunities) != 0 {
+		extendedCommunitiesLen += 3 + uint16(len(*b.ExtendedCommunities)*8)
+	}
+
+	ipv6ExtendedCommunitiesLen := uint16(0)
+	if b.IPv6ExtendedCommunities != nil && len(*b.IPv6ExtendedCommunities) != 0 {
+		ipv6ExtendedCommunitiesLen += 3 + uint16(len(*b.IPv6ExtendedCommunities)*20)
+	}
+
 	clusterListLen := uint16(0)
 	if b.ClusterList != nil && len(*b.ClusterList) != 0 {
 		clusterListLen += 3 + uint16(len(*b.ClusterList)*4)
@@ -221,7 +293,7 @@ func (b *BGPPath) Length() uint16 {
 		}
 	}
 
-	return 4*7 + 4 + asPathLen + communitiesLen + largeCommunitiesLen + clusterListLen + originatorID + onlyToCustomer + unknownAttributesLen
+	return 4*7 + 4 + asPathLen + communitiesLen + largeCommunitiesLen + extendedCommunitiesLen + ipv6ExtendedCommunitiesLen + clusterListLen + originatorID + onlyToCustomer + unknownAttributesLen
 }
 
 // ECMP determines if routes b and c are euqal in terms of ECMP
@@ -238,6 +310,38 @@ func (b *BGPPath) Compare(c *BGPPath) bool {
 		return false
 	}
 
+	if b.RouteDistinguisher != c.RouteDistinguisher {
+		return false
+	}
+
+	if b.RPKIValidationState != c.RPKIValidationState {
+		return false
+	}
+
+	if b.ASPAVerificationState != c.ASPAVerificationState {
+		return false
+	}
+
+	if b.IGPMetric != c.IGPMetric {
+		return false
+	}
+
+	if b.LocalAggregate != c.LocalAggregate {
+		return false
+	}
+
+	if b.LocalVPNExport != c.LocalVPNExport {
+		return false
+	}
+
+	if !b.compareLabelStack(c) {
+		return false
+	}
+
+	if !b.FlowSpecRule.Equal(c.FlowSpecRule) {
+		return false
+	}
+
 	if !b.BGPPathA.compare(c.BGPPathA) {
 		return false
 	}
@@ -258,6 +362,14 @@ func (b *BGPPath) Compare(c *BGPPath) bool {
 		return false
 	}
 
+	if !b.compareExtendedCommunities(c) {
+		return false
+	}
+
+	if !b.compareIPv6ExtendedCommunities(c) {
+		return false
+	}
+
 	if !b.compareUnknownAttributes(c) {
 		return false
 	}
@@ -265,6 +377,20 @@ func (b *BGPPath) Compare(c *BGPPath) bool {
 	return true
 }
 
+func (b *BGPPath) compareLabelStack(c *BGPPath) bool {
+	if len(b.LabelStack) != len(c.LabelStack) {
+		return false
+	}
+
+	for i := range b.LabelStack {
+		if b.LabelStack[i] != c.LabelStack[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
 func (b *BGPPath) compareCommunities(c *BGPPath) bool {
 	if b.Communities == nil && c.Communities == nil {
 		return true
@@ -343,6 +469,50 @@ func (b *BGPPath) compareLargeCommunities(c *BGPPath) bool {
 	return true
 }
 
+func (b *BGPPath) compareExtendedCommunities(c *BGPPath) bool {
+	if b.ExtendedCommunities == nil && c.ExtendedCommunities == nil {
+		return true
+	}
+
+	if b.ExtendedCommunities == nil || c.ExtendedCommunities == nil {
+		return false
+	}
+
+	if len(*b.ExtendedCommunities) != len(*c.ExtendedCommunities) {
+		return false
+	}
+
+	for i := range *b.ExtendedCommunities {
+		if (*b.ExtendedCommunities)[i] != (*c.ExtendedCommunities)[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (b *BGPPath) compareIPv6ExtendedCommunities(c *BGPPath) bool {
+	if b.IPv6ExtendedCommunities == nil && c.IPv6ExtendedCommunities == nil {
+		return true
+	}
+
+	if b.IPv6ExtendedCommunities == nil || c.IPv6ExtendedCommunities == nil {
+		return false
+	}
+
+	if len(*b.IPv6ExtendedCommunities) != len(*c.IPv6ExtendedCommunities) {
+		return false
+	}
+
+	for i := range *b.IPv6ExtendedCommunities {
+		if (*b.IPv6ExtendedCommunities)[i] != (*c.IPv6ExtendedCommunities)[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
 func (b *BGPPath) compareUnknownAttributes(c *BGPPath) bool {
 	if len(b.UnknownAttributes) != len(c.UnknownAttributes) {
 		return false
@@ -393,6 +563,10 @@ func (b *BGPPath) Equal(c *BGPPath) bool {
 		return false
 	}
 
+	if !b.SameRoute(c) {
+		return false
+	}
+
 	return b.Select(c) == 0
 }
 
@@ -444,7 +618,14 @@ func (b *BGPPath) Select(c *BGPPath) int8 {
 		return 1
 	}
 
-	// e) TODO: interior cost (hello IS-IS and OSPF)
+	// e)
+	if c.IGPMetric < b.IGPMetric {
+		return -1
+	}
+
+	if c.IGPMetric > b.IGPMetric {
+		return 1
+	}
 
 	// f) + RFC4456 9. (Route Reflection)
 	bgpIdentifierC := c.BGPPathA.BGPIdentifier
@@ -576,6 +757,24 @@ func (b *BGPPath) String() string {
 	fmt.Fprintf(buf, "NEXT HOP: %s, ", b.BGPPathA.NextHop)
 	fmt.Fprintf(buf, "MED: %d, ", b.BGPPathA.MED)
 	fmt.Fprintf(buf, "Path ID: %d, ", b.PathIdentifier)
+	if b.RouteDistinguisher != 0 {
+		fmt.Fprintf(buf, "RD: %s, ", b.RouteDistinguisherString())
+	}
+	if len(b.LabelStack) > 0 {
+		fmt.Fprintf(buf, "Labels: %v, ", b.LabelStack)
+	}
+	if b.FlowSpecRule != nil {
+		fmt.Fprintf(buf, "FlowSpec: %s, ", b.FlowSpecRule.String())
+	}
+	if b.RPKIValidationState != RPKIValidationUnverified {
+		fmt.Fprintf(buf, "RPKI: %s, ", RPKIValidationStateString(b.RPKIValidationState))
+	}
+	if b.ASPAVerificationState != ASPAVerificationUnverified {
+		fmt.Fprintf(buf, "ASPA: %s, ", ASPAVerificationStateString(b.ASPAVerificationState))
+	}
+	if b.IGPMetric != 0 {
+		fmt.Fprintf(buf, "IGP Metric: %d, ", b.IGPMetric)
+	}
 	fmt.Fprintf(buf, "Source: %s, ", b.BGPPathA.Source)
 	if b.BGPPathA.OnlyToCustomer != 0 {
 		fmt.Fprintf(buf, "OnlyToCustomer: %d, ", b.BGPPathA.OnlyToCustomer)
@@ -586,6 +785,12 @@ func (b *BGPPath) String() string {
 	if b.LargeCommunities != nil {
 		fmt.Fprintf(buf, "LargeCommunities: %v", *b.LargeCommunities)
 	}
+	if b.ExtendedCommunities != nil {
+		fmt.Fprintf(buf, ", ExtendedCommunities: %s", b.ExtendedCommunities.String())
+	}
+	if b.IPv6ExtendedCommunities != nil {
+		fmt.Fprintf(buf, ", IPv6ExtendedCommunities: %s", b.IPv6ExtendedCommunities.String())
+	}
 
 	if b.BGPPathA.OriginatorID != 0 {
 		oid := convert.Uint32Byte(b.BGPPathA.OriginatorID)
@@ -624,6 +829,24 @@ func (b *BGPPath) Print() string {
 	fmt.Fprintf(buf, "\t\tNEXT HOP: %s\n", b.BGPPathA.NextHop)
 	fmt.Fprintf(buf, "\t\tMED: %d\n", b.BGPPathA.MED)
 	fmt.Fprintf(buf, "\t\tPath ID: %d\n", b.PathIdentifier)
+	if b.RouteDistinguisher != 0 {
+		fmt.Fprintf(buf, "\t\tRD: %s\n", b.RouteDistinguisherString())
+	}
+	if len(b.LabelStack) > 0 {
+		fmt.Fprintf(buf, "\t\tLabels: %v\n", b.LabelStack)
+	}
+	if b.FlowSpecRule != nil {
+		fmt.Fprintf(buf, "\t\tFlowSpec: %s\n", b.FlowSpecRule.String())
+	}
+	if b.RPKIValidationState != RPKIValidationUnverified {
+		fmt.Fprintf(buf, "\t\tRPKI: %s\n", RPKIValidationStateString(b.RPKIValidationState))
+	}
+	if b.ASPAVerificationState != ASPAVerificationUnverified {
+		fmt.Fprintf(buf, "\t\tASPA: %s\n", ASPAVerificationStateString(b.ASPAVerificationState))
+	}
+	if b.IGPMetric != 0 {
+		fmt.Fprintf(buf, "\t\tIGP Metric: %d\n", b.IGPMetric)
+	}
 	fmt.Fprintf(buf, "\t\tSource: %s\n", b.BGPPathA.Source)
 	if b.BGPPathA.OnlyToCustomer != 0 {
 		fmt.Fprintf(buf, "\t\tOnlyToCustomer: %d\n", b.BGPPathA.OnlyToCustomer)
@@ -634,6 +857,12 @@ func (b *BGPPath) Print() string {
 	if b.LargeCommunities != nil {
 		fmt.Fprintf(buf, "\t\tLargeCommunities: %v\n", *b.LargeCommunities)
 	}
+	if b.ExtendedCommunities != nil {
+		fmt.Fprintf(buf, "\t\tExtendedCommunities: %s\n", b.ExtendedCommunities.String())
+	}
+	if b.IPv6ExtendedCommunities != nil {
+		fmt.Fprintf(buf, "\t\tIPv6ExtendedCommunities: %s\n", b.IPv6ExtendedCommunities.String())
+	}
 
 	if b.BGPPathA.OriginatorID != 0 {
 		oid := convert.Uint32Byte(b.BGPPathA.OriginatorID)
@@ -714,12 +943,31 @@ func (b *BGPPath) Copy() *BGPPath {
 		copy(*cp.LargeCommunities, *b.LargeCommunities)
 	}
 
+	if cp.ExtendedCommunities != nil {
+		extendedCommunities := make(types.ExtendedCommunities, len(*cp.ExtendedCommunities))
+		cp.ExtendedCommunities = &extendedCommunities
+		copy(*cp.ExtendedCommunities, *b.ExtendedCommunities)
+	}
+
+	if cp.IPv6ExtendedCommunities != nil {
+		ipv6ExtendedCommunities := make(types.IPv6ExtendedCommunities, len(*cp.IPv6ExtendedCommunities))
+		cp.IPv6ExtendedCommunities = &ipv6ExtendedCommunities
+		copy(*cp.IPv6ExtendedCommunities, *b.IPv6ExtendedCommunities)
+	}
+
 	if b.ClusterList != nil {
 		clusterList := make(types.ClusterList, len(*cp.ClusterList))
 		cp.ClusterList = &clusterList
 		copy(*cp.ClusterList, *b.ClusterList)
 	}
 
+	if b.LabelStack != nil {
+		cp.LabelStack = make([]uint32, len(b.LabelStack))
+		copy(cp.LabelStack, b.LabelStack)
+	}
+
+	cp.FlowSpecRule = b.FlowSpecRule.Copy()
+
 	return &cp
 }
 
@@ -735,7 +983,7 @@ func (bpa *BGPPathA) Copy() *BGPPathA {
 
 // ComputeHash computes an hash over all attributes of the path
 func (b *BGPPath) ComputeHash() string {
-	s := fmt.Sprintf("%s\t%d\t%s\t%d\t%d\t%v\t%d\t%s\t%s\t%s\t%d\t%s",
+	s := fmt.Sprintf("%s\t%d\t%s\t%d\t%d\t%v\t%d\t%s\t%s\t%s\t%s\t%s\t%d\t%s\t%d\t%v\t%s",
 		b.BGPPathA.NextHop.String(),
 		b.BGPPathA.LocalPref,
 		b.ASPath.String(),
@@ -746,15 +994,20 @@ func (b *BGPPath) ComputeHash() string {
 		b.BGPPathA.Source.String(),
 		b.Communities.String(),
 		b.LargeCommunities.String(),
+		b.ExtendedCommunities.String(),
+		b.IPv6ExtendedCommunities.String(),
 		b.BGPPathA.OriginatorID,
-		b.ClusterList.String())
+		b.ClusterList.String(),
+		b.RouteDistinguisher,
+		b.LabelStack,
+		b.FlowSpecRule.String())
 
 	return fmt.Sprintf("%x", sha256.Sum256([]byte(s)))
 }
 
 // ComputeHash computes an hash over all attributes of the path
 func (b *BGPPath) ComputeHashWithPathID() string {
-	s := fmt.Sprintf("%s\t%d\t%s\t%d\t%d\t%v\t%d\t%s\t%s\t%s\t%d\t%d\t%s",
+	s := fmt.Sprintf("%s\t%d\t%s\t%d\t%d\t%v\t%d\t%s\t%s\t%s\t%s\t%s\t%d\t%d\t%s\t%d\t%v\t%s",
 		b.BGPPathA.NextHop.String(),
 		b.BGPPathA.LocalPref,
 		b.ASPath.String(),
@@ -765,13 +1018,34 @@ func (b *BGPPath) ComputeHashWithPathID() string {
 		b.BGPPathA.Source.String(),
 		b.Communities.String(),
 		b.LargeCommunities.String(),
+		b.ExtendedCommunities.String(),
+		b.IPv6ExtendedCommunities.String(),
 		b.PathIdentifier,
 		b.BGPPathA.OriginatorID,
-		b.ClusterList.String())
+		b.ClusterList.String(),
+		b.RouteDistinguisher,
+		b.LabelStack,
+		b.FlowSpecRule.String())
 
 	return fmt.Sprintf("%x", sha256.Sum256([]byte(s)))
 }
 
+// RouteDistinguisherString returns the formated route distinguisher
+func (b *BGPPath) RouteDistinguisherString() string {
+	return fmt.Sprintf("%d:%d", b.RouteDistinguisher>>32, b.RouteDistinguisher&0xffffffff)
+}
+
+// SameRoute checks if b and c are paths of the same route of a prefix. VPN routes are identified by
+// route distinguisher and prefix (RFC4364 Sect. 4.1), FlowSpec routes by their rule (RFC8955 Sect. 4).
+func (b *BGPPath) SameRoute(c *BGPPath) bool {
+	return b.RouteDistinguisher == c.RouteDistinguisher && b.FlowSpecRule.Equal(c.FlowSpecRule)
+}
+
+// QualifiesPrefix checks if the path belongs to a route not identified by its prefix alone
+func (b *BGPPath) QualifiesPrefix() bool {
+	return b.RouteDistinguisher != 0 || b.FlowSpecRule != nil
+}
+
 // CommunitiesString returns the formated communities
 func (b *BGPPath) CommunitiesString() string {
 	str := &strings.Builder{}
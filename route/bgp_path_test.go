@@ -40,6 +40,21 @@ func TestBGPPathFromProtoBGPPath(t *testing.T) {
 				DataPart2:           666,
 			},
 		},
+		ExtendedCommunities: []*api.ExtendedCommunity{
+			{
+				Type:    0,
+				SubType: 2,
+				Value:   0xfde800000064,
+			},
+		},
+		Ipv6ExtendedCommunities: []*api.IPv6ExtendedCommunity{
+			{
+				Type:                0,
+				SubType:             3,
+				GlobalAdministrator: bnet.IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 1).ToProto(),
+				LocalAdministrator:  100,
+			},
+		},
 		UnknownAttributes: []*api.UnknownPathAttribute{
 			{
 				Optional:   true,
@@ -90,6 +105,17 @@ func TestBGPPathFromProtoBGPPath(t *testing.T) {
 				DataPart2:           666,
 			},
 		},
+		ExtendedCommunities: &types.ExtendedCommunities{
+			types.NewRouteTarget(65000, false, 100),
+		},
+		IPv6ExtendedCommunities: &types.IPv6ExtendedCommunities{
+			{
+				Type:                types.IPv6ExtendedCommunityTypeIPv6Address,
+				SubType:             types.ExtendedCommunitySubTypeRouteOrigin,
+				GlobalAdministrator: bnet.IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 1),
+				LocalAdministrator:  100,
+			},
+		},
 		UnknownAttributes: []types.UnknownPathAttribute{
 			{
 				Optional:   true,
@@ -160,12 +186,115 @@ func TestBGPPathToProto(t *testing.T) {
 				Source:            bnet.IPv4FromOctets(10, 0, 0, 2).ToProto(),
 			},
 		},
+		{
+			name: "Path with extended communities",
+			value: &BGPPath{
+				PathIdentifier: 1,
+				ExtendedCommunities: &types.ExtendedCommunities{
+					types.NewRouteOrigin(65000, false, 100),
+				},
+			},
+			expected: &api.BGPPath{
+				PathIdentifier:    1,
+				UnknownAttributes: make([]*api.UnknownPathAttribute, 0),
+				ExtendedCommunities: []*api.ExtendedCommunity{
+					{
+						Type:    0,
+						SubType: 3,
+						Value:   0xfde800000064,
+					},
+				},
+			},
+		},
+		{
+			name: "FlowSpec path",
+			value: &BGPPath{
+				FlowSpecRule: &types.FlowSpecRule{
+					Components: []types.FlowSpecComponent{
+						{
+							Type:   types.FlowSpecComponentDestinationPrefix,
+							Prefix: bnet.NewPfx(bnet.IPv4FromOctets(192, 0, 2, 0), 24).Ptr(),
+						},
+						{
+							Type:       types.FlowSpecComponentDestinationPort,
+							Operations: []types.FlowSpecOperation{{Equal: true, Value: 53}},
+						},
+					},
+				},
+			},
+			expected: &api.BGPPath{
+				UnknownAttributes: make([]*api.UnknownPathAttribute, 0),
+				FlowspecRule: &api.FlowSpecRule{
+					Components: []*api.FlowSpecComponent{
+						{
+							Type:   types.FlowSpecComponentDestinationPrefix,
+							Prefix: bnet.NewPfx(bnet.IPv4FromOctets(192, 0, 2, 0), 24).ToProto(),
+						},
+						{
+							Type:       types.FlowSpecComponentDestinationPort,
+							Operations: []*api.FlowSpecOperation{{Equal: true, Value: 53}},
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, test := range tests {
 		assert.Equal(t, test.expected, test.value.ToProto(), test.name)
 	}
 }
 
+func TestBGPPathSameRoute(t *testing.T) {
+	rule := func(port uint64) *types.FlowSpecRule {
+		return &types.FlowSpecRule{
+			Components: []types.FlowSpecComponent{
+				{
+					Type:       types.FlowSpecComponentDestinationPort,
+					Operations: []types.FlowSpecOperation{{Equal: true, Value: port}},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		a        *BGPPath
+		b        *BGPPath
+		expected bool
+	}{
+		{
+			name:     "Unicast paths",
+			a:        &BGPPath{},
+			b:        &BGPPath{},
+			expected: true,
+		},
+		{
+			name:     "Different route distinguishers",
+			a:        &BGPPath{RouteDistinguisher: 1},
+			b:        &BGPPath{RouteDistinguisher: 2},
+			expected: false,
+		},
+		{
+			name:     "Same FlowSpec rule",
+			a:        &BGPPath{FlowSpecRule: rule(53)},
+			b:        &BGPPath{FlowSpecRule: rule(53)},
+			expected: true,
+		},
+		{
+			name:     "Different FlowSpec rules",
+			a:        &BGPPath{FlowSpecRule: rule(53)},
+			b:        &BGPPath{FlowSpecRule: rule(123)},
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.a.SameRoute(test.b))
+		})
+	}
+}
+
 func TestBGPSelect(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -353,6 +482,42 @@ func TestBGPSelect(t *testing.T) {
 			},
 			expected: -1,
 		},
+		{
+			name: "IGP metric",
+			p: &BGPPath{
+				BGPPathA: &BGPPathA{
+					Source:  bnet.IPv4(0).Ptr(),
+					NextHop: bnet.IPv4(0).Ptr(),
+				},
+				IGPMetric: 10,
+			},
+			q: &BGPPath{
+				BGPPathA: &BGPPathA{
+					Source:  bnet.IPv4(0).Ptr(),
+					NextHop: bnet.IPv4(0).Ptr(),
+				},
+				IGPMetric: 20,
+			},
+			expected: 1,
+		},
+		{
+			name: "IGP metric #2",
+			p: &BGPPath{
+				BGPPathA: &BGPPathA{
+					Source:  bnet.IPv4(0).Ptr(),
+					NextHop: bnet.IPv4(0).Ptr(),
+				},
+				IGPMetric: 20,
+			},
+			q: &BGPPath{
+				BGPPathA: &BGPPathA{
+					Source:  bnet.IPv4(0).Ptr(),
+					NextHop: bnet.IPv4(0).Ptr(),
+				},
+				IGPMetric: 10,
+			},
+			expected: -1,
+		},
 	}
 
 	for _, test := range tests {
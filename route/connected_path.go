@@ -0,0 +1,61 @@
+package route
+
+import (
+	"fmt"
+	"strings"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+)
+
+// ConnectedPath represents a path to a network directly connected to an interface
+type ConnectedPath struct {
+	Interface string
+	Address   *bnet.IP // Address of the interface within the network
+}
+
+// Select returns negative if s < t, 0 if paths are equal, positive if s > t
+func (s *ConnectedPath) Select(t *ConnectedPath) int8 {
+	c := strings.Compare(s.Interface, t.Interface)
+	if c != 0 {
+		return int8(c)
+	}
+
+	return s.Address.Compare(t.Address)
+}
+
+// Equal returns true if s and t are equal
+func (s *ConnectedPath) Equal(t *ConnectedPath) bool {
+	if s == nil || t == nil {
+		return false
+	}
+
+	return s.Select(t) == 0
+}
+
+// ECMP determines if path s and t are equal in terms of ECMP
+func (s *ConnectedPath) ECMP(t *ConnectedPath) bool {
+	return true
+}
+
+// Copy duplicates the current object
+func (s *ConnectedPath) Copy() *ConnectedPath {
+	if s == nil {
+		return nil
+	}
+
+	cp := *s
+	return &cp
+}
+
+// Print all known information about a route in logfile friendly format
+func (s *ConnectedPath) String() string {
+	return fmt.Sprintf("Interface: %s, Address: %s", s.Interface, s.Address.String())
+}
+
+// Print all known information about a route in human readable form
+func (s *ConnectedPath) Print() string {
+	ret := fmt.Sprintf("\t\tInterface: %s\n", s.Interface)
+	ret += fmt.Sprintf("\t\tAddress: %s\n", s.Address.String())
+
+	return ret
+}
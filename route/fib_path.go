@@ -6,8 +6,14 @@ import (
 	bnet "github.com/bio-routing/bio-rd/net"
 )
 
+// Route protocol IDs of the OS routing table (see /etc/iproute2/rt_protos)
 const (
-	ProtoBio = 45 // bio
+	ProtoKernel = 2  // kernel
+	ProtoBoot   = 3  // boot
+	ProtoStatic = 4  // static
+	ProtoRA     = 9  // ra
+	ProtoDHCP   = 16 // dhcp
+	ProtoBio    = 45 // bio
 )
 
 // FIBPath represents a path learned via Netlink of a route
@@ -33,20 +39,14 @@ func NewNlPathFromBgpPath(p *BGPPath) *FIBPath {
 
 // Select compares s with t and returns negative if s < t, 0 if paths are equal, positive if s > t
 func (s *FIBPath) Select(t *FIBPath) int8 {
-	if s.NextHop.Compare(t.NextHop) < 0 {
-		return -1
+	c := compareOptionalIPs(s.NextHop, t.NextHop)
+	if c != 0 {
+		return c
 	}
 
-	if s.NextHop.Compare(t.NextHop) > 0 {
-		return 1
-	}
-
-	if s.Src.Compare(t.Src) < 0 {
-		return -1
-	}
-
-	if s.Src.Compare(t.Src) > 0 {
-		return 1
+	c = compareOptionalIPs(s.Src, t.Src)
+	if c != 0 {
+		return c
 	}
 
 	if s.Priority < t.Priority {
@@ -78,7 +78,29 @@ func (s *FIBPath) Select(t *FIBPath) int8 {
 
 // ECMP determines if path s and t are equal in terms of ECMP
 func (s *FIBPath) ECMP(t *FIBPath) bool {
-	return s.Src.Compare(t.Src) == 0 && s.Priority == t.Priority && s.Protocol == t.Protocol && s.Type == t.Type && s.Table == t.Table
+	return compareOptionalIPs(s.Src, t.Src) == 0 && s.Priority == t.Priority && s.Protocol == t.Protocol && s.Type == t.Type && s.Table == t.Table
+}
+
+// compareOptionalIPs compares two IPs which might be absent, e.g. the gateway of a device route
+func compareOptionalIPs(a, b *bnet.IP) int8 {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+
+	return a.Compare(b)
+}
+
+func optionalIPString(addr *bnet.IP) string {
+	if addr == nil {
+		return "none"
+	}
+
+	return addr.String()
 }
 
 // Copy duplicates the current object
@@ -93,8 +115,8 @@ func (s *FIBPath) Copy() *FIBPath {
 
 // Print all known information about a route in logfile friendly format
 func (s *FIBPath) String() string {
-	ret := fmt.Sprintf("Source: %s, ", s.Src.String())
-	ret += fmt.Sprintf("NextHop: %s, ", s.NextHop.String())
+	ret := fmt.Sprintf("Source: %s, ", optionalIPString(s.Src))
+	ret += fmt.Sprintf("NextHop: %s, ", optionalIPString(s.NextHop))
 	ret += fmt.Sprintf("Priority: %d, ", s.Priority)
 	ret += fmt.Sprintf("Type: %d, ", s.Type)
 	ret += fmt.Sprintf("Table: %d", s.Table)
@@ -104,8 +126,8 @@ func (s *FIBPath) String() string {
 
 // Print all known information about a route in human readable form
 func (s *FIBPath) Print() string {
-	ret := fmt.Sprintf("\t\tSource: %s\n", s.Src.String())
-	ret += fmt.Sprintf("\t\tNextHop: %s\n", s.NextHop.String())
+	ret := fmt.Sprintf("\t\tSource: %s\n", optionalIPString(s.Src))
+	ret += fmt.Sprintf("\t\tNextHop: %s\n", optionalIPString(s.NextHop))
 	ret += fmt.Sprintf("\t\tPriority: %d\n", s.Priority)
 	ret += fmt.Sprintf("\t\tType: %d\n", s.Type)
 	ret += fmt.Sprintf("\t\tTable: %d\n", s.Table)
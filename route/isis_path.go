@@ -0,0 +1,78 @@
+package route
+
+import (
+	"fmt"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+)
+
+// ISISPath represents a path computed by the IS-IS SPF
+type ISISPath struct {
+	NextHop *bnet.IP
+	Metric  uint32
+	Level   uint8
+}
+
+// Select returns negative if s < t, 0 if paths are equal, positive if s > t.
+// Level 1 paths are preferred over level 2 paths, paths with lower metric are preferred over paths with higher metric.
+func (s *ISISPath) Select(t *ISISPath) int8 {
+	if s.Level < t.Level {
+		return 1
+	}
+
+	if s.Level > t.Level {
+		return -1
+	}
+
+	if s.Metric < t.Metric {
+		return 1
+	}
+
+	if s.Metric > t.Metric {
+		return -1
+	}
+
+	return compareOptionalIPs(s.NextHop, t.NextHop)
+}
+
+// Equal returns true if s and t are equal
+func (s *ISISPath) Equal(t *ISISPath) bool {
+	if s == nil || t == nil {
+		return false
+	}
+
+	return s.Select(t) == 0
+}
+
+// ECMP determines if path s and t are equal in terms of ECMP
+func (s *ISISPath) ECMP(t *ISISPath) bool {
+	if t == nil {
+		return false
+	}
+
+	return s.Level == t.Level && s.Metric == t.Metric
+}
+
+// Copy duplicates the current object
+func (s *ISISPath) Copy() *ISISPath {
+	if s == nil {
+		return nil
+	}
+
+	cp := *s
+	return &cp
+}
+
+// Print all known information about a route in logfile friendly format
+func (s *ISISPath) String() string {
+	return fmt.Sprintf("Next hop: %s, Metric: %d, Level: %d", optionalIPString(s.NextHop), s.Metric, s.Level)
+}
+
+// Print all known information about a route in human readable form
+func (s *ISISPath) Print() string {
+	ret := fmt.Sprintf("\t\tNext hop: %s\n", optionalIPString(s.NextHop))
+	ret += fmt.Sprintf("\t\tMetric: %d\n", s.Metric)
+	ret += fmt.Sprintf("\t\tLevel: %d\n", s.Level)
+
+	return ret
+}
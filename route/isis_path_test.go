@@ -0,0 +1,88 @@
+package route
+
+import (
+	"testing"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestISISPathSelect(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        *ISISPath
+		t        *ISISPath
+		expected int8
+	}{
+		{
+			name:     "equal",
+			s:        &ISISPath{NextHop: bnet.IPv4FromOctets(192, 0, 2, 1).Ptr(), Metric: 10, Level: 2},
+			t:        &ISISPath{NextHop: bnet.IPv4FromOctets(192, 0, 2, 1).Ptr(), Metric: 10, Level: 2},
+			expected: 0,
+		},
+		{
+			name:     "lower metric",
+			s:        &ISISPath{NextHop: bnet.IPv4FromOctets(192, 0, 2, 2).Ptr(), Metric: 10, Level: 2},
+			t:        &ISISPath{NextHop: bnet.IPv4FromOctets(192, 0, 2, 1).Ptr(), Metric: 20, Level: 2},
+			expected: 1,
+		},
+		{
+			name:     "higher metric",
+			s:        &ISISPath{NextHop: bnet.IPv4FromOctets(192, 0, 2, 1).Ptr(), Metric: 30, Level: 2},
+			t:        &ISISPath{NextHop: bnet.IPv4FromOctets(192, 0, 2, 1).Ptr(), Metric: 20, Level: 2},
+			expected: -1,
+		},
+		{
+			name:     "level 1 preferred",
+			s:        &ISISPath{NextHop: bnet.IPv4FromOctets(192, 0, 2, 1).Ptr(), Metric: 30, Level: 1},
+			t:        &ISISPath{NextHop: bnet.IPv4FromOctets(192, 0, 2, 1).Ptr(), Metric: 20, Level: 2},
+			expected: 1,
+		},
+		{
+			name:     "lower next hop",
+			s:        &ISISPath{NextHop: bnet.IPv4FromOctets(192, 0, 2, 1).Ptr(), Metric: 10, Level: 2},
+			t:        &ISISPath{NextHop: bnet.IPv4FromOctets(192, 0, 2, 2).Ptr(), Metric: 10, Level: 2},
+			expected: -1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.s.Select(test.t))
+		})
+	}
+}
+
+func TestISISPathECMP(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        *ISISPath
+		t        *ISISPath
+		expected bool
+	}{
+		{
+			name:     "equal cost",
+			s:        &ISISPath{NextHop: bnet.IPv4FromOctets(192, 0, 2, 1).Ptr(), Metric: 10, Level: 2},
+			t:        &ISISPath{NextHop: bnet.IPv4FromOctets(192, 0, 2, 2).Ptr(), Metric: 10, Level: 2},
+			expected: true,
+		},
+		{
+			name:     "different cost",
+			s:        &ISISPath{NextHop: bnet.IPv4FromOctets(192, 0, 2, 1).Ptr(), Metric: 10, Level: 2},
+			t:        &ISISPath{NextHop: bnet.IPv4FromOctets(192, 0, 2, 2).Ptr(), Metric: 20, Level: 2},
+			expected: false,
+		},
+		{
+			name:     "different level",
+			s:        &ISISPath{NextHop: bnet.IPv4FromOctets(192, 0, 2, 1).Ptr(), Metric: 10, Level: 1},
+			t:        &ISISPath{NextHop: bnet.IPv4FromOctets(192, 0, 2, 2).Ptr(), Metric: 10, Level: 2},
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.s.ECMP(test.t))
+		})
+	}
+}
@@ -18,6 +18,8 @@ const (
 	HiddenReasonClusterLoop
 	HiddenReasonOTCMismatch
 	HiddenReasonEmptyASPath
+	HiddenReasonASPAInvalid
+	HiddenReasonDampened
 )
 
 // Path represents a network path
@@ -29,6 +31,8 @@ type Path struct {
 	StaticPath        *StaticPath
 	BGPPath           *BGPPath
 	FIBPath           *FIBPath
+	ConnectedPath     *ConnectedPath
+	ISISPath          *ISISPath
 }
 
 // Select returns negative if p < q, 0 if paths are equal, positive if p > q
@@ -58,6 +62,10 @@ func (p *Path) Select(q *Path) int8 {
 		return p.StaticPath.Select(q.StaticPath)
 	case FIBPathType:
 		return p.FIBPath.Select(q.FIBPath)
+	case ConnectedPathType:
+		return p.ConnectedPath.Select(q.ConnectedPath)
+	case ISISPathType:
+		return p.ISISPath.Select(q.ISISPath)
 	}
 
 	return 0
@@ -65,6 +73,10 @@ func (p *Path) Select(q *Path) int8 {
 
 // ECMP checks if path p and q are equal enough to be considered for ECMP usage
 func (p *Path) ECMP(q *Path) bool {
+	if p.Type != q.Type {
+		return false
+	}
+
 	switch p.Type {
 	case BGPPathType:
 		return p.BGPPath.ECMP(q.BGPPath)
@@ -72,6 +84,10 @@ func (p *Path) ECMP(q *Path) bool {
 		return p.StaticPath.ECMP(q.StaticPath)
 	case FIBPathType:
 		return p.FIBPath.ECMP(q.FIBPath)
+	case ConnectedPathType:
+		return p.ConnectedPath.ECMP(q.ConnectedPath)
+	case ISISPathType:
+		return p.ISISPath.ECMP(q.ISISPath)
 	}
 
 	panic("Unknown path type")
@@ -107,6 +123,12 @@ func (p *Path) ToProto() *api.Path {
 		a.HiddenReason = api.Path_HiddenReasonClusterLoop
 	case HiddenReasonOTCMismatch:
 		a.HiddenReason = api.Path_HiddenReasonOTCMismatch
+	case HiddenReasonEmptyASPath:
+		a.HiddenReason = api.Path_HiddenReasonEmptyASPath
+	case HiddenReasonASPAInvalid:
+		a.HiddenReason = api.Path_HiddenReasonASPAInvalid
+	case HiddenReasonDampened:
+		a.HiddenReason = api.Path_HiddenReasonDampened
 	}
 
 	return a
@@ -127,6 +149,12 @@ func (p *Path) Compare(q *Path) bool {
 		return p.BGPPath.Compare(q.BGPPath)
 	case StaticPathType:
 		return p.StaticPath.Compare(q.StaticPath)
+	case FIBPathType:
+		return p.FIBPath.Select(q.FIBPath) == 0
+	case ConnectedPathType:
+		return p.ConnectedPath.Equal(q.ConnectedPath)
+	case ISISPathType:
+		return p.ISISPath.Equal(q.ISISPath)
 	}
 
 	return false
@@ -186,6 +214,10 @@ func (p *Path) String() string {
 		pathInfo = p.BGPPath.String()
 	case FIBPathType:
 		pathInfo = p.FIBPath.String()
+	case ConnectedPathType:
+		pathInfo = p.ConnectedPath.String()
+	case ISISPathType:
+		pathInfo = p.ISISPath.String()
 	default:
 		return fmt.Sprintf("Unknown path type. Probably not implemented yet (%d)", p.Type)
 	}
@@ -217,6 +249,10 @@ func (p *Path) Print() string {
 		buf.WriteString(p.BGPPath.Print())
 	case FIBPathType:
 		buf.WriteString(p.FIBPath.Print())
+	case ConnectedPathType:
+		buf.WriteString(p.ConnectedPath.Print())
+	case ISISPathType:
+		buf.WriteString(p.ISISPath.Print())
 	}
 
 	return buf.String()
@@ -231,6 +267,7 @@ func (p *Path) Copy() *Path {
 	cp := *p
 	cp.BGPPath = cp.BGPPath.Copy()
 	cp.StaticPath = cp.StaticPath.Copy()
+	cp.ISISPath = cp.ISISPath.Copy()
 
 	return &cp
 }
@@ -244,6 +281,10 @@ func (p *Path) NextHop() *bnet.IP {
 		return p.StaticPath.NextHop
 	case FIBPathType:
 		return p.FIBPath.NextHop
+	case ConnectedPathType:
+		return nil
+	case ISISPathType:
+		return p.ISISPath.NextHop
 	}
 
 	panic("Unknown path type")
@@ -273,6 +314,10 @@ func (p *Path) HiddenReasonString() string {
 		return "OTC mismatch"
 	case HiddenReasonEmptyASPath:
 		return "Empty eBGP AS Path"
+	case HiddenReasonASPAInvalid:
+		return "ASPA verification invalid"
+	case HiddenReasonDampened:
+		return "Suppressed by flap dampening"
 	default:
 		return "unknown"
 	}
@@ -284,6 +329,8 @@ func (p *Path) GetNextHop() *bnet.IP {
 		return p.BGPPath.GetNextHop()
 	case StaticPathType:
 		return p.StaticPath.GetNextHop()
+	case ISISPathType:
+		return p.ISISPath.NextHop
 	}
 
 	return nil
@@ -310,6 +357,10 @@ func GetPathTypeName(t uint8) string {
 		return "Netlink"
 	case StaticPathType:
 		return "static"
+	case ConnectedPathType:
+		return "connected"
+	case ISISPathType:
+		return "IS-IS"
 	default:
 		return "unknown"
 	}
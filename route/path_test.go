@@ -946,6 +946,32 @@ func TestPathHiddenReasonString(t *testing.T) {
 			},
 			reason: "OTC mismatch",
 		},
+		{
+			name: "ASPA invalid",
+			source: &Path{
+				Type: BGPPathType,
+				BGPPath: &BGPPath{
+					BGPPathA: &BGPPathA{
+						NextHop: bnet.IPv4(123).Ptr(),
+					},
+				},
+				HiddenReason: HiddenReasonASPAInvalid,
+			},
+			reason: "ASPA verification invalid",
+		},
+		{
+			name: "Dampened",
+			source: &Path{
+				Type: BGPPathType,
+				BGPPath: &BGPPath{
+					BGPPathA: &BGPPathA{
+						NextHop: bnet.IPv4(123).Ptr(),
+					},
+				},
+				HiddenReason: HiddenReasonDampened,
+			},
+			reason: "Suppressed by flap dampening",
+		},
 	}
 
 	for _, test := range tests {
@@ -1164,6 +1190,15 @@ func TestToProto(t *testing.T) {
 				HiddenReason: api.Path_HiddenReasonOTCMismatch,
 			},
 		},
+		{
+			name: "Hidden: Dampened",
+			path: &Path{
+				HiddenReason: HiddenReasonDampened,
+			},
+			result: &api.Path{
+				HiddenReason: api.Path_HiddenReasonDampened,
+			},
+		},
 
 		/*
 			{
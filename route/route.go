@@ -26,6 +26,9 @@ const (
 
 	// FIBPathType indicates a path is a FIB path
 	FIBPathType
+
+	// ConnectedPathType indicates a path is a path to a directly connected network
+	ConnectedPathType
 )
 
 // Route links a prefix to paths
@@ -137,6 +140,10 @@ func (r *Route) BestPath() *Path {
 	if r == nil {
 		return nil
 	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	if len(r.paths) == 0 {
 		return nil
 	}
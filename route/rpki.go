@@ -0,0 +1,57 @@
+package route
+
+const (
+	// RPKIValidationUnverified indicates the origin of a path has not been validated
+	RPKIValidationUnverified = uint8(iota)
+
+	// RPKIValidationValid indicates a VRP covering the prefix matches the origin of a path (RFC6811)
+	RPKIValidationValid
+
+	// RPKIValidationInvalid indicates VRPs cover the prefix but none of them matches the origin of a path (RFC6811)
+	RPKIValidationInvalid
+
+	// RPKIValidationNotFound indicates no VRP covers the prefix of a path (RFC6811)
+	RPKIValidationNotFound
+)
+
+// RPKIValidationStateString returns the name of an RPKI origin validation state
+func RPKIValidationStateString(state uint8) string {
+	switch state {
+	case RPKIValidationValid:
+		return "valid"
+	case RPKIValidationInvalid:
+		return "invalid"
+	case RPKIValidationNotFound:
+		return "not-found"
+	}
+
+	return "unverified"
+}
+
+const (
+	// ASPAVerificationUnverified indicates the AS path of a path has not been verified
+	ASPAVerificationUnverified = uint8(iota)
+
+	// ASPAVerificationValid indicates ASPAs attest all hops of the AS path (draft-ietf-sidrops-aspa-verification)
+	ASPAVerificationValid
+
+	// ASPAVerificationInvalid indicates ASPAs prove the AS path to be a route leak (draft-ietf-sidrops-aspa-verification)
+	ASPAVerificationInvalid
+
+	// ASPAVerificationUnknown indicates hops of the AS path lack ASPAs to verify it (draft-ietf-sidrops-aspa-verification)
+	ASPAVerificationUnknown
+)
+
+// ASPAVerificationStateString returns the name of an ASPA verification state
+func ASPAVerificationStateString(state uint8) string {
+	switch state {
+	case ASPAVerificationValid:
+		return "valid"
+	case ASPAVerificationInvalid:
+		return "invalid"
+	case ASPAVerificationUnknown:
+		return "unknown"
+	}
+
+	return "unverified"
+}
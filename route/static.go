@@ -11,6 +11,7 @@ import (
 // StaticPath represents a static path of a route
 type StaticPath struct {
 	NextHop *bnet.IP
+	Discard bool // Traffic to discard routes is dropped
 }
 
 func (r *Route) staticPathSelection() {
@@ -23,7 +24,15 @@ func (r *Route) staticPathSelection() {
 
 // Select returns negative if s < t, 0 if paths are equal, positive if s > t
 func (s *StaticPath) Select(t *StaticPath) int8 {
-	return s.NextHop.Compare(t.NextHop)
+	if s.Discard != t.Discard {
+		if s.Discard {
+			return 1
+		}
+
+		return -1
+	}
+
+	return compareOptionalIPs(s.NextHop, t.NextHop)
 }
 
 // Compare checks if paths a and t are the same
@@ -37,7 +46,7 @@ func (s *StaticPath) Equal(t *StaticPath) bool {
 		return false
 	}
 
-	return s.NextHop.Compare(t.NextHop) == 0
+	return s.Select(t) == 0
 }
 
 // ECMP determines if path s and t are equal in terms of ECMP
@@ -60,6 +69,10 @@ func (s *StaticPath) String() string {
 		return "path not present."
 	}
 
+	if s.Discard {
+		return "Discard"
+	}
+
 	return fmt.Sprintf("Next hop: %s", s.NextHop.String())
 }
 
@@ -67,7 +80,11 @@ func (s *StaticPath) String() string {
 func (s *StaticPath) Print() string {
 	buf := &strings.Builder{}
 
-	fmt.Fprintf(buf, "\t\tNext hop: %s\n", s.NextHop.String())
+	if s.Discard {
+		fmt.Fprintf(buf, "\t\tDiscard\n")
+	} else {
+		fmt.Fprintf(buf, "\t\tNext hop: %s\n", s.NextHop.String())
+	}
 
 	return buf.String()
 }
@@ -78,6 +95,12 @@ func (s *StaticPath) ToProto() *api.StaticPath {
 		return nil
 	}
 
+	if s.Discard {
+		return &api.StaticPath{
+			Discard: true,
+		}
+	}
+
 	return &api.StaticPath{
 		NextHop: s.NextHop.ToProto(),
 	}
@@ -85,6 +108,12 @@ func (s *StaticPath) ToProto() *api.StaticPath {
 
 // StaticPathFromProtoStaticPath converts a proto StaticPath to StaticPath
 func StaticPathFromProtoStaticPath(pb *api.StaticPath, dedup bool) *StaticPath {
+	if pb.Discard {
+		return &StaticPath{
+			Discard: true,
+		}
+	}
+
 	return &StaticPath{
 		NextHop: bnet.IPFromProtoIP(pb.NextHop).Ptr(),
 	}
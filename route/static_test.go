@@ -0,0 +1,70 @@
+package route
+
+import (
+	"testing"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStaticPathSelect(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        *StaticPath
+		t        *StaticPath
+		expected int8
+	}{
+		{
+			name:     "equal next hops",
+			s:        &StaticPath{NextHop: bnet.IPv4FromOctets(192, 0, 2, 1).Ptr()},
+			t:        &StaticPath{NextHop: bnet.IPv4FromOctets(192, 0, 2, 1).Ptr()},
+			expected: 0,
+		},
+		{
+			name:     "lower next hop",
+			s:        &StaticPath{NextHop: bnet.IPv4FromOctets(192, 0, 2, 1).Ptr()},
+			t:        &StaticPath{NextHop: bnet.IPv4FromOctets(192, 0, 2, 2).Ptr()},
+			expected: -1,
+		},
+		{
+			name:     "discard",
+			s:        &StaticPath{Discard: true},
+			t:        &StaticPath{NextHop: bnet.IPv4FromOctets(192, 0, 2, 2).Ptr()},
+			expected: 1,
+		},
+		{
+			name:     "both discard",
+			s:        &StaticPath{Discard: true},
+			t:        &StaticPath{Discard: true},
+			expected: 0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.s.Select(test.t))
+		})
+	}
+}
+
+func TestStaticPathToProto(t *testing.T) {
+	tests := []struct {
+		name string
+		path *StaticPath
+	}{
+		{
+			name: "next hop",
+			path: &StaticPath{NextHop: bnet.IPv4FromOctets(192, 0, 2, 1).Ptr()},
+		},
+		{
+			name: "discard",
+			path: &StaticPath{Discard: true},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.path, StaticPathFromProtoStaticPath(test.path.ToProto(), false))
+		})
+	}
+}
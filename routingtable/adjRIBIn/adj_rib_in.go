@@ -2,9 +2,11 @@ package adjRIBIn
 
 import (
 	"sync"
+	"sync/atomic"
 
 	"github.com/bio-routing/bio-rd/net"
 	"github.com/bio-routing/bio-rd/protocols/bgp/packet"
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
 	"github.com/bio-routing/bio-rd/route"
 	"github.com/bio-routing/bio-rd/routingtable"
 	"github.com/bio-routing/bio-rd/routingtable/filter"
@@ -16,6 +18,7 @@ import (
 type AdjRIBIn struct {
 	clientManager     *routingtable.ClientManager
 	rt                *routingtable.RoutingTable
+	pathCount         int64
 	mu                sync.RWMutex
 	exportFilterChain filter.Chain
 	vrf               *vrf.VRF
@@ -39,9 +42,36 @@ func New(exportFilterChain filter.Chain, vrf *vrf.VRF, sessionAttrs routingtable
 	}
 
 	a.clientManager = routingtable.NewClientManager(a)
+	if a.sessionAttrs.RPKIValidator != nil {
+		a.sessionAttrs.RPKIValidator.Register(a)
+	}
+
+	if a.tracksNextHops() {
+		a.sessionAttrs.NextHopTracker.Register(a)
+	}
+
+	if a.sessionAttrs.RouteDampener != nil {
+		a.sessionAttrs.RouteDampener.Register(a)
+	}
+
 	return a
 }
 
+// Dispose stops the revalidation of routes on RPKI and ASPA changes, the tracking of next hops and the reuse of dampened routes
+func (a *AdjRIBIn) Dispose() {
+	if a.sessionAttrs.RPKIValidator != nil {
+		a.sessionAttrs.RPKIValidator.Unregister(a)
+	}
+
+	if a.tracksNextHops() {
+		a.sessionAttrs.NextHopTracker.Unregister(a)
+	}
+
+	if a.sessionAttrs.RouteDampener != nil {
+		a.sessionAttrs.RouteDampener.Unregister(a)
+	}
+}
+
 // ClientCount gets the number of registered clients
 func (a *AdjRIBIn) ClientCount() uint64 {
 	return a.clientManager.ClientCount()
@@ -77,39 +107,218 @@ func (a *AdjRIBIn) ReplaceFilterChain(c filter.Chain) {
 	for _, route := range routes {
 		paths := route.Paths()
 		for _, path := range paths {
+			// Ineligible paths have never been propagated
+			if path.IsHidden() {
+				continue
+			}
+
 			currentPath, currentReject := a.exportFilterChain.Process(route.Prefix(), path)
 			newPath, newReject := c.Process(route.Prefix(), path)
+			a.updateClients(route.Prefix(), currentPath, currentReject, newPath, newReject)
+		}
+	}
+
+	a.exportFilterChain = c
+}
+
+// updateClients propagates the change of the filtered representation of a path to all clients
+func (a *AdjRIBIn) updateClients(pfx *net.Prefix, currentPath *route.Path, currentReject bool, newPath *route.Path, newReject bool) {
+	if currentReject && newReject {
+		return
+	}
+
+	if currentReject && !newReject {
+		for _, client := range a.clientManager.Clients() {
+			client.AddPath(pfx, newPath)
+		}
+
+		return
+	}
+
+	if !currentReject && newReject {
+		for _, client := range a.clientManager.Clients() {
+			client.RemovePath(pfx, currentPath)
+		}
+
+		return
+	}
+
+	if currentPath.Equal(newPath) {
+		return
+	}
+
+	for _, client := range a.clientManager.Clients() {
+		client.ReplacePath(pfx, currentPath, newPath)
+	}
+}
+
+// RPKIUpdate revalidates the origin of all routes covered by the prefixes of changed VRPs
+func (a *AdjRIBIn) RPKIUpdate(pfxs []*net.Prefix) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for _, pfx := range pfxs {
+		for _, r := range a.rt.GetLonger(pfx) {
+			for _, p := range r.Paths() {
+				// Ineligible paths have never been validated nor propagated
+				if p.HiddenReason != route.HiddenReasonNone {
+					continue
+				}
 
-			if currentReject && newReject {
+				state := a.sessionAttrs.RPKIValidator.Validate(r.Prefix(), a.originASN(p))
+				if state == p.BGPPath.RPKIValidationState {
+					continue
+				}
+
+				currentPath, currentReject := a.exportFilterChain.Process(r.Prefix(), p)
+				p.BGPPath.RPKIValidationState = state
+				newPath, newReject := a.exportFilterChain.Process(r.Prefix(), p)
+				a.updateClients(r.Prefix(), currentPath, currentReject, newPath, newReject)
+			}
+		}
+	}
+}
+
+// ASPAUpdate re-verifies the AS paths of all routes
+func (a *AdjRIBIn) ASPAUpdate() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for _, r := range a.rt.Dump() {
+		for _, p := range r.Paths() {
+			// Paths ineligible for other reasons have never been verified nor propagated
+			if p.HiddenReason != route.HiddenReasonNone && p.HiddenReason != route.HiddenReasonASPAInvalid {
 				continue
 			}
 
-			if currentReject && !newReject {
-				for _, client := range a.clientManager.Clients() {
-					client.AddPath(route.Prefix(), newPath)
-				}
+			state := a.verifyASPA(p)
+			if state == p.BGPPath.ASPAVerificationState {
+				continue
+			}
+
+			// Paths hidden due to a failed verification are treated as rejected by the filter
+			var currentPath, newPath *route.Path
+			currentReject, newReject := true, true
+			if p.HiddenReason == route.HiddenReasonNone {
+				currentPath, currentReject = a.exportFilterChain.Process(r.Prefix(), p)
+			}
+
+			// The origin validation state of a path that has been hidden may be outdated
+			a.verify(r.Prefix(), p)
+			p.HiddenReason = route.HiddenReasonNone
+			if a.hideASPAInvalid(p) {
+				p.HiddenReason = route.HiddenReasonASPAInvalid
+			} else {
+				newPath, newReject = a.exportFilterChain.Process(r.Prefix(), p)
+			}
+
+			a.updateClients(r.Prefix(), currentPath, currentReject, newPath, newReject)
+		}
+	}
+}
+
+// NextHopUpdate re-evaluates all routes using any of the given next hops
+func (a *AdjRIBIn) NextHopUpdate(nextHops []*net.IP) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	// The tracker may have been replaced while the update was in flight
+	if !a.tracksNextHops() {
+		return
+	}
+
+	changed := make(map[net.IP]struct{}, len(nextHops))
+	for _, nh := range nextHops {
+		changed[*nh] = struct{}{}
+	}
 
+	for _, r := range a.rt.Dump() {
+		for _, p := range r.Paths() {
+			if p.BGPPath.BGPPathA.NextHop == nil {
 				continue
 			}
 
-			if !currentReject && newReject {
-				for _, client := range a.clientManager.Clients() {
-					client.RemovePath(route.Prefix(), newPath)
-				}
+			if _, found := changed[*p.BGPPath.BGPPathA.NextHop]; !found {
 				continue
 			}
 
-			if !currentReject && !newReject {
-				for _, client := range a.clientManager.Clients() {
-					if !currentPath.Equal(newPath) {
-						client.ReplacePath(route.Prefix(), currentPath, newPath)
-					}
-				}
+			reachable, metric := a.sessionAttrs.NextHopTracker.Resolve(p.BGPPath.BGPPathA.NextHop)
+			a.applyNextHopResolution(r.Prefix(), p, reachable, metric)
+		}
+	}
+}
+
+// ReplaceNextHopTracker replaces the tracker resolving the next hops of received paths and re-evaluates all paths.
+// Next hops are not resolved anymore if t is nil.
+func (a *AdjRIBIn) ReplaceNextHopTracker(t routingtable.NextHopTracker) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if t == a.sessionAttrs.NextHopTracker {
+		return
+	}
+
+	routes := a.rt.Dump()
+	if a.tracksNextHops() {
+		for _, r := range routes {
+			a.untrackNextHops(r.Paths())
+		}
+
+		a.sessionAttrs.NextHopTracker.Unregister(a)
+	}
+
+	a.sessionAttrs.NextHopTracker = t
+	if a.tracksNextHops() {
+		t.Register(a)
+	}
+
+	for _, r := range routes {
+		for _, p := range r.Paths() {
+			reachable, metric := true, uint32(0)
+			if a.tracksNextHops() && p.BGPPath.BGPPathA.NextHop != nil {
+				reachable, metric = t.Track(p.BGPPath.BGPPathA.NextHop)
 			}
+
+			a.applyNextHopResolution(r.Prefix(), p, reachable, metric)
 		}
 	}
+}
 
-	a.exportFilterChain = c
+// applyNextHopResolution updates the IGP metric and the eligibility of a path after the resolution of its next hop
+// changed and propagates the change to all clients
+func (a *AdjRIBIn) applyNextHopResolution(pfx *net.Prefix, p *route.Path, reachable bool, metric uint32) {
+	// Paths ineligible for other reasons have never been propagated
+	if p.HiddenReason != route.HiddenReasonNone && p.HiddenReason != route.HiddenReasonNextHopUnreachable {
+		p.BGPPath.IGPMetric = metric
+		return
+	}
+
+	hidden := p.HiddenReason == route.HiddenReasonNextHopUnreachable
+	if hidden == !reachable && metric == p.BGPPath.IGPMetric {
+		return
+	}
+
+	// Paths hidden due to an unreachable next hop are treated as rejected by the filter
+	var currentPath, newPath *route.Path
+	currentReject, newReject := true, true
+	if !hidden {
+		currentPath, currentReject = a.exportFilterChain.Process(pfx, p)
+	}
+
+	p.BGPPath.IGPMetric = metric
+	p.HiddenReason = route.HiddenReasonNone
+	if reachable {
+		// The origin validation state of a path that has been hidden may be outdated
+		if hidden {
+			a.verify(pfx, p)
+		}
+
+		newPath, newReject = a.exportFilterChain.Process(pfx, p)
+	} else {
+		p.HiddenReason = route.HiddenReasonNextHopUnreachable
+	}
+
+	a.updateClients(pfx, currentPath, currentReject, newPath, newReject)
 }
 
 func (a *AdjRIBIn) ReplacePath(pfx *net.Prefix, old *route.Path, new *route.Path) {
@@ -125,6 +334,10 @@ func (a *AdjRIBIn) UpdateNewClient(client routingtable.RouteTableClient) error {
 	for _, route := range routes {
 		paths := route.Paths()
 		for _, path := range paths {
+			if path.IsHidden() {
+				continue
+			}
+
 			path, reject := a.exportFilterChain.Process(route.Prefix(), path)
 			if reject {
 				continue
@@ -149,6 +362,11 @@ func (a *AdjRIBIn) RouteCount() int64 {
 	return a.rt.GetRouteCount()
 }
 
+// PathCount returns the number of stored paths
+func (a *AdjRIBIn) PathCount() int64 {
+	return atomic.LoadInt64(&a.pathCount)
+}
+
 // AddPath replaces the path for prefix `pfx`. If the prefix doesn't exist it is added.
 func (a *AdjRIBIn) AddPath(pfx *net.Prefix, p *route.Path) error {
 	a.mu.Lock()
@@ -160,23 +378,28 @@ func (a *AdjRIBIn) AddPath(pfx *net.Prefix, p *route.Path) error {
 // addPath replaces the path for prefix `pfx`. If the prefix doesn't exist it is added.
 func (a *AdjRIBIn) addPath(pfx *net.Prefix, p *route.Path) error {
 	var oldPaths []*route.Path
-	if a.sessionAttrs.AddPathRX {
+	if a.sessionAttrs.AddPathRX || p.BGPPath.QualifiesPrefix() {
 		oldPaths = make([]*route.Path, 0)
 		r := a.rt.Get(pfx)
 		if r != nil {
 			for _, path := range r.Paths() {
-				// RFC7911 sec 5 par 1 states (pfx, PathIdentifier) should be unique
-				if path.BGPPath.PathIdentifier == p.BGPPath.PathIdentifier {
-					a.rt.RemovePath(pfx, path)
-					oldPaths = append(oldPaths, path)
+				if !a.replaces(p, path) {
+					continue
 				}
+
+				a.rt.RemovePath(pfx, path)
+				oldPaths = append(oldPaths, path)
 			}
 		}
 		a.rt.AddPath(pfx, p)
 	} else {
 		oldPaths = a.rt.ReplacePath(pfx, p)
 	}
+	atomic.AddInt64(&a.pathCount, 1-int64(len(oldPaths)))
 	a.removePathsFromClients(pfx, oldPaths)
+	a.untrackNextHops(oldPaths)
+	suppressed := a.dampen(pfx, p, oldPaths)
+	reachable := a.trackNextHop(p)
 
 	// Bail out if this path is considered ineligible
 	p.HiddenReason = a.validatePath(p)
@@ -184,6 +407,23 @@ func (a *AdjRIBIn) addPath(pfx *net.Prefix, p *route.Path) error {
 		return nil
 	}
 
+	if suppressed {
+		p.HiddenReason = route.HiddenReasonDampened
+		return nil
+	}
+
+	// Routes received via iBGP are only usable if their next hop can be resolved
+	if !reachable {
+		p.HiddenReason = route.HiddenReasonNextHopUnreachable
+		return nil
+	}
+
+	a.accept(pfx, p)
+	return nil
+}
+
+// accept applies the import policy to an eligible path and propagates it to all clients
+func (a *AdjRIBIn) accept(pfx *net.Prefix, p *route.Path) {
 	// RFC4277 Sect 8. suggest to set a  use Local Preference as default value for eBGP
 	// This needs to happen before policies are applied, so this has effect when doing
 	// relative changes to Local Preference.
@@ -191,16 +431,22 @@ func (a *AdjRIBIn) addPath(pfx *net.Prefix, p *route.Path) error {
 		p.BGPPath.BGPPathA.LocalPref = a.sessionAttrs.DefaultLocalPreference
 	}
 
+	// RFC6811: The validation state has to be known to the import policy
+	a.verify(pfx, p)
+	if a.hideASPAInvalid(p) {
+		p.HiddenReason = route.HiddenReasonASPAInvalid
+		return
+	}
+
 	p, reject := a.exportFilterChain.Process(pfx, p)
 	if reject {
 		p.HiddenReason = route.HiddenReasonFilteredByPolicy
-		return nil
+		return
 	}
 
 	for _, client := range a.clientManager.Clients() {
 		client.AddPath(pfx, p)
 	}
-	return nil
 }
 
 // RemovePath removes the path for prefix `pfx`
@@ -221,20 +467,160 @@ func (a *AdjRIBIn) removePath(pfx *net.Prefix, p *route.Path) bool {
 	removed := make([]*route.Path, 0)
 	oldPaths := r.Paths()
 	for _, path := range oldPaths {
-		if a.sessionAttrs.AddPathRX {
-			if p != nil && path.BGPPath.PathIdentifier != p.BGPPath.PathIdentifier {
-				continue
-			}
+		if p != nil && !a.replaces(p, path) {
+			continue
 		}
 
 		a.rt.RemovePath(pfx, path)
 		removed = append(removed, path)
+
+		if a.sessionAttrs.RouteDampener != nil {
+			a.sessionAttrs.RouteDampener.Withdraw(pfx, path.BGPPath.PathIdentifier)
+		}
 	}
+	atomic.AddInt64(&a.pathCount, -int64(len(removed)))
 
 	a.removePathsFromClients(pfx, removed)
+	a.untrackNextHops(removed)
 	return true
 }
 
+// dampen accounts for the advertisement of path p replacing oldPaths and returns if p is suppressed
+func (a *AdjRIBIn) dampen(pfx *net.Prefix, p *route.Path, oldPaths []*route.Path) bool {
+	if a.sessionAttrs.RouteDampener == nil {
+		return false
+	}
+
+	changed := false
+	for _, old := range oldPaths {
+		if a.attributesChanged(old, p) {
+			changed = true
+		}
+	}
+
+	return a.sessionAttrs.RouteDampener.Advertise(pfx, p.BGPPath.PathIdentifier, changed)
+}
+
+// attributesChanged checks if the attributes of path p received from the peer differ from the ones of the path
+// it replaces. Attributes of the old path we determined ourselves are ignored.
+func (a *AdjRIBIn) attributesChanged(old *route.Path, p *route.Path) bool {
+	o := old.BGPPath.Copy()
+	o.RPKIValidationState = p.BGPPath.RPKIValidationState
+	o.ASPAVerificationState = p.BGPPath.ASPAVerificationState
+	o.IGPMetric = p.BGPPath.IGPMetric
+
+	if !a.sessionAttrs.IBGP && p.BGPPath.BGPPathA.LocalPref == 0 {
+		o.BGPPathA.LocalPref = 0
+	}
+
+	if p.BGPPath.BGPPathA.OnlyToCustomer == 0 {
+		o.BGPPathA.OnlyToCustomer = 0
+	}
+
+	return !o.Compare(p.BGPPath)
+}
+
+// DampeningReuse re-evaluates the paths of a route that is not suppressed anymore
+func (a *AdjRIBIn) DampeningReuse(pfx *net.Prefix, pathID uint32) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	r := a.rt.Get(pfx)
+	if r == nil {
+		return
+	}
+
+	for _, p := range r.Paths() {
+		if p.HiddenReason != route.HiddenReasonDampened || p.BGPPath.PathIdentifier != pathID {
+			continue
+		}
+
+		a.reuse(pfx, p)
+	}
+}
+
+// ReplaceRouteDampener replaces the dampener of received routes. Paths suppressed by the previous dampener are reused.
+// Routes are not dampened anymore if d is nil.
+func (a *AdjRIBIn) ReplaceRouteDampener(d routingtable.RouteDampener) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if d == a.sessionAttrs.RouteDampener {
+		return
+	}
+
+	if a.sessionAttrs.RouteDampener != nil {
+		a.sessionAttrs.RouteDampener.Unregister(a)
+	}
+
+	a.sessionAttrs.RouteDampener = d
+	if d != nil {
+		d.Register(a)
+	}
+
+	for _, r := range a.rt.Dump() {
+		for _, p := range r.Paths() {
+			if p.HiddenReason != route.HiddenReasonDampened {
+				continue
+			}
+
+			a.reuse(r.Prefix(), p)
+		}
+	}
+}
+
+// reuse propagates a path that was suppressed by the dampener
+func (a *AdjRIBIn) reuse(pfx *net.Prefix, p *route.Path) {
+	// The resolution of the next hop has not been updated while the path was suppressed
+	p.HiddenReason = route.HiddenReasonNone
+	if !a.resolveNextHop(p) {
+		p.HiddenReason = route.HiddenReasonNextHopUnreachable
+		return
+	}
+
+	a.accept(pfx, p)
+}
+
+// routeDampener returns the dampener of received routes
+func (a *AdjRIBIn) routeDampener() routingtable.RouteDampener {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	return a.sessionAttrs.RouteDampener
+}
+
+// DampeningState returns the dampening state of a path or nil if it did not flap recently
+func (a *AdjRIBIn) DampeningState(pfx *net.Prefix, p *route.Path) *routingtable.DampeningState {
+	d := a.routeDampener()
+	if d == nil {
+		return nil
+	}
+
+	return d.State(pfx, p.BGPPath.PathIdentifier)
+}
+
+// ClearDampening drops the dampening state of all routes covered by pfx or of all routes if pfx is nil.
+// Suppressed routes are reused.
+func (a *AdjRIBIn) ClearDampening(pfx *net.Prefix) {
+	// The dampener reuses routes through DampeningReuse which acquires the lock itself
+	d := a.routeDampener()
+	if d == nil {
+		return
+	}
+
+	d.Clear(pfx)
+}
+
+// replaces checks if path p received from the peer supersedes path old of the same prefix
+func (a *AdjRIBIn) replaces(p *route.Path, old *route.Path) bool {
+	// RFC7911 sec 5 par 1 states (pfx, PathIdentifier) should be unique
+	if a.sessionAttrs.AddPathRX && old.BGPPath.PathIdentifier != p.BGPPath.PathIdentifier {
+		return false
+	}
+
+	return old.BGPPath.SameRoute(p.BGPPath)
+}
+
 func (a *AdjRIBIn) removePathsFromClients(pfx *net.Prefix, paths []*route.Path) {
 	for _, path := range paths {
 		// If this path wasn't eligible in the first place, we didn't announce it
@@ -331,6 +717,90 @@ func (a *AdjRIBIn) validatePath(p *route.Path) uint8 {
 	return route.HiddenReasonNone
 }
 
+// verify determines the RPKI origin validation and ASPA verification states of a path
+func (a *AdjRIBIn) verify(pfx *net.Prefix, p *route.Path) {
+	if a.sessionAttrs.RPKIValidator == nil {
+		return
+	}
+
+	p.BGPPath.RPKIValidationState = a.sessionAttrs.RPKIValidator.Validate(pfx, a.originASN(p))
+	p.BGPPath.ASPAVerificationState = a.verifyASPA(p)
+}
+
+// verifyASPA verifies the AS path of a path against ASPAs
+func (a *AdjRIBIn) verifyASPA(p *route.Path) uint8 {
+	// The verification depends on the relationship to the neighbor which has to be configured
+	if a.sessionAttrs.RPKIValidator == nil || a.sessionAttrs.IBGP || !a.sessionAttrs.PeerRoleEnabled {
+		return route.ASPAVerificationUnverified
+	}
+
+	return a.sessionAttrs.RPKIValidator.VerifyASPath(p.BGPPath.ASPath, a.sessionAttrs.PeerASN, a.sessionAttrs.PeerRoleLocal)
+}
+
+func (a *AdjRIBIn) hideASPAInvalid(p *route.Path) bool {
+	return a.sessionAttrs.ASPARejectInvalid && p.BGPPath.ASPAVerificationState == route.ASPAVerificationInvalid
+}
+
+// tracksNextHops checks if the next hops of received routes are resolved
+func (a *AdjRIBIn) tracksNextHops() bool {
+	return a.sessionAttrs.NextHopTracker != nil && a.sessionAttrs.IBGP
+}
+
+// trackNextHop starts tracking the next hop of a path, sets its IGP metric and returns if the next hop is reachable
+func (a *AdjRIBIn) trackNextHop(p *route.Path) bool {
+	if !a.tracksNextHops() || p.BGPPath.BGPPathA.NextHop == nil {
+		return true
+	}
+
+	reachable, metric := a.sessionAttrs.NextHopTracker.Track(p.BGPPath.BGPPathA.NextHop)
+	p.BGPPath.IGPMetric = metric
+	return reachable
+}
+
+// resolveNextHop updates the IGP metric of a path with a tracked next hop and returns if the next hop is reachable
+func (a *AdjRIBIn) resolveNextHop(p *route.Path) bool {
+	if !a.tracksNextHops() || p.BGPPath.BGPPathA.NextHop == nil {
+		return true
+	}
+
+	reachable, metric := a.sessionAttrs.NextHopTracker.Resolve(p.BGPPath.BGPPathA.NextHop)
+	p.BGPPath.IGPMetric = metric
+	return reachable
+}
+
+// untrackNextHops stops tracking the next hops of removed paths
+func (a *AdjRIBIn) untrackNextHops(paths []*route.Path) {
+	if !a.tracksNextHops() {
+		return
+	}
+
+	for _, p := range paths {
+		if p.BGPPath.BGPPathA.NextHop != nil {
+			a.sessionAttrs.NextHopTracker.Untrack(p.BGPPath.BGPPathA.NextHop)
+		}
+	}
+}
+
+// originASN returns the origin AS of a path as defined in RFC6811 Sect. 2. 0 denotes an unknown origin.
+func (a *AdjRIBIn) originASN(p *route.Path) uint32 {
+	// Paths originated in our own AS have an empty AS path
+	if p.BGPPath.ASPath == nil || len(*p.BGPPath.ASPath) == 0 {
+		return a.sessionAttrs.LocalASN
+	}
+
+	seg := (*p.BGPPath.ASPath)[len(*p.BGPPath.ASPath)-1]
+	if seg.Type != types.ASSequence {
+		return 0
+	}
+
+	origin := seg.GetLastASN()
+	if origin == nil {
+		return 0
+	}
+
+	return *origin
+}
+
 func (a *AdjRIBIn) ourASNsInPath(p *route.Path) bool {
 	if p.BGPPath.ASPath == nil {
 		return false
@@ -9,6 +9,8 @@ import (
 	"github.com/bio-routing/bio-rd/route"
 	"github.com/bio-routing/bio-rd/routingtable"
 	"github.com/bio-routing/bio-rd/routingtable/filter"
+	"github.com/bio-routing/bio-rd/routingtable/filter/actions"
+	"github.com/bio-routing/bio-rd/routingtable/locRIB"
 	"github.com/bio-routing/bio-rd/routingtable/vrf"
 	"github.com/stretchr/testify/assert"
 )
@@ -275,6 +277,199 @@ func TestAddPath(t *testing.T) {
 				}),
 			},
 		},
+		{
+			name: "Add VPN routes with different route distinguishers (iBGP)",
+			iBGP: true,
+			routes: []*route.Route{
+				route.NewRoute(net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr(), &route.Path{
+					Type: route.BGPPathType,
+					BGPPath: &route.BGPPath{
+						RouteDistinguisher: 65000<<32 + 1,
+						BGPPathA: &route.BGPPathA{
+							LocalPref: 100,
+							NextHop:   net.IPv4FromOctets(20, 0, 0, 0).Ptr(),
+							Source:    net.IPv4FromOctets(20, 0, 0, 0).Ptr(),
+						},
+					},
+				}),
+				route.NewRoute(net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr(), &route.Path{
+					Type: route.BGPPathType,
+					BGPPath: &route.BGPPath{
+						RouteDistinguisher: 65000<<32 + 2,
+						BGPPathA: &route.BGPPathA{
+							LocalPref: 200,
+							NextHop:   net.IPv4FromOctets(20, 0, 0, 0).Ptr(),
+							Source:    net.IPv4FromOctets(20, 0, 0, 0).Ptr(),
+						},
+					},
+				}),
+			},
+			removePfx:  nil,
+			removePath: nil,
+			expected: []*route.Route{
+				route.NewRouteAddPath(net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr(), []*route.Path{
+					{
+						Type: route.BGPPathType,
+						BGPPath: &route.BGPPath{
+							RouteDistinguisher: 65000<<32 + 1,
+							BGPPathA: &route.BGPPathA{
+								LocalPref: 100,
+								NextHop:   net.IPv4FromOctets(20, 0, 0, 0).Ptr(),
+								Source:    net.IPv4FromOctets(20, 0, 0, 0).Ptr(),
+							},
+						},
+					},
+					{
+						Type: route.BGPPathType,
+						BGPPath: &route.BGPPath{
+							RouteDistinguisher: 65000<<32 + 2,
+							BGPPathA: &route.BGPPathA{
+								LocalPref: 200,
+								NextHop:   net.IPv4FromOctets(20, 0, 0, 0).Ptr(),
+								Source:    net.IPv4FromOctets(20, 0, 0, 0).Ptr(),
+							},
+						},
+					},
+				}),
+			},
+		},
+		{
+			name: "Overwrite VPN route with same route distinguisher (iBGP)",
+			iBGP: true,
+			routes: []*route.Route{
+				route.NewRoute(net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr(), &route.Path{
+					Type: route.BGPPathType,
+					BGPPath: &route.BGPPath{
+						RouteDistinguisher: 65000<<32 + 1,
+						BGPPathA: &route.BGPPathA{
+							LocalPref: 100,
+							NextHop:   net.IPv4FromOctets(20, 0, 0, 0).Ptr(),
+							Source:    net.IPv4FromOctets(20, 0, 0, 0).Ptr(),
+						},
+					},
+				}),
+				route.NewRoute(net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr(), &route.Path{
+					Type: route.BGPPathType,
+					BGPPath: &route.BGPPath{
+						RouteDistinguisher: 65000<<32 + 1,
+						BGPPathA: &route.BGPPathA{
+							LocalPref: 200,
+							NextHop:   net.IPv4FromOctets(20, 0, 0, 0).Ptr(),
+							Source:    net.IPv4FromOctets(20, 0, 0, 0).Ptr(),
+						},
+					},
+				}),
+			},
+			removePfx: net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr(),
+			removePath: &route.Path{
+				Type: route.BGPPathType,
+				BGPPath: &route.BGPPath{
+					RouteDistinguisher: 65000<<32 + 1,
+					BGPPathA: &route.BGPPathA{
+						LocalPref: 100,
+						NextHop:   net.IPv4FromOctets(20, 0, 0, 0).Ptr(),
+						Source:    net.IPv4FromOctets(20, 0, 0, 0).Ptr(),
+					},
+				},
+			},
+			expected: []*route.Route{
+				route.NewRoute(net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr(), &route.Path{
+					Type: route.BGPPathType,
+					BGPPath: &route.BGPPath{
+						RouteDistinguisher: 65000<<32 + 1,
+						BGPPathA: &route.BGPPathA{
+							LocalPref: 200,
+							NextHop:   net.IPv4FromOctets(20, 0, 0, 0).Ptr(),
+							Source:    net.IPv4FromOctets(20, 0, 0, 0).Ptr(),
+						},
+					},
+				}),
+			},
+		},
+		{
+			name: "Add FlowSpec routes with different rules (iBGP)",
+			iBGP: true,
+			routes: []*route.Route{
+				route.NewRoute(net.NewPfx(net.IPv4(0), 0).Ptr(), &route.Path{
+					Type: route.BGPPathType,
+					BGPPath: &route.BGPPath{
+						FlowSpecRule: &types.FlowSpecRule{
+							Components: []types.FlowSpecComponent{
+								{
+									Type:       types.FlowSpecComponentDestinationPort,
+									Operations: []types.FlowSpecOperation{{Equal: true, Value: 53}},
+								},
+							},
+						},
+						BGPPathA: &route.BGPPathA{
+							LocalPref: 100,
+							NextHop:   net.IPv4(0).Ptr(),
+							Source:    net.IPv4FromOctets(20, 0, 0, 0).Ptr(),
+						},
+					},
+				}),
+				route.NewRoute(net.NewPfx(net.IPv4(0), 0).Ptr(), &route.Path{
+					Type: route.BGPPathType,
+					BGPPath: &route.BGPPath{
+						FlowSpecRule: &types.FlowSpecRule{
+							Components: []types.FlowSpecComponent{
+								{
+									Type:       types.FlowSpecComponentDestinationPort,
+									Operations: []types.FlowSpecOperation{{Equal: true, Value: 123}},
+								},
+							},
+						},
+						BGPPathA: &route.BGPPathA{
+							LocalPref: 200,
+							NextHop:   net.IPv4(0).Ptr(),
+							Source:    net.IPv4FromOctets(20, 0, 0, 0).Ptr(),
+						},
+					},
+				}),
+			},
+			removePfx:  nil,
+			removePath: nil,
+			expected: []*route.Route{
+				route.NewRouteAddPath(net.NewPfx(net.IPv4(0), 0).Ptr(), []*route.Path{
+					{
+						Type: route.BGPPathType,
+						BGPPath: &route.BGPPath{
+							FlowSpecRule: &types.FlowSpecRule{
+								Components: []types.FlowSpecComponent{
+									{
+										Type:       types.FlowSpecComponentDestinationPort,
+										Operations: []types.FlowSpecOperation{{Equal: true, Value: 53}},
+									},
+								},
+							},
+							BGPPathA: &route.BGPPathA{
+								LocalPref: 100,
+								NextHop:   net.IPv4(0).Ptr(),
+								Source:    net.IPv4FromOctets(20, 0, 0, 0).Ptr(),
+							},
+						},
+					},
+					{
+						Type: route.BGPPathType,
+						BGPPath: &route.BGPPath{
+							FlowSpecRule: &types.FlowSpecRule{
+								Components: []types.FlowSpecComponent{
+									{
+										Type:       types.FlowSpecComponentDestinationPort,
+										Operations: []types.FlowSpecOperation{{Equal: true, Value: 123}},
+									},
+								},
+							},
+							BGPPathA: &route.BGPPathA{
+								LocalPref: 200,
+								NextHop:   net.IPv4(0).Ptr(),
+								Source:    net.IPv4FromOctets(20, 0, 0, 0).Ptr(),
+							},
+						},
+					},
+				}),
+			},
+		},
 		{
 			name:    "Add eBGP route (with BGP add path)",
 			addPath: true,
@@ -699,6 +894,46 @@ func TestUnregister(t *testing.T) {
 	assert.Equal(t, &routingtable.RemovePathParams{Pfx: pfxs[1], Path: paths[2]}, r[2], "Withdraw 3")
 }
 
+func TestPathCount(t *testing.T) {
+	a := New(filter.NewAcceptAllFilterChain(), vrf.NewUntrackedVRF("vrf0", 0), routingtable.SessionAttrs{
+		AddPathRX: true,
+	})
+
+	pfxs := []*net.Prefix{
+		net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 16).Ptr(),
+		net.NewPfx(net.IPv4FromOctets(10, 0, 1, 0), 24).Ptr(),
+	}
+
+	path := func(id uint32, nh uint8) *route.Path {
+		return &route.Path{
+			Type: route.BGPPathType,
+			BGPPath: &route.BGPPath{
+				PathIdentifier: id,
+				BGPPathA: &route.BGPPathA{
+					Source:  net.IPv4FromOctets(192, 168, 0, 1).Ptr(),
+					NextHop: net.IPv4FromOctets(192, 168, 0, nh).Ptr(),
+				},
+			},
+		}
+	}
+
+	a.AddPath(pfxs[0], path(1, 1))
+	a.AddPath(pfxs[0], path(2, 2))
+	a.AddPath(pfxs[1], path(1, 1))
+	assert.Equal(t, int64(3), a.PathCount())
+	assert.Equal(t, int64(2), a.RouteCount())
+
+	// Same path identifier replaces the path
+	a.AddPath(pfxs[0], path(2, 3))
+	assert.Equal(t, int64(3), a.PathCount())
+
+	a.RemovePath(pfxs[0], path(1, 1))
+	assert.Equal(t, int64(2), a.PathCount())
+
+	a.Flush()
+	assert.Equal(t, int64(0), a.PathCount())
+}
+
 func TestPeerRoleOTC(t *testing.T) {
 	routerID := net.IPv4FromOctets(1, 1, 1, 1).Ptr().ToUint32()
 
@@ -1001,3 +1236,567 @@ func TestPeerRoleOTC(t *testing.T) {
 		assert.Equal(t, test.expected, adjRIBIn.rt.Dump(), test.name)
 	}
 }
+
+type mockRPKIValidator struct {
+	origins map[net.Prefix]uint32
+	// leakers holds the ASNs whose presence in an AS path makes the path invalid
+	leakers map[uint32]struct{}
+	clients map[routingtable.RPKIValidatorClient]struct{}
+}
+
+func (m *mockRPKIValidator) Validate(pfx *net.Prefix, originASN uint32) uint8 {
+	origin, found := m.origins[*pfx]
+	if !found {
+		return route.RPKIValidationNotFound
+	}
+
+	if origin != originASN {
+		return route.RPKIValidationInvalid
+	}
+
+	return route.RPKIValidationValid
+}
+
+func (m *mockRPKIValidator) VerifyASPath(asPath *types.ASPath, neighborASN uint32, localRole uint8) uint8 {
+	for _, seg := range *asPath {
+		for _, asn := range seg.ASNs {
+			if _, found := m.leakers[asn]; found {
+				return route.ASPAVerificationInvalid
+			}
+		}
+	}
+
+	return route.ASPAVerificationValid
+}
+
+func (m *mockRPKIValidator) Register(client routingtable.RPKIValidatorClient) {
+	m.clients[client] = struct{}{}
+}
+
+func (m *mockRPKIValidator) Unregister(client routingtable.RPKIValidatorClient) {
+	delete(m.clients, client)
+}
+
+func TestRPKIValidation(t *testing.T) {
+	pfx1 := net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr()
+	pfx2 := net.NewPfx(net.IPv4FromOctets(11, 0, 0, 0), 8).Ptr()
+	pfx3 := net.NewPfx(net.IPv4FromOctets(12, 0, 0, 0), 8).Ptr()
+	bgpPath := func(asPath []uint32, state uint8) *route.Path {
+		pa := route.NewBGPPathA()
+		pa.LocalPref = 100
+
+		return &route.Path{
+			Type: route.BGPPathType,
+			BGPPath: &route.BGPPath{
+				ASPath:              types.NewASPath(asPath),
+				BGPPathA:            pa,
+				RPKIValidationState: state,
+			},
+		}
+	}
+
+	validator := &mockRPKIValidator{
+		origins: map[net.Prefix]uint32{
+			*pfx2: 65001,
+			*pfx3: 65003,
+		},
+		clients: make(map[routingtable.RPKIValidatorClient]struct{}),
+	}
+
+	rejectInvalid := filter.Chain{
+		filter.NewFilter("RPKI", []*filter.Term{
+			filter.NewTerm("INVALID", []*filter.TermCondition{
+				filter.NewTermCondition(nil, nil).WithRPKIValidationStates(route.RPKIValidationInvalid),
+			}, []actions.Action{
+				actions.NewRejectAction(),
+			}),
+			filter.NewTerm("ACCEPT", []*filter.TermCondition{}, []actions.Action{
+				actions.NewAcceptAction(),
+			}),
+		}),
+	}
+
+	rib := locRIB.New("inet.0")
+	a := New(rejectInvalid, vrf.NewUntrackedVRF("inet.0", 0), routingtable.SessionAttrs{
+		RouterID:      net.IPv4FromOctets(1, 1, 1, 1).Ptr().ToUint32(),
+		LocalASN:      65000,
+		PeerASN:       65001,
+		RPKIValidator: validator,
+	})
+	a.Register(rib)
+	assert.Contains(t, validator.clients, a)
+
+	a.AddPath(pfx1, bgpPath([]uint32{65001}, route.RPKIValidationUnverified))
+	a.AddPath(pfx2, bgpPath([]uint32{65001, 65002}, route.RPKIValidationUnverified))
+	a.AddPath(pfx3, bgpPath([]uint32{65001, 65003}, route.RPKIValidationUnverified))
+
+	assert.Equal(t, map[net.Prefix][]*route.Path{
+		*pfx1: {bgpPath([]uint32{65001}, route.RPKIValidationNotFound)},
+		*pfx3: {bgpPath([]uint32{65001, 65003}, route.RPKIValidationValid)},
+	}, dumpRIB(rib))
+
+	// VRP changes make the first route invalid, the second one valid and leave the third one untouched
+	validator.origins[*pfx1] = 65002
+	validator.origins[*pfx2] = 65002
+	a.RPKIUpdate([]*net.Prefix{pfx1, pfx2})
+
+	assert.Equal(t, map[net.Prefix][]*route.Path{
+		*pfx2: {bgpPath([]uint32{65001, 65002}, route.RPKIValidationValid)},
+		*pfx3: {bgpPath([]uint32{65001, 65003}, route.RPKIValidationValid)},
+	}, dumpRIB(rib))
+
+	a.Dispose()
+	assert.NotContains(t, validator.clients, a)
+}
+
+func TestASPAVerification(t *testing.T) {
+	pfx1 := net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr()
+	pfx2 := net.NewPfx(net.IPv4FromOctets(11, 0, 0, 0), 8).Ptr()
+	bgpPath := func(asPath []uint32, state uint8) *route.Path {
+		pa := route.NewBGPPathA()
+		pa.LocalPref = 100
+
+		return &route.Path{
+			Type: route.BGPPathType,
+			BGPPath: &route.BGPPath{
+				ASPath:                types.NewASPath(asPath),
+				BGPPathA:              pa,
+				RPKIValidationState:   route.RPKIValidationNotFound,
+				ASPAVerificationState: state,
+			},
+		}
+	}
+
+	validator := &mockRPKIValidator{
+		leakers: map[uint32]struct{}{
+			65002: {},
+		},
+		clients: make(map[routingtable.RPKIValidatorClient]struct{}),
+	}
+
+	rib := locRIB.New("inet.0")
+	a := New(filter.NewAcceptAllFilterChain(), vrf.NewUntrackedVRF("inet.0", 0), routingtable.SessionAttrs{
+		RouterID:          net.IPv4FromOctets(1, 1, 1, 1).Ptr().ToUint32(),
+		LocalASN:          65000,
+		PeerASN:           65001,
+		RPKIValidator:     validator,
+		ASPARejectInvalid: true,
+		PeerRoleEnabled:   true,
+		PeerRoleLocal:     packet.PeerRoleRoleProvider,
+	})
+	a.Register(rib)
+
+	a.AddPath(pfx1, bgpPath([]uint32{65001, 65003}, route.ASPAVerificationUnverified))
+	a.AddPath(pfx2, bgpPath([]uint32{65001, 65002}, route.ASPAVerificationUnverified))
+
+	assert.Equal(t, map[net.Prefix][]*route.Path{
+		*pfx1: {bgpPath([]uint32{65001, 65003}, route.ASPAVerificationValid)},
+	}, dumpRIB(rib))
+	assert.Equal(t, uint8(route.HiddenReasonASPAInvalid), a.Get(pfx2).Paths()[0].HiddenReason)
+
+	// ASPA changes make the first route invalid and the second one valid
+	validator.leakers = map[uint32]struct{}{
+		65003: {},
+	}
+	a.ASPAUpdate()
+
+	assert.Equal(t, map[net.Prefix][]*route.Path{
+		*pfx2: {bgpPath([]uint32{65001, 65002}, route.ASPAVerificationValid)},
+	}, dumpRIB(rib))
+	assert.Equal(t, uint8(route.HiddenReasonASPAInvalid), a.Get(pfx1).Paths()[0].HiddenReason)
+	assert.Equal(t, uint8(route.HiddenReasonNone), a.Get(pfx2).Paths()[0].HiddenReason)
+}
+
+type mockNextHopTracker struct {
+	metrics map[net.IP]uint32 // metrics holds the IGP metrics of all reachable next hops
+	refs    map[net.IP]int
+	clients map[routingtable.NextHopTrackerClient]struct{}
+}
+
+func (m *mockNextHopTracker) Track(nh *net.IP) (bool, uint32) {
+	m.refs[*nh]++
+	return m.Resolve(nh)
+}
+
+func (m *mockNextHopTracker) Untrack(nh *net.IP) {
+	m.refs[*nh]--
+}
+
+func (m *mockNextHopTracker) Resolve(nh *net.IP) (bool, uint32) {
+	metric, found := m.metrics[*nh]
+	return found, metric
+}
+
+func (m *mockNextHopTracker) Register(client routingtable.NextHopTrackerClient) {
+	m.clients[client] = struct{}{}
+}
+
+func (m *mockNextHopTracker) Unregister(client routingtable.NextHopTrackerClient) {
+	delete(m.clients, client)
+}
+
+func TestNextHopTracking(t *testing.T) {
+	pfx1 := net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr()
+	pfx2 := net.NewPfx(net.IPv4FromOctets(11, 0, 0, 0), 8).Ptr()
+	nh1 := net.IPv4FromOctets(192, 0, 2, 1)
+	nh2 := net.IPv4FromOctets(192, 0, 2, 2)
+	bgpPath := func(nh net.IP, metric uint32) *route.Path {
+		pa := route.NewBGPPathA()
+		pa.NextHop = nh.Ptr()
+		pa.LocalPref = 100
+
+		return &route.Path{
+			Type: route.BGPPathType,
+			BGPPath: &route.BGPPath{
+				ASPath:    types.NewASPath([]uint32{}),
+				BGPPathA:  pa,
+				IGPMetric: metric,
+			},
+		}
+	}
+
+	tracker := &mockNextHopTracker{
+		metrics: map[net.IP]uint32{
+			nh1: 10,
+		},
+		refs:    make(map[net.IP]int),
+		clients: make(map[routingtable.NextHopTrackerClient]struct{}),
+	}
+
+	rib := locRIB.New("inet.0")
+	a := New(filter.NewAcceptAllFilterChain(), vrf.NewUntrackedVRF("inet.0", 0), routingtable.SessionAttrs{
+		RouterID:       net.IPv4FromOctets(1, 1, 1, 1).Ptr().ToUint32(),
+		LocalASN:       65000,
+		PeerASN:        65000,
+		IBGP:           true,
+		NextHopTracker: tracker,
+	})
+	a.Register(rib)
+	assert.Len(t, tracker.clients, 1)
+
+	a.AddPath(pfx1, bgpPath(nh1, 0))
+	a.AddPath(pfx2, bgpPath(nh2, 0))
+
+	assert.Equal(t, map[net.Prefix][]*route.Path{
+		*pfx1: {bgpPath(nh1, 10)},
+	}, dumpRIB(rib))
+	assert.Equal(t, uint8(route.HiddenReasonNextHopUnreachable), a.Get(pfx2).Paths()[0].HiddenReason)
+
+	// The first next hop becomes unreachable, the second one reachable
+	tracker.metrics = map[net.IP]uint32{
+		nh2: 20,
+	}
+	a.NextHopUpdate([]*net.IP{nh1.Ptr(), nh2.Ptr()})
+
+	assert.Equal(t, map[net.Prefix][]*route.Path{
+		*pfx2: {bgpPath(nh2, 20)},
+	}, dumpRIB(rib))
+	assert.Equal(t, uint8(route.HiddenReasonNextHopUnreachable), a.Get(pfx1).Paths()[0].HiddenReason)
+
+	// A metric change replaces the path
+	tracker.metrics[nh2] = 30
+	a.NextHopUpdate([]*net.IP{nh2.Ptr()})
+
+	assert.Equal(t, map[net.Prefix][]*route.Path{
+		*pfx2: {bgpPath(nh2, 30)},
+	}, dumpRIB(rib))
+
+	a.RemovePath(pfx1, bgpPath(nh1, 0))
+	a.RemovePath(pfx2, bgpPath(nh2, 0))
+	assert.Equal(t, map[net.IP]int{
+		nh1: 0,
+		nh2: 0,
+	}, tracker.refs)
+
+	a.Dispose()
+	assert.Len(t, tracker.clients, 0)
+}
+
+func TestReplaceNextHopTracker(t *testing.T) {
+	pfx1 := net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr()
+	pfx2 := net.NewPfx(net.IPv4FromOctets(11, 0, 0, 0), 8).Ptr()
+	nh1 := net.IPv4FromOctets(192, 0, 2, 1)
+	nh2 := net.IPv4FromOctets(192, 0, 2, 2)
+	bgpPath := func(nh net.IP, metric uint32) *route.Path {
+		pa := route.NewBGPPathA()
+		pa.NextHop = nh.Ptr()
+		pa.LocalPref = 100
+
+		return &route.Path{
+			Type: route.BGPPathType,
+			BGPPath: &route.BGPPath{
+				ASPath:    types.NewASPath([]uint32{}),
+				BGPPathA:  pa,
+				IGPMetric: metric,
+			},
+		}
+	}
+
+	newTracker := func(metrics map[net.IP]uint32) *mockNextHopTracker {
+		return &mockNextHopTracker{
+			metrics: metrics,
+			refs:    make(map[net.IP]int),
+			clients: make(map[routingtable.NextHopTrackerClient]struct{}),
+		}
+	}
+
+	rib := locRIB.New("inet.0")
+	a := New(filter.NewAcceptAllFilterChain(), vrf.NewUntrackedVRF("inet.0", 0), routingtable.SessionAttrs{
+		RouterID: net.IPv4FromOctets(1, 1, 1, 1).Ptr().ToUint32(),
+		LocalASN: 65000,
+		PeerASN:  65000,
+		IBGP:     true,
+	})
+	a.Register(rib)
+
+	a.AddPath(pfx1, bgpPath(nh1, 0))
+	a.AddPath(pfx2, bgpPath(nh2, 0))
+
+	// Enabling next hop tracking hides the path with the unreachable next hop
+	t1 := newTracker(map[net.IP]uint32{
+		nh1: 10,
+	})
+	a.ReplaceNextHopTracker(t1)
+
+	assert.Len(t, t1.clients, 1)
+	assert.Equal(t, map[net.IP]int{
+		nh1: 1,
+		nh2: 1,
+	}, t1.refs)
+	assert.Equal(t, map[net.Prefix][]*route.Path{
+		*pfx1: {bgpPath(nh1, 10)},
+	}, dumpRIB(rib))
+	assert.Equal(t, uint8(route.HiddenReasonNextHopUnreachable), a.Get(pfx2).Paths()[0].HiddenReason)
+
+	// Another tracker takes over the next hops of all paths
+	t2 := newTracker(map[net.IP]uint32{
+		nh2: 20,
+	})
+	a.ReplaceNextHopTracker(t2)
+
+	assert.Len(t, t1.clients, 0)
+	assert.Equal(t, map[net.IP]int{
+		nh1: 0,
+		nh2: 0,
+	}, t1.refs)
+	assert.Len(t, t2.clients, 1)
+	assert.Equal(t, map[net.IP]int{
+		nh1: 1,
+		nh2: 1,
+	}, t2.refs)
+	assert.Equal(t, map[net.Prefix][]*route.Path{
+		*pfx2: {bgpPath(nh2, 20)},
+	}, dumpRIB(rib))
+	assert.Equal(t, uint8(route.HiddenReasonNextHopUnreachable), a.Get(pfx1).Paths()[0].HiddenReason)
+
+	// Disabling next hop tracking makes all paths eligible again
+	a.ReplaceNextHopTracker(nil)
+
+	assert.Len(t, t2.clients, 0)
+	assert.Equal(t, map[net.IP]int{
+		nh1: 0,
+		nh2: 0,
+	}, t2.refs)
+	assert.Equal(t, map[net.Prefix][]*route.Path{
+		*pfx1: {bgpPath(nh1, 0)},
+		*pfx2: {bgpPath(nh2, 0)},
+	}, dumpRIB(rib))
+}
+
+type mockRouteDampener struct {
+	suppressed  map[net.Prefix]bool
+	changes     map[net.Prefix]int
+	withdrawals map[net.Prefix]int
+	clients     map[routingtable.RouteDampenerClient]struct{}
+}
+
+func (m *mockRouteDampener) Advertise(pfx *net.Prefix, pathID uint32, changed bool) bool {
+	if changed {
+		m.changes[*pfx]++
+	}
+
+	return m.suppressed[*pfx]
+}
+
+func (m *mockRouteDampener) Withdraw(pfx *net.Prefix, pathID uint32) {
+	m.withdrawals[*pfx]++
+}
+
+func (m *mockRouteDampener) State(pfx *net.Prefix, pathID uint32) *routingtable.DampeningState {
+	return nil
+}
+
+func (m *mockRouteDampener) Clear(pfx *net.Prefix) {}
+
+func (m *mockRouteDampener) Register(client routingtable.RouteDampenerClient) {
+	m.clients[client] = struct{}{}
+}
+
+func (m *mockRouteDampener) Unregister(client routingtable.RouteDampenerClient) {
+	delete(m.clients, client)
+}
+
+func TestRouteDampening(t *testing.T) {
+	pfx1 := net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr()
+	pfx2 := net.NewPfx(net.IPv4FromOctets(11, 0, 0, 0), 8).Ptr()
+	bgpPath := func(med uint32) *route.Path {
+		pa := route.NewBGPPathA()
+		pa.NextHop = net.IPv4FromOctets(192, 0, 2, 1).Ptr()
+		pa.LocalPref = 100
+		pa.MED = med
+
+		return &route.Path{
+			Type: route.BGPPathType,
+			BGPPath: &route.BGPPath{
+				ASPath:   types.NewASPath([]uint32{}),
+				BGPPathA: pa,
+			},
+		}
+	}
+
+	dampener := &mockRouteDampener{
+		suppressed: map[net.Prefix]bool{
+			*pfx1: true,
+		},
+		changes:     make(map[net.Prefix]int),
+		withdrawals: make(map[net.Prefix]int),
+		clients:     make(map[routingtable.RouteDampenerClient]struct{}),
+	}
+
+	rib := locRIB.New("inet.0")
+	a := New(filter.NewAcceptAllFilterChain(), vrf.NewUntrackedVRF("inet.0", 0), routingtable.SessionAttrs{
+		RouterID:      net.IPv4FromOctets(1, 1, 1, 1).Ptr().ToUint32(),
+		LocalASN:      65000,
+		PeerASN:       65000,
+		IBGP:          true,
+		RouteDampener: dampener,
+	})
+	a.Register(rib)
+	assert.Len(t, dampener.clients, 1)
+
+	a.AddPath(pfx1, bgpPath(0))
+	a.AddPath(pfx2, bgpPath(0))
+
+	assert.Equal(t, map[net.Prefix][]*route.Path{
+		*pfx2: {bgpPath(0)},
+	}, dumpRIB(rib))
+	assert.Equal(t, uint8(route.HiddenReasonDampened), a.Get(pfx1).Paths()[0].HiddenReason)
+
+	// Only a change of the attributes received from the peer is penalized
+	a.AddPath(pfx2, bgpPath(0))
+	assert.Equal(t, 0, dampener.changes[*pfx2])
+
+	a.AddPath(pfx2, bgpPath(10))
+	assert.Equal(t, 1, dampener.changes[*pfx2])
+	assert.Equal(t, map[net.Prefix][]*route.Path{
+		*pfx2: {bgpPath(10)},
+	}, dumpRIB(rib))
+
+	a.RemovePath(pfx2, bgpPath(10))
+	assert.Equal(t, 1, dampener.withdrawals[*pfx2])
+	assert.Empty(t, dumpRIB(rib))
+
+	// The suppressed path is advertised once it is reused
+	dampener.suppressed[*pfx1] = false
+	a.DampeningReuse(pfx1, 0)
+
+	assert.Equal(t, map[net.Prefix][]*route.Path{
+		*pfx1: {bgpPath(0)},
+	}, dumpRIB(rib))
+	assert.Equal(t, uint8(route.HiddenReasonNone), a.Get(pfx1).Paths()[0].HiddenReason)
+
+	a.Dispose()
+	assert.Len(t, dampener.clients, 0)
+}
+
+func TestReplaceRouteDampener(t *testing.T) {
+	pfx1 := net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr()
+	pfx2 := net.NewPfx(net.IPv4FromOctets(11, 0, 0, 0), 8).Ptr()
+	bgpPath := func() *route.Path {
+		pa := route.NewBGPPathA()
+		pa.NextHop = net.IPv4FromOctets(192, 0, 2, 1).Ptr()
+		pa.LocalPref = 100
+
+		return &route.Path{
+			Type: route.BGPPathType,
+			BGPPath: &route.BGPPath{
+				ASPath:   types.NewASPath([]uint32{}),
+				BGPPathA: pa,
+			},
+		}
+	}
+	newDampener := func(suppressed ...*net.Prefix) *mockRouteDampener {
+		m := &mockRouteDampener{
+			suppressed:  make(map[net.Prefix]bool),
+			changes:     make(map[net.Prefix]int),
+			withdrawals: make(map[net.Prefix]int),
+			clients:     make(map[routingtable.RouteDampenerClient]struct{}),
+		}
+
+		for _, pfx := range suppressed {
+			m.suppressed[*pfx] = true
+		}
+
+		return m
+	}
+
+	rib := locRIB.New("inet.0")
+	a := New(filter.NewAcceptAllFilterChain(), vrf.NewUntrackedVRF("inet.0", 0), routingtable.SessionAttrs{
+		RouterID: net.IPv4FromOctets(1, 1, 1, 1).Ptr().ToUint32(),
+		LocalASN: 65000,
+		PeerASN:  65000,
+		IBGP:     true,
+	})
+	a.Register(rib)
+	defer a.Dispose()
+
+	// Routes received before dampening is enabled are not suppressed retroactively
+	a.AddPath(pfx1, bgpPath())
+
+	d1 := newDampener(pfx1, pfx2)
+	a.ReplaceRouteDampener(d1)
+	assert.Len(t, d1.clients, 1)
+
+	a.AddPath(pfx2, bgpPath())
+	assert.Equal(t, map[net.Prefix][]*route.Path{
+		*pfx1: {bgpPath()},
+	}, dumpRIB(rib))
+
+	// The new dampener does not know the routes suppressed by the previous one
+	d2 := newDampener()
+	a.ReplaceRouteDampener(d2)
+	assert.Len(t, d1.clients, 0)
+	assert.Len(t, d2.clients, 1)
+	assert.Equal(t, map[net.Prefix][]*route.Path{
+		*pfx1: {bgpPath()},
+		*pfx2: {bgpPath()},
+	}, dumpRIB(rib))
+
+	a.RemovePath(pfx2, bgpPath())
+	assert.Equal(t, 1, d2.withdrawals[*pfx2])
+
+	d2.suppressed[*pfx2] = true
+	a.AddPath(pfx2, bgpPath())
+	assert.Equal(t, uint8(route.HiddenReasonDampened), a.Get(pfx2).Paths()[0].HiddenReason)
+
+	// Disabling dampening reuses all suppressed routes
+	a.ReplaceRouteDampener(nil)
+	assert.Len(t, d2.clients, 0)
+	assert.Equal(t, uint8(route.HiddenReasonNone), a.Get(pfx2).Paths()[0].HiddenReason)
+	assert.Equal(t, map[net.Prefix][]*route.Path{
+		*pfx1: {bgpPath()},
+		*pfx2: {bgpPath()},
+	}, dumpRIB(rib))
+
+	a.RemovePath(pfx2, bgpPath())
+	assert.Equal(t, 1, d2.withdrawals[*pfx2])
+}
+
+func dumpRIB(rib *locRIB.LocRIB) map[net.Prefix][]*route.Path {
+	res := make(map[net.Prefix][]*route.Path)
+	for _, r := range rib.Dump() {
+		res[*r.Prefix()] = r.Paths()
+	}
+
+	return res
+}
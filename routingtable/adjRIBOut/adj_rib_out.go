@@ -10,32 +10,42 @@ import (
 	"github.com/bio-routing/bio-rd/route"
 	"github.com/bio-routing/bio-rd/routingtable"
 	"github.com/bio-routing/bio-rd/routingtable/filter"
-	"github.com/bio-routing/bio-rd/routingtable/locRIB"
 	"github.com/bio-routing/bio-rd/util/log"
 )
 
+// RIB is the interface of the RIB an AdjRIBOut is registered to
+type RIB interface {
+	RefreshClient(client routingtable.RouteTableClient)
+}
+
 // AdjRIBOut represents an Adjacency RIB Out with BGP add path
 type AdjRIBOut struct {
 	clientManager            *routingtable.ClientManager
-	rib                      *locRIB.LocRIB
+	rib                      RIB
 	rt                       *routingtable.RoutingTable
 	sessionAttrs             routingtable.SessionAttrs
 	pathIDManager            *pathIDManager
 	exportFilterChain        filter.Chain
 	exportFilterChainPending filter.Chain
 	mu                       sync.RWMutex
+	refreshMu                sync.Mutex
 }
 
 // New creates a new Adjacency RIB Out with BGP add path
-func New(rib *locRIB.LocRIB, sessionAttrs routingtable.SessionAttrs, exportFilterChain filter.Chain) *AdjRIBOut {
+func New(rib RIB, sessionAttrs routingtable.SessionAttrs, exportFilterChain filter.Chain) *AdjRIBOut {
 	a := &AdjRIBOut{
-		rib:               rib,
-		rt:                routingtable.NewRoutingTable(),
-		sessionAttrs:      sessionAttrs,
-		pathIDManager:     newPathIDManager(),
-		exportFilterChain: exportFilterChain,
+		rib:                      rib,
+		rt:                       routingtable.NewRoutingTable(),
+		sessionAttrs:             sessionAttrs,
+		pathIDManager:            newPathIDManager(),
+		exportFilterChain:        exportFilterChain,
+		exportFilterChainPending: exportFilterChain,
 	}
 	a.clientManager = routingtable.NewClientManager(a)
+	if a.sessionAttrs.RouteSuppressor != nil {
+		a.sessionAttrs.RouteSuppressor.Register(a)
+	}
+
 	return a
 }
 
@@ -89,6 +99,12 @@ func (a *AdjRIBOut) checkPropagateUpdateIBGP(pfx *bnet.Prefix, p *route.Path) (r
 		return p, true
 	}
 
+	// Paths exported from one of our VRFs and our own aggregates are propagated with ourselves as next hop (RFC4364 Sect. 4.3.2)
+	if p.BGPPath.LocalVPNExport || p.BGPPath.LocalAggregate {
+		p.BGPPath.BGPPathA.NextHop = a.sessionAttrs.LocalIP
+		return p, true
+	}
+
 	// Don't export routes learned via iBGP to an iBGP neighbor which is NOT a route reflection client
 	if !p.BGPPath.BGPPathA.EBGP && a.sessionAttrs.IBGP && !a.sessionAttrs.RouteReflectorClient {
 		return nil, false
@@ -131,6 +147,10 @@ func (a *AdjRIBOut) checkPropagateUpdateEBGP(pfx *bnet.Prefix, p *route.Path) (r
 		p.BGPPath.BGPPathA.NextHop = a.sessionAttrs.LocalIP
 	}
 
+	// RFC4360 Sect. 6: Non-transitive extended communities must not be advertised to other ASes
+	p.BGPPath.ExtendedCommunities = p.BGPPath.ExtendedCommunities.Transitive()
+	p.BGPPath.IPv6ExtendedCommunities = p.BGPPath.IPv6ExtendedCommunities.Transitive()
+
 	// RFC9234 Sect 5. Egress par. - Check OTC attribute
 	if a.sessionAttrs.PeerRoleEnabled && a.sessionAttrs.PeerRoleAdvByPeer {
 		pr := a.sessionAttrs.PeerRoleRemote
@@ -158,6 +178,10 @@ func (a *AdjRIBOut) AddPathInitialDump(pfx *bnet.Prefix, p *route.Path) error {
 
 // AddPath adds path p to prefix `pfx`
 func (a *AdjRIBOut) AddPath(pfx *bnet.Prefix, p *route.Path) error {
+	if a.suppressed(pfx) {
+		return nil
+	}
+
 	p, redist := p.CheckRedistribute(route.BGPPathType)
 	if redist {
 		err := a.redistributePath(p)
@@ -193,6 +217,10 @@ func (a *AdjRIBOut) addPath(pfx *bnet.Prefix, p *route.Path) error {
 
 		p.BGPPath.PathIdentifier = pathID
 		a.rt.AddPath(pfx, p)
+	} else if p.BGPPath.QualifiesPrefix() {
+		oldPaths := a.removePathsOfSameRoute(pfx, p)
+		a.rt.AddPath(pfx, p)
+		a.removePathsFromClients(pfx, oldPaths)
 	} else {
 		// rt.ReplacePath will add this path to the rt in any case, so no rt.AddPath here!
 		oldPaths := a.rt.ReplacePath(pfx, p)
@@ -282,6 +310,26 @@ func (a *AdjRIBOut) removePathsForPrefix(pfx *bnet.Prefix) bool {
 	return true
 }
 
+// removePathsOfSameRoute removes all paths of the route of path x. Multiple routes may share a prefix for VPN and FlowSpec paths.
+func (a *AdjRIBOut) removePathsOfSameRoute(pfx *bnet.Prefix, x *route.Path) []*route.Path {
+	r := a.rt.Get(pfx)
+	if r == nil {
+		return nil
+	}
+
+	removed := make([]*route.Path, 0)
+	for _, p := range r.Paths() {
+		if !p.BGPPath.SameRoute(x.BGPPath) {
+			continue
+		}
+
+		a.rt.RemovePath(pfx, p)
+		removed = append(removed, p)
+	}
+
+	return removed
+}
+
 func (a *AdjRIBOut) removePathsFromClients(pfx *bnet.Prefix, paths []*route.Path) {
 	for _, p := range paths {
 		a.removePathFromClients(pfx, p)
@@ -343,7 +391,31 @@ func (a *AdjRIBOut) ReplacePath(pfx *bnet.Prefix, old *route.Path, new *route.Pa
 
 // RefreshRoute refreshes a route
 func (a *AdjRIBOut) RefreshRoute(pfx *bnet.Prefix, ribPaths []*route.Path) {
+	a.refreshMu.Lock()
+	defer a.refreshMu.Unlock()
+
+	r := a.rt.Get(pfx)
+	if a.suppressed(pfx) {
+		if r != nil {
+			for _, p := range r.Paths() {
+				a.removePath(pfx, p)
+			}
+		}
+
+		return
+	}
+
+	// Paths of a suppressed route have not been advertised no matter what the current filter says
+	advertised := r != nil
 	for _, p := range ribPaths {
+		p, redist := p.CheckRedistribute(route.BGPPathType)
+		if redist {
+			err := a.redistributePath(p)
+			if err != nil {
+				continue
+			}
+		}
+
 		p, propagate := a.checkPropagateUpdate(pfx, p)
 		if !propagate {
 			continue
@@ -351,6 +423,9 @@ func (a *AdjRIBOut) RefreshRoute(pfx *bnet.Prefix, ribPaths []*route.Path) {
 
 		currentPath, currentReject := a.exportFilterChain.Process(pfx, p)
 		newPath, newReject := a.exportFilterChainPending.Process(pfx, p)
+		if !advertised {
+			currentReject = true
+		}
 
 		if currentReject && newReject {
 			continue
@@ -392,9 +467,22 @@ func (a *AdjRIBOut) GetLonger(pfx *bnet.Prefix) (res []*route.Route) {
 	return a.rt.GetLonger(pfx)
 }
 
-// Dispose is here to fulfill an interface. We don't care if the RIB we're registred to
-// as a client is gone as this only happens in the BMP use case where AdjRIBOut is not used.
-func (a *AdjRIBOut) Dispose() {}
+// Dispose stops the re-evaluation of routes on changes of the suppressed routes. We don't care if the RIB we're
+// registred to as a client is gone as this only happens in the BMP use case where AdjRIBOut is not used.
+func (a *AdjRIBOut) Dispose() {
+	if a.sessionAttrs.RouteSuppressor != nil {
+		a.sessionAttrs.RouteSuppressor.Unregister(a)
+	}
+}
+
+// SuppressionUpdate withdraws routes suppressed now and advertises routes not suppressed anymore
+func (a *AdjRIBOut) SuppressionUpdate() {
+	a.rib.RefreshClient(a)
+}
+
+func (a *AdjRIBOut) suppressed(pfx *bnet.Prefix) bool {
+	return a.sessionAttrs.RouteSuppressor != nil && a.sessionAttrs.RouteSuppressor.Suppressed(pfx)
+}
 
 func (a *AdjRIBOut) redistributePath(p *route.Path) error {
 	// We're working on a copy of the Path, so make sure we have a fresh start
@@ -403,18 +491,33 @@ func (a *AdjRIBOut) redistributePath(p *route.Path) error {
 	switch p.RedistributedFrom {
 	case route.StaticPathType:
 		a.redistributeFromStatic(p)
+	case route.FIBPathType:
+		a.redistributeFromFIB(p)
+	case route.ConnectedPathType:
+		p.BGPPath.BGPPathA.NextHop = a.sessionAttrs.LocalIP
 	default:
-		return fmt.Errorf("redistribution from %s to BGP is not supported (yet?)", route.GetPathTypeName(p.Type))
+		return fmt.Errorf("redistribution from %s to BGP is not supported (yet?)", route.GetPathTypeName(p.RedistributedFrom))
 	}
 
 	return nil
 }
 
 func (a *AdjRIBOut) redistributeFromStatic(p *route.Path) {
-	if p.StaticPath == nil {
+	// Discard routes have no next hop
+	if p.StaticPath == nil || p.StaticPath.NextHop == nil {
 		p.BGPPath.BGPPathA.NextHop = a.sessionAttrs.LocalIP
 		return
 	}
 
 	p.BGPPath.BGPPathA.NextHop = p.StaticPath.NextHop
 }
+
+func (a *AdjRIBOut) redistributeFromFIB(p *route.Path) {
+	// Device routes (e.g. for DHCP pools) have no gateway, so we are the next hop
+	if p.FIBPath == nil || p.FIBPath.NextHop == nil {
+		p.BGPPath.BGPPathA.NextHop = a.sessionAttrs.LocalIP
+		return
+	}
+
+	p.BGPPath.BGPPathA.NextHop = p.FIBPath.NextHop
+}
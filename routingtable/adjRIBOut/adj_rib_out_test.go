@@ -13,6 +13,7 @@ import (
 
 	"github.com/bio-routing/bio-rd/route"
 	"github.com/bio-routing/bio-rd/routingtable"
+	"github.com/bio-routing/bio-rd/routingtable/locRIB"
 )
 
 func TestBestPathOnlyEBGP(t *testing.T) {
@@ -1828,3 +1829,296 @@ func TestOTC(t *testing.T) {
 		assert.Equal(t, test.expected, adjRIBOut.rt.Dump())
 	}
 }
+
+func TestNonTransitiveExtendedCommunitiesEBGP(t *testing.T) {
+	sessionAttrs := routingtable.SessionAttrs{
+		Type:     route.BGPPathType,
+		LocalIP:  net.IPv4FromOctets(127, 0, 0, 1).Ptr(),
+		PeerIP:   net.IPv4FromOctets(127, 0, 0, 2).Ptr(),
+		LocalASN: 41981,
+	}
+
+	adjRIBOut := New(nil, sessionAttrs, filter.NewAcceptAllFilterChain())
+
+	pfx := net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr()
+	adjRIBOut.AddPath(pfx, &route.Path{
+		Type: route.BGPPathType,
+		BGPPath: &route.BGPPath{
+			BGPPathA: &route.BGPPathA{
+				Source: net.IPv4(0).Ptr(),
+			},
+			ASPath: &types.ASPath{},
+			ExtendedCommunities: &types.ExtendedCommunities{
+				types.NewRouteTarget(65000, false, 100),
+				{
+					Type:    types.ExtendedCommunityTypeTwoOctetAS | types.ExtendedCommunityTypeNonTransitive,
+					SubType: 0x04,
+					Value:   1,
+				},
+			},
+		},
+	})
+
+	routes := adjRIBOut.rt.Dump()
+	assert.Equal(t, 1, len(routes))
+	assert.Equal(t, &types.ExtendedCommunities{
+		types.NewRouteTarget(65000, false, 100),
+	}, routes[0].Paths()[0].BGPPath.ExtendedCommunities)
+}
+
+func TestVPNPathsIBGP(t *testing.T) {
+	sessionAttrs := routingtable.SessionAttrs{
+		Type:     route.BGPPathType,
+		LocalIP:  net.IPv4FromOctets(127, 0, 0, 1).Ptr(),
+		PeerIP:   net.IPv4FromOctets(127, 0, 0, 2).Ptr(),
+		IBGP:     true,
+		LocalASN: 41981,
+	}
+
+	vpnPath := func(rd uint64, source *net.IP, nextHop *net.IP, localExport bool) *route.Path {
+		return &route.Path{
+			Type: route.BGPPathType,
+			BGPPath: &route.BGPPath{
+				RouteDistinguisher: rd,
+				LabelStack:         []uint32{1000},
+				BGPPathA: &route.BGPPathA{
+					Source:  source,
+					NextHop: nextHop,
+				},
+				ASPath:         &types.ASPath{},
+				LocalVPNExport: localExport,
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		paths    []*route.Path
+		expected []*route.Route
+	}{
+		{
+			name: "Locally exported VPN path is propagated with ourselves as next hop",
+			paths: []*route.Path{
+				vpnPath(65000<<32+1, net.IPv4(0).Ptr(), net.IPv4FromOctets(192, 0, 2, 1).Ptr(), true),
+			},
+			expected: []*route.Route{
+				route.NewRoute(net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr(),
+					vpnPath(65000<<32+1, net.IPv4(0).Ptr(), sessionAttrs.LocalIP, true)),
+			},
+		},
+		{
+			name: "VPN path learned via iBGP is not propagated",
+			paths: []*route.Path{
+				vpnPath(65000<<32+1, net.IPv4FromOctets(192, 0, 2, 2).Ptr(), net.IPv4FromOctets(192, 0, 2, 2).Ptr(), false),
+			},
+			expected: []*route.Route{},
+		},
+		{
+			name: "Same prefix with different route distinguishers",
+			paths: []*route.Path{
+				vpnPath(65000<<32+1, net.IPv4(0).Ptr(), net.IPv4FromOctets(192, 0, 2, 1).Ptr(), true),
+				vpnPath(65000<<32+2, net.IPv4(0).Ptr(), net.IPv4FromOctets(192, 0, 2, 1).Ptr(), true),
+			},
+			expected: []*route.Route{
+				route.NewRouteAddPath(net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr(), []*route.Path{
+					vpnPath(65000<<32+1, net.IPv4(0).Ptr(), sessionAttrs.LocalIP, true),
+					vpnPath(65000<<32+2, net.IPv4(0).Ptr(), sessionAttrs.LocalIP, true),
+				}),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			adjRIBOut := New(nil, sessionAttrs, filter.NewAcceptAllFilterChain())
+			for _, p := range test.paths {
+				adjRIBOut.AddPath(net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr(), p)
+			}
+
+			assert.Equal(t, test.expected, adjRIBOut.rt.Dump())
+		})
+	}
+}
+
+func TestRedistributePath(t *testing.T) {
+	sessionAttrs := routingtable.SessionAttrs{
+		Type:    route.BGPPathType,
+		LocalIP: net.IPv4FromOctets(127, 0, 0, 1).Ptr(),
+		PeerIP:  net.IPv4FromOctets(127, 0, 0, 2).Ptr(),
+	}
+
+	tests := []struct {
+		name            string
+		path            *route.Path
+		wantFail        bool
+		expectedNextHop *net.IP
+	}{
+		{
+			name: "Static path",
+			path: &route.Path{
+				Type: route.StaticPathType,
+				StaticPath: &route.StaticPath{
+					NextHop: net.IPv4FromOctets(192, 0, 2, 1).Ptr(),
+				},
+			},
+			expectedNextHop: net.IPv4FromOctets(192, 0, 2, 1).Ptr(),
+		},
+		{
+			name: "Static discard path",
+			path: &route.Path{
+				Type: route.StaticPathType,
+				StaticPath: &route.StaticPath{
+					Discard: true,
+				},
+			},
+			expectedNextHop: sessionAttrs.LocalIP,
+		},
+		{
+			name: "FIB path with gateway",
+			path: &route.Path{
+				Type: route.FIBPathType,
+				FIBPath: &route.FIBPath{
+					NextHop: net.IPv4FromOctets(192, 0, 2, 1).Ptr(),
+				},
+			},
+			expectedNextHop: net.IPv4FromOctets(192, 0, 2, 1).Ptr(),
+		},
+		{
+			name: "FIB path without gateway",
+			path: &route.Path{
+				Type:    route.FIBPathType,
+				FIBPath: &route.FIBPath{},
+			},
+			expectedNextHop: sessionAttrs.LocalIP,
+		},
+		{
+			name: "Connected path",
+			path: &route.Path{
+				Type: route.ConnectedPathType,
+				ConnectedPath: &route.ConnectedPath{
+					Interface: "lo",
+					Address:   net.IPv4FromOctets(10, 0, 0, 1).Ptr(),
+				},
+			},
+			expectedNextHop: sessionAttrs.LocalIP,
+		},
+		{
+			name: "OSPF path",
+			path: &route.Path{
+				Type: route.OSPFPathType,
+			},
+			wantFail: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := New(nil, sessionAttrs, filter.NewAcceptAllFilterChain())
+			p, redist := test.path.CheckRedistribute(route.BGPPathType)
+			assert.True(t, redist)
+
+			err := a.redistributePath(p)
+			if test.wantFail {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedNextHop, p.BGPPath.BGPPathA.NextHop)
+		})
+	}
+}
+
+func TestLocalAggregateIBGP(t *testing.T) {
+	sessionAttrs := routingtable.SessionAttrs{
+		Type:     route.BGPPathType,
+		LocalIP:  net.IPv4FromOctets(127, 0, 0, 1).Ptr(),
+		PeerIP:   net.IPv4FromOctets(127, 0, 0, 2).Ptr(),
+		IBGP:     true,
+		LocalASN: 41981,
+	}
+
+	p := route.NewBGPPath()
+	p.LocalAggregate = true
+
+	adjRIBOut := New(nil, sessionAttrs, filter.NewAcceptAllFilterChain())
+	adjRIBOut.AddPath(net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr(), &route.Path{
+		Type:    route.BGPPathType,
+		BGPPath: p,
+	})
+
+	routes := adjRIBOut.rt.Dump()
+	assert.Equal(t, 1, len(routes))
+	assert.Equal(t, sessionAttrs.LocalIP, routes[0].Paths()[0].BGPPath.BGPPathA.NextHop)
+}
+
+type mockRouteSuppressor struct {
+	suppressed map[net.Prefix]struct{}
+	clients    map[routingtable.RouteSuppressorClient]struct{}
+}
+
+func (m *mockRouteSuppressor) Suppressed(pfx *net.Prefix) bool {
+	_, found := m.suppressed[*pfx]
+	return found
+}
+
+func (m *mockRouteSuppressor) Register(client routingtable.RouteSuppressorClient) {
+	m.clients[client] = struct{}{}
+}
+
+func (m *mockRouteSuppressor) Unregister(client routingtable.RouteSuppressorClient) {
+	delete(m.clients, client)
+}
+
+func (m *mockRouteSuppressor) set(pfx *net.Prefix, suppressed bool) {
+	if suppressed {
+		m.suppressed[*pfx] = struct{}{}
+	} else {
+		delete(m.suppressed, *pfx)
+	}
+
+	for c := range m.clients {
+		c.SuppressionUpdate()
+	}
+}
+
+func TestSuppression(t *testing.T) {
+	suppressor := &mockRouteSuppressor{
+		suppressed: make(map[net.Prefix]struct{}),
+		clients:    make(map[routingtable.RouteSuppressorClient]struct{}),
+	}
+	sessionAttrs := routingtable.SessionAttrs{
+		Type:            route.BGPPathType,
+		LocalIP:         net.IPv4FromOctets(127, 0, 0, 1).Ptr(),
+		PeerIP:          net.IPv4FromOctets(127, 0, 0, 2).Ptr(),
+		LocalASN:        41981,
+		RouteSuppressor: suppressor,
+	}
+
+	pfxA := net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 24).Ptr()
+	pfxB := net.NewPfx(net.IPv4FromOctets(10, 0, 1, 0), 24).Ptr()
+	p := route.NewBGPPath()
+	p.BGPPathA.EBGP = true
+
+	rib := locRIB.New("inet.0")
+	adjRIBOut := New(rib, sessionAttrs, filter.NewAcceptAllFilterChain())
+	rib.Register(adjRIBOut)
+	assert.Len(t, suppressor.clients, 1)
+
+	suppressor.set(pfxA, true)
+	rib.AddPath(pfxA, &route.Path{Type: route.BGPPathType, BGPPath: p})
+	rib.AddPath(pfxB, &route.Path{Type: route.BGPPathType, BGPPath: p})
+	assert.Nil(t, adjRIBOut.Get(pfxA), "suppressed route is not advertised")
+	assert.NotNil(t, adjRIBOut.Get(pfxB))
+
+	suppressor.set(pfxB, true)
+	assert.Nil(t, adjRIBOut.Get(pfxB), "route is withdrawn once suppressed")
+
+	suppressor.set(pfxA, false)
+	assert.NotNil(t, adjRIBOut.Get(pfxA), "route is advertised once not suppressed anymore")
+	assert.Equal(t, []uint32{41981}, (*adjRIBOut.Get(pfxA).Paths()[0].BGPPath.ASPath)[0].ASNs)
+	assert.Equal(t, uint16(0), rib.Get(pfxA).Paths()[0].BGPPath.ASPathLen, "RIB path is not modified")
+
+	adjRIBOut.Dispose()
+	assert.Len(t, suppressor.clients, 0)
+}
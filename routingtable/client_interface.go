@@ -33,6 +33,14 @@ type AdjRIB interface {
 type AdjRIBIn interface {
 	AdjRIB
 	Flush()
+	// PathCount returns the number of paths received from the peer
+	PathCount() int64
+	// ReplaceNextHopTracker replaces the tracker resolving the next hops of received paths
+	ReplaceNextHopTracker(NextHopTracker)
+	// ReplaceRouteDampener replaces the dampener of received routes
+	ReplaceRouteDampener(RouteDampener)
+	// A call to Dispose() signals that the AdjRIBIn is not used anymore
+	Dispose()
 }
 
 // AdjRIBOut is the interface any AdjRIBOut must implement
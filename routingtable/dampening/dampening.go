@@ -0,0 +1,368 @@
+package dampening
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	bbclock "github.com/benbjohnson/clock"
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/routingtable"
+)
+
+var (
+	clock = bbclock.New()
+)
+
+// SetClock replaces the clock the figures of merit decay with
+func SetClock(c bbclock.Clock) {
+	clock = c
+}
+
+const (
+	// DefaultHalfLife is the half life of the figure of merit recommended by RFC7196
+	DefaultHalfLife = 15 * time.Minute
+
+	// DefaultReuseThreshold is the reuse threshold recommended by RFC7196
+	DefaultReuseThreshold = 750
+
+	// DefaultSuppressThreshold is the suppress threshold recommended by RFC7196
+	DefaultSuppressThreshold = 6000
+
+	// DefaultMaxSuppressTime is the maximum suppress time recommended by RFC7196
+	DefaultMaxSuppressTime = 60 * time.Minute
+
+	// WithdrawalPenalty is added to the figure of merit of a route on every withdrawal
+	WithdrawalPenalty = 1000
+
+	// AttributeChangePenalty is added to the figure of merit of a route on every change of its attributes
+	AttributeChangePenalty = 500
+)
+
+// Config represents the dampening parameters of an address family of a neighbor
+type Config struct {
+	// HalfLife is the time after which the figure of merit of a route has decayed by half
+	HalfLife time.Duration
+
+	// ReuseThreshold is the figure of merit below which a suppressed route is reused
+	ReuseThreshold uint32
+
+	// SuppressThreshold is the figure of merit above which a route is suppressed
+	SuppressThreshold uint32
+
+	// MaxSuppressTime limits the time a route is suppressed after it stopped flapping
+	MaxSuppressTime time.Duration
+}
+
+// Equal compares two dampening configs
+func (c *Config) Equal(x *Config) bool {
+	if c == nil || x == nil {
+		return c == x
+	}
+
+	return *c == *x
+}
+
+// Ceiling returns the maximum figure of merit. A route reaching it is reused MaxSuppressTime after its last flap.
+func (c *Config) Ceiling() float64 {
+	return float64(c.ReuseThreshold) * math.Exp2(float64(c.MaxSuppressTime)/float64(c.HalfLife))
+}
+
+// Dampener keeps track of the figure of merit of the routes received from a neighbor (RFC2439)
+type Dampener struct {
+	cfg     Config
+	ceiling float64
+	mu      sync.Mutex
+	states  map[key]*state
+	clients map[routingtable.RouteDampenerClient]struct{}
+}
+
+type key struct {
+	pfx    bnet.Prefix
+	pathID uint32
+}
+
+type state struct {
+	figureOfMerit float64
+	updated       time.Time
+	suppressed    bool
+	timer         *bbclock.Timer
+
+	// seq identifies the most recently armed timer
+	seq uint64
+}
+
+// New creates a new dampener
+func New(cfg Config) *Dampener {
+	return &Dampener{
+		cfg:     cfg,
+		ceiling: cfg.Ceiling(),
+		states:  make(map[key]*state),
+		clients: make(map[routingtable.RouteDampenerClient]struct{}),
+	}
+}
+
+// SetConfig replaces the dampening parameters. The figures of merit of all routes are kept. Suppressed routes whose
+// figure of merit is not above the new reuse threshold are reused immediately.
+func (d *Dampener) SetConfig(cfg Config) {
+	d.mu.Lock()
+
+	if d.cfg == cfg {
+		d.mu.Unlock()
+		return
+	}
+
+	now := clock.Now()
+	for _, s := range d.states {
+		d.decay(s, now)
+	}
+
+	d.cfg = cfg
+	d.ceiling = cfg.Ceiling()
+
+	reused := make([]key, 0)
+	for k, s := range d.states {
+		s.figureOfMerit = math.Min(s.figureOfMerit, d.ceiling)
+		if s.suppressed && s.figureOfMerit <= float64(d.cfg.ReuseThreshold) {
+			s.suppressed = false
+			reused = append(reused, k)
+		}
+
+		if !s.suppressed && s.figureOfMerit <= float64(d.cfg.ReuseThreshold)/2 {
+			s.timer.Stop()
+			delete(d.states, k)
+			continue
+		}
+
+		d.schedule(k, s)
+	}
+
+	clients := d.clientList()
+	d.mu.Unlock()
+
+	d.notify(clients, reused)
+}
+
+// Register registers a client to be notified about routes which are not suppressed anymore
+func (d *Dampener) Register(client routingtable.RouteDampenerClient) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.clients[client] = struct{}{}
+}
+
+// Unregister unregisters a client
+func (d *Dampener) Unregister(client routingtable.RouteDampenerClient) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	delete(d.clients, client)
+}
+
+// Advertise accounts for an advertisement of a route and returns if the route is suppressed
+func (d *Dampener) Advertise(pfx *bnet.Prefix, pathID uint32, changed bool) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	k := key{
+		pfx:    *pfx,
+		pathID: pathID,
+	}
+
+	s := d.states[k]
+	if changed {
+		s = d.penalize(k, AttributeChangePenalty)
+	}
+
+	return s != nil && s.suppressed
+}
+
+// Withdraw accounts for the withdrawal of a route
+func (d *Dampener) Withdraw(pfx *bnet.Prefix, pathID uint32) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.penalize(key{
+		pfx:    *pfx,
+		pathID: pathID,
+	}, WithdrawalPenalty)
+}
+
+// State returns the dampening state of a route or nil if the route did not flap recently
+func (d *Dampener) State(pfx *bnet.Prefix, pathID uint32) *routingtable.DampeningState {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	s := d.states[key{
+		pfx:    *pfx,
+		pathID: pathID,
+	}]
+	if s == nil {
+		return nil
+	}
+
+	now := clock.Now()
+	d.decay(s, now)
+
+	res := &routingtable.DampeningState{
+		FigureOfMerit: uint32(math.Round(s.figureOfMerit)),
+		Suppressed:    s.suppressed,
+	}
+
+	if s.suppressed {
+		res.ReuseTime = now.Add(d.decayTime(s.figureOfMerit, float64(d.cfg.ReuseThreshold)))
+	}
+
+	return res
+}
+
+// Clear drops the dampening state of all routes covered by pfx or of all routes if pfx is nil.
+// Suppressed routes are reused immediately.
+func (d *Dampener) Clear(pfx *bnet.Prefix) {
+	d.mu.Lock()
+
+	reused := make([]key, 0)
+	for k, s := range d.states {
+		if pfx != nil && !covers(pfx, &k.pfx) {
+			continue
+		}
+
+		s.timer.Stop()
+		delete(d.states, k)
+
+		if s.suppressed {
+			reused = append(reused, k)
+		}
+	}
+
+	clients := d.clientList()
+	d.mu.Unlock()
+
+	d.notify(clients, reused)
+}
+
+// Stop stops all timers. Suppressed routes are not reused anymore.
+func (d *Dampener) Stop() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for k, s := range d.states {
+		s.timer.Stop()
+		delete(d.states, k)
+	}
+}
+
+// penalize adds a penalty to the figure of merit of a route and suppresses it if the suppress threshold is exceeded
+func (d *Dampener) penalize(k key, penalty float64) *state {
+	now := clock.Now()
+
+	s := d.states[k]
+	if s == nil {
+		s = &state{
+			updated: now,
+		}
+		d.states[k] = s
+	}
+
+	d.decay(s, now)
+	s.figureOfMerit = math.Min(s.figureOfMerit+penalty, d.ceiling)
+	if s.figureOfMerit > float64(d.cfg.SuppressThreshold) {
+		s.suppressed = true
+	}
+
+	d.schedule(k, s)
+	return s
+}
+
+// decay updates the figure of merit of a route to the current time
+func (d *Dampener) decay(s *state, now time.Time) {
+	s.figureOfMerit *= math.Exp2(-float64(now.Sub(s.updated)) / float64(d.cfg.HalfLife))
+	s.updated = now
+}
+
+// decayTime returns the time it takes figureOfMerit to decay to threshold
+func (d *Dampener) decayTime(figureOfMerit float64, threshold float64) time.Duration {
+	if figureOfMerit <= threshold {
+		return 0
+	}
+
+	return time.Duration(math.Ceil(float64(d.cfg.HalfLife) * math.Log2(figureOfMerit/threshold)))
+}
+
+// schedule arms the timer of a route for the time a suppressed route is reused or the state
+// of a route that is not suppressed can be dropped. The state is dropped once the figure of merit
+// decayed to half of the reuse threshold.
+func (d *Dampener) schedule(k key, s *state) {
+	threshold := float64(d.cfg.ReuseThreshold)
+	if !s.suppressed {
+		threshold /= 2
+	}
+
+	if s.timer != nil {
+		s.timer.Stop()
+	}
+
+	s.seq++
+	seq := s.seq
+	s.timer = clock.AfterFunc(d.decayTime(s.figureOfMerit, threshold), func() {
+		d.expire(k, s, seq)
+	})
+}
+
+// expire reuses a suppressed route or drops the state of a route that stopped flapping
+func (d *Dampener) expire(k key, s *state, seq uint64) {
+	d.mu.Lock()
+
+	// The timer has been re-armed or the state has been cleared in the meantime
+	if d.states[k] != s || s.seq != seq {
+		d.mu.Unlock()
+		return
+	}
+
+	d.decay(s, clock.Now())
+
+	reused := make([]key, 0, 1)
+	if s.suppressed && s.figureOfMerit <= float64(d.cfg.ReuseThreshold) {
+		s.suppressed = false
+		reused = append(reused, k)
+	}
+
+	if !s.suppressed && s.figureOfMerit <= float64(d.cfg.ReuseThreshold)/2 {
+		delete(d.states, k)
+	} else {
+		d.schedule(k, s)
+	}
+
+	clients := d.clientList()
+	d.mu.Unlock()
+
+	d.notify(clients, reused)
+}
+
+func (d *Dampener) clientList() []routingtable.RouteDampenerClient {
+	clients := make([]routingtable.RouteDampenerClient, 0, len(d.clients))
+	for c := range d.clients {
+		clients = append(clients, c)
+	}
+
+	return clients
+}
+
+func (d *Dampener) notify(clients []routingtable.RouteDampenerClient, reused []key) {
+	for _, k := range reused {
+		pfx := k.pfx
+		for _, c := range clients {
+			c.DampeningReuse(&pfx, k.pathID)
+		}
+	}
+}
+
+// covers checks if pfx is equal to or a more specific of agg
+func covers(agg *bnet.Prefix, pfx *bnet.Prefix) bool {
+	aggAddr, addr := agg.Addr(), pfx.Addr()
+	if aggAddr.IsIPv4() != addr.IsIPv4() || pfx.Len() < agg.Len() {
+		return false
+	}
+
+	return agg.GetIPNet().Contains(addr.ToNetIP())
+}
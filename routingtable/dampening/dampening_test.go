@@ -0,0 +1,332 @@
+package dampening
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	bbclock "github.com/benbjohnson/clock"
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/stretchr/testify/assert"
+)
+
+type reuse struct {
+	pfx    bnet.Prefix
+	pathID uint32
+}
+
+type clientMock struct {
+	mu     sync.Mutex
+	reused []reuse
+}
+
+func (c *clientMock) DampeningReuse(pfx *bnet.Prefix, pathID uint32) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.reused = append(c.reused, reuse{
+		pfx:    *pfx,
+		pathID: pathID,
+	})
+}
+
+func defaultConfig() Config {
+	return Config{
+		HalfLife:          DefaultHalfLife,
+		ReuseThreshold:    DefaultReuseThreshold,
+		SuppressThreshold: DefaultSuppressThreshold,
+		MaxSuppressTime:   DefaultMaxSuppressTime,
+	}
+}
+
+func flap(d *Dampener, pfx *bnet.Prefix, pathID uint32, n int) bool {
+	suppressed := false
+	for i := 0; i < n; i++ {
+		d.Withdraw(pfx, pathID)
+		suppressed = d.Advertise(pfx, pathID, false)
+	}
+
+	return suppressed
+}
+
+func TestConfigCeiling(t *testing.T) {
+	cfg := defaultConfig()
+	assert.Equal(t, float64(12000), cfg.Ceiling())
+}
+
+func TestAdvertise(t *testing.T) {
+	pfx := bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 100, 0), 24).Ptr()
+
+	tests := []struct {
+		name           string
+		withdrawals    int
+		changes        int
+		wantSuppressed bool
+		wantFOM        uint32
+	}{
+		{
+			name:           "stable route",
+			wantSuppressed: false,
+		},
+		{
+			name:           "suppress threshold reached",
+			withdrawals:    6,
+			wantSuppressed: false,
+			wantFOM:        6000,
+		},
+		{
+			name:           "suppress threshold exceeded",
+			withdrawals:    6,
+			changes:        1,
+			wantSuppressed: true,
+			wantFOM:        6500,
+		},
+		{
+			name:           "attribute changes",
+			changes:        13,
+			wantSuppressed: true,
+			wantFOM:        6500,
+		},
+		{
+			name:           "ceiling",
+			withdrawals:    20,
+			wantSuppressed: true,
+			wantFOM:        12000,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			SetClock(bbclock.NewMock())
+			d := New(defaultConfig())
+			defer d.Stop()
+
+			for i := 0; i < test.withdrawals; i++ {
+				d.Withdraw(pfx, 0)
+			}
+
+			for i := 0; i < test.changes; i++ {
+				d.Advertise(pfx, 0, true)
+			}
+
+			assert.Equal(t, test.wantSuppressed, d.Advertise(pfx, 0, false))
+
+			s := d.State(pfx, 0)
+			if test.wantFOM == 0 {
+				assert.Nil(t, s)
+				return
+			}
+
+			assert.Equal(t, test.wantFOM, s.FigureOfMerit)
+			assert.Equal(t, test.wantSuppressed, s.Suppressed)
+		})
+	}
+}
+
+func TestReuse(t *testing.T) {
+	clock := bbclock.NewMock()
+	SetClock(clock)
+
+	pfx := bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 100, 0), 24).Ptr()
+	c := &clientMock{}
+	d := New(defaultConfig())
+	d.Register(c)
+	defer d.Stop()
+
+	// 7000 decays to the reuse threshold of 750 after ~48m20s
+	assert.True(t, flap(d, pfx, 0, 7))
+	assert.WithinDuration(t, clock.Now().Add(48*time.Minute+20*time.Second), d.State(pfx, 0).ReuseTime, time.Second)
+
+	clock.Add(48 * time.Minute)
+	assert.True(t, d.Advertise(pfx, 0, false))
+	assert.Empty(t, c.reused)
+
+	clock.Add(time.Minute)
+	assert.False(t, d.Advertise(pfx, 0, false))
+	assert.Equal(t, []reuse{{pfx: *pfx, pathID: 0}}, c.reused)
+
+	s := d.State(pfx, 0)
+	assert.False(t, s.Suppressed)
+	assert.True(t, s.ReuseTime.IsZero())
+
+	// The state is dropped once the figure of merit decayed to half of the reuse threshold
+	clock.Add(15 * time.Minute)
+	assert.Nil(t, d.State(pfx, 0))
+	assert.Len(t, c.reused, 1)
+}
+
+func TestMaxSuppressTime(t *testing.T) {
+	clock := bbclock.NewMock()
+	SetClock(clock)
+
+	pfx := bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 100, 0), 24).Ptr()
+	c := &clientMock{}
+	d := New(defaultConfig())
+	d.Register(c)
+	defer d.Stop()
+
+	assert.True(t, flap(d, pfx, 0, 50))
+
+	clock.Add(DefaultMaxSuppressTime - time.Second)
+	assert.True(t, d.State(pfx, 0).Suppressed)
+
+	clock.Add(time.Second)
+	assert.False(t, d.State(pfx, 0).Suppressed)
+	assert.Len(t, c.reused, 1)
+}
+
+func TestSetConfig(t *testing.T) {
+	pfx := bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 100, 0), 24).Ptr()
+
+	tests := []struct {
+		name           string
+		cfg            func(*Config)
+		wantFOM        uint32
+		wantSuppressed bool
+		wantReuseIn    time.Duration
+		wantReused     int
+	}{
+		{
+			name:           "unchanged",
+			cfg:            func(*Config) {},
+			wantFOM:        7000,
+			wantSuppressed: true,
+			wantReuseIn:    48*time.Minute + 20*time.Second,
+		},
+		{
+			name: "reuse threshold raised above the figure of merit",
+			cfg: func(c *Config) {
+				c.ReuseThreshold = 7500
+				c.SuppressThreshold = 9000
+			},
+			wantFOM:    7000,
+			wantReused: 1,
+		},
+		{
+			name: "shorter half life",
+			cfg: func(c *Config) {
+				c.HalfLife = 5 * time.Minute
+			},
+			wantFOM:        7000,
+			wantSuppressed: true,
+			wantReuseIn:    16*time.Minute + 7*time.Second,
+		},
+		{
+			name: "shorter max suppress time",
+			cfg: func(c *Config) {
+				c.MaxSuppressTime = 10 * time.Minute
+			},
+			wantFOM:        1191,
+			wantSuppressed: true,
+			wantReuseIn:    10 * time.Minute,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			clock := bbclock.NewMock()
+			SetClock(clock)
+
+			c := &clientMock{}
+			d := New(defaultConfig())
+			d.Register(c)
+			defer d.Stop()
+
+			assert.True(t, flap(d, pfx, 0, 7))
+
+			cfg := defaultConfig()
+			test.cfg(&cfg)
+			d.SetConfig(cfg)
+
+			assert.Len(t, c.reused, test.wantReused)
+
+			s := d.State(pfx, 0)
+			assert.Equal(t, test.wantFOM, s.FigureOfMerit)
+			assert.Equal(t, test.wantSuppressed, s.Suppressed)
+			if !test.wantSuppressed {
+				return
+			}
+
+			assert.WithinDuration(t, clock.Now().Add(test.wantReuseIn), s.ReuseTime, time.Second)
+
+			clock.Add(test.wantReuseIn + time.Second)
+			assert.Len(t, c.reused, 1)
+		})
+	}
+}
+
+func TestClear(t *testing.T) {
+	pfxA := bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 100, 0), 24).Ptr()
+	pfxB := bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 100, 128), 25).Ptr()
+	pfxC := bnet.NewPfx(bnet.IPv4FromOctets(203, 0, 113, 0), 24).Ptr()
+	pfxD := bnet.NewPfx(bnet.IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 0), 32).Ptr()
+
+	tests := []struct {
+		name       string
+		clear      *bnet.Prefix
+		wantReused []*bnet.Prefix
+		wantDamped []*bnet.Prefix
+	}{
+		{
+			name:       "all",
+			wantReused: []*bnet.Prefix{pfxA, pfxB, pfxC, pfxD},
+		},
+		{
+			name:       "covered prefixes",
+			clear:      bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 100, 0), 24).Ptr(),
+			wantReused: []*bnet.Prefix{pfxA, pfxB},
+			wantDamped: []*bnet.Prefix{pfxC, pfxD},
+		},
+		{
+			name:       "more specific",
+			clear:      pfxB,
+			wantReused: []*bnet.Prefix{pfxB},
+			wantDamped: []*bnet.Prefix{pfxA, pfxC, pfxD},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			SetClock(bbclock.NewMock())
+			c := &clientMock{}
+			d := New(defaultConfig())
+			d.Register(c)
+			defer d.Stop()
+
+			for _, pfx := range []*bnet.Prefix{pfxA, pfxB, pfxC, pfxD} {
+				assert.True(t, flap(d, pfx, 0, 7))
+			}
+
+			d.Clear(test.clear)
+
+			assert.Len(t, c.reused, len(test.wantReused))
+			for _, pfx := range test.wantReused {
+				assert.Contains(t, c.reused, reuse{pfx: *pfx})
+				assert.Nil(t, d.State(pfx, 0))
+				assert.False(t, d.Advertise(pfx, 0, false))
+			}
+
+			for _, pfx := range test.wantDamped {
+				assert.True(t, d.Advertise(pfx, 0, false))
+			}
+		})
+	}
+}
+
+func TestUnregister(t *testing.T) {
+	clock := bbclock.NewMock()
+	SetClock(clock)
+
+	pfx := bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 100, 0), 24).Ptr()
+	c := &clientMock{}
+	d := New(defaultConfig())
+	d.Register(c)
+	defer d.Stop()
+
+	assert.True(t, flap(d, pfx, 0, 7))
+	d.Unregister(c)
+
+	clock.Add(time.Hour)
+	assert.False(t, d.Advertise(pfx, 0, false))
+	assert.Empty(t, c.reused)
+}
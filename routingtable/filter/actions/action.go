@@ -16,4 +16,10 @@ type Result struct {
 	Path      *route.Path
 	Reject    bool
 	Terminate bool
+
+	// NextTerm skips the remaining actions of the term and continues with the next term
+	NextTerm bool
+
+	// NextPolicy skips the remaining terms of the policy and continues with the next policy
+	NextPolicy bool
 }
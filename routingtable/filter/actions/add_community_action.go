@@ -6,6 +6,7 @@ import (
 	"github.com/bio-routing/bio-rd/route"
 )
 
+// AddCommunityAction adds communities to a path
 type AddCommunityAction struct {
 	communities *types.Communities
 }
@@ -16,7 +17,7 @@ func NewAddCommunityAction(coms *types.Communities) *AddCommunityAction {
 	}
 }
 
-func (a *AddCommunityAction) Do(p net.Prefix, pa *route.Path) Result {
+func (a *AddCommunityAction) Do(p *net.Prefix, pa *route.Path) Result {
 	if pa.BGPPath == nil || len(*a.communities) == 0 {
 		return Result{Path: pa}
 	}
@@ -32,3 +33,41 @@ func (a *AddCommunityAction) Do(p net.Prefix, pa *route.Path) Result {
 
 	return Result{Path: modified}
 }
+
+// Equal compares actions
+func (a *AddCommunityAction) Equal(b Action) bool {
+	x, ok := b.(*AddCommunityAction)
+	if !ok {
+		return false
+	}
+
+	return communitiesEqual(a.communities, x.communities)
+}
+
+func containsCommunity(coms types.Communities, c uint32) bool {
+	for _, com := range coms {
+		if com == c {
+			return true
+		}
+	}
+
+	return false
+}
+
+func communitiesEqual(a, b *types.Communities) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	if len(*a) != len(*b) {
+		return false
+	}
+
+	for i := range *a {
+		if (*a)[i] != (*b)[i] {
+			return false
+		}
+	}
+
+	return true
+}
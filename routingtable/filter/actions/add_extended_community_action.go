@@ -0,0 +1,77 @@
+package actions
+
+import (
+	"github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
+	"github.com/bio-routing/bio-rd/route"
+)
+
+// AddExtendedCommunityAction adds extended communities to a path unless they are already present
+type AddExtendedCommunityAction struct {
+	communities *types.ExtendedCommunities
+}
+
+func NewAddExtendedCommunityAction(coms *types.ExtendedCommunities) *AddExtendedCommunityAction {
+	return &AddExtendedCommunityAction{
+		communities: coms,
+	}
+}
+
+func (a *AddExtendedCommunityAction) Do(p *net.Prefix, pa *route.Path) Result {
+	if pa.BGPPath == nil || len(*a.communities) == 0 {
+		return Result{Path: pa}
+	}
+
+	modified := pa.Copy()
+	if modified.BGPPath.ExtendedCommunities == nil {
+		modified.BGPPath.ExtendedCommunities = &types.ExtendedCommunities{}
+	}
+
+	for _, com := range *a.communities {
+		if containsExtendedCommunity(*modified.BGPPath.ExtendedCommunities, com) {
+			continue
+		}
+
+		*modified.BGPPath.ExtendedCommunities = append(*modified.BGPPath.ExtendedCommunities, com)
+	}
+
+	return Result{Path: modified}
+}
+
+// Equal compares actions
+func (a *AddExtendedCommunityAction) Equal(b Action) bool {
+	x, ok := b.(*AddExtendedCommunityAction)
+	if !ok {
+		return false
+	}
+
+	return extendedCommunitiesEqual(a.communities, x.communities)
+}
+
+func containsExtendedCommunity(coms types.ExtendedCommunities, c types.ExtendedCommunity) bool {
+	for _, com := range coms {
+		if com == c {
+			return true
+		}
+	}
+
+	return false
+}
+
+func extendedCommunitiesEqual(a, b *types.ExtendedCommunities) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	if len(*a) != len(*b) {
+		return false
+	}
+
+	for i := range *a {
+		if (*a)[i] != (*b)[i] {
+			return false
+		}
+	}
+
+	return true
+}
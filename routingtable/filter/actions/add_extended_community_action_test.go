@@ -0,0 +1,63 @@
+package actions
+
+import (
+	"testing"
+
+	"github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddingExtendedCommunities(t *testing.T) {
+	tests := []struct {
+		name        string
+		current     *types.ExtendedCommunities
+		communities *types.ExtendedCommunities
+		expected    string
+	}{
+		{
+			name: "add one to empty",
+			communities: &types.ExtendedCommunities{
+				types.NewRouteTarget(65000, false, 100),
+			},
+			expected: "target:65000:100",
+		},
+		{
+			name: "add two to existing",
+			current: &types.ExtendedCommunities{
+				types.NewRouteOrigin(65000, false, 1),
+			},
+			communities: &types.ExtendedCommunities{
+				types.NewRouteTarget(65000, false, 100),
+				types.NewRouteTarget(65000, false, 200),
+			},
+			expected: "origin:65000:1 target:65000:100 target:65000:200",
+		},
+		{
+			name: "add existing",
+			current: &types.ExtendedCommunities{
+				types.NewRouteTarget(65000, false, 100),
+			},
+			communities: &types.ExtendedCommunities{
+				types.NewRouteTarget(65000, false, 100),
+			},
+			expected: "target:65000:100",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := &route.Path{
+				BGPPath: &route.BGPPath{
+					ExtendedCommunities: test.current,
+				},
+			}
+
+			a := NewAddExtendedCommunityAction(test.communities)
+			res := a.Do(&net.Prefix{}, p)
+
+			assert.Equal(t, test.expected, res.Path.BGPPath.ExtendedCommunities.String())
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/bio-routing/bio-rd/route"
 )
 
+// AddLargeCommunityAction adds large communities to a path
 type AddLargeCommunityAction struct {
 	communities *types.LargeCommunities
 }
@@ -16,7 +17,7 @@ func NewAddLargeCommunityAction(coms *types.LargeCommunities) *AddLargeCommunity
 	}
 }
 
-func (a *AddLargeCommunityAction) Do(p net.Prefix, pa *route.Path) Result {
+func (a *AddLargeCommunityAction) Do(p *net.Prefix, pa *route.Path) Result {
 	if pa.BGPPath == nil || len(*a.communities) == 0 {
 		return Result{Path: pa}
 	}
@@ -29,3 +30,41 @@ func (a *AddLargeCommunityAction) Do(p net.Prefix, pa *route.Path) Result {
 	*modified.BGPPath.LargeCommunities = append(*modified.BGPPath.LargeCommunities, *a.communities...)
 	return Result{Path: modified}
 }
+
+// Equal compares actions
+func (a *AddLargeCommunityAction) Equal(b Action) bool {
+	x, ok := b.(*AddLargeCommunityAction)
+	if !ok {
+		return false
+	}
+
+	return largeCommunitiesEqual(a.communities, x.communities)
+}
+
+func containsLargeCommunity(coms types.LargeCommunities, c types.LargeCommunity) bool {
+	for _, com := range coms {
+		if com == c {
+			return true
+		}
+	}
+
+	return false
+}
+
+func largeCommunitiesEqual(a, b *types.LargeCommunities) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	if len(*a) != len(*b) {
+		return false
+	}
+
+	for i := range *a {
+		if (*a)[i] != (*b)[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,34 @@
+package actions
+
+import (
+	"github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/route"
+)
+
+// NextPolicyAction skips the remaining terms of a policy and continues with the next policy of the chain
+type NextPolicyAction struct {
+}
+
+// NewNextPolicyAction returns a new NextPolicyAction
+func NewNextPolicyAction() *NextPolicyAction {
+	return &NextPolicyAction{}
+}
+
+// Do applies the action
+func (*NextPolicyAction) Do(p *net.Prefix, pa *route.Path) Result {
+	return Result{
+		Path:       pa,
+		NextPolicy: true,
+	}
+}
+
+// Equal compares actions
+func (a *NextPolicyAction) Equal(b Action) bool {
+	switch b.(type) {
+	case *NextPolicyAction:
+	default:
+		return false
+	}
+
+	return true
+}
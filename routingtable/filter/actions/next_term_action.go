@@ -0,0 +1,34 @@
+package actions
+
+import (
+	"github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/route"
+)
+
+// NextTermAction skips the remaining actions of a term and continues with the next term
+type NextTermAction struct {
+}
+
+// NewNextTermAction returns a new NextTermAction
+func NewNextTermAction() *NextTermAction {
+	return &NextTermAction{}
+}
+
+// Do applies the action
+func (*NextTermAction) Do(p *net.Prefix, pa *route.Path) Result {
+	return Result{
+		Path:     pa,
+		NextTerm: true,
+	}
+}
+
+// Equal compares actions
+func (a *NextTermAction) Equal(b Action) bool {
+	switch b.(type) {
+	case *NextTermAction:
+	default:
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,51 @@
+package actions
+
+import (
+	"github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
+	"github.com/bio-routing/bio-rd/route"
+)
+
+// RemoveCommunityAction removes communities from a path
+type RemoveCommunityAction struct {
+	communities *types.Communities
+}
+
+func NewRemoveCommunityAction(coms *types.Communities) *RemoveCommunityAction {
+	return &RemoveCommunityAction{
+		communities: coms,
+	}
+}
+
+func (a *RemoveCommunityAction) Do(p *net.Prefix, pa *route.Path) Result {
+	if pa.BGPPath == nil || pa.BGPPath.Communities == nil || len(*a.communities) == 0 {
+		return Result{Path: pa}
+	}
+
+	modified := pa.Copy()
+	coms := make(types.Communities, 0, len(*modified.BGPPath.Communities))
+	for _, com := range *modified.BGPPath.Communities {
+		if containsCommunity(*a.communities, com) {
+			continue
+		}
+
+		coms = append(coms, com)
+	}
+
+	modified.BGPPath.Communities = nil
+	if len(coms) > 0 {
+		modified.BGPPath.Communities = &coms
+	}
+
+	return Result{Path: modified}
+}
+
+// Equal compares actions
+func (a *RemoveCommunityAction) Equal(b Action) bool {
+	x, ok := b.(*RemoveCommunityAction)
+	if !ok {
+		return false
+	}
+
+	return communitiesEqual(a.communities, x.communities)
+}
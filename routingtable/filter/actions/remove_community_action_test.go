@@ -0,0 +1,62 @@
+package actions
+
+import (
+	"testing"
+
+	"github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemovingCommunities(t *testing.T) {
+	tests := []struct {
+		name        string
+		current     *types.Communities
+		communities *types.Communities
+		expected    *types.Communities
+	}{
+		{
+			name: "remove from empty",
+			communities: &types.Communities{
+				65538,
+			},
+		},
+		{
+			name: "remove one of three",
+			current: &types.Communities{
+				65538, 196612, 327686,
+			},
+			communities: &types.Communities{
+				196612,
+			},
+			expected: &types.Communities{
+				65538, 327686,
+			},
+		},
+		{
+			name: "remove last",
+			current: &types.Communities{
+				65538,
+			},
+			communities: &types.Communities{
+				65538,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := &route.Path{
+				BGPPath: &route.BGPPath{
+					Communities: test.current,
+				},
+			}
+
+			a := NewRemoveCommunityAction(test.communities)
+			res := a.Do(&net.Prefix{}, p)
+
+			assert.Equal(t, test.expected, res.Path.BGPPath.Communities)
+		})
+	}
+}
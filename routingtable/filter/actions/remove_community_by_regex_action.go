@@ -0,0 +1,62 @@
+package actions
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
+	"github.com/bio-routing/bio-rd/route"
+)
+
+// RemoveCommunityByRegexAction removes all communities matching a regular expression from a path.
+// The expression is matched against the human readable representation of the community, e.g. "(65000,100)".
+type RemoveCommunityByRegexAction struct {
+	expr string
+	re   *regexp.Regexp
+}
+
+func NewRemoveCommunityByRegexAction(expr string) (*RemoveCommunityByRegexAction, error) {
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid community expression %q: %w", expr, err)
+	}
+
+	return &RemoveCommunityByRegexAction{
+		expr: expr,
+		re:   re,
+	}, nil
+}
+
+func (a *RemoveCommunityByRegexAction) Do(p *net.Prefix, pa *route.Path) Result {
+	if pa.BGPPath == nil || pa.BGPPath.Communities == nil {
+		return Result{Path: pa}
+	}
+
+	modified := pa.Copy()
+	coms := make(types.Communities, 0, len(*modified.BGPPath.Communities))
+	for _, com := range *modified.BGPPath.Communities {
+		if a.re.MatchString(types.CommunityStringForUint32(com)) {
+			continue
+		}
+
+		coms = append(coms, com)
+	}
+
+	modified.BGPPath.Communities = nil
+	if len(coms) > 0 {
+		modified.BGPPath.Communities = &coms
+	}
+
+	return Result{Path: modified}
+}
+
+// Equal compares actions
+func (a *RemoveCommunityByRegexAction) Equal(b Action) bool {
+	x, ok := b.(*RemoveCommunityByRegexAction)
+	if !ok {
+		return false
+	}
+
+	return a.expr == x.expr
+}
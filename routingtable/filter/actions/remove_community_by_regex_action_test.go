@@ -0,0 +1,91 @@
+package actions
+
+import (
+	"testing"
+
+	"github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemovingCommunitiesByRegex(t *testing.T) {
+	tests := []struct {
+		name     string
+		expr     string
+		current  *types.Communities
+		expected *types.Communities
+		wantFail bool
+	}{
+		{
+			name: "remove all of an ASN",
+			expr: `^\(65000,`,
+			current: &types.Communities{
+				65000<<16 | 1, 65001<<16 | 1, 65000<<16 | 2,
+			},
+			expected: &types.Communities{
+				65001<<16 | 1,
+			},
+		},
+		{
+			name: "remove all",
+			expr: ".*",
+			current: &types.Communities{
+				65000<<16 | 1,
+			},
+		},
+		{
+			name: "no communities",
+			expr: ".*",
+		},
+		{
+			name:     "invalid expression",
+			expr:     "(",
+			wantFail: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a, err := NewRemoveCommunityByRegexAction(test.expr)
+			if test.wantFail {
+				assert.Error(t, err)
+				return
+			}
+
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			p := &route.Path{
+				BGPPath: &route.BGPPath{
+					Communities: test.current,
+				},
+			}
+
+			res := a.Do(&net.Prefix{}, p)
+			assert.Equal(t, test.expected, res.Path.BGPPath.Communities)
+		})
+	}
+}
+
+func TestRemovingLargeCommunitiesByRegex(t *testing.T) {
+	p := &route.Path{
+		BGPPath: &route.BGPPath{
+			LargeCommunities: &types.LargeCommunities{
+				{GlobalAdministrator: 65000, DataPart1: 1, DataPart2: 1},
+				{GlobalAdministrator: 65000, DataPart1: 2, DataPart2: 1},
+			},
+		},
+	}
+
+	a, err := NewRemoveLargeCommunityByRegexAction(`^\(65000,1,`)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	res := a.Do(&net.Prefix{}, p)
+	assert.Equal(t, &types.LargeCommunities{
+		{GlobalAdministrator: 65000, DataPart1: 2, DataPart2: 1},
+	}, res.Path.BGPPath.LargeCommunities)
+}
@@ -0,0 +1,51 @@
+package actions
+
+import (
+	"github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
+	"github.com/bio-routing/bio-rd/route"
+)
+
+// RemoveExtendedCommunityAction removes extended communities from a path
+type RemoveExtendedCommunityAction struct {
+	communities *types.ExtendedCommunities
+}
+
+func NewRemoveExtendedCommunityAction(coms *types.ExtendedCommunities) *RemoveExtendedCommunityAction {
+	return &RemoveExtendedCommunityAction{
+		communities: coms,
+	}
+}
+
+func (a *RemoveExtendedCommunityAction) Do(p *net.Prefix, pa *route.Path) Result {
+	if pa.BGPPath == nil || pa.BGPPath.ExtendedCommunities == nil || len(*a.communities) == 0 {
+		return Result{Path: pa}
+	}
+
+	modified := pa.Copy()
+	coms := make(types.ExtendedCommunities, 0, len(*modified.BGPPath.ExtendedCommunities))
+	for _, com := range *modified.BGPPath.ExtendedCommunities {
+		if containsExtendedCommunity(*a.communities, com) {
+			continue
+		}
+
+		coms = append(coms, com)
+	}
+
+	modified.BGPPath.ExtendedCommunities = nil
+	if len(coms) > 0 {
+		modified.BGPPath.ExtendedCommunities = &coms
+	}
+
+	return Result{Path: modified}
+}
+
+// Equal compares actions
+func (a *RemoveExtendedCommunityAction) Equal(b Action) bool {
+	x, ok := b.(*RemoveExtendedCommunityAction)
+	if !ok {
+		return false
+	}
+
+	return extendedCommunitiesEqual(a.communities, x.communities)
+}
@@ -0,0 +1,63 @@
+package actions
+
+import (
+	"testing"
+
+	"github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemovingExtendedCommunities(t *testing.T) {
+	tests := []struct {
+		name        string
+		current     *types.ExtendedCommunities
+		communities *types.ExtendedCommunities
+		expected    *types.ExtendedCommunities
+	}{
+		{
+			name: "remove from empty",
+			communities: &types.ExtendedCommunities{
+				types.NewRouteTarget(65000, false, 100),
+			},
+		},
+		{
+			name: "remove one of two",
+			current: &types.ExtendedCommunities{
+				types.NewRouteOrigin(65000, false, 1),
+				types.NewRouteTarget(65000, false, 100),
+			},
+			communities: &types.ExtendedCommunities{
+				types.NewRouteTarget(65000, false, 100),
+			},
+			expected: &types.ExtendedCommunities{
+				types.NewRouteOrigin(65000, false, 1),
+			},
+		},
+		{
+			name: "remove last",
+			current: &types.ExtendedCommunities{
+				types.NewRouteTarget(65000, false, 100),
+			},
+			communities: &types.ExtendedCommunities{
+				types.NewRouteTarget(65000, false, 100),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := &route.Path{
+				BGPPath: &route.BGPPath{
+					ExtendedCommunities: test.current,
+				},
+			}
+
+			a := NewRemoveExtendedCommunityAction(test.communities)
+			res := a.Do(&net.Prefix{}, p)
+
+			assert.Equal(t, test.expected, res.Path.BGPPath.ExtendedCommunities)
+		})
+	}
+}
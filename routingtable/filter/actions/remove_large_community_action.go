@@ -0,0 +1,51 @@
+package actions
+
+import (
+	"github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
+	"github.com/bio-routing/bio-rd/route"
+)
+
+// RemoveLargeCommunityAction removes large communities from a path
+type RemoveLargeCommunityAction struct {
+	communities *types.LargeCommunities
+}
+
+func NewRemoveLargeCommunityAction(coms *types.LargeCommunities) *RemoveLargeCommunityAction {
+	return &RemoveLargeCommunityAction{
+		communities: coms,
+	}
+}
+
+func (a *RemoveLargeCommunityAction) Do(p *net.Prefix, pa *route.Path) Result {
+	if pa.BGPPath == nil || pa.BGPPath.LargeCommunities == nil || len(*a.communities) == 0 {
+		return Result{Path: pa}
+	}
+
+	modified := pa.Copy()
+	coms := make(types.LargeCommunities, 0, len(*modified.BGPPath.LargeCommunities))
+	for _, com := range *modified.BGPPath.LargeCommunities {
+		if containsLargeCommunity(*a.communities, com) {
+			continue
+		}
+
+		coms = append(coms, com)
+	}
+
+	modified.BGPPath.LargeCommunities = nil
+	if len(coms) > 0 {
+		modified.BGPPath.LargeCommunities = &coms
+	}
+
+	return Result{Path: modified}
+}
+
+// Equal compares actions
+func (a *RemoveLargeCommunityAction) Equal(b Action) bool {
+	x, ok := b.(*RemoveLargeCommunityAction)
+	if !ok {
+		return false
+	}
+
+	return largeCommunitiesEqual(a.communities, x.communities)
+}
@@ -0,0 +1,54 @@
+package actions
+
+import (
+	"testing"
+
+	"github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemovingLargeCommunities(t *testing.T) {
+	tests := []struct {
+		name        string
+		current     *types.LargeCommunities
+		communities *types.LargeCommunities
+		expected    *types.LargeCommunities
+	}{
+		{
+			name: "remove from empty",
+			communities: &types.LargeCommunities{
+				{GlobalAdministrator: 65000, DataPart1: 1, DataPart2: 1},
+			},
+		},
+		{
+			name: "remove one of two",
+			current: &types.LargeCommunities{
+				{GlobalAdministrator: 65000, DataPart1: 1, DataPart2: 1},
+				{GlobalAdministrator: 65000, DataPart1: 1, DataPart2: 2},
+			},
+			communities: &types.LargeCommunities{
+				{GlobalAdministrator: 65000, DataPart1: 1, DataPart2: 1},
+			},
+			expected: &types.LargeCommunities{
+				{GlobalAdministrator: 65000, DataPart1: 1, DataPart2: 2},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := &route.Path{
+				BGPPath: &route.BGPPath{
+					LargeCommunities: test.current,
+				},
+			}
+
+			a := NewRemoveLargeCommunityAction(test.communities)
+			res := a.Do(&net.Prefix{}, p)
+
+			assert.Equal(t, test.expected, res.Path.BGPPath.LargeCommunities)
+		})
+	}
+}
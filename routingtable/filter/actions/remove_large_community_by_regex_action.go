@@ -0,0 +1,62 @@
+package actions
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
+	"github.com/bio-routing/bio-rd/route"
+)
+
+// RemoveLargeCommunityByRegexAction removes all large communities matching a regular expression from a path.
+// The expression is matched against the human readable representation of the community, e.g. "(65000,1,100)".
+type RemoveLargeCommunityByRegexAction struct {
+	expr string
+	re   *regexp.Regexp
+}
+
+func NewRemoveLargeCommunityByRegexAction(expr string) (*RemoveLargeCommunityByRegexAction, error) {
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid large community expression %q: %w", expr, err)
+	}
+
+	return &RemoveLargeCommunityByRegexAction{
+		expr: expr,
+		re:   re,
+	}, nil
+}
+
+func (a *RemoveLargeCommunityByRegexAction) Do(p *net.Prefix, pa *route.Path) Result {
+	if pa.BGPPath == nil || pa.BGPPath.LargeCommunities == nil {
+		return Result{Path: pa}
+	}
+
+	modified := pa.Copy()
+	coms := make(types.LargeCommunities, 0, len(*modified.BGPPath.LargeCommunities))
+	for _, com := range *modified.BGPPath.LargeCommunities {
+		if a.re.MatchString(com.String()) {
+			continue
+		}
+
+		coms = append(coms, com)
+	}
+
+	modified.BGPPath.LargeCommunities = nil
+	if len(coms) > 0 {
+		modified.BGPPath.LargeCommunities = &coms
+	}
+
+	return Result{Path: modified}
+}
+
+// Equal compares actions
+func (a *RemoveLargeCommunityByRegexAction) Equal(b Action) bool {
+	x, ok := b.(*RemoveLargeCommunityByRegexAction)
+	if !ok {
+		return false
+	}
+
+	return a.expr == x.expr
+}
@@ -0,0 +1,65 @@
+package actions
+
+import (
+	"github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
+	"github.com/bio-routing/bio-rd/route"
+)
+
+// RemovePrivateASNsAction removes all private ASNs (RFC6996) from the AS path
+type RemovePrivateASNsAction struct {
+}
+
+// NewRemovePrivateASNsAction creates a new RemovePrivateASNsAction
+func NewRemovePrivateASNsAction() *RemovePrivateASNsAction {
+	return &RemovePrivateASNsAction{}
+}
+
+// Do applies the action
+func (a *RemovePrivateASNsAction) Do(p *net.Prefix, pa *route.Path) Result {
+	if pa.BGPPath == nil || pa.BGPPath.ASPath == nil {
+		return Result{Path: pa}
+	}
+
+	modified := pa.Copy()
+	asPath := make(types.ASPath, 0, len(*modified.BGPPath.ASPath))
+	for _, seg := range *modified.BGPPath.ASPath {
+		asns := make([]uint32, 0, len(seg.ASNs))
+		for _, asn := range seg.ASNs {
+			if isPrivateASN(asn) {
+				continue
+			}
+
+			asns = append(asns, asn)
+		}
+
+		if len(asns) == 0 {
+			continue
+		}
+
+		asPath = append(asPath, types.ASPathSegment{
+			Type: seg.Type,
+			ASNs: asns,
+		})
+	}
+
+	modified.BGPPath.ASPath = &asPath
+	modified.BGPPath.ASPathLen = asPath.Length()
+
+	return Result{Path: modified}
+}
+
+// Equal compares actions
+func (a *RemovePrivateASNsAction) Equal(b Action) bool {
+	switch b.(type) {
+	case *RemovePrivateASNsAction:
+	default:
+		return false
+	}
+
+	return true
+}
+
+func isPrivateASN(asn uint32) bool {
+	return (asn >= 64512 && asn <= 65534) || (asn >= 4200000000 && asn <= 4294967294)
+}
@@ -0,0 +1,71 @@
+package actions
+
+import (
+	"testing"
+
+	"github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemovePrivateASNs(t *testing.T) {
+	tests := []struct {
+		name           string
+		bgpPath        *route.BGPPath
+		expectedPath   string
+		expectedLength uint16
+	}{
+		{
+			name: "BGPPath is nil",
+		},
+		{
+			name: "no private ASNs",
+			bgpPath: &route.BGPPath{
+				ASPath: &types.ASPath{
+					{Type: types.ASSequence, ASNs: []uint32{3320, 15169}},
+				},
+			},
+			expectedPath:   "3320 15169",
+			expectedLength: 2,
+		},
+		{
+			name: "private ASNs in sequence and set",
+			bgpPath: &route.BGPPath{
+				ASPath: &types.ASPath{
+					{Type: types.ASSequence, ASNs: []uint32{3320, 64512, 4200000000}},
+					{Type: types.ASSet, ASNs: []uint32{65534, 15169}},
+				},
+			},
+			expectedPath:   "3320 (15169)",
+			expectedLength: 2,
+		},
+		{
+			name: "only private ASNs",
+			bgpPath: &route.BGPPath{
+				ASPath: &types.ASPath{
+					{Type: types.ASSequence, ASNs: []uint32{65000}},
+					{Type: types.ASSet, ASNs: []uint32{65001, 65002}},
+				},
+			},
+			expectedPath:   "",
+			expectedLength: 0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := NewRemovePrivateASNsAction()
+			res := a.Do(&net.Prefix{}, &route.Path{
+				BGPPath: test.bgpPath,
+			})
+
+			if test.bgpPath == nil {
+				return
+			}
+
+			assert.Equal(t, test.expectedPath, res.Path.BGPPath.ASPath.String())
+			assert.Equal(t, test.expectedLength, res.Path.BGPPath.ASPathLen)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package actions
+
+import (
+	"github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
+	"github.com/bio-routing/bio-rd/route"
+)
+
+// ReplaceCommunityAction replaces all communities of a path
+type ReplaceCommunityAction struct {
+	communities *types.Communities
+}
+
+func NewReplaceCommunityAction(coms *types.Communities) *ReplaceCommunityAction {
+	return &ReplaceCommunityAction{
+		communities: coms,
+	}
+}
+
+func (a *ReplaceCommunityAction) Do(p *net.Prefix, pa *route.Path) Result {
+	if pa.BGPPath == nil {
+		return Result{Path: pa}
+	}
+
+	modified := pa.Copy()
+	modified.BGPPath.Communities = nil
+	if len(*a.communities) > 0 {
+		coms := make(types.Communities, len(*a.communities))
+		copy(coms, *a.communities)
+		modified.BGPPath.Communities = &coms
+	}
+
+	return Result{Path: modified}
+}
+
+// Equal compares actions
+func (a *ReplaceCommunityAction) Equal(b Action) bool {
+	x, ok := b.(*ReplaceCommunityAction)
+	if !ok {
+		return false
+	}
+
+	return communitiesEqual(a.communities, x.communities)
+}
@@ -0,0 +1,63 @@
+package actions
+
+import (
+	"testing"
+
+	"github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplacingCommunities(t *testing.T) {
+	tests := []struct {
+		name        string
+		current     *types.Communities
+		communities *types.Communities
+		expected    *types.Communities
+	}{
+		{
+			name: "replace empty",
+			communities: &types.Communities{
+				65538,
+			},
+			expected: &types.Communities{
+				65538,
+			},
+		},
+		{
+			name: "replace existing",
+			current: &types.Communities{
+				65538, 196612,
+			},
+			communities: &types.Communities{
+				327686,
+			},
+			expected: &types.Communities{
+				327686,
+			},
+		},
+		{
+			name: "replace with nothing",
+			current: &types.Communities{
+				65538,
+			},
+			communities: &types.Communities{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := &route.Path{
+				BGPPath: &route.BGPPath{
+					Communities: test.current,
+				},
+			}
+
+			a := NewReplaceCommunityAction(test.communities)
+			res := a.Do(&net.Prefix{}, p)
+
+			assert.Equal(t, test.expected, res.Path.BGPPath.Communities)
+		})
+	}
+}
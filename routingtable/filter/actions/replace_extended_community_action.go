@@ -0,0 +1,53 @@
+package actions
+
+import (
+	"github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
+	"github.com/bio-routing/bio-rd/route"
+)
+
+// ReplaceExtendedCommunityAction replaces the route target and route origin extended communities of a path.
+// Other extended communities (e.g. FlowSpec actions) are kept.
+type ReplaceExtendedCommunityAction struct {
+	communities *types.ExtendedCommunities
+}
+
+func NewReplaceExtendedCommunityAction(coms *types.ExtendedCommunities) *ReplaceExtendedCommunityAction {
+	return &ReplaceExtendedCommunityAction{
+		communities: coms,
+	}
+}
+
+func (a *ReplaceExtendedCommunityAction) Do(p *net.Prefix, pa *route.Path) Result {
+	if pa.BGPPath == nil {
+		return Result{Path: pa}
+	}
+
+	modified := pa.Copy()
+	coms := make(types.ExtendedCommunities, 0)
+	if pa.BGPPath.ExtendedCommunities != nil {
+		for _, c := range *pa.BGPPath.ExtendedCommunities {
+			if !c.IsRouteTarget() && !c.IsRouteOrigin() {
+				coms = append(coms, c)
+			}
+		}
+	}
+
+	coms = append(coms, *a.communities...)
+	modified.BGPPath.ExtendedCommunities = nil
+	if len(coms) > 0 {
+		modified.BGPPath.ExtendedCommunities = &coms
+	}
+
+	return Result{Path: modified}
+}
+
+// Equal compares actions
+func (a *ReplaceExtendedCommunityAction) Equal(b Action) bool {
+	x, ok := b.(*ReplaceExtendedCommunityAction)
+	if !ok {
+		return false
+	}
+
+	return extendedCommunitiesEqual(a.communities, x.communities)
+}
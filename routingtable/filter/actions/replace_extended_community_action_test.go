@@ -0,0 +1,79 @@
+package actions
+
+import (
+	"testing"
+
+	"github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplacingExtendedCommunities(t *testing.T) {
+	tests := []struct {
+		name        string
+		current     *types.ExtendedCommunities
+		communities *types.ExtendedCommunities
+		expected    *types.ExtendedCommunities
+	}{
+		{
+			name: "replace empty",
+			communities: &types.ExtendedCommunities{
+				types.NewRouteTarget(65000, false, 100),
+			},
+			expected: &types.ExtendedCommunities{
+				types.NewRouteTarget(65000, false, 100),
+			},
+		},
+		{
+			name: "replace existing",
+			current: &types.ExtendedCommunities{
+				types.NewRouteOrigin(65000, false, 1),
+				types.NewRouteTarget(65000, false, 100),
+			},
+			communities: &types.ExtendedCommunities{
+				types.NewRouteTarget(65000, false, 200),
+			},
+			expected: &types.ExtendedCommunities{
+				types.NewRouteTarget(65000, false, 200),
+			},
+		},
+		{
+			name: "replace with nothing",
+			current: &types.ExtendedCommunities{
+				types.NewRouteTarget(65000, false, 100),
+			},
+			communities: &types.ExtendedCommunities{},
+		},
+		{
+			name: "keep other extended communities",
+			current: &types.ExtendedCommunities{
+				types.NewRouteTarget(65000, false, 100),
+				types.NewFlowSpecTrafficRate(65000, 0),
+				types.NewRouteOrigin(65000, false, 1),
+			},
+			communities: &types.ExtendedCommunities{
+				types.NewRouteTarget(65000, false, 200),
+			},
+			expected: &types.ExtendedCommunities{
+				types.NewFlowSpecTrafficRate(65000, 0),
+				types.NewRouteTarget(65000, false, 200),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := &route.Path{
+				BGPPath: &route.BGPPath{
+					ExtendedCommunities: test.current,
+				},
+			}
+
+			a := NewReplaceExtendedCommunityAction(test.communities)
+			res := a.Do(&net.Prefix{}, p)
+
+			assert.Equal(t, test.expected, res.Path.BGPPath.ExtendedCommunities)
+		})
+	}
+}
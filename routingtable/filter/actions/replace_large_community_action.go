@@ -0,0 +1,44 @@
+package actions
+
+import (
+	"github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
+	"github.com/bio-routing/bio-rd/route"
+)
+
+// ReplaceLargeCommunityAction replaces all large communities of a path
+type ReplaceLargeCommunityAction struct {
+	communities *types.LargeCommunities
+}
+
+func NewReplaceLargeCommunityAction(coms *types.LargeCommunities) *ReplaceLargeCommunityAction {
+	return &ReplaceLargeCommunityAction{
+		communities: coms,
+	}
+}
+
+func (a *ReplaceLargeCommunityAction) Do(p *net.Prefix, pa *route.Path) Result {
+	if pa.BGPPath == nil {
+		return Result{Path: pa}
+	}
+
+	modified := pa.Copy()
+	modified.BGPPath.LargeCommunities = nil
+	if len(*a.communities) > 0 {
+		coms := make(types.LargeCommunities, len(*a.communities))
+		copy(coms, *a.communities)
+		modified.BGPPath.LargeCommunities = &coms
+	}
+
+	return Result{Path: modified}
+}
+
+// Equal compares actions
+func (a *ReplaceLargeCommunityAction) Equal(b Action) bool {
+	x, ok := b.(*ReplaceLargeCommunityAction)
+	if !ok {
+		return false
+	}
+
+	return largeCommunitiesEqual(a.communities, x.communities)
+}
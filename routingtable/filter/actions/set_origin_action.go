@@ -0,0 +1,40 @@
+package actions
+
+import (
+	"github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/route"
+)
+
+// SetOriginAction sets the BGP origin
+type SetOriginAction struct {
+	origin uint8
+}
+
+// NewSetOriginAction creates a new SetOriginAction
+func NewSetOriginAction(origin uint8) *SetOriginAction {
+	return &SetOriginAction{
+		origin: origin,
+	}
+}
+
+// Do applies the action
+func (a *SetOriginAction) Do(p *net.Prefix, pa *route.Path) Result {
+	if pa.BGPPath == nil {
+		return Result{Path: pa}
+	}
+
+	modified := pa.Copy()
+	modified.BGPPath.BGPPathA.Origin = a.origin
+
+	return Result{Path: modified}
+}
+
+// Equal compares actions
+func (a *SetOriginAction) Equal(b Action) bool {
+	x, ok := b.(*SetOriginAction)
+	if !ok {
+		return false
+	}
+
+	return a.origin == x.origin
+}
@@ -0,0 +1,46 @@
+package actions
+
+import (
+	"testing"
+
+	"github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetOrigin(t *testing.T) {
+	tests := []struct {
+		name           string
+		origin         uint8
+		bgpPath        *route.BGPPath
+		expectedOrigin uint8
+	}{
+		{
+			name:   "BGPPath is nil",
+			origin: 2,
+		},
+		{
+			name:   "set origin",
+			origin: 2,
+			bgpPath: &route.BGPPath{
+				BGPPathA: &route.BGPPathA{
+					Origin: 0,
+				},
+			},
+			expectedOrigin: 2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := NewSetOriginAction(test.origin)
+			res := a.Do(&net.Prefix{}, &route.Path{
+				BGPPath: test.bgpPath,
+			})
+
+			if test.bgpPath != nil {
+				assert.Equal(t, test.expectedOrigin, res.Path.BGPPath.BGPPathA.Origin)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package filter
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
+)
+
+// asPathDelimiter is what an underscore in an AS path expression expands to. It matches the
+// boundary of an ASN, e.g. "_65000_" matches paths containing AS65000 but not AS165000.
+const asPathDelimiter = `(^|[ ()]|$)`
+
+// ASPathFilter represents a filter matching AS paths against a regular expression
+type ASPathFilter struct {
+	expr string
+	re   *regexp.Regexp
+}
+
+// NewASPathFilter creates a new AS path filter. The expression is matched against the string
+// representation of the AS path (e.g. "65000 65001 (65002 65003)"). Underscores match ASN boundaries.
+func NewASPathFilter(expr string) (*ASPathFilter, error) {
+	re, err := regexp.Compile(strings.ReplaceAll(expr, "_", asPathDelimiter))
+	if err != nil {
+		return nil, fmt.Errorf("invalid AS path expression %q: %w", expr, err)
+	}
+
+	return &ASPathFilter{
+		expr: expr,
+		re:   re,
+	}, nil
+}
+
+// Matches checks if the AS path matches the expression
+func (f *ASPathFilter) Matches(p *types.ASPath) bool {
+	return f.re.MatchString(p.String())
+}
+
+func (f *ASPathFilter) equal(x *ASPathFilter) bool {
+	return f.expr == x.expr
+}
@@ -0,0 +1,80 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestASPathFilter(t *testing.T) {
+	path := &types.ASPath{
+		{Type: types.ASSequence, ASNs: []uint32{65000, 65000, 165001}},
+		{Type: types.ASSet, ASNs: []uint32{65002, 65003}},
+	}
+
+	tests := []struct {
+		name     string
+		expr     string
+		path     *types.ASPath
+		wantFail bool
+		expected bool
+	}{
+		{
+			name:     "first ASN",
+			expr:     "^65000_",
+			path:     path,
+			expected: true,
+		},
+		{
+			name:     "ASN boundary",
+			expr:     "_65001_",
+			path:     path,
+			expected: false,
+		},
+		{
+			name:     "ASN in AS set",
+			expr:     "_65003_",
+			path:     path,
+			expected: true,
+		},
+		{
+			name:     "prepended ASN",
+			expr:     "^(65000 )+165001",
+			path:     path,
+			expected: true,
+		},
+		{
+			name:     "empty AS path",
+			expr:     "^$",
+			path:     &types.ASPath{},
+			expected: true,
+		},
+		{
+			name:     "nil AS path",
+			expr:     "^$",
+			expected: true,
+		},
+		{
+			name:     "invalid expression",
+			expr:     "(65000",
+			wantFail: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, err := NewASPathFilter(test.expr)
+			if test.wantFail {
+				assert.Error(t, err)
+				return
+			}
+
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			assert.Equal(t, test.expected, f.Matches(test.path))
+		})
+	}
+}
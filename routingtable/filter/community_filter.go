@@ -2,11 +2,24 @@ package filter
 
 import "github.com/bio-routing/bio-rd/protocols/bgp/types"
 
+// CommunityFilter represents a filter for communities
 type CommunityFilter struct {
 	community uint32
 }
 
+// NewCommunityFilter creates a new filter matching community c
+func NewCommunityFilter(c uint32) *CommunityFilter {
+	return &CommunityFilter{
+		community: c,
+	}
+}
+
+// Matches checks if the community f.community is on the given list
 func (f *CommunityFilter) Matches(coms *types.Communities) bool {
+	if coms == nil {
+		return false
+	}
+
 	for _, com := range *coms {
 		if com == f.community {
 			return true
@@ -15,3 +28,7 @@ func (f *CommunityFilter) Matches(coms *types.Communities) bool {
 
 	return false
 }
+
+func (f *CommunityFilter) equal(x *CommunityFilter) bool {
+	return f.community == x.community
+}
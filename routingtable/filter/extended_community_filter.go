@@ -0,0 +1,36 @@
+package filter
+
+import (
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
+)
+
+// ExtendedCommunityFilter represents a filter for extended communities, e.g. route targets or route origins
+type ExtendedCommunityFilter struct {
+	community types.ExtendedCommunity
+}
+
+// NewExtendedCommunityFilter creates a new filter matching extended community c
+func NewExtendedCommunityFilter(c types.ExtendedCommunity) *ExtendedCommunityFilter {
+	return &ExtendedCommunityFilter{
+		community: c,
+	}
+}
+
+// Matches checks if the community f.community is on the given list
+func (f *ExtendedCommunityFilter) Matches(coms *types.ExtendedCommunities) bool {
+	if coms == nil {
+		return false
+	}
+
+	for _, com := range *coms {
+		if com == f.community {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (f *ExtendedCommunityFilter) equal(x *ExtendedCommunityFilter) bool {
+	return f.community == x.community
+}
@@ -43,6 +43,9 @@ func (f *Filter) Process(p *net.Prefix, pa *route.Path) FilterResult {
 		}
 
 		pa = res.Path
+		if res.NextPolicy {
+			break
+		}
 	}
 
 	return FilterResult{
@@ -77,6 +77,62 @@ func TestProcessTerms(t *testing.T) {
 			expectAccept:   true,
 			expectModified: true,
 		},
+		{
+			name:   "next term skips remaining actions",
+			prefix: net.NewPfx(net.IPv4(0), 0).Ptr(),
+			path:   &route.Path{},
+			terms: []*Term{
+				{
+					then: []actions.Action{
+						actions.NewNextTermAction(),
+						&actions.RejectAction{},
+					},
+				},
+				{
+					then: []actions.Action{
+						&actions.AcceptAction{},
+					},
+				},
+			},
+			expectAccept:   true,
+			expectModified: false,
+		},
+		{
+			name:   "next policy skips remaining terms",
+			prefix: net.NewPfx(net.IPv4(0), 0).Ptr(),
+			path: &route.Path{
+				Type: route.BGPPathType,
+				BGPPath: &route.BGPPath{
+					BGPPathA: &route.BGPPathA{
+						LocalPref: 23,
+					},
+				},
+			},
+			terms: []*Term{
+				{
+					then: []actions.Action{
+						actions.NewSetLocalPrefAction(42),
+						actions.NewNextPolicyAction(),
+						actions.NewSetLocalPrefAction(100),
+					},
+				},
+				{
+					then: []actions.Action{
+						&actions.RejectAction{},
+					},
+				},
+			},
+			expectAccept:   true,
+			expectModified: true,
+			expectedPath: &route.Path{
+				Type: route.BGPPathType,
+				BGPPath: &route.BGPPath{
+					BGPPathA: &route.BGPPathA{
+						LocalPref: 42,
+					},
+				},
+			},
+		},
 		{
 			name:   "Overwrite Next-Hop",
 			prefix: net.NewPfx(net.IPv4(0), 0).Ptr(),
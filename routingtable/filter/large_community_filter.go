@@ -9,6 +9,13 @@ type LargeCommunityFilter struct {
 	community types.LargeCommunity
 }
 
+// NewLargeCommunityFilter creates a new filter matching large community c
+func NewLargeCommunityFilter(c types.LargeCommunity) *LargeCommunityFilter {
+	return &LargeCommunityFilter{
+		community: c,
+	}
+}
+
 // Matches checks if a community f.community is on the filter list
 func (f *LargeCommunityFilter) Matches(coms *types.LargeCommunities) bool {
 	if coms == nil {
@@ -23,3 +30,7 @@ func (f *LargeCommunityFilter) Matches(coms *types.LargeCommunities) bool {
 
 	return false
 }
+
+func (f *LargeCommunityFilter) equal(x *LargeCommunityFilter) bool {
+	return f.community == x.community
+}
@@ -17,6 +17,9 @@ type TermResult struct {
 	Path      *route.Path
 	Terminate bool
 	Reject    bool
+
+	// NextPolicy indicates the remaining terms of the policy are to be skipped
+	NextPolicy bool
 }
 
 // NewTerm creates a new term
@@ -55,7 +58,18 @@ func (t *Term) processActions(p *net.Prefix, pa *route.Path) TermResult {
 				Reject:    res.Reject,
 			}
 		}
+
+		if res.NextPolicy {
+			return TermResult{
+				Path:       res.Path,
+				NextPolicy: true,
+			}
+		}
+
 		pa = res.Path
+		if res.NextTerm {
+			break
+		}
 	}
 
 	return TermResult{Path: pa}
@@ -2,15 +2,24 @@ package filter
 
 import (
 	"github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
 	"github.com/bio-routing/bio-rd/route"
 )
 
 type TermCondition struct {
-	prefixLists           []*PrefixList
-	routeFilters          []*RouteFilter
-	communityFilters      []*CommunityFilter
-	largeCommunityFilters []*LargeCommunityFilter
-	protocols             []uint8
+	prefixLists              []*PrefixList
+	routeFilters             []*RouteFilter
+	communityFilters         []*CommunityFilter
+	largeCommunityFilters    []*LargeCommunityFilter
+	extendedCommunityFilters []*ExtendedCommunityFilter
+	asPathFilters            []*ASPathFilter
+	originASNs               []uint32
+	nextHops                 []*net.IP
+	meds                     []uint32
+	localPrefs               []uint32
+	protocols                []uint8
+	rpkiValidationStates     []uint8
+	aspaVerificationStates   []uint8
 }
 
 func NewTermCondition(prefixLists []*PrefixList, routeFilters []*RouteFilter) *TermCondition {
@@ -38,12 +47,92 @@ func NewTermConditionWithProtocols(protocols ...uint8) *TermCondition {
 	}
 }
 
+func NewTermConditionWithExtendedCommunityFilters(filters ...*ExtendedCommunityFilter) *TermCondition {
+	return &TermCondition{
+		extendedCommunityFilters: filters,
+	}
+}
+
+// WithCommunityFilters sets the community filters of the condition
+func (t *TermCondition) WithCommunityFilters(filters ...*CommunityFilter) *TermCondition {
+	t.communityFilters = filters
+	return t
+}
+
+// WithLargeCommunityFilters sets the large community filters of the condition
+func (t *TermCondition) WithLargeCommunityFilters(filters ...*LargeCommunityFilter) *TermCondition {
+	t.largeCommunityFilters = filters
+	return t
+}
+
+// WithExtendedCommunityFilters sets the extended community filters of the condition
+func (t *TermCondition) WithExtendedCommunityFilters(filters ...*ExtendedCommunityFilter) *TermCondition {
+	t.extendedCommunityFilters = filters
+	return t
+}
+
+// WithASPathFilters sets the AS path filters of the condition
+func (t *TermCondition) WithASPathFilters(filters ...*ASPathFilter) *TermCondition {
+	t.asPathFilters = filters
+	return t
+}
+
+// WithOriginASNs sets the origin ASNs (the last ASN of the AS path) the condition matches
+func (t *TermCondition) WithOriginASNs(asns ...uint32) *TermCondition {
+	t.originASNs = asns
+	return t
+}
+
+// WithNextHops sets the next hops the condition matches
+func (t *TermCondition) WithNextHops(nextHops ...*net.IP) *TermCondition {
+	t.nextHops = nextHops
+	return t
+}
+
+// WithMEDs sets the MEDs the condition matches
+func (t *TermCondition) WithMEDs(meds ...uint32) *TermCondition {
+	t.meds = meds
+	return t
+}
+
+// WithLocalPrefs sets the local preferences the condition matches
+func (t *TermCondition) WithLocalPrefs(localPrefs ...uint32) *TermCondition {
+	t.localPrefs = localPrefs
+	return t
+}
+
+// WithProtocols sets the protocols (path types) the condition matches
+func (t *TermCondition) WithProtocols(protocols ...uint8) *TermCondition {
+	t.protocols = protocols
+	return t
+}
+
+// WithRPKIValidationStates sets the RPKI origin validation states (see route.RPKIValidation*) the condition matches
+func (t *TermCondition) WithRPKIValidationStates(states ...uint8) *TermCondition {
+	t.rpkiValidationStates = states
+	return t
+}
+
+// WithASPAVerificationStates sets the ASPA verification states (see route.ASPAVerification*) the condition matches
+func (t *TermCondition) WithASPAVerificationStates(states ...uint8) *TermCondition {
+	t.aspaVerificationStates = states
+	return t
+}
+
 func (f *TermCondition) Matches(p *net.Prefix, pa *route.Path) bool {
 	return f.matchesPrefixListFilters(p) &&
 		f.matchesRouteFilters(p) &&
 		f.matchesCommunityFilters(pa) &&
 		f.matchesLargeCommunityFilters(pa) &&
-		f.matchesProtocols(pa)
+		f.matchesExtendedCommunityFilters(pa) &&
+		f.matchesASPathFilters(pa) &&
+		f.matchesOriginASNs(pa) &&
+		f.matchesNextHops(pa) &&
+		f.matchesMEDs(pa) &&
+		f.matchesLocalPrefs(pa) &&
+		f.matchesProtocols(pa) &&
+		f.matchesRPKIValidationStates(pa) &&
+		f.matchesASPAVerificationStates(pa)
 }
 
 func (t *TermCondition) matchesPrefixListFilters(p *net.Prefix) bool {
@@ -110,13 +199,181 @@ func (t *TermCondition) matchesLargeCommunityFilters(pa *route.Path) bool {
 	return false
 }
 
+func (t *TermCondition) matchesExtendedCommunityFilters(pa *route.Path) bool {
+	if len(t.extendedCommunityFilters) == 0 {
+		return true
+	}
+
+	if pa.BGPPath == nil {
+		return false
+	}
+
+	for _, l := range t.extendedCommunityFilters {
+		if l.Matches(pa.BGPPath.ExtendedCommunities) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (t *TermCondition) matchesASPathFilters(pa *route.Path) bool {
+	if len(t.asPathFilters) == 0 {
+		return true
+	}
+
+	if pa.BGPPath == nil {
+		return false
+	}
+
+	for _, l := range t.asPathFilters {
+		if l.Matches(pa.BGPPath.ASPath) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (t *TermCondition) matchesOriginASNs(pa *route.Path) bool {
+	if len(t.originASNs) == 0 {
+		return true
+	}
+
+	if pa.BGPPath == nil || pa.BGPPath.ASPath == nil || len(*pa.BGPPath.ASPath) == 0 {
+		return false
+	}
+
+	// The origin is unknown if the path ends with an AS_SET (RFC6907 Sect. 2)
+	seg := (*pa.BGPPath.ASPath)[len(*pa.BGPPath.ASPath)-1]
+	if seg.Type != types.ASSequence {
+		return false
+	}
+
+	origin := seg.GetLastASN()
+	if origin == nil {
+		return false
+	}
+
+	for _, asn := range t.originASNs {
+		if asn == *origin {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (t *TermCondition) matchesNextHops(pa *route.Path) bool {
+	if len(t.nextHops) == 0 {
+		return true
+	}
+
+	switch pa.Type {
+	case route.BGPPathType, route.StaticPathType, route.FIBPathType, route.ISISPathType:
+	default:
+		return false
+	}
+
+	nh := pa.NextHop()
+	if nh == nil {
+		return false
+	}
+
+	for _, x := range t.nextHops {
+		if x.Equal(*nh) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (t *TermCondition) matchesMEDs(pa *route.Path) bool {
+	if len(t.meds) == 0 {
+		return true
+	}
+
+	if pa.BGPPath == nil || pa.BGPPath.BGPPathA == nil {
+		return false
+	}
+
+	for _, med := range t.meds {
+		if med == pa.BGPPath.BGPPathA.MED {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (t *TermCondition) matchesLocalPrefs(pa *route.Path) bool {
+	if len(t.localPrefs) == 0 {
+		return true
+	}
+
+	if pa.BGPPath == nil || pa.BGPPath.BGPPathA == nil {
+		return false
+	}
+
+	for _, lp := range t.localPrefs {
+		if lp == pa.BGPPath.BGPPathA.LocalPref {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (t *TermCondition) matchesProtocols(pa *route.Path) bool {
 	if len(t.protocols) == 0 {
 		return true
 	}
 
+	// Redistributed paths match the protocol they have been learned from
+	pathType := pa.Type
+	if pa.IsRedistributed() {
+		pathType = pa.RedistributedFrom
+	}
+
 	for _, protocol := range t.protocols {
-		if protocol == pa.Type {
+		if protocol == pathType {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (t *TermCondition) matchesRPKIValidationStates(pa *route.Path) bool {
+	if len(t.rpkiValidationStates) == 0 {
+		return true
+	}
+
+	if pa.BGPPath == nil {
+		return false
+	}
+
+	for _, state := range t.rpkiValidationStates {
+		if state == pa.BGPPath.RPKIValidationState {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (t *TermCondition) matchesASPAVerificationStates(pa *route.Path) bool {
+	if len(t.aspaVerificationStates) == 0 {
+		return true
+	}
+
+	if pa.BGPPath == nil {
+		return false
+	}
+
+	for _, state := range t.aspaVerificationStates {
+		if state == pa.BGPPath.ASPAVerificationState {
 			return true
 		}
 	}
@@ -125,6 +382,10 @@ func (t *TermCondition) matchesProtocols(pa *route.Path) bool {
 }
 
 func (t *TermCondition) equal(x *TermCondition) bool {
+	if len(t.prefixLists) != len(x.prefixLists) {
+		return false
+	}
+
 	if len(t.routeFilters) != len(x.routeFilters) {
 		return false
 	}
@@ -137,15 +398,97 @@ func (t *TermCondition) equal(x *TermCondition) bool {
 		return false
 	}
 
+	if len(t.extendedCommunityFilters) != len(x.extendedCommunityFilters) {
+		return false
+	}
+
+	if len(t.asPathFilters) != len(x.asPathFilters) {
+		return false
+	}
+
+	if !uint32SlicesEqual(t.originASNs, x.originASNs) || !uint32SlicesEqual(t.meds, x.meds) ||
+		!uint32SlicesEqual(t.localPrefs, x.localPrefs) {
+		return false
+	}
+
+	if !uint8SlicesEqual(t.protocols, x.protocols) || !uint8SlicesEqual(t.rpkiValidationStates, x.rpkiValidationStates) ||
+		!uint8SlicesEqual(t.aspaVerificationStates, x.aspaVerificationStates) {
+		return false
+	}
+
+	if len(t.nextHops) != len(x.nextHops) {
+		return false
+	}
+
+	for i := range t.prefixLists {
+		if !t.prefixLists[i].equal(x.prefixLists[i]) {
+			return false
+		}
+	}
+
 	for i := range t.routeFilters {
 		if !t.routeFilters[i].equal(x.routeFilters[i]) {
 			return false
 		}
 	}
 
-	// TODO: Compare community filters
+	for i := range t.extendedCommunityFilters {
+		if !t.extendedCommunityFilters[i].equal(x.extendedCommunityFilters[i]) {
+			return false
+		}
+	}
+
+	for i := range t.communityFilters {
+		if !t.communityFilters[i].equal(x.communityFilters[i]) {
+			return false
+		}
+	}
 
-	// TODO: Compare large community filters
+	for i := range t.largeCommunityFilters {
+		if !t.largeCommunityFilters[i].equal(x.largeCommunityFilters[i]) {
+			return false
+		}
+	}
+
+	for i := range t.asPathFilters {
+		if !t.asPathFilters[i].equal(x.asPathFilters[i]) {
+			return false
+		}
+	}
+
+	for i := range t.nextHops {
+		if !t.nextHops[i].Equal(*x.nextHops[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func uint32SlicesEqual(a, b []uint32) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func uint8SlicesEqual(a, b []uint8) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
 
 	return true
 }
@@ -11,14 +11,20 @@ import (
 
 func TestMatches(t *testing.T) {
 	tests := []struct {
-		name                  string
-		prefix                *net.Prefix
-		bgpPath               *route.BGPPath
-		prefixLists           []*PrefixList
-		routeFilters          []*RouteFilter
-		communityFilters      []*CommunityFilter
-		largeCommunityFilters []*LargeCommunityFilter
-		expected              bool
+		name                     string
+		prefix                   *net.Prefix
+		bgpPath                  *route.BGPPath
+		prefixLists              []*PrefixList
+		routeFilters             []*RouteFilter
+		communityFilters         []*CommunityFilter
+		largeCommunityFilters    []*LargeCommunityFilter
+		extendedCommunityFilters []*ExtendedCommunityFilter
+		asPathFilters            []*ASPathFilter
+		originASNs               []uint32
+		nextHops                 []*net.IP
+		meds                     []uint32
+		localPrefs               []uint32
+		expected                 bool
 	}{
 		{
 			name:   "one prefix matches in prefix list, no route filters set",
@@ -190,6 +196,125 @@ func TestMatches(t *testing.T) {
 			},
 			expected: false,
 		},
+		{
+			name:   "route target matches",
+			prefix: net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 24).Ptr(),
+			bgpPath: &route.BGPPath{
+				ExtendedCommunities: &types.ExtendedCommunities{
+					types.NewRouteOrigin(65000, false, 100),
+					types.NewRouteTarget(65000, false, 100),
+				},
+			},
+			extendedCommunityFilters: []*ExtendedCommunityFilter{
+				NewExtendedCommunityFilter(types.NewRouteTarget(65000, false, 100)),
+			},
+			expected: true,
+		},
+		{
+			name:   "route target does not match route origin",
+			prefix: net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 24).Ptr(),
+			bgpPath: &route.BGPPath{
+				ExtendedCommunities: &types.ExtendedCommunities{
+					types.NewRouteOrigin(65000, false, 100),
+				},
+			},
+			extendedCommunityFilters: []*ExtendedCommunityFilter{
+				NewExtendedCommunityFilter(types.NewRouteTarget(65000, false, 100)),
+			},
+			expected: false,
+		},
+		{
+			name:    "extended community filter, no extended communities",
+			prefix:  net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 24).Ptr(),
+			bgpPath: &route.BGPPath{},
+			extendedCommunityFilters: []*ExtendedCommunityFilter{
+				NewExtendedCommunityFilter(types.NewRouteTarget(65000, false, 100)),
+			},
+			expected: false,
+		},
+		{
+			name:   "AS path matches",
+			prefix: net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 24).Ptr(),
+			bgpPath: &route.BGPPath{
+				ASPath: &types.ASPath{
+					{Type: types.ASSequence, ASNs: []uint32{65000, 65001}},
+				},
+			},
+			asPathFilters: []*ASPathFilter{
+				mustASPathFilter("^65000_"),
+			},
+			expected: true,
+		},
+		{
+			name:   "AS path does not match",
+			prefix: net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 24).Ptr(),
+			bgpPath: &route.BGPPath{
+				ASPath: &types.ASPath{
+					{Type: types.ASSequence, ASNs: []uint32{165000, 65001}},
+				},
+			},
+			asPathFilters: []*ASPathFilter{
+				mustASPathFilter("^65000_"),
+			},
+			expected: false,
+		},
+		{
+			name:   "origin AS matches",
+			prefix: net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 24).Ptr(),
+			bgpPath: &route.BGPPath{
+				ASPath: &types.ASPath{
+					{Type: types.ASSequence, ASNs: []uint32{65000, 65001}},
+				},
+			},
+			originASNs: []uint32{65002, 65001},
+			expected:   true,
+		},
+		{
+			name:   "origin AS does not match AS set",
+			prefix: net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 24).Ptr(),
+			bgpPath: &route.BGPPath{
+				ASPath: &types.ASPath{
+					{Type: types.ASSequence, ASNs: []uint32{65000}},
+					{Type: types.ASSet, ASNs: []uint32{65001}},
+				},
+			},
+			originASNs: []uint32{65001},
+			expected:   false,
+		},
+		{
+			name:   "next hop, MED and local pref match",
+			prefix: net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 24).Ptr(),
+			bgpPath: &route.BGPPath{
+				BGPPathA: &route.BGPPathA{
+					NextHop:   net.IPv4FromOctets(192, 0, 2, 1).Ptr(),
+					MED:       10,
+					LocalPref: 200,
+				},
+			},
+			nextHops:   []*net.IP{net.IPv4FromOctets(192, 0, 2, 1).Ptr()},
+			meds:       []uint32{10},
+			localPrefs: []uint32{100, 200},
+			expected:   true,
+		},
+		{
+			name:   "next hop matches, local pref does not",
+			prefix: net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 24).Ptr(),
+			bgpPath: &route.BGPPath{
+				BGPPathA: &route.BGPPathA{
+					NextHop:   net.IPv4FromOctets(192, 0, 2, 1).Ptr(),
+					LocalPref: 100,
+				},
+			},
+			nextHops:   []*net.IP{net.IPv4FromOctets(192, 0, 2, 1).Ptr()},
+			localPrefs: []uint32{200},
+			expected:   false,
+		},
+		{
+			name:     "next hop filter, bgp path is nil",
+			prefix:   net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 24).Ptr(),
+			nextHops: []*net.IP{net.IPv4FromOctets(192, 0, 2, 1).Ptr()},
+			expected: false,
+		},
 	}
 
 	for _, test := range tests {
@@ -197,12 +322,258 @@ func TestMatches(t *testing.T) {
 			f := NewTermCondition(test.prefixLists, test.routeFilters)
 			f.communityFilters = test.communityFilters
 			f.largeCommunityFilters = test.largeCommunityFilters
+			f.extendedCommunityFilters = test.extendedCommunityFilters
+			f.WithASPathFilters(test.asPathFilters...).
+				WithOriginASNs(test.originASNs...).
+				WithNextHops(test.nextHops...).
+				WithMEDs(test.meds...).
+				WithLocalPrefs(test.localPrefs...)
 
 			pa := &route.Path{
 				BGPPath: test.bgpPath,
 			}
 
+			if test.bgpPath != nil {
+				pa.Type = route.BGPPathType
+			}
+
 			assert.Equal(te, test.expected, f.Matches(test.prefix, pa))
 		})
 	}
 }
+
+func TestMatchesProtocols(t *testing.T) {
+	tests := []struct {
+		name      string
+		protocols []uint8
+		path      *route.Path
+		expected  bool
+	}{
+		{
+			name:      "connected path matches connected",
+			protocols: []uint8{route.ConnectedPathType},
+			path: &route.Path{
+				Type:          route.ConnectedPathType,
+				ConnectedPath: &route.ConnectedPath{},
+			},
+			expected: true,
+		},
+		{
+			name:      "FIB path does not match static",
+			protocols: []uint8{route.StaticPathType},
+			path: &route.Path{
+				Type:    route.FIBPathType,
+				FIBPath: &route.FIBPath{},
+			},
+			expected: false,
+		},
+		{
+			name:      "redistributed FIB path matches FIB",
+			protocols: []uint8{route.ConnectedPathType, route.FIBPathType},
+			path: &route.Path{
+				Type:              route.BGPPathType,
+				RedistributedFrom: route.FIBPathType,
+				BGPPath:           route.NewBGPPath(),
+			},
+			expected: true,
+		},
+		{
+			name:      "redistributed FIB path does not match BGP",
+			protocols: []uint8{route.BGPPathType},
+			path: &route.Path{
+				Type:              route.BGPPathType,
+				RedistributedFrom: route.FIBPathType,
+				BGPPath:           route.NewBGPPath(),
+			},
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := NewTermConditionWithProtocols(test.protocols...)
+			assert.Equal(t, test.expected, c.Matches(net.NewPfx(net.IPv4(0), 0).Ptr(), test.path))
+		})
+	}
+}
+
+func TestMatchesRPKIValidationStates(t *testing.T) {
+	tests := []struct {
+		name     string
+		states   []uint8
+		path     *route.Path
+		expected bool
+	}{
+		{
+			name:   "invalid path matches invalid",
+			states: []uint8{route.RPKIValidationInvalid},
+			path: &route.Path{
+				Type: route.BGPPathType,
+				BGPPath: &route.BGPPath{
+					RPKIValidationState: route.RPKIValidationInvalid,
+				},
+			},
+			expected: true,
+		},
+		{
+			name:   "valid path does not match invalid or not found",
+			states: []uint8{route.RPKIValidationInvalid, route.RPKIValidationNotFound},
+			path: &route.Path{
+				Type: route.BGPPathType,
+				BGPPath: &route.BGPPath{
+					RPKIValidationState: route.RPKIValidationValid,
+				},
+			},
+			expected: false,
+		},
+		{
+			name:   "unverified path matches unverified",
+			states: []uint8{route.RPKIValidationUnverified},
+			path: &route.Path{
+				Type:    route.BGPPathType,
+				BGPPath: &route.BGPPath{},
+			},
+			expected: true,
+		},
+		{
+			name:   "static path does not match",
+			states: []uint8{route.RPKIValidationUnverified},
+			path: &route.Path{
+				Type:       route.StaticPathType,
+				StaticPath: &route.StaticPath{},
+			},
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := NewTermCondition(nil, nil).WithRPKIValidationStates(test.states...)
+			assert.Equal(t, test.expected, c.Matches(net.NewPfx(net.IPv4(0), 0).Ptr(), test.path))
+		})
+	}
+}
+
+func TestMatchesASPAVerificationStates(t *testing.T) {
+	tests := []struct {
+		name     string
+		states   []uint8
+		path     *route.Path
+		expected bool
+	}{
+		{
+			name:   "invalid path matches invalid",
+			states: []uint8{route.ASPAVerificationInvalid},
+			path: &route.Path{
+				Type: route.BGPPathType,
+				BGPPath: &route.BGPPath{
+					ASPAVerificationState: route.ASPAVerificationInvalid,
+				},
+			},
+			expected: true,
+		},
+		{
+			name:   "valid path does not match invalid or unknown",
+			states: []uint8{route.ASPAVerificationInvalid, route.ASPAVerificationUnknown},
+			path: &route.Path{
+				Type: route.BGPPathType,
+				BGPPath: &route.BGPPath{
+					ASPAVerificationState: route.ASPAVerificationValid,
+				},
+			},
+			expected: false,
+		},
+		{
+			name:   "static path does not match",
+			states: []uint8{route.ASPAVerificationUnverified},
+			path: &route.Path{
+				Type:       route.StaticPathType,
+				StaticPath: &route.StaticPath{},
+			},
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := NewTermCondition(nil, nil).WithASPAVerificationStates(test.states...)
+			assert.Equal(t, test.expected, c.Matches(net.NewPfx(net.IPv4(0), 0).Ptr(), test.path))
+		})
+	}
+}
+
+func TestTermConditionEqual(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        *TermCondition
+		b        *TermCondition
+		expected bool
+	}{
+		{
+			name: "equal prefix lists",
+			a: NewTermConditionWithPrefixLists(
+				NewPrefixList(net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr()),
+			),
+			b: NewTermConditionWithPrefixLists(
+				NewPrefixList(net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr()),
+			),
+			expected: true,
+		},
+		{
+			name: "prefix list contents differ",
+			a: NewTermConditionWithPrefixLists(
+				NewPrefixList(net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr()),
+			),
+			b: NewTermConditionWithPrefixLists(
+				NewPrefixList(net.NewPfx(net.IPv4FromOctets(11, 0, 0, 0), 8).Ptr()),
+			),
+			expected: false,
+		},
+		{
+			name: "prefix list lengths differ",
+			a: NewTermConditionWithPrefixLists(
+				NewPrefixList(net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr()),
+			),
+			b: NewTermConditionWithPrefixLists(
+				NewPrefixList(
+					net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr(),
+					net.NewPfx(net.IPv4FromOctets(11, 0, 0, 0), 8).Ptr(),
+				),
+			),
+			expected: false,
+		},
+		{
+			name: "prefix list matchers differ",
+			a: NewTermConditionWithPrefixLists(
+				NewPrefixList(net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr()),
+			),
+			b: NewTermConditionWithPrefixLists(
+				NewPrefixListWithMatcher(NewInRangeMatcher(8, 24), net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr()),
+			),
+			expected: false,
+		},
+		{
+			name: "number of prefix lists differs",
+			a: NewTermConditionWithPrefixLists(
+				NewPrefixList(net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr()),
+			),
+			b:        NewTermConditionWithPrefixLists(),
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.a.equal(test.b))
+		})
+	}
+}
+
+func mustASPathFilter(expr string) *ASPathFilter {
+	f, err := NewASPathFilter(expr)
+	if err != nil {
+		panic(err)
+	}
+
+	return f
+}
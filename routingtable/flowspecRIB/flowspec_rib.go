@@ -0,0 +1,237 @@
+package flowspecRIB
+
+import (
+	"sync"
+
+	"github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/bio-routing/bio-rd/routingtable"
+	"github.com/bio-routing/bio-rd/routingtable/filter"
+	"github.com/bio-routing/bio-rd/routingtable/locRIB"
+)
+
+// FlowSpecRIB represents a routing information base for FlowSpec address families (RFC8955, RFC8956).
+// FlowSpec routes are identified by their rule, so path selection is done per rule.
+type FlowSpecRIB struct {
+	name    string
+	ribs    map[string]*locRIB.LocRIB
+	clients map[routingtable.RouteTableClient]*client
+	mu      sync.RWMutex
+}
+
+// client wraps a client registered to the FlowSpecRIB. As the client is registered to the LocRIB of every
+// rule, end of RIB and dispose signals are sent by the FlowSpecRIB itself.
+type client struct {
+	routingtable.RouteTableClient
+	opts routingtable.ClientOptions
+}
+
+func (c *client) EndOfRIB() {}
+
+func (c *client) Dispose() {}
+
+// New creates a new FlowSpec RIB
+func New(name string) *FlowSpecRIB {
+	return &FlowSpecRIB{
+		name:    name,
+		ribs:    make(map[string]*locRIB.LocRIB),
+		clients: make(map[routingtable.RouteTableClient]*client),
+	}
+}
+
+// Name gets the name of the FlowSpecRIB
+func (f *FlowSpecRIB) Name() string {
+	return f.name
+}
+
+func ruleKey(p *route.Path) string {
+	if p.BGPPath == nil {
+		return ""
+	}
+
+	return p.BGPPath.FlowSpecRule.String()
+}
+
+func (f *FlowSpecRIB) ribOrCreate(key string) *locRIB.LocRIB {
+	rib, exists := f.ribs[key]
+	if exists {
+		return rib
+	}
+
+	rib = locRIB.New(f.name)
+	for _, c := range f.clients {
+		rib.RegisterWithOptions(c, c.opts)
+	}
+
+	f.ribs[key] = rib
+	return rib
+}
+
+func (f *FlowSpecRIB) ribList() []*locRIB.LocRIB {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	ret := make([]*locRIB.LocRIB, 0, len(f.ribs))
+	for _, rib := range f.ribs {
+		ret = append(ret, rib)
+	}
+
+	return ret
+}
+
+// AddPathInitialDump adds a path during the initial dump
+func (f *FlowSpecRIB) AddPathInitialDump(pfx *net.Prefix, p *route.Path) error {
+	return f.AddPath(pfx, p)
+}
+
+// AddPath adds a path to the RIB of the paths rule
+func (f *FlowSpecRIB) AddPath(pfx *net.Prefix, p *route.Path) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.ribOrCreate(ruleKey(p)).AddPath(pfx, p)
+}
+
+// RemovePath removes a path from the RIB of the paths rule
+func (f *FlowSpecRIB) RemovePath(pfx *net.Prefix, p *route.Path) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.removePath(pfx, p)
+}
+
+func (f *FlowSpecRIB) removePath(pfx *net.Prefix, p *route.Path) bool {
+	key := ruleKey(p)
+	rib, exists := f.ribs[key]
+	if !exists {
+		return false
+	}
+
+	removed := rib.RemovePath(pfx, p)
+
+	// Rules are short lived compared to route distinguishers, so we drop the RIB of a rule once it is empty
+	if rib.RouteCount() == 0 {
+		delete(f.ribs, key)
+	}
+
+	return removed
+}
+
+// ReplacePath replaces a path
+func (f *FlowSpecRIB) ReplacePath(pfx *net.Prefix, oldPath *route.Path, newPath *route.Path) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if ruleKey(oldPath) == ruleKey(newPath) {
+		f.ribOrCreate(ruleKey(newPath)).ReplacePath(pfx, oldPath, newPath)
+		return
+	}
+
+	f.removePath(pfx, oldPath)
+	f.ribOrCreate(ruleKey(newPath)).AddPath(pfx, newPath)
+}
+
+// EndOfRIB is here to fulfill an interface
+func (f *FlowSpecRIB) EndOfRIB() {}
+
+// RefreshRoute is here to fulfill an interface
+func (f *FlowSpecRIB) RefreshRoute(*net.Prefix, []*route.Path) {}
+
+// ReplaceFilterChain is here to fulfill an interface
+func (f *FlowSpecRIB) ReplaceFilterChain(filter.Chain) {}
+
+// Register registers a client for updates
+func (f *FlowSpecRIB) Register(c routingtable.RouteTableClient) {
+	f.RegisterWithOptions(c, routingtable.ClientOptions{BestOnly: true})
+}
+
+// RegisterWithOptions registers a client with options for updates
+func (f *FlowSpecRIB) RegisterWithOptions(c routingtable.RouteTableClient, opt routingtable.ClientOptions) {
+	w := &client{
+		RouteTableClient: c,
+		opts:             opt,
+	}
+
+	f.mu.Lock()
+	f.clients[c] = w
+	for _, rib := range f.ribs {
+		rib.RegisterWithOptions(w, opt)
+	}
+	f.mu.Unlock()
+
+	c.EndOfRIB()
+}
+
+// Unregister unregisters a client
+func (f *FlowSpecRIB) Unregister(c routingtable.RouteTableClient) {
+	f.mu.Lock()
+	w, found := f.clients[c]
+	delete(f.clients, c)
+	f.mu.Unlock()
+
+	if !found {
+		return
+	}
+
+	for _, rib := range f.ribList() {
+		rib.Unregister(w)
+	}
+}
+
+// RefreshClient re-sends all propagated paths to a certain client
+func (f *FlowSpecRIB) RefreshClient(c routingtable.RouteTableClient) {
+	f.mu.RLock()
+	w, found := f.clients[c]
+	f.mu.RUnlock()
+
+	if !found {
+		return
+	}
+
+	for _, rib := range f.ribList() {
+		rib.RefreshClient(w)
+	}
+}
+
+// ClientCount gets the number of registered clients
+func (f *FlowSpecRIB) ClientCount() uint64 {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	return uint64(len(f.clients))
+}
+
+// RouteCount returns the number of stored rules
+func (f *FlowSpecRIB) RouteCount() int64 {
+	n := int64(0)
+	for _, rib := range f.ribList() {
+		n += rib.RouteCount()
+	}
+
+	return n
+}
+
+// Dump dumps the routes of all rules
+func (f *FlowSpecRIB) Dump() []*route.Route {
+	ret := make([]*route.Route, 0)
+	for _, rib := range f.ribList() {
+		ret = append(ret, rib.Dump()...)
+	}
+
+	return ret
+}
+
+// Dispose tells all clients that this FlowSpecRIB is not to be used anymore
+func (f *FlowSpecRIB) Dispose() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for c := range f.clients {
+		c.Dispose()
+		delete(f.clients, c)
+	}
+
+	for key := range f.ribs {
+		delete(f.ribs, key)
+	}
+}
@@ -0,0 +1,184 @@
+package flowspecRIB
+
+import (
+	"testing"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingClient struct {
+	paths     map[bnet.Prefix][]*route.Path
+	endOfRIBs int
+	disposed  bool
+}
+
+func newRecordingClient() *recordingClient {
+	return &recordingClient{
+		paths: make(map[bnet.Prefix][]*route.Path),
+	}
+}
+
+func (r *recordingClient) AddPath(pfx *bnet.Prefix, p *route.Path) error {
+	r.paths[*pfx] = append(r.paths[*pfx], p)
+	return nil
+}
+
+func (r *recordingClient) AddPathInitialDump(pfx *bnet.Prefix, p *route.Path) error {
+	return r.AddPath(pfx, p)
+}
+
+func (r *recordingClient) EndOfRIB() {
+	r.endOfRIBs++
+}
+
+func (r *recordingClient) RemovePath(pfx *bnet.Prefix, p *route.Path) bool {
+	for i, x := range r.paths[*pfx] {
+		if x.Equal(p) {
+			r.paths[*pfx] = append(r.paths[*pfx][:i], r.paths[*pfx][i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *recordingClient) ReplacePath(*bnet.Prefix, *route.Path, *route.Path) {}
+
+func (r *recordingClient) RefreshRoute(*bnet.Prefix, []*route.Path) {}
+
+func (r *recordingClient) Dispose() {
+	r.disposed = true
+}
+
+func flowSpecPath(port uint64, localPref uint32, source uint32) *route.Path {
+	return &route.Path{
+		Type: route.BGPPathType,
+		BGPPath: &route.BGPPath{
+			FlowSpecRule: &types.FlowSpecRule{
+				Components: []types.FlowSpecComponent{
+					{
+						Type:   types.FlowSpecComponentDestinationPrefix,
+						Prefix: bnet.NewPfx(bnet.IPv4FromOctets(192, 0, 2, 0), 24).Ptr(),
+					},
+					{
+						Type:       types.FlowSpecComponentDestinationPort,
+						Operations: []types.FlowSpecOperation{{Equal: true, Value: port}},
+					},
+				},
+			},
+			ASPath: route.NewBGPPath().ASPath,
+			BGPPathA: &route.BGPPathA{
+				LocalPref: localPref,
+				NextHop:   bnet.IPv4(0).Ptr(),
+				Source:    bnet.IPv4(source).Ptr(),
+			},
+		},
+	}
+}
+
+func TestFlowSpecRIBPathSelection(t *testing.T) {
+	pfx := bnet.NewPfx(bnet.IPv4FromOctets(192, 0, 2, 0), 24).Ptr()
+
+	tests := []struct {
+		name       string
+		add        []*route.Path
+		remove     []*route.Path
+		expected   []*route.Path
+		routeCount int64
+		ribCount   int
+	}{
+		{
+			name: "Different rules for the same prefix",
+			add: []*route.Path{
+				flowSpecPath(53, 100, 1),
+				flowSpecPath(123, 100, 2),
+			},
+			expected: []*route.Path{
+				flowSpecPath(53, 100, 1),
+				flowSpecPath(123, 100, 2),
+			},
+			routeCount: 2,
+			ribCount:   2,
+		},
+		{
+			name: "Best path per rule",
+			add: []*route.Path{
+				flowSpecPath(53, 100, 1),
+				flowSpecPath(53, 200, 2),
+				flowSpecPath(123, 100, 3),
+			},
+			expected: []*route.Path{
+				flowSpecPath(53, 200, 2),
+				flowSpecPath(123, 100, 3),
+			},
+			routeCount: 2,
+			ribCount:   2,
+		},
+		{
+			name: "Withdraw of last path of a rule",
+			add: []*route.Path{
+				flowSpecPath(53, 100, 1),
+				flowSpecPath(123, 100, 2),
+			},
+			remove: []*route.Path{
+				flowSpecPath(53, 100, 1),
+			},
+			expected: []*route.Path{
+				flowSpecPath(123, 100, 2),
+			},
+			routeCount: 1,
+			ribCount:   1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f := New("inetflow.0")
+			c := newRecordingClient()
+			f.Register(c)
+
+			for _, p := range test.add {
+				f.AddPath(pfx, p)
+			}
+
+			for _, p := range test.remove {
+				f.RemovePath(pfx, p)
+			}
+
+			assert.ElementsMatch(t, test.expected, c.paths[*pfx])
+			assert.Equal(t, test.routeCount, f.RouteCount())
+			assert.Len(t, f.ribs, test.ribCount)
+		})
+	}
+}
+
+func TestFlowSpecRIBRegister(t *testing.T) {
+	pfx := bnet.NewPfx(bnet.IPv4FromOctets(192, 0, 2, 0), 24).Ptr()
+
+	f := New("inetflow.0")
+	f.AddPath(pfx, flowSpecPath(53, 100, 1))
+	f.AddPath(pfx, flowSpecPath(123, 100, 2))
+
+	c := newRecordingClient()
+	f.Register(c)
+
+	assert.ElementsMatch(t, []*route.Path{
+		flowSpecPath(53, 100, 1),
+		flowSpecPath(123, 100, 2),
+	}, c.paths[*pfx])
+	assert.Equal(t, 1, c.endOfRIBs, "End-of-RIB must be signaled once for all rules")
+	assert.Len(t, f.Dump(), 2)
+
+	f.Unregister(c)
+	f.AddPath(pfx, flowSpecPath(443, 100, 3))
+	assert.Len(t, c.paths[*pfx], 2)
+	assert.Equal(t, uint64(0), f.ClientCount())
+
+	f.Register(c)
+	f.Dispose()
+	assert.True(t, c.disposed)
+	assert.Equal(t, int64(0), f.RouteCount())
+}
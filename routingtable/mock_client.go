@@ -16,6 +16,7 @@ type RemovePathParams struct {
 type RTMockClient struct {
 	removed        []*RemovePathParams
 	FakeRouteCount int64
+	FakePathCount  int64
 }
 
 func NewRTMockClient() *RTMockClient {
@@ -72,10 +73,18 @@ func (m *RTMockClient) RouteCount() int64 {
 	return m.FakeRouteCount
 }
 
+func (m *RTMockClient) PathCount() int64 {
+	return m.FakePathCount
+}
+
 func (m *RTMockClient) RefreshRoute(*net.Prefix, []*route.Path) {}
 
 func (m *RTMockClient) ReplaceFilterChain(filter.Chain) {}
 
+func (m *RTMockClient) ReplaceNextHopTracker(NextHopTracker) {}
+
+func (m *RTMockClient) ReplaceRouteDampener(RouteDampener) {}
+
 func (m *RTMockClient) ReplacePath(*net.Prefix, *route.Path, *route.Path) {}
 
 func (m *RTMockClient) Dispose() {}
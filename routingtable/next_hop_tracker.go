@@ -0,0 +1,26 @@
+package routingtable
+
+import (
+	"github.com/bio-routing/bio-rd/net"
+)
+
+// NextHopTracker resolves the next hops of BGP paths via the routes of a VRF and keeps track of their reachability
+type NextHopTracker interface {
+	// Track starts tracking a next hop and returns if it is reachable and the IGP metric to reach it.
+	// Every call of Track has to be followed by a call of Untrack once the next hop is not used anymore.
+	Track(nh *net.IP) (reachable bool, metric uint32)
+
+	// Untrack stops tracking a next hop
+	Untrack(nh *net.IP)
+
+	// Resolve returns if a next hop is reachable and the IGP metric to reach it
+	Resolve(nh *net.IP) (reachable bool, metric uint32)
+	Register(client NextHopTrackerClient)
+	Unregister(client NextHopTrackerClient)
+}
+
+// NextHopTrackerClient is notified about changes of the resolution of tracked next hops
+type NextHopTrackerClient interface {
+	// NextHopUpdate re-evaluates all paths using any of the next hops
+	NextHopUpdate(nextHops []*net.IP)
+}
@@ -0,0 +1,89 @@
+package nexthop
+
+import (
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/bio-routing/bio-rd/routingtable/locRIB"
+)
+
+// maxResolveDepth limits the number of recursive lookups to resolve a next hop
+const maxResolveDepth = 8
+
+// ResolveOptions controls which routes a next hop may be resolved by
+type ResolveOptions struct {
+	// Recursive resolves next hops recursively via routes learned by BGP. Otherwise BGP routes are skipped.
+	Recursive bool
+
+	// DefaultRoute allows next hops to be resolved via the default route
+	DefaultRoute bool
+
+	// Exclude is a prefix whose route is never used, e.g. the prefix of the route whose next hop is resolved
+	Exclude *bnet.Prefix
+}
+
+// Resolve looks up the best path of the most specific route to nh in rib. It returns the directly reachable
+// address traffic to nh is forwarded to and the interior cost to reach it. The address is nil if nh is unresolvable.
+func Resolve(rib *locRIB.LocRIB, nh *bnet.IP, opts ResolveOptions) (*bnet.IP, uint32) {
+	return resolve(rib, nh, opts, 0)
+}
+
+func resolve(rib *locRIB.LocRIB, nh *bnet.IP, opts ResolveOptions, depth int) (*bnet.IP, uint32) {
+	if depth >= maxResolveDepth {
+		return nil, 0
+	}
+
+	hostLen := uint8(32)
+	if !nh.IsIPv4() {
+		hostLen = 128
+	}
+
+	routes := rib.LPM(bnet.NewPfx(*nh, hostLen).Ptr())
+	for i := len(routes) - 1; i >= 0; i-- {
+		if routes[i].Pfxlen() == 0 && !opts.DefaultRoute {
+			break
+		}
+
+		if opts.Exclude != nil && routes[i].Prefix().Equal(opts.Exclude) {
+			continue
+		}
+
+		p := routes[i].BestPath()
+		if p == nil {
+			continue
+		}
+
+		switch p.Type {
+		case route.ConnectedPathType:
+			return nh, 0
+		case route.FIBPathType:
+			// Device routes have no gateway
+			if p.FIBPath.NextHop == nil {
+				return nh, uint32(p.FIBPath.Priority)
+			}
+
+			return p.FIBPath.NextHop, uint32(p.FIBPath.Priority)
+		case route.StaticPathType:
+			if p.StaticPath.Discard {
+				return nil, 0
+			}
+
+			return p.StaticPath.NextHop, 0
+		case route.ISISPathType:
+			return p.ISISPath.NextHop, p.ISISPath.Metric
+		case route.BGPPathType:
+			if !opts.Recursive {
+				continue
+			}
+
+			if p.BGPPath.BGPPathA.NextHop == nil {
+				return nil, 0
+			}
+
+			return resolve(rib, p.BGPPath.BGPPathA.NextHop, opts, depth+1)
+		}
+
+		return nil, 0
+	}
+
+	return nil, 0
+}
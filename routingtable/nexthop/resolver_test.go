@@ -0,0 +1,105 @@
+package nexthop
+
+import (
+	"testing"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/bio-routing/bio-rd/routingtable/locRIB"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveOptions(t *testing.T) {
+	gw := bnet.IPv4FromOctets(192, 0, 2, 1).Ptr()
+	nh := bnet.IPv4FromOctets(203, 0, 113, 1).Ptr()
+	host := bnet.NewPfx(bnet.IPv4FromOctets(203, 0, 113, 1), 32)
+	net := bnet.NewPfx(bnet.IPv4FromOctets(203, 0, 113, 0), 24)
+	defaultRoute := bnet.NewPfx(bnet.IPv4(0), 0)
+
+	type rt struct {
+		pfx  bnet.Prefix
+		path *route.Path
+	}
+
+	tests := []struct {
+		name            string
+		routes          []rt
+		opts            ResolveOptions
+		expectedNextHop *bnet.IP
+		expectedMetric  uint32
+	}{
+		{
+			name: "best path of the most specific route",
+			routes: []rt{
+				{pfx: host, path: isisPath(bnet.IPv4FromOctets(192, 0, 2, 2).Ptr(), 30)},
+				{pfx: host, path: fibPath(gw, 20)},
+			},
+			expectedNextHop: gw,
+			expectedMetric:  20,
+		},
+		{
+			name: "BGP route skipped",
+			routes: []rt{
+				{pfx: net, path: connectedPath(bnet.IPv4FromOctets(203, 0, 113, 100).Ptr())},
+				{pfx: host, path: bgpPath(gw)},
+			},
+			expectedNextHop: nh,
+		},
+		{
+			name: "BGP route resolved recursively",
+			routes: []rt{
+				{pfx: host, path: bgpPath(gw)},
+				{pfx: bnet.NewPfx(*gw, 32), path: isisPath(bnet.IPv4FromOctets(192, 0, 2, 2).Ptr(), 10)},
+			},
+			opts: ResolveOptions{
+				Recursive: true,
+			},
+			expectedNextHop: bnet.IPv4FromOctets(192, 0, 2, 2).Ptr(),
+			expectedMetric:  10,
+		},
+		{
+			name: "BGP route resolving itself",
+			routes: []rt{
+				{pfx: host, path: bgpPath(nh)},
+			},
+			opts: ResolveOptions{
+				Recursive: true,
+			},
+		},
+		{
+			name: "excluded prefix",
+			routes: []rt{
+				{pfx: net, path: staticPath(gw, false)},
+				{pfx: host, path: fibPath(bnet.IPv4FromOctets(192, 0, 2, 2).Ptr(), 0)},
+			},
+			opts: ResolveOptions{
+				Exclude: host.Ptr(),
+			},
+			expectedNextHop: gw,
+		},
+		{
+			name: "default route",
+			routes: []rt{
+				{pfx: defaultRoute, path: staticPath(gw, false)},
+			},
+			opts: ResolveOptions{
+				DefaultRoute: true,
+			},
+			expectedNextHop: gw,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rib := locRIB.New("inet.0")
+			for _, r := range test.routes {
+				pfx := r.pfx
+				rib.AddPath(&pfx, r.path)
+			}
+
+			addr, metric := Resolve(rib, nh, test.opts)
+			assert.Equal(t, test.expectedNextHop, addr)
+			assert.Equal(t, test.expectedMetric, metric)
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package nexthop
+
+import (
+	"sync"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/routingtable"
+	"github.com/bio-routing/bio-rd/routingtable/locRIB"
+)
+
+// Tracker resolves the next hops of BGP paths via the IGP, static and connected routes of a VRF.
+// Routes learned via BGP and the default route are never used to resolve a next hop.
+type Tracker struct {
+	rib4     *locRIB.LocRIB
+	rib6     *locRIB.LocRIB
+	mu       sync.Mutex
+	nextHops map[bnet.IP]*nextHop
+	clients  map[routingtable.NextHopTrackerClient]struct{}
+	observer *routingtable.RIBObserver
+}
+
+type nextHop struct {
+	refs       uint
+	resolution resolution
+}
+
+type resolution struct {
+	reachable bool
+	metric    uint32
+}
+
+// New creates a new next hop tracker resolving IPv4 next hops via rib4 and IPv6 next hops via rib6
+func New(rib4 *locRIB.LocRIB, rib6 *locRIB.LocRIB) *Tracker {
+	t := &Tracker{
+		rib4:     rib4,
+		rib6:     rib6,
+		nextHops: make(map[bnet.IP]*nextHop),
+		clients:  make(map[routingtable.NextHopTrackerClient]struct{}),
+	}
+	t.observer = routingtable.NewRIBObserver(t.update)
+
+	return t
+}
+
+// Start starts tracking changes of the RIBs
+func (t *Tracker) Start() {
+	t.observer.Start()
+
+	t.rib4.Register(t.observer)
+	t.rib6.Register(t.observer)
+}
+
+// Stop stops tracking changes of the RIBs
+func (t *Tracker) Stop() {
+	t.rib4.Unregister(t.observer)
+	t.rib6.Unregister(t.observer)
+	t.observer.Stop()
+}
+
+// Register registers a client to be notified about changes of the resolution of next hops
+func (t *Tracker) Register(client routingtable.NextHopTrackerClient) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.clients[client] = struct{}{}
+}
+
+// Unregister unregisters a client
+func (t *Tracker) Unregister(client routingtable.NextHopTrackerClient) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.clients, client)
+}
+
+// Track starts tracking a next hop and returns if it is reachable and the IGP metric to reach it
+func (t *Tracker) Track(nh *bnet.IP) (reachable bool, metric uint32) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	n, found := t.nextHops[*nh]
+	if !found {
+		n = &nextHop{
+			resolution: t.resolve(nh),
+		}
+		t.nextHops[*nh] = n
+	}
+
+	n.refs++
+	return n.resolution.reachable, n.resolution.metric
+}
+
+// Untrack stops tracking a next hop
+func (t *Tracker) Untrack(nh *bnet.IP) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	n, found := t.nextHops[*nh]
+	if !found {
+		return
+	}
+
+	n.refs--
+	if n.refs == 0 {
+		delete(t.nextHops, *nh)
+	}
+}
+
+// Resolve returns if a next hop is reachable and the IGP metric to reach it
+func (t *Tracker) Resolve(nh *bnet.IP) (reachable bool, metric uint32) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	n, found := t.nextHops[*nh]
+	if found {
+		return n.resolution.reachable, n.resolution.metric
+	}
+
+	r := t.resolve(nh)
+	return r.reachable, r.metric
+}
+
+// update resolves all tracked next hops and notifies the clients about changes
+func (t *Tracker) update() {
+	t.mu.Lock()
+	changed := make([]*bnet.IP, 0)
+	for addr, n := range t.nextHops {
+		addr := addr
+		r := t.resolve(&addr)
+		if r == n.resolution {
+			continue
+		}
+
+		n.resolution = r
+		changed = append(changed, &addr)
+	}
+
+	clients := make([]routingtable.NextHopTrackerClient, 0, len(t.clients))
+	for c := range t.clients {
+		clients = append(clients, c)
+	}
+	t.mu.Unlock()
+
+	if len(changed) == 0 {
+		return
+	}
+
+	for _, c := range clients {
+		c.NextHopUpdate(changed)
+	}
+}
+
+// resolve resolves nh via the most specific route that has not been learned via BGP
+func (t *Tracker) resolve(nh *bnet.IP) resolution {
+	rib := t.rib4
+	if !nh.IsIPv4() {
+		rib = t.rib6
+	}
+
+	addr, metric := Resolve(rib, nh, ResolveOptions{})
+	return resolution{
+		reachable: addr != nil,
+		metric:    metric,
+	}
+}
@@ -0,0 +1,223 @@
+package nexthop
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/bio-routing/bio-rd/routingtable/locRIB"
+	"github.com/stretchr/testify/assert"
+)
+
+func connectedPath(addr *bnet.IP) *route.Path {
+	return &route.Path{
+		Type: route.ConnectedPathType,
+		ConnectedPath: &route.ConnectedPath{
+			Interface: "eth0",
+			Address:   addr,
+		},
+	}
+}
+
+func fibPath(nh *bnet.IP, priority int) *route.Path {
+	return &route.Path{
+		Type: route.FIBPathType,
+		FIBPath: &route.FIBPath{
+			NextHop:  nh,
+			Priority: priority,
+		},
+	}
+}
+
+func staticPath(nh *bnet.IP, discard bool) *route.Path {
+	return &route.Path{
+		Type: route.StaticPathType,
+		StaticPath: &route.StaticPath{
+			NextHop: nh,
+			Discard: discard,
+		},
+	}
+}
+
+func isisPath(nh *bnet.IP, metric uint32) *route.Path {
+	return &route.Path{
+		Type: route.ISISPathType,
+		ISISPath: &route.ISISPath{
+			NextHop: nh,
+			Metric:  metric,
+			Level:   2,
+		},
+	}
+}
+
+func bgpPath(nh *bnet.IP) *route.Path {
+	p := route.NewBGPPath()
+	p.BGPPathA.NextHop = nh
+
+	return &route.Path{
+		Type:    route.BGPPathType,
+		BGPPath: p,
+	}
+}
+
+func TestResolve(t *testing.T) {
+	gw := bnet.IPv4FromOctets(192, 0, 2, 1).Ptr()
+	nh := bnet.IPv4FromOctets(203, 0, 113, 1).Ptr()
+	nh6 := bnet.IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 1).Ptr()
+
+	tests := []struct {
+		name              string
+		rib4              map[bnet.Prefix]*route.Path
+		rib6              map[bnet.Prefix]*route.Path
+		nh                *bnet.IP
+		expectedReachable bool
+		expectedMetric    uint32
+	}{
+		{
+			name: "no route",
+			nh:   nh,
+		},
+		{
+			name: "connected route",
+			rib4: map[bnet.Prefix]*route.Path{
+				bnet.NewPfx(bnet.IPv4FromOctets(203, 0, 113, 0), 24): connectedPath(bnet.IPv4FromOctets(203, 0, 113, 100).Ptr()),
+			},
+			nh:                nh,
+			expectedReachable: true,
+		},
+		{
+			name: "most specific kernel route",
+			rib4: map[bnet.Prefix]*route.Path{
+				bnet.NewPfx(bnet.IPv4FromOctets(203, 0, 113, 0), 24): fibPath(gw, 100),
+				bnet.NewPfx(bnet.IPv4FromOctets(203, 0, 113, 1), 32): fibPath(gw, 20),
+			},
+			nh:                nh,
+			expectedReachable: true,
+			expectedMetric:    20,
+		},
+		{
+			name: "BGP routes are skipped",
+			rib4: map[bnet.Prefix]*route.Path{
+				bnet.NewPfx(bnet.IPv4FromOctets(203, 0, 113, 0), 24): fibPath(gw, 100),
+				bnet.NewPfx(bnet.IPv4FromOctets(203, 0, 113, 1), 32): bgpPath(gw),
+			},
+			nh:                nh,
+			expectedReachable: true,
+			expectedMetric:    100,
+		},
+		{
+			name: "default route is skipped",
+			rib4: map[bnet.Prefix]*route.Path{
+				bnet.NewPfx(bnet.IPv4(0), 0): fibPath(gw, 100),
+			},
+			nh: nh,
+		},
+		{
+			name: "discard route",
+			rib4: map[bnet.Prefix]*route.Path{
+				bnet.NewPfx(bnet.IPv4FromOctets(203, 0, 113, 0), 24): fibPath(gw, 100),
+				bnet.NewPfx(bnet.IPv4FromOctets(203, 0, 113, 0), 25): staticPath(nil, true),
+			},
+			nh: nh,
+		},
+		{
+			name: "IS-IS route",
+			rib4: map[bnet.Prefix]*route.Path{
+				bnet.NewPfx(bnet.IPv4FromOctets(203, 0, 113, 1), 32): isisPath(gw, 30),
+			},
+			nh:                nh,
+			expectedReachable: true,
+			expectedMetric:    30,
+		},
+		{
+			name: "IPv6 static route",
+			rib6: map[bnet.Prefix]*route.Path{
+				bnet.NewPfx(bnet.IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 0), 32): staticPath(bnet.IPv6FromBlocks(0xfe80, 0, 0, 0, 0, 0, 0, 1).Ptr(), false),
+			},
+			nh:                nh6,
+			expectedReachable: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tr := New(locRIB.New("inet.0"), locRIB.New("inet6.0"))
+			for pfx, p := range test.rib4 {
+				pfx := pfx
+				tr.rib4.AddPath(&pfx, p)
+			}
+
+			for pfx, p := range test.rib6 {
+				pfx := pfx
+				tr.rib6.AddPath(&pfx, p)
+			}
+
+			reachable, metric := tr.Resolve(test.nh)
+			assert.Equal(t, test.expectedReachable, reachable)
+			assert.Equal(t, test.expectedMetric, metric)
+		})
+	}
+}
+
+type mockClient struct {
+	mu       sync.Mutex
+	nextHops []bnet.IP
+}
+
+func (m *mockClient) NextHopUpdate(nextHops []*bnet.IP) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, nh := range nextHops {
+		m.nextHops = append(m.nextHops, *nh)
+	}
+}
+
+func (m *mockClient) updates() []bnet.IP {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.nextHops
+}
+
+func TestTrackerTracksRIB(t *testing.T) {
+	pfx := bnet.NewPfx(bnet.IPv4FromOctets(203, 0, 113, 0), 24).Ptr()
+	nh := bnet.IPv4FromOctets(203, 0, 113, 1)
+	untracked := bnet.IPv4FromOctets(203, 0, 113, 2)
+
+	tr := New(locRIB.New("inet.0"), locRIB.New("inet6.0"))
+	c := &mockClient{}
+	tr.Register(c)
+	tr.Start()
+	defer tr.Stop()
+
+	reachable, _ := tr.Track(nh.Ptr())
+	assert.False(t, reachable)
+
+	tr.rib4.AddPath(pfx, fibPath(bnet.IPv4FromOctets(192, 0, 2, 1).Ptr(), 10))
+	assert.Eventually(t, func() bool {
+		return len(c.updates()) == 1
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, []bnet.IP{nh}, c.updates())
+
+	reachable, metric := tr.Resolve(nh.Ptr())
+	assert.True(t, reachable)
+	assert.Equal(t, uint32(10), metric)
+
+	// Next hops are not tracked anymore once they are not used
+	tr.Untrack(nh.Ptr())
+	assert.Len(t, tr.nextHops, 0)
+
+	reachable, _ = tr.Track(untracked.Ptr())
+	assert.True(t, reachable)
+	tr.rib4.RemovePath(pfx, fibPath(bnet.IPv4FromOctets(192, 0, 2, 1).Ptr(), 10))
+	assert.Eventually(t, func() bool {
+		return len(c.updates()) == 2
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, []bnet.IP{nh, untracked}, c.updates())
+
+	tr.Unregister(c)
+	assert.Len(t, tr.clients, 0)
+}
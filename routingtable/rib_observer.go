@@ -0,0 +1,86 @@
+package routingtable
+
+import (
+	"github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/route"
+)
+
+// RIBObserver is a RIB client calling an update function on any change of the RIBs it is registered to.
+// Updates are applied asynchronously as RIB clients must not modify the RIB they are notified by.
+// Changes notified while an update is pending are coalesced into a single call.
+type RIBObserver struct {
+	update  func()
+	trigger chan struct{}
+	done    chan struct{}
+}
+
+// NewRIBObserver creates a new RIB observer calling update on changes
+func NewRIBObserver(update func()) *RIBObserver {
+	return &RIBObserver{
+		update:  update,
+		trigger: make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
+}
+
+// Start starts applying updates
+func (o *RIBObserver) Start() {
+	go o.updater()
+}
+
+// Stop stops applying updates
+func (o *RIBObserver) Stop() {
+	close(o.done)
+}
+
+func (o *RIBObserver) updater() {
+	for {
+		select {
+		case <-o.done:
+			return
+		case <-o.trigger:
+			o.update()
+		}
+	}
+}
+
+// Trigger schedules an update
+func (o *RIBObserver) Trigger() {
+	select {
+	case o.trigger <- struct{}{}:
+	default:
+	}
+}
+
+// AddPath schedules an update
+func (o *RIBObserver) AddPath(*net.Prefix, *route.Path) error {
+	o.Trigger()
+	return nil
+}
+
+// AddPathInitialDump schedules an update
+func (o *RIBObserver) AddPathInitialDump(pfx *net.Prefix, p *route.Path) error {
+	return o.AddPath(pfx, p)
+}
+
+// EndOfRIB is here to fulfill an interface
+func (o *RIBObserver) EndOfRIB() {}
+
+// RemovePath schedules an update
+func (o *RIBObserver) RemovePath(*net.Prefix, *route.Path) bool {
+	o.Trigger()
+	return true
+}
+
+// ReplacePath schedules an update
+func (o *RIBObserver) ReplacePath(*net.Prefix, *route.Path, *route.Path) {
+	o.Trigger()
+}
+
+// RefreshRoute schedules an update
+func (o *RIBObserver) RefreshRoute(*net.Prefix, []*route.Path) {
+	o.Trigger()
+}
+
+// Dispose is here to fulfill an interface
+func (o *RIBObserver) Dispose() {}
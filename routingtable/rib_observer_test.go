@@ -0,0 +1,82 @@
+package routingtable
+
+import (
+	"testing"
+	"time"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRIBObserver(t *testing.T) {
+	pfx := bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 0), 8).Ptr()
+	p := &route.Path{}
+
+	tests := []struct {
+		name   string
+		notify func(o *RIBObserver)
+	}{
+		{
+			name: "AddPath",
+			notify: func(o *RIBObserver) {
+				o.AddPath(pfx, p)
+			},
+		},
+		{
+			name: "AddPathInitialDump",
+			notify: func(o *RIBObserver) {
+				o.AddPathInitialDump(pfx, p)
+			},
+		},
+		{
+			name: "RemovePath",
+			notify: func(o *RIBObserver) {
+				o.RemovePath(pfx, p)
+			},
+		},
+		{
+			name: "ReplacePath",
+			notify: func(o *RIBObserver) {
+				o.ReplacePath(pfx, p, p)
+			},
+		},
+		{
+			name: "RefreshRoute",
+			notify: func(o *RIBObserver) {
+				o.RefreshRoute(pfx, []*route.Path{p})
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			updates := make(chan struct{}, 10)
+			o := NewRIBObserver(func() {
+				updates <- struct{}{}
+			})
+			o.Start()
+			defer o.Stop()
+
+			test.notify(o)
+
+			select {
+			case <-updates:
+			case <-time.After(time.Second):
+				t.Fatal("update was not applied")
+			}
+		})
+	}
+}
+
+func TestRIBObserverCoalescesUpdates(t *testing.T) {
+	pfx := bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 0), 8).Ptr()
+	o := NewRIBObserver(func() {})
+
+	// Changes notified while an update is pending don't schedule another one
+	for i := 0; i < 5; i++ {
+		o.AddPath(pfx, &route.Path{})
+	}
+
+	assert.Len(t, o.trigger, 1)
+}
@@ -0,0 +1,62 @@
+package routingtable
+
+import (
+	"time"
+
+	"github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/route/api"
+)
+
+// RouteDampener keeps track of the stability of the routes received from a neighbor and suppresses flapping routes (RFC2439)
+type RouteDampener interface {
+	// Advertise accounts for an advertisement of a route and returns if the route is suppressed.
+	// changed indicates if the attributes of a previously advertised route changed.
+	Advertise(pfx *net.Prefix, pathID uint32, changed bool) (suppressed bool)
+
+	// Withdraw accounts for the withdrawal of a route
+	Withdraw(pfx *net.Prefix, pathID uint32)
+
+	// State returns the dampening state of a route or nil if the route did not flap recently
+	State(pfx *net.Prefix, pathID uint32) *DampeningState
+
+	// Clear drops the dampening state of all routes covered by pfx. The state of all routes is dropped if pfx is nil.
+	Clear(pfx *net.Prefix)
+	Register(client RouteDampenerClient)
+	Unregister(client RouteDampenerClient)
+}
+
+// RouteDampenerClient is notified about routes which are not suppressed anymore
+type RouteDampenerClient interface {
+	// DampeningReuse re-evaluates a route that has been suppressed
+	DampeningReuse(pfx *net.Prefix, pathID uint32)
+}
+
+// DampeningState represents the dampening state of a route
+type DampeningState struct {
+	// FigureOfMerit is the penalty accumulated by the flaps of the route, decayed to the current time
+	FigureOfMerit uint32
+
+	// Suppressed indicates if the route is suppressed
+	Suppressed bool
+
+	// ReuseTime is the time the route is reused if it does not flap anymore. Only set if suppressed.
+	ReuseTime time.Time
+}
+
+// ToProto converts a DampeningState to its protobuf representation
+func (s *DampeningState) ToProto() *api.DampeningState {
+	if s == nil {
+		return nil
+	}
+
+	a := &api.DampeningState{
+		FigureOfMerit: s.FigureOfMerit,
+		Suppressed:    s.Suppressed,
+	}
+
+	if s.Suppressed {
+		a.ReuseTime = uint64(s.ReuseTime.Unix())
+	}
+
+	return a
+}
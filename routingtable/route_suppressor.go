@@ -0,0 +1,19 @@
+package routingtable
+
+import (
+	"github.com/bio-routing/bio-rd/net"
+)
+
+// RouteSuppressor determines routes which must not be advertised to BGP neighbors, e.g. the more specifics of aggregates
+type RouteSuppressor interface {
+	// Suppressed checks if the route for pfx must not be advertised
+	Suppressed(pfx *net.Prefix) bool
+	Register(client RouteSuppressorClient)
+	Unregister(client RouteSuppressorClient)
+}
+
+// RouteSuppressorClient is notified about changes of the suppressed routes
+type RouteSuppressorClient interface {
+	// SuppressionUpdate re-evaluates all routes
+	SuppressionUpdate()
+}
@@ -0,0 +1,29 @@
+package routingtable
+
+import (
+	"github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
+)
+
+// RPKIValidator validates the origin AS of routes against validated ROA payloads (RFC6811)
+// and verifies AS paths against ASPAs (draft-ietf-sidrops-aspa-verification)
+type RPKIValidator interface {
+	// Validate returns the validation state (see route.RPKIValidation*) of a route. An origin ASN
+	// of 0 indicates an unknown origin, e.g. for AS paths ending with an AS_SET.
+	Validate(pfx *net.Prefix, originASN uint32) uint8
+
+	// VerifyASPath returns the verification state (see route.ASPAVerification*) of an AS path received
+	// from a neighbor. localRole is our role (see packet.PeerRoleRole*) on the session to the neighbor.
+	VerifyASPath(asPath *types.ASPath, neighborASN uint32, localRole uint8) uint8
+	Register(client RPKIValidatorClient)
+	Unregister(client RPKIValidatorClient)
+}
+
+// RPKIValidatorClient is notified about changes of validated ROA payloads and ASPAs
+type RPKIValidatorClient interface {
+	// RPKIUpdate re-validates all routes covered by any of the prefixes of changed VRPs
+	RPKIUpdate(pfxs []*net.Prefix)
+
+	// ASPAUpdate re-verifies the AS paths of all routes
+	ASPAUpdate()
+}
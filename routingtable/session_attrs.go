@@ -43,6 +43,23 @@ type SessionAttrs struct {
 	// AddPathTX indicates if AddPath send is active
 	AddPathTX bool
 
+	// RPKIValidator validates the origin of received routes. Routes are not validated if nil.
+	RPKIValidator RPKIValidator
+
+	// ASPARejectInvalid indicates if routes failing the ASPA verification are ineligible
+	ASPARejectInvalid bool
+
+	// NextHopTracker resolves the next hops of routes received via iBGP. Routes with unresolvable
+	// next hops are ineligible. Next hops are not resolved if nil.
+	NextHopTracker NextHopTracker
+
+	// RouteSuppressor determines routes not to be advertised to the neighbor, e.g. the more specifics of
+	// aggregates. No routes are suppressed if nil.
+	RouteSuppressor RouteSuppressor
+
+	// RouteDampener suppresses flapping routes received from the neighbor. Routes are not dampened if nil.
+	RouteDampener RouteDampener
+
 	// RouterIP indicates the IP address of the remote BMP peer (only for BMP)
 	RouterIP bnet.IP
 
@@ -143,7 +143,7 @@ func (rt *RoutingTable) GetLonger(pfx *net.Prefix) (res []*route.Route) {
 		return []*route.Route{}
 	}
 
-	return rt.root.get(pfx).dumpPfxs(res)
+	return rt.root.getLonger(pfx).dumpPfxs(res)
 }
 
 // Dump dumps all routes in table rt into a slice
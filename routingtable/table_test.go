@@ -381,7 +381,20 @@ func TestGetLonger(t *testing.T) {
 			},
 		},
 		{
-			name:     "Test 2: Empty root",
+			name: "Test 2: Search pfx not in table and dump more specifics",
+			routes: []*route.Route{
+				route.NewRoute(net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 16).Ptr(), nil),
+				route.NewRoute(net.NewPfx(net.IPv4FromOctets(10, 128, 0, 0), 16).Ptr(), nil),
+				route.NewRoute(net.NewPfx(net.IPv4FromOctets(11, 0, 0, 0), 16).Ptr(), nil),
+			},
+			needle: net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr(),
+			expected: []*route.Route{
+				route.NewRoute(net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 16).Ptr(), nil),
+				route.NewRoute(net.NewPfx(net.IPv4FromOctets(10, 128, 0, 0), 16).Ptr(), nil),
+			},
+		},
+		{
+			name:     "Test 3: Empty root",
 			routes:   nil,
 			needle:   net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr(),
 			expected: []*route.Route{},
@@ -114,6 +114,28 @@ func (n *node) get(pfx *net.Prefix) *node {
 	return n.h.get(pfx)
 }
 
+// getLonger returns the topmost node of the sub trie holding pfx and all of its more specifics
+func (n *node) getLonger(pfx *net.Prefix) *node {
+	if n == nil {
+		return nil
+	}
+
+	currentPfx := n.route.Prefix()
+	if currentPfx.Equal(pfx) || pfx.Contains(currentPfx) {
+		return n
+	}
+
+	if !currentPfx.Contains(pfx) {
+		return nil
+	}
+
+	b := pfx.Addr().BitAtPosition(n.route.Pfxlen() + 1)
+	if !b {
+		return n.l.getLonger(pfx)
+	}
+	return n.h.getLonger(pfx)
+}
+
 func (n *node) addPath(pfx *net.Prefix, p *route.Path) (*node, bool) {
 	currentPfx := n.route.Prefix()
 	if currentPfx.Equal(pfx) {
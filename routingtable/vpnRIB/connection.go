@@ -0,0 +1,341 @@
+package vpnRIB
+
+import (
+	"sync"
+
+	"github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/bio-routing/bio-rd/routingtable/locRIB"
+)
+
+// ConnectionConfig defines how paths are exchanged between a VRF and a VPN RIB
+type ConnectionConfig struct {
+	RouteDistinguisher uint64
+	Label              uint32
+	ImportRouteTargets []types.ExtendedCommunity
+	ExportRouteTargets []types.ExtendedCommunity
+}
+
+// Equal checks if two connection configs are equal
+func (c ConnectionConfig) Equal(x ConnectionConfig) bool {
+	return c.RouteDistinguisher == x.RouteDistinguisher &&
+		c.Label == x.Label &&
+		extendedCommunitiesEqual(c.ImportRouteTargets, x.ImportRouteTargets) &&
+		extendedCommunitiesEqual(c.ExportRouteTargets, x.ExportRouteTargets)
+}
+
+func extendedCommunitiesEqual(a []types.ExtendedCommunity, b []types.ExtendedCommunity) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Connection exchanges paths between the unicast RIB of a VRF and a VPN RIB (RFC4364 Sect. 4.3).
+// Paths of the VRF are exported with the VRFs route distinguisher, label and export route targets.
+// VPN paths carrying at least one of the import route targets are imported into the VRF.
+// Paths exported by any local VRF are never imported again.
+type Connection struct {
+	rib      *locRIB.LocRIB
+	vpn      *VPNRIB
+	cfg      ConnectionConfig
+	exporter *exporter
+	importer *importer
+}
+
+// Connect connects the unicast RIB of a VRF with a VPN RIB
+func Connect(rib *locRIB.LocRIB, vpn *VPNRIB, cfg ConnectionConfig) *Connection {
+	c := &Connection{
+		rib: rib,
+		vpn: vpn,
+		cfg: cfg,
+	}
+
+	c.importer = newImporter(c)
+	c.exporter = &exporter{
+		conn: c,
+	}
+
+	vpn.Register(c.importer)
+	rib.Register(c.exporter)
+
+	return c
+}
+
+// Disconnect stops the exchange of paths and removes all exchanged paths
+func (c *Connection) Disconnect() {
+	c.rib.Unregister(c.exporter)
+	c.vpn.Unregister(c.importer)
+	c.importer.stop()
+
+	for _, r := range c.vpn.Dump() {
+		for _, p := range r.Paths() {
+			if p.BGPPath.RouteDistinguisher == c.cfg.RouteDistinguisher && p.BGPPath.LocalVPNExport {
+				c.vpn.RemovePath(r.Prefix(), p)
+			}
+		}
+	}
+
+	for pfx, paths := range c.importer.imported {
+		for _, p := range paths {
+			c.rib.RemovePath(pfx.Ptr(), p)
+		}
+	}
+}
+
+func (c *Connection) exportPath(p *route.Path) *route.Path {
+	var bgpPath *route.BGPPath
+	if p.BGPPath != nil {
+		bgpPath = p.BGPPath.Copy()
+	} else {
+		bgpPath = route.NewBGPPath()
+		bgpPath.BGPPathA = bgpPath.BGPPathA.Copy()
+		if p.StaticPath != nil {
+			bgpPath.BGPPathA.NextHop = p.StaticPath.NextHop
+		}
+	}
+
+	// Locally exported paths are not learned from any peer and therefore have no source address
+	bgpPath.BGPPathA.Source = net.IPv4(0).Dedup()
+	bgpPath.BGPPathA.EBGP = false
+	bgpPath.LocalVPNExport = true
+	bgpPath.RouteDistinguisher = c.cfg.RouteDistinguisher
+	bgpPath.LabelStack = []uint32{c.cfg.Label}
+
+	ecs := make(types.ExtendedCommunities, 0)
+	if bgpPath.ExtendedCommunities != nil {
+		ecs = append(ecs, *bgpPath.ExtendedCommunities...)
+	}
+
+	for _, rt := range c.cfg.ExportRouteTargets {
+		if !containsExtendedCommunity(ecs, rt) {
+			ecs = append(ecs, rt)
+		}
+	}
+	bgpPath.ExtendedCommunities = &ecs
+
+	return &route.Path{
+		Type:    route.BGPPathType,
+		BGPPath: bgpPath,
+	}
+}
+
+func (c *Connection) exports(p *route.Path) bool {
+	// Paths imported from a VPN RIB are not exported again
+	if p.BGPPath != nil && p.BGPPath.RouteDistinguisher != 0 {
+		return false
+	}
+
+	return len(c.cfg.ExportRouteTargets) > 0
+}
+
+func (c *Connection) imports(p *route.Path) bool {
+	if p.BGPPath == nil || p.BGPPath.LocalVPNExport || p.BGPPath.ExtendedCommunities == nil {
+		return false
+	}
+
+	for _, rt := range c.cfg.ImportRouteTargets {
+		if containsExtendedCommunity(*p.BGPPath.ExtendedCommunities, rt) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsExtendedCommunity(ecs types.ExtendedCommunities, ec types.ExtendedCommunity) bool {
+	for _, x := range ecs {
+		if x == ec {
+			return true
+		}
+	}
+
+	return false
+}
+
+// exporter exports the paths of the VRFs unicast RIB into the VPN RIB
+type exporter struct {
+	conn *Connection
+}
+
+func (e *exporter) AddPathInitialDump(pfx *net.Prefix, p *route.Path) error {
+	return e.AddPath(pfx, p)
+}
+
+func (e *exporter) AddPath(pfx *net.Prefix, p *route.Path) error {
+	if !e.conn.exports(p) {
+		return nil
+	}
+
+	return e.conn.vpn.AddPath(pfx, e.conn.exportPath(p))
+}
+
+func (e *exporter) RemovePath(pfx *net.Prefix, p *route.Path) bool {
+	if !e.conn.exports(p) {
+		return false
+	}
+
+	return e.conn.vpn.RemovePath(pfx, e.conn.exportPath(p))
+}
+
+func (e *exporter) ReplacePath(pfx *net.Prefix, oldPath *route.Path, newPath *route.Path) {
+	e.RemovePath(pfx, oldPath)
+	e.AddPath(pfx, newPath)
+}
+
+func (e *exporter) EndOfRIB() {}
+
+func (e *exporter) RefreshRoute(*net.Prefix, []*route.Path) {}
+
+func (e *exporter) Dispose() {}
+
+type importOp struct {
+	pfx    *net.Prefix
+	path   *route.Path
+	remove bool
+}
+
+// importer imports paths of the VPN RIB into the VRFs unicast RIB. Paths are imported asynchronously
+// as the VRFs RIB may be locked while exporting a path into the VPN RIB at the same time.
+type importer struct {
+	conn   *Connection
+	queue  []importOp
+	mu     sync.Mutex
+	wakeup chan struct{}
+	done   chan struct{}
+	wg     sync.WaitGroup
+
+	// imported holds the paths imported by this connection. It's only accessed by the import routine.
+	imported map[net.Prefix][]*route.Path
+}
+
+func newImporter(c *Connection) *importer {
+	i := &importer{
+		conn:     c,
+		queue:    make([]importOp, 0),
+		wakeup:   make(chan struct{}, 1),
+		done:     make(chan struct{}),
+		imported: make(map[net.Prefix][]*route.Path),
+	}
+
+	i.wg.Add(1)
+	go i.run()
+
+	return i
+}
+
+func (i *importer) run() {
+	defer i.wg.Done()
+
+	for {
+		select {
+		case <-i.done:
+			return
+		case <-i.wakeup:
+			i.processQueue()
+		}
+	}
+}
+
+func (i *importer) processQueue() {
+	i.mu.Lock()
+	ops := i.queue
+	i.queue = make([]importOp, 0)
+	i.mu.Unlock()
+
+	for _, op := range ops {
+		if op.remove {
+			i.conn.rib.RemovePath(op.pfx, op.path)
+			i.forget(op.pfx, op.path)
+			continue
+		}
+
+		if i.conn.rib.AddPath(op.pfx, op.path) == nil {
+			i.imported[*op.pfx] = append(i.imported[*op.pfx], op.path)
+		}
+	}
+}
+
+func (i *importer) forget(pfx *net.Prefix, p *route.Path) {
+	paths := i.imported[*pfx]
+	for j := range paths {
+		if !paths[j].Equal(p) {
+			continue
+		}
+
+		paths = append(paths[:j], paths[j+1:]...)
+		if len(paths) == 0 {
+			delete(i.imported, *pfx)
+			return
+		}
+
+		i.imported[*pfx] = paths
+		return
+	}
+}
+
+func (i *importer) stop() {
+	close(i.done)
+	i.wg.Wait()
+}
+
+func (i *importer) enqueue(op importOp) {
+	i.mu.Lock()
+	i.queue = append(i.queue, op)
+	i.mu.Unlock()
+
+	select {
+	case i.wakeup <- struct{}{}:
+	default:
+	}
+}
+
+func (i *importer) AddPathInitialDump(pfx *net.Prefix, p *route.Path) error {
+	return i.AddPath(pfx, p)
+}
+
+func (i *importer) AddPath(pfx *net.Prefix, p *route.Path) error {
+	if !i.conn.imports(p) {
+		return nil
+	}
+
+	i.enqueue(importOp{
+		pfx:  pfx,
+		path: p,
+	})
+
+	return nil
+}
+
+func (i *importer) RemovePath(pfx *net.Prefix, p *route.Path) bool {
+	if !i.conn.imports(p) {
+		return false
+	}
+
+	i.enqueue(importOp{
+		pfx:    pfx,
+		path:   p,
+		remove: true,
+	})
+
+	return true
+}
+
+func (i *importer) ReplacePath(pfx *net.Prefix, oldPath *route.Path, newPath *route.Path) {
+	i.RemovePath(pfx, oldPath)
+	i.AddPath(pfx, newPath)
+}
+
+func (i *importer) EndOfRIB() {}
+
+func (i *importer) RefreshRoute(*net.Prefix, []*route.Path) {}
+
+func (i *importer) Dispose() {}
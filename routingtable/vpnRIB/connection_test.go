@@ -0,0 +1,152 @@
+package vpnRIB
+
+import (
+	"testing"
+	"time"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/bio-routing/bio-rd/routingtable/locRIB"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConnectionExport(t *testing.T) {
+	pfx := bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 0), 24).Ptr()
+	rt := types.NewRouteTarget(65000, false, 100)
+
+	rib := locRIB.New("inet.0")
+	vpn := New("bgp.l3vpn.0")
+	rib.AddPath(pfx, &route.Path{
+		Type: route.StaticPathType,
+		StaticPath: &route.StaticPath{
+			NextHop: bnet.IPv4FromOctets(192, 0, 2, 1).Ptr(),
+		},
+	})
+
+	c := Connect(rib, vpn, ConnectionConfig{
+		RouteDistinguisher: 65000<<32 + 1,
+		Label:              1000,
+		ExportRouteTargets: []types.ExtendedCommunity{rt},
+	})
+
+	routes := vpn.Dump()
+	if assert.Len(t, routes, 1) {
+		p := routes[0].BestPath()
+		assert.Equal(t, uint64(65000<<32+1), p.BGPPath.RouteDistinguisher)
+		assert.Equal(t, []uint32{1000}, p.BGPPath.LabelStack)
+		assert.Equal(t, types.ExtendedCommunities{rt}, *p.BGPPath.ExtendedCommunities)
+		assert.Equal(t, bnet.IPv4FromOctets(192, 0, 2, 1), *p.BGPPath.BGPPathA.NextHop)
+		assert.True(t, p.BGPPath.LocalVPNExport)
+	}
+
+	rib.RemovePath(pfx, &route.Path{
+		Type: route.StaticPathType,
+		StaticPath: &route.StaticPath{
+			NextHop: bnet.IPv4FromOctets(192, 0, 2, 1).Ptr(),
+		},
+	})
+	assert.Equal(t, int64(0), vpn.RouteCount())
+
+	c.Disconnect()
+}
+
+func TestConnectionImport(t *testing.T) {
+	pfx := bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 0), 24).Ptr()
+	rt := types.NewRouteTarget(65000, false, 100)
+
+	tests := []struct {
+		name     string
+		path     *route.Path
+		imported bool
+	}{
+		{
+			name:     "Matching route target",
+			path:     vpnPathWithRouteTargets(65000<<32+2, 1, rt),
+			imported: true,
+		},
+		{
+			name:     "Route target not matching",
+			path:     vpnPathWithRouteTargets(65000<<32+2, 1, types.NewRouteTarget(65000, false, 200)),
+			imported: false,
+		},
+		{
+			name:     "Locally exported path",
+			path:     localVPNPathWithRouteTargets(65000<<32+2, rt),
+			imported: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rib := locRIB.New("inet.0")
+			vpn := New("bgp.l3vpn.0")
+			c := Connect(rib, vpn, ConnectionConfig{
+				RouteDistinguisher: 65000<<32 + 1,
+				Label:              1000,
+				ImportRouteTargets: []types.ExtendedCommunity{rt},
+			})
+
+			vpn.AddPath(pfx, test.path)
+			if test.imported {
+				assert.Eventually(t, func() bool {
+					return rib.RouteCount() == 1
+				}, time.Second, time.Millisecond)
+			}
+
+			// a path never imported must not show up late
+			time.Sleep(10 * time.Millisecond)
+			assert.Equal(t, test.imported, rib.RouteCount() == 1)
+
+			c.Disconnect()
+			assert.Equal(t, int64(0), rib.RouteCount())
+		})
+	}
+}
+
+func TestConnectionDisconnectRemovesOwnPathsOnly(t *testing.T) {
+	pfxA := bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 0), 24).Ptr()
+	pfxB := bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 1, 0), 24).Ptr()
+	rtA := types.NewRouteTarget(65000, false, 100)
+	rtB := types.NewRouteTarget(65000, false, 200)
+
+	rib := locRIB.New("inet.0")
+	vpnA := New("bgp.l3vpn.0")
+	vpnB := New("bgp.l3vpn.1")
+	a := Connect(rib, vpnA, ConnectionConfig{
+		RouteDistinguisher: 65000<<32 + 1,
+		ImportRouteTargets: []types.ExtendedCommunity{rtA},
+	})
+	b := Connect(rib, vpnB, ConnectionConfig{
+		RouteDistinguisher: 65000<<32 + 1,
+		ImportRouteTargets: []types.ExtendedCommunity{rtB},
+	})
+
+	vpnA.AddPath(pfxA, vpnPathWithRouteTargets(65000<<32+2, 1, rtA))
+	vpnB.AddPath(pfxB, vpnPathWithRouteTargets(65000<<32+2, 1, rtB))
+	assert.Eventually(t, func() bool {
+		return rib.RouteCount() == 2
+	}, time.Second, time.Millisecond)
+
+	a.Disconnect()
+	assert.Nil(t, rib.Get(pfxA))
+	assert.NotNil(t, rib.Get(pfxB))
+
+	b.Disconnect()
+	assert.Equal(t, int64(0), rib.RouteCount())
+}
+
+func vpnPathWithRouteTargets(rd uint64, source uint32, rts ...types.ExtendedCommunity) *route.Path {
+	p := vpnPath(rd, 100, source)
+	ecs := types.ExtendedCommunities(rts)
+	p.BGPPath.ExtendedCommunities = &ecs
+
+	return p
+}
+
+func localVPNPathWithRouteTargets(rd uint64, rts ...types.ExtendedCommunity) *route.Path {
+	p := vpnPathWithRouteTargets(rd, 0, rts...)
+	p.BGPPath.LocalVPNExport = true
+
+	return p
+}
@@ -0,0 +1,231 @@
+package vpnRIB
+
+import (
+	"sync"
+
+	"github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/bio-routing/bio-rd/routingtable"
+	"github.com/bio-routing/bio-rd/routingtable/filter"
+	"github.com/bio-routing/bio-rd/routingtable/locRIB"
+)
+
+// VPNRIB represents a routing information base for VPN address families (RFC4364).
+// Path selection is done per route distinguisher so paths of different VPNs never compete with each other.
+type VPNRIB struct {
+	name    string
+	ribs    map[uint64]*locRIB.LocRIB
+	clients map[routingtable.RouteTableClient]*client
+	mu      sync.RWMutex
+}
+
+// client wraps a client registered to the VPNRIB. As the client is registered to the LocRIB of every
+// route distinguisher, end of RIB and dispose signals are sent by the VPNRIB itself.
+type client struct {
+	routingtable.RouteTableClient
+	opts routingtable.ClientOptions
+}
+
+func (c *client) EndOfRIB() {}
+
+func (c *client) Dispose() {}
+
+// New creates a new VPN RIB
+func New(name string) *VPNRIB {
+	return &VPNRIB{
+		name:    name,
+		ribs:    make(map[uint64]*locRIB.LocRIB),
+		clients: make(map[routingtable.RouteTableClient]*client),
+	}
+}
+
+// Name gets the name of the VPNRIB
+func (v *VPNRIB) Name() string {
+	return v.name
+}
+
+func routeDistinguisher(p *route.Path) uint64 {
+	if p.BGPPath == nil {
+		return 0
+	}
+
+	return p.BGPPath.RouteDistinguisher
+}
+
+func (v *VPNRIB) rib(rd uint64) *locRIB.LocRIB {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	return v.ribs[rd]
+}
+
+func (v *VPNRIB) ribOrCreate(rd uint64) *locRIB.LocRIB {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	rib, exists := v.ribs[rd]
+	if exists {
+		return rib
+	}
+
+	rib = locRIB.New(v.name)
+	for _, c := range v.clients {
+		rib.RegisterWithOptions(c, c.opts)
+	}
+
+	v.ribs[rd] = rib
+	return rib
+}
+
+func (v *VPNRIB) ribList() []*locRIB.LocRIB {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	ret := make([]*locRIB.LocRIB, 0, len(v.ribs))
+	for _, rib := range v.ribs {
+		ret = append(ret, rib)
+	}
+
+	return ret
+}
+
+// AddPathInitialDump adds a path during the initial dump
+func (v *VPNRIB) AddPathInitialDump(pfx *net.Prefix, p *route.Path) error {
+	return v.AddPath(pfx, p)
+}
+
+// AddPath adds a path to the RIB of the paths route distinguisher
+func (v *VPNRIB) AddPath(pfx *net.Prefix, p *route.Path) error {
+	return v.ribOrCreate(routeDistinguisher(p)).AddPath(pfx, p)
+}
+
+// RemovePath removes a path from the RIB of the paths route distinguisher
+func (v *VPNRIB) RemovePath(pfx *net.Prefix, p *route.Path) bool {
+	rib := v.rib(routeDistinguisher(p))
+	if rib == nil {
+		return false
+	}
+
+	return rib.RemovePath(pfx, p)
+}
+
+// ReplacePath replaces a path
+func (v *VPNRIB) ReplacePath(pfx *net.Prefix, oldPath *route.Path, newPath *route.Path) {
+	if routeDistinguisher(oldPath) == routeDistinguisher(newPath) {
+		v.ribOrCreate(routeDistinguisher(newPath)).ReplacePath(pfx, oldPath, newPath)
+		return
+	}
+
+	v.RemovePath(pfx, oldPath)
+	v.AddPath(pfx, newPath)
+}
+
+// EndOfRIB is here to fulfill an interface
+func (v *VPNRIB) EndOfRIB() {}
+
+// RefreshRoute is here to fulfill an interface
+func (v *VPNRIB) RefreshRoute(*net.Prefix, []*route.Path) {}
+
+// ReplaceFilterChain is here to fulfill an interface
+func (v *VPNRIB) ReplaceFilterChain(filter.Chain) {}
+
+// Register registers a client for updates
+func (v *VPNRIB) Register(c routingtable.RouteTableClient) {
+	v.RegisterWithOptions(c, routingtable.ClientOptions{BestOnly: true})
+}
+
+// RegisterWithOptions registers a client with options for updates
+func (v *VPNRIB) RegisterWithOptions(c routingtable.RouteTableClient, opt routingtable.ClientOptions) {
+	w := &client{
+		RouteTableClient: c,
+		opts:             opt,
+	}
+
+	v.mu.Lock()
+	v.clients[c] = w
+	ribs := make([]*locRIB.LocRIB, 0, len(v.ribs))
+	for _, rib := range v.ribs {
+		ribs = append(ribs, rib)
+	}
+	v.mu.Unlock()
+
+	for _, rib := range ribs {
+		rib.RegisterWithOptions(w, opt)
+	}
+
+	c.EndOfRIB()
+}
+
+// Unregister unregisters a client
+func (v *VPNRIB) Unregister(c routingtable.RouteTableClient) {
+	v.mu.Lock()
+	w, found := v.clients[c]
+	delete(v.clients, c)
+	v.mu.Unlock()
+
+	if !found {
+		return
+	}
+
+	for _, rib := range v.ribList() {
+		rib.Unregister(w)
+	}
+}
+
+// RefreshClient re-sends all propagated paths to a certain client
+func (v *VPNRIB) RefreshClient(c routingtable.RouteTableClient) {
+	v.mu.RLock()
+	w, found := v.clients[c]
+	v.mu.RUnlock()
+
+	if !found {
+		return
+	}
+
+	for _, rib := range v.ribList() {
+		rib.RefreshClient(w)
+	}
+}
+
+// ClientCount gets the number of registered clients
+func (v *VPNRIB) ClientCount() uint64 {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	return uint64(len(v.clients))
+}
+
+// RouteCount returns the number of stored routes of all route distinguishers
+func (v *VPNRIB) RouteCount() int64 {
+	n := int64(0)
+	for _, rib := range v.ribList() {
+		n += rib.RouteCount()
+	}
+
+	return n
+}
+
+// Dump dumps the routes of all route distinguishers
+func (v *VPNRIB) Dump() []*route.Route {
+	ret := make([]*route.Route, 0)
+	for _, rib := range v.ribList() {
+		ret = append(ret, rib.Dump()...)
+	}
+
+	return ret
+}
+
+// Dispose tells all clients that this VPNRIB is not to be used anymore
+func (v *VPNRIB) Dispose() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	for c := range v.clients {
+		c.Dispose()
+		delete(v.clients, c)
+	}
+
+	for rd := range v.ribs {
+		delete(v.ribs, rd)
+	}
+}
@@ -0,0 +1,181 @@
+package vpnRIB
+
+import (
+	"testing"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingClient struct {
+	paths     map[bnet.Prefix][]*route.Path
+	endOfRIBs int
+	disposed  bool
+	refreshed int
+}
+
+func newRecordingClient() *recordingClient {
+	return &recordingClient{
+		paths: make(map[bnet.Prefix][]*route.Path),
+	}
+}
+
+func (r *recordingClient) AddPath(pfx *bnet.Prefix, p *route.Path) error {
+	r.paths[*pfx] = append(r.paths[*pfx], p)
+	return nil
+}
+
+func (r *recordingClient) AddPathInitialDump(pfx *bnet.Prefix, p *route.Path) error {
+	return r.AddPath(pfx, p)
+}
+
+func (r *recordingClient) EndOfRIB() {
+	r.endOfRIBs++
+}
+
+func (r *recordingClient) RemovePath(pfx *bnet.Prefix, p *route.Path) bool {
+	for i, x := range r.paths[*pfx] {
+		if x.Equal(p) {
+			r.paths[*pfx] = append(r.paths[*pfx][:i], r.paths[*pfx][i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *recordingClient) ReplacePath(*bnet.Prefix, *route.Path, *route.Path) {}
+
+func (r *recordingClient) RefreshRoute(*bnet.Prefix, []*route.Path) {
+	r.refreshed++
+}
+
+func (r *recordingClient) Dispose() {
+	r.disposed = true
+}
+
+func vpnPath(rd uint64, localPref uint32, source uint32) *route.Path {
+	return &route.Path{
+		Type: route.BGPPathType,
+		BGPPath: &route.BGPPath{
+			RouteDistinguisher: rd,
+			LabelStack:         []uint32{100},
+			ASPath:             route.NewBGPPath().ASPath,
+			BGPPathA: &route.BGPPathA{
+				LocalPref: localPref,
+				NextHop:   bnet.IPv4(source).Ptr(),
+				Source:    bnet.IPv4(source).Ptr(),
+			},
+		},
+	}
+}
+
+func TestVPNRIBPathSelection(t *testing.T) {
+	pfx := bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 0), 24).Ptr()
+
+	tests := []struct {
+		name       string
+		add        []*route.Path
+		remove     []*route.Path
+		expected   []*route.Path
+		routeCount int64
+	}{
+		{
+			name: "Same prefix in different VPNs",
+			add: []*route.Path{
+				vpnPath(65000<<32+1, 100, 1),
+				vpnPath(65000<<32+2, 100, 2),
+			},
+			expected: []*route.Path{
+				vpnPath(65000<<32+1, 100, 1),
+				vpnPath(65000<<32+2, 100, 2),
+			},
+			routeCount: 2,
+		},
+		{
+			name: "Best path per route distinguisher",
+			add: []*route.Path{
+				vpnPath(65000<<32+1, 100, 1),
+				vpnPath(65000<<32+1, 200, 2),
+				vpnPath(65000<<32+2, 100, 3),
+			},
+			expected: []*route.Path{
+				vpnPath(65000<<32+1, 200, 2),
+				vpnPath(65000<<32+2, 100, 3),
+			},
+			routeCount: 2,
+		},
+		{
+			name: "Withdraw in one VPN",
+			add: []*route.Path{
+				vpnPath(65000<<32+1, 100, 1),
+				vpnPath(65000<<32+2, 100, 2),
+			},
+			remove: []*route.Path{
+				vpnPath(65000<<32+1, 100, 1),
+			},
+			expected: []*route.Path{
+				vpnPath(65000<<32+2, 100, 2),
+			},
+			routeCount: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			v := New("bgp.l3vpn.0")
+			c := newRecordingClient()
+			v.Register(c)
+
+			for _, p := range test.add {
+				v.AddPath(pfx, p)
+			}
+
+			for _, p := range test.remove {
+				v.RemovePath(pfx, p)
+			}
+
+			assert.ElementsMatch(t, test.expected, c.paths[*pfx])
+			assert.Equal(t, test.routeCount, v.RouteCount())
+		})
+	}
+}
+
+func TestVPNRIBRegister(t *testing.T) {
+	pfx := bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 0), 24).Ptr()
+
+	v := New("bgp.l3vpn.0")
+	v.AddPath(pfx, vpnPath(65000<<32+1, 100, 1))
+	v.AddPath(pfx, vpnPath(65000<<32+2, 100, 2))
+
+	c := newRecordingClient()
+	v.Register(c)
+
+	assert.ElementsMatch(t, []*route.Path{
+		vpnPath(65000<<32+1, 100, 1),
+		vpnPath(65000<<32+2, 100, 2),
+	}, c.paths[*pfx])
+	assert.Equal(t, 1, c.endOfRIBs, "End-of-RIB must be signaled once for all route distinguishers")
+	assert.Equal(t, uint64(1), v.ClientCount())
+
+	v.RefreshClient(c)
+	assert.Equal(t, 2, c.refreshed)
+
+	v.Unregister(c)
+	v.AddPath(pfx, vpnPath(65000<<32+3, 100, 3))
+	assert.Len(t, c.paths[*pfx], 2)
+	assert.Equal(t, uint64(0), v.ClientCount())
+}
+
+func TestVPNRIBDispose(t *testing.T) {
+	v := New("bgp.l3vpn.0")
+	c := newRecordingClient()
+	v.Register(c)
+	v.AddPath(bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 0), 24).Ptr(), vpnPath(65000<<32+1, 100, 1))
+
+	v.Dispose()
+
+	assert.True(t, c.disposed)
+	assert.Equal(t, int64(0), v.RouteCount())
+}
@@ -6,16 +6,21 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
+	"github.com/bio-routing/bio-rd/routingtable/flowspecRIB"
 	"github.com/bio-routing/bio-rd/routingtable/locRIB"
+	"github.com/bio-routing/bio-rd/routingtable/vpnRIB"
 	"github.com/bio-routing/bio-rd/util/refcounter"
 )
 
 const DefaultVRFName = "main"
 
 const (
-	afiIPv4     = 1
-	afiIPv6     = 2
-	safiUnicast = 1
+	afiIPv4      = 1
+	afiIPv6      = 2
+	safiUnicast  = 1
+	safiMPLSVPN  = 128
+	safiFlowSpec = 133
 )
 
 type addressFamily struct {
@@ -30,6 +35,11 @@ type VRF struct {
 	ribs                   map[addressFamily]*locRIB.LocRIB
 	mu                     sync.Mutex
 	ribNames               map[string]*locRIB.LocRIB
+	vpnRIBs                map[addressFamily]*vpnRIB.VPNRIB
+	vpnConnections         []*vpnRIB.Connection
+	vpnVRF                 *VRF
+	vpnConfig              vpnRIB.ConnectionConfig
+	flowSpecRIBs           map[addressFamily]*flowspecRIB.FlowSpecRIB
 	contributingASNs       *refcounter.RefcounterUint32
 	contributingClusterIDs *refcounter.RefcounterUint32
 }
@@ -39,6 +49,8 @@ func New(name string, rd uint64) (*VRF, error) {
 	v := NewUntrackedVRF(name, rd)
 	v.CreateIPv4UnicastLocRIB("inet.0")
 	v.CreateIPv6UnicastLocRIB("inet6.0")
+	v.CreateIPv4FlowSpecRIB("inetflow.0")
+	v.CreateIPv6FlowSpecRIB("inet6flow.0")
 
 	err := globalRegistry.registerVRF(v)
 	if err != nil {
@@ -54,6 +66,8 @@ func NewUntrackedVRF(name string, rd uint64) *VRF {
 		routeDistinguisher:     rd,
 		ribs:                   make(map[addressFamily]*locRIB.LocRIB),
 		ribNames:               make(map[string]*locRIB.LocRIB),
+		vpnRIBs:                make(map[addressFamily]*vpnRIB.VPNRIB),
+		flowSpecRIBs:           make(map[addressFamily]*flowspecRIB.FlowSpecRIB),
 		contributingASNs:       refcounter.NewRefCounterUint32(),
 		contributingClusterIDs: refcounter.NewRefCounterUint32(),
 	}
@@ -64,8 +78,7 @@ func (v *VRF) createLocRIB(name string, family addressFamily) (*locRIB.LocRIB, e
 	v.mu.Lock()
 	defer v.mu.Unlock()
 
-	_, found := v.ribNames[name]
-	if found {
+	if v.tableExists(name) {
 		return nil, fmt.Errorf("a table with the name '%s' already exists in VRF '%s'", name, v.name)
 	}
 
@@ -76,6 +89,54 @@ func (v *VRF) createLocRIB(name string, family addressFamily) (*locRIB.LocRIB, e
 	return rib, nil
 }
 
+func (v *VRF) tableExists(name string) bool {
+	if _, found := v.ribNames[name]; found {
+		return true
+	}
+
+	for _, rib := range v.vpnRIBs {
+		if rib.Name() == name {
+			return true
+		}
+	}
+
+	for _, rib := range v.flowSpecRIBs {
+		if rib.Name() == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (v *VRF) createVPNRIB(name string, family addressFamily) (*vpnRIB.VPNRIB, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.tableExists(name) {
+		return nil, fmt.Errorf("a table with the name '%s' already exists in VRF '%s'", name, v.name)
+	}
+
+	rib := vpnRIB.New(name)
+	v.vpnRIBs[family] = rib
+
+	return rib, nil
+}
+
+func (v *VRF) createFlowSpecRIB(name string, family addressFamily) (*flowspecRIB.FlowSpecRIB, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.tableExists(name) {
+		return nil, fmt.Errorf("a table with the name '%s' already exists in VRF '%s'", name, v.name)
+	}
+
+	rib := flowspecRIB.New(name)
+	v.flowSpecRIBs[family] = rib
+
+	return rib, nil
+}
+
 // CreateIPv4UnicastLocRIB creates a LocRIB for the IPv4 unicast address family
 func (v *VRF) CreateIPv4UnicastLocRIB(name string) (*locRIB.LocRIB, error) {
 	return v.createLocRIB(name, addressFamily{afi: afiIPv4, safi: safiUnicast})
@@ -96,6 +157,113 @@ func (v *VRF) IPv6UnicastRIB() *locRIB.LocRIB {
 	return v.ribForAddressFamily(addressFamily{afi: afiIPv6, safi: safiUnicast})
 }
 
+// CreateIPv4VPNRIB creates a VPN RIB for the VPNv4 address family
+func (v *VRF) CreateIPv4VPNRIB(name string) (*vpnRIB.VPNRIB, error) {
+	return v.createVPNRIB(name, addressFamily{afi: afiIPv4, safi: safiMPLSVPN})
+}
+
+// CreateIPv6VPNRIB creates a VPN RIB for the VPNv6 address family
+func (v *VRF) CreateIPv6VPNRIB(name string) (*vpnRIB.VPNRIB, error) {
+	return v.createVPNRIB(name, addressFamily{afi: afiIPv6, safi: safiMPLSVPN})
+}
+
+// IPv4VPNRIB returns the VPN RIB for the VPNv4 address family
+func (v *VRF) IPv4VPNRIB() *vpnRIB.VPNRIB {
+	return v.vpnRIBForAddressFamily(addressFamily{afi: afiIPv4, safi: safiMPLSVPN})
+}
+
+// IPv6VPNRIB returns the VPN RIB for the VPNv6 address family
+func (v *VRF) IPv6VPNRIB() *vpnRIB.VPNRIB {
+	return v.vpnRIBForAddressFamily(addressFamily{afi: afiIPv6, safi: safiMPLSVPN})
+}
+
+func (v *VRF) vpnRIBForAddressFamily(family addressFamily) *vpnRIB.VPNRIB {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	return v.vpnRIBs[family]
+}
+
+// CreateIPv4FlowSpecRIB creates a FlowSpec RIB for the IPv4 FlowSpec address family
+func (v *VRF) CreateIPv4FlowSpecRIB(name string) (*flowspecRIB.FlowSpecRIB, error) {
+	return v.createFlowSpecRIB(name, addressFamily{afi: afiIPv4, safi: safiFlowSpec})
+}
+
+// CreateIPv6FlowSpecRIB creates a FlowSpec RIB for the IPv6 FlowSpec address family
+func (v *VRF) CreateIPv6FlowSpecRIB(name string) (*flowspecRIB.FlowSpecRIB, error) {
+	return v.createFlowSpecRIB(name, addressFamily{afi: afiIPv6, safi: safiFlowSpec})
+}
+
+// IPv4FlowSpecRIB returns the FlowSpec RIB for the IPv4 FlowSpec address family
+func (v *VRF) IPv4FlowSpecRIB() *flowspecRIB.FlowSpecRIB {
+	return v.flowSpecRIBForAddressFamily(addressFamily{afi: afiIPv4, safi: safiFlowSpec})
+}
+
+// IPv6FlowSpecRIB returns the FlowSpec RIB for the IPv6 FlowSpec address family
+func (v *VRF) IPv6FlowSpecRIB() *flowspecRIB.FlowSpecRIB {
+	return v.flowSpecRIBForAddressFamily(addressFamily{afi: afiIPv6, safi: safiFlowSpec})
+}
+
+func (v *VRF) flowSpecRIBForAddressFamily(family addressFamily) *flowspecRIB.FlowSpecRIB {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	return v.flowSpecRIBs[family]
+}
+
+// ConnectVPN exchanges paths between the unicast RIBs of this VRF and the VPN RIBs of the VRF vpn (RFC4364 Sect. 4.3).
+// Paths of this VRF are exported using the VRFs route distinguisher, the given label and export route targets.
+// VPN paths carrying any of the import route targets are imported. Existing connections are replaced if changed.
+func (v *VRF) ConnectVPN(vpn *VRF, importRouteTargets []types.ExtendedCommunity, exportRouteTargets []types.ExtendedCommunity, label uint32) {
+	cfg := vpnRIB.ConnectionConfig{
+		RouteDistinguisher: v.routeDistinguisher,
+		Label:              label,
+		ImportRouteTargets: importRouteTargets,
+		ExportRouteTargets: exportRouteTargets,
+	}
+
+	v.mu.Lock()
+	unchanged := v.vpnConnections != nil && v.vpnVRF == vpn && v.vpnConfig.Equal(cfg)
+	v.mu.Unlock()
+
+	if unchanged {
+		return
+	}
+
+	v.DisconnectVPN()
+	if len(importRouteTargets) == 0 && len(exportRouteTargets) == 0 {
+		return
+	}
+
+	conns := make([]*vpnRIB.Connection, 0, 2)
+	if rib, vr := v.IPv4UnicastRIB(), vpn.IPv4VPNRIB(); rib != nil && vr != nil {
+		conns = append(conns, vpnRIB.Connect(rib, vr, cfg))
+	}
+
+	if rib, vr := v.IPv6UnicastRIB(), vpn.IPv6VPNRIB(); rib != nil && vr != nil {
+		conns = append(conns, vpnRIB.Connect(rib, vr, cfg))
+	}
+
+	v.mu.Lock()
+	v.vpnConnections = conns
+	v.vpnVRF = vpn
+	v.vpnConfig = cfg
+	v.mu.Unlock()
+}
+
+// DisconnectVPN stops exchanging paths with VPN RIBs and removes all exchanged paths
+func (v *VRF) DisconnectVPN() {
+	v.mu.Lock()
+	conns := v.vpnConnections
+	v.vpnConnections = nil
+	v.vpnVRF = nil
+	v.mu.Unlock()
+
+	for _, c := range conns {
+		c.Disconnect()
+	}
+}
+
 // Name is the name of the VRF
 func (v *VRF) Name() string {
 	return v.name
@@ -175,6 +343,14 @@ func (v *VRF) Dispose() {
 	for ribName := range v.ribNames {
 		delete(v.ribNames, ribName)
 	}
+
+	for afi := range v.vpnRIBs {
+		delete(v.vpnRIBs, afi)
+	}
+
+	for afi := range v.flowSpecRIBs {
+		delete(v.flowSpecRIBs, afi)
+	}
 }
 
 // RouteDistinguisherHumanReadable converts 64bit route distinguisher to human readable string form
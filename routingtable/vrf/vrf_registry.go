@@ -34,6 +34,8 @@ func (r *VRFRegistry) CreateVRFIfNotExists(name string, rd uint64) *VRF {
 	r.vrfs[name] = NewUntrackedVRF(name, rd)
 	r.vrfs[name].CreateIPv4UnicastLocRIB("inet.0")
 	r.vrfs[name].CreateIPv6UnicastLocRIB("inet6.0")
+	r.vrfs[name].CreateIPv4FlowSpecRIB("inetflow.0")
+	r.vrfs[name].CreateIPv6FlowSpecRIB("inet6flow.0")
 
 	return r.vrfs[name]
 }
@@ -69,6 +71,9 @@ func (r *VRFRegistry) DisposeAll() {
 		for _, rib := range r.vrfs[id].ribs {
 			rib.Dispose()
 		}
+		for _, rib := range r.vrfs[id].vpnRIBs {
+			rib.Dispose()
+		}
 		delete(r.vrfs, id)
 	}
 }
@@ -93,14 +93,16 @@ func TestBGP(t *testing.T) {
 	pkt := peerA.con.ReadFromOtherEnd()
 	if !assert.Equal(t, []byte{
 		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
-		0x00, 0x2b, // Length
+		0x00, 0x2f, // Length
 		0x01,       // Type
 		0x04,       // Version,
 		0x00, 0x64, // ASN
 		0x00, 0x00, // Hold time
 		0x00, 0x00, 0x00, 0x64, // BGP Identifier
-		0x0e, // Opt param length
-		0x02, 0xc, 0x45, 0x4, 0x0, 0x1, 0x1, 0x2, 0x41, 0x4, 0x0, 0x0, 0x0, 0x64,
+		0x12, // Opt param length
+		0x02, 0x10, 0x45, 0x4, 0x0, 0x1, 0x1, 0x2, 0x41, 0x4, 0x0, 0x0, 0x0, 0x64,
+		0x02, 0x00, // Route Refresh
+		0x46, 0x00, // Enhanced Route Refresh
 	}, pkt) {
 		return
 	}
@@ -161,14 +163,16 @@ func TestBGP(t *testing.T) {
 	pkt = peerB.con.ReadFromOtherEnd()
 	if !assert.Equal(t, []byte{
 		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
-		0x00, 0x2b, // Length
+		0x00, 0x2f, // Length
 		0x01,       // Type
 		0x04,       // Version,
 		0x00, 0x64, // ASN
 		0x00, 0x00, // Hold time
 		0x00, 0x00, 0x00, 0x64, // BGP Identifier
-		0x0e, // Opt param length
-		0x02, 0xc, 0x45, 0x4, 0x0, 0x1, 0x1, 0x2, 0x41, 0x4, 0x0, 0x0, 0x0, 0x64,
+		0x12, // Opt param length
+		0x02, 0x10, 0x45, 0x4, 0x0, 0x1, 0x1, 0x2, 0x41, 0x4, 0x0, 0x0, 0x0, 0x64,
+		0x02, 0x00, // Route Refresh
+		0x46, 0x00, // Enhanced Route Refresh
 	}, pkt) {
 		return
 	}
@@ -195,3 +199,140 @@ func TestBGP(t *testing.T) {
 		return
 	}
 }
+
+func TestBGPGracefulRestartReconnect(t *testing.T) {
+	b := server.NewBGPServer(server.BGPServerConfig{
+		RouterID: net.IPv4FromOctets(1, 1, 1, 1).Ptr().ToUint32(),
+	})
+
+	lm := tcp.NewListenerManager(map[string][]string{
+		"main": {
+			"0.0.0.0:179",
+		},
+	}, false)
+
+	lm.SetListenerFactory(tcp.NewMockListenerFactory())
+	b.SetListenerManager(lm)
+	b.Start()
+
+	vrfReg := vrf.NewVRFRegistry()
+	mainVRF := vrfReg.CreateVRFIfNotExists(vrf.DefaultVRFName, 0)
+
+	err := b.AddPeer(server.PeerConfig{
+		AdminEnabled: true,
+		LocalAS:      100,
+		LocalAddress: bnet.IPv4FromOctets(192, 0, 2, 0).Ptr(),
+		PeerAS:       200,
+		PeerAddress:  bnet.IPv4FromOctets(192, 0, 2, 1).Ptr(),
+		Passive:      true,
+		VRF:          mainVRF,
+		RouterID:     100,
+		GracefulRestart: server.GracefulRestartConfig{
+			Enabled:        true,
+			RestartTime:    120 * time.Second,
+			StalePathsTime: 360 * time.Second,
+		},
+		IPv4: &server.AddressFamilyConfig{
+			ImportFilterChain: filter.Chain{filter.NewAcceptAllFilter()},
+			ExportFilterChain: filter.Chain{filter.NewAcceptAllFilter()},
+		},
+	})
+	if err != nil {
+		t.Errorf("unexpected error adding BGP peer: %v", err)
+		return
+	}
+
+	openMsg := func(restarting bool) []byte {
+		return packet.SerializeOpenMsg(&packet.BGPOpen{
+			Version:       4,
+			ASN:           200,
+			HoldTime:      90,
+			BGPIdentifier: bnet.IPv4FromOctets(192, 0, 2, 1).Ptr().ToUint32(),
+			OptParams: []packet.OptParam{
+				{
+					Type: packet.CapabilitiesParamType,
+					Value: packet.Capabilities{
+						{
+							Code: packet.GracefulRestartCapabilityCode,
+							Value: packet.GracefulRestartCapability{
+								RestartState: restarting,
+								RestartTime:  120,
+								AddressFamilies: []packet.GracefulRestartCapabilityTuple{
+									{
+										AFI:             packet.AFIIPv4,
+										SAFI:            packet.SAFIUnicast,
+										ForwardingState: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		})
+	}
+
+	keepaliveMsg := []byte{
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		00, 19, // Length
+		0x04, // Type Keepalive
+	}
+
+	updateMsg := func(nlri ...byte) []byte {
+		msg := []byte{
+			0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+			00, byte(43 + len(nlri)), // Length
+			0x02,       // Update
+			0x00, 0x00, // Withdraw length
+			0x00, 20, // attributes length
+			0x40, 0x01, 0x01, 0x00, // Origin
+			0x40, 0x02, 0x06, 0x02, 0x02, 0x00, 200, 0x00, 250, // AS Path
+			0x40, 0x03, 0x04, 192, 0, 2, 1, // Next Hop
+		}
+
+		return append(msg, nlri...)
+	}
+
+	endOfRIBMsg := []byte{
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		00, 23, // Length
+		0x02,       // Update
+		0x00, 0x00, // Withdraw length
+		0x00, 0x00, // attributes length
+	}
+
+	pfxA := bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 0), 8).Ptr()
+	pfxB := bnet.NewPfx(bnet.IPv4FromOctets(11, 0, 0, 0), 8).Ptr()
+	rib := mainVRF.IPv4UnicastRIB()
+
+	l := lm.GetListeners(mainVRF)[0]
+	con := l.(*tcp.MockListener).Connect(bnet.IPv4FromOctets(192, 0, 2, 1).Ptr().ToNetIP(), 31337)
+	con.WriteFromOtherEnd(openMsg(false))
+	con.ReadFromOtherEnd()
+	con.WriteFromOtherEnd(keepaliveMsg)
+	con.WriteFromOtherEnd(updateMsg(8, 10, 8, 11))
+
+	time.Sleep(time.Second)
+	assert.NotNil(t, rib.Get(pfxA))
+	assert.NotNil(t, rib.Get(pfxB))
+
+	// The peer restarts and reconnects before the session timed out
+	con = l.(*tcp.MockListener).Connect(bnet.IPv4FromOctets(192, 0, 2, 1).Ptr().ToNetIP(), 31338)
+	con.ReadFromOtherEnd()
+	con.WriteFromOtherEnd(openMsg(true))
+	con.WriteFromOtherEnd(keepaliveMsg)
+	con.WriteFromOtherEnd(updateMsg(8, 10))
+
+	// The paths of the previous session are kept as stale until the peer sent its End-of-RIB marker
+	time.Sleep(time.Second)
+	assert.NotNil(t, rib.Get(pfxA))
+	assert.NotNil(t, rib.Get(pfxB))
+	assert.Equal(t, int64(2), rib.RouteCount())
+
+	con.WriteFromOtherEnd(endOfRIBMsg)
+
+	time.Sleep(time.Second)
+	assert.NotNil(t, rib.Get(pfxA))
+	assert.Nil(t, rib.Get(pfxB))
+	assert.Len(t, rib.Get(pfxA).Paths(), 1)
+}
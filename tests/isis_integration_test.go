@@ -10,6 +10,7 @@ import (
 	"github.com/bio-routing/bio-rd/protocols/isis/packet"
 	"github.com/bio-routing/bio-rd/protocols/isis/server"
 	"github.com/bio-routing/bio-rd/protocols/isis/types"
+	"github.com/bio-routing/bio-rd/routingtable/vrf"
 	"github.com/stretchr/testify/assert"
 
 	bbclock "github.com/benbjohnson/clock"
@@ -232,9 +233,9 @@ var (
 		0, 0x60, // Length
 		7, 6, // Remaining Lifetime
 		12, 12, 12, 13, 13, 13, 0, 0, // LSP ID
-		0, 0, 0, 3, // Sequence number
-		0x58, 0x79, // Checksum
-		0, // Type block
+		0, 0, 0, 2, // Sequence number
+		0x5d, 0x72, // Checksum
+		3, // Type block (IS type level 2)
 		// TLVs
 		1, // Area
 		3, // Length
@@ -292,13 +293,15 @@ func TestISISServer(t *testing.T) {
 	}
 
 	du := &device.MockServer{}
+	v := vrf.NewUntrackedVRF(vrf.DefaultVRFName, 0)
+	v.CreateIPv4UnicastLocRIB("inet.0")
 	s, err := server.New([]*types.NET{
 		{
 			AreaID:   types.AreaID{0x49, 0x00},
 			SystemID: types.SystemID{12, 12, 12, 13, 13, 13},
 			SEL:      0x00,
 		},
-	}, du, 3600)
+	}, du, v, 3600)
 
 	if err != nil {
 		t.Errorf("unexpected failure creating IS-IS server: %v", err)
@@ -380,14 +383,14 @@ func TestISISServer(t *testing.T) {
 
 	// let's check if the LSP gets regenerated when it's life time goes down to 5 minutes:
 	// We'll need to move the clock a few times and send a few hellos and then check for a fresh LSP packet with increased sequence number
-	remainingLifetime := s.GetLSDB()[0].GetLSPDU().RemainingLifetime
-	sequenceNumber := s.GetLSDB()[0].GetLSPDU().SequenceNumber
+	remainingLifetime := s.GetLSDB(2)[0].GetLSPDU().RemainingLifetime
+	sequenceNumber := s.GetLSDB(2)[0].GetLSPDU().SequenceNumber
 	for {
 		clock.Add(time.Second * 4)
 		eth0.SendFromRemote(neighborA.mac, helloFromNeighborAUp)
 
 		eth0.DrainBuffer()
-		lspdu := s.GetLSDB()[0].GetLSPDU()
+		lspdu := s.GetLSDB(2)[0].GetLSPDU()
 		if lspdu.RemainingLifetime > remainingLifetime && lspdu.SequenceNumber > sequenceNumber {
 			break
 		}